@@ -0,0 +1,159 @@
+// Package client - Home Garden Management API の Go クライアント
+//
+// API の操作ごとのメソッドと型（api.gen.go）は cmd/genclient で OpenAPI の仕様から生成します。
+// このファイルは生成したメソッドが使用する通信の部分（認証・組織のスコープ・エラーの変換）です。
+//
+//	c := client.New("https://api.example.com", client.WithToken(token))
+//	crops, err := c.GetCrops(ctx, nil)
+//	var apiErr *client.Error
+//	if errors.As(err, &apiErr) && apiErr.Code() == "CROP_NOT_FOUND" { ... }
+//
+// ハンドラを変更した場合は `go run ./cmd/openapi && go run ./cmd/genclient` で再生成します。
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HeaderOrganizationID は組織のスコープを指定するヘッダーです（handler.HeaderOrganizationID と同じ）。
+const HeaderOrganizationID = "X-Org-ID"
+
+// Client は API のクライアントです。
+type Client struct {
+	baseURL        string
+	httpClient     *http.Client
+	token          string
+	organizationID uint
+}
+
+// Option は Client の設定です。
+type Option func(*Client)
+
+// WithHTTPClient はリクエストに使用する http.Client を設定します（デフォルト: http.DefaultClient）。
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken は Authorization: Bearer で送信する JWT を設定します。
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithOrganization は X-Org-ID で送信する組織のIDを設定します（区画・作物を組織のものとして扱う）。
+func WithOrganization(organizationID uint) Option {
+	return func(c *Client) { c.organizationID = organizationID }
+}
+
+// New はクライアントを作成します。
+//
+// 引数:
+//   - baseURL: API のベースURL（https://api.example.com、末尾の / は不要）
+//   - opts: 認証・組織のスコープなどの設定
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimRight(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken は以降のリクエストで送信する JWT を変更します（ログイン後など、空の場合は送信しない）。
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// Error は API のエラーのレスポンス（2xx 以外）です。
+type Error struct {
+	StatusCode int
+	Problem    *Problem // application/problem+json の場合のみ
+	Body       []byte
+}
+
+// Error はエラーの内容を返します。
+func (e *Error) Error() string {
+	if e.Problem != nil {
+		return fmt.Sprintf("api error %d %s: %s", e.StatusCode, e.Problem.Code, e.Problem.Detail)
+	}
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+// Code はエラーコード（CROP_NOT_FOUND など）を返します（problem+json でない場合は空）。
+func (e *Error) Code() string {
+	if e.Problem == nil {
+		return ""
+	}
+	return e.Problem.Code
+}
+
+// do はリクエストを送信し、レスポンスの JSON を out に読み込みます。
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	data, err := c.doRaw(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// doRaw はリクエストを送信し、レスポンスの本文を返します（2xx 以外は *Error）。
+func (c *Client) doRaw(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.organizationID != 0 {
+		req.Header.Set(HeaderOrganizationID, strconv.FormatUint(uint64(c.organizationID), 10))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &Error{StatusCode: resp.StatusCode, Body: data}
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+			var problem Problem
+			if json.Unmarshal(data, &problem) == nil {
+				apiErr.Problem = &problem
+			}
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/handler"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Client Tests - 生成したクライアントとサーバーの結合テスト
+// =============================================================================
+// テスト対象:
+//   - 生成したメソッドのリクエスト（パス・クエリ・ボディ・認証）とレスポンスの型がハンドラと一致すること
+//   - エラーのレスポンス（problem+json）の *Error への変換
+//   - WithOrganization による X-Org-ID の送信
+
+// newTestServer はモックのリポジトリで全ルートを登録したサーバーを起動します。
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+	svc := service.NewService(repository.NewMockRepositories())
+	handler.NewHandler(svc, auth.NewJWTManager("client-test-secret-key-32-chars!", 24), nil).RegisterRoutes(e)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestClient_CropLifecycle はクライアントでの登録・作物の作成・取得のテストです。
+// 期待動作:
+//   - Register のトークンを SetToken で設定すると、以降のリクエストが認証される
+//   - CreateCrop のレスポンスが CropResponse に読み込まれ、GetCrops・GetCrop で取得できる
+//   - 存在しない作物は *Error（404、CROP_NOT_FOUND）
+func TestClient_CropLifecycle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := newTestServer(t)
+	c := New(server.URL + "/")
+
+	registered, err := c.Register(ctx, &RegisterRequest{Email: "client@example.com", Password: "password123", DisplayName: "Client"})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	c.SetToken(registered.Token)
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	created, createErr := c.CreateCrop(ctx, &CreateCropRequest{
+		Name:                "トマト",
+		PlantedDate:         planted,
+		ExpectedHarvestDate: planted.AddDate(0, 3, 0),
+	})
+	crops, listErr := c.GetCrops(ctx, &GetCropsParams{Status: "planted"})
+	_, notFoundErr := c.GetCrop(ctx, "9999")
+
+	// Assert
+	if createErr != nil || listErr != nil {
+		t.Fatalf("Unexpected errors: %v, %v", createErr, listErr)
+	}
+	if registered.User.Email != "client@example.com" || created.Name != "トマト" || created.UserID != registered.User.ID {
+		t.Errorf("Unexpected responses: %+v, %+v", registered.User, created)
+	}
+	if len(crops) != 1 || crops[0].ID != created.ID || !crops[0].PlantedDate.Equal(planted) {
+		t.Errorf("Expected the created crop, got %+v", crops)
+	}
+	got, err := c.GetCrop(ctx, fmt.Sprint(created.ID))
+	if err != nil || got.Status != "planted" {
+		t.Errorf("GetCrop = %+v, %v", got, err)
+	}
+	var apiErr *Error
+	if !errors.As(notFoundErr, &apiErr) || apiErr.StatusCode != 404 || apiErr.Code() != apperrors.ErrCodeCropNotFound {
+		t.Errorf("Expected 404 CROP_NOT_FOUND, got %v", notFoundErr)
+	}
+}
+
+// TestClient_Organization は WithOrganization のテストです。
+// 期待動作:
+//   - WithOrganization を指定したクライアントで作成した区画は組織の区画で、組織のスコープの一覧にのみ含まれる
+//   - 存在しない組織を指定した場合は *Error（404）
+func TestClient_Organization(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server := newTestServer(t)
+	personal := New(server.URL)
+	registered, err := personal.Register(ctx, &RegisterRequest{Email: "org-client@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	personal.SetToken(registered.Token)
+	org, err := personal.CreateOrganization(ctx, &CreateOrganizationRequest{Name: "学校菜園", Type: "school"})
+	if err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	scoped := New(server.URL, WithToken(registered.Token), WithOrganization(uint(org.ID)))
+	other := New(server.URL, WithToken(registered.Token), WithOrganization(uint(org.ID)+1))
+
+	// Act
+	plot, createErr := scoped.CreatePlot(ctx, &CreatePlotRequest{Name: "A-1", Width: 1, Height: 1})
+	orgPlots, _ := scoped.GetPlots(ctx, nil)
+	personalPlots, _ := personal.GetPlots(ctx, nil)
+	_, otherErr := other.GetPlots(ctx, nil)
+
+	// Assert
+	if createErr != nil || plot.OrganizationID == nil || *plot.OrganizationID != org.ID {
+		t.Fatalf("Expected organization plot, got %+v, %v", plot, createErr)
+	}
+	if len(orgPlots) != 1 || len(personalPlots) != 0 {
+		t.Errorf("Expected 1 organization plot and no personal plots, got %d, %d", len(orgPlots), len(personalPlots))
+	}
+	var apiErr *Error
+	if !errors.As(otherErr, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("Expected unknown organization to be rejected, got %v", otherErr)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Backup - データベースのバックアップとリストア
+// =============================================================================
+// バックアップは S3 に保存してバックアップの履歴に記録するか（POST /api/v1/admin/database/backups と同じ）、--out のファイルに書き出します。
+// リストアは稼働中のサーバーを停止してから、マイグレーションを適用したデータベースに対して実行します:
+//
+//	go run ./cmd/admin migrate
+//	go run ./cmd/admin backup restore --id 12 --yes   # S3 に保存したバックアップ（backup list の ID）
+//	go run ./cmd/admin backup restore --file database.jsonl.gz --yes
+
+// newBackupCommand はデータベースのバックアップのコマンドを作成します。
+func newBackupCommand(run runFunc) *cobra.Command {
+	backup := &cobra.Command{
+		Use:   "backup",
+		Short: "データベースの論理バックアップを作成・リストアする",
+	}
+	backup.AddCommand(newBackupCreateCommand(run), newBackupListCommand(run), newBackupRestoreCommand(run))
+	return backup
+}
+
+// newBackupCreateCommand はバックアップの作成のコマンドを作成します。
+func newBackupCreateCommand(run runFunc) *cobra.Command {
+	var out string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "バックアップを作成して S3 に保存する（--out の場合はファイルに書き出す）",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			if out == "" {
+				backup, err := app.svc.RunDatabaseBackup(cmd.Context(), service.BackupTriggerCLI)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Created backup %d (%d tables, %d rows, %d bytes): %s\n",
+					backup.ID, backup.TableCount, backup.RowCount, backup.FileSizeBytes, backup.S3Key)
+				return nil
+			}
+
+			if app.db == nil {
+				return errors.New("database is not connected")
+			}
+			file, err := os.OpenFile(out, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			stats, err := app.db.Backup(cmd.Context(), file)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(out)
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backed up %d tables, %d rows (migration version %d) to %s\n", stats.Tables, stats.Rows, stats.MigrationVersion, out)
+			return nil
+		}),
+	}
+	cmd.Flags().StringVarP(&out, "out", "o", "", "書き出すファイル（既に存在する場合はエラー、空の場合は S3 に保存）")
+	return cmd
+}
+
+// newBackupListCommand はバックアップの履歴のコマンドを作成します。
+func newBackupListCommand(run runFunc) *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "バックアップの履歴を新しい順に表示する",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			page, err := app.svc.GetDatabaseBackupsPage(cmd.Context(), pagination.Params{Limit: limit}.Normalize())
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			for _, backup := range page.Items {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d rows\t%d bytes\t%s\n", backup.ID, backup.CreatedAt.Format(time.RFC3339),
+					backup.Trigger, backup.Status, backup.RowCount, backup.FileSizeBytes, backup.S3Key+backup.ErrorMessage)
+			}
+			return w.Flush()
+		}),
+	}
+	cmd.Flags().IntVar(&limit, "limit", pagination.DefaultLimit, "表示する件数")
+	return cmd
+}
+
+// newBackupRestoreCommand はバックアップのリストアのコマンドを作成します。
+func newBackupRestoreCommand(run runFunc) *cobra.Command {
+	var id uint
+	var file string
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "バックアップで全てのテーブルの行を置き換える（バックアップの履歴は残す）",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			if (id == 0) == (file == "") {
+				return errors.New("specify either --id or --file")
+			}
+			if !yes {
+				return errors.New("restore replaces all rows in the database; re-run with --yes to confirm")
+			}
+			if app.db == nil {
+				return errors.New("database is not connected")
+			}
+
+			var body io.ReadCloser
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				body = f
+			} else {
+				downloaded, _, err := app.svc.OpenDatabaseBackup(cmd.Context(), id)
+				if err != nil {
+					return fmt.Errorf("backup %d: %w", id, err)
+				}
+				body = downloaded
+			}
+			defer body.Close()
+
+			stats, err := app.db.Restore(cmd.Context(), body)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %d tables, %d rows (migration version %d)\n", stats.Tables, stats.Rows, stats.MigrationVersion)
+
+			// 分析のマテリアライズドビューをリストアした行で作り直す
+			return app.db.RefreshMaterializedViews()
+		}),
+	}
+	cmd.Flags().UintVar(&id, "id", 0, "S3 に保存したバックアップの履歴のID（backup list）")
+	cmd.Flags().StringVar(&file, "file", "", "backup create --out で書き出したファイル")
+	cmd.Flags().BoolVar(&yes, "yes", false, "既存の行を削除してリストアすることを確認する")
+	return cmd
+}
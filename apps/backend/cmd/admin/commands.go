@@ -0,0 +1,264 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/scheduler"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Commands - 管理CLIのコマンド
+// =============================================================================
+
+// minPasswordLength はパスワードの最小文字数です（POST /api/v1/auth/register と同じ）。
+const minPasswordLength = 8
+
+// newRootCommand は管理CLIのルートコマンドを作成します。
+//
+// 引数:
+//   - open: サービスを初期化する関数（各コマンドの実行時に1回呼び出す）
+//
+// 戻り値:
+//   - *cobra.Command: サブコマンドを登録したルートコマンド
+func newRootCommand(open func() (*adminApp, error)) *cobra.Command {
+	root := &cobra.Command{
+		Use:          "admin",
+		Short:        "家庭菜園管理の運用向け管理CLI",
+		SilenceUsage: true,
+	}
+
+	// run はサービスを初期化してコマンドの処理を実行し、終了後に接続を閉じます。
+	run := func(fn func(cmd *cobra.Command, args []string, app *adminApp) error) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			app, err := open()
+			if err != nil {
+				return err
+			}
+			defer app.Close()
+			return fn(cmd, args, app)
+		}
+	}
+
+	root.AddCommand(
+		newMigrateCommand(run),
+		newUserCommand(run),
+		newViewsCommand(run),
+		newSchedulerCommand(run),
+		newExportCommand(run),
+		newSeedCommand(run),
+		newBackupCommand(run),
+	)
+	return root
+}
+
+// runFunc はサービスを初期化してコマンドの処理を実行する RunE を作成します。
+type runFunc func(fn func(cmd *cobra.Command, args []string, app *adminApp) error) func(*cobra.Command, []string) error
+
+// newMigrateCommand はデータベースのセットアップのコマンドを作成します。
+func newMigrateCommand(run runFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "マイグレーション・インデックス・制約・マテリアライズドビューを作成する",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			if app.db == nil {
+				return errors.New("database is not connected")
+			}
+			if err := app.db.Setup(); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Database setup completed")
+			return nil
+		}),
+	}
+}
+
+// newUserCommand はユーザー管理のコマンドを作成します。
+func newUserCommand(run runFunc) *cobra.Command {
+	user := &cobra.Command{
+		Use:   "user",
+		Short: "ユーザーを管理する",
+	}
+
+	var email, password, displayName string
+	var admin bool
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "メールアドレス・パスワードでログインするユーザーを作成する",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			if _, err := mail.ParseAddress(email); err != nil {
+				return fmt.Errorf("invalid email %q", email)
+			}
+			if len(password) < minPasswordLength {
+				return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+			}
+			hashed, err := auth.HashPassword(password)
+			if err != nil {
+				return err
+			}
+			created, err := app.svc.RegisterUser(cmd.Context(), email, hashed, displayName)
+			if err != nil {
+				return err
+			}
+			if admin {
+				if err := app.svc.SetUserAdmin(cmd.Context(), created.ID, true); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created user %d (%s, admin: %v)\n", created.ID, created.Email, admin)
+			return nil
+		}),
+	}
+	create.Flags().StringVar(&email, "email", "", "メールアドレス（必須）")
+	create.Flags().StringVar(&password, "password", "", fmt.Sprintf("パスワード（必須、%d文字以上）", minPasswordLength))
+	create.Flags().StringVar(&displayName, "name", "", "表示名")
+	create.Flags().BoolVar(&admin, "admin", false, "管理者にする（利用統計エンドポイントへのアクセス権）")
+	_ = create.MarkFlagRequired("email")
+	_ = create.MarkFlagRequired("password")
+
+	user.AddCommand(create)
+	return user
+}
+
+// newViewsCommand はマテリアライズドビューのコマンドを作成します。
+func newViewsCommand(run runFunc) *cobra.Command {
+	views := &cobra.Command{
+		Use:   "views",
+		Short: "分析用のマテリアライズドビューを管理する",
+	}
+	views.AddCommand(&cobra.Command{
+		Use:   "refresh",
+		Short: "マテリアライズドビューをリフレッシュする（POST /api/v1/scheduler/analytics/refresh と同じ）",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			result, err := app.svc.RefreshMaterializedViews(cmd.Context())
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			for _, view := range result.Views {
+				status := "ok"
+				if !view.Success {
+					status = "failed: " + view.Error
+				}
+				fmt.Fprintf(w, "%s\t%dms\t%s\n", view.ViewName, view.DurationMs, status)
+			}
+			_ = w.Flush()
+			if result.Failed > 0 {
+				return fmt.Errorf("%d materialized view(s) failed to refresh", result.Failed)
+			}
+			return nil
+		}),
+	})
+	return views
+}
+
+// newSchedulerCommand は定期タスクのコマンドを作成します。
+func newSchedulerCommand(run runFunc) *cobra.Command {
+	sched := &cobra.Command{
+		Use:   "scheduler",
+		Short: "定期タスク（内蔵スケジューラーのジョブ）を手動で実行する",
+	}
+	sched.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "実行できるジョブの一覧を表示する",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			s, err := manualScheduler(app)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			for _, job := range s.Jobs() {
+				fmt.Fprintf(w, "%s\t%s\n", job.Name, job.Schedule)
+			}
+			return w.Flush()
+		}),
+	}, &cobra.Command{
+		Use:   "run <job>",
+		Short: "ジョブをすぐに実行する（実行履歴に記録）",
+		Args:  cobra.ExactArgs(1),
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			s, err := manualScheduler(app)
+			if err != nil {
+				return err
+			}
+			started := time.Now()
+			if err := s.RunNow(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Job %s completed in %s\n", args[0], time.Since(started).Round(time.Millisecond))
+			return nil
+		}),
+	})
+	return sched
+}
+
+// manualScheduler は手動実行用に全てのジョブを登録したスケジューラーを作成します（Start はしない）。
+// 設定で無効にしたジョブも実行できるよう、ジョブごとの有効・無効は無視します。
+// 通知の送信手段が未設定の場合、通知を送信するジョブは登録されません。
+func manualScheduler(app *adminApp) (*scheduler.Scheduler, error) {
+	cfg := app.cfg.Scheduler
+	jobs := make(map[string]config.SchedulerJobConfig, len(config.SchedulerJobNames))
+	for _, def := range config.SchedulerJobNames {
+		jobCfg, ok := cfg.Jobs[def.Name]
+		if !ok || jobCfg.Schedule == "" {
+			jobCfg.Schedule = def.Schedule
+		}
+		jobCfg.Enabled = true
+		jobs[def.Name] = jobCfg
+	}
+	cfg.Jobs = jobs
+	return scheduler.NewFromConfig(cfg, app.svc, app.eventHandler)
+}
+
+// newExportCommand はCSVエクスポートのコマンドを作成します。
+func newExportCommand(run runFunc) *cobra.Command {
+	var userID uint
+	var dataType, out string
+	var anonymize bool
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "ユーザーのデータをCSVでエクスポートする（GET /api/v1/analytics/export/:dataType と同じ）",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			if _, err := app.svc.GetUserByID(cmd.Context(), userID); err != nil {
+				return fmt.Errorf("user %d not found: %w", userID, err)
+			}
+			result, err := app.svc.ExportCSVWithOptions(cmd.Context(), userID, service.ExportDataType(dataType), service.ExportOptions{Anonymize: anonymize})
+			if err != nil {
+				return err
+			}
+			if out == "-" {
+				_, err := cmd.OutOrStdout().Write(result.Data)
+				return err
+			}
+			path := out
+			if path == "" {
+				path = filepath.Base(result.FileName)
+			}
+			if err := os.WriteFile(path, result.Data, 0o600); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Exported %d %s record(s) to %s\n", result.RecordCount, result.DataType, path)
+			return nil
+		}),
+	}
+	cmd.Flags().UintVar(&userID, "user-id", 0, "エクスポートするユーザーのID（必須）")
+	cmd.Flags().StringVar(&dataType, "type", string(service.ExportDataTypeAll), "データの種類（crops, harvests, tasks, growth_records, plot_assignments, all）")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "保存先（空の場合はカレントディレクトリにエクスポートのファイル名で保存、- の場合は標準出力）")
+	cmd.Flags().BoolVar(&anonymize, "anonymize", false, "個人を特定できる情報を除去する")
+	_ = cmd.MarkFlagRequired("user-id")
+	return cmd
+}
@@ -0,0 +1,97 @@
+// Command admin は運用向けの管理CLIです。HTTP API を経由せず、サービス層を直接呼び出します。
+//
+// 使い方（apps/backend で実行、設定はサーバーと同じ環境変数）:
+//
+//	go run ./cmd/admin migrate                                   # テーブルの作成・バージョン管理のマイグレーションの適用
+//	go run ./cmd/admin user create --email a@example.com --password ********
+//	go run ./cmd/admin views refresh                             # マテリアライズドビューのリフレッシュ
+//	go run ./cmd/admin scheduler list                            # 定期タスクの一覧
+//	go run ./cmd/admin scheduler run analytics_refresh           # 定期タスクの手動実行
+//	go run ./cmd/admin export --user-id 1 --type all             # CSV エクスポート
+//	go run ./cmd/admin seed                                      # デモデータの投入
+//	go run ./cmd/admin backup create                             # データベースのバックアップ（S3 に保存、--out でファイル）
+//	go run ./cmd/admin backup restore --id 12 --yes              # バックアップのリストア（--file でファイル）
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/storage"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand(openApp).ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+// adminApp はコマンドが使用するサービスと接続です。
+type adminApp struct {
+	cfg          *config.Config
+	db           *database.DB // nil の場合は migrate を実行できない（テスト用）
+	svc          *service.Service
+	eventHandler service.NotificationEventHandler // nil の場合は通知を送信する定期タスクを実行できない
+}
+
+// Close はデータベースの接続を閉じます。
+func (a *adminApp) Close() {
+	if a.db != nil {
+		_ = a.db.Close()
+	}
+}
+
+// openApp は設定を読み込み、サーバーと同じ構成でデータベース・サービスを初期化します。
+// 各コマンドの実行時に呼び出すため、--help ではデータベースに接続しません。
+func openApp() (*adminApp, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	db, err := database.Connect(cfg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repos := repository.NewRepositoryManager(db.DB)
+	svc := service.NewService(repos)
+	svc.SetPushRateLimit(service.PushRateLimit{
+		PerHour: cfg.Notification.PushLimitPerHour,
+		PerDay:  cfg.Notification.PushLimitPerDay,
+	})
+	svc.SetReminderWorkers(cfg.Notification.ReminderWorkers)
+	svc.SetSchedulerPageSize(cfg.Notification.SchedulerPageSize)
+	svc.SetRetentionPolicy(service.RetentionPolicy{
+		Days:   cfg.Retention.Days,
+		DryRun: cfg.Retention.DryRun,
+	})
+
+	// データベースのバックアップの保存先（保存先が未設定の場合は backup create --out のみ）
+	blobs, err := storage.NewBlobStorage(context.Background(), cfg.Storage, cfg.S3)
+	if err != nil {
+		log.Printf("Warning: File storage initialization failed: %v", err)
+	} else {
+		svc.SetDatabaseBackup(db, storage.NewService(blobs))
+	}
+
+	app := &adminApp{cfg: cfg, db: db, svc: svc}
+	notificationSender, err := service.NewNotificationSender(&cfg.Notification, repos.DeviceToken())
+	if err != nil {
+		log.Printf("Warning: Notification sender initialization failed: %v", err)
+	} else {
+		svc.SetNotificationSender(notificationSender)
+		app.eventHandler = service.NewNotificationEventHandler(svc, notificationSender, repos)
+	}
+	return app, nil
+}
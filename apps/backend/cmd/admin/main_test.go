@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/scheduler"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Admin CLI Tests - 管理CLIのテスト
+// =============================================================================
+// テスト対象:
+//   - user create: ユーザーの作成と管理者権限の設定
+//   - seed: デモデータの投入
+//   - export: CSV エクスポートのファイルへの保存
+//   - scheduler run / migrate: 定期タスクの手動実行、データベースがない場合のエラー
+//   - backup create / restore: 保存先・データベースの未設定、リストアの確認と指定のエラー
+
+// newTestApp はモックリポジトリのサービスを使用する adminApp を作成します。
+func newTestApp() (*adminApp, *service.Service) {
+	svc := service.NewService(repository.NewMockRepositories())
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{Timezone: "UTC"}}
+	return &adminApp{cfg: cfg, svc: svc}, svc
+}
+
+// execute はコマンドを実行し、出力を返します。
+func execute(app *adminApp, args ...string) (string, error) {
+	root := newRootCommand(func() (*adminApp, error) { return app, nil })
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(args)
+	err := root.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+// TestUserCreate はユーザーの作成のテストです。
+// 期待動作:
+//   - --admin を指定したユーザーは管理者になり、パスワードはハッシュ化して保存する
+//   - 短いパスワード・不正なメールアドレス・登録済みのメールアドレスはエラー
+func TestUserCreate(t *testing.T) {
+	// Arrange
+	app, svc := newTestApp()
+	ctx := context.Background()
+
+	// Act
+	out, err := execute(app, "user", "create", "--email", "ops@example.com", "--password", "password123", "--name", "運用", "--admin")
+	_, shortErr := execute(app, "user", "create", "--email", "short@example.com", "--password", "short")
+	_, emailErr := execute(app, "user", "create", "--email", "not-an-email", "--password", "password123")
+	_, duplicateErr := execute(app, "user", "create", "--email", "ops@example.com", "--password", "password123")
+
+	// Assert
+	if err != nil || !strings.Contains(out, "Created user") {
+		t.Fatalf("user create failed: %v (%s)", err, out)
+	}
+	user, err := svc.GetUserByEmail(ctx, "ops@example.com")
+	if err != nil || !user.IsAdmin || user.DisplayName != "運用" || user.PasswordHash == "password123" {
+		t.Errorf("Unexpected created user: %+v %v", user, err)
+	}
+	if shortErr == nil || emailErr == nil {
+		t.Errorf("Expected validation errors, got %v %v", shortErr, emailErr)
+	}
+	if !errors.Is(duplicateErr, service.ErrEmailAlreadyExists) {
+		t.Errorf("Expected ErrEmailAlreadyExists, got %v", duplicateErr)
+	}
+}
+
+// TestSeedAndExport はデモデータの投入とエクスポートのテストです。
+// 期待動作:
+//   - seed はデモユーザーと区画・作物・収穫記録・タスクを作成する
+//   - export は投入した作物を CSV ファイルに保存する
+//   - 同じメールアドレスで再度 seed するとエラー
+func TestSeedAndExport(t *testing.T) {
+	// Arrange
+	app, svc := newTestApp()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "crops.csv")
+
+	// Act
+	seedOut, seedErr := execute(app, "seed")
+	user, userErr := svc.GetUserByEmail(ctx, defaultDemoEmail)
+	if userErr != nil {
+		t.Fatalf("Demo user not created: %v (%s, %v)", userErr, seedOut, seedErr)
+	}
+	exportOut, exportErr := execute(app, "export", "--user-id", fmt.Sprint(user.ID), "--type", "crops", "--out", path)
+	_, reseedErr := execute(app, "seed")
+
+	// Assert
+	if seedErr != nil || !strings.Contains(seedOut, "3 crops") {
+		t.Errorf("seed failed: %v (%s)", seedErr, seedOut)
+	}
+	crops, _ := svc.GetUserCrops(ctx, user.ID)
+	tasks, _ := svc.GetUserTasks(ctx, user.ID)
+	plots, _ := svc.GetUserPlots(ctx, user.ID)
+	if len(crops) != 3 || len(tasks) != 5 || len(plots) != 2 {
+		t.Errorf("Expected 3 crops, 5 tasks and 2 plots, got %d %d %d", len(crops), len(tasks), len(plots))
+	}
+	data, err := os.ReadFile(path)
+	if exportErr != nil || err != nil || !strings.Contains(string(data), "トマト") || !strings.Contains(exportOut, "3 crops") {
+		t.Errorf("Unexpected export: %v %v (%s)", exportErr, err, exportOut)
+	}
+	if reseedErr == nil {
+		t.Error("Expected error when demo user already exists")
+	}
+}
+
+// TestSchedulerRun は定期タスクの手動実行のテストです。
+// 期待動作:
+//   - 登録されたジョブを実行して完了を表示する
+//   - 登録されていないジョブは ErrJobNotFound
+//   - データベースに接続していない場合、migrate はエラー
+func TestSchedulerRun(t *testing.T) {
+	// Arrange
+	app, _ := newTestApp()
+	app.cfg.Scheduler.Jobs = map[string]config.SchedulerJobConfig{
+		config.SchedulerJobTokenBlacklistCleanup: {Enabled: false, Schedule: "@every 1h"},
+	}
+
+	// Act
+	out, err := execute(app, "scheduler", "run", config.SchedulerJobTokenBlacklistCleanup)
+	_, missingErr := execute(app, "scheduler", "run", config.SchedulerJobNotifications)
+	_, migrateErr := execute(app, "migrate")
+
+	// Assert
+	if err != nil || !strings.Contains(out, "completed") {
+		t.Errorf("Expected disabled job to run, got %v (%s)", err, out)
+	}
+	if !errors.Is(missingErr, scheduler.ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound without notification sender, got %v", missingErr)
+	}
+	if migrateErr == nil {
+		t.Error("Expected migrate to fail without database")
+	}
+}
+
+// TestBackup はデータベースのバックアップのコマンドのテストです。
+// 期待動作:
+//   - 保存先が未設定の場合、create は ErrBackupNotConfigured
+//   - restore は --id と --file のどちらか一方が必要で、--yes がない場合は実行しない
+//   - データベースに接続していない場合、create --out と restore はエラー
+func TestBackup(t *testing.T) {
+	// Arrange
+	app, _ := newTestApp()
+	out := filepath.Join(t.TempDir(), "database.jsonl.gz")
+
+	// Act
+	_, createErr := execute(app, "backup", "create")
+	_, createOutErr := execute(app, "backup", "create", "--out", out)
+	_, noSourceErr := execute(app, "backup", "restore", "--yes")
+	_, bothErr := execute(app, "backup", "restore", "--id", "1", "--file", out, "--yes")
+	_, unconfirmedErr := execute(app, "backup", "restore", "--file", out)
+	_, restoreErr := execute(app, "backup", "restore", "--file", out, "--yes")
+
+	// Assert
+	if !errors.Is(createErr, service.ErrBackupNotConfigured) {
+		t.Errorf("Expected ErrBackupNotConfigured, got %v", createErr)
+	}
+	if createOutErr == nil || restoreErr == nil {
+		t.Errorf("Expected create --out and restore to fail without database, got %v / %v", createOutErr, restoreErr)
+	}
+	for _, err := range []error{noSourceErr, bothErr} {
+		if err == nil || !strings.Contains(err.Error(), "--id or --file") {
+			t.Errorf("Expected either --id or --file error, got %v", err)
+		}
+	}
+	if unconfirmedErr == nil || !strings.Contains(unconfirmedErr.Error(), "--yes") {
+		t.Errorf("Expected --yes confirmation error, got %v", unconfirmedErr)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("Expected no backup file, got %v", err)
+	}
+}
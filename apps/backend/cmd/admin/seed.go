@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/seed"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Seed - デモデータの投入
+// =============================================================================
+// フィクスチャセットの投入は internal/seed で共通です（demo 以外のセットは cmd/seed で投入します）。
+
+const (
+	// defaultDemoEmail はデモユーザーのメールアドレスの初期値です。
+	defaultDemoEmail = "demo@example.com"
+	// defaultDemoPassword はデモユーザーのパスワードの初期値です（本番環境では --password で変更してください）。
+	defaultDemoPassword = "demo-password"
+)
+
+// newSeedCommand はデモデータの投入のコマンドを作成します。
+func newSeedCommand(run runFunc) *cobra.Command {
+	var email, password string
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "デモユーザーと区画・作物・成長記録・収穫記録・タスクのデモデータを投入する",
+		Args:  cobra.NoArgs,
+		RunE: run(func(cmd *cobra.Command, args []string, app *adminApp) error {
+			demo, err := seed.Lookup("demo")
+			if err != nil {
+				return err
+			}
+			user, err := seed.CreateUser(cmd.Context(), app.svc, email, password, "デモユーザー")
+			if err != nil {
+				return err
+			}
+			summary, err := demo.Load(cmd.Context(), app.svc, seed.Options{UserID: user.ID})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Seeded demo user %d (%s): %s\n", user.ID, user.Email, summary)
+			return nil
+		}),
+	}
+	cmd.Flags().StringVar(&email, "email", defaultDemoEmail, "デモユーザーのメールアドレス（既に存在する場合はエラー）")
+	cmd.Flags().StringVar(&password, "password", defaultDemoPassword, "デモユーザーのパスワード")
+	return cmd
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// =============================================================================
+// API - 生成するクライアントの操作と型
+// =============================================================================
+
+// api は仕様から読み取ったクライアントの操作と型です（Go・TypeScript で共通）。
+type api struct {
+	title      string
+	schemas    []namedSchema // 名前の順
+	operations []operation   // メソッド名の順
+}
+
+// namedSchema は components.schemas の定義です。
+type namedSchema struct {
+	name   string
+	schema openapi.Schema
+}
+
+// operation はクライアントのメソッドになる操作です。
+type operation struct {
+	name        string // メソッド名（operationId の PascalCase）
+	summary     string
+	method      string // GET / POST / ...
+	path        string // /api/v1/crops/{id}
+	pathParams  []string
+	queryParams []openapi.Parameter
+	body        *openapi.Schema // リクエストボディ（型なしの場合は型・項目が空の Schema、ボディがない場合は nil）
+	response    *openapi.Schema // 成功時のレスポンス（型なしの場合は nil）
+	noContent   bool            // 成功時のレスポンスが 204 No Content
+}
+
+// pathParamPattern は仕様のパスパラメータ（{id}）です。
+var pathParamPattern = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// newAPI は仕様からクライアントの操作と型を読み取ります。
+func newAPI(doc *openapi.Document) *api {
+	a := &api{title: doc.Info.Title}
+	for name, schema := range doc.Components.Schemas {
+		a.schemas = append(a.schemas, namedSchema{name: name, schema: schema})
+	}
+	sort.Slice(a.schemas, func(i, j int) bool { return a.schemas[i].name < a.schemas[j].name })
+
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			if op.Exclude {
+				continue
+			}
+			a.operations = append(a.operations, newClientOperation(strings.ToUpper(method), path, op))
+		}
+	}
+	sort.Slice(a.operations, func(i, j int) bool { return a.operations[i].name < a.operations[j].name })
+	return a
+}
+
+// newClientOperation は仕様の操作からクライアントのメソッドの内容を作成します。
+func newClientOperation(method, path string, op openapi.Operation) operation {
+	o := operation{
+		name:    pascalCase(op.OperationID),
+		summary: op.Summary,
+		method:  method,
+		path:    path,
+	}
+	queryNames := make(map[string]bool)
+	for _, param := range op.Parameters {
+		switch {
+		case param.In == "path":
+			o.pathParams = append(o.pathParams, param.Name)
+		case param.In == "query" && !queryNames[pascalCase(param.Name)]:
+			queryNames[pascalCase(param.Name)] = true // 同じ項目名になるパラメータは最初のもののみ
+			o.queryParams = append(o.queryParams, param)
+		}
+	}
+	if op.RequestBody != nil {
+		body := op.RequestBody.Content["application/json"].Schema
+		if body.Ref == "" {
+			body = openapi.Schema{}
+		}
+		o.body = &body
+	}
+
+	// 成功時のレスポンス（2xx のうち最小のステータスコード）
+	status := ""
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") && (status == "" || code < status) {
+			status = code
+		}
+	}
+	if media, ok := op.Responses[status].Content["application/json"]; ok {
+		o.response = &media.Schema
+	}
+	o.noContent = status == "204"
+	return o
+}
+
+// pathSegments はパスを固定の部分とパスパラメータに分けます（/crops/{id}/harvests → /crops/, id, /harvests）。
+// 戻り値の奇数番目がパスパラメータの名前です。
+func pathSegments(path string) []string {
+	var segments []string
+	last := 0
+	for _, match := range pathParamPattern.FindAllStringSubmatchIndex(path, -1) {
+		segments = append(segments, path[last:match[0]], path[match[2]:match[3]])
+		last = match[1]
+	}
+	if last < len(path) {
+		segments = append(segments, path[last:])
+	}
+	return segments
+}
+
+// initialisms は Go の名前で大文字にする略語です。
+var initialisms = map[string]string{
+	"api": "API", "csv": "CSV", "http": "HTTP", "id": "ID", "ids": "IDs", "json": "JSON",
+	"svg": "SVG", "uid": "UID", "url": "URL", "uri": "URI", "sms": "SMS", "ws": "WS",
+}
+
+// pascalCase は operationId・JSON の項目名を PascalCase にします（plot_id → PlotID、get_GraphQL_api_v1 → GetGraphQLAPIV1）。
+func pascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok && strings.ToLower(word) == word {
+			b.WriteString(initialism)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// camelCase は PascalCase の先頭の単語を小文字にします（PlotID → plotID、ID → id）。
+func camelCase(name string) string {
+	name = pascalCase(name)
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		upper-- // 略語の後の単語の先頭（APIKey → apiKey）
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+// schemaName は参照（#/components/schemas/CropResponse）の定義名を返します。
+func schemaName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// methodConstant は HTTP メソッドの net/http の定数名を返します。
+func methodConstant(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	default:
+		return "http.MethodDelete"
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// =============================================================================
+// Go Client - Go のクライアントの生成
+// =============================================================================
+
+// goHeader は生成する Go のファイルの先頭です（Client・do・doRaw は client/client.go）。
+const goHeader = `// Code generated by cmd/genclient from internal/openapi/openapi.json; DO NOT EDIT.
+
+package client
+`
+
+// generateGo は Go のクライアント（型・パラメータ・Client のメソッド）を生成します。
+func generateGo(a *api) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("\n// =============================================================================\n")
+	b.WriteString("// Types - components.schemas\n")
+	b.WriteString("// =============================================================================\n")
+	for _, s := range a.schemas {
+		fmt.Fprintf(&b, "\n// %s は %s の型です（components.schemas）。\n", s.name, a.title)
+		if s.schema.Description != "" {
+			fmt.Fprintf(&b, "// %s\n", s.schema.Description)
+		}
+		fmt.Fprintf(&b, "type %s %s\n", s.name, goType(s.schema))
+	}
+
+	b.WriteString("\n// =============================================================================\n")
+	b.WriteString("// Operations - paths\n")
+	b.WriteString("// =============================================================================\n")
+	for _, op := range a.operations {
+		writeGoParams(&b, op)
+		writeGoMethod(&b, op)
+	}
+
+	// 使用するパッケージのみ import する
+	imports := []string{"context", "net/http"}
+	for _, pkg := range []string{"net/url", "time"} {
+		if strings.Contains(b.String(), pkg[strings.LastIndex(pkg, "/")+1:]+".") {
+			imports = append(imports, pkg)
+		}
+	}
+	var file strings.Builder
+	file.WriteString(goHeader)
+	file.WriteString("\nimport (\n")
+	for _, pkg := range imports {
+		fmt.Fprintf(&file, "\t%q\n", pkg)
+	}
+	file.WriteString(")\n")
+	file.WriteString(b.String())
+
+	source, err := format.Source([]byte(file.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format Go client: %w", err)
+	}
+	return source, nil
+}
+
+// writeGoParams はクエリパラメータの構造体と url.Values への変換を書き込みます。
+func writeGoParams(b *strings.Builder, op operation) {
+	if len(op.queryParams) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n// %sParams は %s のクエリパラメータです（空の項目は送信しない）。\n", op.name, op.name)
+	fmt.Fprintf(b, "type %sParams struct {\n", op.name)
+	for _, param := range op.queryParams {
+		if param.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", oneLine(param.Description))
+		}
+		fmt.Fprintf(b, "\t%s string\n", pascalCase(param.Name))
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\nfunc (p *%sParams) values() url.Values {\n", op.name)
+	b.WriteString("\tquery := url.Values{}\n\tif p == nil {\n\t\treturn query\n\t}\n")
+	for _, param := range op.queryParams {
+		field := pascalCase(param.Name)
+		fmt.Fprintf(b, "\tif p.%s != \"\" {\n\t\tquery.Set(%q, p.%s)\n\t}\n", field, param.Name, field)
+	}
+	b.WriteString("\treturn query\n}\n")
+}
+
+// writeGoMethod は操作の Client のメソッドを書き込みます。
+func writeGoMethod(b *strings.Builder, op operation) {
+	args := []string{"ctx context.Context"}
+	for _, name := range op.pathParams {
+		args = append(args, goParamName(name)+" string")
+	}
+	query := "nil"
+	if len(op.queryParams) > 0 {
+		args = append(args, fmt.Sprintf("params *%sParams", op.name))
+		query = "params.values()"
+	}
+	body := "nil"
+	if op.body != nil {
+		bodyType := "any"
+		if op.body.Ref != "" {
+			bodyType = "*" + schemaName(op.body.Ref)
+		}
+		args = append(args, "body "+bodyType)
+		body = "body"
+	}
+
+	fmt.Fprintf(b, "\n// %s\n//\n//\t%s %s\n", goSummary(op), op.method, op.path)
+	call := fmt.Sprintf("%s, %s, %s, %s", methodConstant(op.method), goPathExpr(op.path), query, body)
+	signature := fmt.Sprintf("func (c *Client) %s(%s)", op.name, strings.Join(args, ", "))
+	switch {
+	case op.response != nil:
+		fmt.Fprintf(b, "%s (%s, error) {\n", signature, goResultType(*op.response))
+		fmt.Fprintf(b, "\tvar out %s\n", goType(*op.response))
+		fmt.Fprintf(b, "\tif err := c.do(ctx, %s, &out); err != nil {\n\t\treturn %s, err\n\t}\n", call, goZero(*op.response))
+		if op.response.Ref != "" {
+			b.WriteString("\treturn &out, nil\n}\n")
+		} else {
+			b.WriteString("\treturn out, nil\n}\n")
+		}
+	case op.noContent:
+		fmt.Fprintf(b, "%s error {\n\t_, err := c.doRaw(ctx, %s)\n\treturn err\n}\n", signature, call)
+	default:
+		fmt.Fprintf(b, "%s ([]byte, error) {\n\treturn c.doRaw(ctx, %s)\n}\n", signature, call)
+	}
+}
+
+// goSummary はメソッドの doc コメントの1行目です（「CreateCrop は新しい作物を登録します。」）。
+func goSummary(op operation) string {
+	summary := oneLine(op.summary)
+	switch {
+	case summary == "":
+		return fmt.Sprintf("%s は %s %s を呼び出します。", op.name, op.method, op.path)
+	case strings.HasPrefix(summary, op.name+" "):
+		return summary
+	default:
+		return op.name + " は" + summary
+	}
+}
+
+// goPathExpr はパスパラメータを埋め込むパスの式です（"/api/v1/crops/" + url.PathEscape(id)）。
+func goPathExpr(path string) string {
+	var parts []string
+	for i, segment := range pathSegments(path) {
+		if i%2 == 1 {
+			parts = append(parts, "url.PathEscape("+goParamName(segment)+")")
+		} else if segment != "" {
+			parts = append(parts, strconv.Quote(segment))
+		}
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goParamName はパスパラメータの引数名です（予約語は末尾に Param を付ける: type → typeParam）。
+func goParamName(name string) string {
+	name = camelCase(name)
+	if token.IsKeyword(name) {
+		name += "Param"
+	}
+	return name
+}
+
+// goType は定義の Go の型です。
+func goType(s openapi.Schema) string {
+	if s.Ref != "" {
+		return schemaName(s.Ref)
+	}
+	base := ""
+	switch s.Type {
+	case "string":
+		base = "string"
+		if s.Format == "date-time" {
+			base = "time.Time"
+		}
+	case "integer":
+		base = "int64"
+		if s.Format == "int32" {
+			base = "int32"
+		}
+	case "number":
+		base = "float64"
+		if s.Format == "float" {
+			base = "float32"
+		}
+	case "boolean":
+		base = "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]any"
+		}
+		return "[]" + goType(*s.Items)
+	case "object":
+		switch {
+		case s.AdditionalProperties != nil:
+			return "map[string]" + goType(*s.AdditionalProperties)
+		case len(s.Properties) > 0:
+			return goStruct(s)
+		default:
+			return "map[string]any"
+		}
+	default:
+		return "any"
+	}
+	if s.Nullable {
+		return "*" + base
+	}
+	return base
+}
+
+// goStruct は項目のある定義の構造体です（項目は JSON の項目名の順）。
+func goStruct(s openapi.Schema) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, name := range names {
+		property := s.Properties[name]
+		if len(property.Enum) > 0 {
+			fmt.Fprintf(&b, "\t// %s\n", strings.Join(property.Enum, " / "))
+		}
+		tag, fieldType := name, goType(property)
+		if !required[name] {
+			tag += ",omitempty"
+			if property.Ref != "" {
+				fieldType = "*" + fieldType // 省略可能な構造体（$ref は nullable を指定できないため）
+			}
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", pascalCase(name), fieldType, tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// goResultType はメソッドの戻り値の型です（構造体はポインタ）。
+func goResultType(s openapi.Schema) string {
+	if s.Ref != "" {
+		return "*" + schemaName(s.Ref)
+	}
+	return goType(s)
+}
+
+// goZero はエラーの場合に返す値です。
+func goZero(s openapi.Schema) string {
+	switch goType(s) {
+	case "string":
+		return `""`
+	case "int32", "int64", "float32", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	case "time.Time":
+		return "time.Time{}"
+	default:
+		return "nil"
+	}
+}
+
+// oneLine は説明を1行にします（コメント・JSDoc に埋め込むため）。
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
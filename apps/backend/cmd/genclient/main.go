@@ -0,0 +1,86 @@
+// Command genclient は OpenAPI の仕様（internal/openapi/openapi.json）から API のクライアントを生成します。
+//
+//   - Go: client/api.gen.go（github.com/secure-scorecard/backend/client、通信部分は client/client.go）
+//   - TypeScript: packages/shared/src/api/client.ts（@secure-scorecard/shared/api）
+//
+// 仕様の operationId をメソッド名、components.schemas を型にします。
+// リクエストボディ・レスポンスの型を登録していない操作（handler.APITypes）のメソッドはレスポンスの本文をそのまま返し、
+// x-client-exclude の操作（WebSocket・SSE・multipart）は生成しません。
+//
+// 使い方（apps/backend で実行、先に go run ./cmd/openapi で仕様を再生成する）:
+//
+//	go run ./cmd/genclient          # クライアントを再生成
+//	go run ./cmd/genclient -check   # 生成したクライアントと差分がある場合は終了コード 1（CI 用）
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+func main() {
+	goOut := flag.String("go-out", "client/api.gen.go", "Go のクライアントの保存先")
+	tsOut := flag.String("ts-out", "../../packages/shared/src/api/client.ts", "TypeScript のクライアントの保存先")
+	check := flag.Bool("check", false, "保存せずに保存先との差分を確認する")
+	flag.Parse()
+
+	files, err := generate(openapi.Spec(), *goOut, *tsOut)
+	if err != nil {
+		log.Fatalf("Failed to generate API clients: %v", err)
+	}
+
+	outdated := false
+	for _, file := range files {
+		if *check {
+			current, err := os.ReadFile(file.path)
+			if err != nil {
+				log.Fatalf("Failed to read %s: %v", file.path, err)
+			}
+			if !bytes.Equal(current, file.content) {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run `go run ./cmd/genclient` and commit the result\n", file.path)
+				outdated = true
+				continue
+			}
+			fmt.Printf("%s is up to date\n", file.path)
+			continue
+		}
+
+		if err := os.WriteFile(file.path, file.content, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", file.path, err)
+		}
+		fmt.Printf("Wrote %s\n", file.path)
+	}
+	if outdated {
+		os.Exit(1)
+	}
+}
+
+// generatedFile は生成したクライアントのファイルです。
+type generatedFile struct {
+	path    string
+	content []byte
+}
+
+// generate は仕様から Go・TypeScript のクライアントを生成します。
+func generate(spec []byte, goOut, tsOut string) ([]generatedFile, error) {
+	var doc openapi.Document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	api := newAPI(&doc)
+
+	goSource, err := generateGo(api)
+	if err != nil {
+		return nil, err
+	}
+	return []generatedFile{
+		{path: goOut, content: goSource},
+		{path: tsOut, content: generateTypeScript(api)},
+	}, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// =============================================================================
+// Client Generator Tests - クライアントの生成コマンドのテスト
+// =============================================================================
+// テスト対象:
+//   - generate: 保存したクライアントが仕様の変更に追従していること
+//   - pascalCase / camelCase: operationId・項目名の変換
+
+// TestClientUpToDate は保存したクライアントが最新であることのテストです。
+// 期待動作:
+//   - internal/openapi/openapi.json から生成したクライアントが client/api.gen.go・packages/shared/src/api/client.ts と一致する
+//     （一致しない場合は go run ./cmd/genclient で再生成する）
+func TestClientUpToDate(t *testing.T) {
+	// Act
+	files, err := generate(openapi.Spec(), "../../client/api.gen.go", "../../../../packages/shared/src/api/client.ts")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	// Assert
+	for _, file := range files {
+		current, err := os.ReadFile(file.path)
+		if err != nil {
+			t.Fatalf("Failed to read client: %v", err)
+		}
+		if !bytes.Equal(file.content, current) {
+			t.Errorf("%s is out of date; run `go run ./cmd/genclient`", file.path)
+		}
+	}
+}
+
+// TestClientNames は名前の変換のテストです。
+// 期待動作:
+//   - JSON の項目名・パスパラメータは略語を大文字にした PascalCase・camelCase になる
+//   - 予約語のパスパラメータは末尾に Param を付ける
+func TestClientNames(t *testing.T) {
+	tests := []struct {
+		input, pascal, camel string
+	}{
+		{"plot_id", "PlotID", "plotID"},
+		{"organization_id", "OrganizationID", "organizationID"},
+		{"userId", "UserId", "userId"},
+		{"id", "ID", "id"},
+		{"GetCrops", "GetCrops", "getCrops"},
+		{"ExportCSV", "ExportCSV", "exportCSV"},
+		{"get_Hello_api_v1_hello", "GetHelloAPIV1Hello", "getHelloAPIV1Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Act & Assert
+			if got := pascalCase(tt.input); got != tt.pascal {
+				t.Errorf("pascalCase(%q) = %q, want %q", tt.input, got, tt.pascal)
+			}
+			if got := camelCase(tt.input); got != tt.camel {
+				t.Errorf("camelCase(%q) = %q, want %q", tt.input, got, tt.camel)
+			}
+		})
+	}
+	if got := goPathExpr("/api/v1/analytics/charts/{type}"); !strings.Contains(got, "url.PathEscape(typeParam)") {
+		t.Errorf("Expected keyword path param to be renamed, got %s", got)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// =============================================================================
+// TypeScript Client - TypeScript のクライアントの生成
+// =============================================================================
+
+// tsHeader は生成する TypeScript のファイルの先頭です。
+const tsHeader = `/* eslint-disable */
+// Code generated by apps/backend/cmd/genclient from apps/backend/internal/openapi/openapi.json; DO NOT EDIT.
+
+`
+
+// tsRuntime は生成したメソッドが使用する通信の部分です（fetch を使用）。
+const tsRuntime = `
+// =============================================================================
+// Client
+// =============================================================================
+
+/** ApiClient の設定です。 */
+export interface ApiClientOptions {
+  /** API のベースURL（https://api.example.com、末尾の / は不要） */
+  baseUrl: string;
+  /** Authorization: Bearer で送信する JWT */
+  token?: string;
+  /** X-Org-ID で送信する組織のID（区画・作物を組織のものとして扱う） */
+  organizationId?: number;
+  /** リクエストに使用する fetch（デフォルト: globalThis.fetch） */
+  fetch?: typeof fetch;
+}
+
+/** API のエラーのレスポンス（2xx 以外）です。 */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly problem: Problem | undefined,
+    readonly body: string
+  ) {
+    super(problem ? ` + "`api error ${status} ${problem.code}: ${problem.detail ?? ''}`" + ` : ` + "`api error ${status}: ${body}`" + `);
+    this.name = 'ApiError';
+  }
+
+  /** エラーコード（CROP_NOT_FOUND など、problem+json でない場合は undefined） */
+  get code(): string | undefined {
+    return this.problem?.code;
+  }
+}
+
+type QueryParams = Record<string, string | undefined>;
+
+/** Home Garden Management API のクライアントです。 */
+export class ApiClient {
+  private token: string | undefined;
+
+  constructor(private readonly options: ApiClientOptions) {
+    this.token = options.token;
+  }
+
+  /** 以降のリクエストで送信する JWT を変更します（undefined の場合は送信しない）。 */
+  setToken(token: string | undefined): void {
+    this.token = token;
+  }
+
+  private async request<T>(method: string, path: string, query?: QueryParams, body?: unknown): Promise<T> {
+    const url = new URL(this.options.baseUrl.replace(/\/+$/, '') + path);
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined && value !== '') {
+        url.searchParams.set(key, value);
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: 'application/json' };
+    if (body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+    }
+    if (this.token) {
+      headers['Authorization'] = ` + "`Bearer ${this.token}`" + `;
+    }
+    if (this.options.organizationId) {
+      headers['X-Org-ID'] = String(this.options.organizationId);
+    }
+
+    const fetcher = this.options.fetch ?? globalThis.fetch;
+    const response = await fetcher(url.toString(), {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const text = await response.text();
+    const contentType = response.headers.get('Content-Type') ?? '';
+
+    if (!response.ok) {
+      const problem = contentType.startsWith('application/problem+json') ? (JSON.parse(text) as Problem) : undefined;
+      throw new ApiError(response.status, problem, text);
+    }
+    if (text === '' || !contentType.includes('json')) {
+      return text as T;
+    }
+    return JSON.parse(text) as T;
+  }
+`
+
+// tsIdentifier は TypeScript で引用符なしで書ける項目名です。
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// generateTypeScript は TypeScript のクライアント（型・パラメータ・ApiClient のメソッド）を生成します。
+func generateTypeScript(a *api) []byte {
+	var b strings.Builder
+	b.WriteString(tsHeader)
+
+	b.WriteString("// =============================================================================\n")
+	b.WriteString("// Types - components.schemas\n")
+	b.WriteString("// =============================================================================\n")
+	for _, s := range a.schemas {
+		b.WriteString("\n")
+		if s.schema.Description != "" {
+			fmt.Fprintf(&b, "/** %s */\n", tsComment(s.schema.Description))
+		}
+		if s.schema.Type == "object" && len(s.schema.Properties) > 0 {
+			fmt.Fprintf(&b, "export interface %s %s\n", s.name, tsObject(s.schema, ""))
+		} else {
+			fmt.Fprintf(&b, "export type %s = %s;\n", s.name, tsType(s.schema, ""))
+		}
+	}
+
+	for _, op := range a.operations {
+		if len(op.queryParams) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n/** %s のクエリパラメータです（空の項目は送信しない）。 */\nexport interface %sParams {\n", op.name, op.name)
+		for _, param := range op.queryParams {
+			if param.Description != "" {
+				fmt.Fprintf(&b, "  /** %s */\n", tsComment(param.Description))
+			}
+			fmt.Fprintf(&b, "  %s?: string;\n", tsPropertyName(param.Name))
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString(tsRuntime)
+	for _, op := range a.operations {
+		writeTSMethod(&b, op)
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+// writeTSMethod は操作の ApiClient のメソッドを書き込みます。
+func writeTSMethod(b *strings.Builder, op operation) {
+	var args []string
+	for _, name := range op.pathParams {
+		args = append(args, camelCase(name)+": string | number")
+	}
+	query := "undefined"
+	if len(op.queryParams) > 0 {
+		args = append(args, fmt.Sprintf("params?: %sParams", op.name))
+		query = "params as QueryParams | undefined"
+	}
+	body := ""
+	if op.body != nil {
+		bodyType := "unknown"
+		if op.body.Ref != "" {
+			bodyType = schemaName(op.body.Ref)
+		}
+		args = append(args, "body: "+bodyType)
+		body = ", body"
+	} else if len(op.queryParams) == 0 {
+		query = ""
+	}
+
+	result := "unknown"
+	switch {
+	case op.response != nil:
+		result = tsType(*op.response, "  ")
+	case op.noContent:
+		result = "void"
+	}
+
+	callArgs := fmt.Sprintf("'%s', %s", op.method, tsPathExpr(op.path))
+	if query != "" {
+		callArgs += ", " + query
+	}
+	callArgs += body
+
+	fmt.Fprintf(b, "\n  /**\n   * %s\n   *\n   * %s %s\n   */\n", tsComment(goSummary(op)), op.method, op.path)
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n", camelCase(op.name), strings.Join(args, ", "), result)
+	fmt.Fprintf(b, "    return this.request<%s>(%s);\n  }\n", result, callArgs)
+}
+
+// tsPathExpr はパスパラメータを埋め込むパスの式です（`/api/v1/crops/${encodeURIComponent(String(id))}`）。
+func tsPathExpr(path string) string {
+	segments := pathSegments(path)
+	if len(segments) == 1 {
+		return "'" + path + "'"
+	}
+	var b strings.Builder
+	b.WriteString("`")
+	for i, segment := range segments {
+		if i%2 == 1 {
+			fmt.Fprintf(&b, "${encodeURIComponent(String(%s))}", camelCase(segment))
+		} else {
+			b.WriteString(segment)
+		}
+	}
+	b.WriteString("`")
+	return b.String()
+}
+
+// tsType は定義の TypeScript の型です（日時は RFC 3339 の文字列）。
+func tsType(s openapi.Schema, indent string) string {
+	if s.Ref != "" {
+		return schemaName(s.Ref)
+	}
+	base := ""
+	switch s.Type {
+	case "string":
+		base = "string"
+		if len(s.Enum) > 0 {
+			values := make([]string, len(s.Enum))
+			for i, value := range s.Enum {
+				values[i] = "'" + value + "'"
+			}
+			base = strings.Join(values, " | ")
+		}
+	case "integer", "number":
+		base = "number"
+	case "boolean":
+		base = "boolean"
+	case "array":
+		if s.Items == nil {
+			return "unknown[]"
+		}
+		item := tsType(*s.Items, indent)
+		if strings.ContainsAny(item, " |") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		switch {
+		case s.AdditionalProperties != nil:
+			return "Record<string, " + tsType(*s.AdditionalProperties, indent) + ">"
+		case len(s.Properties) > 0:
+			return tsObject(s, indent)
+		default:
+			return "Record<string, unknown>"
+		}
+	default:
+		return "unknown"
+	}
+	if s.Nullable {
+		return base + " | null"
+	}
+	return base
+}
+
+// tsObject は項目のある定義のオブジェクトの型です（必須でない項目は省略可能）。
+func tsObject(s openapi.Schema, indent string) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range names {
+		property := s.Properties[name]
+		if property.Description != "" {
+			fmt.Fprintf(&b, "%s  /** %s */\n", indent, tsComment(property.Description))
+		}
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, tsPropertyName(name), optional, tsType(property, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsPropertyName は項目名です（識別子でない場合は引用符で囲む）。
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return "'" + name + "'"
+}
+
+// tsComment は JSDoc に埋め込む説明です（1行にし、コメントの終わりを含めない）。
+func tsComment(text string) string {
+	return strings.ReplaceAll(oneLine(text), "*/", "* /")
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Commands - マイグレーションCLIのコマンド
+// =============================================================================
+
+// migrationNamePattern は create で指定できるマイグレーションの名前です（ファイル名に使用）。
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// dialectDirs は MySQL・SQLite 用のマイグレーションのファイルのディレクトリです（マイグレーションのディレクトリの下）。
+var dialectDirs = []string{"mysql", "sqlite"}
+
+// migrationFilePattern はマイグレーションのファイル名です（{バージョン}_{名前}.up.sql / .down.sql）。
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_[a-z0-9_]+\.(up|down)\.sql$`)
+
+// newRootCommand はマイグレーションCLIのルートコマンドを作成します。
+//
+// 引数:
+//   - open: データベースに接続する関数（データベースを使用するコマンドの実行時に1回呼び出す）
+//   - dir: create でファイルを作成するディレクトリ
+//
+// 戻り値:
+//   - *cobra.Command: サブコマンドを登録したルートコマンド
+func newRootCommand(open func() (migrator, error), dir string) *cobra.Command {
+	root := &cobra.Command{
+		Use:          "migrate",
+		Short:        "バージョン管理のSQLマイグレーションを実行する",
+		SilenceUsage: true,
+	}
+
+	// run はデータベースに接続してコマンドの処理を実行し、終了後に接続を閉じます。
+	run := func(fn func(cmd *cobra.Command, args []string, db migrator) error) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			db, err := open()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			return fn(cmd, args, db)
+		}
+	}
+
+	root.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "テーブルの AutoMigrate の後、未適用のマイグレーションをすべて適用する",
+			Args:  cobra.NoArgs,
+			RunE: run(func(cmd *cobra.Command, args []string, db migrator) error {
+				if err := db.Setup(); err != nil {
+					return err
+				}
+				return printVersion(cmd, db)
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "直近に適用したマイグレーションを N 件（デフォルト 1）ロールバックする",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: run(func(cmd *cobra.Command, args []string, db migrator) error {
+				steps := 1
+				if len(args) == 1 {
+					n, err := strconv.Atoi(args[0])
+					if err != nil || n <= 0 {
+						return fmt.Errorf("invalid number of migrations %q", args[0])
+					}
+					steps = n
+				}
+				if err := db.MigrateDown(steps); err != nil {
+					return err
+				}
+				return printVersion(cmd, db)
+			}),
+		},
+		&cobra.Command{
+			Use:   "goto VERSION",
+			Short: "指定したバージョンまで適用またはロールバックする",
+			Args:  cobra.ExactArgs(1),
+			RunE: run(func(cmd *cobra.Command, args []string, db migrator) error {
+				version, err := strconv.ParseUint(args[0], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				if err := db.MigrateTo(uint(version)); err != nil {
+					return err
+				}
+				return printVersion(cmd, db)
+			}),
+		},
+		&cobra.Command{
+			Use:   "version",
+			Short: "適用済みのバージョンを表示する",
+			Args:  cobra.NoArgs,
+			RunE: run(func(cmd *cobra.Command, args []string, db migrator) error {
+				return printVersion(cmd, db)
+			}),
+		},
+		&cobra.Command{
+			Use:   "force VERSION",
+			Short: "マイグレーションを実行せずに記録するバージョンを設定し、dirty を解除する（force -- -1 で未適用）",
+			Args:  cobra.ExactArgs(1),
+			RunE: run(func(cmd *cobra.Command, args []string, db migrator) error {
+				version, err := strconv.Atoi(args[0])
+				if err != nil || version < -1 {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				if err := db.ForceMigrationVersion(version); err != nil {
+					return err
+				}
+				return printVersion(cmd, db)
+			}),
+		},
+		newCreateCommand(dir),
+	)
+	return root
+}
+
+// printVersion は適用済みのバージョンを出力します。
+func printVersion(cmd *cobra.Command, db migrator) error {
+	status, err := db.MigrationVersion()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Migration version: %d (dirty: %v)\n", status.Version, status.Dirty)
+	return nil
+}
+
+// newCreateCommand は次のバージョンのマイグレーションのファイルを作成するコマンドを作成します。
+func newCreateCommand(dir string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create NAME",
+		Short: "次のバージョンの up / down のマイグレーションのファイルを作成する（mysql・sqlite ディレクトリにも作成）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !migrationNamePattern.MatchString(name) {
+				return fmt.Errorf("invalid migration name %q (use lowercase letters, digits and underscores)", name)
+			}
+			version, err := nextMigrationVersion(dir)
+			if err != nil {
+				return err
+			}
+			// MySQL・SQLite 用のディレクトリがある場合は同じバージョン・名前のファイルも作成する
+			dirs := []string{dir}
+			for _, dialectDir := range dialectDirs {
+				if info, err := os.Stat(filepath.Join(dir, dialectDir)); err == nil && info.IsDir() {
+					dirs = append(dirs, filepath.Join(dir, dialectDir))
+				}
+			}
+			for _, target := range dirs {
+				for _, direction := range []string{"up", "down"} {
+					path := filepath.Join(target, fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+					content := fmt.Sprintf("-- %s (%s)\n", name, direction)
+					if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+						return fmt.Errorf("failed to create %s: %w", path, err)
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), "Created", path)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// nextMigrationVersion はディレクトリのマイグレーションの最大のバージョンの次のバージョンを返します。
+func nextMigrationVersion(dir string) (uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var latest uint64
+	for _, entry := range entries {
+		if entry.Type()&fs.ModeType != 0 {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, version)
+	}
+	return latest + 1, nil
+}
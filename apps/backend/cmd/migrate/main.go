@@ -0,0 +1,58 @@
+// Command migrate はバージョン管理のSQLマイグレーション（migrations/*.sql）を実行するCLIです。
+//
+// 使い方（apps/backend で実行、設定はサーバーと同じ環境変数）:
+//
+//	go run ./cmd/migrate up                  # テーブルの AutoMigrate の後、未適用のマイグレーションをすべて適用
+//	go run ./cmd/migrate down                # 直近のマイグレーションを1件ロールバック（down 3 で3件）
+//	go run ./cmd/migrate goto 2              # バージョン 2 まで適用またはロールバック
+//	go run ./cmd/migrate version             # 適用済みのバージョンと dirty の状態
+//	go run ./cmd/migrate force 2             # 失敗したマイグレーションの修正後、記録するバージョンを設定
+//	go run ./cmd/migrate create rename_notes # 次のバージョンの up / down のファイルを作成（データベースに接続しない）
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/database"
+)
+
+// migrationsDir は create でファイルを作成するディレクトリです（apps/backend からの相対パス）。
+const migrationsDir = "migrations"
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand(openMigrator, migrationsDir).ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+// migrator はマイグレーションを実行するデータベースです（*database.DB、テストでは差し替え）。
+type migrator interface {
+	Setup() error
+	MigrateDown(steps int) error
+	MigrateTo(version uint) error
+	ForceMigrationVersion(version int) error
+	MigrationVersion() (database.MigrationStatus, error)
+	Close() error
+}
+
+// openMigrator は設定を読み込み、データベースに接続します。
+// 各コマンドの実行時に呼び出すため、--help と create ではデータベースに接続しません。
+func openMigrator() (migrator, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	db, err := database.Connect(cfg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/database"
+)
+
+// =============================================================================
+// Migrate CLI Tests - マイグレーションCLIのテスト
+// =============================================================================
+// テスト対象:
+//   - down / goto / force: 引数の検証とデータベースの呼び出し
+//   - create: 次のバージョンの up / down のファイルの作成（データベースに接続しない）
+
+// fakeMigrator は呼び出しを記録する migrator です。
+type fakeMigrator struct {
+	status database.MigrationStatus
+	calls  []string
+	closed bool
+}
+
+func (f *fakeMigrator) Setup() error {
+	f.calls = append(f.calls, "up")
+	return nil
+}
+
+func (f *fakeMigrator) MigrateDown(steps int) error {
+	f.calls = append(f.calls, "down "+strconv.Itoa(steps))
+	return nil
+}
+
+func (f *fakeMigrator) MigrateTo(version uint) error {
+	f.calls = append(f.calls, "goto "+strconv.Itoa(int(version)))
+	return nil
+}
+
+func (f *fakeMigrator) ForceMigrationVersion(version int) error {
+	f.calls = append(f.calls, "force "+strconv.Itoa(version))
+	return nil
+}
+
+func (f *fakeMigrator) MigrationVersion() (database.MigrationStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeMigrator) Close() error {
+	f.closed = true
+	return nil
+}
+
+// execute はコマンドを実行し、出力を返します。connected はデータベースに接続したかどうかです。
+func execute(db migrator, dir string, args ...string) (out string, connected bool, err error) {
+	root := newRootCommand(func() (migrator, error) {
+		connected = true
+		return db, nil
+	}, dir)
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs(args)
+	err = root.ExecuteContext(context.Background())
+	return buf.String(), connected, err
+}
+
+// TestCommands_Database はデータベースを使用するコマンドのテストです。
+// 期待動作:
+//   - up / down / goto / force は対応するメソッドを呼び出し、適用済みのバージョンを出力して接続を閉じる
+//   - down の件数を省略した場合は 1 件
+//   - 不正な件数・バージョンはエラーでデータベースを変更しない
+func TestCommands_Database(t *testing.T) {
+	// Arrange
+	db := &fakeMigrator{status: database.MigrationStatus{Version: 3}}
+
+	// Act
+	out, _, err := execute(db, t.TempDir(), "up")
+	_, _, downErr := execute(db, t.TempDir(), "down")
+	_, _, downNErr := execute(db, t.TempDir(), "down", "2")
+	_, _, gotoErr := execute(db, t.TempDir(), "goto", "1")
+	_, _, forceErr := execute(db, t.TempDir(), "force", "--", "-1")
+	_, _, invalidDownErr := execute(db, t.TempDir(), "down", "0")
+	_, _, invalidGotoErr := execute(db, t.TempDir(), "goto", "latest")
+
+	// Assert
+	for _, err := range []error{err, downErr, downNErr, gotoErr, forceErr} {
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+	}
+	if !strings.Contains(out, "Migration version: 3 (dirty: false)") {
+		t.Errorf("Expected version output, got %q", out)
+	}
+	want := []string{"up", "down 1", "down 2", "goto 1", "force -1"}
+	if strings.Join(db.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected calls %v, got %v", want, db.calls)
+	}
+	if !db.closed {
+		t.Error("Expected the database connection to be closed")
+	}
+	if invalidDownErr == nil || invalidGotoErr == nil {
+		t.Errorf("Expected errors for invalid arguments, got %v, %v", invalidDownErr, invalidGotoErr)
+	}
+}
+
+// TestCreate は create のテストです。
+// 期待動作:
+//   - 最大のバージョンの次のバージョンで up / down のファイルを作成する（mysql・sqlite ディレクトリにも同じ名前で作成）
+//   - データベースに接続しない
+//   - 名前に小文字・数字・アンダースコア以外を含む場合はエラー
+func TestCreate(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	for _, dialectDir := range []string{"mysql", "sqlite"} {
+		if err := os.Mkdir(filepath.Join(dir, dialectDir), 0o755); err != nil {
+			t.Fatalf("Mkdir failed: %v", err)
+		}
+	}
+	for _, name := range []string{"000001_create_indexes.up.sql", "000001_create_indexes.down.sql", "000004_backfill.up.sql", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	// Act
+	out, connected, err := execute(nil, dir, "create", "rename_notes")
+	_, _, invalidErr := execute(nil, dir, "create", "Rename-Notes")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if connected {
+		t.Error("Expected create not to connect to the database")
+	}
+	for _, name := range []string{
+		"000005_rename_notes.up.sql", "000005_rename_notes.down.sql",
+		filepath.Join("mysql", "000005_rename_notes.up.sql"), filepath.Join("mysql", "000005_rename_notes.down.sql"),
+		filepath.Join("sqlite", "000005_rename_notes.up.sql"), filepath.Join("sqlite", "000005_rename_notes.down.sql"),
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be created: %v", name, err)
+		}
+		if !strings.Contains(out, name) {
+			t.Errorf("Expected output to mention %s, got %q", name, out)
+		}
+	}
+	if invalidErr == nil {
+		t.Error("Expected an error for an invalid migration name")
+	}
+}
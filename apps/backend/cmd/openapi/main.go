@@ -0,0 +1,72 @@
+// Command openapi はハンドラのルートと doc コメントから OpenAPI 3 の仕様を生成します。
+//
+// 使い方（apps/backend で実行）:
+//
+//	go run ./cmd/openapi          # internal/openapi/openapi.json を再生成
+//	go run ./cmd/openapi -check   # 生成した仕様と差分がある場合は終了コード 1（CI 用）
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/handler"
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+func main() {
+	handlerDir := flag.String("handlers", "internal/handler", "ハンドラのパッケージのディレクトリ")
+	out := flag.String("out", filepath.Join("internal/openapi", openapi.SpecFile), "生成した仕様の保存先")
+	check := flag.Bool("check", false, "保存せずに保存先との差分を確認する")
+	flag.Parse()
+
+	spec, err := generate(*handlerDir)
+	if err != nil {
+		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
+	}
+
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *out, err)
+		}
+		if !bytes.Equal(current, spec) {
+			fmt.Fprintf(os.Stderr, "%s is out of date; run `go run ./cmd/openapi` and commit the result\n", *out)
+			os.Exit(1)
+		}
+		fmt.Printf("%s is up to date\n", *out)
+		return
+	}
+
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("Wrote %s\n", *out)
+}
+
+// generate はサーバーと同じルートを登録し、ハンドラの doc コメントと合わせて仕様を生成します。
+// ルートの登録のみ行うため、データベース・外部サービスには接続しません。
+func generate(handlerDir string) ([]byte, error) {
+	docs, err := openapi.ParseHandlerDocs(handlerDir)
+	if err != nil {
+		return nil, err
+	}
+
+	e := echo.New()
+	h := handler.NewHandler(nil, nil, nil)
+	h.RegisterRoutes(e)
+	h.RegisterSchedulerRoutes(e, "", nil)
+	h.RegisterMetricsRoutes(e, "")
+
+	doc := openapi.Generate(openapi.Info{
+		Title:       "Home Garden Management API",
+		Description: "家庭菜園管理アプリのバックエンド API（ハンドラの doc コメントから生成）",
+		Version:     "v1",
+	}, e.Routes(), docs, handler.APITypes())
+	return openapi.Marshal(doc)
+}
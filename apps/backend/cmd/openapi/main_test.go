@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/handler"
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// =============================================================================
+// OpenAPI Generator Tests - 仕様の生成コマンドのテスト
+// =============================================================================
+// テスト対象:
+//   - generate: 埋め込んだ仕様がハンドラの変更に追従していること
+//   - handler.APITypes: 型を登録したハンドラがルートに登録されていること
+
+// TestSpecUpToDate は保存した仕様が最新であることのテストです。
+// 期待動作:
+//   - ハンドラ・ルートから生成した仕様が internal/openapi/openapi.json と一致する
+//     （一致しない場合は go run ./cmd/openapi で再生成する）
+func TestSpecUpToDate(t *testing.T) {
+	// Act
+	spec, err := generate("../../internal/handler")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	current, err := os.ReadFile("../../internal/openapi/openapi.json")
+	if err != nil {
+		t.Fatalf("Failed to read spec: %v", err)
+	}
+
+	// Assert
+	if !bytes.Equal(spec, current) {
+		t.Error("internal/openapi/openapi.json is out of date; run `go run ./cmd/openapi`")
+	}
+}
+
+// TestAPITypesRegistered は型を登録したハンドラのテストです。
+// 期待動作:
+//   - handler.APITypes のキー（Handler.GetCrops など）がすべてルートのハンドラと一致する
+//     （ハンドラ名を変更した場合に型の登録が残らない）
+func TestAPITypesRegistered(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	h := handler.NewHandler(nil, nil, nil)
+	h.RegisterRoutes(e)
+	h.RegisterSchedulerRoutes(e, "", nil)
+	h.RegisterMetricsRoutes(e, "")
+	registered := make(map[string]bool)
+	for _, route := range e.Routes() {
+		registered[openapi.HandlerKey(route.Name)] = true
+	}
+
+	// Act & Assert
+	for key := range handler.APITypes() {
+		if !registered[key] {
+			t.Errorf("handler.APITypes has %s, but no route uses it", key)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/seed"
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// Commands - シードCLIのコマンド
+// =============================================================================
+
+const (
+	// defaultSeedPassword は load で作成するユーザーのパスワードの初期値です。
+	defaultSeedPassword = "demo-password"
+	// productionEnv は投入に --allow-production が必要な APP_ENV です。
+	productionEnv = "production"
+)
+
+// errProductionSeed は本番環境に --allow-production を指定せずに投入した場合のエラー
+var errProductionSeed = errors.New("refusing to seed a production environment without --allow-production")
+
+// newRootCommand はシードCLIのルートコマンドを作成します。
+//
+// 引数:
+//   - open: データベースに接続してサービスを初期化する関数（load の実行時に1回呼び出す）
+//
+// 戻り値:
+//   - *cobra.Command: サブコマンドを登録したルートコマンド
+func newRootCommand(open func() (*seedApp, error)) *cobra.Command {
+	root := &cobra.Command{
+		Use:          "seed",
+		Short:        "フィクスチャセットのデータを投入する",
+		SilenceUsage: true,
+	}
+	root.AddCommand(newListCommand(), newLoadCommand(open))
+	return root
+}
+
+// newListCommand はフィクスチャセットの一覧のコマンドを作成します。
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "フィクスチャセットの一覧を表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tDESCRIPTION")
+			for _, set := range seed.Sets() {
+				fmt.Fprintf(w, "%s\t%s\n", set.Name, set.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newLoadCommand はフィクスチャセットの投入のコマンドを作成します。
+func newLoadCommand(open func() (*seedApp, error)) *cobra.Command {
+	var (
+		email           string
+		password        string
+		userID          uint
+		harvests        int
+		allowProduction bool
+	)
+	cmd := &cobra.Command{
+		Use:   "load <set>",
+		Short: "フィクスチャセットのデータをユーザーに投入する（--user-id を省略した場合はユーザーを作成）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set, err := seed.Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			app, err := open()
+			if err != nil {
+				return err
+			}
+			defer app.Close()
+			if app.cfg.Server.Env == productionEnv && !allowProduction {
+				return errProductionSeed
+			}
+
+			ctx := cmd.Context()
+			var user *model.User
+			if userID != 0 {
+				if user, err = app.svc.GetUserByID(ctx, userID); err != nil {
+					return fmt.Errorf("failed to find user %d: %w", userID, err)
+				}
+			} else {
+				if email == "" {
+					email = set.Name + "@example.com"
+				}
+				if user, err = seed.CreateUser(ctx, app.svc, email, password, set.Name); err != nil {
+					return err
+				}
+			}
+
+			summary, err := set.Load(ctx, app.svc, seed.Options{UserID: user.ID, Harvests: harvests})
+			if err != nil {
+				return fmt.Errorf("failed to load %s after %s: %w", set.Name, summary, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Seeded %s for user %d (%s): %s\n", set.Name, user.ID, user.Email, summary)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "作成するユーザーのメールアドレス（デフォルト: <set>@example.com、既に存在する場合はエラー）")
+	cmd.Flags().StringVar(&password, "password", defaultSeedPassword, fmt.Sprintf("作成するユーザーのパスワード（%d文字以上）", seed.MinPasswordLength))
+	cmd.Flags().UintVar(&userID, "user-id", 0, "データを投入する既存のユーザーのID（指定した場合はユーザーを作成しない）")
+	cmd.Flags().IntVar(&harvests, "harvests", seed.DefaultLoadTestHarvests, "loadtest の収穫記録の件数")
+	cmd.Flags().BoolVar(&allowProduction, "allow-production", false, "APP_ENV=production の環境にも投入する")
+	return cmd
+}
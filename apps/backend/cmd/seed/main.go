@@ -0,0 +1,62 @@
+// Command seed はフィクスチャセット（internal/seed）のデータを投入するCLIです。
+//
+// 使い方（apps/backend で実行、設定はサーバーと同じ環境変数）:
+//
+//	go run ./cmd/seed list                                  # フィクスチャセットの一覧（データベースに接続しない）
+//	go run ./cmd/seed load demo                             # デモユーザー（demo@example.com）を作成してデモの菜園を投入
+//	go run ./cmd/seed load loadtest --harvests 100000       # 負荷試験用のユーザーを作成して収穫記録を投入
+//	go run ./cmd/seed load demo --user-id 42                # 既存のユーザーに投入
+//
+// APP_ENV=production の環境には --allow-production を指定した場合のみ投入します。
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := newRootCommand(openApp).ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+// seedApp はコマンドが使用する設定・サービスと接続です。
+type seedApp struct {
+	cfg *config.Config
+	db  *database.DB // nil の場合は閉じる接続がない（テスト用）
+	svc *service.Service
+}
+
+// Close はデータベースの接続を閉じます。
+func (a *seedApp) Close() {
+	if a.db != nil {
+		_ = a.db.Close()
+	}
+}
+
+// openApp は設定を読み込み、データベースに接続します（テーブルは go run ./cmd/migrate up で作成してください）。
+// load の実行時に呼び出すため、--help と list ではデータベースに接続しません。
+func openApp() (*seedApp, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	db, err := database.Connect(cfg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	svc := service.NewService(repository.NewRepositoryManager(db.DB))
+	return &seedApp{cfg: cfg, db: db, svc: svc}, nil
+}
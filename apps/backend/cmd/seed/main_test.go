@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/seed"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Seed CLI Tests - シードCLIのテスト
+// =============================================================================
+// テスト対象:
+//   - list: フィクスチャセットの一覧（データベースに接続しない）
+//   - load: ユーザーの作成・既存のユーザーへの投入、本番環境の確認、登録されていないセット
+
+// newTestApp はモックリポジトリのサービスを使用する seedApp を作成します。
+func newTestApp(env string) *seedApp {
+	return &seedApp{
+		cfg: &config.Config{Server: config.ServerConfig{Env: env}},
+		svc: service.NewService(repository.NewMockRepositories()),
+	}
+}
+
+// execute はコマンドを実行し、出力を返します。connected はデータベースに接続したかどうかです。
+func execute(app *seedApp, args ...string) (out string, connected bool, err error) {
+	root := newRootCommand(func() (*seedApp, error) {
+		connected = true
+		return app, nil
+	})
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs(args)
+	err = root.ExecuteContext(context.Background())
+	return buf.String(), connected, err
+}
+
+// TestList はフィクスチャセットの一覧のテストです。
+// 期待動作:
+//   - 登録済みのセットの名前と説明を表示し、データベースに接続しない
+func TestList(t *testing.T) {
+	// Act
+	out, connected, err := execute(newTestApp("development"), "list")
+
+	// Assert
+	if err != nil || connected {
+		t.Fatalf("Expected list without a database, got %v (connected=%v)", err, connected)
+	}
+	for _, set := range seed.Sets() {
+		if !strings.Contains(out, set.Name) || !strings.Contains(out, set.Description) {
+			t.Errorf("Expected %s in the list, got %q", set.Name, out)
+		}
+	}
+}
+
+// TestLoad はフィクスチャセットの投入のテストです。
+// 期待動作:
+//   - --user-id を省略した場合は <set>@example.com のユーザーを作成して投入する
+//   - --user-id を指定した場合は既存のユーザーに投入する
+//   - --harvests で loadtest の件数を指定する
+func TestLoad(t *testing.T) {
+	// Arrange
+	app := newTestApp("development")
+	ctx := context.Background()
+
+	// Act
+	demoOut, _, demoErr := execute(app, "load", "demo")
+	user, userErr := app.svc.GetUserByEmail(ctx, "demo@example.com")
+	if userErr != nil {
+		t.Fatalf("Demo user not created: %v (%s, %v)", userErr, demoOut, demoErr)
+	}
+	loadOut, _, loadErr := execute(app, "load", "loadtest", "--user-id", fmt.Sprint(user.ID), "--harvests", "600")
+
+	// Assert
+	if demoErr != nil || !strings.Contains(demoOut, "3 crops") {
+		t.Errorf("load demo failed: %v (%s)", demoErr, demoOut)
+	}
+	if loadErr != nil || !strings.Contains(loadOut, "600 harvests") || !strings.Contains(loadOut, "demo@example.com") {
+		t.Errorf("load loadtest failed: %v (%s)", loadErr, loadOut)
+	}
+	crops, _ := app.svc.GetUserCrops(ctx, user.ID)
+	if len(crops) != 5 {
+		t.Errorf("Expected 3 demo and 2 load test crops, got %d", len(crops))
+	}
+}
+
+// TestLoad_Errors は投入できない場合のテストです。
+// 期待動作:
+//   - 登録されていないセットはデータベースに接続せずに ErrUnknownSet
+//   - 本番環境は --allow-production を指定しない場合は投入しない
+//   - 存在しないユーザー・短いパスワードはエラー
+func TestLoad_Errors(t *testing.T) {
+	// Arrange
+	production := newTestApp(productionEnv)
+
+	// Act
+	_, unknownConnected, unknownErr := execute(newTestApp("development"), "load", "missing")
+	_, _, productionErr := execute(production, "load", "demo")
+	_, _, allowedErr := execute(production, "load", "demo", "--allow-production")
+	_, _, userErr := execute(newTestApp("development"), "load", "demo", "--user-id", "999")
+	_, _, passwordErr := execute(newTestApp("development"), "load", "demo", "--password", "short")
+
+	// Assert
+	if !errors.Is(unknownErr, seed.ErrUnknownSet) || unknownConnected {
+		t.Errorf("Expected ErrUnknownSet without connecting, got %v (connected=%v)", unknownErr, unknownConnected)
+	}
+	if !errors.Is(productionErr, errProductionSeed) {
+		t.Errorf("Expected errProductionSeed, got %v", productionErr)
+	}
+	if allowedErr != nil {
+		t.Errorf("Expected --allow-production to seed, got %v", allowedErr)
+	}
+	if userErr == nil || passwordErr == nil {
+		t.Errorf("Expected errors for a missing user and a short password, got %v / %v", userErr, passwordErr)
+	}
+}
@@ -2,29 +2,52 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // ユーザーごとのタイムゾーン計算用（alpineイメージにはzoneinfoがないため埋め込む）
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
 	"github.com/secure-scorecard/backend/internal/config"
 	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/errorreport"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/events"
+	"github.com/secure-scorecard/backend/internal/grpcapi"
 	"github.com/secure-scorecard/backend/internal/handler"
 	"github.com/secure-scorecard/backend/internal/middleware"
+	"github.com/secure-scorecard/backend/internal/queue"
+	"github.com/secure-scorecard/backend/internal/realtime"
 	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/scheduler"
 	"github.com/secure-scorecard/backend/internal/service"
 	"github.com/secure-scorecard/backend/internal/storage"
+	"github.com/secure-scorecard/backend/internal/tracing"
 	"github.com/secure-scorecard/backend/internal/validator"
+	"github.com/secure-scorecard/backend/internal/worker"
+	"google.golang.org/grpc"
+)
+
+const (
+	// backgroundWorkers is the number of workers in the background worker pool
+	backgroundWorkers = 4
+	// backgroundQueueSize is the number of background jobs that can wait for a worker
+	backgroundQueueSize = 100
 )
 
 func main() {
+	// --standalone serves the full API from in-memory repositories with demo data (no PostgreSQL)
+	standalone := flag.Bool("standalone", false, "serve the API from in-memory repositories with demo data instead of PostgreSQL")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -34,12 +57,27 @@ func main() {
 	// Setup structured logging
 	setupLogging(cfg)
 
+	// Setup distributed tracing (OTLP export when OTEL_EXPORTER_OTLP_ENDPOINT is set, spans are flushed on shutdown)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Server.Env)
+	if err != nil {
+		log.Printf("Warning: Tracing initialization failed, spans will not be exported: %v", err)
+		shutdownTracing = func(context.Context) error { return nil }
+	} else if cfg.Tracing.OTLPEndpoint != "" {
+		log.Printf("Tracing enabled (endpoint: %s, protocol: %s, sample ratio: %g)", cfg.Tracing.OTLPEndpoint, cfg.Tracing.OTLPProtocol, cfg.Tracing.SampleRatio)
+	}
+
 	// Initialize Echo
 	e := echo.New()
 	e.HideBanner = true
 
-	// Set custom error handler
-	e.HTTPErrorHandler = apperrors.ErrorHandler
+	// Set custom error handler (5xx errors and panics are sent to ERROR_REPORT_PROVIDER when configured)
+	errorReporter, err := errorreport.New(cfg.ErrorReport, cfg.Server.Env)
+	if err != nil {
+		log.Printf("Warning: Error reporting initialization failed, errors will not be reported: %v", err)
+	} else if errorReporter != nil {
+		log.Printf("Error reporting enabled (provider: %s, sample rate: %g, panic sample rate: %g)", errorReporter.Provider(), cfg.ErrorReport.SampleRate, cfg.ErrorReport.PanicSampleRate)
+	}
+	e.HTTPErrorHandler = apperrors.NewErrorHandler(errorReporter)
 
 	// Set custom validator
 	e.Validator = validator.NewValidator()
@@ -54,86 +92,299 @@ func main() {
 		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 	})
 
-	// Initialize database
-	db, err := database.Connect(cfg, nil)
-	if err != nil {
+	// API docs (OpenAPI spec and Swagger UI) are only served in development
+	if cfg.Server.Env == "development" {
+		handler.RegisterDocsRoutes(e)
+	}
+
+	// Start accepting requests before connecting to the database (/health and /health/ready only until startup completes)
+	ready := newReadiness(e)
+	server := &http.Server{Addr: fmt.Sprintf(":%s", cfg.Server.Port), Handler: ready, ErrorLog: e.StdLogger}
+	go func() {
+		log.Printf("Starting server on %s (env: %s)", server.Addr, cfg.Server.Env)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Embedded scheduler (self-hosted deployments without EventBridge)
+	var embeddedScheduler *scheduler.Scheduler
+	// Background worker pool (welcome emails, expired token cleanup)
+	var workerPool *worker.Pool
+	// Job queue for heavy work triggered by API calls (export generation)
+	var jobQueue queue.Queue
+	// Event bus for live updates (Server-Sent Events)
+	var eventBus *events.Bus
+	var roomHub *realtime.Hub
+	// gRPC API for internal services and the CLI (enabled by GRPC_PORT)
+	var grpcServer *grpc.Server
+	// File storage for images and exports (pending CDN invalidations are flushed on shutdown)
+	var fileStorage *storage.Service
+
+	// Initialize repositories (PostgreSQL, or in-memory repositories in standalone mode)
+	var db *database.DB
+	var repos repository.Repositories
+	// Serializes access to the in-memory repositories (nil when using the database)
+	var lock *standaloneLock
+	if *standalone {
+		log.Println("Running in standalone mode with in-memory repositories (data is lost on exit)")
+		repos = repository.NewMockRepositories()
+		lock = &standaloneLock{}
+		e.Use(lock.middleware())
+		ready.set(readinessCheckDatabase, readinessSkipped)
+		ready.set(readinessCheckMigrations, readinessSkipped)
+	} else if db, err = database.ConnectWithRetry(context.Background(), cfg, nil); err != nil {
+		// /health/ready stays 503 so orchestrators do not route traffic to this instance
 		log.Printf("Warning: Database connection failed: %v", err)
-		log.Println("Running in standalone mode without database")
-		setupStandaloneRoutes(e)
+		log.Println("Running without database (use --standalone to serve the API from in-memory repositories)")
+		ready.set(readinessCheckDatabase, "unavailable: "+err.Error())
+		setupNoDatabaseRoutes(e)
 	} else {
 		defer db.Close()
+		ready.set(readinessCheckDatabase, readinessOK)
 
 		// Run full database setup (migrations, indexes, constraints, materialized views)
 		if err := db.Setup(); err != nil {
 			log.Printf("Warning: Database setup failed: %v", err)
+			ready.set(readinessCheckMigrations, "failed: "+err.Error())
+		} else {
+			ready.set(readinessCheckMigrations, readinessOK)
 		}
+		repos = repository.NewRepositoryManager(db.DB)
+	}
 
+	if repos != nil {
 		// Initialize JWT manager
 		jwtManager := auth.NewJWTManager(cfg.JWT.Secret, cfg.JWT.ExpireHour)
 
-		// Initialize S3 service (optional - can run without S3)
-		s3Config := &storage.S3Config{
-			Region:          cfg.S3.Region,
-			BucketName:      cfg.S3.BucketName,
-			AccessKeyID:     cfg.S3.AccessKeyID,
-			SecretAccessKey: cfg.S3.SecretAccessKey,
-			CloudFrontURL:   cfg.S3.CloudFrontURL,
-			Endpoint:        cfg.S3.Endpoint,
-		}
-		s3Svc, err := storage.NewS3Service(s3Config)
+		// Initialize file storage (optional - can run without storage)
+		// STORAGE_BACKEND: s3（デフォルト）, local, gcs
+		blobs, err := storage.NewBlobStorage(context.Background(), cfg.Storage, cfg.S3)
 		if err != nil {
-			log.Printf("Warning: S3 service initialization failed: %v", err)
+			log.Printf("Warning: File storage initialization failed: %v", err)
 			log.Println("Image upload functionality will be unavailable")
-			s3Svc = nil
-		} else if !s3Config.IsConfigured() {
-			log.Println("S3 not configured - image upload functionality will be unavailable")
+		} else {
+			fileStorage = storage.NewService(blobs)
+			if blobs == nil {
+				log.Println("File storage not configured - image upload functionality will be unavailable")
+			} else {
+				log.Printf("File storage initialized (backend: %s)", cfg.Storage.Backend)
+			}
 		}
 
-		// Initialize layers with new repository manager
-		repos := repository.NewRepositoryManager(db.DB)
+		// Initialize layers
 		svc := service.NewService(repos)
-		h := handler.NewHandler(svc, jwtManager, s3Svc)
+		svc.SetPushRateLimit(service.PushRateLimit{
+			PerHour: cfg.Notification.PushLimitPerHour,
+			PerDay:  cfg.Notification.PushLimitPerDay,
+		})
+		svc.SetReminderWorkers(cfg.Notification.ReminderWorkers)
+		svc.SetSchedulerPageSize(cfg.Notification.SchedulerPageSize)
+		svc.SetRetentionPolicy(service.RetentionPolicy{
+			Days:   cfg.Retention.Days,
+			DryRun: cfg.Retention.DryRun,
+		})
+		svc.SetOrganizationQuotaDefaults(service.OrganizationQuotas{
+			MaxPlots:   cfg.Organization.DefaultMaxPlots,
+			MaxCrops:   cfg.Organization.DefaultMaxCrops,
+			MaxMembers: cfg.Organization.DefaultMaxMembers,
+		})
+		svc.SetStorageQuota(cfg.Storage.UserQuotaBytes)
+		svc.SetExportPartSize(cfg.Storage.ExportPartSizeBytes)
+
+		// Publish entity-change events to connected SSE clients (closed on shutdown)
+		eventBus = events.NewBus(events.DefaultBufferSize, events.DefaultHistorySize)
+		svc.SetEventBus(eventBus)
+		// Broadcast plot layout and task changes to shared garden rooms over WebSocket
+		roomHub = realtime.NewHub(realtime.DefaultBufferSize)
+		svc.SetRoomHub(roomHub)
+
+		// Start background worker pool (drained on shutdown)
+		workerPool = worker.NewPool(context.Background(), backgroundWorkers, backgroundQueueSize)
+		svc.SetBackgroundRunner(lock.runner(workerPool))
+		// The embedded scheduler runs the same cleanup when its job is enabled
+		tokenCleanupJob, ok := cfg.Scheduler.Jobs[config.SchedulerJobTokenBlacklistCleanup]
+		if cfg.Scheduler.EmbeddedEnabled && (!ok || tokenCleanupJob.Enabled) {
+			log.Println("Expired token cleanup is run by the embedded scheduler")
+		} else if err := workerPool.Every(config.SchedulerJobTokenBlacklistCleanup, 24*time.Hour, lock.wrap(func(ctx context.Context) error {
+			if err := svc.CleanupExpiredTokens(ctx); err != nil {
+				return err
+			}
+			slog.Info("Expired tokens cleaned up successfully")
+			return nil
+		})); err != nil {
+			log.Printf("Warning: Failed to start token cleanup job: %v", err)
+		}
+
+		// Start job queue (in-process or SQS, drained on shutdown)
+		jobs := queue.NewMux()
+		jobs.Handle(service.JobTypeExportGenerate, func(ctx context.Context, job queue.Job) error {
+			var payload service.ExportJobPayload
+			if err := job.Decode(&payload); err != nil {
+				return err
+			}
+			return svc.ProcessExportJob(ctx, payload, job.Attempt >= queue.DefaultMaxAttempts)
+		})
+		jobs.Handle(service.JobTypeDatabaseBackup, func(ctx context.Context, job queue.Job) error {
+			var payload service.DatabaseBackupJobPayload
+			if err := job.Decode(&payload); err != nil {
+				return err
+			}
+			return svc.ProcessDatabaseBackupJob(ctx, payload, job.Attempt >= queue.DefaultMaxAttempts)
+		})
+		jobs.Handle(service.JobTypePhotoProcess, func(ctx context.Context, job queue.Job) error {
+			var payload service.PhotoProcessJobPayload
+			if err := job.Decode(&payload); err != nil {
+				return err
+			}
+			return svc.ProcessPhotoJob(ctx, payload, job.Attempt >= queue.DefaultMaxAttempts)
+		})
+		jobQueue, err = queue.New(context.Background(), cfg.Queue, lock.process(jobs.Process))
+		if err != nil {
+			log.Printf("Warning: Job queue initialization failed: %v", err)
+			log.Println("Asynchronous exports will be unavailable")
+		} else {
+			jobQueue.Start()
+			svc.SetJobQueue(jobQueue)
+			log.Printf("Job queue started (backend: %s)", cfg.Queue.Backend)
+		}
+		if fileStorage != nil {
+			svc.SetExportStorage(fileStorage)
+			svc.SetPhotoStorage(fileStorage)
+			svc.SetAttachmentStorage(fileStorage)
+			if db != nil {
+				svc.SetDatabaseBackup(db, fileStorage)
+			}
+		}
+
+		// Invalidate the CDN cache of replaced and deleted photos (CLOUDFRONT_URL), one batch per interval
+		if fileStorage.IsConfigured() {
+			invalidator, err := storage.NewCDNInvalidator(context.Background(), cfg.Storage, cfg.S3)
+			if err != nil {
+				log.Printf("Warning: CDN invalidation initialization failed: %v", err)
+			} else if invalidator != nil {
+				fileStorage.SetCDNInvalidator(invalidator, cfg.S3.CloudFrontInvalidationBatchSize)
+				interval := time.Duration(cfg.S3.CloudFrontInvalidationIntervalMs) * time.Millisecond
+				if interval <= 0 {
+					interval = storage.DefaultCDNInvalidationInterval
+				}
+				if err := workerPool.Every("cdn_invalidation", interval, fileStorage.FlushCacheInvalidations); err != nil {
+					log.Printf("Warning: Failed to start CDN invalidation job: %v", err)
+				}
+			}
+		}
+
+		// Sign photo URLs with the CloudFront key pair (CLOUDFRONT_KEY_PAIR_ID), otherwise with the storage backend
+		if fileStorage.IsConfigured() {
+			signer, err := storage.NewCloudFrontURLSigner(cfg.Storage, cfg.S3)
+			if err != nil {
+				log.Printf("Warning: CloudFront URL signing initialization failed, using storage presigned URLs: %v", err)
+			} else if signer != nil {
+				fileStorage.SetURLSigner(signer)
+			}
+		}
+
+		h := handler.NewHandler(svc, jwtManager, fileStorage)
+		h.SetWebSocketOriginPatterns(cfg.CORS.AllowedOrigins)
+		h.SetBodyLimits(handler.BodyLimits{
+			Default: cfg.BodyLimit.DefaultBytes,
+			Batch:   cfg.BodyLimit.BatchBytes,
+			Upload:  cfg.BodyLimit.UploadBytes,
+		})
+		if db != nil {
+			h.SetDatabaseMonitor(db)
+		}
 
 		// Register routes
 		h.RegisterRoutes(e)
 
+		// Start gRPC API alongside REST (optional, for internal consumers)
+		if cfg.GRPC.Port != "" {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+			if err != nil {
+				log.Fatalf("Failed to listen for gRPC: %v", err)
+			}
+			grpcServer = grpcapi.NewServer(svc, jwtManager)
+			go func() {
+				log.Printf("Starting gRPC server on %s", listener.Addr())
+				if err := grpcServer.Serve(listener); err != nil {
+					log.Printf("Warning: gRPC server stopped: %v", err)
+				}
+			}()
+		}
+
 		// Initialize notification sender and event handler (optional)
 		var notificationEventHandler service.NotificationEventHandler
-		notificationSender, err := service.NewNotificationSender(&cfg.Notification)
+		notificationSender, err := service.NewNotificationSender(&cfg.Notification, repos.DeviceToken())
 		if err != nil {
 			log.Printf("Warning: Notification sender initialization failed: %v", err)
 			log.Println("Notifications will not be sent (scheduler will still process events)")
 		} else {
 			notificationEventHandler = service.NewNotificationEventHandler(svc, notificationSender, repos)
-			log.Println("Notification sender initialized successfully")
+			svc.SetNotificationSender(notificationSender)
+			log.Printf("Notification sender initialized successfully (push provider: %s)", cfg.Notification.PushProvider)
 		}
 
 		// Register scheduler routes (for EventBridge Scheduler)
 		h.RegisterSchedulerRoutes(e, cfg.Scheduler.AuthToken, notificationEventHandler)
 
-		// Add database health check endpoint
-		e.GET("/health/db", func(c echo.Context) error {
-			if err := db.HealthCheck(); err != nil {
-				return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
-					"status": "unhealthy",
-					"error":  err.Error(),
-				})
+		// Start embedded scheduler (optional, runs the same jobs as the scheduler routes)
+		if cfg.Scheduler.EmbeddedEnabled && lock != nil {
+			log.Println("Embedded scheduler is disabled in standalone mode (call the scheduler routes instead)")
+		} else if cfg.Scheduler.EmbeddedEnabled {
+			embeddedScheduler, err = scheduler.NewFromConfig(cfg.Scheduler, svc, notificationEventHandler)
+			if err != nil {
+				log.Fatalf("Failed to initialize embedded scheduler: %v", err)
+			}
+			embeddedScheduler.Start(context.Background())
+			for _, job := range embeddedScheduler.Jobs() {
+				log.Printf("Embedded scheduler job %s (%s), next run at %s", job.Name, job.Schedule, job.NextRun.Format(time.RFC3339))
 			}
-			return c.JSON(http.StatusOK, map[string]interface{}{
-				"status": "healthy",
-				"stats":  db.Stats(),
+		}
+
+		// Register Prometheus metrics endpoint (connection pool stats and slow queries with a database)
+		h.RegisterMetricsRoutes(e, cfg.Metrics.AuthToken)
+
+		// Add database health check endpoint (per connection: primary and the optional read replica)
+		// レプリカのみ unhealthy の場合は degraded（分析・エクスポート以外の API は利用できるため 200）
+		if db != nil {
+			e.GET("/health/db", func(c echo.Context) error {
+				status, code := "healthy", http.StatusOK
+				connections := map[string]string{}
+				for name, err := range db.ConnectionHealth() {
+					connections[name] = "healthy"
+					if err == nil {
+						continue
+					}
+					connections[name] = "unhealthy: " + err.Error()
+					if name == "primary" {
+						status, code = "unhealthy", http.StatusServiceUnavailable
+					} else if code == http.StatusOK {
+						status = "degraded"
+					}
+				}
+				return c.JSON(code, map[string]interface{}{
+					"status":      status,
+					"connections": connections,
+					"stats":       db.Stats(),
+				})
 			})
-		})
-	}
+		}
 
-	// Start server with graceful shutdown
-	go func() {
-		addr := fmt.Sprintf(":%s", cfg.Server.Port)
-		log.Printf("Starting server on %s (env: %s)", addr, cfg.Server.Env)
-		if err := e.Start(addr); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+		// Create the demo user and records (before the server starts accepting requests)
+		if lock != nil {
+			if err := lock.wrap(func(ctx context.Context) error { return seedStandaloneData(ctx, svc) })(context.Background()); err != nil {
+				log.Fatalf("Failed to create demo data: %v", err)
+			}
+			log.Printf("Demo user: %s / %s", standaloneDemoEmail, standaloneDemoPassword)
 		}
-	}()
+	}
+
+	// Serve all routes from now on (registered above, before the router is used concurrently)
+	ready.markStarted()
+	if ok, _ := ready.status(); ok {
+		log.Println("Server is ready")
+	}
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -145,9 +396,60 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := e.Shutdown(ctx); err != nil {
+	// Close SSE streams first, otherwise Shutdown waits for them until the timeout
+	if eventBus != nil {
+		eventBus.Close()
+	}
+	// WebSocket connections are hijacked and not tracked by Shutdown, so close them explicitly
+	if roomHub != nil {
+		roomHub.Close()
+	}
+	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			log.Println("Warning: gRPC requests did not finish before shutdown")
+			grpcServer.Stop()
+		}
+	}
+	if embeddedScheduler != nil {
+		if err := embeddedScheduler.Stop(ctx); err != nil {
+			log.Printf("Warning: Embedded scheduler jobs did not finish before shutdown: %v", err)
+		}
+	}
+	if jobQueue != nil {
+		if err := jobQueue.Shutdown(ctx); err != nil {
+			log.Printf("Warning: Queued jobs did not finish before shutdown: %v", err)
+		}
+	}
+	if workerPool != nil {
+		if err := workerPool.Shutdown(ctx); err != nil {
+			log.Printf("Warning: Background jobs did not finish before shutdown: %v", err)
+		}
+	}
+	// Photos replaced or deleted since the last batch would otherwise stay cached on the CDN
+	if err := fileStorage.FlushCacheInvalidations(ctx); err != nil {
+		log.Printf("Warning: Failed to flush CDN invalidations: %v", err)
+	}
+	if pending := fileStorage.PendingCacheInvalidations(); pending > 0 {
+		log.Printf("Warning: %d CDN invalidations were not sent before shutdown", pending)
+	}
+	// Export the spans of the requests and jobs finished above
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Warning: Failed to flush trace spans: %v", err)
+	}
+	// Send the error reports of the requests finished above
+	if err := errorReporter.Close(ctx); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	log.Println("Server exited gracefully")
 }
@@ -175,37 +477,13 @@ func setupLogging(cfg *config.Config) {
 	slog.Info("Logging initialized", "env", cfg.Server.Env, "level", level.String())
 }
 
-// startTokenCleanupJob starts a background job to clean up expired tokens
-func startTokenCleanupJob(svc *service.Service) {
-	ticker := time.NewTicker(24 * time.Hour) // Run daily
-	defer ticker.Stop()
-
-	// Run immediately on startup
-	cleanupExpiredTokens(svc)
-
-	// Then run daily
-	for range ticker.C {
-		cleanupExpiredTokens(svc)
-	}
-}
-
-// cleanupExpiredTokens removes expired tokens from the blacklist
-func cleanupExpiredTokens(svc *service.Service) {
-	ctx := context.Background()
-	if err := svc.CleanupExpiredTokens(ctx); err != nil {
-		slog.Error("Failed to cleanup expired tokens", "error", err)
-	} else {
-		slog.Info("Expired tokens cleaned up successfully")
-	}
-}
-
-// setupStandaloneRoutes sets up routes for standalone mode (without database).
+// setupNoDatabaseRoutes sets up routes when the database connection failed.
 // /health は main で常時登録しているのでここでは登録しない。
-func setupStandaloneRoutes(e *echo.Echo) {
+func setupNoDatabaseRoutes(e *echo.Echo) {
 	e.GET("/", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{
 			"message": "Welcome to Home Garden Management API",
-			"mode":    "standalone",
+			"mode":    "no_database",
 		})
 	})
 }
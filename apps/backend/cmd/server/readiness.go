@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// =============================================================================
+// Readiness - 起動の状態と /health/ready
+// =============================================================================
+// サーバーはデータベースへの接続（再試行を含む）より前にリクエストの受け付けを始め、
+// 起動の処理が終わるまでは /health（常に 200）と /health/ready（503）のみに応答します。
+// /health/ready はデータベースの接続とマイグレーションが完了するまで 503 を返すため、
+// docker-compose・Kubernetes 等はこのエンドポイントでトラフィックを流す時点を判定できます。
+
+// 起動の状態の確認項目
+const (
+	readinessCheckDatabase   = "database"
+	readinessCheckMigrations = "migrations"
+)
+
+// 確認項目の状態（失敗した場合はエラーのメッセージ）
+const (
+	readinessPending = "pending"
+	readinessOK      = "ok"
+	readinessSkipped = "skipped" // --standalone（データベースを使用しない）
+)
+
+// readiness は起動の状態を保持し、起動が終わるまでのリクエストに応答します。
+// 起動が終わった後（markStarted）は /health/ready 以外のリクエストを next に渡します。
+type readiness struct {
+	next http.Handler
+
+	mu      sync.RWMutex
+	started bool              // ルートの登録・デモデータの作成が終わったか
+	checks  map[string]string // 確認項目ごとの状態
+}
+
+// newReadiness は全ての確認項目が pending の起動の状態を作成します。
+func newReadiness(next http.Handler) *readiness {
+	return &readiness{
+		next: next,
+		checks: map[string]string{
+			readinessCheckDatabase:   readinessPending,
+			readinessCheckMigrations: readinessPending,
+		},
+	}
+}
+
+// set は確認項目の状態を設定します。
+func (r *readiness) set(check, status string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[check] = status
+}
+
+// markStarted は起動の処理が終わったことを記録します（以降のリクエストは next で処理する）。
+// ルートの登録が終わってから呼び出してください。
+func (r *readiness) markStarted() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = true
+}
+
+// status は起動が終わり、全ての確認項目が ok（または skipped）かと確認項目ごとの状態を返します。
+func (r *readiness) status() (bool, map[string]string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ready := r.started
+	checks := make(map[string]string, len(r.checks))
+	for name, status := range r.checks {
+		checks[name] = status
+		if status != readinessOK && status != readinessSkipped {
+			ready = false
+		}
+	}
+	return ready, checks
+}
+
+// ServeHTTP は /health/ready と起動中のリクエストに応答し、起動が終わった後は next に渡します。
+func (r *readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/health/ready" {
+		ready, checks := r.status()
+		status, code := "ready", http.StatusOK
+		if !ready {
+			status, code = "not_ready", http.StatusServiceUnavailable
+		}
+		writeReadinessJSON(w, code, map[string]interface{}{"status": status, "checks": checks})
+		return
+	}
+
+	r.mu.RLock()
+	started := r.started
+	r.mu.RUnlock()
+	if started {
+		r.next.ServeHTTP(w, req)
+		return
+	}
+
+	// 起動中: /health は常に 200（PaaS のヘルスチェック）、それ以外は 503
+	if req.URL.Path == "/health" {
+		writeReadinessJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		return
+	}
+	w.Header().Set("Retry-After", "5")
+	writeReadinessJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "starting"})
+}
+
+// writeReadinessJSON は JSON のレスポンスを書き込みます。
+func writeReadinessJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Readiness Tests - 起動の状態と /health/ready のテスト
+// =============================================================================
+// テスト対象:
+//   - readiness.ServeHTTP: 起動中の /health・/health/ready・それ以外のリクエスト、起動後の next への受け渡し
+
+// TestReadiness は起動の状態のテストです。
+// 期待動作:
+//   - 起動中は /health が 200、/health/ready とそれ以外のリクエストが 503
+//   - データベースとマイグレーションが ok でも、起動が終わるまでは /health/ready が 503
+//   - 起動後は /health/ready が 200、それ以外のリクエストは next で処理する
+//   - マイグレーションが失敗した場合は起動後も /health/ready が 503
+func TestReadiness(t *testing.T) {
+	// Arrange
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	serve := func(r *readiness, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	ready := newReadiness(next)
+	failed := newReadiness(next)
+
+	// Act & Assert: 起動中
+	if code := serve(ready, "/health").Code; code != http.StatusOK {
+		t.Errorf("Expected /health 200 while starting, got %d", code)
+	}
+	if rec := serve(ready, "/api/v1/crops"); rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 503 with Retry-After while starting, got %d", rec.Code)
+	}
+	ready.set(readinessCheckDatabase, readinessOK)
+	ready.set(readinessCheckMigrations, readinessOK)
+	if rec := serve(ready, "/health/ready"); rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"not_ready"`) {
+		t.Errorf("Expected /health/ready 503 before startup completes, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Act & Assert: 起動後
+	ready.markStarted()
+	if rec := serve(ready, "/health/ready"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"database":"ok"`) {
+		t.Errorf("Expected /health/ready 200 after startup, got %d %s", rec.Code, rec.Body.String())
+	}
+	if code := serve(ready, "/api/v1/crops").Code; code != http.StatusTeapot {
+		t.Errorf("Expected requests to be passed to next after startup, got %d", code)
+	}
+
+	// Act & Assert: マイグレーションの失敗
+	failed.set(readinessCheckDatabase, readinessOK)
+	failed.set(readinessCheckMigrations, "failed: dirty database version 12")
+	failed.markStarted()
+	if rec := serve(failed, "/health/ready"); rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "dirty database") {
+		t.Errorf("Expected /health/ready 503 with the migration error, got %d %s", rec.Code, rec.Body.String())
+	}
+}
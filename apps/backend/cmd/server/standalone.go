@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/queue"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Standalone mode - in-memory demo server (--standalone)
+// =============================================================================
+// --standalone はPostgreSQLの代わりにインメモリのリポジトリ（repository.MockRepositories）でAPI全体を提供します。
+// フロントエンドのローカル開発やデモのため、起動時にデモユーザーと作物・区画・タスク・収穫記録を作成します。
+// データはプロセスの終了で消えます。
+//
+// インメモリのリポジトリは並行アクセスに対応していないため、リクエスト・バックグラウンドジョブ・キューのジョブを
+// standaloneLock で1つずつ実行します。ストリーミングのエンドポイント（SSE・WebSocket）は接続中ロックを保持しないよう対象外です。
+// バッチリクエストのサブリクエストは同じルーターで実行されるため、バッチリクエストが保持しているロックをそのまま使います。
+
+const (
+	// standaloneDemoEmail はデモユーザーのメールアドレスです。
+	standaloneDemoEmail = "demo@example.com"
+	// standaloneDemoPassword はデモユーザーのパスワードです。
+	standaloneDemoPassword = "demo-password"
+)
+
+// standaloneUnlockedPaths は接続中ロックを保持しないストリーミングのルートです。
+var standaloneUnlockedPaths = map[string]bool{
+	"/api/v1/events": true,
+	"/api/v1/ws":     true,
+}
+
+// standaloneLockKey はロックを保持しているリクエストのコンテキストのキーです。
+type standaloneLockKey struct{}
+
+// standaloneLock はインメモリのリポジトリへのアクセスを直列化します。
+// nil の場合（データベースを使用する場合）は各メソッドは何もせずに元の処理を返します。
+type standaloneLock struct {
+	mu sync.Mutex
+}
+
+// middleware はリクエストを1つずつ処理するミドルウェアを返します。
+// ロックを保持しているリクエストのサブリクエスト（バッチリクエスト）はロックを取得せずに処理します
+// （sync.Mutex は再入できないため、取得するとデッドロックする）。
+func (l *standaloneLock) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			if standaloneUnlockedPaths[c.Path()] || ctx.Value(standaloneLockKey{}) == l {
+				return next(c)
+			}
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			c.SetRequest(c.Request().WithContext(context.WithValue(ctx, standaloneLockKey{}, l)))
+			return next(c)
+		}
+	}
+}
+
+// wrap はロックを取得して実行するジョブを返します。
+func (l *standaloneLock) wrap(run func(ctx context.Context) error) func(ctx context.Context) error {
+	if l == nil {
+		return run
+	}
+	return func(ctx context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return run(ctx)
+	}
+}
+
+// process はロックを取得して実行するキューのジョブの処理を返します。
+func (l *standaloneLock) process(process func(ctx context.Context, job queue.Job) error) func(ctx context.Context, job queue.Job) error {
+	if l == nil {
+		return process
+	}
+	return func(ctx context.Context, job queue.Job) error {
+		return l.wrap(func(ctx context.Context) error { return process(ctx, job) })(ctx)
+	}
+}
+
+// runner はロックを取得してジョブを実行するワーカープールを返します。
+func (l *standaloneLock) runner(runner service.BackgroundRunner) service.BackgroundRunner {
+	if l == nil {
+		return runner
+	}
+	return lockedRunner{lock: l, runner: runner}
+}
+
+// lockedRunner はジョブをロックを取得して実行するワーカープールです。
+type lockedRunner struct {
+	lock   *standaloneLock
+	runner service.BackgroundRunner
+}
+
+// Submit はロックを取得して実行するジョブをワーカープールに登録します。
+func (r lockedRunner) Submit(name string, run func(ctx context.Context) error) error {
+	return r.runner.Submit(name, r.lock.wrap(run))
+}
+
+// seedStandaloneData はデモユーザーと、作物・区画・タスク・収穫記録・成長記録のデモデータを作成します。
+// サービスを経由して作成するため、APIで作成した記録と同じ状態になります。
+func seedStandaloneData(ctx context.Context, svc *service.Service) error {
+	hashedPassword, err := auth.HashPassword(standaloneDemoPassword)
+	if err != nil {
+		return err
+	}
+	user, err := svc.RegisterUser(ctx, standaloneDemoEmail, hashedPassword, "デモユーザー")
+	if err != nil {
+		return fmt.Errorf("failed to create demo user: %w", err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	plot := &model.Plot{UserID: user.ID, Name: "A区画", Width: 2, Height: 3, SoilType: "loamy", Sunlight: "full_sun", Status: "available"}
+	if err := svc.CreatePlot(ctx, plot); err != nil {
+		return fmt.Errorf("failed to create demo plot: %w", err)
+	}
+	if err := svc.CreatePlot(ctx, &model.Plot{UserID: user.ID, Name: "B区画", Width: 1, Height: 2, Status: "available"}); err != nil {
+		return fmt.Errorf("failed to create demo plot: %w", err)
+	}
+
+	tomato := &model.Crop{
+		UserID: user.ID, Name: "トマト", Variety: "桃太郎", Status: "growing",
+		PlantedDate: today.AddDate(0, 0, -45), ExpectedHarvestDate: today.AddDate(0, 0, 30),
+		Notes: "支柱を立てて脇芽を摘む",
+	}
+	cucumber := &model.Crop{
+		UserID: user.ID, Name: "キュウリ", Status: "ready_to_harvest",
+		PlantedDate: today.AddDate(0, 0, -60), ExpectedHarvestDate: today.AddDate(0, 0, -5),
+	}
+	for _, crop := range []*model.Crop{tomato, cucumber} {
+		if err := svc.CreateCrop(ctx, crop); err != nil {
+			return fmt.Errorf("failed to create demo crop: %w", err)
+		}
+	}
+	if _, err := svc.AssignCropToPlot(ctx, plot.ID, tomato.ID, tomato.PlantedDate); err != nil {
+		return fmt.Errorf("failed to assign demo crop: %w", err)
+	}
+	if err := svc.CreateGrowthRecord(ctx, &model.GrowthRecord{
+		CropID: tomato.ID, RecordDate: today.AddDate(0, 0, -7), GrowthStage: "flowering", Notes: "最初の花が咲いた",
+	}); err != nil {
+		return fmt.Errorf("failed to create demo growth record: %w", err)
+	}
+	for i, quantity := range []float64{0.4, 0.6, 0.5} {
+		harvest := &model.Harvest{
+			CropID: cucumber.ID, HarvestDate: today.AddDate(0, 0, -2*(3-i)),
+			Quantity: quantity, QuantityUnit: "kg", Quality: "good",
+		}
+		if err := svc.CreateHarvest(ctx, harvest); err != nil {
+			return fmt.Errorf("failed to create demo harvest: %w", err)
+		}
+	}
+
+	tasks := []*model.Task{
+		{UserID: user.ID, Title: "水やり", DueDate: today, Priority: "high", Status: "pending", Recurrence: "daily", RecurrenceInterval: 1},
+		{UserID: user.ID, Title: "トマトの追肥", DueDate: today.AddDate(0, 0, 3), Priority: "medium", Status: "pending"},
+		{UserID: user.ID, Title: "キュウリの収穫", DueDate: today.AddDate(0, 0, -1), Priority: "medium", Status: "pending"},
+	}
+	for _, task := range tasks {
+		if err := svc.CreateTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to create demo task: %w", err)
+		}
+	}
+	return nil
+}
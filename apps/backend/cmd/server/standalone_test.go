@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/handler"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Standalone Tests - --standalone のインメモリのサーバーのテスト
+// =============================================================================
+// テスト対象:
+//   - standaloneLock.middleware: リクエスト・バッチリクエスト（サブリクエスト）の直列化
+//   - seedStandaloneData: デモユーザーとデモデータ
+
+// newStandaloneServer は main と同じ構成（インメモリのリポジトリ・standaloneLock・デモデータ）の Echo を作成します。
+func newStandaloneServer(t *testing.T) *echo.Echo {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.NewErrorHandler(nil)
+	e.Validator = validator.NewValidator()
+	lock := &standaloneLock{}
+	e.Use(lock.middleware())
+	svc := service.NewService(repository.NewMockRepositories())
+	h := handler.NewHandler(svc, auth.NewJWTManager("standalone-test-secret", 1), nil)
+	h.RegisterRoutes(e)
+	if err := lock.wrap(func(ctx context.Context) error { return seedStandaloneData(ctx, svc) })(context.Background()); err != nil {
+		t.Fatalf("Failed to create demo data: %v", err)
+	}
+	return e
+}
+
+// serveWithTimeout はリクエストを実行し、デッドロックした場合はテストを失敗させます。
+func serveWithTimeout(t *testing.T, e *echo.Echo, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		e.ServeHTTP(rec, req)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s %s did not finish (deadlock)", method, path)
+	}
+	return rec
+}
+
+// TestStandalone はインメモリのサーバーへのリクエスト・バッチリクエストのテストです。
+// 期待動作:
+//   - デモユーザーでログインし、デモデータの作物を取得できる
+//   - バッチリクエストのサブリクエストはバッチリクエストのロックで実行される（デッドロックしない）
+//   - バッチリクエストの後も通常のリクエストを処理できる
+func TestStandalone(t *testing.T) {
+	// Arrange
+	e := newStandaloneServer(t)
+	login := serveWithTimeout(t, e, http.MethodPost, "/api/v1/auth/login", "",
+		`{"email":"`+standaloneDemoEmail+`","password":"`+standaloneDemoPassword+`"}`)
+	if login.Code != http.StatusOK {
+		t.Fatalf("Expected the demo user to log in, got %d %s", login.Code, login.Body.String())
+	}
+	var session handler.AuthResponse
+	if err := json.Unmarshal(login.Body.Bytes(), &session); err != nil || session.Token == "" {
+		t.Fatalf("Expected a token, got %s", login.Body.String())
+	}
+
+	// Act
+	crops := serveWithTimeout(t, e, http.MethodGet, "/api/v1/crops", session.Token, "")
+	batch := serveWithTimeout(t, e, http.MethodPost, "/api/v1/batch", session.Token,
+		`{"requests":[{"id":"crops","method":"GET","path":"/crops"},{"id":"task","method":"POST","path":"/tasks","body":{"title":"草取り","due_date":"2030-01-01T00:00:00Z","priority":"low"},"group":"sync"}]}`)
+	tasks := serveWithTimeout(t, e, http.MethodGet, "/api/v1/tasks", session.Token, "")
+
+	// Assert
+	if crops.Code != http.StatusOK || !strings.Contains(crops.Body.String(), "トマト") {
+		t.Errorf("Expected the demo crops, got %d %s", crops.Code, crops.Body.String())
+	}
+	var result handler.BatchResponse
+	if err := json.Unmarshal(batch.Body.Bytes(), &result); err != nil || batch.Code != http.StatusOK {
+		t.Fatalf("Expected the batch response, got %d %s", batch.Code, batch.Body.String())
+	}
+	if result.Succeeded != 2 || result.Responses[0].Status != http.StatusOK || result.Responses[1].Status != http.StatusCreated {
+		t.Errorf("Expected both sub-requests to succeed, got %+v", result.Responses)
+	}
+	if tasks.Code != http.StatusOK || !strings.Contains(tasks.Body.String(), "草取り") {
+		t.Errorf("Expected the task created in the batch, got %d %s", tasks.Code, tasks.Body.String())
+	}
+}
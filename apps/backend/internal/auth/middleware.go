@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/logging"
 )
 
 // TokenBlacklistChecker defines the interface for checking token blacklist
@@ -50,6 +51,7 @@ func AuthMiddleware(jwtManager *JWTManager, blacklistChecker TokenBlacklistCheck
 
 			// Store claims in context
 			c.Set(UserContextKey, claims)
+			setLogUser(c, claims.UserID)
 
 			return next(c)
 		}
@@ -66,6 +68,7 @@ func OptionalAuthMiddleware(jwtManager *JWTManager) echo.MiddlewareFunc {
 				claims, err := jwtManager.ValidateToken(token)
 				if err == nil {
 					c.Set(UserContextKey, claims)
+					setLogUser(c, claims.UserID)
 				}
 			}
 			return next(c)
@@ -73,6 +76,13 @@ func OptionalAuthMiddleware(jwtManager *JWTManager) echo.MiddlewareFunc {
 	}
 }
 
+// setLogUser adds the user ID to the request-scoped logger
+// 以降のハンドラ・サービス・リポジトリのログとアクセスログに user_id が付きます。
+func setLogUser(c echo.Context, userID uint) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(logging.WithUserID(req.Context(), userID)))
+}
+
 // extractToken extracts the JWT token from the request
 // It checks the Authorization header first, then falls back to cookies
 func extractToken(c echo.Context) string {
@@ -0,0 +1,76 @@
+// Package clock - 現在時刻の取得
+//
+// サービス層・通知送信・モックリポジトリは time.Now() を直接呼ばず、Clock から現在時刻を取得します。
+// テストでは Fake を設定して時刻を固定・進めることで、日付の境界（深夜0時・月末・おやすみモードの開始）の
+// 動作を実行する時刻によらず確認できます。
+//
+//	fake := clock.NewFake(time.Date(2026, 5, 31, 23, 59, 0, 0, time.UTC))
+//	svc.SetClock(fake)
+//	fake.Advance(2 * time.Minute) // 6月1日 0:01
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock は現在時刻を返します。
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock はシステムの時刻を返す Clock です。
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// Real はシステムの時刻（time.Now）を返す Clock を返します。
+func Real() Clock {
+	return realClock{}
+}
+
+// Now は c の現在時刻を返します（c が nil の場合はシステムの時刻）。
+// Clock を設定せずに構造体リテラルで作成した値でも、そのまま使えるようにするためのものです。
+func Now(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// =============================================================================
+// Fake - テスト用の時刻
+// =============================================================================
+
+// Fake はテスト用の Clock です。Set・Advance で変更するまで同じ時刻を返します。
+// 複数のゴルーチン（ワーカー・スケジューラー）から同時に使用できます。
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake は now を返す Fake を作成します。
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now は設定した時刻を返します。
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set は時刻を now に変更します。
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Advance は時刻を d 進めます（負の値の場合は戻します）。
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Clock Tests - 現在時刻の取得のテスト
+// =============================================================================
+// テスト対象:
+//   - Fake: 時刻の固定・変更、同時の使用
+//   - Now: nil の場合のシステムの時刻
+
+// TestFake は Fake のテストです。
+// 期待動作:
+//   - Set・Advance で変更するまで同じ時刻を返す
+//   - 複数のゴルーチンから Advance した分だけ進む
+func TestFake(t *testing.T) {
+	// Arrange
+	start := time.Date(2026, 5, 31, 23, 59, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	// Act
+	first, second := fake.Now(), Now(fake)
+	fake.Advance(2 * time.Minute)
+	advanced := fake.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fake.Advance(time.Second)
+		}()
+	}
+	wg.Wait()
+	concurrent := fake.Now()
+	fake.Set(start)
+
+	// Assert
+	if !first.Equal(start) || !second.Equal(start) {
+		t.Errorf("Expected %v, got %v / %v", start, first, second)
+	}
+	if advanced.Day() != 1 || advanced.Month() != time.June {
+		t.Errorf("Expected June 1, got %v", advanced)
+	}
+	if want := start.Add(2*time.Minute + 10*time.Second); !concurrent.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, concurrent)
+	}
+	if !fake.Now().Equal(start) {
+		t.Errorf("Expected Set to reset the time, got %v", fake.Now())
+	}
+}
+
+// TestNow_Nil は Clock が nil の場合のテストです。
+// 期待動作:
+//   - システムの時刻を返す
+func TestNow_Nil(t *testing.T) {
+	// Arrange
+	before := time.Now()
+
+	// Act
+	got := Now(nil)
+
+	// Assert
+	if got.Before(before) || got.After(time.Now()) {
+		t.Errorf("Expected the system time, got %v", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,8 +16,17 @@ type Config struct {
 	JWT          JWTConfig
 	CORS         CORSConfig
 	S3           S3Config
+	Storage      StorageConfig
 	Scheduler    SchedulerConfig
 	Notification NotificationConfig
+	Metrics      MetricsConfig
+	Queue        QueueConfig
+	Retention    RetentionConfig
+	GRPC         GRPCConfig
+	Organization OrganizationConfig
+	BodyLimit    BodyLimitConfig
+	Tracing      TracingConfig
+	ErrorReport  ErrorReportConfig
 }
 
 // NotificationConfig は通知サービスの設定を保持します
@@ -24,14 +34,38 @@ type NotificationConfig struct {
 	// AWS共通設定
 	AWSRegion string // AWSリージョン（SNS/SES用）
 
+	// プッシュ通知プロバイダー: sns（デフォルト）または fcm
+	PushProvider string
+
 	// SNS設定（プッシュ通知用）
 	SNSPlatformARNiOS     string // iOS用 SNS Platform Application ARN
 	SNSPlatformARNAndroid string // Android用 SNS Platform Application ARN
 
+	// FCM設定（PushProvider=fcm の場合、FCM HTTP v1 APIへ直接送信）
+	FCMServiceAccountFile string // サービスアカウントJSONファイルのパス
+	FCMServiceAccountJSON string // サービスアカウントJSON本体（ファイルの代わりに指定可能）
+	FCMProjectID          string // FirebaseプロジェクトID（省略時はサービスアカウントのproject_id）
+
 	// SES設定（メール通知用）
 	SESFromEmail string // SES送信元メールアドレス
 	SESFromName  string // 送信者名
 
+	// SNS SMS設定（重要なアラートのSMS通知用）
+	// SMSSenders は国番号（E.164の先頭、例: "81", "1"）ごとの送信者です。
+	// "+" で始まる値は発信元番号（OriginationNumber）、それ以外は送信者ID（SenderID）として扱います。
+	SMSSenders       map[string]string
+	SMSDefaultSender string // 国番号に一致する設定がない場合の送信者（空の場合はSNSの既定）
+
+	// プッシュ通知の送信数の上限（ユーザーごと、0の場合は無制限）
+	// 上限を超えた分はまとめて「ほかにN件の更新があります」と1件で通知します。
+	PushLimitPerHour int // 1時間あたりの上限（デフォルト: 5）
+	PushLimitPerDay  int // 1日あたりの上限（デフォルト: 20）
+
+	// ReminderWorkers はユーザーごとのリマインダージョブを並行して処理する数（デフォルト: 4）
+	ReminderWorkers int
+	// SchedulerPageSize は定期通知で1ページに処理するユーザー数（デフォルト: 500）
+	SchedulerPageSize int
+
 	// リトライ設定
 	MaxRetries       int // 最大リトライ回数（デフォルト: 3）
 	InitialBackoffMs int // 初回リトライ待機時間(ms)（デフォルト: 1000）
@@ -40,6 +74,176 @@ type NotificationConfig struct {
 // SchedulerConfig はスケジューラー関連の設定を保持します
 type SchedulerConfig struct {
 	AuthToken string // EventBridge Scheduler からの認証トークン
+
+	// 内蔵スケジューラー（EventBridge のないセルフホスト環境用）
+	// 有効にすると、定期タスクをAPIを経由せずにプロセス内で実行します。1インスタンスのみで有効にしてください。
+	EmbeddedEnabled bool                          // SCHEDULER_EMBEDDED_ENABLED（デフォルト: false）
+	Timezone        string                        // スケジュールを判定するタイムゾーン（デフォルト: UTC）
+	CatchUpEnabled  bool                          // SCHEDULER_CATCH_UP_ENABLED 起動時に停止中に過ぎた実行日時のジョブを1回実行するか（デフォルト: true）
+	Jobs            map[string]SchedulerJobConfig // ジョブ名ごとの設定（SchedulerJobNames）
+}
+
+// SchedulerJobConfig は内蔵スケジューラーのジョブごとの設定を保持します
+// 環境変数 SCHEDULER_JOB_{ジョブ名の大文字}_ENABLED / _SCHEDULE で変更できます（例: SCHEDULER_JOB_NOTIFICATIONS_SCHEDULE）。
+type SchedulerJobConfig struct {
+	Enabled  bool   // ジョブを実行するか（デフォルト: true）
+	Schedule string // cron式（分 時 日 月 曜日）または @hourly / @every 5m 等
+}
+
+// 内蔵スケジューラーのジョブ名
+const (
+	SchedulerJobNotifications          = "notifications"            // 定期通知（POST /scheduler/notifications と同じ処理）
+	SchedulerJobNotificationRetry      = "notification_retry"       // 送信に失敗した通知の再送信
+	SchedulerJobNotificationOutbox     = "notification_outbox"      // アウトボックスに残った通知イベントの送信
+	SchedulerJobAnnouncements          = "announcements"            // 管理者からのお知らせの配信
+	SchedulerJobAnalyticsRefresh       = "analytics_refresh"        // 分析用マテリアライズドビューのリフレッシュ
+	SchedulerJobDeviceTokenPrune       = "device_token_prune"       // 無効化されたデバイストークンの削除
+	SchedulerJobNotificationLogCleanup = "notification_log_cleanup" // 保持期間を過ぎた通知ログ・処理済みのアウトボックスの削除
+	SchedulerJobTokenBlacklistCleanup  = "token_blacklist_cleanup"  // 有効期限を過ぎたトークンブラックリストの削除
+	SchedulerJobIdempotencyKeyCleanup  = "idempotency_key_cleanup"  // 保持期間を過ぎたスケジューラーの冪等キー・ジョブの実行履歴の削除
+	SchedulerJobDataRetention          = "data_retention"           // 保持期間を過ぎた削除済みデータ・通知ログ・エクスポートファイルの削除
+	SchedulerJobSeasonRollover         = "season_rollover"          // 前年のシーズンの締め（作物のアーカイブ・区画ごとの記録・ふりかえりの通知）
+	SchedulerJobDatabaseBackup         = "database_backup"          // データベースの論理バックアップの作成とS3への保存（デフォルト: 無効）
+)
+
+// SchedulerJobNames は内蔵スケジューラーのジョブ名とデフォルトのスケジュールです
+var SchedulerJobNames = []struct {
+	Name     string
+	Schedule string
+}{
+	{SchedulerJobNotifications, "0 * * * *"},
+	{SchedulerJobNotificationRetry, "*/5 * * * *"},
+	{SchedulerJobNotificationOutbox, "*/5 * * * *"},
+	{SchedulerJobAnnouncements, "*/5 * * * *"},
+	{SchedulerJobAnalyticsRefresh, "0 3 * * *"},
+	{SchedulerJobDeviceTokenPrune, "30 3 * * *"},
+	{SchedulerJobNotificationLogCleanup, "0 4 * * *"},
+	{SchedulerJobTokenBlacklistCleanup, "30 4 * * *"},
+	{SchedulerJobIdempotencyKeyCleanup, "45 4 * * *"},
+	{SchedulerJobDataRetention, "0 5 * * *"},
+	{SchedulerJobSeasonRollover, "0 6 1 1 *"},
+	{SchedulerJobDatabaseBackup, "0 2 * * *"},
+}
+
+// schedulerJobsDisabledByDefault は SCHEDULER_JOB_{ジョブ名}_ENABLED=true で有効にするジョブです
+var schedulerJobsDisabledByDefault = map[string]bool{
+	SchedulerJobDatabaseBackup: true, // S3 の保存先の容量を使用するため、明示的に有効にする
+}
+
+// SchedulerJobEnabledByDefault は環境変数で指定しない場合にジョブを実行するかを返します
+func SchedulerJobEnabledByDefault(name string) bool {
+	return !schedulerJobsDisabledByDefault[name]
+}
+
+// MetricsConfig はPrometheusメトリクス（/metrics）の設定を保持します
+type MetricsConfig struct {
+	AuthToken string // スクレイプ時の Bearer トークン（空の場合は認証なし、開発環境用）
+}
+
+// GRPCConfig は社内のサービス・CLI 向けの gRPC API の設定を保持します
+type GRPCConfig struct {
+	Port string // GRPC_PORT 待ち受けるポート（空の場合は gRPC サーバーを起動しない）
+}
+
+// OrganizationConfig は組織（コミュニティガーデン・学校）の設定を保持します
+// 上限は作成した組織のデフォルトで、管理者が組織ごとに変更できます（0 の場合は無制限）。
+type OrganizationConfig struct {
+	DefaultMaxPlots   int // ORG_DEFAULT_MAX_PLOTS 区画数の上限（デフォルト: 0）
+	DefaultMaxCrops   int // ORG_DEFAULT_MAX_CROPS 作物数の上限（デフォルト: 0）
+	DefaultMaxMembers int // ORG_DEFAULT_MAX_MEMBERS メンバー数の上限（デフォルト: 0）
+}
+
+// BodyLimitConfig はリクエストボディのサイズの上限（バイト）の設定を保持します
+// 上限を超えたリクエストは 413 PAYLOAD_TOO_LARGE を返します（0 の場合は無制限）。
+type BodyLimitConfig struct {
+	DefaultBytes int64 // BODY_LIMIT_DEFAULT_BYTES JSON の API（デフォルト: 1MB）
+	BatchBytes   int64 // BODY_LIMIT_BATCH_BYTES バッチリクエスト・オフライン同期（デフォルト: 10MB）
+	UploadBytes  int64 // BODY_LIMIT_UPLOAD_BYTES 画像のアップロード（multipart/form-data 全体、デフォルト: 6MB）
+}
+
+// TracingConfig は OpenTelemetry の分散トレーシングの設定を保持します
+// 環境変数は OpenTelemetry の SDK と同じ名前です。OTLPEndpoint が空の場合はスパンを記録しません。
+type TracingConfig struct {
+	OTLPEndpoint string            // OTEL_EXPORTER_OTLP_ENDPOINT 送信先のコレクターのURL（例: http://localhost:4318、空の場合は無効）
+	OTLPProtocol string            // OTEL_EXPORTER_OTLP_PROTOCOL http/protobuf（デフォルト）または grpc
+	OTLPHeaders  map[string]string // OTEL_EXPORTER_OTLP_HEADERS 送信時のヘッダー（例: x-honeycomb-team=KEY）
+	ServiceName  string            // OTEL_SERVICE_NAME リソースのサービス名（デフォルト: home-garden-api）
+	SampleRatio  float64           // OTEL_TRACES_SAMPLER_ARG 親のないトレースを記録する割合（0〜1、デフォルト: 1）
+}
+
+// ErrorReportConfig はエラーの通知（Sentry・Rollbar）の設定を保持します
+// Provider が空の場合は通知しません。5xx のエラーとパニックをリクエストの情報・スタックトレースとともに送信します。
+type ErrorReportConfig struct {
+	Provider           string  // ERROR_REPORT_PROVIDER sentry または rollbar（空の場合は無効）
+	SentryDSN          string  // SENTRY_DSN Sentry のプロジェクトの DSN（例: https://KEY@o1.ingest.sentry.io/123）
+	RollbarAccessToken string  // ROLLBAR_ACCESS_TOKEN Rollbar のプロジェクトのアクセストークン（post_server_item）
+	Release            string  // ERROR_REPORT_RELEASE エラーのリリース（例: git のコミット、空の場合は送信しない）
+	SampleRate         float64 // ERROR_REPORT_SAMPLE_RATE 5xx のエラーを送信する割合（0〜1、デフォルト: 1）
+	PanicSampleRate    float64 // ERROR_REPORT_PANIC_SAMPLE_RATE パニックを送信する割合（0〜1、デフォルト: 1）
+}
+
+// RetentionConfig はデータの保持期間の設定を保持します
+// 保持期間は環境変数 RETENTION_{対象の大文字}_DAYS で変更できます（例: RETENTION_NOTIFICATION_LOGS_DAYS）。0 の場合は削除しません。
+type RetentionConfig struct {
+	Days   map[string]int // 対象ごとの保持期間（日数、RetentionTargets）
+	DryRun bool           // RETENTION_DRY_RUN 定期実行で削除せずに件数のみ記録するか（デフォルト: false）
+}
+
+// データの保持期間の対象
+const (
+	RetentionTargetCareLogs         = "care_logs"         // 削除済みの手入れ記録
+	RetentionTargetTasks            = "tasks"             // 削除済みのタスク
+	RetentionTargetPlants           = "plants"            // 削除済みの植物
+	RetentionTargetGardens          = "gardens"           // 削除済みの庭
+	RetentionTargetGrowthRecords    = "growth_records"    // 削除済みの成長記録
+	RetentionTargetHarvests         = "harvests"          // 削除済みの収穫記録
+	RetentionTargetPlotAssignments  = "plot_assignments"  // 削除済みの区画の割り当て
+	RetentionTargetCrops            = "crops"             // 削除済みの作物
+	RetentionTargetPlots            = "plots"             // 削除済みの区画
+	RetentionTargetNotificationLogs = "notification_logs" // 期限切れ・保持期間を過ぎた通知ログ
+	RetentionTargetExportFiles      = "export_files"      // S3に保存したエクスポートファイル（エクスポート履歴は残す）
+	RetentionTargetSyncTombstones   = "sync_tombstones"   // 同期用の削除の記録（保持期間より古いカーソルの同期は全件の再取得）
+	RetentionTargetPhotos           = "photos"            // 削除済みの写真（画像は削除時に保存先から削除）
+	RetentionTargetOrphanedPhotos   = "orphaned_photos"   // 作物・収穫記録が削除された写真と保存先の画像（保持期間は作物の削除からの猶予）
+	RetentionTargetAttachments      = "attachments"       // 削除済みの添付ファイル（ファイルは削除時に保存先から削除）
+)
+
+// RetentionTargets はデータの保持期間の対象とデフォルトの保持期間（日数）です
+// 削除済みデータは参照する側（子）から順に削除します。
+var RetentionTargets = []struct {
+	Name string
+	Days int
+}{
+	{RetentionTargetCareLogs, 30},
+	{RetentionTargetTasks, 30},
+	{RetentionTargetPlants, 30},
+	{RetentionTargetGardens, 30},
+	{RetentionTargetGrowthRecords, 30},
+	{RetentionTargetHarvests, 30},
+	{RetentionTargetPlotAssignments, 30},
+	{RetentionTargetCrops, 30},
+	{RetentionTargetPlots, 30},
+	{RetentionTargetNotificationLogs, 90},
+	{RetentionTargetExportFiles, 30},
+	{RetentionTargetSyncTombstones, 90},
+	{RetentionTargetOrphanedPhotos, 30},
+	{RetentionTargetPhotos, 30},
+	{RetentionTargetAttachments, 30},
+}
+
+// キューの実装（QUEUE_BACKEND）
+const (
+	QueueBackendMemory = "memory" // プロセス内のチャネル（デフォルト）
+	QueueBackendSQS    = "sqs"    // Amazon SQS
+)
+
+// QueueConfig は非同期ジョブのキューの設定を保持します
+type QueueConfig struct {
+	Backend     string // memory（デフォルト）または sqs
+	SQSQueueURL string // SQSのキューURL（sqs の場合は必須）
+	AWSRegion   string // AWSリージョン（SQS用）
+	Workers     int    // ジョブを処理するワーカー数
+	BufferSize  int    // 処理待ちのジョブの最大数（memory のみ）
 }
 
 // S3Config はS3/CloudFront設定を保持します
@@ -50,6 +254,45 @@ type S3Config struct {
 	SecretAccessKey string // AWSシークレットアクセスキー
 	CloudFrontURL   string // CloudFront DistributionのURL（オプション）
 	Endpoint        string // カスタムエンドポイント（LocalStack等用、オプション）
+
+	// CloudFrontDistributionID は差し替え・削除した画像のキャッシュを削除するディストリビューション
+	// （未設定の場合は CloudFrontURL のホスト名から検索する）
+	CloudFrontDistributionID string
+	// CloudFrontInvalidationIntervalMs はキャッシュの削除の依頼の間隔（依頼の頻度の上限）
+	CloudFrontInvalidationIntervalMs int
+	// CloudFrontInvalidationBatchSize は1回の依頼のパスの最大数
+	CloudFrontInvalidationBatchSize int
+	// CloudFrontKeyPairID は写真の署名付きURLの CloudFront の公開鍵のID（未設定の場合は S3 の署名付きURL）
+	CloudFrontKeyPairID string
+	// CloudFrontPrivateKeyFile は写真の署名付きURLの署名の秘密鍵（PEM）のファイルのパス
+	CloudFrontPrivateKeyFile string
+}
+
+// ファイルの保存先の実装（STORAGE_BACKEND）
+const (
+	StorageBackendS3    = "s3"    // Amazon S3（S3互換のストレージを含む、デフォルト）
+	StorageBackendLocal = "local" // サーバーのローカルファイルシステム
+	StorageBackendGCS   = "gcs"   // Google Cloud Storage
+)
+
+// StorageConfig は画像・エクスポートファイル・バックアップの保存先の設定を保持します
+type StorageConfig struct {
+	Backend string // s3（デフォルト、S3Config を使用）、local または gcs
+
+	// local の設定
+	LocalDir        string // 保存先のディレクトリ
+	LocalBaseURL    string // 署名付きURL・画像URLのAPIサーバーのURL（空の場合は http://localhost:{PORT}）
+	LocalSigningKey string // 署名付きURLの署名の鍵（空の場合は JWT_SECRET）
+
+	// gcs の設定
+	GCSBucketName      string // バケット名（gcs の場合は必須）
+	GCSCredentialsFile string // サービスアカウントのJSONファイルのパス（空の場合は Application Default Credentials）
+	GCSPublicURL       string // 画像URLのCDN等のURL（空の場合は https://storage.googleapis.com/{bucket}）
+
+	// UserQuotaBytes はユーザーごとの保存容量の上限です（写真・エクスポートファイルの合計、0 の場合は無制限）
+	UserQuotaBytes int64
+	// ExportPartSizeBytes は写真を含むエクスポートのZIPの1ファイルの写真の合計の上限です（超える場合は複数のZIPに分ける）
+	ExportPartSizeBytes int64
 }
 
 // ServerConfig holds server-specific configuration
@@ -60,6 +303,12 @@ type ServerConfig struct {
 
 // DatabaseConfig holds database-specific configuration
 type DatabaseConfig struct {
+	// Driver は接続するデータベース（DB_DRIVER: postgres（デフォルト）/ mysql / sqlite）。
+	// mysql は MySQL 8.0.16 以降・MariaDB 10.2 以降で、URL（go-sql-driver/mysql の DSN）または個別フィールドで接続する。
+	// sqlite は PostgreSQL を用意できないローカル開発・テスト用で、接続の設定は SQLitePath のみ使用する。
+	Driver string
+	// SQLitePath は SQLite のデータベースファイル（DB_SQLITE_PATH、":memory:" の場合はプロセス内のメモリ）。
+	SQLitePath string
 	// URL は DATABASE_URL（Neon等のフルコネクション文字列）。
 	// 設定されている場合はこちらが優先され、個別フィールドは無視される。
 	URL      string
@@ -69,8 +318,31 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// QueryTimeoutMs はクエリごとのタイムアウト（ミリ秒、0 でタイムアウトなし）。
+	// クライアントが切断した場合はタイムアウトより前にリクエストのコンテキストでキャンセルされる。
+	QueryTimeoutMs int
+	// MaintenanceQueryTimeoutMs はマテリアライズドビューのリフレッシュ・AutoMigrate のタイムアウト（ミリ秒、0 でタイムアウトなし）。
+	MaintenanceQueryTimeoutMs int
+	// SlowQueryThresholdMs は遅いクエリとしてログに出力する実行時間（ミリ秒、0 で記録しない）。
+	SlowQueryThresholdMs int
+	// ReadReplicaURL は分析・エクスポートの読み取りに使用するレプリカの接続文字列（DB_READ_REPLICA_URL、空の場合はプライマリのみ）。
+	ReadReplicaURL string
+	// ConnectRetries は起動時にデータベースに接続できない場合の再試行の回数（DB_CONNECT_RETRIES、0 で再試行しない）。
+	// docker-compose 等で PostgreSQL の起動がサーバーより遅い場合に、接続できるまで待機する。
+	ConnectRetries int
+	// ConnectRetryInitialMs は最初の再試行までの待機時間（ミリ秒）。再試行ごとに2倍にする。
+	ConnectRetryInitialMs int
+	// ConnectRetryMaxMs は再試行までの待機時間の上限（ミリ秒）。
+	ConnectRetryMaxMs int
 }
 
+// DatabaseConfig.Driver の値
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverMySQL    = "mysql"
+	DatabaseDriverSQLite   = "sqlite"
+)
+
 // JWTConfig holds JWT-specific configuration
 type JWTConfig struct {
 	Secret     string
@@ -95,6 +367,9 @@ func Load() (*Config, error) {
 			Env:  getEnv("APP_ENV", "development"),
 		},
 		Database: DatabaseConfig{
+			Driver:     getEnv("DB_DRIVER", DatabaseDriverPostgres),
+			SQLitePath: getEnv("DB_SQLITE_PATH", "home_garden.db"),
+
 			URL:      getEnv("DATABASE_URL", ""),
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
@@ -102,6 +377,15 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", ""),
 			DBName:   getEnv("DB_NAME", "home_garden"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			QueryTimeoutMs:            getEnvAsInt("DB_QUERY_TIMEOUT_MS", 10000),
+			MaintenanceQueryTimeoutMs: getEnvAsInt("DB_MAINTENANCE_QUERY_TIMEOUT_MS", 300000),
+			SlowQueryThresholdMs:      getEnvAsInt("DB_SLOW_QUERY_THRESHOLD_MS", 500),
+			ReadReplicaURL:            getEnv("DB_READ_REPLICA_URL", ""),
+
+			ConnectRetries:        getEnvAsInt("DB_CONNECT_RETRIES", 10),
+			ConnectRetryInitialMs: getEnvAsInt("DB_CONNECT_RETRY_INITIAL_MS", 500),
+			ConnectRetryMaxMs:     getEnvAsInt("DB_CONNECT_RETRY_MAX_MS", 10000),
 		},
 		JWT: JWTConfig{
 			Secret:     getEnv("JWT_SECRET", "dev-secret-change-in-production"),
@@ -117,19 +401,100 @@ func Load() (*Config, error) {
 			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
 			CloudFrontURL:   getEnv("CLOUDFRONT_URL", ""),
 			Endpoint:        getEnv("S3_ENDPOINT", ""), // LocalStack用
+
+			CloudFrontDistributionID:         getEnv("CLOUDFRONT_DISTRIBUTION_ID", ""),
+			CloudFrontInvalidationIntervalMs: getEnvAsInt("CLOUDFRONT_INVALIDATION_INTERVAL_MS", 60000),
+			CloudFrontInvalidationBatchSize:  getEnvAsInt("CLOUDFRONT_INVALIDATION_BATCH_SIZE", 1000),
+			CloudFrontKeyPairID:              getEnv("CLOUDFRONT_KEY_PAIR_ID", ""),
+			CloudFrontPrivateKeyFile:         getEnv("CLOUDFRONT_PRIVATE_KEY_FILE", ""),
+		},
+		Storage: StorageConfig{
+			Backend:             getEnv("STORAGE_BACKEND", StorageBackendS3),
+			LocalDir:            getEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+			LocalBaseURL:        getEnv("STORAGE_LOCAL_BASE_URL", ""),
+			LocalSigningKey:     getEnv("STORAGE_LOCAL_SIGNING_KEY", ""),
+			GCSBucketName:       getEnv("GCS_BUCKET_NAME", ""),
+			GCSCredentialsFile:  getEnv("GCS_CREDENTIALS_FILE", ""),
+			GCSPublicURL:        getEnv("GCS_PUBLIC_URL", ""),
+			UserQuotaBytes:      int64(getEnvAsInt("STORAGE_USER_QUOTA_BYTES", 1<<30)),
+			ExportPartSizeBytes: int64(getEnvAsInt("EXPORT_PART_SIZE_BYTES", 100<<20)),
 		},
 		Scheduler: SchedulerConfig{
-			AuthToken: getEnv("SCHEDULER_AUTH_TOKEN", ""), // EventBridge用認証トークン
+			AuthToken:       getEnv("SCHEDULER_AUTH_TOKEN", ""), // EventBridge用認証トークン
+			EmbeddedEnabled: getEnvAsBool("SCHEDULER_EMBEDDED_ENABLED", false),
+			Timezone:        getEnv("SCHEDULER_TIMEZONE", "UTC"),
+			CatchUpEnabled:  getEnvAsBool("SCHEDULER_CATCH_UP_ENABLED", true),
+			Jobs:            loadSchedulerJobs(),
 		},
 		Notification: NotificationConfig{
 			AWSRegion:             getEnv("AWS_REGION", "ap-northeast-1"),
+			PushProvider:          getEnv("NOTIFICATION_PUSH_PROVIDER", "sns"),
 			SNSPlatformARNiOS:     getEnv("SNS_PLATFORM_ARN_IOS", ""),
 			SNSPlatformARNAndroid: getEnv("SNS_PLATFORM_ARN_ANDROID", ""),
+			FCMServiceAccountFile: getEnv("FCM_SERVICE_ACCOUNT_FILE", ""),
+			FCMServiceAccountJSON: getEnv("FCM_SERVICE_ACCOUNT_JSON", ""),
+			FCMProjectID:          getEnv("FCM_PROJECT_ID", ""),
 			SESFromEmail:          getEnv("SES_FROM_EMAIL", ""),
 			SESFromName:           getEnv("SES_FROM_NAME", "Home Garden"),
+			SMSSenders:            getEnvAsMap("SMS_SENDERS"), // 例: 81=HomeGarden,1=+18005550100
+			SMSDefaultSender:      getEnv("SMS_DEFAULT_SENDER", ""),
+			PushLimitPerHour:      getEnvAsInt("NOTIFICATION_PUSH_LIMIT_PER_HOUR", 5),
+			PushLimitPerDay:       getEnvAsInt("NOTIFICATION_PUSH_LIMIT_PER_DAY", 20),
+			ReminderWorkers:       getEnvAsInt("NOTIFICATION_REMINDER_WORKERS", 4),
+			SchedulerPageSize:     getEnvAsInt("NOTIFICATION_SCHEDULER_PAGE_SIZE", 500),
 			MaxRetries:            getEnvAsInt("NOTIFICATION_MAX_RETRIES", 3),
 			InitialBackoffMs:      getEnvAsInt("NOTIFICATION_INITIAL_BACKOFF_MS", 1000),
 		},
+		Metrics: MetricsConfig{
+			AuthToken: getEnv("METRICS_AUTH_TOKEN", ""),
+		},
+		Queue: QueueConfig{
+			Backend:     getEnv("QUEUE_BACKEND", QueueBackendMemory),
+			SQSQueueURL: getEnv("QUEUE_SQS_URL", ""),
+			AWSRegion:   getEnv("AWS_REGION", "ap-northeast-1"),
+			Workers:     getEnvAsInt("QUEUE_WORKERS", 2),
+			BufferSize:  getEnvAsInt("QUEUE_BUFFER_SIZE", 100),
+		},
+		Retention: RetentionConfig{
+			Days:   loadRetentionDays(),
+			DryRun: getEnvAsBool("RETENTION_DRY_RUN", false),
+		},
+		GRPC: GRPCConfig{
+			Port: getEnv("GRPC_PORT", ""),
+		},
+		Organization: OrganizationConfig{
+			DefaultMaxPlots:   getEnvAsInt("ORG_DEFAULT_MAX_PLOTS", 0),
+			DefaultMaxCrops:   getEnvAsInt("ORG_DEFAULT_MAX_CROPS", 0),
+			DefaultMaxMembers: getEnvAsInt("ORG_DEFAULT_MAX_MEMBERS", 0),
+		},
+		BodyLimit: BodyLimitConfig{
+			DefaultBytes: int64(getEnvAsInt("BODY_LIMIT_DEFAULT_BYTES", 1<<20)),
+			BatchBytes:   int64(getEnvAsInt("BODY_LIMIT_BATCH_BYTES", 10<<20)),
+			UploadBytes:  int64(getEnvAsInt("BODY_LIMIT_UPLOAD_BYTES", 6<<20)),
+		},
+		Tracing: TracingConfig{
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			OTLPProtocol: getEnv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf"),
+			OTLPHeaders:  getEnvAsMap("OTEL_EXPORTER_OTLP_HEADERS"),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "home-garden-api"),
+			SampleRatio:  getEnvAsFloat("OTEL_TRACES_SAMPLER_ARG", 1),
+		},
+		ErrorReport: ErrorReportConfig{
+			Provider:           getEnv("ERROR_REPORT_PROVIDER", ""),
+			SentryDSN:          getEnv("SENTRY_DSN", ""),
+			RollbarAccessToken: getEnv("ROLLBAR_ACCESS_TOKEN", ""),
+			Release:            getEnv("ERROR_REPORT_RELEASE", ""),
+			SampleRate:         getEnvAsFloat("ERROR_REPORT_SAMPLE_RATE", 1),
+			PanicSampleRate:    getEnvAsFloat("ERROR_REPORT_PANIC_SAMPLE_RATE", 1),
+		},
+	}
+
+	// ローカルの保存先の署名付きURLは、未設定の場合はこのサーバーと JWT の鍵を使用する
+	if config.Storage.LocalBaseURL == "" {
+		config.Storage.LocalBaseURL = "http://localhost:" + config.Server.Port
+	}
+	if config.Storage.LocalSigningKey == "" {
+		config.Storage.LocalSigningKey = config.JWT.Secret
 	}
 
 	return config, nil
@@ -166,6 +531,49 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsFloat gets an environment variable as float64 or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsBool gets an environment variable as bool or returns a default value
+// （strconv.ParseBool の書式: true, false, 1, 0 など）
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// loadRetentionDays はデータの保持期間を環境変数から読み込みます
+func loadRetentionDays() map[string]int {
+	days := make(map[string]int, len(RetentionTargets))
+	for _, target := range RetentionTargets {
+		days[target.Name] = getEnvAsInt("RETENTION_"+strings.ToUpper(target.Name)+"_DAYS", target.Days)
+	}
+	return days
+}
+
+// loadSchedulerJobs は内蔵スケジューラーのジョブごとの設定を環境変数から読み込みます
+func loadSchedulerJobs() map[string]SchedulerJobConfig {
+	jobs := make(map[string]SchedulerJobConfig, len(SchedulerJobNames))
+	for _, job := range SchedulerJobNames {
+		prefix := "SCHEDULER_JOB_" + strings.ToUpper(job.Name)
+		jobs[job.Name] = SchedulerJobConfig{
+			Enabled:  getEnvAsBool(prefix+"_ENABLED", SchedulerJobEnabledByDefault(job.Name)),
+			Schedule: getEnv(prefix+"_SCHEDULE", job.Schedule),
+		}
+	}
+	return jobs
+}
+
 // getEnvAsSlice gets an environment variable as comma-separated slice or returns a default value
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	if value, exists := os.LookupEnv(key); exists && value != "" {
@@ -196,3 +604,17 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// getEnvAsMap gets an environment variable as comma-separated key=value pairs
+// 例: "81=HomeGarden,1=+18005550100"（"=" を含まない項目は無視します）
+func getEnvAsMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range getEnvAsSlice(key, nil) {
+		k, v, ok := strings.Cut(item, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if ok && k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,371 @@
+package database
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// =============================================================================
+// Backup - 論理バックアップとリストア
+// =============================================================================
+// pg_dump などの外部コマンドを使用せず、GORM で全てのモデルのテーブル（models）を読み取り、
+// gzip で圧縮した JSON Lines（1行目がヘッダー、2行目以降が1行1レコード）に書き出します。
+// 列はモデルの列のみで、マイグレーションで作成するインデックス・制約・ビューは含みません（リストアの前に Setup で作成します）。
+//   - バックアップは読み取り専用のトランザクション（REPEATABLE READ）で、全てのテーブルを同じ時点で読み取る
+//   - テーブルは外部キーの参照先から順に書き出し、リストアは同じ順に作成する
+//   - リストアは1つのトランザクションで既存の行を全て削除してから作成し、失敗した場合は何も変更しない
+//   - リストアはマイグレーションのバージョンがバックアップと同じデータベースにのみ実行できる
+
+// BackupFormatVersion はバックアップのファイルの形式のバージョンです。
+const BackupFormatVersion = 1
+
+// backupInsertBatchSize はリストアで1回に作成する行数です。
+const backupInsertBatchSize = 500
+
+// backupExcludedTables はバックアップ・リストアしないテーブルです（リストアしても残すバックアップの履歴）。
+var backupExcludedTables = map[string]bool{"database_backups": true}
+
+var (
+	// ErrInvalidBackup はバックアップのファイルを読み取れない場合のエラー
+	ErrInvalidBackup = errors.New("invalid backup file")
+	// ErrBackupVersionMismatch はバックアップとデータベースのマイグレーションのバージョンが異なる場合のエラー
+	ErrBackupVersionMismatch = errors.New("backup migration version does not match the database")
+)
+
+// BackupHeader はバックアップのファイルの1行目です。
+type BackupHeader struct {
+	Format           int       `json:"format"`            // BackupFormatVersion
+	Driver           string    `json:"driver"`            // バックアップしたデータベース（postgres, mysql, sqlite）
+	MigrationVersion uint      `json:"migration_version"` // バックアップしたデータベースのマイグレーションのバージョン
+	CreatedAt        time.Time `json:"created_at"`
+	Tables           []string  `json:"tables"` // 書き出したテーブル（作成する順）
+}
+
+// backupRecord はバックアップのファイルの2行目以降の1レコードです。
+type backupRecord struct {
+	Table string         `json:"t"`
+	Row   map[string]any `json:"r"` // 列名ごとの値（日時は RFC3339）
+}
+
+// BackupStats はバックアップ・リストアしたテーブルと行数です。
+type BackupStats struct {
+	MigrationVersion uint             `json:"migration_version"`
+	Tables           int              `json:"tables"`
+	Rows             int64            `json:"rows"`
+	TableRows        map[string]int64 `json:"table_rows"` // テーブルごとの行数
+}
+
+// backupTable はバックアップするテーブルと列です。
+type backupTable struct {
+	schema  *schema.Schema
+	columns []string
+}
+
+// backupTables は外部キーの参照先から順に並べたモデルのテーブルを返します。
+func (db *DB) backupTables() ([]backupTable, error) {
+	ordered := models()
+	if reorderer, ok := db.DB.Migrator().(interface {
+		ReorderModels(values []interface{}, autoAdd bool) []interface{}
+	}); ok {
+		ordered = reorderer.ReorderModels(ordered, false)
+	}
+
+	tables := make([]backupTable, 0, len(ordered))
+	for _, value := range ordered {
+		stmt := &gorm.Statement{DB: db.DB}
+		if err := stmt.Parse(value); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", value, err)
+		}
+		if backupExcludedTables[stmt.Schema.Table] {
+			continue
+		}
+		tables = append(tables, backupTable{schema: stmt.Schema, columns: stmt.Schema.DBNames})
+	}
+	return tables, nil
+}
+
+// Backup は全てのモデルのテーブルを w に書き出します（gzip で圧縮した JSON Lines）。
+//
+// 引数:
+//   - ctx: コンテキスト（キャンセルした場合は途中で中断）
+//   - w: 書き出し先
+//
+// 戻り値:
+//   - *BackupStats: 書き出したテーブルと行数
+//   - error: 読み取り・書き出しに失敗した場合のエラー
+func (db *DB) Backup(ctx context.Context, w io.Writer) (*BackupStats, error) {
+	status, err := db.MigrationVersion()
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("migration version %d is dirty", status.Version)
+	}
+	tables, err := db.backupTables()
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	header := BackupHeader{
+		Format:           BackupFormatVersion,
+		Driver:           db.backupDriver().name(),
+		MigrationVersion: status.Version,
+		CreatedAt:        time.Now().UTC(),
+	}
+	for _, table := range tables {
+		header.Tables = append(header.Tables, table.schema.Table)
+	}
+	if err := enc.Encode(header); err != nil {
+		return nil, err
+	}
+
+	stats := &BackupStats{MigrationVersion: status.Version, TableRows: make(map[string]int64, len(tables))}
+	conn := db.DB.WithContext(repository.ContextWithMaintenanceQuery(ctx))
+	err = conn.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tables {
+			count, err := backupTableRows(tx, table, enc)
+			if err != nil {
+				return fmt.Errorf("failed to back up %s: %w", table.schema.Table, err)
+			}
+			stats.Tables++
+			stats.Rows += count
+			stats.TableRows[table.schema.Table] = count
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// backupTableRows はテーブルの全ての行（論理削除した行を含む）を主キーの順に書き出します。
+func backupTableRows(tx *gorm.DB, table backupTable, enc *json.Encoder) (int64, error) {
+	query := tx.Table(table.schema.Table).Select(table.columns)
+	for _, field := range table.schema.PrimaryFields {
+		query = query.Order(field.DBName)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		row := map[string]any{}
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return count, err
+		}
+		for column, value := range row {
+			// JSON・テキストの列はドライバによって []byte で返る
+			if b, ok := value.([]byte); ok {
+				row[column] = string(b)
+			}
+		}
+		if err := enc.Encode(backupRecord{Table: table.schema.Table, Row: row}); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// Restore は Backup で書き出したファイルでモデルのテーブルの行を置き換えます。
+// 1つのトランザクションで既存の行を全て削除してから作成するため、失敗した場合は何も変更しません。
+// リストアの後は分析のマテリアライズドビューをリフレッシュしてください（RefreshMaterializedViews）。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - r: Backup で書き出したファイル
+//
+// 戻り値:
+//   - *BackupStats: 作成したテーブルと行数
+//   - error: 読み取れないファイルは ErrInvalidBackup、バージョンが異なる場合は ErrBackupVersionMismatch、作成に失敗した場合のエラー
+func (db *DB) Restore(ctx context.Context, r io.Reader) (*BackupStats, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	defer gz.Close()
+	dec := json.NewDecoder(bufio.NewReader(gz))
+	dec.UseNumber()
+
+	var header BackupHeader
+	if err := dec.Decode(&header); err != nil || header.Format != BackupFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported header (format %d, %v)", ErrInvalidBackup, header.Format, err)
+	}
+	status, err := db.MigrationVersion()
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty || status.Version != header.MigrationVersion {
+		return nil, fmt.Errorf("%w: backup %d, database %d", ErrBackupVersionMismatch, header.MigrationVersion, status.Version)
+	}
+
+	tables, err := db.backupTables()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]backupTable, len(tables))
+	for _, table := range tables {
+		byName[table.schema.Table] = table
+	}
+	for _, name := range header.Tables {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown table %q", ErrInvalidBackup, name)
+		}
+	}
+
+	stats := &BackupStats{MigrationVersion: header.MigrationVersion, TableRows: make(map[string]int64, len(header.Tables))}
+	conn := db.DB.WithContext(repository.ContextWithMaintenanceQuery(ctx))
+	err = conn.Transaction(func(tx *gorm.DB) error {
+		// 参照元のテーブルから削除する
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := tx.Exec("DELETE FROM " + tx.Statement.Quote(tables[i].schema.Table)).Error; err != nil {
+				return fmt.Errorf("failed to clear %s: %w", tables[i].schema.Table, err)
+			}
+		}
+
+		var batch []map[string]any
+		var current backupTable
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := tx.Table(current.schema.Table).CreateInBatches(batch, backupInsertBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to restore %s: %w", current.schema.Table, err)
+			}
+			stats.Rows += int64(len(batch))
+			stats.TableRows[current.schema.Table] += int64(len(batch))
+			batch = nil
+			return nil
+		}
+		for {
+			var record backupRecord
+			if err := dec.Decode(&record); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+			}
+			table, ok := byName[record.Table]
+			if !ok {
+				return fmt.Errorf("%w: unknown table %q", ErrInvalidBackup, record.Table)
+			}
+			if table.schema != current.schema {
+				if err := flush(); err != nil {
+					return err
+				}
+				current = table
+			}
+			row, err := restoreRow(table, record.Row)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidBackup, record.Table, err)
+			}
+			batch = append(batch, row)
+			if len(batch) >= backupInsertBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		// 自動採番の次の値を作成した行のIDより後にする
+		for _, table := range tables {
+			if field := table.schema.PrioritizedPrimaryField; field != nil && field.AutoIncrement {
+				if err := db.backupDriver().resetSequence(tx, table.schema.Table, field.DBName); err != nil {
+					return fmt.Errorf("failed to reset the sequence of %s: %w", table.schema.Table, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats.Tables = len(header.Tables)
+	log.Printf("Restored %d rows into %d tables (migration version %d)", stats.Rows, stats.Tables, stats.MigrationVersion)
+	return stats, nil
+}
+
+// restoreRow はバックアップの行の値を列の型に変換します（モデルにない列は作成しない）。
+func restoreRow(table backupTable, values map[string]any) (map[string]any, error) {
+	row := make(map[string]any, len(table.columns))
+	for _, column := range table.columns {
+		value, ok := values[column]
+		if !ok || value == nil {
+			row[column] = nil
+			continue
+		}
+		field := table.schema.LookUpField(column)
+		converted, err := restoreValue(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column, err)
+		}
+		row[column] = converted
+	}
+	return row, nil
+}
+
+// restoreValue は JSON の値をフィールドの型の値にします。
+func restoreValue(field *schema.Field, value any) (any, error) {
+	switch field.DataType {
+	case schema.Time:
+		// ドライバの形式の日時（SQLite の文字列の列など）はそのまま作成する
+		if s, ok := value.(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return t, nil
+			}
+			return s, nil
+		}
+	case schema.Int, schema.Uint:
+		if n, ok := value.(json.Number); ok {
+			return n.Int64()
+		}
+	case schema.Float:
+		if n, ok := value.(json.Number); ok {
+			return n.Float64()
+		}
+	case schema.Bool:
+		// SQLite・MySQL は真偽値を 0/1 で返す
+		if n, ok := value.(json.Number); ok {
+			return n.String() != "0", nil
+		}
+	}
+	if n, ok := value.(json.Number); ok {
+		if strings.ContainsAny(n.String(), ".eE") {
+			return n.Float64()
+		}
+		return n.Int64()
+	}
+	return value, nil
+}
+
+// backupDriver は接続しているデータベースのドライバです（未設定の場合は PostgreSQL）。
+func (db *DB) backupDriver() driver {
+	if db.driver == nil {
+		return postgresDriver{}
+	}
+	return db.driver
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync/atomic"
+
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Driver - 接続するデータベースごとの処理
+// =============================================================================
+// DB_DRIVER で PostgreSQL（本番・デフォルト）、MySQL / MariaDB（driver_mysql.go）、
+// SQLite（PostgreSQL を用意できないローカル開発・テスト）を切り替えます。
+// SQLite は cgo を使用しないドライバ（modernc.org/sqlite）のため、CGO_ENABLED=0 のビルドでも動作します。
+// PostgreSQL 固有の機能（全文検索、JSONB の集計、読み取りレプリカ）は MySQL・SQLite では使用できません。
+
+// driver は接続・接続プール・バージョン管理のマイグレーションのデータベースごとの処理です。
+type driver interface {
+	// name は DB_DRIVER の値です（golang-migrate のデータベース名にも使用）。
+	name() string
+	// dsn は設定から接続文字列を作成します。
+	dsn(cfg *config.DatabaseConfig) string
+	// dialector は GORM の接続を作成します。
+	dialector(dsn string) gorm.Dialector
+	// configurePool は接続プールを設定します。
+	configurePool(sqlDB *sql.DB, dbCfg *Config)
+	// migrationDriver はマイグレーション用の接続を開きます（golang-migrate は終了時に接続を閉じる）。
+	migrationDriver(dsn string) (migratedb.Driver, error)
+	// migrations はマイグレーションのファイルです。
+	migrations() (fs.FS, string)
+	// supportsReadReplica は読み取りレプリカを使用できるかを返します。
+	supportsReadReplica() bool
+	// hasMaterializedViews は分析のビューがマテリアライズドビュー（リフレッシュが必要）かを返します。
+	// 通常のビュー（MySQL・SQLite）は常に最新の集計を返します。
+	hasMaterializedViews() bool
+	// resetSequence はリストアの後に自動採番の次の値を作成した行のIDより後にします。
+	resetSequence(tx *gorm.DB, table, column string) error
+}
+
+// driverFor は DB_DRIVER の値のドライバを返します（空の場合は PostgreSQL）。
+func driverFor(name string) (driver, error) {
+	switch name {
+	case "", config.DatabaseDriverPostgres:
+		return postgresDriver{}, nil
+	case config.DatabaseDriverMySQL:
+		return mysqlDriver{}, nil
+	case config.DatabaseDriverSQLite:
+		return sqliteDriver{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q (use %s, %s or %s)", name,
+			config.DatabaseDriverPostgres, config.DatabaseDriverMySQL, config.DatabaseDriverSQLite)
+	}
+}
+
+// configurePool は接続プールを設定の値にします（PostgreSQL・MySQL）。
+func configurePool(sqlDB *sql.DB, dbCfg *Config) {
+	sqlDB.SetMaxIdleConns(dbCfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(dbCfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(dbCfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(dbCfg.ConnMaxIdleTime)
+}
+
+// =============================================================================
+// PostgreSQL
+// =============================================================================
+
+// postgresDriver は PostgreSQL（pgx）のドライバです。
+type postgresDriver struct{}
+
+func (postgresDriver) name() string { return config.DatabaseDriverPostgres }
+
+func (postgresDriver) dsn(cfg *config.DatabaseConfig) string { return cfg.DSN() }
+
+func (postgresDriver) dialector(dsn string) gorm.Dialector { return postgres.Open(dsn) }
+
+func (postgresDriver) configurePool(sqlDB *sql.DB, dbCfg *Config) { configurePool(sqlDB, dbCfg) }
+
+func (postgresDriver) migrationDriver(dsn string) (migratedb.Driver, error) {
+	sqlDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := migratepgx.WithInstance(sqlDB, &migratepgx.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+func (postgresDriver) migrations() (fs.FS, string) { return migrations.FS, "." }
+
+func (postgresDriver) supportsReadReplica() bool { return true }
+
+func (postgresDriver) hasMaterializedViews() bool { return true }
+
+// resetSequence は列のシーケンスを最大のIDの次の値にします（シーケンスのない列は何もしない）。
+func (postgresDriver) resetSequence(tx *gorm.DB, table, column string) error {
+	quotedTable, quotedColumn := tx.Statement.Quote(table), tx.Statement.Quote(column)
+	return tx.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence(?, ?), COALESCE(MAX(%s), 0) + 1, false) FROM %s", quotedColumn, quotedTable),
+		table, column).Error
+}
+
+// =============================================================================
+// SQLite
+// =============================================================================
+
+// sqliteMemoryPath は DB_SQLITE_PATH でプロセス内のメモリのデータベースを指定する値です。
+const sqliteMemoryPath = ":memory:"
+
+// sqliteMemorySeq はメモリのデータベースの名前の連番です（接続ごとに別のデータベースにする）。
+var sqliteMemorySeq atomic.Uint64
+
+// sqliteDriver は SQLite（modernc.org/sqlite）のドライバです。
+type sqliteDriver struct{}
+
+func (sqliteDriver) name() string { return config.DatabaseDriverSQLite }
+
+// dsn は外部キーの制約を有効にした接続文字列を作成します。
+// 日時は SQLite の日付関数で扱える形式（_time_format=sqlite、"2006-01-02 15:04:05.999999999-07:00"）で保存します。
+// メモリのデータベースは共有キャッシュの名前付きのデータベースにし、マイグレーション用の接続からも同じデータベースを使用します。
+func (sqliteDriver) dsn(cfg *config.DatabaseConfig) string {
+	const params = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+	path := cfg.SQLitePath
+	if path == "" || path == sqliteMemoryPath {
+		return fmt.Sprintf("file:home_garden_%d?mode=memory&cache=shared&%s", sqliteMemorySeq.Add(1), params)
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return "file:" + strings.TrimPrefix(path, "file:") + separator + params
+}
+
+func (sqliteDriver) dialector(dsn string) gorm.Dialector {
+	return sqlite.Dialector{DriverName: "sqlite", DSN: dsn}
+}
+
+// configurePool は接続を1つにします。
+// SQLite の書き込みは1つずつのため、複数の接続はロックの待ち（SQLITE_BUSY）になるだけです。
+// メモリのデータベースは最後の接続を閉じると消えるため、接続を閉じないようにします。
+func (sqliteDriver) configurePool(sqlDB *sql.DB, dbCfg *Config) {
+	sqlDB.SetMaxIdleConns(1)
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+	sqlDB.SetConnMaxIdleTime(0)
+}
+
+func (sqliteDriver) migrationDriver(dsn string) (migratedb.Driver, error) {
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := migratesqlite.WithInstance(sqlDB, &migratesqlite.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+func (sqliteDriver) migrations() (fs.FS, string) { return migrations.SQLiteFS, "sqlite" }
+
+func (sqliteDriver) supportsReadReplica() bool { return false }
+
+func (sqliteDriver) hasMaterializedViews() bool { return false }
+
+// resetSequence は何もしません（AUTOINCREMENT のない INTEGER PRIMARY KEY は最大のIDの次の値）。
+func (sqliteDriver) resetSequence(tx *gorm.DB, table, column string) error { return nil }
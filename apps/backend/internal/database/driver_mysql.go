@@ -0,0 +1,168 @@
+package database
+
+import (
+	"database/sql"
+	"io/fs"
+	"net"
+	"strings"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/migrations"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// =============================================================================
+// MySQL / MariaDB
+// =============================================================================
+// MySQL 8.0.16 以降（CHECK 制約を検証するバージョン）と MariaDB 10.2 以降に対応します。
+// マイグレーションは migrations/mysql のファイルで、PostgreSQL の DO ブロックはプロシージャ、
+// マテリアライズドビューは通常のビュー、部分インデックスは条件のないインデックスに置き換えています。
+
+// mysqlDriver は MySQL / MariaDB（go-sql-driver/mysql）のドライバです。
+type mysqlDriver struct{}
+
+func (mysqlDriver) name() string { return config.DatabaseDriverMySQL }
+
+// dsn は DATABASE_URL（go-sql-driver/mysql の形式: user:password@tcp(host:3306)/dbname）または個別の設定から接続文字列を作成します。
+// 日時を time.Time で読み込むため parseTime は常に有効にします。
+func (mysqlDriver) dsn(cfg *config.DatabaseConfig) string {
+	dsnCfg := gomysql.NewConfig()
+	if cfg.URL != "" {
+		parsed, err := gomysql.ParseDSN(strings.TrimPrefix(cfg.URL, "mysql://"))
+		if err != nil {
+			// 接続時に go-sql-driver/mysql のエラーを返す
+			return cfg.URL
+		}
+		dsnCfg = parsed
+	} else {
+		dsnCfg.User = cfg.User
+		dsnCfg.Passwd = cfg.Password
+		dsnCfg.Net = "tcp"
+		dsnCfg.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+		dsnCfg.DBName = cfg.DBName
+	}
+	dsnCfg.ParseTime = true
+	return dsnCfg.FormatDSN()
+}
+
+func (mysqlDriver) dialector(dsn string) gorm.Dialector {
+	return mysqlDialector{Dialector: mysql.New(mysql.Config{DSN: dsn}).(*mysql.Dialector)}
+}
+
+func (mysqlDriver) configurePool(sqlDB *sql.DB, dbCfg *Config) { configurePool(sqlDB, dbCfg) }
+
+// migrationDriver は複数の文を実行できる接続（multiStatements）を開きます（マイグレーションのファイルは複数の文）。
+func (mysqlDriver) migrationDriver(dsn string) (migratedb.Driver, error) {
+	dsnCfg, err := gomysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	dsnCfg.MultiStatements = true
+	sqlDB, err := sql.Open("mysql", dsnCfg.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	driver, err := migratemysql.WithInstance(sqlDB, &migratemysql.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+func (mysqlDriver) migrations() (fs.FS, string) { return migrations.MySQLFS, "mysql" }
+
+func (mysqlDriver) supportsReadReplica() bool { return false }
+
+func (mysqlDriver) hasMaterializedViews() bool { return false }
+
+// resetSequence は何もしません（AUTO_INCREMENT は作成した行のIDより後の値になる）。
+func (mysqlDriver) resetSequence(tx *gorm.DB, table, column string) error { return nil }
+
+// =============================================================================
+// GORM の MySQL の列の型
+// =============================================================================
+
+// postgresJSONType はモデルの JSON の列の型です（PostgreSQL の型を指定している）。
+const postgresJSONType = "jsonb"
+
+// mysqlDialector は PostgreSQL の型を指定したモデルの列を MySQL の型で作成する GORM の接続です。
+// jsonb の列は JSON にし、JSON の列のデフォルト値は式（括弧）で指定します（MySQL はリテラルのデフォルト値を指定できない）。
+type mysqlDialector struct {
+	*mysql.Dialector
+}
+
+// Migrator は列の型に mysqlDialector を使用するマイグレーターを返します。
+func (d mysqlDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return mysqlMigrator{Migrator: mysql.Migrator{
+		Migrator: migrator.Migrator{
+			Config: migrator.Config{
+				DB:        db,
+				Dialector: d,
+			},
+		},
+		Dialector: *d.Dialector,
+	}}
+}
+
+// DataTypeOf は jsonb の列を JSON にします。
+func (d mysqlDialector) DataTypeOf(field *schema.Field) string {
+	if strings.EqualFold(string(field.DataType), postgresJSONType) {
+		return "JSON"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+// mysqlMigrator は JSON の列のデフォルト値を式で指定するマイグレーターです。
+type mysqlMigrator struct {
+	mysql.Migrator
+}
+
+// jsonDefaultExpression は JSON の列のデフォルト値を式にした列を返します（JSON 以外・デフォルト値がない場合は nil）。
+func jsonDefaultExpression(field *schema.Field) *schema.Field {
+	if field == nil || !strings.EqualFold(string(field.DataType), postgresJSONType) ||
+		field.DefaultValue == "" || strings.HasPrefix(field.DefaultValue, "(") {
+		return nil
+	}
+	expression := *field
+	expression.DefaultValue = "(" + field.DefaultValue + ")"
+	expression.DefaultValueInterface = nil
+	return &expression
+}
+
+// FullDataTypeOf は列の定義を返します（JSON の列のデフォルト値は DEFAULT ('...') の形式）。
+func (m mysqlMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	if expression := jsonDefaultExpression(field); expression != nil {
+		return m.Migrator.FullDataTypeOf(expression)
+	}
+	return m.Migrator.FullDataTypeOf(field)
+}
+
+// AddColumn は列を追加します（mysql.Migrator は自身の FullDataTypeOf を使用するため、JSON の列のみここで追加）。
+func (m mysqlMigrator) AddColumn(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		field := jsonDefaultExpression(stmt.Schema.LookUpField(name))
+		if field == nil || field.IgnoreMigration {
+			return m.Migrator.AddColumn(value, name)
+		}
+		return m.DB.Exec("ALTER TABLE ? ADD ? ?", m.CurrentTable(stmt), clause.Column{Name: field.DBName}, m.FullDataTypeOf(field)).Error
+	})
+}
+
+// AlterColumn は列の定義を変更します（AddColumn と同じく JSON の列のみここで変更）。
+func (m mysqlMigrator) AlterColumn(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		field := jsonDefaultExpression(stmt.Schema.LookUpField(name))
+		if field == nil {
+			return m.Migrator.AlterColumn(value, name)
+		}
+		return m.DB.Exec("ALTER TABLE ? MODIFY COLUMN ? ?", m.CurrentTable(stmt), clause.Column{Name: field.DBName}, m.FullDataTypeOf(field)).Error
+	})
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Pool Metrics - 接続プールの統計と遅いクエリ
+// =============================================================================
+// sql.DB の統計（Stats）を接続（primary / replica）ごとにPrometheusのゲージ・カウンターとして
+// /metrics で公開し、運用ダッシュボード（GET /admin/database）では遅いクエリと合わせて返します。
+// 待ち時間（wait_duration）が増え続ける場合は接続数の上限（Config.MaxOpenConns）が不足しています。
+
+// PoolStats は接続プールの統計です。
+type PoolStats struct {
+	Connection         string `json:"connection"` // primary / replica
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDurationMs     int64  `json:"wait_duration_ms"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+
+	waitDuration time.Duration
+}
+
+// newPoolStats は sql.DB の統計から PoolStats を作成します。
+func newPoolStats(connection string, sqlDB *sql.DB) PoolStats {
+	stats := sqlDB.Stats()
+	return PoolStats{
+		Connection:         connection,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		waitDuration:       stats.WaitDuration,
+	}
+}
+
+// PoolStats は接続ごとの接続プールの統計を返します（プライマリ、設定している場合は読み取りレプリカ）。
+func (db *DB) PoolStats() []PoolStats {
+	var result []PoolStats
+	if sqlDB, err := db.DB.DB(); err == nil {
+		result = append(result, newPoolStats("primary", sqlDB))
+	}
+	if db.replica != nil {
+		result = append(result, newPoolStats("replica", db.replica))
+	}
+	return result
+}
+
+// SlowQueries は閾値を超えたクエリの記録を返します（Connect 以外で作成した接続の場合は空の記録）。
+func (db *DB) SlowQueries() *repository.SlowQueryLog {
+	if db.slowQueries == nil {
+		return repository.NewSlowQueryLog(repository.DefaultSlowQueryLogSize)
+	}
+	return db.slowQueries
+}
+
+// SlowQueryThreshold は遅いクエリの閾値を返します（0 の場合は記録しない）。
+func (db *DB) SlowQueryThreshold() time.Duration {
+	return max(db.slowQueryThreshold, 0)
+}
+
+// poolMetric は接続プールの統計のPrometheusメトリクスです。
+type poolMetric struct {
+	name  string
+	kind  string // gauge / counter
+	help  string
+	value func(stats PoolStats) float64
+}
+
+// poolMetrics は /metrics で公開する接続プールの統計です。
+var poolMetrics = []poolMetric{
+	{"db_pool_max_open_connections", "gauge", "Maximum number of open connections to the database.",
+		func(s PoolStats) float64 { return float64(s.MaxOpenConnections) }},
+	{"db_pool_open_connections", "gauge", "Number of established connections, both in use and idle.",
+		func(s PoolStats) float64 { return float64(s.OpenConnections) }},
+	{"db_pool_in_use_connections", "gauge", "Number of connections currently in use.",
+		func(s PoolStats) float64 { return float64(s.InUse) }},
+	{"db_pool_idle_connections", "gauge", "Number of idle connections.",
+		func(s PoolStats) float64 { return float64(s.Idle) }},
+	{"db_pool_wait_count_total", "counter", "Total number of connections waited for.",
+		func(s PoolStats) float64 { return float64(s.WaitCount) }},
+	{"db_pool_wait_duration_seconds_total", "counter", "Total time blocked waiting for a new connection.",
+		func(s PoolStats) float64 { return s.waitDuration.Seconds() }},
+	{"db_pool_max_idle_closed_total", "counter", "Total number of connections closed due to the idle connection limit.",
+		func(s PoolStats) float64 { return float64(s.MaxIdleClosed) }},
+	{"db_pool_max_idle_time_closed_total", "counter", "Total number of connections closed due to the idle time limit.",
+		func(s PoolStats) float64 { return float64(s.MaxIdleTimeClosed) }},
+	{"db_pool_max_lifetime_closed_total", "counter", "Total number of connections closed due to the connection lifetime limit.",
+		func(s PoolStats) float64 { return float64(s.MaxLifetimeClosed) }},
+}
+
+// WritePrometheus は接続プールの統計と遅いクエリの件数をPrometheusのテキスト形式で書き出します。
+//
+// 出力例:
+//
+//	# HELP db_pool_in_use_connections Number of connections currently in use.
+//	# TYPE db_pool_in_use_connections gauge
+//	db_pool_in_use_connections{connection="primary"} 4
+func (db *DB) WritePrometheus(w io.Writer) error {
+	stats := db.PoolStats()
+	for _, metric := range poolMetrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind); err != nil {
+			return err
+		}
+		for _, s := range stats {
+			if _, err := fmt.Fprintf(w, "%s{connection=%q} %g\n", metric.name, s.Connection, metric.value(s)); err != nil {
+				return err
+			}
+		}
+	}
+	return db.SlowQueries().WritePrometheus(w)
+}
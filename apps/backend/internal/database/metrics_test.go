@@ -0,0 +1,87 @@
+package database
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// Pool Metrics Tests - 接続プールの統計と遅いクエリのテスト
+// =============================================================================
+// テスト対象:
+//   - PoolStats / WritePrometheus: 接続ごとのゲージ・カウンター
+//   - Connect: DB_SLOW_QUERY_THRESHOLD_MS の遅いクエリのプラグインの登録
+
+// connectSQLite は SQLite のメモリのデータベースに接続します。
+func connectSQLite(t *testing.T, slowQueryThresholdMs int) *DB {
+	t.Helper()
+	db, err := Connect(&config.Config{Database: config.DatabaseConfig{
+		Driver:               config.DatabaseDriverSQLite,
+		SQLitePath:           sqliteMemoryPath,
+		SlowQueryThresholdMs: slowQueryThresholdMs,
+	}}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// TestWritePrometheus は接続プールのメトリクスのテストです。
+// 期待動作:
+//   - プライマリの接続プールの統計（レプリカを設定していない場合はプライマリのみ）
+//   - 各メトリクスの HELP・TYPE と connection のラベル、遅いクエリの件数
+func TestWritePrometheus(t *testing.T) {
+	// Arrange
+	db := connectSQLite(t, 0)
+
+	// Act
+	stats := db.PoolStats()
+	var buf bytes.Buffer
+	err := db.WritePrometheus(&buf)
+
+	// Assert
+	if len(stats) != 1 || stats[0].Connection != "primary" || stats[0].MaxOpenConnections != 1 {
+		t.Errorf("Expected the primary pool with 1 connection, got %+v", stats)
+	}
+	if err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE db_pool_open_connections gauge\n",
+		`db_pool_max_open_connections{connection="primary"} 1` + "\n",
+		"# TYPE db_pool_wait_duration_seconds_total counter\n",
+		"db_slow_queries_total 0\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the metrics, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestConnect_SlowQueryThreshold は遅いクエリの閾値の設定のテストです。
+// 期待動作:
+//   - 閾値を設定した場合は閾値以上のクエリを記録する
+//   - 0 の場合は記録しない
+func TestConnect_SlowQueryThreshold(t *testing.T) {
+	// Arrange
+	db := connectSQLite(t, 1)
+	disabled := connectSQLite(t, 0)
+
+	// Act
+	db.Exec("WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 200000) SELECT count(*) FROM n")
+	disabled.Exec("SELECT 1")
+
+	// Assert
+	if db.SlowQueryThreshold() != time.Millisecond || db.SlowQueries().Total() != 1 {
+		t.Errorf("Expected 1 slow query over 1ms, got %d (threshold %v)", db.SlowQueries().Total(), db.SlowQueryThreshold())
+	}
+	if disabled.SlowQueryThreshold() != 0 || disabled.SlowQueries().Total() != 0 {
+		t.Errorf("Expected no slow queries when disabled, got %d", disabled.SlowQueries().Total())
+	}
+}
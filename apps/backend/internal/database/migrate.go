@@ -0,0 +1,125 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib" // database/sql の pgx ドライバ
+)
+
+// =============================================================================
+// バージョン管理のマイグレーション（golang-migrate）
+// =============================================================================
+// migrations パッケージに埋め込んだSQLファイルを適用します（SQLite は SQLite 用のファイル）。適用したバージョンは schema_migrations テーブルに記録され、
+// 複数のインスタンスが同時に起動してもアドバイザリロックで1つずつ実行されます。
+// マイグレーションが途中で失敗した場合はバージョンが dirty になるため、原因を修正してから
+// go run ./cmd/migrate force <バージョン> で状態を戻してください。
+
+// migrationsTable は適用したバージョンを記録するテーブルです。
+const migrationsTable = "schema_migrations"
+
+// MigrationStatus は適用済みのマイグレーションのバージョンです。
+type MigrationStatus struct {
+	Version uint // 0 の場合は未適用
+	Dirty   bool // 途中で失敗した場合は true
+}
+
+// withMigrator はマイグレーション用の接続を開いて fn を実行します。
+// golang-migrate は終了時に接続プールを閉じるため、GORM とは別の接続プールを使用します。
+// 接続するデータベースのドライバのファイル（SQLite は migrations/sqlite）を適用します。
+func (db *DB) withMigrator(fn func(m *migrate.Migrate) error) error {
+	if db.dsn == "" {
+		return errors.New("database DSN is not set")
+	}
+	drv := db.driver
+	if drv == nil {
+		drv = postgresDriver{}
+	}
+	driver, err := drv.migrationDriver(db.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to initialize migration driver: %w", err)
+	}
+	fsys, dir := drv.migrations()
+	source, err := iofs.New(fsys, dir)
+	if err != nil {
+		_ = driver.Close()
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, drv.name(), driver)
+	if err != nil {
+		_ = source.Close()
+		_ = driver.Close()
+		return fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	defer m.Close()
+	return fn(m)
+}
+
+// Migrate applies all pending versioned migrations
+// 未適用のマイグレーションをすべて適用します。
+func (db *DB) Migrate() error {
+	log.Println("Applying versioned migrations...")
+	return db.withMigrator(func(m *migrate.Migrate) error {
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		version, _, _ := m.Version()
+		log.Printf("Versioned migrations applied (version: %d)", version)
+		return nil
+	})
+}
+
+// MigrateDown rolls back the given number of migrations
+// 直近に適用したマイグレーションを steps 件ロールバックします（down のファイルを実行）。
+func (db *DB) MigrateDown(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive: %d", steps)
+	}
+	return db.withMigrator(func(m *migrate.Migrate) error {
+		if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to roll back migrations: %w", err)
+		}
+		return nil
+	})
+}
+
+// MigrateTo migrates up or down to the given version
+// 指定したバージョンまで適用またはロールバックします。
+func (db *DB) MigrateTo(version uint) error {
+	return db.withMigrator(func(m *migrate.Migrate) error {
+		if err := m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+		}
+		return nil
+	})
+}
+
+// ForceMigrationVersion sets the recorded version without running migrations
+// マイグレーションを実行せずに記録するバージョンを設定し、dirty を解除します（失敗したマイグレーションの復旧用）。
+// version に -1 を指定すると未適用の状態に戻します。
+func (db *DB) ForceMigrationVersion(version int) error {
+	return db.withMigrator(func(m *migrate.Migrate) error {
+		if err := m.Force(version); err != nil {
+			return fmt.Errorf("failed to force version %d: %w", version, err)
+		}
+		return nil
+	})
+}
+
+// MigrationVersion returns the currently applied migration version
+// 適用済みのマイグレーションのバージョンを返します。
+func (db *DB) MigrationVersion() (MigrationStatus, error) {
+	var status MigrationStatus
+	err := db.withMigrator(func(m *migrate.Migrate) error {
+		version, dirty, err := m.Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+			return fmt.Errorf("failed to get migration version: %w", err)
+		}
+		status = MigrationStatus{Version: version, Dirty: dirty}
+		return nil
+	})
+	return status, err
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// Connect Retry - 起動時の接続の再試行
+// =============================================================================
+// docker-compose 等でデータベースの起動がサーバーより遅い場合、最初の接続に失敗しても
+// DB_CONNECT_RETRIES の回数まで指数バックオフ（DB_CONNECT_RETRY_INITIAL_MS から2倍ずつ、
+// 上限 DB_CONNECT_RETRY_MAX_MS）で再試行します。
+
+// RetryPolicy は接続の再試行の設定です。
+type RetryPolicy struct {
+	Retries      int           // 再試行の回数（0 で再試行しない）
+	InitialDelay time.Duration // 最初の再試行までの待機時間
+	MaxDelay     time.Duration // 待機時間の上限（0 の場合は上限なし）
+}
+
+// RetryPolicyFromConfig は DB_CONNECT_RETRY_* の設定から再試行の設定を作成します。
+func RetryPolicyFromConfig(cfg *config.DatabaseConfig) RetryPolicy {
+	return RetryPolicy{
+		Retries:      cfg.ConnectRetries,
+		InitialDelay: time.Duration(cfg.ConnectRetryInitialMs) * time.Millisecond,
+		MaxDelay:     time.Duration(cfg.ConnectRetryMaxMs) * time.Millisecond,
+	}
+}
+
+// Delay は retry 回目（1から）の再試行までの待機時間を返します。
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// ConnectWithRetry はデータベースに接続し、失敗した場合は設定（cfg.Database の DB_CONNECT_RETRY_*）に従って再試行します。
+//
+// 引数:
+//   - ctx: コンテキスト（キャンセルされた場合は再試行を中止する）
+//   - cfg: アプリケーションの設定
+//   - dbCfg: 接続プールの設定（nil の場合は DefaultConfig）
+//
+// 戻り値:
+//   - *DB: データベースの接続
+//   - error: 全ての試行に失敗した場合は最後のエラー、ctx がキャンセルされた場合はそのエラー
+func ConnectWithRetry(ctx context.Context, cfg *config.Config, dbCfg *Config) (*DB, error) {
+	return connectWithRetry(ctx, RetryPolicyFromConfig(&cfg.Database), func() (*DB, error) {
+		return Connect(cfg, dbCfg)
+	})
+}
+
+// connectWithRetry は connect が成功するまで policy に従って再試行します。
+func connectWithRetry(ctx context.Context, policy RetryPolicy, connect func() (*DB, error)) (*DB, error) {
+	attempts := policy.Retries + 1
+	for attempt := 1; ; attempt++ {
+		db, err := connect()
+		if err == nil {
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("database connection failed after %d attempts: %w", attempts, err)
+		}
+
+		delay := policy.Delay(attempt)
+		log.Printf("Database connection attempt %d/%d failed, retrying in %s: %v", attempt, attempts, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Connect Retry Tests - 起動時の接続の再試行のテスト
+// =============================================================================
+// テスト対象:
+//   - RetryPolicy.Delay: 指数バックオフの待機時間と上限
+//   - connectWithRetry: 再試行の回数、成功した時点での終了、コンテキストのキャンセル
+
+// TestRetryPolicy_Delay は再試行までの待機時間のテストです。
+// 期待動作:
+//   - 再試行ごとに待機時間を2倍にする
+//   - MaxDelay を超えない
+func TestRetryPolicy_Delay(t *testing.T) {
+	// Arrange
+	policy := RetryPolicy{Retries: 10, InitialDelay: 500 * time.Millisecond, MaxDelay: 3 * time.Second}
+	want := []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+
+	for i, expected := range want {
+		// Act
+		got := policy.Delay(i + 1)
+
+		// Assert
+		if got != expected {
+			t.Errorf("Delay(%d) = %s, want %s", i+1, got, expected)
+		}
+	}
+}
+
+// TestConnectWithRetry は接続の再試行のテストです。
+// 期待動作:
+//   - 接続できた時点で再試行をやめる
+//   - Retries 回の再試行の後も失敗した場合は最後のエラーを返す
+//   - コンテキストがキャンセルされた場合は再試行を中止する
+func TestConnectWithRetry(t *testing.T) {
+	// Arrange
+	policy := RetryPolicy{Retries: 3, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	connErr := errors.New("connection refused")
+	attempts := 0
+	connectAfter := func(failures int) func() (*DB, error) {
+		attempts = 0
+		return func() (*DB, error) {
+			attempts++
+			if attempts <= failures {
+				return nil, connErr
+			}
+			return &DB{}, nil
+		}
+	}
+
+	// Act & Assert: 2回目の再試行で接続
+	if db, err := connectWithRetry(context.Background(), policy, connectAfter(2)); err != nil || db == nil || attempts != 3 {
+		t.Errorf("Expected connection on attempt 3, got attempts=%d err=%v", attempts, err)
+	}
+
+	// Act & Assert: 全ての試行に失敗
+	if _, err := connectWithRetry(context.Background(), policy, connectAfter(10)); !errors.Is(err, connErr) || attempts != 4 {
+		t.Errorf("Expected the last error after 4 attempts, got attempts=%d err=%v", attempts, err)
+	}
+
+	// Act & Assert: キャンセル
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := connectWithRetry(ctx, RetryPolicy{Retries: 3, InitialDelay: time.Hour}, connectAfter(10)); !errors.Is(err, context.Canceled) || attempts != 1 {
+		t.Errorf("Expected context.Canceled after 1 attempt, got attempts=%d err=%v", attempts, err)
+	}
+}
@@ -0,0 +1,656 @@
+// Package dto - APIのレスポンスの形式（GORMモデルとの分離）
+//
+// ハンドラー・イベント（SSE）・リアルタイム配信（WebSocket）・同期で返す記録の形式を定義し、モデルから変換します。
+// モデルをそのまま JSON にすると、論理削除の日時（deleted_at）や通知の送信状況などの内部の列、
+// リレーションで読み込んだユーザー（firebase_uid・電話番号・通知設定）がレスポンスに含まれるため、
+// レスポンスにはこのパッケージの形式を使用します。
+//
+//   - JSON の項目名はモデルと同じで、クライアント（同期の data など）はこれまでと同じ形式で記録を扱える
+//   - deleted_at、harvest_ready_notified_at、firebase_uid など内部の列は返さない
+//   - リレーションは読み込み済みの場合のみ返し、他のユーザーの情報は UserSummaryResponse（ID・表示名等）のみ
+package dto
+
+import (
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// =============================================================================
+// Common - 共通の項目と変換
+// =============================================================================
+
+// Base は記録の共通の項目です（model.BaseModel の deleted_at を除く）。
+type Base struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newBase はモデルの共通の項目を変換します。
+func newBase(m model.BaseModel) Base {
+	return Base{ID: m.ID, CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt}
+}
+
+// List はモデルの一覧をレスポンスの一覧に変換します（nil の場合も空の配列）。
+//
+// 引数:
+//   - rows: モデルの一覧
+//   - convert: 1件の変換（NewTaskResponse など）
+func List[T, R any](rows []T, convert func(*T) R) []R {
+	items := make([]R, len(rows))
+	for i := range rows {
+		items[i] = convert(&rows[i])
+	}
+	return items
+}
+
+// Page はモデルの1ページ分をレスポンスの1ページ分に変換します（カーソル・件数はそのまま）。
+//
+// 引数:
+//   - page: モデルの1ページ分
+//   - convert: 1件の変換（NewTaskResponse など）
+func Page[T, R any](page *pagination.Page[T], convert func(*T) R) *pagination.Page[R] {
+	return &pagination.Page[R]{
+		Items:      List(page.Items, convert),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+		Limit:      page.Limit,
+	}
+}
+
+// =============================================================================
+// User - ユーザー
+// =============================================================================
+
+// UserResponse はログイン中のユーザー本人の情報です（登録・ログイン・GET /auth/me）。
+// パスワードのハッシュ・Firebase UID・Webhook の署名用シークレット・ログイン失敗の状況は返しません。
+type UserResponse struct {
+	Base
+	Email                string                      `json:"email"`
+	DisplayName          string                      `json:"display_name"`
+	PhotoURL             string                      `json:"photo_url,omitempty"`
+	IsActive             bool                        `json:"is_active"`
+	NotificationSettings *model.NotificationSettings `json:"notification_settings,omitempty"`
+	BenchmarkOptIn       bool                        `json:"benchmark_opt_in"`
+	IsAdmin              bool                        `json:"is_admin"`
+	Timezone             string                      `json:"timezone"`
+	Locale               string                      `json:"locale"`
+	PhoneNumber          string                      `json:"phone_number,omitempty"`
+	PhoneVerifiedAt      *time.Time                  `json:"phone_verified_at,omitempty"`
+}
+
+// NewUserResponse はユーザー本人の情報に変換します。
+func NewUserResponse(u *model.User) UserResponse {
+	return UserResponse{
+		Base:                 newBase(u.BaseModel),
+		Email:                u.Email,
+		DisplayName:          u.DisplayName,
+		PhotoURL:             u.PhotoURL,
+		IsActive:             u.IsActive,
+		NotificationSettings: u.NotificationSettings,
+		BenchmarkOptIn:       u.BenchmarkOptIn,
+		IsAdmin:              u.IsAdmin,
+		Timezone:             u.Timezone,
+		Locale:               u.Locale,
+		PhoneNumber:          u.PhoneNumber,
+		PhoneVerifiedAt:      u.PhoneVerifiedAt,
+	}
+}
+
+// UserSummaryResponse は他のユーザーに見せるユーザーの情報です（庭のメンバーなど）。
+type UserSummaryResponse struct {
+	ID          uint   `json:"id"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	PhotoURL    string `json:"photo_url,omitempty"`
+}
+
+// newUserSummary は読み込み済みのユーザーを変換します（読み込んでいない場合は nil）。
+func newUserSummary(u *model.User) *UserSummaryResponse {
+	if u.ID == 0 {
+		return nil
+	}
+	return &UserSummaryResponse{ID: u.ID, Email: u.Email, DisplayName: u.DisplayName, PhotoURL: u.PhotoURL}
+}
+
+// =============================================================================
+// Garden - 庭・植物・お手入れ記録・メンバー（レガシー）
+// =============================================================================
+
+// GardenResponse は庭です（所有者のユーザーの情報は返しません）。
+type GardenResponse struct {
+	Base
+	UserID      uint    `json:"user_id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Location    string  `json:"location,omitempty"`
+	SizeM2      float64 `json:"size_m2,omitempty"`
+}
+
+// NewGardenResponse は庭を変換します。
+func NewGardenResponse(g *model.Garden) GardenResponse {
+	return GardenResponse{
+		Base:        newBase(g.BaseModel),
+		UserID:      g.UserID,
+		Name:        g.Name,
+		Description: g.Description,
+		Location:    g.Location,
+		SizeM2:      g.SizeM2,
+	}
+}
+
+// PlantResponse は植物です（garden は読み込み済みの場合のみ）。
+type PlantResponse struct {
+	Base
+	GardenID    uint            `json:"garden_id"`
+	Name        string          `json:"name"`
+	Species     string          `json:"species,omitempty"`
+	PlantedAt   time.Time       `json:"planted_at,omitempty"`
+	HarvestedAt time.Time       `json:"harvested_at,omitempty"`
+	Status      string          `json:"status"`
+	Notes       string          `json:"notes,omitempty"`
+	Garden      *GardenResponse `json:"garden,omitempty"`
+}
+
+// NewPlantResponse は植物を変換します。
+func NewPlantResponse(p *model.Plant) PlantResponse {
+	res := PlantResponse{
+		Base:        newBase(p.BaseModel),
+		GardenID:    p.GardenID,
+		Name:        p.Name,
+		Species:     p.Species,
+		PlantedAt:   p.PlantedAt,
+		HarvestedAt: p.HarvestedAt,
+		Status:      p.Status,
+		Notes:       p.Notes,
+	}
+	if p.Garden.ID != 0 {
+		garden := NewGardenResponse(&p.Garden)
+		res.Garden = &garden
+	}
+	return res
+}
+
+// CareLogResponse はお手入れ記録です（plant は読み込み済みの場合のみ）。
+type CareLogResponse struct {
+	Base
+	PlantID uint           `json:"plant_id"`
+	Type    string         `json:"type"`
+	Notes   string         `json:"notes,omitempty"`
+	CaredAt time.Time      `json:"cared_at"`
+	Plant   *PlantResponse `json:"plant,omitempty"`
+}
+
+// NewCareLogResponse はお手入れ記録を変換します。
+func NewCareLogResponse(l *model.CareLog) CareLogResponse {
+	res := CareLogResponse{
+		Base:    newBase(l.BaseModel),
+		PlantID: l.PlantID,
+		Type:    l.Type,
+		Notes:   l.Notes,
+		CaredAt: l.CaredAt,
+	}
+	if l.Plant.ID != 0 {
+		plant := NewPlantResponse(&l.Plant)
+		res.Plant = &plant
+	}
+	return res
+}
+
+// GardenMemberResponse は庭のメンバーです（user はメンバーの表示用の情報のみ）。
+type GardenMemberResponse struct {
+	Base
+	GardenID uint                 `json:"garden_id"`
+	UserID   uint                 `json:"user_id"`
+	Role     string               `json:"role"`
+	User     *UserSummaryResponse `json:"user,omitempty"`
+}
+
+// NewGardenMemberResponse は庭のメンバーを変換します。
+func NewGardenMemberResponse(m *model.GardenMember) GardenMemberResponse {
+	return GardenMemberResponse{
+		Base:     newBase(m.BaseModel),
+		GardenID: m.GardenID,
+		UserID:   m.UserID,
+		Role:     m.Role,
+		User:     newUserSummary(&m.User),
+	}
+}
+
+// =============================================================================
+// Task - タスク
+// =============================================================================
+
+// TaskResponse はタスクです（plant・parent_task は読み込み済みの場合のみ）。
+type TaskResponse struct {
+	Base
+	UserID      uint       `json:"user_id"`
+	PlantID     *uint      `json:"plant_id,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	DueDate     time.Time  `json:"due_date"`
+	Priority    string     `json:"priority"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	Recurrence         string     `json:"recurrence,omitempty"`
+	RecurrenceInterval int        `json:"recurrence_interval,omitempty"`
+	MaxOccurrences     *int       `json:"max_occurrences,omitempty"`
+	RecurrenceEndDate  *time.Time `json:"recurrence_end_date,omitempty"`
+	OccurrenceCount    int        `json:"occurrence_count"`
+	ParentTaskID       *uint      `json:"parent_task_id,omitempty"`
+	Version            uint       `json:"version"` // 楽観的ロックのバージョン（更新の If-Match・version に指定する）
+
+	Plant      *PlantResponse `json:"plant,omitempty"`
+	ParentTask *TaskResponse  `json:"parent_task,omitempty"`
+}
+
+// NewTaskResponse はタスクを変換します。
+func NewTaskResponse(t *model.Task) TaskResponse {
+	res := TaskResponse{
+		Base:               newBase(t.BaseModel),
+		UserID:             t.UserID,
+		PlantID:            t.PlantID,
+		Title:              t.Title,
+		Description:        t.Description,
+		DueDate:            t.DueDate,
+		Priority:           t.Priority,
+		Status:             t.Status,
+		CompletedAt:        t.CompletedAt,
+		Recurrence:         t.Recurrence,
+		RecurrenceInterval: t.RecurrenceInterval,
+		MaxOccurrences:     t.MaxOccurrences,
+		RecurrenceEndDate:  t.RecurrenceEndDate,
+		OccurrenceCount:    t.OccurrenceCount,
+		ParentTaskID:       t.ParentTaskID,
+		Version:            t.Version,
+	}
+	if t.Plant != nil {
+		plant := NewPlantResponse(t.Plant)
+		res.Plant = &plant
+	}
+	if t.ParentTask != nil {
+		parent := NewTaskResponse(t.ParentTask)
+		res.ParentTask = &parent
+	}
+	return res
+}
+
+// =============================================================================
+// Crop - 作物・成長記録・収穫記録・シーズンの集計
+// =============================================================================
+
+// CropResponse は作物です（収穫可能通知の送信状況は返しません）。
+// growth_records・harvests は読み込み済みの場合のみ返します。
+type CropResponse struct {
+	Base
+	UserID              uint       `json:"user_id"`
+	PlotID              *uint      `json:"plot_id,omitempty"`
+	OrganizationID      *uint      `json:"organization_id,omitempty"`
+	Name                string     `json:"name"`
+	Variety             string     `json:"variety,omitempty"`
+	PlantedDate         time.Time  `json:"planted_date"`
+	ExpectedHarvestDate time.Time  `json:"expected_harvest_date"`
+	Status              string     `json:"status"`
+	Notes               string     `json:"notes,omitempty"`
+	ArchivedAt          *time.Time `json:"archived_at,omitempty"`
+	Version             uint       `json:"version"`
+
+	GrowthRecords []GrowthRecordResponse `json:"growth_records,omitempty"`
+	Harvests      []HarvestResponse      `json:"harvests,omitempty"`
+}
+
+// NewCropResponse は作物を変換します。
+func NewCropResponse(c *model.Crop) CropResponse {
+	res := CropResponse{
+		Base:                newBase(c.BaseModel),
+		UserID:              c.UserID,
+		PlotID:              c.PlotID,
+		OrganizationID:      c.OrganizationID,
+		Name:                c.Name,
+		Variety:             c.Variety,
+		PlantedDate:         c.PlantedDate,
+		ExpectedHarvestDate: c.ExpectedHarvestDate,
+		Status:              c.Status,
+		Notes:               c.Notes,
+		ArchivedAt:          c.ArchivedAt,
+		Version:             c.Version,
+	}
+	if len(c.GrowthRecords) > 0 {
+		res.GrowthRecords = List(c.GrowthRecords, NewGrowthRecordResponse)
+	}
+	if len(c.Harvests) > 0 {
+		res.Harvests = List(c.Harvests, NewHarvestResponse)
+	}
+	return res
+}
+
+// newCropRelation は読み込み済みの作物を変換します（読み込んでいない場合は nil）。
+func newCropRelation(c *model.Crop) *CropResponse {
+	if c == nil || c.ID == 0 {
+		return nil
+	}
+	crop := NewCropResponse(c)
+	return &crop
+}
+
+// GrowthRecordResponse は成長記録です（crop は読み込み済みの場合のみ）。
+type GrowthRecordResponse struct {
+	Base
+	CropID      uint          `json:"crop_id"`
+	RecordDate  time.Time     `json:"record_date"`
+	GrowthStage string        `json:"growth_stage"`
+	Notes       string        `json:"notes,omitempty"`
+	ImageURL    string        `json:"image_url,omitempty"`
+	Crop        *CropResponse `json:"crop,omitempty"`
+}
+
+// NewGrowthRecordResponse は成長記録を変換します。
+func NewGrowthRecordResponse(r *model.GrowthRecord) GrowthRecordResponse {
+	return GrowthRecordResponse{
+		Base:        newBase(r.BaseModel),
+		CropID:      r.CropID,
+		RecordDate:  r.RecordDate,
+		GrowthStage: r.GrowthStage,
+		Notes:       r.Notes,
+		ImageURL:    r.ImageURL,
+		Crop:        newCropRelation(&r.Crop),
+	}
+}
+
+// HarvestResponse は収穫記録です（crop は読み込み済みの場合のみ）。
+type HarvestResponse struct {
+	Base
+	CropID       uint          `json:"crop_id"`
+	HarvestDate  time.Time     `json:"harvest_date"`
+	Quantity     float64       `json:"quantity"`
+	QuantityUnit string        `json:"quantity_unit"`
+	Quality      string        `json:"quality,omitempty"`
+	Notes        string        `json:"notes,omitempty"`
+	Crop         *CropResponse `json:"crop,omitempty"`
+}
+
+// NewHarvestResponse は収穫記録を変換します。
+func NewHarvestResponse(h *model.Harvest) HarvestResponse {
+	return HarvestResponse{
+		Base:         newBase(h.BaseModel),
+		CropID:       h.CropID,
+		HarvestDate:  h.HarvestDate,
+		Quantity:     h.Quantity,
+		QuantityUnit: h.QuantityUnit,
+		Quality:      h.Quality,
+		Notes:        h.Notes,
+		Crop:         newCropRelation(&h.Crop),
+	}
+}
+
+// SeasonSummaryResponse はシーズン・区画ごとの生産性の記録です。
+type SeasonSummaryResponse struct {
+	Base
+	UserID         uint      `json:"user_id"`
+	Season         int       `json:"season"`
+	PlotID         uint      `json:"plot_id"`
+	PlotName       string    `json:"plot_name,omitempty"`
+	AreaM2         float64   `json:"area_m2"`
+	CropsGrown     int       `json:"crops_grown"`
+	CropsHarvested int       `json:"crops_harvested"`
+	CropsFailed    int       `json:"crops_failed"`
+	HarvestCount   int       `json:"harvest_count"`
+	TotalKg        float64   `json:"total_kg"`
+	KgPerM2        float64   `json:"kg_per_m2"`
+	ClosedAt       time.Time `json:"closed_at"`
+}
+
+// NewSeasonSummaryResponse はシーズンの集計を変換します。
+func NewSeasonSummaryResponse(s *model.SeasonSummary) SeasonSummaryResponse {
+	return SeasonSummaryResponse{
+		Base:           newBase(s.BaseModel),
+		UserID:         s.UserID,
+		Season:         s.Season,
+		PlotID:         s.PlotID,
+		PlotName:       s.PlotName,
+		AreaM2:         s.AreaM2,
+		CropsGrown:     s.CropsGrown,
+		CropsHarvested: s.CropsHarvested,
+		CropsFailed:    s.CropsFailed,
+		HarvestCount:   s.HarvestCount,
+		TotalKg:        s.TotalKg,
+		KgPerM2:        s.KgPerM2,
+		ClosedAt:       s.ClosedAt,
+	}
+}
+
+// =============================================================================
+// Plot - 区画・配置
+// =============================================================================
+
+// PlotResponse は区画です（plot_assignments は読み込み済みの場合のみ）。
+type PlotResponse struct {
+	Base
+	UserID         uint    `json:"user_id"`
+	OrganizationID *uint   `json:"organization_id,omitempty"`
+	Name           string  `json:"name"`
+	Width          float64 `json:"width"`
+	Height         float64 `json:"height"`
+	SoilType       string  `json:"soil_type,omitempty"`
+	Sunlight       string  `json:"sunlight,omitempty"`
+	Status         string  `json:"status"`
+	PositionX      *int    `json:"position_x,omitempty"`
+	PositionY      *int    `json:"position_y,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+	Version        uint    `json:"version"`
+
+	PlotAssignments []PlotAssignmentResponse `json:"plot_assignments,omitempty"`
+}
+
+// NewPlotResponse は区画を変換します。
+func NewPlotResponse(p *model.Plot) PlotResponse {
+	res := PlotResponse{
+		Base:           newBase(p.BaseModel),
+		UserID:         p.UserID,
+		OrganizationID: p.OrganizationID,
+		Name:           p.Name,
+		Width:          p.Width,
+		Height:         p.Height,
+		SoilType:       p.SoilType,
+		Sunlight:       p.Sunlight,
+		Status:         p.Status,
+		PositionX:      p.PositionX,
+		PositionY:      p.PositionY,
+		Notes:          p.Notes,
+		Version:        p.Version,
+	}
+	if len(p.PlotAssignments) > 0 {
+		res.PlotAssignments = List(p.PlotAssignments, NewPlotAssignmentResponse)
+	}
+	return res
+}
+
+// PlotAssignmentResponse は区画への作物の配置です（plot・crop は読み込み済みの場合のみ）。
+type PlotAssignmentResponse struct {
+	Base
+	PlotID         uint          `json:"plot_id"`
+	CropID         uint          `json:"crop_id"`
+	AssignedDate   time.Time     `json:"assigned_date"`
+	UnassignedDate *time.Time    `json:"unassigned_date,omitempty"`
+	Plot           *PlotResponse `json:"plot,omitempty"`
+	Crop           *CropResponse `json:"crop,omitempty"`
+}
+
+// NewPlotAssignmentResponse は区画への作物の配置を変換します。
+func NewPlotAssignmentResponse(a *model.PlotAssignment) PlotAssignmentResponse {
+	res := PlotAssignmentResponse{
+		Base:           newBase(a.BaseModel),
+		PlotID:         a.PlotID,
+		CropID:         a.CropID,
+		AssignedDate:   a.AssignedDate,
+		UnassignedDate: a.UnassignedDate,
+		Crop:           newCropRelation(&a.Crop),
+	}
+	if a.Plot.ID != 0 {
+		plot := NewPlotResponse(&a.Plot)
+		res.Plot = &plot
+	}
+	return res
+}
+
+// PlotLayoutItemResponse はレイアウト表示の1区画です（区画・現在の配置・配置中の作物）。
+type PlotLayoutItemResponse struct {
+	Plot             PlotResponse            `json:"plot"`
+	ActiveAssignment *PlotAssignmentResponse `json:"active_assignment,omitempty"`
+	ActiveCrop       *CropResponse           `json:"active_crop,omitempty"`
+}
+
+// NewPlotLayoutItemResponse はレイアウト表示の1区画を変換します。
+//
+// 引数:
+//   - plot: 区画
+//   - assignment: 現在の配置（配置がない場合は nil）
+//   - crop: 配置中の作物（配置がない場合は nil）
+func NewPlotLayoutItemResponse(plot *model.Plot, assignment *model.PlotAssignment, crop *model.Crop) PlotLayoutItemResponse {
+	res := PlotLayoutItemResponse{Plot: NewPlotResponse(plot), ActiveCrop: newCropRelation(crop)}
+	if assignment != nil {
+		active := NewPlotAssignmentResponse(assignment)
+		res.ActiveAssignment = &active
+	}
+	return res
+}
+
+// PlotHistoryItemResponse は区画の栽培履歴の1件です（配置と作物）。
+type PlotHistoryItemResponse struct {
+	Assignment PlotAssignmentResponse `json:"assignment"`
+	Crop       *CropResponse          `json:"crop,omitempty"`
+}
+
+// NewPlotHistoryItemResponse は区画の栽培履歴の1件を変換します。
+func NewPlotHistoryItemResponse(assignment *model.PlotAssignment, crop *model.Crop) PlotHistoryItemResponse {
+	return PlotHistoryItemResponse{Assignment: NewPlotAssignmentResponse(assignment), Crop: newCropRelation(crop)}
+}
+
+// =============================================================================
+// Share - 共有リンク
+// =============================================================================
+
+// ShareTokenResponse は共有リンクのトークンです（発行したユーザー本人にのみ返します）。
+type ShareTokenResponse struct {
+	Base
+	UserID    uint       `json:"user_id"`
+	Token     string     `json:"token,omitempty"` // 発行時のみ（保存するのはハッシュのため、一覧では返さない）
+	Scope     string     `json:"scope"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewShareTokenResponse は共有リンクのトークンを変換します。
+func NewShareTokenResponse(t *model.ShareToken) ShareTokenResponse {
+	return ShareTokenResponse{
+		Base:      newBase(t.BaseModel),
+		UserID:    t.UserID,
+		Token:     t.Token,
+		Scope:     t.Scope,
+		ExpiresAt: t.ExpiresAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
+
+// =============================================================================
+// Announcement - お知らせ（管理者）
+// =============================================================================
+
+// AnnouncementResponse は管理者のお知らせと配信結果です（配信の進捗の位置は返しません）。
+type AnnouncementResponse struct {
+	ID          uint      `json:"id"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Category    string    `json:"category"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Status      string    `json:"status"`
+	CreatedBy   uint      `json:"created_by"`
+
+	AudienceLocale     string `json:"audience_locale,omitempty"`
+	AudiencePlatform   string `json:"audience_platform,omitempty"`
+	AudienceActiveDays int    `json:"audience_active_days,omitempty"`
+
+	TargetCount    int        `json:"target_count"`
+	SentCount      int        `json:"sent_count"`
+	FailedCount    int        `json:"failed_count"`
+	DeferredCount  int        `json:"deferred_count"`
+	DuplicateCount int        `json:"duplicate_count"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// NewAnnouncementResponse はお知らせを変換します。
+func NewAnnouncementResponse(a *model.Announcement) AnnouncementResponse {
+	return AnnouncementResponse{
+		ID:                 a.ID,
+		Title:              a.Title,
+		Body:               a.Body,
+		Category:           a.Category,
+		ScheduledAt:        a.ScheduledAt,
+		Status:             a.Status,
+		CreatedBy:          a.CreatedBy,
+		AudienceLocale:     a.AudienceLocale,
+		AudiencePlatform:   a.AudiencePlatform,
+		AudienceActiveDays: a.AudienceActiveDays,
+		TargetCount:        a.TargetCount,
+		SentCount:          a.SentCount,
+		FailedCount:        a.FailedCount,
+		DeferredCount:      a.DeferredCount,
+		DuplicateCount:     a.DuplicateCount,
+		CompletedAt:        a.CompletedAt,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+// =============================================================================
+// Organization - 組織
+// =============================================================================
+
+// OrganizationResponse は組織です（上限が 0 の場合は無制限）。
+type OrganizationResponse struct {
+	Base
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	OwnerID    uint   `json:"owner_id"`
+	MaxPlots   int    `json:"max_plots"`
+	MaxCrops   int    `json:"max_crops"`
+	MaxMembers int    `json:"max_members"`
+}
+
+// NewOrganizationResponse は組織を変換します。
+func NewOrganizationResponse(o *model.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		Base:       newBase(o.BaseModel),
+		Name:       o.Name,
+		Type:       o.Type,
+		OwnerID:    o.OwnerID,
+		MaxPlots:   o.MaxPlots,
+		MaxCrops:   o.MaxCrops,
+		MaxMembers: o.MaxMembers,
+	}
+}
+
+// OrganizationMemberResponse は組織のメンバーです（user はメンバーの表示用の情報のみ）。
+type OrganizationMemberResponse struct {
+	Base
+	OrganizationID uint                 `json:"organization_id"`
+	UserID         uint                 `json:"user_id"`
+	Role           string               `json:"role"`
+	User           *UserSummaryResponse `json:"user,omitempty"`
+}
+
+// NewOrganizationMemberResponse は組織のメンバーを変換します。
+func NewOrganizationMemberResponse(m *model.OrganizationMember) OrganizationMemberResponse {
+	return OrganizationMemberResponse{
+		Base:           newBase(m.BaseModel),
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Role:           m.Role,
+		User:           newUserSummary(&m.User),
+	}
+}
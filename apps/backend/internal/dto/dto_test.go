@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Response DTO Tests - レスポンスの形式のテスト
+// =============================================================================
+// テスト対象:
+//   - NewXxxResponse: 内部の列・ユーザーの機密情報をレスポンスに含めないこと
+//   - List / Page: 一覧・1ページ分の変換
+
+// sensitiveKeys はレスポンスに含めてはいけない項目です。
+var sensitiveKeys = []string{
+	"deleted_at", "password", "password_hash", "firebase_uid", "webhook_secret",
+	"failed_login_count", "locked_until", "harvest_ready_notified_at", "last_user_id",
+	"notification_preferences",
+}
+
+// leakingUser は機密情報を含むユーザーです（リレーションで読み込んだ場合の想定）。
+func leakingUser() model.User {
+	return model.User{
+		BaseModel:     model.BaseModel{ID: 9, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		FirebaseUID:   "firebase-uid",
+		Email:         "owner@example.com",
+		PasswordHash:  "hash",
+		DisplayName:   "所有者",
+		PhoneNumber:   "+819012345678",
+		WebhookSecret: "secret",
+		NotificationSettings: &model.NotificationSettings{
+			SlackWebhookURL: "https://hooks.slack.com/services/secret",
+		},
+	}
+}
+
+// collectKeys は JSON の全ての項目名（入れ子を含む）を集めます。
+func collectKeys(t *testing.T, v any) map[string]bool {
+	t.Helper()
+	body, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %v", v, err)
+	}
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Failed to decode %s: %v", body, err)
+	}
+	keys := map[string]bool{}
+	var walk func(any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			for key, child := range n {
+				keys[key] = true
+				walk(child)
+			}
+		case []any:
+			for _, child := range n {
+				walk(child)
+			}
+		}
+	}
+	walk(decoded)
+	return keys
+}
+
+// TestResponses_NoSensitiveFields は内部の列・機密情報を含めないことのテストです。
+// 期待動作:
+//   - deleted_at・パスワード・Firebase UID・Webhook の署名用シークレット・通知の送信状況を含めない
+//   - リレーションのユーザーは他のユーザーに見せる情報（表示名等）のみで、電話番号・通知設定を含めない
+//   - 読み込み済みのリレーション（作物の収穫記録、配置の作物など）は返す
+func TestResponses_NoSensitiveFields(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	deleted := model.BaseModel{ID: 1, CreatedAt: now, UpdatedAt: now, DeletedAt: gorm.DeletedAt{Time: now, Valid: true}}
+	user := leakingUser()
+	garden := model.Garden{BaseModel: deleted, UserID: user.ID, Name: "庭", User: user}
+	plant := model.Plant{BaseModel: deleted, GardenID: garden.ID, Name: "トマト", Garden: garden}
+	crop := model.Crop{
+		BaseModel:              deleted,
+		UserID:                 user.ID,
+		Name:                   "トマト",
+		HarvestReadyNotifiedAt: &now,
+		User:                   user,
+		Harvests:               []model.Harvest{{BaseModel: deleted, CropID: 1, Quantity: 1, QuantityUnit: "kg"}},
+		GrowthRecords:          []model.GrowthRecord{{BaseModel: deleted, CropID: 1, GrowthStage: "seedling"}},
+	}
+	plot := model.Plot{BaseModel: deleted, UserID: user.ID, Name: "A-1", Width: 1, Height: 1, User: user}
+	assignment := model.PlotAssignment{BaseModel: deleted, PlotID: plot.ID, CropID: crop.ID, Plot: plot, Crop: crop}
+	tests := []struct {
+		name     string
+		response any
+		want     []string
+	}{
+		{"user", NewUserResponse(&user), []string{"email", "phone_number", "notification_settings"}},
+		{"garden", NewGardenResponse(&garden), []string{"name"}},
+		{"plant", NewPlantResponse(&plant), []string{"garden"}},
+		{"care log", NewCareLogResponse(&model.CareLog{BaseModel: deleted, PlantID: plant.ID, Plant: plant}), []string{"plant"}},
+		{"garden member", NewGardenMemberResponse(&model.GardenMember{BaseModel: deleted, UserID: user.ID, User: user}), []string{"user", "display_name"}},
+		{"task", NewTaskResponse(&model.Task{BaseModel: deleted, UserID: user.ID, User: user, Plant: &plant}), []string{"plant"}},
+		{"crop", NewCropResponse(&crop), []string{"harvests", "growth_records"}},
+		{"harvest", NewHarvestResponse(&model.Harvest{BaseModel: deleted, CropID: crop.ID, Crop: crop}), []string{"crop"}},
+		{"growth record", NewGrowthRecordResponse(&model.GrowthRecord{BaseModel: deleted, CropID: crop.ID, Crop: crop}), []string{"crop"}},
+		{"season summary", NewSeasonSummaryResponse(&model.SeasonSummary{BaseModel: deleted, UserID: user.ID}), []string{"kg_per_m2"}},
+		{"plot", NewPlotResponse(&plot), []string{"width"}},
+		{"plot assignment", NewPlotAssignmentResponse(&assignment), []string{"plot", "crop"}},
+		{"plot layout", NewPlotLayoutItemResponse(&plot, &assignment, &crop), []string{"active_assignment", "active_crop"}},
+		{"plot history", NewPlotHistoryItemResponse(&assignment, &crop), []string{"assignment", "crop"}},
+		{"share token", NewShareTokenResponse(&model.ShareToken{BaseModel: deleted, UserID: user.ID, Token: "token", User: user}), []string{"token"}},
+		{"announcement", NewAnnouncementResponse(&model.Announcement{ID: 1, LastUserID: 42}), []string{"sent_count"}},
+		{"organization member", NewOrganizationMemberResponse(&model.OrganizationMember{BaseModel: deleted, UserID: user.ID, Role: "admin", User: user}), []string{"user", "role"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			keys := collectKeys(t, tt.response)
+
+			// Assert
+			for _, key := range sensitiveKeys {
+				if keys[key] {
+					t.Errorf("Expected %q to be omitted, got keys %v", key, keys)
+				}
+			}
+			if tt.name != "user" && (keys["phone_number"] || keys["notification_settings"]) {
+				t.Errorf("Expected related user details to be omitted, got keys %v", keys)
+			}
+			for _, key := range tt.want {
+				if !keys[key] {
+					t.Errorf("Expected %q in response, got keys %v", key, keys)
+				}
+			}
+		})
+	}
+}
+
+// TestResponses_UnloadedRelations は読み込んでいないリレーションのテストです。
+// 期待動作:
+//   - 読み込んでいないリレーション（ID が 0）は空のオブジェクトではなく省略する
+func TestResponses_UnloadedRelations(t *testing.T) {
+	// Arrange
+	harvest := model.Harvest{BaseModel: model.BaseModel{ID: 1}, CropID: 2, Quantity: 1, QuantityUnit: "kg"}
+	member := model.GardenMember{BaseModel: model.BaseModel{ID: 1}, GardenID: 1, UserID: 2}
+
+	// Act
+	harvestKeys := collectKeys(t, NewHarvestResponse(&harvest))
+	memberKeys := collectKeys(t, NewGardenMemberResponse(&member))
+
+	// Assert
+	if harvestKeys["crop"] || memberKeys["user"] {
+		t.Errorf("Expected unloaded relations to be omitted, got %v %v", harvestKeys, memberKeys)
+	}
+}
+
+// TestListAndPage は一覧・1ページ分の変換のテストです。
+// 期待動作:
+//   - nil の一覧は空の配列（null ではない）
+//   - 1ページ分の next_cursor・has_more・limit はそのまま
+func TestListAndPage(t *testing.T) {
+	// Arrange
+	page := &pagination.Page[model.Task]{
+		Items:      []model.Task{{BaseModel: model.BaseModel{ID: 3}, Title: "水やり"}},
+		NextCursor: pagination.EncodeCursor(3),
+		HasMore:    true,
+		Limit:      1,
+	}
+
+	// Act
+	empty, _ := json.Marshal(List([]model.Task(nil), NewTaskResponse))
+	converted := Page(page, NewTaskResponse)
+
+	// Assert
+	if string(empty) != "[]" {
+		t.Errorf("Expected empty array, got %s", empty)
+	}
+	if len(converted.Items) != 1 || converted.Items[0].ID != 3 || converted.Items[0].Title != "水やり" {
+		t.Errorf("Unexpected items: %+v", converted.Items)
+	}
+	if converted.NextCursor != page.NextCursor || !converted.HasMore || converted.Limit != 1 {
+		t.Errorf("Expected paging fields to be kept, got %+v", converted)
+	}
+}
@@ -0,0 +1,356 @@
+// Package errorreport - エラーの通知（Sentry・Rollbar）
+//
+// HTTPリクエストの 5xx のエラーとパニックを、リクエストの情報（メソッド・ルート・リクエストID・トレースID）・
+// 認証ユーザーID・スタックトレースとともにエラーの通知サービスに送信します（ERROR_REPORT_PROVIDER）。
+//   - sentry: Sentry の Envelope API（SENTRY_DSN、sentry.go）
+//   - rollbar: Rollbar の Item API（ROLLBAR_ACCESS_TOKEN、rollbar.go）
+//
+// 通知は apperrors.NewErrorHandler（エラーのレスポンス）で送信し、パニックは Recover ミドルウェアの
+// LogErrorFunc（Recovered）でパニックの位置のスタックトレースを記録してからエラーのレスポンスにします。
+// 送信はバックグラウンドで行い、送信待ちが多い場合は破棄するため、リクエストの応答を遅らせません。
+// 送信する割合は ERROR_REPORT_SAMPLE_RATE（エラー）・ERROR_REPORT_PANIC_SAMPLE_RATE（パニック）で設定します。
+package errorreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// エラーの通知サービス（ERROR_REPORT_PROVIDER）
+const (
+	ProviderSentry  = "sentry"
+	ProviderRollbar = "rollbar"
+)
+
+const (
+	// reportQueueSize は送信待ちのエラーの最大の件数です（超えたエラーは破棄）
+	reportQueueSize = 100
+	// reportRequestTimeout は通知サービスへのHTTPタイムアウト
+	reportRequestTimeout = 10 * time.Second
+	// maxStackDepth は記録するスタックトレースの最大の深さです
+	maxStackDepth = 64
+	// appModule はアプリケーションのパッケージの接頭辞です（スタックトレースの in_app）
+	appModule = "github.com/secure-scorecard/backend/"
+	// notifierName は通知サービスに送信するクライアントの名前です
+	notifierName = "home-garden-api"
+)
+
+// Event は送信するエラーです。
+type Event struct {
+	Err       error
+	Panic     bool      // Recover ミドルウェアで回復したパニック
+	Stack     []uintptr // エラー・パニックの位置のスタックトレース（runtime.Callers）
+	Method    string
+	Route     string // ルート（"GET /api/v1/crops/:id" のパス部分）
+	URL       string // クエリを除くURL
+	Status    int    // レスポンスのステータスコード
+	RequestID string
+	UserID    uint // 認証ユーザーID（未認証の場合は 0）
+	TraceID   string
+	SpanID    string
+	Timestamp time.Time
+}
+
+// Frame はスタックトレースの1フレームです。
+type Frame struct {
+	Function string // 関数名（例: (*Handler).GetCrop）
+	Module   string // パッケージ（例: github.com/secure-scorecard/backend/internal/handler）
+	File     string
+	Line     int
+	InApp    bool // アプリケーションのパッケージのフレーム
+}
+
+// transport はエラーを通知サービスに送信します（sentryTransport・rollbarTransport）。
+type transport interface {
+	send(ctx context.Context, event Event) error
+}
+
+// stackTracer はスタックトレースを記録したエラーです（apperrors.AppError・PanicError）。
+type stackTracer interface {
+	Stack() []uintptr
+}
+
+// Reporter はエラーをバックグラウンドで通知サービスに送信します。
+// nil の Reporter（通知が無効）のメソッドは何もしません。
+type Reporter struct {
+	provider        string
+	transport       transport
+	sampleRate      float64
+	panicSampleRate float64
+	sample          func() float64 // 0〜1 の乱数（テストで固定）
+
+	mu     sync.Mutex
+	closed bool
+	events chan Event
+	done   chan struct{}
+}
+
+// New は設定に応じた Reporter を作成し、送信を開始します。
+//
+// 引数:
+//   - cfg: エラーの通知の設定（Provider が空の場合は無効）
+//   - env: 実行環境（APP_ENV、エラーの environment）
+//
+// 戻り値:
+//   - *Reporter: エラーの通知（無効の場合は nil）
+//   - error: 通知サービス・DSN・アクセストークンの設定が不正な場合のエラー
+func New(cfg config.ErrorReportConfig, env string) (*Reporter, error) {
+	client := &http.Client{Timeout: reportRequestTimeout}
+	var t transport
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderSentry:
+		sentry, err := newSentryTransport(client, cfg.SentryDSN, env, cfg.Release)
+		if err != nil {
+			return nil, err
+		}
+		t = sentry
+	case ProviderRollbar:
+		if cfg.RollbarAccessToken == "" {
+			return nil, fmt.Errorf("ROLLBAR_ACCESS_TOKEN is required for the rollbar error report provider")
+		}
+		t = newRollbarTransport(client, cfg.RollbarAccessToken, env, cfg.Release)
+	default:
+		return nil, fmt.Errorf("unsupported ERROR_REPORT_PROVIDER: %s", cfg.Provider)
+	}
+	return newReporter(cfg.Provider, t, cfg.SampleRate, cfg.PanicSampleRate), nil
+}
+
+// newReporter は送信を開始した Reporter を作成します。
+func newReporter(provider string, t transport, sampleRate, panicSampleRate float64) *Reporter {
+	r := &Reporter{
+		provider:        provider,
+		transport:       t,
+		sampleRate:      sampleRate,
+		panicSampleRate: panicSampleRate,
+		sample:          rand.Float64,
+		events:          make(chan Event, reportQueueSize),
+		done:            make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Provider は通知サービスの名前を返します（無効の場合は空）。
+func (r *Reporter) Provider() string {
+	if r == nil {
+		return ""
+	}
+	return r.provider
+}
+
+// ReportHTTP はHTTPリクエストのエラーを送信します（apperrors.NewErrorHandler から 5xx のエラーで呼び出す）。
+// クライアントの切断（context.Canceled）は送信しません。
+//
+// 引数:
+//   - c: エラーになったリクエスト
+//   - err: ハンドラ・ミドルウェアのエラー（パニックは PanicError）
+//   - status: レスポンスのステータスコード
+func (r *Reporter) ReportHTTP(c echo.Context, err error, status int) {
+	if r == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	req := c.Request()
+	ctx := req.Context()
+	event := Event{
+		Err:       err,
+		Method:    req.Method,
+		Route:     c.Path(),
+		URL:       c.Scheme() + "://" + req.Host + req.URL.Path,
+		Status:    status,
+		RequestID: logging.RequestID(ctx),
+		UserID:    logging.UserID(ctx),
+		Timestamp: time.Now(),
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		event.TraceID = span.TraceID().String()
+		event.SpanID = span.SpanID().String()
+	}
+	var panicErr *PanicError
+	event.Panic = errors.As(err, &panicErr)
+	var st stackTracer
+	if errors.As(err, &st) {
+		event.Stack = st.Stack()
+	} else {
+		// スタックトレースのないエラーはエラーのレスポンスを作成した位置
+		event.Stack = callers(2)
+	}
+	r.enqueue(event)
+}
+
+// enqueue はサンプリングしたエラーを送信待ちに追加します（送信待ちが多い場合は破棄）。
+func (r *Reporter) enqueue(event Event) {
+	rate := r.sampleRate
+	if event.Panic {
+		rate = r.panicSampleRate
+	}
+	if rate < 1 && r.sample() >= rate {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.events <- event:
+	default:
+		logging.FromContext(context.Background()).Warn("Error report dropped, too many pending reports",
+			"provider", r.provider, "request_id", event.RequestID)
+	}
+}
+
+// run は送信待ちのエラーを順に送信します。
+func (r *Reporter) run() {
+	defer close(r.done)
+	for event := range r.events {
+		ctx, cancel := context.WithTimeout(context.Background(), reportRequestTimeout)
+		if err := r.transport.send(ctx, event); err != nil {
+			logging.FromContext(ctx).Warn("Failed to send error report",
+				"provider", r.provider, "request_id", event.RequestID, "error", err)
+		}
+		cancel()
+	}
+}
+
+// Close は新しいエラーの受け付けを停止し、送信待ちのエラーを送信します（シャットダウン時に呼び出す）。
+//
+// 戻り値:
+//   - error: ctx の期限までに送信が終わらなかった場合のエラー
+func (r *Reporter) Close(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error reports were not sent before shutdown: %w", ctx.Err())
+	}
+}
+
+// =============================================================================
+// Panics - パニックのスタックトレース
+// =============================================================================
+
+// PanicError は Recover ミドルウェアで回復したパニックです（パニックの位置のスタックトレースを含む）。
+type PanicError struct {
+	err   error
+	stack []uintptr
+}
+
+// Error はパニックの内容を返します。
+func (e *PanicError) Error() string {
+	return "panic: " + e.err.Error()
+}
+
+// Unwrap はパニックの値のエラーを返します。
+func (e *PanicError) Unwrap() error {
+	return e.err
+}
+
+// Stack はパニックの位置のスタックトレースを返します。
+func (e *PanicError) Stack() []uintptr {
+	return e.stack
+}
+
+// Recovered は Recover ミドルウェアの LogErrorFunc です（middleware.RecoverConfig）。
+// パニックをスタックトレースとともにログに出力し、パニックの位置のスタックトレースを記録した PanicError を返します。
+// PanicError は ErrorHandler で 500 のレスポンスにし、エラーの通知に送信します。
+func Recovered(c echo.Context, err error, stack []byte) error {
+	logging.FromContext(c.Request().Context()).Error("Panic recovered", "error", err, "stack", string(stack))
+	// Recover ミドルウェアの defer の中のため、パニックした goroutine のスタック（runtime.gopanic より後ろ）を含む
+	return &PanicError{err: err, stack: callers(2)}
+}
+
+// callers は呼び出し元のスタックトレースを返します。
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	return pcs[:n]
+}
+
+// Frames はスタックトレースを古い呼び出しから順のフレームに変換します（Sentry・Rollbar の形式）。
+// パニックのスタックトレースは runtime.gopanic より後ろの回復の処理と、
+// ランタイムが起こしたパニック（nil map への書き込みなど）のランタイムの関数を除きます。
+func Frames(stack []uintptr) []Frame {
+	var frames []Frame
+	panicked := false
+	iter := runtime.CallersFrames(stack)
+	for {
+		f, more := iter.Next()
+		switch {
+		case f.Function == "runtime.gopanic":
+			// 新しい呼び出しから順のため、ここまでは Recover ミドルウェアの処理
+			frames = frames[:0]
+			panicked = true
+		case panicked && len(frames) == 0 && isRuntimeFunction(f.Function):
+			// パニックを起こしたランタイムの関数（runtime.panicmem、runtime.mapassign_faststr など）
+		case f.Function != "":
+			module, function := splitFunction(f.Function)
+			frames = append(frames, Frame{
+				Function: function,
+				Module:   module,
+				File:     f.File,
+				Line:     f.Line,
+				InApp:    strings.HasPrefix(f.Function, appModule),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	// 古い呼び出しから順にする
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// isRuntimeFunction はランタイムの関数の場合に true を返します。
+func isRuntimeFunction(name string) bool {
+	return strings.HasPrefix(name, "runtime.") || strings.HasPrefix(name, "internal/runtime/")
+}
+
+// splitFunction は runtime の関数名をパッケージと関数に分けます。
+// 例: github.com/secure-scorecard/backend/internal/handler.(*Handler).GetCrop → (…/handler, (*Handler).GetCrop)
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// errorType はエラーの種類の名前です（パニックはパニックの値のエラーの型）。
+func errorType(event Event) string {
+	err := event.Err
+	if event.Panic {
+		if inner := errors.Unwrap(err); inner != nil {
+			err = inner
+		}
+	}
+	return fmt.Sprintf("%T", err)
+}
@@ -0,0 +1,261 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/secure-scorecard/backend/internal/config"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/logging"
+)
+
+// =============================================================================
+// Error Report Tests - エラーの通知のテスト
+// =============================================================================
+// テスト対象:
+//   - Reporter（sentry）: 5xx のエラー・パニックのイベント（リクエストID・ユーザーID・スタックトレース）、Envelope API の認証
+//   - Reporter（rollbar）: Item API のエラー（アクセストークン・ユーザーID・スタックトレース）
+//   - Reporter: エラー・パニックのサンプリング、クライアントの切断、停止後のエラー
+//   - New: 通知サービス・DSN・アクセストークンの設定の検証
+
+// capturedRequest は通知サービスが受信したリクエストです。
+type capturedRequest struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// captureServer は受信したリクエストを記録する通知サービスです。
+func captureServer(t *testing.T) (*httptest.Server, func() []capturedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []capturedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, capturedRequest{path: r.URL.Path, header: r.Header.Clone(), body: body})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+// serveErrors はエラーの通知を設定した Echo で、パニック・内部エラー・404 のリクエストを実行します。
+// パニック・内部エラーのリクエストはユーザーID 42 で認証したリクエストです。
+func serveErrors(t *testing.T, reporter *Reporter) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.NewErrorHandler(reporter)
+	e.Use(logging.Middleware())
+	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{LogErrorFunc: Recovered}))
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(logging.WithUserID(c.Request().Context(), 42)))
+			return next(c)
+		}
+	}
+	e.GET("/api/v1/crops/:id", func(c echo.Context) error {
+		var crops map[string]int
+		crops["tomato"]++ // nil map への書き込みでパニック
+		return nil
+	}, authenticate)
+	e.GET("/api/v1/harvests", func(c echo.Context) error {
+		return apperrors.NewInternalError("Failed to fetch harvests")
+	}, authenticate)
+	e.GET("/api/v1/missing", func(c echo.Context) error {
+		return apperrors.NewNotFoundError("Crop")
+	})
+
+	for _, path := range []string{"/api/v1/crops/7?token=secret", "/api/v1/harvests", "/api/v1/missing"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-"+strings.Split(path, "/")[3][:5])
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+	}
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+// TestReporter_Sentry は Sentry へのエラーの送信のテストです。
+// 期待動作:
+//   - DSN のプロジェクトの Envelope API に公開鍵で認証して送信する
+//   - 5xx のエラー・パニックのみ送信し、404 は送信しない
+//   - イベントにリクエストID・ユーザーID・ルート・クエリを除くURL、スタックトレース（最後がエラー・パニックの位置）を含める
+//   - パニックは handled: false の fatal
+func TestReporter_Sentry(t *testing.T) {
+	// Arrange
+	server, received := captureServer(t)
+	dsn := strings.Replace(server.URL, "http://", "http://publickey@", 1) + "/42"
+	reporter, err := New(config.ErrorReportConfig{Provider: ProviderSentry, SentryDSN: dsn, Release: "abc123", SampleRate: 1, PanicSampleRate: 1}, "production")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	// Act
+	serveErrors(t, reporter)
+
+	// Assert
+	requests := received()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 Sentry events for the panic and the internal error, got %d", len(requests))
+	}
+	if requests[0].path != "/api/42/envelope/" || !strings.Contains(requests[0].header.Get("X-Sentry-Auth"), "sentry_key=publickey") {
+		t.Errorf("Expected the envelope API of the project with the public key, got %s %q", requests[0].path, requests[0].header.Get("X-Sentry-Auth"))
+	}
+	events := make([]sentryEvent, len(requests))
+	for i, r := range requests {
+		lines := bytes.Split(bytes.TrimSpace(r.body), []byte("\n"))
+		if len(lines) != 3 {
+			t.Fatalf("Expected the envelope header, item header and event, got %d lines", len(lines))
+		}
+		if err := json.Unmarshal(lines[2], &events[i]); err != nil {
+			t.Fatalf("Failed to decode the event: %v", err)
+		}
+	}
+	panicEvent, internal := events[0], events[1]
+	if panicEvent.Level != "fatal" || panicEvent.Exception.Values[0].Mechanism.Handled {
+		t.Errorf("Expected an unhandled fatal event for the panic, got %s %+v", panicEvent.Level, panicEvent.Exception.Values[0].Mechanism)
+	}
+	if panicEvent.Tags["request_id"] != "req-crops" || panicEvent.User == nil || panicEvent.User.ID != "42" {
+		t.Errorf("Expected the request ID and user ID, got %v %+v", panicEvent.Tags, panicEvent.User)
+	}
+	if panicEvent.Transaction != "GET /api/v1/crops/:id" || strings.Contains(panicEvent.Request.URL, "secret") || panicEvent.Environment != "production" || panicEvent.Release != "abc123" {
+		t.Errorf("Expected the route, the URL without the query, environment and release, got %+v", panicEvent)
+	}
+	for name, event := range map[string]sentryEvent{"panic": panicEvent, "internal error": internal} {
+		frames := event.Exception.Values[0].Stacktrace.Frames
+		if len(frames) == 0 {
+			t.Fatalf("Expected the stack trace of the %s", name)
+		}
+		last := frames[len(frames)-1]
+		if !strings.Contains(last.Function, "serveErrors") || !last.InApp {
+			t.Errorf("Expected the %s in the handler as the last frame, got %+v", name, last)
+		}
+	}
+	if internal.Level != "error" || internal.Exception.Values[0].Value != "[INTERNAL_ERROR] Failed to fetch harvests" {
+		t.Errorf("Expected the internal error, got %s %+v", internal.Level, internal.Exception.Values[0])
+	}
+}
+
+// TestReporter_Rollbar は Rollbar へのエラーの送信のテストです。
+// 期待動作:
+//   - Item API にアクセストークンで認証して送信する
+//   - アイテムにユーザーID（person）・リクエストID・例外の種類とメッセージ・スタックトレースを含める
+func TestReporter_Rollbar(t *testing.T) {
+	// Arrange
+	server, received := captureServer(t)
+	reporter, err := New(config.ErrorReportConfig{Provider: ProviderRollbar, RollbarAccessToken: "token-1", SampleRate: 1, PanicSampleRate: 1}, "staging")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	reporter.transport.(*rollbarTransport).endpoint = server.URL + "/api/1/item/"
+
+	// Act
+	serveErrors(t, reporter)
+
+	// Assert
+	requests := received()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 Rollbar items, got %d", len(requests))
+	}
+	if requests[0].header.Get("X-Rollbar-Access-Token") != "token-1" {
+		t.Errorf("Expected the access token, got %q", requests[0].header.Get("X-Rollbar-Access-Token"))
+	}
+	var item rollbarItem
+	if err := json.Unmarshal(requests[1].body, &item); err != nil {
+		t.Fatalf("Failed to decode the item: %v", err)
+	}
+	data := item.Data
+	if data.Environment != "staging" || data.Person == nil || data.Person.ID != "42" || data.Custom["request_id"] != "req-harve" {
+		t.Errorf("Expected the environment, user ID and request ID, got %+v", data)
+	}
+	if data.Body.Trace.Exception.Class != "*errors.AppError" || data.Context != "GET /api/v1/harvests" {
+		t.Errorf("Expected the AppError of the route, got %+v (%s)", data.Body.Trace.Exception, data.Context)
+	}
+	frames := data.Body.Trace.Frames
+	if len(frames) == 0 || !strings.Contains(frames[len(frames)-1].Method, "serveErrors") {
+		t.Errorf("Expected the handler as the last frame, got %+v", frames)
+	}
+}
+
+// fakeTransport は送信したエラーを記録するテスト用の送信先です。
+type fakeTransport struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (f *fakeTransport) send(_ context.Context, event Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+// TestReporter_Sampling はエラーのサンプリングのテストです。
+// 期待動作:
+//   - エラーは ERROR_REPORT_SAMPLE_RATE、パニックは ERROR_REPORT_PANIC_SAMPLE_RATE の割合で送信する
+//   - クライアントの切断（context.Canceled）・停止（Close）後のエラーは送信しない
+func TestReporter_Sampling(t *testing.T) {
+	// Arrange
+	fake := &fakeTransport{}
+	reporter := newReporter("fake", fake, 0.25, 1)
+	reporter.sample = func() float64 { return 0.5 }
+
+	// Act
+	reporter.enqueue(Event{Err: errors.New("database is down")})
+	reporter.enqueue(Event{Err: errors.New("nil map"), Panic: true})
+	reporter.sample = func() float64 { return 0.1 }
+	reporter.enqueue(Event{Err: errors.New("timeout")})
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/exports", nil), httptest.NewRecorder())
+	reporter.ReportHTTP(c, context.Canceled, http.StatusInternalServerError)
+	if err := reporter.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	reporter.enqueue(Event{Err: errors.New("after shutdown"), Panic: true})
+
+	// Assert
+	if len(fake.events) != 2 || fake.events[0].Err.Error() != "nil map" || fake.events[1].Err.Error() != "timeout" {
+		t.Errorf("Expected the panic and the sampled error, got %+v", fake.events)
+	}
+}
+
+// TestNew はエラーの通知の設定のテストです。
+// 期待動作:
+//   - 通知サービスが未設定の場合は nil（nil の Reporter のメソッドは何もしない）
+//   - 不正な DSN・アクセストークンのない rollbar・未対応の通知サービスはエラー
+//   - DSN のパスの接頭辞（セルフホスト）を Envelope API のURLに含める
+func TestNew(t *testing.T) {
+	// Act
+	disabled, disabledErr := New(config.ErrorReportConfig{}, "test")
+	_, invalidDSNErr := New(config.ErrorReportConfig{Provider: ProviderSentry, SentryDSN: "https://sentry.example.com/1"}, "test")
+	_, rollbarErr := New(config.ErrorReportConfig{Provider: ProviderRollbar}, "test")
+	_, providerErr := New(config.ErrorReportConfig{Provider: "bugsnag"}, "test")
+	selfHosted, selfHostedErr := newSentryTransport(http.DefaultClient, "https://key@sentry.example.com/errors/7", "test", "")
+
+	// Assert
+	if disabled != nil || disabledErr != nil || disabled.Close(context.Background()) != nil || disabled.Provider() != "" {
+		t.Errorf("Expected a nil reporter without a provider, got %v / %v", disabled, disabledErr)
+	}
+	if invalidDSNErr == nil || rollbarErr == nil || providerErr == nil {
+		t.Errorf("Expected errors for the invalid settings, got %v / %v / %v", invalidDSNErr, rollbarErr, providerErr)
+	}
+	if selfHostedErr != nil || selfHosted.endpoint != "https://sentry.example.com/errors/api/7/envelope/" {
+		t.Errorf("Expected the envelope API under the path prefix, got %v (%v)", selfHosted, selfHostedErr)
+	}
+}
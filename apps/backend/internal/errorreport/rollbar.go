@@ -0,0 +1,151 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Rollbar - Item API への送信
+// =============================================================================
+// プロジェクトのアクセストークン（post_server_item のスコープ）で Item API にエラーを1件ずつ送信します。
+
+// rollbarEndpoint は Rollbar の Item API のURLです
+const rollbarEndpoint = "https://api.rollbar.com/api/1/item/"
+
+// rollbarTransport は Rollbar にエラーを送信します。
+type rollbarTransport struct {
+	httpClient  *http.Client
+	endpoint    string
+	accessToken string
+	env         string
+	release     string
+}
+
+// newRollbarTransport は Rollbar の送信先を作成します。
+func newRollbarTransport(client *http.Client, accessToken, env, release string) *rollbarTransport {
+	return &rollbarTransport{
+		httpClient:  client,
+		endpoint:    rollbarEndpoint,
+		accessToken: accessToken,
+		env:         env,
+		release:     release,
+	}
+}
+
+// rollbarItem は Rollbar の Item です（https://docs.rollbar.com/reference/create-item）。
+type rollbarItem struct {
+	Data rollbarData `json:"data"`
+}
+
+type rollbarData struct {
+	Environment string            `json:"environment"`
+	Level       string            `json:"level"`
+	Timestamp   int64             `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Language    string            `json:"language"`
+	UUID        string            `json:"uuid"`
+	Context     string            `json:"context,omitempty"` // ルート
+	CodeVersion string            `json:"code_version,omitempty"`
+	Body        rollbarBody       `json:"body"`
+	Request     rollbarRequest    `json:"request"`
+	Person      *rollbarPerson    `json:"person,omitempty"`
+	Custom      map[string]any    `json:"custom,omitempty"`
+	Notifier    map[string]string `json:"notifier"`
+}
+
+type rollbarBody struct {
+	Trace rollbarTrace `json:"trace"`
+}
+
+type rollbarTrace struct {
+	Frames    []rollbarFrame   `json:"frames"`
+	Exception rollbarException `json:"exception"`
+}
+
+type rollbarFrame struct {
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+	Method   string `json:"method"`
+}
+
+type rollbarException struct {
+	Class   string `json:"class"`
+	Message string `json:"message"`
+}
+
+type rollbarRequest struct {
+	URL    string `json:"url"`
+	Method string `json:"method"`
+}
+
+type rollbarPerson struct {
+	ID string `json:"id"`
+}
+
+// send はエラーを Rollbar の Item として送信します。
+func (t *rollbarTransport) send(ctx context.Context, event Event) error {
+	data := rollbarData{
+		Environment: t.env,
+		Level:       "error",
+		Timestamp:   event.Timestamp.Unix(),
+		Platform:    "go",
+		Language:    "go",
+		UUID:        uuid.New().String(),
+		Context:     strings.TrimSpace(event.Method + " " + event.Route),
+		CodeVersion: t.release,
+		Request:     rollbarRequest{URL: event.URL, Method: event.Method},
+		Custom:      map[string]any{"status": event.Status},
+		Notifier:    map[string]string{"name": notifierName},
+	}
+	if event.Panic {
+		data.Level = "critical"
+	}
+	data.Body.Trace.Exception = rollbarException{Class: errorType(event), Message: event.Err.Error()}
+	// frames は古い呼び出しから順（最後がエラーの位置）
+	data.Body.Trace.Frames = []rollbarFrame{}
+	for _, f := range Frames(event.Stack) {
+		method := f.Function
+		if f.Module != "" {
+			method = f.Module + "." + f.Function
+		}
+		data.Body.Trace.Frames = append(data.Body.Trace.Frames, rollbarFrame{Filename: f.File, Lineno: f.Line, Method: method})
+	}
+	if event.UserID != 0 {
+		data.Person = &rollbarPerson{ID: fmt.Sprint(event.UserID)}
+	}
+	if event.RequestID != "" {
+		data.Custom["request_id"] = event.RequestID
+	}
+	if event.TraceID != "" {
+		data.Custom["trace_id"] = event.TraceID
+	}
+
+	body, err := json.Marshal(rollbarItem{Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode Rollbar item: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Rollbar request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Rollbar-Access-Token", t.accessToken)
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Rollbar item: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Rollbar item failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
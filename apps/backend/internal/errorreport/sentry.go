@@ -0,0 +1,185 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Sentry - Envelope API への送信
+// =============================================================================
+// DSN（https://{公開鍵}@{ホスト}/{プロジェクトID}）のホストの /api/{プロジェクトID}/envelope/ に
+// エラーのイベントを1件ずつ送信します。セルフホストの Sentry（DSN のパスに接頭辞がある場合）にも対応します。
+
+// sentryClient は X-Sentry-Auth の sentry_client です
+const sentryClient = notifierName + "/1.0"
+
+// sentryTransport は Sentry にエラーを送信します。
+type sentryTransport struct {
+	httpClient *http.Client
+	endpoint   string // Envelope API のURL
+	dsn        string
+	publicKey  string
+	env        string
+	release    string
+}
+
+// newSentryTransport は DSN から Sentry の送信先を作成します。
+func newSentryTransport(client *http.Client, dsn, env, release string) (*sentryTransport, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid SENTRY_DSN")
+	}
+	projectID := path.Base(u.Path)
+	if projectID == "" || projectID == "/" || projectID == "." {
+		return nil, fmt.Errorf("invalid SENTRY_DSN: missing project ID")
+	}
+	prefix := strings.TrimSuffix(path.Dir(u.Path), "/")
+	return &sentryTransport{
+		httpClient: client,
+		endpoint:   fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, projectID),
+		dsn:        dsn,
+		publicKey:  u.User.Username(),
+		env:        env,
+		release:    release,
+	}, nil
+}
+
+// sentryEvent は Sentry のイベントです（https://develop.sentry.dev/sdk/data-model/event-payloads/）。
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Exception   sentryExceptions  `json:"exception"`
+	Request     sentryRequest     `json:"request"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Contexts    map[string]any    `json:"contexts,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+	Mechanism  sentryMechanism  `json:"mechanism"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path"`
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryMechanism struct {
+	Type    string `json:"type"`
+	Handled bool   `json:"handled"` // パニックは false
+}
+
+type sentryRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+// send はエラーを Sentry のイベントとして送信します。
+func (t *sentryTransport) send(ctx context.Context, event Event) error {
+	eventID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	payload := sentryEvent{
+		EventID:     eventID,
+		Timestamp:   event.Timestamp.UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "error",
+		Environment: t.env,
+		Release:     t.release,
+		Transaction: strings.TrimSpace(event.Method + " " + event.Route),
+		Request:     sentryRequest{Method: event.Method, URL: event.URL},
+		Tags: map[string]string{
+			"status": strconv.Itoa(event.Status),
+		},
+	}
+	mechanism := sentryMechanism{Type: "generic", Handled: true}
+	if event.Panic {
+		payload.Level = "fatal"
+		mechanism = sentryMechanism{Type: "panic", Handled: false}
+	}
+	exception := sentryException{Type: errorType(event), Value: event.Err.Error(), Mechanism: mechanism}
+	for _, f := range Frames(event.Stack) {
+		exception.Stacktrace.Frames = append(exception.Stacktrace.Frames, sentryFrame{
+			Function: f.Function,
+			Module:   f.Module,
+			AbsPath:  f.File,
+			Filename: path.Base(f.File),
+			Lineno:   f.Line,
+			InApp:    f.InApp,
+		})
+	}
+	payload.Exception.Values = []sentryException{exception}
+	if event.RequestID != "" {
+		payload.Tags["request_id"] = event.RequestID
+	}
+	if event.UserID != 0 {
+		payload.User = &sentryUser{ID: strconv.FormatUint(uint64(event.UserID), 10)}
+	}
+	if event.TraceID != "" {
+		payload.Contexts = map[string]any{"trace": map[string]string{"trace_id": event.TraceID, "span_id": event.SpanID}}
+	}
+
+	// Envelope: ヘッダー・アイテムのヘッダー・イベントの3行
+	eventJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode Sentry event: %w", err)
+	}
+	var body bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": eventID, "dsn": t.dsn, "sent_at": time.Now().UTC().Format(time.RFC3339Nano)})
+	itemHeader, _ := json.Marshal(map[string]any{"type": "event", "length": len(eventJSON)})
+	for _, line := range [][]byte{header, itemHeader, eventJSON} {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create Sentry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=%s", t.publicKey, sentryClient))
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Sentry event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Sentry event failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
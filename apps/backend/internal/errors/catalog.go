@@ -0,0 +1,182 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// =============================================================================
+// Error Catalog - エラーコード一覧
+// =============================================================================
+// エラーレスポンスの code はクライアントが分岐に使う安定した値です（message・detail の文言は変わることがあります）。
+// 一覧は GET /api/v1/errors で公開し、エラーレスポンスの type は一覧の項目（/api/v1/errors/{code}）を指します。
+// コードを追加した場合は一覧にも追加してください（一覧にないコードは type が汎用の項目になります）。
+
+// ErrorTypeBaseURI はエラーレスポンスの type の接頭辞です（エラーコード一覧のエンドポイント）。
+const ErrorTypeBaseURI = "/api/v1/errors/"
+
+// リソース・状況ごとのエラーコード
+const (
+	ErrCodeUserNotFound                 = "USER_NOT_FOUND"
+	ErrCodeGardenNotFound               = "GARDEN_NOT_FOUND"
+	ErrCodePlantNotFound                = "PLANT_NOT_FOUND"
+	ErrCodeCropNotFound                 = "CROP_NOT_FOUND"
+	ErrCodePlotNotFound                 = "PLOT_NOT_FOUND"
+	ErrCodePlotAssignmentNotFound       = "PLOT_ASSIGNMENT_NOT_FOUND"
+	ErrCodeTaskNotFound                 = "TASK_NOT_FOUND"
+	ErrCodeNotificationNotFound         = "NOTIFICATION_NOT_FOUND"
+	ErrCodeShareTokenNotFound           = "SHARE_TOKEN_NOT_FOUND"
+	ErrCodeExportNotFound               = "EXPORT_NOT_FOUND"
+	ErrCodePlotOccupied                 = "PLOT_OCCUPIED"
+	ErrCodeEmailAlreadyRegistered       = "EMAIL_ALREADY_REGISTERED"
+	ErrCodeInvalidDateRange             = "INVALID_DATE_RANGE"
+	ErrCodeInvalidCursor                = "INVALID_CURSOR"
+	ErrCodeInvalidFilter                = "INVALID_FILTER"
+	ErrCodeImageTooLarge                = "IMAGE_TOO_LARGE"
+	ErrCodeUnsupportedImageType         = "UNSUPPORTED_IMAGE_TYPE"
+	ErrCodePayloadTooLarge              = "PAYLOAD_TOO_LARGE"
+	ErrCodeInvalidReminderHour          = "INVALID_REMINDER_HOUR"
+	ErrCodeInvalidQuietHours            = "INVALID_QUIET_HOURS"
+	ErrCodePhoneNotVerified             = "PHONE_NOT_VERIFIED"
+	ErrCodeInvalidWebhookURL            = "INVALID_WEBHOOK_URL"
+	ErrCodeInvalidCustomWebhookURL      = "INVALID_CUSTOM_WEBHOOK_URL"
+	ErrCodeBatchGroupAborted            = "BATCH_GROUP_ABORTED"
+	ErrCodeSyncCursorExpired            = "SYNC_CURSOR_EXPIRED"
+	ErrCodeGardenMemberNotFound         = "GARDEN_MEMBER_NOT_FOUND"
+	ErrCodeGardenMemberExists           = "GARDEN_MEMBER_EXISTS"
+	ErrCodeOrganizationNotFound         = "ORGANIZATION_NOT_FOUND"
+	ErrCodeOrganizationMemberNotFound   = "ORGANIZATION_MEMBER_NOT_FOUND"
+	ErrCodeOrganizationMemberExists     = "ORGANIZATION_MEMBER_EXISTS"
+	ErrCodeOrganizationOwnerCannotLeave = "ORGANIZATION_OWNER_CANNOT_LEAVE"
+	ErrCodeOrganizationQuotaExceeded    = "ORGANIZATION_QUOTA_EXCEEDED"
+	ErrCodeStorageQuotaExceeded         = "STORAGE_QUOTA_EXCEEDED"
+	ErrCodeRestoreParentDeleted         = "RESTORE_PARENT_DELETED"
+	ErrCodeVersionConflict              = "VERSION_CONFLICT"
+	ErrCodePreconditionRequired         = "PRECONDITION_REQUIRED"
+	ErrCodeAttachmentNotFound           = "ATTACHMENT_NOT_FOUND"
+	ErrCodeAttachmentTooLarge           = "ATTACHMENT_TOO_LARGE"
+	ErrCodeUnsupportedAttachmentType    = "UNSUPPORTED_ATTACHMENT_TYPE"
+	ErrCodeAttachmentAlreadyRegistered  = "ATTACHMENT_ALREADY_REGISTERED"
+)
+
+// CatalogEntry はエラーコード一覧の1項目です。
+type CatalogEntry struct {
+	Code        string `json:"code"`
+	Type        string `json:"type"`   // エラーレスポンスの type（この項目のURI）
+	Status      int    `json:"status"` // HTTP ステータスコード
+	Title       string `json:"title"`  // エラーレスポンスの title（コードごとに固定）
+	Description string `json:"description"`
+}
+
+// catalog はエラーコード一覧です（GET /api/v1/errors の並び順）。
+var catalog = []CatalogEntry{
+	// 汎用のエラー
+	{Code: ErrCodeBadRequest, Status: http.StatusBadRequest, Title: "Bad request", Description: "リクエストの形式が正しくありません（不正なIDなど）。"},
+	{Code: ErrCodeValidation, Status: http.StatusUnprocessableEntity, Title: "Validation failed", Description: "リクエストの項目が正しくありません。errors に項目ごとの内容（field, rule, param, message）が入り、message は Accept-Language（ja, en）の言語です。"},
+	{Code: ErrCodeAuthentication, Status: http.StatusUnauthorized, Title: "Authentication required", Description: "トークンがない・無効・期限切れです。ログインし直してください。"},
+	{Code: ErrCodeAuthorization, Status: http.StatusForbidden, Title: "Forbidden", Description: "この操作を行う権限がありません。"},
+	{Code: ErrCodeNotFound, Status: http.StatusNotFound, Title: "Resource not found", Description: "リソースが見つかりません（リソースごとのコードがない場合）。"},
+	{Code: ErrCodeConflict, Status: http.StatusConflict, Title: "Conflict", Description: "リソースの現在の状態ではこの操作を行えません。"},
+	{Code: ErrCodeInternal, Status: http.StatusInternalServerError, Title: "Internal server error", Description: "サーバーの内部エラーです。時間をおいて再試行してください。"},
+	{Code: ErrCodeServiceUnavailable, Status: http.StatusServiceUnavailable, Title: "Service unavailable", Description: "外部サービス（ストレージなど）が利用できません。時間をおいて再試行してください。"},
+
+	// リソースが見つからない
+	{Code: ErrCodeUserNotFound, Status: http.StatusNotFound, Title: "User not found", Description: "ユーザーが見つかりません。"},
+	{Code: ErrCodeGardenNotFound, Status: http.StatusNotFound, Title: "Garden not found", Description: "菜園が見つかりません。"},
+	{Code: ErrCodePlantNotFound, Status: http.StatusNotFound, Title: "Plant not found", Description: "植物が見つかりません。"},
+	{Code: ErrCodeCropNotFound, Status: http.StatusNotFound, Title: "Crop not found", Description: "作物が見つからないか、削除されています。"},
+	{Code: ErrCodePlotNotFound, Status: http.StatusNotFound, Title: "Plot not found", Description: "区画が見つからないか、削除されています。"},
+	{Code: ErrCodePlotAssignmentNotFound, Status: http.StatusNotFound, Title: "Plot assignment not found", Description: "区画に作物が配置されていません。"},
+	{Code: ErrCodeTaskNotFound, Status: http.StatusNotFound, Title: "Task not found", Description: "タスクが見つかりません。"},
+	{Code: ErrCodeNotificationNotFound, Status: http.StatusNotFound, Title: "Notification not found", Description: "通知が見つかりません。"},
+	{Code: ErrCodeShareTokenNotFound, Status: http.StatusNotFound, Title: "Share token not found", Description: "共有リンクが見つからないか、無効化されています。"},
+	{Code: ErrCodeExportNotFound, Status: http.StatusNotFound, Title: "Export not found", Description: "エクスポートが見つかりません。"},
+	{Code: ErrCodeGardenMemberNotFound, Status: http.StatusNotFound, Title: "Garden member not found", Description: "ユーザーは菜園のメンバーではありません。"},
+	{Code: ErrCodeOrganizationNotFound, Status: http.StatusNotFound, Title: "Organization not found", Description: "組織が見つかりません（X-Org-ID の組織を含む）。"},
+	{Code: ErrCodeOrganizationMemberNotFound, Status: http.StatusNotFound, Title: "Organization member not found", Description: "ユーザーは組織のメンバーではありません。"},
+
+	// 状態・入力の内容によるエラー
+	{Code: ErrCodePlotOccupied, Status: http.StatusConflict, Title: "Plot is occupied", Description: "区画には別の作物が配置されています。区画の配置を解除してから指定してください。"},
+	{Code: ErrCodeEmailAlreadyRegistered, Status: http.StatusConflict, Title: "Email already registered", Description: "このメールアドレスは登録済みです。ログインしてください。"},
+	{Code: ErrCodeInvalidDateRange, Status: http.StatusBadRequest, Title: "Invalid date range", Description: "日付の範囲が正しくありません（植え付け日が予想収穫日より後など）。"},
+	{Code: ErrCodeInvalidCursor, Status: http.StatusBadRequest, Title: "Invalid pagination cursor", Description: "limit または cursor が正しくありません。cursor には前のレスポンスの next_cursor をそのまま指定してください。"},
+	{Code: ErrCodeInvalidFilter, Status: http.StatusBadRequest, Title: "Invalid filter", Description: "filter が正しくありません。filter は「フィールド:演算子:値」の形式で、エンドポイントで指定できるフィールドと演算子を指定してください。"},
+	{Code: ErrCodeImageTooLarge, Status: http.StatusRequestEntityTooLarge, Title: "Image too large", Description: "画像のサイズが上限（5MB）を超えています。errors.limit_bytes は上限のバイト数です。"},
+	{Code: ErrCodeUnsupportedImageType, Status: http.StatusBadRequest, Title: "Unsupported image type", Description: "画像の形式は JPEG・PNG・WEBP のみです。"},
+	{Code: ErrCodePayloadTooLarge, Status: http.StatusRequestEntityTooLarge, Title: "Payload too large", Description: "リクエストボディのサイズがエンドポイントの上限を超えています。errors.limit_bytes は上限のバイト数です。"},
+	{Code: ErrCodeInvalidReminderHour, Status: http.StatusBadRequest, Title: "Invalid reminder hour", Description: "リマインダーの送信時刻は0〜23で指定してください。"},
+	{Code: ErrCodeInvalidQuietHours, Status: http.StatusBadRequest, Title: "Invalid quiet hours", Description: "おやすみモードの時刻はHH:MM形式で、開始と終了を異なる時刻にしてください。"},
+	{Code: ErrCodePhoneNotVerified, Status: http.StatusBadRequest, Title: "Phone number not verified", Description: "SMS通知を有効にするには電話番号を認証してください。"},
+	{Code: ErrCodeInvalidWebhookURL, Status: http.StatusBadRequest, Title: "Invalid webhook URL", Description: "Slack・Discord の公式の Webhook URL を指定してください。"},
+	{Code: ErrCodeInvalidCustomWebhookURL, Status: http.StatusBadRequest, Title: "Invalid custom webhook URL", Description: "汎用 Webhook は内部ネットワーク以外の HTTPS の URL を指定してください。"},
+	{Code: ErrCodeBatchGroupAborted, Status: http.StatusFailedDependency, Title: "Batch group aborted", Description: "バッチリクエストの同じグループの別のサブリクエストが失敗したため、実行していません（グループはロールバック済み）。"},
+	{Code: ErrCodeGardenMemberExists, Status: http.StatusConflict, Title: "Garden member already exists", Description: "ユーザーはすでに菜園のメンバー（または所有者）です。"},
+	{Code: ErrCodeOrganizationMemberExists, Status: http.StatusConflict, Title: "Organization member already exists", Description: "ユーザーはすでに組織のメンバーです。"},
+	{Code: ErrCodeOrganizationOwnerCannotLeave, Status: http.StatusConflict, Title: "Organization owner cannot be removed", Description: "組織の作成者（owner）は組織から削除・退出できません。"},
+	{Code: ErrCodeOrganizationQuotaExceeded, Status: http.StatusForbidden, Title: "Organization quota exceeded", Description: "組織の区画・作物・メンバーの数が上限に達しています。組織の管理者に上限の変更を依頼してください。"},
+	{Code: ErrCodeStorageQuotaExceeded, Status: http.StatusForbidden, Title: "Storage quota exceeded", Description: "アップロードすると保存容量の上限を超えます。GET /users/me/storage の使用量と整理の候補を確認し、不要な写真を削除してください。"},
+	{Code: ErrCodeRestoreParentDeleted, Status: http.StatusConflict, Title: "Parent is deleted", Description: "収穫記録の作物が削除されているため復元できません。先に作物を復元してください（作物と一緒に削除した収穫記録も復元されます）。"},
+	{Code: ErrCodeVersionConflict, Status: http.StatusConflict, Title: "Version conflict", Description: "記録は取得した後に別のリクエストで更新されています。errors.current は現在の記録です。内容を確認し、current の version を指定して更新し直してください。"},
+	{Code: ErrCodePreconditionRequired, Status: http.StatusPreconditionRequired, Title: "Precondition required", Description: "タスク・作物・区画の更新には、取得した記録の version を If-Match ヘッダー（\"3\" の形式）またはリクエストボディの version で指定してください。"},
+	{Code: ErrCodeAttachmentNotFound, Status: http.StatusNotFound, Title: "Attachment not found", Description: "指定した添付ファイルが存在しないか、閲覧できない添付ファイルです。"},
+	{Code: ErrCodeAttachmentTooLarge, Status: http.StatusRequestEntityTooLarge, Title: "Attachment too large", Description: "添付ファイルのサイズが上限（10MB）を超えています。errors.limit_bytes は上限のバイト数です。"},
+	{Code: ErrCodeUnsupportedAttachmentType, Status: http.StatusBadRequest, Title: "Unsupported attachment type", Description: "添付ファイルの形式は PDF・JPEG・PNG・WEBP のみです（形式はファイルの内容から判定します）。"},
+	{Code: ErrCodeAttachmentAlreadyRegistered, Status: http.StatusConflict, Title: "Attachment already registered", Description: "アップロードしたファイルはすでに添付ファイルとして登録されています。新しいファイルをアップロードしてから登録してください。"},
+	{Code: ErrCodeSyncCursorExpired, Status: http.StatusGone, Title: "Sync cursor expired", Description: "同期の since が削除の記録の保持期間より古いため、差分を返せません。since を省略して全件を再取得してください。"},
+}
+
+// notFoundCodes は NewNotFoundError のリソース名ごとのエラーコードです。
+var notFoundCodes = map[string]string{
+	"User":                ErrCodeUserNotFound,
+	"Garden":              ErrCodeGardenNotFound,
+	"Plant":               ErrCodePlantNotFound,
+	"Crop":                ErrCodeCropNotFound,
+	"Plot":                ErrCodePlotNotFound,
+	"Active assignment":   ErrCodePlotAssignmentNotFound,
+	"Task":                ErrCodeTaskNotFound,
+	"Notification":        ErrCodeNotificationNotFound,
+	"Share token":         ErrCodeShareTokenNotFound,
+	"Export":              ErrCodeExportNotFound,
+	"Garden member":       ErrCodeGardenMemberNotFound,
+	"Organization":        ErrCodeOrganizationNotFound,
+	"Organization member": ErrCodeOrganizationMemberNotFound,
+	"Attachment":          ErrCodeAttachmentNotFound,
+}
+
+// catalogIndex はエラーコードから一覧の項目の位置を引く索引です。
+var catalogIndex = func() map[string]int {
+	index := make(map[string]int, len(catalog))
+	for i, entry := range catalog {
+		index[entry.Code] = i
+	}
+	return index
+}()
+
+// Catalog はエラーコード一覧を返します。
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, len(catalog))
+	for i, entry := range catalog {
+		entry.Type = TypeURI(entry.Code)
+		entries[i] = entry
+	}
+	return entries
+}
+
+// LookupCode はエラーコードの一覧の項目を返します。
+//
+// 戻り値:
+//   - CatalogEntry: 一覧の項目
+//   - bool: 一覧にないコードの場合は false
+func LookupCode(code string) (CatalogEntry, bool) {
+	i, ok := catalogIndex[code]
+	if !ok {
+		return CatalogEntry{}, false
+	}
+	entry := catalog[i]
+	entry.Type = TypeURI(entry.Code)
+	return entry, true
+}
+
+// TypeURI はエラーコードのエラーレスポンスの type（一覧の項目のURI）を返します。
+func TypeURI(code string) string {
+	return ErrorTypeBaseURI + code
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"runtime"
 )
 
 // AppError represents application-level errors with HTTP status codes
@@ -11,6 +12,8 @@ type AppError struct {
 	Message    string `json:"message"`
 	Details    any    `json:"details,omitempty"`
 	StatusCode int    `json:"-"`
+
+	stack []uintptr // 5xx のエラーを作成した位置のスタックトレース（エラーの通知に含める）
 }
 
 // Error implements the error interface
@@ -18,12 +21,22 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
 }
 
-// ErrorResponse represents the standard error response format
-type ErrorResponse struct {
-	Error AppError `json:"error"`
+// Stack returns the stack trace where the 5xx error was created
+// エラーの通知（errorreport）でハンドラのどの位置で内部エラーになったかを送信します（4xx のエラーは nil）。
+func (e *AppError) Stack() []uintptr {
+	return e.stack
+}
+
+// callers returns the stack trace of the caller of the error constructor
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	// runtime.Callers・callers・コンストラクタを除く
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
 }
 
 // Error code constants
+// 汎用のコードです。リソース・状況ごとのコードは catalog.go を参照してください。
 const (
 	ErrCodeValidation         = "VALIDATION_ERROR"
 	ErrCodeAuthentication     = "AUTHENTICATION_ERROR"
@@ -35,13 +48,32 @@ const (
 	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
 )
 
-// NewValidationError creates a validation error
+// New creates an error with a code from the error catalog
+// ステータスコードはエラーコード一覧（Catalog）の定義を使用します（一覧にないコードは 500）
+func New(code, message string) *AppError {
+	status := http.StatusInternalServerError
+	if entry, ok := LookupCode(code); ok {
+		status = entry.Status
+	}
+	appErr := &AppError{
+		Code:       code,
+		Message:    message,
+		StatusCode: status,
+	}
+	if status >= http.StatusInternalServerError {
+		appErr.stack = callers()
+	}
+	return appErr
+}
+
+// NewValidationError creates a validation error (422 Unprocessable Entity)
+// details が LocalizedDetails の場合、ErrorHandler がリクエストのロケールに応じてメッセージを翻訳します。
 func NewValidationError(message string, details any) *AppError {
 	return &AppError{
 		Code:       ErrCodeValidation,
 		Message:    message,
 		Details:    details,
-		StatusCode: http.StatusBadRequest,
+		StatusCode: http.StatusUnprocessableEntity,
 	}
 }
 
@@ -64,9 +96,14 @@ func NewAuthorizationError(message string) *AppError {
 }
 
 // NewNotFoundError creates a not found error
+// リソースごとのコード（Crop → CROP_NOT_FOUND）がある場合はそのコードを使用します
 func NewNotFoundError(resource string) *AppError {
+	code, ok := notFoundCodes[resource]
+	if !ok {
+		code = ErrCodeNotFound
+	}
 	return &AppError{
-		Code:       ErrCodeNotFound,
+		Code:       code,
 		Message:    fmt.Sprintf("%s not found", resource),
 		StatusCode: http.StatusNotFound,
 	}
@@ -81,12 +118,29 @@ func NewConflictError(message string) *AppError {
 	}
 }
 
+// VersionConflictDetails は楽観的ロックの競合のエラー（409）の詳細です。
+type VersionConflictDetails struct {
+	Current any `json:"current"` // 現在の記録（レスポンスの形式）
+}
+
+// NewVersionConflictError creates a version conflict error (409 Conflict)
+// 更新の前提としたバージョンが現在の記録と一致しない場合に使用し、errors に現在の記録を返します。
+func NewVersionConflictError(resource string, current any) *AppError {
+	return &AppError{
+		Code:       ErrCodeVersionConflict,
+		Message:    fmt.Sprintf("%s was updated by another request", resource),
+		Details:    VersionConflictDetails{Current: current},
+		StatusCode: http.StatusConflict,
+	}
+}
+
 // NewInternalError creates an internal error
 func NewInternalError(message string) *AppError {
 	return &AppError{
 		Code:       ErrCodeInternal,
 		Message:    message,
 		StatusCode: http.StatusInternalServerError,
+		stack:      callers(),
 	}
 }
 
@@ -99,6 +153,22 @@ func NewBadRequestError(message string) *AppError {
 	}
 }
 
+// SizeLimitDetails はサイズの上限を超えたエラー（413）の詳細です。
+type SizeLimitDetails struct {
+	LimitBytes int64 `json:"limit_bytes"` // 上限（バイト）
+}
+
+// NewPayloadTooLargeError creates a payload too large error (413 Request Entity Too Large)
+// code は PAYLOAD_TOO_LARGE（リクエストボディ）、IMAGE_TOO_LARGE（画像）または ATTACHMENT_TOO_LARGE（添付ファイル）で、errors に上限のバイト数を返します。
+func NewPayloadTooLargeError(code, message string, limitBytes int64) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		Details:    SizeLimitDetails{LimitBytes: limitBytes},
+		StatusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
 // NewServiceUnavailableError creates a service unavailable error
 // 外部サービス（S3等）が利用不可の場合に使用します
 func NewServiceUnavailableError(message string) *AppError {
@@ -106,5 +176,6 @@ func NewServiceUnavailableError(message string) *AppError {
 		Code:       ErrCodeServiceUnavailable,
 		Message:    message,
 		StatusCode: http.StatusServiceUnavailable,
+		stack:      callers(),
 	}
 }
@@ -1,14 +1,70 @@
 package errors
 
 import (
-	"log/slog"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/logging"
 )
 
+// MIMEApplicationProblemJSON is the content type of error responses (RFC 7807)
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem represents the error response format (RFC 7807 Problem Details)
+// type・title はエラーコードごとに固定で、detail はこのリクエストでのエラーの内容です。
+// code・errors は拡張メンバーです（errors はバリデーションエラーの項目ごとの内容）。
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	Errors    any    `json:"errors,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// NewProblem creates a problem response from an error code
+// title はエラーコード一覧の定義を使用します（一覧にないコードは汎用の項目）
+func NewProblem(status int, code, detail, instance string) Problem {
+	entry, ok := LookupCode(code)
+	if !ok {
+		entry, _ = LookupCode(genericCode(status))
+	}
+	return Problem{
+		Type:     entry.Type,
+		Title:    entry.Title,
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
+	}
+}
+
+// Reporter sends 5xx errors to an error reporting service (errorreport.Reporter)
+type Reporter interface {
+	ReportHTTP(c echo.Context, err error, status int)
+}
+
 // ErrorHandler is a custom error handler for Echo
 func ErrorHandler(err error, c echo.Context) {
+	handleError(err, c, nil)
+}
+
+// NewErrorHandler returns the error handler that also reports 5xx errors
+// 5xx のエラー（回復したパニックを含む）をリクエストの情報とともにエラーの通知サービスに送信します。
+// 4xx のエラーはクライアントの誤りのため送信しません。
+//
+// 引数:
+//   - reporter: エラーの通知（nil の場合は ErrorHandler と同じ）
+func NewErrorHandler(reporter Reporter) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		handleError(err, c, reporter)
+	}
+}
+
+// handleError logs the error, reports 5xx errors and sends the problem response
+func handleError(err error, c echo.Context, reporter Reporter) {
 	// Default error
 	code := http.StatusInternalServerError
 	message := "Internal server error"
@@ -28,46 +84,63 @@ func ErrorHandler(err error, c echo.Context) {
 			message = msg
 		}
 		// Map Echo errors to our error codes
-		switch code {
-		case http.StatusBadRequest:
-			errorCode = ErrCodeBadRequest
-		case http.StatusUnauthorized:
-			errorCode = ErrCodeAuthentication
-		case http.StatusForbidden:
-			errorCode = ErrCodeAuthorization
-		case http.StatusNotFound:
-			errorCode = ErrCodeNotFound
-		case http.StatusConflict:
-			errorCode = ErrCodeConflict
-		default:
-			errorCode = ErrCodeInternal
-		}
+		errorCode = genericCode(code)
 	}
 
 	// Log error
 	logError(c, err, code)
+	if reporter != nil && code >= http.StatusInternalServerError {
+		reporter.ReportHTTP(c, err, code)
+	}
 
 	// Don't send response if already committed
 	if c.Response().Committed {
 		return
 	}
 
-	// Send JSON response
-	response := ErrorResponse{
-		Error: AppError{
-			Code:    errorCode,
-			Message: message,
-			Details: details,
-		},
+	// Send problem+json response
+	problem := NewProblem(code, errorCode, message, c.Request().URL.Path)
+	if localized, ok := details.(LocalizedDetails); ok {
+		details = localized.Localize(PreferredLocale(c.Request().Header.Get(HeaderAcceptLanguage)))
 	}
+	problem.Errors = details
+	problem.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
 
-	if err := c.JSON(code, response); err != nil {
-		slog.Error("Failed to send error response", "error", err)
+	// c.JSON は Content-Type が設定済みの場合は上書きしない
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	if err := c.JSON(code, problem); err != nil {
+		logging.FromContext(c.Request().Context()).Error("Failed to send error response", "error", err)
+	}
+}
+
+// genericCode returns the generic error code for an HTTP status
+func genericCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeAuthentication
+	case http.StatusForbidden:
+		return ErrCodeAuthorization
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return ErrCodeValidation
+	case http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	default:
+		return ErrCodeInternal
 	}
 }
 
 // logError logs the error with appropriate level
+// リクエストのロガー（logging.Middleware）のため、request_id と認証したリクエストの user_id が付きます。
 func logError(c echo.Context, err error, statusCode int) {
+	logger := logging.FromContext(c.Request().Context())
 	attrs := []any{
 		"method", c.Request().Method,
 		"path", c.Request().URL.Path,
@@ -75,22 +148,12 @@ func logError(c echo.Context, err error, statusCode int) {
 		"error", err.Error(),
 	}
 
-	// Add user ID if available
-	if userID := c.Get("user"); userID != nil {
-		attrs = append(attrs, "user_id", userID)
-	}
-
-	// Add request ID if available
-	if reqID := c.Response().Header().Get(echo.HeaderXRequestID); reqID != "" {
-		attrs = append(attrs, "request_id", reqID)
-	}
-
 	// Log with appropriate level
 	if statusCode >= 500 {
-		slog.Error("HTTP request error", attrs...)
+		logger.Error("HTTP request error", attrs...)
 	} else if statusCode >= 400 {
-		slog.Warn("HTTP request warning", attrs...)
+		logger.Warn("HTTP request warning", attrs...)
 	} else {
-		slog.Info("HTTP request", attrs...)
+		logger.Info("HTTP request", attrs...)
 	}
 }
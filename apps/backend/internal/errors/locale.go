@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Error Locale - エラーメッセージの言語
+// =============================================================================
+// バリデーションエラーの項目ごとのメッセージは、リクエストの Accept-Language の言語で返します。
+// detail・title は言語によらず英語です（クライアントは code で分岐します）。
+
+// HeaderAcceptLanguage はエラーメッセージの言語を指定するヘッダーです。
+const HeaderAcceptLanguage = "Accept-Language"
+
+// DefaultLocale は Accept-Language がない・対応していない言語の場合の言語です。
+const DefaultLocale = "en"
+
+// SupportedLocales はエラーメッセージが対応している言語です。
+var SupportedLocales = []string{"en", "ja"}
+
+// LocalizedDetails はロケールに応じてメッセージを翻訳できるエラーの詳細です（バリデーションエラーの項目ごとの内容）。
+type LocalizedDetails interface {
+	Localize(locale string) any
+}
+
+// PreferredLocale は Accept-Language のうち品質値（q）が最も高い対応言語を返します。
+// 地域の指定（ja-JP など）は無視し、対応言語がない場合は DefaultLocale です。
+//
+// 引数:
+//   - header: Accept-Language ヘッダーの値（例: "ja-JP,ja;q=0.9,en;q=0.8"）
+//
+// 戻り値:
+//   - string: SupportedLocales のいずれか
+func PreferredLocale(header string) string {
+	best, bestQ := DefaultLocale, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !isSupportedLocale(lang) {
+			continue
+		}
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// isSupportedLocale は言語がエラーメッセージの対応言語かを判定します。
+func isSupportedLocale(lang string) bool {
+	for _, locale := range SupportedLocales {
+		if locale == lang {
+			return true
+		}
+	}
+	return false
+}
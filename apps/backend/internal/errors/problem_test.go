@@ -0,0 +1,214 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// =============================================================================
+// Problem Details Tests - エラーレスポンス（RFC 7807）のテスト
+// =============================================================================
+// テスト対象:
+//   - ErrorHandler: application/problem+json の形式（type/title/status/detail/instance/code）
+//   - NewNotFoundError / New: エラーコード一覧のコード・ステータスの使用
+//   - Catalog: エラーコード一覧の整合性
+//   - PreferredLocale: Accept-Language からのエラーメッセージの言語の選択
+
+// serveError は ErrorHandler で err を返した場合のレスポンスを返します。
+func serveError(t *testing.T, err error, path string) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+
+	ErrorHandler(err, c)
+
+	var problem Problem
+	if decodeErr := json.Unmarshal(rec.Body.Bytes(), &problem); decodeErr != nil {
+		t.Fatalf("Failed to decode error response %q: %v", rec.Body.String(), decodeErr)
+	}
+	return rec, problem
+}
+
+// TestErrorHandler_AppError はアプリケーションのエラーのレスポンスのテストです。
+// 期待動作:
+//   - Content-Type は application/problem+json
+//   - code はリソースごとのコード、type・title はエラーコード一覧の項目
+//   - detail はエラーのメッセージ、instance はリクエストのパス
+func TestErrorHandler_AppError(t *testing.T) {
+	// Act
+	rec, problem := serveError(t, NewNotFoundError("Crop"), "/api/v1/crops/42")
+
+	// Assert
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(echo.HeaderContentType); got != MIMEApplicationProblemJSON {
+		t.Errorf("Expected Content-Type %s, got %s", MIMEApplicationProblemJSON, got)
+	}
+	want := Problem{
+		Type:      "/api/v1/errors/CROP_NOT_FOUND",
+		Title:     "Crop not found",
+		Status:    http.StatusNotFound,
+		Detail:    "Crop not found",
+		Instance:  "/api/v1/crops/42",
+		Code:      ErrCodeCropNotFound,
+		RequestID: "req-1",
+	}
+	if problem != want {
+		t.Errorf("Expected %+v, got %+v", want, problem)
+	}
+}
+
+// TestErrorHandler_ValidationDetails はバリデーションエラーのレスポンスのテストです。
+// 期待動作:
+//   - 項目ごとの内容を errors に含める
+func TestErrorHandler_ValidationDetails(t *testing.T) {
+	// Arrange
+	details := []map[string]string{{"field": "name", "message": "name is required"}}
+
+	// Act
+	rec, _ := serveError(t, NewValidationError("Validation failed", details), "/api/v1/crops")
+
+	// Assert
+	var body struct {
+		Code   string              `json:"code"`
+		Errors []map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if body.Code != ErrCodeValidation || len(body.Errors) != 1 || body.Errors[0]["field"] != "name" {
+		t.Errorf("Expected validation error with name detail, got %s", rec.Body.String())
+	}
+}
+
+// TestErrorHandler_EchoError は Echo のエラー（ルートなし・認証ミドルウェア）のレスポンスのテストです。
+// 期待動作:
+//   - ステータスから汎用のコードを使用し、Echo のメッセージを detail にする
+func TestErrorHandler_EchoError(t *testing.T) {
+	// Act
+	rec, problem := serveError(t, echo.NewHTTPError(http.StatusUnauthorized, "token has expired"), "/api/v1/tasks")
+
+	// Assert
+	if rec.Code != http.StatusUnauthorized || problem.Code != ErrCodeAuthentication {
+		t.Errorf("Expected 401 %s, got %d %s", ErrCodeAuthentication, rec.Code, problem.Code)
+	}
+	if problem.Detail != "token has expired" || problem.Type != TypeURI(ErrCodeAuthentication) {
+		t.Errorf("Unexpected problem: %+v", problem)
+	}
+}
+
+// TestErrorHandler_UnknownError は想定外のエラーのレスポンスのテストです。
+// 期待動作:
+//   - 500 INTERNAL_ERROR で、エラーの内容は detail に含めない
+func TestErrorHandler_UnknownError(t *testing.T) {
+	// Act
+	rec, problem := serveError(t, http.ErrHandlerTimeout, "/api/v1/tasks")
+
+	// Assert
+	if rec.Code != http.StatusInternalServerError || problem.Code != ErrCodeInternal {
+		t.Errorf("Expected 500 %s, got %d %s", ErrCodeInternal, rec.Code, problem.Code)
+	}
+	if problem.Detail != "Internal server error" {
+		t.Errorf("Expected generic detail, got %q", problem.Detail)
+	}
+}
+
+// TestNew はエラーコード一覧のコードからのエラーの作成のテストです。
+// 期待動作:
+//   - ステータスはエラーコード一覧の定義を使用する
+//   - 一覧にないコードは 500
+func TestNew(t *testing.T) {
+	tests := []struct {
+		code   string
+		status int
+	}{
+		{ErrCodePlotOccupied, http.StatusConflict},
+		{ErrCodeInvalidCursor, http.StatusBadRequest},
+		{ErrCodeInvalidFilter, http.StatusBadRequest},
+		{"UNKNOWN_CODE", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			// Act
+			err := New(tt.code, "message")
+
+			// Assert
+			if err.Code != tt.code || err.StatusCode != tt.status {
+				t.Errorf("Expected %s/%d, got %s/%d", tt.code, tt.status, err.Code, err.StatusCode)
+			}
+		})
+	}
+}
+
+// TestNewNotFoundError_Codes はリソースごとの not found のコードのテストです。
+// 期待動作:
+//   - 一覧にあるリソースはリソースごとのコード、ないリソースは汎用の NOT_FOUND
+func TestNewNotFoundError_Codes(t *testing.T) {
+	if got := NewNotFoundError("Plot").Code; got != ErrCodePlotNotFound {
+		t.Errorf("Expected %s, got %s", ErrCodePlotNotFound, got)
+	}
+	if got := NewNotFoundError("Active assignment").Code; got != ErrCodePlotAssignmentNotFound {
+		t.Errorf("Expected %s, got %s", ErrCodePlotAssignmentNotFound, got)
+	}
+	if got := NewNotFoundError("Schedule").Code; got != ErrCodeNotFound {
+		t.Errorf("Expected %s, got %s", ErrCodeNotFound, got)
+	}
+}
+
+// TestCatalog_Consistency はエラーコード一覧の整合性のテストです。
+// 期待動作:
+//   - コードは重複せず、全項目に title・description・4xx/5xx のステータス・type がある
+//   - NewNotFoundError のコードは全て一覧にある（404）
+func TestCatalog_Consistency(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, entry := range Catalog() {
+		if seen[entry.Code] {
+			t.Errorf("Duplicate code %s", entry.Code)
+		}
+		seen[entry.Code] = true
+		if entry.Title == "" || entry.Description == "" || entry.Status < 400 || entry.Type != TypeURI(entry.Code) {
+			t.Errorf("Incomplete catalog entry: %+v", entry)
+		}
+	}
+	for resource, code := range notFoundCodes {
+		entry, ok := LookupCode(code)
+		if !ok || entry.Status != http.StatusNotFound {
+			t.Errorf("Expected %s (%s) to be a 404 catalog entry, got %+v", code, resource, entry)
+		}
+	}
+}
+
+// TestPreferredLocale はエラーメッセージの言語の選択のテストです。
+// 期待動作:
+//   - 品質値（q）が最も高い対応言語を選び、地域の指定は無視する
+//   - 対応言語がない・ヘッダーがない場合は DefaultLocale（en）
+func TestPreferredLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"ja", "ja"},
+		{"ja-JP,ja;q=0.9,en;q=0.8", "ja"},
+		{"en-US,en;q=0.9,ja;q=0.8", "en"},
+		{"fr-FR,ja;q=0.3,en;q=0.7", "en"},
+		{"fr-FR,de;q=0.5", DefaultLocale},
+		{"ja;q=0", DefaultLocale},
+		{"", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := PreferredLocale(tt.header); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Package events - プロセス内のイベントバス
+//
+// サービス層で発生した記録の変更（タスクの完了、収穫記録の追加、通知の受信など）をユーザーごとに配信します。
+// Server-Sent Events（GET /api/v1/events）で接続中のクライアントに変更を通知するために使用します。
+//
+//   - イベントはユーザー単位で配信し、他のユーザーの購読者には送らない
+//   - イベントIDはバスで連番を振り、再接続時に Last-Event-ID 以降の直近のイベントを再送する
+//   - 購読者のバッファが満杯の場合（受信が遅いクライアント）は購読を閉じ、クライアントの再接続で再送する
+//
+// バスはプロセス内のみで、複数のインスタンスで実行する場合は接続しているインスタンスで発生したイベントのみ届きます。
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Event Types - イベントの種類
+// =============================================================================
+
+const (
+	// TypeTaskCompleted はタスクを完了した（Data は完了したタスク）
+	TypeTaskCompleted = "task.completed"
+	// TypeHarvestAdded は収穫記録を追加した（Data は追加した収穫記録）
+	TypeHarvestAdded = "harvest.added"
+	// TypeNotificationReceived は通知を受信箱に追加した（Data は受信箱の通知）
+	TypeNotificationReceived = "notification.received"
+)
+
+const (
+	// DefaultBufferSize は購読者ごとの配信待ちのイベントの最大数です。
+	DefaultBufferSize = 64
+	// DefaultHistorySize は再接続時の再送のために保持する直近のイベント数です（全ユーザー合計）。
+	DefaultHistorySize = 1024
+)
+
+// Event はサービス層で発生した記録の変更のイベントです。
+type Event struct {
+	ID         uint64    `json:"id"`   // バスが振る連番（SSE の id）
+	Type       string    `json:"type"` // task.completed など（SSE の event）
+	UserID     uint      `json:"-"`    // 配信先のユーザー
+	Entity     string    `json:"entity"`
+	EntityID   uint      `json:"entity_id"`
+	Data       any       `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// =============================================================================
+// Bus - イベントバス
+// =============================================================================
+
+// Bus はユーザーごとにイベントを配信するプロセス内のイベントバスです。
+type Bus struct {
+	mu          sync.Mutex
+	nextID      uint64
+	subscribers map[*Subscription]struct{}
+	history     []Event // 直近のイベント（古い順、最大 historySize 件）
+	historySize int
+	bufferSize  int
+	closed      bool
+}
+
+// NewBus は新しいBusを作成します。
+//
+// 引数:
+//   - bufferSize: 購読者ごとの配信待ちのイベントの最大数（1未満の場合は DefaultBufferSize）
+//   - historySize: 再送のために保持する直近のイベント数（0 の場合は再送しない）
+func NewBus(bufferSize, historySize int) *Bus {
+	if bufferSize < 1 {
+		bufferSize = DefaultBufferSize
+	}
+	if historySize < 0 {
+		historySize = 0
+	}
+	return &Bus{
+		subscribers: make(map[*Subscription]struct{}),
+		historySize: historySize,
+		bufferSize:  bufferSize,
+	}
+}
+
+// Publish はイベントに連番のIDを振り、同じユーザーの購読者に配信します。
+// 配信は待たずに行い、バッファが満杯の購読者の購読は閉じます。
+func (b *Bus) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.nextID++
+	event.ID = b.nextID
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if b.historySize > 0 {
+		if len(b.history) >= b.historySize {
+			b.history = append(b.history[:0], b.history[1:]...)
+		}
+		b.history = append(b.history, event)
+	}
+
+	for sub := range b.subscribers {
+		if sub.userID != event.UserID {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// 受信が遅いクライアントはイベントが抜けるため、切断して再接続時に再送する
+			b.removeLocked(sub)
+		}
+	}
+}
+
+// Subscribe はユーザーのイベントを購読します。
+// lastEventID を指定した場合は、保持している直近のイベントのうちそれより後のイベントを先に配信します。
+//
+// 引数:
+//   - userID: 購読するユーザー
+//   - lastEventID: 前回の接続で最後に受信したイベントのID（0 の場合は再送しない）
+//
+// 戻り値:
+//   - *Subscription: 購読（バスを閉じた後は閉じた購読）
+func (b *Bus) Subscribe(userID uint, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+	if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID && event.UserID == userID {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		userID: userID,
+		events: make(chan Event, b.bufferSize+len(replay)),
+		bus:    b,
+	}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	if b.closed {
+		close(sub.events)
+		return sub
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// SubscriberCount は購読中の購読者数を返します。
+func (b *Bus) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Close は全ての購読を閉じ、以降のイベントを配信しません（サーバーの停止時に接続を終了するため）。
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		b.removeLocked(sub)
+	}
+}
+
+// removeLocked は購読を解除してチャネルを閉じます（b.mu を保持して呼び出す）。
+func (b *Bus) removeLocked(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
+
+// Subscription はユーザーのイベントの購読です。
+type Subscription struct {
+	userID uint
+	events chan Event
+	bus    *Bus
+}
+
+// Events はイベントを受信するチャネルを返します。
+// 購読を解除した場合、バッファが満杯になった場合、バスを閉じた場合はチャネルを閉じます。
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close は購読を解除します（複数回呼び出してもよい）。
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.removeLocked(s)
+}
@@ -0,0 +1,211 @@
+// Package fieldset - レスポンスのフィールドの選択（sparse fieldsets）
+//
+// クエリパラメータ fields で指定したフィールドだけを JSON のレスポンスに残します。
+// 通信量の少ないモバイルアプリが一覧から必要な列（タスクの id, title, due_date など）だけを取得するために使用します。
+//
+//   - fields はカンマ区切りの JSON のキー（例: fields=id,title,due_date）
+//   - 入れ子のオブジェクトはドット区切りで指定する（例: fields=id,crop.name）
+//   - 配列は要素ごとに、一覧の1ページ分のレスポンス（items, has_more など）は items の要素に適用する
+//   - レスポンスにないフィールドは無視する
+package fieldset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// =============================================================================
+// Sparse Fieldsets - フィールドの選択
+// =============================================================================
+
+// MaxFields は fields に指定できるフィールドの数の上限です。
+const MaxFields = 50
+
+// ErrInvalidFields は読み取れない fields を指定した場合のエラー
+var ErrInvalidFields = errors.New("invalid fields parameter")
+
+// fieldPathPattern はフィールドの指定（JSON のキーのドット区切り）の形式です。
+var fieldPathPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// Set は選択するフィールドです（指定した順序）。
+type Set []Field
+
+// Field は選択するフィールドの1つです。
+type Field struct {
+	Name   string
+	Nested Set // 入れ子のオブジェクトから選択するフィールド（nil の場合は値の全体）
+}
+
+// Parse はクエリパラメータ fields から選択するフィールドを作成します。
+// 同じフィールドの全体と入れ子の両方を指定した場合（crop,crop.name）は全体を選択します。
+//
+// 引数:
+//   - raw: カンマ区切りのフィールド（空の場合は全てのフィールド）
+//
+// 戻り値:
+//   - Set: 選択するフィールド（raw が空の場合は nil）
+//   - error: 形式が不正・上限を超える場合は ErrInvalidFields
+func Parse(raw string) (Set, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	paths := strings.Split(raw, ",")
+	if len(paths) > MaxFields {
+		return nil, ErrInvalidFields
+	}
+
+	var set Set
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if !fieldPathPattern.MatchString(path) {
+			return nil, ErrInvalidFields
+		}
+		set = set.add(strings.Split(path, "."))
+	}
+	return set, nil
+}
+
+// add はドット区切りのフィールドを追加した Set を返します。
+func (s Set) add(names []string) Set {
+	for i := range s {
+		if s[i].Name != names[0] {
+			continue
+		}
+		if s[i].Nested == nil {
+			// すでに全体を選択している
+			return s
+		}
+		if len(names) == 1 {
+			s[i].Nested = nil
+		} else {
+			s[i].Nested = s[i].Nested.add(names[1:])
+		}
+		return s
+	}
+
+	field := Field{Name: names[0]}
+	if len(names) > 1 {
+		field.Nested = Set{}.add(names[1:])
+	}
+	return append(s, field)
+}
+
+// Apply は JSON から選択したフィールドだけを残した JSON を返します。
+// オブジェクトのキーは fields で指定した順序になり、文字列・数値などの値はそのまま返します。
+//
+// 引数:
+//   - body: レスポンスの JSON
+//   - set: 選択するフィールド（nil の場合は body をそのまま返す）
+//
+// 戻り値:
+//   - []byte: 選択したフィールドの JSON
+//   - error: body が JSON として読み取れない場合のエラー
+func Apply(body []byte, set Set) ([]byte, error) {
+	if set == nil {
+		return body, nil
+	}
+	if !json.Valid(body) {
+		return nil, errors.New("response is not valid JSON")
+	}
+	var buf bytes.Buffer
+	if err := writeValue(&buf, json.RawMessage(bytes.TrimSpace(body)), set, true); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeValue は値から選択したフィールドを書き込みます（top はレスポンスの最上位の値）。
+func writeValue(buf *bytes.Buffer, value json.RawMessage, set Set, top bool) error {
+	if len(value) == 0 {
+		return errors.New("empty JSON value")
+	}
+	switch value[0] {
+	case '[':
+		return writeArray(buf, value, set)
+	case '{':
+		if top {
+			return writeTopObject(buf, value, set)
+		}
+		return writeObject(buf, value, set)
+	}
+	// 文字列・数値・真偽値・null は選択の対象外
+	buf.Write(value)
+	return nil
+}
+
+// writeArray は配列の要素ごとに選択したフィールドを書き込みます。
+func writeArray(buf *bytes.Buffer, value json.RawMessage, set Set) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return err
+	}
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeValue(buf, item, set, false); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// writeTopObject は最上位のオブジェクトを書き込みます。
+// 一覧の1ページ分のレスポンス（pagination.Page）の場合は items の要素に適用し、ページングのフィールドは残します。
+func writeTopObject(buf *bytes.Buffer, value json.RawMessage, set Set) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(value, &object); err != nil {
+		return err
+	}
+	items, isPage := object["items"]
+	if _, hasMore := object["has_more"]; !isPage || !hasMore {
+		return writeObject(buf, value, set)
+	}
+
+	var filtered bytes.Buffer
+	if err := writeValue(&filtered, items, set, false); err != nil {
+		return err
+	}
+	object["items"] = filtered.Bytes()
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
+
+// writeObject はオブジェクトから選択したフィールドを指定した順序で書き込みます。
+func writeObject(buf *bytes.Buffer, value json.RawMessage, set Set) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(value, &object); err != nil {
+		return err
+	}
+	buf.WriteByte('{')
+	written := 0
+	for _, field := range set {
+		fieldValue, ok := object[field.Name]
+		if !ok {
+			continue
+		}
+		if written > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(field.Name)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if field.Nested == nil {
+			buf.Write(fieldValue)
+		} else if err := writeValue(buf, fieldValue, field.Nested, false); err != nil {
+			return err
+		}
+		written++
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,105 @@
+package fieldset
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Sparse Fieldsets Tests - フィールドの選択のテスト
+// =============================================================================
+// テスト対象:
+//   - Parse: fields の読み取り、入れ子・重複の統合、不正な形式の拒否
+//   - Apply: オブジェクト・配列・一覧の1ページ分のレスポンスからのフィールドの選択
+
+// TestParse は fields の読み取りのテストです。
+// 期待動作:
+//   - 空の場合は nil（全てのフィールド）
+//   - ドット区切りは入れ子、全体と入れ子の両方の指定は全体になる
+//   - 不正な文字・空のフィールド・上限を超える指定は ErrInvalidFields
+func TestParse(t *testing.T) {
+	// Act
+	empty, err := Parse(" ")
+	if err != nil || empty != nil {
+		t.Fatalf("Expected nil set for empty fields, got %v %v", empty, err)
+	}
+	set, err := Parse("id, crop.name,crop.variety,plot.name,plot")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(set) != 3 || set[0].Name != "id" || set[1].Name != "crop" || set[2].Name != "plot" {
+		t.Fatalf("Unexpected fields: %+v", set)
+	}
+	if len(set[1].Nested) != 2 || set[1].Nested[1].Name != "variety" {
+		t.Errorf("Expected crop.name and crop.variety nested, got %+v", set[1].Nested)
+	}
+	if set[2].Nested != nil {
+		t.Errorf("Expected whole plot to be selected, got %+v", set[2].Nested)
+	}
+
+	for _, raw := range []string{"id,,title", "title;drop", "crop.", strings.Repeat("a,", MaxFields) + "a"} {
+		if _, err := Parse(raw); !errors.Is(err, ErrInvalidFields) {
+			t.Errorf("Expected ErrInvalidFields for %q, got %v", raw, err)
+		}
+	}
+}
+
+// TestApply はフィールドの選択のテストです。
+// 期待動作:
+//   - オブジェクトは指定したフィールドだけを指定した順序で残し、ないフィールドは無視する
+//   - 配列は要素ごと、入れ子のオブジェクトはドット区切りの指定で選択する
+//   - 一覧の1ページ分のレスポンスは items に適用し、ページングのフィールドを残す
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		body   string
+		want   string
+	}{
+		{
+			name:   "オブジェクト",
+			fields: "title,id,missing",
+			body:   `{"id": 1, "title": "水やり", "due_date": "2026-05-01T09:00:00Z"}`,
+			want:   `{"title":"水やり","id":1}`,
+		},
+		{
+			name:   "配列と入れ子",
+			fields: "id,crop.name",
+			body:   `[{"id": 1, "crop": {"name": "トマト", "variety": "桃太郎"}}, {"id": 2, "crop": null}]`,
+			want:   `[{"id":1,"crop":{"name":"トマト"}},{"id":2,"crop":null}]`,
+		},
+		{
+			name:   "一覧の1ページ分",
+			fields: "id",
+			body:   `{"items": [{"id": 3, "title": "a"}], "next_cursor": "eyJpZCI6M30", "has_more": true, "limit": 1}`,
+			want:   `{"has_more":true,"items":[{"id":3}],"limit":1,"next_cursor":"eyJpZCI6M30"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			set, err := Parse(tt.fields)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			// Act
+			got, err := Apply([]byte(tt.body), set)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := Apply([]byte("not json"), Set{{Name: "id"}}); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,299 @@
+// Package filter - 一覧の取得の絞り込みの条件
+//
+// 一覧のエンドポイント（タスク・作物・区画）で共通の絞り込みの条件（列, 演算子, 値）を提供します。
+// リポジトリは条件を GORM の WHERE に変換するため、絞り込みの組み合わせごとにメソッド（GetByXAndY など）を追加する必要はありません。
+//
+//   - クエリパラメータ filter に「フィールド:演算子:値」を指定する（複数指定した場合は AND、例: filter=priority:in:high,medium）
+//   - 指定できるフィールドと型はエンドポイントごとの Schema で決まり、Schema にないフィールドはエラーにする
+//   - 条件の列名は Schema の固定の列名のため、ユーザーの入力が SQL の列名になることはない
+//   - 値は型ごとに変換する（文字列、数値、日時は RFC3339 または YYYY-MM-DD（UTC の0時）、真偽値は true / false）
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Filter Spec - 絞り込みの条件
+// =============================================================================
+
+const (
+	// MaxConditions は filter に指定できる条件の数の上限です。
+	MaxConditions = 10
+	// MaxValues は in の演算子に指定できる値の数の上限です。
+	MaxValues = 50
+)
+
+// ErrInvalidFilter は読み取れない filter を指定した場合のエラー
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Operator は条件の演算子です。
+type Operator string
+
+// 条件の演算子
+const (
+	Eq       Operator = "eq"       // 等しい
+	Ne       Operator = "ne"       // 等しくない
+	Gt       Operator = "gt"       // より大きい（後）
+	Gte      Operator = "gte"      // 以上（以降）
+	Lt       Operator = "lt"       // より小さい（前）
+	Lte      Operator = "lte"      // 以下（以前）
+	In       Operator = "in"       // カンマ区切りの値のいずれか
+	Contains Operator = "contains" // 部分一致（大文字と小文字を区別しない）
+)
+
+// Type はフィールドの値の型です。
+type Type int
+
+// フィールドの値の型
+const (
+	String Type = iota
+	Number
+	Time
+	Bool
+)
+
+// operatorsByType は型ごとに指定できる演算子です。
+var operatorsByType = map[Type][]Operator{
+	String: {Eq, Ne, In, Contains},
+	Number: {Eq, Ne, Gt, Gte, Lt, Lte, In},
+	Time:   {Eq, Ne, Gt, Gte, Lt, Lte},
+	Bool:   {Eq, Ne},
+}
+
+// Field は絞り込みに指定できるフィールドです。
+type Field struct {
+	Column string // 条件の列名（固定の値、ユーザーの入力を使用しない）
+	Type   Type
+}
+
+// Schema はエンドポイントで絞り込みに指定できるフィールドです（キーはクエリパラメータのフィールド名）。
+type Schema map[string]Field
+
+// Condition は絞り込みの条件の1つです。
+type Condition struct {
+	Field    string   // 列名（Parse では Schema の Column）
+	Operator Operator // 演算子
+	Value    any      // 型ごとの値（string, float64, time.Time, bool、In の場合はそれらの []any）
+}
+
+// Spec は絞り込みの条件です（すべての条件を満たす行、空の場合は絞り込まない）。
+type Spec []Condition
+
+// Parse はクエリパラメータ filter の値から絞り込みの条件を作成します。
+//
+// 引数:
+//   - raw: 「フィールド:演算子:値」の形式の条件（空の場合は絞り込まない）
+//   - schema: 指定できるフィールド
+//
+// 戻り値:
+//   - Spec: 絞り込みの条件（raw が空の場合は nil）
+//   - error: 形式が不正・Schema にないフィールド・型に対応しない演算子・変換できない値の場合は ErrInvalidFilter
+func Parse(raw []string, schema Schema) (Spec, error) {
+	if len(raw) > MaxConditions {
+		return nil, fmt.Errorf("%w: at most %d conditions", ErrInvalidFilter, MaxConditions)
+	}
+
+	var spec Spec
+	for _, expression := range raw {
+		parts := strings.SplitN(expression, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%w: %q is not field:operator:value", ErrInvalidFilter, expression)
+		}
+		name, op, value := strings.TrimSpace(parts[0]), Operator(strings.TrimSpace(parts[1])), parts[2]
+
+		field, ok := schema[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, name)
+		}
+		if !supports(field.Type, op) {
+			return nil, fmt.Errorf("%w: operator %q is not supported for %s", ErrInvalidFilter, op, name)
+		}
+
+		condition := Condition{Field: field.Column, Operator: op}
+		if op == In {
+			values := strings.Split(value, ",")
+			if len(values) > MaxValues {
+				return nil, fmt.Errorf("%w: at most %d values for %s", ErrInvalidFilter, MaxValues, name)
+			}
+			converted := make([]any, 0, len(values))
+			for _, v := range values {
+				parsed, err := parseValue(field.Type, v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, name, err)
+				}
+				converted = append(converted, parsed)
+			}
+			condition.Value = converted
+		} else {
+			parsed, err := parseValue(field.Type, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, name, err)
+			}
+			condition.Value = parsed
+		}
+		spec = append(spec, condition)
+	}
+	return spec, nil
+}
+
+// EqualIfSet は value が空でない場合に列が value と等しい条件を追加した Spec を返します。
+// status クエリパラメータなど、省略可能な固定の条件に使用します。
+func (s Spec) EqualIfSet(column, value string) Spec {
+	if value == "" {
+		return s
+	}
+	return append(s, Condition{Field: column, Operator: Eq, Value: value})
+}
+
+// Match は行が絞り込みの条件をすべて満たすかどうかを返します（モックのリポジトリ用、SQL の WHERE と同じ判定）。
+// lookup は列名から行の値を返し、NULL（nil のポインター）の列は SQL と同様にどの条件も満たしません。
+//
+// 引数:
+//   - lookup: 列名から行の値を返す関数（列がない場合は false）
+//
+// 戻り値:
+//   - bool: すべての条件を満たす場合は true
+func (s Spec) Match(lookup func(column string) (any, bool)) bool {
+	for _, condition := range s {
+		value, ok := lookup(condition.Field)
+		if !ok {
+			return false
+		}
+		actual, ok := normalize(value)
+		if !ok || !condition.matches(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches は正規化した行の値が条件を満たすかどうかを返します。
+func (c Condition) matches(actual any) bool {
+	switch c.Operator {
+	case In:
+		values, _ := c.Value.([]any)
+		for _, v := range values {
+			if cmp, ok := compare(actual, v); ok && cmp == 0 {
+				return true
+			}
+		}
+		return false
+	case Contains:
+		text, ok := actual.(string)
+		pattern, _ := c.Value.(string)
+		return ok && strings.Contains(strings.ToLower(text), strings.ToLower(pattern))
+	}
+
+	cmp, ok := compare(actual, c.Value)
+	if !ok {
+		return false
+	}
+	switch c.Operator {
+	case Eq:
+		return cmp == 0
+	case Ne:
+		return cmp != 0
+	case Gt:
+		return cmp > 0
+	case Gte:
+		return cmp >= 0
+	case Lt:
+		return cmp < 0
+	case Lte:
+		return cmp <= 0
+	}
+	return false
+}
+
+// normalize は行の値を Condition.Value と同じ型（string, float64, time.Time, bool）に変換します（NULL の場合は false）。
+func normalize(value any) (any, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Invalid:
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// compare は2つの値を比較します（型が異なる場合は false）。
+func compare(a, b any) (int, bool) {
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return strings.Compare(x, y), ok
+	case float64:
+		y, ok := b.(float64)
+		switch {
+		case !ok:
+			return 0, false
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case time.Time:
+		y, ok := b.(time.Time)
+		return x.Compare(y), ok
+	case bool:
+		y, ok := b.(bool)
+		if !ok || x == y {
+			return 0, ok
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// supports は型に演算子を指定できるかどうかを返します。
+func supports(t Type, op Operator) bool {
+	for _, candidate := range operatorsByType[t] {
+		if candidate == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseValue はクエリパラメータの値を型ごとの値に変換します。
+func parseValue(t Type, value string) (any, error) {
+	value = strings.TrimSpace(value)
+	switch t {
+	case Number:
+		return strconv.ParseFloat(value, 64)
+	case Time:
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed, nil
+		}
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not RFC3339 or YYYY-MM-DD", value)
+		}
+		return parsed, nil
+	case Bool:
+		return strconv.ParseBool(value)
+	default:
+		if value == "" {
+			return nil, errors.New("empty value")
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,147 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Filter Tests - 絞り込みの条件のテスト
+// =============================================================================
+// テスト対象:
+//   - Parse: 「フィールド:演算子:値」の解析、Schema・型・演算子の検証
+//   - EqualIfSet: 省略可能な等価の条件の追加
+//   - Match: モックのリポジトリでの行の判定（NULL はどの条件も満たさない）
+
+// testSchema はテスト用のフィールドです。
+var testSchema = Schema{
+	"status":   {Column: "status", Type: String},
+	"title":    {Column: "title", Type: String},
+	"width":    {Column: "width", Type: Number},
+	"due_date": {Column: "due_date", Type: Time},
+	"done":     {Column: "is_done", Type: Bool},
+}
+
+// TestParse は filter の解析のテストです。
+// 期待動作:
+//   - Schema の列名と型ごとの値の条件を作成する（in はカンマ区切り、日時は RFC3339 または YYYY-MM-DD、値の ":" はそのまま）
+//   - 形式が不正・Schema にないフィールド・型に対応しない演算子・変換できない値・条件の数の超過は ErrInvalidFilter
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []string
+		want    Condition
+		wantErr string
+	}{
+		{name: "string", raw: []string{"status:eq:pending"}, want: Condition{Field: "status", Operator: Eq, Value: "pending"}},
+		{name: "colon in value", raw: []string{"title:contains:10:00"}, want: Condition{Field: "title", Operator: Contains, Value: "10:00"}},
+		{name: "number", raw: []string{"width:gte:1.5"}, want: Condition{Field: "width", Operator: Gte, Value: 1.5}},
+		{name: "date", raw: []string{"due_date:lt:2026-05-01"}, want: Condition{Field: "due_date", Operator: Lt, Value: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		{name: "bool", raw: []string{"done:eq:true"}, want: Condition{Field: "is_done", Operator: Eq, Value: true}},
+		{name: "malformed", raw: []string{"status=pending"}, wantErr: "not field:operator:value"},
+		{name: "unknown field", raw: []string{"user_id:eq:1"}, wantErr: "unknown field"},
+		{name: "unsupported operator", raw: []string{"due_date:contains:2026"}, wantErr: "not supported"},
+		{name: "invalid number", raw: []string{"width:gt:wide"}, wantErr: "width"},
+		{name: "invalid time", raw: []string{"due_date:gt:tomorrow"}, wantErr: "RFC3339"},
+		{name: "empty value", raw: []string{"status:eq:"}, wantErr: "empty value"},
+		{name: "too many", raw: make([]string, MaxConditions+1), wantErr: "at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			spec, err := Parse(tt.raw, testSchema)
+
+			// Assert
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidFilter) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected ErrInvalidFilter containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || len(spec) != 1 {
+				t.Fatalf("Expected 1 condition, got %v (%v)", spec, err)
+			}
+			got := spec[0]
+			if got.Field != tt.want.Field || got.Operator != tt.want.Operator || compareValues(got.Value, tt.want.Value) != 0 {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// compareValues はテストの値を比較します（比較できない場合は -1）。
+func compareValues(a, b any) int {
+	cmp, ok := compare(a, b)
+	if !ok {
+		return -1
+	}
+	return cmp
+}
+
+// TestParse_In は in の演算子のテストです。
+// 期待動作:
+//   - カンマ区切りの値をそれぞれ型ごとに変換する
+//   - 空の filter は nil（絞り込まない）
+func TestParse_In(t *testing.T) {
+	// Act
+	spec, err := Parse([]string{"status:in:pending, completed", "width:in:1,2"}, testSchema)
+	empty, emptyErr := Parse(nil, testSchema)
+
+	// Assert
+	if err != nil || len(spec) != 2 {
+		t.Fatalf("Expected 2 conditions, got %v (%v)", spec, err)
+	}
+	statuses, _ := spec[0].Value.([]any)
+	widths, _ := spec[1].Value.([]any)
+	if len(statuses) != 2 || statuses[1] != "completed" || len(widths) != 2 || widths[1] != 2.0 {
+		t.Errorf("Expected converted values, got %v and %v", statuses, widths)
+	}
+	if emptyErr != nil || empty != nil {
+		t.Errorf("Expected no conditions, got %v (%v)", empty, emptyErr)
+	}
+}
+
+// TestSpec_Match は行の判定のテストです。
+// 期待動作:
+//   - すべての条件を満たす行のみ true（整数・ポインターの値は正規化して比較）
+//   - contains は大文字と小文字を区別しない
+//   - NULL（nil のポインター）・存在しない列はどの条件も満たさない
+//   - EqualIfSet は空の値の場合は条件を追加しない
+func TestSpec_Match(t *testing.T) {
+	// Arrange
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	width := 3
+	row := map[string]any{"status": "pending", "title": "Water Tomatoes", "width": &width, "due_date": due, "is_done": false, "completed_at": (*time.Time)(nil)}
+	lookup := func(column string) (any, bool) {
+		value, ok := row[column]
+		return value, ok
+	}
+	spec, err := Parse([]string{"title:contains:tomato", "width:in:2,3", "due_date:gte:2026-05-01", "done:ne:true"}, testSchema)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Act
+	matched := spec.Match(lookup)
+	byStatus := Spec{}.EqualIfSet("status", "completed").Match(lookup)
+	unset := Spec{}.EqualIfSet("status", "")
+	null := Spec{{Field: "completed_at", Operator: Ne, Value: due}}.Match(lookup)
+	missing := Spec{{Field: "priority", Operator: Ne, Value: "high"}}.Match(lookup)
+
+	// Assert
+	if !matched {
+		t.Error("Expected the row to match all conditions")
+	}
+	if byStatus {
+		t.Error("Expected a different status not to match")
+	}
+	if len(unset) != 0 || !unset.Match(lookup) {
+		t.Errorf("Expected no conditions for an empty status, got %v", unset)
+	}
+	if null || missing {
+		t.Errorf("Expected NULL and missing columns not to match, got %v and %v", null, missing)
+	}
+}
@@ -0,0 +1,229 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// DataLoader - バッチ取得ローダー
+// =============================================================================
+
+// DefaultBatchWait はバッチを締め切るまでの待機時間です。
+// gqlgenは兄弟フィールドを並行に解決するため、この間に集まったキーを1回で取得します。
+const DefaultBatchWait = 2 * time.Millisecond
+
+// batchFunc はキーの一覧から値のマップを取得する関数です。
+// マップに存在しないキーはゼロ値として扱われます。
+type batchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// loaderBatch は1回分のバッチ取得の状態を保持します。
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	results map[K]V
+	err     error
+	done    chan struct{}
+}
+
+// Loader はキー単位の取得要求をまとめてバッチ取得する汎用DataLoaderです。
+// 取得結果はリクエスト内でキャッシュされます。
+type Loader[K comparable, V any] struct {
+	fetch batchFunc[K, V]
+	wait  time.Duration
+
+	mu    sync.Mutex
+	batch *loaderBatch[K, V]
+	cache map[K]V
+}
+
+// NewLoader は新しいLoaderを作成します。
+//
+// 引数:
+//   - fetch: バッチ取得関数
+//   - wait: バッチを締め切るまでの待機時間
+//
+// 戻り値:
+//   - *Loader: DataLoaderインスタンス
+func NewLoader[K comparable, V any](fetch batchFunc[K, V], wait time.Duration) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch: fetch,
+		wait:  wait,
+		cache: make(map[K]V),
+	}
+}
+
+// Load はキーに対応する値を取得します。
+// 待機時間内に呼ばれた他のLoadとまとめて1回のバッチ取得が行われます。
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	if v, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return v, nil
+	}
+
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[K, V]{done: make(chan struct{})}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+	}
+	b.keys = appendUnique(b.keys, key)
+	l.mu.Unlock()
+
+	<-b.done
+	if b.err != nil {
+		var zero V
+		return zero, b.err
+	}
+	return b.results[key], nil
+}
+
+// dispatch はバッチを締め切って取得関数を実行します。
+// 取得関数が panic した場合もバッチを待つすべての Load にエラーを返します。
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *loaderBatch[K, V]) {
+	defer close(b.done)
+	defer func() {
+		if r := recover(); r != nil {
+			b.results, b.err = nil, fmt.Errorf("dataloader: batch function panicked: %v", r)
+		}
+	}()
+
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	keys := b.keys
+	l.mu.Unlock()
+
+	b.results, b.err = l.fetch(ctx, keys)
+
+	if b.err == nil {
+		l.mu.Lock()
+		for _, k := range keys {
+			l.cache[k] = b.results[k]
+		}
+		l.mu.Unlock()
+	}
+}
+
+// appendUnique は重複しない場合のみキーを追加します。
+func appendUnique[K comparable](keys []K, key K) []K {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
+// =============================================================================
+// Loaders - リクエスト単位のローダー群
+// =============================================================================
+
+// Loaders はリクエスト単位で生成されるDataLoaderの集合です。
+// すべてのローダーは認証済みユーザーのデータのみを返します。
+type Loaders struct {
+	CropByID              *Loader[uint, *model.Crop]
+	PlotByID              *Loader[uint, *model.Plot]
+	ActiveCropByPlotID    *Loader[uint, *model.Crop]
+	HarvestsByCropID      *Loader[uint, []*model.Harvest]
+	GrowthRecordsByCropID *Loader[uint, []*model.GrowthRecord]
+}
+
+// NewLoaders はユーザー単位のDataLoader群を作成します。
+//
+// 引数:
+//   - svc: ビジネスロジックサービス
+//   - userID: 認証済みユーザーID
+//
+// 戻り値:
+//   - *Loaders: DataLoader群
+func NewLoaders(svc *service.Service, userID uint) *Loaders {
+	return &Loaders{
+		CropByID: NewLoader(func(ctx context.Context, ids []uint) (map[uint]*model.Crop, error) {
+			crops, err := svc.GetUserCropsByIDs(ctx, userID, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*model.Crop, len(crops))
+			for i := range crops {
+				result[crops[i].ID] = &crops[i]
+			}
+			return result, nil
+		}, DefaultBatchWait),
+
+		PlotByID: NewLoader(func(ctx context.Context, ids []uint) (map[uint]*model.Plot, error) {
+			plots, err := svc.GetUserPlotsByIDs(ctx, userID, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*model.Plot, len(plots))
+			for i := range plots {
+				result[plots[i].ID] = &plots[i]
+			}
+			return result, nil
+		}, DefaultBatchWait),
+
+		ActiveCropByPlotID: NewLoader(func(ctx context.Context, plotIDs []uint) (map[uint]*model.Crop, error) {
+			return svc.GetUserActiveCropsByPlotIDs(ctx, userID, plotIDs)
+		}, DefaultBatchWait),
+
+		HarvestsByCropID: NewLoader(func(ctx context.Context, cropIDs []uint) (map[uint][]*model.Harvest, error) {
+			harvests, err := svc.GetUserHarvestsByCropIDs(ctx, userID, cropIDs)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint][]*model.Harvest, len(cropIDs))
+			for i := range harvests {
+				result[harvests[i].CropID] = append(result[harvests[i].CropID], &harvests[i])
+			}
+			return result, nil
+		}, DefaultBatchWait),
+
+		GrowthRecordsByCropID: NewLoader(func(ctx context.Context, cropIDs []uint) (map[uint][]*model.GrowthRecord, error) {
+			records, err := svc.GetUserGrowthRecordsByCropIDs(ctx, userID, cropIDs)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint][]*model.GrowthRecord, len(cropIDs))
+			for i := range records {
+				result[records[i].CropID] = append(result[records[i].CropID], &records[i])
+			}
+			return result, nil
+		}, DefaultBatchWait),
+	}
+}
+
+// =============================================================================
+// Context Helpers - コンテキストヘルパー
+// =============================================================================
+
+type contextKey string
+
+const (
+	userIDKey  contextKey = "graphql_user_id"
+	loadersKey contextKey = "graphql_loaders"
+)
+
+// WithUser は認証済みユーザーIDとDataLoader群をコンテキストに設定します。
+func WithUser(ctx context.Context, svc *service.Service, userID uint) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	return context.WithValue(ctx, loadersKey, NewLoaders(svc, userID))
+}
+
+// userIDFromContext はコンテキストから認証済みユーザーIDを取得します。
+func userIDFromContext(ctx context.Context) uint {
+	userID, _ := ctx.Value(userIDKey).(uint)
+	return userID
+}
+
+// loadersFromContext はコンテキストからDataLoader群を取得します。
+func loadersFromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// DataLoader Tests - バッチ取得ローダーのテスト
+// =============================================================================
+// テスト対象:
+//   - Loader.Load: 取得関数が panic した場合のバッチの完了
+
+// TestLoader_FetchPanic は取得関数が panic した場合のテストです。
+// 期待動作:
+//   - 同じバッチを待つすべての Load がブロックせずにエラーを返す
+func TestLoader_FetchPanic(t *testing.T) {
+	// Arrange
+	loader := NewLoader(func(ctx context.Context, keys []uint) (map[uint]string, error) {
+		panic("boom")
+	}, DefaultBatchWait)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	errs := make(chan error, 2)
+	for _, key := range []uint{1, 2} {
+		go func() {
+			_, err := loader.Load(ctx, key)
+			errs <- err
+		}()
+	}
+
+	// Assert
+	for range 2 {
+		select {
+		case err := <-errs:
+			if err == nil {
+				t.Error("Expected an error from the panicking batch")
+			}
+		case <-ctx.Done():
+			t.Fatal("Expected Load to return after the batch function panicked")
+		}
+	}
+}
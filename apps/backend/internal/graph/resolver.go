@@ -0,0 +1,43 @@
+// Package graph はGraphQL APIのリゾルバーを提供します。
+//
+// スキーマは schema.graphqls に定義され、gqlgenによって generated.go が生成されます。
+// ネストしたフィールド（作物の収穫記録、区画の現在の作物など）は
+// リクエスト単位のDataLoaderでバッチ取得し、N+1クエリを回避します。
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate --config ../../gqlgen.yml
+
+import (
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// Resolver はGraphQLリゾルバーのルートです。
+// 依存するサービスを保持し、各リゾルバーから参照されます。
+type Resolver struct {
+	service *service.Service
+}
+
+// NewResolver は新しいResolverを作成します。
+//
+// 引数:
+//   - svc: ビジネスロジックサービス
+//
+// 戻り値:
+//   - *Resolver: リゾルバーのルート
+func NewResolver(svc *service.Service) *Resolver {
+	return &Resolver{service: svc}
+}
+
+// Analytics は分析クエリのルートオブジェクトです。
+// 各フィールドは引数付きのリゾルバーで個別に解決されます。
+type Analytics struct{}
+
+// toPointers は値スライスをポインタスライスに変換します。
+// gqlgenはリスト要素をポインタで扱うため、サービス層の戻り値を変換する際に使用します。
+func toPointers[T any](items []T) []*T {
+	result := make([]*T, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
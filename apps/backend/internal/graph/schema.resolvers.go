@@ -0,0 +1,221 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// HarvestSummary is the resolver for the harvestSummary field.
+func (r *analyticsResolver) HarvestSummary(ctx context.Context, obj *Analytics, startDate *time.Time, endDate *time.Time, cropID *uint) (*service.HarvestSummary, error) {
+	return r.service.GetHarvestSummary(ctx, userIDFromContext(ctx), service.HarvestFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		CropID:    cropID,
+	})
+}
+
+// MonthlyHarvest is the resolver for the monthlyHarvest field.
+func (r *analyticsResolver) MonthlyHarvest(ctx context.Context, obj *Analytics, year *int, startDate *time.Time, endDate *time.Time) ([]*service.MonthlyHarvestData, error) {
+	chart, err := r.service.GetChartData(ctx, userIDFromContext(ctx), service.ChartTypeMonthlyHarvest, service.ChartFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Year:      year,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, _ := chart.Data.([]service.MonthlyHarvestData)
+	return toPointers(data), nil
+}
+
+// CropComparison is the resolver for the cropComparison field.
+func (r *analyticsResolver) CropComparison(ctx context.Context, obj *Analytics, startDate *time.Time, endDate *time.Time) ([]*service.CropComparisonData, error) {
+	chart, err := r.service.GetChartData(ctx, userIDFromContext(ctx), service.ChartTypeCropComparison, service.ChartFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, _ := chart.Data.([]service.CropComparisonData)
+	return toPointers(data), nil
+}
+
+// PlotProductivity is the resolver for the plotProductivity field.
+func (r *analyticsResolver) PlotProductivity(ctx context.Context, obj *Analytics, startDate *time.Time, endDate *time.Time) ([]*service.PlotProductivityData, error) {
+	chart, err := r.service.GetChartData(ctx, userIDFromContext(ctx), service.ChartTypePlotProductivity, service.ChartFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, _ := chart.Data.([]service.PlotProductivityData)
+	return toPointers(data), nil
+}
+
+// Plot is the resolver for the plot field.
+func (r *cropResolver) Plot(ctx context.Context, obj *model.Crop) (*model.Plot, error) {
+	if obj.PlotID == nil {
+		return nil, nil
+	}
+	return loadersFromContext(ctx).PlotByID.Load(ctx, *obj.PlotID)
+}
+
+// Harvests is the resolver for the harvests field.
+func (r *cropResolver) Harvests(ctx context.Context, obj *model.Crop) ([]*model.Harvest, error) {
+	harvests, err := loadersFromContext(ctx).HarvestsByCropID.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if harvests == nil {
+		harvests = []*model.Harvest{}
+	}
+	return harvests, nil
+}
+
+// GrowthRecords is the resolver for the growthRecords field.
+func (r *cropResolver) GrowthRecords(ctx context.Context, obj *model.Crop) ([]*model.GrowthRecord, error) {
+	records, err := loadersFromContext(ctx).GrowthRecordsByCropID.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []*model.GrowthRecord{}
+	}
+	return records, nil
+}
+
+// Crop is the resolver for the crop field.
+func (r *harvestResolver) Crop(ctx context.Context, obj *model.Harvest) (*model.Crop, error) {
+	return loadersFromContext(ctx).CropByID.Load(ctx, obj.CropID)
+}
+
+// QualityDistribution is the resolver for the qualityDistribution field.
+func (r *harvestSummaryResolver) QualityDistribution(ctx context.Context, obj *service.HarvestSummary) ([]*QualityCount, error) {
+	result := make([]*QualityCount, 0, len(obj.QualityDistribution))
+	for quality, count := range obj.QualityDistribution {
+		result = append(result, &QualityCount{Quality: quality, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Quality < result[j].Quality })
+	return result, nil
+}
+
+// CurrentCrop is the resolver for the currentCrop field.
+func (r *plotResolver) CurrentCrop(ctx context.Context, obj *model.Plot) (*model.Crop, error) {
+	return loadersFromContext(ctx).ActiveCropByPlotID.Load(ctx, obj.ID)
+}
+
+// Crops is the resolver for the crops field.
+func (r *queryResolver) Crops(ctx context.Context, status *string) ([]*model.Crop, error) {
+	userID := userIDFromContext(ctx)
+	var crops []model.Crop
+	var err error
+	if status != nil && *status != "" {
+		crops, err = r.service.GetUserCropsByStatus(ctx, userID, *status)
+	} else {
+		crops, err = r.service.GetUserCrops(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(crops), nil
+}
+
+// Crop is the resolver for the crop field.
+func (r *queryResolver) Crop(ctx context.Context, id uint) (*model.Crop, error) {
+	return loadersFromContext(ctx).CropByID.Load(ctx, id)
+}
+
+// Plots is the resolver for the plots field.
+func (r *queryResolver) Plots(ctx context.Context, status *string) ([]*model.Plot, error) {
+	userID := userIDFromContext(ctx)
+	var plots []model.Plot
+	var err error
+	if status != nil && *status != "" {
+		plots, err = r.service.GetUserPlotsByStatus(ctx, userID, *status)
+	} else {
+		plots, err = r.service.GetUserPlots(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(plots), nil
+}
+
+// Plot is the resolver for the plot field.
+func (r *queryResolver) Plot(ctx context.Context, id uint) (*model.Plot, error) {
+	return loadersFromContext(ctx).PlotByID.Load(ctx, id)
+}
+
+// Tasks is the resolver for the tasks field.
+func (r *queryResolver) Tasks(ctx context.Context, status *string) ([]*model.Task, error) {
+	userID := userIDFromContext(ctx)
+	var tasks []model.Task
+	var err error
+	if status != nil && *status != "" {
+		tasks, err = r.service.GetUserTasksByStatus(ctx, userID, *status)
+	} else {
+		tasks, err = r.service.GetUserTasks(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(tasks), nil
+}
+
+// TodayTasks is the resolver for the todayTasks field.
+func (r *queryResolver) TodayTasks(ctx context.Context) ([]*model.Task, error) {
+	tasks, err := r.service.GetTodayTasks(ctx, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(tasks), nil
+}
+
+// OverdueTasks is the resolver for the overdueTasks field.
+func (r *queryResolver) OverdueTasks(ctx context.Context) ([]*model.Task, error) {
+	tasks, err := r.service.GetOverdueTasks(ctx, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(tasks), nil
+}
+
+// Analytics is the resolver for the analytics field.
+func (r *queryResolver) Analytics(ctx context.Context) (*Analytics, error) {
+	return &Analytics{}, nil
+}
+
+// Analytics returns AnalyticsResolver implementation.
+func (r *Resolver) Analytics() AnalyticsResolver { return &analyticsResolver{r} }
+
+// Crop returns CropResolver implementation.
+func (r *Resolver) Crop() CropResolver { return &cropResolver{r} }
+
+// Harvest returns HarvestResolver implementation.
+func (r *Resolver) Harvest() HarvestResolver { return &harvestResolver{r} }
+
+// HarvestSummary returns HarvestSummaryResolver implementation.
+func (r *Resolver) HarvestSummary() HarvestSummaryResolver { return &harvestSummaryResolver{r} }
+
+// Plot returns PlotResolver implementation.
+func (r *Resolver) Plot() PlotResolver { return &plotResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type analyticsResolver struct{ *Resolver }
+type cropResolver struct{ *Resolver }
+type harvestResolver struct{ *Resolver }
+type harvestSummaryResolver struct{ *Resolver }
+type plotResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
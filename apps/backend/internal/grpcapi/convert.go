@@ -0,0 +1,110 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/grpcapi/gardenv1"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// =============================================================================
+// Conversions - モデルから protobuf のメッセージへの変換
+// =============================================================================
+
+// toProtoCrop は作物を protobuf のメッセージに変換します。
+func toProtoCrop(crop *model.Crop) *gardenv1.Crop {
+	return &gardenv1.Crop{
+		Id:                  uint32(crop.ID),
+		UserId:              uint32(crop.UserID),
+		PlotId:              optionalID(crop.PlotID),
+		Name:                crop.Name,
+		Variety:             crop.Variety,
+		PlantedDate:         timestamp(crop.PlantedDate),
+		ExpectedHarvestDate: timestamp(crop.ExpectedHarvestDate),
+		Status:              crop.Status,
+		Notes:               crop.Notes,
+		CreatedAt:           timestamp(crop.CreatedAt),
+		UpdatedAt:           timestamp(crop.UpdatedAt),
+	}
+}
+
+// toProtoTask はタスクを protobuf のメッセージに変換します。
+func toProtoTask(task *model.Task) *gardenv1.Task {
+	msg := &gardenv1.Task{
+		Id:                 uint32(task.ID),
+		UserId:             uint32(task.UserID),
+		PlantId:            optionalID(task.PlantID),
+		Title:              task.Title,
+		Description:        task.Description,
+		DueDate:            timestamp(task.DueDate),
+		Priority:           task.Priority,
+		Status:             task.Status,
+		Recurrence:         task.Recurrence,
+		RecurrenceInterval: int32(task.RecurrenceInterval),
+		CreatedAt:          timestamp(task.CreatedAt),
+		UpdatedAt:          timestamp(task.UpdatedAt),
+	}
+	if task.CompletedAt != nil {
+		msg.CompletedAt = timestamp(*task.CompletedAt)
+	}
+	return msg
+}
+
+// toProtoHarvest は収穫記録を protobuf のメッセージに変換します。
+func toProtoHarvest(harvest *model.Harvest) *gardenv1.Harvest {
+	return &gardenv1.Harvest{
+		Id:           uint32(harvest.ID),
+		CropId:       uint32(harvest.CropID),
+		HarvestDate:  timestamp(harvest.HarvestDate),
+		Quantity:     harvest.Quantity,
+		QuantityUnit: harvest.QuantityUnit,
+		Quality:      harvest.Quality,
+		Notes:        harvest.Notes,
+		CreatedAt:    timestamp(harvest.CreatedAt),
+		UpdatedAt:    timestamp(harvest.UpdatedAt),
+	}
+}
+
+// toProtoHarvestSummary は収穫量の集計を protobuf のメッセージに変換します。
+func toProtoHarvestSummary(summary *service.HarvestSummary) *gardenv1.HarvestSummary {
+	msg := &gardenv1.HarvestSummary{
+		TotalHarvests:       int32(summary.TotalHarvests),
+		TotalQuantityKg:     summary.TotalQuantityKg,
+		QualityDistribution: make(map[string]int32, len(summary.QualityDistribution)),
+	}
+	for _, crop := range summary.CropSummaries {
+		msg.CropSummaries = append(msg.CropSummaries, &gardenv1.CropHarvestSummary{
+			CropId:            uint32(crop.CropID),
+			CropName:          crop.CropName,
+			HarvestCount:      int32(crop.HarvestCount),
+			TotalQuantity:     crop.TotalQuantity,
+			QuantityUnit:      crop.QuantityUnit,
+			TotalQuantityKg:   crop.TotalQuantityKg,
+			AverageQuantity:   crop.AverageQuantity,
+			AverageGrowthDays: int32(crop.AverageGrowthDays),
+		})
+	}
+	for quality, count := range summary.QualityDistribution {
+		msg.QualityDistribution[quality] = int32(count)
+	}
+	return msg
+}
+
+// timestamp は日時を Timestamp に変換します（ゼロ値は未設定）。
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// optionalID は任意のIDを optional のフィールドの値に変換します。
+func optionalID(id *uint) *uint32 {
+	if id == nil {
+		return nil
+	}
+	value := uint32(*id)
+	return &value
+}
@@ -0,0 +1,204 @@
+// Package grpcapi は社内のサービス・CLI 向けの gRPC API を提供します。
+//
+// proto/garden/v1/garden.proto で定義した作物・タスク・収穫記録・分析のサービスを、
+// REST API と同じサービス層（service.Service）で実装します。
+//   - 認証は REST と同じ JWT（メタデータ authorization: Bearer <JWT>、ログアウトで無効化したトークンは拒否）
+//   - エラーは apperrors の HTTP ステータスから gRPC のステータスコードに変換し、
+//     エラーコード（TASK_NOT_FOUND など）は google.rpc.ErrorInfo の reason に設定
+//     （バリデーションエラーの項目ごとの内容は google.rpc.BadRequest の field_violations）
+//   - メタデータ x-request-id のリクエストIDを引き継ぎ、ログに request_id・user_id を付ける（REST の X-Request-ID と同じ）
+//   - サーバーリフレクションを有効にするため、grpcurl などでサービスの一覧を取得できる
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/grpcapi/gardenv1"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// =============================================================================
+// gRPC Server - サーバーの作成と認証
+// =============================================================================
+
+// ErrorDomain はエラーの ErrorInfo の domain です。
+const ErrorDomain = "secure-scorecard"
+
+// reflectionServicePrefix はサーバーリフレクションのメソッドの接頭辞です（認証なし）。
+const reflectionServicePrefix = "/grpc.reflection."
+
+// metadataRequestID はリクエストIDのメタデータのキーです（REST の X-Request-ID）。
+const metadataRequestID = "x-request-id"
+
+// userIDKey はコンテキストに保存する認証ユーザーIDのキーです。
+type userIDKey struct{}
+
+// NewServer は作物・タスク・収穫記録・分析のサービスを登録した gRPC サーバーを作成します。
+//
+// 引数:
+//   - svc: ビジネスロジックサービス
+//   - jwtManager: JWT の検証（REST API と同じもの）
+//
+// 戻り値:
+//   - *grpc.Server: Serve で待ち受けを開始するサーバー
+func NewServer(svc *service.Service, jwtManager *auth.JWTManager) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestIDInterceptor,
+		errorInterceptor,
+		authInterceptor(svc, jwtManager),
+	))
+	gardenv1.RegisterCropServiceServer(server, &cropServer{service: svc})
+	gardenv1.RegisterTaskServiceServer(server, &taskServer{service: svc})
+	gardenv1.RegisterHarvestServiceServer(server, &harvestServer{service: svc})
+	gardenv1.RegisterAnalyticsServiceServer(server, &analyticsServer{service: svc})
+	reflection.Register(server)
+	return server
+}
+
+// authInterceptor はメタデータの JWT を検証し、認証ユーザーIDをコンテキストに設定します。
+func authInterceptor(svc *service.Service, jwtManager *auth.JWTManager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, reflectionServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		token := bearerToken(ctx)
+		if token == "" {
+			return nil, apperrors.NewAuthenticationError("Missing authentication token")
+		}
+		revoked, err := svc.IsTokenRevoked(ctx, auth.HashToken(token))
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to check token status")
+		}
+		if revoked {
+			return nil, apperrors.NewAuthenticationError("Token has been revoked")
+		}
+		claims, err := jwtManager.ValidateToken(token)
+		if err != nil {
+			if errors.Is(err, auth.ErrExpiredToken) {
+				return nil, apperrors.NewAuthenticationError("Token has expired")
+			}
+			return nil, apperrors.NewAuthenticationError("Invalid token")
+		}
+		ctx = repository.ContextWithActor(ctx, claims.UserID) // 変更履歴に記録する変更したユーザー
+		ctx = logging.WithUserID(ctx, claims.UserID)          // ログの user_id
+		return handler(context.WithValue(ctx, userIDKey{}, claims.UserID), req)
+	}
+}
+
+// requestIDInterceptor はメタデータ x-request-id のリクエストIDを引き継ぎ（ない場合は新しいID）、
+// リクエストIDを付けたロガーをコンテキストに設定します。IDはレスポンスのヘッダー x-request-id で返します。
+func requestIDInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(metadataRequestID); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if !logging.ValidRequestID(requestID) {
+		requestID = uuid.New().String()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+	ctx = logging.With(logging.WithRequestID(ctx, requestID), "grpc_method", info.FullMethod)
+	return handler(ctx, req)
+}
+
+// bearerToken はメタデータ authorization の Bearer トークンを返します。
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if token, found := strings.CutPrefix(value, "Bearer "); found {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// userIDFromContext は認証ユーザーIDを返します（authInterceptor で設定）。
+func userIDFromContext(ctx context.Context) uint {
+	userID, _ := ctx.Value(userIDKey{}).(uint)
+	return userID
+}
+
+// =============================================================================
+// Errors - エラーの変換
+// =============================================================================
+
+// errorInterceptor はサービスのエラーを gRPC のステータスに変換します。
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatusError(ctx, info.FullMethod, err)
+	}
+	return resp, nil
+}
+
+// toStatusError はエラーを gRPC のステータスのエラーに変換します。
+// apperrors 以外のエラーは内容を返さず、ログに記録して INTERNAL にします。
+func toStatusError(ctx context.Context, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		logging.FromContext(ctx).Error("gRPC request failed", "method", method, "error", err)
+		appErr = apperrors.NewInternalError("Internal server error")
+	}
+	st := status.New(grpcCode(appErr.StatusCode), appErr.Message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain}}
+	// バリデーションエラーは項目ごとの内容を BadRequest の field_violations に設定
+	if fieldErrors, ok := appErr.Details.(validator.FieldErrors); ok {
+		badRequest := &errdetails.BadRequest{}
+		for _, fe := range fieldErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fe.Field,
+				Description: fe.Message,
+				Reason:      strings.ToUpper(fe.Rule),
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if detailed, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// grpcCode は HTTP ステータスに対応する gRPC のステータスコードを返します。
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
@@ -0,0 +1,212 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/grpcapi/gardenv1"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// =============================================================================
+// gRPC API Tests - gRPC API のテスト
+// =============================================================================
+// テスト対象:
+//   - authInterceptor: JWT の検証、ログアウトで無効化したトークンの拒否
+//   - CropService / TaskService / HarvestService / AnalyticsService: サービス層の呼び出し
+//   - toStatusError: エラーの gRPC のステータスコードと ErrorInfo の reason
+
+// grpcTestEnv はテスト用の gRPC サーバーへの接続です。
+type grpcTestEnv struct {
+	conn       *grpc.ClientConn
+	mockRepos  *repository.MockRepositories
+	jwtManager *auth.JWTManager
+}
+
+// newGRPCTestEnv はメモリ内のリスナーで gRPC サーバーを起動し、接続を返します。
+func newGRPCTestEnv(t *testing.T) *grpcTestEnv {
+	t.Helper()
+	mockRepos := repository.NewMockRepositories()
+	jwtManager := auth.NewJWTManager("grpc-test-secret-key-32-characters", 24)
+	server := NewServer(service.NewService(mockRepos), jwtManager)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &grpcTestEnv{conn: conn, mockRepos: mockRepos, jwtManager: jwtManager}
+}
+
+// authContext はユーザーの JWT をメタデータに設定したコンテキストを返します。
+func (env *grpcTestEnv) authContext(t *testing.T, userID uint) context.Context {
+	t.Helper()
+	token, err := env.jwtManager.GenerateToken(userID, "", "grpc@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// assertStatus はエラーの gRPC のステータスコードと ErrorInfo の reason を確認します。
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != code {
+		t.Fatalf("Expected %s, got %v", code, err)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.Reason != reason || info.Domain != ErrorDomain {
+				t.Errorf("Expected reason %s, got %+v", reason, info)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected ErrorInfo with reason %s, got no details", reason)
+}
+
+// TestGRPC_Authentication は認証のテストです。
+// 期待動作:
+//   - トークンがない・無効なトークンは UNAUTHENTICATED（AUTHENTICATION_ERROR）
+//   - ログアウトで無効化したトークンは UNAUTHENTICATED
+func TestGRPC_Authentication(t *testing.T) {
+	// Arrange
+	env := newGRPCTestEnv(t)
+	client := gardenv1.NewCropServiceClient(env.conn)
+	token, _ := env.jwtManager.GenerateToken(1, "", "grpc@example.com")
+	_ = env.mockRepos.TokenBlacklist().Add(context.Background(), auth.HashToken(token), time.Now().Add(time.Hour))
+	revokedCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	invalidCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+
+	// Act
+	_, missingErr := client.ListCrops(context.Background(), &gardenv1.ListCropsRequest{})
+	_, invalidErr := client.ListCrops(invalidCtx, &gardenv1.ListCropsRequest{})
+	_, revokedErr := client.ListCrops(revokedCtx, &gardenv1.ListCropsRequest{})
+
+	// Assert
+	assertStatus(t, missingErr, codes.Unauthenticated, apperrors.ErrCodeAuthentication)
+	assertStatus(t, invalidErr, codes.Unauthenticated, apperrors.ErrCodeAuthentication)
+	assertStatus(t, revokedErr, codes.Unauthenticated, apperrors.ErrCodeAuthentication)
+}
+
+// TestGRPC_CropsAndHarvests は作物・収穫記録・分析のテストです。
+// 期待動作:
+//   - CreateCrop で登録した作物は ListCrops・GetCrop で取得できる
+//   - CreateHarvest で追加した収穫記録は ListHarvests で取得でき、GetHarvestSummary は収穫データを集計する
+//   - 他のユーザーの作物は NOT_FOUND（CROP_NOT_FOUND）、検証エラーは INVALID_ARGUMENT
+func TestGRPC_CropsAndHarvests(t *testing.T) {
+	// Arrange
+	env := newGRPCTestEnv(t)
+	ctx := env.authContext(t, 1)
+	crops := gardenv1.NewCropServiceClient(env.conn)
+	harvests := gardenv1.NewHarvestServiceClient(env.conn)
+	analytics := gardenv1.NewAnalyticsServiceClient(env.conn)
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	crop, err := crops.CreateCrop(ctx, &gardenv1.CreateCropRequest{
+		Name:                "トマト",
+		PlantedDate:         timestamppb.New(planted),
+		ExpectedHarvestDate: timestamppb.New(planted.AddDate(0, 3, 0)),
+	})
+	if err != nil {
+		t.Fatalf("CreateCrop failed: %v", err)
+	}
+	_, err = harvests.CreateHarvest(ctx, &gardenv1.CreateHarvestRequest{
+		CropId:       crop.GetId(),
+		HarvestDate:  timestamppb.New(planted.AddDate(0, 3, 0)),
+		Quantity:     1.5,
+		QuantityUnit: "kg",
+		Quality:      "good",
+	})
+	if err != nil {
+		t.Fatalf("CreateHarvest failed: %v", err)
+	}
+	list, listErr := crops.ListCrops(ctx, &gardenv1.ListCropsRequest{PageSize: 10})
+	harvestList, harvestErr := harvests.ListHarvests(ctx, &gardenv1.ListHarvestsRequest{CropId: crop.GetId()})
+	// モックの集計用の収穫データ（HarvestsByUserID）に直接追加（ListHarvests の後）
+	env.mockRepos.GetMockHarvestRepository().AddHarvestForUser(1, &model.Harvest{
+		CropID:       uint(crop.GetId()),
+		HarvestDate:  planted.AddDate(0, 3, 0),
+		Quantity:     1.5,
+		QuantityUnit: "kg",
+		Quality:      "good",
+	})
+	summary, summaryErr := analytics.GetHarvestSummary(ctx, &gardenv1.GetHarvestSummaryRequest{})
+	_, otherErr := crops.GetCrop(env.authContext(t, 2), &gardenv1.GetCropRequest{Id: crop.GetId()})
+	_, invalidErr := crops.CreateCrop(ctx, &gardenv1.CreateCropRequest{Name: "日付なし"})
+
+	// Assert
+	if crop.GetStatus() != "planted" || crop.GetUserId() != 1 || !crop.GetPlantedDate().AsTime().Equal(planted) {
+		t.Errorf("Unexpected created crop: %+v", crop)
+	}
+	if listErr != nil || len(list.GetCrops()) != 1 || list.GetCrops()[0].GetName() != "トマト" || list.GetNextPageToken() != "" {
+		t.Errorf("Expected one crop, got %v %v", list, listErr)
+	}
+	if harvestErr != nil || len(harvestList.GetHarvests()) != 1 || harvestList.GetHarvests()[0].GetQuantity() != 1.5 {
+		t.Errorf("Expected one harvest, got %v %v", harvestList, harvestErr)
+	}
+	if summaryErr != nil || summary.GetTotalHarvests() != 1 || summary.GetTotalQuantityKg() != 1.5 || summary.GetQualityDistribution()["good"] != 1 {
+		t.Errorf("Unexpected harvest summary: %v %v", summary, summaryErr)
+	}
+	assertStatus(t, otherErr, codes.NotFound, apperrors.ErrCodeCropNotFound)
+	assertStatus(t, invalidErr, codes.InvalidArgument, apperrors.ErrCodeValidation)
+}
+
+// TestGRPC_Tasks はタスクのテストです。
+// 期待動作:
+//   - CreateTask は優先度を省略すると medium、ステータスは pending
+//   - CompleteTask は完了したタスク（completed, completed_at）を返す
+//   - 他のユーザーのタスク・存在しないタスクは NOT_FOUND（TASK_NOT_FOUND）
+func TestGRPC_Tasks(t *testing.T) {
+	// Arrange
+	env := newGRPCTestEnv(t)
+	ctx := env.authContext(t, 1)
+	tasks := gardenv1.NewTaskServiceClient(env.conn)
+
+	// Act
+	task, err := tasks.CreateTask(ctx, &gardenv1.CreateTaskRequest{
+		Title:   "水やり",
+		DueDate: timestamppb.New(time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	_, otherErr := tasks.CompleteTask(env.authContext(t, 2), &gardenv1.CompleteTaskRequest{Id: task.GetId()})
+	completed, completeErr := tasks.CompleteTask(ctx, &gardenv1.CompleteTaskRequest{Id: task.GetId()})
+	_, missingErr := tasks.GetTask(ctx, &gardenv1.GetTaskRequest{Id: 999})
+
+	// Assert
+	if task.GetPriority() != "medium" || task.GetStatus() != "pending" {
+		t.Errorf("Unexpected created task: %+v", task)
+	}
+	assertStatus(t, otherErr, codes.NotFound, apperrors.ErrCodeTaskNotFound)
+	if completeErr != nil || completed.GetStatus() != "completed" || completed.GetCompletedAt() == nil {
+		t.Errorf("Expected completed task, got %v %v", completed, completeErr)
+	}
+	assertStatus(t, missingErr, codes.NotFound, apperrors.ErrCodeTaskNotFound)
+}
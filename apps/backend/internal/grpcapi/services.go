@@ -0,0 +1,360 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/filter"
+	"github.com/secure-scorecard/backend/internal/grpcapi/gardenv1"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Services - 作物・タスク・収穫記録・分析
+// =============================================================================
+// 入力の検証は REST API のリクエストと同じ規則（validate タグ）で行います。
+// 他のユーザーの記録は存在を明かさないよう NOT_FOUND を返します。
+
+// inputValidator はリクエストの検証に使用します（REST API と同じ検証のメッセージ）。
+var inputValidator = validator.NewValidator()
+
+// cropServer は CropService の実装です。
+type cropServer struct {
+	gardenv1.UnimplementedCropServiceServer
+	service *service.Service
+}
+
+// createCropInput は CreateCrop の検証の規則です（handler.CreateCropRequest と同じ）。
+type createCropInput struct {
+	Name                string                 `validate:"required,max=100"`
+	Variety             string                 `validate:"max=100"`
+	PlantedDate         *timestamppb.Timestamp `validate:"required"`
+	ExpectedHarvestDate *timestamppb.Timestamp `validate:"required"`
+	Notes               string                 `validate:"max=1000"`
+}
+
+// ListCrops は認証ユーザーの作物を新しい順に1ページ分返します。
+func (s *cropServer) ListCrops(ctx context.Context, req *gardenv1.ListCropsRequest) (*gardenv1.ListCropsResponse, error) {
+	params, err := pageParams(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.service.GetUserCropsPage(ctx, userIDFromContext(ctx), filter.Spec{}.EqualIfSet("status", req.GetStatus()), params)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gardenv1.ListCropsResponse{NextPageToken: page.NextCursor}
+	for i := range page.Items {
+		resp.Crops = append(resp.Crops, toProtoCrop(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+// GetCrop は認証ユーザーの作物を返します。
+func (s *cropServer) GetCrop(ctx context.Context, req *gardenv1.GetCropRequest) (*gardenv1.Crop, error) {
+	crop, err := userCrop(ctx, s.service, uint(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoCrop(crop), nil
+}
+
+// CreateCrop は作物を登録します（REST API の POST /api/v1/crops と同じ）。
+func (s *cropServer) CreateCrop(ctx context.Context, req *gardenv1.CreateCropRequest) (*gardenv1.Crop, error) {
+	userID := userIDFromContext(ctx)
+	input := createCropInput{
+		Name:                req.GetName(),
+		Variety:             req.GetVariety(),
+		Notes:               req.GetNotes(),
+		PlantedDate:         req.GetPlantedDate(),
+		ExpectedHarvestDate: req.GetExpectedHarvestDate(),
+	}
+	if err := inputValidator.Validate(input); err != nil {
+		return nil, err
+	}
+	plantedDate := req.GetPlantedDate().AsTime()
+	expectedHarvestDate := req.GetExpectedHarvestDate().AsTime()
+	if plantedDate.After(expectedHarvestDate) {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidDateRange, "planted_date must be before or equal to expected_harvest_date")
+	}
+
+	var plotID *uint
+	if req.PlotId != nil {
+		id := uint(req.GetPlotId())
+		if err := checkPlotAvailable(ctx, s.service, userID, id); err != nil {
+			return nil, err
+		}
+		plotID = &id
+	}
+
+	crop := &model.Crop{
+		UserID:              userID,
+		PlotID:              plotID,
+		Name:                req.GetName(),
+		Variety:             req.GetVariety(),
+		PlantedDate:         plantedDate,
+		ExpectedHarvestDate: expectedHarvestDate,
+		Status:              "planted", // 新規作物は常に planted
+		Notes:               req.GetNotes(),
+	}
+	if err := s.service.CreateCrop(ctx, crop); err != nil {
+		return nil, err
+	}
+	return toProtoCrop(crop), nil
+}
+
+// taskServer は TaskService の実装です。
+type taskServer struct {
+	gardenv1.UnimplementedTaskServiceServer
+	service *service.Service
+}
+
+// createTaskInput は CreateTask の検証の規則です（handler.CreateTaskRequest と同じ）。
+type createTaskInput struct {
+	Title       string                 `validate:"required,max=200"`
+	Description string                 `validate:"max=1000"`
+	DueDate     *timestamppb.Timestamp `validate:"required"`
+	Priority    string                 `validate:"omitempty,oneof=low medium high"`
+	Recurrence  string                 `validate:"omitempty,oneof=daily weekly monthly"`
+}
+
+// ListTasks は認証ユーザーのタスクを新しい順に1ページ分返します。
+func (s *taskServer) ListTasks(ctx context.Context, req *gardenv1.ListTasksRequest) (*gardenv1.ListTasksResponse, error) {
+	params, err := pageParams(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.service.GetUserTasksPage(ctx, userIDFromContext(ctx), filter.Spec{}.EqualIfSet("status", req.GetStatus()), params)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gardenv1.ListTasksResponse{NextPageToken: page.NextCursor}
+	for i := range page.Items {
+		resp.Tasks = append(resp.Tasks, toProtoTask(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+// GetTask は認証ユーザーのタスクを返します。
+func (s *taskServer) GetTask(ctx context.Context, req *gardenv1.GetTaskRequest) (*gardenv1.Task, error) {
+	task, err := userTask(ctx, s.service, uint(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoTask(task), nil
+}
+
+// CreateTask はタスクを登録します（REST API の POST /api/v1/tasks と同じ）。
+func (s *taskServer) CreateTask(ctx context.Context, req *gardenv1.CreateTaskRequest) (*gardenv1.Task, error) {
+	input := createTaskInput{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		DueDate:     req.GetDueDate(),
+		Priority:    req.GetPriority(),
+		Recurrence:  req.GetRecurrence(),
+	}
+	if err := inputValidator.Validate(input); err != nil {
+		return nil, err
+	}
+
+	priority := req.GetPriority()
+	if priority == "" {
+		priority = "medium"
+	}
+	recurrenceInterval := int(req.GetRecurrenceInterval())
+	if recurrenceInterval <= 0 {
+		recurrenceInterval = 1
+	}
+	var plantID *uint
+	if req.PlantId != nil {
+		id := uint(req.GetPlantId())
+		plantID = &id
+	}
+
+	task := &model.Task{
+		UserID:             userIDFromContext(ctx),
+		PlantID:            plantID,
+		Title:              req.GetTitle(),
+		Description:        req.GetDescription(),
+		DueDate:            req.GetDueDate().AsTime(),
+		Priority:           priority,
+		Status:             "pending", // 新規タスクは常に pending
+		Recurrence:         req.GetRecurrence(),
+		RecurrenceInterval: recurrenceInterval,
+	}
+	if err := s.service.CreateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return toProtoTask(task), nil
+}
+
+// CompleteTask は認証ユーザーのタスクを完了し、完了したタスクを返します。
+func (s *taskServer) CompleteTask(ctx context.Context, req *gardenv1.CompleteTaskRequest) (*gardenv1.Task, error) {
+	if _, err := userTask(ctx, s.service, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+	if err := s.service.CompleteTask(ctx, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+	task, err := s.service.GetTaskByID(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toProtoTask(task), nil
+}
+
+// harvestServer は HarvestService の実装です。
+type harvestServer struct {
+	gardenv1.UnimplementedHarvestServiceServer
+	service *service.Service
+}
+
+// createHarvestInput は CreateHarvest の検証の規則です（handler.CreateHarvestRequest と同じ）。
+type createHarvestInput struct {
+	HarvestDate  *timestamppb.Timestamp `validate:"required"`
+	Quantity     float64                `validate:"required,gt=0"`
+	QuantityUnit string                 `validate:"required,oneof=kg g pieces"`
+	Quality      string                 `validate:"omitempty,oneof=excellent good fair poor"`
+	Notes        string                 `validate:"max=1000"`
+}
+
+// ListHarvests は認証ユーザーの作物の収穫記録を新しい順に1ページ分返します。
+func (s *harvestServer) ListHarvests(ctx context.Context, req *gardenv1.ListHarvestsRequest) (*gardenv1.ListHarvestsResponse, error) {
+	if _, err := userCrop(ctx, s.service, uint(req.GetCropId())); err != nil {
+		return nil, err
+	}
+	params, err := pageParams(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.service.GetCropHarvestsPage(ctx, uint(req.GetCropId()), params)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gardenv1.ListHarvestsResponse{NextPageToken: page.NextCursor}
+	for i := range page.Items {
+		resp.Harvests = append(resp.Harvests, toProtoHarvest(&page.Items[i]))
+	}
+	return resp, nil
+}
+
+// CreateHarvest は認証ユーザーの作物に収穫記録を追加します。
+func (s *harvestServer) CreateHarvest(ctx context.Context, req *gardenv1.CreateHarvestRequest) (*gardenv1.Harvest, error) {
+	input := createHarvestInput{
+		HarvestDate:  req.GetHarvestDate(),
+		Quantity:     req.GetQuantity(),
+		QuantityUnit: req.GetQuantityUnit(),
+		Quality:      req.GetQuality(),
+		Notes:        req.GetNotes(),
+	}
+	if err := inputValidator.Validate(input); err != nil {
+		return nil, err
+	}
+	if _, err := userCrop(ctx, s.service, uint(req.GetCropId())); err != nil {
+		return nil, err
+	}
+
+	harvest := &model.Harvest{
+		CropID:       uint(req.GetCropId()),
+		HarvestDate:  req.GetHarvestDate().AsTime(),
+		Quantity:     req.GetQuantity(),
+		QuantityUnit: req.GetQuantityUnit(),
+		Quality:      req.GetQuality(),
+		Notes:        req.GetNotes(),
+	}
+	if err := s.service.CreateHarvest(ctx, harvest); err != nil {
+		return nil, err
+	}
+	return toProtoHarvest(harvest), nil
+}
+
+// analyticsServer は AnalyticsService の実装です。
+type analyticsServer struct {
+	gardenv1.UnimplementedAnalyticsServiceServer
+	service *service.Service
+}
+
+// GetHarvestSummary は認証ユーザーの収穫量を作物ごとに集計します。
+func (s *analyticsServer) GetHarvestSummary(ctx context.Context, req *gardenv1.GetHarvestSummaryRequest) (*gardenv1.HarvestSummary, error) {
+	var filter service.HarvestFilter
+	if req.GetStartDate() != nil {
+		startDate := req.GetStartDate().AsTime()
+		filter.StartDate = &startDate
+	}
+	if req.GetEndDate() != nil {
+		endDate := req.GetEndDate().AsTime()
+		filter.EndDate = &endDate
+	}
+	if req.CropId != nil {
+		cropID := uint(req.GetCropId())
+		filter.CropID = &cropID
+	}
+
+	summary, err := s.service.GetHarvestSummary(ctx, userIDFromContext(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoHarvestSummary(summary), nil
+}
+
+// =============================================================================
+// Helpers - 所有者の確認・ページング
+// =============================================================================
+
+// userCrop は認証ユーザーの作物を返します（他のユーザーの作物は CROP_NOT_FOUND）。
+func userCrop(ctx context.Context, svc *service.Service, cropID uint) (*model.Crop, error) {
+	crop, err := svc.GetCropByID(ctx, cropID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Crop")
+		}
+		return nil, err
+	}
+	if crop.UserID != userIDFromContext(ctx) {
+		return nil, apperrors.NewNotFoundError("Crop")
+	}
+	return crop, nil
+}
+
+// userTask は認証ユーザーのタスクを返します（他のユーザーのタスクは TASK_NOT_FOUND）。
+func userTask(ctx context.Context, svc *service.Service, taskID uint) (*model.Task, error) {
+	task, err := svc.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Task")
+		}
+		return nil, err
+	}
+	if task.UserID != userIDFromContext(ctx) {
+		return nil, apperrors.NewNotFoundError("Task")
+	}
+	return task, nil
+}
+
+// checkPlotAvailable は認証ユーザーの区画で、別の作物が配置されていないことを確認します。
+func checkPlotAvailable(ctx context.Context, svc *service.Service, userID, plotID uint) error {
+	plot, err := svc.GetPlotByID(ctx, plotID)
+	if err != nil || plot.UserID != userID {
+		return apperrors.NewNotFoundError("Plot")
+	}
+	assignment, err := svc.GetActivePlotAssignment(ctx, plotID)
+	if err == nil && assignment != nil {
+		return apperrors.New(apperrors.ErrCodePlotOccupied, "Plot is already occupied by another crop")
+	}
+	return nil
+}
+
+// pageParams は page_size と page_token からページングの指定を作成します。
+func pageParams(pageSize int32, pageToken string) (pagination.Params, error) {
+	params, err := pagination.NewParams(int(pageSize), pageToken)
+	if err != nil {
+		return pagination.Params{}, apperrors.NewBadRequestError("Invalid page_token")
+	}
+	return params, nil
+}
@@ -0,0 +1,220 @@
+// Package handler - Admin Handler
+//
+// 管理者向けの利用統計・通知の送信結果・メールテンプレートプレビューのHTTPハンドラと、統計を収集するミドルウェアを提供します。
+// エンドポイント:
+//   - GET /api/v1/admin/usage - 利用統計（アクティブユーザー、作成レコード数、通知数、エクスポート数）
+//   - GET /api/v1/admin/email-templates/:type/preview - 通知メールテンプレートのプレビュー
+//   - GET /api/v1/admin/notifications/stats - 通知のチャネルごとの送信結果
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// アクティブユーザー記録の間引き
+// =============================================================================
+
+// activeUserTracker はその日に記録済みのユーザーをプロセス内で保持します。
+// リクエストごとにDBへ書き込まないよう、1ユーザー1日1回に間引きます。
+type activeUserTracker struct {
+	mu   sync.Mutex
+	date string
+	seen map[uint]bool
+}
+
+// newActiveUserTracker は新しいactiveUserTrackerを作成します。
+func newActiveUserTracker() *activeUserTracker {
+	return &activeUserTracker{seen: make(map[uint]bool)}
+}
+
+// markSeen はユーザーを記録済みにし、当日初めての場合はtrueを返します。
+// 日付が変わった場合は記録をリセットします。
+func (t *activeUserTracker) markSeen(userID uint, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	date := now.UTC().Format("2006-01-02")
+	if t.date != date {
+		t.date = date
+		t.seen = make(map[uint]bool)
+	}
+	if t.seen[userID] {
+		return false
+	}
+	t.seen[userID] = true
+	return true
+}
+
+// =============================================================================
+// ミドルウェア
+// =============================================================================
+
+// usageTrackingMiddleware は認証済みリクエストから利用統計を記録します。
+//   - アクティブユーザー: 1ユーザー1日1回記録
+//   - 作成レコード数: POSTで201 Createdを返したリクエストを加算
+//
+// 統計の記録に失敗してもリクエストは失敗させません。
+func (h *Handler) usageTrackingMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			if userID := auth.GetUserIDFromContext(c); userID != 0 && h.activeUsers.markSeen(userID, time.Now()) {
+				_ = h.service.RecordUserActivity(ctx, userID)
+			}
+
+			err := next(c)
+
+			if err == nil && c.Request().Method == http.MethodPost && c.Response().Status == http.StatusCreated {
+				_ = h.service.IncrementUsage(ctx, service.UsageMetricRecordsCreated, 1)
+			}
+			return err
+		}
+	}
+}
+
+// adminOnlyMiddleware は管理者ユーザーのみアクセスを許可します。
+// AuthMiddleware の後に適用する必要があります。
+func (h *Handler) adminOnlyMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID := auth.GetUserIDFromContext(c)
+			if userID == 0 {
+				return apperrors.NewAuthenticationError("Not authenticated")
+			}
+
+			isAdmin, err := h.service.IsAdminUser(c.Request().Context(), userID)
+			if err != nil || !isAdmin {
+				return apperrors.NewAuthorizationError("Admin access required")
+			}
+			return next(c)
+		}
+	}
+}
+
+// =============================================================================
+// 利用統計
+// =============================================================================
+
+// GetUsageStats は管理者向けの利用統計を取得します。
+//
+// クエリパラメータ:
+//   - days: 集計日数（当日を含む、1〜366、デフォルト: 30）
+//
+// レスポンス:
+//   - 200: UsageStats オブジェクト
+//   - 400: 不正なdays
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetUsageStats(c echo.Context) error {
+	days := service.DefaultUsageStatsDays
+	if daysStr := c.QueryParam("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid days")
+		}
+		days = parsed
+	}
+
+	stats, err := h.service.GetUsageStats(c.Request().Context(), days)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidUsageStatsRange) {
+			return apperrors.NewBadRequestError("days must be between 1 and 366")
+		}
+		return apperrors.NewInternalError("Failed to get usage stats")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// =============================================================================
+// 通知の送信結果
+// =============================================================================
+
+// GetNotificationStats は通知のチャネルごとの送信結果（sent, failed, deferred, deduped）を取得します。
+// 通知ログの保持期間が24時間のため、集計できるのは直近24時間までです。
+//
+// クエリパラメータ:
+//   - hours: 集計時間（1〜24、デフォルト: 24）
+//
+// レスポンス:
+//   - 200: NotificationDeliveryStats オブジェクト
+//   - 400: 不正なhours
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetNotificationStats(c echo.Context) error {
+	hours := service.DefaultNotificationStatsHours
+	if hoursStr := c.QueryParam("hours"); hoursStr != "" {
+		parsed, err := strconv.Atoi(hoursStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid hours")
+		}
+		hours = parsed
+	}
+
+	stats, err := h.service.GetNotificationDeliveryStats(c.Request().Context(), hours)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidNotificationStatsRange) {
+			return apperrors.NewBadRequestError("hours must be between 1 and 24")
+		}
+		return apperrors.NewInternalError("Failed to get notification stats")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// =============================================================================
+// メールテンプレートプレビュー
+// =============================================================================
+
+// PreviewEmailTemplate はサンプルデータで通知メールテンプレートを生成します。
+//
+// パスパラメータ:
+//   - type: 通知イベントタイプ（task_due_reminder, task_overdue_alert, harvest_reminder, daily_digest）
+//
+// クエリパラメータ:
+//   - locale: 言語（ja, en、デフォルト: ja）
+//   - format: html の場合はHTML本文をそのまま返す（ブラウザで確認用）
+//
+// レスポンス:
+//   - 200: RenderedEmail オブジェクト（format=html の場合はHTML）
+//   - 400: 未対応の言語
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 404: 未知のイベントタイプ
+//   - 500: 内部エラー
+func (h *Handler) PreviewEmailTemplate(c echo.Context) error {
+	locale := c.QueryParam("locale")
+	if locale == "" {
+		locale = service.DefaultEmailLocale
+	}
+
+	email, err := h.service.PreviewNotificationEmail(c.Request().Context(), service.NotificationEventType(c.Param("type")), locale)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedLocale):
+			return apperrors.NewBadRequestError("Unsupported locale")
+		case errors.Is(err, service.ErrUnknownEmailTemplate):
+			return apperrors.NewNotFoundError("Email template")
+		default:
+			return apperrors.NewInternalError("Failed to render email template")
+		}
+	}
+
+	if c.QueryParam("format") == "html" {
+		return c.HTML(http.StatusOK, email.HTMLBody)
+	}
+	return c.JSON(http.StatusOK, email)
+}
@@ -6,9 +6,12 @@
 //   - GET /api/v1/analytics/harvest - 収穫量集計取得
 //   - GET /api/v1/analytics/charts/:type - グラフデータ取得
 //   - GET /api/v1/analytics/export/:dataType - CSVエクスポート
+//   - GET /api/v1/analytics/timeseries - 汎用時系列データ取得
+//   - GET/PUT /api/v1/users/settings/benchmark - ベンチマーク参加設定
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -17,6 +20,7 @@ import (
 	"github.com/secure-scorecard/backend/internal/auth"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
 )
 
 // =============================================================================
@@ -33,6 +37,7 @@ import (
 //
 // レスポンス:
 //   - 200: HarvestSummary オブジェクト
+//   - 304: 変更なし（If-None-Match が一致、ボディなし）
 //   - 400: パラメータ形式エラー
 //   - 401: 認証エラー
 //   - 500: 内部エラー
@@ -91,17 +96,20 @@ func (h *Handler) GetHarvestSummary(c echo.Context) error {
 // グラフの種類に応じたデータを生成して返します。
 //
 // パスパラメータ:
-//   - type: グラフの種類（monthly_harvest, crop_comparison, plot_productivity）
+//   - type: グラフの種類（monthly_harvest, crop_comparison, plot_productivity, crop_benchmark, harvest_heatmap, crop_seasons）
 //
 // クエリパラメータ:
 //   - start_date: 開始日（YYYY-MM-DD形式、省略可）
 //   - end_date: 終了日（YYYY-MM-DD形式、省略可）
-//   - year: 対象年（省略可）
+//   - year: 対象年（省略可、harvest_heatmapでは省略時に今年）
+//   - crop: 作物名（crop_benchmark, crop_seasonsで必須）
 //
 // レスポンス:
 //   - 200: ChartData オブジェクト
+//   - 304: 変更なし（If-None-Match が一致、ボディなし）
 //   - 400: パラメータ形式エラーまたは不正なグラフ種類
 //   - 401: 認証エラー
+//   - 403: ベンチマーク未参加（crop_benchmark）
 //   - 500: 内部エラー
 func (h *Handler) GetChartData(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -124,9 +132,12 @@ func (h *Handler) GetChartData(c echo.Context) error {
 		service.ChartTypeMonthlyHarvest:   true,
 		service.ChartTypeCropComparison:   true,
 		service.ChartTypePlotProductivity: true,
+		service.ChartTypeCropBenchmark:    true,
+		service.ChartTypeHarvestHeatmap:   true,
+		service.ChartTypeCropSeasons:      true,
 	}
 	if !validTypes[chartType] {
-		return apperrors.NewBadRequestError("Invalid chart type. Valid types: monthly_harvest, crop_comparison, plot_productivity")
+		return apperrors.NewBadRequestError("Invalid chart type. Valid types: monthly_harvest, crop_comparison, plot_productivity, crop_benchmark, harvest_heatmap, crop_seasons")
 	}
 
 	// フィルタ条件を解析
@@ -161,23 +172,108 @@ func (h *Handler) GetChartData(c echo.Context) error {
 		filter.Year = &year
 	}
 
+	// 作物名（ベンチマーク・シーズン比較用）
+	filter.CropName = c.QueryParam("crop")
+
 	// グラフデータを取得
 	chartData, err := h.service.GetChartData(ctx, userID, chartType, filter)
 	if err != nil {
+		if errors.Is(err, service.ErrBenchmarkCropRequired) {
+			return apperrors.NewBadRequestError("crop query parameter is required for crop_benchmark")
+		}
+		if errors.Is(err, service.ErrSeasonCropRequired) {
+			return apperrors.NewBadRequestError("crop query parameter is required for crop_seasons")
+		}
+		if errors.Is(err, service.ErrBenchmarkNotOptedIn) {
+			return apperrors.NewAuthorizationError("Benchmark requires opt-in. Enable it via PUT /api/v1/users/settings/benchmark")
+		}
 		return apperrors.NewInternalError("Failed to generate chart data")
 	}
 
 	return c.JSON(http.StatusOK, chartData)
 }
 
+// GetTimeSeries は汎用の時系列データを取得します。
+// 新しいグラフを追加する際に専用のChartTypeを追加しなくて済むよう、
+// 指標・粒度・分割軸をクエリパラメータで指定します。
+//
+// クエリパラメータ:
+//   - metric: 指標（harvest_kg, harvest_count、デフォルト: harvest_kg）
+//   - granularity: 粒度（day, week, month、デフォルト: month）
+//   - group_by: 分割軸（crop, plot、省略時は合計のみ）
+//   - start_date: 開始日（YYYY-MM-DD形式、省略可）
+//   - end_date: 終了日（YYYY-MM-DD形式、省略可）
+//
+// レスポンス:
+//   - 200: TimeSeriesResult オブジェクト
+//   - 304: 変更なし（If-None-Match が一致、ボディなし）
+//   - 400: パラメータ不正
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetTimeSeries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	query := service.TimeSeriesQuery{
+		Metric:      service.TimeSeriesMetric(c.QueryParam("metric")),
+		Granularity: service.TimeSeriesGranularity(c.QueryParam("granularity")),
+		GroupBy:     service.TimeSeriesGroupBy(c.QueryParam("group_by")),
+	}
+	if query.Metric == "" {
+		query.Metric = service.MetricHarvestKg
+	}
+	if query.Granularity == "" {
+		query.Granularity = service.GranularityMonth
+	}
+
+	// 開始日
+	if startDateStr := c.QueryParam("start_date"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid start_date format. Use YYYY-MM-DD")
+		}
+		query.StartDate = &startDate
+	}
+
+	// 終了日
+	if endDateStr := c.QueryParam("end_date"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid end_date format. Use YYYY-MM-DD")
+		}
+		// 終了日は当日の終わりまでを含む
+		endDate = endDate.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		query.EndDate = &endDate
+	}
+
+	result, err := h.service.GetTimeSeries(ctx, userID, query)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimeSeriesQuery) {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+		return apperrors.NewInternalError("Failed to generate time series")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 // ExportCSV はデータをCSV形式でエクスポートします。
 // データ種類に応じたCSVファイルまたはZIPファイルをダウンロードとして返します。
+// 生成したエクスポートは履歴に記録され、GET /exports から再ダウンロードできます。
 //
 // パスパラメータ:
-//   - dataType: エクスポートするデータ種類（crops, harvests, tasks, all）
+//   - dataType: エクスポートするデータ種類（crops, harvests, tasks, growth_records, plot_assignments, all）
+//
+// クエリパラメータ:
+//   - anonymize: trueの場合、メール・表示名・所在地・自由記述などの個人情報を除去（省略時: false）
 //
 // レスポンス:
-//   - 200: CSV/ZIPファイル（Content-Disposition: attachment）
+//   - 200: CSV/ZIPファイル（Content-Disposition: attachment、X-Export-ID: 履歴ID）
 //   - 400: 不正なデータ種類
 //   - 401: 認証エラー
 //   - 500: 内部エラー
@@ -198,25 +294,98 @@ func (h *Handler) ExportCSV(c echo.Context) error {
 
 	// データ種類をバリデーション
 	dataType := service.ExportDataType(dataTypeStr)
-	validTypes := map[service.ExportDataType]bool{
-		service.ExportDataTypeCrops:    true,
-		service.ExportDataTypeHarvests: true,
-		service.ExportDataTypeTasks:    true,
-		service.ExportDataTypeAll:      true,
+	if !validExportDataTypes[dataType] {
+		return apperrors.NewBadRequestError(invalidExportDataTypeMessage)
 	}
-	if !validTypes[dataType] {
-		return apperrors.NewBadRequestError("Invalid data type. Valid types: crops, harvests, tasks, all")
+
+	// 匿名化オプションを取得
+	var opts service.ExportOptions
+	if anonymizeStr := c.QueryParam("anonymize"); anonymizeStr != "" {
+		anonymize, err := strconv.ParseBool(anonymizeStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid anonymize parameter")
+		}
+		opts.Anonymize = anonymize
 	}
 
 	// CSVをエクスポート
-	result, err := h.service.ExportCSV(ctx, userID, dataType)
+	result, err := h.service.ExportCSVWithOptions(ctx, userID, dataType, opts)
 	if err != nil {
 		return apperrors.NewInternalError("Failed to export CSV")
 	}
 
+	// エクスポート履歴を記録（S3設定時はファイルも保存し再ダウンロード可能にする）
+	if record := h.storeExport(c, userID, result); record != nil {
+		c.Response().Header().Set("X-Export-ID", strconv.FormatUint(uint64(record.ID), 10))
+	}
+
 	// レスポンスヘッダーを設定
 	c.Response().Header().Set("Content-Disposition", "attachment; filename=\""+result.FileName+"\"")
 	c.Response().Header().Set("Content-Type", result.ContentType)
 
 	return c.Blob(http.StatusOK, result.ContentType, result.Data)
 }
+
+// =============================================================================
+// ベンチマーク設定
+// =============================================================================
+
+// BenchmarkSettingsRequest はベンチマーク参加設定の構造体です。
+type BenchmarkSettingsRequest struct {
+	OptIn *bool `json:"opt_in" validate:"required"`
+}
+
+// GetBenchmarkSettings はユーザーのベンチマーク参加設定を取得します。
+//
+// レスポンス:
+//   - 200: {"opt_in": bool}
+//   - 401: 認証エラー
+//   - 404: ユーザーが見つからない
+func (h *Handler) GetBenchmarkSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	user, err := h.service.GetUserByID(ctx, userID)
+	if err != nil {
+		return apperrors.NewNotFoundError("User")
+	}
+
+	return c.JSON(http.StatusOK, map[string]bool{"opt_in": user.BenchmarkOptIn})
+}
+
+// UpdateBenchmarkSettings はユーザーのベンチマーク参加設定を更新します。
+// 参加すると自分のデータが匿名集計に含まれ、他ユーザーとの比較を閲覧できます。
+//
+// リクエストボディ:
+//   - opt_in: 参加する場合はtrue（必須）
+//
+// レスポンス:
+//   - 200: {"opt_in": bool}
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateBenchmarkSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req BenchmarkSettingsRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	optIn, err := h.service.SetBenchmarkOptIn(ctx, userID, *req.OptIn)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to update benchmark settings")
+	}
+
+	return c.JSON(http.StatusOK, map[string]bool{"opt_in": optIn})
+}
@@ -0,0 +1,72 @@
+// Package handler - Analytics Cache
+//
+// グラフ・集計のエンドポイントの HTTP キャッシュ（Cache-Control / ETag）を提供します。
+// ETag はデータのバージョン（作物・収穫記録・区画の変更で増える）から作成するため、
+// If-None-Match が一致する場合は集計を実行せずに 304 Not Modified を返します。
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Analytics Cache - グラフ・集計の Cache-Control / ETag
+// =============================================================================
+// Cache-Control は public, no-cache です。ブラウザ・CDN はレスポンスを保存し、使用する前に毎回 ETag で再検証します。
+// ETag はユーザー・組織のスコープごとに異なるため、CDN が Authorization をキャッシュキーに含めない場合でも、
+// 他のユーザーの再検証は一致せずにそのユーザーのレスポンスが返ります（Vary でもキーを分けるよう指定）。
+// 他のユーザーのデータを含むグラフ（crop_benchmark）は対象外で、ボディから作成した ETag を使用します。
+
+// AnalyticsCacheControl はグラフ・集計のレスポンスの Cache-Control です。
+const AnalyticsCacheControl = "public, no-cache"
+
+// analyticsCacheVary はグラフ・集計のレスポンスの Vary です（ユーザー・組織のスコープでキャッシュを分ける）。
+var analyticsCacheVary = echo.HeaderAuthorization + ", X-Org-ID"
+
+// analyticsUncachedCharts はデータのバージョンの ETag を使用しないグラフの種類です。
+var analyticsUncachedCharts = map[service.ChartType]bool{
+	service.ChartTypeCropBenchmark: true, // 他のユーザーの収穫記録で内容が変わる
+}
+
+// analyticsCacheMiddleware はグラフ・集計のレスポンスにデータのバージョンの ETag と Cache-Control を設定し、
+// If-None-Match が一致する場合はハンドラを実行せずに 304 Not Modified を返します。
+// バージョンの取得に失敗した場合は、条件付きリクエストのミドルウェア（ボディの ETag）に任せます。
+func (h *Handler) analyticsCacheMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			userID := auth.GetUserIDFromContext(c)
+			if (req.Method != http.MethodGet && req.Method != http.MethodHead) || userID == 0 ||
+				analyticsUncachedCharts[service.ChartType(c.Param("type"))] {
+				return next(c)
+			}
+
+			resource := fmt.Sprintf("%s?%s", req.URL.Path, c.QueryParams().Encode())
+			etag, err := h.service.AnalyticsETag(req.Context(), userID, resource)
+			if err != nil {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(headerETag, etag)
+			header.Set(echo.HeaderCacheControl, AnalyticsCacheControl)
+			header.Add(echo.HeaderVary, analyticsCacheVary)
+			if ifNoneMatch := req.Header.Get(headerIfNoneMatch); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+				return c.NoContent(http.StatusNotModified)
+			}
+
+			if err := next(c); err != nil {
+				// エラーのレスポンスはキャッシュさせない
+				header.Del(headerETag)
+				header.Del(echo.HeaderCacheControl)
+				return err
+			}
+			return nil
+		}
+	}
+}
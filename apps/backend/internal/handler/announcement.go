@@ -0,0 +1,155 @@
+// Package handler - Announcement Handler
+//
+// 管理者からのお知らせ配信のHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST /api/v1/admin/announcements - お知らせの作成（配信予約）
+//   - GET /api/v1/admin/announcements - お知らせの一覧（配信結果を含む）
+//   - GET /api/v1/admin/announcements/:id - お知らせの配信結果
+//   - POST /api/v1/admin/announcements/:id/cancel - お知らせの配信取り消し
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// AnnouncementAudienceRequest はお知らせの配信対象の条件です（省略した条件は適用しません）。
+type AnnouncementAudienceRequest struct {
+	Locale     string `json:"locale" validate:"omitempty,oneof=ja en"`
+	Platform   string `json:"platform" validate:"omitempty,oneof=ios android web"`
+	ActiveDays int    `json:"active_days" validate:"omitempty,min=1,max=365"` // 直近N日以内にアクティブなユーザー
+}
+
+// CreateAnnouncementRequest はお知らせの作成リクエストです。
+type CreateAnnouncementRequest struct {
+	Title       string                      `json:"title" validate:"required,max=200"`
+	Body        string                      `json:"body" validate:"required,max=1000"`
+	Category    string                      `json:"category" validate:"omitempty,oneof=maintenance feature general"`
+	ScheduledAt *time.Time                  `json:"scheduled_at"` // 省略した場合は次回のスケジューラー実行時に配信
+	Audience    AnnouncementAudienceRequest `json:"audience"`
+}
+
+// CreateAnnouncement はお知らせを作成し、配信を予約します。
+//
+// リクエストボディ:
+//
+//	{
+//	  "title": "メンテナンスのお知らせ",
+//	  "body": "4月1日 2:00〜4:00 はご利用いただけません。",
+//	  "category": "maintenance",
+//	  "scheduled_at": "2026-03-31T09:00:00Z",
+//	  "audience": {"locale": "ja", "platform": "ios", "active_days": 30}
+//	}
+//
+// レスポンス:
+//   - 201: Announcement オブジェクト（target_count は現時点の対象ユーザー数）
+//   - 400: リクエストボディの形式が不正、過去の配信予定日時
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) CreateAnnouncement(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req CreateAnnouncementRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	announcement, err := h.service.CreateAnnouncement(ctx, auth.GetUserIDFromContext(c), service.CreateAnnouncementInput{
+		Title:       req.Title,
+		Body:        req.Body,
+		Category:    req.Category,
+		ScheduledAt: req.ScheduledAt,
+		Locale:      req.Audience.Locale,
+		Platform:    req.Audience.Platform,
+		ActiveDays:  req.Audience.ActiveDays,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAnnouncement) {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+		return apperrors.NewInternalError("Failed to create announcement")
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewAnnouncementResponse(announcement))
+}
+
+// GetAnnouncements はお知らせを新しい順に取得します（最大100件）。
+//
+// レスポンス:
+//   - 200: Announcement の配列
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetAnnouncements(c echo.Context) error {
+	announcements, err := h.service.ListAnnouncements(c.Request().Context())
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get announcements")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(announcements, dto.NewAnnouncementResponse))
+}
+
+// GetAnnouncement はお知らせと配信結果を取得します。
+//
+// パスパラメータ:
+//   - id: お知らせID
+//
+// レスポンス:
+//   - 200: Announcement オブジェクト（target_count, sent_count, failed_count, deferred_count, duplicate_count）
+//   - 400: 無効なID形式
+//   - 404: お知らせが見つからない
+func (h *Handler) GetAnnouncement(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid announcement ID")
+	}
+
+	announcement, err := h.service.GetAnnouncement(c.Request().Context(), uint(id))
+	if err != nil {
+		return apperrors.NewNotFoundError("Announcement")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewAnnouncementResponse(announcement))
+}
+
+// CancelAnnouncement はお知らせの配信を取り消します。
+// 配信中の場合は、配信済みのユーザーを除いて以降の配信を止めます。
+//
+// パスパラメータ:
+//   - id: お知らせID
+//
+// レスポンス:
+//   - 200: 取り消した Announcement オブジェクト
+//   - 400: 無効なID形式
+//   - 404: お知らせが見つからない
+//   - 409: 配信済み・取り消し済み
+func (h *Handler) CancelAnnouncement(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid announcement ID")
+	}
+
+	announcement, err := h.service.CancelAnnouncement(c.Request().Context(), uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAnnouncementNotFound):
+			return apperrors.NewNotFoundError("Announcement")
+		case errors.Is(err, service.ErrAnnouncementNotCancellable):
+			return apperrors.NewConflictError("Announcement has already been sent or cancelled")
+		}
+		return apperrors.NewInternalError("Failed to cancel announcement")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewAnnouncementResponse(announcement))
+}
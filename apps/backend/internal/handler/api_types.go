@@ -0,0 +1,176 @@
+// Package handler - API Types
+//
+// OpenAPI の仕様（cmd/openapi）に含めるハンドラのリクエストボディ・レスポンスの型です。
+// doc コメントからは型を読み取れないため、ここに登録したハンドラのみ components.schemas に定義を追加し、
+// cmd/genclient で生成するクライアント（Go・TypeScript）のメソッドが型付きになります。
+// 登録していないハンドラのクライアントのメソッドは、レスポンスの本文をそのまま返します。
+package handler
+
+import (
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/openapi"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// APITypes はハンドラ（HandlerKey: Handler.GetCrops など）ごとのリクエストボディ・レスポンスの型を返します。
+// 一覧のレスポンスは limit・cursor を指定しない場合の配列です（指定した場合は items・next_cursor のページ）。
+func APITypes() map[string]openapi.RouteTypes {
+	return map[string]openapi.RouteTypes{
+		// Health
+		"Handler.Health": {Response: HealthResponse{}},
+		"Handler.Hello":  {Response: HelloResponse{}},
+
+		// Auth
+		"AuthHandler.Register":      {Request: RegisterRequest{}, Response: AuthResponse{}},
+		"AuthHandler.Login":         {Request: LoginRequest{}, Response: AuthResponse{}},
+		"AuthHandler.FirebaseLogin": {Request: FirebaseLoginRequest{}, Response: AuthResponse{}},
+		"AuthHandler.Logout":        {Response: map[string]string{}},
+		"AuthHandler.RefreshToken":  {Response: map[string]string{}},
+		"AuthHandler.Me":            {Response: dto.UserResponse{}},
+
+		// Errors
+		"Handler.GetErrorCatalog":      {Response: ErrorCatalogResponse{}},
+		"Handler.GetErrorCatalogEntry": {Response: apperrors.CatalogEntry{}},
+
+		// Crops
+		"Handler.GetCrops":           {Response: []dto.CropResponse{}},
+		"Handler.CreateCrop":         {Request: CreateCropRequest{}, Response: dto.CropResponse{}},
+		"Handler.GetCrop":            {Response: dto.CropResponse{}},
+		"Handler.UpdateCrop":         {Request: UpdateCropRequest{}, Response: dto.CropResponse{}},
+		"Handler.GetGrowthRecords":   {Response: []dto.GrowthRecordResponse{}},
+		"Handler.CreateGrowthRecord": {Request: CreateGrowthRecordRequest{}, Response: dto.GrowthRecordResponse{}},
+		"Handler.GetHarvests":        {Response: []dto.HarvestResponse{}},
+		"Handler.CreateHarvest":      {Request: CreateHarvestRequest{}, Response: dto.HarvestResponse{}},
+		"Handler.GenerateImageUploadURL": {
+			Request:  GenerateImageUploadURLRequest{},
+			Response: GenerateImageUploadURLResponse{},
+		},
+		"Handler.UploadImage":     {Exclude: true}, // multipart/form-data
+		"Handler.PresignUpload":   {Request: PresignUploadRequest{}, Response: PresignUploadResponse{}},
+		"Handler.CompleteUpload":  {Request: CompleteUploadRequest{}, Response: PhotoResponse{}},
+		"Handler.GetPhoto":        {Response: PhotoResponse{}},
+		"Handler.ReplacePhoto":    {Request: ReplacePhotoRequest{}, Response: PhotoResponse{}},
+		"Handler.GetStorageUsage": {Response: service.StorageUsage{}},
+		"Handler.RestoreCrop":     {Response: dto.CropResponse{}},
+		"Handler.RestoreHarvest":  {Response: dto.HarvestResponse{}},
+
+		// Attachments
+		"Handler.PresignCropAttachment": {Request: PresignAttachmentRequest{}, Response: PresignUploadResponse{}},
+		"Handler.CreateCropAttachment":  {Request: CreateAttachmentRequest{}, Response: AttachmentResponse{}},
+		"Handler.GetCropAttachments":    {Response: []AttachmentResponse{}},
+		"Handler.PresignPlotAttachment": {Request: PresignAttachmentRequest{}, Response: PresignUploadResponse{}},
+		"Handler.CreatePlotAttachment":  {Request: CreateAttachmentRequest{}, Response: AttachmentResponse{}},
+		"Handler.GetPlotAttachments":    {Response: []AttachmentResponse{}},
+
+		// Plots
+		"Handler.GetPlots":                {Response: []dto.PlotResponse{}},
+		"Handler.CreatePlot":              {Request: CreatePlotRequest{}, Response: dto.PlotResponse{}},
+		"Handler.GetPlot":                 {Response: dto.PlotResponse{}},
+		"Handler.UpdatePlot":              {Request: UpdatePlotRequest{}, Response: dto.PlotResponse{}},
+		"Handler.AssignCrop":              {Request: AssignCropRequest{}, Response: dto.PlotAssignmentResponse{}},
+		"Handler.GetPlotAssignments":      {Response: []dto.PlotAssignmentResponse{}},
+		"Handler.GetActivePlotAssignment": {Response: dto.PlotAssignmentResponse{}},
+		"Handler.RestorePlot":             {Response: dto.PlotResponse{}},
+
+		// Tasks
+		"Handler.GetTasks":        {Response: []dto.TaskResponse{}},
+		"Handler.GetTodayTasks":   {Response: []dto.TaskResponse{}},
+		"Handler.GetOverdueTasks": {Response: []dto.TaskResponse{}},
+		"Handler.CreateTask":      {Request: CreateTaskRequest{}, Response: dto.TaskResponse{}},
+		"Handler.GetTask":         {Response: dto.TaskResponse{}},
+		"Handler.UpdateTask":      {Request: UpdateTaskRequest{}, Response: dto.TaskResponse{}},
+		"Handler.CompleteTask":    {Response: dto.TaskResponse{}},
+		"Handler.RestoreTask":     {Response: dto.TaskResponse{}},
+
+		// Gardens・Plants
+		"Handler.GetGardens":       {Response: []dto.GardenResponse{}},
+		"Handler.CreateGarden":     {Request: CreateGardenRequest{}, Response: dto.GardenResponse{}},
+		"Handler.GetSharedGardens": {Response: []dto.GardenResponse{}},
+		"Handler.GetGarden":        {Response: dto.GardenResponse{}},
+		"Handler.UpdateGarden":     {Request: UpdateGardenRequest{}, Response: dto.GardenResponse{}},
+		"Handler.GetGardenPlants":  {Response: []dto.PlantResponse{}},
+		"Handler.CreatePlant":      {Request: CreatePlantRequest{}, Response: dto.PlantResponse{}},
+		"Handler.GetGardenMembers": {Response: []dto.GardenMemberResponse{}},
+		"Handler.AddGardenMember":  {Request: AddGardenMemberRequest{}, Response: dto.GardenMemberResponse{}},
+		"Handler.GetPlant":         {Response: dto.PlantResponse{}},
+		"Handler.UpdatePlant":      {Request: UpdatePlantRequest{}, Response: dto.PlantResponse{}},
+		"Handler.GetPlantCareLogs": {Response: []dto.CareLogResponse{}},
+		"Handler.CreateCareLog":    {Request: CreateCareLogRequest{}, Response: dto.CareLogResponse{}},
+
+		// Organizations
+		"Handler.GetOrganizations":         {Response: []dto.OrganizationResponse{}},
+		"Handler.CreateOrganization":       {Request: CreateOrganizationRequest{}, Response: dto.OrganizationResponse{}},
+		"Handler.GetOrganization":          {Response: OrganizationDetailResponse{}},
+		"Handler.GetOrganizationMembers":   {Response: []dto.OrganizationMemberResponse{}},
+		"Handler.AddOrganizationMember":    {Request: AddOrganizationMemberRequest{}, Response: dto.OrganizationMemberResponse{}},
+		"Handler.UpdateOrganizationQuotas": {Request: UpdateOrganizationQuotasRequest{}, Response: dto.OrganizationResponse{}},
+
+		// Analytics・Exports
+		"Handler.GetSeasonSummaries":      {Response: []dto.SeasonSummaryResponse{}},
+		"Handler.GetBenchmarkSettings":    {Response: map[string]bool{}},
+		"Handler.UpdateBenchmarkSettings": {Request: BenchmarkSettingsRequest{}, Response: map[string]bool{}},
+		"Handler.RequestExport":           {Request: RequestExportRequest{}, Response: ExportRecordResponse{}},
+		"Handler.EstimateExport":          {Response: service.ExportEstimate{}},
+
+		// Users・Notifications
+		"Handler.GetNotificationSettings":       {Response: NotificationSettingsResponse{}},
+		"Handler.UpdateNotificationSettings":    {Request: UpdateNotificationSettingsRequest{}, Response: NotificationSettingsResponse{}},
+		"Handler.GetTimezoneSettings":           {Response: map[string]string{}},
+		"Handler.UpdateTimezoneSettings":        {Request: TimezoneSettingsRequest{}, Response: map[string]string{}},
+		"Handler.GetLocaleSettings":             {Response: map[string]string{}},
+		"Handler.UpdateLocaleSettings":          {Request: LocaleSettingsRequest{}, Response: map[string]string{}},
+		"Handler.CreateShareToken":              {Request: CreateShareTokenRequest{}, Response: dto.ShareTokenResponse{}},
+		"Handler.GetShareTokens":                {Response: []dto.ShareTokenResponse{}},
+		"Handler.GetUnreadNotificationCount":    {Response: UnreadNotificationCountResponse{}},
+		"Handler.UpdateNotificationPreferences": {Request: UpdateNotificationPreferencesRequest{}},
+		"Handler.StartPhoneVerification":        {Request: StartPhoneVerificationRequest{}},
+		"Handler.ConfirmPhoneVerification":      {Request: ConfirmPhoneVerificationRequest{}},
+		"Handler.GetCustomWebhookSecret":        {Response: CustomWebhookSecretResponse{}},
+		"Handler.RotateCustomWebhookSecret":     {Response: CustomWebhookSecretResponse{}},
+		"Handler.RegisterDeviceToken":           {Request: RegisterDeviceTokenRequest{}, Response: RegisterDeviceTokenResponse{}},
+		"Handler.HandlePushAction":              {Request: PushActionRequest{}},
+
+		// Admin
+		"Handler.CreateAnnouncement":            {Request: CreateAnnouncementRequest{}, Response: dto.AnnouncementResponse{}},
+		"Handler.GetAnnouncements":              {Response: []dto.AnnouncementResponse{}},
+		"Handler.GetAnnouncement":               {Response: dto.AnnouncementResponse{}},
+		"Handler.CancelAnnouncement":            {Response: dto.AnnouncementResponse{}},
+		"Handler.UpdateScheduleConfig":          {Request: UpdateScheduleConfigRequest{}},
+		"Handler.GetNotificationDeadLetter":     {Response: NotificationDeadLetterResponse{}},
+		"Handler.RedriveNotificationDeadLetter": {Response: NotificationDeadLetterResponse{}},
+		"Handler.GetDatabaseOps":                {Response: DatabaseOpsResponse{}},
+		"Handler.CreateDatabaseBackup":          {Response: model.DatabaseBackup{}},
+		"Handler.GetDatabaseBackups":            {Response: pagination.Page[model.DatabaseBackup]{}},
+
+		// Batch・Sync
+		"Handler.Batch":           {Request: BatchRequest{}, Response: BatchResponse{}},
+		"Handler.PushSyncChanges": {Request: SyncPushRequest{}},
+
+		// Trash
+		"Handler.GetTrash": {Response: []service.TrashItem{}},
+
+		// Audit
+		"Handler.GetAuditLogs": {Response: pagination.Page[model.AuditLog]{}},
+
+		// Search
+		"Handler.Search": {Response: service.SearchResults{}},
+
+		// Scheduler（X-Scheduler-Token）
+		"SchedulerHandler.ProcessScheduledNotifications": {Response: ProcessNotificationsResponse{}},
+		"SchedulerHandler.RetryNotifications":            {Response: RetryNotificationsResponse{}},
+		"SchedulerHandler.DispatchNotificationOutbox":    {Response: DispatchOutboxResponse{}},
+		"SchedulerHandler.GetSchedulerStatus":            {Response: map[string]string{}},
+		"SchedulerHandler.RefreshAnalyticsViews":         {Response: RefreshAnalyticsResponse{}},
+		"SchedulerHandler.DeliverAnnouncements":          {Response: DeliverAnnouncementsResponse{}},
+		"SchedulerHandler.PruneDeviceTokens":             {Response: PruneDeviceTokensResponse{}},
+		"SchedulerHandler.PurgeExpiredData":              {Response: PurgeExpiredDataResponse{}},
+		"SchedulerHandler.RunSeasonRollover":             {Response: SeasonRolloverResponse{}},
+
+		// WebSocket・Server-Sent Events（接続が続くため、クライアントは生成しない）
+		"Handler.ConnectRealtime": {Exclude: true},
+		"Handler.StreamEvents":    {Exclude: true},
+	}
+}
@@ -0,0 +1,472 @@
+// Package handler - Attachment Handler
+//
+// 作物・区画の添付ファイル（土壌検査の報告書・種苗の請求書の PDF、書類を撮影した画像）のHTTPハンドラを提供します。
+// ファイルは写真と同様に Presigned URL で保存先に直接 PUT し、完了後に登録のコールバックを呼び出します。
+// 登録の時点でファイルの内容から形式（PDF・JPEG・PNG・WEBP）を判定し、サイズ（10MB まで）とともに確認します。
+// エンドポイント:
+//   - POST   /api/v1/crops/:id/attachments/presign - 作物の添付ファイルの直接アップロード用のPresigned URLと登録のコールバックの生成
+//   - POST   /api/v1/crops/:id/attachments         - アップロードしたファイルの確認と作物の添付ファイルの登録
+//   - GET    /api/v1/crops/:id/attachments         - 作物の添付ファイル一覧
+//   - POST   /api/v1/plots/:id/attachments/presign - 区画の添付ファイルの直接アップロード用のPresigned URLと登録のコールバックの生成
+//   - POST   /api/v1/plots/:id/attachments         - アップロードしたファイルの確認と区画の添付ファイルの登録
+//   - GET    /api/v1/plots/:id/attachments         - 区画の添付ファイル一覧
+//   - GET    /api/v1/attachments/:id/download      - 添付ファイルのダウンロード用の署名付きURL（有効期限付き）
+//   - DELETE /api/v1/attachments/:id               - 添付ファイルの削除（保存先のファイルも削除）
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/storage"
+	"github.com/secure-scorecard/backend/internal/validator"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// リクエスト・レスポンス
+// =============================================================================
+
+// PresignAttachmentRequest は添付ファイルの直接アップロード用のPresigned URL生成リクエストの構造体です。
+type PresignAttachmentRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=application/pdf image/jpeg image/png image/webp"`
+	SizeBytes   int64  `json:"size_bytes" validate:"required,min=1"`  // アップロードするファイルのサイズ（最大 10MB、署名に含める）
+	FileName    string `json:"file_name" validate:"required,max=255"` // 元のファイル名（登録のコールバックの body に含める）
+}
+
+// CreateAttachmentRequest は添付ファイルの登録リクエストの構造体です。
+type CreateAttachmentRequest struct {
+	ObjectKey string `json:"object_key" validate:"required,max=500"` // POST /:id/attachments/presign の object_key
+	FileName  string `json:"file_name" validate:"required,max=255"`  // 表示・ダウンロードのファイル名
+}
+
+// AttachmentResponse は添付ファイルのレスポンスの構造体です。
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"user_id"`      // アップロードしたユーザー
+	ParentType  string    `json:"parent_type"`  // crop, plot
+	ParentID    uint      `json:"parent_id"`    // 添付先の作物・区画のID
+	FileName    string    `json:"file_name"`    // 表示・ダウンロードのファイル名
+	ContentType string    `json:"content_type"` // ファイルの内容から判定したMIMEタイプ
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// =============================================================================
+// ハンドラメソッド - 作物
+// =============================================================================
+
+// PresignCropAttachment は作物の添付ファイルの直接アップロード用のPresigned URLと登録のコールバックを生成します。
+// クライアントは upload_url に headers を付けてファイルを PUT し、完了後に callback を呼び出します。
+//
+// パスパラメータ:
+//   - id: 作物のID
+//
+// リクエストボディ:
+//   - content_type: ファイルのMIMEタイプ（application/pdf, image/jpeg, image/png, image/webp）
+//   - size_bytes: ファイルのサイズ（バイト）
+//   - file_name: 元のファイル名
+//
+// レスポンス:
+//   - 200: Presigned URLと登録のコールバック
+//   - 400: 不正なID、リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 404: 作物が存在しない、または閲覧できない作物（CROP_NOT_FOUND）
+//   - 413: サイズ超過（ATTACHMENT_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) PresignCropAttachment(c echo.Context) error {
+	return h.presignAttachment(c, service.AttachmentParentCrop)
+}
+
+// CreateCropAttachment はアップロードしたファイルを確認して作物の添付ファイルとして登録します（登録のコールバック）。
+// 保存先のオブジェクトのサイズとファイルの内容の形式を確認し、条件を満たさないオブジェクトは削除します。
+//
+// パスパラメータ:
+//   - id: 作物のID
+//
+// リクエストボディ:
+//   - object_key: POST /crops/:id/attachments/presign の object_key
+//   - file_name: 表示・ダウンロードのファイル名
+//
+// レスポンス:
+//   - 201: 登録した添付ファイル
+//   - 400: 不正なID、リクエストボディの形式が不正、ファイルの形式が不正（UNSUPPORTED_ATTACHMENT_TYPE）
+//   - 401: 認証エラー
+//   - 403: 他のユーザーのオブジェクトキー、保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED、アップロードしたファイルは削除）
+//   - 404: 作物が存在しない、または閲覧できない作物（CROP_NOT_FOUND）、ファイルがアップロードされていない
+//   - 409: ファイルが添付ファイルとして登録済み（ATTACHMENT_ALREADY_REGISTERED）
+//   - 413: サイズ超過（ATTACHMENT_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) CreateCropAttachment(c echo.Context) error {
+	return h.createAttachment(c, service.AttachmentParentCrop)
+}
+
+// GetCropAttachments は作物の添付ファイルの一覧を返します（古い順）。
+// 作物の所有者・組織のメンバーが取得できます。ダウンロードは GET /attachments/:id/download の署名付きURLです。
+//
+// パスパラメータ:
+//   - id: 作物のID
+//
+// レスポンス:
+//   - 200: 添付ファイルの一覧
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 作物が存在しない、または閲覧できない作物（CROP_NOT_FOUND）
+//   - 500: 内部エラー
+func (h *Handler) GetCropAttachments(c echo.Context) error {
+	return h.listAttachments(c, service.AttachmentParentCrop)
+}
+
+// =============================================================================
+// ハンドラメソッド - 区画
+// =============================================================================
+
+// PresignPlotAttachment は区画の添付ファイルの直接アップロード用のPresigned URLと登録のコールバックを生成します。
+// クライアントは upload_url に headers を付けてファイルを PUT し、完了後に callback を呼び出します。
+//
+// パスパラメータ:
+//   - id: 区画のID
+//
+// リクエストボディ:
+//   - content_type: ファイルのMIMEタイプ（application/pdf, image/jpeg, image/png, image/webp）
+//   - size_bytes: ファイルのサイズ（バイト）
+//   - file_name: 元のファイル名
+//
+// レスポンス:
+//   - 200: Presigned URLと登録のコールバック
+//   - 400: 不正なID、リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 404: 区画が存在しない、または閲覧できない区画（PLOT_NOT_FOUND）
+//   - 413: サイズ超過（ATTACHMENT_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) PresignPlotAttachment(c echo.Context) error {
+	return h.presignAttachment(c, service.AttachmentParentPlot)
+}
+
+// CreatePlotAttachment はアップロードしたファイルを確認して区画の添付ファイルとして登録します（登録のコールバック）。
+// 保存先のオブジェクトのサイズとファイルの内容の形式を確認し、条件を満たさないオブジェクトは削除します。
+//
+// パスパラメータ:
+//   - id: 区画のID
+//
+// リクエストボディ:
+//   - object_key: POST /plots/:id/attachments/presign の object_key
+//   - file_name: 表示・ダウンロードのファイル名
+//
+// レスポンス:
+//   - 201: 登録した添付ファイル
+//   - 400: 不正なID、リクエストボディの形式が不正、ファイルの形式が不正（UNSUPPORTED_ATTACHMENT_TYPE）
+//   - 401: 認証エラー
+//   - 403: 他のユーザーのオブジェクトキー、保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED、アップロードしたファイルは削除）
+//   - 404: 区画が存在しない、または閲覧できない区画（PLOT_NOT_FOUND）、ファイルがアップロードされていない
+//   - 409: ファイルが添付ファイルとして登録済み（ATTACHMENT_ALREADY_REGISTERED）
+//   - 413: サイズ超過（ATTACHMENT_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) CreatePlotAttachment(c echo.Context) error {
+	return h.createAttachment(c, service.AttachmentParentPlot)
+}
+
+// GetPlotAttachments は区画の添付ファイルの一覧を返します（古い順）。
+// 区画の所有者・組織のメンバーが取得できます。ダウンロードは GET /attachments/:id/download の署名付きURLです。
+//
+// パスパラメータ:
+//   - id: 区画のID
+//
+// レスポンス:
+//   - 200: 添付ファイルの一覧
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 区画が存在しない、または閲覧できない区画（PLOT_NOT_FOUND）
+//   - 500: 内部エラー
+func (h *Handler) GetPlotAttachments(c echo.Context) error {
+	return h.listAttachments(c, service.AttachmentParentPlot)
+}
+
+// =============================================================================
+// ハンドラメソッド - 添付ファイル
+// =============================================================================
+
+// DownloadAttachment は添付ファイルのダウンロード用の署名付きURLを返します。
+// 添付ファイルは公開URLで取得できないため、リクエストのたびに有効期限（15分）付きの署名付きURLを発行します。
+// アップロードしたユーザーのほか、添付先の作物・区画の所有者・組織のメンバーが取得できます。
+//
+// パスパラメータ:
+//   - id: 添付ファイルのID
+//
+// レスポンス:
+//   - 200: {"download_url": string, "expires_at": time}
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 添付ファイルが存在しない、または閲覧できない添付ファイル（ATTACHMENT_NOT_FOUND）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) DownloadAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid attachment ID")
+	}
+
+	attachment, err := h.service.GetAttachment(ctx, userID, uint(id))
+	if err != nil {
+		return apperrors.NewNotFoundError("Attachment")
+	}
+	if !h.fileStorage.IsConfigured() {
+		return apperrors.NewServiceUnavailableError("Attachment storage is not configured")
+	}
+
+	result, err := h.fileStorage.AttachmentDownloadURL(ctx, attachment.ObjectKey, attachment.FileName)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to generate download URL")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteAttachment は添付ファイルを削除します（保存先のファイルも削除）。
+// 削除できるのは添付ファイルをアップロードしたユーザーのみです。
+//
+// パスパラメータ:
+//   - id: 添付ファイルのID
+//
+// レスポンス:
+//   - 204: 削除成功
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 添付ファイルが存在しない、または他のユーザーの添付ファイル（ATTACHMENT_NOT_FOUND）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) DeleteAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid attachment ID")
+	}
+
+	if err := h.service.DeleteAttachment(ctx, userID, uint(id)); err != nil {
+		return attachmentError(err, "", "Failed to delete attachment")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// =============================================================================
+// ヘルパー関数
+// =============================================================================
+
+// presignAttachment は添付先の添付ファイルの直接アップロード用のPresigned URLと登録のコールバックを生成します。
+func (h *Handler) presignAttachment(c echo.Context, parentType string) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	parentID, err := attachmentParentID(c, parentType)
+	if err != nil {
+		return err
+	}
+
+	var req PresignAttachmentRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.SizeBytes > storage.MaxAttachmentSize {
+		return attachmentTooLargeError()
+	}
+
+	if err := h.service.CheckAttachmentParent(ctx, userID, parentType, parentID); err != nil {
+		return attachmentError(err, parentType, "Failed to check attachment parent")
+	}
+	if h.fileStorage == nil {
+		return apperrors.NewServiceUnavailableError("Attachment storage is not available")
+	}
+	if err := h.service.CheckStorageQuota(ctx, userID, req.SizeBytes); err != nil {
+		return attachmentError(err, parentType, "Failed to check storage quota")
+	}
+
+	result, err := h.fileStorage.PresignAttachmentUpload(ctx, userID, req.ContentType, req.SizeBytes)
+	if err != nil {
+		return attachmentError(err, parentType, "Failed to generate upload URL")
+	}
+
+	return c.JSON(http.StatusOK, PresignUploadResponse{
+		UploadURL: result.UploadURL,
+		Method:    http.MethodPut,
+		Headers:   result.Headers,
+		ObjectKey: result.ObjectKey,
+		ExpiresAt: result.ExpiresAt,
+		Callback: UploadCallback{
+			Method: http.MethodPost,
+			URL:    attachmentCallbackPath(parentType, parentID),
+			Body:   map[string]string{"object_key": result.ObjectKey, "file_name": req.FileName},
+		},
+	})
+}
+
+// createAttachment はアップロードしたファイルを確認して添付先の添付ファイルとして登録します。
+func (h *Handler) createAttachment(c echo.Context, parentType string) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	parentID, err := attachmentParentID(c, parentType)
+	if err != nil {
+		return err
+	}
+
+	var req CreateAttachmentRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if h.fileStorage == nil {
+		return apperrors.NewServiceUnavailableError("Attachment storage is not available")
+	}
+
+	result, err := h.fileStorage.RegisterAttachmentUpload(ctx, userID, req.ObjectKey)
+	if err != nil {
+		return attachmentError(err, parentType, "Failed to register upload")
+	}
+
+	attachment, err := h.service.CreateAttachment(ctx, userID, service.AttachmentUpload{
+		ParentType:  parentType,
+		ParentID:    parentID,
+		ObjectKey:   result.ObjectKey,
+		FileName:    req.FileName,
+		ContentType: result.ContentType,
+		SizeBytes:   result.Size,
+	})
+	if err != nil {
+		return attachmentError(err, parentType, "Failed to create attachment")
+	}
+
+	return c.JSON(http.StatusCreated, newAttachmentResponse(attachment))
+}
+
+// listAttachments は添付先の添付ファイルの一覧を返します。
+func (h *Handler) listAttachments(c echo.Context, parentType string) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	parentID, err := attachmentParentID(c, parentType)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := h.service.ListAttachments(ctx, userID, parentType, parentID)
+	if err != nil {
+		return attachmentError(err, parentType, "Failed to get attachments")
+	}
+
+	response := make([]AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		response = append(response, newAttachmentResponse(&attachments[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// attachmentParentID はパスパラメータの添付先（作物・区画）のIDを返します。
+func attachmentParentID(c echo.Context, parentType string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		if parentType == service.AttachmentParentPlot {
+			return 0, apperrors.NewBadRequestError("Invalid plot ID")
+		}
+		return 0, apperrors.NewBadRequestError("Invalid crop ID")
+	}
+	return uint(id), nil
+}
+
+// attachmentCallbackPath は添付先の添付ファイルの登録のコールバックのパスを返します。
+func attachmentCallbackPath(parentType string, parentID uint) string {
+	if parentType == service.AttachmentParentPlot {
+		return fmt.Sprintf("/api/v1/plots/%d/attachments", parentID)
+	}
+	return fmt.Sprintf("/api/v1/crops/%d/attachments", parentID)
+}
+
+// newAttachmentResponse は添付ファイルのレスポンスを作成します。
+func newAttachmentResponse(attachment *model.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          attachment.ID,
+		UserID:      attachment.UserID,
+		ParentType:  attachment.ParentType,
+		ParentID:    attachment.ParentID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// attachmentTooLargeError は添付ファイルが上限（10MB）を超えた場合のエラーです。
+func attachmentTooLargeError() error {
+	return apperrors.NewPayloadTooLargeError(apperrors.ErrCodeAttachmentTooLarge, "Attachment size exceeds maximum allowed size (10MB)", storage.MaxAttachmentSize)
+}
+
+// attachmentError は添付ファイルの直接アップロード・登録のエラーを API のエラーに変換します。
+func attachmentError(err error, parentType, message string) error {
+	switch {
+	case errors.Is(err, storage.ErrStorageNotConfigured), errors.Is(err, service.ErrAttachmentStorageNotConfigured):
+		return apperrors.NewServiceUnavailableError("Attachment storage is not configured")
+	case errors.Is(err, storage.ErrUploadKeyForbidden):
+		return apperrors.NewAuthorizationError("Object key does not belong to the user")
+	case errors.Is(err, storage.ErrUploadNotFound):
+		return apperrors.NewNotFoundError("Upload")
+	case errors.Is(err, service.ErrAttachmentParentNotFound):
+		if parentType == service.AttachmentParentPlot {
+			return apperrors.NewNotFoundError("Plot")
+		}
+		return apperrors.NewNotFoundError("Crop")
+	case errors.Is(err, service.ErrAttachmentNotOwned), errors.Is(err, gorm.ErrRecordNotFound):
+		return apperrors.NewNotFoundError("Attachment")
+	case errors.Is(err, service.ErrStorageQuotaExceeded):
+		return storageQuotaExceededError()
+	case errors.Is(err, service.ErrAttachmentAlreadyRegistered):
+		return apperrors.New(apperrors.ErrCodeAttachmentAlreadyRegistered, "Attachment is already registered")
+	case errors.Is(err, storage.ErrAttachmentTooLarge):
+		return attachmentTooLargeError()
+	case errors.Is(err, storage.ErrInvalidAttachmentType):
+		return apperrors.New(apperrors.ErrCodeUnsupportedAttachmentType, "Invalid attachment type: only PDF, JPEG, PNG, and WEBP are allowed")
+	default:
+		return apperrors.NewInternalError(message)
+	}
+}
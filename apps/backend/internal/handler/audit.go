@@ -0,0 +1,87 @@
+// Package handler - Audit Handler
+//
+// 作物・区画・タスク・収穫記録の変更履歴のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/audit?entity=&id= - 記録の変更履歴（記録の所有者と管理者）
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// ミドルウェア
+// =============================================================================
+
+// auditActorMiddleware は認証済みユーザーを変更履歴の変更したユーザーとしてリクエストの context に設定します。
+func auditActorMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if userID := auth.GetUserIDFromContext(c); userID != 0 {
+				req := c.Request()
+				c.SetRequest(req.WithContext(repository.ContextWithActor(req.Context(), userID)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// GetAuditLogs は記録の変更履歴を新しい順に1ページずつ返します。
+// 管理者以外は所有者が自分の記録（収穫記録は作物）の変更履歴のみ参照できます。
+//
+// クエリパラメータ:
+//   - entity: エンティティ（crops/plots/tasks/harvests）
+//   - id: 記録のID
+//   - limit: 1ページの件数（省略した場合は既定の件数）
+//   - cursor: 前のページの next_cursor
+//
+// レスポンス:
+//   - 200: 変更履歴の1ページ分（action, actor_id, route, changes の before/after）
+//   - 400: 不正な entity・id・cursor
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetAuditLogs(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	entityID, err := strconv.ParseUint(c.QueryParam("id"), 10, 32)
+	if err != nil || entityID == 0 {
+		return apperrors.NewBadRequestError("Invalid record ID")
+	}
+	// limit・cursorを省略した場合は最初のページを返す
+	params, paginated, err := paginationParams(c)
+	if err != nil {
+		return err
+	}
+	if !paginated {
+		params = pagination.Params{}.Normalize()
+	}
+
+	page, err := h.service.GetAuditLogsPage(ctx, userID, c.QueryParam("entity"), uint(entityID), params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditEntity) {
+			return apperrors.NewBadRequestError("Invalid audit entity")
+		}
+		return apperrors.NewInternalError("Failed to fetch audit logs")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Audit Tests - 変更履歴のテスト
+// =============================================================================
+// テスト対象:
+//   - GET /audit: 記録の所有者・管理者の変更履歴の参照と不正な entity・id
+//   - auditActorMiddleware: 認証済みユーザーの変更したユーザーの設定
+
+// TestGetAuditLogs は変更履歴の参照のテストです。
+// 期待動作:
+//   - 所有者は自分の記録の変更履歴を新しい順に1ページ分（items）で返す
+//   - 他のユーザーの記録の変更履歴は空のページ、管理者は全ての所有者の変更履歴を返す
+//   - 不正な entity・id は 400
+func TestGetAuditLogs(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+	jwtManager := auth.NewJWTManager("audit-log-test-secret-key-32-chars!!", 24)
+	repos := repository.NewMockRepositories()
+	NewHandler(service.NewService(repos), jwtManager, nil).RegisterRoutes(e)
+	ctx := context.Background()
+	owner := &model.User{Email: "owner@example.com"}
+	other := &model.User{Email: "other@example.com"}
+	admin := &model.User{Email: "admin@example.com", IsAdmin: true}
+	for _, user := range []*model.User{owner, other, admin} {
+		if err := repos.User().Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+	repos.GetMockAuditLogRepository().Logs = []model.AuditLog{
+		{ID: 1, Entity: "tasks", EntityID: 7, Action: model.AuditActionCreate, ActorID: &owner.ID, OwnerID: owner.ID},
+		{ID: 2, Entity: "tasks", EntityID: 7, Action: model.AuditActionUpdate, ActorID: &owner.ID, OwnerID: owner.ID,
+			Changes: map[string]model.AuditChange{"title": {Before: "水やり", After: "水やり（朝）"}}},
+		{ID: 3, Entity: "crops", EntityID: 7, Action: model.AuditActionCreate, OwnerID: owner.ID},
+	}
+	request := func(user *model.User, path string) *httptest.ResponseRecorder {
+		token, err := jwtManager.GenerateToken(user.ID, "", user.Email)
+		if err != nil {
+			t.Fatalf("GenerateToken failed: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	actions := func(rec *httptest.ResponseRecorder) []string {
+		var page struct {
+			Items []model.AuditLog `json:"items"`
+		}
+		_ = json.Unmarshal(rec.Body.Bytes(), &page)
+		got := []string{}
+		for _, entry := range page.Items {
+			got = append(got, entry.Action)
+		}
+		return got
+	}
+
+	// Act
+	ownRec := request(owner, "/api/v1/audit?entity=tasks&id=7")
+	otherRec := request(other, "/api/v1/audit?entity=tasks&id=7")
+	adminRec := request(admin, "/api/v1/audit?entity=tasks&id=7&limit=1")
+	invalidEntity := request(owner, "/api/v1/audit?entity=users&id=7")
+	invalidID := request(owner, "/api/v1/audit?entity=tasks&id=abc")
+
+	// Assert
+	if got := actions(ownRec); ownRec.Code != http.StatusOK || len(got) != 2 || got[0] != model.AuditActionUpdate {
+		t.Errorf("Expected the owner's update and create logs, got %d %v", ownRec.Code, got)
+	}
+	if got := actions(otherRec); otherRec.Code != http.StatusOK || len(got) != 0 {
+		t.Errorf("Expected no logs for another user, got %d %v", otherRec.Code, got)
+	}
+	var adminPage struct {
+		NextCursor string `json:"next_cursor"`
+	}
+	_ = json.Unmarshal(adminRec.Body.Bytes(), &adminPage)
+	if got := actions(adminRec); adminRec.Code != http.StatusOK || len(got) != 1 || adminPage.NextCursor == "" {
+		t.Errorf("Expected one page of logs with a next cursor for the admin, got %d %v", adminRec.Code, got)
+	}
+	for _, rec := range []*httptest.ResponseRecorder{invalidEntity, invalidID} {
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d %s", rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// TestAuditActorMiddleware は変更したユーザーのミドルウェアのテストです。
+// 期待動作:
+//   - 認証済みユーザーを repository.ActorFromContext で取得できる
+//   - 認証していないリクエストには設定しない
+func TestAuditActorMiddleware(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	var actors []uint
+	handle := auditActorMiddleware()(func(c echo.Context) error {
+		actor, _ := repository.ActorFromContext(c.Request().Context())
+		actors = append(actors, actor)
+		return nil
+	})
+	authenticated := e.NewContext(httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", nil), httptest.NewRecorder())
+	authenticated.Set(auth.UserContextKey, &auth.Claims{UserID: 5})
+	anonymous := e.NewContext(httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", nil), httptest.NewRecorder())
+
+	// Act
+	_ = handle(authenticated)
+	_ = handle(anonymous)
+
+	// Assert
+	if len(actors) != 2 || actors[0] != 5 || actors[1] != 0 {
+		t.Errorf("Expected actors [5 0], got %v", actors)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/service"
 	"github.com/secure-scorecard/backend/internal/validator"
@@ -48,8 +49,8 @@ type FirebaseLoginRequest struct {
 
 // AuthResponse represents the authentication response
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  interface{} `json:"user"`
+	Token string           `json:"token"`
+	User  dto.UserResponse `json:"user"`
 }
 
 // Register handles user registration with email and password
@@ -71,7 +72,7 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	user, err := h.service.RegisterUser(ctx, req.Email, hashedPassword, req.DisplayName)
 	if err != nil {
 		if errors.Is(err, service.ErrEmailAlreadyExists) {
-			return apperrors.NewConflictError("Email already registered")
+			return apperrors.New(apperrors.ErrCodeEmailAlreadyRegistered, "Email already registered")
 		}
 		return apperrors.NewInternalError("Failed to register user")
 	}
@@ -88,7 +89,7 @@ func (h *AuthHandler) Register(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, AuthResponse{
 		Token: token,
-		User:  user,
+		User:  dto.NewUserResponse(user),
 	})
 }
 
@@ -136,7 +137,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, AuthResponse{
 		Token: token,
-		User:  user,
+		User:  dto.NewUserResponse(user),
 	})
 }
 
@@ -167,7 +168,7 @@ func (h *AuthHandler) FirebaseLogin(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, AuthResponse{
 		Token: token,
-		User:  user,
+		User:  dto.NewUserResponse(user),
 	})
 }
 
@@ -237,5 +238,5 @@ func (h *AuthHandler) Me(c echo.Context) error {
 		return apperrors.NewNotFoundError("User")
 	}
 
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
 }
@@ -62,7 +62,7 @@ func TestRegister_Success(t *testing.T) {
 		t.Error("Expected token in response")
 	}
 
-	if response.User == nil {
+	if response.User.ID == 0 || response.User.Email != "test@example.com" {
 		t.Error("Expected user in response")
 	}
 }
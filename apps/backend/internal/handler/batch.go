@@ -0,0 +1,290 @@
+// Package handler - Batch Handler
+//
+// 複数のAPIリクエストを1回のリクエストでまとめて実行するHTTPハンドラを提供します。
+// オフラインで記録した操作（タスクの完了、作物・収穫記録の登録など）を、接続が戻った時に1回で同期するために使用します。
+// エンドポイント:
+//   - POST /api/v1/batch - サブリクエストを順番に実行し、サブリクエストごとのレスポンスを返す
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Batch Requests - バッチリクエスト
+// =============================================================================
+// サブリクエストは通常のリクエストと同じルート・ミドルウェア（認証を含む）で1件ずつ順番に実行します。
+// 同じ group のサブリクエストは1つのトランザクションで実行し、いずれかが失敗（4xx・5xx）した場合は
+// グループ全体をロールバックして、グループの残りのサブリクエストは実行しません。
+// group のないサブリクエストは独立しており、失敗しても次のサブリクエストを実行します。
+
+// MaxBatchRequests は1回のバッチリクエストのサブリクエストの上限です。
+const MaxBatchRequests = 50
+
+// batchPathPrefix はサブリクエストのパスの接頭辞です（パスは /api/v1 からの相対パスでも指定できる）。
+const batchPathPrefix = "/api/v1"
+
+// errBatchGroupFailed はトランザクションのグループのサブリクエストが失敗した場合のエラーです（ロールバック用）。
+var errBatchGroupFailed = errors.New("batch group sub-request failed")
+
+// BatchRequest はバッチリクエストのリクエストボディです。
+type BatchRequest struct {
+	Requests []BatchSubRequest `json:"requests" validate:"required,min=1,dive"`
+}
+
+// BatchSubRequest はバッチリクエストのサブリクエストです。
+type BatchSubRequest struct {
+	ID     string          `json:"id,omitempty"`                                               // クライアントが指定する識別子（レスポンスにそのまま返す）
+	Method string          `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"` // HTTP メソッド
+	Path   string          `json:"path" validate:"required"`                                   // パス（/tasks または /api/v1/tasks、クエリ文字列を含めてよい）
+	Body   json.RawMessage `json:"body,omitempty"`                                             // リクエストボディ（JSON）
+	Group  string          `json:"group,omitempty"`                                            // トランザクションのグループ（連続したサブリクエストに同じ値を指定）
+}
+
+// BatchSubResponse はサブリクエストごとのレスポンスです。
+type BatchSubResponse struct {
+	ID         string          `json:"id,omitempty"`
+	Status     int             `json:"status"`
+	Body       json.RawMessage `json:"body,omitempty"` // レスポンスボディ（JSON 以外は JSON の文字列）
+	Group      string          `json:"group,omitempty"`
+	RolledBack bool            `json:"rolled_back,omitempty"` // 成功したがグループの失敗によりロールバックされた
+}
+
+// BatchResponse はバッチリクエストのレスポンスです。
+type BatchResponse struct {
+	Responses []BatchSubResponse `json:"responses"` // サブリクエストと同じ順序
+	Succeeded int                `json:"succeeded"` // 成功し、ロールバックされなかったサブリクエスト数
+	Failed    int                `json:"failed"`    // 失敗・未実行・ロールバックされたサブリクエスト数
+}
+
+// batchGroup は連続して実行するサブリクエストのまとまり（トランザクションのグループ、または group のない1件）です。
+type batchGroup struct {
+	name  string // トランザクションのグループ名（空の場合は group のない1件）
+	start int    // 最初のサブリクエストの位置
+	end   int    // 最後のサブリクエストの次の位置
+}
+
+// Batch は複数のサブリクエストを順番に実行し、サブリクエストごとのレスポンスを返します。
+// サブリクエストはバッチリクエストの Authorization ヘッダーで認証します。
+// 同じ group のサブリクエストは1つのトランザクションで実行し、1件でも失敗した場合はグループ全体をロールバックします
+// （成功していたサブリクエストは rolled_back、実行しなかったサブリクエストは 424 BATCH_GROUP_ABORTED）。
+//
+// リクエストボディ:
+//   - requests: サブリクエストの配列（1〜50件、順番に実行）
+//   - requests[].id: クライアントが指定する識別子（任意、レスポンスにそのまま返す）
+//   - requests[].method: GET / POST / PUT / PATCH / DELETE
+//   - requests[].path: /api/v1 からの相対パス（/tasks/1/complete など）
+//   - requests[].body: リクエストボディ（任意）
+//   - requests[].group: トランザクションのグループ（任意、同じグループのサブリクエストは連続させる）
+//
+// レスポンス:
+//   - 200: サブリクエストごとのレスポンス（responses, succeeded, failed）
+//   - 400: サブリクエストの上限超過、バッチの入れ子、連続していないグループ
+//   - 401: 認証エラー
+//   - 413: リクエストボディが上限（BODY_LIMIT_BATCH_BYTES）を超える（PAYLOAD_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//
+// 例:
+//
+//	{
+//	  "responses": [
+//	    {"id": "op-1", "status": 201, "body": {"id": 12, "title": "水やり"}, "group": "sync"},
+//	    {"id": "op-2", "status": 200, "body": {"id": 3, "status": "completed"}, "group": "sync"}
+//	  ],
+//	  "succeeded": 2,
+//	  "failed": 0
+//	}
+func (h *Handler) Batch(c echo.Context) error {
+	var req BatchRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if len(req.Requests) > MaxBatchRequests {
+		return apperrors.NewBadRequestError(fmt.Sprintf("A batch can contain at most %d sub-requests", MaxBatchRequests))
+	}
+	groups, err := batchGroups(req.Requests)
+	if err != nil {
+		return err
+	}
+	if h.router == nil {
+		return apperrors.NewServiceUnavailableError("Batch requests are not available")
+	}
+
+	responses := make([]BatchSubResponse, len(req.Requests))
+	for _, group := range groups {
+		if group.name == "" {
+			responses[group.start] = h.serveBatchSubRequest(c.Request().Context(), c, req.Requests[group.start])
+			continue
+		}
+		h.serveBatchGroup(c, req.Requests, responses, group)
+	}
+
+	result := BatchResponse{Responses: responses}
+	for _, resp := range responses {
+		if resp.Status < http.StatusBadRequest && !resp.RolledBack {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// serveBatchGroup はトランザクションのグループのサブリクエストを1つのトランザクションで実行します。
+// 失敗したサブリクエストがある場合はロールバックし、それまでのレスポンスを rolled_back、残りを未実行（424）にします。
+func (h *Handler) serveBatchGroup(c echo.Context, requests []BatchSubRequest, responses []BatchSubResponse, group batchGroup) {
+	failedAt := -1
+	err := h.service.RunInTransaction(c.Request().Context(), func(txCtx context.Context) error {
+		for i := group.start; i < group.end; i++ {
+			responses[i] = h.serveBatchSubRequest(txCtx, c, requests[i])
+			if responses[i].Status >= http.StatusBadRequest {
+				failedAt = i
+				return errBatchGroupFailed
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	// 全て成功したがコミットに失敗した場合は全てロールバックされている
+	for i := group.start; i < group.end; i++ {
+		switch {
+		case failedAt < 0 || i < failedAt:
+			responses[i].RolledBack = true
+		case i > failedAt:
+			responses[i] = abortedBatchSubResponse(requests[i], c.Request().URL.Path)
+		}
+	}
+}
+
+// serveBatchSubRequest はサブリクエストを通常のリクエストと同じルート・ミドルウェアで実行します。
+// ctx はトランザクションのグループの場合はトランザクションのコンテキストです。
+func (h *Handler) serveBatchSubRequest(ctx context.Context, c echo.Context, sub BatchSubRequest) BatchSubResponse {
+	parent := c.Request()
+	subReq, err := http.NewRequestWithContext(ctx, sub.Method, batchSubRequestPath(sub.Path), bytes.NewReader(sub.Body))
+	if err != nil {
+		return batchErrorResponse(sub, apperrors.NewBadRequestError("Invalid sub-request path"), parent.URL.Path)
+	}
+	// 認証・組織（X-Org-ID）などはバッチリクエストのヘッダーを引き継ぐ
+	for _, header := range []string{echo.HeaderAuthorization, HeaderOrganizationID, "Accept-Language", "User-Agent"} {
+		if value := parent.Header.Get(header); value != "" {
+			subReq.Header.Set(header, value)
+		}
+	}
+	// リクエストIDはバッチリクエストのID（付与したIDを含む）で、サブリクエストのログも同じ request_id になる
+	if requestID := c.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+		subReq.Header.Set(echo.HeaderXRequestID, requestID)
+	}
+	if len(sub.Body) > 0 {
+		subReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	subReq.RemoteAddr = parent.RemoteAddr
+
+	rec := newBatchResponseRecorder()
+	h.router.ServeHTTP(rec, subReq)
+
+	resp := BatchSubResponse{ID: sub.ID, Status: rec.status, Group: sub.Group}
+	if body := bytes.TrimSpace(rec.body.Bytes()); len(body) > 0 {
+		if json.Valid(body) {
+			resp.Body = json.RawMessage(body)
+		} else {
+			resp.Body, _ = json.Marshal(string(body))
+		}
+	}
+	return resp
+}
+
+// batchGroups はサブリクエストを実行の単位（トランザクションのグループ、または group のない1件）に分けます。
+//
+// 戻り値:
+//   - []batchGroup: 実行の単位（サブリクエストの順序）
+//   - error: バッチの入れ子・ストリーミングのエンドポイント・連続していないグループの場合は BadRequest
+func batchGroups(requests []BatchSubRequest) ([]batchGroup, error) {
+	var groups []batchGroup
+	seen := make(map[string]bool)
+	for i, sub := range requests {
+		path, _, _ := strings.Cut(batchSubRequestPath(sub.Path), "?")
+		switch path {
+		case batchPathPrefix + "/batch":
+			return nil, apperrors.NewBadRequestError("Batch requests cannot be nested")
+		case batchPathPrefix + "/events", batchPathPrefix + "/ws":
+			// イベントのストリーム・WebSocket は接続を閉じるまでレスポンスが終わらない
+			return nil, apperrors.NewBadRequestError("Streaming endpoints cannot be used in a batch")
+		}
+
+		if sub.Group != "" && len(groups) > 0 && groups[len(groups)-1].name == sub.Group {
+			groups[len(groups)-1].end = i + 1
+			continue
+		}
+		if sub.Group != "" {
+			if seen[sub.Group] {
+				return nil, apperrors.NewBadRequestError("Sub-requests of group " + sub.Group + " must be contiguous")
+			}
+			seen[sub.Group] = true
+		}
+		groups = append(groups, batchGroup{name: sub.Group, start: i, end: i + 1})
+	}
+	return groups, nil
+}
+
+// batchSubRequestPath はサブリクエストのパスを /api/v1 からのパスにします。
+func batchSubRequestPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if path == batchPathPrefix || strings.HasPrefix(path, batchPathPrefix+"/") {
+		return path
+	}
+	return batchPathPrefix + path
+}
+
+// abortedBatchSubResponse はグループのサブリクエストが失敗したため実行しなかったサブリクエストのレスポンスです。
+func abortedBatchSubResponse(sub BatchSubRequest, instance string) BatchSubResponse {
+	err := apperrors.New(apperrors.ErrCodeBatchGroupAborted, "Not executed because another sub-request in group "+sub.Group+" failed")
+	return batchErrorResponse(sub, err, instance)
+}
+
+// batchErrorResponse はサブリクエストを実行できなかった場合のレスポンス（problem+json の本文）です。
+func batchErrorResponse(sub BatchSubRequest, err *apperrors.AppError, instance string) BatchSubResponse {
+	body, _ := json.Marshal(apperrors.NewProblem(err.StatusCode, err.Code, err.Message, instance))
+	return BatchSubResponse{ID: sub.ID, Status: err.StatusCode, Body: body, Group: sub.Group}
+}
+
+// batchResponseRecorder はサブリクエストのレスポンスを記録する http.ResponseWriter です。
+type batchResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// newBatchResponseRecorder は新しい batchResponseRecorder を作成します。
+func newBatchResponseRecorder() *batchResponseRecorder {
+	return &batchResponseRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+// Header はレスポンスヘッダーを返します。
+func (r *batchResponseRecorder) Header() http.Header {
+	return r.header
+}
+
+// Write はレスポンスボディを記録します。
+func (r *batchResponseRecorder) Write(p []byte) (int, error) {
+	return r.body.Write(p)
+}
+
+// WriteHeader はステータスコードを記録します。
+func (r *batchResponseRecorder) WriteHeader(status int) {
+	r.status = status
+}
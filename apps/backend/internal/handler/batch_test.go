@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Batch Tests - バッチリクエストのテスト
+// =============================================================================
+// テスト対象:
+//   - Batch: サブリクエストを順番に実行し、サブリクエストごとのレスポンスを返すこと
+//   - トランザクションのグループの失敗時のロールバック・未実行
+//   - 入れ子・連続していないグループ・上限超過の拒否
+
+// newBatchTestEcho は全ルートを登録したテスト用の Echo と認証トークンを作成します。
+func newBatchTestEcho(t *testing.T) (*echo.Echo, *repository.MockRepositories, string) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	mockRepos := repository.NewMockRepositories()
+	jwtManager := auth.NewJWTManager("batch-test-secret-key-32-characters", 24)
+	NewHandler(service.NewService(mockRepos), jwtManager, nil).RegisterRoutes(e)
+
+	token, err := jwtManager.GenerateToken(1, "", "batch@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return e, mockRepos, token
+}
+
+// postBatch はバッチリクエストを送信し、レスポンスを返します。
+func postBatch(t *testing.T, e *echo.Echo, token, body string) (*httptest.ResponseRecorder, BatchResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var resp BatchResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode batch response: %v", err)
+		}
+	}
+	return rec, resp
+}
+
+// TestBatch_Independent は group のないサブリクエストのテストです。
+// 期待動作:
+//   - サブリクエストを順番に実行し、id・ステータス・ボディを同じ順序で返す
+//   - 失敗したサブリクエストがあっても次のサブリクエストを実行する
+//   - サブリクエストはバッチリクエストのトークンで認証される
+func TestBatch_Independent(t *testing.T) {
+	// Arrange
+	e, mockRepos, token := newBatchTestEcho(t)
+	body := `{"requests": [
+		{"id": "create", "method": "POST", "path": "/tasks", "body": {"title": "水やり", "due_date": "2026-05-01T09:00:00Z"}},
+		{"id": "missing", "method": "GET", "path": "/tasks/999"},
+		{"id": "list", "method": "GET", "path": "/api/v1/tasks"}
+	]}`
+
+	// Act
+	rec, resp := postBatch(t, e, token, body)
+
+	// Assert
+	if rec.Code != http.StatusOK || len(resp.Responses) != 3 {
+		t.Fatalf("Expected 200 with 3 responses, got %d: %s", rec.Code, rec.Body.String())
+	}
+	create, missing, list := resp.Responses[0], resp.Responses[1], resp.Responses[2]
+	if create.ID != "create" || create.Status != http.StatusCreated {
+		t.Errorf("Expected created task, got %+v", create)
+	}
+	var problem apperrors.Problem
+	_ = json.Unmarshal(missing.Body, &problem)
+	if missing.Status != http.StatusNotFound || problem.Code != apperrors.ErrCodeTaskNotFound {
+		t.Errorf("Expected TASK_NOT_FOUND, got %d %s", missing.Status, string(missing.Body))
+	}
+	var tasks []map[string]any
+	if err := json.Unmarshal(list.Body, &tasks); err != nil || list.Status != http.StatusOK || len(tasks) != 1 {
+		t.Errorf("Expected the created task in the list, got %d %s", list.Status, string(list.Body))
+	}
+	if resp.Succeeded != 2 || resp.Failed != 1 {
+		t.Errorf("Expected 2 succeeded / 1 failed, got %d / %d", resp.Succeeded, resp.Failed)
+	}
+	if len(mockRepos.GetMockTaskRepository().Tasks) != 1 {
+		t.Errorf("Expected 1 stored task, got %d", len(mockRepos.GetMockTaskRepository().Tasks))
+	}
+}
+
+// TestBatch_GroupFailure はトランザクションのグループのサブリクエストが失敗した場合のテストです。
+// 期待動作:
+//   - 失敗より前のサブリクエストは rolled_back、失敗より後は 424 BATCH_GROUP_ABORTED で実行しない
+//   - グループの後の group のないサブリクエストは実行する
+func TestBatch_GroupFailure(t *testing.T) {
+	// Arrange
+	e, mockRepos, token := newBatchTestEcho(t)
+	body := `{"requests": [
+		{"id": "a", "method": "POST", "path": "/tasks", "group": "sync", "body": {"title": "水やり", "due_date": "2026-05-01T09:00:00Z"}},
+		{"id": "b", "method": "POST", "path": "/tasks/999/complete", "group": "sync"},
+		{"id": "c", "method": "POST", "path": "/tasks", "group": "sync", "body": {"title": "追肥", "due_date": "2026-05-02T09:00:00Z"}},
+		{"id": "d", "method": "GET", "path": "/tasks"}
+	]}`
+
+	// Act
+	rec, resp := postBatch(t, e, token, body)
+
+	// Assert
+	if rec.Code != http.StatusOK || len(resp.Responses) != 4 {
+		t.Fatalf("Expected 200 with 4 responses, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if a := resp.Responses[0]; a.Status != http.StatusCreated || !a.RolledBack || a.Group != "sync" {
+		t.Errorf("Expected rolled back create, got %+v", a)
+	}
+	if b := resp.Responses[1]; b.Status < http.StatusBadRequest || b.RolledBack {
+		t.Errorf("Expected failed sub-request, got %+v", b)
+	}
+	var problem apperrors.Problem
+	_ = json.Unmarshal(resp.Responses[2].Body, &problem)
+	if resp.Responses[2].Status != http.StatusFailedDependency || problem.Code != apperrors.ErrCodeBatchGroupAborted {
+		t.Errorf("Expected aborted sub-request, got %+v", resp.Responses[2])
+	}
+	if d := resp.Responses[3]; d.Status != http.StatusOK {
+		t.Errorf("Expected independent sub-request after the group to run, got %+v", d)
+	}
+	if resp.Succeeded != 1 || resp.Failed != 3 {
+		t.Errorf("Expected 1 succeeded / 3 failed, got %d / %d", resp.Succeeded, resp.Failed)
+	}
+	// モックのトランザクションはロールバックしないため、未実行のサブリクエストが保存されていないことのみ確認する
+	if len(mockRepos.GetMockTaskRepository().Tasks) != 1 {
+		t.Errorf("Expected the aborted create not to run, got %d tasks", len(mockRepos.GetMockTaskRepository().Tasks))
+	}
+}
+
+// TestBatch_Invalid は不正なバッチリクエストのテストです。
+// 期待動作:
+//   - 入れ子のバッチ・連続していないグループ・上限超過は 400、不正なメソッド・空のバッチは 422 でサブリクエストを実行しない
+//   - 認証トークンがない場合は 401
+func TestBatch_Invalid(t *testing.T) {
+	e, _, token := newBatchTestEcho(t)
+	tooMany := make([]string, MaxBatchRequests+1)
+	for i := range tooMany {
+		tooMany[i] = `{"method": "GET", "path": "/tasks"}`
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"nested", `{"requests": [{"method": "POST", "path": "/batch", "body": {"requests": []}}]}`, http.StatusBadRequest},
+		{"split group", `{"requests": [
+			{"method": "GET", "path": "/tasks", "group": "g"},
+			{"method": "GET", "path": "/tasks"},
+			{"method": "GET", "path": "/tasks", "group": "g"}
+		]}`, http.StatusBadRequest},
+		{"too many", `{"requests": [` + strings.Join(tooMany, ",") + `]}`, http.StatusBadRequest},
+		{"invalid method", `{"requests": [{"method": "TRACE", "path": "/tasks"}]}`, http.StatusUnprocessableEntity},
+		{"empty", `{"requests": []}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rec, _ := postBatch(t, e, token, tt.body)
+
+			// Assert
+			if rec.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		rec, _ := postBatch(t, e, "", `{"requests": [{"method": "GET", "path": "/tasks"}]}`)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d", rec.Code)
+		}
+	})
+}
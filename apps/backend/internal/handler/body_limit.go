@@ -0,0 +1,110 @@
+// Package handler - Body Limits
+//
+// リクエストボディのサイズの上限をルートのグループごとに設定するミドルウェアを提供します。
+// 上限を超えたリクエストはボディを最後まで読み込まずに 413 PAYLOAD_TOO_LARGE を返すため、
+// 大きなリクエスト（巨大な JSON・画像）でサーバーのメモリを使い切らないようにします。
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+)
+
+// =============================================================================
+// Body Limits - リクエストボディのサイズの上限
+// =============================================================================
+// /api/v1 のグループに Default、バッチ・同期・画像のアップロードのルートにそれぞれの上限を設定します。
+// ルートの上限はグループの上限を置き換えます（グループより大きい上限も指定できる）。
+// ボディの読み込み時に判定するため、ハンドラの c.Bind・multipart の読み込みは変更せずに上限が適用されます。
+
+// BodyLimits はリクエストボディのサイズの上限です（バイト、0 の場合は無制限）。
+type BodyLimits struct {
+	Default int64 // JSON の API（下記以外の /api/v1 のルート）
+	Batch   int64 // バッチリクエスト・オフライン同期（POST /batch, POST /sync）
+	Upload  int64 // 画像のアップロード（POST /crops/images の multipart/form-data 全体）
+}
+
+// DefaultBodyLimits は SetBodyLimits を呼び出さない場合の上限です（config.BodyLimitConfig のデフォルトと同じ）。
+var DefaultBodyLimits = BodyLimits{
+	Default: 1 << 20,  // 1MB
+	Batch:   10 << 20, // 10MB
+	Upload:  6 << 20,  // 6MB（画像の上限 5MB と multipart の区切り・ヘッダー）
+}
+
+// SetBodyLimits はリクエストボディのサイズの上限を設定します。
+// 上限はルートの登録時に適用するため、RegisterRoutes の前に呼び出してください。
+//
+// 引数:
+//   - limits: ルートのグループごとの上限（バイト、0 の場合は無制限）
+func (h *Handler) SetBodyLimits(limits BodyLimits) {
+	h.bodyLimits = limits
+}
+
+// limitedBody は上限までしか読み込まないリクエストボディです。
+// 上限を超えた場合（Content-Length が上限より大きい場合は最初の読み込み）は *http.MaxBytesError を返します。
+type limitedBody struct {
+	io.ReadCloser
+	contentLength int64
+	limit         int64
+	read          int64
+	exceeded      bool
+}
+
+// Read はボディを読み込みます（上限を超えた部分は返さない）。
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded || (b.limit > 0 && b.contentLength > b.limit) {
+		b.exceeded = true
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	if b.limit > 0 && int64(len(p)) > b.limit-b.read+1 {
+		p = p[:b.limit-b.read+1] // 上限を超えたかを判定するため 1 バイト多く読み込む
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.limit > 0 && b.read > b.limit {
+		b.exceeded = true
+		return n - int(b.read-b.limit), &http.MaxBytesError{Limit: b.limit}
+	}
+	return n, err
+}
+
+// bodyLimitMiddleware はリクエストボディのサイズを limit バイトまでにします。
+// 上限を超えた場合は、ハンドラのエラー（c.Bind の 400 など）を 413 PAYLOAD_TOO_LARGE に置き換えます。
+// すでにグループの上限が設定されている場合は、上限を limit に置き換えます。
+//
+// 引数:
+//   - limit: 上限（バイト、0 の場合は無制限）
+func bodyLimitMiddleware(limit int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			body, ok := req.Body.(*limitedBody)
+			if ok {
+				body.limit = limit
+			} else {
+				body = &limitedBody{ReadCloser: req.Body, contentLength: req.ContentLength, limit: limit}
+				req.Body = body
+			}
+
+			err := next(c)
+			if body.exceeded {
+				return payloadTooLargeError(body.limit)
+			}
+			return err
+		}
+	}
+}
+
+// payloadTooLargeError はリクエストボディが上限を超えた場合のエラーです。
+func payloadTooLargeError(limit int64) error {
+	return apperrors.NewPayloadTooLargeError(apperrors.ErrCodePayloadTooLarge,
+		fmt.Sprintf("Request body exceeds the maximum allowed size (%d bytes)", limit), limit)
+}
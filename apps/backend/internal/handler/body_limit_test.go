@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/storage"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Body Limit Tests - リクエストボディのサイズの上限のテスト
+// =============================================================================
+// テスト対象:
+//   - bodyLimitMiddleware: 上限を超えたリクエストの 413 PAYLOAD_TOO_LARGE（Content-Length あり・なし）
+//   - ルートの上限（バッチ）によるグループの上限の置き換え
+//   - UploadImage: multipart のストリームの読み込み、画像のサイズ超過（413 IMAGE_TOO_LARGE）・形式不正
+
+// pngImage は PNG のシグネチャ（MIME タイプの判定に使用）から始まる size バイトのデータを作成します。
+func pngImage(size int) []byte {
+	data := make([]byte, size)
+	copy(data, "\x89PNG\r\n\x1a\n")
+	return data
+}
+
+// newBodyLimitTestEcho は上限を設定して全ルートを登録したテスト用の Echo と認証トークンを作成します。
+func newBodyLimitTestEcho(t *testing.T, limits BodyLimits) (*echo.Echo, string) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	fileStorage := storage.NewService(nil) // 未設定（アップロードは 503）
+	jwtManager := auth.NewJWTManager("body-limit-test-secret-key-32-chars", 24)
+	h := NewHandler(service.NewService(repository.NewMockRepositories()), jwtManager, fileStorage)
+	h.SetBodyLimits(limits)
+	h.RegisterRoutes(e)
+
+	token, err := jwtManager.GenerateToken(1, "", "body-limit@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return e, token
+}
+
+// doBodyLimit はリクエストを送信し、レスポンスを返します。
+func doBodyLimit(e *echo.Echo, token, path, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeSizeLimitProblem は 413 のレスポンスのエラーコードと上限のバイト数を返します。
+func decodeSizeLimitProblem(t *testing.T, rec *httptest.ResponseRecorder) (string, int64) {
+	t.Helper()
+	var problem struct {
+		Code   string                     `json:"code"`
+		Errors apperrors.SizeLimitDetails `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v (%s)", err, rec.Body.String())
+	}
+	return problem.Code, problem.Errors.LimitBytes
+}
+
+// imageUpload は image のパートに content を含む multipart/form-data のボディを作成します。
+func imageUpload(t *testing.T, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("note", "before image"); err != nil {
+		t.Fatalf("WriteField failed: %v", err)
+	}
+	part, err := writer.CreateFormFile("image", "photo.png")
+	if err != nil {
+		t.Fatalf("CreateFormFile failed: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return &body, writer.FormDataContentType()
+}
+
+// onlyReader は Content-Length を持たないボディです（チャンク転送のリクエスト）。
+type onlyReader struct{ io.Reader }
+
+// TestBodyLimit_Default はグループの上限のテストです。
+// 期待動作:
+//   - 上限以下のボディは通常どおり処理される（201）
+//   - Content-Length が上限を超える場合は 413 PAYLOAD_TOO_LARGE で、errors.limit_bytes に上限を返す
+//   - Content-Length がない場合も上限を超えた時点で 413（c.Bind の 400 を置き換える）
+func TestBodyLimit_Default(t *testing.T) {
+	// Arrange
+	e, token := newBodyLimitTestEcho(t, BodyLimits{Default: 128, Batch: 1 << 20, Upload: 1 << 20})
+	large := `{"name": "` + strings.Repeat("A", 256) + `", "width": 1, "height": 1}`
+
+	// Act
+	small := doBodyLimit(e, token, "/api/v1/plots", echo.MIMEApplicationJSON, strings.NewReader(`{"name": "A区画", "width": 1, "height": 1}`))
+	declared := doBodyLimit(e, token, "/api/v1/plots", echo.MIMEApplicationJSON, strings.NewReader(large))
+	chunked := doBodyLimit(e, token, "/api/v1/plots", echo.MIMEApplicationJSON, onlyReader{strings.NewReader(large)})
+
+	// Assert
+	if small.Code != http.StatusCreated {
+		t.Errorf("Expected 201 for a small body, got %d %s", small.Code, small.Body.String())
+	}
+	for name, rec := range map[string]*httptest.ResponseRecorder{"declared": declared, "chunked": chunked} {
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: expected 413, got %d %s", name, rec.Code, rec.Body.String())
+			continue
+		}
+		if code, limit := decodeSizeLimitProblem(t, rec); code != apperrors.ErrCodePayloadTooLarge || limit != 128 {
+			t.Errorf("%s: expected PAYLOAD_TOO_LARGE with limit 128, got %s %d", name, code, limit)
+		}
+	}
+}
+
+// TestBodyLimit_RouteOverride はルートの上限のテストです。
+// 期待動作:
+//   - バッチリクエストはグループの上限より大きいボディも受け付ける（ルートの上限で置き換え）
+//   - バッチの上限を超えた場合は 413 で、errors.limit_bytes はバッチの上限
+func TestBodyLimit_RouteOverride(t *testing.T) {
+	// Arrange
+	e, token := newBodyLimitTestEcho(t, BodyLimits{Default: 64, Batch: 1024, Upload: 1 << 20})
+	batch := `{"requests": [{"method": "GET", "path": "/api/v1/plots", "id": "` + strings.Repeat("a", 100) + `"}]}`
+	tooLarge := `{"requests": [{"method": "GET", "path": "/api/v1/plots", "id": "` + strings.Repeat("a", 2048) + `"}]}`
+
+	// Act
+	accepted := doBodyLimit(e, token, "/api/v1/batch", echo.MIMEApplicationJSON, strings.NewReader(batch))
+	rejected := doBodyLimit(e, token, "/api/v1/batch", echo.MIMEApplicationJSON, strings.NewReader(tooLarge))
+
+	// Assert
+	if accepted.Code != http.StatusOK {
+		t.Errorf("Expected batch within its own limit to succeed, got %d %s", accepted.Code, accepted.Body.String())
+	}
+	if rejected.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected 413, got %d %s", rejected.Code, rejected.Body.String())
+	}
+	if code, limit := decodeSizeLimitProblem(t, rejected); code != apperrors.ErrCodePayloadTooLarge || limit != 1024 {
+		t.Errorf("Expected PAYLOAD_TOO_LARGE with limit 1024, got %s %d", code, limit)
+	}
+}
+
+// TestUploadImage_Streaming は画像のアップロードのテストです（S3 は未設定）。
+// 期待動作:
+//   - image より前のパートを読み飛ばし、形式・サイズの検証を通過した画像は S3 の未設定で 503
+//   - 5MB を超える画像は 413 IMAGE_TOO_LARGE（errors.limit_bytes は 5MB）
+//   - 画像でないファイルは 400 UNSUPPORTED_IMAGE_TYPE、image のパートがない場合は 400
+//   - multipart 全体がアップロードの上限を超える場合は 413 PAYLOAD_TOO_LARGE
+func TestUploadImage_Streaming(t *testing.T) {
+	// Arrange
+	e, token := newBodyLimitTestEcho(t, DefaultBodyLimits)
+	limited, limitedToken := newBodyLimitTestEcho(t, BodyLimits{Default: 1 << 20, Batch: 1 << 20, Upload: 4096})
+	valid, validType := imageUpload(t, pngImage(1024))
+	oversized, oversizedType := imageUpload(t, pngImage(storage.MaxImageSize+1))
+	text, textType := imageUpload(t, []byte("this is not an image"))
+	overLimit, overLimitType := imageUpload(t, pngImage(8192))
+
+	// Act
+	validRec := doBodyLimit(e, token, "/api/v1/crops/images", validType, valid)
+	oversizedRec := doBodyLimit(e, token, "/api/v1/crops/images", oversizedType, oversized)
+	textRec := doBodyLimit(e, token, "/api/v1/crops/images", textType, text)
+	missingRec := doBodyLimit(e, token, "/api/v1/crops/images", echo.MIMEApplicationJSON, strings.NewReader(`{}`))
+	overLimitRec := doBodyLimit(limited, limitedToken, "/api/v1/crops/images", overLimitType, overLimit)
+
+	// Assert
+	if validRec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected valid image to reach S3 (503 not configured), got %d %s", validRec.Code, validRec.Body.String())
+	}
+	if oversizedRec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for oversized image, got %d %s", oversizedRec.Code, oversizedRec.Body.String())
+	} else if code, limit := decodeSizeLimitProblem(t, oversizedRec); code != apperrors.ErrCodeImageTooLarge || limit != storage.MaxImageSize {
+		t.Errorf("Expected IMAGE_TOO_LARGE with limit %d, got %s %d", storage.MaxImageSize, code, limit)
+	}
+	if textRec.Code != http.StatusBadRequest || !strings.Contains(textRec.Body.String(), apperrors.ErrCodeUnsupportedImageType) {
+		t.Errorf("Expected 400 UNSUPPORTED_IMAGE_TYPE, got %d %s", textRec.Code, textRec.Body.String())
+	}
+	if missingRec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without multipart body, got %d", missingRec.Code)
+	}
+	if overLimitRec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected 413 for body over upload limit, got %d %s", overLimitRec.Code, overLimitRec.Body.String())
+	}
+	if code, limit := decodeSizeLimitProblem(t, overLimitRec); code != apperrors.ErrCodePayloadTooLarge || limit != 4096 {
+		t.Errorf("Expected PAYLOAD_TOO_LARGE with limit 4096, got %s %d", code, limit)
+	}
+}
@@ -0,0 +1,159 @@
+// Package handler - Conditional Requests
+//
+// GET のレスポンスに ETag を付け、If-None-Match（または If-Modified-Since）が一致する場合は
+// 304 Not Modified をボディなしで返すミドルウェアを提供します。
+// 区画のレイアウト・グラフデータなどを定期的に取得するモバイルアプリの通信量を減らすために使用します。
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// =============================================================================
+// Conditional GET - ETag / If-None-Match
+// =============================================================================
+// ETag はレスポンスのボディの SHA-256 から作成するため、ハンドラの変更は不要です。
+// Last-Modified はハンドラが設定した場合のみ付きます（単一の記録の取得で updated_at を設定）。
+// 一覧・集計は削除で内容が変わっても最終更新日時が変わらないため、ETag のみで判定します。
+
+// ConditionalCacheControl はハンドラが Cache-Control を設定していない GET のレスポンスの Cache-Control です。
+// レスポンスはユーザーごとのため共有キャッシュには保存させず、毎回 ETag で再検証させます。
+const ConditionalCacheControl = "private, no-cache"
+
+// 条件付きリクエストのヘッダー（echo に定数がないもの）
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// conditionalSkipPaths はレスポンスをバッファしないルートです（ストリーミングのエンドポイント）。
+var conditionalSkipPaths = map[string]bool{
+	"/api/v1/events": true,
+}
+
+// conditionalGetMiddleware は GET・HEAD の 200 のレスポンスに ETag を付け、
+// クライアントの持つ ETag と一致する場合は 304 Not Modified を返します。
+//
+// 判定（RFC 9110）:
+//   - If-None-Match がある場合は ETag の弱い比較（W/ を無視）で判定し、* は常に一致
+//   - If-None-Match がなく If-Modified-Since がある場合は、Last-Modified がその日時以前なら 304
+func conditionalGetMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if (req.Method != http.MethodGet && req.Method != http.MethodHead) || conditionalSkipPaths[c.Path()] {
+				return next(c)
+			}
+
+			res := c.Response()
+			original := res.Writer
+			buffer := &bufferedResponseWriter{ResponseWriter: original}
+			res.Writer = buffer
+			err := next(c)
+			res.Writer = original
+
+			if buffer.status == 0 {
+				// ハンドラがレスポンスを書き込んでいない（エラーハンドラが書き込む）
+				return err
+			}
+			if buffer.status == http.StatusOK {
+				header := res.Header()
+				etag := header.Get(headerETag)
+				if etag == "" {
+					etag = bodyETag(buffer.body.Bytes())
+					header.Set(headerETag, etag)
+				}
+				if header.Get(echo.HeaderCacheControl) == "" {
+					header.Set(echo.HeaderCacheControl, ConditionalCacheControl)
+				}
+				if notModified(req, etag, header.Get(echo.HeaderLastModified)) {
+					header.Del(echo.HeaderContentType)
+					header.Del(echo.HeaderContentLength)
+					res.Status = http.StatusNotModified
+					res.Size = 0
+					original.WriteHeader(http.StatusNotModified)
+					return err
+				}
+			}
+			original.WriteHeader(buffer.status)
+			_, _ = original.Write(buffer.body.Bytes())
+			return err
+		}
+	}
+}
+
+// bufferedResponseWriter はステータスとボディを書き込まずに保持します（ヘッダーは元の ResponseWriter に設定）。
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader はステータスを保持します。
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write はボディを保持します。
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// bodyETag はボディの SHA-256 の先頭128ビットから強い ETag を作成します。
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified は条件付きリクエストの条件から 304 を返すかを判定します。
+func notModified(req *http.Request, etag, lastModified string) bool {
+	if ifNoneMatch := req.Header.Get(headerIfNoneMatch); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := req.Header.Get(echo.HeaderIfModifiedSince)
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches は If-None-Match のいずれかの ETag が弱い比較で一致するかを判定します。
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// setLastModified はレスポンスに Last-Modified を設定します（If-Modified-Since の判定に使用、ゼロ値は設定しない）。
+func setLastModified(c echo.Context, modifiedAt time.Time) {
+	if modifiedAt.IsZero() {
+		return
+	}
+	c.Response().Header().Set(echo.HeaderLastModified, modifiedAt.UTC().Format(http.TimeFormat))
+}
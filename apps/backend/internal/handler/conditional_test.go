@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Conditional Request Tests - 条件付きリクエストのテスト
+// =============================================================================
+// テスト対象:
+//   - conditionalGetMiddleware: GET のレスポンスへの ETag の付与と If-None-Match による 304
+//   - If-Modified-Since と Last-Modified（単一の記録の取得）による 304
+//   - GET 以外・エラーのレスポンスには ETag を付けないこと
+//   - analyticsCacheMiddleware: グラフ・集計のデータのバージョンの ETag と、作物・収穫記録の変更による無効化
+
+// newConditionalTestEcho は全ルートを登録したテスト用の Echo と認証トークンを作成します。
+func newConditionalTestEcho(t *testing.T) (*echo.Echo, string) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	mockRepos := repository.NewMockRepositories()
+	jwtManager := auth.NewJWTManager("conditional-test-secret-key-32-chars", 24)
+	NewHandler(service.NewService(mockRepos), jwtManager, nil).RegisterRoutes(e)
+
+	token, err := jwtManager.GenerateToken(1, "", "conditional@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return e, token
+}
+
+// doConditional はリクエストを送信し、レスポンスを返します（headers は追加のヘッダー）。
+func doConditional(e *echo.Echo, token, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestConditional_ETag は ETag と If-None-Match のテストです。
+// 期待動作:
+//   - GET の 200 のレスポンスに ETag と Cache-Control: private, no-cache が付く
+//   - If-None-Match が一致する場合（弱い ETag・複数指定を含む）は 304 でボディなし
+//   - 記録を変更した後は ETag が変わり、古い ETag では 200 と新しいボディを返す
+func TestConditional_ETag(t *testing.T) {
+	// Arrange
+	e, token := newConditionalTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/plots", `{"name": "A区画", "width": 2, "height": 3}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create plot: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Act
+	first := doConditional(e, token, http.MethodGet, "/api/v1/plots/layout", "", nil)
+	etag := first.Header().Get("ETag")
+	revalidated := doConditional(e, token, http.MethodGet, "/api/v1/plots/layout", "", map[string]string{"If-None-Match": etag})
+	weak := doConditional(e, token, http.MethodGet, "/api/v1/plots/layout", "", map[string]string{"If-None-Match": `"other", W/` + etag})
+
+	// Assert
+	if first.Code != http.StatusOK || etag == "" || !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("Expected 200 with a strong ETag, got %d %q", first.Code, etag)
+	}
+	if got := first.Header().Get(echo.HeaderCacheControl); got != ConditionalCacheControl {
+		t.Errorf("Expected Cache-Control %q, got %q", ConditionalCacheControl, got)
+	}
+	if revalidated.Code != http.StatusNotModified || revalidated.Body.Len() != 0 {
+		t.Errorf("Expected 304 without body, got %d %q", revalidated.Code, revalidated.Body.String())
+	}
+	if revalidated.Header().Get("ETag") != etag {
+		t.Errorf("Expected 304 to repeat ETag %q, got %q", etag, revalidated.Header().Get("ETag"))
+	}
+	if weak.Code != http.StatusNotModified {
+		t.Errorf("Expected weak comparison to match, got %d", weak.Code)
+	}
+
+	// Act - 区画を変更した後の再検証
+	if rec := doConditional(e, token, http.MethodPut, "/api/v1/plots/1", `{"name": "B区画", "version": 1}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("Failed to update plot: %d %s", rec.Code, rec.Body.String())
+	}
+	changed := doConditional(e, token, http.MethodGet, "/api/v1/plots/layout", "", map[string]string{"If-None-Match": etag})
+
+	// Assert
+	if changed.Code != http.StatusOK || !strings.Contains(changed.Body.String(), "B区画") {
+		t.Fatalf("Expected 200 with updated layout, got %d %s", changed.Code, changed.Body.String())
+	}
+	if changed.Header().Get("ETag") == etag {
+		t.Error("Expected ETag to change after the plot was updated")
+	}
+}
+
+// TestConditional_IfModifiedSince は Last-Modified と If-Modified-Since のテストです。
+// 期待動作:
+//   - 単一の記録の取得は updated_at を Last-Modified に設定する
+//   - If-Modified-Since が Last-Modified 以降の場合は 304、以前の場合は 200
+//   - If-None-Match がある場合は If-Modified-Since より優先する
+func TestConditional_IfModifiedSince(t *testing.T) {
+	// Arrange
+	e, token := newConditionalTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/tasks", `{"title": "水やり", "due_date": "2026-05-01T09:00:00Z"}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create task: %d %s", rec.Code, rec.Body.String())
+	}
+	first := doConditional(e, token, http.MethodGet, "/api/v1/tasks/1", "", nil)
+	lastModified := first.Header().Get(echo.HeaderLastModified)
+	modifiedAt, err := http.ParseTime(lastModified)
+	if err != nil {
+		t.Fatalf("Expected Last-Modified header, got %q", lastModified)
+	}
+	before := modifiedAt.Add(-time.Hour).Format(http.TimeFormat)
+
+	// Act
+	notModified := doConditional(e, token, http.MethodGet, "/api/v1/tasks/1", "", map[string]string{echo.HeaderIfModifiedSince: lastModified})
+	modified := doConditional(e, token, http.MethodGet, "/api/v1/tasks/1", "", map[string]string{echo.HeaderIfModifiedSince: before})
+	mismatch := doConditional(e, token, http.MethodGet, "/api/v1/tasks/1", "", map[string]string{
+		echo.HeaderIfModifiedSince: lastModified,
+		"If-None-Match":            `"stale"`,
+	})
+
+	// Assert
+	if notModified.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for If-Modified-Since = Last-Modified, got %d", notModified.Code)
+	}
+	if modified.Code != http.StatusOK {
+		t.Errorf("Expected 200 for If-Modified-Since before Last-Modified, got %d", modified.Code)
+	}
+	if mismatch.Code != http.StatusOK {
+		t.Errorf("Expected If-None-Match to take precedence, got %d", mismatch.Code)
+	}
+}
+
+// TestConditional_Skipped は ETag を付けないレスポンスのテストです。
+// 期待動作:
+//   - GET 以外のレスポンスには ETag を付けない
+//   - エラーのレスポンスには ETag を付けず、If-None-Match: * でも 304 にしない
+func TestConditional_Skipped(t *testing.T) {
+	// Arrange
+	e, token := newConditionalTestEcho(t)
+
+	// Act
+	created := doConditional(e, token, http.MethodPost, "/api/v1/tasks", `{"title": "水やり", "due_date": "2026-05-01T09:00:00Z"}`, nil)
+	missing := doConditional(e, token, http.MethodGet, "/api/v1/tasks/999", "", map[string]string{"If-None-Match": "*"})
+
+	// Assert
+	if created.Code != http.StatusCreated || created.Header().Get("ETag") != "" {
+		t.Errorf("Expected 201 without ETag, got %d %q", created.Code, created.Header().Get("ETag"))
+	}
+	if missing.Code != http.StatusNotFound || missing.Header().Get("ETag") != "" {
+		t.Errorf("Expected 404 without ETag, got %d %q", missing.Code, missing.Header().Get("ETag"))
+	}
+}
+
+// TestConditional_AnalyticsVersion はグラフ・集計のデータのバージョンの ETag のテストです。
+// 期待動作:
+//   - グラフのレスポンスにデータのバージョンの ETag・Cache-Control: public, no-cache・Vary が付く
+//   - If-None-Match が一致する場合は 304、クエリが異なる場合は ETag も異なる
+//   - 収穫記録を追加した後は古い ETag で 200 を返し、ETag が変わる
+func TestConditional_AnalyticsVersion(t *testing.T) {
+	// Arrange
+	e, token := newConditionalTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/crops", `{"name": "トマト", "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create crop: %d %s", rec.Code, rec.Body.String())
+	}
+	const chartPath = "/api/v1/analytics/charts/monthly_harvest"
+
+	// Act
+	first := doConditional(e, token, http.MethodGet, chartPath, "", nil)
+	etag := first.Header().Get("ETag")
+	revalidated := doConditional(e, token, http.MethodGet, chartPath, "", map[string]string{"If-None-Match": etag})
+	otherQuery := doConditional(e, token, http.MethodGet, chartPath+"?year=2025", "", nil)
+
+	// Assert
+	if first.Code != http.StatusOK || !strings.HasPrefix(etag, `"a`) {
+		t.Fatalf("Expected 200 with a data version ETag, got %d %q", first.Code, etag)
+	}
+	if got := first.Header().Get(echo.HeaderCacheControl); got != AnalyticsCacheControl {
+		t.Errorf("Expected Cache-Control %q, got %q", AnalyticsCacheControl, got)
+	}
+	if got := first.Header().Get(echo.HeaderVary); !strings.Contains(got, echo.HeaderAuthorization) || !strings.Contains(got, "X-Org-ID") {
+		t.Errorf("Expected Vary on Authorization and X-Org-ID, got %q", got)
+	}
+	if revalidated.Code != http.StatusNotModified || revalidated.Body.Len() != 0 {
+		t.Errorf("Expected 304 without body, got %d %q", revalidated.Code, revalidated.Body.String())
+	}
+	if otherQuery.Header().Get("ETag") == etag {
+		t.Error("Expected a different ETag for a different query")
+	}
+
+	// Act - 収穫記録を追加した後の再検証
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/crops/1/harvests", `{"harvest_date": "2026-07-01T00:00:00Z", "quantity": 1, "quantity_unit": "kg"}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create harvest: %d %s", rec.Code, rec.Body.String())
+	}
+	changed := doConditional(e, token, http.MethodGet, chartPath, "", map[string]string{"If-None-Match": etag})
+
+	// Assert
+	if changed.Code != http.StatusOK || changed.Header().Get("ETag") == etag {
+		t.Errorf("Expected 200 with a new ETag after the harvest was added, got %d %q", changed.Code, changed.Header().Get("ETag"))
+	}
+}
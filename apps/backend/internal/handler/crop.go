@@ -14,16 +14,21 @@
 package handler
 
 import (
+	"errors"
 	"bytes"
+	"context"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
 	"github.com/secure-scorecard/backend/internal/storage"
 	"github.com/secure-scorecard/backend/internal/validator"
 )
@@ -60,6 +65,9 @@ type UpdateCropRequest struct {
 	Status              string    `json:"status" validate:"omitempty,oneof=planted growing ready_to_harvest harvested failed"`
 	PlotID              *uint     `json:"plot_id"`
 	Notes               string    `json:"notes" validate:"max=1000"`
+
+	// 取得した作物の version（If-Match ヘッダーを指定しない場合は必須）
+	Version *uint `json:"version"`
 }
 
 // CreateGrowthRecordRequest は成長記録追加リクエストの構造体です。
@@ -97,11 +105,18 @@ type CreateHarvestRequest struct {
 
 // GetCrops はユーザーの全作物を取得します。
 //
+// リクエストヘッダー:
+//   - Accept: text/csv の場合はエクスポートと同じ列のCSV（ページングした場合は X-Next-Cursor に次のページのカーソル）
+//
 // クエリパラメータ:
 //   - status: フィルタするステータス（planted/growing/ready_to_harvest/harvested/failed）
+//   - filter: 絞り込みの条件「フィールド:演算子:値」、複数指定可（status, name, variety, plot_id, planted_date, expected_harvest_date, created_at）
+//   - limit: 1ページの件数（指定した場合は1ページ分を返す、最大100）
+//   - cursor: 前のページの next_cursor
 //
 // レスポンス:
-//   - 200: 作物の配列（植え付け日順）
+//   - 200: 作物の配列（植え付け日順）、limit・cursor・filter を指定した場合は1ページ分（items, next_cursor, has_more, limit、新しい順）
+//   - 400: 不正な limit・cursor・filter
 //   - 401: 認証エラー
 //   - 500: 内部エラー
 func (h *Handler) GetCrops(c echo.Context) error {
@@ -115,8 +130,30 @@ func (h *Handler) GetCrops(c echo.Context) error {
 
 	// statusクエリパラメータでフィルタリング
 	status := c.QueryParam("status")
+	spec, filtered, err := filterSpec(c, cropFilterSchema)
+	if err != nil {
+		return err
+	}
+	csv := negotiateCSV(c)
+
+	// limit・cursor・filterを指定した場合は1ページ分を返す
+	params, paginated, err := paginationParams(c)
+	if err != nil {
+		return err
+	}
+	if paginated || filtered {
+		page, err := h.service.GetUserCropsPage(ctx, userID, spec, params)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to fetch crops")
+		}
+		if csv {
+			result, err := h.service.CropsCSV(page.Items)
+			return respondListCSV(c, result, err, page.NextCursor)
+		}
+		return c.JSON(http.StatusOK, dto.Page(page, dto.NewCropResponse))
+	}
+
 	var crops []model.Crop
-	var err error
 
 	if status != "" {
 		// ステータスでフィルタ
@@ -129,8 +166,12 @@ func (h *Handler) GetCrops(c echo.Context) error {
 	if err != nil {
 		return apperrors.NewInternalError("Failed to fetch crops")
 	}
+	if csv {
+		result, err := h.service.CropsCSV(crops)
+		return respondListCSV(c, result, err, "")
+	}
 
-	return c.JSON(http.StatusOK, crops)
+	return c.JSON(http.StatusOK, dto.List(crops, dto.NewCropResponse))
 }
 
 // GetCrop は特定の作物を取得します。
@@ -140,6 +181,7 @@ func (h *Handler) GetCrops(c echo.Context) error {
 //
 // レスポンス:
 //   - 200: 作物オブジェクト
+//   - 304: 変更なし（If-None-Match / If-Modified-Since が一致、ボディなし）
 //   - 400: 無効なID形式
 //   - 404: 作物が見つからない
 func (h *Handler) GetCrop(c echo.Context) error {
@@ -157,7 +199,8 @@ func (h *Handler) GetCrop(c echo.Context) error {
 		return apperrors.NewNotFoundError("Crop")
 	}
 
-	return c.JSON(http.StatusOK, crop)
+	setLastModified(c, crop.UpdatedAt)
+	return c.JSON(http.StatusOK, dto.NewCropResponse(crop))
 }
 
 // CreateCrop は新しい作物を登録します。
@@ -171,9 +214,13 @@ func (h *Handler) GetCrop(c echo.Context) error {
 //   - notes: メモ（任意）
 //
 // レスポンス:
-//   - 201: 登録された作物
-//   - 400: バリデーションエラー
+//   - 201: 登録された作物（X-Org-ID を指定した場合は組織の作物）
+//   - 400: リクエストボディの形式が不正
 //   - 401: 認証エラー
+//   - 403: 組織の作物数が上限（ORGANIZATION_QUOTA_EXCEEDED）
+//   - 404: 指定した区画が見つからない（PLOT_NOT_FOUND）
+//   - 409: 指定した区画に別の作物が配置されている（PLOT_OCCUPIED）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) CreateCrop(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -192,7 +239,14 @@ func (h *Handler) CreateCrop(c echo.Context) error {
 
 	// 日付バリデーション: plantedDate <= expectedHarvestDate
 	if req.PlantedDate.After(req.ExpectedHarvestDate) {
-		return apperrors.NewBadRequestError("planted_date must be before or equal to expected_harvest_date")
+		return apperrors.New(apperrors.ErrCodeInvalidDateRange, "planted_date must be before or equal to expected_harvest_date")
+	}
+
+	// 区画が指定された場合は別の作物が配置されていないかチェック
+	if req.PlotID != nil {
+		if err := h.checkPlotAvailable(ctx, *req.PlotID, 0); err != nil {
+			return err
+		}
 	}
 
 	// 作物モデルを作成
@@ -209,10 +263,30 @@ func (h *Handler) CreateCrop(c echo.Context) error {
 
 	// DBに保存
 	if err := h.service.CreateCrop(ctx, crop); err != nil {
-		return apperrors.NewInternalError("Failed to create crop")
+		return organizationError(err, "Failed to create crop")
 	}
 
-	return c.JSON(http.StatusCreated, crop)
+	return c.JSON(http.StatusCreated, dto.NewCropResponse(crop))
+}
+
+// checkPlotAvailable は作物を区画に配置できるか（別の作物が配置されていないか）をチェックします。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - plotID: 区画ID
+//   - cropID: 配置する作物ID（新規登録の場合は 0）
+//
+// 戻り値:
+//   - error: 区画が見つからない場合は PLOT_NOT_FOUND、別の作物が配置されている場合は PLOT_OCCUPIED
+func (h *Handler) checkPlotAvailable(ctx context.Context, plotID, cropID uint) error {
+	if _, err := h.service.GetPlotByID(ctx, plotID); err != nil {
+		return apperrors.NewNotFoundError("Plot")
+	}
+	assignment, err := h.service.GetActivePlotAssignment(ctx, plotID)
+	if err == nil && assignment != nil && assignment.CropID != cropID {
+		return apperrors.New(apperrors.ErrCodePlotOccupied, "Plot is already occupied by another crop")
+	}
+	return nil
 }
 
 // UpdateCrop は既存の作物を更新します。
@@ -220,12 +294,18 @@ func (h *Handler) CreateCrop(c echo.Context) error {
 // パスパラメータ:
 //   - id: 作物ID
 //
-// リクエストボディ: 更新するフィールド（任意）
+// リクエストヘッダー:
+//   - If-Match: 取得した作物の version（"3" の形式、ボディの version を指定しない場合は必須）
+//
+// リクエストボディ: 更新するフィールド（任意）と取得した作物の version
 //
 // レスポンス:
-//   - 200: 更新された作物
-//   - 400: バリデーションエラー
-//   - 404: 作物が見つからない
+//   - 200: 更新された作物（ETag は更新後の version）
+//   - 400: リクエストボディ・If-Match の形式が不正
+//   - 404: 作物・指定した区画が見つからない（CROP_NOT_FOUND / PLOT_NOT_FOUND）
+//   - 409: 指定した区画に別の作物が配置されている（PLOT_OCCUPIED）、取得した後に他のリクエストで更新されている（VERSION_CONFLICT、errors.current に現在の作物）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 428: If-Match・version を指定していない（PRECONDITION_REQUIRED）
 //   - 500: 内部エラー
 func (h *Handler) UpdateCrop(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -241,12 +321,20 @@ func (h *Handler) UpdateCrop(c echo.Context) error {
 	if err := validator.BindAndValidate(c, &req); err != nil {
 		return err
 	}
+	version, err := requiredVersion(c, req.Version)
+	if err != nil {
+		return err
+	}
 
 	// 既存の作物を取得
 	crop, err := h.service.GetCropByID(ctx, uint(id))
 	if err != nil {
 		return apperrors.NewNotFoundError("Crop")
 	}
+	if crop.Version != version {
+		// 取得した後に他のリクエストで更新されている（楽観的ロック）
+		return apperrors.NewVersionConflictError("Crop", dto.NewCropResponse(crop))
+	}
 
 	// リクエストで指定されたフィールドのみ更新
 	if req.Name != "" {
@@ -265,6 +353,9 @@ func (h *Handler) UpdateCrop(c echo.Context) error {
 		crop.Status = req.Status
 	}
 	if req.PlotID != nil {
+		if err := h.checkPlotAvailable(ctx, *req.PlotID, crop.ID); err != nil {
+			return err
+		}
 		crop.PlotID = req.PlotID
 	}
 	if req.Notes != "" {
@@ -273,15 +364,22 @@ func (h *Handler) UpdateCrop(c echo.Context) error {
 
 	// 日付バリデーション: plantedDate <= expectedHarvestDate
 	if crop.PlantedDate.After(crop.ExpectedHarvestDate) {
-		return apperrors.NewBadRequestError("planted_date must be before or equal to expected_harvest_date")
+		return apperrors.New(apperrors.ErrCodeInvalidDateRange, "planted_date must be before or equal to expected_harvest_date")
 	}
 
 	// DBを更新
 	if err := h.service.UpdateCrop(ctx, crop); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			// 確認した後、保存するまでの間に他のリクエストで更新された
+			if current, getErr := h.service.GetCropByID(ctx, uint(id)); getErr == nil {
+				return apperrors.NewVersionConflictError("Crop", dto.NewCropResponse(current))
+			}
+		}
 		return apperrors.NewInternalError("Failed to update crop")
 	}
 
-	return c.JSON(http.StatusOK, crop)
+	c.Response().Header().Set(headerETag, versionETag(crop.Version))
+	return c.JSON(http.StatusOK, dto.NewCropResponse(crop))
 }
 
 // DeleteCrop は作物を削除します（論理削除）。
@@ -339,7 +437,7 @@ func (h *Handler) GetGrowthRecords(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch growth records")
 	}
 
-	return c.JSON(http.StatusOK, records)
+	return c.JSON(http.StatusOK, dto.List(records, dto.NewGrowthRecordResponse))
 }
 
 // CreateGrowthRecord は新しい成長記録を追加します。
@@ -355,7 +453,8 @@ func (h *Handler) GetGrowthRecords(c echo.Context) error {
 //
 // レスポンス:
 //   - 201: 追加された成長記録
-//   - 400: バリデーションエラー
+//   - 400: リクエストボディの形式が不正
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) CreateGrowthRecord(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -386,7 +485,7 @@ func (h *Handler) CreateGrowthRecord(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to create growth record")
 	}
 
-	return c.JSON(http.StatusCreated, record)
+	return c.JSON(http.StatusCreated, dto.NewGrowthRecordResponse(record))
 }
 
 // =============================================================================
@@ -398,9 +497,16 @@ func (h *Handler) CreateGrowthRecord(c echo.Context) error {
 // パスパラメータ:
 //   - id: 作物ID
 //
+// リクエストヘッダー:
+//   - Accept: text/csv の場合はエクスポートと同じ列のCSV（ページングした場合は X-Next-Cursor に次のページのカーソル）
+//
+// クエリパラメータ:
+//   - limit: 1ページの件数（指定した場合は1ページ分を返す、最大100）
+//   - cursor: 前のページの next_cursor
+//
 // レスポンス:
-//   - 200: 収穫記録の配列
-//   - 400: 無効なID形式
+//   - 200: 収穫記録の配列（収穫日の新しい順）、limit・cursor を指定した場合は1ページ分（items, next_cursor, has_more, limit、新しい順）
+//   - 400: 無効なID形式、不正な limit・cursor
 //   - 500: 内部エラー
 func (h *Handler) GetHarvests(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -411,13 +517,36 @@ func (h *Handler) GetHarvests(c echo.Context) error {
 		return apperrors.NewBadRequestError("Invalid crop ID")
 	}
 
+	csv := negotiateCSV(c)
+
+	// limit・cursorを指定した場合は1ページ分を返す
+	params, paginated, err := paginationParams(c)
+	if err != nil {
+		return err
+	}
+	if paginated {
+		page, err := h.service.GetCropHarvestsPage(ctx, uint(cropID), params)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to fetch harvests")
+		}
+		if csv {
+			result, err := h.service.HarvestsCSV(ctx, page.Items)
+			return respondListCSV(c, result, err, page.NextCursor)
+		}
+		return c.JSON(http.StatusOK, dto.Page(page, dto.NewHarvestResponse))
+	}
+
 	// 収穫記録を取得
 	harvests, err := h.service.GetCropHarvests(ctx, uint(cropID))
 	if err != nil {
 		return apperrors.NewInternalError("Failed to fetch harvests")
 	}
+	if csv {
+		result, err := h.service.HarvestsCSV(ctx, harvests)
+		return respondListCSV(c, result, err, "")
+	}
 
-	return c.JSON(http.StatusOK, harvests)
+	return c.JSON(http.StatusOK, dto.List(harvests, dto.NewHarvestResponse))
 }
 
 // CreateHarvest は新しい収穫記録を追加します。
@@ -434,7 +563,8 @@ func (h *Handler) GetHarvests(c echo.Context) error {
 //
 // レスポンス:
 //   - 201: 追加された収穫記録
-//   - 400: バリデーションエラー
+//   - 400: リクエストボディの形式が不正
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) CreateHarvest(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -466,7 +596,7 @@ func (h *Handler) CreateHarvest(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to create harvest")
 	}
 
-	return c.JSON(http.StatusCreated, harvest)
+	return c.JSON(http.StatusCreated, dto.NewHarvestResponse(harvest))
 }
 
 // =============================================================================
@@ -481,22 +611,24 @@ type GenerateImageUploadURLRequest struct {
 // GenerateImageUploadURLResponse はPresigned URL生成レスポンスの構造体です。
 type GenerateImageUploadURLResponse struct {
 	UploadURL  string    `json:"upload_url"`   // アップロード用Presigned URL
-	ObjectKey  string    `json:"object_key"`   // S3オブジェクトキー
+	ObjectKey  string    `json:"object_key"`   // オブジェクトキー
 	ContentURL string    `json:"content_url"`  // アップロード後の画像URL（CloudFront経由）
 	ExpiresAt  time.Time `json:"expires_at"`   // URLの有効期限
 }
 
-// GenerateImageUploadURL はS3 Presigned URLを生成します。
-// クライアントはこのURLを使用して直接S3に画像をアップロードできます。
+// GenerateImageUploadURL は画像のアップロード用のPresigned URLを生成します。
+// クライアントはこのURLを使用して直接保存先（S3・GCS・ローカル）に画像をアップロードできます。
 //
 // リクエストボディ:
 //   - content_type: 画像のMIMEタイプ（image/jpeg, image/png, image/webp）
 //
 // レスポンス:
 //   - 200: Presigned URL情報
-//   - 400: バリデーションエラー
+//   - 400: リクエストボディの形式が不正
 //   - 401: 認証エラー
-//   - 503: S3未設定エラー
+//   - 403: 保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
 func (h *Handler) GenerateImageUploadURL(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -512,19 +644,24 @@ func (h *Handler) GenerateImageUploadURL(c echo.Context) error {
 		return err
 	}
 
-	// S3サービスがnilかチェック（HandlerにS3Serviceを追加する必要あり）
-	if h.s3Service == nil {
+	// 保存先が設定されているかチェック
+	if h.fileStorage == nil {
 		return apperrors.NewServiceUnavailableError("Image upload service is not available")
 	}
 
+	// 保存容量の上限を確認（アップロードするサイズは不明のため画像の上限で確認）
+	if err := h.service.CheckStorageQuota(ctx, userID, storage.MaxImageSize); err != nil {
+		return uploadError(err, "Failed to check storage quota")
+	}
+
 	// Presigned URLを生成
-	result, err := h.s3Service.GenerateUploadURL(ctx, userID, req.ContentType)
+	result, err := h.fileStorage.GenerateUploadURL(ctx, userID, req.ContentType)
 	if err != nil {
-		if err == storage.ErrS3NotConfigured {
+		if err == storage.ErrStorageNotConfigured {
 			return apperrors.NewServiceUnavailableError("Image upload service is not configured")
 		}
 		if err == storage.ErrInvalidImageType {
-			return apperrors.NewBadRequestError("Invalid image type: only JPEG, PNG, and WEBP are allowed")
+			return apperrors.New(apperrors.ErrCodeUnsupportedImageType, "Invalid image type: only JPEG, PNG, and WEBP are allowed")
 		}
 		return apperrors.NewInternalError("Failed to generate upload URL")
 	}
@@ -539,22 +676,26 @@ func (h *Handler) GenerateImageUploadURL(c echo.Context) error {
 
 // UploadImageResponse は画像アップロードレスポンスの構造体です。
 type UploadImageResponse struct {
-	ObjectKey  string `json:"object_key"`  // S3オブジェクトキー
+	ObjectKey  string `json:"object_key"`  // オブジェクトキー
 	ContentURL string `json:"content_url"` // 画像URL（CloudFront経由）
 	Size       int64  `json:"size"`        // ファイルサイズ（バイト）
 }
 
-// UploadImage はサーバー経由で画像をS3にアップロードします。
+// UploadImage はサーバー経由で画像を保存先にアップロードします。
 // multipart/form-data形式でファイルを受け取ります。
+// リクエストボディはストリームとして読み込み、メモリに保持するのは画像の上限（5MB）までです
+// （フォームを一時ファイル・メモリに展開しない）。
 //
 // リクエスト:
 //   - image: 画像ファイル（multipart/form-data）
 //
 // レスポンス:
 //   - 201: アップロード成功
-//   - 400: バリデーションエラー（サイズ超過、形式不正）
+//   - 400: バリデーションエラー（image がない、形式不正）
 //   - 401: 認証エラー
-//   - 503: S3未設定エラー
+//   - 403: 保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 413: サイズ超過（画像が 5MB を超える場合は IMAGE_TOO_LARGE、リクエストボディ全体が上限を超える場合は PAYLOAD_TOO_LARGE）
+//   - 503: 保存先の未設定エラー
 //
 // 制限:
 //   - 最大ファイルサイズ: 5MB
@@ -568,70 +709,56 @@ func (h *Handler) UploadImage(c echo.Context) error {
 		return apperrors.NewAuthenticationError("Not authenticated")
 	}
 
-	// S3サービスがnilかチェック
-	if h.s3Service == nil {
+	// 保存先が設定されているかチェック
+	if h.fileStorage == nil {
 		return apperrors.NewServiceUnavailableError("Image upload service is not available")
 	}
 
-	// multipart/form-dataからファイルを取得
-	file, err := c.FormFile("image")
+	// multipart/form-dataから image のパートを取得（前のパートは読み飛ばす）
+	part, err := imageFormPart(c.Request())
 	if err != nil {
 		return apperrors.NewBadRequestError("Image file is required")
 	}
+	defer part.Close()
 
-	// ファイルサイズをチェック
-	if file.Size > storage.MaxImageSize {
-		return apperrors.NewBadRequestError("File size exceeds maximum allowed size (5MB)")
-	}
-
-	// ファイルを開く
-	src, err := file.Open()
-	if err != nil {
-		return apperrors.NewInternalError("Failed to read uploaded file")
-	}
-	defer src.Close()
-
-	// ファイル内容を読み込んでMIMEタイプを検証
 	// 先頭512バイトでMIMEタイプを判定
-	buf := make([]byte, 512)
-	n, err := src.Read(buf)
-	if err != nil && err != io.EOF {
-		return apperrors.NewInternalError("Failed to read file content")
+	head := make([]byte, 512)
+	n, err := io.ReadFull(part, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return apperrors.NewBadRequestError("Failed to read uploaded file")
 	}
 
-	contentType, err := storage.ValidateImageFile(buf[:n], file.Size)
+	contentType, err := storage.ValidateImageFile(head[:n], int64(n))
 	if err != nil {
-		if err == storage.ErrFileTooLarge {
-			return apperrors.NewBadRequestError("File size exceeds maximum allowed size (5MB)")
-		}
 		if err == storage.ErrInvalidImageType {
-			return apperrors.NewBadRequestError("Invalid image type: only JPEG, PNG, and WEBP are allowed")
+			return apperrors.New(apperrors.ErrCodeUnsupportedImageType, "Invalid image type: only JPEG, PNG, and WEBP are allowed")
 		}
 		return apperrors.NewInternalError("Failed to validate image")
 	}
 
-	// ファイルポインタを先頭に戻す
-	if _, err := src.Seek(0, io.SeekStart); err != nil {
-		return apperrors.NewInternalError("Failed to process file")
-	}
-
-	// 全内容を読み込む（S3アップロード用）
-	content, err := io.ReadAll(src)
+	// 残りを上限 + 1 バイトまで読み込む（上限を超えた時点で読み込みをやめる）
+	content, err := io.ReadAll(io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), part), storage.MaxImageSize+1))
 	if err != nil {
-		return apperrors.NewInternalError("Failed to read file content")
+		return apperrors.NewBadRequestError("Failed to read uploaded file")
+	}
+	if len(content) > storage.MaxImageSize {
+		return imageTooLargeError()
+	}
+	if err := h.service.CheckStorageQuota(ctx, userID, int64(len(content))); err != nil {
+		return uploadError(err, "Failed to check storage quota")
 	}
 
-	// S3にアップロード（Exponential backoffリトライ付き）
-	result, err := h.s3Service.UploadImage(ctx, userID, bytes.NewReader(content), contentType, file.Size)
+	// 保存先にアップロード（Exponential backoffリトライ付き）
+	result, err := h.fileStorage.UploadImage(ctx, userID, bytes.NewReader(content), contentType, int64(len(content)))
 	if err != nil {
-		if err == storage.ErrS3NotConfigured {
+		if err == storage.ErrStorageNotConfigured {
 			return apperrors.NewServiceUnavailableError("Image upload service is not configured")
 		}
 		if err == storage.ErrFileTooLarge {
-			return apperrors.NewBadRequestError("File size exceeds maximum allowed size (5MB)")
+			return imageTooLargeError()
 		}
 		if err == storage.ErrInvalidImageType {
-			return apperrors.NewBadRequestError("Invalid image type: only JPEG, PNG, and WEBP are allowed")
+			return apperrors.New(apperrors.ErrCodeUnsupportedImageType, "Invalid image type: only JPEG, PNG, and WEBP are allowed")
 		}
 		return apperrors.NewInternalError("Failed to upload image")
 	}
@@ -642,3 +769,27 @@ func (h *Handler) UploadImage(c echo.Context) error {
 		Size:       result.Size,
 	})
 }
+
+// imageFormPart は multipart/form-data のリクエストボディから image のパートを返します。
+// パートは読み込み途中のストリームのため、呼び出し側で読み込んでから閉じます。
+func imageFormPart(req *http.Request) (*multipart.Part, error) {
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "image" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// imageTooLargeError は画像が上限（5MB）を超えた場合のエラーです。
+func imageTooLargeError() error {
+	return apperrors.NewPayloadTooLargeError(apperrors.ErrCodeImageTooLarge, "File size exceeds maximum allowed size (5MB)", storage.MaxImageSize)
+}
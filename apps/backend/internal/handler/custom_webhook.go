@@ -0,0 +1,75 @@
+// Package handler - Custom Webhook Handler
+//
+// 汎用Webhook通知の署名用シークレットのHTTPハンドラを提供します。
+// 汎用WebhookのURLと有効/無効は通知設定（PUT /api/v1/users/settings/notifications）で変更します。
+// エンドポイント:
+//   - GET /api/v1/users/me/webhook-secret - 署名用シークレットの取得
+//   - POST /api/v1/users/me/webhook-secret/rotate - 署名用シークレットの再発行
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// CustomWebhookSecretResponse は汎用Webhookの署名用シークレットのレスポンスです。
+type CustomWebhookSecretResponse struct {
+	Secret          string `json:"secret,omitempty"` // 未発行の場合は省略
+	SignatureHeader string `json:"signature_header"` // 署名のヘッダー名
+	TimestampHeader string `json:"timestamp_header"` // 署名に使用した送信時刻のヘッダー名
+}
+
+// newCustomWebhookSecretResponse は署名用シークレットのレスポンスを作成します。
+func newCustomWebhookSecretResponse(secret string) CustomWebhookSecretResponse {
+	return CustomWebhookSecretResponse{
+		Secret:          secret,
+		SignatureHeader: service.CustomWebhookSignatureHeader,
+		TimestampHeader: service.CustomWebhookTimestampHeader,
+	}
+}
+
+// GetCustomWebhookSecret は汎用Webhookの署名用シークレットを取得します。
+// シークレットは汎用Webhookを初めて有効にしたときに発行されます。
+//
+// レスポンス:
+//   - 200: CustomWebhookSecretResponse
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetCustomWebhookSecret(c echo.Context) error {
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	secret, err := h.service.GetCustomWebhookSecret(c.Request().Context(), userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get webhook secret")
+	}
+
+	return c.JSON(http.StatusOK, newCustomWebhookSecretResponse(secret))
+}
+
+// RotateCustomWebhookSecret は汎用Webhookの署名用シークレットを再発行します。
+// 古いシークレットは直ちに無効になるため、受信側の設定も更新してください。
+//
+// レスポンス:
+//   - 200: CustomWebhookSecretResponse（新しいシークレット）
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) RotateCustomWebhookSecret(c echo.Context) error {
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	secret, err := h.service.RotateCustomWebhookSecret(c.Request().Context(), userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to rotate webhook secret")
+	}
+
+	return c.JSON(http.StatusOK, newCustomWebhookSecretResponse(secret))
+}
@@ -0,0 +1,98 @@
+// Package handler - Data Retention Handler
+//
+// 保持期間を過ぎたデータの削除のHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST /api/v1/scheduler/retention/purge - 保持期間を過ぎたデータの削除（EventBridge Scheduler 用、dry_run で件数のみ）
+//   - GET /api/v1/admin/retention/report - 削除対象の件数（dry run）
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// PurgeExpiredDataResponse は保持期間を過ぎたデータの削除処理のレスポンスです。
+type PurgeExpiredDataResponse struct {
+	Success bool                     `json:"success"`
+	Report  *service.RetentionReport `json:"report,omitempty"`
+	Message string                   `json:"message,omitempty"`
+}
+
+// PurgeExpiredData は保持期間を過ぎたデータ（論理削除した行・通知ログ・エクスポートファイル）を削除します。
+// AWS EventBridge Scheduler から毎日呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/retention/purge
+//
+// クエリパラメータ:
+//   - dry_run: true の場合は削除せずに件数のみ報告（省略時は RETENTION_DRY_RUN の設定）
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "report": {"dry_run": false, "targets": [{"target": "crops", "retention_days": 30, "matched": 3, "purged": 3}]},
+//	  "message": "保持期間を過ぎたデータを削除しました"
+//	}
+//
+// 一部の対象の削除に失敗した場合は 207 Multi-Status を返します。
+func (h *SchedulerHandler) PurgeExpiredData(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var report *service.RetentionReport
+	var err error
+	if dryRun := c.QueryParam("dry_run"); dryRun != "" {
+		parsed, parseErr := strconv.ParseBool(dryRun)
+		if parseErr != nil {
+			return c.JSON(http.StatusBadRequest, PurgeExpiredDataResponse{
+				Success: false,
+				Message: "dry_run は true または false で指定してください",
+			})
+		}
+		report, err = h.service.PurgeExpiredData(ctx, parsed)
+	} else {
+		report, err = h.service.RunScheduledDataRetention(ctx)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, PurgeExpiredDataResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	status := http.StatusOK
+	message := "保持期間を過ぎたデータを削除しました"
+	if report.DryRun {
+		message = "削除対象の件数を集計しました（dry run）"
+	}
+	if len(report.Errors) > 0 {
+		status = http.StatusMultiStatus
+		message = "一部の対象の削除に失敗しました"
+	}
+
+	return c.JSON(status, PurgeExpiredDataResponse{
+		Success: len(report.Errors) == 0,
+		Report:  report,
+		Message: message,
+	})
+}
+
+// GetRetentionReport は保持期間を過ぎたデータの件数を削除せずに集計します（dry run）。
+// 保持期間（RETENTION_*_DAYS）を変更する前の確認に使用します。
+//
+// レスポンス:
+//   - 200: RetentionReport オブジェクト（dry_run: true、purged は常に 0）
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetRetentionReport(c echo.Context) error {
+	report, err := h.service.PurgeExpiredData(c.Request().Context(), true)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get retention report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
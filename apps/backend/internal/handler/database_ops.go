@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/database"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// データベースの運用ダッシュボード
+// =============================================================================
+
+// DatabaseMonitor は接続プールの統計と遅いクエリを提供します（*database.DB）。
+type DatabaseMonitor interface {
+	PoolStats() []database.PoolStats
+	SlowQueries() *repository.SlowQueryLog
+	SlowQueryThreshold() time.Duration
+	WritePrometheus(w io.Writer) error
+}
+
+// DatabaseOpsResponse は運用ダッシュボードのデータベースの状態です。
+type DatabaseOpsResponse struct {
+	Pools       []database.PoolStats `json:"pools"`
+	SlowQueries SlowQueriesResponse  `json:"slow_queries"`
+}
+
+// SlowQueriesResponse は閾値を超えたクエリの件数と直近の記録です。
+type SlowQueriesResponse struct {
+	ThresholdMs int64                  `json:"threshold_ms"` // 0 の場合は記録しない（DB_SLOW_QUERY_THRESHOLD_MS）
+	Total       uint64                 `json:"total"`        // プロセス起動時からの件数
+	Recent      []repository.SlowQuery `json:"recent"`       // 新しい順
+}
+
+// SetDatabaseMonitor は運用ダッシュボードと /metrics で公開するデータベースを設定します。
+// 設定しない場合（スタンドアロンモード）、GET /admin/database は 503 を返し、/metrics は通知のカウンターのみです。
+func (h *Handler) SetDatabaseMonitor(monitor DatabaseMonitor) {
+	h.dbMonitor = monitor
+}
+
+// GetDatabaseOps は接続プールの統計と直近の遅いクエリを取得します。
+// 遅いクエリの SQL はプレースホルダーのままで、パラメータの値は含みません。
+//
+// レスポンス:
+//   - 200: DatabaseOpsResponse オブジェクト
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 503: データベースに接続していない（スタンドアロンモード）
+func (h *Handler) GetDatabaseOps(c echo.Context) error {
+	if h.dbMonitor == nil {
+		return apperrors.NewServiceUnavailableError("Database monitoring is not available")
+	}
+
+	slowQueries := h.dbMonitor.SlowQueries()
+	return c.JSON(http.StatusOK, DatabaseOpsResponse{
+		Pools: h.dbMonitor.PoolStats(),
+		SlowQueries: SlowQueriesResponse{
+			ThresholdMs: h.dbMonitor.SlowQueryThreshold().Milliseconds(),
+			Total:       slowQueries.Total(),
+			Recent:      slowQueries.Recent(),
+		},
+	})
+}
+
+// CreateDatabaseBackup はデータベースのバックアップの作成をジョブキューに登録します。
+// バックアップは保存先（S3・GCS・ローカル）に保存され、リストアは管理CLI（admin backup restore）で実行します。
+//
+// レスポンス:
+//   - 202: 登録したバックアップの履歴（status: pending）
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+//   - 503: ジョブキューまたは保存先が未設定・利用不可
+func (h *Handler) CreateDatabaseBackup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	backup, err := h.service.RequestDatabaseBackup(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBackupNotConfigured):
+			return apperrors.NewServiceUnavailableError("Database backup is not configured")
+		case errors.Is(err, service.ErrJobQueueUnavailable):
+			return apperrors.NewServiceUnavailableError("Backup queue is unavailable")
+		}
+		return apperrors.NewInternalError("Failed to request database backup")
+	}
+
+	return c.JSON(http.StatusAccepted, backup)
+}
+
+// GetDatabaseBackups はデータベースのバックアップの履歴を新しい順に1ページずつ返します。
+//
+// クエリパラメータ:
+//   - limit: 1ページの件数（省略した場合は既定の件数）
+//   - cursor: 前のページの next_cursor
+//
+// レスポンス:
+//   - 200: バックアップの履歴の1ページ分（trigger, status, s3_key, row_count など）
+//   - 400: 不正な limit・cursor
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetDatabaseBackups(c echo.Context) error {
+	// limit・cursorを省略した場合は最初のページを返す
+	params, paginated, err := paginationParams(c)
+	if err != nil {
+		return err
+	}
+	if !paginated {
+		params = pagination.Params{}.Normalize()
+	}
+
+	page, err := h.service.GetDatabaseBackupsPage(c.Request().Context(), params)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to fetch database backups")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/database"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Database Ops Tests - データベースの運用ダッシュボードのテスト
+// =============================================================================
+// テスト対象:
+//   - GetDatabaseOps: 接続プールの統計と直近の遅いクエリ、データベースがない場合の 503
+//   - GetMetrics: 通知のカウンターと接続プールのメトリクス
+
+// fakeDatabaseMonitor は固定の統計を返す DatabaseMonitor です。
+type fakeDatabaseMonitor struct {
+	slowQueries *repository.SlowQueryLog
+}
+
+func (m *fakeDatabaseMonitor) PoolStats() []database.PoolStats {
+	return []database.PoolStats{{Connection: "primary", MaxOpenConnections: 100, OpenConnections: 3, InUse: 1, Idle: 2}}
+}
+
+func (m *fakeDatabaseMonitor) SlowQueries() *repository.SlowQueryLog { return m.slowQueries }
+
+func (m *fakeDatabaseMonitor) SlowQueryThreshold() time.Duration { return 500 * time.Millisecond }
+
+func (m *fakeDatabaseMonitor) WritePrometheus(w io.Writer) error {
+	_, err := fmt.Fprintln(w, `db_pool_in_use_connections{connection="primary"} 1`)
+	return err
+}
+
+// serveOps はハンドラーを実行し、レスポンスを返します。
+func serveOps(h *Handler, handle echo.HandlerFunc, path string) (*httptest.ResponseRecorder, error) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, path, nil), rec)
+	return rec, handle(c)
+}
+
+// TestGetDatabaseOps は運用ダッシュボードのテストです。
+// 期待動作:
+//   - 接続プールの統計、遅いクエリの閾値・件数・直近の記録を返す
+//   - SetDatabaseMonitor を設定していない場合は 503
+func TestGetDatabaseOps(t *testing.T) {
+	// Arrange
+	slowQueries := repository.NewSlowQueryLog(repository.DefaultSlowQueryLogSize)
+	slowQueries.Record(repository.SlowQuery{SQL: "SELECT * FROM harvests WHERE user_id = $1", Route: "GET /api/v1/analytics/harvests", DurationMs: 812})
+	h := NewHandler(service.NewService(repository.NewMockRepositories()), nil, nil)
+	standalone := NewHandler(service.NewService(repository.NewMockRepositories()), nil, nil)
+	h.SetDatabaseMonitor(&fakeDatabaseMonitor{slowQueries: slowQueries})
+
+	// Act
+	rec, err := serveOps(h, h.GetDatabaseOps, "/api/v1/admin/database")
+	_, standaloneErr := serveOps(standalone, standalone.GetDatabaseOps, "/api/v1/admin/database")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetDatabaseOps failed: %v", err)
+	}
+	var resp DatabaseOpsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if len(resp.Pools) != 1 || resp.Pools[0].Connection != "primary" || resp.Pools[0].InUse != 1 {
+		t.Errorf("Unexpected pools %+v", resp.Pools)
+	}
+	if resp.SlowQueries.ThresholdMs != 500 || resp.SlowQueries.Total != 1 || len(resp.SlowQueries.Recent) != 1 ||
+		resp.SlowQueries.Recent[0].Route != "GET /api/v1/analytics/harvests" {
+		t.Errorf("Unexpected slow queries %+v", resp.SlowQueries)
+	}
+	var appErr *apperrors.AppError
+	if !errors.As(standaloneErr, &appErr) || appErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a database, got %v", standaloneErr)
+	}
+}
+
+// TestGetMetrics_Database は /metrics のデータベースのメトリクスのテストです。
+// 期待動作:
+//   - SetDatabaseMonitor を設定した場合は通知のカウンターに続けて接続プールのメトリクスを書き出す
+func TestGetMetrics_Database(t *testing.T) {
+	// Arrange
+	h := NewHandler(service.NewService(repository.NewMockRepositories()), nil, nil)
+	h.SetDatabaseMonitor(&fakeDatabaseMonitor{slowQueries: repository.NewSlowQueryLog(0)})
+
+	// Act
+	rec, err := serveOps(h, h.GetMetrics, "/metrics")
+
+	// Assert
+	body := rec.Body.String()
+	if err != nil || !strings.Contains(body, "notification_deliveries_total") ||
+		!strings.Contains(body, `db_pool_in_use_connections{connection="primary"} 1`) {
+		t.Errorf("Expected notification and pool metrics, got %q (%v)", body, err)
+	}
+}
@@ -0,0 +1,56 @@
+// Package handler - Error Catalog Handler
+//
+// エラーレスポンスのエラーコード一覧を提供します（認証不要）。
+// エラーレスポンス（application/problem+json）の type はこの一覧の項目を指します。
+// エンドポイント:
+//   - GET /api/v1/errors       - エラーコード一覧
+//   - GET /api/v1/errors/:code - エラーコードの説明
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+)
+
+// ErrorCatalogResponse はエラーコード一覧のレスポンスです。
+type ErrorCatalogResponse struct {
+	Errors []apperrors.CatalogEntry `json:"errors"`
+}
+
+// GetErrorCatalog はエラーレスポンスのエラーコード一覧を返します。
+// クライアントは code で分岐し、title・description を表示やログに使用できます。
+//
+// レスポンス:
+//
+//	{
+//	  "errors": [
+//	    {
+//	      "code": "CROP_NOT_FOUND",
+//	      "type": "/api/v1/errors/CROP_NOT_FOUND",
+//	      "status": 404,
+//	      "title": "Crop not found",
+//	      "description": "作物が見つからないか、削除されています。"
+//	    }
+//	  ]
+//	}
+func (h *Handler) GetErrorCatalog(c echo.Context) error {
+	return c.JSON(http.StatusOK, ErrorCatalogResponse{Errors: apperrors.Catalog()})
+}
+
+// GetErrorCatalogEntry はエラーコードの説明を返します（エラーレスポンスの type の参照先）。
+//
+// パスパラメータ:
+//   - code: エラーコード（CROP_NOT_FOUND など）
+//
+// レスポンス:
+//   - 200: エラーコードの説明（code, type, status, title, description）
+//   - 404: 一覧にないエラーコード
+func (h *Handler) GetErrorCatalogEntry(c echo.Context) error {
+	entry, ok := apperrors.LookupCode(c.Param("code"))
+	if !ok {
+		return apperrors.NewNotFoundError("Error code")
+	}
+	return c.JSON(http.StatusOK, entry)
+}
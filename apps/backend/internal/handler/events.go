@@ -0,0 +1,122 @@
+// Package handler - Events Handler
+//
+// 記録の変更をライブで通知する Server-Sent Events のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/events - 認証ユーザーの記録の変更のイベントのストリーム（text/event-stream）
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/events"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Server-Sent Events - 記録の変更のストリーム
+// =============================================================================
+// イベントは SSE の形式（id・event・data）で送信します。data はイベントの JSON です。
+//
+//	id: 42
+//	event: task.completed
+//	data: {"id":42,"type":"task.completed","entity":"tasks","entity_id":7,"data":{...},"occurred_at":"..."}
+//
+// 接続が切れた場合、クライアントは Last-Event-ID ヘッダー（または last_event_id クエリパラメータ）に
+// 最後に受信した id を指定して再接続すると、サーバーが保持している直近のイベントを再送します。
+
+const (
+	// EventsHeartbeatInterval はイベントがない場合に接続を維持するためのコメントを送る間隔です。
+	EventsHeartbeatInterval = 25 * time.Second
+	// EventsRetry はクライアントが切断後に再接続するまでの待機時間です（SSE の retry）。
+	EventsRetry = 3 * time.Second
+)
+
+// StreamEvents は認証ユーザーの記録の変更のイベントを Server-Sent Events で送信します。
+// タスクの完了（task.completed）・収穫記録の追加（harvest.added）・通知の受信（notification.received）を
+// 発生した順に送信し、クライアントが切断するまで接続を維持します。
+//
+// リクエストヘッダー:
+//   - Last-Event-ID: 再接続時に前回最後に受信したイベントのID（以降の直近のイベントを再送）
+//
+// クエリパラメータ:
+//   - last_event_id: Last-Event-ID ヘッダーを指定できないクライアント向けの代替
+//
+// レスポンス:
+//   - 200: イベントのストリーム（text/event-stream）
+//   - 401: 認証エラー
+//   - 503: イベントの配信が無効
+func (h *Handler) StreamEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+	// 読み取れない ID は再送しない（新しい接続として扱う）
+	lastID, _ := strconv.ParseUint(lastEventID, 10, 64)
+
+	sub, err := h.service.SubscribeEvents(userID, lastID)
+	if err != nil {
+		if errors.Is(err, service.ErrEventsUnavailable) {
+			return apperrors.NewServiceUnavailableError("Live events are not available")
+		}
+		return apperrors.NewInternalError("Failed to subscribe to events")
+	}
+	defer sub.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // リバースプロキシのバッファリングを無効化
+	res.WriteHeader(http.StatusOK)
+	fmt.Fprintf(res, "retry: %d\n\n", EventsRetry.Milliseconds())
+	res.Flush()
+
+	heartbeat := time.NewTicker(EventsHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				// 受信が遅い・サーバーの停止で購読が閉じられた（クライアントは Last-Event-ID で再接続する）
+				return nil
+			}
+			if err := writeSSEEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeSSEEvent はイベントを SSE の形式で書き込みます。
+func writeSSEEvent(w http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
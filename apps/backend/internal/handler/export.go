@@ -0,0 +1,290 @@
+// Package handler - Export Handler
+//
+// エクスポート履歴と再ダウンロードのHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST /api/v1/exports             - エクスポートの生成をジョブキューに登録（非同期、全データは写真の画像も含められる）
+//   - GET /api/v1/exports              - エクスポート履歴一覧
+//   - GET /api/v1/exports/estimate     - 写真を含むエクスポートの大きさ・ZIPの数の見積もり
+//   - GET /api/v1/exports/:id/download - 過去のエクスポートの再ダウンロードURL取得
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// ExportRecordResponse はエクスポート履歴のレスポンスです。
+type ExportRecordResponse struct {
+	ID            uint      `json:"id"`
+	DataType      string    `json:"data_type"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	RecordCount   int       `json:"record_count"`
+	FileSizeBytes int64     `json:"file_size_bytes"`
+	Anonymized    bool      `json:"anonymized"`   // 個人情報を除去したエクスポートか
+	Downloadable  bool      `json:"downloadable"` // 保存先に保存済みで再ダウンロード可能か
+	Status        string    `json:"status"`       // pending, completed, failed
+	ErrorMessage  string    `json:"error_message,omitempty"`
+	IncludePhotos bool      `json:"include_photos"`      // 写真の画像を含むか
+	PartNumber    int       `json:"part_number"`         // 分割したZIPの番号（1から）
+	PartCount     int       `json:"part_count"`          // 分割したZIPの数
+	ParentID      *uint     `json:"parent_id,omitempty"` // 分割したZIPの最初のエクスポート履歴（2番目以降のZIP）
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// validExportDataTypes はエクスポートできるデータ種類です。
+var validExportDataTypes = map[service.ExportDataType]bool{
+	service.ExportDataTypeCrops:           true,
+	service.ExportDataTypeHarvests:        true,
+	service.ExportDataTypeTasks:           true,
+	service.ExportDataTypeGrowthRecords:   true,
+	service.ExportDataTypePlotAssignments: true,
+	service.ExportDataTypeAll:             true,
+}
+
+// invalidExportDataTypeMessage は不正なデータ種類の場合のエラーメッセージです。
+const invalidExportDataTypeMessage = "Invalid data type. Valid types: crops, harvests, tasks, growth_records, plot_assignments, all"
+
+// RequestExportRequest は非同期エクスポートのリクエストです。
+type RequestExportRequest struct {
+	DataType      string `json:"data_type"`      // crops, harvests, tasks, growth_records, plot_assignments, all
+	Anonymize     bool   `json:"anonymize"`      // 個人情報を除去するか
+	IncludePhotos bool   `json:"include_photos"` // 写真の画像を含めるか（all のみ）
+}
+
+// newExportRecordResponse はモデルからレスポンスを生成します。
+func newExportRecordResponse(record *model.ExportRecord) ExportRecordResponse {
+	return ExportRecordResponse{
+		ID:            record.ID,
+		DataType:      record.DataType,
+		FileName:      record.FileName,
+		ContentType:   record.ContentType,
+		RecordCount:   record.RecordCount,
+		FileSizeBytes: record.FileSizeBytes,
+		Anonymized:    record.Anonymized,
+		Downloadable:  record.IsDownloadable(),
+		Status:        record.Status,
+		ErrorMessage:  record.ErrorMessage,
+		IncludePhotos: record.IncludePhotos,
+		PartNumber:    record.PartNumber,
+		PartCount:     record.PartCount,
+		ParentID:      record.ParentID,
+		CreatedAt:     record.CreatedAt,
+	}
+}
+
+// storeExport は生成したエクスポートを保存先に保存し、履歴を記録します。
+// 保存先が未設定またはアップロードに失敗した場合でも、メタデータのみ記録します。
+// 履歴の記録はベストエフォートで、失敗してもエクスポート自体は成功させます。
+func (h *Handler) storeExport(c echo.Context, userID uint, result *service.CSVExportResult) *model.ExportRecord {
+	ctx := c.Request().Context()
+
+	var s3Key string
+	if h.fileStorage.IsConfigured() {
+		if key, err := h.fileStorage.UploadExport(ctx, userID, result.FileName, result.ContentType, result.Data); err == nil {
+			s3Key = key
+		}
+	}
+
+	record, err := h.service.RecordExport(ctx, userID, result, s3Key)
+	if err != nil {
+		return nil
+	}
+	return record
+}
+
+// RequestExport はエクスポートの生成をジョブキューに登録します。
+// 生成を待たずに pending のエクスポート履歴を返し、完了後は GET /exports/:id/download からダウンロードできます。
+// 写真を含む全データのエクスポートは写真の画像を ZIP の photos/ に含め（一覧は photos.csv）、
+// 写真が多い場合は複数の ZIP に分けます（part_count、2番目以降の ZIP は GET /exports の parent_id が同じ履歴）。
+//
+// リクエストボディ:
+//   - data_type: エクスポートするデータ種類（crops, harvests, tasks, growth_records, plot_assignments, all）
+//   - anonymize: trueの場合、個人情報を除去（省略時: false）
+//   - include_photos: trueの場合、写真の画像を含める（all のみ、省略時: false）
+//
+// レスポンス:
+//   - 202: 登録したエクスポート履歴（status: pending）
+//   - 400: 不正なリクエスト・データ種類、all 以外で include_photos
+//   - 401: 認証エラー
+//   - 403: 写真を含めると保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 500: 内部エラー
+//   - 503: ジョブキューまたは保存先が未設定・利用不可
+func (h *Handler) RequestExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req RequestExportRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.NewBadRequestError("Invalid request body")
+	}
+
+	dataType := service.ExportDataType(req.DataType)
+	if !validExportDataTypes[dataType] {
+		return apperrors.NewBadRequestError(invalidExportDataTypeMessage)
+	}
+
+	record, err := h.service.RequestExport(ctx, userID, dataType, service.ExportOptions{Anonymize: req.Anonymize, IncludePhotos: req.IncludePhotos})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExportPhotosRequireAll):
+			return apperrors.NewBadRequestError("Photos can only be included in the all data export")
+		case errors.Is(err, service.ErrStorageQuotaExceeded):
+			return storageQuotaExceededError()
+		case errors.Is(err, service.ErrExportStorageNotConfigured), errors.Is(err, service.ErrPhotoStorageNotConfigured):
+			return apperrors.NewServiceUnavailableError("Export storage is not configured")
+		case errors.Is(err, service.ErrJobQueueUnavailable):
+			return apperrors.NewServiceUnavailableError("Export queue is unavailable")
+		}
+		return apperrors.NewInternalError("Failed to request export")
+	}
+
+	return c.JSON(http.StatusAccepted, newExportRecordResponse(record))
+}
+
+// EstimateExport はエクスポートに含める写真の枚数・容量と ZIP の数を見積もります。
+// 写真を含めるエクスポートの前に、ダウンロードの大きさと ZIP の数の確認に使用します（CSV の大きさは含めない）。
+//
+// クエリパラメータ:
+//   - data_type: エクスポートするデータ種類（省略時: all）
+//   - include_photos: trueの場合、写真の画像を含める（all のみ、省略時: false）
+//
+// レスポンス:
+//   - 200: 見積もり（photo_count、photo_bytes、part_count、part_size_bytes）
+//   - 400: 不正なデータ種類・include_photos、all 以外で include_photos
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) EstimateExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	dataType := service.ExportDataTypeAll
+	if dataTypeStr := c.QueryParam("data_type"); dataTypeStr != "" {
+		dataType = service.ExportDataType(dataTypeStr)
+	}
+	if !validExportDataTypes[dataType] {
+		return apperrors.NewBadRequestError(invalidExportDataTypeMessage)
+	}
+
+	var opts service.ExportOptions
+	if includePhotosStr := c.QueryParam("include_photos"); includePhotosStr != "" {
+		includePhotos, err := strconv.ParseBool(includePhotosStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid include_photos parameter")
+		}
+		opts.IncludePhotos = includePhotos
+	}
+
+	estimate, err := h.service.EstimateExport(ctx, userID, dataType, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrExportPhotosRequireAll) {
+			return apperrors.NewBadRequestError("Photos can only be included in the all data export")
+		}
+		return apperrors.NewInternalError("Failed to estimate export")
+	}
+
+	return c.JSON(http.StatusOK, estimate)
+}
+
+// GetExports はユーザーのエクスポート履歴を新しい順に取得します。
+//
+// クエリパラメータ:
+//   - limit: 取得件数（省略時: 50）
+//
+// レスポンス:
+//   - 200: エクスポート履歴一覧
+//   - 400: 不正なlimit
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetExports(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			return apperrors.NewBadRequestError("Invalid limit")
+		}
+		limit = parsed
+	}
+
+	records, err := h.service.GetUserExports(ctx, userID, limit)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get exports")
+	}
+
+	response := make([]ExportRecordResponse, 0, len(records))
+	for i := range records {
+		response = append(response, newExportRecordResponse(&records[i]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DownloadExport は過去のエクスポートの再ダウンロード用Presigned URLを返します。
+// ファイルは再生成せず、保存先に保存済みのものを返します。
+//
+// パスパラメータ:
+//   - id: エクスポート履歴ID
+//
+// レスポンス:
+//   - 200: {"download_url": string, "expires_at": time}
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 404: エクスポートが存在しない（他ユーザーのものを含む）
+//   - 409: ファイルが保存されておらず再ダウンロード不可
+//   - 503: 保存先が未設定
+func (h *Handler) DownloadExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid export ID")
+	}
+
+	record, err := h.service.GetExportRecord(ctx, userID, uint(id))
+	if err != nil {
+		return apperrors.NewNotFoundError("Export")
+	}
+
+	if !record.IsDownloadable() {
+		return apperrors.NewConflictError("Export file is not stored; please generate a new export")
+	}
+
+	if !h.fileStorage.IsConfigured() {
+		return apperrors.NewServiceUnavailableError("Export storage is not configured")
+	}
+
+	result, err := h.fileStorage.GenerateDownloadURL(ctx, record.S3Key, record.FileName)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to generate download URL")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
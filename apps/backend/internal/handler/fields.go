@@ -0,0 +1,67 @@
+// Package handler - Sparse Fieldsets
+//
+// GET のクエリパラメータ fields で指定したフィールドだけを JSON のレスポンスに残すミドルウェアを提供します。
+// ハンドラは常に全てのフィールドを返し、選択は internal/fieldset で共通に行います。
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/fieldset"
+)
+
+// =============================================================================
+// Sparse Fieldsets - ?fields=
+// =============================================================================
+// 条件付きリクエストのミドルウェアより内側で実行するため、ETag は選択後のレスポンスから作成します。
+
+// FieldsQueryParam はフィールドを選択するクエリパラメータです。
+const FieldsQueryParam = "fields"
+
+// fieldsSkipPaths は fields を適用しないルートです（ストリーミング・独自の選択の仕組みを持つエンドポイント）。
+var fieldsSkipPaths = map[string]bool{
+	"/api/v1/events":  true,
+	"/api/v1/graphql": true,
+}
+
+// fieldsMiddleware は GET の 200 の JSON のレスポンスから fields で指定したフィールドを選択します。
+// fields の形式が不正な場合は 400 を返し、JSON 以外のレスポンス（CSV・画像など）はそのまま返します。
+func fieldsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := c.QueryParam(FieldsQueryParam)
+			if c.Request().Method != http.MethodGet || raw == "" || fieldsSkipPaths[c.Path()] {
+				return next(c)
+			}
+			set, err := fieldset.Parse(raw)
+			if err != nil {
+				return apperrors.NewBadRequestError("Invalid fields parameter: use comma-separated JSON keys (e.g. fields=id,title,crop.name)")
+			}
+
+			res := c.Response()
+			original := res.Writer
+			buffer := &bufferedResponseWriter{ResponseWriter: original}
+			res.Writer = buffer
+			err = next(c)
+			res.Writer = original
+
+			if buffer.status == 0 {
+				// ハンドラがレスポンスを書き込んでいない（エラーハンドラが書き込む）
+				return err
+			}
+			body := buffer.body.Bytes()
+			if buffer.status == http.StatusOK && strings.HasPrefix(res.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+				if selected, selectErr := fieldset.Apply(body, set); selectErr == nil {
+					body = selected
+					res.Header().Del(echo.HeaderContentLength)
+				}
+			}
+			original.WriteHeader(buffer.status)
+			_, _ = original.Write(body)
+			return err
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// =============================================================================
+// Sparse Fieldsets Tests - ?fields= のテスト
+// =============================================================================
+// テスト対象:
+//   - fieldsMiddleware: GET の JSON のレスポンスからのフィールドの選択
+//   - ETag が選択後のレスポンスから作成されること、不正な fields の拒否
+
+// TestFields_SelectTaskColumns はタスクの一覧のフィールドの選択のテストです。
+// 期待動作:
+//   - fields=id,title,due_date の場合は各タスクにその3つのフィールドだけが残る
+//   - fields の異なるレスポンスは ETag が異なる
+//   - 不正な fields は 400
+func TestFields_SelectTaskColumns(t *testing.T) {
+	// Arrange
+	e, token := newConditionalTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/tasks", `{"title": "水やり", "description": "朝に", "due_date": "2026-05-01T09:00:00Z"}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create task: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Act
+	full := doConditional(e, token, http.MethodGet, "/api/v1/tasks", "", nil)
+	sparse := doConditional(e, token, http.MethodGet, "/api/v1/tasks?fields=id,title,due_date", "", nil)
+	invalid := doConditional(e, token, http.MethodGet, "/api/v1/tasks?fields=title,,id", "", nil)
+
+	// Assert
+	if sparse.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", sparse.Code, sparse.Body.String())
+	}
+	var tasks []map[string]any
+	if err := json.Unmarshal(sparse.Body.Bytes(), &tasks); err != nil || len(tasks) != 1 {
+		t.Fatalf("Failed to decode sparse tasks: %v %s", err, sparse.Body.String())
+	}
+	if len(tasks[0]) != 3 || tasks[0]["title"] != "水やり" || tasks[0]["due_date"] == nil || tasks[0]["id"] == nil {
+		t.Errorf("Expected only id, title and due_date, got %v", tasks[0])
+	}
+	if sparse.Body.Len() >= full.Body.Len() {
+		t.Errorf("Expected sparse response (%d bytes) to be smaller than full (%d bytes)", sparse.Body.Len(), full.Body.Len())
+	}
+	if sparse.Header().Get("ETag") == "" || sparse.Header().Get("ETag") == full.Header().Get("ETag") {
+		t.Errorf("Expected ETag of the selected response, got %q (full %q)", sparse.Header().Get("ETag"), full.Header().Get("ETag"))
+	}
+	if invalid.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid fields, got %d", invalid.Code)
+	}
+}
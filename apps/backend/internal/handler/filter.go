@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/filter"
+)
+
+// =============================================================================
+// 一覧の絞り込み（filter クエリパラメータ）
+// =============================================================================
+
+// taskFilterSchema は GET /tasks の filter に指定できるフィールドです。
+var taskFilterSchema = filter.Schema{
+	"status":       {Column: "status", Type: filter.String},
+	"priority":     {Column: "priority", Type: filter.String},
+	"title":        {Column: "title", Type: filter.String},
+	"recurrence":   {Column: "recurrence", Type: filter.String},
+	"due_date":     {Column: "due_date", Type: filter.Time},
+	"completed_at": {Column: "completed_at", Type: filter.Time},
+	"created_at":   {Column: "created_at", Type: filter.Time},
+}
+
+// cropFilterSchema は GET /crops の filter に指定できるフィールドです。
+var cropFilterSchema = filter.Schema{
+	"status":                {Column: "status", Type: filter.String},
+	"name":                  {Column: "name", Type: filter.String},
+	"variety":               {Column: "variety", Type: filter.String},
+	"plot_id":               {Column: "plot_id", Type: filter.Number},
+	"planted_date":          {Column: "planted_date", Type: filter.Time},
+	"expected_harvest_date": {Column: "expected_harvest_date", Type: filter.Time},
+	"created_at":            {Column: "created_at", Type: filter.Time},
+}
+
+// plotFilterSchema は GET /plots の filter に指定できるフィールドです。
+var plotFilterSchema = filter.Schema{
+	"status":     {Column: "status", Type: filter.String},
+	"name":       {Column: "name", Type: filter.String},
+	"soil_type":  {Column: "soil_type", Type: filter.String},
+	"sunlight":   {Column: "sunlight", Type: filter.String},
+	"width":      {Column: "width", Type: filter.Number},
+	"height":     {Column: "height", Type: filter.Number},
+	"created_at": {Column: "created_at", Type: filter.Time},
+}
+
+// filterSpec はクエリパラメータ filter（複数指定可）と status から絞り込みの条件を取得します。
+// filter を指定した場合、一覧は limit・cursor を指定した場合と同じく1ページずつ返します。
+//
+// 引数:
+//   - c: Echo コンテキスト
+//   - schema: エンドポイントで指定できるフィールド
+//
+// 戻り値:
+//   - filter.Spec: 絞り込みの条件（status を含む）
+//   - bool: filter を指定した場合は true
+//   - error: 不正な filter の場合は INVALID_FILTER（400）
+func filterSpec(c echo.Context, schema filter.Schema) (filter.Spec, bool, error) {
+	raw := c.QueryParams()["filter"]
+	spec, err := filter.Parse(raw, schema)
+	if err != nil {
+		return nil, false, apperrors.New(apperrors.ErrCodeInvalidFilter, err.Error())
+	}
+	return spec.EqualIfSet("status", c.QueryParam("status")), len(raw) > 0, nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Filter Tests - 一覧の filter クエリパラメータのテスト
+// =============================================================================
+// テスト対象:
+//   - GET /tasks, /crops: filter の絞り込み（status との組み合わせ）と1ページ分のレスポンス
+//   - filterSpec: 不正な filter の INVALID_FILTER
+
+// TestListFilter は一覧の filter のテストです。
+// 期待動作:
+//   - filter を指定した場合は条件を満たす記録を1ページ分（items）で返す
+//   - status と filter の条件は AND
+//   - Schema にないフィールド・不正な形式は 400 INVALID_FILTER
+func TestListFilter(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+	jwtManager := auth.NewJWTManager("list-filter-test-secret-key-32-chars", 24)
+	NewHandler(service.NewService(repository.NewMockRepositories()), jwtManager, nil).RegisterRoutes(e)
+	token, err := jwtManager.GenerateToken(1, "", "filter@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	titles := func(rec *httptest.ResponseRecorder) string {
+		var page struct {
+			Items []struct {
+				Title string `json:"title"`
+				Name  string `json:"name"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+			return rec.Body.String()
+		}
+		var got []string
+		for _, item := range page.Items {
+			got = append(got, item.Title+item.Name)
+		}
+		return strings.Join(got, ",")
+	}
+
+	for _, task := range []struct{ title, priority, due string }{
+		{"水やり", "high", "2026-05-01"},
+		{"草取り", "low", "2026-05-02"},
+		{"追肥", "high", "2026-05-03"},
+	} {
+		body := fmt.Sprintf(`{"title": %q, "priority": %q, "due_date": "%sT00:00:00Z"}`, task.title, task.priority, task.due)
+		if rec := request(http.MethodPost, "/api/v1/tasks", body); rec.Code != http.StatusCreated {
+			t.Fatalf("CreateTask failed: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+	for _, name := range []string{"ミニトマト", "キュウリ"} {
+		body := fmt.Sprintf(`{"name": %q, "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`, name)
+		if rec := request(http.MethodPost, "/api/v1/crops", body); rec.Code != http.StatusCreated {
+			t.Fatalf("CreateCrop failed: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	// Act
+	high := request(http.MethodGet, "/api/v1/tasks?filter=priority:eq:high&filter=due_date:gt:2026-05-01", "")
+	completed := request(http.MethodGet, "/api/v1/tasks?status=completed&filter=priority:eq:high", "")
+	crops := request(http.MethodGet, "/api/v1/crops?filter="+url.QueryEscape("name:contains:トマト"), "")
+	unknown := request(http.MethodGet, "/api/v1/tasks?filter=user_id:eq:2", "")
+	malformed := request(http.MethodGet, "/api/v1/crops?filter=name", "")
+
+	// Assert
+	if high.Code != http.StatusOK || titles(high) != "追肥" {
+		t.Errorf("Expected the high priority task after 2026-05-01, got %d %s", high.Code, titles(high))
+	}
+	if completed.Code != http.StatusOK || titles(completed) != "" {
+		t.Errorf("Expected no completed tasks, got %d %s", completed.Code, titles(completed))
+	}
+	if crops.Code != http.StatusOK || titles(crops) != "ミニトマト" {
+		t.Errorf("Expected the crop containing トマト, got %d %s", crops.Code, titles(crops))
+	}
+	for _, rec := range []*httptest.ResponseRecorder{unknown, malformed} {
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"code":"`+apperrors.ErrCodeInvalidFilter+`"`) {
+			t.Errorf("Expected 400 INVALID_FILTER, got %d %s", rec.Code, rec.Body.String())
+		}
+	}
+}
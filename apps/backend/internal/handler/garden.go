@@ -3,9 +3,11 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
 	"github.com/secure-scorecard/backend/internal/validator"
@@ -27,6 +29,16 @@ type UpdateGardenRequest struct {
 	SizeM2      float64 `json:"size_m2" validate:"gte=0"`
 }
 
+// CreatePlantRequest represents the request body for creating a plant
+type CreatePlantRequest struct {
+	Name        string    `json:"name" validate:"required,max=100"`
+	Species     string    `json:"species" validate:"max=100"`
+	PlantedAt   time.Time `json:"planted_at"`
+	HarvestedAt time.Time `json:"harvested_at"`
+	Status      string    `json:"status" validate:"max=50"`
+	Notes       string    `json:"notes" validate:"max=1000"`
+}
+
 // GetGardens returns all gardens for the current user
 func (h *Handler) GetGardens(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -40,7 +52,7 @@ func (h *Handler) GetGardens(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch gardens")
 	}
 
-	return c.JSON(http.StatusOK, gardens)
+	return c.JSON(http.StatusOK, dto.List(gardens, dto.NewGardenResponse))
 }
 
 // GetGarden returns a specific garden
@@ -56,7 +68,7 @@ func (h *Handler) GetGarden(c echo.Context) error {
 		return apperrors.NewNotFoundError("Garden")
 	}
 
-	return c.JSON(http.StatusOK, garden)
+	return c.JSON(http.StatusOK, dto.NewGardenResponse(garden))
 }
 
 // CreateGarden creates a new garden
@@ -77,7 +89,7 @@ func (h *Handler) CreateGarden(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to create garden")
 	}
 
-	return c.JSON(http.StatusCreated, garden)
+	return c.JSON(http.StatusCreated, dto.NewGardenResponse(garden))
 }
 
 // UpdateGarden updates an existing garden
@@ -116,7 +128,7 @@ func (h *Handler) UpdateGarden(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to update garden")
 	}
 
-	return c.JSON(http.StatusOK, garden)
+	return c.JSON(http.StatusOK, dto.NewGardenResponse(garden))
 }
 
 // DeleteGarden deletes a garden
@@ -147,7 +159,7 @@ func (h *Handler) GetGardenPlants(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch plants")
 	}
 
-	return c.JSON(http.StatusOK, plants)
+	return c.JSON(http.StatusOK, dto.List(plants, dto.NewPlantResponse))
 }
 
 // CreatePlant creates a new plant in a garden
@@ -158,16 +170,24 @@ func (h *Handler) CreatePlant(c echo.Context) error {
 		return apperrors.NewBadRequestError("Invalid garden ID")
 	}
 
-	var plant model.Plant
-	if err := c.Bind(&plant); err != nil {
-		return apperrors.NewBadRequestError("Invalid request body")
+	var req CreatePlantRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
-	plant.GardenID = uint(gardenID)
+	plant := model.Plant{
+		GardenID:    uint(gardenID),
+		Name:        req.Name,
+		Species:     req.Species,
+		PlantedAt:   req.PlantedAt,
+		HarvestedAt: req.HarvestedAt,
+		Status:      req.Status,
+		Notes:       req.Notes,
+	}
 
 	if err := h.service.CreatePlant(ctx, &plant); err != nil {
 		return apperrors.NewInternalError("Failed to create plant")
 	}
 
-	return c.JSON(http.StatusCreated, plant)
+	return c.JSON(http.StatusCreated, dto.NewPlantResponse(&plant))
 }
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/graph"
+)
+
+// =============================================================================
+// GraphQL Handler - GraphQLエンドポイント
+// =============================================================================
+
+// MaxGraphQLComplexity はクエリの最大複雑度です。
+// 深いネストによる過負荷を防ぎます。
+const MaxGraphQLComplexity = 500
+
+// newGraphQLServer はgqlgenのHTTPサーバーを作成します。
+func (h *Handler) newGraphQLServer() *handler.Server {
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers: graph.NewResolver(h.service),
+	}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(MaxGraphQLComplexity))
+	return srv
+}
+
+// GraphQL はGraphQLクエリを実行します。
+// 作物・区画・タスク・収穫・分析データをネストして1リクエストで取得できます。
+//
+// リクエスト:
+//   - POST: {"query": "...", "variables": {...}}
+//   - GET: ?query=...&variables=...
+//
+// レスポンス:
+//   - 200: GraphQLレスポンス（エラーは errors フィールドに格納）
+//   - 401: 認証エラー
+func (h *Handler) GraphQL(c echo.Context) error {
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	// リクエスト単位でユーザーIDとDataLoaderをコンテキストに設定
+	req := c.Request()
+	ctx := graph.WithUser(req.Context(), h.service, userID)
+
+	h.graphqlServer.ServeHTTP(c.Response(), req.WithContext(ctx))
+	return nil
+}
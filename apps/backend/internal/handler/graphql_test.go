@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// GraphQL Handler Tests - GraphQLエンドポイントテスト
+// =============================================================================
+
+// graphqlResponse はテスト用のGraphQLレスポンス構造体です。
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// executeGraphQL はテスト用にGraphQLクエリを実行します。
+func executeGraphQL(t *testing.T, setup *integrationTestSetup, userID uint, query string) graphqlResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	c, rec := setup.createAuthenticatedContext(http.MethodPost, "/api/v1/graphql", string(body), userID)
+
+	if err := setup.handler.GraphQL(c); err != nil {
+		t.Fatalf("GraphQL handler returned error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var resp graphqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return resp
+}
+
+func TestGraphQL_NestedCropsWithHarvests(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	ctx := context.Background()
+	now := time.Now()
+
+	tomato := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: now, ExpectedHarvestDate: now}
+	cucumber := &model.Crop{UserID: 1, Name: "きゅうり", PlantedDate: now, ExpectedHarvestDate: now}
+	setup.service.CreateCrop(ctx, tomato)
+	setup.service.CreateCrop(ctx, cucumber)
+	setup.service.CreateHarvest(ctx, &model.Harvest{CropID: tomato.ID, HarvestDate: now, Quantity: 2, QuantityUnit: "kg"})
+	setup.service.CreateHarvest(ctx, &model.Harvest{CropID: tomato.ID, HarvestDate: now, Quantity: 3, QuantityUnit: "kg"})
+	setup.service.CreateHarvest(ctx, &model.Harvest{CropID: cucumber.ID, HarvestDate: now, Quantity: 1, QuantityUnit: "kg"})
+
+	// Act
+	resp := executeGraphQL(t, setup, 1, `{ crops { id name harvests { quantity crop { name } } } }`)
+
+	// Assert
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", resp.Errors)
+	}
+
+	var data struct {
+		Crops []struct {
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Harvests []struct {
+				Quantity float64 `json:"quantity"`
+				Crop     struct {
+					Name string `json:"name"`
+				} `json:"crop"`
+			} `json:"harvests"`
+		} `json:"crops"`
+	}
+	json.Unmarshal(resp.Data, &data)
+
+	if len(data.Crops) != 2 {
+		t.Fatalf("Expected 2 crops, got %d", len(data.Crops))
+	}
+	for _, crop := range data.Crops {
+		expected := map[string]int{"トマト": 2, "きゅうり": 1}[crop.Name]
+		if len(crop.Harvests) != expected {
+			t.Errorf("Expected %d harvests for %s, got %d", expected, crop.Name, len(crop.Harvests))
+		}
+		for _, h := range crop.Harvests {
+			if h.Crop.Name != crop.Name {
+				t.Errorf("Expected nested crop name %s, got %s", crop.Name, h.Crop.Name)
+			}
+		}
+	}
+}
+
+func TestGraphQL_CropOfOtherUserIsNull(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	now := time.Now()
+	otherCrop := &model.Crop{UserID: 2, Name: "なす", PlantedDate: now, ExpectedHarvestDate: now}
+	setup.service.CreateCrop(context.Background(), otherCrop)
+
+	// Act
+	resp := executeGraphQL(t, setup, 1, fmt.Sprintf(`{ crop(id: "%d") { name } }`, otherCrop.ID))
+
+	// Assert
+	var data struct {
+		Crop *struct {
+			Name string `json:"name"`
+		} `json:"crop"`
+	}
+	json.Unmarshal(resp.Data, &data)
+	if data.Crop != nil {
+		t.Errorf("Expected null for other user's crop, got %+v", data.Crop)
+	}
+}
+
+// TestGraphQL_CropLoaderLoadsRequestedIDs は作物の DataLoader が要求されたIDのみを取得することをテストします。
+// 期待動作:
+//   - ユーザーの全作物（GetByUserID）を取得せず、要求されたIDだけを GetByIDs で取得する
+//   - 入れ子の収穫記録の作物も同じIDで取得し、他のユーザーの作物は返さない
+func TestGraphQL_CropLoaderLoadsRequestedIDs(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	ctx := context.Background()
+	now := time.Now()
+	tomato := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: now, ExpectedHarvestDate: now}
+	for _, crop := range []*model.Crop{tomato, {UserID: 1, Name: "きゅうり", PlantedDate: now, ExpectedHarvestDate: now}, {UserID: 1, Name: "なす", PlantedDate: now, ExpectedHarvestDate: now}} {
+		setup.service.CreateCrop(ctx, crop)
+	}
+	setup.service.CreateHarvest(ctx, &model.Harvest{CropID: tomato.ID, HarvestDate: now, Quantity: 2, QuantityUnit: "kg"})
+
+	cropRepo := setup.mockRepos.GetMockCropRepository()
+	var mu sync.Mutex
+	var requested [][]uint
+	cropRepo.GetByIDsFunc = func(ctx context.Context, ids []uint) ([]model.Crop, error) {
+		mu.Lock()
+		requested = append(requested, ids)
+		mu.Unlock()
+		var crops []model.Crop
+		for _, id := range ids {
+			if crop, ok := cropRepo.Crops[id]; ok {
+				crops = append(crops, *crop)
+			}
+		}
+		return crops, nil
+	}
+	cropRepo.GetByUserIDFunc = func(ctx context.Context, userID uint) ([]model.Crop, error) {
+		t.Error("Expected the crop loader not to load all crops of the user")
+		return nil, nil
+	}
+
+	// Act
+	resp := executeGraphQL(t, setup, 1, fmt.Sprintf(`{ crop(id: "%d") { name harvests { crop { name } } } }`, tomato.ID))
+
+	// Assert
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %+v", resp.Errors)
+	}
+	var data struct {
+		Crop struct {
+			Name     string `json:"name"`
+			Harvests []struct {
+				Crop struct {
+					Name string `json:"name"`
+				} `json:"crop"`
+			} `json:"harvests"`
+		} `json:"crop"`
+	}
+	json.Unmarshal(resp.Data, &data)
+	if data.Crop.Name != "トマト" || len(data.Crop.Harvests) != 1 || data.Crop.Harvests[0].Crop.Name != "トマト" {
+		t.Errorf("Expected the crop and the crop of its harvest, got %s", resp.Data)
+	}
+	if len(requested) == 0 {
+		t.Error("Expected the crop loader to use GetByIDs")
+	}
+	for _, ids := range requested {
+		if len(ids) != 1 || ids[0] != tomato.ID {
+			t.Errorf("Expected only the requested crop ID %d, got %v", tomato.ID, ids)
+		}
+	}
+}
+
+func TestGraphQL_AnalyticsHarvestSummary(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	now := time.Now()
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: now, ExpectedHarvestDate: now}
+	setup.service.CreateCrop(context.Background(), crop)
+	harvestRepo := setup.mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: crop.ID, HarvestDate: now, Quantity: 1500, QuantityUnit: "g", Quality: "good"})
+
+	// Act
+	resp := executeGraphQL(t, setup, 1, `{ analytics { harvestSummary { totalHarvests totalQuantityKg qualityDistribution { quality count } } } }`)
+
+	// Assert
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", resp.Errors)
+	}
+	var data struct {
+		Analytics struct {
+			HarvestSummary struct {
+				TotalHarvests   int     `json:"totalHarvests"`
+				TotalQuantityKg float64 `json:"totalQuantityKg"`
+			} `json:"harvestSummary"`
+		} `json:"analytics"`
+	}
+	json.Unmarshal(resp.Data, &data)
+	if data.Analytics.HarvestSummary.TotalHarvests != 1 {
+		t.Errorf("Expected 1 harvest, got %d", data.Analytics.HarvestSummary.TotalHarvests)
+	}
+	if data.Analytics.HarvestSummary.TotalQuantityKg != 1.5 {
+		t.Errorf("Expected 1.5kg, got %f", data.Analytics.HarvestSummary.TotalQuantityKg)
+	}
+}
+
+func TestGraphQL_Unauthenticated(t *testing.T) {
+	setup := newIntegrationTestSetup()
+	c, _ := setup.createContext(http.MethodPost, "/api/v1/graphql", `{"query":"{ crops { id } }"}`)
+
+	if err := setup.handler.GraphQL(c); err == nil {
+		t.Error("Expected authentication error")
+	}
+}
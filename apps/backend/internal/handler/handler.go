@@ -1,6 +1,7 @@
 package handler
 
 import (
+	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
 	"github.com/secure-scorecard/backend/internal/service"
@@ -9,28 +10,63 @@ import (
 
 // Handler holds all HTTP handlers
 type Handler struct {
-	service    *service.Service
-	jwtManager *auth.JWTManager
-	s3Service  *storage.S3Service
+	service     *service.Service
+	jwtManager  *auth.JWTManager
+	fileStorage *storage.Service // 画像・エクスポートファイルの保存先（nil の場合はアップロードは 503）
+
+	graphqlServer    *gqlhandler.Server
+	publicStatsCache *publicStatsCache
+	activeUsers      *activeUserTracker
+	router           *echo.Echo      // バッチリクエストのサブリクエストの実行先（RegisterRoutes で設定）
+	wsOriginPatterns []string        // WebSocket の接続を許可するオリジン（同じホストは常に許可）
+	bodyLimits       BodyLimits      // リクエストボディのサイズの上限（SetBodyLimits で変更）
+	dbMonitor        DatabaseMonitor // 接続プールの統計と遅いクエリ（SetDatabaseMonitor で設定、スタンドアロンモードでは nil）
 }
 
 // NewHandler creates a new Handler instance
-func NewHandler(svc *service.Service, jwtManager *auth.JWTManager, s3Svc *storage.S3Service) *Handler {
-	return &Handler{
-		service:    svc,
-		jwtManager: jwtManager,
-		s3Service:  s3Svc,
+func NewHandler(svc *service.Service, jwtManager *auth.JWTManager, fileStorage *storage.Service) *Handler {
+	h := &Handler{
+		service:     svc,
+		jwtManager:  jwtManager,
+		fileStorage: fileStorage,
+
+		publicStatsCache: newPublicStatsCache(PublicStatsCacheTTL),
+		activeUsers:      newActiveUserTracker(),
+		bodyLimits:       DefaultBodyLimits,
 	}
+	h.graphqlServer = h.newGraphQLServer()
+	return h
 }
 
 // RegisterRoutes registers all routes
 func (h *Handler) RegisterRoutes(e *echo.Echo) {
+	h.router = e
+
 	// Health check (public)
 	e.GET("/health", h.Health)
 	e.GET("/", h.Hello)
 
+	// Local file storage (STORAGE_BACKEND=local)
+	// ローカルの保存先の署名付きURLのアップロード・ダウンロードと画像URL（署名で認証）
+	if files := h.fileStorage.HTTPHandler(); files != nil {
+		e.Any(storage.LocalFilesPath+"/*", echo.WrapHandler(files))
+	}
+
+	// Public share endpoints (no auth)
+	// 公開共有エンドポイント - 共有トークンで認証なしに閲覧可能
+	public := e.Group("/public")
+	public.Use(conditionalGetMiddleware()) // ETag / If-None-Match（304 Not Modified）
+	public.GET("/:shareToken/stats.json", h.GetPublicStatsJSON) // 収穫統計（JSON）
+	public.GET("/:shareToken/stats.svg", h.GetPublicStatsSVG)   // 収穫統計バッジ（SVG）
+
 	// API v1 group
 	api := e.Group("/api/v1")
+	api.Use(bodyLimitMiddleware(h.bodyLimits.Default)) // リクエストボディのサイズの上限（413 PAYLOAD_TOO_LARGE）
+
+	// Error catalog (public)
+	// エラーコード一覧 - エラーレスポンスの type の参照先
+	api.GET("/errors", h.GetErrorCatalog)
+	api.GET("/errors/:code", h.GetErrorCatalogEntry)
 
 	// Auth endpoints (public)
 	authHandler := NewAuthHandler(h.service, h.jwtManager)
@@ -46,14 +82,28 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	authProtected.POST("/refresh", authHandler.RefreshToken)
 	authProtected.GET("/me", authHandler.Me)
 
+	// Realtime endpoint (authenticated by the first WebSocket message)
+	// 共有の庭のルーム - ブラウザの WebSocket はヘッダーを指定できないため、接続後に JWT で認証
+	api.GET("/ws", h.ConnectRealtime)
+
 	// Protected API endpoints
 	protected := api.Group("")
 	protected.Use(auth.AuthMiddleware(h.jwtManager, h.service))
+	protected.Use(h.usageTrackingMiddleware())     // 利用統計（アクティブユーザー、作成レコード数）
+	protected.Use(conditionalGetMiddleware())      // ETag / If-None-Match（304 Not Modified）
+	protected.Use(fieldsMiddleware())              // ?fields= によるフィールドの選択
+	protected.Use(h.organizationScopeMiddleware()) // X-Org-ID による組織のスコープ（区画・作物）
+	protected.Use(auditActorMiddleware())          // 変更履歴に記録する変更したユーザー
+
+	// Batch endpoint (protected)
+	// バッチリクエスト - オフラインで記録した操作をまとめて同期（サブリクエストも同じルートで認証）
+	protected.POST("/batch", h.Batch, bodyLimitMiddleware(h.bodyLimits.Batch))
 
 	// Gardens endpoints (protected)
 	gardens := protected.Group("/gardens")
 	gardens.GET("", h.GetGardens)
 	gardens.POST("", h.CreateGarden)
+	gardens.GET("/shared", h.GetSharedGardens) // メンバーとして参加している庭の一覧
 	gardens.GET("/:id", h.GetGarden)
 	gardens.PUT("/:id", h.UpdateGarden)
 	gardens.DELETE("/:id", h.DeleteGarden)
@@ -62,6 +112,22 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	gardens.GET("/:id/plants", h.GetGardenPlants)
 	gardens.POST("/:id/plants", h.CreatePlant)
 
+	// Garden member endpoints (nested under gardens, protected)
+	// 共有の庭（世帯）のメンバー - メンバーは WebSocket で区画のレイアウトとタスクの変更を受信
+	gardens.GET("/:id/members", h.GetGardenMembers)                // メンバー一覧（所有者・メンバー）
+	gardens.POST("/:id/members", h.AddGardenMember)                // メンバー追加（所有者のみ、メールアドレスで指定）
+	gardens.DELETE("/:id/members/:userId", h.RemoveGardenMember)   // メンバー削除（所有者、またはメンバー本人の退出）
+
+	// Organization endpoints (protected)
+	// 組織（コミュニティガーデン・学校） - X-Org-ID ヘッダーで区画・作物を組織のものとして扱う
+	organizations := protected.Group("/organizations")
+	organizations.GET("", h.GetOrganizations)                                // 所属している組織の一覧
+	organizations.POST("", h.CreateOrganization)                             // 組織の作成（作成したユーザーが owner）
+	organizations.GET("/:id", h.GetOrganization)                             // 組織の詳細（役割・区画・作物・メンバーの数）
+	organizations.GET("/:id/members", h.GetOrganizationMembers)              // メンバー一覧（メンバー）
+	organizations.POST("/:id/members", h.AddOrganizationMember)              // メンバー追加（owner・admin のみ、メールアドレスと役割で指定）
+	organizations.DELETE("/:id/members/:userId", h.RemoveOrganizationMember) // メンバー削除（owner・admin、またはメンバー本人の退出）
+
 	// Plants endpoints (direct access, protected)
 	plants := protected.Group("/plants")
 	plants.GET("/:id", h.GetPlant)
@@ -79,7 +145,7 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	// Task endpoints (protected)
 	// タスク管理エンドポイント - やることリストのCRUD操作
 	tasks := protected.Group("/tasks")
-	tasks.GET("", h.GetTasks)                   // 全タスク取得（statusクエリパラメータでフィルタ可能）
+	tasks.GET("", h.GetTasks)                   // 全タスク取得（statusクエリパラメータでフィルタ可能、limit・cursorでページング）
 	tasks.GET("/today", h.GetTodayTasks)        // 今日のタスク取得
 	tasks.GET("/overdue", h.GetOverdueTasks)    // 期限切れタスク取得
 	tasks.POST("", h.CreateTask)                // 新規タスク作成
@@ -87,20 +153,31 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	tasks.PUT("/:id", h.UpdateTask)             // タスク更新
 	tasks.DELETE("/:id", h.DeleteTask)          // タスク削除
 	tasks.POST("/:id/complete", h.CompleteTask) // タスク完了
+	tasks.POST("/:id/restore", h.RestoreTask)   // 削除したタスクの復元
 
 	// Crop endpoints (protected)
 	// 作物管理エンドポイント - 作物の植え付けから収穫までのライフサイクル管理
 	crops := protected.Group("/crops")
-	crops.GET("", h.GetCrops)        // 全作物取得（statusクエリパラメータでフィルタ可能）
+	crops.GET("", h.GetCrops)        // 全作物取得（statusクエリパラメータでフィルタ可能、limit・cursorでページング）
 	crops.POST("", h.CreateCrop)     // 新規作物登録
 	crops.GET("/:id", h.GetCrop)     // 特定作物取得
 	crops.PUT("/:id", h.UpdateCrop)  // 作物更新
 	crops.DELETE("/:id", h.DeleteCrop) // 作物削除
+	crops.POST("/:id/restore", h.RestoreCrop) // 削除した作物の復元（成長記録・収穫記録を含む）
 
 	// Image upload endpoints (nested under crops)
 	// 画像アップロードエンドポイント - S3 Presigned URL生成・直接アップロード
 	crops.POST("/images/presign", h.GenerateImageUploadURL) // Presigned URL生成（クライアント直接アップロード用）
-	crops.POST("/images", h.UploadImage)                     // サーバー経由アップロード（multipart/form-data）
+	crops.POST("/images", h.UploadImage, bodyLimitMiddleware(h.bodyLimits.Upload)) // サーバー経由アップロード（multipart/form-data）
+
+	// Direct upload endpoints (protected)
+	// 直接アップロードエンドポイント - モバイルクライアントからS3への直接PUTと登録のコールバック
+	uploads := protected.Group("/uploads")
+	uploads.POST("/presign", h.PresignUpload)      // Presigned URL（サイズを署名に含める）と登録のコールバックの生成
+	uploads.POST("/complete", h.CompleteUpload)    // アップロードした画像の確認と写真の登録（加工はジョブキュー）
+	protected.GET("/photos/:id", h.GetPhoto)       // 写真の加工の状態とバリアントの画像URL
+	protected.PUT("/photos/:id", h.ReplacePhoto)   // 写真の画像の差し替え（バリアントの CDN のキャッシュも削除）
+	protected.DELETE("/photos/:id", h.DeletePhoto) // 写真の削除（保存先の画像・バリアントも削除）
 
 	// Growth records endpoints (nested under crops)
 	// 成長記録エンドポイント - 作物の成長観察記録
@@ -109,18 +186,23 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 
 	// Harvest endpoints (nested under crops)
 	// 収穫記録エンドポイント - 収穫量と品質の記録
-	crops.GET("/:id/harvests", h.GetHarvests)   // 収穫記録一覧取得
+	crops.GET("/:id/harvests", h.GetHarvests)   // 収穫記録一覧取得（limit・cursorでページング）
 	crops.POST("/:id/harvests", h.CreateHarvest) // 収穫記録追加
 
+	// Harvest restore endpoint (protected)
+	harvests := protected.Group("/harvests")
+	harvests.POST("/:id/restore", h.RestoreHarvest) // 削除した収穫記録の復元（作物が削除されている場合は409）
+
 	// Plot endpoints (protected)
 	// 区画管理エンドポイント - 菜園のグリッドレイアウト管理
 	plots := protected.Group("/plots")
-	plots.GET("", h.GetPlots)         // 全区画取得（statusクエリパラメータでフィルタ可能）
+	plots.GET("", h.GetPlots)         // 全区画取得（statusクエリパラメータでフィルタ可能、limit・cursorでページング）
 	plots.POST("", h.CreatePlot)      // 新規区画作成
 	plots.GET("/layout", h.GetPlotLayout) // 全区画のレイアウトデータ取得（グリッド表示用）
 	plots.GET("/:id", h.GetPlot)      // 特定区画取得
 	plots.PUT("/:id", h.UpdatePlot)   // 区画更新
 	plots.DELETE("/:id", h.DeletePlot) // 区画削除
+	plots.POST("/:id/restore", h.RestorePlot) // 削除した区画の復元（配置履歴を含む）
 
 	// Plot assignment endpoints (nested under plots)
 	// 区画配置エンドポイント - 作物の配置管理
@@ -130,21 +212,130 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	plots.GET("/:id/assignment", h.GetActivePlotAssignment) // アクティブな配置取得
 	plots.GET("/:id/history", h.GetPlotHistory) // 区画の栽培履歴取得（作物情報付き）
 
+	// Attachment endpoints (nested under crops/plots)
+	// 添付ファイルエンドポイント - 作物・区画の PDF 等のファイルの直接アップロードと一覧・ダウンロード
+	crops.POST("/:id/attachments/presign", h.PresignCropAttachment) // 添付ファイルの直接アップロード用のPresigned URL生成
+	crops.POST("/:id/attachments", h.CreateCropAttachment)          // アップロードしたファイルの確認と添付ファイルの登録
+	crops.GET("/:id/attachments", h.GetCropAttachments)             // 作物の添付ファイル一覧取得
+	plots.POST("/:id/attachments/presign", h.PresignPlotAttachment) // 添付ファイルの直接アップロード用のPresigned URL生成
+	plots.POST("/:id/attachments", h.CreatePlotAttachment)          // アップロードしたファイルの確認と添付ファイルの登録
+	plots.GET("/:id/attachments", h.GetPlotAttachments)             // 区画の添付ファイル一覧取得
+	protected.GET("/attachments/:id/download", h.DownloadAttachment) // 添付ファイルのダウンロード用の署名付きURL（有効期限付き）
+	protected.DELETE("/attachments/:id", h.DeleteAttachment)         // 添付ファイルの削除（保存先のファイルも削除）
+
 	// Analytics endpoints (protected)
-	// 分析データエンドポイント - 収穫量・成長データなどの集計・分析
-	analytics := protected.Group("/analytics")
-	analytics.GET("/harvest", h.GetHarvestSummary)         // 収穫量集計取得
-	analytics.GET("/charts/:type", h.GetChartData)         // グラフデータ取得（月別、作物別、区画別）
-	analytics.GET("/export/:dataType", h.ExportCSV)        // CSVエクスポート（作物、収穫、タスク、全部）
+	// 分析データエンドポイント - 収穫量・成長データなどの集計・分析（読み取りは読み取りレプリカ）
+	analytics := protected.Group("/analytics", readReplicaMiddleware)
+	analytics.GET("/harvest", h.GetHarvestSummary, h.analyticsCacheMiddleware()) // 収穫量集計取得（データのバージョンの ETag）
+	analytics.GET("/charts/:type", h.GetChartData, h.analyticsCacheMiddleware())  // グラフデータ取得（月別、作物別、区画別、ベンチマーク、ヒートマップ）
+	analytics.GET("/export/:dataType", h.ExportCSV)                               // CSVエクスポート（作物、収穫、タスク、全部）
+	analytics.GET("/timeseries", h.GetTimeSeries, h.analyticsCacheMiddleware())   // 汎用時系列データ取得（指標・粒度・分割軸を指定）
+	analytics.GET("/seasons/:season", h.GetSeasonSummaries) // シーズンの区画ごとの記録取得（シーズンの締めで作成）
+
+	// Export history endpoints (protected)
+	// エクスポート履歴エンドポイント - 過去のエクスポートを再生成せずに再ダウンロード
+	exports := protected.Group("/exports")
+	exports.POST("", h.RequestExport)               // エクスポートの生成をジョブキューに登録（非同期）
+	exports.GET("", h.GetExports)                   // エクスポート履歴一覧
+	exports.GET("/estimate", h.EstimateExport)      // 写真を含むエクスポートの大きさ・ZIPの数の見積もり
+	exports.GET("/:id/download", h.DownloadExport)  // 再ダウンロード用Presigned URL取得
+
+	// Live events endpoint (protected)
+	// Server-Sent Events - タスクの完了・収穫記録の追加・通知の受信をライブで通知
+	protected.GET("/events", h.StreamEvents) // イベントのストリーム（Last-Event-IDヘッダーで再接続時に再送）
+
+	// Sync endpoints (protected)
+	// オフライン同期エンドポイント - 前回の同期以降の変更の取得・オフラインで記録した変更の反映
+	protected.GET("/sync", h.GetSyncChanges)   // 作成・更新・削除された記録の取得（sinceクエリパラメータ）
+	protected.POST("/sync", h.PushSyncChanges, bodyLimitMiddleware(h.bodyLimits.Batch)) // 変更の反映（競合の判定あり）
+
+	// Trash endpoint (protected)
+	// ゴミ箱エンドポイント - 保持期間内に削除された記録の一覧（復元は各リソースの /:id/restore）
+	protected.GET("/trash", h.GetTrash) // 削除された記録の一覧（typeクエリパラメータでフィルタ可能）
+
+	// Audit endpoint (protected)
+	// 変更履歴エンドポイント - 作物・区画・タスク・収穫記録の作成・更新・削除・復元の履歴
+	protected.GET("/audit", h.GetAuditLogs) // 記録の変更履歴（entity・idクエリパラメータ、所有者と管理者）
+
+	// Search endpoint (protected)
+	// 横断検索エンドポイント - 検索バーの作物・タスク・区画・成長記録のメモの検索
+	protected.GET("/search", h.Search) // 横断検索（q, type, limitクエリパラメータ）
 
 	// Notification endpoints (protected)
 	// 通知管理エンドポイント - デバイストークン登録、通知設定
 	notifications := protected.Group("/notifications")
 	notifications.POST("/device-token", h.RegisterDeviceToken)    // デバイストークン登録（FCM/APNS）
 	notifications.DELETE("/device-token", h.DeleteDeviceToken)    // デバイストークン削除
+	notifications.POST("/actions", h.HandlePushAction)            // プッシュ通知のアクションボタン（完了・スヌーズ）
 
 	// User notification settings (protected)
 	// ユーザー通知設定エンドポイント
 	users.GET("/settings/notifications", h.GetNotificationSettings)    // 通知設定取得
 	users.PUT("/settings/notifications", h.UpdateNotificationSettings) // 通知設定更新
+	users.GET("/settings/benchmark", h.GetBenchmarkSettings)           // ベンチマーク参加設定取得
+	users.PUT("/settings/benchmark", h.UpdateBenchmarkSettings)        // ベンチマーク参加設定更新
+	users.GET("/settings/timezone", h.GetTimezoneSettings)             // タイムゾーン設定取得
+	users.PUT("/settings/timezone", h.UpdateTimezoneSettings)          // タイムゾーン設定更新
+	users.GET("/settings/locale", h.GetLocaleSettings)                 // 通知メールの言語設定取得
+	users.PUT("/settings/locale", h.UpdateLocaleSettings)              // 通知メールの言語設定更新
+
+	// Share token endpoints (protected)
+	// 共有トークン管理エンドポイント - 公開バッジ用トークンの発行・失効
+	users.POST("/me/share-tokens", h.CreateShareToken)         // 共有トークン発行
+	users.GET("/me/share-tokens", h.GetShareTokens)            // 共有トークン一覧
+	users.DELETE("/me/share-tokens/:id", h.RevokeShareToken)   // 共有トークン失効
+
+	// Storage usage endpoints (protected)
+	// 保存容量エンドポイント - 写真・エクスポートファイルの使用量と整理の候補
+	users.GET("/me/storage", h.GetStorageUsage) // 保存容量の使用量と上限、整理の候補（作物が削除された写真）
+
+	// Notification inbox endpoints (protected)
+	// アプリ内通知受信箱エンドポイント - 通知ログを受信箱として一覧・既読管理
+	users.GET("/me/notifications", h.GetNotificationInbox)                    // 受信箱一覧（limit, offset, cursor, unreadクエリパラメータ）
+	users.GET("/me/notifications/unread-count", h.GetUnreadNotificationCount) // 未読通知数
+	users.POST("/me/notifications/:id/read", h.MarkNotificationRead)          // 通知を既読にする
+
+	// Notification preference endpoints (protected)
+	// 通知設定マトリクスエンドポイント - イベントタイプ×チャネルごとの通知可否
+	users.GET("/me/notification-preferences", h.GetNotificationPreferences)    // 通知設定マトリクス取得
+	users.PUT("/me/notification-preferences", h.UpdateNotificationPreferences) // 通知設定マトリクス更新
+
+	// Phone verification endpoints (protected)
+	// SMS通知用の電話番号認証エンドポイント - 認証コードをSMSで送信して確認
+	users.GET("/me/phone", h.GetPhoneVerificationStatus)                     // 認証状況取得
+	users.POST("/me/phone/verification", h.StartPhoneVerification)           // 認証コード送信
+	users.POST("/me/phone/verification/confirm", h.ConfirmPhoneVerification) // 認証コード確認
+	users.DELETE("/me/phone", h.RemovePhoneNumber)                           // 電話番号削除
+
+	// Custom webhook endpoints (protected)
+	// 汎用Webhook通知エンドポイント - 署名用シークレットの取得・再発行
+	users.GET("/me/webhook-secret", h.GetCustomWebhookSecret)           // 署名用シークレット取得
+	users.POST("/me/webhook-secret/rotate", h.RotateCustomWebhookSecret) // 署名用シークレット再発行
+
+	// Admin endpoints (protected, admin only)
+	// 管理者向けエンドポイント - 利用統計、通知の送信結果、メールテンプレートプレビュー、お知らせ配信、送信できなかった通知の再送信、データ保持期間、データベースの状態
+	admin := protected.Group("/admin")
+	admin.Use(h.adminOnlyMiddleware())
+	admin.GET("/usage", h.GetUsageStats)                                                   // 利用統計取得（daysクエリパラメータで期間指定）
+	admin.GET("/notifications/stats", h.GetNotificationStats)                              // 通知のチャネルごとの送信結果（hoursクエリパラメータで期間指定）
+	admin.GET("/email-templates/:type/preview", h.PreviewEmailTemplate)                    // 通知メールプレビュー（locale, formatクエリパラメータ）
+	admin.POST("/announcements", h.CreateAnnouncement)                                     // お知らせ作成（配信予約・配信対象の条件）
+	admin.GET("/announcements", h.GetAnnouncements)                                        // お知らせ一覧（配信結果を含む）
+	admin.GET("/announcements/:id", h.GetAnnouncement)                                     // お知らせの配信結果
+	admin.POST("/announcements/:id/cancel", h.CancelAnnouncement)                          // お知らせの配信取り消し
+	admin.GET("/schedules", h.GetScheduleConfigs)                                          // 定期タスクのスケジュール設定一覧
+	admin.PUT("/schedules/:name", h.UpdateScheduleConfig)                                  // 定期タスクのスケジュール・一時停止・先読み日数の変更
+	admin.GET("/notifications/dead-letters", h.GetNotificationDeadLetters)                 // 送信できなかった通知イベント一覧（status, limitクエリパラメータ）
+	admin.GET("/notifications/dead-letters/:id", h.GetNotificationDeadLetter)              // 送信できなかった通知イベントの詳細
+	admin.POST("/notifications/dead-letters/:id/redrive", h.RedriveNotificationDeadLetter) // 送信できなかった通知イベントの再送信
+	admin.GET("/retention/report", h.GetRetentionReport)                                   // 保持期間を過ぎたデータの件数（dry run）
+	admin.PUT("/organizations/:id/quotas", h.UpdateOrganizationQuotas)                     // 組織の区画・作物・メンバーの数の上限の変更
+	admin.GET("/database", h.GetDatabaseOps)                                               // 接続プールの統計と直近の遅いクエリ
+	admin.POST("/database/backups", h.CreateDatabaseBackup)                                // データベースのバックアップの作成（S3 に保存）
+	admin.GET("/database/backups", h.GetDatabaseBackups)                                   // データベースのバックアップの履歴（limit, cursorクエリパラメータ）
+
+	// GraphQL endpoint (protected)
+	// ダッシュボード向けGraphQL API - 複数リソースをネストして1リクエストで取得
+	protected.GET("/graphql", h.GraphQL)
+	protected.POST("/graphql", h.GraphQL)
 }
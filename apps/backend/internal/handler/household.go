@@ -0,0 +1,195 @@
+// Package handler - Household Handler
+//
+// 庭を共有する世帯のメンバーのHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET    /api/v1/gardens/shared              - メンバーとして参加している庭の一覧
+//   - GET    /api/v1/gardens/:id/members         - 庭のメンバーの一覧（所有者・メンバー）
+//   - POST   /api/v1/gardens/:id/members         - メンバーの追加（所有者のみ）
+//   - DELETE /api/v1/gardens/:id/members/:userId - メンバーの削除（所有者、またはメンバー本人の退出）
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Request/Response 構造体
+// =============================================================================
+
+// AddGardenMemberRequest は庭のメンバーの追加のリクエストボディです。
+//
+// フィールド:
+//   - Email: 追加するユーザーのメールアドレス（登録済みのユーザーのみ）
+type AddGardenMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// GetSharedGardens は認証ユーザーがメンバーとして参加している庭の一覧を返します。
+// 所有する庭は GET /api/v1/gardens で取得します。
+//
+// レスポンス:
+//   - 200: 参加している庭の一覧
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetSharedGardens(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	gardens, err := h.service.GetSharedGardens(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to fetch shared gardens")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(gardens, dto.NewGardenResponse))
+}
+
+// GetGardenMembers は庭のメンバーの一覧を返します（所有者は含まない）。
+//
+// パスパラメータ:
+//   - id: 庭ID
+//
+// レスポンス:
+//   - 200: メンバーの一覧（追加順）
+//   - 400: 不正な庭ID
+//   - 401: 認証エラー
+//   - 403: 庭の所有者・メンバーでない
+//   - 404: 庭が見つからない
+//   - 500: 内部エラー
+func (h *Handler) GetGardenMembers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	gardenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid garden ID")
+	}
+
+	members, err := h.service.GetGardenMembers(ctx, userID, uint(gardenID))
+	if err != nil {
+		return gardenMemberError(err, "Failed to fetch garden members")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(members, dto.NewGardenMemberResponse))
+}
+
+// AddGardenMember はメールアドレスのユーザーを庭のメンバーに追加します。
+// メンバーは WebSocket（GET /api/v1/ws）で庭のルームに参加し、区画のレイアウトとタスクの変更を受信できます。
+//
+// パスパラメータ:
+//   - id: 庭ID
+//
+// レスポンス:
+//   - 201: 追加したメンバー（ユーザー情報付き）
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 庭の所有者でない
+//   - 404: 庭・ユーザーが見つからない
+//   - 409: すでにメンバー・所有者（GARDEN_MEMBER_EXISTS）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) AddGardenMember(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	gardenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid garden ID")
+	}
+
+	var req AddGardenMemberRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	member, err := h.service.AddGardenMember(ctx, userID, uint(gardenID), req.Email)
+	if err != nil {
+		return gardenMemberError(err, "Failed to add garden member")
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewGardenMemberResponse(member))
+}
+
+// RemoveGardenMember は庭のメンバーを削除します。
+// 所有者は全てのメンバーを、メンバーは自分自身（世帯からの退出）を削除できます。
+// 削除したユーザーの WebSocket の接続は庭のルームから退出します（room.revoked）。
+//
+// パスパラメータ:
+//   - id: 庭ID
+//   - userId: 削除するメンバーのユーザーID
+//
+// レスポンス:
+//   - 204: 削除成功
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 403: 庭の所有者・メンバー本人でない
+//   - 404: 庭が見つからない、ユーザーがメンバーでない（GARDEN_MEMBER_NOT_FOUND）
+//   - 500: 内部エラー
+func (h *Handler) RemoveGardenMember(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	gardenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid garden ID")
+	}
+	memberUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid user ID")
+	}
+
+	if err := h.service.RemoveGardenMember(ctx, userID, uint(gardenID), uint(memberUserID)); err != nil {
+		return gardenMemberError(err, "Failed to remove garden member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// gardenMemberError は庭のメンバーのサービスのエラーをAPIのエラーに変換します。
+func gardenMemberError(err error, internalMessage string) error {
+	switch {
+	case errors.Is(err, service.ErrGardenNotFound):
+		return apperrors.NewNotFoundError("Garden")
+	case errors.Is(err, service.ErrGardenAccessDenied):
+		return apperrors.NewAuthorizationError("Not allowed to access this garden")
+	case errors.Is(err, service.ErrGardenMemberUserNotFound):
+		return apperrors.NewNotFoundError("User")
+	case errors.Is(err, service.ErrGardenMemberExists):
+		return apperrors.New(apperrors.ErrCodeGardenMemberExists, "User is already a member of this garden")
+	case errors.Is(err, service.ErrGardenMemberNotFound):
+		return apperrors.NewNotFoundError("Garden member")
+	}
+	return apperrors.NewInternalError(internalMessage)
+}
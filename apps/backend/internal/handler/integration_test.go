@@ -41,7 +41,7 @@ func newIntegrationTestSetup() *integrationTestSetup {
 	svc := service.NewService(mockRepos)
 	jwtManager := auth.NewJWTManager("integration-test-secret-key-32chars", 24)
 	authHandler := NewAuthHandler(svc, jwtManager)
-	handler := NewHandler(svc, jwtManager, nil) // nil for S3Service in tests
+	handler := NewHandler(svc, jwtManager, nil) // nil for file storage in tests
 
 	return &integrationTestSetup{
 		echo:        e,
@@ -113,7 +113,7 @@ func TestAuthFlowIntegration(t *testing.T) {
 		if response.Token == "" {
 			t.Error("Expected token in registration response")
 		}
-		if response.User == nil {
+		if response.User.ID == 0 {
 			t.Error("Expected user in response")
 		}
 	})
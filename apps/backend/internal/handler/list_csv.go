@@ -0,0 +1,83 @@
+// Package handler - List CSV
+//
+// 一覧のエンドポイントのコンテンツネゴシエーション（Accept: text/csv）を提供します。
+// 対象のエンドポイント:
+//   - GET /api/v1/tasks             - タスクの一覧
+//   - GET /api/v1/crops             - 作物の一覧
+//   - GET /api/v1/crops/:id/harvests - 収穫記録の一覧
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Content Negotiation - Accept: text/csv
+// =============================================================================
+// Accept で text/csv が application/json より優先される場合（q 値が大きい、または同じ q 値で先に指定）は、
+// 一覧の絞り込み・ページングの結果をエクスポートと同じ列のCSVで返します。
+// ページングした場合は次のページのカーソルを X-Next-Cursor ヘッダーで返します（最後のページでは省略）。
+
+const (
+	// mimeTextCSV はCSVのメディアタイプです。
+	mimeTextCSV = "text/csv"
+	// headerNextCursor はCSVの一覧の次のページのカーソルのヘッダーです。
+	headerNextCursor = "X-Next-Cursor"
+)
+
+// negotiateCSV はリクエストの Accept がCSVを優先するかを判定します。
+// 一覧のレスポンスは Accept で表現が変わるため、Vary: Accept も設定します。
+func negotiateCSV(c echo.Context) bool {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	csvQ, jsonQ := 0.0, 0.0
+	csvFirst := false
+	for _, part := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case mimeTextCSV:
+			if q > csvQ {
+				csvQ = q
+				csvFirst = jsonQ == 0
+			}
+		case echo.MIMEApplicationJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return csvQ > jsonQ || (csvQ > 0 && csvQ == jsonQ && csvFirst)
+}
+
+// respondListCSV は一覧のCSVを返します（ファイル名は Content-Disposition で指定）。
+//
+// 引数:
+//   - c: リクエストコンテキスト
+//   - result: サービスが作成したCSV
+//   - err: CSVの作成のエラー
+//   - nextCursor: 次のページのカーソル（ページングしない場合・最後のページでは空文字）
+func respondListCSV(c echo.Context, result *service.CSVExportResult, err error, nextCursor string) error {
+	if err != nil {
+		return apperrors.NewInternalError("Failed to generate CSV")
+	}
+	header := c.Response().Header()
+	header.Set("Content-Disposition", "attachment; filename=\""+result.FileName+"\"")
+	if nextCursor != "" {
+		header.Set(headerNextCursor, nextCursor)
+	}
+	return c.Blob(http.StatusOK, result.ContentType, result.Data)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// List CSV Tests - 一覧の Accept: text/csv のテスト
+// =============================================================================
+// テスト対象:
+//   - negotiateCSV: Accept の q 値・順序によるCSVの判定
+//   - GET /tasks, /crops, /crops/:id/harvests: Accept: text/csv のCSV、ページングの X-Next-Cursor
+
+// TestNegotiateCSV は Accept によるCSVの判定のテストです。
+// 期待動作:
+//   - text/csv が application/json より q 値が大きい、または同じ q 値で先に指定した場合はCSV
+//   - Accept なし・*/* のみ・application/json が優先される場合は JSON
+func TestNegotiateCSV(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"text/csv", true},
+		{"text/csv; charset=utf-8", true},
+		{"application/json, text/csv", false},
+		{"text/csv, application/json", true},
+		{"application/json;q=0.5, text/csv", true},
+		{"text/csv;q=0.1, application/json", false},
+		{"text/csv;q=0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Act
+			got := negotiateCSV(c)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("negotiateCSV(%q) = %v, want %v", tt.accept, got, tt.want)
+			}
+			if rec.Header().Get(echo.HeaderVary) != echo.HeaderAccept {
+				t.Errorf("Expected Vary: Accept, got %q", rec.Header().Get(echo.HeaderVary))
+			}
+		})
+	}
+}
+
+// TestListCSV_Endpoints は一覧のエンドポイントのCSVのテストです。
+// 期待動作:
+//   - Accept: text/csv の場合はエクスポートと同じ列のCSV（text/csv、Content-Disposition: attachment）
+//   - ステータスの絞り込み・ページングの結果のみを含み、次のページがある場合は X-Next-Cursor
+//   - Accept: application/json の場合は従来どおり JSON
+func TestListCSV_Endpoints(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+	jwtManager := auth.NewJWTManager("list-csv-test-secret-key-32-chars!", 24)
+	NewHandler(service.NewService(repository.NewMockRepositories()), jwtManager, nil).RegisterRoutes(e)
+	token, err := jwtManager.GenerateToken(1, "", "csv@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	request := func(method, path, accept, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, title := range []string{"水やり", "草取り"} {
+		if rec := request(http.MethodPost, "/api/v1/tasks", "", fmt.Sprintf(`{"title": %q, "due_date": "2026-05-01T00:00:00Z"}`, title)); rec.Code != http.StatusCreated {
+			t.Fatalf("CreateTask failed: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+	if rec := request(http.MethodPost, "/api/v1/crops", "", `{"name": "トマト", "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("CreateCrop failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := request(http.MethodPost, "/api/v1/crops/1/harvests", "", `{"harvest_date": "2026-07-01T00:00:00Z", "quantity": 1.5, "quantity_unit": "kg"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("CreateHarvest failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Act
+	tasks := request(http.MethodGet, "/api/v1/tasks", "text/csv", "")
+	page := request(http.MethodGet, "/api/v1/tasks?limit=1", "text/csv", "")
+	crops := request(http.MethodGet, "/api/v1/crops", "text/csv", "")
+	harvests := request(http.MethodGet, "/api/v1/crops/1/harvests", "text/csv", "")
+	asJSON := request(http.MethodGet, "/api/v1/tasks", echo.MIMEApplicationJSON, "")
+
+	// Assert
+	if tasks.Code != http.StatusOK || !strings.HasPrefix(tasks.Header().Get(echo.HeaderContentType), "text/csv") {
+		t.Fatalf("Expected CSV, got %d %q", tasks.Code, tasks.Header().Get(echo.HeaderContentType))
+	}
+	if !strings.HasPrefix(tasks.Header().Get("Content-Disposition"), "attachment;") {
+		t.Errorf("Expected attachment, got %q", tasks.Header().Get("Content-Disposition"))
+	}
+	if body := tasks.Body.String(); !strings.Contains(body, "ID,タイトル,説明") || !strings.Contains(body, "水やり") || !strings.Contains(body, "草取り") {
+		t.Errorf("Expected task CSV with both tasks, got %q", body)
+	}
+	if lines := strings.Count(strings.TrimSpace(page.Body.String()), "\n"); lines != 1 || page.Header().Get(headerNextCursor) == "" {
+		t.Errorf("Expected 1 row with X-Next-Cursor, got %d rows, cursor %q", lines, page.Header().Get(headerNextCursor))
+	}
+	if !strings.Contains(crops.Body.String(), "トマト") {
+		t.Errorf("Expected crop CSV, got %q", crops.Body.String())
+	}
+	if body := harvests.Body.String(); !strings.Contains(body, "作物名") || !strings.Contains(body, "トマト") || !strings.Contains(body, "1.50") {
+		t.Errorf("Expected harvest CSV with crop name, got %q", body)
+	}
+	if !strings.HasPrefix(asJSON.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		t.Errorf("Expected JSON for Accept: application/json, got %q", asJSON.Header().Get(echo.HeaderContentType))
+	}
+}
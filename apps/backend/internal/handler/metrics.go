@@ -0,0 +1,62 @@
+// Package handler - Metrics Handler
+//
+// Prometheusのスクレイプ用エンドポイントを提供します。
+// エンドポイント:
+//   - GET /metrics - 通知のチャネルごとの送信結果のカウンター、接続プールの統計（Prometheusテキスト形式）
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// prometheusContentType はPrometheusテキスト形式のContent-Typeです。
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// RegisterMetricsRoutes はPrometheusのスクレイプ用エンドポイントを登録します。
+// authToken を設定した場合は Authorization: Bearer <token> を必須にします。
+func (h *Handler) RegisterMetricsRoutes(e *echo.Echo, authToken string) {
+	e.GET("/metrics", h.GetMetrics, metricsAuthMiddleware(authToken))
+}
+
+// GetMetrics は通知の送信結果のカウンターと接続プールの統計をPrometheusテキスト形式で返します。
+//
+// レスポンス:
+//   - 200: notification_deliveries_total{channel, outcome}、db_pool_*{connection}、db_slow_queries_total
+//     （データベースのメトリクスは SetDatabaseMonitor で設定した場合のみ）
+//   - 401: 認証トークンが一致しない
+func (h *Handler) GetMetrics(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, prometheusContentType)
+	c.Response().WriteHeader(http.StatusOK)
+	if err := service.DefaultNotificationMetrics.WritePrometheus(c.Response()); err != nil {
+		return err
+	}
+	if h.dbMonitor == nil {
+		return nil
+	}
+	return h.dbMonitor.WritePrometheus(c.Response())
+}
+
+// metricsAuthMiddleware はメトリクス用の Bearer トークン認証ミドルウェアです。
+func metricsAuthMiddleware(expectedToken string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// トークンが設定されていない場合は認証をスキップ（開発環境用）
+			if expectedToken == "" {
+				return next(c)
+			}
+
+			token := c.Request().Header.Get(echo.HeaderAuthorization)
+			if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+expectedToken)) != 1 {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error":   "unauthorized",
+					"message": "無効な認証トークンです",
+				})
+			}
+			return next(c)
+		}
+	}
+}
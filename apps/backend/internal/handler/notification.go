@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
 )
 
 // =============================================================================
@@ -33,7 +36,24 @@ type UpdateNotificationSettingsRequest struct {
 	EmailEnabled              *bool `json:"email_enabled,omitempty"`
 	TaskReminders             *bool `json:"task_reminders,omitempty"`
 	HarvestReminders          *bool `json:"harvest_reminders,omitempty"`
+	HarvestReadyAlerts        *bool `json:"harvest_ready_alerts,omitempty"` // 収穫可能になった作物の通知（省略時は有効）
 	GrowthRecordNotifications *bool `json:"growth_record_notifications,omitempty"`
+	DigestNotifications       *bool `json:"digest_notifications,omitempty"` // 定期通知をダイジェストにまとめる
+	DailyReminderHour         *int  `json:"daily_reminder_hour,omitempty"` // 日次リマインダーの送信時刻（0-23、ユーザーのタイムゾーン）
+
+	SlackEnabled      *bool   `json:"slack_enabled,omitempty"`
+	SlackWebhookURL   *string `json:"slack_webhook_url,omitempty"`   // https://hooks.slack.com/services/...
+	DiscordEnabled    *bool   `json:"discord_enabled,omitempty"`
+	DiscordWebhookURL *string `json:"discord_webhook_url,omitempty"` // https://discord.com/api/webhooks/...
+
+	CustomWebhookEnabled *bool   `json:"custom_webhook_enabled,omitempty"`
+	CustomWebhookURL     *string `json:"custom_webhook_url,omitempty"` // 通知イベントのJSONを署名付きでPOSTするHTTPS URL
+
+	QuietHoursEnabled *bool   `json:"quiet_hours_enabled,omitempty"`
+	QuietHoursStart   *string `json:"quiet_hours_start,omitempty"` // HH:MM（ユーザーのタイムゾーン）
+	QuietHoursEnd     *string `json:"quiet_hours_end,omitempty"`   // HH:MM（ユーザーのタイムゾーン）
+
+	SMSEnabled *bool `json:"sms_enabled,omitempty"` // 重要なアラートをSMSでも受け取る（電話番号の認証が必要）
 }
 
 // NotificationSettingsResponse は通知設定レスポンスです。
@@ -42,7 +62,20 @@ type NotificationSettingsResponse struct {
 	EmailEnabled              bool   `json:"email_enabled"`
 	TaskReminders             bool   `json:"task_reminders"`
 	HarvestReminders          bool   `json:"harvest_reminders"`
+	HarvestReadyAlerts        bool   `json:"harvest_ready_alerts"`
 	GrowthRecordNotifications bool   `json:"growth_record_notifications"`
+	DigestNotifications       bool   `json:"digest_notifications"`
+	DailyReminderHour         *int   `json:"daily_reminder_hour,omitempty"`
+	SlackEnabled              bool   `json:"slack_enabled"`
+	SlackWebhookURL           string `json:"slack_webhook_url,omitempty"`
+	DiscordEnabled            bool   `json:"discord_enabled"`
+	DiscordWebhookURL         string `json:"discord_webhook_url,omitempty"`
+	CustomWebhookEnabled      bool   `json:"custom_webhook_enabled"`
+	CustomWebhookURL          string `json:"custom_webhook_url,omitempty"`
+	QuietHoursEnabled         bool   `json:"quiet_hours_enabled"`
+	QuietHoursStart           string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd             string `json:"quiet_hours_end,omitempty"`
+	SMSEnabled                bool   `json:"sms_enabled"`
 	Message                   string `json:"message,omitempty"`
 }
 
@@ -73,36 +106,24 @@ func (h *Handler) RegisterDeviceToken(c echo.Context) error {
 	// ユーザーIDを取得
 	userID, ok := c.Get("user_id").(uint)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error":   "unauthorized",
-			"message": "認証が必要です",
-		})
+		return apperrors.NewAuthenticationError("認証が必要です")
 	}
 
 	// リクエストをパース
 	var req RegisterDeviceTokenRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "invalid_request",
-			"message": "リクエストの形式が正しくありません",
-		})
+		return apperrors.NewBadRequestError("リクエストの形式が正しくありません")
 	}
 
 	// バリデーション
 	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "validation_error",
-			"message": err.Error(),
-		})
+		return err // バリデーターが VALIDATION_ERROR（項目ごとの内容付き）を返す
 	}
 
 	// サービス層でトークン登録/更新
 	deviceToken, err := h.service.RegisterDeviceToken(ctx, userID, req.Token, req.Platform, req.DeviceID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "registration_failed",
-			"message": "デバイストークンの登録に失敗しました",
-		})
+		return apperrors.NewInternalError("デバイストークンの登録に失敗しました")
 	}
 
 	return c.JSON(http.StatusOK, RegisterDeviceTokenResponse{
@@ -125,20 +146,14 @@ func (h *Handler) DeleteDeviceToken(c echo.Context) error {
 	// ユーザーIDを取得
 	userID, ok := c.Get("user_id").(uint)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error":   "unauthorized",
-			"message": "認証が必要です",
-		})
+		return apperrors.NewAuthenticationError("認証が必要です")
 	}
 
 	platform := c.QueryParam("platform")
 	if platform == "" {
 		// プラットフォーム指定なしの場合は全削除
 		if err := h.service.DeleteAllDeviceTokens(ctx, userID); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error":   "deletion_failed",
-				"message": "デバイストークンの削除に失敗しました",
-			})
+			return apperrors.NewInternalError("デバイストークンの削除に失敗しました")
 		}
 		return c.JSON(http.StatusOK, map[string]string{
 			"message": "全てのデバイストークンを削除しました",
@@ -147,10 +162,7 @@ func (h *Handler) DeleteDeviceToken(c echo.Context) error {
 
 	// 特定プラットフォームのトークンを削除
 	if err := h.service.DeleteDeviceTokenByPlatform(ctx, userID, platform); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "deletion_failed",
-			"message": "デバイストークンの削除に失敗しました",
-		})
+		return apperrors.NewInternalError("デバイストークンの削除に失敗しました")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -167,19 +179,13 @@ func (h *Handler) GetNotificationSettings(c echo.Context) error {
 	// ユーザーIDを取得
 	userID, ok := c.Get("user_id").(uint)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error":   "unauthorized",
-			"message": "認証が必要です",
-		})
+		return apperrors.NewAuthenticationError("認証が必要です")
 	}
 
 	// ユーザー情報を取得
 	user, err := h.service.GetUserByID(ctx, userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "fetch_failed",
-			"message": "ユーザー情報の取得に失敗しました",
-		})
+		return apperrors.NewInternalError("ユーザー情報の取得に失敗しました")
 	}
 
 	// 通知設定を返す（デフォルト値を設定）
@@ -199,7 +205,20 @@ func (h *Handler) GetNotificationSettings(c echo.Context) error {
 		EmailEnabled:              settings.EmailEnabled,
 		TaskReminders:             settings.TaskReminders,
 		HarvestReminders:          settings.HarvestReminders,
+		HarvestReadyAlerts:        getBoolValue(settings.HarvestReadyAlerts, true),
 		GrowthRecordNotifications: settings.GrowthRecordNotifications,
+		DigestNotifications:       settings.DigestNotifications,
+		DailyReminderHour:         settings.DailyReminderHour,
+		SlackEnabled:              settings.SlackEnabled,
+		SlackWebhookURL:           settings.SlackWebhookURL,
+		DiscordEnabled:            settings.DiscordEnabled,
+		DiscordWebhookURL:         settings.DiscordWebhookURL,
+		CustomWebhookEnabled:      settings.CustomWebhookEnabled,
+		CustomWebhookURL:          settings.CustomWebhookURL,
+		QuietHoursEnabled:         settings.QuietHoursEnabled,
+		QuietHoursStart:           settings.QuietHoursStart,
+		QuietHoursEnd:             settings.QuietHoursEnd,
+		SMSEnabled:                settings.SMSEnabled,
 	})
 }
 
@@ -214,7 +233,18 @@ func (h *Handler) GetNotificationSettings(c echo.Context) error {
 //	  "email_enabled": true,
 //	  "task_reminders": true,
 //	  "harvest_reminders": true,
-//	  "growth_record_notifications": false
+//	  "harvest_ready_alerts": true,
+//	  "growth_record_notifications": false,
+//	  "digest_notifications": true,
+//	  "daily_reminder_hour": 7,
+//	  "slack_enabled": true,
+//	  "slack_webhook_url": "https://hooks.slack.com/services/T000/B000/XXXX",
+//	  "discord_enabled": false,
+//	  "custom_webhook_enabled": true,
+//	  "custom_webhook_url": "https://home.example.com/hooks/garden",
+//	  "quiet_hours_enabled": true,
+//	  "quiet_hours_start": "21:00",
+//	  "quiet_hours_end": "08:00"
 //	}
 func (h *Handler) UpdateNotificationSettings(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -222,19 +252,13 @@ func (h *Handler) UpdateNotificationSettings(c echo.Context) error {
 	// ユーザーIDを取得
 	userID, ok := c.Get("user_id").(uint)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error":   "unauthorized",
-			"message": "認証が必要です",
-		})
+		return apperrors.NewAuthenticationError("認証が必要です")
 	}
 
 	// リクエストをパース
 	var req UpdateNotificationSettingsRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error":   "invalid_request",
-			"message": "リクエストの形式が正しくありません",
-		})
+		return apperrors.NewBadRequestError("リクエストの形式が正しくありません")
 	}
 
 	// サービス層で設定更新
@@ -243,13 +267,38 @@ func (h *Handler) UpdateNotificationSettings(c echo.Context) error {
 		EmailEnabled:              getBoolValue(req.EmailEnabled, true),
 		TaskReminders:             getBoolValue(req.TaskReminders, true),
 		HarvestReminders:          getBoolValue(req.HarvestReminders, true),
+		HarvestReadyAlerts:        req.HarvestReadyAlerts,
 		GrowthRecordNotifications: getBoolValue(req.GrowthRecordNotifications, false),
+		DigestNotifications:       getBoolValue(req.DigestNotifications, false),
+		DailyReminderHour:         req.DailyReminderHour,
+		SlackEnabled:              getBoolValue(req.SlackEnabled, false),
+		SlackWebhookURL:           getStringValue(req.SlackWebhookURL),
+		DiscordEnabled:            getBoolValue(req.DiscordEnabled, false),
+		DiscordWebhookURL:         getStringValue(req.DiscordWebhookURL),
+		CustomWebhookEnabled:      getBoolValue(req.CustomWebhookEnabled, false),
+		CustomWebhookURL:          getStringValue(req.CustomWebhookURL),
+		QuietHoursEnabled:         getBoolValue(req.QuietHoursEnabled, false),
+		QuietHoursStart:           getStringValue(req.QuietHoursStart),
+		QuietHoursEnd:             getStringValue(req.QuietHoursEnd),
+		SMSEnabled:                getBoolValue(req.SMSEnabled, false),
 	})
+	if errors.Is(err, service.ErrInvalidReminderHour) {
+		return apperrors.New(apperrors.ErrCodeInvalidReminderHour, "リマインダーの送信時刻は0〜23で指定してください")
+	}
+	if errors.Is(err, service.ErrInvalidQuietHours) {
+		return apperrors.New(apperrors.ErrCodeInvalidQuietHours, "おやすみモードの時刻はHH:MM形式で、開始と終了を異なる時刻にしてください")
+	}
+	if errors.Is(err, service.ErrPhoneNotVerified) {
+		return apperrors.New(apperrors.ErrCodePhoneNotVerified, "SMS通知を有効にするには電話番号を認証してください")
+	}
+	if errors.Is(err, service.ErrInvalidWebhookURL) {
+		return apperrors.New(apperrors.ErrCodeInvalidWebhookURL, "Webhook URLが正しくありません（Slack/Discordの公式Webhook URLを指定してください）")
+	}
+	if errors.Is(err, service.ErrInvalidCustomWebhookURL) {
+		return apperrors.New(apperrors.ErrCodeInvalidCustomWebhookURL, "汎用Webhook URLが正しくありません（内部ネットワーク以外のHTTPS URLを指定してください）")
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error":   "update_failed",
-			"message": "通知設定の更新に失敗しました",
-		})
+		return apperrors.NewInternalError("通知設定の更新に失敗しました")
 	}
 
 	return c.JSON(http.StatusOK, NotificationSettingsResponse{
@@ -257,7 +306,20 @@ func (h *Handler) UpdateNotificationSettings(c echo.Context) error {
 		EmailEnabled:              settings.EmailEnabled,
 		TaskReminders:             settings.TaskReminders,
 		HarvestReminders:          settings.HarvestReminders,
+		HarvestReadyAlerts:        getBoolValue(settings.HarvestReadyAlerts, true),
 		GrowthRecordNotifications: settings.GrowthRecordNotifications,
+		DigestNotifications:       settings.DigestNotifications,
+		DailyReminderHour:         settings.DailyReminderHour,
+		SlackEnabled:              settings.SlackEnabled,
+		SlackWebhookURL:           settings.SlackWebhookURL,
+		DiscordEnabled:            settings.DiscordEnabled,
+		DiscordWebhookURL:         settings.DiscordWebhookURL,
+		CustomWebhookEnabled:      settings.CustomWebhookEnabled,
+		CustomWebhookURL:          settings.CustomWebhookURL,
+		QuietHoursEnabled:         settings.QuietHoursEnabled,
+		QuietHoursStart:           settings.QuietHoursStart,
+		QuietHoursEnd:             settings.QuietHoursEnd,
+		SMSEnabled:                settings.SMSEnabled,
 		Message:                   "通知設定を更新しました",
 	})
 }
@@ -269,3 +331,11 @@ func getBoolValue(ptr *bool, defaultValue bool) bool {
 	}
 	return *ptr
 }
+
+// getStringValue は *string から string を取得します（nilの場合は空文字を返す）
+func getStringValue(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
@@ -0,0 +1,156 @@
+// Package handler - Notification Dead Letter Handler
+//
+// 管理者向けの送信できなかった通知イベント（デッドレター）のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/admin/notifications/dead-letters - デッドレター一覧
+//   - GET /api/v1/admin/notifications/dead-letters/:id - デッドレターの詳細（通知イベントを含む）
+//   - POST /api/v1/admin/notifications/dead-letters/:id/redrive - 通知イベントの再送信
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// NotificationDeadLetterResponse はデッドレターのレスポンスです。
+type NotificationDeadLetterResponse struct {
+	ID           uint            `json:"id"`
+	UserID       uint            `json:"user_id"`
+	EventType    string          `json:"event_type"`
+	Source       string          `json:"source"`    // outbox, notification_log
+	SourceID     uint            `json:"source_id"` // アウトボックスのイベントID・通知ログID
+	ErrorMessage string          `json:"error_message"`
+	Attempts     int             `json:"attempts"`
+	Status       string          `json:"status"` // open, redriven
+	Payload      json.RawMessage `json:"payload,omitempty"`
+	RedrivenAt   *time.Time      `json:"redriven_at,omitempty"`
+	RedrivenBy   uint            `json:"redriven_by,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// newNotificationDeadLetterResponse はデッドレターのレスポンスを作成します。
+// includePayload が true の場合は通知イベントのJSONを含めます（詳細・再送信のみ）。
+func newNotificationDeadLetterResponse(letter *model.NotificationDeadLetter, includePayload bool) NotificationDeadLetterResponse {
+	resp := NotificationDeadLetterResponse{
+		ID:           letter.ID,
+		UserID:       letter.UserID,
+		EventType:    letter.EventType,
+		Source:       letter.Source,
+		SourceID:     letter.SourceID,
+		ErrorMessage: letter.ErrorMessage,
+		Attempts:     letter.Attempts,
+		Status:       letter.Status,
+		RedrivenAt:   letter.RedrivenAt,
+		RedrivenBy:   letter.RedrivenBy,
+		CreatedAt:    letter.CreatedAt,
+	}
+	if includePayload && json.Valid([]byte(letter.Payload)) {
+		resp.Payload = json.RawMessage(letter.Payload)
+	}
+	return resp
+}
+
+// GetNotificationDeadLetters はデッドレターを新しい順に取得します。
+//
+// クエリパラメータ:
+//   - status: open, redriven（省略時は全件）
+//   - limit: 取得件数（デフォルト100、最大500）
+//
+// レスポンス:
+//   - 200: NotificationDeadLetterResponse の配列（payload は含まない）
+//   - 400: 無効なstatus・limit
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetNotificationDeadLetters(c echo.Context) error {
+	status := c.QueryParam("status")
+	if status != "" && status != service.DeadLetterStatusOpen && status != service.DeadLetterStatusRedriven {
+		return apperrors.NewBadRequestError("status must be open or redriven")
+	}
+	limit := service.DefaultDeadLetterListLimit
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > 500 {
+			return apperrors.NewBadRequestError("limit must be between 1 and 500")
+		}
+		limit = parsed
+	}
+
+	letters, err := h.service.ListNotificationDeadLetters(c.Request().Context(), status, limit)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get notification dead letters")
+	}
+
+	resp := make([]NotificationDeadLetterResponse, 0, len(letters))
+	for i := range letters {
+		resp = append(resp, newNotificationDeadLetterResponse(&letters[i], false))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetNotificationDeadLetter はデッドレターと通知イベントを取得します。
+//
+// パスパラメータ:
+//   - id: デッドレターID
+//
+// レスポンス:
+//   - 200: NotificationDeadLetterResponse（payload を含む）
+//   - 400: 無効なID形式
+//   - 404: デッドレターが見つからない
+func (h *Handler) GetNotificationDeadLetter(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid dead letter ID")
+	}
+
+	letter, err := h.service.GetNotificationDeadLetter(c.Request().Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrDeadLetterNotFound) {
+			return apperrors.NewNotFoundError("Dead letter")
+		}
+		return apperrors.NewInternalError("Failed to get notification dead letter")
+	}
+
+	return c.JSON(http.StatusOK, newNotificationDeadLetterResponse(letter, true))
+}
+
+// RedriveNotificationDeadLetter はデッドレターの通知イベントを再送信します。
+// イベントはアウトボックスに戻り、次回のディスパッチで送信されます。
+//
+// パスパラメータ:
+//   - id: デッドレターID
+//
+// レスポンス:
+//   - 202: 再送信を受け付けた NotificationDeadLetterResponse（status: redriven）
+//   - 400: 無効なID形式
+//   - 404: デッドレターが見つからない
+//   - 409: 再送信済み
+//   - 500: 内部エラー
+func (h *Handler) RedriveNotificationDeadLetter(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid dead letter ID")
+	}
+
+	letter, err := h.service.RedriveNotificationDeadLetter(c.Request().Context(), auth.GetUserIDFromContext(c), uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDeadLetterNotFound):
+			return apperrors.NewNotFoundError("Dead letter")
+		case errors.Is(err, service.ErrDeadLetterAlreadyRedriven):
+			return apperrors.NewConflictError("Dead letter has already been redriven")
+		}
+		return apperrors.NewInternalError("Failed to redrive notification dead letter")
+	}
+
+	return c.JSON(http.StatusAccepted, newNotificationDeadLetterResponse(letter, true))
+}
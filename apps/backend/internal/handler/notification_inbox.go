@@ -0,0 +1,146 @@
+// Package handler - Notification Inbox Handler
+//
+// アプリ内通知受信箱のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET  /api/v1/users/me/notifications              - 受信箱一覧（オフセット・カーソルによるページング、未読フィルタ）
+//   - GET  /api/v1/users/me/notifications/unread-count - 未読通知数
+//   - POST /api/v1/users/me/notifications/:id/read     - 通知を既読にする
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// UnreadNotificationCountResponse は未読通知数レスポンスです。
+type UnreadNotificationCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// GetNotificationInbox はユーザーの受信箱を最新順に取得します。
+//
+// クエリパラメータ:
+//   - limit: 取得件数（省略時: 20、最大: 100）
+//   - offset: 取得開始位置（省略時: 0）
+//   - cursor: 前のページの next_cursor（指定した場合は offset を使用せずカーソルで続きを取得）
+//   - unread: trueの場合は未読のみ取得
+//
+// レスポンス:
+//   - 200: 受信箱の1ページ分（items, total, unread_count, limit, offset, next_cursor）、
+//     cursor を指定した場合は items, next_cursor, has_more, limit の1ページ分
+//   - 400: 不正なクエリパラメータ
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetNotificationInbox(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			return apperrors.NewBadRequestError("Invalid limit")
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			return apperrors.NewBadRequestError("Invalid offset")
+		}
+		offset = parsed
+	}
+
+	unreadOnly := false
+	if unreadStr := c.QueryParam("unread"); unreadStr != "" {
+		parsed, err := strconv.ParseBool(unreadStr)
+		if err != nil {
+			return apperrors.NewBadRequestError("Invalid unread flag")
+		}
+		unreadOnly = parsed
+	}
+
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		params, err := pagination.NewParams(limit, cursor)
+		if err != nil {
+			return apperrors.New(apperrors.ErrCodeInvalidCursor, "Invalid cursor")
+		}
+		page, err := h.service.GetNotificationInboxPage(ctx, userID, unreadOnly, params)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to get notifications")
+		}
+		return c.JSON(http.StatusOK, page)
+	}
+
+	inbox, err := h.service.GetNotificationInbox(ctx, userID, limit, offset, unreadOnly)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get notifications")
+	}
+
+	return c.JSON(http.StatusOK, inbox)
+}
+
+// GetUnreadNotificationCount はユーザーの未読通知数を取得します。
+//
+// レスポンス:
+//   - 200: {"unread_count": 3}
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetUnreadNotificationCount(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	count, err := h.service.GetUnreadNotificationCount(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to count unread notifications")
+	}
+
+	return c.JSON(http.StatusOK, UnreadNotificationCountResponse{UnreadCount: count})
+}
+
+// MarkNotificationRead は受信箱の通知を既読にします。
+// 既読済みの通知に対しても成功を返します（冪等）。
+//
+// パスパラメータ:
+//   - id: 通知ID
+//
+// レスポンス:
+//   - 200: 既読にした通知
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 404: 通知が見つからない（他ユーザーの通知を含む）
+func (h *Handler) MarkNotificationRead(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid notification ID")
+	}
+
+	item, err := h.service.MarkNotificationRead(ctx, userID, uint(id))
+	if err != nil {
+		return apperrors.NewNotFoundError("Notification")
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
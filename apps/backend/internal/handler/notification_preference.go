@@ -0,0 +1,104 @@
+// Package handler - Notification Preference Handler
+//
+// イベントタイプ×チャネルの通知設定のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/users/me/notification-preferences - 通知設定マトリクス取得
+//   - PUT /api/v1/users/me/notification-preferences - 通知設定マトリクス更新
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// NotificationPreferenceRequest は通知設定マトリクスの1セルのリクエストです。
+type NotificationPreferenceRequest struct {
+	EventType string `json:"event_type" validate:"required"` // task_due_reminder, task_overdue_alert, harvest_reminder, daily_digest
+	Channel   string `json:"channel" validate:"required"`    // push, email, webhook
+	Enabled   bool   `json:"enabled"`
+}
+
+// UpdateNotificationPreferencesRequest は通知設定マトリクス更新リクエストです。
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,min=1,dive"`
+}
+
+// GetNotificationPreferences はユーザーの通知設定マトリクスを取得します。
+// 設定していない組み合わせは通知設定（/settings/notifications）から決まる既定値を返します。
+//
+// レスポンス:
+//   - 200: NotificationPreferenceMatrix（全イベントタイプ×チャネル）
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetNotificationPreferences(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	matrix, err := h.service.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get notification preferences")
+	}
+
+	return c.JSON(http.StatusOK, matrix)
+}
+
+// UpdateNotificationPreferences はユーザーの通知設定マトリクスを更新します。
+// リクエストに含めた組み合わせのみ更新します。
+//
+// リクエストボディ:
+//
+//	{
+//	  "preferences": [
+//	    {"event_type": "harvest_reminder", "channel": "push", "enabled": false},
+//	    {"event_type": "task_overdue_alert", "channel": "webhook", "enabled": true}
+//	  ]
+//	}
+//
+// レスポンス:
+//   - 200: 更新後の NotificationPreferenceMatrix
+//   - 400: リクエストボディの形式が不正、未知のイベントタイプ・チャネル
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateNotificationPreferences(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req UpdateNotificationPreferencesRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	items := make([]service.NotificationPreferenceItem, 0, len(req.Preferences))
+	for _, pref := range req.Preferences {
+		items = append(items, service.NotificationPreferenceItem{
+			EventType: pref.EventType,
+			Channel:   pref.Channel,
+			Enabled:   pref.Enabled,
+		})
+	}
+
+	matrix, err := h.service.UpdateNotificationPreferences(ctx, userID, items)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidNotificationPreference) {
+			return apperrors.NewBadRequestError("Unknown event type or channel")
+		}
+		return apperrors.NewInternalError("Failed to update notification preferences")
+	}
+
+	return c.JSON(http.StatusOK, matrix)
+}
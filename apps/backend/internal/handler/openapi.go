@@ -0,0 +1,56 @@
+// Package handler - OpenAPI Handler
+//
+// API の仕様（OpenAPI 3）と Swagger UI を提供します（開発環境のみ）。
+// エンドポイント:
+//   - GET /openapi.json - OpenAPI 3 の仕様（cmd/openapi で生成して埋め込んだもの）
+//   - GET /docs - Swagger UI
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/openapi"
+)
+
+// swaggerUIPage は /openapi.json を表示する Swagger UI のページです。
+// Swagger UI の本体（JavaScript・CSS）は CDN から読み込みます。
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="ja">
+<head>
+  <meta charset="utf-8">
+  <title>Home Garden Management API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// RegisterDocsRoutes は API の仕様と Swagger UI のルートを登録します。
+// 全エンドポイント（管理者・スケジューラー用を含む）が記載されるため、開発環境でのみ登録します。
+func RegisterDocsRoutes(e *echo.Echo) {
+	e.GET("/openapi.json", GetOpenAPISpec)
+	e.GET("/docs", GetSwaggerUI)
+}
+
+// GetOpenAPISpec は OpenAPI 3 の仕様を返します。
+//
+// レスポンス:
+//   - 200: OpenAPI 3 の仕様（JSON）
+func GetOpenAPISpec(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, openapi.Spec())
+}
+
+// GetSwaggerUI は仕様を表示する Swagger UI のページを返します。
+//
+// レスポンス:
+//   - 200: Swagger UI（HTML）
+func GetSwaggerUI(c echo.Context) error {
+	return c.HTML(http.StatusOK, swaggerUIPage)
+}
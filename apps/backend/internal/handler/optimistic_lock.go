@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+)
+
+// =============================================================================
+// Optimistic Lock - If-Match / version
+// =============================================================================
+// タスク・作物・区画の更新（PUT）は、取得した記録の version を If-Match ヘッダー（"3" の形式）
+// またはリクエストボディの version で指定する必要があります。
+// 現在の記録のバージョンと一致しない場合は 409 VERSION_CONFLICT（errors.current に現在の記録）を返し、
+// 同じ記録を同時に編集したメンバーの変更を黙って上書きしないようにします。
+
+// headerIfMatch は更新の前提とする記録のバージョンのヘッダーです。
+const headerIfMatch = "If-Match"
+
+// versionETag は記録のバージョンを If-Match に指定する形式（強い ETag）にします。
+// 更新のレスポンスの ETag に設定し、続けて更新する場合はそのまま If-Match に指定できます。
+func versionETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// requiredVersion は更新の前提とする記録のバージョンを返します。
+// If-Match ヘッダーがある場合はボディの version より優先します（W/ は無視）。
+//
+// 引数:
+//   - c: Echo コンテキスト
+//   - bodyVersion: リクエストボディの version（省略した場合は nil）
+//
+// 戻り値:
+//   - uint: 前提とするバージョン
+//   - error: どちらも指定していない場合は 428 PRECONDITION_REQUIRED、If-Match が不正な場合は 400
+func requiredVersion(c echo.Context, bodyVersion *uint) (uint, error) {
+	if ifMatch := strings.TrimSpace(c.Request().Header.Get(headerIfMatch)); ifMatch != "" {
+		value := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+		version, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || version == 0 {
+			return 0, apperrors.NewBadRequestError(`If-Match must be the record version (e.g. "3")`)
+		}
+		return uint(version), nil
+	}
+	if bodyVersion != nil {
+		return *bodyVersion, nil
+	}
+	return 0, apperrors.New(apperrors.ErrCodePreconditionRequired, "version is required; send If-Match or version")
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Optimistic Lock Tests - 楽観的ロックのテスト
+// =============================================================================
+// テスト対象:
+//   - requiredVersion: If-Match ヘッダー・ボディの version の指定と 428 / 400
+//   - タスク・作物・区画の更新: バージョンの一致による更新、ETag と version の更新
+//   - 古いバージョンでの更新による 409 VERSION_CONFLICT と errors.current
+
+// newOptimisticLockTestEcho は全ルートを登録したテスト用の Echo・モックリポジトリ・認証トークンを作成します。
+func newOptimisticLockTestEcho(t *testing.T) (*echo.Echo, *repository.MockRepositories, string) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	mockRepos := repository.NewMockRepositories()
+	jwtManager := auth.NewJWTManager("optimistic-lock-test-secret-32-chars", 24)
+	NewHandler(service.NewService(mockRepos), jwtManager, nil).RegisterRoutes(e)
+
+	token, err := jwtManager.GenerateToken(1, "", "optimistic@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return e, mockRepos, token
+}
+
+// versionConflictBody は 409 のレスポンスのボディです。
+type versionConflictBody struct {
+	Code   string `json:"code"`
+	Errors struct {
+		Current struct {
+			Version uint   `json:"version"`
+			Title   string `json:"title"`
+		} `json:"current"`
+	} `json:"errors"`
+}
+
+// TestOptimisticLock_Task はタスクの更新のバージョンのテストです。
+// 期待動作:
+//   - version も If-Match も指定しない場合は 428 PRECONDITION_REQUIRED
+//   - If-Match が一致する場合は更新し、version が進み ETag に新しいバージョンを返す
+//   - 古いバージョンの場合は更新せず 409 VERSION_CONFLICT と現在の記録を返す
+//   - If-Match が不正な場合は 400
+func TestOptimisticLock_Task(t *testing.T) {
+	// Arrange
+	e, _, token := newOptimisticLockTestEcho(t)
+	created := doConditional(e, token, http.MethodPost, "/api/v1/tasks", `{"title": "水やり", "due_date": "2026-10-20T09:00:00Z"}`, nil)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("Failed to create task: %d %s", created.Code, created.Body.String())
+	}
+
+	// Act
+	missing := doConditional(e, token, http.MethodPut, "/api/v1/tasks/1", `{"title": "水やり（朝）"}`, nil)
+	updated := doConditional(e, token, http.MethodPut, "/api/v1/tasks/1", `{"title": "水やり（朝）"}`, map[string]string{"If-Match": `"1"`})
+	stale := doConditional(e, token, http.MethodPut, "/api/v1/tasks/1", `{"title": "水やり（夕）", "version": 1}`, nil)
+	invalid := doConditional(e, token, http.MethodPut, "/api/v1/tasks/1", `{"title": "水やり（夕）"}`, map[string]string{"If-Match": "*"})
+
+	// Assert
+	if missing.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected 428 without a version, got %d", missing.Code)
+	}
+	if updated.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with a matching If-Match, got %d %s", updated.Code, updated.Body.String())
+	}
+	if got := updated.Header().Get("ETag"); got != `"2"` {
+		t.Errorf(`Expected ETag "2", got %q`, got)
+	}
+	var task struct {
+		Version uint `json:"version"`
+	}
+	if err := json.Unmarshal(updated.Body.Bytes(), &task); err != nil || task.Version != 2 {
+		t.Errorf("Expected version 2 in the response, got %d (%v)", task.Version, err)
+	}
+	if stale.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 with a stale version, got %d", stale.Code)
+	}
+	var conflict versionConflictBody
+	if err := json.Unmarshal(stale.Body.Bytes(), &conflict); err != nil {
+		t.Fatalf("Failed to decode the conflict: %v", err)
+	}
+	if conflict.Code != string(apperrors.ErrCodeVersionConflict) {
+		t.Errorf("Expected code %s, got %s", apperrors.ErrCodeVersionConflict, conflict.Code)
+	}
+	if conflict.Errors.Current.Version != 2 || conflict.Errors.Current.Title != "水やり（朝）" {
+		t.Errorf("Expected the current task (version 2) in errors.current, got %+v", conflict.Errors.Current)
+	}
+	if invalid.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 with an invalid If-Match, got %d", invalid.Code)
+	}
+}
+
+// TestOptimisticLock_ConcurrentUpdate は読み込んでから更新するまでに他の更新があった場合のテストです。
+// 期待動作:
+//   - リポジトリの更新が ErrVersionConflict の場合は 409 VERSION_CONFLICT を返す
+func TestOptimisticLock_ConcurrentUpdate(t *testing.T) {
+	// Arrange
+	e, mockRepos, token := newOptimisticLockTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/crops", `{"name": "トマト", "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create crop: %d %s", rec.Code, rec.Body.String())
+	}
+	mockRepos.GetMockCropRepository().UpdateFunc = func(ctx context.Context, crop *model.Crop) error {
+		return repository.ErrVersionConflict
+	}
+
+	// Act
+	rec := doConditional(e, token, http.MethodPut, "/api/v1/crops/1", `{"name": "ミニトマト", "version": 1}`, nil)
+
+	// Assert
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected 409, got %d %s", rec.Code, rec.Body.String())
+	}
+	var conflict versionConflictBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &conflict); err != nil || conflict.Code != string(apperrors.ErrCodeVersionConflict) {
+		t.Errorf("Expected code %s, got %q (%v)", apperrors.ErrCodeVersionConflict, conflict.Code, err)
+	}
+}
+
+// TestOptimisticLock_Plot は区画の更新のバージョンのテストです。
+// 期待動作:
+//   - ボディの version が一致する場合は更新し、続けて ETag を If-Match に指定して更新できる
+func TestOptimisticLock_Plot(t *testing.T) {
+	// Arrange
+	e, _, token := newOptimisticLockTestEcho(t)
+	if rec := doConditional(e, token, http.MethodPost, "/api/v1/plots", `{"name": "A区画", "width": 2, "height": 3}`, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("Failed to create plot: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Act
+	first := doConditional(e, token, http.MethodPut, "/api/v1/plots/1", `{"name": "B区画", "version": 1}`, nil)
+	second := doConditional(e, token, http.MethodPut, "/api/v1/plots/1", `{"name": "C区画"}`, map[string]string{"If-Match": first.Header().Get("ETag")})
+
+	// Assert
+	if first.Code != http.StatusOK || second.Code != http.StatusOK {
+		t.Fatalf("Expected both updates to succeed, got %d and %d %s", first.Code, second.Code, second.Body.String())
+	}
+	if got := second.Header().Get("ETag"); got != `"3"` {
+		t.Errorf(`Expected ETag "3", got %q`, got)
+	}
+}
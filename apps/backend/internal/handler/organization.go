@@ -0,0 +1,382 @@
+// Package handler - Organization Handler
+//
+// 区画・作物を共有する組織（コミュニティガーデン・学校）のHTTPハンドラと、X-Org-ID ヘッダーの組織のスコープを設定するミドルウェアを提供します。
+// エンドポイント:
+//   - GET    /api/v1/organizations                      - 所属している組織の一覧
+//   - POST   /api/v1/organizations                      - 組織の作成（作成したユーザーが owner）
+//   - GET    /api/v1/organizations/:id                  - 組織の詳細（役割・区画・作物・メンバーの数）
+//   - GET    /api/v1/organizations/:id/members          - 組織のメンバーの一覧（メンバー）
+//   - POST   /api/v1/organizations/:id/members          - メンバーの追加（owner・admin のみ）
+//   - DELETE /api/v1/organizations/:id/members/:userId  - メンバーの削除（owner・admin、またはメンバー本人の退出）
+//   - PUT    /api/v1/admin/organizations/:id/quotas     - 組織の上限の変更（管理者のみ）
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// HeaderOrganizationID は区画・作物を組織のものとして扱うリクエストのヘッダーです（値は組織ID）。
+const HeaderOrganizationID = "X-Org-ID"
+
+// =============================================================================
+// Request/Response 構造体
+// =============================================================================
+
+// CreateOrganizationRequest は組織の作成のリクエストボディです。
+//
+// フィールド:
+//   - Name: 組織名（必須、100文字以内）
+//   - Type: 組織の種類（community_garden, school）
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Type string `json:"type" validate:"required,oneof=community_garden school"`
+}
+
+// AddOrganizationMemberRequest は組織のメンバーの追加のリクエストボディです。
+//
+// フィールド:
+//   - Email: 追加するユーザーのメールアドレス（登録済みのユーザーのみ）
+//   - Role: 役割（admin, member, viewer、デフォルト: member）
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"omitempty,oneof=admin member viewer"`
+}
+
+// UpdateOrganizationQuotasRequest は組織の上限の変更のリクエストボディです（0 の場合は無制限）。
+type UpdateOrganizationQuotasRequest struct {
+	MaxPlots   int `json:"max_plots" validate:"gte=0"`
+	MaxCrops   int `json:"max_crops" validate:"gte=0"`
+	MaxMembers int `json:"max_members" validate:"gte=0"`
+}
+
+// OrganizationDetailResponse は組織の詳細のレスポンスです。
+type OrganizationDetailResponse struct {
+	dto.OrganizationResponse
+	Role  string                    `json:"role"`  // リクエストしたユーザーの役割
+	Usage service.OrganizationUsage `json:"usage"` // 現在の区画・作物・メンバーの数
+}
+
+// =============================================================================
+// ミドルウェア
+// =============================================================================
+
+// organizationScopeMiddleware は X-Org-ID ヘッダーの組織のスコープをリクエストの context に設定します。
+// ヘッダーがない場合は個人のスコープで、区画・作物のリポジトリは組織の区画・作物を返しません。
+// 組織のメンバーでない場合は 403、viewer の GET・HEAD 以外のリクエストは 403 を返します。
+func (h *Handler) organizationScopeMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			raw := req.Header.Get(HeaderOrganizationID)
+			if raw == "" {
+				c.SetRequest(req.WithContext(repository.ContextWithOrganization(req.Context(), 0)))
+				return next(c)
+			}
+
+			organizationID, err := strconv.ParseUint(raw, 10, 32)
+			if err != nil || organizationID == 0 {
+				return apperrors.NewBadRequestError("Invalid X-Org-ID header")
+			}
+			userID := auth.GetUserIDFromContext(c)
+			if userID == 0 {
+				return apperrors.NewAuthenticationError("Not authenticated")
+			}
+
+			member, err := h.service.GetOrganizationMembership(req.Context(), uint(organizationID), userID)
+			if err != nil {
+				return organizationError(err, "Failed to resolve organization")
+			}
+			if member.Role == model.OrganizationRoleViewer && req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return apperrors.NewAuthorizationError("Organization viewers cannot modify resources")
+			}
+
+			c.SetRequest(req.WithContext(repository.ContextWithOrganization(req.Context(), uint(organizationID))))
+			return next(c)
+		}
+	}
+}
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// GetOrganizations は認証ユーザーが所属している組織の一覧を返します。
+//
+// レスポンス:
+//   - 200: 組織の一覧（ID順）
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetOrganizations(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	organizations, err := h.service.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to fetch organizations")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(organizations, dto.NewOrganizationResponse))
+}
+
+// CreateOrganization は組織を作成します。作成したユーザーは組織の owner になります。
+// 区画・作物・メンバーの数の上限はサーバーのデフォルトで、管理者が変更できます。
+//
+// レスポンス:
+//   - 201: 作成した組織
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) CreateOrganization(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req CreateOrganizationRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	organization, err := h.service.CreateOrganization(ctx, userID, req.Name, req.Type)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to create organization")
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewOrganizationResponse(organization))
+}
+
+// GetOrganization は組織の詳細（リクエストしたユーザーの役割、現在の区画・作物・メンバーの数）を返します。
+//
+// パスパラメータ:
+//   - id: 組織ID
+//
+// レスポンス:
+//   - 200: 組織の詳細（role, usage を含む）
+//   - 400: 不正な組織ID
+//   - 401: 認証エラー
+//   - 403: 組織のメンバーでない
+//   - 404: 組織が見つからない（ORGANIZATION_NOT_FOUND）
+//   - 500: 内部エラー
+func (h *Handler) GetOrganization(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid organization ID")
+	}
+
+	organization, member, usage, err := h.service.GetOrganization(ctx, userID, uint(organizationID))
+	if err != nil {
+		return organizationError(err, "Failed to fetch organization")
+	}
+
+	return c.JSON(http.StatusOK, OrganizationDetailResponse{
+		OrganizationResponse: dto.NewOrganizationResponse(organization),
+		Role:                 member.Role,
+		Usage:                *usage,
+	})
+}
+
+// GetOrganizationMembers は組織のメンバーの一覧を返します（owner を含む）。
+//
+// パスパラメータ:
+//   - id: 組織ID
+//
+// レスポンス:
+//   - 200: メンバーの一覧（追加順）
+//   - 400: 不正な組織ID
+//   - 401: 認証エラー
+//   - 403: 組織のメンバーでない
+//   - 404: 組織が見つからない
+//   - 500: 内部エラー
+func (h *Handler) GetOrganizationMembers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid organization ID")
+	}
+
+	members, err := h.service.GetOrganizationMembers(ctx, userID, uint(organizationID))
+	if err != nil {
+		return organizationError(err, "Failed to fetch organization members")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(members, dto.NewOrganizationMemberResponse))
+}
+
+// AddOrganizationMember はメールアドレスのユーザーを組織のメンバーに追加します。
+//
+// パスパラメータ:
+//   - id: 組織ID
+//
+// レスポンス:
+//   - 201: 追加したメンバー（ユーザー情報付き）
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 組織の owner・admin でない、メンバー数が上限（ORGANIZATION_QUOTA_EXCEEDED）
+//   - 404: 組織・ユーザーが見つからない
+//   - 409: すでにメンバー（ORGANIZATION_MEMBER_EXISTS）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) AddOrganizationMember(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid organization ID")
+	}
+
+	var req AddOrganizationMemberRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.Role == "" {
+		req.Role = model.OrganizationRoleMember
+	}
+
+	member, err := h.service.AddOrganizationMember(ctx, userID, uint(organizationID), req.Email, req.Role)
+	if err != nil {
+		return organizationError(err, "Failed to add organization member")
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewOrganizationMemberResponse(member))
+}
+
+// RemoveOrganizationMember は組織のメンバーを削除します。
+// owner・admin は全てのメンバーを、メンバーは自分自身（組織からの退出）を削除できます。owner は削除できません。
+//
+// パスパラメータ:
+//   - id: 組織ID
+//   - userId: 削除するメンバーのユーザーID
+//
+// レスポンス:
+//   - 204: 削除成功
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 403: 組織の owner・admin・メンバー本人でない
+//   - 404: 組織が見つからない、ユーザーがメンバーでない（ORGANIZATION_MEMBER_NOT_FOUND）
+//   - 409: owner は削除できない（ORGANIZATION_OWNER_CANNOT_LEAVE）
+//   - 500: 内部エラー
+func (h *Handler) RemoveOrganizationMember(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid organization ID")
+	}
+	memberUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid user ID")
+	}
+
+	if err := h.service.RemoveOrganizationMember(ctx, userID, uint(organizationID), uint(memberUserID)); err != nil {
+		return organizationError(err, "Failed to remove organization member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UpdateOrganizationQuotas は組織の区画・作物・メンバーの数の上限を変更します（管理者のみ）。
+// 現在の数が新しい上限を超えていても既存の記録は削除せず、新しい作成・追加のみ拒否します。
+//
+// パスパラメータ:
+//   - id: 組織ID
+//
+// レスポンス:
+//   - 200: 変更した組織
+//   - 400: 不正な組織ID・リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 404: 組織が見つからない（ORGANIZATION_NOT_FOUND）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateOrganizationQuotas(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid organization ID")
+	}
+
+	var req UpdateOrganizationQuotasRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	organization, err := h.service.UpdateOrganizationQuotas(ctx, uint(organizationID), service.OrganizationQuotas{
+		MaxPlots:   req.MaxPlots,
+		MaxCrops:   req.MaxCrops,
+		MaxMembers: req.MaxMembers,
+	})
+	if err != nil {
+		return organizationError(err, "Failed to update organization quotas")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewOrganizationResponse(organization))
+}
+
+// organizationError は組織のサービスのエラーをAPIのエラーに変換します。
+func organizationError(err error, internalMessage string) error {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound):
+		return apperrors.NewNotFoundError("Organization")
+	case errors.Is(err, service.ErrOrganizationAccessDenied):
+		return apperrors.NewAuthorizationError("Not allowed to access this organization")
+	case errors.Is(err, service.ErrOrganizationMemberUserNotFound):
+		return apperrors.NewNotFoundError("User")
+	case errors.Is(err, service.ErrOrganizationMemberExists):
+		return apperrors.New(apperrors.ErrCodeOrganizationMemberExists, "User is already a member of this organization")
+	case errors.Is(err, service.ErrOrganizationMemberNotFound):
+		return apperrors.NewNotFoundError("Organization member")
+	case errors.Is(err, service.ErrOrganizationOwnerCannotLeave):
+		return apperrors.New(apperrors.ErrCodeOrganizationOwnerCannotLeave, "Organization owner cannot be removed")
+	case errors.Is(err, service.ErrOrganizationQuotaExceeded):
+		return apperrors.New(apperrors.ErrCodeOrganizationQuotaExceeded, "Organization quota exceeded")
+	}
+	return apperrors.NewInternalError(internalMessage)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Organization Tests - 組織と X-Org-ID のスコープのテスト
+// =============================================================================
+// テスト対象:
+//   - organizationScopeMiddleware: X-Org-ID の検証、メンバー以外・viewer の変更の拒否
+//   - X-Org-ID を指定した区画・作物の作成・一覧（組織のもの）、指定しない一覧（個人のもの）
+//   - 組織の上限（ORGANIZATION_QUOTA_EXCEEDED）、管理者による上限の変更
+
+// organizationTestSetup は全ルームを登録したテスト用のサーバーと組織のユーザーです。
+type organizationTestSetup struct {
+	echo       *echo.Echo
+	jwtManager *auth.JWTManager
+	org        *model.Organization
+	owner      *model.User
+	viewer     *model.User
+	outsider   *model.User
+	admin      *model.User
+}
+
+// newOrganizationTestSetup は owner の組織（区画の上限は1）と viewer・部外者・管理者のユーザーを作成します。
+func newOrganizationTestSetup(t *testing.T) *organizationTestSetup {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	mockRepos := repository.NewMockRepositories()
+	svc := service.NewService(mockRepos)
+	svc.SetOrganizationQuotaDefaults(service.OrganizationQuotas{MaxPlots: 1})
+	jwtManager := auth.NewJWTManager("organization-test-secret-key-32ch", 24)
+	NewHandler(svc, jwtManager, nil).RegisterRoutes(e)
+
+	ctx := context.Background()
+	setup := &organizationTestSetup{echo: e, jwtManager: jwtManager}
+	for i, user := range []**model.User{&setup.owner, &setup.viewer, &setup.outsider, &setup.admin} {
+		*user = &model.User{Email: fmt.Sprintf("org-user%d@example.com", i+1), DisplayName: fmt.Sprintf("User %d", i+1), IsActive: true}
+		if err := mockRepos.User().Create(ctx, *user); err != nil {
+			t.Fatalf("Create user failed: %v", err)
+		}
+	}
+	setup.admin.IsAdmin = true
+
+	org, err := svc.CreateOrganization(ctx, setup.owner.ID, "市民農園", model.OrganizationTypeCommunityGarden)
+	if err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	if _, err := svc.AddOrganizationMember(ctx, setup.owner.ID, org.ID, setup.viewer.Email, model.OrganizationRoleViewer); err != nil {
+		t.Fatalf("AddOrganizationMember failed: %v", err)
+	}
+	setup.org = org
+	return setup
+}
+
+// request は認証付きのリクエストを送信します（orgID が空の場合は X-Org-ID を指定しない）。
+func (s *organizationTestSetup) request(t *testing.T, user *model.User, method, path, orgID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	token, err := s.jwtManager.GenerateToken(user.ID, "", user.Email)
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	if orgID != "" {
+		req.Header.Set(HeaderOrganizationID, orgID)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestOrganization_ScopeMiddleware は X-Org-ID のスコープのテストです。
+// 期待動作:
+//   - 不正な X-Org-ID は 400、存在しない組織は 404、メンバーでない場合は 403
+//   - viewer は組織の区画を一覧できるが、作成は 403
+//   - owner が X-Org-ID を指定して作成した区画は組織の区画で、上限を超える作成は 403 ORGANIZATION_QUOTA_EXCEEDED
+//   - X-Org-ID を指定しない一覧には組織の区画を含めない
+func TestOrganization_ScopeMiddleware(t *testing.T) {
+	// Arrange
+	s := newOrganizationTestSetup(t)
+	orgID := fmt.Sprint(s.org.ID)
+	plotBody := `{"name": "A-1", "width": 1, "height": 1}`
+
+	tests := []struct {
+		name       string
+		user       *model.User
+		method     string
+		orgID      string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid header", s.owner, http.MethodGet, "abc", "", http.StatusBadRequest, apperrors.ErrCodeBadRequest},
+		{"unknown organization", s.owner, http.MethodGet, "999", "", http.StatusNotFound, apperrors.ErrCodeOrganizationNotFound},
+		{"outsider", s.outsider, http.MethodGet, orgID, "", http.StatusForbidden, apperrors.ErrCodeAuthorization},
+		{"viewer create", s.viewer, http.MethodPost, orgID, plotBody, http.StatusForbidden, apperrors.ErrCodeAuthorization},
+		{"owner create", s.owner, http.MethodPost, orgID, plotBody, http.StatusCreated, ""},
+		{"quota exceeded", s.owner, http.MethodPost, orgID, plotBody, http.StatusForbidden, apperrors.ErrCodeOrganizationQuotaExceeded},
+		{"viewer list", s.viewer, http.MethodGet, orgID, "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rec := s.request(t, tt.user, tt.method, "/api/v1/plots", tt.orgID, tt.body)
+
+			// Assert
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(rec.Body.String(), `"code":"`+tt.wantCode+`"`) {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+
+	// 組織の区画は viewer の組織のスコープの一覧に含まれ、owner の個人の一覧には含まれない
+	var orgPlots, personalPlots []map[string]any
+	_ = json.Unmarshal(s.request(t, s.viewer, http.MethodGet, "/api/v1/plots", orgID, "").Body.Bytes(), &orgPlots)
+	_ = json.Unmarshal(s.request(t, s.owner, http.MethodGet, "/api/v1/plots", "", "").Body.Bytes(), &personalPlots)
+	if len(orgPlots) != 1 || orgPlots[0]["organization_id"] != float64(s.org.ID) {
+		t.Errorf("Expected organization plot in organization scope, got %v", orgPlots)
+	}
+	if len(personalPlots) != 0 {
+		t.Errorf("Expected no personal plots, got %v", personalPlots)
+	}
+}
+
+// TestOrganization_Endpoints は組織のエンドポイントのテストです。
+// 期待動作:
+//   - 組織の詳細は役割と区画・作物・メンバーの数を返す（部外者は 403）
+//   - viewer はメンバーを追加できない（403）、owner は削除できない（409）
+//   - 上限の変更は管理者のみ（それ以外は 403）
+func TestOrganization_Endpoints(t *testing.T) {
+	// Arrange
+	s := newOrganizationTestSetup(t)
+	base := fmt.Sprintf("/api/v1/organizations/%d", s.org.ID)
+
+	// Act
+	detail := s.request(t, s.viewer, http.MethodGet, base, "", "")
+	outsiderDetail := s.request(t, s.outsider, http.MethodGet, base, "", "")
+	viewerAdd := s.request(t, s.viewer, http.MethodPost, base+"/members", "", `{"email": "org-user3@example.com"}`)
+	removeOwner := s.request(t, s.owner, http.MethodDelete, fmt.Sprintf("%s/members/%d", base, s.owner.ID), "", "")
+	quotasPath := fmt.Sprintf("/api/v1/admin/organizations/%d/quotas", s.org.ID)
+	ownerQuotas := s.request(t, s.owner, http.MethodPut, quotasPath, "", `{"max_plots": 10}`)
+	adminQuotas := s.request(t, s.admin, http.MethodPut, quotasPath, "", `{"max_plots": 10, "max_crops": 20, "max_members": 30}`)
+
+	// Assert
+	var body OrganizationDetailResponse
+	if err := json.Unmarshal(detail.Body.Bytes(), &body); err != nil || detail.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", detail.Code, detail.Body.String())
+	}
+	if body.Role != model.OrganizationRoleViewer || body.Usage.Members != 2 || body.Name != "市民農園" {
+		t.Errorf("Unexpected detail: %+v", body)
+	}
+	if outsiderDetail.Code != http.StatusForbidden || viewerAdd.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d %d", outsiderDetail.Code, viewerAdd.Code)
+	}
+	if removeOwner.Code != http.StatusConflict || !strings.Contains(removeOwner.Body.String(), apperrors.ErrCodeOrganizationOwnerCannotLeave) {
+		t.Errorf("Expected 409 ORGANIZATION_OWNER_CANNOT_LEAVE, got %d: %s", removeOwner.Code, removeOwner.Body.String())
+	}
+	if ownerQuotas.Code != http.StatusForbidden {
+		t.Errorf("Expected non-admin quota update to be 403, got %d", ownerQuotas.Code)
+	}
+	if adminQuotas.Code != http.StatusOK || !strings.Contains(adminQuotas.Body.String(), `"max_members":30`) {
+		t.Errorf("Expected admin quota update, got %d: %s", adminQuotas.Code, adminQuotas.Body.String())
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// paginationParams はクエリパラメータ（limit, cursor）からカーソルページングの指定を取得します。
+// 一覧のエンドポイントはどちらかのパラメータを指定した場合のみ共通の形式（pagination.Page）で1ページずつ返し、
+// 指定しない場合はこれまでどおり全件の配列を返します。
+//
+// 戻り値:
+//   - pagination.Params: ページングの指定
+//   - bool: limit または cursor を指定した場合は true
+//   - error: 不正な limit・cursor の場合は INVALID_CURSOR（400）
+func paginationParams(c echo.Context) (pagination.Params, bool, error) {
+	limit, cursor, ok, err := paginationQuery(c)
+	if err != nil || !ok {
+		return pagination.Params{}, false, err
+	}
+
+	params, err := pagination.NewParams(limit, cursor)
+	if err != nil {
+		return pagination.Params{}, false, apperrors.New(apperrors.ErrCodeInvalidCursor, "Invalid cursor")
+	}
+	return params, true, nil
+}
+
+// keyPaginationParams はクエリパラメータ（limit, cursor）から列の順（期限日順など）のカーソルページングの指定を取得します。
+// paginationParams と同じく、どちらかのパラメータを指定した場合のみページングします。
+//
+// 戻り値:
+//   - pagination.KeyParams: ページングの指定
+//   - bool: limit または cursor を指定した場合は true
+//   - error: 不正な limit・cursor（別の並び順のカーソルを含む）の場合は INVALID_CURSOR（400）
+func keyPaginationParams(c echo.Context) (pagination.KeyParams, bool, error) {
+	limit, cursor, ok, err := paginationQuery(c)
+	if err != nil || !ok {
+		return pagination.KeyParams{}, false, err
+	}
+
+	params, err := pagination.NewKeyParams(limit, cursor)
+	if err != nil {
+		return pagination.KeyParams{}, false, apperrors.New(apperrors.ErrCodeInvalidCursor, "Invalid cursor")
+	}
+	return params, true, nil
+}
+
+// paginationQuery はクエリパラメータの limit と cursor を取得します（どちらも指定しない場合は ok が false）。
+func paginationQuery(c echo.Context) (limit int, cursor string, ok bool, err error) {
+	limitStr := c.QueryParam("limit")
+	cursor = c.QueryParam("cursor")
+	if limitStr == "" && cursor == "" {
+		return 0, "", false, nil
+	}
+
+	if limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			return 0, "", false, apperrors.New(apperrors.ErrCodeInvalidCursor, "Invalid limit")
+		}
+		limit = parsed
+	}
+	return limit, cursor, true, nil
+}
@@ -0,0 +1,163 @@
+// Package handler - Phone Verification Handler
+//
+// SMS通知用の電話番号認証のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/users/me/phone - 電話番号の認証状況取得
+//   - POST /api/v1/users/me/phone/verification - 認証コードをSMSで送信
+//   - POST /api/v1/users/me/phone/verification/confirm - 認証コードを確認
+//   - DELETE /api/v1/users/me/phone - 電話番号を削除（SMS通知も無効化）
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// StartPhoneVerificationRequest は電話番号認証の開始リクエストです。
+type StartPhoneVerificationRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required"` // E.164形式（例: +819012345678）
+}
+
+// ConfirmPhoneVerificationRequest は認証コードの確認リクエストです。
+type ConfirmPhoneVerificationRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// GetPhoneVerificationStatus は電話番号の認証状況を取得します。
+//
+// レスポンス:
+//   - 200: PhoneVerificationStatus
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetPhoneVerificationStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	status, err := h.service.GetPhoneVerificationStatus(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get phone verification status")
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// StartPhoneVerification は電話番号の認証を開始し、認証コードをSMSで送信します。
+//
+// リクエストボディ:
+//
+//	{
+//	  "phone_number": "+819012345678"
+//	}
+//
+// レスポンス:
+//   - 200: PhoneVerificationStatus（認証中の番号と認証コードの有効期限）
+//   - 400: 電話番号がE.164形式でない
+//   - 401: 認証エラー
+//   - 409: 再送信間隔内（1分、番号に関わらずユーザーごと）、送信数の上限（1時間に5回・1日に10回）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: SMSの送信設定がない
+//   - 500: 内部エラー
+func (h *Handler) StartPhoneVerification(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req StartPhoneVerificationRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	status, err := h.service.StartPhoneVerification(ctx, userID, req.PhoneNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPhoneNumber):
+			return apperrors.NewBadRequestError("Phone number must be in E.164 format")
+		case errors.Is(err, service.ErrPhoneVerificationTooSoon):
+			return apperrors.NewConflictError("Verification code was sent recently")
+		case errors.Is(err, service.ErrPhoneVerificationSendLimit):
+			return apperrors.NewConflictError("Too many verification codes were sent. Please try again later")
+		case errors.Is(err, service.ErrSMSUnavailable):
+			return apperrors.NewServiceUnavailableError("SMS is not available")
+		}
+		return apperrors.NewInternalError("Failed to send verification code")
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// ConfirmPhoneVerification は認証コードを確認し、電話番号を認証済みにします。
+//
+// リクエストボディ:
+//
+//	{
+//	  "code": "123456"
+//	}
+//
+// レスポンス:
+//   - 200: PhoneVerificationStatus（認証済みの番号）
+//   - 400: 認証コードが一致しない、認証中の番号がない・失効している、入力失敗の上限に達した
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) ConfirmPhoneVerification(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req ConfirmPhoneVerificationRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	status, err := h.service.ConfirmPhoneVerification(ctx, userID, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPhoneVerificationCodeMismatch):
+			return apperrors.NewBadRequestError("Verification code does not match")
+		case errors.Is(err, service.ErrPhoneVerificationNotFound):
+			return apperrors.NewBadRequestError("No pending verification or code expired")
+		case errors.Is(err, service.ErrPhoneVerificationTooManyAttempts):
+			return apperrors.NewBadRequestError("Too many attempts, request a new code")
+		}
+		return apperrors.NewInternalError("Failed to verify phone number")
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// RemovePhoneNumber は電話番号を削除し、SMS通知を無効にします。
+//
+// レスポンス:
+//   - 204: 削除成功
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) RemovePhoneNumber(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	if err := h.service.RemovePhoneNumber(ctx, userID); err != nil {
+		return apperrors.NewInternalError("Failed to remove phone number")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -6,27 +6,37 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/validator"
 )
 
+// UpdatePlantRequest represents the request body for updating a plant
+// 指定した項目のみ更新します（空の項目は変更しません）。
+type UpdatePlantRequest struct {
+	Name        string    `json:"name" validate:"max=100"`
+	Species     string    `json:"species" validate:"max=100"`
+	PlantedAt   time.Time `json:"planted_at"`
+	HarvestedAt time.Time `json:"harvested_at"`
+	Status      string    `json:"status" validate:"max=50"`
+	Notes       string    `json:"notes" validate:"max=1000"`
+}
+
 // GetPlant returns a specific plant
 func (h *Handler) GetPlant(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid plant ID",
-		})
+		return apperrors.NewBadRequestError("Invalid plant ID")
 	}
 
 	plant, err := h.service.GetPlantByID(ctx, uint(id))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "Plant not found",
-		})
+		return apperrors.NewNotFoundError("Plant")
 	}
 
-	return c.JSON(http.StatusOK, plant)
+	return c.JSON(http.StatusOK, dto.NewPlantResponse(plant))
 }
 
 // UpdatePlant updates an existing plant
@@ -34,31 +44,44 @@ func (h *Handler) UpdatePlant(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid plant ID",
-		})
+		return apperrors.NewBadRequestError("Invalid plant ID")
+	}
+
+	var req UpdatePlantRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
 	}
 
 	plant, err := h.service.GetPlantByID(ctx, uint(id))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "Plant not found",
-		})
+		return apperrors.NewNotFoundError("Plant")
 	}
 
-	if err := c.Bind(plant); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	// Update fields
+	if req.Name != "" {
+		plant.Name = req.Name
+	}
+	if req.Species != "" {
+		plant.Species = req.Species
+	}
+	if !req.PlantedAt.IsZero() {
+		plant.PlantedAt = req.PlantedAt
+	}
+	if !req.HarvestedAt.IsZero() {
+		plant.HarvestedAt = req.HarvestedAt
+	}
+	if req.Status != "" {
+		plant.Status = req.Status
+	}
+	if req.Notes != "" {
+		plant.Notes = req.Notes
 	}
 
 	if err := h.service.UpdatePlant(ctx, plant); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to update plant",
-		})
+		return apperrors.NewInternalError("Failed to update plant")
 	}
 
-	return c.JSON(http.StatusOK, plant)
+	return c.JSON(http.StatusOK, dto.NewPlantResponse(plant))
 }
 
 // DeletePlant deletes a plant
@@ -66,15 +89,11 @@ func (h *Handler) DeletePlant(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid plant ID",
-		})
+		return apperrors.NewBadRequestError("Invalid plant ID")
 	}
 
 	if err := h.service.DeletePlant(ctx, uint(id)); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete plant",
-		})
+		return apperrors.NewInternalError("Failed to delete plant")
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -85,19 +104,15 @@ func (h *Handler) GetPlantCareLogs(c echo.Context) error {
 	ctx := c.Request().Context()
 	plantID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid plant ID",
-		})
+		return apperrors.NewBadRequestError("Invalid plant ID")
 	}
 
 	careLogs, err := h.service.GetPlantCareLogs(ctx, uint(plantID))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to fetch care logs",
-		})
+		return apperrors.NewInternalError("Failed to fetch care logs")
 	}
 
-	return c.JSON(http.StatusOK, careLogs)
+	return c.JSON(http.StatusOK, dto.List(careLogs, dto.NewCareLogResponse))
 }
 
 // CreateCareLogRequest represents the request body for creating a care log
@@ -112,24 +127,18 @@ func (h *Handler) CreateCareLog(c echo.Context) error {
 	ctx := c.Request().Context()
 	plantID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid plant ID",
-		})
+		return apperrors.NewBadRequestError("Invalid plant ID")
 	}
 
 	var req CreateCareLogRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+		return apperrors.NewBadRequestError("Invalid request body")
 	}
 
 	// Verify plant exists
 	_, err = h.service.GetPlantByID(ctx, uint(plantID))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "Plant not found",
-		})
+		return apperrors.NewNotFoundError("Plant")
 	}
 
 	// Parse cared_at time
@@ -149,10 +158,8 @@ func (h *Handler) CreateCareLog(c echo.Context) error {
 	}
 
 	if err := h.service.CreateCareLog(ctx, careLog); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create care log",
-		})
+		return apperrors.NewInternalError("Failed to create care log")
 	}
 
-	return c.JSON(http.StatusCreated, careLog)
+	return c.JSON(http.StatusCreated, dto.NewCareLogResponse(careLog))
 }
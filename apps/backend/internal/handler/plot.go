@@ -13,14 +13,17 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
 	"github.com/secure-scorecard/backend/internal/validator"
 )
 
@@ -61,6 +64,9 @@ type UpdatePlotRequest struct {
 	PositionX *int    `json:"position_x"`
 	PositionY *int    `json:"position_y"`
 	Notes     string  `json:"notes" validate:"max=1000"`
+
+	// 取得した区画の version（If-Match ヘッダーを指定しない場合は必須）
+	Version *uint `json:"version"`
 }
 
 // AssignCropRequest は作物配置リクエストの構造体です。
@@ -81,9 +87,13 @@ type AssignCropRequest struct {
 //
 // クエリパラメータ:
 //   - status: フィルタするステータス（available/occupied）
+//   - filter: 絞り込みの条件「フィールド:演算子:値」、複数指定可（status, name, soil_type, sunlight, width, height, created_at）
+//   - limit: 1ページの件数（指定した場合は1ページ分を返す、最大100）
+//   - cursor: 前のページの next_cursor
 //
 // レスポンス:
-//   - 200: 区画の配列
+//   - 200: 区画の配列、limit・cursor・filter を指定した場合は1ページ分（items, next_cursor, has_more, limit、新しい順）
+//   - 400: 不正な limit・cursor・filter
 //   - 401: 認証エラー
 //   - 500: 内部エラー
 func (h *Handler) GetPlots(c echo.Context) error {
@@ -97,8 +107,25 @@ func (h *Handler) GetPlots(c echo.Context) error {
 
 	// statusクエリパラメータでフィルタリング
 	status := c.QueryParam("status")
+	spec, filtered, err := filterSpec(c, plotFilterSchema)
+	if err != nil {
+		return err
+	}
+
+	// limit・cursor・filterを指定した場合は1ページ分を返す
+	params, paginated, err := paginationParams(c)
+	if err != nil {
+		return err
+	}
+	if paginated || filtered {
+		page, err := h.service.GetUserPlotsPage(ctx, userID, spec, params)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to fetch plots")
+		}
+		return c.JSON(http.StatusOK, dto.Page(page, dto.NewPlotResponse))
+	}
+
 	var plots []model.Plot
-	var err error
 
 	if status != "" {
 		// ステータスでフィルタ
@@ -112,7 +139,7 @@ func (h *Handler) GetPlots(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch plots")
 	}
 
-	return c.JSON(http.StatusOK, plots)
+	return c.JSON(http.StatusOK, dto.List(plots, dto.NewPlotResponse))
 }
 
 // GetPlot は特定の区画を取得します。
@@ -122,6 +149,7 @@ func (h *Handler) GetPlots(c echo.Context) error {
 //
 // レスポンス:
 //   - 200: 区画オブジェクト
+//   - 304: 変更なし（If-None-Match / If-Modified-Since が一致、ボディなし）
 //   - 400: 無効なID形式
 //   - 404: 区画が見つからない
 func (h *Handler) GetPlot(c echo.Context) error {
@@ -139,7 +167,8 @@ func (h *Handler) GetPlot(c echo.Context) error {
 		return apperrors.NewNotFoundError("Plot")
 	}
 
-	return c.JSON(http.StatusOK, plot)
+	setLastModified(c, plot.UpdatedAt)
+	return c.JSON(http.StatusOK, dto.NewPlotResponse(plot))
 }
 
 // CreatePlot は新しい区画を作成します。
@@ -155,9 +184,11 @@ func (h *Handler) GetPlot(c echo.Context) error {
 //   - notes: メモ（任意）
 //
 // レスポンス:
-//   - 201: 作成された区画
-//   - 400: バリデーションエラー
+//   - 201: 作成された区画（X-Org-ID を指定した場合は組織の区画）
+//   - 400: リクエストボディの形式が不正
 //   - 401: 認証エラー
+//   - 403: 組織の区画数が上限（ORGANIZATION_QUOTA_EXCEEDED）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) CreatePlot(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -190,10 +221,10 @@ func (h *Handler) CreatePlot(c echo.Context) error {
 
 	// DBに保存
 	if err := h.service.CreatePlot(ctx, plot); err != nil {
-		return apperrors.NewInternalError("Failed to create plot")
+		return organizationError(err, "Failed to create plot")
 	}
 
-	return c.JSON(http.StatusCreated, plot)
+	return c.JSON(http.StatusCreated, dto.NewPlotResponse(plot))
 }
 
 // UpdatePlot は既存の区画を更新します。
@@ -201,12 +232,18 @@ func (h *Handler) CreatePlot(c echo.Context) error {
 // パスパラメータ:
 //   - id: 区画ID
 //
-// リクエストボディ: 更新するフィールド（任意）
+// リクエストヘッダー:
+//   - If-Match: 取得した区画の version（"3" の形式、ボディの version を指定しない場合は必須）
+//
+// リクエストボディ: 更新するフィールド（任意）と取得した区画の version
 //
 // レスポンス:
-//   - 200: 更新された区画
-//   - 400: バリデーションエラー
+//   - 200: 更新された区画（ETag は更新後の version）
+//   - 400: リクエストボディ・If-Match の形式が不正
 //   - 404: 区画が見つからない
+//   - 409: 取得した後に他のリクエストで更新されている（VERSION_CONFLICT、errors.current に現在の区画）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 428: If-Match・version を指定していない（PRECONDITION_REQUIRED）
 //   - 500: 内部エラー
 func (h *Handler) UpdatePlot(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -222,12 +259,20 @@ func (h *Handler) UpdatePlot(c echo.Context) error {
 	if err := validator.BindAndValidate(c, &req); err != nil {
 		return err
 	}
+	version, err := requiredVersion(c, req.Version)
+	if err != nil {
+		return err
+	}
 
 	// 既存の区画を取得
 	plot, err := h.service.GetPlotByID(ctx, uint(id))
 	if err != nil {
 		return apperrors.NewNotFoundError("Plot")
 	}
+	if plot.Version != version {
+		// 取得した後に他のリクエストで更新されている（楽観的ロック）
+		return apperrors.NewVersionConflictError("Plot", dto.NewPlotResponse(plot))
+	}
 
 	// リクエストで指定されたフィールドのみ更新
 	if req.Name != "" {
@@ -257,10 +302,17 @@ func (h *Handler) UpdatePlot(c echo.Context) error {
 
 	// DBを更新
 	if err := h.service.UpdatePlot(ctx, plot); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			// 確認した後、保存するまでの間に他のリクエストで更新された
+			if current, getErr := h.service.GetPlotByID(ctx, uint(id)); getErr == nil {
+				return apperrors.NewVersionConflictError("Plot", dto.NewPlotResponse(current))
+			}
+		}
 		return apperrors.NewInternalError("Failed to update plot")
 	}
 
-	return c.JSON(http.StatusOK, plot)
+	c.Response().Header().Set(headerETag, versionETag(plot.Version))
+	return c.JSON(http.StatusOK, dto.NewPlotResponse(plot))
 }
 
 // DeletePlot は区画を削除します（論理削除）。
@@ -306,7 +358,8 @@ func (h *Handler) DeletePlot(c echo.Context) error {
 //
 // レスポンス:
 //   - 201: 作成された配置
-//   - 400: バリデーションエラー
+//   - 400: リクエストボディの形式が不正
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) AssignCrop(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -335,7 +388,7 @@ func (h *Handler) AssignCrop(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to assign crop to plot")
 	}
 
-	return c.JSON(http.StatusCreated, assignment)
+	return c.JSON(http.StatusCreated, dto.NewPlotAssignmentResponse(assignment))
 }
 
 // UnassignCrop は区画から作物の配置を解除します。
@@ -389,7 +442,7 @@ func (h *Handler) GetPlotAssignments(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch plot assignments")
 	}
 
-	return c.JSON(http.StatusOK, assignments)
+	return c.JSON(http.StatusOK, dto.List(assignments, dto.NewPlotAssignmentResponse))
 }
 
 // GetActivePlotAssignment は区画の現在アクティブな配置を取得します。
@@ -416,7 +469,7 @@ func (h *Handler) GetActivePlotAssignment(c echo.Context) error {
 		return apperrors.NewNotFoundError("Active assignment")
 	}
 
-	return c.JSON(http.StatusOK, assignment)
+	return c.JSON(http.StatusOK, dto.NewPlotAssignmentResponse(assignment))
 }
 
 // =============================================================================
@@ -428,6 +481,7 @@ func (h *Handler) GetActivePlotAssignment(c echo.Context) error {
 //
 // レスポンス:
 //   - 200: レイアウトデータの配列（各要素に区画、アクティブな配置、作物情報を含む）
+//   - 304: 変更なし（If-None-Match が一致、ボディなし）
 //   - 401: 認証エラー
 //   - 500: 内部エラー
 func (h *Handler) GetPlotLayout(c echo.Context) error {
@@ -0,0 +1,67 @@
+// Package handler - Push Action Handler
+//
+// プッシュ通知のアクションボタンのコールバックを処理するHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST /api/v1/notifications/actions - アクションボタンの操作（完了にする・1時間後に通知）
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// PushActionRequest はプッシュ通知のアクションボタンのリクエストです。
+// task_ids には通知のカスタムデータの task_ids をそのまま指定します。
+type PushActionRequest struct {
+	Action  string `json:"action" validate:"required,oneof=mark_done snooze_1h"`
+	TaskIDs []uint `json:"task_ids" validate:"required,min=1"`
+}
+
+// HandlePushAction はプッシュ通知のアクションボタンの操作を処理します。
+//
+// リクエストボディ:
+//
+//	{
+//	  "action": "snooze_1h",
+//	  "task_ids": [123]
+//	}
+//
+// レスポンス:
+//   - 200: PushActionResult（スヌーズの場合は再通知日時を含む）
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 404: タスクが見つからない
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) HandlePushAction(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req PushActionRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.service.HandlePushAction(ctx, userID, req.Action, req.TaskIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownPushAction):
+			return apperrors.NewBadRequestError("Unknown action")
+		case errors.Is(err, service.ErrPushActionTaskNotFound):
+			return apperrors.NewNotFoundError("Task")
+		}
+		return apperrors.NewInternalError("Failed to handle push action")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
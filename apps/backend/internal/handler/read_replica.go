@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// readReplicaMiddleware はリクエストの読み取りクエリを読み取りレプリカで実行させます（DB_READ_REPLICA_URL を設定した場合）。
+// 集計・グラフ・CSVエクスポートなど、レプリケーションの遅延を許容できる重い読み取りのエンドポイントに使用します。
+// 書き込みとトランザクション内のクエリはプライマリで実行します。
+func readReplicaMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(repository.ContextWithReadReplica(req.Context())))
+		return next(c)
+	}
+}
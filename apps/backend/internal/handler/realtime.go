@@ -0,0 +1,232 @@
+// Package handler - Realtime Handler
+//
+// 共有の庭（世帯）の区画のレイアウトとタスクの変更をリアルタイムに配信する WebSocket のハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/ws - WebSocket の接続（接続後の最初のメッセージで JWT による認証）
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/realtime"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// WebSocket - 共有の庭のルーム
+// =============================================================================
+// ブラウザの WebSocket は Authorization ヘッダーを指定できないため、接続後の最初のメッセージで JWT を送ります。
+// 認証後、所有者・メンバーである庭のルームに参加すると、その庭の変更のメッセージ（realtime.Message）を受信します。
+//
+//	→ {"type": "auth", "token": "<JWT>"}
+//	← {"type": "auth_ok", "user_id": 1}
+//	→ {"type": "join", "garden_id": 3}
+//	← {"type": "joined", "garden_id": 3}
+//	← {"type": "plot.updated", "garden_id": 3, "entity": "plots", "entity_id": 7, "data": {...}, "occurred_at": "..."}
+//	→ {"type": "leave", "garden_id": 3}
+//	← {"type": "left", "garden_id": 3}
+//
+// 参加できない場合は {"type": "error", "code": "GARDEN_NOT_FOUND" など} を返し、接続は維持します。
+// 認証に失敗した場合は WSCloseUnauthorized で接続を閉じます。
+
+const (
+	// WSAuthTimeout は接続から認証のメッセージを受信するまでの待機時間です。
+	WSAuthTimeout = 10 * time.Second
+	// WSPingInterval は接続を維持するための ping の間隔です。
+	WSPingInterval = 25 * time.Second
+	// WSWriteTimeout はメッセージの送信（ping の応答を含む）の待機時間です。
+	WSWriteTimeout = 10 * time.Second
+	// WSMaxMessageSize はクライアントから受信するメッセージの最大サイズ（バイト）です。
+	WSMaxMessageSize = 4096
+
+	// WSCloseUnauthorized は認証に失敗した場合のクローズコードです（アプリケーション定義の 4000 番台）。
+	WSCloseUnauthorized websocket.StatusCode = 4401
+)
+
+// クライアント・サーバーの制御メッセージの種類
+const (
+	wsTypeAuth   = "auth"
+	wsTypeAuthOK = "auth_ok"
+	wsTypeJoin   = "join"
+	wsTypeJoined = "joined"
+	wsTypeLeave  = "leave"
+	wsTypeLeft   = "left"
+	wsTypePing   = "ping"
+	wsTypePong   = "pong"
+	wsTypeError  = "error"
+)
+
+// wsClientMessage はクライアントから受信するメッセージです。
+type wsClientMessage struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`     // auth
+	GardenID uint   `json:"garden_id,omitempty"` // join, leave
+}
+
+// wsControlMessage はサーバーから送る制御メッセージです（変更のメッセージは realtime.Message）。
+type wsControlMessage struct {
+	Type     string `json:"type"`
+	UserID   uint   `json:"user_id,omitempty"`
+	GardenID uint   `json:"garden_id,omitempty"`
+	Code     string `json:"code,omitempty"`    // error のエラーコード（エラーコード一覧と同じ）
+	Message  string `json:"message,omitempty"` // error の内容
+}
+
+// SetWebSocketOriginPatterns は WebSocket の接続を許可するオリジンを設定します。
+// 同じホストからの接続とオリジンのない接続（モバイルアプリ）は常に許可します。
+//
+// 引数:
+//   - patterns: 許可するオリジン（"https://app.example.com" または "app.example.com"、path.Match の形式）
+func (h *Handler) SetWebSocketOriginPatterns(patterns []string) {
+	h.wsOriginPatterns = patterns
+}
+
+// ConnectRealtime は共有の庭のリアルタイム配信の WebSocket の接続を処理します。
+// 接続後の最初のメッセージ（type: auth）の JWT で認証し、参加したルームの庭の
+// 区画のレイアウト（plot.created/updated/deleted）とタスク（task.created/updated/completed/deleted）の変更を送ります。
+// メンバーから外れた場合は room.revoked を送ってルームから退出させます。
+//
+// レスポンス:
+//   - 101: WebSocket の接続（認証に失敗した場合はクローズコード 4401）
+//   - 403: 許可されていないオリジン
+func (h *Handler) ConnectRealtime(c echo.Context) error {
+	conn, err := websocket.Accept(c.Response(), c.Request(), &websocket.AcceptOptions{
+		OriginPatterns: h.wsOriginPatterns,
+	})
+	if err != nil {
+		// Accept がエラーのレスポンスを書き込み済み
+		return nil
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(WSMaxMessageSize)
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	userID, err := h.authenticateWebSocket(ctx, c, conn)
+	if err != nil {
+		conn.Close(WSCloseUnauthorized, "authentication failed")
+		return nil
+	}
+	client, err := h.service.ConnectRealtime(userID)
+	if err != nil {
+		conn.Close(websocket.StatusTryAgainLater, "realtime updates are unavailable")
+		return nil
+	}
+	defer client.Close()
+	if err := writeWebSocket(ctx, conn, wsControlMessage{Type: wsTypeAuthOK, UserID: userID}); err != nil {
+		return nil
+	}
+
+	// 受信はルームの参加・退出の処理と ping の応答のため、送信と並行して行う
+	go h.readWebSocket(ctx, cancel, conn, client)
+
+	ping := time.NewTicker(WSPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-client.Messages():
+			if !ok {
+				// 受信が遅い・サーバーの停止でハブが接続を閉じた（クライアントは再接続してレイアウトを再取得する）
+				conn.Close(websocket.StatusTryAgainLater, "connection closed by server, reconnect")
+				return nil
+			}
+			if err := writeWebSocket(ctx, conn, msg); err != nil {
+				return nil
+			}
+		case <-ping.C:
+			pingCtx, pingCancel := context.WithTimeout(ctx, WSWriteTimeout)
+			err := conn.Ping(pingCtx)
+			pingCancel()
+			if err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// authenticateWebSocket は最初のメッセージ（type: auth）の JWT を検証し、ユーザーIDを返します。
+// 無効化（ログアウト）されたトークンは認証ミドルウェアと同じく拒否します。
+func (h *Handler) authenticateWebSocket(ctx context.Context, c echo.Context, conn *websocket.Conn) (uint, error) {
+	authCtx, cancel := context.WithTimeout(ctx, WSAuthTimeout)
+	defer cancel()
+
+	var msg wsClientMessage
+	if err := wsjson.Read(authCtx, conn, &msg); err != nil {
+		return 0, err
+	}
+	if msg.Type != wsTypeAuth || msg.Token == "" {
+		return 0, errors.New("first message must be auth with a token")
+	}
+
+	blacklisted, err := h.service.IsTokenBlacklisted(c, auth.HashToken(msg.Token))
+	if err != nil {
+		return 0, err
+	}
+	if blacklisted {
+		return 0, errors.New("token has been revoked")
+	}
+	claims, err := h.jwtManager.ValidateToken(msg.Token)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+// readWebSocket はクライアントのメッセージ（join/leave/ping）を処理します。
+// 接続が切れた場合・不正なメッセージを受信した場合は cancel で送信を終了させます。
+func (h *Handler) readWebSocket(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, client *realtime.Client) {
+	defer cancel()
+	for {
+		var msg wsClientMessage
+		if err := wsjson.Read(ctx, conn, &msg); err != nil {
+			return
+		}
+
+		var reply wsControlMessage
+		switch msg.Type {
+		case wsTypeJoin:
+			reply = h.joinGardenRoom(ctx, client, msg.GardenID)
+		case wsTypeLeave:
+			h.service.LeaveGardenRoom(client, msg.GardenID)
+			reply = wsControlMessage{Type: wsTypeLeft, GardenID: msg.GardenID}
+		case wsTypePing:
+			reply = wsControlMessage{Type: wsTypePong}
+		default:
+			reply = wsControlMessage{Type: wsTypeError, Code: apperrors.ErrCodeBadRequest, Message: "Unknown message type"}
+		}
+		if err := writeWebSocket(ctx, conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// joinGardenRoom は接続を庭のルームに参加させ、応答のメッセージを返します。
+func (h *Handler) joinGardenRoom(ctx context.Context, client *realtime.Client, gardenID uint) wsControlMessage {
+	err := h.service.JoinGardenRoom(ctx, client, gardenID)
+	switch {
+	case err == nil:
+		return wsControlMessage{Type: wsTypeJoined, GardenID: gardenID}
+	case errors.Is(err, service.ErrGardenNotFound):
+		return wsControlMessage{Type: wsTypeError, GardenID: gardenID, Code: apperrors.ErrCodeGardenNotFound, Message: "Garden not found"}
+	case errors.Is(err, service.ErrGardenAccessDenied):
+		return wsControlMessage{Type: wsTypeError, GardenID: gardenID, Code: apperrors.ErrCodeAuthorization, Message: "Not a member of this garden"}
+	}
+	return wsControlMessage{Type: wsTypeError, GardenID: gardenID, Code: apperrors.ErrCodeInternal, Message: "Failed to join garden room"}
+}
+
+// writeWebSocket はメッセージを JSON で送信します。
+func writeWebSocket(ctx context.Context, conn *websocket.Conn, v any) error {
+	writeCtx, cancel := context.WithTimeout(ctx, WSWriteTimeout)
+	defer cancel()
+	return wsjson.Write(writeCtx, conn, v)
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/realtime"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Realtime Tests - 共有の庭のメンバーと WebSocket のテスト
+// =============================================================================
+// テスト対象:
+//   - AddGardenMember / RemoveGardenMember: 所有者のみの追加、重複・未登録のユーザーの拒否
+//   - ConnectRealtime: JWT の認証のハンドシェイク、庭のルームへの参加の認可
+//   - 所有者の区画・タスクの変更のメンバーへの配信、メンバーから外れた接続の退出（room.revoked）
+
+// realtimeTestSetup は全ルームを登録したテスト用のサーバーとユーザーです。
+type realtimeTestSetup struct {
+	echo       *echo.Echo
+	server     *httptest.Server
+	mockRepos  *repository.MockRepositories
+	jwtManager *auth.JWTManager
+	garden     *model.Garden
+	owner      *model.User
+	member     *model.User
+	outsider   *model.User
+}
+
+// newRealtimeTestSetup は所有者の庭と、メンバー・部外者のユーザーを作成します（メンバーは未追加）。
+func newRealtimeTestSetup(t *testing.T) *realtimeTestSetup {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+
+	mockRepos := repository.NewMockRepositories()
+	svc := service.NewService(mockRepos)
+	svc.SetRoomHub(realtime.NewHub(realtime.DefaultBufferSize))
+	jwtManager := auth.NewJWTManager("realtime-test-secret-key-32-chars", 24)
+	NewHandler(svc, jwtManager, nil).RegisterRoutes(e)
+
+	ctx := context.Background()
+	setup := &realtimeTestSetup{echo: e, mockRepos: mockRepos, jwtManager: jwtManager}
+	for i, user := range []**model.User{&setup.owner, &setup.member, &setup.outsider} {
+		*user = &model.User{Email: fmt.Sprintf("user%d@example.com", i+1), DisplayName: fmt.Sprintf("User %d", i+1), IsActive: true}
+		if err := mockRepos.User().Create(ctx, *user); err != nil {
+			t.Fatalf("Create user failed: %v", err)
+		}
+	}
+	setup.garden = &model.Garden{UserID: setup.owner.ID, Name: "家庭菜園"}
+	_ = mockRepos.Garden().Create(ctx, setup.garden)
+
+	setup.server = httptest.NewServer(e)
+	t.Cleanup(setup.server.Close)
+	return setup
+}
+
+// token はユーザーの認証トークンを返します。
+func (s *realtimeTestSetup) token(t *testing.T, user *model.User) string {
+	t.Helper()
+	token, err := s.jwtManager.GenerateToken(user.ID, "", user.Email)
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	return token
+}
+
+// request は認証付きのリクエストを送信します。
+func (s *realtimeTestSetup) request(t *testing.T, user *model.User, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+s.token(t, user))
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// dial は WebSocket で接続し、最初のメッセージで token を送ります。
+func (s *realtimeTestSetup) dial(t *testing.T, token string) *websocket.Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(s.server.URL, "http")+"/api/v1/ws", nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	if err := wsjson.Write(ctx, conn, map[string]any{"type": "auth", "token": token}); err != nil {
+		t.Fatalf("Write auth failed: %v", err)
+	}
+	return conn
+}
+
+// send はメッセージを送信し、次に受信したメッセージを返します。
+func send(t *testing.T, conn *websocket.Conn, msg map[string]any) map[string]any {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := wsjson.Write(ctx, conn, msg); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return receive(t, conn)
+}
+
+// receive はメッセージを1件受信します。
+func receive(t *testing.T, conn *websocket.Conn) map[string]any {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var msg map[string]any
+	if err := wsjson.Read(ctx, conn, &msg); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	return msg
+}
+
+// TestRealtime_GardenMembers は庭のメンバーの管理のテストです。
+// 期待動作:
+//   - 所有者はメールアドレスでメンバーを追加でき、メンバーは一覧・参加している庭を取得できる
+//   - 所有者以外の追加は 403、重複・所有者自身は 409、未登録のユーザーは 404
+//   - 部外者の一覧は 403、メンバーは自分自身を削除（退出）できる
+func TestRealtime_GardenMembers(t *testing.T) {
+	// Arrange
+	s := newRealtimeTestSetup(t)
+	membersPath := fmt.Sprintf("/api/v1/gardens/%d/members", s.garden.ID)
+
+	// Act
+	added := s.request(t, s.owner, http.MethodPost, membersPath, `{"email": "user2@example.com"}`)
+
+	// Assert
+	if added.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", added.Code, added.Body.String())
+	}
+	var members []model.GardenMember
+	listed := s.request(t, s.member, http.MethodGet, membersPath, "")
+	if err := json.Unmarshal(listed.Body.Bytes(), &members); err != nil || len(members) != 1 || members[0].User.Email != s.member.Email {
+		t.Errorf("Expected the member in the list, got %d %s", listed.Code, listed.Body.String())
+	}
+	var shared []model.Garden
+	_ = json.Unmarshal(s.request(t, s.member, http.MethodGet, "/api/v1/gardens/shared", "").Body.Bytes(), &shared)
+	if len(shared) != 1 || shared[0].ID != s.garden.ID {
+		t.Errorf("Expected the shared garden, got %+v", shared)
+	}
+
+	tests := []struct {
+		name   string
+		user   *model.User
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"non-owner add", s.member, http.MethodPost, membersPath, `{"email": "user3@example.com"}`, http.StatusForbidden, apperrors.ErrCodeAuthorization},
+		{"duplicate", s.owner, http.MethodPost, membersPath, `{"email": "user2@example.com"}`, http.StatusConflict, apperrors.ErrCodeGardenMemberExists},
+		{"owner as member", s.owner, http.MethodPost, membersPath, `{"email": "user1@example.com"}`, http.StatusConflict, apperrors.ErrCodeGardenMemberExists},
+		{"unknown user", s.owner, http.MethodPost, membersPath, `{"email": "nobody@example.com"}`, http.StatusNotFound, apperrors.ErrCodeUserNotFound},
+		{"outsider list", s.outsider, http.MethodGet, membersPath, "", http.StatusForbidden, apperrors.ErrCodeAuthorization},
+		{"missing garden", s.owner, http.MethodGet, "/api/v1/gardens/999/members", "", http.StatusNotFound, apperrors.ErrCodeGardenNotFound},
+		{"outsider remove", s.outsider, http.MethodDelete, fmt.Sprintf("%s/%d", membersPath, s.member.ID), "", http.StatusForbidden, apperrors.ErrCodeAuthorization},
+		{"member leaves", s.member, http.MethodDelete, fmt.Sprintf("%s/%d", membersPath, s.member.ID), "", http.StatusNoContent, ""},
+		{"already left", s.owner, http.MethodDelete, fmt.Sprintf("%s/%d", membersPath, s.member.ID), "", http.StatusNotFound, apperrors.ErrCodeGardenMemberNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rec := s.request(t, tt.user, tt.method, tt.path, tt.body)
+
+			// Assert
+			if rec.Code != tt.status {
+				t.Fatalf("Expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			var problem apperrors.Problem
+			_ = json.Unmarshal(rec.Body.Bytes(), &problem)
+			if problem.Code != tt.code {
+				t.Errorf("Expected code %q, got %q", tt.code, problem.Code)
+			}
+		})
+	}
+}
+
+// TestRealtime_Handshake は WebSocket の認証のハンドシェイクのテストです。
+// 期待動作:
+//   - 有効な JWT は auth_ok とユーザーIDを返す
+//   - 無効な JWT・無効化されたトークンはクローズコード 4401 で接続を閉じる
+func TestRealtime_Handshake(t *testing.T) {
+	s := newRealtimeTestSetup(t)
+
+	t.Run("valid", func(t *testing.T) {
+		// Act
+		conn := s.dial(t, s.token(t, s.owner))
+
+		// Assert
+		if msg := receive(t, conn); msg["type"] != "auth_ok" || msg["user_id"] != float64(s.owner.ID) {
+			t.Errorf("Expected auth_ok for the owner, got %v", msg)
+		}
+	})
+
+	revoked := s.token(t, s.owner)
+	_ = s.mockRepos.TokenBlacklist().Add(context.Background(), auth.HashToken(revoked), time.Now().Add(time.Hour))
+	for name, token := range map[string]string{"invalid": "not-a-jwt", "revoked": revoked} {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			conn := s.dial(t, token)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, _, err := conn.Read(ctx)
+
+			// Assert
+			if websocket.CloseStatus(err) != WSCloseUnauthorized {
+				t.Errorf("Expected close status %d, got %v", WSCloseUnauthorized, err)
+			}
+		})
+	}
+}
+
+// TestRealtime_HouseholdRoom は庭のルームへの配信のテストです。
+// 期待動作:
+//   - 部外者はルームに参加できない（AUTHORIZATION_ERROR）、存在しない庭は GARDEN_NOT_FOUND
+//   - メンバーは所有者の区画の作成・タスクの完了を plot.created・task.completed で受信する
+//   - メンバーから外れると room.revoked を受信し、以降の変更は受信しない
+func TestRealtime_HouseholdRoom(t *testing.T) {
+	// Arrange
+	s := newRealtimeTestSetup(t)
+	membersPath := fmt.Sprintf("/api/v1/gardens/%d/members", s.garden.ID)
+	if rec := s.request(t, s.owner, http.MethodPost, membersPath, `{"email": "user2@example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("AddGardenMember failed: %d %s", rec.Code, rec.Body.String())
+	}
+	task := &model.Task{UserID: s.owner.ID, Title: "水やり", DueDate: time.Now()}
+	_ = s.mockRepos.Task().Create(context.Background(), task)
+
+	outsider := s.dial(t, s.token(t, s.outsider))
+	receive(t, outsider)
+	if msg := send(t, outsider, map[string]any{"type": "join", "garden_id": s.garden.ID}); msg["type"] != "error" || msg["code"] != apperrors.ErrCodeAuthorization {
+		t.Errorf("Expected outsider join to be denied, got %v", msg)
+	}
+	if msg := send(t, outsider, map[string]any{"type": "join", "garden_id": 999}); msg["code"] != apperrors.ErrCodeGardenNotFound {
+		t.Errorf("Expected GARDEN_NOT_FOUND, got %v", msg)
+	}
+
+	member := s.dial(t, s.token(t, s.member))
+	receive(t, member)
+	if msg := send(t, member, map[string]any{"type": "join", "garden_id": s.garden.ID}); msg["type"] != "joined" {
+		t.Fatalf("Expected member to join, got %v", msg)
+	}
+
+	// Act
+	created := s.request(t, s.owner, http.MethodPost, "/api/v1/plots", `{"name": "A-1", "width": 1.5, "height": 2}`)
+	completed := s.request(t, s.owner, http.MethodPost, fmt.Sprintf("/api/v1/tasks/%d/complete", task.ID), "")
+
+	// Assert
+	if created.Code != http.StatusCreated || completed.Code != http.StatusOK {
+		t.Fatalf("Expected owner changes to succeed, got %d / %d", created.Code, completed.Code)
+	}
+	plotMsg := receive(t, member)
+	data, _ := plotMsg["data"].(map[string]any)
+	plot, _ := data["plot"].(map[string]any)
+	if plotMsg["type"] != realtime.TypePlotCreated || plotMsg["garden_id"] != float64(s.garden.ID) || plot["name"] != "A-1" {
+		t.Errorf("Expected plot.created with the plot layout, got %v", plotMsg)
+	}
+	if taskMsg := receive(t, member); taskMsg["type"] != realtime.TypeTaskCompleted || taskMsg["entity_id"] != float64(task.ID) {
+		t.Errorf("Expected task.completed, got %v", taskMsg)
+	}
+
+	// Act: メンバーから外す
+	if rec := s.request(t, s.owner, http.MethodDelete, fmt.Sprintf("%s/%d", membersPath, s.member.ID), ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("RemoveGardenMember failed: %d", rec.Code)
+	}
+	s.request(t, s.owner, http.MethodPost, "/api/v1/plots", `{"name": "A-2", "width": 1, "height": 1}`)
+
+	// Assert
+	if msg := receive(t, member); msg["type"] != realtime.TypeRoomRevoked {
+		t.Errorf("Expected room.revoked, got %v", msg)
+	}
+	if msg := send(t, member, map[string]any{"type": "ping"}); msg["type"] != "pong" {
+		t.Errorf("Expected no further changes after removal, got %v", msg)
+	}
+}
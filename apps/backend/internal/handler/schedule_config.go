@@ -0,0 +1,95 @@
+// Package handler - Schedule Config Handler
+//
+// 管理者向けの定期タスクのスケジュール設定のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/admin/schedules - 全ジョブのスケジュール設定
+//   - PUT /api/v1/admin/schedules/:name - ジョブのスケジュール・一時停止・先読み日数の変更
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/scheduler"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// UpdateScheduleConfigRequest はスケジュール設定の変更リクエストです（省略した項目は変更しません）。
+type UpdateScheduleConfigRequest struct {
+	Schedule      *string `json:"schedule" validate:"omitempty,max=100"`            // cron式（空文字でデフォルトに戻す）
+	Enabled       *bool   `json:"enabled"`                                          // false で一時停止
+	LookaheadDays *int    `json:"lookahead_days" validate:"omitempty,min=0,max=30"` // notifications のみ（0 でデフォルトに戻す）
+}
+
+// GetScheduleConfigs は全ジョブのスケジュール設定を取得します。
+//
+// レスポンス:
+//   - 200: ScheduleConfigEntry の配列（schedule, default_schedule, enabled, lookahead_days, customized）
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 500: 内部エラー
+func (h *Handler) GetScheduleConfigs(c echo.Context) error {
+	entries, err := h.service.ListScheduleConfigs(c.Request().Context())
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get schedule configs")
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+// UpdateScheduleConfig はジョブのスケジュール設定を変更します。
+// 内蔵スケジューラーは次回の読み込み時（最長1分後）に変更を反映します。
+//
+// パスパラメータ:
+//   - name: ジョブ名（notifications, token_blacklist_cleanup 等）
+//
+// リクエストボディ:
+//
+//	{
+//	  "schedule": "0 */2 * * *",
+//	  "enabled": true,
+//	  "lookahead_days": 14
+//	}
+//
+// レスポンス:
+//   - 200: 変更後の ScheduleConfigEntry
+//   - 400: リクエストボディの形式が不正、不正なcron式・先読み日数
+//   - 401: 認証エラー
+//   - 403: 管理者以外
+//   - 404: ジョブが存在しない
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateScheduleConfig(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req UpdateScheduleConfigRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.Schedule != nil && *req.Schedule != "" {
+		if _, err := scheduler.ParseSchedule(*req.Schedule); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+	}
+
+	entry, err := h.service.UpdateScheduleConfig(ctx, auth.GetUserIDFromContext(c), c.Param("name"), service.UpdateScheduleConfigInput{
+		Schedule:      req.Schedule,
+		Enabled:       req.Enabled,
+		LookaheadDays: req.LookaheadDays,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownScheduleJob):
+			return apperrors.NewNotFoundError("Schedule")
+		case errors.Is(err, service.ErrInvalidScheduleConfig):
+			return apperrors.NewBadRequestError(err.Error())
+		}
+		return apperrors.NewInternalError("Failed to update schedule config")
+	}
+
+	return c.JSON(http.StatusOK, entry)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/service"
@@ -12,6 +16,8 @@ import (
 // =============================================================================
 // AWS EventBridge Scheduler から呼び出される定期タスク処理のエンドポイントを提供します。
 // 認証不要で、EventBridge からの呼び出しを想定しています。
+// EventBridge のないセルフホスト環境では、同じ処理を内蔵スケジューラー（internal/scheduler、
+// SCHEDULER_EMBEDDED_ENABLED=true）で実行できます。
 
 // SchedulerHandler はスケジューラー処理のハンドラーです。
 type SchedulerHandler struct {
@@ -33,6 +39,9 @@ type ProcessNotificationsRequest struct {
 	// SchedulerToken は EventBridge Scheduler から送信される認証トークンです。
 	// 環境変数 SCHEDULER_AUTH_TOKEN と一致する必要があります。
 	SchedulerToken string `json:"scheduler_token" validate:"required"`
+
+	// IdempotencyKey は再送時に同じ処理を実行しないための冪等キーです（任意、Idempotency-Key ヘッダーでも指定可能）。
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // ProcessNotificationsResponse はスケジューラー処理のレスポンスです。
@@ -42,19 +51,25 @@ type ProcessNotificationsResponse struct {
 	OverdueTaskAlerts  int    `json:"overdue_task_alerts"`
 	TodayTaskReminders int    `json:"today_task_reminders"`
 	HarvestReminders   int    `json:"harvest_reminders"`
+	HarvestReadyAlerts int    `json:"harvest_ready_alerts"`
 	TotalEvents        int    `json:"total_events"`
+	DuplicateSends     int    `json:"duplicate_sends,omitempty"` // 同日内に送信済みのためスキップした件数（通知送信時のみ）
+	EnqueuedEvents     int    `json:"enqueued_events,omitempty"` // アウトボックスに保存した通知イベントの件数（通知送信時のみ）
 	Message            string `json:"message,omitempty"`
 }
 
 // ProcessScheduledNotifications は定期通知処理を実行します。
-// AWS EventBridge Scheduler から毎日呼び出されます。
+// AWS EventBridge Scheduler から1時間ごとに呼び出されます。
+// 各ユーザーのタイムゾーンで日次リマインダーの送信時刻を過ぎている場合のみ通知し、
+// 同日内の重複送信は重複防止キーで抑止されます。
 //
 // エンドポイント: POST /api/v1/scheduler/notifications
 //
 // リクエストボディ:
 //
 //	{
-//	  "scheduler_token": "認証トークン"
+//	  "scheduler_token": "認証トークン",
+//	  "idempotency_key": "<aws.scheduler.execution-id>"
 //	}
 //
 // レスポンス:
@@ -65,7 +80,8 @@ type ProcessNotificationsResponse struct {
 //	  "overdue_task_alerts": 3,
 //	  "today_task_reminders": 5,
 //	  "harvest_reminders": 2,
-//	  "total_events": 10,
+//	  "harvest_ready_alerts": 1,
+//	  "total_events": 11,
 //	  "message": "処理が正常に完了しました"
 //	}
 //
@@ -73,6 +89,9 @@ type ProcessNotificationsResponse struct {
 //   - 期限切れタスク検出（3件以上で警告通知）
 //   - 当日タスクのリマインダー通知
 //   - 7日以内の収穫予定リマインダー通知
+//   - 収穫可能になった（収穫予定日を迎えた）作物の通知
+//
+// 通知送信が設定されている場合、生成した通知イベントはアウトボックスに保存してから送信します。
 //
 // 注意: このエンドポイントはスケジューラー専用です。
 // 認証トークンによる簡易認証を使用します。
@@ -93,6 +112,8 @@ func (h *SchedulerHandler) ProcessScheduledNotifications(c echo.Context) error {
 			Success:            true,
 			ProcessedAt:        result.ProcessedAt.Format("2006-01-02T15:04:05Z07:00"),
 			TotalEvents:        result.TotalEvents,
+			DuplicateSends:     result.DuplicateSends,
+			EnqueuedEvents:     result.EnqueuedEvents,
 			Message:            "処理が正常に完了しました（通知送信済み）",
 		})
 	}
@@ -112,11 +133,306 @@ func (h *SchedulerHandler) ProcessScheduledNotifications(c echo.Context) error {
 		OverdueTaskAlerts:  result.OverdueTaskAlerts,
 		TodayTaskReminders: result.TodayTaskReminders,
 		HarvestReminders:   result.HarvestReminders,
+		HarvestReadyAlerts: result.HarvestReadyAlerts,
 		TotalEvents:        len(result.Events),
 		Message:            "処理が正常に完了しました（通知未送信）",
 	})
 }
 
+// DispatchOutboxResponse はアウトボックスの送信処理のレスポンスです。
+type DispatchOutboxResponse struct {
+	Success         bool     `json:"success"`
+	ProcessedAt     string   `json:"processed_at,omitempty"`
+	TotalEvents     int      `json:"total_events"`
+	SuccessfulSends int      `json:"successful_sends"`
+	FailedSends     int      `json:"failed_sends"`
+	DeferredSends   int      `json:"deferred_sends"`
+	DuplicateSends  int      `json:"duplicate_sends"`
+	Errors          []string `json:"errors,omitempty"`
+	Message         string   `json:"message,omitempty"`
+}
+
+// DispatchNotificationOutbox はアウトボックスの送信待ちの通知イベントを送信します。
+// 定期通知の処理中にプロセスが終了した場合などに残ったイベントを送信するため、
+// AWS EventBridge Scheduler から定期的（例: 5分ごと）に呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/notifications/outbox
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "processed_at": "2024-01-15T09:05:00Z",
+//	  "total_events": 4,
+//	  "successful_sends": 3,
+//	  "failed_sends": 1,
+//	  "deferred_sends": 0,
+//	  "duplicate_sends": 0,
+//	  "message": "アウトボックスの送信処理が完了しました"
+//	}
+//
+// 通知送信が設定されていない場合は 503 を返します。
+func (h *SchedulerHandler) DispatchNotificationOutbox(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if h.eventHandler == nil {
+		return c.JSON(http.StatusServiceUnavailable, DispatchOutboxResponse{
+			Success: false,
+			Message: "通知送信が設定されていません",
+		})
+	}
+
+	result, err := h.eventHandler.DispatchNotificationOutbox(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, DispatchOutboxResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, DispatchOutboxResponse{
+		Success:         true,
+		ProcessedAt:     result.ProcessedAt.Format("2006-01-02T15:04:05Z07:00"),
+		TotalEvents:     result.TotalEvents,
+		SuccessfulSends: result.SuccessfulSends,
+		FailedSends:     result.FailedSends,
+		DeferredSends:   result.DeferredSends,
+		DuplicateSends:  result.DuplicateSends,
+		Errors:          result.Errors,
+		Message:         "アウトボックスの送信処理が完了しました",
+	})
+}
+
+// RetryNotificationsResponse は通知リトライ処理のレスポンスです。
+type RetryNotificationsResponse struct {
+	Success      bool     `json:"success"`
+	ProcessedAt  string   `json:"processed_at,omitempty"`
+	Attempted    int      `json:"attempted"`
+	Succeeded    int      `json:"succeeded"`
+	Rescheduled  int      `json:"rescheduled"`
+	DeadLettered int      `json:"dead_lettered"`
+	Overflow     int      `json:"overflow_summaries,omitempty"` // 送信数の上限で送らなかったプッシュ通知のまとめ通知を送った件数
+	Errors       []string `json:"errors,omitempty"`
+	Message      string   `json:"message,omitempty"`
+}
+
+// RetryNotifications は送信に失敗した通知を再送信します。
+// AWS EventBridge Scheduler から定期的（例: 5分ごと）に呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/notifications/retry
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "processed_at": "2024-01-15T09:05:00Z",
+//	  "attempted": 3,
+//	  "succeeded": 2,
+//	  "rescheduled": 1,
+//	  "dead_lettered": 0,
+//	  "message": "リトライ処理が完了しました"
+//	}
+//
+// 通知送信が設定されていない場合は 503 を返します。
+func (h *SchedulerHandler) RetryNotifications(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if h.eventHandler == nil {
+		return c.JSON(http.StatusServiceUnavailable, RetryNotificationsResponse{
+			Success: false,
+			Message: "通知送信が設定されていません",
+		})
+	}
+
+	result, err := h.eventHandler.RetryPendingNotifications(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, RetryNotificationsResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, RetryNotificationsResponse{
+		Success:      true,
+		ProcessedAt:  result.ProcessedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Attempted:    result.Attempted,
+		Succeeded:    result.Succeeded,
+		Rescheduled:  result.Rescheduled,
+		DeadLettered: result.DeadLettered,
+		Overflow:     result.OverflowSummaries,
+		Errors:       result.Errors,
+		Message:      "リトライ処理が完了しました",
+	})
+}
+
+// RefreshAnalyticsResponse はマテリアライズドビューのリフレッシュ処理のレスポンスです。
+type RefreshAnalyticsResponse struct {
+	Success     bool                        `json:"success"`
+	RefreshedAt string                      `json:"refreshed_at,omitempty"`
+	Views       []service.ViewRefreshResult `json:"views,omitempty"`
+	Message     string                      `json:"message,omitempty"`
+}
+
+// RefreshAnalyticsViews は分析用マテリアライズドビューをリフレッシュします。
+// AWS EventBridge Scheduler から毎日（深夜）呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/analytics/refresh
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "refreshed_at": "2024-01-15T03:00:00Z",
+//	  "views": [{"view_name": "mv_harvest_analytics", "success": true, "duration_ms": 120}],
+//	  "message": "リフレッシュが完了しました"
+//	}
+//
+// 一部のビューのリフレッシュに失敗した場合は 207 Multi-Status を返します。
+func (h *SchedulerHandler) RefreshAnalyticsViews(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	result, err := h.service.RefreshMaterializedViews(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, RefreshAnalyticsResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	status := http.StatusOK
+	message := "リフレッシュが完了しました"
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+		message = "一部のビューのリフレッシュに失敗しました"
+	}
+
+	return c.JSON(status, RefreshAnalyticsResponse{
+		Success:     result.Failed == 0,
+		RefreshedAt: result.RefreshedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Views:       result.Views,
+		Message:     message,
+	})
+}
+
+// GetAnalyticsRefreshStatus はマテリアライズドビューのリフレッシュ状況を返します。
+//
+// エンドポイント: GET /api/v1/scheduler/analytics/status
+func (h *SchedulerHandler) GetAnalyticsRefreshStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	statuses, err := h.service.GetMaterializedViewStatuses(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error":   "internal_error",
+			"message": "リフレッシュ状況の取得に失敗しました",
+		})
+	}
+	freshness, _ := h.service.GetAnalyticsFreshness(ctx)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"views":     statuses,
+		"freshness": freshness,
+	})
+}
+
+// DeliverAnnouncementsResponse はお知らせの配信処理のレスポンスです。
+type DeliverAnnouncementsResponse struct {
+	Success       bool     `json:"success"`
+	ProcessedAt   string   `json:"processed_at,omitempty"`
+	Announcements int      `json:"announcements"`
+	Completed     int      `json:"completed"`
+	Recipients    int      `json:"recipients"`
+	Errors        []string `json:"errors,omitempty"`
+	Message       string   `json:"message,omitempty"`
+}
+
+// DeliverAnnouncements は配信予定日時を過ぎた管理者からのお知らせを配信します。
+// AWS EventBridge Scheduler から定期的（例: 5分ごと）に呼び出されることを想定しています。
+// 対象ユーザーが多い場合は1回の処理で500人ずつ配信し、残りは次回の処理で配信します。
+//
+// エンドポイント: POST /api/v1/scheduler/announcements/deliver
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "processed_at": "2024-01-15T09:05:00Z",
+//	  "announcements": 1,
+//	  "completed": 1,
+//	  "recipients": 120,
+//	  "message": "お知らせの配信処理が完了しました"
+//	}
+//
+// 通知送信が設定されていない場合は 503 を返します。
+func (h *SchedulerHandler) DeliverAnnouncements(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if h.eventHandler == nil {
+		return c.JSON(http.StatusServiceUnavailable, DeliverAnnouncementsResponse{
+			Success: false,
+			Message: "通知送信が設定されていません",
+		})
+	}
+
+	result, err := h.eventHandler.DeliverAnnouncements(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, DeliverAnnouncementsResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, DeliverAnnouncementsResponse{
+		Success:       true,
+		ProcessedAt:   result.ProcessedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Announcements: result.Announcements,
+		Completed:     result.Completed,
+		Recipients:    result.Recipients,
+		Errors:        result.Errors,
+		Message:       "お知らせの配信処理が完了しました",
+	})
+}
+
+// PruneDeviceTokensResponse は無効なデバイストークンの削除処理のレスポンスです。
+type PruneDeviceTokensResponse struct {
+	Success     bool   `json:"success"`
+	ProcessedAt string `json:"processed_at,omitempty"`
+	Deleted     int64  `json:"deleted"`
+	Message     string `json:"message,omitempty"`
+}
+
+// PruneDeviceTokens は無効化から90日以上経過したデバイストークンを削除します。
+// AWS EventBridge Scheduler から毎日呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/device-tokens/prune
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "processed_at": "2024-01-15T03:00:00Z",
+//	  "deleted": 12,
+//	  "message": "無効なデバイストークンを削除しました"
+//	}
+func (h *SchedulerHandler) PruneDeviceTokens(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	deleted, err := h.service.PruneInactiveDeviceTokens(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, PruneDeviceTokensResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, PruneDeviceTokensResponse{
+		Success:     true,
+		ProcessedAt: time.Now().Format("2006-01-02T15:04:05Z07:00"),
+		Deleted:     deleted,
+		Message:     "無効なデバイストークンを削除しました",
+	})
+}
+
 // GetSchedulerStatus はスケジューラーのステータスを返します。
 // ヘルスチェック用のエンドポイントです。
 //
@@ -151,9 +467,20 @@ func (h *Handler) RegisterSchedulerRoutes(e *echo.Echo, schedulerToken string, e
 	// トークン認証ミドルウェアを適用
 	scheduler.Use(schedulerAuthMiddleware(schedulerToken))
 
+	// POSTエンドポイントは冪等キーで再送時の重複処理を防ぐ
+	idempotent := schedulerIdempotencyMiddleware(h.service)
+
 	// ルート登録
-	scheduler.POST("/notifications", schedulerHandler.ProcessScheduledNotifications)
+	scheduler.POST("/notifications", schedulerHandler.ProcessScheduledNotifications, idempotent)
+	scheduler.POST("/notifications/retry", schedulerHandler.RetryNotifications, idempotent)
+	scheduler.POST("/notifications/outbox", schedulerHandler.DispatchNotificationOutbox, idempotent)
 	scheduler.GET("/status", schedulerHandler.GetSchedulerStatus)
+	scheduler.POST("/analytics/refresh", schedulerHandler.RefreshAnalyticsViews, idempotent)
+	scheduler.GET("/analytics/status", schedulerHandler.GetAnalyticsRefreshStatus)
+	scheduler.POST("/device-tokens/prune", schedulerHandler.PruneDeviceTokens, idempotent)
+	scheduler.POST("/announcements/deliver", schedulerHandler.DeliverAnnouncements, idempotent)
+	scheduler.POST("/retention/purge", schedulerHandler.PurgeExpiredData, idempotent)
+	scheduler.POST("/seasons/rollover", schedulerHandler.RunSeasonRollover, idempotent)
 }
 
 // schedulerAuthMiddleware はスケジューラー用の簡易認証ミドルウェアです。
@@ -170,9 +497,14 @@ func schedulerAuthMiddleware(expectedToken string) echo.MiddlewareFunc {
 			token := c.Request().Header.Get("X-Scheduler-Token")
 			if token == "" {
 				// ヘッダーにない場合はリクエストボディからも確認
-				var req ProcessNotificationsRequest
-				if err := c.Bind(&req); err == nil && req.SchedulerToken != "" {
-					token = req.SchedulerToken
+				// （冪等キーの確認でもボディを読むため、読んだボディは戻す）
+				if req := c.Request(); req.Body != nil {
+					body, _ := io.ReadAll(req.Body)
+					req.Body = io.NopCloser(bytes.NewReader(body))
+					var payload ProcessNotificationsRequest
+					if err := json.Unmarshal(body, &payload); err == nil && payload.SchedulerToken != "" {
+						token = payload.SchedulerToken
+					}
 				}
 			}
 
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Scheduler Idempotency - スケジューラー呼び出しの冪等キー
+// =============================================================================
+// EventBridge の再送で同じ処理（通知の一括送信など）が二重に実行されないよう、
+// スケジューラーのPOSTエンドポイントは冪等キーを受け付けます。
+//
+// 冪等キーの指定方法（どちらか）:
+//   - ヘッダー: Idempotency-Key
+//   - リクエストボディ: {"idempotency_key": "..."}
+//     （EventBridge Scheduler では "<aws.scheduler.execution-id>" を指定すると再送時も同じ値になります）
+//
+// 同じキーの再送には処理せずに記録したレスポンスを返し、Idempotent-Replayed: true を付けます。
+// 冪等キーがないリクエストはこれまでどおり毎回処理します。
+
+const (
+	// IdempotencyKeyHeader は冪等キーのヘッダー
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader は記録したレスポンスを返したことを示すヘッダー
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// schedulerIdempotencyBody はリクエストボディの冪等キーです。
+type schedulerIdempotencyBody struct {
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// schedulerIdempotencyMiddleware は冪等キー付きのリクエストの処理結果を記録し、同じキーの再送には記録したレスポンスを返します。
+//   - 同じキーのリクエストを処理中の場合は 409
+//   - 冪等キーが長すぎる場合は 400
+//   - 処理がサーバーエラー（5xx）になった場合は記録せず、再送で処理し直す
+func schedulerIdempotencyMiddleware(svc *service.Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := schedulerIdempotencyKey(c)
+			if key == "" {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			endpoint := c.Path()
+			cached, err := svc.BeginSchedulerInvocation(ctx, endpoint, key)
+			switch {
+			case errors.Is(err, service.ErrInvalidIdempotencyKey):
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error":   "invalid_idempotency_key",
+					"message": "冪等キーが不正です",
+				})
+			case errors.Is(err, service.ErrSchedulerInvocationInProgress):
+				return c.JSON(http.StatusConflict, map[string]string{
+					"error":   "idempotency_conflict",
+					"message": "同じ冪等キーのリクエストを処理中です",
+				})
+			case err != nil:
+				return c.JSON(http.StatusInternalServerError, map[string]string{
+					"error":   "internal_error",
+					"message": "冪等キーの確認に失敗しました",
+				})
+			case cached != nil:
+				c.Response().Header().Set(IdempotentReplayedHeader, "true")
+				return c.Blob(cached.StatusCode, echo.MIMEApplicationJSONCharsetUTF8, []byte(cached.ResponseBody))
+			}
+
+			// レスポンスを記録しながら処理する
+			recorder := &responseBodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			handlerErr := next(c)
+
+			status := c.Response().Status
+			if handlerErr != nil {
+				status = http.StatusInternalServerError
+			}
+			// 記録に失敗してもレスポンスは返す（処理中のままのキーは一定時間後に処理し直せる）
+			_ = svc.CompleteSchedulerInvocation(ctx, endpoint, key, status, recorder.body.Bytes())
+			return handlerErr
+		}
+	}
+}
+
+// schedulerIdempotencyKey はヘッダーまたはリクエストボディから冪等キーを取得します。
+// ボディは後続の処理でも読めるように戻します。
+func schedulerIdempotencyKey(c echo.Context) string {
+	if key := c.Request().Header.Get(IdempotencyKeyHeader); key != "" {
+		return key
+	}
+
+	req := c.Request()
+	if req.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) == 0 {
+		return ""
+	}
+	var payload schedulerIdempotencyBody
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.IdempotencyKey
+}
+
+// responseBodyRecorder はレスポンスボディを書き込みながら記録する http.ResponseWriter です。
+type responseBodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write はレスポンスボディを書き込み、記録します。
+func (r *responseBodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
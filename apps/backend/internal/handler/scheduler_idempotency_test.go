@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Scheduler Idempotency Tests - スケジューラー冪等キーのテスト
+// =============================================================================
+// テスト対象:
+//   - 同じ冪等キーの再送に記録したレスポンスを返し、処理を再実行しないこと
+//   - 処理中のキーの 409、サーバーエラー時の再処理
+//   - リクエストボディの冪等キーとスケジューラートークン
+
+// newSchedulerIdempotencyTestEcho は冪等キーのミドルウェアを付けたテスト用ルートを作成します。
+// ハンドラーは呼び出し回数を返し、status が 500 の間はサーバーエラーを返します。
+func newSchedulerIdempotencyTestEcho(svc *service.Service, calls *int, status *int) *echo.Echo {
+	e := echo.New()
+	group := e.Group("/api/v1/scheduler")
+	group.Use(schedulerAuthMiddleware("scheduler-secret"))
+	group.POST("/notifications", func(c echo.Context) error {
+		*calls++
+		return c.JSON(*status, map[string]int{"call": *calls})
+	}, schedulerIdempotencyMiddleware(svc))
+	return e
+}
+
+// postScheduler はスケジューラーエンドポイントへPOSTします。
+func postScheduler(e *echo.Echo, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduler/notifications", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestSchedulerIdempotency_Replay は同じ冪等キーの再送のテストです。
+// 期待動作:
+//   - 同じキーの再送は処理せず、記録したレスポンスと Idempotent-Replayed を返す
+//   - 別のキー・キーなしのリクエストは毎回処理する
+//   - ボディの idempotency_key とスケジューラートークンを併用できる
+func TestSchedulerIdempotency_Replay(t *testing.T) {
+	// Arrange
+	svc := service.NewService(repository.NewMockRepositories())
+	calls, status := 0, http.StatusOK
+	e := newSchedulerIdempotencyTestEcho(svc, &calls, &status)
+	body := `{"scheduler_token":"scheduler-secret","idempotency_key":"exec-1"}`
+
+	// Act
+	first := postScheduler(e, body, nil)
+	replay := postScheduler(e, body, nil)
+	other := postScheduler(e, "", map[string]string{"X-Scheduler-Token": "scheduler-secret", IdempotencyKeyHeader: "exec-2"})
+	noKey := postScheduler(e, "", map[string]string{"X-Scheduler-Token": "scheduler-secret"})
+
+	// Assert
+	if first.Code != http.StatusOK || replay.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d / %d: %s", first.Code, replay.Code, replay.Body.String())
+	}
+	if calls != 3 {
+		t.Errorf("Expected handler to run 3 times, got %d", calls)
+	}
+	if replay.Body.String() != first.Body.String() || replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("Expected cached response, got %q (replayed=%q)", replay.Body.String(), replay.Header().Get(IdempotentReplayedHeader))
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" || other.Code != http.StatusOK || noKey.Code != http.StatusOK {
+		t.Errorf("Unexpected responses: first replayed=%q other=%d noKey=%d",
+			first.Header().Get(IdempotentReplayedHeader), other.Code, noKey.Code)
+	}
+
+	// 認証できないリクエストには記録したレスポンスを返さない
+	if rec := postScheduler(e, `{"idempotency_key":"exec-1"}`, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without scheduler token, got %d", rec.Code)
+	}
+}
+
+// TestSchedulerIdempotency_ErrorsAndInProgress はサーバーエラーと処理中のキーのテストです。
+// 期待動作:
+//   - サーバーエラー（5xx）のレスポンスは記録せず、再送で処理し直す
+//   - 処理中のキーへのリクエストは 409
+//   - 放棄された処理中のキーは一定時間後に処理し直す
+func TestSchedulerIdempotency_ErrorsAndInProgress(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := service.NewService(mockRepos)
+	calls, status := 0, http.StatusInternalServerError
+	e := newSchedulerIdempotencyTestEcho(svc, &calls, &status)
+	headers := map[string]string{"X-Scheduler-Token": "scheduler-secret", IdempotencyKeyHeader: "exec-retry"}
+
+	// Act & Assert
+	if rec := postScheduler(e, "", headers); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d", rec.Code)
+	}
+	status = http.StatusOK
+	if rec := postScheduler(e, "", headers); rec.Code != http.StatusOK || calls != 2 {
+		t.Errorf("Expected retry after server error to run again, got %d (calls=%d)", rec.Code, calls)
+	}
+
+	ctx := context.Background()
+	_, _ = mockRepos.SchedulerInvocation().Reserve(ctx, &model.SchedulerInvocation{
+		Endpoint:       "/api/v1/scheduler/notifications",
+		IdempotencyKey: "exec-running",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	})
+	running := map[string]string{"X-Scheduler-Token": "scheduler-secret", IdempotencyKeyHeader: "exec-running"}
+	if rec := postScheduler(e, "", running); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for in-progress key, got %d", rec.Code)
+	}
+
+	for _, invocation := range mockRepos.GetMockSchedulerInvocationRepository().Invocations {
+		invocation.CreatedAt = time.Now().Add(-time.Hour)
+	}
+	if rec := postScheduler(e, "", running); rec.Code != http.StatusOK {
+		t.Errorf("Expected abandoned key to be processed again, got %d", rec.Code)
+	}
+	if rec := postScheduler(e, "", map[string]string{"X-Scheduler-Token": "scheduler-secret", IdempotencyKeyHeader: strings.Repeat("k", 201)}); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for too long key, got %d", rec.Code)
+	}
+}
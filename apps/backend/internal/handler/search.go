@@ -0,0 +1,72 @@
+// Package handler - Search Handler
+//
+// 検索バーの横断検索のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET /api/v1/search - 作物・タスク・区画・成長記録のメモの横断検索
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// Search は作物・タスク・区画・成長記録のメモを横断して検索し、関連度の高い順に返します。
+// 英数字の単語は全文検索、日本語や表記の揺れはトライグラム・部分一致で検索します。
+// X-Org-ID を指定した場合は組織の作物・区画と作物の成長記録を検索します。
+//
+// クエリパラメータ:
+//   - q: 検索語（必須、100文字まで）
+//   - type: 検索の対象（crops/tasks/plots/growth_records のカンマ区切り、省略した場合は全て）
+//   - limit: 最大件数（省略した場合は 20、50 まで）
+//
+// レスポンス:
+//   - 200: 検索結果（query, results: type, id, crop_id, title, snippet, rank, updated_at）
+//   - 400: 検索語・type・limit が不正
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) Search(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var types []string
+	if param := c.QueryParam("type"); param != "" {
+		types = strings.Split(param, ",")
+	}
+	limit := 0
+	if param := c.QueryParam("limit"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil || parsed < 1 {
+			return apperrors.NewBadRequestError("Invalid limit")
+		}
+		limit = parsed
+	}
+
+	results, err := h.service.Search(ctx, userID, c.QueryParam("q"), types, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSearchQuery):
+			return apperrors.NewBadRequestError("Search query must be 1 to 100 characters")
+		case errors.Is(err, service.ErrInvalidSearchType):
+			return apperrors.NewBadRequestError("Invalid search type")
+		}
+		return apperrors.NewInternalError("Failed to search")
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,124 @@
+// Package handler - Season Rollover Handler
+//
+// シーズンの締めのHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST /api/v1/scheduler/seasons/rollover - 前年のシーズンの締め（EventBridge Scheduler 用、season で対象を指定）
+//   - GET /api/v1/analytics/seasons/:season - シーズンの区画ごとの記録
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// SeasonRolloverResponse はシーズンの締めの処理のレスポンスです。
+type SeasonRolloverResponse struct {
+	Success bool                          `json:"success"`
+	Result  *service.SeasonRolloverResult `json:"result,omitempty"`
+	Message string                        `json:"message,omitempty"`
+}
+
+// RunSeasonRollover はシーズンを締めます（収穫済み・失敗の作物のアーカイブ、区画ごとの記録、ふりかえりの通知）。
+// AWS EventBridge Scheduler から毎年1月1日に呼び出されることを想定しています。
+//
+// エンドポイント: POST /api/v1/scheduler/seasons/rollover
+//
+// クエリパラメータ:
+//   - season: 締めるシーズン（植え付け年、省略時は前年）
+//
+// レスポンス:
+//
+//	{
+//	  "success": true,
+//	  "result": {"season": 2025, "users": 120, "archived_crops": 840, "summaries": 310, "notifications": 118},
+//	  "message": "シーズンを締めました"
+//	}
+//
+// 終わっていないシーズンを指定した場合は 400、通知送信が設定されていない場合は 503、
+// 一部のユーザーの処理に失敗した場合は 207 Multi-Status を返します。
+func (h *SchedulerHandler) RunSeasonRollover(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if h.eventHandler == nil {
+		return c.JSON(http.StatusServiceUnavailable, SeasonRolloverResponse{
+			Success: false,
+			Message: "通知送信が設定されていません",
+		})
+	}
+
+	var season int
+	if seasonStr := c.QueryParam("season"); seasonStr != "" {
+		parsed, err := strconv.Atoi(seasonStr)
+		if err != nil || parsed <= 0 {
+			return c.JSON(http.StatusBadRequest, SeasonRolloverResponse{
+				Success: false,
+				Message: "season は年（例: 2025）で指定してください",
+			})
+		}
+		season = parsed
+	}
+
+	result, err := h.eventHandler.RunSeasonRollover(ctx, season)
+	if errors.Is(err, service.ErrSeasonNotFinished) {
+		return c.JSON(http.StatusBadRequest, SeasonRolloverResponse{
+			Success: false,
+			Message: "終わっていないシーズンは締められません",
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, SeasonRolloverResponse{
+			Success: false,
+			Message: "処理中にエラーが発生しました: " + err.Error(),
+		})
+	}
+
+	status := http.StatusOK
+	message := "シーズンを締めました"
+	if len(result.Errors) > 0 {
+		status = http.StatusMultiStatus
+		message = "一部のユーザーのシーズンの締めに失敗しました"
+	}
+
+	return c.JSON(status, SeasonRolloverResponse{
+		Success: len(result.Errors) == 0,
+		Result:  result,
+		Message: message,
+	})
+}
+
+// GetSeasonSummaries はシーズンの区画ごとの記録を取得します。
+// 記録はシーズンの締めで作成されるため、締める前のシーズンは空の一覧を返します。
+//
+// パスパラメータ:
+//   - season: シーズン（植え付け年）
+//
+// レスポンス:
+//   - 200: SeasonSummary の配列（総収穫量の多い順）
+//   - 400: シーズンの形式エラー
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetSeasonSummaries(c echo.Context) error {
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil || season <= 0 {
+		return apperrors.NewBadRequestError("Invalid season")
+	}
+
+	summaries, err := h.service.GetSeasonSummaries(c.Request().Context(), userID, season)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get season summaries")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(summaries, dto.NewSeasonSummaryResponse))
+}
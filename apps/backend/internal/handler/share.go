@@ -0,0 +1,333 @@
+// Package handler - Share Handler
+//
+// 公開共有機能のHTTPハンドラを提供します。
+// エンドポイント:
+//   - POST   /api/v1/users/me/share-tokens     - 共有トークン発行
+//   - GET    /api/v1/users/me/share-tokens     - 共有トークン一覧
+//   - DELETE /api/v1/users/me/share-tokens/:id - 共有トークン失効
+//   - GET    /public/:shareToken/stats.json    - 収穫統計（JSON、認証不要）
+//   - GET    /public/:shareToken/stats.svg     - 収穫統計バッジ（SVG、認証不要）
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// 定数定義
+// =============================================================================
+
+const (
+	// PublicStatsCacheTTL はサーバー内キャッシュの有効期間
+	PublicStatsCacheTTL = 10 * time.Minute
+
+	// PublicStatsCacheControl は公開統計レスポンスのCache-Controlヘッダー
+	// ブログ埋め込みを想定して CDN・ブラウザでキャッシュさせますが、
+	// 失効した共有トークンの統計を返し続けないよう、キャッシュは数分で切れるようにします
+	PublicStatsCacheControl = "public, max-age=300, stale-while-revalidate=60"
+
+	// publicStatsMaxAge・publicStatsStaleWhileRevalidate は PublicStatsCacheControl の期間です
+	publicStatsMaxAge               = 5 * time.Minute
+	publicStatsStaleWhileRevalidate = time.Minute
+)
+
+// =============================================================================
+// Request/Response 構造体
+// =============================================================================
+
+// CreateShareTokenRequest は共有トークン発行リクエストの構造体です。
+//
+// フィールド:
+//   - ExpiresInDays: 有効日数（任意、未指定の場合は無期限）
+type CreateShareTokenRequest struct {
+	ExpiresInDays *int `json:"expires_in_days" validate:"omitempty,min=1,max=3650"`
+}
+
+// =============================================================================
+// 公開統計キャッシュ
+// =============================================================================
+
+// publicStatsEntry はキャッシュされた公開統計です。
+type publicStatsEntry struct {
+	stats     *service.PublicStats
+	expiresAt time.Time
+}
+
+// publicStatsCache はトークン単位（トークンのハッシュ）で公開統計をキャッシュします。
+// バッジは埋め込み先から頻繁に取得されるため、DB集計を間引きます。
+// 共有トークンの有効期限を過ぎてキャッシュせず、失効時は invalidate で削除します。
+type publicStatsCache struct {
+	mu      sync.Mutex
+	entries map[string]publicStatsEntry
+	ttl     time.Duration
+}
+
+// newPublicStatsCache は新しいpublicStatsCacheを作成します。
+func newPublicStatsCache(ttl time.Duration) *publicStatsCache {
+	return &publicStatsCache{
+		entries: make(map[string]publicStatsEntry),
+		ttl:     ttl,
+	}
+}
+
+// get はキャッシュされた統計を取得します。期限切れの場合はnilを返します。
+func (c *publicStatsCache) get(tokenHash string) *service.PublicStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[tokenHash]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, tokenHash)
+		return nil
+	}
+	return entry.stats
+}
+
+// set は統計をキャッシュに保存します（共有トークンの有効期限がTTLより前の場合は有効期限まで）。
+func (c *publicStatsCache) set(tokenHash string, stats *service.PublicStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := time.Now().Add(c.ttl)
+	if stats.ExpiresAt != nil && stats.ExpiresAt.Before(expiresAt) {
+		expiresAt = *stats.ExpiresAt
+	}
+	c.entries[tokenHash] = publicStatsEntry{stats: stats, expiresAt: expiresAt}
+}
+
+// invalidate はトークンのキャッシュを削除します。
+func (c *publicStatsCache) invalidate(tokenHash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, tokenHash)
+}
+
+// =============================================================================
+// 共有トークン管理
+// =============================================================================
+
+// CreateShareToken は新しい共有トークンを発行します。
+// トークン文字列（token）は発行時のレスポンスでのみ返します（保存するのはハッシュのみ）。
+//
+// リクエストボディ:
+//   - expires_in_days: 有効日数（任意）
+//
+// レスポンス:
+//   - 201: 発行された共有トークン
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+func (h *Handler) CreateShareToken(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req CreateShareTokenRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		t := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	token, err := h.service.CreateShareToken(ctx, userID, expiresAt)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to create share token")
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewShareTokenResponse(token))
+}
+
+// GetShareTokens はユーザーの共有トークン一覧を取得します（トークン文字列は含みません）。
+//
+// レスポンス:
+//   - 200: 共有トークンの配列
+//   - 401: 認証エラー
+func (h *Handler) GetShareTokens(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	tokens, err := h.service.GetUserShareTokens(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get share tokens")
+	}
+
+	return c.JSON(http.StatusOK, dto.List(tokens, dto.NewShareTokenResponse))
+}
+
+// RevokeShareToken は共有トークンを失効させます。
+// 失効後は公開エンドポイントから404が返されます（サーバー内キャッシュは即時に無効化しますが、
+// CDN・ブラウザのキャッシュが切れるまでの最大6分間は失効前のレスポンスが返る場合があります）。
+//
+// パスパラメータ:
+//   - id: 共有トークンID
+//
+// レスポンス:
+//   - 204: 失効成功
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 404: 共有トークンが見つからない
+//   - 500: 失効の保存に失敗
+func (h *Handler) RevokeShareToken(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid share token ID")
+	}
+
+	token, err := h.service.RevokeShareToken(ctx, userID, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, service.ErrShareTokenNotOwned) {
+			return apperrors.NewNotFoundError("Share token")
+		}
+		return apperrors.NewInternalError("Failed to revoke share token")
+	}
+
+	h.publicStatsCache.invalidate(token.TokenHash)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// =============================================================================
+// 公開統計エンドポイント（認証不要）
+// =============================================================================
+
+// loadPublicStats はキャッシュを考慮して公開統計を取得します。
+func (h *Handler) loadPublicStats(c echo.Context) (*service.PublicStats, error) {
+	token := c.Param("shareToken")
+	tokenHash := auth.HashToken(token)
+
+	if stats := h.publicStatsCache.get(tokenHash); stats != nil {
+		return stats, nil
+	}
+
+	stats, err := h.service.GetPublicStats(c.Request().Context(), token)
+	if err != nil {
+		if errors.Is(err, service.ErrShareTokenInvalid) {
+			return nil, apperrors.NewNotFoundError("Share token")
+		}
+		return nil, apperrors.NewInternalError("Failed to get stats")
+	}
+
+	h.publicStatsCache.set(tokenHash, stats)
+	return stats, nil
+}
+
+// publicStatsCacheControl は公開統計の Cache-Control ヘッダーを返します。
+// 共有トークンの有効期限が max-age より前の場合は、期限を過ぎて CDN・ブラウザがキャッシュしないようにします。
+func publicStatsCacheControl(stats *service.PublicStats) string {
+	if stats.ExpiresAt == nil {
+		return PublicStatsCacheControl
+	}
+	remaining := time.Until(*stats.ExpiresAt)
+	if remaining >= publicStatsMaxAge+publicStatsStaleWhileRevalidate {
+		return PublicStatsCacheControl
+	}
+	if remaining <= 0 {
+		return "no-store"
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(min(remaining, publicStatsMaxAge).Seconds()))
+}
+
+// GetPublicStatsJSON は共有トークンに紐づく収穫統計をJSONで返します。
+//
+// パスパラメータ:
+//   - shareToken: 共有トークン
+//
+// レスポンス:
+//   - 200: 収穫統計
+//   - 404: トークンが無効
+func (h *Handler) GetPublicStatsJSON(c echo.Context) error {
+	stats, err := h.loadPublicStats(c)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", publicStatsCacheControl(stats))
+	return c.JSON(http.StatusOK, stats)
+}
+
+// GetPublicStatsSVG は共有トークンに紐づく収穫統計をSVGバッジで返します。
+// ブログ等に <img> タグで埋め込むことを想定しています。
+//
+// パスパラメータ:
+//   - shareToken: 共有トークン
+//
+// レスポンス:
+//   - 200: SVGバッジ（例: "harvested | 42 kg this year"）
+//   - 404: トークンが無効
+func (h *Handler) GetPublicStatsSVG(c echo.Context) error {
+	stats, err := h.loadPublicStats(c)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", publicStatsCacheControl(stats))
+	return c.Blob(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(renderStatsBadge(stats)))
+}
+
+// renderStatsBadge はshields.io風のSVGバッジを生成します。
+func renderStatsBadge(stats *service.PublicStats) string {
+	label := "harvested"
+	value := fmt.Sprintf("%s kg this year", formatBadgeKg(stats.TotalHarvestKg))
+
+	// 文字幅は概算（Verdana 11px で1文字あたり約7px）
+	labelWidth := len(label)*7 + 10
+	valueWidth := len(value)*7 + 10
+	totalWidth := labelWidth + valueWidth
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[3]s">
+<title>%[2]s: %[3]s</title>
+<rect width="%[4]d" height="20" fill="#555"/>
+<rect x="%[4]d" width="%[5]d" height="20" fill="#4c9a2a"/>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[6]d" y="14">%[2]s</text>
+<text x="%[7]d" y="14">%[3]s</text>
+</g>
+</svg>`,
+		totalWidth,
+		html.EscapeString(label),
+		html.EscapeString(value),
+		labelWidth,
+		valueWidth,
+		labelWidth/2,
+		labelWidth+valueWidth/2,
+	)
+}
+
+// formatBadgeKg はバッジ表示用に収穫量を整形します。
+// 10kg以上は整数、それ未満は小数第1位まで表示します。
+func formatBadgeKg(kg float64) string {
+	if kg >= 10 {
+		return strconv.FormatFloat(kg, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(kg, 'f', 1, 64)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Share Handler Tests - 公開共有のハンドラのテスト
+// =============================================================================
+// テスト対象:
+//   - CreateShareToken / GetShareTokens: トークン文字列は発行時のみ返す
+//   - loadPublicStats / RevokeShareToken: トークンのハッシュ単位のキャッシュと失効時の削除
+//   - RevokeShareToken: 見つからない場合のみ 404、保存の失敗は 500
+//   - publicStatsCache / publicStatsCacheControl: 共有トークンの有効期限を過ぎてキャッシュしない
+
+// TestShareToken_PublicStatsCache は共有トークンの発行・公開統計・失効のテストです。
+// 期待動作:
+//   - 発行時のレスポンスにのみトークン文字列を含み、一覧には含めない
+//   - 公開統計はトークンのハッシュをキーにキャッシュする（トークン文字列をキャッシュのキーにしない）
+//   - 失効するとキャッシュを削除し、公開統計は 404
+func TestShareToken_PublicStatsCache(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	_ = setup.mockRepos.User().Create(context.Background(), &model.User{Email: "share@example.com"})
+	createCtx, createRec := setup.createAuthenticatedContext(http.MethodPost, "/api/v1/users/me/share-tokens", `{}`, 1)
+	if err := setup.handler.CreateShareToken(createCtx); err != nil {
+		t.Fatalf("CreateShareToken failed: %v", err)
+	}
+	var created dto.ShareTokenResponse
+	_ = json.Unmarshal(createRec.Body.Bytes(), &created)
+	publicStats := func() error {
+		c, _ := setup.createContext(http.MethodGet, "/public/"+created.Token+"/stats.json", "")
+		c.SetParamNames("shareToken")
+		c.SetParamValues(created.Token)
+		return setup.handler.GetPublicStatsJSON(c)
+	}
+
+	// Act
+	listCtx, listRec := setup.createAuthenticatedContext(http.MethodGet, "/api/v1/users/me/share-tokens", "", 1)
+	listErr := setup.handler.GetShareTokens(listCtx)
+	statsErr := publicStats()
+	_, cachedByHash := setup.handler.publicStatsCache.entries[auth.HashToken(created.Token)]
+	_, cachedByToken := setup.handler.publicStatsCache.entries[created.Token]
+	revokeCtx, revokeRec := setup.createAuthenticatedContext(http.MethodDelete, "/api/v1/users/me/share-tokens/1", "", 1)
+	revokeCtx.SetParamNames("id")
+	revokeCtx.SetParamValues(fmt.Sprint(created.ID))
+	revokeErr := setup.handler.RevokeShareToken(revokeCtx)
+	_, cachedAfterRevoke := setup.handler.publicStatsCache.entries[auth.HashToken(created.Token)]
+	revokedErr := publicStats()
+
+	// Assert
+	if len(created.Token) != 64 {
+		t.Fatalf("Expected the token in the create response, got %s", createRec.Body.String())
+	}
+	if listErr != nil || strings.Contains(listRec.Body.String(), created.Token) || strings.Contains(listRec.Body.String(), `"token"`) {
+		t.Errorf("Expected the list not to include the token, got %v %s", listErr, listRec.Body.String())
+	}
+	if statsErr != nil || !cachedByHash || cachedByToken {
+		t.Errorf("Expected the stats to be cached by the token hash, got %v (hash %v, token %v)", statsErr, cachedByHash, cachedByToken)
+	}
+	if revokeErr != nil || revokeRec.Code != http.StatusNoContent || cachedAfterRevoke {
+		t.Errorf("Expected the revoke to drop the cache, got %v %d (cached %v)", revokeErr, revokeRec.Code, cachedAfterRevoke)
+	}
+	var appErr *apperrors.AppError
+	if !errors.As(revokedErr, &appErr) || appErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for the revoked token, got %v", revokedErr)
+	}
+}
+
+// TestRevokeShareToken_Errors は共有トークンの失効のエラーのテストです。
+// 期待動作:
+//   - 存在しない・他のユーザーの共有トークンは 404
+//   - 保存の失敗は 500（404 にしない）
+func TestRevokeShareToken_Errors(t *testing.T) {
+	// Arrange
+	setup := newIntegrationTestSetup()
+	token := &model.ShareToken{UserID: 2, TokenHash: auth.HashToken("other")}
+	_ = setup.mockRepos.ShareToken().Create(context.Background(), token)
+	own := &model.ShareToken{UserID: 1, TokenHash: auth.HashToken("own")}
+	_ = setup.mockRepos.ShareToken().Create(context.Background(), own)
+	revoke := func(id uint) error {
+		c, _ := setup.createAuthenticatedContext(http.MethodDelete, "/api/v1/users/me/share-tokens/"+fmt.Sprint(id), "", 1)
+		c.SetParamNames("id")
+		c.SetParamValues(fmt.Sprint(id))
+		return setup.handler.RevokeShareToken(c)
+	}
+
+	// Act
+	missingErr := revoke(999)
+	otherErr := revoke(token.ID)
+	setup.mockRepos.GetMockShareTokenRepository().UpdateErr = errors.New("connection reset")
+	updateErr := revoke(own.ID)
+
+	// Assert
+	for name, err := range map[string]error{"missing": missingErr, "other user's": otherErr} {
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected 404 for the %s share token, got %v", name, err)
+		}
+	}
+	var appErr *apperrors.AppError
+	if !errors.As(updateErr, &appErr) || appErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected 500 for the update failure, got %v", updateErr)
+	}
+}
+
+// TestPublicStatsCache_ExpiresAt は共有トークンの有効期限とキャッシュのテストです。
+// 期待動作:
+//   - 有効期限がTTLより前の場合は有効期限までキャッシュし、期限を過ぎた統計は返さない
+//   - 無期限のトークンはTTLまでキャッシュする
+//   - Cache-Control の max-age は有効期限まで（無期限・十分に先の場合は PublicStatsCacheControl）
+func TestPublicStatsCache_ExpiresAt(t *testing.T) {
+	// Arrange
+	cache := newPublicStatsCache(PublicStatsCacheTTL)
+	soon := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Second)
+	later := time.Now().Add(4 * time.Minute)
+	farFuture := time.Now().Add(30 * 24 * time.Hour)
+
+	// Act
+	cache.set("soon", &service.PublicStats{ExpiresAt: &soon})
+	cache.set("expired", &service.PublicStats{ExpiresAt: &past})
+	cache.set("unlimited", &service.PublicStats{})
+	expired := cache.get("expired")
+	unlimited := cache.get("unlimited")
+
+	// Assert
+	if entry := cache.entries["soon"]; !entry.expiresAt.Equal(soon) {
+		t.Errorf("Expected the entry to expire with the share token at %v, got %v", soon, entry.expiresAt)
+	}
+	if expired != nil {
+		t.Errorf("Expected no stats past the share token expiry, got %+v", expired)
+	}
+	if unlimited == nil || cache.entries["unlimited"].expiresAt.After(time.Now().Add(PublicStatsCacheTTL)) {
+		t.Errorf("Expected the unlimited token to be cached for the TTL, got %+v", cache.entries["unlimited"])
+	}
+	if got := publicStatsCacheControl(&service.PublicStats{ExpiresAt: &later}); !strings.HasPrefix(got, "public, max-age=23") || strings.Contains(got, "stale-while-revalidate") {
+		t.Errorf("Expected max-age up to the expiry (about 240s), got %q", got)
+	}
+	if got := publicStatsCacheControl(&service.PublicStats{ExpiresAt: &farFuture}); got != PublicStatsCacheControl {
+		t.Errorf("Expected %q for a far expiry, got %q", PublicStatsCacheControl, got)
+	}
+	if got := publicStatsCacheControl(&service.PublicStats{}); got != PublicStatsCacheControl {
+		t.Errorf("Expected %q without expiry, got %q", PublicStatsCacheControl, got)
+	}
+}
@@ -0,0 +1,124 @@
+// Package handler - Sync Handler
+//
+// モバイルアプリのオフライン利用のための同期のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET  /api/v1/sync - 前回の同期以降に作成・更新・削除された記録の取得
+//   - POST /api/v1/sync - オフラインで記録した変更の反映（競合の判定あり）
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Request/Response 構造体
+// =============================================================================
+
+// SyncPushRequest はオフラインで記録した変更の反映のリクエストボディです。
+//
+// フィールド:
+//   - Changes: 変更の一覧（記録した順、最大500件）
+//   - Strategy: 競合の解決方法（server_wins/client_wins、デフォルト: server_wins）
+type SyncPushRequest struct {
+	Changes  []service.SyncChange `json:"changes" validate:"required"`
+	Strategy string               `json:"strategy" validate:"omitempty,oneof=server_wins client_wins"`
+}
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// GetSyncChanges は前回の同期以降に作成・更新・削除された記録を返します。
+// 対象はタスク・作物・区画・収穫記録で、種類ごとに created・updated・deleted（削除した記録のID）を返します。
+// 次回の同期では next_cursor を since に指定します（直前の数秒分の変更は重複して返すため、IDで上書きしてください）。
+//
+// クエリパラメータ:
+//   - since: 前回の同期の next_cursor（省略した場合は全件を返し、full が true）
+//
+// レスポンス:
+//   - 200: 記録の変更（tasks, crops, plots, harvests, full, next_cursor, server_time）
+//   - 400: 不正な since（INVALID_CURSOR）
+//   - 401: 認証エラー
+//   - 410: since が削除の記録の保持期間より古い（SYNC_CURSOR_EXPIRED、since を省略して全件を再取得）
+//   - 500: 内部エラー
+func (h *Handler) GetSyncChanges(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	changes, err := h.service.GetSyncChanges(ctx, userID, c.QueryParam("since"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSyncCursor):
+			return apperrors.New(apperrors.ErrCodeInvalidCursor, "Invalid sync cursor")
+		case errors.Is(err, service.ErrSyncCursorExpired):
+			return apperrors.New(apperrors.ErrCodeSyncCursorExpired, "Sync cursor has expired, fetch all records without since")
+		}
+		return apperrors.NewInternalError("Failed to fetch sync changes")
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
+
+// PushSyncChanges はオフラインで記録した変更を順番に反映します。
+// 全ての変更を1つのトランザクションで反映し、変更ごとの結果（applied/conflict/rejected）を返します。
+// 競合・不正な変更は他の変更の反映を止めません。
+//
+// 変更（changes の各要素）:
+//   - entity: tasks/crops/plots/harvests
+//   - op: upsert（id を省略した場合は新規作成）/ delete
+//   - id: サーバーの記録のID
+//   - client_id: クライアントの一時的なID（結果にそのまま返す）
+//   - base_updated_at: 最後に取得したサーバーの updated_at（更新・削除の競合の判定に使用）
+//   - data: upsert の記録の内容（取得のエンドポイントと同じ形式、収穫記録は作成のみ）
+//
+// 競合（サーバーの記録が base_updated_at より後に更新、またはサーバーで削除済み）:
+//   - server_wins: 反映せず、conflict とサーバーの記録を返す
+//   - client_wins: クライアントの変更を反映する（削除済みの記録の upsert は新しい記録として作成）
+//
+// レスポンス:
+//   - 200: 変更ごとの結果（results, applied, conflicts, rejected）
+//   - 400: リクエストボディの形式が不正、変更が500件を超える
+//   - 401: 認証エラー
+//   - 413: リクエストボディが上限（BODY_LIMIT_BATCH_BYTES）を超える（PAYLOAD_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー（変更は反映しない）
+func (h *Handler) PushSyncChanges(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req SyncPushRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.service.PushSyncChanges(ctx, userID, req.Changes, req.Strategy)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTooManySyncChanges):
+			return apperrors.NewBadRequestError(fmt.Sprintf("A sync can contain at most %d changes", service.MaxSyncPushChanges))
+		case errors.Is(err, service.ErrInvalidSyncStrategy):
+			return apperrors.NewBadRequestError("Invalid conflict strategy")
+		}
+		return apperrors.NewInternalError("Failed to apply sync changes")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
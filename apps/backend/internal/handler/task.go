@@ -13,14 +13,18 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
 	apperrors "github.com/secure-scorecard/backend/internal/errors"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/service"
 	"github.com/secure-scorecard/backend/internal/validator"
 )
 
@@ -74,19 +78,33 @@ type UpdateTaskRequest struct {
 	RecurrenceInterval *int       `json:"recurrence_interval"`
 	MaxOccurrences     *int       `json:"max_occurrences"`
 	RecurrenceEndDate  *time.Time `json:"recurrence_end_date"`
+
+	// 取得したタスクの version（If-Match ヘッダーを指定しない場合は必須）
+	Version *uint `json:"version"`
 }
 
 // =============================================================================
 // ハンドラメソッド
 // =============================================================================
 
+// taskSortDueDate は GetTasks の sort で期限日順のページングを指定する値です。
+const taskSortDueDate = "due_date"
+
 // GetTasks はユーザーの全タスクを取得します。
 //
+// リクエストヘッダー:
+//   - Accept: text/csv の場合はエクスポートと同じ列のCSV（ページングした場合は X-Next-Cursor に次のページのカーソル）
+//
 // クエリパラメータ:
 //   - status: フィルタするステータス（pending/completed/cancelled）
+//   - filter: 絞り込みの条件「フィールド:演算子:値」、複数指定可（status, priority, title, recurrence, due_date, completed_at, created_at）
+//   - limit: 1ページの件数（指定した場合は1ページ分を返す、最大100）
+//   - cursor: 前のページの next_cursor
+//   - sort: ページングの並び順（省略時は新しい順、due_date は期限日順）
 //
 // レスポンス:
-//   - 200: タスクの配列（期限日順）
+//   - 200: タスクの配列（期限日順）、limit・cursor・filter を指定した場合は1ページ分（items, next_cursor, has_more, limit、sort の順）
+//   - 400: 不正な limit・cursor・sort・filter
 //   - 401: 認証エラー
 //   - 500: 内部エラー
 func (h *Handler) GetTasks(c echo.Context) error {
@@ -100,8 +118,47 @@ func (h *Handler) GetTasks(c echo.Context) error {
 
 	// statusクエリパラメータでフィルタリング
 	status := c.QueryParam("status")
+	spec, filtered, err := filterSpec(c, taskFilterSchema)
+	if err != nil {
+		return err
+	}
+	csv := negotiateCSV(c)
+
+	// limit・cursor・filterを指定した場合は sort の順に1ページ分を返す
+	var page *pagination.Page[model.Task]
+	switch sort := c.QueryParam("sort"); sort {
+	case "":
+		params, paginated, err := paginationParams(c)
+		if err != nil {
+			return err
+		}
+		if paginated || filtered {
+			if page, err = h.service.GetUserTasksPage(ctx, userID, spec, params); err != nil {
+				return apperrors.NewInternalError("Failed to fetch tasks")
+			}
+		}
+	case taskSortDueDate:
+		params, paginated, err := keyPaginationParams(c)
+		if err != nil {
+			return err
+		}
+		if paginated || filtered {
+			if page, err = h.service.GetUserTasksPageByDueDate(ctx, userID, spec, params); err != nil {
+				return apperrors.NewInternalError("Failed to fetch tasks")
+			}
+		}
+	default:
+		return apperrors.NewBadRequestError("Invalid sort: " + sort)
+	}
+	if page != nil {
+		if csv {
+			result, err := h.service.TasksCSV(page.Items)
+			return respondListCSV(c, result, err, page.NextCursor)
+		}
+		return c.JSON(http.StatusOK, dto.Page(page, dto.NewTaskResponse))
+	}
+
 	var tasks []model.Task
-	var err error
 
 	if status != "" {
 		// ステータスでフィルタ
@@ -114,8 +171,12 @@ func (h *Handler) GetTasks(c echo.Context) error {
 	if err != nil {
 		return apperrors.NewInternalError("Failed to fetch tasks")
 	}
+	if csv {
+		result, err := h.service.TasksCSV(tasks)
+		return respondListCSV(c, result, err, "")
+	}
 
-	return c.JSON(http.StatusOK, tasks)
+	return c.JSON(http.StatusOK, dto.List(tasks, dto.NewTaskResponse))
 }
 
 // GetTask は特定のタスクを取得します。
@@ -125,6 +186,7 @@ func (h *Handler) GetTasks(c echo.Context) error {
 //
 // レスポンス:
 //   - 200: タスクオブジェクト
+//   - 304: 変更なし（If-None-Match / If-Modified-Since が一致、ボディなし）
 //   - 400: 無効なID形式
 //   - 404: タスクが見つからない
 func (h *Handler) GetTask(c echo.Context) error {
@@ -142,7 +204,8 @@ func (h *Handler) GetTask(c echo.Context) error {
 		return apperrors.NewNotFoundError("Task")
 	}
 
-	return c.JSON(http.StatusOK, task)
+	setLastModified(c, task.UpdatedAt)
+	return c.JSON(http.StatusOK, dto.NewTaskResponse(task))
 }
 
 // CreateTask は新しいタスクを作成します。
@@ -156,8 +219,9 @@ func (h *Handler) GetTask(c echo.Context) error {
 //
 // レスポンス:
 //   - 201: 作成されたタスク
-//   - 400: バリデーションエラー
+//   - 400: リクエストボディの形式が不正
 //   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
 //   - 500: 内部エラー
 func (h *Handler) CreateTask(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -206,7 +270,7 @@ func (h *Handler) CreateTask(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to create task")
 	}
 
-	return c.JSON(http.StatusCreated, task)
+	return c.JSON(http.StatusCreated, dto.NewTaskResponse(task))
 }
 
 // UpdateTask は既存のタスクを更新します。
@@ -214,12 +278,18 @@ func (h *Handler) CreateTask(c echo.Context) error {
 // パスパラメータ:
 //   - id: タスクID
 //
-// リクエストボディ: 更新するフィールド（任意）
+// リクエストヘッダー:
+//   - If-Match: 取得したタスクの version（"3" の形式、ボディの version を指定しない場合は必須）
+//
+// リクエストボディ: 更新するフィールド（任意）と取得したタスクの version
 //
 // レスポンス:
-//   - 200: 更新されたタスク
-//   - 400: バリデーションエラー
+//   - 200: 更新されたタスク（ETag は更新後の version）
+//   - 400: リクエストボディ・If-Match の形式が不正
 //   - 404: タスクが見つからない
+//   - 409: 取得した後に他のリクエストで更新されている（VERSION_CONFLICT、errors.current に現在のタスク）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 428: If-Match・version を指定していない（PRECONDITION_REQUIRED）
 //   - 500: 内部エラー
 func (h *Handler) UpdateTask(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -235,12 +305,20 @@ func (h *Handler) UpdateTask(c echo.Context) error {
 	if err := validator.BindAndValidate(c, &req); err != nil {
 		return err
 	}
+	version, err := requiredVersion(c, req.Version)
+	if err != nil {
+		return err
+	}
 
 	// 既存のタスクを取得
 	task, err := h.service.GetTaskByID(ctx, uint(id))
 	if err != nil {
 		return apperrors.NewNotFoundError("Task")
 	}
+	if task.Version != version {
+		// 取得した後に他のリクエストで更新されている（楽観的ロック）
+		return apperrors.NewVersionConflictError("Task", dto.NewTaskResponse(task))
+	}
 
 	// リクエストで指定されたフィールドのみ更新
 	if req.Title != "" {
@@ -283,10 +361,17 @@ func (h *Handler) UpdateTask(c echo.Context) error {
 
 	// DBを更新
 	if err := h.service.UpdateTask(ctx, task); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			// 確認した後、保存するまでの間に他のリクエストで更新された
+			if current, getErr := h.service.GetTaskByID(ctx, uint(id)); getErr == nil {
+				return apperrors.NewVersionConflictError("Task", dto.NewTaskResponse(current))
+			}
+		}
 		return apperrors.NewInternalError("Failed to update task")
 	}
 
-	return c.JSON(http.StatusOK, task)
+	c.Response().Header().Set(headerETag, versionETag(task.Version))
+	return c.JSON(http.StatusOK, dto.NewTaskResponse(task))
 }
 
 // DeleteTask はタスクを削除します（論理削除）。
@@ -345,7 +430,7 @@ func (h *Handler) CompleteTask(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch completed task")
 	}
 
-	return c.JSON(http.StatusOK, task)
+	return c.JSON(http.StatusOK, dto.NewTaskResponse(task))
 }
 
 // GetTodayTasks は今日が期限のタスクを取得します。
@@ -370,7 +455,7 @@ func (h *Handler) GetTodayTasks(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch today's tasks")
 	}
 
-	return c.JSON(http.StatusOK, tasks)
+	return c.JSON(http.StatusOK, dto.List(tasks, dto.NewTaskResponse))
 }
 
 // GetOverdueTasks は期限切れのタスクを取得します。
@@ -395,5 +480,5 @@ func (h *Handler) GetOverdueTasks(c echo.Context) error {
 		return apperrors.NewInternalError("Failed to fetch overdue tasks")
 	}
 
-	return c.JSON(http.StatusOK, tasks)
+	return c.JSON(http.StatusOK, dto.List(tasks, dto.NewTaskResponse))
 }
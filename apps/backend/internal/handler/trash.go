@@ -0,0 +1,189 @@
+// Package handler - Trash Handler
+//
+// 論理削除した記録のゴミ箱と復元のHTTPハンドラを提供します。
+// エンドポイント:
+//   - GET  /api/v1/trash                  - 保持期間内に削除された記録の一覧
+//   - POST /api/v1/tasks/:id/restore      - タスクの復元
+//   - POST /api/v1/crops/:id/restore      - 作物の復元（成長記録・収穫記録を含む）
+//   - POST /api/v1/plots/:id/restore      - 区画の復元（配置履歴を含む）
+//   - POST /api/v1/harvests/:id/restore   - 収穫記録の復元
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// GetTrash は保持期間内に削除された記録を削除日時の新しい順に返します。
+// 作物と一緒に削除した収穫記録は含みません（作物の復元で一緒に復元されます）。
+// X-Org-ID を指定した場合は組織の作物・区画の記録を返します。
+//
+// クエリパラメータ:
+//   - type: 記録の種類（tasks/crops/plots/harvests、省略した場合は全種類）
+//
+// レスポンス:
+//   - 200: 削除された記録の配列（type, id, name, deleted_at, purge_at）
+//   - 400: 不正な type
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	items, err := h.service.GetTrash(ctx, userID, c.QueryParam("type"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTrashType) {
+			return apperrors.NewBadRequestError("Invalid trash type")
+		}
+		return apperrors.NewInternalError("Failed to fetch trash")
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
+// RestoreTask は削除したタスクを復元します。
+//
+// パスパラメータ:
+//   - id: タスクID
+//
+// レスポンス:
+//   - 200: 復元したタスク
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 404: 削除されたタスクが見つからない（保持期間を過ぎた場合を含む）
+//   - 500: 内部エラー
+func (h *Handler) RestoreTask(c echo.Context) error {
+	userID, id, err := restoreParams(c, "Invalid task ID")
+	if err != nil {
+		return err
+	}
+
+	task, err := h.service.RestoreTask(c.Request().Context(), userID, id)
+	if err != nil {
+		return restoreError(err, "Task", "Failed to restore task")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewTaskResponse(task))
+}
+
+// RestoreCrop は削除した作物を、作物と一緒に削除した成長記録・収穫記録とともに復元します。
+//
+// パスパラメータ:
+//   - id: 作物ID
+//
+// レスポンス:
+//   - 200: 復元した作物
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 403: 組織の作物数が上限（ORGANIZATION_QUOTA_EXCEEDED）
+//   - 404: 削除された作物が見つからない（保持期間を過ぎた場合を含む）
+//   - 500: 内部エラー
+func (h *Handler) RestoreCrop(c echo.Context) error {
+	userID, id, err := restoreParams(c, "Invalid crop ID")
+	if err != nil {
+		return err
+	}
+
+	crop, err := h.service.RestoreCrop(c.Request().Context(), userID, id)
+	if err != nil {
+		return restoreError(err, "Crop", "Failed to restore crop")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewCropResponse(crop))
+}
+
+// RestorePlot は削除した区画を、区画と一緒に削除した配置履歴とともに復元します。
+//
+// パスパラメータ:
+//   - id: 区画ID
+//
+// レスポンス:
+//   - 200: 復元した区画
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 403: 組織の区画数が上限（ORGANIZATION_QUOTA_EXCEEDED）
+//   - 404: 削除された区画が見つからない（保持期間を過ぎた場合を含む）
+//   - 500: 内部エラー
+func (h *Handler) RestorePlot(c echo.Context) error {
+	userID, id, err := restoreParams(c, "Invalid plot ID")
+	if err != nil {
+		return err
+	}
+
+	plot, err := h.service.RestorePlot(c.Request().Context(), userID, id)
+	if err != nil {
+		return restoreError(err, "Plot", "Failed to restore plot")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewPlotResponse(plot))
+}
+
+// RestoreHarvest は削除した収穫記録を復元します。
+//
+// パスパラメータ:
+//   - id: 収穫記録ID
+//
+// レスポンス:
+//   - 200: 復元した収穫記録
+//   - 400: 無効なID形式
+//   - 401: 認証エラー
+//   - 404: 削除された収穫記録が見つからない（保持期間を過ぎた場合を含む）
+//   - 409: 作物が削除されている（RESTORE_PARENT_DELETED、先に作物を復元）
+//   - 500: 内部エラー
+func (h *Handler) RestoreHarvest(c echo.Context) error {
+	userID, id, err := restoreParams(c, "Invalid harvest ID")
+	if err != nil {
+		return err
+	}
+
+	harvest, err := h.service.RestoreHarvest(c.Request().Context(), userID, id)
+	if err != nil {
+		return restoreError(err, "Harvest", "Failed to restore harvest")
+	}
+
+	return c.JSON(http.StatusOK, dto.NewHarvestResponse(harvest))
+}
+
+// =============================================================================
+// ヘルパー関数
+// =============================================================================
+
+// restoreParams は復元のリクエストの認証済みユーザーIDとパスパラメータのIDを返します。
+func restoreParams(c echo.Context, invalidIDMessage string) (uint, uint, error) {
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return 0, 0, apperrors.NewAuthenticationError("Not authenticated")
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, apperrors.NewBadRequestError(invalidIDMessage)
+	}
+	return userID, uint(id), nil
+}
+
+// restoreError は復元のサービスのエラーをAPIのエラーに変換します。
+func restoreError(err error, resource, internalMessage string) error {
+	switch {
+	case errors.Is(err, service.ErrTrashItemNotFound):
+		return apperrors.NewNotFoundError(resource)
+	case errors.Is(err, service.ErrRestoreParentDeleted):
+		return apperrors.New(apperrors.ErrCodeRestoreParentDeleted, "Crop of this harvest is deleted, restore the crop first")
+	}
+	return organizationError(err, internalMessage)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/dto"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Trash Tests - ゴミ箱と復元のエンドポイントのテスト
+// =============================================================================
+// テスト対象:
+//   - GET /trash: 削除された記録の一覧、不正な type の 400
+//   - POST /{crops|harvests|tasks}/:id/restore: 復元、作物が削除されている収穫記録の 409、見つからない記録の 404
+
+// TestTrash_Endpoints はゴミ箱と復元のエンドポイントのテストです。
+// 期待動作:
+//   - 削除した作物がゴミ箱に表示され、作物と一緒に削除した収穫記録は表示されない
+//   - 作物が削除されている収穫記録の復元は 409 RESTORE_PARENT_DELETED
+//   - 作物の復元は 200 で、収穫記録も復元されてゴミ箱から消える
+//   - 削除されていないタスクの復元は 404 TASK_NOT_FOUND、不正な type は 400
+func TestTrash_Endpoints(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.HTTPErrorHandler = apperrors.ErrorHandler
+	e.Validator = validator.NewValidator()
+	jwtManager := auth.NewJWTManager("trash-test-secret-key-32-chars!!", 24)
+	NewHandler(service.NewService(repository.NewMockRepositories()), jwtManager, nil).RegisterRoutes(e)
+	token, err := jwtManager.GenerateToken(1, "", "trash@example.com")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var crop dto.CropResponse
+	created := request(http.MethodPost, "/api/v1/crops", `{"name": "トマト", "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`)
+	if err := json.Unmarshal(created.Body.Bytes(), &crop); err != nil || crop.ID == 0 {
+		t.Fatalf("CreateCrop failed: %d %s", created.Code, created.Body.String())
+	}
+	var harvest dto.HarvestResponse
+	harvested := request(http.MethodPost, fmt.Sprintf("/api/v1/crops/%d/harvests", crop.ID), `{"harvest_date": "2026-07-01T00:00:00Z", "quantity": 1, "quantity_unit": "kg"}`)
+	if err := json.Unmarshal(harvested.Body.Bytes(), &harvest); err != nil || harvest.ID == 0 {
+		t.Fatalf("CreateHarvest failed: %d %s", harvested.Code, harvested.Body.String())
+	}
+	if rec := request(http.MethodDelete, fmt.Sprintf("/api/v1/crops/%d", crop.ID), ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("DeleteCrop failed: %d", rec.Code)
+	}
+
+	// Act
+	trash := request(http.MethodGet, "/api/v1/trash", "")
+	parentDeleted := request(http.MethodPost, fmt.Sprintf("/api/v1/harvests/%d/restore", harvest.ID), "")
+	restored := request(http.MethodPost, fmt.Sprintf("/api/v1/crops/%d/restore", crop.ID), "")
+	emptied := request(http.MethodGet, "/api/v1/trash", "")
+	notFound := request(http.MethodPost, "/api/v1/tasks/9999/restore", "")
+	invalidType := request(http.MethodGet, "/api/v1/trash?type=gardens", "")
+
+	// Assert
+	var items []service.TrashItem
+	if err := json.Unmarshal(trash.Body.Bytes(), &items); err != nil || trash.Code != http.StatusOK {
+		t.Fatalf("GetTrash failed: %d %s", trash.Code, trash.Body.String())
+	}
+	if len(items) != 1 || items[0].Type != "crops" || items[0].ID != crop.ID || items[0].PurgeAt == nil {
+		t.Errorf("Expected only the deleted crop in trash, got %+v", items)
+	}
+	if parentDeleted.Code != http.StatusConflict || !strings.Contains(parentDeleted.Body.String(), apperrors.ErrCodeRestoreParentDeleted) {
+		t.Errorf("Expected 409 RESTORE_PARENT_DELETED, got %d %s", parentDeleted.Code, parentDeleted.Body.String())
+	}
+	if restored.Code != http.StatusOK {
+		t.Errorf("Expected crop to be restored, got %d %s", restored.Code, restored.Body.String())
+	}
+	if strings.TrimSpace(emptied.Body.String()) != "[]" {
+		t.Errorf("Expected empty trash after restore, got %s", emptied.Body.String())
+	}
+	if rec := request(http.MethodGet, fmt.Sprintf("/api/v1/crops/%d/harvests", crop.ID), ""); !strings.Contains(rec.Body.String(), fmt.Sprintf(`"id":%d`, harvest.ID)) {
+		t.Errorf("Expected harvest to be restored with the crop, got %s", rec.Body.String())
+	}
+	if notFound.Code != http.StatusNotFound || !strings.Contains(notFound.Body.String(), apperrors.ErrCodeTaskNotFound) {
+		t.Errorf("Expected 404 TASK_NOT_FOUND, got %d %s", notFound.Code, notFound.Body.String())
+	}
+	if invalidType.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid type, got %d", invalidType.Code)
+	}
+}
@@ -0,0 +1,456 @@
+// Package handler - Upload Handler
+//
+// モバイルクライアントから保存先（S3・GCS・ローカル）への画像の直接アップロードのHTTPハンドラを提供します。
+// 画像はサーバーを経由せずに Presigned URL で保存先に PUT し、完了後に登録のコールバックを呼び出します。
+// 登録した画像は写真として加工します（サムネイル等の WebP のバリアント、位置情報の除去）。
+// エンドポイント:
+//   - POST /api/v1/uploads/presign   - 直接アップロード用のPresigned URLと登録のコールバックの生成
+//   - POST /api/v1/uploads/complete  - アップロードした画像の確認と写真の登録（登録のコールバック）
+//   - GET  /api/v1/photos/:id        - 写真の加工の状態とバリアントの画像の署名付きURL（有効期限付き）
+//   - PUT  /api/v1/photos/:id        - 写真の画像の差し替え（CDN のキャッシュも削除）
+//   - DELETE /api/v1/photos/:id      - 写真の削除（保存先の画像・バリアントも削除）
+//   - GET  /api/v1/users/me/storage  - 保存容量の使用量と上限、整理の候補
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/imageproc"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/storage"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// uploadCallbackPath は直接アップロードの登録のコールバックのパスです。
+const uploadCallbackPath = "/api/v1/uploads/complete"
+
+// =============================================================================
+// ハンドラメソッド
+// =============================================================================
+
+// PresignUploadRequest は直接アップロード用のPresigned URL生成リクエストの構造体です。
+type PresignUploadRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=image/jpeg image/png image/webp"`
+	SizeBytes   int64  `json:"size_bytes" validate:"required,min=1"` // アップロードする画像のサイズ（最大 5MB、署名に含める）
+}
+
+// UploadCallback は直接アップロードの完了後に呼び出す登録のコールバックです。
+type UploadCallback struct {
+	Method string            `json:"method"` // POST
+	URL    string            `json:"url"`    // /api/v1/uploads/complete
+	Body   map[string]string `json:"body"`   // リクエストボディ（object_key）
+}
+
+// PresignUploadResponse は直接アップロード用のPresigned URL生成レスポンスの構造体です。
+type PresignUploadResponse struct {
+	UploadURL  string            `json:"upload_url"`  // アップロード用Presigned URL
+	Method     string            `json:"method"`      // アップロードのHTTPメソッド（PUT）
+	Headers    map[string]string `json:"headers"`     // PUT に付けるヘッダー（Content-Type・サイズ、署名に含まれる）
+	ObjectKey  string            `json:"object_key"`  // オブジェクトキー
+	ContentURL string            `json:"content_url"` // 登録後の画像URL（CloudFront経由）
+	ExpiresAt  time.Time         `json:"expires_at"`  // URLの有効期限
+	Callback   UploadCallback    `json:"callback"`    // アップロードの完了後に呼び出す登録のコールバック
+}
+
+// PresignUpload は直接アップロード用のPresigned URLと登録のコールバックを生成します。
+// クライアントは upload_url に headers を付けて画像を PUT し、完了後に callback を呼び出します。
+// 申告したサイズ以外の PUT は保存先が拒否します。
+//
+// リクエストボディ:
+//   - content_type: 画像のMIMEタイプ（image/jpeg, image/png, image/webp）
+//   - size_bytes: 画像のサイズ（バイト）
+//
+// レスポンス:
+//   - 200: Presigned URLと登録のコールバック
+//   - 400: リクエストボディの形式が不正
+//   - 401: 認証エラー
+//   - 403: 保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED）
+//   - 413: サイズ超過（IMAGE_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) PresignUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req PresignUploadRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.SizeBytes > storage.MaxImageSize {
+		return imageTooLargeError()
+	}
+
+	if h.fileStorage == nil {
+		return apperrors.NewServiceUnavailableError("Image upload service is not available")
+	}
+	if err := h.service.CheckStorageQuota(ctx, userID, req.SizeBytes); err != nil {
+		return uploadError(err, "Failed to check storage quota")
+	}
+
+	result, err := h.fileStorage.PresignDirectUpload(ctx, userID, req.ContentType, req.SizeBytes)
+	if err != nil {
+		return uploadError(err, "Failed to generate upload URL")
+	}
+
+	return c.JSON(http.StatusOK, PresignUploadResponse{
+		UploadURL:  result.UploadURL,
+		Method:     http.MethodPut,
+		Headers:    result.Headers,
+		ObjectKey:  result.ObjectKey,
+		ContentURL: result.ContentURL,
+		ExpiresAt:  result.ExpiresAt,
+		Callback: UploadCallback{
+			Method: http.MethodPost,
+			URL:    uploadCallbackPath,
+			Body:   map[string]string{"object_key": result.ObjectKey},
+		},
+	})
+}
+
+// CompleteUploadRequest は直接アップロードの登録リクエストの構造体です。
+type CompleteUploadRequest struct {
+	ObjectKey string `json:"object_key" validate:"required,max=500"` // POST /uploads/presign の object_key
+	CropID    *uint  `json:"crop_id,omitempty"`                      // 写真の作物（オプション）
+	HarvestID *uint  `json:"harvest_id,omitempty"`                   // 写真の収穫記録（オプション）
+}
+
+// PhotoResponse は写真のレスポンスの構造体です。
+type PhotoResponse struct {
+	ID               uint              `json:"id"`
+	CropID           *uint             `json:"crop_id,omitempty"`            // 写真の作物
+	HarvestID        *uint             `json:"harvest_id,omitempty"`         // 写真の収穫記録
+	Status           string            `json:"status"`                       // pending（加工待ち）, completed, failed
+	Width            int               `json:"width"`                        // 向きを補正した元の画像の幅
+	Height           int               `json:"height"`                       // 向きを補正した元の画像の高さ
+	Variants         map[string]string `json:"variants,omitempty"`           // バリアント（thumbnail, medium, large）ごとの画像の署名付きURL（WebP）
+	VariantsExpireAt *time.Time        `json:"variants_expire_at,omitempty"` // variants の署名付きURLの有効期限
+	SizeBytes        int64             `json:"size_bytes"`                   // 保存容量（加工前は元の画像、加工後はバリアントの合計）
+	ErrorMessage     string            `json:"error_message,omitempty"`      // 加工に失敗した理由
+	CreatedAt        time.Time         `json:"created_at"`
+	ProcessedAt      *time.Time        `json:"processed_at,omitempty"`
+}
+
+// CompleteUpload は直接アップロードした画像を確認して写真を登録します（登録のコールバック）。
+// 保存先のオブジェクトのサイズと画像形式を確認し、条件を満たさないオブジェクトは削除します。
+// 写真の加工（WebP のバリアントの作成・位置情報の除去）はジョブキューで行い、
+// pending の場合は GET /photos/:id で completed になるまで確認します。
+// バリアントの画像URLは有効期限（variants_expire_at）付きの署名付きURLのため保存せず、表示のたびに GET /photos/:id で取得します。
+// 作物・収穫記録を指定した写真は、作物・収穫記録の削除後に GET /users/me/storage の整理の候補（orphaned_photos）になり、
+// 保持期間（RETENTION_ORPHANED_PHOTOS_DAYS）を過ぎると画像ごと削除します。
+//
+// リクエストボディ:
+//   - object_key: POST /uploads/presign の object_key
+//   - crop_id: 写真の作物（オプション）
+//   - harvest_id: 写真の収穫記録（オプション）
+//
+// レスポンス:
+//   - 201: 加工した写真（その場で加工した場合）
+//   - 202: 加工待ちの写真（Status: pending）
+//   - 400: リクエストボディの形式が不正、画像形式が不正（UNSUPPORTED_IMAGE_TYPE）
+//   - 401: 認証エラー
+//   - 403: 他のユーザーのオブジェクトキー、保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED、アップロードした画像は削除）
+//   - 404: 画像がアップロードされていない、作物（CROP_NOT_FOUND）・収穫記録が見つからない
+//   - 413: サイズ超過（IMAGE_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) CompleteUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req CompleteUploadRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if h.fileStorage == nil {
+		return apperrors.NewServiceUnavailableError("Image upload service is not available")
+	}
+
+	result, err := h.fileStorage.RegisterDirectUpload(ctx, userID, req.ObjectKey)
+	if err != nil {
+		return uploadError(err, "Failed to register upload")
+	}
+
+	photo, err := h.service.CreatePhoto(ctx, userID, service.PhotoUpload{
+		SourceKey: result.ObjectKey,
+		SizeBytes: result.Size,
+		CropID:    req.CropID,
+		HarvestID: req.HarvestID,
+	})
+	if err != nil {
+		return uploadError(err, "Failed to process photo")
+	}
+
+	status := http.StatusCreated
+	if photo.Status == service.PhotoStatusPending {
+		status = http.StatusAccepted
+	}
+	response, err := h.photoResponse(ctx, photo)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to sign photo URLs")
+	}
+	return c.JSON(status, response)
+}
+
+// GetPhoto は写真の加工の状態とバリアントの画像の署名付きURLを返します。
+// 写真の画像は公開URLで取得できないため、リクエストのたびに有効期限（15分）付きの署名付きURLを発行します。
+// 写真をアップロードしたユーザーのほか、写真の作物（収穫記録の写真は収穫記録の作物）の所有者・組織のメンバーが取得できます。
+//
+// パスパラメータ:
+//   - id: 写真のID
+//
+// レスポンス:
+//   - 200: 写真（completed の場合は variants に署名付きURL、variants_expire_at に有効期限）
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 写真が存在しない、または閲覧できない写真
+//   - 500: 署名付きURLの生成エラー
+func (h *Handler) GetPhoto(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid photo ID")
+	}
+
+	photo, err := h.service.GetPhoto(ctx, userID, uint(id))
+	if err != nil {
+		return apperrors.NewNotFoundError("Photo")
+	}
+
+	response, err := h.photoResponse(ctx, photo)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to sign photo URLs")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// ReplacePhotoRequest は写真の画像の差し替えリクエストの構造体です。
+type ReplacePhotoRequest struct {
+	ObjectKey string `json:"object_key" validate:"required,max=500"` // POST /uploads/presign の object_key
+}
+
+// ReplacePhoto は直接アップロードした画像を確認して写真の画像を差し替えます。
+// 差し替えた画像は写真の登録と同様に加工し、同じ画像URLのバリアントを上書きします。
+// 加工の後に以前のバリアントの CDN（CloudFront）のキャッシュの削除を依頼するため、反映まで数分かかる場合があります。
+//
+// パスパラメータ:
+//   - id: 写真のID
+//
+// リクエストボディ:
+//   - object_key: POST /uploads/presign の object_key
+//
+// レスポンス:
+//   - 200: 加工した写真（その場で加工した場合）
+//   - 202: 加工待ちの写真（Status: pending）
+//   - 400: 不正なID、リクエストボディの形式が不正、画像形式が不正（UNSUPPORTED_IMAGE_TYPE）
+//   - 401: 認証エラー
+//   - 403: 他のユーザーのオブジェクトキー、保存容量の上限を超える（STORAGE_QUOTA_EXCEEDED、アップロードした画像は削除）
+//   - 404: 写真が存在しない、または他のユーザーの写真、画像がアップロードされていない
+//   - 413: サイズ超過（IMAGE_TOO_LARGE）
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 503: 保存先の未設定エラー
+func (h *Handler) ReplacePhoto(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid photo ID")
+	}
+
+	var req ReplacePhotoRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if photo, err := h.service.GetPhoto(ctx, userID, uint(id)); err != nil || photo.UserID != userID {
+		return apperrors.NewNotFoundError("Photo")
+	}
+	if h.fileStorage == nil {
+		return apperrors.NewServiceUnavailableError("Image upload service is not available")
+	}
+
+	result, err := h.fileStorage.RegisterDirectUpload(ctx, userID, req.ObjectKey)
+	if err != nil {
+		return uploadError(err, "Failed to register upload")
+	}
+
+	photo, err := h.service.ReplacePhoto(ctx, userID, uint(id), service.PhotoUpload{
+		SourceKey: result.ObjectKey,
+		SizeBytes: result.Size,
+	})
+	if err != nil {
+		return uploadError(err, "Failed to process photo")
+	}
+
+	status := http.StatusOK
+	if photo.Status == service.PhotoStatusPending {
+		status = http.StatusAccepted
+	}
+	response, err := h.photoResponse(ctx, photo)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to sign photo URLs")
+	}
+	return c.JSON(status, response)
+}
+
+// DeletePhoto は写真を削除します（保存先の元の画像・バリアントも削除）。
+// GET /users/me/storage の整理の候補（orphaned_photos）の写真の削除に使用します。
+// バリアントの CDN（CloudFront）のキャッシュの削除も依頼します。
+//
+// パスパラメータ:
+//   - id: 写真のID
+//
+// レスポンス:
+//   - 204: 削除成功
+//   - 400: 不正なID
+//   - 401: 認証エラー
+//   - 404: 写真が存在しない、または他のユーザーの写真
+//   - 503: 保存先の未設定エラー
+func (h *Handler) DeletePhoto(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid photo ID")
+	}
+
+	if photo, err := h.service.GetPhoto(ctx, userID, uint(id)); err != nil || photo.UserID != userID {
+		return apperrors.NewNotFoundError("Photo")
+	}
+	if err := h.service.DeletePhoto(ctx, userID, uint(id)); err != nil {
+		return uploadError(err, "Failed to delete photo")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetStorageUsage はユーザーの保存容量の使用量と上限、整理の候補を返します。
+// 使用量は写真（加工後はバリアントの合計）、作物・区画の添付ファイルと保存先にファイルが残っているエクスポートの合計です。
+//
+// レスポンス:
+//   - 200: 使用量（used_bytes）、上限（quota_bytes、0 の場合は無制限）、種類ごとの件数と容量（by_type）、
+//     整理の候補（suggestions、作物が削除された写真は orphaned_photos）
+//   - 401: 認証エラー
+//   - 500: 内部エラー
+func (h *Handler) GetStorageUsage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 認証済みユーザーIDを取得
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	usage, err := h.service.GetStorageUsage(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get storage usage")
+	}
+
+	return c.JSON(http.StatusOK, usage)
+}
+
+// =============================================================================
+// ヘルパー関数
+// =============================================================================
+
+// photoResponse は写真のレスポンスを作成します（バリアントのキーを有効期限付きの署名付きURLに変換する）。
+func (h *Handler) photoResponse(ctx context.Context, photo *model.Photo) (PhotoResponse, error) {
+	response := PhotoResponse{
+		ID:           photo.ID,
+		CropID:       photo.CropID,
+		HarvestID:    photo.HarvestID,
+		Status:       photo.Status,
+		Width:        photo.Width,
+		Height:       photo.Height,
+		SizeBytes:    photo.SizeBytes,
+		ErrorMessage: photo.ErrorMessage,
+		CreatedAt:    photo.CreatedAt,
+		ProcessedAt:  photo.ProcessedAt,
+	}
+	if photo.Status != service.PhotoStatusCompleted || h.fileStorage == nil {
+		return response, nil
+	}
+	response.Variants = map[string]string{}
+	for name, key := range map[string]string{
+		imageproc.VariantThumbnail: photo.ThumbnailKey,
+		imageproc.VariantMedium:    photo.MediumKey,
+		imageproc.VariantLarge:     photo.LargeKey,
+	} {
+		if key == "" {
+			continue
+		}
+		signed, err := h.fileStorage.PhotoURL(ctx, key)
+		if err != nil {
+			return response, err
+		}
+		response.Variants[name] = signed.DownloadURL
+		response.VariantsExpireAt = &signed.ExpiresAt
+	}
+	return response, nil
+}
+
+// storageQuotaExceededError は保存容量の上限を超えるアップロードのエラーを返します。
+func storageQuotaExceededError() error {
+	return apperrors.New(apperrors.ErrCodeStorageQuotaExceeded, "Storage quota exceeded")
+}
+
+// uploadError は直接アップロード・写真の加工のエラーを API のエラーに変換します。
+func uploadError(err error, message string) error {
+	switch {
+	case errors.Is(err, storage.ErrStorageNotConfigured), errors.Is(err, service.ErrPhotoStorageNotConfigured):
+		return apperrors.NewServiceUnavailableError("Image upload service is not configured")
+	case errors.Is(err, storage.ErrUploadKeyForbidden):
+		return apperrors.NewAuthorizationError("Object key does not belong to the user")
+	case errors.Is(err, storage.ErrUploadNotFound):
+		return apperrors.NewNotFoundError("Upload")
+	case errors.Is(err, service.ErrPhotoCropNotFound):
+		return apperrors.NewNotFoundError("Crop")
+	case errors.Is(err, service.ErrPhotoHarvestNotFound):
+		return apperrors.NewNotFoundError("Harvest")
+	case errors.Is(err, service.ErrStorageQuotaExceeded):
+		return storageQuotaExceededError()
+	case errors.Is(err, storage.ErrFileTooLarge), errors.Is(err, imageproc.ErrTooManyPixels):
+		return imageTooLargeError()
+	case errors.Is(err, storage.ErrInvalidImageType), errors.Is(err, imageproc.ErrInvalidImage):
+		return apperrors.New(apperrors.ErrCodeUnsupportedImageType, "Invalid image type: only JPEG, PNG, and WEBP are allowed")
+	default:
+		return apperrors.NewInternalError(message)
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+)
+
+// =============================================================================
+// Upload Tests - 保存先への直接アップロードのテスト
+// =============================================================================
+// テスト対象:
+//   - PresignUpload: サイズの上限（413 IMAGE_TOO_LARGE）・バリデーション、保存先の未設定の 503
+//   - CompleteUpload: 他のユーザーのオブジェクトキーの 403、保存先の未設定の 503
+
+// TestPresignUpload は直接アップロード用のPresigned URL生成のテストです。
+// 期待動作:
+//   - size_bytes が 5MB を超える場合は 413 IMAGE_TOO_LARGE
+//   - size_bytes がない・許可されていない content_type は 422
+//   - 保存先が未設定の場合は 503
+func TestPresignUpload(t *testing.T) {
+	// Arrange
+	e, token := newBodyLimitTestEcho(t, BodyLimits{})
+	presign := func(body string) int {
+		return doBodyLimit(e, token, "/api/v1/uploads/presign", "application/json", strings.NewReader(body)).Code
+	}
+
+	// Act
+	tooLarge := doBodyLimit(e, token, "/api/v1/uploads/presign", "application/json", strings.NewReader(`{"content_type":"image/png","size_bytes":5242881}`))
+	missingSize := presign(`{"content_type":"image/png"}`)
+	invalidType := presign(`{"content_type":"image/gif","size_bytes":1024}`)
+	notConfigured := presign(`{"content_type":"image/png","size_bytes":1024}`)
+
+	// Assert
+	if code, limit := decodeSizeLimitProblem(t, tooLarge); tooLarge.Code != http.StatusRequestEntityTooLarge || code != apperrors.ErrCodeImageTooLarge || limit != 5*1024*1024 {
+		t.Errorf("Expected 413 IMAGE_TOO_LARGE, got %d %s (limit %d)", tooLarge.Code, code, limit)
+	}
+	if missingSize != http.StatusUnprocessableEntity || invalidType != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for missing size and invalid type, got %d / %d", missingSize, invalidType)
+	}
+	if notConfigured != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without storage, got %d", notConfigured)
+	}
+}
+
+// TestCompleteUpload は直接アップロードの登録のテストです。
+// 期待動作:
+//   - 他のユーザーの接頭辞・パスの移動（..）を含むオブジェクトキーは保存先を確認する前に 403
+//   - 自分のオブジェクトキーで保存先が未設定の場合は 503
+func TestCompleteUpload(t *testing.T) {
+	// Arrange
+	e, token := newBodyLimitTestEcho(t, BodyLimits{})
+	complete := func(objectKey string) int {
+		body := `{"object_key":"` + objectKey + `"}`
+		return doBodyLimit(e, token, "/api/v1/uploads/complete", "application/json", strings.NewReader(body)).Code
+	}
+
+	// Act
+	otherUser := complete("uploads/2/2026/05/photo.png")
+	traversal := complete("uploads/1/../2/2026/05/photo.png")
+	crossType := complete("crops/images/1/2026/05/photo.png")
+	own := complete("uploads/1/2026/05/photo.png")
+
+	// Assert
+	if otherUser != http.StatusForbidden || traversal != http.StatusForbidden || crossType != http.StatusForbidden {
+		t.Errorf("Expected 403 for keys outside uploads/1/, got %d / %d / %d", otherUser, traversal, crossType)
+	}
+	if own != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without storage, got %d", own)
+	}
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/auth"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/service"
+	"github.com/secure-scorecard/backend/internal/validator"
 )
 
 // GetCurrentUser returns the current authenticated user
@@ -14,3 +19,136 @@ func (h *Handler) GetCurrentUser(c echo.Context) error {
 		"message": "Authentication not implemented yet",
 	})
 }
+
+// =============================================================================
+// タイムゾーン設定
+// =============================================================================
+
+// TimezoneSettingsRequest はタイムゾーン設定の構造体です。
+type TimezoneSettingsRequest struct {
+	Timezone string `json:"timezone" validate:"required"` // IANAタイムゾーン名（例: Asia/Tokyo）
+}
+
+// GetTimezoneSettings はユーザーのタイムゾーン設定を取得します。
+//
+// レスポンス:
+//   - 200: {"timezone": "Asia/Tokyo"}
+//   - 401: 認証エラー
+//   - 404: ユーザーが見つからない
+func (h *Handler) GetTimezoneSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	tz, err := h.service.GetUserTimezone(ctx, userID)
+	if err != nil {
+		return apperrors.NewNotFoundError("User")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"timezone": tz})
+}
+
+// UpdateTimezoneSettings はユーザーのタイムゾーン設定を更新します。
+// 「今日のタスク」の境界と日次リマインダーの送信時刻はこのタイムゾーンで計算されます。
+//
+// リクエストボディ:
+//   - timezone: IANAタイムゾーン名（必須）
+//
+// レスポンス:
+//   - 200: {"timezone": "Asia/Tokyo"}
+//   - 400: リクエストボディの形式が不正、不正なタイムゾーン
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateTimezoneSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req TimezoneSettingsRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	tz, err := h.service.SetUserTimezone(ctx, userID, req.Timezone)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			return apperrors.NewBadRequestError("Invalid timezone")
+		}
+		return apperrors.NewInternalError("Failed to update timezone settings")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"timezone": tz})
+}
+
+// =============================================================================
+// 言語設定
+// =============================================================================
+
+// LocaleSettingsRequest は通知メールの言語設定の構造体です。
+type LocaleSettingsRequest struct {
+	Locale string `json:"locale" validate:"required"` // 言語タグ（例: ja, en, en-US）
+}
+
+// GetLocaleSettings はユーザーの通知（メール・プッシュ通知）の言語設定を取得します。
+//
+// レスポンス:
+//   - 200: {"locale": "ja"}
+//   - 401: 認証エラー
+//   - 404: ユーザーが見つからない
+func (h *Handler) GetLocaleSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	locale, err := h.service.GetUserLocale(ctx, userID)
+	if err != nil {
+		return apperrors.NewNotFoundError("User")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"locale": locale})
+}
+
+// UpdateLocaleSettings はユーザーの通知（メール・プッシュ通知）の言語設定を更新します。
+//
+// リクエストボディ:
+//   - locale: 言語タグ（必須、ja または en）
+//
+// レスポンス:
+//   - 200: {"locale": "en"}
+//   - 400: リクエストボディの形式が不正、未対応の言語
+//   - 401: 認証エラー
+//   - 422: バリデーションエラー（errors に項目ごとの内容）
+//   - 500: 内部エラー
+func (h *Handler) UpdateLocaleSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID := auth.GetUserIDFromContext(c)
+	if userID == 0 {
+		return apperrors.NewAuthenticationError("Not authenticated")
+	}
+
+	var req LocaleSettingsRequest
+	if err := validator.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	locale, err := h.service.SetUserLocale(ctx, userID, req.Locale)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedLocale) {
+			return apperrors.NewBadRequestError("Unsupported locale")
+		}
+		return apperrors.NewInternalError("Failed to update locale settings")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"locale": locale})
+}
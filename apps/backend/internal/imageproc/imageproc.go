@@ -0,0 +1,150 @@
+// Package imageproc - アップロードした画像の加工
+//
+// 直接アップロード（POST /api/v1/uploads/complete）の写真から標準サイズのバリアントを作成します。
+// 機能:
+//   - EXIF の向き（Orientation）の補正（JPEG・WebP）
+//   - 長辺を上限に縮小したバリアント（サムネイル・中・大）の作成（拡大はしない）
+//   - WebP への変換（EXIF・XMP 等のメタデータは書き出さないため、位置情報（GPS）を含まない）
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // image.Decode で JPEG をデコードする
+	_ "image/png"  // image.Decode で PNG をデコードする
+	"io"
+
+	"github.com/gen2brain/webp"
+	"golang.org/x/image/draw"
+)
+
+// =============================================================================
+// 定数定義
+// =============================================================================
+
+const (
+	// MaxPixels はデコードする画像の画素数の上限です（小さいファイルで巨大な画像を展開させない）
+	MaxPixels = 50 * 1000 * 1000
+
+	// WebPQuality はバリアントの WebP の品質です（0〜100）
+	WebPQuality = 80
+
+	// VariantContentType はバリアントの MIME タイプです
+	VariantContentType = "image/webp"
+)
+
+// バリアントの名前
+const (
+	VariantThumbnail = "thumbnail"
+	VariantMedium    = "medium"
+	VariantLarge     = "large"
+)
+
+// Variant は作成するバリアントの設定です。
+type Variant struct {
+	Name    string // バリアントの名前（thumbnail, medium, large）
+	MaxSize int    // 長辺の上限（ピクセル、元の画像が小さい場合はそのままのサイズ）
+}
+
+// StandardVariants は写真の標準のバリアントです（一覧のサムネイル・詳細画面・全画面表示）。
+var StandardVariants = []Variant{
+	{Name: VariantThumbnail, MaxSize: 256},
+	{Name: VariantMedium, MaxSize: 1024},
+	{Name: VariantLarge, MaxSize: 2048},
+}
+
+var (
+	// ErrInvalidImage は画像としてデコードできない場合のエラー（JPEG・PNG・WebP 以外を含む）
+	ErrInvalidImage = errors.New("invalid image")
+
+	// ErrTooManyPixels は画素数が MaxPixels を超える場合のエラー
+	ErrTooManyPixels = errors.New("image has too many pixels")
+)
+
+// =============================================================================
+// 画像の加工
+// =============================================================================
+
+// Output は作成したバリアントです（WebP）。
+type Output struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Result は画像の加工の結果です。
+type Result struct {
+	Width    int      // 向きを補正した元の画像の幅
+	Height   int      // 向きを補正した元の画像の高さ
+	Variants []Output // variants の順のバリアント
+}
+
+// Process は画像をデコードし、向きを補正して variants のサイズの WebP に変換します。
+//
+// 引数:
+//   - r: 元の画像（JPEG, PNG, WebP）
+//   - variants: 作成するバリアント
+//
+// 戻り値:
+//   - *Result: 元の画像のサイズとバリアント
+//   - error: デコードできない場合は ErrInvalidImage、画素数の上限を超える場合は ErrTooManyPixels
+func Process(r io.Reader, variants []Variant) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	var src image.Image
+	if format == "webp" {
+		// WebP の EXIF の向きはデコーダで補正する
+		src, err = webp.Decode(bytes.NewReader(data), webp.Options{AutoRotate: true})
+	} else {
+		src, _, err = image.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if format == "jpeg" {
+		src = applyOrientation(src, jpegOrientation(data))
+	}
+
+	bounds := src.Bounds()
+	result := &Result{Width: bounds.Dx(), Height: bounds.Dy()}
+	for _, variant := range variants {
+		resized := resize(src, variant.MaxSize)
+		var buf bytes.Buffer
+		if err := webp.Encode(&buf, resized, webp.Options{Quality: WebPQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode %s variant: %w", variant.Name, err)
+		}
+		size := resized.Bounds()
+		result.Variants = append(result.Variants, Output{Name: variant.Name, Width: size.Dx(), Height: size.Dy(), Data: buf.Bytes()})
+	}
+	return result, nil
+}
+
+// resize は長辺が maxSize 以下になるよう縮小した画像を返します（小さい画像はそのまま）。
+func resize(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	long := max(width, height)
+	if maxSize <= 0 || long <= maxSize {
+		return src
+	}
+
+	width = max(1, (width*maxSize+long/2)/long)
+	height = max(1, (height*maxSize+long/2)/long)
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
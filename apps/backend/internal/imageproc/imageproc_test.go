@@ -0,0 +1,149 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/gen2brain/webp"
+)
+
+// =============================================================================
+// Image Processing Tests - アップロードした画像の加工のテスト
+// =============================================================================
+// テスト対象:
+//   - Process: バリアントのサイズ・WebP への変換・EXIF（位置情報）の除去、デコードできない画像
+//   - jpegOrientation / applyOrientation: EXIF の向きの補正
+
+// exifJPEG は Orientation と GPS の IFD を含む APP1（Exif）を付けた width x height の JPEG を作成します。
+// 左上の画素は赤、それ以外は白です。
+func exifJPEG(t *testing.T, width, height, orientation int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg.Encode failed: %v", err)
+	}
+
+	// TIFF（ビッグエンディアン）: IFD0 に Orientation と GPSInfo（0x8825）
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 2)
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, byte(orientation), 0x00, 0x00)
+	tiff = append(tiff, 0x88, 0x25, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x26)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00)
+	tiff = append(tiff, []byte("GPS 35.6812N 139.7671E")...)
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	data = binary.BigEndian.AppendUint16(data, uint16(len(app1)+2))
+	data = append(data, app1...)
+	return append(data, encoded.Bytes()[2:]...)
+}
+
+// TestProcess は画像の加工のテストです。
+// 期待動作:
+//   - Orientation 6（時計回りに90度）の JPEG は縦横を入れ替えて補正する
+//   - 長辺が上限を超えるバリアントは縮小し、上限以下の場合は拡大しない
+//   - バリアントは WebP で、EXIF・位置情報を含まない
+func TestProcess(t *testing.T) {
+	// Arrange
+	data := exifJPEG(t, 600, 300, 6)
+	variants := []Variant{{Name: VariantThumbnail, MaxSize: 100}, {Name: VariantLarge, MaxSize: 2048}}
+
+	// Act
+	result, err := Process(bytes.NewReader(data), variants)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result.Width != 300 || result.Height != 600 {
+		t.Errorf("Expected the rotated size 300x600, got %dx%d", result.Width, result.Height)
+	}
+	if len(result.Variants) != 2 {
+		t.Fatalf("Expected 2 variants, got %d", len(result.Variants))
+	}
+	if thumb := result.Variants[0]; thumb.Width != 50 || thumb.Height != 100 {
+		t.Errorf("Expected a 50x100 thumbnail, got %dx%d", thumb.Width, thumb.Height)
+	}
+	if large := result.Variants[1]; large.Width != 300 || large.Height != 600 {
+		t.Errorf("Expected the large variant not to be upscaled, got %dx%d", large.Width, large.Height)
+	}
+	for _, variant := range result.Variants {
+		if bytes.Contains(variant.Data, []byte("EXIF")) || bytes.Contains(variant.Data, []byte("Exif")) || bytes.Contains(variant.Data, []byte("GPS")) {
+			t.Errorf("Expected %s variant without EXIF", variant.Name)
+		}
+		if _, err := webp.Decode(bytes.NewReader(variant.Data)); err != nil {
+			t.Errorf("Expected %s variant to be WebP: %v", variant.Name, err)
+		}
+	}
+
+	// 左上の赤は、時計回りに90度回転すると右上になる
+	large, _ := webp.Decode(bytes.NewReader(result.Variants[1].Data))
+	if r, g, _, _ := large.At(296, 3).RGBA(); r < 0xC000 || g > 0x6000 {
+		t.Errorf("Expected the red corner at the top right, got r=%x g=%x", r, g)
+	}
+}
+
+// TestProcess_InvalidImage はデコードできない画像のテストです。
+// 期待動作:
+//   - 画像でないデータは ErrInvalidImage
+func TestProcess_InvalidImage(t *testing.T) {
+	// Act
+	_, err := Process(bytes.NewReader([]byte("\xFF\xD8\xFFnot really a jpeg")), StandardVariants)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("Expected ErrInvalidImage, got %v", err)
+	}
+}
+
+// TestApplyOrientation は EXIF の向きの補正のテストです。
+// 期待動作:
+//   - Orientation ごとに左上の画素が表示する向きの位置に移動する
+//   - EXIF がない JPEG は 1（補正なし）
+func TestApplyOrientation(t *testing.T) {
+	// Arrange: 3x2 の画像の左上（0, 0）だけ不透明
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	tests := []struct {
+		orientation int
+		want        image.Point
+	}{
+		{1, image.Pt(0, 0)},
+		{2, image.Pt(2, 0)},
+		{3, image.Pt(2, 1)},
+		{4, image.Pt(0, 1)},
+		{5, image.Pt(0, 0)},
+		{6, image.Pt(1, 0)},
+		{7, image.Pt(1, 2)},
+		{8, image.Pt(0, 2)},
+	}
+
+	for _, tt := range tests {
+		// Act
+		out := applyOrientation(src, tt.orientation)
+
+		// Assert
+		if _, _, _, a := out.At(tt.want.X, tt.want.Y).RGBA(); a == 0 {
+			t.Errorf("orientation %d: expected the corner pixel at %v", tt.orientation, tt.want)
+		}
+	}
+	if got := jpegOrientation([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0x00, 0x02}); got != 1 {
+		t.Errorf("Expected orientation 1 without EXIF, got %d", got)
+	}
+}
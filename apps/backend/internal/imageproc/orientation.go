@@ -0,0 +1,121 @@
+package imageproc
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// =============================================================================
+// EXIF の向き（Orientation）
+// =============================================================================
+// スマートフォンのカメラは画素を回転せずに保存し、表示する向きを EXIF の Orientation（0x0112）に記録します。
+// 変換した WebP には EXIF を書き出さないため、加工の前に画素を表示する向きに回転します。
+
+// exifOrientationTag は EXIF の Orientation のタグです。
+const exifOrientationTag = 0x0112
+
+// jpegOrientation は JPEG の APP1（Exif）の Orientation（1〜8）を返します。
+// EXIF がない・読み取れない場合は 1（補正なし）を返します。
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 { // SOS・EOI 以降にメタデータはない
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		segment := pos + 4
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+		if marker == 0xE1 && end-segment > 6 && string(data[segment:segment+6]) == "Exif\x00\x00" {
+			return tiffOrientation(data[segment+6 : end])
+		}
+		pos = end
+	}
+	return 1
+}
+
+// tiffOrientation は EXIF の TIFF ヘッダーと IFD0 から Orientation を返します。
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+			return orientation
+		}
+		return 1
+	}
+	return 1
+}
+
+// applyOrientation は Orientation（2〜8）に従って画像を回転・反転します（1 の場合はそのまま）。
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	in := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(in, in.Bounds(), src, bounds.Min, draw.Src)
+
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 { // 5〜8 は縦横が入れ替わる
+		dstWidth, dstHeight = height, width
+	}
+	out := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			// 出力の (x, y) に対応する元の画像の座標
+			var sx, sy int
+			switch orientation {
+			case 2: // 左右反転
+				sx, sy = width-1-x, y
+			case 3: // 180度回転
+				sx, sy = width-1-x, height-1-y
+			case 4: // 上下反転
+				sx, sy = x, height-1-y
+			case 5: // 左上と右下を結ぶ対角線で反転
+				sx, sy = y, x
+			case 6: // 時計回りに90度回転
+				sx, sy = y, height-1-x
+			case 7: // 右上と左下を結ぶ対角線で反転
+				sx, sy = width-1-y, height-1-x
+			case 8: // 反時計回りに90度回転
+				sx, sy = width-1-y, x
+			}
+			copy(out.Pix[out.PixOffset(x, y):out.PixOffset(x, y)+4], in.Pix[in.PixOffset(sx, sy):in.PixOffset(sx, sy)+4])
+		}
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// HTTP - リクエストIDとアクセスログ
+// =============================================================================
+// リクエストヘッダーの X-Request-ID（ゲートウェイ・呼び出し元のサービスが付けたもの）を引き継ぎ、
+// ない場合・不正な場合は新しいIDを付けます。IDはレスポンスヘッダー X-Request-ID とエラーの request_id で返します。
+// アクセスログはルート（"GET /api/v1/crops/:id"）とパスを含む JSON の1行で、認証したリクエストは user_id を含みます。
+
+// maxRequestIDLength は引き継ぐリクエストIDの最大の長さです（長いIDはすべてのログの行に含まれるため）
+const maxRequestIDLength = 128
+
+// Middleware はリクエストIDを付けたロガーをリクエストのコンテキストに設定し、アクセスログを出力する Echo のミドルウェアを返します。
+// エラーのレスポンスはこのミドルウェアで書き込む（c.Error）ため、Recover より前に登録してください。
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if !ValidRequestID(requestID) {
+				requestID = uuid.New().String()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			ctx := WithRequestID(req.Context(), requestID)
+			if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+				ctx = With(ctx, "trace_id", span.TraceID().String())
+			}
+			c.SetRequest(req.WithContext(ctx))
+
+			start := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// 認証ミドルウェアで user_id を追加したロガー
+			FromContext(c.Request().Context()).Info("HTTP request",
+				"method", req.Method,
+				"route", c.Path(),
+				"path", req.URL.Path,
+				"status", c.Response().Status,
+				"latency_ms", float64(time.Since(start).Microseconds())/1000,
+				"bytes_out", c.Response().Size,
+				"remote_ip", c.RealIP(),
+			)
+			return nil
+		}
+	}
+}
+
+// ValidRequestID はリクエストヘッダー（gRPC はメタデータ）のリクエストIDを引き継げる場合に true を返します。
+// 英数字と - _ . : のみの maxRequestIDLength 文字以下のIDを引き継ぎます（ログへの改行・制御文字の混入を防ぐ）。
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+// Package logging - リクエストごとの構造化ログ
+//
+// リクエストID（X-Request-ID）・認証ユーザーIDを付けた slog のロガーをリクエストのコンテキストに保存し、
+// ハンドラ・サービス・リポジトリのログは FromContext(ctx) のロガーで出力します。
+// 同じリクエストのログ（アクセスログ・エラー・遅いクエリ）を request_id で検索できます。
+//
+//	logging.FromContext(ctx).Warn("Slow query", "duration_ms", 812.5)
+//	// {"level":"WARN","msg":"Slow query","request_id":"...","user_id":42,"duration_ms":812.5}
+//
+// 構成:
+//   - Middleware: X-Request-ID の付与・引き継ぎとアクセスログ（http.go）
+//   - WithUserID: 認証したユーザーIDをロガーに追加（auth の認証ミドルウェアで設定）
+//   - キューのジョブは登録したリクエストのリクエストID・ユーザーIDを引き継ぎます（queue.Mux.Process）
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey はコンテキストに保存するロガーのキーです。
+type loggerKey struct{}
+
+// requestKey はコンテキストに保存するリクエストID・ユーザーIDのキーです。
+type requestKey struct{}
+
+// requestInfo はコンテキストのリクエストID・ユーザーIDです（キューのジョブに引き継ぐ）。
+type requestInfo struct {
+	requestID string
+	userID    uint
+}
+
+// FromContext はコンテキストのロガーを返します。
+// ロガーを設定していないコンテキスト（起動時の処理など）は slog.Default() を返します。
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// WithLogger はロガーを保存したコンテキストを返します。
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// With はコンテキストのロガーに属性を追加したコンテキストを返します。
+//
+// 引数:
+//   - ctx: 元のコンテキスト
+//   - args: 追加する属性（slog.Logger.With と同じキーと値の組）
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// WithRequestID はリクエストIDを設定し、ロガーに request_id を追加したコンテキストを返します。
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	info := requestInfoFromContext(ctx)
+	info.requestID = requestID
+	return With(context.WithValue(ctx, requestKey{}, info), "request_id", requestID)
+}
+
+// WithUserID は認証ユーザーIDを設定し、ロガーに user_id を追加したコンテキストを返します。
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	info := requestInfoFromContext(ctx)
+	info.userID = userID
+	return With(context.WithValue(ctx, requestKey{}, info), "user_id", userID)
+}
+
+// RequestID はコンテキストのリクエストIDを返します（リクエスト以外は空）。
+func RequestID(ctx context.Context) string {
+	return requestInfoFromContext(ctx).requestID
+}
+
+// UserID はコンテキストの認証ユーザーIDを返します（未認証の場合は 0）。
+func UserID(ctx context.Context) uint {
+	return requestInfoFromContext(ctx).userID
+}
+
+// requestInfoFromContext はコンテキストのリクエストID・ユーザーIDを返します。
+func requestInfoFromContext(ctx context.Context) requestInfo {
+	info, _ := ctx.Value(requestKey{}).(requestInfo)
+	return info
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// =============================================================================
+// Request Logging Tests - リクエストごとの構造化ログのテスト
+// =============================================================================
+// テスト対象:
+//   - Middleware: X-Request-ID の引き継ぎ・付与、コンテキストのロガー、アクセスログ
+//   - WithRequestID / WithUserID: ロガーの request_id・user_id とコンテキストのID
+
+// captureLogs はテストの間、slog.Default() の出力（JSON）を記録します。
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// logLines は記録したログを1行ずつ JSON として読み込みます。
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected a JSON log line, got %q", line)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+// TestMiddleware はリクエストIDとアクセスログのミドルウェアのテストです。
+// 期待動作:
+//   - リクエストヘッダーの X-Request-ID を引き継ぎ、レスポンスヘッダーで返す
+//   - X-Request-ID がない・不正（制御文字・長すぎる）場合は新しいIDを付ける
+//   - ハンドラのコンテキストのロガー・アクセスログに request_id と認証後の user_id が付く
+//   - ハンドラのエラーはエラーのレスポンスのステータスでアクセスログに記録する
+func TestMiddleware(t *testing.T) {
+	// Arrange
+	buf := captureLogs(t)
+	e := echo.New()
+	e.Use(Middleware())
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(WithUserID(c.Request().Context(), 42)))
+			return next(c)
+		}
+	}
+	e.GET("/api/v1/crops/:id", func(c echo.Context) error {
+		FromContext(c.Request().Context()).Info("Loading crop")
+		return c.NoContent(http.StatusOK)
+	}, authenticate)
+	e.GET("/api/v1/fail", func(c echo.Context) error { return errors.New("database is down") })
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/crops/7", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-abc.123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	generatedIDs := make([]string, 0, 3)
+	for _, id := range []string{"", "bad\nid", strings.Repeat("a", maxRequestIDLength+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/fail", nil)
+		if id != "" {
+			req.Header.Set(echo.HeaderXRequestID, id)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		generatedIDs = append(generatedIDs, rec.Header().Get(echo.HeaderXRequestID))
+	}
+
+	// Assert
+	if rec.Header().Get(echo.HeaderXRequestID) != "req-abc.123" {
+		t.Errorf("Expected the request ID of the request header, got %q", rec.Header().Get(echo.HeaderXRequestID))
+	}
+	for i, id := range generatedIDs {
+		if len(id) != 36 {
+			t.Errorf("Expected a new request ID for request %d, got %q", i, id)
+		}
+	}
+	lines := logLines(t, buf)
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 log lines, got %d: %s", len(lines), buf.String())
+	}
+	handler, access := lines[0], lines[1]
+	if handler["msg"] != "Loading crop" || handler["request_id"] != "req-abc.123" || handler["user_id"] != float64(42) {
+		t.Errorf("Expected the handler log with request_id and user_id, got %v", handler)
+	}
+	if access["msg"] != "HTTP request" || access["request_id"] != "req-abc.123" || access["user_id"] != float64(42) {
+		t.Errorf("Expected the access log with request_id and user_id, got %v", access)
+	}
+	if access["route"] != "/api/v1/crops/:id" || access["path"] != "/api/v1/crops/7" || access["status"] != float64(http.StatusOK) {
+		t.Errorf("Expected the route, path and status in the access log, got %v", access)
+	}
+	failed := lines[2]
+	if failed["status"] != float64(http.StatusInternalServerError) || failed["request_id"] != generatedIDs[0] || failed["user_id"] != nil {
+		t.Errorf("Expected the error status with the generated request ID, got %v", failed)
+	}
+}
+
+// TestWithUserID はコンテキストのリクエストID・ユーザーIDのテストです。
+// 期待動作:
+//   - WithRequestID・WithUserID で設定したIDをコンテキストから取得できる（ロガーを設定していない場合は空）
+//   - ロガーを設定していないコンテキストは slog.Default() を返す
+func TestWithUserID(t *testing.T) {
+	// Arrange
+	buf := captureLogs(t)
+	ctx := WithUserID(WithRequestID(context.Background(), "req-1"), 7)
+
+	// Act
+	FromContext(ctx).Warn("Slow query")
+	FromContext(context.Background()).Info("Startup")
+
+	// Assert
+	if RequestID(ctx) != "req-1" || UserID(ctx) != 7 {
+		t.Errorf("Expected the request ID and user ID on the context, got %q / %d", RequestID(ctx), UserID(ctx))
+	}
+	if RequestID(context.Background()) != "" || UserID(context.Background()) != 0 {
+		t.Error("Expected no request ID without a request")
+	}
+	lines := logLines(t, buf)
+	if lines[0]["request_id"] != "req-1" || lines[0]["user_id"] != float64(7) {
+		t.Errorf("Expected request_id and user_id on the context logger, got %v", lines[0])
+	}
+	if _, ok := lines[1]["request_id"]; ok {
+		t.Errorf("Expected the default logger without request_id, got %v", lines[1])
+	}
+}
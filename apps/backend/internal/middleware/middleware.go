@@ -4,26 +4,35 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/errorreport"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/tracing"
 )
 
 // SetupMiddleware configures all middleware for the application
 func SetupMiddleware(e *echo.Echo, cfg *config.Config) {
-	// Request ID
-	e.Use(middleware.RequestID())
+	// Distributed tracing (before the logger, which writes the error responses recorded on the span)
+	e.Use(tracing.Middleware())
 
-	// Logger
-	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Format: "${time_rfc3339} ${id} ${method} ${uri} ${status} ${latency_human}\n",
+	// Request ID (X-Request-ID) and the request-scoped logger with the access log
+	e.Use(logging.Middleware())
+
+	// Recover from panics (the stack trace of the panic is logged and sent with the error report)
+	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
+		DisableStackAll: true, // パニックした goroutine のスタックのみログに出力する
+		LogErrorFunc:    errorreport.Recovered,
 	}))
 
-	// Recover from panics
-	e.Use(middleware.Recover())
+	// Record the route of slow queries
+	e.Use(QueryRoute())
 
 	// CORS with whitelisted origins
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     cfg.CORS.AllowedOrigins,
 		AllowMethods:     []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.PATCH, echo.OPTIONS},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "If-None-Match", echo.HeaderIfModifiedSince, "X-Org-ID"},
+		ExposeHeaders:    []string{"ETag", echo.HeaderLastModified, "X-Next-Cursor", tracing.HeaderTraceID, echo.HeaderXRequestID}, // 条件付きリクエスト（304 Not Modified）、CSVの一覧の次のページ、トレースID・リクエストID
 		AllowCredentials: true, // Required for cookies
 	}))
 
@@ -37,3 +46,14 @@ func SetupMiddleware(e *echo.Echo, cfg *config.Config) {
 	}))
 }
 
+// QueryRoute sets the matched route on the request context for the slow query log
+// ルートはパスのテンプレート（"GET /api/v1/crops/:id"）のため、IDなどの値はログに含まれません。
+func QueryRoute() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(repository.ContextWithRoute(req.Context(), req.Method+" "+c.Path())))
+			return next(c)
+		}
+	}
+}
@@ -21,7 +21,28 @@ type NotificationSettings struct {
 	EmailEnabled             bool `json:"email_enabled"`              // メール通知有効
 	TaskReminders            bool `json:"task_reminders"`             // タスクリマインダー
 	HarvestReminders         bool `json:"harvest_reminders"`          // 収穫リマインダー
+	HarvestReadyAlerts       *bool `json:"harvest_ready_alerts,omitempty"` // 収穫可能になった作物の通知（nilの場合は有効）
 	GrowthRecordNotifications bool `json:"growth_record_notifications"` // 成長記録通知
+	DigestNotifications       bool `json:"digest_notifications"`        // 定期通知を1日1件のダイジェストにまとめる（falseの場合は個別に送信）
+	DailyReminderHour         *int `json:"daily_reminder_hour,omitempty"` // 日次リマインダーの送信時刻（ユーザーのタイムゾーンでの時、0-23。nilの場合は最初のスケジューラー実行時）
+
+	// Webhook通知（Slack/Discord）
+	SlackEnabled      bool   `json:"slack_enabled"`                 // Slack通知有効
+	SlackWebhookURL   string `json:"slack_webhook_url,omitempty"`   // Slack Incoming Webhook URL
+	DiscordEnabled    bool   `json:"discord_enabled"`               // Discord通知有効
+	DiscordWebhookURL string `json:"discord_webhook_url,omitempty"` // Discord Webhook URL
+
+	// 汎用Webhook通知（通知イベントのJSONを署名付きでPOST。署名用シークレットは User.WebhookSecret）
+	CustomWebhookEnabled bool   `json:"custom_webhook_enabled"`         // 汎用Webhook通知有効
+	CustomWebhookURL     string `json:"custom_webhook_url,omitempty"`   // 汎用Webhook URL（HTTPSのみ）
+
+	// おやすみモード（ユーザーのタイムゾーンでの "HH:MM"。開始 > 終了の場合は日をまたぐ）
+	QuietHoursEnabled bool   `json:"quiet_hours_enabled"`         // おやすみモード有効
+	QuietHoursStart   string `json:"quiet_hours_start,omitempty"` // 開始時刻（例: 21:00）
+	QuietHoursEnd     string `json:"quiet_hours_end,omitempty"`   // 終了時刻（例: 08:00）
+
+	// SMS通知（重要なアラートのみ。User.PhoneNumber の認証が必要）
+	SMSEnabled bool `json:"sms_enabled"` // 霜注意報・アカウントセキュリティのアラートをSMSでも受け取る（オプトイン）
 }
 
 // User represents a user in the system
@@ -29,7 +50,7 @@ type NotificationSettings struct {
 type User struct {
 	BaseModel
 	FirebaseUID          string                `gorm:"uniqueIndex;size:128" json:"firebase_uid,omitempty"`
-	Email                string                `gorm:"uniqueIndex;size:255;not null" json:"email"`
+	Email                string                `gorm:"size:255;not null" json:"email"` // 論理削除していないユーザーで一意（migrations 000005 の部分一意インデックス）
 	PasswordHash         string                `gorm:"size:255" json:"-"`
 	DisplayName          string                `gorm:"size:100" json:"display_name"`
 	PhotoURL             string                `gorm:"size:500" json:"photo_url,omitempty"`
@@ -37,6 +58,16 @@ type User struct {
 	FailedLoginCount     int                   `gorm:"default:0" json:"-"`
 	LockedUntil          *time.Time            `json:"-"`
 	NotificationSettings *NotificationSettings `gorm:"type:jsonb;serializer:json;default:'{\"push_enabled\":true,\"email_enabled\":true,\"task_reminders\":true,\"harvest_reminders\":true,\"growth_record_notifications\":false}'" json:"notification_settings,omitempty"`
+	BenchmarkOptIn       bool                  `gorm:"default:false" json:"benchmark_opt_in"` // 匿名ベンチマークへの参加（オプトイン）
+	IsAdmin              bool                  `gorm:"default:false" json:"is_admin"`          // 管理者（利用統計エンドポイントへのアクセス権）
+	Timezone             string                `gorm:"size:64;default:'Asia/Tokyo'" json:"timezone"` // IANAタイムゾーン名（「今日」の境界やリマインダー時刻の基準）
+	Locale               string                `gorm:"size:10;default:'ja'" json:"locale"`          // 通知（メール・プッシュ通知）の言語（ja, en）
+	PhoneNumber          string                `gorm:"size:20" json:"phone_number,omitempty"`         // SMS通知の送信先（E.164形式、認証済みの番号のみ）
+	PhoneVerifiedAt      *time.Time            `json:"phone_verified_at,omitempty"`                   // 電話番号の認証日時
+	WebhookSecret        string                `gorm:"size:64" json:"-"`                              // 汎用Webhookの署名用シークレット（HMAC-SHA256）
+
+	// リレーション
+	NotificationPreferences []NotificationPreference `gorm:"foreignKey:UserID" json:"-"` // イベントタイプ×チャネルの通知設定
 }
 
 // Garden represents a garden owned by a user
@@ -111,6 +142,9 @@ type Task struct {
 	OccurrenceCount    int        `gorm:"default:0" json:"occurrence_count"`              // current count
 	ParentTaskID       *uint      `gorm:"index" json:"parent_task_id,omitempty"`          // original task ID
 
+	// 楽観的ロックのバージョン（更新ごとに1増え、更新のリクエストの If-Match・version と一致しない場合は 409）
+	Version uint `gorm:"not null;default:1" json:"version"`
+
 	// リレーション
 	User       User   `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Plant      *Plant `gorm:"foreignKey:PlantID" json:"plant,omitempty"`
@@ -167,6 +201,7 @@ type Crop struct {
 	BaseModel
 	UserID              uint       `gorm:"index;not null" json:"user_id"`
 	PlotID              *uint      `gorm:"index" json:"plot_id,omitempty"` // 区画への配置（任意）
+	OrganizationID      *uint      `gorm:"index" json:"organization_id,omitempty"` // 組織の作物（NULL の場合は個人の作物）
 	Name                string     `gorm:"size:100;not null" json:"name"`
 	Variety             string     `gorm:"size:100" json:"variety,omitempty"` // 品種
 	PlantedDate         time.Time  `gorm:"not null" json:"planted_date"`
@@ -174,6 +209,15 @@ type Crop struct {
 	Status              string     `gorm:"size:20;default:'planted'" json:"status"` // planted, growing, ready_to_harvest, harvested, failed
 	Notes               string     `gorm:"size:1000" json:"notes,omitempty"`
 
+	// 収穫可能通知の送信日時（収穫可能でなくなった場合はリセットし、再び収穫可能になったら通知する）
+	HarvestReadyNotifiedAt *time.Time `json:"harvest_ready_notified_at,omitempty"`
+
+	// シーズンの締め（収穫済み・失敗の作物をシーズン終了後にアーカイブ）の日時
+	ArchivedAt *time.Time `gorm:"index" json:"archived_at,omitempty"`
+
+	// 楽観的ロックのバージョン（Task.Version と同じ）
+	Version uint `gorm:"not null;default:1" json:"version"`
+
 	// リレーション
 	User          User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	GrowthRecords []GrowthRecord `gorm:"foreignKey:CropID" json:"growth_records,omitempty"`
@@ -221,6 +265,25 @@ type Harvest struct {
 	Crop Crop `gorm:"foreignKey:CropID" json:"crop,omitempty"`
 }
 
+// SeasonSummary はシーズン（植え付け年）ごとの区画の生産性の記録を表すモデルです。
+// シーズンの締めで作物をアーカイブする際に作成し、区画の面積・名前はその時点の値を保存します。
+// PlotID が 0 の行は区画に配置していない作物の集計です。
+type SeasonSummary struct {
+	BaseModel
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_season_summaries_user_season_plot" json:"user_id"`
+	Season         int       `gorm:"not null;uniqueIndex:idx_season_summaries_user_season_plot" json:"season"` // 植え付け年
+	PlotID         uint      `gorm:"not null;uniqueIndex:idx_season_summaries_user_season_plot" json:"plot_id"`
+	PlotName       string    `gorm:"size:100" json:"plot_name,omitempty"`
+	AreaM2         float64   `json:"area_m2"`
+	CropsGrown     int       `gorm:"not null" json:"crops_grown"`
+	CropsHarvested int       `gorm:"not null" json:"crops_harvested"`
+	CropsFailed    int       `gorm:"not null" json:"crops_failed"`
+	HarvestCount   int       `gorm:"not null" json:"harvest_count"`
+	TotalKg        float64   `gorm:"not null" json:"total_kg"` // kg換算の総収穫量
+	KgPerM2        float64   `json:"kg_per_m2"`
+	ClosedAt       time.Time `gorm:"not null" json:"closed_at"` // シーズンを締めた日時
+}
+
 // TableName overrides the table name for Crop
 func (Crop) TableName() string {
 	return "crops"
@@ -236,6 +299,11 @@ func (Harvest) TableName() string {
 	return "harvests"
 }
 
+// TableName overrides the table name for SeasonSummary
+func (SeasonSummary) TableName() string {
+	return "season_summaries"
+}
+
 // =============================================================================
 // Plot Domain Models - 区画管理モデル
 // =============================================================================
@@ -263,6 +331,7 @@ func (Harvest) TableName() string {
 type Plot struct {
 	BaseModel
 	UserID    uint    `gorm:"index;not null" json:"user_id"`
+	OrganizationID *uint `gorm:"index" json:"organization_id,omitempty"` // 組織の区画（NULL の場合は個人の区画）
 	Name      string  `gorm:"size:100;not null" json:"name"`
 	Width     float64 `gorm:"not null" json:"width"`            // メートル単位
 	Height    float64 `gorm:"not null" json:"height"`           // メートル単位
@@ -273,6 +342,9 @@ type Plot struct {
 	PositionY *int    `json:"position_y,omitempty"` // グリッド内のY座標（任意）
 	Notes     string  `gorm:"size:1000" json:"notes,omitempty"`
 
+	// 楽観的ロックのバージョン（Task.Version と同じ）
+	Version uint `gorm:"not null;default:1" json:"version"`
+
 	// リレーション
 	User            User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	PlotAssignments []PlotAssignment  `gorm:"foreignKey:PlotID" json:"plot_assignments,omitempty"`
@@ -323,6 +395,13 @@ type DeviceToken struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// EndpointARN はSNSプラットフォームエンドポイントのARNのキャッシュ（SNS送信時に作成、トークン再登録でクリア）
+	EndpointARN string `gorm:"size:500" json:"-"`
+
+	// 無効化情報（SNS/FCMから無効なトークンと通知された場合に記録）
+	DeactivatedAt      *time.Time `json:"deactivated_at,omitempty"`
+	DeactivationReason string     `gorm:"size:50" json:"deactivation_reason,omitempty"` // sns_endpoint_disabled, sns_invalid_token, fcm_unregistered, fcm_invalid_token
+
 	// リレーション
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
@@ -336,22 +415,27 @@ func (DeviceToken) TableName() string {
 // 重複通知防止と配信状況の追跡に使用します。
 //
 // ステータス:
-//   - pending: 送信待ち
+//   - pending: 送信待ち（送信失敗後のリトライ待ちを含む）
 //   - sent: 送信済み
-//   - failed: 送信失敗
+//   - failed: 送信失敗（最大リトライ回数に到達、デッドレター）
 //   - delivered: 配信確認済み
 type NotificationLog struct {
 	ID               uint       `gorm:"primaryKey" json:"id"`
 	UserID           uint       `gorm:"index;not null" json:"user_id"`
-	NotificationType string     `gorm:"size:50;not null" json:"notification_type"` // task_due_reminder, task_overdue_alert, harvest_reminder
-	Channel          string     `gorm:"size:20;not null" json:"channel"`           // push, email
+	NotificationType string     `gorm:"size:50;not null" json:"notification_type"` // task_due_reminder, task_overdue_alert, harvest_reminder, daily_digest
+	Channel          string     `gorm:"size:100;not null" json:"channel"`          // 送信したチャネルのカンマ区切り（push, email, slack, discord, custom_webhook, sms）
+	ChannelStatus    map[string]string `gorm:"type:jsonb;serializer:json" json:"channel_status,omitempty"` // チャネルごとの送信結果（sent, failed, deferred）
+	DuplicateCount   int        `gorm:"default:0" json:"duplicate_count"`          // 重複防止キーにより送信しなかった同じ通知の件数
 	Title            string     `gorm:"size:200" json:"title"`
 	Body             string     `gorm:"size:1000" json:"body"`
-	Status           string     `gorm:"size:20;default:'pending'" json:"status"` // pending, sent, failed, delivered
+	Status           string     `gorm:"size:20;default:'pending'" json:"status"` // pending, deferred, sent, failed, delivered
 	ErrorMessage     string     `gorm:"size:500" json:"error_message,omitempty"`
 	RetryCount       int        `gorm:"default:0" json:"retry_count"`
+	NextRetryAt      *time.Time `gorm:"index" json:"next_retry_at,omitempty"` // 次回リトライ予定日時（nilの場合は即時）。deferredの場合はおやすみモードの終了日時
 	SentAt           *time.Time `json:"sent_at,omitempty"`
+	ReadAt           *time.Time `gorm:"index" json:"read_at,omitempty"` // アプリ内受信箱での既読日時（nilの場合は未読）
 	DeduplicationKey string     `gorm:"size:100;index" json:"deduplication_key,omitempty"` // 重複防止用キー
+	PushRateLimited  bool       `gorm:"default:false;index" json:"push_rate_limited"`      // 送信数の上限によりプッシュ通知を送らず、まとめ通知の送信待ちのもの
 	ExpiresAt        time.Time  `gorm:"index" json:"expires_at"`                           // TTL用（24時間）
 	CreatedAt        time.Time  `json:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at"`
@@ -364,3 +448,599 @@ type NotificationLog struct {
 func (NotificationLog) TableName() string {
 	return "notification_logs"
 }
+
+// NotificationPreference はイベントタイプ×チャネルごとの通知設定を表します。
+// (UserID, EventType, Channel) ごとに1行を持ち、行がない組み合わせは
+// NotificationSettings の値から決まる既定値を使用します。
+//
+// チャネル:
+//   - push: プッシュ通知
+//   - email: メール通知
+//   - webhook: Slack/Discord Webhook（送信先は NotificationSettings で設定）
+type NotificationPreference struct {
+	UserID    uint      `gorm:"primaryKey" json:"-"`
+	EventType string    `gorm:"primaryKey;size:50" json:"event_type"` // task_due_reminder, task_overdue_alert, harvest_reminder, daily_digest
+	Channel   string    `gorm:"primaryKey;size:20" json:"channel"`    // push, email, webhook
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// PhoneVerification はSMS通知用の電話番号の認証コードを表します。
+// ユーザーごとに認証中の番号を1件だけ保持し、認証に成功したら削除します。
+// 認証コードはハッシュ化して保存します。
+type PhoneVerification struct {
+	UserID      uint      `gorm:"primaryKey" json:"-"`
+	PhoneNumber string    `gorm:"size:20;not null" json:"phone_number"` // 認証中の電話番号（E.164形式）
+	CodeHash    string    `gorm:"size:64;not null" json:"-"`            // 認証コードのSHA-256ハッシュ
+	Attempts    int       `gorm:"not null;default:0" json:"-"`          // 認証コードの入力失敗回数
+	ExpiresAt   time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for PhoneVerification
+func (PhoneVerification) TableName() string {
+	return "phone_verifications"
+}
+
+// PhoneVerificationSend は認証コードのSMSの送信記録です。
+// 認証中の番号（PhoneVerification）は番号の変更・認証・削除で置き換わるため、送信数の上限は送信記録で判定します。
+type PhoneVerificationSend struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"index;not null" json:"user_id"`
+	PhoneNumber string    `gorm:"size:20;not null" json:"phone_number"` // 送信先の電話番号（E.164形式）
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName overrides the table name for PhoneVerificationSend
+func (PhoneVerificationSend) TableName() string {
+	return "phone_verification_sends"
+}
+
+// Announcement は管理者が全ユーザーまたは条件に合うユーザーへ配信するお知らせを表します。
+// 配信予定日時を過ぎたものをスケジューラーが通知パイプラインで配信し、配信結果を集計します。
+// 対象ユーザーが多い場合は複数回の処理に分けて配信し、LastUserID まで配信済みとして記録します。
+type Announcement struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Title       string    `gorm:"size:200;not null" json:"title"`
+	Body        string    `gorm:"size:1000;not null" json:"body"`
+	Category    string    `gorm:"size:20;default:'general'" json:"category"`       // maintenance, feature, general
+	ScheduledAt time.Time `gorm:"index;not null" json:"scheduled_at"`              // 配信予定日時
+	Status      string    `gorm:"size:20;default:'scheduled';index" json:"status"` // scheduled, sending, sent, cancelled
+	CreatedBy   uint      `gorm:"not null" json:"created_by"`
+
+	// 配信対象の条件（空・0 の場合は条件なし）
+	AudienceLocale     string `gorm:"size:10" json:"audience_locale,omitempty"`   // 言語（ja, en）
+	AudiencePlatform   string `gorm:"size:20" json:"audience_platform,omitempty"` // 有効なデバイストークンのプラットフォーム（ios, android, web）
+	AudienceActiveDays int    `json:"audience_active_days,omitempty"`             // 直近N日以内にアクティブなユーザー
+
+	// 配信結果
+	LastUserID     uint       `gorm:"default:0" json:"-"`               // 配信済みの最後のユーザーID（次回はこれより大きいIDから配信）
+	TargetCount    int        `gorm:"default:0" json:"target_count"`    // 配信対象のユーザー数
+	SentCount      int        `gorm:"default:0" json:"sent_count"`      // 送信に成功した件数
+	FailedCount    int        `gorm:"default:0" json:"failed_count"`    // 送信に失敗した件数（リトライ処理で再送信）
+	DeferredCount  int        `gorm:"default:0" json:"deferred_count"`  // おやすみモードのため保留した件数
+	DuplicateCount int        `gorm:"default:0" json:"duplicate_count"` // 送信済みのためスキップした件数
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`           // 全対象ユーザーへの配信が完了した日時
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for Announcement
+func (Announcement) TableName() string {
+	return "announcements"
+}
+
+// NotificationOutbox はスケジューラーが生成した通知イベントの送信待ちを表します（アウトボックス）。
+// 通知イベントはトランザクション内でまとめて保存し、ディスパッチャーが取り出して送信します。
+// 送信処理の途中でプロセスが終了しても、送信済みにしていないイベントは次回のディスパッチで送信します。
+//
+// ステータス:
+//   - pending: 送信待ち（AvailableAt 以降に送信）
+//   - dispatched: 通知イベントハンドラーで処理済み（送信失敗時の再送信は通知ログのリトライ処理で行う）
+//   - failed: 最大試行回数に到達（ユーザーを取得できないなど）
+type NotificationOutbox struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	UserID           uint       `gorm:"index;not null" json:"user_id"`
+	EventType        string     `gorm:"size:50;not null" json:"event_type"`
+	DeduplicationKey string     `gorm:"size:100;not null;uniqueIndex" json:"deduplication_key"` // 同じ通知イベントを二重に保存しないためのキー（通知ログと同じ形式）
+	Payload          string     `gorm:"type:jsonb;not null" json:"-"`                          // 通知イベント（service.NotificationEvent）のJSON
+	Status           string     `gorm:"size:20;default:'pending';index" json:"status"`          // pending, dispatched, failed
+	Attempts         int        `gorm:"default:0" json:"attempts"`
+	LastError        string     `gorm:"size:500" json:"last_error,omitempty"`
+	AvailableAt      time.Time  `gorm:"index" json:"available_at"` // 送信を試行できる日時
+	DispatchedAt     *time.Time `json:"dispatched_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for NotificationOutbox
+func (NotificationOutbox) TableName() string {
+	return "notification_outbox"
+}
+
+// NotificationDeadLetter は再試行しても送信できなかった通知イベントを表します。
+// 管理者がエラー内容を確認し、アウトボックスに戻して再送信（再ドライブ）できます。
+//
+// 発生元:
+//   - outbox: アウトボックスのイベントが最大試行回数に到達（ユーザーを取得できないなど）
+//   - notification_log: 送信に失敗した通知が最大リトライ回数に到達
+//
+// ステータス:
+//   - open: 未対応
+//   - redriven: アウトボックスに戻して再送信済み
+
+
+type NotificationDeadLetter struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"index;not null" json:"user_id"`
+	EventType    string     `gorm:"size:50;not null" json:"event_type"`
+	Source       string     `gorm:"size:20;not null" json:"source"` // outbox, notification_log
+	SourceID     uint       `json:"source_id"`                      // 発生元のアウトボックス・通知ログのID
+	Payload      string     `gorm:"type:jsonb;not null" json:"-"`   // 通知イベント（service.NotificationEvent）のJSON
+	ErrorMessage string     `gorm:"size:500" json:"error_message"`
+	Attempts     int        `gorm:"default:0" json:"attempts"`                  // 失敗するまでの試行回数
+	Status       string     `gorm:"size:20;default:'open';index" json:"status"` // open, redriven
+	RedrivenAt   *time.Time `json:"redriven_at,omitempty"`
+	RedrivenBy   uint       `json:"redriven_by,omitempty"` // 再ドライブした管理者のユーザーID
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for NotificationDeadLetter
+func (NotificationDeadLetter) TableName() string {
+	return "notification_dead_letters"
+}
+
+// =============================================================================
+// Scheduler Models - スケジューラーモデル
+// =============================================================================
+
+// SchedulerInvocation はスケジューラーエンドポイントの冪等キーごとの処理結果を表します。
+// EventBridge の再送で同じ冪等キーのリクエストを受けた場合は、処理せずに記録したレスポンスを返します。
+// StatusCode が 0 の間は処理中です。
+type SchedulerInvocation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Endpoint       string     `gorm:"size:100;not null;uniqueIndex:idx_scheduler_invocations_key" json:"endpoint"`
+	IdempotencyKey string     `gorm:"size:200;not null;uniqueIndex:idx_scheduler_invocations_key" json:"idempotency_key"`
+	StatusCode     int        `json:"status_code"`        // 0 = 処理中
+	ResponseBody   string     `gorm:"type:text" json:"-"` // 再送時に返すレスポンス（JSON）
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt      time.Time  `gorm:"index" json:"expires_at"` // 冪等キーの保持期限
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName overrides the table name for SchedulerInvocation
+func (SchedulerInvocation) TableName() string {
+	return "scheduler_invocations"
+}
+
+
+// JobRun は内蔵スケジューラーのジョブの実行履歴を表します。
+// 起動時に実行履歴から停止中に過ぎた実行日時を検出し、ジョブを1回だけ実行（キャッチアップ）します。
+// Status は running（実行中、または実行中にプロセスが終了した）, succeeded, failed のいずれかです。
+type JobRun struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	JobName      string     `gorm:"size:50;not null;index:idx_job_runs_job_scheduled" json:"job_name"`
+	ScheduledAt  time.Time  `gorm:"not null;index:idx_job_runs_job_scheduled" json:"scheduled_at"` // 実行した回の実行日時（キャッチアップの場合は最後に過ぎた実行日時）
+	CatchUp      bool       `gorm:"not null;default:false" json:"catch_up"`                        // 停止中に過ぎた実行日時のキャッチアップか
+	Status       string     `gorm:"size:20;not null;default:'running'" json:"status"`
+	ErrorMessage string     `gorm:"size:500" json:"error_message,omitempty"`
+	StartedAt    time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+// TableName overrides the table name for JobRun
+func (JobRun) TableName() string {
+	return "job_runs"
+}
+
+// SchedulerCheckpoint はユーザーをページごとに処理するスケジューラーの処理の進捗を表します。
+// 処理の途中でプロセスが終了した場合、同じスロットの次の実行は LastUserID の次のユーザーから再開します。
+type SchedulerCheckpoint struct {
+	Name        string     `gorm:"primaryKey;size:50" json:"name"` // 処理名（notifications）
+	SlotTime    time.Time  `gorm:"not null" json:"slot_time"`      // 処理中のスロット（毎時0分）
+	LastUserID  uint       `gorm:"not null" json:"last_user_id"`   // 処理を終えたページの最後のユーザーID
+	Pages       int        `gorm:"not null" json:"pages"`          // スロットで処理を終えたページ数
+	CompletedAt *time.Time `json:"completed_at,omitempty"`         // スロットの全ユーザーの処理を終えた日時
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for SchedulerCheckpoint
+func (SchedulerCheckpoint) TableName() string {
+	return "scheduler_checkpoints"
+}
+
+// ScheduleConfig は定期タスクのスケジュールの管理者による設定を表します。
+// 内蔵スケジューラーは実行のたびに読み込み、環境変数・デフォルトのスケジュールより優先します。
+// 行がないジョブは環境変数・デフォルトのスケジュールで実行します。
+type ScheduleConfig struct {
+	BaseModel
+	Name          string `gorm:"size:50;uniqueIndex;not null" json:"name"` // ジョブ名（notifications, token_blacklist_cleanup 等）
+	Schedule      string `gorm:"size:100" json:"schedule"`                 // cron式（空の場合は環境変数・デフォルトのスケジュール）
+	Enabled       bool   `gorm:"not null" json:"enabled"`                  // false の場合はジョブを一時停止
+	LookaheadDays *int   `json:"lookahead_days,omitempty"`                 // 先読み日数（notifications: 収穫リマインダーを送る日数）
+	UpdatedBy     uint   `json:"updated_by"`                               // 最後に変更した管理者のユーザーID
+}
+
+// TableName overrides the table name for ScheduleConfig
+func (ScheduleConfig) TableName() string {
+	return "schedule_configs"
+}
+
+// =============================================================================
+// Analytics Metadata Models - 分析メタデータモデル
+// =============================================================================
+
+// MaterializedViewRefresh はマテリアライズドビューのリフレッシュ履歴を表します。
+// ビューごとに最新のリフレッシュ結果を1行で保持します。
+type MaterializedViewRefresh struct {
+	ViewName        string     `gorm:"primaryKey;size:100" json:"view_name"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"` // 最後に成功したリフレッシュ日時
+	LastAttemptAt   time.Time  `json:"last_attempt_at"`             // 最後にリフレッシュを試行した日時
+	DurationMs      int64      `json:"duration_ms"`                 // 最後の試行の所要時間
+	LastError       string     `gorm:"size:500" json:"last_error,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name for MaterializedViewRefresh
+func (MaterializedViewRefresh) TableName() string {
+	return "materialized_view_refreshes"
+}
+
+// AnalyticsDataVersion は分析データのスコープ（ユーザー・組織）ごとのデータのバージョンを表します。
+// 作物・収穫記録・区画の変更で増やし、グラフ・集計のレスポンスの ETag に使用します。
+type AnalyticsDataVersion struct {
+	Scope     string    `gorm:"primaryKey;size:64" json:"scope"` // user:<ID> または org:<ID>
+	Version   int64     `gorm:"not null;default:0" json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for AnalyticsDataVersion
+func (AnalyticsDataVersion) TableName() string {
+	return "analytics_data_versions"
+}
+
+// UsageCounter は日別の利用統計カウンターを表します（管理者向け統計用）。
+// (Date, Metric) ごとに1行を持ち、ミドルウェアやサービスから加算されます。
+type UsageCounter struct {
+	Date      time.Time `gorm:"type:date;primaryKey" json:"date"`
+	Metric    string    `gorm:"primaryKey;size:50" json:"metric"` // records_created, notifications_sent 等
+	Value     int64     `gorm:"not null;default:0" json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name for UsageCounter
+func (UsageCounter) TableName() string {
+	return "usage_counters"
+}
+
+// DailyActiveUser は日別のアクティブユーザーを表します。
+// ユーザーごとに1日1行のみ記録し、ユニークユーザー数の集計に使用します。
+type DailyActiveUser struct {
+	Date   time.Time `gorm:"type:date;primaryKey" json:"date"`
+	UserID uint      `gorm:"primaryKey" json:"user_id"`
+}
+
+// TableName overrides the table name for DailyActiveUser
+func (DailyActiveUser) TableName() string {
+	return "daily_active_users"
+}
+
+// =============================================================================
+// Export Domain Models - エクスポートモデル
+// =============================================================================
+
+// ExportRecord は生成されたエクスポートファイルのメタデータを表します。
+// S3に保存されたファイルを再生成せずに再ダウンロードするために使用します。
+//
+// データ種類:
+//   - crops, harvests, tasks, growth_records, plot_assignments: 単一CSV
+//   - all: 全データのZIP
+//
+// 状態:
+//   - pending: ジョブキューで生成待ち（POST /exports）
+//   - completed: 生成済み
+//   - failed: 生成に失敗（ErrorMessage に理由）
+type ExportRecord struct {
+	BaseModel
+	UserID        uint   `gorm:"index;not null" json:"user_id"`
+	DataType      string `gorm:"size:20;not null" json:"data_type"` // crops, harvests, tasks, growth_records, plot_assignments, all
+	FileName      string `gorm:"size:200;not null" json:"file_name"`
+	ContentType   string `gorm:"size:100;not null" json:"content_type"`
+	RecordCount   int    `gorm:"default:0" json:"record_count"`
+	FileSizeBytes int64  `gorm:"default:0" json:"file_size_bytes"`
+	Anonymized    bool   `gorm:"default:false" json:"anonymized"` // 個人情報を除去したエクスポートか
+	S3Key         string `gorm:"size:500" json:"-"` // 空の場合はS3未保存（再ダウンロード不可）
+	Status        string `gorm:"size:20;not null;default:'completed'" json:"status"` // pending, completed, failed
+	ErrorMessage  string `gorm:"size:500" json:"error_message,omitempty"`            // 生成に失敗した理由
+	IncludePhotos bool   `gorm:"default:false" json:"include_photos"`                // 写真の画像を含むか（all のみ）
+	PartNumber    int    `gorm:"default:1" json:"part_number"`                       // 分割したZIPの番号（1から）
+	PartCount     int    `gorm:"default:1" json:"part_count"`                        // 分割したZIPの数
+	ParentID      *uint  `gorm:"index" json:"parent_id,omitempty"`                   // 分割したZIPの最初のエクスポート履歴（2番目以降のZIP）
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// IsDownloadable は再ダウンロード可能か（S3に保存済みか）を判定します。
+func (e *ExportRecord) IsDownloadable() bool {
+	return e.S3Key != ""
+}
+
+// TableName overrides the table name for ExportRecord
+func (ExportRecord) TableName() string {
+	return "export_records"
+}
+
+// =============================================================================
+// Sharing Domain Models - 共有モデル
+// =============================================================================
+
+// ShareToken は公開共有用のトークンを表します。
+// 認証なしで閲覧できる読み取り専用の公開エンドポイント（統計バッジ等）で使用します。
+//
+// トークンは SHA-256 のハッシュ（TokenHash）のみ保存し、トークン文字列は発行時のレスポンスでのみ返します。
+//
+// スコープ:
+//   - stats: 収穫統計の公開
+type ShareToken struct {
+	BaseModel
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	TokenHash string     `gorm:"column:token;size:64;uniqueIndex;not null" json:"-"` // SHA-256 hash（列名は平文を保存していた時の token）
+	Token     string     `gorm:"-" json:"-"`                                         // 発行時のトークン文字列（保存しない）
+	Scope     string     `gorm:"size:20;not null;default:'stats'" json:"scope"`      // stats
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`                               // nil = 無期限
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`                               // 失効日時
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// IsValid はトークンが有効（未失効かつ期限内）かどうかを判定します。
+func (t *ShareToken) IsValid(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	if t.ExpiresAt != nil && now.After(*t.ExpiresAt) {
+		return false
+	}
+	return true
+}
+
+// TableName overrides the table name for ShareToken
+func (ShareToken) TableName() string {
+	return "share_tokens"
+}
+
+// =============================================================================
+// Sync Domain Models - 同期モデル
+// =============================================================================
+
+// SyncTombstone は同期（GET /sync）でクライアントに削除を伝えるための削除の記録です。
+// 論理削除した行は同期のクエリから除外されるため、削除した記録のIDをここに残します。
+// 保持期間（RetentionTargetSyncTombstones）を過ぎた記録は削除し、それより古いカーソルの同期は全件の再取得になります。
+type SyncTombstone struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index:idx_sync_tombstones_user_deleted" json:"user_id"`
+	Entity    string    `gorm:"size:20;not null" json:"entity"` // tasks, crops, plots, harvests
+	EntityID  uint      `gorm:"not null" json:"entity_id"`
+	DeletedAt time.Time `gorm:"not null;index:idx_sync_tombstones_user_deleted" json:"deleted_at"`
+}
+
+// TableName overrides the table name for SyncTombstone
+func (SyncTombstone) TableName() string {
+	return "sync_tombstones"
+}
+
+// =============================================================================
+// Household Domain Models - 共有の庭（世帯）モデル
+// =============================================================================
+
+// GardenMember は庭を共有する世帯のメンバーを表します。
+// 庭の所有者（Garden.UserID）はメンバーを追加・削除でき、メンバーは WebSocket（GET /api/v1/ws）で
+// 庭のルームに参加して区画のレイアウトとタスクの変更をリアルタイムに受信します。
+type GardenMember struct {
+	BaseModel
+	GardenID uint   `gorm:"not null;uniqueIndex:idx_garden_members_garden_user" json:"garden_id"`
+	UserID   uint   `gorm:"not null;uniqueIndex:idx_garden_members_garden_user;index" json:"user_id"`
+	Role     string `gorm:"size:20;not null;default:'member'" json:"role"` // member
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName overrides the table name for GardenMember
+func (GardenMember) TableName() string {
+	return "garden_members"
+}
+
+// =============================================================================
+// Organization Domain Models - 組織（コミュニティガーデン・学校）モデル
+// =============================================================================
+
+// 組織の種類
+const (
+	OrganizationTypeCommunityGarden = "community_garden" // コミュニティガーデン
+	OrganizationTypeSchool          = "school"           // 学校
+)
+
+// 組織のメンバーの役割
+//   - owner: 組織の作成者（メンバーの追加・削除、組織から抜けることはできない）
+//   - admin: メンバーの追加・削除
+//   - member: 区画・作物の作成・更新
+//   - viewer: 閲覧のみ
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+	OrganizationRoleViewer = "viewer"
+)
+
+// Organization は区画・作物を共有する組織（コミュニティガーデン・学校）を表します。
+// X-Org-ID ヘッダーで組織を指定したリクエストでは、区画・作物は組織のものとして作成・取得されます。
+// 上限（MaxPlots・MaxCrops・MaxMembers）が 0 の場合は無制限です。
+type Organization struct {
+	BaseModel
+	Name       string `gorm:"size:100;not null" json:"name"`
+	Type       string `gorm:"size:20;not null" json:"type"` // community_garden, school
+	OwnerID    uint   `gorm:"not null;index" json:"owner_id"`
+	MaxPlots   int    `gorm:"not null;default:0" json:"max_plots"`   // 区画数の上限
+	MaxCrops   int    `gorm:"not null;default:0" json:"max_crops"`   // 作物数の上限
+	MaxMembers int    `gorm:"not null;default:0" json:"max_members"` // メンバー数の上限
+}
+
+// TableName overrides the table name for Organization
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// OrganizationMember は組織のメンバーと役割を表します。
+type OrganizationMember struct {
+	BaseModel
+	OrganizationID uint   `gorm:"not null;uniqueIndex:idx_organization_members_org_user" json:"organization_id"`
+	UserID         uint   `gorm:"not null;uniqueIndex:idx_organization_members_org_user;index" json:"user_id"`
+	Role           string `gorm:"size:20;not null;default:'member'" json:"role"` // owner, admin, member, viewer
+
+	// リレーション
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName overrides the table name for OrganizationMember
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// =============================================================================
+// Audit Domain Models - 変更履歴モデル
+// =============================================================================
+
+// 変更履歴の操作
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore" // ゴミ箱からの復元（deleted_at を NULL に戻す更新）
+)
+
+// AuditLog は作物・区画・タスク・収穫記録の変更の記録です（誰が・いつ・何を変更したか）。
+// リポジトリの GORM のプラグイン（repository.AuditPlugin）が変更と同じトランザクションで作成します。
+type AuditLog struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Entity   string `gorm:"size:20;not null;index:idx_audit_logs_entity" json:"entity"` // crops, plots, tasks, harvests
+	EntityID uint   `gorm:"not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Action   string `gorm:"size:20;not null" json:"action"`  // create, update, delete, restore
+	ActorID  *uint  `gorm:"index" json:"actor_id"`           // 変更したユーザー（定期実行などのリクエスト以外の変更は NULL）
+	OwnerID  uint   `gorm:"not null;index" json:"owner_id"`  // 記録の所有者（収穫記録は作物の所有者）
+	Route    string `gorm:"size:200" json:"route,omitempty"` // 変更したリクエストのルート（"PUT /api/v1/tasks/:id" など）
+
+	// 変更した列ごとの変更前・変更後の値（作成は全列の変更後、削除は全列の変更前のみ）
+	Changes map[string]AuditChange `gorm:"type:jsonb;serializer:json" json:"changes"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// AuditChange は1つの列の変更前・変更後の値です（作成の変更前・削除の変更後は null）。
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// TableName overrides the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// =============================================================================
+// Database Backup Domain Models - データベースのバックアップの履歴モデル
+// =============================================================================
+
+// DatabaseBackup はデータベースの論理バックアップの履歴です（S3 に保存したファイル）。
+// バックアップ・リストアの対象外のテーブルのため、古いバックアップをリストアしても履歴は残ります。
+//
+// 状態:
+//   - pending: ジョブキューで作成待ち（POST /admin/database/backups）
+//   - completed: 作成して S3 に保存済み
+//   - failed: 作成に失敗（ErrorMessage に理由）
+type DatabaseBackup struct {
+	BaseModel
+	Trigger          string     `gorm:"size:20;not null" json:"trigger"`                  // manual（管理者）, scheduled（定期実行）, cli（管理CLI）
+	RequestedBy      *uint      `gorm:"index" json:"requested_by,omitempty"`              // 作成を指示した管理者（定期実行・管理CLI は NULL）
+	Status           string     `gorm:"size:20;not null;default:'pending'" json:"status"` // pending, completed, failed
+	S3Key            string     `gorm:"size:500" json:"s3_key,omitempty"`                 // 保存したファイル（admin backup restore --id で使用）
+	FileSizeBytes    int64      `gorm:"default:0" json:"file_size_bytes"`
+	TableCount       int        `gorm:"default:0" json:"table_count"`
+	RowCount         int64      `gorm:"default:0" json:"row_count"`
+	MigrationVersion uint       `gorm:"default:0" json:"migration_version"` // リストア先に必要なマイグレーションのバージョン
+	ErrorMessage     string     `gorm:"size:500" json:"error_message,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
+
+// TableName overrides the table name for DatabaseBackup
+func (DatabaseBackup) TableName() string {
+	return "database_backups"
+}
+
+// =============================================================================
+// Photo Domain Models - 写真モデル
+// =============================================================================
+
+// Photo はクライアントが S3 に直接アップロードした写真と加工したバリアント（WebP）です。
+// アップロードした元の画像は位置情報（EXIF の GPS）を含む可能性があるため、加工の後に削除します。
+//
+// 状態:
+//   - pending: 加工待ち（ジョブキューで加工）
+//   - completed: バリアントを作成済み（ThumbnailKey・MediumKey・LargeKey）
+//   - failed: 加工に失敗（ErrorMessage に理由）
+//
+// SizeBytes はユーザーの保存容量（GET /users/me/storage、STORAGE_USER_QUOTA_BYTES）の集計に使用します。
+// 作物・収穫記録が削除された写真は、保持期間（RETENTION_ORPHANED_PHOTOS_DAYS）の後にデータの保持期間の処理で画像ごと削除します。
+type Photo struct {
+	BaseModel
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	CropID       *uint      `gorm:"index" json:"crop_id,omitempty"`                   // 写真の作物（オプション、作物の削除後は整理の候補）
+	HarvestID    *uint      `gorm:"index" json:"harvest_id,omitempty"`                // 写真の収穫記録（オプション、収穫記録の削除後は整理の候補）
+	SourceKey    string     `gorm:"size:500;not null" json:"-"`                       // アップロードした元の画像（加工後に削除）
+	Status       string     `gorm:"size:20;not null;default:'pending'" json:"status"` // pending, completed, failed
+	Width        int        `gorm:"default:0" json:"width"`                           // 向きを補正した元の画像の幅
+	Height       int        `gorm:"default:0" json:"height"`                          // 向きを補正した元の画像の高さ
+	ThumbnailKey string     `gorm:"size:500" json:"thumbnail_key,omitempty"`          // 長辺 256px
+	MediumKey    string     `gorm:"size:500" json:"medium_key,omitempty"`             // 長辺 1024px
+	LargeKey     string     `gorm:"size:500" json:"large_key,omitempty"`              // 長辺 2048px
+	SizeBytes    int64      `gorm:"default:0" json:"size_bytes"`                      // 保存容量（加工前は元の画像、加工後はバリアントの合計）
+	ErrorMessage string     `gorm:"size:500" json:"error_message,omitempty"`
+	ProcessedAt  *time.Time `json:"processed_at,omitempty"`
+}
+
+// TableName overrides the table name for Photo
+func (Photo) TableName() string {
+	return "photos"
+}
+
+// =============================================================================
+// Attachment Domain Models - 添付ファイルモデル
+// =============================================================================
+
+// Attachment は作物・区画に添付したファイル（土壌検査の報告書・種苗の請求書の PDF 等）です。
+// ファイルはクライアントが保存先に直接アップロードし、登録の時点で先頭のバイト列の形式とサイズを確認します。
+// SizeBytes は写真と同様にユーザーの保存容量（GET /users/me/storage、STORAGE_USER_QUOTA_BYTES）の集計に使用します。
+type Attachment struct {
+	BaseModel
+	UserID      uint   `gorm:"not null;index" json:"user_id"`                                    // アップロードしたユーザー
+	ParentType  string `gorm:"size:20;not null;index:idx_attachments_parent" json:"parent_type"` // crop, plot
+	ParentID    uint   `gorm:"not null;index:idx_attachments_parent" json:"parent_id"`
+	FileName    string `gorm:"size:255;not null" json:"file_name"`     // 表示・ダウンロードのファイル名
+	ContentType string `gorm:"size:100;not null" json:"content_type"`  // 内容から判定したMIMEタイプ（application/pdf 等）
+	ObjectKey   string `gorm:"size:500;not null;uniqueIndex" json:"-"` // 保存先のオブジェクトキー（1つのファイルは1回だけ登録できる）
+	SizeBytes   int64  `gorm:"not null;default:0" json:"size_bytes"`
+}
+
+// TableName overrides the table name for Attachment
+func (Attachment) TableName() string {
+	return "attachments"
+}
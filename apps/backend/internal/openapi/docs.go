@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Handler Docs - ハンドラの doc コメントの読み取り
+// =============================================================================
+
+// HandlerDoc はハンドラの doc コメントから読み取った内容です。
+type HandlerDoc struct {
+	Summary     string
+	Description string
+	PathParams  []ParamDoc
+	QueryParams []ParamDoc
+	RequestBody string // リクエストボディの項目（「- name: 説明」の行）
+	Responses   []ResponseDoc
+}
+
+// ParamDoc はパラメータの説明です。
+type ParamDoc struct {
+	Name        string
+	Description string
+}
+
+// ResponseDoc はステータスコードごとのレスポンスの説明です。
+type ResponseDoc struct {
+	Status      int
+	Description string
+}
+
+// doc コメントの見出し
+const (
+	sectionPathParams  = "パスパラメータ:"
+	sectionQueryParams = "クエリパラメータ:"
+	sectionRequestBody = "リクエストボディ:"
+	sectionResponses   = "レスポンス:"
+)
+
+// HandlerKey はルート名（Echo のハンドラの関数名）から doc コメントのキー（Handler.GetTasks）を返します。
+// ルート名は「github.com/.../handler.(*Handler).GetTasks-fm」の形式です。
+func HandlerKey(routeName string) string {
+	name := strings.TrimSuffix(routeName, "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:] // パッケージ名を除く
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
+
+// ParseHandlerDocs はディレクトリの Go のソース（テストを除く）からメソッドの doc コメントを読み取ります。
+//
+// 引数:
+//   - dir: ハンドラのパッケージのディレクトリ（internal/handler）
+//
+// 戻り値:
+//   - map[string]HandlerDoc: レシーバの型名.メソッド名（Handler.GetTasks）をキーとした doc コメント
+//   - error: ソースを読み取れない場合のエラー
+func ParseHandlerDocs(dir string) (map[string]HandlerDoc, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]HandlerDoc)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Doc == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recv := receiverName(fn.Recv.List[0].Type)
+			if recv == "" {
+				continue
+			}
+			docs[recv+"."+fn.Name.Name] = parseHandlerDoc(fn.Name.Name, fn.Doc.Text())
+		}
+	}
+	return docs, nil
+}
+
+// receiverName はレシーバの型名を返します（*Handler → Handler）。
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// parseHandlerDoc は doc コメントを見出しごとに読み取ります。
+// 見出しの内容は次の字下げされていない行まで続きます（字下げしたコードブロックを含む）。
+func parseHandlerDoc(name, text string) HandlerDoc {
+	var doc HandlerDoc
+	var description []string
+	var responseExample []string
+	section := ""
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		trimmed := strings.TrimSpace(line)
+
+		if i == 0 {
+			doc.Summary = strings.TrimSpace(strings.TrimPrefix(trimmed, name+" は"))
+			continue
+		}
+		switch trimmed {
+		case sectionPathParams, sectionQueryParams, sectionRequestBody, sectionResponses:
+			section = trimmed
+			continue
+		}
+		if trimmed != "" && !indented {
+			section = ""
+		}
+		if section == "" {
+			description = append(description, line)
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+
+		item, isItem := strings.CutPrefix(trimmed, "- ")
+		key, value, hasValue := strings.Cut(item, ":")
+		switch {
+		case section == sectionResponses && !isItem && strings.HasPrefix(line, "\t"):
+			responseExample = append(responseExample, strings.TrimPrefix(line, "\t"))
+		case section == sectionResponses && !isItem:
+			// 前のレスポンスの説明の続き
+			if len(doc.Responses) > 0 {
+				doc.Responses[len(doc.Responses)-1].Description += trimmed
+			}
+		case section == sectionResponses:
+			if status, err := strconv.Atoi(strings.TrimSpace(key)); err == nil && hasValue {
+				doc.Responses = append(doc.Responses, ResponseDoc{Status: status, Description: strings.TrimSpace(value)})
+			}
+		case section == sectionRequestBody && isItem:
+			if doc.RequestBody != "" {
+				doc.RequestBody += "\n"
+			}
+			doc.RequestBody += "- " + item
+		case isItem && hasValue:
+			param := ParamDoc{Name: strings.TrimSpace(key), Description: strings.TrimSpace(value)}
+			if section == sectionPathParams {
+				doc.PathParams = append(doc.PathParams, param)
+			} else {
+				doc.QueryParams = append(doc.QueryParams, param)
+			}
+		case !isItem:
+			// 前の項目の説明の続き
+			if section == sectionQueryParams && len(doc.QueryParams) > 0 {
+				doc.QueryParams[len(doc.QueryParams)-1].Description += " " + trimmed
+			}
+			if section == sectionPathParams && len(doc.PathParams) > 0 {
+				doc.PathParams[len(doc.PathParams)-1].Description += " " + trimmed
+			}
+		}
+	}
+
+	// レスポンスの例（JSON）のみの場合は 200 の説明にする
+	if len(doc.Responses) == 0 && len(responseExample) > 0 {
+		doc.Responses = append(doc.Responses, ResponseDoc{
+			Status:      200,
+			Description: "成功\n\n```json\n" + strings.Join(responseExample, "\n") + "\n```",
+		})
+	}
+	doc.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	return doc
+}
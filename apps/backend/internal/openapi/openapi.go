@@ -0,0 +1,433 @@
+// Package openapi - OpenAPI 3 仕様の生成
+//
+// Echo に登録したルートとハンドラの doc コメントから OpenAPI 3 の仕様（openapi.json）を生成します。
+// アノテーション用のコメントを別に書かず、各ハンドラの既存の doc コメントの形式をそのまま読み取ります。
+//
+//   - 1行目（「GetTasks はユーザーの全タスクを取得します。」）を summary、残りの本文を description にする
+//   - 「パスパラメータ:」「クエリパラメータ:」の「- name: 説明」をパラメータにする
+//   - 「リクエストボディ:」の項目をリクエストボディの説明にする
+//   - 「レスポンス:」の「- 200: 説明」をレスポンスにする
+//
+// doc コメントから読み取れないリクエストボディ・レスポンスの型は、ハンドラのパッケージで RouteTypes として登録し、
+// components.schemas に定義を追加します。operationId はハンドラ名（GetCrops）にし、
+// cmd/genclient はこの仕様から型付きの Go・TypeScript のクライアントを生成します。
+//
+// 生成した仕様は openapi.json としてこのパッケージに埋め込み、開発環境で /openapi.json と Swagger UI（/docs）から参照します。
+// ハンドラを変更した場合は `go run ./cmd/openapi` で再生成します（CI では -check で差分を検出します）。
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// =============================================================================
+// OpenAPI Document - OpenAPI 3 の仕様
+// =============================================================================
+
+// Version は生成する仕様の OpenAPI のバージョンです。
+const Version = "3.0.3"
+
+// Document は OpenAPI 3 の仕様のルートです。
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"` // パス → メソッド（小文字） → 操作
+	Components Components                      `json:"components"`
+}
+
+// Info は API の情報です。
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server は API のベースURLです。
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag は操作の分類です（/api/v1 直下のパスの区切りごと）。
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Operation は1つのメソッド・パスの操作です。
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security"`                   // 空の配列は認証なし
+	Exclude     bool                  `json:"x-client-exclude,omitempty"` // クライアントを生成しない（RouteTypes.Exclude）
+}
+
+// Parameter はパス・クエリのパラメータです。
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"` // path / query
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	Schema      Schema `json:"schema"`
+}
+
+// RequestBody はリクエストボディです。
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response はステータスコードごとのレスポンスです。
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"` // エラーのレスポンス（4xx・5xx）と、型を登録した成功時のレスポンス
+}
+
+// MediaType はリクエストボディの形式です。
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Schema は値の型です（型・項目が空の場合は任意の値）。
+type Schema struct {
+	Ref                  string            `json:"$ref,omitempty"` // 共通の定義（#/components/schemas/...）を参照する場合
+	Type                 string            `json:"type,omitempty"`
+	Format               string            `json:"format,omitempty"` // date-time / int32 / int64 / float / double
+	Description          string            `json:"description,omitempty"`
+	Nullable             bool              `json:"nullable,omitempty"`
+	Enum                 []string          `json:"enum,omitempty"`
+	Items                *Schema           `json:"items,omitempty"` // 配列の要素
+	Properties           map[string]Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema           `json:"additionalProperties,omitempty"` // マップの値
+	Required             []string          `json:"required,omitempty"`
+}
+
+// Components は仕様の共通の定義です。
+type Components struct {
+	Schemas         map[string]Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// problemSchema はエラーのレスポンス（RFC 7807 Problem Details）の定義です。
+// code の一覧は GET /api/v1/errors で取得できます。
+var problemSchema = Schema{
+	Type:        "object",
+	Description: "エラーのレスポンス（application/problem+json、RFC 7807）。code の一覧は GET /api/v1/errors を参照",
+	Properties: map[string]Schema{
+		"type":       {Type: "string", Description: "エラーコードの説明のURI（/api/v1/errors/{code}）"},
+		"title":      {Type: "string", Description: "エラーコードごとに固定の概要"},
+		"status":     {Type: "integer", Description: "HTTP ステータスコード"},
+		"detail":     {Type: "string", Description: "このリクエストでのエラーの内容"},
+		"instance":   {Type: "string", Description: "リクエストのパス"},
+		"code":       {Type: "string", Description: "安定したエラーコード（CROP_NOT_FOUND など）"},
+		"errors":     {Type: "array", Description: "バリデーションエラーの項目ごとの内容（field, rule, param, message）。message は Accept-Language（ja, en）の言語"},
+		"request_id": {Type: "string", Description: "リクエストID（X-Request-ID）"},
+	},
+	Required: []string{"type", "title", "status", "code"},
+}
+
+// problemContent はエラーのレスポンスの形式です。
+var problemContent = map[string]MediaType{
+	"application/problem+json": {Schema: Schema{Ref: "#/components/schemas/Problem"}},
+}
+
+// SecurityScheme は認証の方式です。
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// 認証の方式の名前
+const (
+	SecurityBearer    = "bearerAuth"     // JWT（Authorization: Bearer）
+	SecurityScheduler = "schedulerToken" // スケジューラー用トークン（X-Scheduler-Token）
+	SecurityMetrics   = "metricsToken"   // メトリクス用トークン（Authorization: Bearer）
+)
+
+// publicPaths は認証なしで呼び出せるパスです（前方一致）。
+var publicPaths = []string{
+	"/health",
+	"/public/",
+	"/api/v1/auth/register",
+	"/api/v1/auth/login",
+	"/api/v1/auth/firebase-login",
+	"/api/v1/auth/logout",
+	"/api/v1/errors",
+	"/api/v1/ws", // 接続後の最初のメッセージで JWT を送る（HTTP のリクエストでは認証しない）
+}
+
+// pathParamPattern は Echo のパスパラメータ（:id）です。
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// operationMethods は仕様に含めるメソッドです（ルートが見つからない場合の Group の内部ルートなどを除く）。
+var operationMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// Generate は Echo のルートとハンドラの doc コメントから仕様を生成します。
+//
+// 引数:
+//   - info: API の情報
+//   - routes: Echo に登録したルート（e.Routes()）
+//   - docs: ParseHandlerDocs で読み取ったハンドラの doc コメント（キーは HandlerKey）
+//   - types: ハンドラのリクエストボディ・レスポンスの型（キーは HandlerKey、登録していないハンドラは型なし）
+//
+// 戻り値:
+//   - *Document: 仕様（パス・メソッドの順序によらず同じ内容になる）
+func Generate(info Info, routes []*echo.Route, docs map[string]HandlerDoc, types map[string]RouteTypes) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: "/", Description: "このサーバー"}},
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			Schemas: map[string]Schema{"Problem": problemSchema},
+			SecuritySchemes: map[string]SecurityScheme{
+				SecurityBearer:    {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				SecurityScheduler: {Type: "apiKey", In: "header", Name: "X-Scheduler-Token", Description: "SCHEDULER_AUTH_TOKEN"},
+				SecurityMetrics:   {Type: "http", Scheme: "bearer", Description: "METRICS_AUTH_TOKEN"},
+			},
+		},
+	}
+
+	// 同名の定義の区別・operationId が登録の順序によらないように、パス・メソッドの順に処理する
+	routes = sortedRoutes(routes)
+	builder := newSchemaBuilder(doc.Components.Schemas)
+	handlerRoutes := make(map[string]int) // ハンドラ名ごと・ハンドラ名とメソッドごとのルート数
+	for _, route := range routes {
+		handlerRoutes[route.Name]++
+		handlerRoutes[route.Method+" "+route.Name]++
+	}
+
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		if !operationMethods[route.Method] {
+			continue
+		}
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		handlerDoc := docs[HandlerKey(route.Name)]
+		op := newOperation(route, handlerDoc, handlerRoutes[route.Name], handlerRoutes[route.Method+" "+route.Name])
+		applyRouteTypes(&op, builder, types[HandlerKey(route.Name)])
+		if tag := routeTag(route.Path); tag != "" {
+			op.Tags = []string{tag}
+			tags[tag] = true
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return doc
+}
+
+// Marshal は仕様を openapi.json の形式（字下げ付き、末尾に改行）にします。
+// キーは並べ替えられるため、同じ内容の仕様は常に同じバイト列になります（-check の差分検出用）。
+func Marshal(doc *Document) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// newOperation はルートとハンドラの doc コメントから操作を作成します。
+// handlerRoutes・methodRoutes はハンドラを登録したルート数・同じメソッドで登録したルート数です（operationId の区別）。
+func newOperation(route *echo.Route, handlerDoc HandlerDoc, handlerRoutes, methodRoutes int) Operation {
+	op := Operation{
+		OperationID: operationID(route, handlerRoutes, methodRoutes),
+		Summary:     handlerDoc.Summary,
+		Description: handlerDoc.Description,
+		Responses:   make(map[string]Response),
+		Security:    routeSecurity(route.Path),
+	}
+
+	documented := make(map[string]string, len(handlerDoc.PathParams))
+	for _, param := range handlerDoc.PathParams {
+		documented[param.Name] = param.Description
+	}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        match[1],
+			In:          "path",
+			Description: documented[match[1]],
+			Required:    true,
+			Schema:      Schema{Type: "string"},
+		})
+	}
+	for _, param := range handlerDoc.QueryParams {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Schema:      Schema{Type: "string"},
+		})
+	}
+
+	if handlerDoc.RequestBody != "" {
+		op.RequestBody = &RequestBody{
+			Description: handlerDoc.RequestBody,
+			Required:    true,
+			Content:     map[string]MediaType{echo.MIMEApplicationJSON: {Schema: Schema{Type: "object"}}},
+		}
+	}
+
+	for _, response := range handlerDoc.Responses {
+		resp := Response{Description: response.Description}
+		if response.Status >= 400 && usesProblemDetails(route.Path) {
+			resp.Content = problemContent
+		}
+		op.Responses[strconv.Itoa(response.Status)] = resp
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = Response{Description: "成功"}
+	}
+	return op
+}
+
+// operationID はハンドラ名から一意の operationId を作成します（クライアントのメソッド名になる）。
+// 同じハンドラを異なるメソッドで登録している場合はメソッドを前に付け（GetGraphQL・PostGraphQL）、
+// 同じメソッドで複数のパスに登録している場合・無名関数の場合は、メソッドとパスを含めて区別します。
+func operationID(route *echo.Route, handlerRoutes, methodRoutes int) string {
+	name := route.Name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	if !isIdentifier(name) {
+		name = ""
+	}
+	switch {
+	case name != "" && handlerRoutes == 1:
+		return name
+	case name != "" && methodRoutes == 1:
+		return route.Method[:1] + strings.ToLower(route.Method[1:]) + name
+	}
+	path := strings.NewReplacer("/", "_", ":", "", "{", "", "}", "", "*", "all", "-", "_", ".", "_").Replace(route.Path)
+	return strings.Trim(strings.ToLower(route.Method)+"_"+name+path, "_")
+}
+
+// applyRouteTypes は登録した型をリクエストボディ・成功時のレスポンスの定義にします。
+func applyRouteTypes(op *Operation, builder *schemaBuilder, types RouteTypes) {
+	op.Exclude = types.Exclude
+	if schema := builder.schemaOf(types.Request, true); schema != nil {
+		if op.RequestBody == nil {
+			op.RequestBody = &RequestBody{Required: true}
+		}
+		op.RequestBody.Content = map[string]MediaType{echo.MIMEApplicationJSON: {Schema: *schema}}
+	}
+	if schema := builder.schemaOf(types.Response, false); schema != nil {
+		status := successStatus(op.Responses)
+		resp := op.Responses[status]
+		resp.Content = map[string]MediaType{echo.MIMEApplicationJSON: {Schema: *schema}}
+		op.Responses[status] = resp
+	}
+}
+
+// successStatus は成功時（2xx）のレスポンスのステータスコードを返します（複数の場合は最小のもの）。
+func successStatus(responses map[string]Response) string {
+	status := ""
+	for code := range responses {
+		if strings.HasPrefix(code, "2") && (status == "" || code < status) {
+			status = code
+		}
+	}
+	if status == "" {
+		status = "200"
+		responses[status] = Response{Description: "成功"}
+	}
+	return status
+}
+
+// sortedRoutes はルートをパス・メソッドの順に並べ替えたコピーを返します。
+func sortedRoutes(routes []*echo.Route) []*echo.Route {
+	sorted := append([]*echo.Route(nil), routes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+	return sorted
+}
+
+// routeTag はパスから操作の分類を返します（/api/v1/tasks/:id → tasks）。
+func routeTag(path string) string {
+	path = strings.TrimPrefix(path, "/api/v1")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" || strings.HasPrefix(segments[0], ":") {
+		return ""
+	}
+	return segments[0]
+}
+
+// routeSecurity はパスから認証の方式を返します。
+func routeSecurity(path string) []map[string][]string {
+	switch {
+	case path == "/" || isPublicPath(path):
+		return []map[string][]string{}
+	case strings.HasPrefix(path, "/api/v1/scheduler"):
+		return []map[string][]string{{SecurityScheduler: {}}}
+	case path == "/metrics":
+		return []map[string][]string{{SecurityMetrics: {}}}
+	default:
+		return []map[string][]string{{SecurityBearer: {}}}
+	}
+}
+
+// usesProblemDetails はエラーを application/problem+json で返すパスかを判定します。
+// スケジューラー・メトリクスのエンドポイントは独自の形式（success・message など）で返します。
+func usesProblemDetails(path string) bool {
+	return !strings.HasPrefix(path, "/api/v1/scheduler") && path != "/metrics"
+}
+
+// isPublicPath は認証なしで呼び出せるパスかを判定します。
+func isPublicPath(path string) bool {
+	for _, prefix := range publicPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentifier はハンドラ名が Go の識別子か（無名関数ではないか）を判定します。
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
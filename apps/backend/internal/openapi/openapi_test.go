@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// =============================================================================
+// OpenAPI Tests - OpenAPI 3 仕様の生成のテスト
+// =============================================================================
+// テスト対象:
+//   - ParseHandlerDocs: doc コメントの見出しごとの読み取り
+//   - Generate: パス・パラメータ・認証・分類の生成
+//   - Generate（RouteTypes）: リクエストボディ・レスポンスの型の定義と operationId
+
+const testHandlerSource = `package handler
+
+// GetCrop は特定の作物を取得します。
+// 削除済みの作物は取得できません。
+//
+// パスパラメータ:
+//   - id: 作物ID
+//
+// クエリパラメータ:
+//   - include: 含める関連データ
+//     （growth_records / harvests）
+//
+// レスポンス:
+//   - 200: 作物オブジェクト
+//   - 404: 作物が見つからない
+func (h *Handler) GetCrop(c echo.Context) error { return nil }
+
+// RunJob はジョブを実行します。
+//
+// リクエストボディ:
+//   - name: ジョブ名（必須）
+//
+// レスポンス:
+//
+//	{"success": true}
+//
+// 失敗した場合は 500 を返します。
+func (h *SchedulerHandler) RunJob(c echo.Context) error { return nil }
+
+// helper はハンドラではない関数です。
+func helper() {}
+`
+
+// TestParseHandlerDocs は doc コメントの読み取りのテストです。
+// 期待動作:
+//   - 1行目を summary、見出し以外の本文を description にする
+//   - パス・クエリのパラメータとレスポンスを読み取り、続きの行を説明に含める
+//   - レスポンスの例のみの場合は 200 の説明にする
+func TestParseHandlerDocs(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "crop.go"), []byte(testHandlerSource), 0o644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	// Act
+	docs, err := ParseHandlerDocs(dir)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ParseHandlerDocs failed: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 handler docs, got %d", len(docs))
+	}
+	crop := docs["Handler.GetCrop"]
+	if crop.Summary != "特定の作物を取得します。" || crop.Description != "削除済みの作物は取得できません。" {
+		t.Errorf("Unexpected summary/description: %+v", crop)
+	}
+	if len(crop.PathParams) != 1 || crop.PathParams[0].Name != "id" {
+		t.Errorf("Unexpected path params: %+v", crop.PathParams)
+	}
+	if len(crop.QueryParams) != 1 || !strings.Contains(crop.QueryParams[0].Description, "harvests") {
+		t.Errorf("Expected continued query param description, got %+v", crop.QueryParams)
+	}
+	if len(crop.Responses) != 2 || crop.Responses[1].Status != 404 {
+		t.Errorf("Unexpected responses: %+v", crop.Responses)
+	}
+
+	job := docs["SchedulerHandler.RunJob"]
+	if job.RequestBody != "- name: ジョブ名（必須）" {
+		t.Errorf("Unexpected request body: %q", job.RequestBody)
+	}
+	if len(job.Responses) != 1 || !strings.Contains(job.Responses[0].Description, `{"success": true}`) {
+		t.Errorf("Expected response example as 200, got %+v", job.Responses)
+	}
+	if !strings.Contains(job.Description, "500") {
+		t.Errorf("Expected text after the example in description, got %q", job.Description)
+	}
+}
+
+// TestGenerate は仕様の生成のテストです。
+// 期待動作:
+//   - :id を {id} に変換し、パスパラメータを必須にする
+//   - パスごとの認証（JWT・スケジューラー用トークン・認証なし）を設定する
+//   - Group の内部ルート（ルートが見つからない場合）は含めない
+//   - エラーのレスポンスは Problem（application/problem+json）を参照する（スケジューラー用を除く）
+func TestGenerate(t *testing.T) {
+	// Arrange
+	routes := []*echo.Route{
+		{Method: "GET", Path: "/api/v1/crops/:id", Name: "github.com/secure-scorecard/backend/internal/handler.(*Handler).GetCrop-fm"},
+		{Method: "POST", Path: "/api/v1/scheduler/jobs", Name: "github.com/secure-scorecard/backend/internal/handler.(*SchedulerHandler).RunJob-fm"},
+		{Method: "POST", Path: "/api/v1/auth/login", Name: "github.com/secure-scorecard/backend/internal/handler.(*AuthHandler).Login-fm"},
+		{Method: echo.RouteNotFound, Path: "/api/v1/*", Name: "github.com/labstack/echo/v4.glob..func1"},
+	}
+	docs := map[string]HandlerDoc{
+		"Handler.GetCrop": {
+			Summary:    "特定の作物を取得します。",
+			PathParams: []ParamDoc{{Name: "id", Description: "作物ID"}},
+			Responses:  []ResponseDoc{{Status: 200, Description: "作物"}, {Status: 404, Description: "作物が見つからない"}},
+		},
+		"SchedulerHandler.RunJob": {Responses: []ResponseDoc{{Status: 401, Description: "認証エラー"}}},
+	}
+
+	// Act
+	doc := Generate(Info{Title: "test", Version: "v1"}, routes, docs, nil)
+
+	// Assert
+	if len(doc.Paths) != 3 {
+		t.Fatalf("Expected 3 paths, got %v", doc.Paths)
+	}
+	crop := doc.Paths["/api/v1/crops/{id}"]["get"]
+	if crop.Summary != "特定の作物を取得します。" || len(crop.Parameters) != 1 || !crop.Parameters[0].Required || crop.Parameters[0].Description != "作物ID" {
+		t.Errorf("Unexpected crop operation: %+v", crop)
+	}
+	if _, ok := crop.Security[0][SecurityBearer]; !ok || crop.Tags[0] != "crops" {
+		t.Errorf("Expected bearer auth and crops tag, got %+v", crop)
+	}
+	if _, ok := doc.Paths["/api/v1/scheduler/jobs"]["post"].Security[0][SecurityScheduler]; !ok {
+		t.Error("Expected scheduler token auth for scheduler routes")
+	}
+	if login := doc.Paths["/api/v1/auth/login"]["post"]; len(login.Security) != 0 || login.Responses["200"].Description == "" {
+		t.Errorf("Expected public login with default response, got %+v", login)
+	}
+	if _, ok := crop.Responses["404"].Content["application/problem+json"]; !ok || crop.Responses["200"].Content != nil {
+		t.Errorf("Expected problem+json only for the error response, got %+v", crop.Responses)
+	}
+	if doc.Paths["/api/v1/scheduler/jobs"]["post"].Responses["401"].Content != nil {
+		t.Error("Expected scheduler errors not to reference the Problem schema")
+	}
+	if _, ok := doc.Components.Schemas["Problem"]; !ok {
+		t.Error("Expected Problem schema in components")
+	}
+}
+
+// testCreateRequest・testItemResponse はリクエストボディ・レスポンスの型のテスト用の型です。
+type testCreateRequest struct {
+	Name     string  `json:"name" validate:"required,max=100"`
+	Priority string  `json:"priority" validate:"omitempty,oneof=low high"`
+	PlotID   *uint   `json:"plot_id"`
+	Ignored  string  `json:"-"`
+	Tags     []int32 `json:"tags"`
+}
+
+type testBase struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testItemResponse struct {
+	testBase
+	Name     string            `json:"name"`
+	Note     string            `json:"note,omitempty"`
+	Parent   *testItemResponse `json:"parent,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Archived *time.Time        `json:"archived_at,omitempty"`
+}
+
+// TestGenerate_RouteTypes はリクエストボディ・レスポンスの型の定義のテストです。
+// 期待動作:
+//   - 構造体は components.schemas に追加して参照し、埋め込みの構造体の項目は同じ階層に含める
+//   - リクエストは validate の required、レスポンスは omitempty のない項目を必須にし、oneof は enum にする
+//   - 型を登録したレスポンスは成功時（2xx）のステータスコードの content にする（配列は items で参照）
+//   - operationId は1つのルートのハンドラはハンドラ名、異なるメソッドで登録したハンドラはメソッド付き
+//   - Exclude の操作は x-client-exclude にする
+func TestGenerate_RouteTypes(t *testing.T) {
+	// Arrange
+	name := func(method string) string {
+		return "github.com/secure-scorecard/backend/internal/handler.(*Handler)." + method + "-fm"
+	}
+	routes := []*echo.Route{
+		{Method: "POST", Path: "/api/v1/items", Name: name("CreateItem")},
+		{Method: "GET", Path: "/api/v1/items", Name: name("GetItems")},
+		{Method: "GET", Path: "/api/v1/query", Name: name("Query")},
+		{Method: "POST", Path: "/api/v1/query", Name: name("Query")},
+		{Method: "GET", Path: "/api/v1/stream", Name: name("Stream")},
+	}
+	docs := map[string]HandlerDoc{
+		"Handler.CreateItem": {Responses: []ResponseDoc{{Status: 201, Description: "作成した項目"}, {Status: 400, Description: "不正"}}},
+	}
+	types := map[string]RouteTypes{
+		"Handler.CreateItem": {Request: testCreateRequest{}, Response: testItemResponse{}},
+		"Handler.GetItems":   {Response: []testItemResponse{}},
+		"Handler.Stream":     {Exclude: true},
+	}
+
+	// Act
+	doc := Generate(Info{Title: "test", Version: "v1"}, routes, docs, types)
+
+	// Assert
+	create := doc.Paths["/api/v1/items"]["post"]
+	if create.OperationID != "CreateItem" || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/testCreateRequest" {
+		t.Errorf("Unexpected create operation: %+v", create)
+	}
+	if create.Responses["201"].Content["application/json"].Schema.Ref != "#/components/schemas/testItemResponse" {
+		t.Errorf("Expected the 201 response to reference the response type, got %+v", create.Responses)
+	}
+	list := doc.Paths["/api/v1/items"]["get"].Responses["200"].Content["application/json"].Schema
+	if list.Type != "array" || list.Items.Ref != "#/components/schemas/testItemResponse" {
+		t.Errorf("Expected array of testItemResponse, got %+v", list)
+	}
+
+	request := doc.Components.Schemas["testCreateRequest"]
+	if strings.Join(request.Required, ",") != "name" || len(request.Properties) != 4 {
+		t.Errorf("Unexpected request schema: %+v", request)
+	}
+	if p := request.Properties["priority"]; strings.Join(p.Enum, ",") != "low,high" {
+		t.Errorf("Expected enum from oneof, got %+v", p)
+	}
+	if p := request.Properties["plot_id"]; !p.Nullable || p.Format != "int64" || request.Properties["tags"].Items.Format != "int32" {
+		t.Errorf("Unexpected nullable/array properties: %+v", request.Properties)
+	}
+	item := doc.Components.Schemas["testItemResponse"]
+	if strings.Join(item.Required, ",") != "created_at,id,labels,name" {
+		t.Errorf("Unexpected required properties: %v", item.Required)
+	}
+	if item.Properties["created_at"].Format != "date-time" || item.Properties["parent"].Ref != "#/components/schemas/testItemResponse" {
+		t.Errorf("Unexpected embedded/recursive properties: %+v", item.Properties)
+	}
+	if item.Properties["labels"].AdditionalProperties.Type != "string" {
+		t.Errorf("Expected map as additionalProperties, got %+v", item.Properties["labels"])
+	}
+
+	if get, post := doc.Paths["/api/v1/query"]["get"].OperationID, doc.Paths["/api/v1/query"]["post"].OperationID; get != "GetQuery" || post != "PostQuery" {
+		t.Errorf("Expected method-prefixed operationIds, got %s %s", get, post)
+	}
+	if !doc.Paths["/api/v1/stream"]["get"].Exclude {
+		t.Error("Expected excluded operation to be marked x-client-exclude")
+	}
+}
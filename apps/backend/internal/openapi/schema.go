@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Route Types - ルートのリクエスト・レスポンスの型
+// =============================================================================
+
+// RouteTypes はハンドラのリクエストボディ・成功時のレスポンスの Go の型です。
+// doc コメントからは型を読み取れないため、ハンドラのパッケージで HandlerKey ごとに登録し、
+// 仕様の components.schemas に型の定義を追加します（cmd/genclient で型付きのクライアントを生成するため）。
+type RouteTypes struct {
+	Request  any  // リクエストボディ（CreateCropRequest{} など、nil の場合は型なし）
+	Response any  // 成功時のレスポンス（dto.CropResponse{}、[]dto.CropResponse{} など、nil の場合は型なし）
+	Exclude  bool // WebSocket・SSE・multipart など JSON の1往復ではない操作（クライアントを生成しない）
+}
+
+// ExtensionClientExclude はクライアントを生成しない操作の拡張フィールドです。
+const ExtensionClientExclude = "x-client-exclude"
+
+// timeType は日時（RFC 3339 の文字列）の型です。
+var timeType = reflect.TypeOf(time.Time{})
+
+// rawMessageType は任意の JSON の型です。
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// schemaBuilder は Go の型から components.schemas の定義を作成します。
+type schemaBuilder struct {
+	schemas map[string]Schema
+	names   map[string]reflect.Type // 定義名ごとの型（異なるパッケージの同名の型の区別）
+}
+
+// newSchemaBuilder は components.schemas に定義を追加する schemaBuilder を作成します。
+func newSchemaBuilder(schemas map[string]Schema) *schemaBuilder {
+	return &schemaBuilder{schemas: schemas, names: make(map[string]reflect.Type)}
+}
+
+// schemaOf は値の型の定義を返します（構造体は components.schemas に追加して参照を返す）。
+// request が true の場合は validate タグの required、false の場合は omitempty のない項目を必須にします。
+func (b *schemaBuilder) schemaOf(value any, request bool) *Schema {
+	if value == nil {
+		return nil
+	}
+	schema := b.schema(reflect.TypeOf(value), request)
+	return &schema
+}
+
+// schema は型の定義を返します。
+func (b *schemaBuilder) schema(t reflect.Type, request bool) Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var schema Schema
+	switch {
+	case t == timeType:
+		schema = Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		schema = Schema{}
+	default:
+		schema = b.kindSchema(t, request)
+	}
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+// kindSchema は型の種類ごとの定義を返します。
+func (b *schemaBuilder) kindSchema(t reflect.Type, request bool) Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{Type: "boolean"}
+	case reflect.String:
+		return Schema{Type: "string"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		items := b.schema(t.Elem(), request)
+		return Schema{Type: "array", Items: &items}
+	case reflect.Map:
+		values := b.schema(t.Elem(), request)
+		return Schema{Type: "object", AdditionalProperties: &values}
+	case reflect.Struct:
+		return b.structRef(t, request)
+	default:
+		return Schema{} // interface{} など任意の値
+	}
+}
+
+// structRef は構造体の定義を components.schemas に追加し、参照を返します（無名の構造体は参照せずに定義を返す）。
+func (b *schemaBuilder) structRef(t reflect.Type, request bool) Schema {
+	if t.Name() == "" {
+		return b.structSchema(t, request)
+	}
+	name := b.schemaName(t)
+	ref := Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := b.schemas[name]; ok {
+		return ref
+	}
+
+	// 再帰的な型のため、項目を読み取る前に定義名を予約する
+	b.schemas[name] = Schema{Type: "object"}
+	b.schemas[name] = b.structSchema(t, request)
+	return ref
+}
+
+// structSchema は構造体の項目の定義を返します。
+func (b *schemaBuilder) structSchema(t reflect.Type, request bool) Schema {
+	schema := Schema{Type: "object", Properties: make(map[string]Schema)}
+	b.addFields(&schema, t, request)
+	sort.Strings(schema.Required)
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+// addFields は構造体の項目を定義に追加します（埋め込みの構造体の項目は同じ階層に追加する）。
+func (b *schemaBuilder) addFields(schema *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(schema, embedded, request)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := b.schema(field.Type, request)
+		if enum := oneOfValues(field.Tag.Get("validate")); len(enum) > 0 {
+			property.Enum = enum
+		}
+		schema.Properties[name] = property
+		if fieldRequired(field, options, request) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// fieldRequired は項目が必須かを判定します。
+// リクエストは validate タグの required、レスポンスは omitempty のない項目を必須にします。
+func fieldRequired(field reflect.StructField, jsonOptions string, request bool) bool {
+	if request {
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+		return false
+	}
+	for _, option := range strings.Split(jsonOptions, ",") {
+		if option == "omitempty" {
+			return false
+		}
+	}
+	return true
+}
+
+// oneOfValues は validate タグの oneof の値を返します（oneof=low medium high → low, medium, high）。
+func oneOfValues(validate string) []string {
+	for _, rule := range strings.Split(validate, ",") {
+		if values, ok := strings.CutPrefix(rule, "oneof="); ok {
+			return strings.Fields(values)
+		}
+	}
+	return nil
+}
+
+// schemaName は構造体の定義名を返します。
+// ジェネリクスの型は型引数の名前を続け（Page[dto.CropResponse] → PageCropResponse）、
+// 異なるパッケージの同名の型はパッケージ名を前に付けます。
+func (b *schemaBuilder) schemaName(t reflect.Type) string {
+	name := t.Name()
+	if base, args, ok := strings.Cut(name, "["); ok {
+		name = base
+		for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+			if i := strings.LastIndex(arg, "."); i >= 0 {
+				arg = arg[i+1:]
+			}
+			name += strings.TrimPrefix(arg, "*")
+		}
+	}
+
+	if existing, ok := b.names[name]; ok && existing != t {
+		pkg := t.PkgPath()
+		if i := strings.LastIndex(pkg, "/"); i >= 0 {
+			pkg = pkg[i+1:]
+		}
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	b.names[name] = t
+	return name
+}
@@ -0,0 +1,16 @@
+package openapi
+
+import _ "embed"
+
+// spec は cmd/openapi で生成した仕様です。
+//
+//go:embed openapi.json
+var spec []byte
+
+// Spec は埋め込んだ仕様（openapi.json）を返します。
+func Spec() []byte {
+	return spec
+}
+
+// SpecFile は生成した仕様のファイル名です（このパッケージのディレクトリに保存します）。
+const SpecFile = "openapi.json"
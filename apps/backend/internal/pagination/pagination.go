@@ -0,0 +1,206 @@
+// Package pagination - 一覧の取得のカーソルページング
+//
+// 一覧のエンドポイント（タスク・作物・収穫記録・区画・通知）で共通のページングを提供します。
+// ページの位置はオフセットではなく前のページの最後の行のIDをカーソルとして指定するため、
+// ページを読む間に行が追加・削除されても行の重複・抜けが起きません。
+//
+//   - 一覧はIDの降順（新しい順）で、カーソルより前（ID が小さい）の行を取得する
+//   - カーソルはクライアントにとって不透明な文字列（base64url）で、前のレスポンスの next_cursor をそのまま渡す
+//   - リポジトリは Limit+1 件を取得し、NewPage が次のページの有無を判定する
+//   - 期限日などの列の順の一覧は、前のページの最後の行の (列の値, ID) をカーソルにする（KeyParams・NewKeyPage）
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// =============================================================================
+// Cursor Pagination - カーソルページング
+// =============================================================================
+
+const (
+	// DefaultLimit は limit を省略した場合の1ページの件数です。
+	DefaultLimit = 20
+	// MaxLimit は1ページの件数の上限です。
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor は読み取れないカーソルを指定した場合のエラー
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Params は一覧の取得のページングの指定です。
+type Params struct {
+	Limit    int  // 1ページの件数（Normalize で 1〜MaxLimit に補正）
+	BeforeID uint // カーソル（前のページの最後の行のID、0 の場合は最初のページ）
+}
+
+// cursor はカーソルの文字列の中身です。
+type cursor struct {
+	ID  uint       `json:"id"`
+	Key *time.Time `json:"key,omitempty"` // 列の順の一覧の最後の行の列の値（IDの順の一覧では省略）
+}
+
+// NewParams はクエリパラメータの limit と cursor からページングの指定を作成します。
+//
+// 引数:
+//   - limit: 1ページの件数（0以下はデフォルト、MaxLimit を超える場合は MaxLimit）
+//   - token: 前のレスポンスの next_cursor（空の場合は最初のページ）
+//
+// 戻り値:
+//   - Params: ページングの指定
+//   - error: 読み取れないカーソルの場合は ErrInvalidCursor
+func NewParams(limit int, token string) (Params, error) {
+	params := Params{Limit: limit}
+	if token != "" {
+		id, err := DecodeCursor(token)
+		if err != nil {
+			return Params{}, err
+		}
+		params.BeforeID = id
+	}
+	return params.Normalize(), nil
+}
+
+// Normalize は件数をデフォルト・上限の範囲に補正した指定を返します。
+func (p Params) Normalize() Params {
+	if p.Limit <= 0 {
+		p.Limit = DefaultLimit
+	}
+	if p.Limit > MaxLimit {
+		p.Limit = MaxLimit
+	}
+	return p
+}
+
+// EncodeCursor は行のIDをカーソルの文字列にします。
+func EncodeCursor(id uint) string {
+	payload, _ := json.Marshal(cursor{ID: id})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor はカーソルの文字列から行のIDを取り出します。
+func DecodeCursor(token string) (uint, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.ID == 0 || c.Key != nil {
+		return 0, ErrInvalidCursor
+	}
+	return c.ID, nil
+}
+
+// Page は一覧の1ページ分のレスポンスです（一覧のエンドポイントで共通の形式）。
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"` // 次のページのカーソル（最後のページでは省略）
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
+
+// NewPage はリポジトリが取得した Limit+1 件までの行から1ページ分のレスポンスを作成します。
+// Limit 件を超える行がある場合は次のページがあるものとし、Limit 件目の行のIDを次のカーソルにします。
+//
+// 引数:
+//   - rows: IDの降順に取得した行（最大 Limit+1 件）
+//   - params: 取得に使用したページングの指定
+//   - idOf: 行のIDを返す関数
+func NewPage[T any](rows []T, params Params, idOf func(T) uint) *Page[T] {
+	params = params.Normalize()
+	page := &Page[T]{Items: rows, Limit: params.Limit}
+	if len(rows) > params.Limit {
+		page.Items = rows[:params.Limit]
+		page.HasMore = true
+		page.NextCursor = EncodeCursor(idOf(page.Items[len(page.Items)-1]))
+	}
+	if page.Items == nil {
+		page.Items = make([]T, 0)
+	}
+	return page
+}
+
+// =============================================================================
+// Keyset Pagination - 列の値とIDのカーソルページング
+// =============================================================================
+// 期限日の順など、ID以外の列の順の一覧は (列の値, ID) の組をカーソルにし、
+// リポジトリは WHERE (列, id) > (?, ?) ORDER BY 列, id で続きを取得します（OFFSET を使わない）。
+// 列の値が同じ行はIDの順に並ぶため、ページの境界で行が重複・抜けしません。
+
+// KeyParams は列の値の昇順の一覧の取得のページングの指定です。
+type KeyParams struct {
+	Limit    int       // 1ページの件数（Normalize で 1〜MaxLimit に補正）
+	AfterKey time.Time // カーソルの列の値（AfterID が 0 の場合は無視）
+	AfterID  uint      // カーソル（前のページの最後の行のID、0 の場合は最初のページ）
+}
+
+// NewKeyParams はクエリパラメータの limit と cursor から列の順のページングの指定を作成します。
+//
+// 引数:
+//   - limit: 1ページの件数（0以下はデフォルト、MaxLimit を超える場合は MaxLimit）
+//   - token: 前のレスポンスの next_cursor（空の場合は最初のページ）
+//
+// 戻り値:
+//   - KeyParams: ページングの指定
+//   - error: 読み取れないカーソル（IDの順の一覧のカーソルを含む）の場合は ErrInvalidCursor
+func NewKeyParams(limit int, token string) (KeyParams, error) {
+	params := KeyParams{Limit: limit}
+	if token != "" {
+		key, id, err := DecodeKeyCursor(token)
+		if err != nil {
+			return KeyParams{}, err
+		}
+		params.AfterKey, params.AfterID = key, id
+	}
+	return params.Normalize(), nil
+}
+
+// Normalize は件数をデフォルト・上限の範囲に補正した指定を返します。
+func (p KeyParams) Normalize() KeyParams {
+	p.Limit = Params{Limit: p.Limit}.Normalize().Limit
+	return p
+}
+
+// EncodeKeyCursor は行の列の値とIDをカーソルの文字列にします。
+// 列の値はタイムゾーンのオフセットを保ちます（SQLite は日時を文字列で比較するため、保存した値と同じオフセットで比較します）。
+func EncodeKeyCursor(key time.Time, id uint) string {
+	payload, _ := json.Marshal(cursor{ID: id, Key: &key})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeKeyCursor はカーソルの文字列から行の列の値とIDを取り出します。
+func DecodeKeyCursor(token string) (time.Time, uint, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.ID == 0 || c.Key == nil {
+		return time.Time{}, 0, ErrInvalidCursor
+	}
+	return *c.Key, c.ID, nil
+}
+
+// NewKeyPage はリポジトリが取得した Limit+1 件までの行から1ページ分のレスポンスを作成します。
+// Limit 件を超える行がある場合は次のページがあるものとし、Limit 件目の行の列の値とIDを次のカーソルにします。
+//
+// 引数:
+//   - rows: (列の値, ID) の昇順に取得した行（最大 Limit+1 件）
+//   - params: 取得に使用したページングの指定
+//   - keyOf: 行の列の値とIDを返す関数
+func NewKeyPage[T any](rows []T, params KeyParams, keyOf func(T) (time.Time, uint)) *Page[T] {
+	params = params.Normalize()
+	page := &Page[T]{Items: rows, Limit: params.Limit}
+	if len(rows) > params.Limit {
+		page.Items = rows[:params.Limit]
+		page.HasMore = true
+		page.NextCursor = EncodeKeyCursor(keyOf(page.Items[len(page.Items)-1]))
+	}
+	if page.Items == nil {
+		page.Items = make([]T, 0)
+	}
+	return page
+}
@@ -0,0 +1,132 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Cursor Pagination Tests - カーソルページングのテスト
+// =============================================================================
+// テスト対象:
+//   - NewParams / DecodeCursor: 件数の補正、カーソルの読み取り
+//   - NewPage: 次のページの有無とカーソル
+//   - NewKeyParams / NewKeyPage: 列の値とIDのカーソル
+
+// TestNewParams はページングの指定の作成のテストです。
+// 期待動作:
+//   - 件数は 0 以下でデフォルト、上限を超える場合は MaxLimit
+//   - EncodeCursor したカーソルからIDを取り出す
+//   - 読み取れないカーソルは ErrInvalidCursor
+func TestNewParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		token     string
+		want      Params
+		wantError bool
+	}{
+		{name: "デフォルト", limit: 0, want: Params{Limit: DefaultLimit}},
+		{name: "上限", limit: 1000, want: Params{Limit: MaxLimit}},
+		{name: "カーソル", limit: 5, token: EncodeCursor(42), want: Params{Limit: 5, BeforeID: 42}},
+		{name: "不正なカーソル", limit: 5, token: "not-a-cursor", wantError: true},
+		{name: "IDのないカーソル", limit: 5, token: "e30", wantError: true}, // {}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParams(tt.limit, tt.token)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("Expected ErrInvalidCursor, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("NewParams(%d, %q) = %+v, %v, want %+v", tt.limit, tt.token, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewPage はページの作成のテストです。
+// 期待動作:
+//   - Limit+1 件の行がある場合は Limit 件に切り詰め、最後の行のIDを次のカーソルにする
+//   - Limit 件以下の場合は最後のページ（カーソルなし）
+//   - 行がない場合も items は空の配列
+func TestNewPage(t *testing.T) {
+	idOf := func(id uint) uint { return id }
+	params := Params{Limit: 2}
+
+	more := NewPage([]uint{9, 7, 4}, params, idOf)
+	if len(more.Items) != 2 || !more.HasMore || more.Limit != 2 {
+		t.Fatalf("Expected 2 items with more pages, got %+v", more)
+	}
+	if id, err := DecodeCursor(more.NextCursor); err != nil || id != 7 {
+		t.Errorf("Expected next cursor for id 7, got %d (err=%v)", id, err)
+	}
+
+	last := NewPage([]uint{3}, params, idOf)
+	if last.HasMore || last.NextCursor != "" {
+		t.Errorf("Expected last page without cursor, got %+v", last)
+	}
+
+	empty := NewPage[uint](nil, params, idOf)
+	if empty.Items == nil || len(empty.Items) != 0 {
+		t.Errorf("Expected empty items, got %#v", empty.Items)
+	}
+}
+
+// TestNewKeyParams は列の順のページングの指定の作成のテストです。
+// 期待動作:
+//   - EncodeKeyCursor したカーソルから列の値とIDを取り出す
+//   - IDの順の一覧のカーソルと列の順の一覧のカーソルは互いに読み取れない（ErrInvalidCursor）
+func TestNewKeyParams(t *testing.T) {
+	// Arrange
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	keyCursor := EncodeKeyCursor(due, 42)
+
+	// Act
+	got, err := NewKeyParams(0, keyCursor)
+	_, idErr := NewKeyParams(5, EncodeCursor(42))
+	_, keyErr := NewParams(5, keyCursor)
+
+	// Assert
+	if err != nil || got.Limit != DefaultLimit || got.AfterID != 42 || !got.AfterKey.Equal(due) {
+		t.Errorf("NewKeyParams = %+v, %v, want key %v and id 42", got, err, due)
+	}
+	if !errors.Is(idErr, ErrInvalidCursor) || !errors.Is(keyErr, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for mismatched cursors, got %v / %v", idErr, keyErr)
+	}
+}
+
+// TestNewKeyPage は列の順のページの作成のテストです。
+// 期待動作:
+//   - Limit+1 件の行がある場合は Limit 件目の行の列の値とIDを次のカーソルにする
+//   - Limit 件以下の場合は最後のページ（カーソルなし）
+func TestNewKeyPage(t *testing.T) {
+	// Arrange
+	type row struct {
+		due time.Time
+		id  uint
+	}
+	base := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := []row{{base, 7}, {base, 9}, {base.Add(time.Hour), 3}}
+	keyOf := func(r row) (time.Time, uint) { return r.due, r.id }
+	params := KeyParams{Limit: 2}
+
+	// Act
+	more := NewKeyPage(rows, params, keyOf)
+	last := NewKeyPage(rows[:1], params, keyOf)
+
+	// Assert
+	if len(more.Items) != 2 || !more.HasMore {
+		t.Fatalf("Expected 2 items with more pages, got %+v", more)
+	}
+	if key, id, err := DecodeKeyCursor(more.NextCursor); err != nil || id != 9 || !key.Equal(base) {
+		t.Errorf("Expected next cursor for (%v, 9), got (%v, %d) err=%v", base, key, id, err)
+	}
+	if last.HasMore || last.NextCursor != "" {
+		t.Errorf("Expected last page without cursor, got %+v", last)
+	}
+}
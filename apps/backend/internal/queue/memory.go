@@ -0,0 +1,92 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/worker"
+)
+
+// =============================================================================
+// Memory Queue - プロセス内のジョブキュー
+// =============================================================================
+// SQS を使用しない環境向けに、worker.Pool でジョブを処理します。
+// 失敗したジョブは同じワーカーで待機してから再試行し、最大回数に達した場合はログに記録して破棄します。
+
+// memoryRetryDelay は失敗したジョブを再試行するまでの待機時間です（再試行ごとに2倍）。
+const memoryRetryDelay = time.Second
+
+// MemoryQueue はプロセス内のジョブキューです。
+type MemoryQueue struct {
+	handler     Handler
+	maxAttempts int
+	retryDelay  time.Duration
+	pool        *worker.Pool
+}
+
+// NewMemoryQueue は新しいMemoryQueueを作成します。
+//
+// 引数:
+//   - handler: ジョブを処理する関数
+//   - workers: ワーカー数（1未満の場合は1）
+//   - bufferSize: 処理待ちのジョブの最大数
+func NewMemoryQueue(handler Handler, workers, bufferSize int) *MemoryQueue {
+	return &MemoryQueue{
+		handler:     handler,
+		maxAttempts: DefaultMaxAttempts,
+		retryDelay:  memoryRetryDelay,
+		pool:        worker.NewPool(context.Background(), workers, bufferSize),
+	}
+}
+
+// Enqueue はジョブを登録します。キューが満杯の場合は待たずに ErrQueueFull を返します。
+func (q *MemoryQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	job, err := newJob(ctx, jobType, payload)
+	if err != nil {
+		return err
+	}
+
+	err = q.pool.Submit(jobType, func(ctx context.Context) error {
+		return q.process(ctx, job)
+	})
+	switch {
+	case errors.Is(err, worker.ErrPoolClosed):
+		return ErrQueueClosed
+	case errors.Is(err, worker.ErrQueueFull):
+		return ErrQueueFull
+	}
+	return err
+}
+
+// Start はジョブの処理を開始します（ワーカーは作成時に起動済みのため何もしません）。
+func (q *MemoryQueue) Start() {}
+
+// Shutdown は新しいジョブの受付を止め、処理中・処理待ちのジョブの完了を待ちます。
+func (q *MemoryQueue) Shutdown(ctx context.Context) error {
+	return q.pool.Shutdown(ctx)
+}
+
+// process はジョブを最大回数まで処理します。最大回数に達した場合はログに記録して破棄します。
+func (q *MemoryQueue) process(ctx context.Context, job Job) error {
+	delay := q.retryDelay
+	for attempt := 1; ; attempt++ {
+		job.Attempt = attempt
+		err := q.handler(ctx, job)
+		if err == nil {
+			return nil
+		}
+		if attempt >= q.maxAttempts || errors.Is(err, ErrUnknownJobType) {
+			log.Printf("Queue: job %s (%s) failed after %d attempt(s): %v", job.ID, job.Type, attempt, err)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,179 @@
+// Package queue - 非同期ジョブのキュー
+//
+// API の呼び出しで発生する重い処理（エクスポートの生成など）をジョブとしてキューに登録し、
+// ワーカーで処理します。HTTPリクエストは登録だけで応答し、処理の完了を待ちません。
+//
+// キューの実装（QUEUE_BACKEND）:
+//   - memory: プロセス内のチャネル（デフォルト。再起動時に未処理のジョブは失われる）
+//   - sqs: Amazon SQS（QUEUE_SQS_URL。複数インスタンスで処理を分散し、失敗したジョブは再配信される）
+//
+// ジョブには登録したリクエストのトレースのコンテキスト（W3C Trace Context）を含め、
+// 処理（Mux.Process）のスパンは登録したリクエストのトレースに記録します。
+// 処理のログには登録したリクエストのリクエストID・ユーザーIDが付きます（logging.FromContext）。
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Job Queue - ジョブキュー
+// =============================================================================
+
+// DefaultMaxAttempts はジョブを処理する最大回数です（失敗した場合に再試行する）。
+const DefaultMaxAttempts = 3
+
+// tracerName はジョブの処理のスパンの instrumentation scope です。
+const tracerName = "github.com/secure-scorecard/backend/internal/queue"
+
+var (
+	// ErrQueueClosed はシャットダウン後にジョブを登録した場合のエラー
+	ErrQueueClosed = errors.New("job queue is closed")
+	// ErrQueueFull はキューが満杯でジョブを登録できない場合のエラー
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrUnknownJobType は処理が登録されていないジョブの種類の場合のエラー
+	ErrUnknownJobType = errors.New("unknown job type")
+)
+
+// Job はキューに登録するジョブです。
+type Job struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`    // ジョブの種類（例: export.generate）
+	Payload json.RawMessage `json:"payload"` // ジョブの種類ごとのパラメータ（JSON）
+	Attempt int             `json:"-"`       // 処理の回数（1回目は1）
+
+	// TraceContext は登録したリクエストのトレースのコンテキストです（traceparent 等、トレースがない場合は空）
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	// RequestID・UserID は登録したリクエストのリクエストID・認証ユーザーIDです（処理のログに付ける）
+	RequestID string `json:"request_id,omitempty"`
+	UserID    uint   `json:"user_id,omitempty"`
+}
+
+// Decode はジョブのパラメータを v に読み込みます。
+func (j Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Handler はジョブを処理する関数です。エラーを返した場合は最大回数まで再試行します。
+type Handler func(ctx context.Context, job Job) error
+
+// Queue はジョブキューのインターフェースです。
+type Queue interface {
+	// Enqueue はジョブを登録します（payload はJSONに変換します）。
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	// Start はジョブの処理を開始します。
+	Start()
+	// Shutdown は新しいジョブの受付を止め、処理中のジョブの完了を待ちます。
+	Shutdown(ctx context.Context) error
+}
+
+// newJob はジョブを作成します（ctx のトレースのコンテキストを含める）。
+func newJob(ctx context.Context, jobType string, payload interface{}) (Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	job := Job{ID: uuid.New().String(), Type: jobType, Payload: data, RequestID: logging.RequestID(ctx), UserID: logging.UserID(ctx)}
+	if len(carrier) > 0 {
+		job.TraceContext = carrier
+	}
+	return job, nil
+}
+
+// New は設定に応じたジョブキューを作成します。
+//
+// 引数:
+//   - ctx: AWS設定の読み込みに使用するコンテキスト
+//   - cfg: キュー設定（QUEUE_BACKEND）
+//   - handler: ジョブを処理する関数（Mux.Process など）
+//
+// 戻り値:
+//   - Queue: ジョブキュー（Start で処理を開始）
+//   - error: 設定が不正な場合のエラー
+func New(ctx context.Context, cfg config.QueueConfig, handler Handler) (Queue, error) {
+	switch cfg.Backend {
+	case "", config.QueueBackendMemory:
+		return NewMemoryQueue(handler, cfg.Workers, cfg.BufferSize), nil
+	case config.QueueBackendSQS:
+		return NewSQSQueue(ctx, cfg, handler)
+	default:
+		return nil, fmt.Errorf("unsupported queue backend: %s", cfg.Backend)
+	}
+}
+
+// =============================================================================
+// Mux - ジョブの種類ごとの処理
+// =============================================================================
+
+// Mux はジョブの種類ごとに処理を振り分けます。
+type Mux struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewMux は新しいMuxを作成します。
+func NewMux() *Mux {
+	return &Mux{handlers: make(map[string]Handler)}
+}
+
+// Handle はジョブの種類の処理を登録します。
+func (m *Mux) Handle(jobType string, handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[jobType] = handler
+}
+
+// Process はジョブの種類に登録された処理を実行します。
+// 処理はジョブを登録したリクエストのトレースのスパン（"queue.process {ジョブの種類}"）として記録し、
+// 処理のコンテキストのロガーには登録したリクエストの request_id・user_id とジョブの job_id・job_type を付けます。
+// 処理が登録されていない場合は ErrUnknownJobType を返します。
+func (m *Mux) Process(ctx context.Context, job Job) (err error) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(job.TraceContext))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "queue.process "+job.Type,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("queue.job.id", job.ID),
+			attribute.String("queue.job.type", job.Type),
+			attribute.Int("queue.job.attempt", job.Attempt),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	// 処理のログ（サービス・リポジトリ）に登録したリクエストのリクエストID・ユーザーIDを付ける
+	if job.RequestID != "" {
+		ctx = logging.WithRequestID(ctx, job.RequestID)
+	}
+	if job.UserID != 0 {
+		ctx = logging.WithUserID(ctx, job.UserID)
+	}
+	ctx = logging.With(ctx, "job_id", job.ID, "job_type", job.Type)
+
+	m.mu.RLock()
+	handler, ok := m.handlers[job.Type]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJobType, job.Type)
+	}
+	return handler(ctx, job)
+}
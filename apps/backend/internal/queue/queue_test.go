@@ -0,0 +1,353 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Job Queue Tests - ジョブキューのテスト
+// =============================================================================
+// テスト対象:
+//   - MemoryQueue: 失敗したジョブの再試行、シャットダウン時の処理待ちジョブの完了
+//   - Mux: ジョブの種類ごとの振り分け、登録したリクエストのトレースのスパン・リクエストID・ユーザーID
+//   - SQSQueue: 成功・最大回数に達したメッセージの削除、失敗したメッセージの再配信
+//   - sqsClient: AWS JSON プロトコルのリクエストと署名
+
+// TestMemoryQueue_RetryAndShutdown はプロセス内のジョブキューのテストです。
+// 期待動作:
+//   - 失敗したジョブを成功するまで再試行する
+//   - Shutdown は処理待ちのジョブの完了を待つ
+//   - シャットダウン後の登録は ErrQueueClosed
+func TestMemoryQueue_RetryAndShutdown(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	var payloads []string
+	q := NewMemoryQueue(func(ctx context.Context, job Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[job.ID]++
+		var payload struct {
+			Name string `json:"name"`
+		}
+		if err := job.Decode(&payload); err != nil {
+			return err
+		}
+		if payload.Name == "flaky" && job.Attempt < 2 {
+			return errors.New("temporary failure")
+		}
+		payloads = append(payloads, payload.Name)
+		return nil
+	}, 1, 10)
+	q.retryDelay = time.Millisecond
+	q.Start()
+
+	// Act
+	for _, name := range []string{"flaky", "ok"} {
+		if err := q.Enqueue(context.Background(), "test", map[string]string{"name": name}); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+	shutdownErr := q.Shutdown(context.Background())
+
+	// Assert
+	if shutdownErr != nil {
+		t.Fatalf("Shutdown failed: %v", shutdownErr)
+	}
+	if len(payloads) != 2 || payloads[0] != "flaky" || payloads[1] != "ok" {
+		t.Errorf("Expected both jobs to complete in order, got %v", payloads)
+	}
+	total := 0
+	for _, n := range attempts {
+		total += n
+	}
+	if total != 3 {
+		t.Errorf("Expected 3 attempts (1 retry), got %d", total)
+	}
+	if err := q.Enqueue(context.Background(), "test", nil); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected ErrQueueClosed after shutdown, got %v", err)
+	}
+}
+
+// TestMux_Process はジョブの種類ごとの振り分けのテストです。
+// 期待動作:
+//   - 登録した種類のジョブはその処理を実行する
+//   - 登録していない種類のジョブは ErrUnknownJobType
+func TestMux_Process(t *testing.T) {
+	// Arrange
+	mux := NewMux()
+	called := false
+	mux.Handle("export.generate", func(ctx context.Context, job Job) error {
+		called = true
+		return nil
+	})
+
+	// Act
+	err := mux.Process(context.Background(), Job{Type: "export.generate"})
+	unknownErr := mux.Process(context.Background(), Job{Type: "photo.resize"})
+
+	// Assert
+	if err != nil || !called {
+		t.Errorf("Expected registered handler to run, got called=%v err=%v", called, err)
+	}
+	if !errors.Is(unknownErr, ErrUnknownJobType) {
+		t.Errorf("Expected ErrUnknownJobType, got %v", unknownErr)
+	}
+}
+
+// TestMux_ProcessTraceContext はジョブの処理のスパンのテストです。
+// 期待動作:
+//   - ジョブに登録したコンテキストのトレース（traceparent）を含め、JSON で受け渡せる
+//   - 処理のスパンは登録したリクエストのスパンの子で、処理のコンテキストに設定する
+//   - 処理のエラーはスパンのエラー、トレースのないジョブは新しいトレース
+func TestMux_ProcessTraceContext(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	mux := NewMux()
+	var handlerSpans []trace.SpanContext
+	mux.Handle("export.generate", func(ctx context.Context, job Job) error {
+		handlerSpans = append(handlerSpans, trace.SpanContextFromContext(ctx))
+		return errors.New("export failed")
+	})
+	ctx, request := otel.Tracer("test").Start(context.Background(), "POST /api/v1/exports")
+	job, err := newJob(ctx, "export.generate", map[string]int{"user_id": 1})
+	request.End()
+	if err != nil {
+		t.Fatalf("newJob failed: %v", err)
+	}
+	untraced, _ := newJob(context.Background(), "export.generate", nil)
+
+	// Act
+	body, _ := json.Marshal(job)
+	var received Job
+	_ = json.Unmarshal(body, &received)
+	processErr := mux.Process(context.Background(), received)
+	_ = mux.Process(context.Background(), untraced)
+
+	// Assert
+	if received.TraceContext["traceparent"] == "" || untraced.TraceContext != nil {
+		t.Fatalf("Expected the traceparent only on the traced job, got %v / %v", received.TraceContext, untraced.TraceContext)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected the request span and 2 job spans, got %d", len(spans))
+	}
+	process := spans[1]
+	if process.Name() != "queue.process export.generate" || process.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("Expected the job span under the request, got %s (parent %v)", process.Name(), process.Parent())
+	}
+	if len(handlerSpans) != 2 || handlerSpans[0].TraceID() != request.SpanContext().TraceID() {
+		t.Error("Expected the handler context in the trace of the request")
+	}
+	if processErr == nil || process.Status().Code != codes.Error {
+		t.Errorf("Expected the handler error on the span, got %v %v", processErr, process.Status())
+	}
+	if spans[2].Parent().IsValid() {
+		t.Errorf("Expected a new trace for the untraced job, got parent %v", spans[2].Parent())
+	}
+}
+
+// TestMux_ProcessRequestContext は登録したリクエストのリクエストID・ユーザーIDの引き継ぎのテストです。
+// 期待動作:
+//   - ジョブ（JSON）に登録したリクエストのリクエストID・ユーザーIDを含める
+//   - 処理のコンテキストに同じリクエストID・ユーザーIDを設定する（リクエスト以外で登録したジョブは空）
+func TestMux_ProcessRequestContext(t *testing.T) {
+	// Arrange
+	mux := NewMux()
+	var handled []string
+	var handledUsers []uint
+	mux.Handle("export.generate", func(ctx context.Context, job Job) error {
+		handled = append(handled, logging.RequestID(ctx))
+		handledUsers = append(handledUsers, logging.UserID(ctx))
+		return nil
+	})
+	ctx := logging.WithUserID(logging.WithRequestID(context.Background(), "req-1"), 42)
+	job, err := newJob(ctx, "export.generate", map[string]int{"user_id": 42})
+	if err != nil {
+		t.Fatalf("newJob failed: %v", err)
+	}
+	scheduled, _ := newJob(context.Background(), "export.generate", nil)
+
+	// Act
+	body, _ := json.Marshal(job)
+	var received Job
+	_ = json.Unmarshal(body, &received)
+	_ = mux.Process(context.Background(), received)
+	_ = mux.Process(context.Background(), scheduled)
+
+	// Assert
+	if received.RequestID != "req-1" || received.UserID != 42 {
+		t.Errorf("Expected the request ID and user ID in the job, got %q / %d", received.RequestID, received.UserID)
+	}
+	if len(handled) != 2 || handled[0] != "req-1" || handledUsers[0] != 42 {
+		t.Fatalf("Expected the request ID and user ID on the handler context, got %v / %v", handled, handledUsers)
+	}
+	if handled[1] != "" || handledUsers[1] != 0 {
+		t.Errorf("Expected no request ID for the job without a request, got %q / %d", handled[1], handledUsers[1])
+	}
+}
+
+// fakeSQS はテスト用の SQS API です。
+type fakeSQS struct {
+	mu       sync.Mutex
+	sent     []string
+	pending  []sqsMessage
+	deleted  []string
+	received chan struct{}
+}
+
+func (f *fakeSQS) SendMessage(ctx context.Context, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, body)
+	return nil
+}
+
+func (f *fakeSQS) ReceiveMessages(ctx context.Context) ([]sqsMessage, error) {
+	f.mu.Lock()
+	messages := f.pending
+	f.pending = nil
+	f.mu.Unlock()
+	if len(messages) > 0 {
+		return messages, nil
+	}
+	select {
+	case f.received <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (f *fakeSQS) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, receiptHandle)
+	return nil
+}
+
+// TestSQSQueue_Process は SQS のジョブキューのテストです。
+// 期待動作:
+//   - Enqueue はジョブをメッセージ本文（JSON）として送信する
+//   - 成功したメッセージは削除する
+//   - 失敗したメッセージは最大回数未満なら削除せず、最大回数に達したら削除する
+func TestSQSQueue_Process(t *testing.T) {
+	// Arrange
+	api := &fakeSQS{received: make(chan struct{}, 1)}
+	q := newSQSQueue(api, func(ctx context.Context, job Job) error {
+		if job.Type == "fail" {
+			return errors.New("failed")
+		}
+		return nil
+	}, 1)
+
+	if err := q.Enqueue(context.Background(), "ok", map[string]int{"export_id": 1}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	var sent Job
+	if err := json.Unmarshal([]byte(api.sent[0]), &sent); err != nil || sent.Type != "ok" || string(sent.Payload) != `{"export_id":1}` {
+		t.Fatalf("Unexpected message body %q (err=%v)", api.sent[0], err)
+	}
+	api.pending = []sqsMessage{
+		{MessageID: "1", ReceiptHandle: "ok", Body: api.sent[0], Attributes: map[string]string{"ApproximateReceiveCount": "1"}},
+		{MessageID: "2", ReceiptHandle: "retry", Body: `{"id":"2","type":"fail"}`, Attributes: map[string]string{"ApproximateReceiveCount": "1"}},
+		{MessageID: "3", ReceiptHandle: "exhausted", Body: `{"id":"3","type":"fail"}`, Attributes: map[string]string{"ApproximateReceiveCount": "3"}},
+	}
+
+	// Act
+	q.Start()
+	<-api.received
+	shutdownErr := q.Shutdown(context.Background())
+
+	// Assert
+	if shutdownErr != nil {
+		t.Fatalf("Shutdown failed: %v", shutdownErr)
+	}
+	if strings.Join(api.deleted, ",") != "ok,exhausted" {
+		t.Errorf("Expected ok and exhausted messages to be deleted, got %v", api.deleted)
+	}
+}
+
+// TestSQSClient_Call は SQS API クライアントのテストです。
+// 期待動作:
+//   - AWS JSON プロトコル（X-Amz-Target）で SigV4 署名したリクエストを送信する
+//   - 受信したメッセージを読み取る
+//   - エラーレスポンスはエラーを返す
+func TestSQSClient_Call(t *testing.T) {
+	// Arrange
+	var gotTarget, gotAuth string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTarget = r.Header.Get("X-Amz-Target")
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		switch gotTarget {
+		case "AmazonSQS.ReceiveMessage":
+			_, _ = w.Write([]byte(`{"Messages":[{"MessageId":"m1","ReceiptHandle":"h1","Body":"{}","Attributes":{"ApproximateReceiveCount":"2"}}]}`))
+		case "AmazonSQS.DeleteMessage":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.sqs#ReceiptHandleIsInvalid","message":"invalid"}`))
+		default:
+			_, _ = w.Write([]byte(`{"MessageId":"m1"}`))
+		}
+	}))
+	defer server.Close()
+
+	queueURL := server.URL + "/123456789012/exports"
+	creds := aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	})
+	client, err := newSQSClient(queueURL, "ap-northeast-1", creds)
+	if err != nil {
+		t.Fatalf("newSQSClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	// Act & Assert
+	if err := client.SendMessage(ctx, "hello"); err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if gotTarget != "AmazonSQS.SendMessage" || gotBody["QueueUrl"] != queueURL || gotBody["MessageBody"] != "hello" {
+		t.Errorf("Unexpected SendMessage request: target=%s body=%v", gotTarget, gotBody)
+	}
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(gotAuth, "/ap-northeast-1/sqs/aws4_request") {
+		t.Errorf("Expected SigV4 authorization, got %q", gotAuth)
+	}
+
+	messages, err := client.ReceiveMessages(ctx)
+	if err != nil {
+		t.Fatalf("ReceiveMessages failed: %v", err)
+	}
+	if len(messages) != 1 || messages[0].ReceiptHandle != "h1" || messages[0].Attributes["ApproximateReceiveCount"] != "2" {
+		t.Errorf("Unexpected messages: %+v", messages)
+	}
+
+	if err := client.DeleteMessage(ctx, "h1"); err == nil || !strings.Contains(err.Error(), "ReceiptHandleIsInvalid") {
+		t.Errorf("Expected DeleteMessage error, got %v", err)
+	}
+}
@@ -0,0 +1,371 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// SQS Queue - Amazon SQS のジョブキュー
+// =============================================================================
+// ジョブを SQS のメッセージとして送信し、ワーカーがロングポーリングで受信して処理します。
+//   - 処理に成功したメッセージは削除する
+//   - 失敗したメッセージは削除せず、可視性タイムアウト後に SQS が再配信する
+//   - 受信回数が最大回数に達したメッセージはログに記録して削除する（キューにリドライブポリシーがある場合はその前にDLQへ移動）
+//
+// SQS API は AWS JSON プロトコル（X-Amz-Target: AmazonSQS.*）で呼び出し、SigV4 で署名します。
+
+const (
+	// sqsWaitTimeSeconds はロングポーリングの待機時間（最大20秒）
+	sqsWaitTimeSeconds = 20
+	// sqsMaxMessages は1回の受信で取得するメッセージの最大数（最大10件）
+	sqsMaxMessages = 10
+	// sqsRequestTimeout はSQS APIのリクエストタイムアウト（ロングポーリングの待機時間より長くする）
+	sqsRequestTimeout = 30 * time.Second
+	// sqsReceiveErrorDelay は受信に失敗した場合に再試行するまでの待機時間
+	sqsReceiveErrorDelay = 5 * time.Second
+)
+
+// sqsAPI はジョブキューが使用する SQS API です（テストで差し替え可能）。
+type sqsAPI interface {
+	SendMessage(ctx context.Context, body string) error
+	ReceiveMessages(ctx context.Context) ([]sqsMessage, error)
+	DeleteMessage(ctx context.Context, receiptHandle string) error
+}
+
+// sqsMessage は受信した SQS のメッセージです。
+type sqsMessage struct {
+	MessageID     string            `json:"MessageId"`
+	ReceiptHandle string            `json:"ReceiptHandle"`
+	Body          string            `json:"Body"`
+	Attributes    map[string]string `json:"Attributes"`
+}
+
+// SQSQueue は Amazon SQS のジョブキューです。
+type SQSQueue struct {
+	api         sqsAPI
+	handler     Handler
+	workers     int
+	maxAttempts int
+
+	// stopCtx は受信の停止の合図、workCtx はジョブに渡すコンテキスト（Shutdown の期限を過ぎた場合のみ取り消す）
+	stopCtx    context.Context
+	stop       context.CancelFunc
+	workCtx    context.Context
+	cancelWork context.CancelFunc
+
+	mu      sync.Mutex
+	closed  bool
+	started bool
+	wg      sync.WaitGroup
+}
+
+// NewSQSQueue は新しいSQSQueueを作成します。
+//
+// 引数:
+//   - ctx: AWS設定の読み込みに使用するコンテキスト
+//   - cfg: キュー設定（SQSQueueURL, AWSRegion, Workers）
+//   - handler: ジョブを処理する関数
+//
+// 戻り値:
+//   - *SQSQueue: ジョブキュー（Start で受信を開始）
+//   - error: キューURLが不正、AWS設定を読み込めない場合のエラー
+func NewSQSQueue(ctx context.Context, cfg config.QueueConfig, handler Handler) (*SQSQueue, error) {
+	if cfg.SQSQueueURL == "" {
+		return nil, errors.New("QUEUE_SQS_URL is required for the sqs queue backend")
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	api, err := newSQSClient(cfg.SQSQueueURL, cfg.AWSRegion, awsCfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return newSQSQueue(api, handler, cfg.Workers), nil
+}
+
+// newSQSQueue は SQS API を指定してSQSQueueを作成します。
+func newSQSQueue(api sqsAPI, handler Handler, workers int) *SQSQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	stopCtx, stop := context.WithCancel(context.Background())
+	workCtx, cancelWork := context.WithCancel(context.Background())
+	return &SQSQueue{
+		api:         api,
+		handler:     handler,
+		workers:     workers,
+		maxAttempts: DefaultMaxAttempts,
+		stopCtx:     stopCtx,
+		stop:        stop,
+		workCtx:     workCtx,
+		cancelWork:  cancelWork,
+	}
+}
+
+// Enqueue はジョブを SQS に送信します。
+func (q *SQSQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	q.mu.Lock()
+	closed := q.closed
+	q.mu.Unlock()
+	if closed {
+		return ErrQueueClosed
+	}
+
+	job, err := newJob(ctx, jobType, payload)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	return q.api.SendMessage(ctx, string(body))
+}
+
+// Start はワーカーごとにメッセージの受信を開始します。
+func (q *SQSQueue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started || q.closed {
+		return
+	}
+	q.started = true
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.poll()
+	}
+}
+
+// Shutdown は受信を止め、処理中のジョブの完了を待ちます。
+// ctx の期限までに終わらない場合はジョブのコンテキストを取り消し、ctx のエラーを返します。
+// 処理を終えていないメッセージは削除しないため、可視性タイムアウト後に再配信されます。
+func (q *SQSQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.stop()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancelWork()
+		return nil
+	case <-ctx.Done():
+		q.cancelWork()
+		return ctx.Err()
+	}
+}
+
+// poll はメッセージを受信して処理します。停止の合図を受けると受信を止めます。
+func (q *SQSQueue) poll() {
+	defer q.wg.Done()
+	for {
+		if q.stopCtx.Err() != nil {
+			return
+		}
+
+		messages, err := q.api.ReceiveMessages(q.stopCtx)
+		if err != nil {
+			if q.stopCtx.Err() != nil {
+				return
+			}
+			log.Printf("Queue: failed to receive SQS messages: %v", err)
+			select {
+			case <-q.stopCtx.Done():
+				return
+			case <-time.After(sqsReceiveErrorDelay):
+			}
+			continue
+		}
+
+		for _, message := range messages {
+			q.process(message)
+		}
+	}
+}
+
+// process はメッセージのジョブを処理し、成功・最大回数に達した場合はメッセージを削除します。
+func (q *SQSQueue) process(message sqsMessage) {
+	var job Job
+	if err := json.Unmarshal([]byte(message.Body), &job); err != nil {
+		log.Printf("Queue: discarding malformed SQS message %s: %v", message.MessageID, err)
+		q.delete(message)
+		return
+	}
+	job.Attempt = 1
+	if count, err := strconv.Atoi(message.Attributes["ApproximateReceiveCount"]); err == nil && count > 0 {
+		job.Attempt = count
+	}
+
+	err := q.handler(q.workCtx, job)
+	if err != nil {
+		if job.Attempt < q.maxAttempts && !errors.Is(err, ErrUnknownJobType) {
+			// 削除せず、可視性タイムアウト後の再配信で再試行する
+			log.Printf("Queue: job %s (%s) failed (attempt %d), will retry: %v", job.ID, job.Type, job.Attempt, err)
+			return
+		}
+		log.Printf("Queue: job %s (%s) failed after %d attempt(s): %v", job.ID, job.Type, job.Attempt, err)
+	}
+	q.delete(message)
+}
+
+// delete はメッセージを削除します（シャットダウン中も削除できるよう workCtx を使用）。
+func (q *SQSQueue) delete(message sqsMessage) {
+	if err := q.api.DeleteMessage(q.workCtx, message.ReceiptHandle); err != nil {
+		log.Printf("Queue: failed to delete SQS message %s: %v", message.MessageID, err)
+	}
+}
+
+// =============================================================================
+// SQS Client - SQS API クライアント（AWS JSON プロトコル）
+// =============================================================================
+
+// sqsClient は SQS API を AWS JSON プロトコルで呼び出すクライアントです。
+type sqsClient struct {
+	httpClient  *http.Client
+	endpoint    string // キューURLのスキームとホスト（例: https://sqs.ap-northeast-1.amazonaws.com）
+	queueURL    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+// newSQSClient は新しいsqsClientを作成します。
+func newSQSClient(queueURL, region string, credentials aws.CredentialsProvider) (*sqsClient, error) {
+	parsed, err := url.Parse(queueURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid SQS queue URL: %q", queueURL)
+	}
+	return &sqsClient{
+		httpClient:  &http.Client{Timeout: sqsRequestTimeout},
+		endpoint:    parsed.Scheme + "://" + parsed.Host + "/",
+		queueURL:    queueURL,
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+// SendMessage はメッセージを送信します。
+func (c *sqsClient) SendMessage(ctx context.Context, body string) error {
+	return c.call(ctx, "SendMessage", map[string]interface{}{
+		"QueueUrl":    c.queueURL,
+		"MessageBody": body,
+	}, nil)
+}
+
+// ReceiveMessages はロングポーリングでメッセージを受信します。
+func (c *sqsClient) ReceiveMessages(ctx context.Context) ([]sqsMessage, error) {
+	var resp struct {
+		Messages []sqsMessage `json:"Messages"`
+	}
+	err := c.call(ctx, "ReceiveMessage", map[string]interface{}{
+		"QueueUrl":                    c.queueURL,
+		"MaxNumberOfMessages":         sqsMaxMessages,
+		"WaitTimeSeconds":             sqsWaitTimeSeconds,
+		"MessageSystemAttributeNames": []string{"ApproximateReceiveCount"},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Messages, nil
+}
+
+// DeleteMessage はメッセージを削除します。
+func (c *sqsClient) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	return c.call(ctx, "DeleteMessage", map[string]interface{}{
+		"QueueUrl":      c.queueURL,
+		"ReceiptHandle": receiptHandle,
+	}, nil)
+}
+
+// call は SQS API を呼び出し、レスポンスを out に読み込みます。
+// 呼び出し元のスパンがある場合（リクエスト・ジョブからの登録）は呼び出しのスパンを記録します
+// （ワーカーのロングポーリングの受信・削除はトレースを作成しない）。
+func (c *sqsClient) call(ctx context.Context, action string, in interface{}, out interface{}) error {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		_, _, err := c.send(ctx, action, in, out)
+		return err
+	}
+	ctx, span := tracing.StartAWSSpan(ctx, "SQS", action, c.region)
+	defer span.End()
+	status, requestID, err := c.send(ctx, action, in, out)
+	tracing.FinishAWSSpan(span, status, requestID, err)
+	return err
+}
+
+// send は SQS API のリクエストを送信し、レスポンスのステータスコードとリクエストIDを返します（レスポンスがない場合は 0）。
+func (c *sqsClient) send(ctx context.Context, action string, in interface{}, out interface{}) (int, string, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to encode SQS %s request: %w", action, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create SQS %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "sqs", c.region, time.Now()); err != nil {
+		return 0, "", fmt.Errorf("failed to sign SQS %s request: %w", action, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("SQS %s request failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+	requestID := resp.Header.Get("X-Amzn-Requestid")
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, requestID, fmt.Errorf("failed to read SQS %s response: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return resp.StatusCode, requestID, fmt.Errorf("SQS %s failed with status %d: %s %s", action, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.StatusCode, requestID, fmt.Errorf("failed to decode SQS %s response: %w", action, err)
+		}
+	}
+	return resp.StatusCode, requestID, nil
+}
@@ -0,0 +1,233 @@
+// Package realtime - 共有の庭のリアルタイム配信のハブ
+//
+// 庭を共有する世帯のメンバー（所有者とメンバー）に、区画のレイアウトとタスクの変更を
+// WebSocket（GET /api/v1/ws）でリアルタイムに配信します。
+//
+//   - 接続（Client）は庭ごとのルームに参加し、参加しているルームのメッセージのみ受信する
+//   - ルームへの参加の認可（所有者・メンバーの判定）はサービス層で行い、ハブは配信のみを担う
+//   - 接続のバッファが満杯の場合（受信が遅いクライアント）は接続を閉じ、クライアントは再接続してレイアウトを再取得する
+//
+// ハブはプロセス内のみで、複数のインスタンスで実行する場合は接続しているインスタンスで発生した変更のみ届きます。
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Message Types - メッセージの種類
+// =============================================================================
+
+const (
+	// TypePlotCreated は区画を作成した（Data は区画のレイアウト）
+	TypePlotCreated = "plot.created"
+	// TypePlotUpdated は区画・区画の作物の配置を更新した（Data は区画のレイアウト）
+	TypePlotUpdated = "plot.updated"
+	// TypePlotDeleted は区画を削除した（Data なし）
+	TypePlotDeleted = "plot.deleted"
+	// TypeTaskCreated はタスクを作成した（Data はタスク）
+	TypeTaskCreated = "task.created"
+	// TypeTaskUpdated はタスクを更新した（Data はタスク）
+	TypeTaskUpdated = "task.updated"
+	// TypeTaskCompleted はタスクを完了した（Data は完了したタスク）
+	TypeTaskCompleted = "task.completed"
+	// TypeTaskDeleted はタスクを削除した（Data なし）
+	TypeTaskDeleted = "task.deleted"
+	// TypeRoomRevoked は庭のメンバーから外れ、ルームから退出させた（Data なし）
+	TypeRoomRevoked = "room.revoked"
+)
+
+// DefaultBufferSize は接続ごとの配信待ちのメッセージの最大数です。
+const DefaultBufferSize = 64
+
+// Message はルームに配信するメッセージです。
+type Message struct {
+	Type       string    `json:"type"`
+	GardenID   uint      `json:"garden_id"` // 配信先のルーム（庭）
+	Entity     string    `json:"entity,omitempty"`
+	EntityID   uint      `json:"entity_id,omitempty"`
+	Data       any       `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// =============================================================================
+// Hub - ルームのハブ
+// =============================================================================
+
+// Hub は庭ごとのルームにメッセージを配信するプロセス内のハブです。
+type Hub struct {
+	mu         sync.Mutex
+	clients    map[*Client]struct{}
+	rooms      map[uint]map[*Client]struct{}
+	bufferSize int
+	closed     bool
+}
+
+// NewHub は新しいHubを作成します。
+//
+// 引数:
+//   - bufferSize: 接続ごとの配信待ちのメッセージの最大数（1未満の場合は DefaultBufferSize）
+func NewHub(bufferSize int) *Hub {
+	if bufferSize < 1 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		clients:    make(map[*Client]struct{}),
+		rooms:      make(map[uint]map[*Client]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+// Connect はユーザーの接続を登録します。接続はルームに参加するまでメッセージを受信しません。
+//
+// 戻り値:
+//   - *Client: 接続（ハブを閉じた後は閉じた接続）
+func (h *Hub) Connect(userID uint) *Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	client := &Client{
+		userID:   userID,
+		messages: make(chan Message, h.bufferSize),
+		rooms:    make(map[uint]struct{}),
+		hub:      h,
+	}
+	if h.closed {
+		close(client.messages)
+		return client
+	}
+	h.clients[client] = struct{}{}
+	return client
+}
+
+// Join は接続を庭のルームに参加させます（閉じた接続・参加済みの場合は何もしない）。
+func (h *Hub) Join(client *Client, gardenID uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	room, ok := h.rooms[gardenID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		h.rooms[gardenID] = room
+	}
+	room[client] = struct{}{}
+	client.rooms[gardenID] = struct{}{}
+}
+
+// Leave は接続を庭のルームから退出させます。
+func (h *Hub) Leave(client *Client, gardenID uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.leaveLocked(client, gardenID)
+}
+
+// Broadcast は庭のルームに参加している全ての接続にメッセージを配信します。
+// 配信は待たずに行い、バッファが満杯の接続は閉じます。
+func (h *Hub) Broadcast(gardenID uint, msg Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	msg.GardenID = gardenID
+	if msg.OccurredAt.IsZero() {
+		msg.OccurredAt = time.Now()
+	}
+	for client := range h.rooms[gardenID] {
+		h.sendLocked(client, msg)
+	}
+}
+
+// RemoveUser はユーザーの全ての接続を庭のルームから退出させ、room.revoked を送ります。
+// 庭のメンバーから外れたユーザーが以降の変更を受信しないようにするために使用します。
+func (h *Hub) RemoveUser(gardenID, userID uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.rooms[gardenID] {
+		if client.userID != userID {
+			continue
+		}
+		h.leaveLocked(client, gardenID)
+		h.sendLocked(client, Message{Type: TypeRoomRevoked, GardenID: gardenID, OccurredAt: time.Now()})
+	}
+}
+
+// RoomSize は庭のルームに参加している接続数を返します。
+func (h *Hub) RoomSize(gardenID uint) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.rooms[gardenID])
+}
+
+// Close は全ての接続を閉じ、以降のメッセージを配信しません（サーバーの停止時に接続を終了するため）。
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for client := range h.clients {
+		h.disconnectLocked(client)
+	}
+}
+
+// sendLocked は接続にメッセージを送り、バッファが満杯の場合は接続を閉じます（h.mu を保持して呼び出す）。
+func (h *Hub) sendLocked(client *Client, msg Message) {
+	select {
+	case client.messages <- msg:
+	default:
+		// 受信が遅いクライアントはメッセージが抜けるため、切断して再接続時にレイアウトを再取得させる
+		h.disconnectLocked(client)
+	}
+}
+
+// leaveLocked は接続をルームから退出させます（h.mu を保持して呼び出す）。
+func (h *Hub) leaveLocked(client *Client, gardenID uint) {
+	delete(client.rooms, gardenID)
+	room, ok := h.rooms[gardenID]
+	if !ok {
+		return
+	}
+	delete(room, client)
+	if len(room) == 0 {
+		delete(h.rooms, gardenID)
+	}
+}
+
+// disconnectLocked は接続を全てのルームから退出させ、チャネルを閉じます（h.mu を保持して呼び出す）。
+func (h *Hub) disconnectLocked(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	for gardenID := range client.rooms {
+		h.leaveLocked(client, gardenID)
+	}
+	delete(h.clients, client)
+	close(client.messages)
+}
+
+// Client はユーザーの WebSocket の接続です。
+type Client struct {
+	userID   uint
+	messages chan Message
+	rooms    map[uint]struct{} // 参加しているルーム（h.mu で保護）
+	hub      *Hub
+}
+
+// UserID は接続のユーザーIDを返します。
+func (c *Client) UserID() uint {
+	return c.userID
+}
+
+// Messages はメッセージを受信するチャネルを返します。
+// 接続を閉じた場合、バッファが満杯になった場合、ハブを閉じた場合はチャネルを閉じます。
+func (c *Client) Messages() <-chan Message {
+	return c.messages
+}
+
+// Close は接続を全てのルームから退出させて閉じます（複数回呼び出してもよい）。
+func (c *Client) Close() {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	c.hub.disconnectLocked(c)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// AnalyticsViewRepository Implementation - マテリアライズドビューリポジトリ
+// =============================================================================
+
+// analyticsViewRepository implements AnalyticsViewRepository
+type analyticsViewRepository struct {
+	db *gorm.DB
+}
+
+// Refresh はマテリアライズドビューをリフレッシュします。
+// CONCURRENTLY オプションでロックを最小化し、失敗した場合は通常のリフレッシュを試行します。
+// 集計に時間がかかるため、通常のクエリではなくメンテナンスのタイムアウトを使用します。
+// PostgreSQL 以外（DB_DRIVER=mysql / sqlite）のビューは通常のビューで常に最新のため、何もしません。
+func (r *analyticsViewRepository) Refresh(ctx context.Context, viewName string) error {
+	db := GetDB(ContextWithMaintenanceQuery(ctx), r.db)
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	ident := clause.Table{Name: viewName}
+
+	if err := db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY ?", ident).Error; err == nil {
+		return nil
+	}
+	if err := db.Exec("REFRESH MATERIALIZED VIEW ?", ident).Error; err != nil {
+		return fmt.Errorf("failed to refresh %s: %w", viewName, err)
+	}
+	return nil
+}
+
+// RecordRefresh はリフレッシュ結果をビュー名でupsertします。
+// 失敗時（LastRefreshedAtがnil）は前回の成功日時を上書きしません。
+func (r *analyticsViewRepository) RecordRefresh(ctx context.Context, record *model.MaterializedViewRefresh) error {
+	columns := []string{"last_attempt_at", "duration_ms", "last_error", "updated_at"}
+	if record.LastRefreshedAt != nil {
+		columns = append(columns, "last_refreshed_at")
+	}
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "view_name"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(record).Error
+}
+
+// GetCropHarvestAnalytics は mv_harvest_analytics から同じ作物名の行を植え付け日順に取得します。
+// ビューはリフレッシュ時点の集計のため、直近の収穫が反映されていない場合があります。
+func (r *analyticsViewRepository) GetCropHarvestAnalytics(ctx context.Context, userID uint, cropName string) ([]CropHarvestAnalytics, error) {
+	var rows []CropHarvestAnalytics
+	if err := GetDB(ctx, r.db).Table("mv_harvest_analytics").
+		Select("crop_id, crop_name, planted_date, total_quantity, COALESCE(quantity_unit, '') AS quantity_unit, COALESCE(harvest_count, 0) AS harvest_count, days_to_first_harvest, avg_quality_score").
+		Where("user_id = ? AND LOWER(crop_name) = LOWER(?)", userID, cropName).
+		Order("planted_date").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetRefreshStatuses は全ビューの最新リフレッシュ結果を取得します。
+func (r *analyticsViewRepository) GetRefreshStatuses(ctx context.Context) ([]model.MaterializedViewRefresh, error) {
+	var records []model.MaterializedViewRefresh
+	if err := GetDB(ctx, r.db).Order("view_name").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetDataVersion はスコープのデータのバージョンの合計を返します。
+// 各スコープのバージョンは増えるのみのため、いずれかのスコープが変わると合計も変わります。
+func (r *analyticsViewRepository) GetDataVersion(ctx context.Context, scopes []string) (int64, error) {
+	var version int64
+	if err := GetDB(ctx, r.db).Model(&model.AnalyticsDataVersion{}).
+		Select("COALESCE(SUM(version), 0)").
+		Where("scope IN ?", scopes).
+		Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// BumpDataVersion はスコープのデータのバージョンを1増やします（スコープごとにupsert）。
+// トランザクション内で呼び出した場合は、記録の変更と一緒にコミット・ロールバックされます。
+func (r *analyticsViewRepository) BumpDataVersion(ctx context.Context, scopes ...string) error {
+	db := GetDB(ctx, r.db)
+	for _, scope := range scopes {
+		if err := db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"version":    gorm.Expr("analytics_data_versions.version + 1"),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}),
+		}).Create(&model.AnalyticsDataVersion{Scope: scope, Version: 1}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// AnnouncementRepository Implementation - お知らせリポジトリ
+// =============================================================================
+
+// announcementRepository implements AnnouncementRepository
+type announcementRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しいお知らせを作成します。
+func (r *announcementRepository) Create(ctx context.Context, announcement *model.Announcement) error {
+	return GetDB(ctx, r.db).Create(announcement).Error
+}
+
+// GetByID はIDでお知らせを取得します。
+func (r *announcementRepository) GetByID(ctx context.Context, id uint) (*model.Announcement, error) {
+	var announcement model.Announcement
+	if err := GetDB(ctx, r.db).First(&announcement, id).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// List はお知らせを新しい順に取得します。
+func (r *announcementRepository) List(ctx context.Context, limit int) ([]model.Announcement, error) {
+	var announcements []model.Announcement
+	query := GetDB(ctx, r.db).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&announcements).Error; err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// Update はお知らせを更新します。
+func (r *announcementRepository) Update(ctx context.Context, announcement *model.Announcement) error {
+	return GetDB(ctx, r.db).Save(announcement).Error
+}
+
+// GetDue は配信予定日時を過ぎた scheduled / sending のお知らせを取得します。
+func (r *announcementRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]model.Announcement, error) {
+	var announcements []model.Announcement
+	err := GetDB(ctx, r.db).
+		Where("status IN ? AND scheduled_at <= ?", []string{"scheduled", "sending"}, now).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&announcements).Error
+	if err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// GetAudienceUserIDs は条件に合う有効なユーザーのIDを afterUserID より後から昇順に取得します。
+func (r *announcementRepository) GetAudienceUserIDs(ctx context.Context, audience AnnouncementAudience, afterUserID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.audienceQuery(ctx, audience).
+		Where("users.id > ?", afterUserID).
+		Order("users.id ASC").
+		Limit(limit).
+		Pluck("users.id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CountAudience は条件に合う有効なユーザー数を返します。
+func (r *announcementRepository) CountAudience(ctx context.Context, audience AnnouncementAudience) (int64, error) {
+	var count int64
+	if err := r.audienceQuery(ctx, audience).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// audienceQuery は配信対象のユーザーを絞り込むクエリを作成します。
+// プラットフォーム・アクティブ日の条件は EXISTS で判定し、ユーザーが重複しないようにします。
+func (r *announcementRepository) audienceQuery(ctx context.Context, audience AnnouncementAudience) *gorm.DB {
+	query := GetDB(ctx, r.db).Model(&model.User{}).Where("users.is_active = ?", true)
+	if audience.Locale != "" {
+		query = query.Where("users.locale = ?", audience.Locale)
+	}
+	if audience.Platform != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM device_tokens WHERE device_tokens.user_id = users.id AND device_tokens.is_active = ? AND device_tokens.platform = ?)",
+			true, audience.Platform)
+	}
+	if audience.ActiveSince != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM daily_active_users WHERE daily_active_users.user_id = users.id AND daily_active_users.date >= ?)",
+			*audience.ActiveSince)
+	}
+	return query
+}
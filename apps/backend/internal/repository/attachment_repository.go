@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// AttachmentRepository Implementation - 添付ファイルリポジトリ
+// =============================================================================
+
+// attachmentRepository implements AttachmentRepository
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しい添付ファイルを保存します。
+// オブジェクトキーが登録済みの場合は gorm.ErrDuplicatedKey を返します。
+func (r *attachmentRepository) Create(ctx context.Context, attachment *model.Attachment) error {
+	db := GetDB(ctx, r.db)
+	err := db.Create(attachment).Error
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		return translator.Translate(err)
+	}
+	return err
+}
+
+// ExistsByObjectKey はオブジェクトキーが添付ファイルとして登録済みか判定します（論理削除した添付ファイルを含む）。
+func (r *attachmentRepository) ExistsByObjectKey(ctx context.Context, objectKey string) (bool, error) {
+	var count int64
+	if err := GetDB(ctx, r.db).Unscoped().Model(&model.Attachment{}).
+		Where("object_key = ?", objectKey).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetByID はIDで添付ファイルを取得します。
+func (r *attachmentRepository) GetByID(ctx context.Context, id uint) (*model.Attachment, error) {
+	var attachment model.Attachment
+	if err := GetDB(ctx, r.db).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Delete は添付ファイルを論理削除します。
+func (r *attachmentRepository) Delete(ctx context.Context, id uint) error {
+	return GetDB(ctx, r.db).Delete(&model.Attachment{}, id).Error
+}
+
+// ListByParent は添付先の添付ファイルを古い順に取得します。
+func (r *attachmentRepository) ListByParent(ctx context.Context, parentType string, parentID uint) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := GetDB(ctx, r.db).
+		Where("parent_type = ? AND parent_id = ?", parentType, parentID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetStorageTotalByUserID はユーザーの添付ファイルの件数と保存容量の合計を返します。
+func (r *attachmentRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	if err := GetDB(ctx, r.db).Model(&model.Attachment{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(size_bytes), 0) AS bytes").
+		Where("user_id = ?", userID).
+		Scan(&total).Error; err != nil {
+		return StorageTotal{}, err
+	}
+	return total, nil
+}
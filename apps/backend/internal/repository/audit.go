@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// =============================================================================
+// Audit Log - 作物・区画・タスク・収穫記録の変更履歴
+// =============================================================================
+// GORM のプラグイン（AuditPlugin）で作物・区画・タスク・収穫記録の作成・更新・削除を audit_logs に記録します。
+// リポジトリのメソッドごとではなく GORM のコールバックで記録するため、一括作成・オフライン同期・ゴミ箱からの復元・
+// 定期実行（シーズンの締めなど）の変更も記録されます。
+//   - 更新・削除は実行前に対象の行を読み込み、更新は実行後の行と比較して変更した列のみ記録する
+//   - 変更履歴は変更と同じ接続（トランザクション内の場合は同じトランザクション）で作成し、ロールバックした変更は残らない
+//   - 変更したユーザーはリクエストの context（ContextWithActor）から取得する（リクエスト以外の変更は NULL）
+//   - 変更履歴を作成できなかった場合は変更のクエリのエラーにする
+
+const (
+	// auditBeforeKey は更新・削除の前に読み込んだ行の Statement の設定のキーです。
+	auditBeforeKey = "repository:audit_before"
+	// auditBatchSize は変更履歴の一括作成の件数です。
+	auditBatchSize = 500
+)
+
+// AuditEntities は変更履歴を記録するテーブルです（GET /audit の entity）。
+var AuditEntities = []string{"crops", "plots", "tasks", "harvests"}
+
+// auditIgnoredColumns は変更として記録しない列です（更新のたびに変わる）。
+var auditIgnoredColumns = map[string]bool{"updated_at": true}
+
+// actorKey is the context key for the user making the change
+type actorKey struct{}
+
+// ContextWithActor は変更履歴に記録する変更したユーザーを設定した context を返します。
+//
+// 引数:
+//   - ctx: 元の context
+//   - userID: 認証済みのユーザーID
+//
+// 戻り値:
+//   - context.Context: 変更したユーザーを設定した context
+func ContextWithActor(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext は context の変更したユーザーを返します（設定していない場合は false）。
+func ActorFromContext(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	userID, ok := ctx.Value(actorKey{}).(uint)
+	return userID, ok && userID != 0
+}
+
+// AuditPlugin は作物・区画・タスク・収穫記録の変更履歴を記録する GORM のプラグインです。
+type AuditPlugin struct{}
+
+// NewAuditPlugin は新しいAuditPluginを作成します。
+//
+// 戻り値:
+//   - *AuditPlugin: db.Use で登録するプラグイン
+func NewAuditPlugin() *AuditPlugin {
+	return &AuditPlugin{}
+}
+
+// Name はプラグインの名前を返します（gorm.Plugin）。
+func (p *AuditPlugin) Name() string {
+	return "repository:audit"
+}
+
+// Initialize は作成・更新・削除の変更履歴を記録するコールバックを登録します（gorm.Plugin）。
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().After("gorm:create").Register("repository:audit_create", p.afterCreate),
+		callbacks.Update().Before("gorm:update").Register("repository:audit_load", p.loadBefore),
+		callbacks.Update().After("gorm:update").Register("repository:audit_update", p.afterUpdate),
+		callbacks.Delete().Before("gorm:delete").Register("repository:audit_load", p.loadBefore),
+		callbacks.Delete().After("gorm:delete").Register("repository:audit_delete", p.afterDelete),
+	)
+}
+
+// audited は変更履歴を記録するクエリかどうかを返します。
+func audited(db *gorm.DB) bool {
+	return db.Error == nil && !db.DryRun && db.Statement.Schema != nil &&
+		slices.Contains(AuditEntities, db.Statement.Schema.Table)
+}
+
+// afterCreate は作成した行（一括作成の場合は全ての行）の変更履歴を記録します。
+func (p *AuditPlugin) afterCreate(db *gorm.DB) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	var rows []map[string]any
+	forEachRow(db.Statement.ReflectValue, func(row reflect.Value) {
+		rows = append(rows, auditSnapshot(db.Statement.Context, db.Statement.Schema, row))
+	})
+	logs := make([]model.AuditLog, 0, len(rows))
+	for _, after := range rows {
+		logs = append(logs, model.AuditLog{Action: model.AuditActionCreate, Changes: auditChanges(nil, after)})
+	}
+	p.record(db, rows, logs)
+}
+
+// loadBefore は更新・削除の対象の行を読み込みます（クエリと同じ条件、論理削除の扱いも同じ）。
+func (p *AuditPlugin) loadBefore(db *gorm.DB) {
+	if !audited(db) {
+		return
+	}
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Model(reflect.New(stmt.Schema.ModelType).Interface())
+	if stmt.Unscoped {
+		query = query.Unscoped()
+	}
+
+	conditions := false
+	if where, ok := stmt.Clauses["WHERE"]; ok {
+		if expression, ok := where.Expression.(clause.Where); ok && len(expression.Exprs) > 0 {
+			query = query.Clauses(expression)
+			conditions = true
+		}
+	}
+	// Model に主キーを設定した更新・削除（Updates(record)・Delete(&record)）は GORM が主キーの条件を追加する
+	if stmt.ReflectValue.Kind() == reflect.Struct {
+		for _, field := range stmt.Schema.PrimaryFields {
+			if value, zero := field.ValueOf(stmt.Context, stmt.ReflectValue); !zero {
+				query = query.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: value})
+				conditions = true
+			}
+		}
+	}
+	// 条件のない更新・削除は GORM が実行しない（ErrMissingWhereClause）
+	if !conditions {
+		return
+	}
+
+	rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to load rows for the audit log: %w", err))
+		return
+	}
+	db.InstanceSet(auditBeforeKey, rows.Elem())
+}
+
+// afterUpdate は更新した行を読み込み直し、変更した列の変更履歴を記録します（変更のない行は記録しない）。
+func (p *AuditPlugin) afterUpdate(db *gorm.DB) {
+	befores, ids, ok := p.loaded(db)
+	if !ok {
+		return
+	}
+	stmt := db.Statement
+	rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := db.Session(&gorm.Session{NewDB: true}).Unscoped().
+		Where(clause.IN{Column: clause.PrimaryColumn, Values: ids}).
+		Find(rows.Interface()).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to load updated rows for the audit log: %w", err))
+		return
+	}
+	afters := make(map[any]map[string]any)
+	forEachRow(rows.Elem(), func(row reflect.Value) {
+		snapshot := auditSnapshot(stmt.Context, stmt.Schema, row)
+		afters[snapshot["id"]] = snapshot
+	})
+
+	var snapshots []map[string]any
+	var logs []model.AuditLog
+	for _, before := range befores {
+		after, ok := afters[before["id"]]
+		if !ok {
+			continue
+		}
+		changes := auditChanges(before, after)
+		if len(changes) == 0 {
+			continue
+		}
+		action := model.AuditActionUpdate
+		if change, ok := changes["deleted_at"]; ok && change.Before != nil && change.After == nil {
+			action = model.AuditActionRestore
+		}
+		snapshots = append(snapshots, after)
+		logs = append(logs, model.AuditLog{Action: action, Changes: changes})
+	}
+	p.record(db, snapshots, logs)
+}
+
+// afterDelete は削除した行の変更履歴を記録します（変更前の全ての列）。
+func (p *AuditPlugin) afterDelete(db *gorm.DB) {
+	befores, _, ok := p.loaded(db)
+	if !ok {
+		return
+	}
+	logs := make([]model.AuditLog, 0, len(befores))
+	for _, before := range befores {
+		logs = append(logs, model.AuditLog{Action: model.AuditActionDelete, Changes: auditChanges(before, nil)})
+	}
+	p.record(db, befores, logs)
+}
+
+// loaded は loadBefore で読み込んだ行と主キーを返します（クエリが失敗した・行を更新しなかった場合は false）。
+func (p *AuditPlugin) loaded(db *gorm.DB) ([]map[string]any, []any, bool) {
+	if !audited(db) || db.Statement.RowsAffected == 0 {
+		return nil, nil, false
+	}
+	value, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return nil, nil, false
+	}
+	var snapshots []map[string]any
+	var ids []any
+	forEachRow(value.(reflect.Value), func(row reflect.Value) {
+		snapshot := auditSnapshot(db.Statement.Context, db.Statement.Schema, row)
+		snapshots = append(snapshots, snapshot)
+		ids = append(ids, snapshot["id"])
+	})
+	return snapshots, ids, len(snapshots) > 0
+}
+
+// record は変更履歴に記録の ID・所有者・変更したユーザー・ルートを設定し、変更と同じ接続で作成します。
+func (p *AuditPlugin) record(db *gorm.DB, snapshots []map[string]any, logs []model.AuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+	stmt := db.Statement
+	session := db.Session(&gorm.Session{NewDB: true})
+	var actorID *uint
+	if userID, ok := ActorFromContext(stmt.Context); ok {
+		actorID = &userID
+	}
+	route := RouteFromContext(stmt.Context)
+	cropOwners := make(map[uint]uint)
+
+	for i := range logs {
+		snapshot := snapshots[i]
+		ownerID, err := auditOwner(session, stmt.Schema.Table, snapshot, cropOwners)
+		if err != nil {
+			db.AddError(fmt.Errorf("failed to resolve the owner for the audit log: %w", err))
+			return
+		}
+		logs[i].Entity = stmt.Schema.Table
+		logs[i].EntityID = toUint(snapshot["id"])
+		logs[i].OwnerID = ownerID
+		logs[i].ActorID = actorID
+		logs[i].Route = route
+	}
+	if err := session.CreateInBatches(&logs, auditBatchSize).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to record the audit log: %w", err))
+	}
+}
+
+// auditOwner は記録の所有者を返します（収穫記録は作物の所有者、cropOwners は作物ごとのキャッシュ）。
+func auditOwner(db *gorm.DB, table string, snapshot map[string]any, cropOwners map[uint]uint) (uint, error) {
+	if table != "harvests" {
+		return toUint(snapshot["user_id"]), nil
+	}
+	cropID := toUint(snapshot["crop_id"])
+	if ownerID, ok := cropOwners[cropID]; ok {
+		return ownerID, nil
+	}
+	var ownerID uint
+	if err := db.Unscoped().Model(&model.Crop{}).Where("id = ?", cropID).Select("user_id").Scan(&ownerID).Error; err != nil {
+		return 0, err
+	}
+	cropOwners[cropID] = ownerID
+	return ownerID, nil
+}
+
+// auditSnapshot は行の列ごとの値を返します（リレーションと auditIgnoredColumns の列を除く）。
+func auditSnapshot(ctx context.Context, s *schema.Schema, row reflect.Value) map[string]any {
+	snapshot := make(map[string]any, len(s.DBNames))
+	for _, field := range s.Fields {
+		if field.DBName == "" || auditIgnoredColumns[field.DBName] {
+			continue
+		}
+		value, _ := field.ValueOf(ctx, row)
+		if deletedAt, ok := value.(gorm.DeletedAt); ok {
+			value = nil
+			if deletedAt.Valid {
+				value = deletedAt.Time
+			}
+		}
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+			value = nil
+		}
+		snapshot[field.DBName] = value
+	}
+	return snapshot
+}
+
+// auditChanges は変更前・変更後の値が異なる列を返します（作成は before、削除は after が nil）。
+// 値は JSON の表現で比較します（読み込んだ時刻の位置情報の違いなどを変更としない）。
+func auditChanges(before, after map[string]any) map[string]model.AuditChange {
+	changes := make(map[string]model.AuditChange)
+	for column := range mergeKeys(before, after) {
+		b, a := before[column], after[column]
+		if before != nil && after != nil {
+			encodedBefore, _ := json.Marshal(b)
+			encodedAfter, _ := json.Marshal(a)
+			if string(encodedBefore) == string(encodedAfter) {
+				continue
+			}
+		}
+		changes[column] = model.AuditChange{Before: b, After: a}
+	}
+	return changes
+}
+
+// mergeKeys は2つの行の列名を返します。
+func mergeKeys(before, after map[string]any) map[string]struct{} {
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for column := range before {
+		keys[column] = struct{}{}
+	}
+	for column := range after {
+		keys[column] = struct{}{}
+	}
+	return keys
+}
+
+// forEachRow は構造体または構造体のスライスの各行に fn を実行します。
+func forEachRow(value reflect.Value, fn func(row reflect.Value)) {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			fn(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		fn(value)
+	}
+}
+
+// toUint は ID の列の値を uint に変換します（NULL の場合は 0）。
+func toUint(value any) uint {
+	switch v := value.(type) {
+	case uint:
+		return v
+	case *uint:
+		if v != nil {
+			return *v
+		}
+	}
+	return 0
+}
+
+// =============================================================================
+// AuditLogRepository Implementation - 変更履歴リポジトリ
+// =============================================================================
+
+// auditLogRepository implements AuditLogRepository
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// ListByEntity retrieves one page of the audit log of a record (newest first, ownerID 0 means any owner)
+func (r *auditLogRepository) ListByEntity(ctx context.Context, entity string, entityID, ownerID uint, params pagination.Params) ([]model.AuditLog, error) {
+	query := GetDB(ctx, r.db).Where("entity = ? AND entity_id = ?", entity, entityID)
+	if ownerID != 0 {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	var logs []model.AuditLog
+	if err := paginateByID(query, params).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
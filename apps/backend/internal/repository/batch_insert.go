@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Batch Insert - 複数の記録の一括作成
+// =============================================================================
+// 多数の記録を1件ずつ作成すると記録ごとにデータベースとの往復が発生するため、
+// createBatchSize 件ずつの複数行の INSERT（GORM の CreateInBatches）で作成します。
+// SkipDefaultTransaction のため CreateInBatches は複数のバッチをトランザクションにしません。
+// トランザクション外で複数のバッチになる場合はトランザクションで作成し、途中のバッチの失敗で一部だけ作成されないようにします。
+
+// createBatchSize は1回の INSERT で作成する記録の数です。
+// PostgreSQL のプレースホルダーの上限（65535）に対し、列の多いタスク・作物でも十分に小さい値です。
+const createBatchSize = 500
+
+// createInBatches は records を createBatchSize 件ずつ作成します（空の場合は何もしない）。
+// 作成した記録のIDは records の各要素に設定します。
+func createInBatches[T any](ctx context.Context, db *gorm.DB, records []T) error {
+	if len(records) == 0 {
+		return nil
+	}
+	conn := GetDB(ctx, db)
+	if TxFromContext(ctx) != nil || len(records) <= createBatchSize {
+		return conn.CreateInBatches(&records, createBatchSize).Error
+	}
+	return conn.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&records, createBatchSize).Error
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/filter"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 	"gorm.io/gorm"
 )
 
@@ -17,24 +19,45 @@ type cropRepository struct {
 	db *gorm.DB
 }
 
-// Create creates a new crop
+// Create creates a new crop (an organization's crop in an organization scope)
 func (r *cropRepository) Create(ctx context.Context, crop *model.Crop) error {
+	assignOrganization(ctx, &crop.OrganizationID)
 	return GetDB(ctx, r.db).Create(crop).Error
 }
 
-// GetByID retrieves a crop by ID
+// CreateBatch creates multiple crops with batched inserts (in the organization of the scope)
+func (r *cropRepository) CreateBatch(ctx context.Context, crops []model.Crop) error {
+	for i := range crops {
+		assignOrganization(ctx, &crops[i].OrganizationID)
+	}
+	return createInBatches(ctx, r.db, crops)
+}
+
+// GetByID retrieves a crop by ID (crops outside the organization scope are not found)
 func (r *cropRepository) GetByID(ctx context.Context, id uint) (*model.Crop, error) {
 	var crop model.Crop
-	if err := GetDB(ctx, r.db).First(&crop, id).Error; err != nil {
+	if err := scopeRecordByOrganization(ctx, GetDB(ctx, r.db)).First(&crop, id).Error; err != nil {
 		return nil, err
 	}
 	return &crop, nil
 }
 
-// GetByUserID retrieves all crops for a user
+// GetByIDs retrieves crops by IDs in one query (missing IDs and crops outside the organization scope are omitted)
+func (r *cropRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Crop, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var crops []model.Crop
+	if err := scopeRecordByOrganization(ctx, GetDB(ctx, r.db)).Where("id IN ?", ids).Find(&crops).Error; err != nil {
+		return nil, err
+	}
+	return crops, nil
+}
+
+// GetByUserID retrieves all crops for a user (all of the organization's crops in an organization scope)
 func (r *cropRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Crop, error) {
 	var crops []model.Crop
-	if err := GetDB(ctx, r.db).Where("user_id = ?", userID).Order("planted_date DESC").Find(&crops).Error; err != nil {
+	if err := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID).Order("planted_date DESC").Find(&crops).Error; err != nil {
 		return nil, err
 	}
 	return crops, nil
@@ -43,32 +66,47 @@ func (r *cropRepository) GetByUserID(ctx context.Context, userID uint) ([]model.
 // GetByUserIDAndStatus retrieves crops for a user with a specific status
 func (r *cropRepository) GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Crop, error) {
 	var crops []model.Crop
-	if err := GetDB(ctx, r.db).Where("user_id = ? AND status = ?", userID, status).Order("planted_date DESC").Find(&crops).Error; err != nil {
+	if err := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID).Where("status = ?", status).Order("planted_date DESC").Find(&crops).Error; err != nil {
+		return nil, err
+	}
+	return crops, nil
+}
+
+// ListByUserIDPaginated retrieves one page of crops for a user (newest first, filtered by spec)
+func (r *cropRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Crop, error) {
+	query := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID)
+	query = applyFilter(query, spec)
+	var crops []model.Crop
+	if err := paginateByID(query, params).Find(&crops).Error; err != nil {
 		return nil, err
 	}
 	return crops, nil
 }
 
-// GetUpcomingHarvests は指定日数以内に収穫予定の作物を取得します（通知処理用）
+// GetUpcomingHarvests は指定したユーザーの収穫予定日が [from, to) の栽培中の作物を取得します（通知処理用）
 // ユーザー情報を含めて取得し、収穫リマインダー通知に使用します
+// ユーザーごとの「今日」はタイムゾーンで異なるため、サービス層で広めの範囲を指定して絞り込みます
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
-//   - daysAhead: 今日から何日後までを対象とするか
+//   - userIDs: 対象のユーザー（定期通知のページ）
+//   - from: 対象期間の開始（含む）
+//   - to: 対象期間の終了（含まない）
 //
 // 戻り値:
-//   - []model.Crop: 収穫予定の作物一覧（ユーザー情報を含む）
+//   - []model.Crop: 収穫予定の作物一覧（ユーザー情報と通知設定マトリクスを含む）
 //   - error: 取得に失敗した場合のエラー
-func (r *cropRepository) GetUpcomingHarvests(ctx context.Context, daysAhead int) ([]model.Crop, error) {
+func (r *cropRepository) GetUpcomingHarvests(ctx context.Context, userIDs []uint, from, to time.Time) ([]model.Crop, error) {
 	var crops []model.Crop
-	today := time.Now().Truncate(24 * time.Hour)
-	targetDate := today.AddDate(0, 0, daysAhead)
+	if len(userIDs) == 0 {
+		return crops, nil
+	}
 
-	// status が "growing" で、expected_harvest_date が今日から daysAhead 日後の作物を取得
 	if err := GetDB(ctx, r.db).
 		Preload("User").
-		Where("status = ? AND expected_harvest_date >= ? AND expected_harvest_date <= ?",
-			"growing", today, targetDate).
+		Preload("User.NotificationPreferences").
+		Where("user_id IN ? AND status = ? AND expected_harvest_date >= ? AND expected_harvest_date < ?",
+			userIDs, "growing", from, to).
 		Order("user_id ASC, expected_harvest_date ASC").
 		Find(&crops).Error; err != nil {
 		return nil, err
@@ -76,14 +114,126 @@ func (r *cropRepository) GetUpcomingHarvests(ctx context.Context, daysAhead int)
 	return crops, nil
 }
 
-// Update updates a crop
+// GetHarvestReadyCandidates は指定したユーザーの収穫可能通知の対象となる作物を取得します（通知処理用）
+// ステータスが ready_to_harvest の作物と、収穫予定日が before より前の栽培中の作物のうち、
+// 収穫可能通知をまだ送っていないものを対象とします
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userIDs: 対象のユーザー（定期通知のページ）
+//   - before: 収穫予定日の上限（含まない）
+//
+// 戻り値:
+//   - []model.Crop: 対象の作物一覧（ユーザー情報と通知設定マトリクスを含む）
+//   - error: 取得に失敗した場合のエラー
+func (r *cropRepository) GetHarvestReadyCandidates(ctx context.Context, userIDs []uint, before time.Time) ([]model.Crop, error) {
+	var crops []model.Crop
+	if len(userIDs) == 0 {
+		return crops, nil
+	}
+
+	if err := GetDB(ctx, r.db).
+		Preload("User").
+		Preload("User.NotificationPreferences").
+		Where("user_id IN ? AND harvest_ready_notified_at IS NULL", userIDs).
+		Where("status = ? OR (status IN ? AND expected_harvest_date < ?)",
+			"ready_to_harvest", []string{"planted", "growing"}, before).
+		Order("user_id ASC, expected_harvest_date ASC").
+		Find(&crops).Error; err != nil {
+		return nil, err
+	}
+	return crops, nil
+}
+
+// MarkHarvestReadyNotified は作物の収穫可能通知の送信日時を記録します
+func (r *cropRepository) MarkHarvestReadyNotified(ctx context.Context, ids []uint, notifiedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Model(&model.Crop{}).
+		Where("id IN ?", ids).
+		UpdateColumn("harvest_ready_notified_at", notifiedAt).Error
+}
+
+// GetFinishedPlantedBetween は植え付け日が [from, to) の収穫済み・失敗の作物を取得します（シーズンの締め用）
+// アーカイブ済みの作物も含め、シーズンの締めを再実行しても同じ集計になるようにします
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - from: 植え付け日の開始（含む）
+//   - to: 植え付け日の終了（含まない）
+//
+// 戻り値:
+//   - []model.Crop: 対象の作物一覧（ユーザー情報と通知設定マトリクスを含む）
+//   - error: 取得に失敗した場合のエラー
+func (r *cropRepository) GetFinishedPlantedBetween(ctx context.Context, from, to time.Time) ([]model.Crop, error) {
+	var crops []model.Crop
+
+	if err := GetDB(ctx, r.db).
+		Preload("User").
+		Preload("User.NotificationPreferences").
+		Where("status IN ? AND planted_date >= ? AND planted_date < ?",
+			[]string{"harvested", "failed"}, from, to).
+		Order("user_id ASC, id ASC").
+		Find(&crops).Error; err != nil {
+		return nil, err
+	}
+	return crops, nil
+}
+
+// Archive は作物のアーカイブ日時を記録します（アーカイブ済みの作物は変更しません）
+func (r *cropRepository) Archive(ctx context.Context, ids []uint, archivedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Model(&model.Crop{}).
+		Where("id IN ? AND archived_at IS NULL", ids).
+		UpdateColumn("archived_at", archivedAt).Error
+}
+
+// Update updates a crop if its version is unchanged (optimistic lock, ErrVersionConflict otherwise)
 func (r *cropRepository) Update(ctx context.Context, crop *model.Crop) error {
-	return GetDB(ctx, r.db).Save(crop).Error
+	return updateVersioned(GetDB(ctx, r.db), crop, &crop.Version)
+}
+
+// CountByOrganizationID counts the crops of an organization (for the organization quota)
+func (r *cropRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Model(&model.Crop{}).Where("organization_id = ?", organizationID).Count(&count).Error
+	return count, err
 }
 
-// Delete soft deletes a crop
+// Delete soft deletes a crop (crops outside the organization scope are not deleted)
 func (r *cropRepository) Delete(ctx context.Context, id uint) error {
-	return GetDB(ctx, r.db).Delete(&model.Crop{}, id).Error
+	return scopeRecordByOrganization(ctx, GetDB(ctx, r.db)).Delete(&model.Crop{}, id).Error
+}
+
+// GetDeletedByUserID retrieves the user's crops soft deleted after deletedAfter (the organization's crops in an organization scope)
+func (r *cropRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Crop, error) {
+	var crops []model.Crop
+	if err := scopeListByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at"), userID).
+		Where("deleted_at > ?", deletedAfter).
+		Order("deleted_at DESC").
+		Find(&crops).Error; err != nil {
+		return nil, err
+	}
+	return crops, nil
+}
+
+// GetDeletedByID retrieves a soft deleted crop by ID (crops outside the organization scope are not found)
+func (r *cropRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Crop, error) {
+	var crop model.Crop
+	if err := scopeRecordByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at")).First(&crop, id).Error; err != nil {
+		return nil, err
+	}
+	return &crop, nil
+}
+
+// Restore restores a soft deleted crop (crops outside the organization scope are not restored)
+func (r *cropRepository) Restore(ctx context.Context, id uint) error {
+	return scopeRecordByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at")).Model(&model.Crop{}).
+		Where("id = ?", id).
+		Updates(restoreColumns()).Error
 }
 
 // =============================================================================
@@ -100,6 +250,11 @@ func (r *growthRecordRepository) Create(ctx context.Context, record *model.Growt
 	return GetDB(ctx, r.db).Create(record).Error
 }
 
+// CreateBatch creates multiple growth records with batched inserts
+func (r *growthRecordRepository) CreateBatch(ctx context.Context, records []model.GrowthRecord) error {
+	return createInBatches(ctx, r.db, records)
+}
+
 // GetByID retrieves a growth record by ID
 func (r *growthRecordRepository) GetByID(ctx context.Context, id uint) (*model.GrowthRecord, error) {
 	var record model.GrowthRecord
@@ -118,6 +273,18 @@ func (r *growthRecordRepository) GetByCropID(ctx context.Context, cropID uint) (
 	return records, nil
 }
 
+// GetByCropIDs retrieves growth records for multiple crops in a single query
+func (r *growthRecordRepository) GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.GrowthRecord, error) {
+	var records []model.GrowthRecord
+	if len(cropIDs) == 0 {
+		return records, nil
+	}
+	if err := GetDB(ctx, r.db).Where("crop_id IN ?", cropIDs).Order("record_date DESC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
 // Delete soft deletes a growth record
 func (r *growthRecordRepository) Delete(ctx context.Context, id uint) error {
 	return GetDB(ctx, r.db).Delete(&model.GrowthRecord{}, id).Error
@@ -128,6 +295,13 @@ func (r *growthRecordRepository) DeleteByCropID(ctx context.Context, cropID uint
 	return GetDB(ctx, r.db).Where("crop_id = ?", cropID).Delete(&model.GrowthRecord{}).Error
 }
 
+// RestoreByCropID restores the growth records of a crop soft deleted at or after deletedFrom
+func (r *growthRecordRepository) RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) error {
+	return unscopedDeleted(GetDB(ctx, r.db), "deleted_at").Model(&model.GrowthRecord{}).
+		Where("crop_id = ? AND deleted_at >= ?", cropID, deletedFrom).
+		Updates(restoreColumns()).Error
+}
+
 // =============================================================================
 // HarvestRepository Implementation - 収穫記録リポジトリ
 // =============================================================================
@@ -142,6 +316,11 @@ func (r *harvestRepository) Create(ctx context.Context, harvest *model.Harvest)
 	return GetDB(ctx, r.db).Create(harvest).Error
 }
 
+// CreateBatch creates multiple harvest records with batched inserts
+func (r *harvestRepository) CreateBatch(ctx context.Context, harvests []model.Harvest) error {
+	return createInBatches(ctx, r.db, harvests)
+}
+
 // GetByID retrieves a harvest record by ID
 func (r *harvestRepository) GetByID(ctx context.Context, id uint) (*model.Harvest, error) {
 	var harvest model.Harvest
@@ -160,6 +339,27 @@ func (r *harvestRepository) GetByCropID(ctx context.Context, cropID uint) ([]mod
 	return harvests, nil
 }
 
+// ListByCropIDPaginated retrieves one page of harvest records for a crop (newest first)
+func (r *harvestRepository) ListByCropIDPaginated(ctx context.Context, cropID uint, params pagination.Params) ([]model.Harvest, error) {
+	var harvests []model.Harvest
+	if err := paginateByID(GetDB(ctx, r.db).Where("crop_id = ?", cropID), params).Find(&harvests).Error; err != nil {
+		return nil, err
+	}
+	return harvests, nil
+}
+
+// GetByCropIDs retrieves harvest records for multiple crops in a single query
+func (r *harvestRepository) GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.Harvest, error) {
+	var harvests []model.Harvest
+	if len(cropIDs) == 0 {
+		return harvests, nil
+	}
+	if err := GetDB(ctx, r.db).Where("crop_id IN ?", cropIDs).Order("harvest_date DESC").Find(&harvests).Error; err != nil {
+		return nil, err
+	}
+	return harvests, nil
+}
+
 // Delete soft deletes a harvest record
 func (r *harvestRepository) Delete(ctx context.Context, id uint) error {
 	return GetDB(ctx, r.db).Delete(&model.Harvest{}, id).Error
@@ -170,6 +370,106 @@ func (r *harvestRepository) DeleteByCropID(ctx context.Context, cropID uint) err
 	return GetDB(ctx, r.db).Where("crop_id = ?", cropID).Delete(&model.Harvest{}).Error
 }
 
+// GetDeletedByUserID retrieves soft deleted harvest records of the user's crops (crops that are not deleted)
+// 作物と一緒に削除した収穫記録は作物の復元で復元するため含めません。
+func (r *harvestRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Harvest, error) {
+	query := unscopedDeleted(GetDB(ctx, r.db), "harvests.deleted_at").
+		Joins("JOIN crops ON crops.id = harvests.crop_id AND crops.deleted_at IS NULL").
+		Where("harvests.deleted_at > ?", deletedAfter)
+
+	var harvests []model.Harvest
+	if err := scopeListByOrganization(ctx, query, userID).
+		Order("harvests.deleted_at DESC").
+		Find(&harvests).Error; err != nil {
+		return nil, err
+	}
+	return harvests, nil
+}
+
+// GetDeletedByID retrieves a soft deleted harvest record by ID
+func (r *harvestRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Harvest, error) {
+	var harvest model.Harvest
+	if err := unscopedDeleted(GetDB(ctx, r.db), "deleted_at").First(&harvest, id).Error; err != nil {
+		return nil, err
+	}
+	return &harvest, nil
+}
+
+// Restore restores a soft deleted harvest record
+func (r *harvestRepository) Restore(ctx context.Context, id uint) error {
+	return unscopedDeleted(GetDB(ctx, r.db), "deleted_at").Model(&model.Harvest{}).
+		Where("id = ?", id).
+		Updates(restoreColumns()).Error
+}
+
+// RestoreByCropID restores the harvest records of a crop soft deleted at or after deletedFrom and returns their IDs
+func (r *harvestRepository) RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) ([]uint, error) {
+	var ids []uint
+	if err := unscopedDeleted(GetDB(ctx, r.db), "deleted_at").Model(&model.Harvest{}).
+		Where("crop_id = ? AND deleted_at >= ?", cropID, deletedFrom).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if err := GetDB(ctx, r.db).Unscoped().Model(&model.Harvest{}).
+		Where("id IN ?", ids).
+		Updates(restoreColumns()).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetBenchmarkYields はベンチマークにオプトインしたユーザーごとに、
+// 指定作物の総収穫量（kg換算）と栽培区画の合計面積を1クエリで集計します。
+// 区画に配置されていない作物は面積が不明なため集計対象外です。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - cropName: 作物名（大文字小文字を区別しない）
+//
+// 戻り値:
+//   - []UserCropYield: ユーザー単位の集計結果
+//   - error: 取得に失敗した場合のエラー
+func (r *harvestRepository) GetBenchmarkYields(ctx context.Context, cropName string) ([]UserCropYield, error) {
+	var yields []UserCropYield
+	query := `
+WITH target_crops AS (
+	SELECT c.id, c.user_id
+	FROM crops c
+	JOIN users u ON u.id = c.user_id AND u.deleted_at IS NULL AND u.benchmark_opt_in = true
+	WHERE c.deleted_at IS NULL AND LOWER(c.name) = LOWER(?)
+	  AND EXISTS (SELECT 1 FROM plot_assignments pa WHERE pa.crop_id = c.id AND pa.deleted_at IS NULL)
+), areas AS (
+	SELECT cp.user_id, SUM(p.width * p.height) AS area_m2
+	FROM (
+		SELECT DISTINCT tc.user_id, pa.plot_id
+		FROM target_crops tc
+		JOIN plot_assignments pa ON pa.crop_id = tc.id AND pa.deleted_at IS NULL
+	) cp
+	JOIN plots p ON p.id = cp.plot_id AND p.deleted_at IS NULL
+	GROUP BY cp.user_id
+), totals AS (
+	SELECT tc.user_id, SUM(CASE h.quantity_unit
+		WHEN 'g' THEN h.quantity / 1000.0
+		WHEN 'pieces' THEN h.quantity * 0.1
+		ELSE h.quantity END) AS total_kg
+	FROM harvests h
+	JOIN target_crops tc ON tc.id = h.crop_id
+	WHERE h.deleted_at IS NULL
+	GROUP BY tc.user_id
+)
+SELECT t.user_id, t.total_kg, a.area_m2
+FROM totals t
+JOIN areas a ON a.user_id = t.user_id
+WHERE a.area_m2 > 0`
+	if err := GetDB(ctx, r.db).Raw(query, cropName).Scan(&yields).Error; err != nil {
+		return nil, err
+	}
+	return yields, nil
+}
+
 // GetByUserIDWithDateRange はユーザーの収穫記録を日付範囲でフィルタして取得します。
 // Analytics用のクエリで、cropsテーブルとJOINしてユーザーの収穫データを取得します。
 // startDate/endDateがnilの場合は、その方向の制限はありません。
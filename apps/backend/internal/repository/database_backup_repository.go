@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// DatabaseBackupRepository Implementation - データベースのバックアップの履歴リポジトリ
+// =============================================================================
+
+// databaseBackupRepository implements DatabaseBackupRepository
+type databaseBackupRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しいバックアップの履歴を保存します。
+func (r *databaseBackupRepository) Create(ctx context.Context, backup *model.DatabaseBackup) error {
+	return GetDB(ctx, r.db).Create(backup).Error
+}
+
+// GetByID はIDでバックアップの履歴を取得します。
+func (r *databaseBackupRepository) GetByID(ctx context.Context, id uint) (*model.DatabaseBackup, error) {
+	var backup model.DatabaseBackup
+	if err := GetDB(ctx, r.db).First(&backup, id).Error; err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// Update はバックアップの履歴を更新します（バックアップの作成結果の記録）。
+func (r *databaseBackupRepository) Update(ctx context.Context, backup *model.DatabaseBackup) error {
+	return GetDB(ctx, r.db).Save(backup).Error
+}
+
+// ListPaginated はバックアップの履歴を新しい順に1ページ分取得します。
+func (r *databaseBackupRepository) ListPaginated(ctx context.Context, params pagination.Params) ([]model.DatabaseBackup, error) {
+	var backups []model.DatabaseBackup
+	if err := paginateByID(GetDB(ctx, r.db), params).Find(&backups).Error; err != nil {
+		return nil, err
+	}
+	return backups, nil
+}
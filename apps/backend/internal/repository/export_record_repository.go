@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// ExportRecordRepository Implementation - エクスポート履歴リポジトリ
+// =============================================================================
+
+// exportRecordRepository implements ExportRecordRepository
+type exportRecordRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しいエクスポート履歴を保存します。
+func (r *exportRecordRepository) Create(ctx context.Context, record *model.ExportRecord) error {
+	return GetDB(ctx, r.db).Create(record).Error
+}
+
+// GetByID はIDでエクスポート履歴を取得します。
+func (r *exportRecordRepository) GetByID(ctx context.Context, id uint) (*model.ExportRecord, error) {
+	var record model.ExportRecord
+	if err := GetDB(ctx, r.db).First(&record, id).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Update はエクスポート履歴を更新します（非同期エクスポートの生成結果の記録）。
+func (r *exportRecordRepository) Update(ctx context.Context, record *model.ExportRecord) error {
+	return GetDB(ctx, r.db).Save(record).Error
+}
+
+// GetByUserID はユーザーのエクスポート履歴を新しい順に取得します。
+func (r *exportRecordRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]model.ExportRecord, error) {
+	var records []model.ExportRecord
+	query := GetDB(ctx, r.db).Where("user_id = ?", userID).Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetWithFilesCreatedBefore は before より前に作成され、S3にファイルが残っているエクスポート履歴を古い順に取得します。
+func (r *exportRecordRepository) GetWithFilesCreatedBefore(ctx context.Context, before time.Time, limit int) ([]model.ExportRecord, error) {
+	var records []model.ExportRecord
+	query := GetDB(ctx, r.db).Where("s3_key <> '' AND created_at < ?", before).Order("created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CountWithFilesCreatedBefore は before より前に作成され、S3にファイルが残っているエクスポート履歴の件数を返します。
+func (r *exportRecordRepository) CountWithFilesCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	if err := GetDB(ctx, r.db).Model(&model.ExportRecord{}).
+		Where("s3_key <> '' AND created_at < ?", before).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetStorageTotalByUserID はユーザーの保存先にファイルが残っているエクスポートの件数と容量の合計を返します。
+func (r *exportRecordRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	if err := GetDB(ctx, r.db).Model(&model.ExportRecord{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(file_size_bytes), 0) AS bytes").
+		Where("user_id = ? AND s3_key <> ''", userID).
+		Scan(&total).Error; err != nil {
+		return StorageTotal{}, err
+	}
+	return total, nil
+}
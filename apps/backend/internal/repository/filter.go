@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/secure-scorecard/backend/internal/filter"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// =============================================================================
+// Filter - 絞り込みの条件の GORM の条件への変換
+// =============================================================================
+
+// likeEscaper は contains の値の LIKE のワイルドカードをエスケープします。
+// バックスラッシュのエスケープは MySQL の既定と PostgreSQL で扱いが異なるため、全ドライバーで同じ ESCAPE '!' を使用します。
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// applyFilter は絞り込みの条件をクエリの WHERE に追加します。
+// 列名は filter.Schema の固定の列名（filter.Parse の Condition.Field）で、値はすべてプレースホルダーで渡します。
+func applyFilter(query *gorm.DB, spec filter.Spec) *gorm.DB {
+	for _, condition := range spec {
+		column := condition.Field
+		switch condition.Operator {
+		case filter.Eq:
+			query = query.Where(fmt.Sprintf("%s = ?", column), condition.Value)
+		case filter.Ne:
+			query = query.Where(fmt.Sprintf("%s <> ?", column), condition.Value)
+		case filter.Gt:
+			query = query.Where(fmt.Sprintf("%s > ?", column), condition.Value)
+		case filter.Gte:
+			query = query.Where(fmt.Sprintf("%s >= ?", column), condition.Value)
+		case filter.Lt:
+			query = query.Where(fmt.Sprintf("%s < ?", column), condition.Value)
+		case filter.Lte:
+			query = query.Where(fmt.Sprintf("%s <= ?", column), condition.Value)
+		case filter.In:
+			query = query.Where(fmt.Sprintf("%s IN ?", column), condition.Value)
+		case filter.Contains:
+			pattern, _ := condition.Value.(string)
+			query = query.Where(fmt.Sprintf("LOWER(%s) LIKE ? ESCAPE '!'", column), "%"+likeEscaper.Replace(strings.ToLower(pattern))+"%")
+		}
+	}
+	return query
+}
+
+// filterSchemaCache はモックのリポジトリでモデルの列を解決する GORM のスキーマのキャッシュです。
+var filterSchemaCache sync.Map
+
+// matchesFilter はモデルの行が絞り込みの条件を満たすかどうかを返します（モックのリポジトリ用）。
+// 列名は GORM のスキーマ（gorm タグ・命名規則）でフィールドに解決します。
+func matchesFilter(spec filter.Spec, row any) bool {
+	if len(spec) == 0 {
+		return true
+	}
+	s, err := schema.Parse(row, &filterSchemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return false
+	}
+	value := reflect.Indirect(reflect.ValueOf(row))
+	return spec.Match(func(column string) (any, bool) {
+		field := s.LookUpField(column)
+		if field == nil {
+			return nil, false
+		}
+		v, _ := field.ValueOf(context.Background(), value)
+		return v, true
+	})
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// GardenMemberRepository Implementation - 共有の庭のメンバーリポジトリ
+// =============================================================================
+
+// gardenMemberRepository implements GardenMemberRepository
+type gardenMemberRepository struct {
+	db *gorm.DB
+}
+
+// Create は庭のメンバーを追加します。
+func (r *gardenMemberRepository) Create(ctx context.Context, member *model.GardenMember) error {
+	return GetDB(ctx, r.db).Create(member).Error
+}
+
+// GetByGardenID は庭のメンバーをユーザー情報付きで追加順に取得します。
+func (r *gardenMemberRepository) GetByGardenID(ctx context.Context, gardenID uint) ([]model.GardenMember, error) {
+	var members []model.GardenMember
+	if err := GetDB(ctx, r.db).
+		Preload("User").
+		Where("garden_id = ?", gardenID).
+		Order("created_at ASC, id ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Get は庭のユーザーのメンバーを取得します。
+func (r *gardenMemberRepository) Get(ctx context.Context, gardenID, userID uint) (*model.GardenMember, error) {
+	var member model.GardenMember
+	if err := GetDB(ctx, r.db).
+		Where("garden_id = ? AND user_id = ?", gardenID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetSharedGardens はユーザーがメンバーとして参加している庭を取得します。
+func (r *gardenMemberRepository) GetSharedGardens(ctx context.Context, userID uint) ([]model.Garden, error) {
+	var gardens []model.Garden
+	if err := GetDB(ctx, r.db).
+		Joins("JOIN garden_members ON garden_members.garden_id = gardens.id AND garden_members.deleted_at IS NULL").
+		Where("garden_members.user_id = ?", userID).
+		Order("gardens.id ASC").
+		Find(&gardens).Error; err != nil {
+		return nil, err
+	}
+	return gardens, nil
+}
+
+// Delete はメンバーを物理削除します。
+func (r *gardenMemberRepository) Delete(ctx context.Context, gardenID, userID uint) error {
+	return GetDB(ctx, r.db).
+		Unscoped().
+		Where("garden_id = ? AND user_id = ?", gardenID, userID).
+		Delete(&model.GardenMember{}).Error
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/filter"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 )
 
 // UserRepository defines the interface for user data access
@@ -13,6 +15,8 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uint) (*model.User, error)
 	GetByFirebaseUID(ctx context.Context, uid string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	// GetIDsAfter はIDが afterID より大きいユーザーのIDを昇順に最大 limit 件取得します（キーセットページネーション用）
+	GetIDsAfter(ctx context.Context, afterID uint, limit int) ([]uint, error)
 	Update(ctx context.Context, user *model.User) error
 	Delete(ctx context.Context, id uint) error
 }
@@ -54,53 +58,128 @@ type TokenBlacklistRepository interface {
 // TaskRepository defines the interface for task data access
 type TaskRepository interface {
 	Create(ctx context.Context, task *model.Task) error
+	// CreateBatch は複数のタスクを数百件ずつの INSERT でまとめて作成します（IDは各要素に設定、1件でも失敗した場合はすべて作成しない）
+	CreateBatch(ctx context.Context, tasks []model.Task) error
 	GetByID(ctx context.Context, id uint) (*model.Task, error)
 	GetByUserID(ctx context.Context, userID uint) ([]model.Task, error)
 	GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Task, error)
-	GetTodayTasks(ctx context.Context, userID uint) ([]model.Task, error)
-	GetOverdueTasks(ctx context.Context, userID uint) ([]model.Task, error)
-	// GetAllOverdueTasks はシステム全体の期限切れタスクを取得します（通知処理用）
-	GetAllOverdueTasks(ctx context.Context) ([]model.Task, error)
-	// GetAllTodayTasks はシステム全体の今日が期限のタスクを取得します（通知処理用）
-	GetAllTodayTasks(ctx context.Context) ([]model.Task, error)
+	// ListByUserIDPaginated はユーザーのタスクをIDの降順でカーソルより前から Limit+1 件まで取得します（status が空の場合は全ステータス）
+	ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Task, error)
+	// ListByUserIDByDueDate はユーザーのタスクを (期限日, ID) の昇順でカーソルより後から Limit+1 件まで取得します（status が空の場合は全ステータス）
+	ListByUserIDByDueDate(ctx context.Context, userID uint, spec filter.Spec, params pagination.KeyParams) ([]model.Task, error)
+	// GetTodayTasks は期限が [dayStart, dayEnd) の未完了タスクを取得します（境界はユーザーのタイムゾーンで計算）
+	GetTodayTasks(ctx context.Context, userID uint, dayStart, dayEnd time.Time) ([]model.Task, error)
+	// GetOverdueTasks は期限が dayStart より前の未完了タスクを取得します
+	GetOverdueTasks(ctx context.Context, userID uint, dayStart time.Time) ([]model.Task, error)
+	// GetPendingTasksDueBefore は指定したユーザーの期限が before より前の未完了タスクを取得します（通知処理用、ユーザー情報付き）
+	GetPendingTasksDueBefore(ctx context.Context, userIDs []uint, before time.Time) ([]model.Task, error)
+	// Update はタスクの Version が読み込んだ時点のままの場合のみ更新し、Version を1つ進めます（一致しない場合は ErrVersionConflict）
 	Update(ctx context.Context, task *model.Task) error
 	Delete(ctx context.Context, id uint) error
+	// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除されたタスクを削除日時の新しい順に取得します（ゴミ箱）
+	GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Task, error)
+	// GetDeletedByID は論理削除されたタスクを取得します（削除されていない場合は gorm.ErrRecordNotFound）
+	GetDeletedByID(ctx context.Context, id uint) (*model.Task, error)
+	// Restore は論理削除されたタスクを復元します（updated_at も更新）
+	Restore(ctx context.Context, id uint) error
 }
 
 // CropRepository defines the interface for crop data access
 // 作物の植え付けから収穫までのライフサイクルを管理します
 type CropRepository interface {
 	Create(ctx context.Context, crop *model.Crop) error
+	// CreateBatch は複数の作物を数百件ずつの INSERT でまとめて作成します（IDは各要素に設定、組織のスコープでは組織を設定、1件でも失敗した場合はすべて作成しない）
+	CreateBatch(ctx context.Context, crops []model.Crop) error
 	GetByID(ctx context.Context, id uint) (*model.Crop, error)
+	// GetByIDs は複数の作物を1回のクエリで取得します（見つからないIDは結果に含まない、順序は不定）
+	GetByIDs(ctx context.Context, ids []uint) ([]model.Crop, error)
 	GetByUserID(ctx context.Context, userID uint) ([]model.Crop, error)
 	GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Crop, error)
-	// GetUpcomingHarvests は指定日数以内に収穫予定の作物を取得します（通知処理用）
-	GetUpcomingHarvests(ctx context.Context, daysAhead int) ([]model.Crop, error)
+	// ListByUserIDPaginated はユーザーの作物をIDの降順でカーソルより前から Limit+1 件まで取得します（status が空の場合は全ステータス）
+	ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Crop, error)
+	// GetUpcomingHarvests は指定したユーザーの収穫予定日が [from, to) の栽培中の作物を取得します（通知処理用、ユーザー情報付き）
+	GetUpcomingHarvests(ctx context.Context, userIDs []uint, from, to time.Time) ([]model.Crop, error)
+	// GetHarvestReadyCandidates は指定したユーザーの収穫可能通知をまだ送っていない、収穫可能または収穫予定日が before より前の作物を取得します（ユーザー情報付き）
+	GetHarvestReadyCandidates(ctx context.Context, userIDs []uint, before time.Time) ([]model.Crop, error)
+	// MarkHarvestReadyNotified は作物の収穫可能通知の送信日時を記録します
+	MarkHarvestReadyNotified(ctx context.Context, ids []uint, notifiedAt time.Time) error
+	// GetFinishedPlantedBetween は植え付け日が [from, to) の収穫済み・失敗の作物を取得します（アーカイブ済みを含む、ユーザー情報付き）
+	GetFinishedPlantedBetween(ctx context.Context, from, to time.Time) ([]model.Crop, error)
+	// Archive は作物をアーカイブします（アーカイブ済みの作物は変更しません）
+	Archive(ctx context.Context, ids []uint, archivedAt time.Time) error
+	// CountByOrganizationID は組織の作物数を取得します（組織の上限の確認用）
+	CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error)
+	// Update は作物の Version が読み込んだ時点のままの場合のみ更新し、Version を1つ進めます（一致しない場合は ErrVersionConflict）
 	Update(ctx context.Context, crop *model.Crop) error
 	Delete(ctx context.Context, id uint) error
+	// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除された作物を削除日時の新しい順に取得します（ゴミ箱、組織のスコープでは組織の作物）
+	GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Crop, error)
+	// GetDeletedByID は論理削除された作物を取得します（削除されていない場合は gorm.ErrRecordNotFound）
+	GetDeletedByID(ctx context.Context, id uint) (*model.Crop, error)
+	// Restore は論理削除された作物を復元します（updated_at も更新）
+	Restore(ctx context.Context, id uint) error
 }
 
 // GrowthRecordRepository defines the interface for growth record data access
 // 作物の成長記録を管理します
 type GrowthRecordRepository interface {
 	Create(ctx context.Context, record *model.GrowthRecord) error
+	// CreateBatch は複数の成長記録を数百件ずつの INSERT でまとめて作成します（IDは各要素に設定、1件でも失敗した場合はすべて作成しない）
+	CreateBatch(ctx context.Context, records []model.GrowthRecord) error
 	GetByID(ctx context.Context, id uint) (*model.GrowthRecord, error)
 	GetByCropID(ctx context.Context, cropID uint) ([]model.GrowthRecord, error)
+	// GetByCropIDs は複数作物の成長記録を1クエリで取得します（DataLoader用）
+	GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.GrowthRecord, error)
 	Delete(ctx context.Context, id uint) error
 	DeleteByCropID(ctx context.Context, cropID uint) error
+	// RestoreByCropID は作物の deletedFrom 以降に論理削除された成長記録を復元します（作物の復元用）
+	RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) error
+}
+
+// UserCropYield はユーザー単位の作物収穫量の集計結果です（ベンチマーク用）
+type UserCropYield struct {
+	UserID  uint    `json:"user_id"`
+	TotalKg float64 `json:"total_kg"` // kg換算の総収穫量
+	AreaM2  float64 `json:"area_m2"`  // 作物を栽培した区画の合計面積
 }
 
 // HarvestRepository defines the interface for harvest data access
 // 収穫記録を管理します
 type HarvestRepository interface {
 	Create(ctx context.Context, harvest *model.Harvest) error
+	// CreateBatch は複数の収穫記録を数百件ずつの INSERT でまとめて作成します（IDは各要素に設定、1件でも失敗した場合はすべて作成しない）
+	CreateBatch(ctx context.Context, harvests []model.Harvest) error
 	GetByID(ctx context.Context, id uint) (*model.Harvest, error)
 	GetByCropID(ctx context.Context, cropID uint) ([]model.Harvest, error)
+	// ListByCropIDPaginated は作物の収穫記録をIDの降順でカーソルより前から Limit+1 件まで取得します
+	ListByCropIDPaginated(ctx context.Context, cropID uint, params pagination.Params) ([]model.Harvest, error)
+	// GetByCropIDs は複数作物の収穫記録を1クエリで取得します（DataLoader用）
+	GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.Harvest, error)
+	// GetBenchmarkYields はベンチマーク参加ユーザーごとの作物の収穫量と栽培面積を集計します
+	// 作物名は大文字小文字を区別せずに比較します
+	GetBenchmarkYields(ctx context.Context, cropName string) ([]UserCropYield, error)
 	// GetByUserIDWithDateRange はユーザーの収穫記録を日付範囲でフィルタして取得します
 	// Analytics用。startDate/endDateがnilの場合は制限なし
 	GetByUserIDWithDateRange(ctx context.Context, userID uint, startDate, endDate *time.Time) ([]model.Harvest, error)
 	Delete(ctx context.Context, id uint) error
 	DeleteByCropID(ctx context.Context, cropID uint) error
+	// GetDeletedByUserID はユーザーの作物（削除されていないもの）の deletedAfter より後に論理削除された収穫記録を取得します（ゴミ箱）
+	GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Harvest, error)
+	// GetDeletedByID は論理削除された収穫記録を取得します（削除されていない場合は gorm.ErrRecordNotFound）
+	GetDeletedByID(ctx context.Context, id uint) (*model.Harvest, error)
+	// Restore は論理削除された収穫記録を復元します（updated_at も更新）
+	Restore(ctx context.Context, id uint) error
+	// RestoreByCropID は作物の deletedFrom 以降に論理削除された収穫記録を復元し、復元した収穫記録のIDを返します（作物の復元用）
+	RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) ([]uint, error)
+}
+
+// SeasonSummaryRepository defines the interface for season summary data access
+// シーズンごとの区画の生産性の記録を管理します
+type SeasonSummaryRepository interface {
+	// Upsert はユーザー・シーズン・区画ごとの記録を作成または更新します
+	Upsert(ctx context.Context, summaries []model.SeasonSummary) error
+	// GetByUserAndSeason はユーザーのシーズンの記録を取得します（総収穫量の多い順）
+	GetByUserAndSeason(ctx context.Context, userID uint, season int) ([]model.SeasonSummary, error)
 }
 
 // PlotRepository defines the interface for plot data access
@@ -108,10 +187,25 @@ type HarvestRepository interface {
 type PlotRepository interface {
 	Create(ctx context.Context, plot *model.Plot) error
 	GetByID(ctx context.Context, id uint) (*model.Plot, error)
+	// GetByIDs は複数の区画を1回のクエリで取得します（見つからないIDは結果に含まない、順序は不定）
+	GetByIDs(ctx context.Context, ids []uint) ([]model.Plot, error)
 	GetByUserID(ctx context.Context, userID uint) ([]model.Plot, error)
+	// GetLayoutByUserID はユーザーの区画を現在アクティブな配置（PlotAssignments、最大1件）と配置の作物（Crop）とともに取得します
+	GetLayoutByUserID(ctx context.Context, userID uint) ([]model.Plot, error)
 	GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Plot, error)
+	// ListByUserIDPaginated はユーザーの区画をIDの降順でカーソルより前から Limit+1 件まで取得します（status が空の場合は全ステータス）
+	ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Plot, error)
+	// CountByOrganizationID は組織の区画数を取得します（組織の上限の確認用）
+	CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error)
+	// Update は区画の Version が読み込んだ時点のままの場合のみ更新し、Version を1つ進めます（一致しない場合は ErrVersionConflict）
 	Update(ctx context.Context, plot *model.Plot) error
 	Delete(ctx context.Context, id uint) error
+	// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除された区画を削除日時の新しい順に取得します（ゴミ箱、組織のスコープでは組織の区画）
+	GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Plot, error)
+	// GetDeletedByID は論理削除された区画を取得します（削除されていない場合は gorm.ErrRecordNotFound）
+	GetDeletedByID(ctx context.Context, id uint) (*model.Plot, error)
+	// Restore は論理削除された区画を復元します（updated_at も更新）
+	Restore(ctx context.Context, id uint) error
 }
 
 // PlotAssignmentRepository defines the interface for plot assignment data access
@@ -119,12 +213,20 @@ type PlotRepository interface {
 type PlotAssignmentRepository interface {
 	Create(ctx context.Context, assignment *model.PlotAssignment) error
 	GetByID(ctx context.Context, id uint) (*model.PlotAssignment, error)
+	// GetByIDs は複数の配置を1回のクエリで取得します（見つからないIDは結果に含まない、順序は不定）
+	GetByIDs(ctx context.Context, ids []uint) ([]model.PlotAssignment, error)
 	GetByPlotID(ctx context.Context, plotID uint) ([]model.PlotAssignment, error)
+	// GetByPlotIDs は複数の区画の全配置履歴を1回のクエリで配置日の昇順に取得します
+	GetByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error)
 	GetActiveByPlotID(ctx context.Context, plotID uint) (*model.PlotAssignment, error) // 現在アクティブな配置
+	// GetActiveByPlotIDs は複数の区画の現在アクティブな配置を1回のクエリで取得します（配置のない区画は結果に含まない）
+	GetActiveByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error)
 	GetByCropID(ctx context.Context, cropID uint) ([]model.PlotAssignment, error)
 	Update(ctx context.Context, assignment *model.PlotAssignment) error
 	Delete(ctx context.Context, id uint) error
 	DeleteByPlotID(ctx context.Context, plotID uint) error
+	// RestoreByPlotID は区画の deletedFrom 以降に論理削除された配置を復元します（区画の復元用）
+	RestoreByPlotID(ctx context.Context, plotID uint, deletedFrom time.Time) error
 }
 
 // DeviceTokenRepository defines the interface for device token data access
@@ -148,8 +250,12 @@ type DeviceTokenRepository interface {
 	Delete(ctx context.Context, id uint) error
 	// DeleteByUserID はユーザーの全デバイストークンを削除します
 	DeleteByUserID(ctx context.Context, userID uint) error
-	// DeactivateToken はトークンを無効化します（無効トークン検出時）
-	DeactivateToken(ctx context.Context, id uint) error
+	// DeactivateToken はトークンを無効化し、理由を記録します（無効トークン検出時）
+	DeactivateToken(ctx context.Context, id uint, reason string) error
+	// UpdateEndpointARN はトークンのSNSエンドポイントARNのキャッシュを更新します
+	UpdateEndpointARN(ctx context.Context, id uint, endpointARN string) error
+	// DeleteInactiveBefore は指定日時より前に無効化されたトークンを削除し、削除件数を返します
+	DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 // NotificationLogRepository defines the interface for notification log data access
@@ -163,12 +269,379 @@ type NotificationLogRepository interface {
 	GetByDeduplicationKey(ctx context.Context, key string) (*model.NotificationLog, error)
 	// GetByUserID はユーザーの通知ログを取得します
 	GetByUserID(ctx context.Context, userID uint, limit int) ([]model.NotificationLog, error)
-	// GetPendingNotifications は送信待ちの通知を取得します（リトライ用）
+	// GetInboxByUserID はアプリ内受信箱用にユーザーの通知ログをページ単位で取得します（総件数付き）
+	GetInboxByUserID(ctx context.Context, userID uint, limit, offset int, unreadOnly bool) ([]model.NotificationLog, int64, error)
+	// ListInboxByUserIDPaginated はアプリ内受信箱用にユーザーの通知ログをIDの降順でカーソルより前から Limit+1 件まで取得します
+	ListInboxByUserIDPaginated(ctx context.Context, userID uint, unreadOnly bool, params pagination.Params) ([]model.NotificationLog, error)
+	// CountUnreadByUserID はユーザーの未読通知数を取得します
+	CountUnreadByUserID(ctx context.Context, userID uint) (int64, error)
+	// MarkAsRead は通知ログを既読にします（既読済みの場合は何もしません）
+	MarkAsRead(ctx context.Context, id uint, readAt time.Time) error
+	// GetPendingNotifications は送信待ちの通知を取得します（リトライ・おやすみモード保留分、次回リトライ予定日時を過ぎたもののみ）
 	GetPendingNotifications(ctx context.Context, limit int) ([]model.NotificationLog, error)
 	// Update は通知ログを更新します
 	Update(ctx context.Context, log *model.NotificationLog) error
+	// CountPushesSince はユーザーへ指定日時以降に送信したプッシュ通知の件数を取得します（送信数の上限の判定用）
+	CountPushesSince(ctx context.Context, userID uint, since time.Time) (int64, error)
+	// GetPushRateLimited は送信数の上限によりプッシュ通知を送らなかった通知ログを取得します（古い順）
+	GetPushRateLimited(ctx context.Context, limit int) ([]model.NotificationLog, error)
+	// ClearPushRateLimited は通知ログのまとめ通知の送信待ちを解除します
+	ClearPushRateLimited(ctx context.Context, ids []uint) error
 	// DeleteExpired は期限切れの通知ログを削除します
 	DeleteExpired(ctx context.Context) error
+	// CountExpiredOrCreatedBefore は期限切れ、または createdBefore より前に作成された通知ログの件数を取得します（保持期間のdry run用）
+	CountExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error)
+	// DeleteExpiredOrCreatedBefore は期限切れ、または createdBefore より前に作成された通知ログを削除し、削除件数を返します
+	DeleteExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error)
+	// CountChannelOutcomesSince は指定日時以降に作成された通知ログのチャネル×送信結果ごとの件数を取得します（重複分は deduped）
+	CountChannelOutcomesSince(ctx context.Context, since time.Time) ([]NotificationChannelOutcomeCount, error)
+}
+
+// NotificationChannelOutcomeCount はチャネル×送信結果ごとの通知の件数です
+type NotificationChannelOutcomeCount struct {
+	Channel string // push, email, slack, discord, custom_webhook, sms
+	Outcome string // sent, failed, deferred, deduped
+	Count   int64
+}
+
+// NotificationPreferenceRepository defines the interface for notification preference data access
+// イベントタイプ×チャネルごとの通知設定を管理します
+type NotificationPreferenceRepository interface {
+	// GetByUserID はユーザーの通知設定（設定済みの組み合わせのみ）を取得します
+	GetByUserID(ctx context.Context, userID uint) ([]model.NotificationPreference, error)
+	// Upsert は通知設定を作成または更新します（同じユーザー・イベントタイプ・チャネルの行は上書き）
+	Upsert(ctx context.Context, prefs []model.NotificationPreference) error
+}
+
+// PhoneVerificationRepository defines the interface for phone verification data access
+// SMS通知用の電話番号の認証コードを管理します（ユーザーごとに1件）
+type PhoneVerificationRepository interface {
+	// GetByUserID はユーザーの認証中の電話番号を取得します
+	GetByUserID(ctx context.Context, userID uint) (*model.PhoneVerification, error)
+	// Save は認証コードを作成または更新します（同じユーザーの行は上書き）
+	Save(ctx context.Context, verification *model.PhoneVerification) error
+	// DeleteByUserID はユーザーの認証コードを削除します
+	DeleteByUserID(ctx context.Context, userID uint) error
+	// RecordSend は認証コードのSMSの送信を記録し、before より前のユーザーの送信記録を削除します
+	RecordSend(ctx context.Context, send *model.PhoneVerificationSend, before time.Time) error
+	// GetSendsSince は since 以降のユーザーの送信記録を新しい順に取得します（番号に関わらず全て）
+	GetSendsSince(ctx context.Context, userID uint, since time.Time) ([]model.PhoneVerificationSend, error)
+}
+
+// AnnouncementAudience はお知らせの配信対象の条件です（空・nil の条件は適用しない）
+type AnnouncementAudience struct {
+	Locale      string     // 言語（ja, en）
+	Platform    string     // 有効なデバイストークンのプラットフォーム（ios, android, web）
+	ActiveSince *time.Time // この日以降にアクティブなユーザー
+}
+
+// AnnouncementRepository defines the interface for announcement data access
+// 管理者が配信するお知らせと配信対象ユーザーの抽出を管理します
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *model.Announcement) error
+	GetByID(ctx context.Context, id uint) (*model.Announcement, error)
+	// List はお知らせを新しい順に取得します
+	List(ctx context.Context, limit int) ([]model.Announcement, error)
+	Update(ctx context.Context, announcement *model.Announcement) error
+	// GetDue は配信予定日時を過ぎた scheduled / sending のお知らせを配信予定日時の順に取得します
+	GetDue(ctx context.Context, now time.Time, limit int) ([]model.Announcement, error)
+	// GetAudienceUserIDs は条件に合う有効なユーザーのうち afterUserID より大きいIDを昇順に取得します
+	GetAudienceUserIDs(ctx context.Context, audience AnnouncementAudience, afterUserID uint, limit int) ([]uint, error)
+	// CountAudience は条件に合う有効なユーザー数を返します
+	CountAudience(ctx context.Context, audience AnnouncementAudience) (int64, error)
+}
+
+// SchedulerInvocationRepository defines the interface for scheduler invocation data access
+// スケジューラーエンドポイントの冪等キーと処理結果を管理します
+type SchedulerInvocationRepository interface {
+	// Reserve は冪等キーを処理中として登録します。既に登録済みの場合は false を返します
+	Reserve(ctx context.Context, invocation *model.SchedulerInvocation) (bool, error)
+	// GetByKey はエンドポイントと冪等キーで処理結果を取得します
+	GetByKey(ctx context.Context, endpoint, key string) (*model.SchedulerInvocation, error)
+	// Complete は処理結果（ステータスコードとレスポンス）を記録します
+	Complete(ctx context.Context, id uint, statusCode int, responseBody string, completedAt time.Time) error
+	// Delete は冪等キーの登録を削除します（処理失敗時・期限切れの再登録時）
+	Delete(ctx context.Context, id uint) error
+	// DeleteExpired は保持期限を過ぎた冪等キーを削除し、削除件数を返します
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// JobRunRepository defines the interface for job run data access
+// 内蔵スケジューラーのジョブの実行履歴を管理します
+type JobRunRepository interface {
+	// Create は実行履歴を作成します
+	Create(ctx context.Context, run *model.JobRun) error
+	// Finish は実行結果（succeeded, failed）を記録します
+	Finish(ctx context.Context, id uint, status, errorMessage string, finishedAt time.Time) error
+	// GetLatest はジョブの実行日時が最も新しい実行履歴を取得します
+	GetLatest(ctx context.Context, jobName string) (*model.JobRun, error)
+	// DeleteStartedBefore は指定日時より前に開始した実行履歴を削除し、削除件数を返します
+	DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SchedulerCheckpointRepository defines the interface for scheduler checkpoint data access
+// ユーザーをページごとに処理するスケジューラーの処理の進捗を管理します
+type SchedulerCheckpointRepository interface {
+	// Get は処理名の進捗を取得します
+	Get(ctx context.Context, name string) (*model.SchedulerCheckpoint, error)
+	// Save は処理名の進捗を作成または更新します
+	Save(ctx context.Context, checkpoint *model.SchedulerCheckpoint) error
+}
+
+// ScheduleConfigRepository defines the interface for schedule config data access
+// 管理者が変更した定期タスクのスケジュールを管理します
+type ScheduleConfigRepository interface {
+	// GetAll は全ジョブの設定をジョブ名順に取得します
+	GetAll(ctx context.Context) ([]model.ScheduleConfig, error)
+	// GetByName はジョブ名で設定を取得します
+	GetByName(ctx context.Context, name string) (*model.ScheduleConfig, error)
+	// Upsert はジョブ名の設定を作成または更新します
+	Upsert(ctx context.Context, config *model.ScheduleConfig) error
+}
+
+// NotificationOutboxRepository defines the interface for notification outbox data access
+// スケジューラーが生成した通知イベントの送信待ち（アウトボックス）を管理します
+type NotificationOutboxRepository interface {
+	// Enqueue は通知イベントを送信待ちとして保存し、保存した件数を返します。同じ重複防止キーのイベントは保存しません
+	Enqueue(ctx context.Context, entries []model.NotificationOutbox) (int64, error)
+	// GetPending は送信を試行できる送信待ちのイベントを古い順に取得します
+	GetPending(ctx context.Context, now time.Time, limit int) ([]model.NotificationOutbox, error)
+	// MarkDispatched はイベントを処理済みにします
+	MarkDispatched(ctx context.Context, id uint, dispatchedAt time.Time) error
+	// MarkAttemptFailed は送信の試行の失敗を記録します（status が failed の場合は以降送信しない）
+	MarkAttemptFailed(ctx context.Context, id uint, status string, attempts int, lastError string, availableAt time.Time) error
+	// DeleteDispatchedBefore は指定日時より前に処理済みになったイベントを削除し、削除件数を返します
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NotificationDeadLetterRepository defines the interface for notification dead letter data access
+// 再試行しても送信できなかった通知イベントを管理します
+type NotificationDeadLetterRepository interface {
+	// Create はデッドレターを保存します
+	Create(ctx context.Context, letter *model.NotificationDeadLetter) error
+	// GetByID はIDでデッドレターを取得します
+	GetByID(ctx context.Context, id uint) (*model.NotificationDeadLetter, error)
+	// List はデッドレターを新しい順に取得します（status が空の場合は全ステータス）
+	List(ctx context.Context, status string, limit int) ([]model.NotificationDeadLetter, error)
+	// Update はデッドレターを更新します
+	Update(ctx context.Context, letter *model.NotificationDeadLetter) error
+}
+
+// ShareTokenRepository defines the interface for share token data access
+// 公開共有用のトークンを管理します
+type ShareTokenRepository interface {
+	Create(ctx context.Context, token *model.ShareToken) error
+	GetByID(ctx context.Context, id uint) (*model.ShareToken, error)
+	// GetByTokenHash はトークンのハッシュ（SHA-256）で共有トークンを取得します
+	GetByTokenHash(ctx context.Context, tokenHash string) (*model.ShareToken, error)
+	GetByUserID(ctx context.Context, userID uint) ([]model.ShareToken, error)
+	Update(ctx context.Context, token *model.ShareToken) error
+}
+
+// AnalyticsViewRepository defines the interface for materialized view maintenance
+// 分析用マテリアライズドビューのリフレッシュとメタデータを管理します
+type AnalyticsViewRepository interface {
+	// Refresh は指定したマテリアライズドビューをリフレッシュします
+	Refresh(ctx context.Context, viewName string) error
+	// RecordRefresh はリフレッシュ結果をメタデータテーブルに保存します（ビュー名でupsert）
+	RecordRefresh(ctx context.Context, record *model.MaterializedViewRefresh) error
+	// GetRefreshStatuses は全ビューの最新リフレッシュ結果を取得します
+	GetRefreshStatuses(ctx context.Context) ([]model.MaterializedViewRefresh, error)
+	// GetCropHarvestAnalytics は mv_harvest_analytics から作物名（大文字小文字を区別しない）に一致する行を取得します
+	GetCropHarvestAnalytics(ctx context.Context, userID uint, cropName string) ([]CropHarvestAnalytics, error)
+	// GetDataVersion はスコープ（user:<ID>, org:<ID> など）のデータのバージョンの合計を返します（記録がない場合は 0）
+	GetDataVersion(ctx context.Context, scopes []string) (int64, error)
+	// BumpDataVersion はスコープのデータのバージョンを1増やします（記録がない場合は作成）
+	BumpDataVersion(ctx context.Context, scopes ...string) error
+}
+
+// CropHarvestAnalytics は mv_harvest_analytics の1行（作物1件分の収穫集計）です
+type CropHarvestAnalytics struct {
+	CropID             uint      `json:"crop_id"`
+	CropName           string    `json:"crop_name"`
+	PlantedDate        time.Time `json:"planted_date"`
+	TotalQuantity      float64   `json:"total_quantity"`
+	QuantityUnit       string    `json:"quantity_unit"`
+	HarvestCount       int       `json:"harvest_count"`
+	DaysToFirstHarvest *int      `json:"days_to_first_harvest,omitempty"` // 収穫がない場合はnil
+	AvgQualityScore    *float64  `json:"avg_quality_score,omitempty"`     // 1(poor)〜4(excellent)
+}
+
+// ExportRecordRepository defines the interface for export history data access
+// 生成済みエクスポートファイルのメタデータを管理します
+type ExportRecordRepository interface {
+	Create(ctx context.Context, record *model.ExportRecord) error
+	GetByID(ctx context.Context, id uint) (*model.ExportRecord, error)
+	Update(ctx context.Context, record *model.ExportRecord) error
+	// GetByUserID はユーザーのエクスポート履歴を新しい順に取得します（limit <= 0 で全件）
+	GetByUserID(ctx context.Context, userID uint, limit int) ([]model.ExportRecord, error)
+	// GetWithFilesCreatedBefore は before より前に作成され、S3にファイルが残っているエクスポート履歴を古い順に取得します
+	GetWithFilesCreatedBefore(ctx context.Context, before time.Time, limit int) ([]model.ExportRecord, error)
+	// CountWithFilesCreatedBefore は GetWithFilesCreatedBefore の対象のエクスポート履歴の件数を返します（件数の上限なし）
+	CountWithFilesCreatedBefore(ctx context.Context, before time.Time) (int64, error)
+	// GetStorageTotalByUserID はユーザーの保存先にファイルが残っているエクスポートの件数と容量の合計を返します
+	GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error)
+}
+
+// RetentionRepository defines the interface for data retention purging
+// 論理削除（deleted_at）から保持期間を過ぎた行を物理削除します
+// table は呼び出し元で定義した対象のテーブル名のみ指定してください
+type RetentionRepository interface {
+	// CountSoftDeleted は before より前に論理削除された行の件数を取得します
+	CountSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error)
+	// PurgeSoftDeleted は before より前に論理削除された行を物理削除し、削除件数を返します
+	PurgeSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error)
+}
+
+// SyncRepository defines the interface for offline sync data access
+// 同期（GET /sync）の期間内に作成・更新された記録と、削除の記録を取得します
+// 期間は since より後、until 以前（since, until]で、記録は更新日時の順に返します
+type SyncRepository interface {
+	ChangedTasks(ctx context.Context, userID uint, since, until time.Time) ([]model.Task, error)
+	ChangedCrops(ctx context.Context, userID uint, since, until time.Time) ([]model.Crop, error)
+	ChangedPlots(ctx context.Context, userID uint, since, until time.Time) ([]model.Plot, error)
+	// ChangedHarvests はユーザーの作物（削除されていないもの）の収穫記録を取得します
+	ChangedHarvests(ctx context.Context, userID uint, since, until time.Time) ([]model.Harvest, error)
+	CreateTombstones(ctx context.Context, tombstones []model.SyncTombstone) error
+	// GetTombstones は期間内の削除の記録を削除日時の順に取得します
+	GetTombstones(ctx context.Context, userID uint, since, until time.Time) ([]model.SyncTombstone, error)
+	// GetTombstone は記録の削除の記録を取得します（ない場合は gorm.ErrRecordNotFound）
+	GetTombstone(ctx context.Context, userID uint, entity string, entityID uint) (*model.SyncTombstone, error)
+	// DeleteTombstones は記録の削除の記録を削除します（ゴミ箱から復元した記録用）
+	DeleteTombstones(ctx context.Context, userID uint, entity string, entityIDs []uint) error
+}
+
+// SearchHit は横断検索で一致した記録です
+type SearchHit struct {
+	Type      string    `json:"type"` // crops, tasks, plots, growth_records
+	ID        uint      `json:"id"`
+	CropID    uint      `json:"crop_id"` // 成長記録の作物ID（成長記録以外は 0）
+	Title     string    `json:"title"`   // 作物・区画の名前、タスクのタイトル、成長記録の作物の名前
+	Body      string    `json:"body"`    // 検索した文書（スニペットの作成用）
+	Rank      float64   `json:"rank"`    // 関連度（全文検索で一致した場合は 1 以上）
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SearchRepository defines the interface for global search
+// 作物・タスク・区画・成長記録のメモを全文検索（一致しない場合はトライグラム・部分一致）で検索します
+type SearchRepository interface {
+	// Search は対象（SearchEntity*）の記録を関連度の高い順に limit 件まで検索します（組織のスコープでは組織の作物・区画と作物の成長記録）
+	Search(ctx context.Context, userID uint, entity, query string, limit int) ([]SearchHit, error)
+}
+
+// GardenMemberRepository defines the interface for garden member data access
+// 庭を共有する世帯のメンバー（所有者以外）を管理します
+type GardenMemberRepository interface {
+	Create(ctx context.Context, member *model.GardenMember) error
+	// GetByGardenID は庭のメンバーをユーザー情報付きで追加順に取得します
+	GetByGardenID(ctx context.Context, gardenID uint) ([]model.GardenMember, error)
+	// Get は庭のユーザーのメンバーを取得します（メンバーでない場合は gorm.ErrRecordNotFound）
+	Get(ctx context.Context, gardenID, userID uint) (*model.GardenMember, error)
+	// GetSharedGardens はユーザーがメンバーとして参加している庭を取得します（所有する庭は含まない）
+	GetSharedGardens(ctx context.Context, userID uint) ([]model.Garden, error)
+	// Delete はメンバーを物理削除します（同じユーザーを再度追加できるようにするため）
+	Delete(ctx context.Context, gardenID, userID uint) error
+}
+
+// OrganizationRepository defines the interface for organization data access
+// 区画・作物を共有する組織（コミュニティガーデン・学校）を管理します
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *model.Organization) error
+	GetByID(ctx context.Context, id uint) (*model.Organization, error)
+	// GetByUserID はユーザーがメンバーの組織を取得します（ID順）
+	GetByUserID(ctx context.Context, userID uint) ([]model.Organization, error)
+	Update(ctx context.Context, organization *model.Organization) error
+}
+
+// OrganizationMemberRepository defines the interface for organization member data access
+// 組織のメンバーと役割（owner, admin, member, viewer）を管理します
+type OrganizationMemberRepository interface {
+	Create(ctx context.Context, member *model.OrganizationMember) error
+	// GetByOrganizationID は組織のメンバーをユーザー情報付きで追加順に取得します
+	GetByOrganizationID(ctx context.Context, organizationID uint) ([]model.OrganizationMember, error)
+	// Get は組織のユーザーのメンバーを取得します（メンバーでない場合は gorm.ErrRecordNotFound）
+	Get(ctx context.Context, organizationID, userID uint) (*model.OrganizationMember, error)
+	// CountByOrganizationID は組織のメンバー数を取得します（組織の上限の確認用）
+	CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error)
+	// Delete はメンバーを物理削除します（同じユーザーを再度追加できるようにするため）
+	Delete(ctx context.Context, organizationID, userID uint) error
+}
+
+// AuditLogRepository defines the interface for audit log data access
+// 変更履歴は AuditPlugin が作成するため、取得のみです
+type AuditLogRepository interface {
+	// ListByEntity は記録の変更履歴を1ページ分取得します（新しい順、ownerID が 0 の場合は所有者で絞り込まない）
+	ListByEntity(ctx context.Context, entity string, entityID, ownerID uint, params pagination.Params) ([]model.AuditLog, error)
+}
+
+// DatabaseBackupRepository defines the interface for database backup history data access
+// バックアップのファイルは S3 に保存し、履歴には保存先と作成結果を記録します
+type DatabaseBackupRepository interface {
+	Create(ctx context.Context, backup *model.DatabaseBackup) error
+	GetByID(ctx context.Context, id uint) (*model.DatabaseBackup, error)
+	Update(ctx context.Context, backup *model.DatabaseBackup) error
+	// ListPaginated はバックアップの履歴を1ページ分取得します（新しい順）
+	ListPaginated(ctx context.Context, params pagination.Params) ([]model.DatabaseBackup, error)
+}
+
+// PhotoRepository defines the interface for photo data access
+// 画像とバリアントは保存先（S3・GCS・ローカル）に保存し、写真には保存先と加工の結果を記録します
+type PhotoRepository interface {
+	Create(ctx context.Context, photo *model.Photo) error
+	GetByID(ctx context.Context, id uint) (*model.Photo, error)
+	Update(ctx context.Context, photo *model.Photo) error
+	// Delete は写真を論理削除します（保持期間の後に物理削除）
+	Delete(ctx context.Context, id uint) error
+	// GetStorageTotalByUserID はユーザーの写真の件数と保存容量（SizeBytes）の合計を返します
+	GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error)
+	// GetOrphanedByUserID は作物・収穫記録が削除された（論理削除を含む）ユーザーの写真を古い順に取得します
+	GetOrphanedByUserID(ctx context.Context, userID uint) ([]model.Photo, error)
+	// GetCompletedByUserID はユーザーの加工済み（completed）の写真を古い順に取得します（エクスポート用）
+	GetCompletedByUserID(ctx context.Context, userID uint) ([]model.Photo, error)
+	// GetOrphanedBefore は before より前に作物・収穫記録が論理削除された（物理削除済みの場合は before より前に作成した）写真を古い順に取得します
+	GetOrphanedBefore(ctx context.Context, before time.Time, limit int) ([]model.Photo, error)
+	// CountOrphanedBefore は GetOrphanedBefore の対象の写真の件数を返します（件数の上限なし）
+	CountOrphanedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AttachmentRepository defines the interface for attachment data access
+// ファイルは保存先（S3・GCS・ローカル）に保存し、添付ファイルには添付先（作物・区画）と保存先を記録します
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *model.Attachment) error
+	GetByID(ctx context.Context, id uint) (*model.Attachment, error)
+	// ExistsByObjectKey はオブジェクトキーが添付ファイルとして登録済みか判定します（論理削除した添付ファイルを含む）
+	ExistsByObjectKey(ctx context.Context, objectKey string) (bool, error)
+	// Delete は添付ファイルを論理削除します（保持期間の後に物理削除）
+	Delete(ctx context.Context, id uint) error
+	// ListByParent は添付先（crop, plot）の添付ファイルを古い順に取得します
+	ListByParent(ctx context.Context, parentType string, parentID uint) ([]model.Attachment, error)
+	// GetStorageTotalByUserID はユーザーがアップロードした添付ファイルの件数と保存容量（SizeBytes）の合計を返します
+	GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error)
+}
+
+// StorageTotal は保存容量の集計結果です
+type StorageTotal struct {
+	Count int64 `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// DailyCount は日別の件数集計結果です
+type DailyCount struct {
+	Date  time.Time `json:"date"`
+	Count int64     `json:"count"`
+}
+
+// UsageStatsRepository defines the interface for usage statistics data access
+// 管理者向け利用統計の日別カウンターとアクティブユーザーを管理します
+type UsageStatsRepository interface {
+	// IncrementCounter は (date, metric) のカウンターに delta を加算します（存在しない場合は作成）
+	IncrementCounter(ctx context.Context, date time.Time, metric string, delta int64) error
+	// RecordActiveUser はユーザーをその日のアクティブユーザーとして記録します（重複は無視）
+	RecordActiveUser(ctx context.Context, date time.Time, userID uint) error
+	GetCounters(ctx context.Context, from, to time.Time) ([]model.UsageCounter, error)
+	GetDailyActiveUsers(ctx context.Context, from, to time.Time) ([]DailyCount, error)
+	// CountDistinctActiveUsers は期間内のユニークアクティブユーザー数を返します
+	CountDistinctActiveUsers(ctx context.Context, from, to time.Time) (int64, error)
 }
 
 // Repositories aggregates all repository interfaces
@@ -182,10 +655,34 @@ type Repositories interface {
 	Crop() CropRepository
 	GrowthRecord() GrowthRecordRepository
 	Harvest() HarvestRepository
+	SeasonSummary() SeasonSummaryRepository
 	Plot() PlotRepository
 	PlotAssignment() PlotAssignmentRepository
 	DeviceToken() DeviceTokenRepository
 	NotificationLog() NotificationLogRepository
+	NotificationPreference() NotificationPreferenceRepository
+	PhoneVerification() PhoneVerificationRepository
+	Announcement() AnnouncementRepository
+	NotificationOutbox() NotificationOutboxRepository
+	NotificationDeadLetter() NotificationDeadLetterRepository
+	SchedulerInvocation() SchedulerInvocationRepository
+	JobRun() JobRunRepository
+	SchedulerCheckpoint() SchedulerCheckpointRepository
+	ScheduleConfig() ScheduleConfigRepository
+	ShareToken() ShareTokenRepository
+	AnalyticsView() AnalyticsViewRepository
+	ExportRecord() ExportRecordRepository
+	UsageStats() UsageStatsRepository
+	Retention() RetentionRepository
+	Sync() SyncRepository
+	Search() SearchRepository
+	GardenMember() GardenMemberRepository
+	Organization() OrganizationRepository
+	OrganizationMember() OrganizationMemberRepository
+	AuditLog() AuditLogRepository
+	DatabaseBackup() DatabaseBackupRepository
+	Photo() PhotoRepository
+	Attachment() AttachmentRepository
 
 	// Transaction support
 	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// JobRunRepository Implementation - ジョブ実行履歴リポジトリ
+// =============================================================================
+
+// jobRunRepository implements JobRunRepository
+type jobRunRepository struct {
+	db *gorm.DB
+}
+
+// Create は実行履歴を作成します。
+func (r *jobRunRepository) Create(ctx context.Context, run *model.JobRun) error {
+	return GetDB(ctx, r.db).Create(run).Error
+}
+
+// Finish は実行結果を記録します。
+func (r *jobRunRepository) Finish(ctx context.Context, id uint, status, errorMessage string, finishedAt time.Time) error {
+	return GetDB(ctx, r.db).Model(&model.JobRun{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        status,
+		"error_message": errorMessage,
+		"finished_at":   finishedAt,
+	}).Error
+}
+
+// GetLatest はジョブの実行日時が最も新しい実行履歴を取得します。
+func (r *jobRunRepository) GetLatest(ctx context.Context, jobName string) (*model.JobRun, error) {
+	var run model.JobRun
+	if err := GetDB(ctx, r.db).Where("job_name = ?", jobName).Order("scheduled_at DESC").First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// DeleteStartedBefore は指定日時より前に開始した実行履歴を削除します。
+func (r *jobRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).Where("started_at < ?", before).Delete(&model.JobRun{})
+	return result.RowsAffected, result.Error
+}
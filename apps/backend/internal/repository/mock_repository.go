@@ -19,15 +19,25 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/filter"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 	"gorm.io/gorm"
 )
 
 // MockUserRepository は UserRepository インターフェースのモック実装です。
 // テスト時にデータベースの代わりにメモリ内のMapを使用します。
 type MockUserRepository struct {
+	mockClock
+
 	// Users はIDをキーとしたユーザーの格納Map
 	// PostgreSQLのプライマリキー検索をシミュレート
 	Users map[uint]*model.User
@@ -37,6 +47,9 @@ type MockUserRepository struct {
 	// 同じユーザーを2つのMapに格納することで、両方の検索パターンに対応
 	UsersByEmail map[string]*model.User
 
+	// DeletedUsers は論理削除したユーザー（GORM のソフトデリートをシミュレート、GetUnscoped で参照）
+	DeletedUsers map[uint]*model.User
+
 	// NextID は次に割り当てるID（自動インクリメントをシミュレート）
 	// PostgreSQLの SERIAL / BIGSERIAL と同じ動作
 	NextID uint
@@ -63,6 +76,7 @@ func NewMockUserRepository() *MockUserRepository {
 	return &MockUserRepository{
 		Users:        make(map[uint]*model.User),
 		UsersByEmail: make(map[string]*model.User),
+		DeletedUsers: make(map[uint]*model.User),
 		NextID:       1,
 	}
 }
@@ -86,8 +100,8 @@ func (r *MockUserRepository) Create(ctx context.Context, user *model.User) error
 	r.NextID++
 
 	// GORMのCreatedAt/UpdatedAt自動設定をシミュレート
-	user.CreatedAt = time.Now()
-	user.UpdatedAt = time.Now()
+	user.CreatedAt = r.now()
+	user.UpdatedAt = r.now()
 
 	// 両方のMapに保存（同じポインタを格納）
 	r.Users[user.ID] = user
@@ -155,7 +169,7 @@ func (r *MockUserRepository) Update(ctx context.Context, user *model.User) error
 	}
 
 	// GORMのUpdatedAt自動更新をシミュレート
-	user.UpdatedAt = time.Now()
+	user.UpdatedAt = r.now()
 
 	// Mapを更新（ポインタなので実際は同じオブジェクト）
 	r.Users[user.ID] = user
@@ -164,20 +178,96 @@ func (r *MockUserRepository) Update(ctx context.Context, user *model.User) error
 	return nil
 }
 
+// GetIDsAfter はIDが afterID より大きいユーザーのIDを昇順に最大 limit 件返します。
+func (r *MockUserRepository) GetIDsAfter(ctx context.Context, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	for id := range r.Users {
+		if id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+// containsUserID は userIDs に userID が含まれるかを判定します（IN 句のシミュレート）。
+func containsUserID(userIDs []uint, userID uint) bool {
+	for _, id := range userIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// getUnscoped は論理削除した記録も含めてIDで取得します（GORM の Unscoped().First に相当）。
+// 論理削除するモックリポジトリは削除した記録を Deleted〜 の Map に移すため、両方の Map から探します。
+func getUnscoped[T any](active, deleted map[uint]*T, id uint) (*T, error) {
+	if record, ok := active[id]; ok {
+		return record, nil
+	}
+	if record, ok := deleted[id]; ok {
+		return record, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// purgeDeletedRecords は before より前に論理削除した記録の件数を返し、dryRun でない場合は物理削除します。
+func purgeDeletedRecords[T any](deleted map[uint]*T, deletedAt func(*T) gorm.DeletedAt, before time.Time, dryRun bool) int64 {
+	var count int64
+	for id, record := range deleted {
+		if at := deletedAt(record); at.Valid && at.Time.Before(before) {
+			count++
+			if !dryRun {
+				delete(deleted, id)
+			}
+		}
+	}
+	return count
+}
+
+// paginateMockByID はIDの降順のカーソルページングをシミュレートします（Limit+1 件まで返します）。
+func paginateMockByID[T any](rows []T, params pagination.Params, idOf func(T) uint) []T {
+	params = params.Normalize()
+	var result []T
+	for _, row := range rows {
+		if params.BeforeID == 0 || idOf(row) < params.BeforeID {
+			result = append(result, row)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return idOf(result[i]) > idOf(result[j]) })
+	if len(result) > params.Limit+1 {
+		result = result[:params.Limit+1]
+	}
+	return result
+}
+
 // Delete はユーザーを削除します。
 // 両方のMapから削除します（物理削除をシミュレート）。
 func (r *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	if user, ok := r.Users[id]; ok {
-		// 両方のMapから削除
+		// 両方のMapから削除し、論理削除したユーザーとして残す
 		delete(r.UsersByEmail, user.Email)
 		delete(r.Users, id)
+		user.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedUsers[id] = user
 	}
 	return nil
 }
 
+// GetUnscoped は論理削除したユーザーも含めてIDで取得します（GORM の Unscoped に相当、テスト用）。
+func (r *MockUserRepository) GetUnscoped(id uint) (*model.User, error) {
+	return getUnscoped(r.Users, r.DeletedUsers, id)
+}
+
 // MockTokenBlacklistRepository は TokenBlacklistRepository のモック実装です。
 // ログアウト時のトークン無効化機能をテストするために使用します。
 type MockTokenBlacklistRepository struct {
+	mockClock
+
 	// Tokens はトークンハッシュをキー、有効期限を値とするMap
 	// 「このトークンは無効化されている」という状態を保持
 	Tokens map[string]time.Time
@@ -207,7 +297,7 @@ func (r *MockTokenBlacklistRepository) IsBlacklisted(ctx context.Context, tokenH
 // DeleteExpired は期限切れのトークンを削除します。
 // 定期的なクリーンアップジョブをシミュレートします。
 func (r *MockTokenBlacklistRepository) DeleteExpired(ctx context.Context) error {
-	now := time.Now()
+	now := r.now()
 	for hash, expiresAt := range r.Tokens {
 		if expiresAt.Before(now) {
 			delete(r.Tokens, hash)
@@ -216,18 +306,77 @@ func (r *MockTokenBlacklistRepository) DeleteExpired(ctx context.Context) error
 	return nil
 }
 
-// MockGardenRepository は GardenRepository のスタブ実装です。
-type MockGardenRepository struct{}
+// MockGardenRepository は GardenRepository インターフェースのモック実装です。
+type MockGardenRepository struct {
+	mockClock
+
+	Gardens map[uint]*model.Garden
+	NextID  uint
+
+	// DeletedGardens は論理削除した庭（GetUnscoped で参照、保持期間を過ぎると物理削除）
+	DeletedGardens map[uint]*model.Garden
+}
+
+// NewMockGardenRepository は新しいMockGardenRepositoryを作成します。
+func NewMockGardenRepository() *MockGardenRepository {
+	return &MockGardenRepository{
+		Gardens:        make(map[uint]*model.Garden),
+		NextID:         1,
+		DeletedGardens: make(map[uint]*model.Garden),
+	}
+}
+
+func (r *MockGardenRepository) Create(ctx context.Context, garden *model.Garden) error {
+	garden.ID = r.NextID
+	r.NextID++
+	garden.CreatedAt = r.now()
+	garden.UpdatedAt = r.now()
+	r.Gardens[garden.ID] = garden
+	return nil
+}
 
-func (r *MockGardenRepository) Create(ctx context.Context, garden *model.Garden) error { return nil }
 func (r *MockGardenRepository) GetByID(ctx context.Context, id uint) (*model.Garden, error) {
+	if garden, ok := r.Gardens[id]; ok {
+		return garden, nil
+	}
 	return nil, gorm.ErrRecordNotFound
 }
+
 func (r *MockGardenRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Garden, error) {
-	return nil, nil
+	var result []model.Garden
+	for _, garden := range r.Gardens {
+		if garden.UserID == userID {
+			result = append(result, *garden)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockGardenRepository) Update(ctx context.Context, garden *model.Garden) error {
+	garden.UpdatedAt = r.now()
+	r.Gardens[garden.ID] = garden
+	return nil
+}
+
+func (r *MockGardenRepository) Delete(ctx context.Context, id uint) error {
+	if garden, ok := r.Gardens[id]; ok {
+		delete(r.Gardens, id)
+		garden.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedGardens[id] = garden
+	}
+	return nil
+}
+
+// GetUnscoped は論理削除した庭も含めてIDで取得します（テスト用）。
+func (r *MockGardenRepository) GetUnscoped(id uint) (*model.Garden, error) {
+	return getUnscoped(r.Gardens, r.DeletedGardens, id)
+}
+
+// purgeDeleted は before より前に論理削除した庭を物理削除します（MockRetentionRepository）。
+func (r *MockGardenRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedGardens, func(g *model.Garden) gorm.DeletedAt { return g.DeletedAt }, before, dryRun)
 }
-func (r *MockGardenRepository) Update(ctx context.Context, garden *model.Garden) error { return nil }
-func (r *MockGardenRepository) Delete(ctx context.Context, id uint) error              { return nil }
 
 // MockPlantRepository は PlantRepository のスタブ実装です。
 type MockPlantRepository struct{}
@@ -260,6 +409,8 @@ func (r *MockCareLogRepository) Delete(ctx context.Context, id uint) error { ret
 // MockTaskRepository は TaskRepository インターフェースのモック実装です。
 // タスク管理機能のテストに使用します。
 type MockTaskRepository struct {
+	mockClock
+
 	// Tasks はIDをキーとしたタスクの格納Map
 	Tasks map[uint]*model.Task
 
@@ -267,6 +418,9 @@ type MockTaskRepository struct {
 	// ユーザーごとのタスク一覧取得をO(1)で実現
 	TasksByUserID map[uint][]*model.Task
 
+	// DeletedTasks は論理削除したタスク（ゴミ箱・復元用）
+	DeletedTasks map[uint]*model.Task
+
 	// NextID は次に割り当てるID（自動インクリメントをシミュレート）
 	NextID uint
 
@@ -284,6 +438,7 @@ func NewMockTaskRepository() *MockTaskRepository {
 	return &MockTaskRepository{
 		Tasks:         make(map[uint]*model.Task),
 		TasksByUserID: make(map[uint][]*model.Task),
+		DeletedTasks:  make(map[uint]*model.Task),
 		NextID:        1,
 	}
 }
@@ -296,8 +451,11 @@ func (r *MockTaskRepository) Create(ctx context.Context, task *model.Task) error
 
 	task.ID = r.NextID
 	r.NextID++
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
+	task.CreatedAt = r.now()
+	task.UpdatedAt = r.now()
+	if task.Version == 0 {
+		task.Version = 1
+	}
 
 	r.Tasks[task.ID] = task
 	r.TasksByUserID[task.UserID] = append(r.TasksByUserID[task.UserID], task)
@@ -305,6 +463,16 @@ func (r *MockTaskRepository) Create(ctx context.Context, task *model.Task) error
 	return nil
 }
 
+// CreateBatch は複数のタスクを Create で順にメモリに保存します（最初のエラーで中断）。
+func (r *MockTaskRepository) CreateBatch(ctx context.Context, tasks []model.Task) error {
+	for i := range tasks {
+		if err := r.Create(ctx, &tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetByID はIDでタスクを検索します。
 func (r *MockTaskRepository) GetByID(ctx context.Context, id uint) (*model.Task, error) {
 	if r.GetByIDFunc != nil {
@@ -346,67 +514,88 @@ func (r *MockTaskRepository) GetByUserIDAndStatus(ctx context.Context, userID ui
 	return result, nil
 }
 
-// GetTodayTasks は今日が期限のタスクを取得します。
-func (r *MockTaskRepository) GetTodayTasks(ctx context.Context, userID uint) ([]model.Task, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
+// ListByUserIDPaginated はユーザーのタスクを1ページ分取得します（spec の条件で絞り込み）。
+func (r *MockTaskRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Task, error) {
+	var matched []model.Task
+	for _, t := range r.TasksByUserID[userID] {
+		if matchesFilter(spec, t) {
+			matched = append(matched, *t)
+		}
+	}
+	return paginateMockByID(matched, params, func(t model.Task) uint { return t.ID }), nil
+}
 
+// ListByUserIDByDueDate はユーザーのタスクを期限日の順に1ページ分取得します（spec の条件で絞り込み）。
+func (r *MockTaskRepository) ListByUserIDByDueDate(ctx context.Context, userID uint, spec filter.Spec, params pagination.KeyParams) ([]model.Task, error) {
+	params = params.Normalize()
+	after := func(t *model.Task) bool {
+		if params.AfterID == 0 {
+			return true
+		}
+		return t.DueDate.After(params.AfterKey) || (t.DueDate.Equal(params.AfterKey) && t.ID > params.AfterID)
+	}
 	var result []model.Task
 	for _, t := range r.TasksByUserID[userID] {
-		if t.Status == "pending" && !t.DueDate.Before(today) && t.DueDate.Before(tomorrow) {
+		if matchesFilter(spec, t) && after(t) {
 			result = append(result, *t)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DueDate.Equal(result[j].DueDate) {
+			return result[i].DueDate.Before(result[j].DueDate)
+		}
+		return result[i].ID < result[j].ID
+	})
+	if len(result) > params.Limit+1 {
+		result = result[:params.Limit+1]
+	}
 	return result, nil
 }
 
-// GetOverdueTasks は期限切れのタスクを取得します。
-func (r *MockTaskRepository) GetOverdueTasks(ctx context.Context, userID uint) ([]model.Task, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-
+// GetTodayTasks は期限が [dayStart, dayEnd) の未完了タスクを取得します。
+func (r *MockTaskRepository) GetTodayTasks(ctx context.Context, userID uint, dayStart, dayEnd time.Time) ([]model.Task, error) {
 	var result []model.Task
 	for _, t := range r.TasksByUserID[userID] {
-		if t.Status == "pending" && t.DueDate.Before(today) {
+		if t.Status == "pending" && !t.DueDate.Before(dayStart) && t.DueDate.Before(dayEnd) {
 			result = append(result, *t)
 		}
 	}
 	return result, nil
 }
 
-// GetAllOverdueTasks はシステム全体の期限切れタスクを取得します（通知処理用）。
-func (r *MockTaskRepository) GetAllOverdueTasks(ctx context.Context) ([]model.Task, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-
+// GetOverdueTasks は期限が dayStart より前の未完了タスクを取得します。
+func (r *MockTaskRepository) GetOverdueTasks(ctx context.Context, userID uint, dayStart time.Time) ([]model.Task, error) {
 	var result []model.Task
-	for _, t := range r.Tasks {
-		if t.Status == "pending" && t.DueDate.Before(today) {
+	for _, t := range r.TasksByUserID[userID] {
+		if t.Status == "pending" && t.DueDate.Before(dayStart) {
 			result = append(result, *t)
 		}
 	}
 	return result, nil
 }
 
-// GetAllTodayTasks はシステム全体の今日が期限のタスクを取得します（通知処理用）。
-func (r *MockTaskRepository) GetAllTodayTasks(ctx context.Context) ([]model.Task, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
-
+// GetPendingTasksDueBefore は指定したユーザーの期限が before より前の未完了タスクを取得します（通知処理用）。
+func (r *MockTaskRepository) GetPendingTasksDueBefore(ctx context.Context, userIDs []uint, before time.Time) ([]model.Task, error) {
 	var result []model.Task
 	for _, t := range r.Tasks {
-		if t.Status == "pending" && !t.DueDate.Before(today) && t.DueDate.Before(tomorrow) {
+		if containsUserID(userIDs, t.UserID) && t.Status == "pending" && t.DueDate.Before(before) {
 			result = append(result, *t)
 		}
 	}
 	return result, nil
 }
 
-// Update はタスクを更新します。
+// Update はタスクを更新します（保存済みのタスクと Version が異なる場合は ErrVersionConflict）。
 func (r *MockTaskRepository) Update(ctx context.Context, task *model.Task) error {
 	if r.UpdateFunc != nil {
 		return r.UpdateFunc(ctx, task)
 	}
 
-	task.UpdatedAt = time.Now()
+	if stored, ok := r.Tasks[task.ID]; ok && stored != task && stored.Version != task.Version {
+		return ErrVersionConflict
+	}
+	task.Version++
+	task.UpdatedAt = r.now()
 	r.Tasks[task.ID] = task
 	return nil
 }
@@ -427,6 +616,50 @@ func (r *MockTaskRepository) Delete(ctx context.Context, id uint) error {
 			}
 		}
 		delete(r.Tasks, id)
+		task.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedTasks[id] = task
+	}
+	return nil
+}
+
+// GetUnscoped は論理削除したタスクも含めてIDで取得します（テスト用）。
+func (r *MockTaskRepository) GetUnscoped(id uint) (*model.Task, error) {
+	return getUnscoped(r.Tasks, r.DeletedTasks, id)
+}
+
+// purgeDeleted は before より前に論理削除したタスクを物理削除します（MockRetentionRepository）。
+func (r *MockTaskRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedTasks, func(t *model.Task) gorm.DeletedAt { return t.DeletedAt }, before, dryRun)
+}
+
+// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除されたタスクを削除日時の新しい順に取得します。
+func (r *MockTaskRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Task, error) {
+	var result []model.Task
+	for _, t := range r.DeletedTasks {
+		if t.UserID == userID && t.DeletedAt.Time.After(deletedAfter) {
+			result = append(result, *t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.Time.After(result[j].DeletedAt.Time) })
+	return result, nil
+}
+
+// GetDeletedByID は論理削除されたタスクを取得します。
+func (r *MockTaskRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Task, error) {
+	if task, ok := r.DeletedTasks[id]; ok {
+		return task, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Restore は論理削除されたタスクを復元します。
+func (r *MockTaskRepository) Restore(ctx context.Context, id uint) error {
+	if task, ok := r.DeletedTasks[id]; ok {
+		delete(r.DeletedTasks, id)
+		task.DeletedAt = gorm.DeletedAt{}
+		task.UpdatedAt = r.now()
+		r.Tasks[id] = task
+		r.TasksByUserID[task.UserID] = append(r.TasksByUserID[task.UserID], task)
 	}
 	return nil
 }
@@ -434,18 +667,24 @@ func (r *MockTaskRepository) Delete(ctx context.Context, id uint) error {
 // MockCropRepository は CropRepository インターフェースのモック実装です。
 // 作物管理機能のテストに使用します。
 type MockCropRepository struct {
+	mockClock
+
 	// Crops はIDをキーとした作物の格納Map
 	Crops map[uint]*model.Crop
 
 	// CropsByUserID はユーザーIDをキーとした作物リストの格納Map
 	CropsByUserID map[uint][]*model.Crop
 
+	// DeletedCrops は論理削除した作物（ゴミ箱・復元用）
+	DeletedCrops map[uint]*model.Crop
+
 	// NextID は次に割り当てるID
 	NextID uint
 
 	// カスタム動作用のフック関数
 	CreateFunc             func(ctx context.Context, crop *model.Crop) error
 	GetByIDFunc            func(ctx context.Context, id uint) (*model.Crop, error)
+	GetByIDsFunc           func(ctx context.Context, ids []uint) ([]model.Crop, error)
 	GetByUserIDFunc        func(ctx context.Context, userID uint) ([]model.Crop, error)
 	GetByUserIDAndStatusFunc func(ctx context.Context, userID uint, status string) ([]model.Crop, error)
 	UpdateFunc             func(ctx context.Context, crop *model.Crop) error
@@ -457,6 +696,7 @@ func NewMockCropRepository() *MockCropRepository {
 	return &MockCropRepository{
 		Crops:         make(map[uint]*model.Crop),
 		CropsByUserID: make(map[uint][]*model.Crop),
+		DeletedCrops:  make(map[uint]*model.Crop),
 		NextID:        1,
 	}
 }
@@ -467,10 +707,14 @@ func (r *MockCropRepository) Create(ctx context.Context, crop *model.Crop) error
 		return r.CreateFunc(ctx, crop)
 	}
 
+	assignOrganization(ctx, &crop.OrganizationID)
 	crop.ID = r.NextID
 	r.NextID++
-	crop.CreatedAt = time.Now()
-	crop.UpdatedAt = time.Now()
+	crop.CreatedAt = r.now()
+	crop.UpdatedAt = r.now()
+	if crop.Version == 0 {
+		crop.Version = 1
+	}
 
 	r.Crops[crop.ID] = crop
 	r.CropsByUserID[crop.UserID] = append(r.CropsByUserID[crop.UserID], crop)
@@ -478,25 +722,55 @@ func (r *MockCropRepository) Create(ctx context.Context, crop *model.Crop) error
 	return nil
 }
 
+// CreateBatch は複数の作物を Create で順にメモリに保存します（最初のエラーで中断）。
+func (r *MockCropRepository) CreateBatch(ctx context.Context, crops []model.Crop) error {
+	for i := range crops {
+		if err := r.Create(ctx, &crops[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetByID はIDで作物を検索します。
 func (r *MockCropRepository) GetByID(ctx context.Context, id uint) (*model.Crop, error) {
 	if r.GetByIDFunc != nil {
 		return r.GetByIDFunc(ctx, id)
 	}
 
-	if crop, ok := r.Crops[id]; ok {
+	if crop, ok := r.Crops[id]; ok && inOrganizationScope(ctx, crop.OrganizationID) {
 		return crop, nil
 	}
 	return nil, gorm.ErrRecordNotFound
 }
 
+// GetByIDs は複数のIDで作物を検索します（GetByID で見つからない作物は含まない）。
+func (r *MockCropRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Crop, error) {
+	if r.GetByIDsFunc != nil {
+		return r.GetByIDsFunc(ctx, ids)
+	}
+
+	var result []model.Crop
+	for _, id := range ids {
+		crop, err := r.GetByID(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *crop)
+	}
+	return result, nil
+}
+
 // GetByUserID はユーザーIDで全作物を取得します。
 func (r *MockCropRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Crop, error) {
 	if r.GetByUserIDFunc != nil {
 		return r.GetByUserIDFunc(ctx, userID)
 	}
 
-	crops := r.CropsByUserID[userID]
+	crops := r.scopedCrops(ctx, userID)
 	result := make([]model.Crop, len(crops))
 	for i, c := range crops {
 		result[i] = *c
@@ -511,7 +785,7 @@ func (r *MockCropRepository) GetByUserIDAndStatus(ctx context.Context, userID ui
 	}
 
 	var result []model.Crop
-	for _, c := range r.CropsByUserID[userID] {
+	for _, c := range r.scopedCrops(ctx, userID) {
 		if c.Status == status {
 			result = append(result, *c)
 		}
@@ -519,29 +793,138 @@ func (r *MockCropRepository) GetByUserIDAndStatus(ctx context.Context, userID ui
 	return result, nil
 }
 
-// GetUpcomingHarvests は指定日数以内に収穫予定の作物を取得します（通知処理用）。
-func (r *MockCropRepository) GetUpcomingHarvests(ctx context.Context, daysAhead int) ([]model.Crop, error) {
-	today := time.Now().Truncate(24 * time.Hour)
-	targetDate := today.AddDate(0, 0, daysAhead)
+// ListByUserIDPaginated はユーザーの作物を1ページ分取得します（spec の条件で絞り込み）。
+func (r *MockCropRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Crop, error) {
+	var matched []model.Crop
+	for _, c := range r.scopedCrops(ctx, userID) {
+		if matchesFilter(spec, c) {
+			matched = append(matched, *c)
+		}
+	}
+	return paginateMockByID(matched, params, func(c model.Crop) uint { return c.ID }), nil
+}
+
+// GetUpcomingHarvests は指定したユーザーの収穫予定日が [from, to) の栽培中の作物を取得します（通知処理用）。
+func (r *MockCropRepository) GetUpcomingHarvests(ctx context.Context, userIDs []uint, from, to time.Time) ([]model.Crop, error) {
+	var result []model.Crop
+	for _, c := range r.Crops {
+		if containsUserID(userIDs, c.UserID) && c.Status == "growing" &&
+			!c.ExpectedHarvestDate.Before(from) &&
+			c.ExpectedHarvestDate.Before(to) {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
 
+// GetHarvestReadyCandidates は指定したユーザーの収穫可能通知の対象となる作物を取得します（通知処理用）。
+func (r *MockCropRepository) GetHarvestReadyCandidates(ctx context.Context, userIDs []uint, before time.Time) ([]model.Crop, error) {
 	var result []model.Crop
 	for _, c := range r.Crops {
-		if c.Status == "growing" &&
-			!c.ExpectedHarvestDate.Before(today) &&
-			!c.ExpectedHarvestDate.After(targetDate) {
+		if !containsUserID(userIDs, c.UserID) || c.HarvestReadyNotifiedAt != nil {
+			continue
+		}
+		if c.Status == "ready_to_harvest" ||
+			((c.Status == "planted" || c.Status == "growing") && c.ExpectedHarvestDate.Before(before)) {
 			result = append(result, *c)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// MarkHarvestReadyNotified は作物の収穫可能通知の送信日時を記録します。
+func (r *MockCropRepository) MarkHarvestReadyNotified(ctx context.Context, ids []uint, notifiedAt time.Time) error {
+	for _, id := range ids {
+		if c, ok := r.Crops[id]; ok {
+			at := notifiedAt
+			c.HarvestReadyNotifiedAt = &at
+		}
+	}
+	return nil
+}
+
+// GetFinishedPlantedBetween は植え付け日が [from, to) の収穫済み・失敗の作物を返します（アーカイブ済みを含む）。
+func (r *MockCropRepository) GetFinishedPlantedBetween(ctx context.Context, from, to time.Time) ([]model.Crop, error) {
+	var result []model.Crop
+	for _, c := range r.Crops {
+		if c.Status != "harvested" && c.Status != "failed" {
+			continue
+		}
+		if c.PlantedDate.Before(from) || !c.PlantedDate.Before(to) {
+			continue
+		}
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].UserID != result[j].UserID {
+			return result[i].UserID < result[j].UserID
+		}
+		return result[i].ID < result[j].ID
+	})
 	return result, nil
 }
 
-// Update は作物を更新します。
+// Archive は作物のアーカイブ日時を記録します（アーカイブ済みの作物は変更しません）。
+func (r *MockCropRepository) Archive(ctx context.Context, ids []uint, archivedAt time.Time) error {
+	for _, id := range ids {
+		if c, ok := r.Crops[id]; ok && c.ArchivedAt == nil {
+			at := archivedAt
+			c.ArchivedAt = &at
+		}
+	}
+	return nil
+}
+
+// inListScope は一覧の行が組織のスコープに含まれるかを判定します（組織のスコープでは組織の行、それ以外はユーザーの行）。
+func inListScope(ctx context.Context, ownerID, userID uint, organizationID *uint) bool {
+	if scoped, ok := OrganizationFromContext(ctx); ok && scoped != 0 {
+		return inOrganizationScope(ctx, organizationID)
+	}
+	return ownerID == userID && inOrganizationScope(ctx, organizationID)
+}
+
+// scopedCrops はユーザーの作物を組織のスコープで絞り込みます（組織のスコープではユーザーによらず組織の作物をID順に返す）。
+func (r *MockCropRepository) scopedCrops(ctx context.Context, userID uint) []*model.Crop {
+	candidates := r.CropsByUserID[userID]
+	if organizationID, ok := OrganizationFromContext(ctx); ok && organizationID != 0 {
+		candidates = make([]*model.Crop, 0, len(r.Crops))
+		for _, c := range r.Crops {
+			candidates = append(candidates, c)
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	}
+	var result []*model.Crop
+	for _, c := range candidates {
+		if inOrganizationScope(ctx, c.OrganizationID) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// CountByOrganizationID は組織の作物数を取得します。
+func (r *MockCropRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	for _, c := range r.Crops {
+		if c.OrganizationID != nil && *c.OrganizationID == organizationID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update は作物を更新します（保存済みの作物と Version が異なる場合は ErrVersionConflict）。
 func (r *MockCropRepository) Update(ctx context.Context, crop *model.Crop) error {
 	if r.UpdateFunc != nil {
 		return r.UpdateFunc(ctx, crop)
 	}
 
-	crop.UpdatedAt = time.Now()
+	if stored, ok := r.Crops[crop.ID]; ok && stored != crop && stored.Version != crop.Version {
+		return ErrVersionConflict
+	}
+	crop.Version++
+	crop.UpdatedAt = r.now()
 	r.Crops[crop.ID] = crop
 	return nil
 }
@@ -552,7 +935,7 @@ func (r *MockCropRepository) Delete(ctx context.Context, id uint) error {
 		return r.DeleteFunc(ctx, id)
 	}
 
-	if crop, ok := r.Crops[id]; ok {
+	if crop, ok := r.Crops[id]; ok && inOrganizationScope(ctx, crop.OrganizationID) {
 		// CropsByUserIDからも削除
 		userCrops := r.CropsByUserID[crop.UserID]
 		for i, c := range userCrops {
@@ -562,18 +945,67 @@ func (r *MockCropRepository) Delete(ctx context.Context, id uint) error {
 			}
 		}
 		delete(r.Crops, id)
+		crop.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedCrops[id] = crop
+	}
+	return nil
+}
+
+// GetUnscoped は論理削除した作物も含めてIDで取得します（テスト用）。
+func (r *MockCropRepository) GetUnscoped(id uint) (*model.Crop, error) {
+	return getUnscoped(r.Crops, r.DeletedCrops, id)
+}
+
+// purgeDeleted は before より前に論理削除した作物を物理削除します（MockRetentionRepository）。
+func (r *MockCropRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedCrops, func(c *model.Crop) gorm.DeletedAt { return c.DeletedAt }, before, dryRun)
+}
+
+// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除された作物を削除日時の新しい順に取得します（組織のスコープでは組織の作物）。
+func (r *MockCropRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Crop, error) {
+	var result []model.Crop
+	for _, c := range r.DeletedCrops {
+		if inListScope(ctx, c.UserID, userID, c.OrganizationID) && c.DeletedAt.Time.After(deletedAfter) {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.Time.After(result[j].DeletedAt.Time) })
+	return result, nil
+}
+
+// GetDeletedByID は論理削除された作物を取得します。
+func (r *MockCropRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Crop, error) {
+	if crop, ok := r.DeletedCrops[id]; ok && inOrganizationScope(ctx, crop.OrganizationID) {
+		return crop, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Restore は論理削除された作物を復元します。
+func (r *MockCropRepository) Restore(ctx context.Context, id uint) error {
+	if crop, ok := r.DeletedCrops[id]; ok && inOrganizationScope(ctx, crop.OrganizationID) {
+		delete(r.DeletedCrops, id)
+		crop.DeletedAt = gorm.DeletedAt{}
+		crop.UpdatedAt = r.now()
+		r.Crops[id] = crop
+		r.CropsByUserID[crop.UserID] = append(r.CropsByUserID[crop.UserID], crop)
 	}
 	return nil
 }
 
 // MockGrowthRecordRepository は GrowthRecordRepository インターフェースのモック実装です。
 type MockGrowthRecordRepository struct {
+	mockClock
+
 	// Records はIDをキーとした成長記録の格納Map
 	Records map[uint]*model.GrowthRecord
 
 	// RecordsByCropID は作物IDをキーとした成長記録リストの格納Map
 	RecordsByCropID map[uint][]*model.GrowthRecord
 
+	// DeletedRecords は論理削除した成長記録（作物の復元用）
+	DeletedRecords map[uint]*model.GrowthRecord
+
 	// NextID は次に割り当てるID
 	NextID uint
 }
@@ -583,6 +1015,7 @@ func NewMockGrowthRecordRepository() *MockGrowthRecordRepository {
 	return &MockGrowthRecordRepository{
 		Records:         make(map[uint]*model.GrowthRecord),
 		RecordsByCropID: make(map[uint][]*model.GrowthRecord),
+		DeletedRecords:  make(map[uint]*model.GrowthRecord),
 		NextID:          1,
 	}
 }
@@ -591,8 +1024,8 @@ func NewMockGrowthRecordRepository() *MockGrowthRecordRepository {
 func (r *MockGrowthRecordRepository) Create(ctx context.Context, record *model.GrowthRecord) error {
 	record.ID = r.NextID
 	r.NextID++
-	record.CreatedAt = time.Now()
-	record.UpdatedAt = time.Now()
+	record.CreatedAt = r.now()
+	record.UpdatedAt = r.now()
 
 	r.Records[record.ID] = record
 	r.RecordsByCropID[record.CropID] = append(r.RecordsByCropID[record.CropID], record)
@@ -600,6 +1033,16 @@ func (r *MockGrowthRecordRepository) Create(ctx context.Context, record *model.G
 	return nil
 }
 
+// CreateBatch は複数の成長記録を Create で順にメモリに保存します（最初のエラーで中断）。
+func (r *MockGrowthRecordRepository) CreateBatch(ctx context.Context, records []model.GrowthRecord) error {
+	for i := range records {
+		if err := r.Create(ctx, &records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetByID はIDで成長記録を検索します。
 func (r *MockGrowthRecordRepository) GetByID(ctx context.Context, id uint) (*model.GrowthRecord, error) {
 	if record, ok := r.Records[id]; ok {
@@ -618,6 +1061,17 @@ func (r *MockGrowthRecordRepository) GetByCropID(ctx context.Context, cropID uin
 	return result, nil
 }
 
+// GetByCropIDs は複数の作物IDで成長記録をまとめて取得します。
+func (r *MockGrowthRecordRepository) GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.GrowthRecord, error) {
+	var result []model.GrowthRecord
+	for _, cropID := range cropIDs {
+		for _, rec := range r.RecordsByCropID[cropID] {
+			result = append(result, *rec)
+		}
+	}
+	return result, nil
+}
+
 // Delete は成長記録を削除します。
 func (r *MockGrowthRecordRepository) Delete(ctx context.Context, id uint) error {
 	if record, ok := r.Records[id]; ok {
@@ -630,21 +1084,50 @@ func (r *MockGrowthRecordRepository) Delete(ctx context.Context, id uint) error
 			}
 		}
 		delete(r.Records, id)
+		record.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedRecords[id] = record
 	}
 	return nil
 }
 
+// GetUnscoped は論理削除した成長記録も含めてIDで取得します（テスト用）。
+func (r *MockGrowthRecordRepository) GetUnscoped(id uint) (*model.GrowthRecord, error) {
+	return getUnscoped(r.Records, r.DeletedRecords, id)
+}
+
+// purgeDeleted は before より前に論理削除した成長記録を物理削除します（MockRetentionRepository）。
+func (r *MockGrowthRecordRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedRecords, func(g *model.GrowthRecord) gorm.DeletedAt { return g.DeletedAt }, before, dryRun)
+}
+
 // DeleteByCropID は作物IDで全成長記録を削除します（バッチ削除）。
 func (r *MockGrowthRecordRepository) DeleteByCropID(ctx context.Context, cropID uint) error {
 	for _, record := range r.RecordsByCropID[cropID] {
 		delete(r.Records, record.ID)
+		record.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedRecords[record.ID] = record
 	}
 	delete(r.RecordsByCropID, cropID)
 	return nil
 }
 
+// RestoreByCropID は作物の deletedFrom 以降に論理削除された成長記録を復元します。
+func (r *MockGrowthRecordRepository) RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) error {
+	for id, record := range r.DeletedRecords {
+		if record.CropID == cropID && !record.DeletedAt.Time.Before(deletedFrom) {
+			delete(r.DeletedRecords, id)
+			record.DeletedAt = gorm.DeletedAt{}
+			r.Records[id] = record
+			r.RecordsByCropID[cropID] = append(r.RecordsByCropID[cropID], record)
+		}
+	}
+	return nil
+}
+
 // MockHarvestRepository は HarvestRepository インターフェースのモック実装です。
 type MockHarvestRepository struct {
+	mockClock
+
 	// Harvests はIDをキーとした収穫記録の格納Map
 	Harvests map[uint]*model.Harvest
 
@@ -654,9 +1137,18 @@ type MockHarvestRepository struct {
 	// HarvestsByUserID はユーザーIDをキーとした収穫記録リストの格納Map（Analytics用）
 	HarvestsByUserID map[uint][]*model.Harvest
 
+	// DeletedHarvests は論理削除した収穫記録（ゴミ箱・復元用）
+	DeletedHarvests map[uint]*model.Harvest
+
 	// NextID は次に割り当てるID
 	NextID uint
 
+	// crops はゴミ箱の一覧で収穫記録の作物（所有者・組織）を参照する作物のモックです（NewMockRepositories で設定）
+	crops *MockCropRepository
+
+	// BenchmarkYields は作物名（小文字）をキーとしたベンチマーク集計結果
+	BenchmarkYields map[string][]UserCropYield
+
 	// カスタム動作用のフック関数
 	GetByUserIDWithDateRangeFunc func(ctx context.Context, userID uint, startDate, endDate *time.Time) ([]model.Harvest, error)
 }
@@ -667,6 +1159,8 @@ func NewMockHarvestRepository() *MockHarvestRepository {
 		Harvests:         make(map[uint]*model.Harvest),
 		HarvestsByCropID: make(map[uint][]*model.Harvest),
 		HarvestsByUserID: make(map[uint][]*model.Harvest),
+		DeletedHarvests:  make(map[uint]*model.Harvest),
+		BenchmarkYields:  make(map[string][]UserCropYield),
 		NextID:           1,
 	}
 }
@@ -675,8 +1169,8 @@ func NewMockHarvestRepository() *MockHarvestRepository {
 func (r *MockHarvestRepository) Create(ctx context.Context, harvest *model.Harvest) error {
 	harvest.ID = r.NextID
 	r.NextID++
-	harvest.CreatedAt = time.Now()
-	harvest.UpdatedAt = time.Now()
+	harvest.CreatedAt = r.now()
+	harvest.UpdatedAt = r.now()
 
 	r.Harvests[harvest.ID] = harvest
 	r.HarvestsByCropID[harvest.CropID] = append(r.HarvestsByCropID[harvest.CropID], harvest)
@@ -684,6 +1178,16 @@ func (r *MockHarvestRepository) Create(ctx context.Context, harvest *model.Harve
 	return nil
 }
 
+// CreateBatch は複数の収穫記録を Create で順にメモリに保存します（最初のエラーで中断）。
+func (r *MockHarvestRepository) CreateBatch(ctx context.Context, harvests []model.Harvest) error {
+	for i := range harvests {
+		if err := r.Create(ctx, &harvests[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetByID はIDで収穫記録を検索します。
 func (r *MockHarvestRepository) GetByID(ctx context.Context, id uint) (*model.Harvest, error) {
 	if harvest, ok := r.Harvests[id]; ok {
@@ -702,6 +1206,23 @@ func (r *MockHarvestRepository) GetByCropID(ctx context.Context, cropID uint) ([
 	return result, nil
 }
 
+// ListByCropIDPaginated は作物の収穫記録を1ページ分取得します。
+func (r *MockHarvestRepository) ListByCropIDPaginated(ctx context.Context, cropID uint, params pagination.Params) ([]model.Harvest, error) {
+	harvests, _ := r.GetByCropID(ctx, cropID)
+	return paginateMockByID(harvests, params, func(h model.Harvest) uint { return h.ID }), nil
+}
+
+// GetByCropIDs は複数の作物IDで収穫記録をまとめて取得します。
+func (r *MockHarvestRepository) GetByCropIDs(ctx context.Context, cropIDs []uint) ([]model.Harvest, error) {
+	var result []model.Harvest
+	for _, cropID := range cropIDs {
+		for _, h := range r.HarvestsByCropID[cropID] {
+			result = append(result, *h)
+		}
+	}
+	return result, nil
+}
+
 // Delete は収穫記録を削除します。
 func (r *MockHarvestRepository) Delete(ctx context.Context, id uint) error {
 	if harvest, ok := r.Harvests[id]; ok {
@@ -714,19 +1235,87 @@ func (r *MockHarvestRepository) Delete(ctx context.Context, id uint) error {
 			}
 		}
 		delete(r.Harvests, id)
+		harvest.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedHarvests[id] = harvest
 	}
 	return nil
 }
 
+// GetUnscoped は論理削除した収穫記録も含めてIDで取得します（テスト用）。
+func (r *MockHarvestRepository) GetUnscoped(id uint) (*model.Harvest, error) {
+	return getUnscoped(r.Harvests, r.DeletedHarvests, id)
+}
+
+// purgeDeleted は before より前に論理削除した収穫記録を物理削除します（MockRetentionRepository）。
+func (r *MockHarvestRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedHarvests, func(h *model.Harvest) gorm.DeletedAt { return h.DeletedAt }, before, dryRun)
+}
+
 // DeleteByCropID は作物IDで全収穫記録を削除します（バッチ削除）。
 func (r *MockHarvestRepository) DeleteByCropID(ctx context.Context, cropID uint) error {
 	for _, harvest := range r.HarvestsByCropID[cropID] {
 		delete(r.Harvests, harvest.ID)
+		harvest.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedHarvests[harvest.ID] = harvest
 	}
 	delete(r.HarvestsByCropID, cropID)
 	return nil
 }
 
+// GetDeletedByUserID はユーザーの作物（削除されていないもの）の deletedAfter より後に論理削除された収穫記録を取得します。
+func (r *MockHarvestRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Harvest, error) {
+	var result []model.Harvest
+	if r.crops == nil {
+		return result, nil
+	}
+	for _, h := range r.DeletedHarvests {
+		crop, ok := r.crops.Crops[h.CropID]
+		if ok && inListScope(ctx, crop.UserID, userID, crop.OrganizationID) && h.DeletedAt.Time.After(deletedAfter) {
+			result = append(result, *h)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.Time.After(result[j].DeletedAt.Time) })
+	return result, nil
+}
+
+// GetDeletedByID は論理削除された収穫記録を取得します。
+func (r *MockHarvestRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Harvest, error) {
+	if harvest, ok := r.DeletedHarvests[id]; ok {
+		return harvest, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Restore は論理削除された収穫記録を復元します。
+func (r *MockHarvestRepository) Restore(ctx context.Context, id uint) error {
+	if harvest, ok := r.DeletedHarvests[id]; ok {
+		r.restore(harvest)
+	}
+	return nil
+}
+
+// RestoreByCropID は作物の deletedFrom 以降に論理削除された収穫記録を復元し、復元した収穫記録のIDを返します。
+func (r *MockHarvestRepository) RestoreByCropID(ctx context.Context, cropID uint, deletedFrom time.Time) ([]uint, error) {
+	var ids []uint
+	for _, harvest := range r.DeletedHarvests {
+		if harvest.CropID == cropID && !harvest.DeletedAt.Time.Before(deletedFrom) {
+			r.restore(harvest)
+			ids = append(ids, harvest.ID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// restore は論理削除された収穫記録を Harvests・HarvestsByCropID に戻します。
+func (r *MockHarvestRepository) restore(harvest *model.Harvest) {
+	delete(r.DeletedHarvests, harvest.ID)
+	harvest.DeletedAt = gorm.DeletedAt{}
+	harvest.UpdatedAt = r.now()
+	r.Harvests[harvest.ID] = harvest
+	r.HarvestsByCropID[harvest.CropID] = append(r.HarvestsByCropID[harvest.CropID], harvest)
+}
+
 // GetByUserIDWithDateRange はユーザーの収穫記録を日付範囲でフィルタして取得します。
 // HarvestsByUserIDに事前にデータをセットするか、GetByUserIDWithDateRangeFuncを使用してください。
 func (r *MockHarvestRepository) GetByUserIDWithDateRange(ctx context.Context, userID uint, startDate, endDate *time.Time) ([]model.Harvest, error) {
@@ -751,13 +1340,18 @@ func (r *MockHarvestRepository) GetByUserIDWithDateRange(ctx context.Context, us
 	return result, nil
 }
 
+// GetBenchmarkYields はBenchmarkYieldsに事前にセットされた集計結果を返します。
+func (r *MockHarvestRepository) GetBenchmarkYields(ctx context.Context, cropName string) ([]UserCropYield, error) {
+	return r.BenchmarkYields[strings.ToLower(cropName)], nil
+}
+
 // AddHarvestForUser はテスト用にユーザーIDに関連付けて収穫記録を追加します。
 // Analytics機能のテストで使用します。
 func (r *MockHarvestRepository) AddHarvestForUser(userID uint, harvest *model.Harvest) {
 	harvest.ID = r.NextID
 	r.NextID++
-	harvest.CreatedAt = time.Now()
-	harvest.UpdatedAt = time.Now()
+	harvest.CreatedAt = r.now()
+	harvest.UpdatedAt = r.now()
 
 	r.Harvests[harvest.ID] = harvest
 	r.HarvestsByCropID[harvest.CropID] = append(r.HarvestsByCropID[harvest.CropID], harvest)
@@ -767,15 +1361,24 @@ func (r *MockHarvestRepository) AddHarvestForUser(userID uint, harvest *model.Ha
 // MockPlotRepository は PlotRepository インターフェースのモック実装です。
 // 区画管理機能のテストに使用します。
 type MockPlotRepository struct {
+	mockClock
+
 	// Plots はIDをキーとした区画の格納Map
 	Plots map[uint]*model.Plot
 
 	// PlotsByUserID はユーザーIDをキーとした区画リストの格納Map
 	PlotsByUserID map[uint][]*model.Plot
 
+	// DeletedPlots は論理削除した区画（ゴミ箱・復元用）
+	DeletedPlots map[uint]*model.Plot
+
 	// NextID は次に割り当てるID
 	NextID uint
 
+	// assignments, crops は GetLayoutByUserID の配置・作物の参照先（NewMockRepositories で設定）
+	assignments *MockPlotAssignmentRepository
+	crops       *MockCropRepository
+
 	// カスタム動作用のフック関数
 	CreateFunc               func(ctx context.Context, plot *model.Plot) error
 	GetByIDFunc              func(ctx context.Context, id uint) (*model.Plot, error)
@@ -789,6 +1392,7 @@ type MockPlotRepository struct {
 func NewMockPlotRepository() *MockPlotRepository {
 	return &MockPlotRepository{
 		Plots:         make(map[uint]*model.Plot),
+		DeletedPlots:  make(map[uint]*model.Plot),
 		PlotsByUserID: make(map[uint][]*model.Plot),
 		NextID:        1,
 	}
@@ -800,10 +1404,14 @@ func (r *MockPlotRepository) Create(ctx context.Context, plot *model.Plot) error
 		return r.CreateFunc(ctx, plot)
 	}
 
+	assignOrganization(ctx, &plot.OrganizationID)
 	plot.ID = r.NextID
 	r.NextID++
-	plot.CreatedAt = time.Now()
-	plot.UpdatedAt = time.Now()
+	plot.CreatedAt = r.now()
+	plot.UpdatedAt = r.now()
+	if plot.Version == 0 {
+		plot.Version = 1
+	}
 
 	r.Plots[plot.ID] = plot
 	r.PlotsByUserID[plot.UserID] = append(r.PlotsByUserID[plot.UserID], plot)
@@ -817,19 +1425,35 @@ func (r *MockPlotRepository) GetByID(ctx context.Context, id uint) (*model.Plot,
 		return r.GetByIDFunc(ctx, id)
 	}
 
-	if plot, ok := r.Plots[id]; ok {
+	if plot, ok := r.Plots[id]; ok && inOrganizationScope(ctx, plot.OrganizationID) {
 		return plot, nil
 	}
 	return nil, gorm.ErrRecordNotFound
 }
 
+// GetByIDs は複数のIDで区画を検索します（GetByID で見つからない区画は含まない）。
+func (r *MockPlotRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Plot, error) {
+	var result []model.Plot
+	for _, id := range ids {
+		plot, err := r.GetByID(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *plot)
+	}
+	return result, nil
+}
+
 // GetByUserID はユーザーIDで全区画を取得します。
 func (r *MockPlotRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Plot, error) {
 	if r.GetByUserIDFunc != nil {
 		return r.GetByUserIDFunc(ctx, userID)
 	}
 
-	plots := r.PlotsByUserID[userID]
+	plots := r.scopedPlots(ctx, userID)
 	result := make([]model.Plot, len(plots))
 	for i, p := range plots {
 		result[i] = *p
@@ -837,6 +1461,29 @@ func (r *MockPlotRepository) GetByUserID(ctx context.Context, userID uint) ([]mo
 	return result, nil
 }
 
+// GetLayoutByUserID はユーザーの区画を現在アクティブな配置と作物とともに取得します。
+// 見つからない作物（削除済み）の配置は Crop がゼロ値のままです。
+func (r *MockPlotRepository) GetLayoutByUserID(ctx context.Context, userID uint) ([]model.Plot, error) {
+	plots, err := r.GetByUserID(ctx, userID)
+	if err != nil || r.assignments == nil {
+		return plots, err
+	}
+	for i := range plots {
+		assignment, err := r.assignments.GetActiveByPlotID(ctx, plots[i].ID)
+		if err != nil {
+			continue
+		}
+		active := *assignment
+		if r.crops != nil {
+			if crop, ok := r.crops.Crops[active.CropID]; ok {
+				active.Crop = *crop
+			}
+		}
+		plots[i].PlotAssignments = []model.PlotAssignment{active}
+	}
+	return plots, nil
+}
+
 // GetByUserIDAndStatus はユーザーIDとステータスで区画を取得します。
 func (r *MockPlotRepository) GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Plot, error) {
 	if r.GetByUserIDAndStatusFunc != nil {
@@ -844,7 +1491,7 @@ func (r *MockPlotRepository) GetByUserIDAndStatus(ctx context.Context, userID ui
 	}
 
 	var result []model.Plot
-	for _, p := range r.PlotsByUserID[userID] {
+	for _, p := range r.scopedPlots(ctx, userID) {
 		if p.Status == status {
 			result = append(result, *p)
 		}
@@ -852,13 +1499,58 @@ func (r *MockPlotRepository) GetByUserIDAndStatus(ctx context.Context, userID ui
 	return result, nil
 }
 
-// Update は区画を更新します。
+// ListByUserIDPaginated はユーザーの区画を1ページ分取得します（spec の条件で絞り込み）。
+func (r *MockPlotRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Plot, error) {
+	var matched []model.Plot
+	for _, p := range r.scopedPlots(ctx, userID) {
+		if matchesFilter(spec, p) {
+			matched = append(matched, *p)
+		}
+	}
+	return paginateMockByID(matched, params, func(p model.Plot) uint { return p.ID }), nil
+}
+
+// scopedPlots はユーザーの区画を組織のスコープで絞り込みます（組織のスコープではユーザーによらず組織の区画をID順に返す）。
+func (r *MockPlotRepository) scopedPlots(ctx context.Context, userID uint) []*model.Plot {
+	candidates := r.PlotsByUserID[userID]
+	if organizationID, ok := OrganizationFromContext(ctx); ok && organizationID != 0 {
+		candidates = make([]*model.Plot, 0, len(r.Plots))
+		for _, p := range r.Plots {
+			candidates = append(candidates, p)
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	}
+	var result []*model.Plot
+	for _, p := range candidates {
+		if inOrganizationScope(ctx, p.OrganizationID) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// CountByOrganizationID は組織の区画数を取得します。
+func (r *MockPlotRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	for _, p := range r.Plots {
+		if p.OrganizationID != nil && *p.OrganizationID == organizationID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update は区画を更新します（保存済みの区画と Version が異なる場合は ErrVersionConflict）。
 func (r *MockPlotRepository) Update(ctx context.Context, plot *model.Plot) error {
 	if r.UpdateFunc != nil {
 		return r.UpdateFunc(ctx, plot)
 	}
 
-	plot.UpdatedAt = time.Now()
+	if stored, ok := r.Plots[plot.ID]; ok && stored != plot && stored.Version != plot.Version {
+		return ErrVersionConflict
+	}
+	plot.Version++
+	plot.UpdatedAt = r.now()
 	r.Plots[plot.ID] = plot
 	return nil
 }
@@ -869,7 +1561,7 @@ func (r *MockPlotRepository) Delete(ctx context.Context, id uint) error {
 		return r.DeleteFunc(ctx, id)
 	}
 
-	if plot, ok := r.Plots[id]; ok {
+	if plot, ok := r.Plots[id]; ok && inOrganizationScope(ctx, plot.OrganizationID) {
 		// PlotsByUserIDからも削除
 		userPlots := r.PlotsByUserID[plot.UserID]
 		for i, p := range userPlots {
@@ -879,6 +1571,50 @@ func (r *MockPlotRepository) Delete(ctx context.Context, id uint) error {
 			}
 		}
 		delete(r.Plots, id)
+		plot.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedPlots[id] = plot
+	}
+	return nil
+}
+
+// GetUnscoped は論理削除した区画も含めてIDで取得します（テスト用）。
+func (r *MockPlotRepository) GetUnscoped(id uint) (*model.Plot, error) {
+	return getUnscoped(r.Plots, r.DeletedPlots, id)
+}
+
+// purgeDeleted は before より前に論理削除した区画を物理削除します（MockRetentionRepository）。
+func (r *MockPlotRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedPlots, func(p *model.Plot) gorm.DeletedAt { return p.DeletedAt }, before, dryRun)
+}
+
+// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除された区画を削除日時の新しい順に取得します（組織のスコープでは組織の区画）。
+func (r *MockPlotRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Plot, error) {
+	var result []model.Plot
+	for _, p := range r.DeletedPlots {
+		if inListScope(ctx, p.UserID, userID, p.OrganizationID) && p.DeletedAt.Time.After(deletedAfter) {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.Time.After(result[j].DeletedAt.Time) })
+	return result, nil
+}
+
+// GetDeletedByID は論理削除された区画を取得します。
+func (r *MockPlotRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Plot, error) {
+	if plot, ok := r.DeletedPlots[id]; ok && inOrganizationScope(ctx, plot.OrganizationID) {
+		return plot, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// Restore は論理削除された区画を復元します。
+func (r *MockPlotRepository) Restore(ctx context.Context, id uint) error {
+	if plot, ok := r.DeletedPlots[id]; ok && inOrganizationScope(ctx, plot.OrganizationID) {
+		delete(r.DeletedPlots, id)
+		plot.DeletedAt = gorm.DeletedAt{}
+		plot.UpdatedAt = r.now()
+		r.Plots[id] = plot
+		r.PlotsByUserID[plot.UserID] = append(r.PlotsByUserID[plot.UserID], plot)
 	}
 	return nil
 }
@@ -886,6 +1622,8 @@ func (r *MockPlotRepository) Delete(ctx context.Context, id uint) error {
 // MockPlotAssignmentRepository は PlotAssignmentRepository インターフェースのモック実装です。
 // 区画への作物配置管理機能のテストに使用します。
 type MockPlotAssignmentRepository struct {
+	mockClock
+
 	// Assignments はIDをキーとした配置の格納Map
 	Assignments map[uint]*model.PlotAssignment
 
@@ -895,6 +1633,9 @@ type MockPlotAssignmentRepository struct {
 	// AssignmentsByCropID は作物IDをキーとした配置リストの格納Map
 	AssignmentsByCropID map[uint][]*model.PlotAssignment
 
+	// DeletedAssignments は論理削除した配置（区画の復元用）
+	DeletedAssignments map[uint]*model.PlotAssignment
+
 	// NextID は次に割り当てるID
 	NextID uint
 }
@@ -905,6 +1646,7 @@ func NewMockPlotAssignmentRepository() *MockPlotAssignmentRepository {
 		Assignments:         make(map[uint]*model.PlotAssignment),
 		AssignmentsByPlotID: make(map[uint][]*model.PlotAssignment),
 		AssignmentsByCropID: make(map[uint][]*model.PlotAssignment),
+		DeletedAssignments:  make(map[uint]*model.PlotAssignment),
 		NextID:              1,
 	}
 }
@@ -913,8 +1655,8 @@ func NewMockPlotAssignmentRepository() *MockPlotAssignmentRepository {
 func (r *MockPlotAssignmentRepository) Create(ctx context.Context, assignment *model.PlotAssignment) error {
 	assignment.ID = r.NextID
 	r.NextID++
-	assignment.CreatedAt = time.Now()
-	assignment.UpdatedAt = time.Now()
+	assignment.CreatedAt = r.now()
+	assignment.UpdatedAt = r.now()
 
 	r.Assignments[assignment.ID] = assignment
 	r.AssignmentsByPlotID[assignment.PlotID] = append(r.AssignmentsByPlotID[assignment.PlotID], assignment)
@@ -931,6 +1673,17 @@ func (r *MockPlotAssignmentRepository) GetByID(ctx context.Context, id uint) (*m
 	return nil, gorm.ErrRecordNotFound
 }
 
+// GetByIDs は複数のIDで区画配置を検索します（見つからない配置は含まない）。
+func (r *MockPlotAssignmentRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.PlotAssignment, error) {
+	var result []model.PlotAssignment
+	for _, id := range ids {
+		if assignment, ok := r.Assignments[id]; ok {
+			result = append(result, *assignment)
+		}
+	}
+	return result, nil
+}
+
 // GetByPlotID は区画IDで全配置履歴を取得します。
 func (r *MockPlotAssignmentRepository) GetByPlotID(ctx context.Context, plotID uint) ([]model.PlotAssignment, error) {
 	assignments := r.AssignmentsByPlotID[plotID]
@@ -941,6 +1694,23 @@ func (r *MockPlotAssignmentRepository) GetByPlotID(ctx context.Context, plotID u
 	return result, nil
 }
 
+// GetByPlotIDs は複数の区画の全配置履歴を配置日の昇順で取得します。
+func (r *MockPlotAssignmentRepository) GetByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error) {
+	var result []model.PlotAssignment
+	for _, plotID := range plotIDs {
+		for _, a := range r.AssignmentsByPlotID[plotID] {
+			result = append(result, *a)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].AssignedDate.Equal(result[j].AssignedDate) {
+			return result[i].AssignedDate.Before(result[j].AssignedDate)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
 // GetActiveByPlotID は区画の現在アクティブな配置を取得します。
 func (r *MockPlotAssignmentRepository) GetActiveByPlotID(ctx context.Context, plotID uint) (*model.PlotAssignment, error) {
 	for _, a := range r.AssignmentsByPlotID[plotID] {
@@ -951,6 +1721,17 @@ func (r *MockPlotAssignmentRepository) GetActiveByPlotID(ctx context.Context, pl
 	return nil, gorm.ErrRecordNotFound
 }
 
+// GetActiveByPlotIDs は複数の区画の現在アクティブな配置を取得します（GetActiveByPlotID と同じ配置）。
+func (r *MockPlotAssignmentRepository) GetActiveByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error) {
+	var result []model.PlotAssignment
+	for _, plotID := range plotIDs {
+		if assignment, err := r.GetActiveByPlotID(ctx, plotID); err == nil {
+			result = append(result, *assignment)
+		}
+	}
+	return result, nil
+}
+
 // GetByCropID は作物IDで全配置履歴を取得します。
 func (r *MockPlotAssignmentRepository) GetByCropID(ctx context.Context, cropID uint) ([]model.PlotAssignment, error) {
 	assignments := r.AssignmentsByCropID[cropID]
@@ -963,7 +1744,7 @@ func (r *MockPlotAssignmentRepository) GetByCropID(ctx context.Context, cropID u
 
 // Update は区画配置を更新します。
 func (r *MockPlotAssignmentRepository) Update(ctx context.Context, assignment *model.PlotAssignment) error {
-	assignment.UpdatedAt = time.Now()
+	assignment.UpdatedAt = r.now()
 	r.Assignments[assignment.ID] = assignment
 	return nil
 }
@@ -988,10 +1769,22 @@ func (r *MockPlotAssignmentRepository) Delete(ctx context.Context, id uint) erro
 			}
 		}
 		delete(r.Assignments, id)
+		assignment.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedAssignments[id] = assignment
 	}
 	return nil
 }
 
+// GetUnscoped は論理削除した配置も含めてIDで取得します（テスト用）。
+func (r *MockPlotAssignmentRepository) GetUnscoped(id uint) (*model.PlotAssignment, error) {
+	return getUnscoped(r.Assignments, r.DeletedAssignments, id)
+}
+
+// purgeDeleted は before より前に論理削除した配置を物理削除します（MockRetentionRepository）。
+func (r *MockPlotAssignmentRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedAssignments, func(a *model.PlotAssignment) gorm.DeletedAt { return a.DeletedAt }, before, dryRun)
+}
+
 // DeleteByPlotID は区画IDで全配置を削除します（バッチ削除）。
 func (r *MockPlotAssignmentRepository) DeleteByPlotID(ctx context.Context, plotID uint) error {
 	for _, assignment := range r.AssignmentsByPlotID[plotID] {
@@ -1004,13 +1797,31 @@ func (r *MockPlotAssignmentRepository) DeleteByPlotID(ctx context.Context, plotI
 			}
 		}
 		delete(r.Assignments, assignment.ID)
+		assignment.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedAssignments[assignment.ID] = assignment
 	}
 	delete(r.AssignmentsByPlotID, plotID)
 	return nil
 }
 
+// RestoreByPlotID は区画の deletedFrom 以降に論理削除された配置を復元します。
+func (r *MockPlotAssignmentRepository) RestoreByPlotID(ctx context.Context, plotID uint, deletedFrom time.Time) error {
+	for id, assignment := range r.DeletedAssignments {
+		if assignment.PlotID == plotID && !assignment.DeletedAt.Time.Before(deletedFrom) {
+			delete(r.DeletedAssignments, id)
+			assignment.DeletedAt = gorm.DeletedAt{}
+			r.Assignments[id] = assignment
+			r.AssignmentsByPlotID[plotID] = append(r.AssignmentsByPlotID[plotID], assignment)
+			r.AssignmentsByCropID[assignment.CropID] = append(r.AssignmentsByCropID[assignment.CropID], assignment)
+		}
+	}
+	return nil
+}
+
 // MockDeviceTokenRepository は DeviceTokenRepository インターフェースのモック実装です。
 type MockDeviceTokenRepository struct {
+	mockClock
+
 	Tokens          map[uint]*model.DeviceToken
 	TokensByUserID  map[uint][]*model.DeviceToken
 	TokensByToken   map[string]*model.DeviceToken
@@ -1030,8 +1841,8 @@ func NewMockDeviceTokenRepository() *MockDeviceTokenRepository {
 func (r *MockDeviceTokenRepository) Create(ctx context.Context, token *model.DeviceToken) error {
 	token.ID = r.NextID
 	r.NextID++
-	token.CreatedAt = time.Now()
-	token.UpdatedAt = time.Now()
+	token.CreatedAt = r.now()
+	token.UpdatedAt = r.now()
 	r.Tokens[token.ID] = token
 	r.TokensByUserID[token.UserID] = append(r.TokensByUserID[token.UserID], token)
 	r.TokensByToken[token.Token] = token
@@ -1081,7 +1892,7 @@ func (r *MockDeviceTokenRepository) GetActiveByUserID(ctx context.Context, userI
 }
 
 func (r *MockDeviceTokenRepository) Update(ctx context.Context, token *model.DeviceToken) error {
-	token.UpdatedAt = time.Now()
+	token.UpdatedAt = r.now()
 	r.Tokens[token.ID] = token
 	return nil
 }
@@ -1110,16 +1921,43 @@ func (r *MockDeviceTokenRepository) DeleteByUserID(ctx context.Context, userID u
 	return nil
 }
 
-func (r *MockDeviceTokenRepository) DeactivateToken(ctx context.Context, id uint) error {
+func (r *MockDeviceTokenRepository) DeactivateToken(ctx context.Context, id uint, reason string) error {
 	if token, ok := r.Tokens[id]; ok {
+		now := r.now()
 		token.IsActive = false
-		token.UpdatedAt = time.Now()
+		token.UpdatedAt = now
+		token.DeactivatedAt = &now
+		token.DeactivationReason = reason
+	}
+	return nil
+}
+
+func (r *MockDeviceTokenRepository) UpdateEndpointARN(ctx context.Context, id uint, endpointARN string) error {
+	if token, ok := r.Tokens[id]; ok {
+		token.EndpointARN = endpointARN
 	}
 	return nil
 }
 
+func (r *MockDeviceTokenRepository) DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, token := range r.Tokens {
+		inactiveSince := token.UpdatedAt
+		if token.DeactivatedAt != nil {
+			inactiveSince = *token.DeactivatedAt
+		}
+		if !token.IsActive && inactiveSince.Before(before) {
+			_ = r.Delete(ctx, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 // MockNotificationLogRepository は NotificationLogRepository インターフェースのモック実装です。
 type MockNotificationLogRepository struct {
+	mockClock
+
 	Logs                 map[uint]*model.NotificationLog
 	LogsByUserID         map[uint][]*model.NotificationLog
 	LogsByDeduplication  map[string]*model.NotificationLog
@@ -1139,8 +1977,8 @@ func NewMockNotificationLogRepository() *MockNotificationLogRepository {
 func (r *MockNotificationLogRepository) Create(ctx context.Context, log *model.NotificationLog) error {
 	log.ID = r.NextID
 	r.NextID++
-	log.CreatedAt = time.Now()
-	log.UpdatedAt = time.Now()
+	log.CreatedAt = r.now()
+	log.UpdatedAt = r.now()
 	r.Logs[log.ID] = log
 	r.LogsByUserID[log.UserID] = append(r.LogsByUserID[log.UserID], log)
 	if log.DeduplicationKey != "" {
@@ -1158,7 +1996,7 @@ func (r *MockNotificationLogRepository) GetByID(ctx context.Context, id uint) (*
 
 func (r *MockNotificationLogRepository) GetByDeduplicationKey(ctx context.Context, key string) (*model.NotificationLog, error) {
 	if log, ok := r.LogsByDeduplication[key]; ok {
-		if log.ExpiresAt.After(time.Now()) {
+		if log.ExpiresAt.After(r.now()) {
 			return log, nil
 		}
 	}
@@ -1174,10 +2012,66 @@ func (r *MockNotificationLogRepository) GetByUserID(ctx context.Context, userID
 	return result, nil
 }
 
+func (r *MockNotificationLogRepository) GetInboxByUserID(ctx context.Context, userID uint, limit, offset int, unreadOnly bool) ([]model.NotificationLog, int64, error) {
+	logs := r.LogsByUserID[userID]
+	var matched []model.NotificationLog
+	for i := len(logs) - 1; i >= 0; i-- {
+		if unreadOnly && logs[i].ReadAt != nil {
+			continue
+		}
+		if strings.HasPrefix(logs[i].NotificationType, "transactional_") {
+			continue
+		}
+		matched = append(matched, *logs[i])
+	}
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return []model.NotificationLog{}, total, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+// ListInboxByUserIDPaginated はアプリ内受信箱用にユーザーの通知ログを1ページ分取得します。
+func (r *MockNotificationLogRepository) ListInboxByUserIDPaginated(ctx context.Context, userID uint, unreadOnly bool, params pagination.Params) ([]model.NotificationLog, error) {
+	var matched []model.NotificationLog
+	for _, log := range r.LogsByUserID[userID] {
+		if unreadOnly && log.ReadAt != nil {
+			continue
+		}
+		if strings.HasPrefix(log.NotificationType, "transactional_") {
+			continue
+		}
+		matched = append(matched, *log)
+	}
+	return paginateMockByID(matched, params, func(l model.NotificationLog) uint { return l.ID }), nil
+}
+
+func (r *MockNotificationLogRepository) CountUnreadByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	for _, log := range r.LogsByUserID[userID] {
+		if log.ReadAt == nil && !strings.HasPrefix(log.NotificationType, "transactional_") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MockNotificationLogRepository) MarkAsRead(ctx context.Context, id uint, readAt time.Time) error {
+	if log, ok := r.Logs[id]; ok && log.ReadAt == nil {
+		log.ReadAt = &readAt
+	}
+	return nil
+}
+
 func (r *MockNotificationLogRepository) GetPendingNotifications(ctx context.Context, limit int) ([]model.NotificationLog, error) {
 	var result []model.NotificationLog
+	now := r.now()
 	for _, log := range r.Logs {
-		if log.Status == "pending" && log.RetryCount < 3 {
+		if (log.Status == "pending" || log.Status == "deferred") && log.RetryCount < 3 && (log.NextRetryAt == nil || !log.NextRetryAt.After(now)) {
 			result = append(result, *log)
 			if limit > 0 && len(result) >= limit {
 				break
@@ -1188,13 +2082,46 @@ func (r *MockNotificationLogRepository) GetPendingNotifications(ctx context.Cont
 }
 
 func (r *MockNotificationLogRepository) Update(ctx context.Context, log *model.NotificationLog) error {
-	log.UpdatedAt = time.Now()
+	log.UpdatedAt = r.now()
 	r.Logs[log.ID] = log
 	return nil
 }
 
+func (r *MockNotificationLogRepository) CountPushesSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, log := range r.LogsByUserID[userID] {
+		if log.Status == "sent" && log.SentAt != nil && !log.SentAt.Before(since) && strings.Contains(log.Channel, "push") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MockNotificationLogRepository) GetPushRateLimited(ctx context.Context, limit int) ([]model.NotificationLog, error) {
+	var result []model.NotificationLog
+	for _, log := range r.Logs {
+		if log.PushRateLimited {
+			result = append(result, *log)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockNotificationLogRepository) ClearPushRateLimited(ctx context.Context, ids []uint) error {
+	for _, id := range ids {
+		if log, ok := r.Logs[id]; ok {
+			log.PushRateLimited = false
+		}
+	}
+	return nil
+}
+
 func (r *MockNotificationLogRepository) DeleteExpired(ctx context.Context) error {
-	now := time.Now()
+	now := r.now()
 	for id, log := range r.Logs {
 		if log.ExpiresAt.Before(now) {
 			delete(r.LogsByDeduplication, log.DeduplicationKey)
@@ -1211,112 +2138,2054 @@ func (r *MockNotificationLogRepository) DeleteExpired(ctx context.Context) error
 	return nil
 }
 
-// MockRepositories は Repositories インターフェースのモック実装です。
-// 各リポジトリのモックを保持し、テストで依存性注入するために使用します。
-//
-// 設計パターン: ファサードパターン
-// - 複数のモックリポジトリを1つのインターフェースでまとめる
-// - Service層は本番/テストを意識せずRepositoriesインターフェースを使用
-type MockRepositories struct {
-	userRepo            *MockUserRepository
-	gardenRepo          *MockGardenRepository
-	plantRepo           *MockPlantRepository
-	careLogRepo         *MockCareLogRepository
-	tokenBlacklistRepo  *MockTokenBlacklistRepository
-	taskRepo            *MockTaskRepository
-	cropRepo            *MockCropRepository
-	growthRecordRepo    *MockGrowthRecordRepository
-	harvestRepo         *MockHarvestRepository
-	plotRepo            *MockPlotRepository
-	plotAssignmentRepo  *MockPlotAssignmentRepository
-	deviceTokenRepo     *MockDeviceTokenRepository
-	notificationLogRepo *MockNotificationLogRepository
+func (r *MockNotificationLogRepository) CountExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error) {
+	var count int64
+	for _, log := range r.Logs {
+		if log.ExpiresAt.Before(now) || log.CreatedAt.Before(createdBefore) {
+			count++
+		}
+	}
+	return count, nil
 }
 
-// NewMockRepositories は新しいMockRepositoriesを作成します。
-// 各モックリポジトリを初期化して返します。
-func NewMockRepositories() *MockRepositories {
-	return &MockRepositories{
-		userRepo:            NewMockUserRepository(),
-		gardenRepo:          &MockGardenRepository{},
-		plantRepo:           &MockPlantRepository{},
-		careLogRepo:         &MockCareLogRepository{},
-		tokenBlacklistRepo:  NewMockTokenBlacklistRepository(),
-		taskRepo:            NewMockTaskRepository(),
-		cropRepo:            NewMockCropRepository(),
-		growthRecordRepo:    NewMockGrowthRecordRepository(),
-		harvestRepo:         NewMockHarvestRepository(),
-		plotRepo:            NewMockPlotRepository(),
-		plotAssignmentRepo:  NewMockPlotAssignmentRepository(),
-		deviceTokenRepo:     NewMockDeviceTokenRepository(),
-		notificationLogRepo: NewMockNotificationLogRepository(),
+func (r *MockNotificationLogRepository) DeleteExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error) {
+	var deleted int64
+	for id, log := range r.Logs {
+		if !log.ExpiresAt.Before(now) && !log.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		delete(r.LogsByDeduplication, log.DeduplicationKey)
+		logs := r.LogsByUserID[log.UserID]
+		for i, l := range logs {
+			if l.ID == id {
+				r.LogsByUserID[log.UserID] = append(logs[:i], logs[i+1:]...)
+				break
+			}
+		}
+		delete(r.Logs, id)
+		deleted++
 	}
+	return deleted, nil
 }
 
-// User は UserRepository インターフェースを返します。
-// Service層から呼び出されます。
-func (m *MockRepositories) User() UserRepository {
-	return m.userRepo
+func (r *MockNotificationLogRepository) CountChannelOutcomesSince(ctx context.Context, since time.Time) ([]NotificationChannelOutcomeCount, error) {
+	counts := make(map[[2]string]int64)
+	for _, log := range r.Logs {
+		if log.CreatedAt.Before(since) {
+			continue
+		}
+		for channel, outcome := range log.ChannelStatus {
+			counts[[2]string{channel, outcome}]++
+			if log.DuplicateCount > 0 {
+				counts[[2]string{channel, "deduped"}] += int64(log.DuplicateCount)
+			}
+		}
+	}
+	result := make([]NotificationChannelOutcomeCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, NotificationChannelOutcomeCount{Channel: key[0], Outcome: key[1], Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Channel != result[j].Channel {
+			return result[i].Channel < result[j].Channel
+		}
+		return result[i].Outcome < result[j].Outcome
+	})
+	return result, nil
 }
 
-// Garden は GardenRepository インターフェースを返します。
-func (m *MockRepositories) Garden() GardenRepository {
-	return m.gardenRepo
-}
+// MockShareTokenRepository は ShareTokenRepository インターフェースのモック実装です。
+type MockShareTokenRepository struct {
+	mockClock
 
-// Plant は PlantRepository インターフェースを返します。
-func (m *MockRepositories) Plant() PlantRepository {
-	return m.plantRepo
+	Tokens       map[uint]*model.ShareToken
+	TokensByHash map[string]*model.ShareToken
+	NextID       uint
+	UpdateErr    error // Update 時に返すエラー（失敗のテスト用）
 }
 
-// CareLog は CareLogRepository インターフェースを返します。
-func (m *MockRepositories) CareLog() CareLogRepository {
-	return m.careLogRepo
+// NewMockShareTokenRepository は新しいMockShareTokenRepositoryを作成します。
+func NewMockShareTokenRepository() *MockShareTokenRepository {
+	return &MockShareTokenRepository{
+		Tokens:       make(map[uint]*model.ShareToken),
+		TokensByHash: make(map[string]*model.ShareToken),
+		NextID:       1,
+	}
 }
 
-// TokenBlacklist は TokenBlacklistRepository インターフェースを返します。
-func (m *MockRepositories) TokenBlacklist() TokenBlacklistRepository {
-	return m.tokenBlacklistRepo
+func (r *MockShareTokenRepository) Create(ctx context.Context, token *model.ShareToken) error {
+	token.ID = r.NextID
+	r.NextID++
+	token.CreatedAt = r.now()
+	token.UpdatedAt = r.now()
+	// トークン文字列は保存しない（データベースと同じくハッシュのみ）
+	stored := *token
+	stored.Token = ""
+	r.Tokens[token.ID] = &stored
+	r.TokensByHash[token.TokenHash] = &stored
+	return nil
 }
 
-// Task は TaskRepository インターフェースを返します。
-func (m *MockRepositories) Task() TaskRepository {
-	return m.taskRepo
+func (r *MockShareTokenRepository) GetByID(ctx context.Context, id uint) (*model.ShareToken, error) {
+	if token, ok := r.Tokens[id]; ok {
+		return token, nil
+	}
+	return nil, gorm.ErrRecordNotFound
 }
 
-// Crop は CropRepository インターフェースを返します。
-func (m *MockRepositories) Crop() CropRepository {
-	return m.cropRepo
+func (r *MockShareTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.ShareToken, error) {
+	if t, ok := r.TokensByHash[tokenHash]; ok {
+		return t, nil
+	}
+	return nil, gorm.ErrRecordNotFound
 }
 
-// GrowthRecord は GrowthRecordRepository インターフェースを返します。
-func (m *MockRepositories) GrowthRecord() GrowthRecordRepository {
-	return m.growthRecordRepo
+func (r *MockShareTokenRepository) GetByUserID(ctx context.Context, userID uint) ([]model.ShareToken, error) {
+	var result []model.ShareToken
+	for _, token := range r.Tokens {
+		if token.UserID == userID {
+			result = append(result, *token)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result, nil
 }
 
-// Harvest は HarvestRepository インターフェースを返します。
-func (m *MockRepositories) Harvest() HarvestRepository {
-	return m.harvestRepo
+func (r *MockShareTokenRepository) Update(ctx context.Context, token *model.ShareToken) error {
+	if r.UpdateErr != nil {
+		return r.UpdateErr
+	}
+	token.UpdatedAt = r.now()
+	r.Tokens[token.ID] = token
+	r.TokensByHash[token.TokenHash] = token
+	return nil
 }
 
-// Plot は PlotRepository インターフェースを返します。
-func (m *MockRepositories) Plot() PlotRepository {
-	return m.plotRepo
-}
+// MockAnalyticsViewRepository は AnalyticsViewRepository インターフェースのモック実装です。
+type MockAnalyticsViewRepository struct {
+	mockClock
 
-// PlotAssignment は PlotAssignmentRepository インターフェースを返します。
-func (m *MockRepositories) PlotAssignment() PlotAssignmentRepository {
-	return m.plotAssignmentRepo
-}
+	// Records はビュー名をキーとしたリフレッシュ結果の格納Map
+	Records map[string]*model.MaterializedViewRefresh
 
-// DeviceToken は DeviceTokenRepository インターフェースを返します。
-func (m *MockRepositories) DeviceToken() DeviceTokenRepository {
-	return m.deviceTokenRepo
+	// RefreshedViews はRefreshが呼ばれたビュー名の履歴
+	RefreshedViews []string
+
+	// CropAnalytics はユーザーIDをキーとした mv_harvest_analytics の行
+	CropAnalytics map[uint][]CropHarvestAnalytics
+
+	// DataVersions はスコープをキーとしたデータのバージョン
+	DataVersions map[string]int64
+
+	// カスタム動作用のフック関数
+	RefreshFunc func(ctx context.Context, viewName string) error
 }
 
-// NotificationLog は NotificationLogRepository インターフェースを返します。
-func (m *MockRepositories) NotificationLog() NotificationLogRepository {
-	return m.notificationLogRepo
+// NewMockAnalyticsViewRepository は新しいMockAnalyticsViewRepositoryを作成します。
+func NewMockAnalyticsViewRepository() *MockAnalyticsViewRepository {
+	return &MockAnalyticsViewRepository{
+		Records:       make(map[string]*model.MaterializedViewRefresh),
+		CropAnalytics: make(map[uint][]CropHarvestAnalytics),
+		DataVersions:  make(map[string]int64),
+	}
+}
+
+func (r *MockAnalyticsViewRepository) Refresh(ctx context.Context, viewName string) error {
+	r.RefreshedViews = append(r.RefreshedViews, viewName)
+	if r.RefreshFunc != nil {
+		return r.RefreshFunc(ctx, viewName)
+	}
+	return nil
+}
+
+func (r *MockAnalyticsViewRepository) RecordRefresh(ctx context.Context, record *model.MaterializedViewRefresh) error {
+	record.UpdatedAt = r.now()
+	// 失敗時は前回成功日時を保持する（本番のupsertと同じ挙動）
+	if existing, ok := r.Records[record.ViewName]; ok && record.LastRefreshedAt == nil {
+		record.LastRefreshedAt = existing.LastRefreshedAt
+	}
+	r.Records[record.ViewName] = record
+	return nil
+}
+
+func (r *MockAnalyticsViewRepository) GetCropHarvestAnalytics(ctx context.Context, userID uint, cropName string) ([]CropHarvestAnalytics, error) {
+	var result []CropHarvestAnalytics
+	for _, row := range r.CropAnalytics[userID] {
+		if strings.EqualFold(row.CropName, cropName) {
+			result = append(result, row)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PlantedDate.Before(result[j].PlantedDate) })
+	return result, nil
+}
+
+func (r *MockAnalyticsViewRepository) GetRefreshStatuses(ctx context.Context) ([]model.MaterializedViewRefresh, error) {
+	result := make([]model.MaterializedViewRefresh, 0, len(r.Records))
+	for _, record := range r.Records {
+		result = append(result, *record)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ViewName < result[j].ViewName })
+	return result, nil
+}
+
+func (r *MockAnalyticsViewRepository) GetDataVersion(ctx context.Context, scopes []string) (int64, error) {
+	var version int64
+	for _, scope := range scopes {
+		version += r.DataVersions[scope]
+	}
+	return version, nil
+}
+
+func (r *MockAnalyticsViewRepository) BumpDataVersion(ctx context.Context, scopes ...string) error {
+	for _, scope := range scopes {
+		r.DataVersions[scope]++
+	}
+	return nil
+}
+
+// MockExportRecordRepository は ExportRecordRepository インターフェースのモック実装です。
+type MockExportRecordRepository struct {
+	mockClock
+
+	Records map[uint]*model.ExportRecord
+	NextID  uint
+}
+
+// NewMockExportRecordRepository は新しいMockExportRecordRepositoryを作成します。
+func NewMockExportRecordRepository() *MockExportRecordRepository {
+	return &MockExportRecordRepository{
+		Records: make(map[uint]*model.ExportRecord),
+		NextID:  1,
+	}
+}
+
+func (r *MockExportRecordRepository) Create(ctx context.Context, record *model.ExportRecord) error {
+	record.ID = r.NextID
+	r.NextID++
+	record.CreatedAt = r.now()
+	record.UpdatedAt = r.now()
+	r.Records[record.ID] = record
+	return nil
+}
+
+func (r *MockExportRecordRepository) GetByID(ctx context.Context, id uint) (*model.ExportRecord, error) {
+	if record, ok := r.Records[id]; ok {
+		return record, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockExportRecordRepository) Update(ctx context.Context, record *model.ExportRecord) error {
+	if _, ok := r.Records[record.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	record.UpdatedAt = r.now()
+	r.Records[record.ID] = record
+	return nil
+}
+
+func (r *MockExportRecordRepository) GetByUserID(ctx context.Context, userID uint, limit int) ([]model.ExportRecord, error) {
+	var result []model.ExportRecord
+	for _, record := range r.Records {
+		if record.UserID == userID {
+			result = append(result, *record)
+		}
+	}
+	// 新しい順（IDの降順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockExportRecordRepository) GetWithFilesCreatedBefore(ctx context.Context, before time.Time, limit int) ([]model.ExportRecord, error) {
+	var result []model.ExportRecord
+	for _, record := range r.Records {
+		if record.S3Key != "" && record.CreatedAt.Before(before) {
+			result = append(result, *record)
+		}
+	}
+	// 古い順（IDの昇順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockExportRecordRepository) CountWithFilesCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	records, err := r.GetWithFilesCreatedBefore(ctx, before, 0)
+	return int64(len(records)), err
+}
+
+func (r *MockExportRecordRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	for _, record := range r.Records {
+		if record.UserID == userID && record.S3Key != "" {
+			total.Count++
+			total.Bytes += record.FileSizeBytes
+		}
+	}
+	return total, nil
+}
+
+// MockSeasonSummaryRepository は SeasonSummaryRepository インターフェースのモック実装です。
+// キーは "ユーザーID/シーズン/区画ID" です。
+type MockSeasonSummaryRepository struct {
+	mockClock
+
+	Summaries map[string]*model.SeasonSummary
+	NextID    uint
+	UpsertErr error // Upsert 時に返すエラー（失敗のテスト用）
+}
+
+// NewMockSeasonSummaryRepository は新しいMockSeasonSummaryRepositoryを作成します。
+func NewMockSeasonSummaryRepository() *MockSeasonSummaryRepository {
+	return &MockSeasonSummaryRepository{
+		Summaries: make(map[string]*model.SeasonSummary),
+		NextID:    1,
+	}
+}
+
+func (r *MockSeasonSummaryRepository) Upsert(ctx context.Context, summaries []model.SeasonSummary) error {
+	if r.UpsertErr != nil {
+		return r.UpsertErr
+	}
+	for _, summary := range summaries {
+		key := fmt.Sprintf("%d/%d/%d", summary.UserID, summary.Season, summary.PlotID)
+		if existing, ok := r.Summaries[key]; ok {
+			summary.ID = existing.ID
+			summary.CreatedAt = existing.CreatedAt
+		} else {
+			summary.ID = r.NextID
+			r.NextID++
+			summary.CreatedAt = r.now()
+		}
+		summary.UpdatedAt = r.now()
+		stored := summary
+		r.Summaries[key] = &stored
+	}
+	return nil
+}
+
+func (r *MockSeasonSummaryRepository) GetByUserAndSeason(ctx context.Context, userID uint, season int) ([]model.SeasonSummary, error) {
+	var result []model.SeasonSummary
+	for _, summary := range r.Summaries {
+		if summary.UserID == userID && summary.Season == season {
+			result = append(result, *summary)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalKg != result[j].TotalKg {
+			return result[i].TotalKg > result[j].TotalKg
+		}
+		return result[i].PlotID < result[j].PlotID
+	})
+	return result, nil
+}
+
+// MockRetentionRepository は RetentionRepository インターフェースのモック実装です。
+// テーブル名ごとの論理削除日時（DeletedAt）で削除対象を判定します。
+type MockRetentionRepository struct {
+	DeletedAt map[string][]time.Time
+	Err       map[string]error // テーブルごとの削除時のエラー（外部キー制約のシミュレート）
+
+	// tables はテーブル名をキーとした論理削除した記録を保持するモックリポジトリです（NewMockRepositories で設定）。
+	// DeletedAt の件数に加えて、各モックリポジトリで論理削除した記録を数え・物理削除します。
+	tables map[string]mockSoftDeleteTable
+}
+
+// mockSoftDeleteTable は論理削除した記録を保持するモックリポジトリです。
+type mockSoftDeleteTable interface {
+	purgeDeleted(before time.Time, dryRun bool) int64
+}
+
+// NewMockRetentionRepository は新しいMockRetentionRepositoryを作成します。
+func NewMockRetentionRepository() *MockRetentionRepository {
+	return &MockRetentionRepository{
+		DeletedAt: make(map[string][]time.Time),
+		Err:       make(map[string]error),
+	}
+}
+
+func (r *MockRetentionRepository) CountSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error) {
+	var count int64
+	for _, deletedAt := range r.DeletedAt[table] {
+		if deletedAt.Before(before) {
+			count++
+		}
+	}
+	if repo, ok := r.tables[table]; ok {
+		count += repo.purgeDeleted(before, true)
+	}
+	return count, nil
+}
+
+func (r *MockRetentionRepository) PurgeSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error) {
+	if err := r.Err[table]; err != nil {
+		return 0, err
+	}
+	var kept []time.Time
+	var deleted int64
+	for _, deletedAt := range r.DeletedAt[table] {
+		if deletedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, deletedAt)
+	}
+	r.DeletedAt[table] = kept
+	if repo, ok := r.tables[table]; ok {
+		deleted += repo.purgeDeleted(before, false)
+	}
+	return deleted, nil
+}
+
+// MockSyncRepository は SyncRepository インターフェースのモック実装です。
+// 作成・更新された記録はタスク・作物・区画・収穫記録のモックの UpdatedAt から判定します。
+type MockSyncRepository struct {
+	Tombstones []model.SyncTombstone
+
+	tasks    *MockTaskRepository
+	crops    *MockCropRepository
+	plots    *MockPlotRepository
+	harvests *MockHarvestRepository
+}
+
+// NewMockSyncRepository は新しいMockSyncRepositoryを作成します。
+func NewMockSyncRepository(tasks *MockTaskRepository, crops *MockCropRepository, plots *MockPlotRepository, harvests *MockHarvestRepository) *MockSyncRepository {
+	return &MockSyncRepository{tasks: tasks, crops: crops, plots: plots, harvests: harvests}
+}
+
+// inSyncWindow は updatedAt が期間（since, until]に含まれるかを判定します。
+func inSyncWindow(updatedAt, since, until time.Time) bool {
+	return updatedAt.After(since) && !updatedAt.After(until)
+}
+
+func (r *MockSyncRepository) ChangedTasks(ctx context.Context, userID uint, since, until time.Time) ([]model.Task, error) {
+	var result []model.Task
+	for _, task := range r.tasks.Tasks {
+		if task.UserID == userID && inSyncWindow(task.UpdatedAt, since, until) {
+			result = append(result, *task)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockSyncRepository) ChangedCrops(ctx context.Context, userID uint, since, until time.Time) ([]model.Crop, error) {
+	var result []model.Crop
+	for _, crop := range r.crops.Crops {
+		if crop.UserID == userID && inSyncWindow(crop.UpdatedAt, since, until) {
+			result = append(result, *crop)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockSyncRepository) ChangedPlots(ctx context.Context, userID uint, since, until time.Time) ([]model.Plot, error) {
+	var result []model.Plot
+	for _, plot := range r.plots.Plots {
+		if plot.UserID == userID && inSyncWindow(plot.UpdatedAt, since, until) {
+			result = append(result, *plot)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockSyncRepository) ChangedHarvests(ctx context.Context, userID uint, since, until time.Time) ([]model.Harvest, error) {
+	var result []model.Harvest
+	for _, harvest := range r.harvests.Harvests {
+		crop, ok := r.crops.Crops[harvest.CropID]
+		if ok && crop.UserID == userID && inSyncWindow(harvest.UpdatedAt, since, until) {
+			result = append(result, *harvest)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockSyncRepository) CreateTombstones(ctx context.Context, tombstones []model.SyncTombstone) error {
+	for _, tombstone := range tombstones {
+		tombstone.ID = uint(len(r.Tombstones) + 1)
+		r.Tombstones = append(r.Tombstones, tombstone)
+	}
+	return nil
+}
+
+func (r *MockSyncRepository) GetTombstones(ctx context.Context, userID uint, since, until time.Time) ([]model.SyncTombstone, error) {
+	var result []model.SyncTombstone
+	for _, tombstone := range r.Tombstones {
+		if tombstone.UserID == userID && inSyncWindow(tombstone.DeletedAt, since, until) {
+			result = append(result, tombstone)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockSyncRepository) GetTombstone(ctx context.Context, userID uint, entity string, entityID uint) (*model.SyncTombstone, error) {
+	for i := len(r.Tombstones) - 1; i >= 0; i-- {
+		tombstone := r.Tombstones[i]
+		if tombstone.UserID == userID && tombstone.Entity == entity && tombstone.EntityID == entityID {
+			return &tombstone, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// DeleteTombstones は記録の削除の記録を削除します。
+func (r *MockSyncRepository) DeleteTombstones(ctx context.Context, userID uint, entity string, entityIDs []uint) error {
+	restored := make(map[uint]bool, len(entityIDs))
+	for _, id := range entityIDs {
+		restored[id] = true
+	}
+	kept := r.Tombstones[:0]
+	for _, tombstone := range r.Tombstones {
+		if !(tombstone.UserID == userID && tombstone.Entity == entity && restored[tombstone.EntityID]) {
+			kept = append(kept, tombstone)
+		}
+	}
+	r.Tombstones = kept
+	return nil
+}
+
+// MockSearchRepository は SearchRepository インターフェースのモック実装です。
+// 全文検索の代わりに、大文字・小文字を区別しない部分一致で検索します（タイトルの一致は関連度 2、本文のみは 1）。
+type MockSearchRepository struct {
+	tasks         *MockTaskRepository
+	crops         *MockCropRepository
+	plots         *MockPlotRepository
+	growthRecords *MockGrowthRecordRepository
+}
+
+// NewMockSearchRepository は新しいMockSearchRepositoryを作成します。
+func NewMockSearchRepository(tasks *MockTaskRepository, crops *MockCropRepository, plots *MockPlotRepository, growthRecords *MockGrowthRecordRepository) *MockSearchRepository {
+	return &MockSearchRepository{tasks: tasks, crops: crops, plots: plots, growthRecords: growthRecords}
+}
+
+// Search は対象の記録を部分一致で検索し、関連度・更新日時の順に limit 件まで返します。
+func (r *MockSearchRepository) Search(ctx context.Context, userID uint, entity, query string, limit int) ([]SearchHit, error) {
+	needle := strings.ToLower(query)
+	var hits []SearchHit
+	add := func(hit SearchHit) {
+		switch {
+		case strings.Contains(strings.ToLower(hit.Title), needle):
+			hit.Rank = 2
+		case strings.Contains(strings.ToLower(hit.Body), needle):
+			hit.Rank = 1
+		default:
+			return
+		}
+		hit.Type = entity
+		hits = append(hits, hit)
+	}
+
+	switch entity {
+	case SearchEntityCrops:
+		for _, c := range r.crops.scopedCrops(ctx, userID) {
+			add(SearchHit{ID: c.ID, Title: c.Name, Body: c.Name + " " + c.Variety + " " + c.Notes, UpdatedAt: c.UpdatedAt})
+		}
+	case SearchEntityTasks:
+		for _, t := range r.tasks.TasksByUserID[userID] {
+			add(SearchHit{ID: t.ID, Title: t.Title, Body: t.Title + " " + t.Description, UpdatedAt: t.UpdatedAt})
+		}
+	case SearchEntityPlots:
+		for _, p := range r.plots.scopedPlots(ctx, userID) {
+			add(SearchHit{ID: p.ID, Title: p.Name, Body: p.Name + " " + p.Notes, UpdatedAt: p.UpdatedAt})
+		}
+	case SearchEntityGrowthRecords:
+		for _, c := range r.crops.scopedCrops(ctx, userID) {
+			for _, record := range r.growthRecords.RecordsByCropID[c.ID] {
+				if strings.Contains(strings.ToLower(record.Notes), needle) {
+					hits = append(hits, SearchHit{Type: entity, ID: record.ID, CropID: c.ID, Title: c.Name, Body: record.Notes, Rank: 1, UpdatedAt: record.UpdatedAt})
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown search entity: %s", entity)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// MockUsageStatsRepository は UsageStatsRepository インターフェースのモック実装です。
+// キーは日付（YYYY-MM-DD）です。
+type MockUsageStatsRepository struct {
+	Counters    map[string]map[string]int64 // date -> metric -> value
+	ActiveUsers map[string]map[uint]bool    // date -> userID
+}
+
+// NewMockUsageStatsRepository は新しいMockUsageStatsRepositoryを作成します。
+func NewMockUsageStatsRepository() *MockUsageStatsRepository {
+	return &MockUsageStatsRepository{
+		Counters:    make(map[string]map[string]int64),
+		ActiveUsers: make(map[string]map[uint]bool),
+	}
+}
+
+func (r *MockUsageStatsRepository) IncrementCounter(ctx context.Context, date time.Time, metric string, delta int64) error {
+	key := date.Format("2006-01-02")
+	if r.Counters[key] == nil {
+		r.Counters[key] = make(map[string]int64)
+	}
+	r.Counters[key][metric] += delta
+	return nil
+}
+
+func (r *MockUsageStatsRepository) RecordActiveUser(ctx context.Context, date time.Time, userID uint) error {
+	key := date.Format("2006-01-02")
+	if r.ActiveUsers[key] == nil {
+		r.ActiveUsers[key] = make(map[uint]bool)
+	}
+	r.ActiveUsers[key][userID] = true
+	return nil
+}
+
+// mockDateInRange は日付キーが from〜to（両端を含む）の範囲内か判定します。
+func mockDateInRange(key string, from, to time.Time) bool {
+	return key >= from.Format("2006-01-02") && key <= to.Format("2006-01-02")
+}
+
+func (r *MockUsageStatsRepository) GetCounters(ctx context.Context, from, to time.Time) ([]model.UsageCounter, error) {
+	var result []model.UsageCounter
+	for key, metrics := range r.Counters {
+		if !mockDateInRange(key, from, to) {
+			continue
+		}
+		date, _ := time.Parse("2006-01-02", key)
+		for metric, value := range metrics {
+			result = append(result, model.UsageCounter{Date: date, Metric: metric, Value: value})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Metric < result[j].Metric
+	})
+	return result, nil
+}
+
+func (r *MockUsageStatsRepository) GetDailyActiveUsers(ctx context.Context, from, to time.Time) ([]DailyCount, error) {
+	var result []DailyCount
+	for key, users := range r.ActiveUsers {
+		if !mockDateInRange(key, from, to) {
+			continue
+		}
+		date, _ := time.Parse("2006-01-02", key)
+		result = append(result, DailyCount{Date: date, Count: int64(len(users))})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result, nil
+}
+
+func (r *MockUsageStatsRepository) CountDistinctActiveUsers(ctx context.Context, from, to time.Time) (int64, error) {
+	distinct := make(map[uint]bool)
+	for key, users := range r.ActiveUsers {
+		if !mockDateInRange(key, from, to) {
+			continue
+		}
+		for userID := range users {
+			distinct[userID] = true
+		}
+	}
+	return int64(len(distinct)), nil
+}
+
+// MockNotificationPreferenceRepository は NotificationPreferenceRepository インターフェースのモック実装です。
+// キーは {user_id}:{event_type}:{channel} です。
+type MockNotificationPreferenceRepository struct {
+	mockClock
+
+	Preferences map[string]*model.NotificationPreference
+}
+
+// NewMockNotificationPreferenceRepository は新しいMockNotificationPreferenceRepositoryを作成します。
+func NewMockNotificationPreferenceRepository() *MockNotificationPreferenceRepository {
+	return &MockNotificationPreferenceRepository{
+		Preferences: make(map[string]*model.NotificationPreference),
+	}
+}
+
+func (r *MockNotificationPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]model.NotificationPreference, error) {
+	var result []model.NotificationPreference
+	for _, pref := range r.Preferences {
+		if pref.UserID == userID {
+			result = append(result, *pref)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EventType != result[j].EventType {
+			return result[i].EventType < result[j].EventType
+		}
+		return result[i].Channel < result[j].Channel
+	})
+	return result, nil
+}
+
+func (r *MockNotificationPreferenceRepository) Upsert(ctx context.Context, prefs []model.NotificationPreference) error {
+	now := r.now()
+	for i := range prefs {
+		pref := prefs[i]
+		pref.UpdatedAt = now
+		r.Preferences[fmt.Sprintf("%d:%s:%s", pref.UserID, pref.EventType, pref.Channel)] = &pref
+	}
+	return nil
+}
+
+// MockPhoneVerificationRepository は PhoneVerificationRepository インターフェースのモック実装です。
+// ユーザーIDをキーに認証コードを保持します。
+type MockPhoneVerificationRepository struct {
+	mockClock
+
+	Verifications map[uint]*model.PhoneVerification
+	Sends         []model.PhoneVerificationSend
+	NextSendID    uint
+}
+
+// NewMockPhoneVerificationRepository は新しいMockPhoneVerificationRepositoryを作成します。
+func NewMockPhoneVerificationRepository() *MockPhoneVerificationRepository {
+	return &MockPhoneVerificationRepository{
+		Verifications: make(map[uint]*model.PhoneVerification),
+		NextSendID:    1,
+	}
+}
+
+func (r *MockPhoneVerificationRepository) GetByUserID(ctx context.Context, userID uint) (*model.PhoneVerification, error) {
+	if v, ok := r.Verifications[userID]; ok {
+		return v, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockPhoneVerificationRepository) Save(ctx context.Context, verification *model.PhoneVerification) error {
+	now := r.now()
+	if existing, ok := r.Verifications[verification.UserID]; ok {
+		verification.CreatedAt = existing.CreatedAt
+	} else {
+		verification.CreatedAt = now
+	}
+	verification.UpdatedAt = now
+	r.Verifications[verification.UserID] = verification
+	return nil
+}
+
+func (r *MockPhoneVerificationRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	delete(r.Verifications, userID)
+	return nil
+}
+
+func (r *MockPhoneVerificationRepository) RecordSend(ctx context.Context, send *model.PhoneVerificationSend, before time.Time) error {
+	kept := r.Sends[:0]
+	for _, s := range r.Sends {
+		if s.UserID != send.UserID || !s.CreatedAt.Before(before) {
+			kept = append(kept, s)
+		}
+	}
+	send.ID = r.NextSendID
+	r.NextSendID++
+	send.CreatedAt = r.now()
+	r.Sends = append(kept, *send)
+	return nil
+}
+
+func (r *MockPhoneVerificationRepository) GetSendsSince(ctx context.Context, userID uint, since time.Time) ([]model.PhoneVerificationSend, error) {
+	var sends []model.PhoneVerificationSend
+	for i := len(r.Sends) - 1; i >= 0; i-- {
+		if r.Sends[i].UserID == userID && !r.Sends[i].CreatedAt.Before(since) {
+			sends = append(sends, r.Sends[i])
+		}
+	}
+	return sends, nil
+}
+
+// MockAnnouncementRepository は AnnouncementRepository インターフェースのモック実装です。
+// 配信対象の抽出はユーザー・デバイストークン・アクティブユーザーのモックを参照します。
+type MockAnnouncementRepository struct {
+	mockClock
+
+	Announcements map[uint]*model.Announcement
+	NextID        uint
+
+	users      *MockUserRepository
+	tokens     *MockDeviceTokenRepository
+	usageStats *MockUsageStatsRepository
+}
+
+// NewMockAnnouncementRepository は新しいMockAnnouncementRepositoryを作成します。
+func NewMockAnnouncementRepository(users *MockUserRepository, tokens *MockDeviceTokenRepository, usageStats *MockUsageStatsRepository) *MockAnnouncementRepository {
+	return &MockAnnouncementRepository{
+		Announcements: make(map[uint]*model.Announcement),
+		NextID:        1,
+		users:         users,
+		tokens:        tokens,
+		usageStats:    usageStats,
+	}
+}
+
+func (r *MockAnnouncementRepository) Create(ctx context.Context, announcement *model.Announcement) error {
+	announcement.ID = r.NextID
+	r.NextID++
+	announcement.CreatedAt = r.now()
+	announcement.UpdatedAt = announcement.CreatedAt
+	if announcement.Status == "" {
+		announcement.Status = "scheduled"
+	}
+	stored := *announcement
+	r.Announcements[announcement.ID] = &stored
+	return nil
+}
+
+func (r *MockAnnouncementRepository) GetByID(ctx context.Context, id uint) (*model.Announcement, error) {
+	if announcement, ok := r.Announcements[id]; ok {
+		copied := *announcement
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockAnnouncementRepository) List(ctx context.Context, limit int) ([]model.Announcement, error) {
+	var result []model.Announcement
+	for _, announcement := range r.Announcements {
+		result = append(result, *announcement)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockAnnouncementRepository) Update(ctx context.Context, announcement *model.Announcement) error {
+	if _, ok := r.Announcements[announcement.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	announcement.UpdatedAt = r.now()
+	stored := *announcement
+	r.Announcements[announcement.ID] = &stored
+	return nil
+}
+
+func (r *MockAnnouncementRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]model.Announcement, error) {
+	var result []model.Announcement
+	for _, announcement := range r.Announcements {
+		if (announcement.Status == "scheduled" || announcement.Status == "sending") && !announcement.ScheduledAt.After(now) {
+			result = append(result, *announcement)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ScheduledAt.Before(result[j].ScheduledAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockAnnouncementRepository) GetAudienceUserIDs(ctx context.Context, audience AnnouncementAudience, afterUserID uint, limit int) ([]uint, error) {
+	var ids []uint
+	for id, user := range r.users.Users {
+		if id > afterUserID && r.inAudience(user, audience) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (r *MockAnnouncementRepository) CountAudience(ctx context.Context, audience AnnouncementAudience) (int64, error) {
+	var count int64
+	for _, user := range r.users.Users {
+		if r.inAudience(user, audience) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// inAudience はユーザーが配信対象の条件に合うかを判定します。
+func (r *MockAnnouncementRepository) inAudience(user *model.User, audience AnnouncementAudience) bool {
+	if !user.IsActive || (audience.Locale != "" && user.Locale != audience.Locale) {
+		return false
+	}
+	if audience.Platform != "" {
+		found := false
+		for _, token := range r.tokens.TokensByUserID[user.ID] {
+			if token.IsActive && token.Platform == audience.Platform {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if audience.ActiveSince != nil {
+		since := audience.ActiveSince.Format("2006-01-02")
+		for date, users := range r.usageStats.ActiveUsers {
+			if date >= since && users[user.ID] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// MockSchedulerInvocationRepository は SchedulerInvocationRepository インターフェースのモック実装です。
+type MockSchedulerInvocationRepository struct {
+	mockClock
+
+	Invocations map[uint]*model.SchedulerInvocation
+	NextID      uint
+}
+
+// NewMockSchedulerInvocationRepository は新しいMockSchedulerInvocationRepositoryを作成します。
+func NewMockSchedulerInvocationRepository() *MockSchedulerInvocationRepository {
+	return &MockSchedulerInvocationRepository{
+		Invocations: make(map[uint]*model.SchedulerInvocation),
+		NextID:      1,
+	}
+}
+
+func (r *MockSchedulerInvocationRepository) Reserve(ctx context.Context, invocation *model.SchedulerInvocation) (bool, error) {
+	if _, err := r.GetByKey(ctx, invocation.Endpoint, invocation.IdempotencyKey); err == nil {
+		return false, nil
+	}
+	invocation.ID = r.NextID
+	r.NextID++
+	invocation.CreatedAt = r.now()
+	stored := *invocation
+	r.Invocations[invocation.ID] = &stored
+	return true, nil
+}
+
+func (r *MockSchedulerInvocationRepository) GetByKey(ctx context.Context, endpoint, key string) (*model.SchedulerInvocation, error) {
+	for _, invocation := range r.Invocations {
+		if invocation.Endpoint == endpoint && invocation.IdempotencyKey == key {
+			found := *invocation
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockSchedulerInvocationRepository) Complete(ctx context.Context, id uint, statusCode int, responseBody string, completedAt time.Time) error {
+	if invocation, ok := r.Invocations[id]; ok {
+		invocation.StatusCode = statusCode
+		invocation.ResponseBody = responseBody
+		invocation.CompletedAt = &completedAt
+	}
+	return nil
+}
+
+func (r *MockSchedulerInvocationRepository) Delete(ctx context.Context, id uint) error {
+	delete(r.Invocations, id)
+	return nil
+}
+
+func (r *MockSchedulerInvocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	for id, invocation := range r.Invocations {
+		if invocation.ExpiresAt.Before(now) {
+			delete(r.Invocations, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MockJobRunRepository は JobRunRepository インターフェースのモック実装です。
+type MockJobRunRepository struct {
+	Runs   map[uint]*model.JobRun
+	NextID uint
+}
+
+// NewMockJobRunRepository は新しいMockJobRunRepositoryを作成します。
+func NewMockJobRunRepository() *MockJobRunRepository {
+	return &MockJobRunRepository{
+		Runs:   make(map[uint]*model.JobRun),
+		NextID: 1,
+	}
+}
+
+func (r *MockJobRunRepository) Create(ctx context.Context, run *model.JobRun) error {
+	run.ID = r.NextID
+	r.NextID++
+	if run.Status == "" {
+		run.Status = "running"
+	}
+	r.Runs[run.ID] = run
+	return nil
+}
+
+func (r *MockJobRunRepository) Finish(ctx context.Context, id uint, status, errorMessage string, finishedAt time.Time) error {
+	run, ok := r.Runs[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	run.Status = status
+	run.ErrorMessage = errorMessage
+	run.FinishedAt = &finishedAt
+	return nil
+}
+
+func (r *MockJobRunRepository) GetLatest(ctx context.Context, jobName string) (*model.JobRun, error) {
+	var latest *model.JobRun
+	for _, run := range r.Runs {
+		if run.JobName == jobName && (latest == nil || run.ScheduledAt.After(latest.ScheduledAt)) {
+			latest = run
+		}
+	}
+	if latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *latest
+	return &found, nil
+}
+
+func (r *MockJobRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, run := range r.Runs {
+		if run.StartedAt.Before(before) {
+			delete(r.Runs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MockSchedulerCheckpointRepository は SchedulerCheckpointRepository インターフェースのモック実装です。
+type MockSchedulerCheckpointRepository struct {
+	mockClock
+
+	Checkpoints map[string]*model.SchedulerCheckpoint
+	SaveCount   int // Save の呼び出し回数（進捗の記録のテスト用）
+}
+
+// NewMockSchedulerCheckpointRepository は新しいMockSchedulerCheckpointRepositoryを作成します。
+func NewMockSchedulerCheckpointRepository() *MockSchedulerCheckpointRepository {
+	return &MockSchedulerCheckpointRepository{Checkpoints: make(map[string]*model.SchedulerCheckpoint)}
+}
+
+func (r *MockSchedulerCheckpointRepository) Get(ctx context.Context, name string) (*model.SchedulerCheckpoint, error) {
+	if checkpoint, ok := r.Checkpoints[name]; ok {
+		stored := *checkpoint
+		return &stored, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockSchedulerCheckpointRepository) Save(ctx context.Context, checkpoint *model.SchedulerCheckpoint) error {
+	checkpoint.UpdatedAt = r.now()
+	stored := *checkpoint
+	r.Checkpoints[checkpoint.Name] = &stored
+	r.SaveCount++
+	return nil
+}
+
+// MockScheduleConfigRepository は ScheduleConfigRepository インターフェースのモック実装です。
+type MockScheduleConfigRepository struct {
+	mockClock
+
+	Configs map[string]*model.ScheduleConfig
+	NextID  uint
+}
+
+// NewMockScheduleConfigRepository は新しいMockScheduleConfigRepositoryを作成します。
+func NewMockScheduleConfigRepository() *MockScheduleConfigRepository {
+	return &MockScheduleConfigRepository{
+		Configs: make(map[string]*model.ScheduleConfig),
+		NextID:  1,
+	}
+}
+
+func (r *MockScheduleConfigRepository) GetAll(ctx context.Context) ([]model.ScheduleConfig, error) {
+	result := make([]model.ScheduleConfig, 0, len(r.Configs))
+	for _, config := range r.Configs {
+		result = append(result, *config)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (r *MockScheduleConfigRepository) GetByName(ctx context.Context, name string) (*model.ScheduleConfig, error) {
+	if config, ok := r.Configs[name]; ok {
+		found := *config
+		return &found, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockScheduleConfigRepository) Upsert(ctx context.Context, config *model.ScheduleConfig) error {
+	if existing, ok := r.Configs[config.Name]; ok {
+		config.ID = existing.ID
+		config.CreatedAt = existing.CreatedAt
+	} else {
+		config.ID = r.NextID
+		r.NextID++
+		config.CreatedAt = r.now()
+	}
+	config.UpdatedAt = r.now()
+	stored := *config
+	r.Configs[config.Name] = &stored
+	return nil
+}
+
+// MockNotificationOutboxRepository は NotificationOutboxRepository インターフェースのモック実装です。
+type MockNotificationOutboxRepository struct {
+	mockClock
+
+	Entries map[uint]*model.NotificationOutbox
+	NextID  uint
+}
+
+// NewMockNotificationOutboxRepository は新しいMockNotificationOutboxRepositoryを作成します。
+func NewMockNotificationOutboxRepository() *MockNotificationOutboxRepository {
+	return &MockNotificationOutboxRepository{
+		Entries: make(map[uint]*model.NotificationOutbox),
+		NextID:  1,
+	}
+}
+
+func (r *MockNotificationOutboxRepository) Enqueue(ctx context.Context, entries []model.NotificationOutbox) (int64, error) {
+	var inserted int64
+	for i := range entries {
+		duplicate := false
+		for _, existing := range r.Entries {
+			if existing.DeduplicationKey == entries[i].DeduplicationKey {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		entry := entries[i]
+		entry.ID = r.NextID
+		r.NextID++
+		if entry.Status == "" {
+			entry.Status = "pending"
+		}
+		entry.CreatedAt = r.now()
+		r.Entries[entry.ID] = &entry
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (r *MockNotificationOutboxRepository) GetPending(ctx context.Context, now time.Time, limit int) ([]model.NotificationOutbox, error) {
+	var pending []model.NotificationOutbox
+	for id := uint(1); id < r.NextID; id++ {
+		entry, ok := r.Entries[id]
+		if !ok || entry.Status != "pending" || entry.AvailableAt.After(now) {
+			continue
+		}
+		pending = append(pending, *entry)
+		if limit > 0 && len(pending) >= limit {
+			break
+		}
+	}
+	return pending, nil
+}
+
+func (r *MockNotificationOutboxRepository) MarkDispatched(ctx context.Context, id uint, dispatchedAt time.Time) error {
+	if entry, ok := r.Entries[id]; ok {
+		entry.Status = "dispatched"
+		entry.DispatchedAt = &dispatchedAt
+	}
+	return nil
+}
+
+func (r *MockNotificationOutboxRepository) MarkAttemptFailed(ctx context.Context, id uint, status string, attempts int, lastError string, availableAt time.Time) error {
+	if entry, ok := r.Entries[id]; ok {
+		entry.Status = status
+		entry.Attempts = attempts
+		entry.LastError = lastError
+		entry.AvailableAt = availableAt
+	}
+	return nil
+}
+
+func (r *MockNotificationOutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, entry := range r.Entries {
+		if entry.Status == "dispatched" && entry.DispatchedAt != nil && entry.DispatchedAt.Before(before) {
+			delete(r.Entries, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// MockNotificationDeadLetterRepository は NotificationDeadLetterRepository インターフェースのモック実装です。
+type MockNotificationDeadLetterRepository struct {
+	mockClock
+
+	Letters map[uint]*model.NotificationDeadLetter
+	NextID  uint
+}
+
+// NewMockNotificationDeadLetterRepository は新しいMockNotificationDeadLetterRepositoryを作成します。
+func NewMockNotificationDeadLetterRepository() *MockNotificationDeadLetterRepository {
+	return &MockNotificationDeadLetterRepository{
+		Letters: make(map[uint]*model.NotificationDeadLetter),
+		NextID:  1,
+	}
+}
+
+func (r *MockNotificationDeadLetterRepository) Create(ctx context.Context, letter *model.NotificationDeadLetter) error {
+	letter.ID = r.NextID
+	r.NextID++
+	if letter.Status == "" {
+		letter.Status = "open"
+	}
+	letter.CreatedAt = r.now()
+	letter.UpdatedAt = r.now()
+	stored := *letter
+	r.Letters[letter.ID] = &stored
+	return nil
+}
+
+func (r *MockNotificationDeadLetterRepository) GetByID(ctx context.Context, id uint) (*model.NotificationDeadLetter, error) {
+	if letter, ok := r.Letters[id]; ok {
+		found := *letter
+		return &found, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockNotificationDeadLetterRepository) List(ctx context.Context, status string, limit int) ([]model.NotificationDeadLetter, error) {
+	var result []model.NotificationDeadLetter
+	for _, letter := range r.Letters {
+		if status == "" || letter.Status == status {
+			result = append(result, *letter)
+		}
+	}
+	// 新しい順（IDの降順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockNotificationDeadLetterRepository) Update(ctx context.Context, letter *model.NotificationDeadLetter) error {
+	if _, ok := r.Letters[letter.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	letter.UpdatedAt = r.now()
+	stored := *letter
+	r.Letters[letter.ID] = &stored
+	return nil
+}
+
+// MockGardenMemberRepository は GardenMemberRepository インターフェースのモック実装です。
+// メンバーのユーザー情報・参加している庭は、ユーザー・庭のモックから取得します。
+type MockGardenMemberRepository struct {
+	mockClock
+
+	Members []*model.GardenMember
+	NextID  uint
+
+	users   *MockUserRepository
+	gardens *MockGardenRepository
+}
+
+// NewMockGardenMemberRepository は新しいMockGardenMemberRepositoryを作成します。
+func NewMockGardenMemberRepository(users *MockUserRepository, gardens *MockGardenRepository) *MockGardenMemberRepository {
+	return &MockGardenMemberRepository{NextID: 1, users: users, gardens: gardens}
+}
+
+func (r *MockGardenMemberRepository) Create(ctx context.Context, member *model.GardenMember) error {
+	if existing, _ := r.Get(ctx, member.GardenID, member.UserID); existing != nil {
+		return gorm.ErrDuplicatedKey
+	}
+	member.ID = r.NextID
+	r.NextID++
+	member.CreatedAt = r.now()
+	member.UpdatedAt = r.now()
+	r.Members = append(r.Members, member)
+	return nil
+}
+
+func (r *MockGardenMemberRepository) GetByGardenID(ctx context.Context, gardenID uint) ([]model.GardenMember, error) {
+	var result []model.GardenMember
+	for _, member := range r.Members {
+		if member.GardenID == gardenID {
+			m := *member
+			if user, ok := r.users.Users[m.UserID]; ok {
+				m.User = *user
+			}
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockGardenMemberRepository) Get(ctx context.Context, gardenID, userID uint) (*model.GardenMember, error) {
+	for _, member := range r.Members {
+		if member.GardenID == gardenID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockGardenMemberRepository) GetSharedGardens(ctx context.Context, userID uint) ([]model.Garden, error) {
+	var result []model.Garden
+	for _, member := range r.Members {
+		if member.UserID != userID {
+			continue
+		}
+		if garden, ok := r.gardens.Gardens[member.GardenID]; ok {
+			result = append(result, *garden)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockGardenMemberRepository) Delete(ctx context.Context, gardenID, userID uint) error {
+	kept := r.Members[:0]
+	for _, member := range r.Members {
+		if member.GardenID != gardenID || member.UserID != userID {
+			kept = append(kept, member)
+		}
+	}
+	r.Members = kept
+	return nil
+}
+
+// MockOrganizationRepository は OrganizationRepository インターフェースのモック実装です。
+// ユーザーがメンバーの組織は、組織のメンバーのモックから取得します。
+type MockOrganizationRepository struct {
+	mockClock
+
+	Organizations map[uint]*model.Organization
+	NextID        uint
+
+	members *MockOrganizationMemberRepository
+}
+
+// NewMockOrganizationRepository は新しいMockOrganizationRepositoryを作成します。
+func NewMockOrganizationRepository(members *MockOrganizationMemberRepository) *MockOrganizationRepository {
+	return &MockOrganizationRepository{Organizations: make(map[uint]*model.Organization), NextID: 1, members: members}
+}
+
+func (r *MockOrganizationRepository) Create(ctx context.Context, organization *model.Organization) error {
+	organization.ID = r.NextID
+	r.NextID++
+	organization.CreatedAt = r.now()
+	organization.UpdatedAt = r.now()
+	r.Organizations[organization.ID] = organization
+	return nil
+}
+
+func (r *MockOrganizationRepository) GetByID(ctx context.Context, id uint) (*model.Organization, error) {
+	if organization, ok := r.Organizations[id]; ok {
+		return organization, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockOrganizationRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Organization, error) {
+	var result []model.Organization
+	for _, member := range r.members.Members {
+		if member.UserID != userID {
+			continue
+		}
+		if organization, ok := r.Organizations[member.OrganizationID]; ok {
+			result = append(result, *organization)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockOrganizationRepository) Update(ctx context.Context, organization *model.Organization) error {
+	if _, ok := r.Organizations[organization.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	organization.UpdatedAt = r.now()
+	r.Organizations[organization.ID] = organization
+	return nil
+}
+
+// MockOrganizationMemberRepository は OrganizationMemberRepository インターフェースのモック実装です。
+// メンバーのユーザー情報は、ユーザーのモックから取得します。
+type MockOrganizationMemberRepository struct {
+	mockClock
+
+	Members []*model.OrganizationMember
+	NextID  uint
+
+	users *MockUserRepository
+}
+
+// NewMockOrganizationMemberRepository は新しいMockOrganizationMemberRepositoryを作成します。
+func NewMockOrganizationMemberRepository(users *MockUserRepository) *MockOrganizationMemberRepository {
+	return &MockOrganizationMemberRepository{NextID: 1, users: users}
+}
+
+func (r *MockOrganizationMemberRepository) Create(ctx context.Context, member *model.OrganizationMember) error {
+	if existing, _ := r.Get(ctx, member.OrganizationID, member.UserID); existing != nil {
+		return gorm.ErrDuplicatedKey
+	}
+	member.ID = r.NextID
+	r.NextID++
+	member.CreatedAt = r.now()
+	member.UpdatedAt = r.now()
+	r.Members = append(r.Members, member)
+	return nil
+}
+
+func (r *MockOrganizationMemberRepository) GetByOrganizationID(ctx context.Context, organizationID uint) ([]model.OrganizationMember, error) {
+	var result []model.OrganizationMember
+	for _, member := range r.Members {
+		if member.OrganizationID == organizationID {
+			m := *member
+			if user, ok := r.users.Users[m.UserID]; ok {
+				m.User = *user
+			}
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockOrganizationMemberRepository) Get(ctx context.Context, organizationID, userID uint) (*model.OrganizationMember, error) {
+	for _, member := range r.Members {
+		if member.OrganizationID == organizationID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockOrganizationMemberRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	for _, member := range r.Members {
+		if member.OrganizationID == organizationID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MockOrganizationMemberRepository) Delete(ctx context.Context, organizationID, userID uint) error {
+	kept := r.Members[:0]
+	for _, member := range r.Members {
+		if member.OrganizationID != organizationID || member.UserID != userID {
+			kept = append(kept, member)
+		}
+	}
+	r.Members = kept
+	return nil
+}
+
+// MockAuditLogRepository は AuditLogRepository インターフェースのモック実装です。
+// 本番の変更履歴は AuditPlugin が作成するため、テストでは Logs に直接追加します。
+type MockAuditLogRepository struct {
+	Logs []model.AuditLog
+}
+
+// NewMockAuditLogRepository は新しいMockAuditLogRepositoryを作成します。
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{}
+}
+
+// ListByEntity は記録の変更履歴を1ページ分取得します（新しい順、ownerID が 0 の場合は所有者で絞り込まない）。
+func (r *MockAuditLogRepository) ListByEntity(ctx context.Context, entity string, entityID, ownerID uint, params pagination.Params) ([]model.AuditLog, error) {
+	var matched []model.AuditLog
+	for _, entry := range r.Logs {
+		if entry.Entity == entity && entry.EntityID == entityID && (ownerID == 0 || entry.OwnerID == ownerID) {
+			matched = append(matched, entry)
+		}
+	}
+	return paginateMockByID(matched, params, func(l model.AuditLog) uint { return l.ID }), nil
+}
+
+// MockDatabaseBackupRepository は DatabaseBackupRepository インターフェースのモック実装です。
+type MockDatabaseBackupRepository struct {
+	mockClock
+
+	Backups map[uint]*model.DatabaseBackup
+	NextID  uint
+}
+
+// NewMockDatabaseBackupRepository は新しいMockDatabaseBackupRepositoryを作成します。
+func NewMockDatabaseBackupRepository() *MockDatabaseBackupRepository {
+	return &MockDatabaseBackupRepository{
+		Backups: make(map[uint]*model.DatabaseBackup),
+		NextID:  1,
+	}
+}
+
+func (r *MockDatabaseBackupRepository) Create(ctx context.Context, backup *model.DatabaseBackup) error {
+	backup.ID = r.NextID
+	r.NextID++
+	backup.CreatedAt = r.now()
+	backup.UpdatedAt = r.now()
+	r.Backups[backup.ID] = backup
+	return nil
+}
+
+func (r *MockDatabaseBackupRepository) GetByID(ctx context.Context, id uint) (*model.DatabaseBackup, error) {
+	if backup, ok := r.Backups[id]; ok {
+		return backup, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockDatabaseBackupRepository) Update(ctx context.Context, backup *model.DatabaseBackup) error {
+	if _, ok := r.Backups[backup.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	backup.UpdatedAt = r.now()
+	r.Backups[backup.ID] = backup
+	return nil
+}
+
+func (r *MockDatabaseBackupRepository) ListPaginated(ctx context.Context, params pagination.Params) ([]model.DatabaseBackup, error) {
+	backups := make([]model.DatabaseBackup, 0, len(r.Backups))
+	for _, backup := range r.Backups {
+		backups = append(backups, *backup)
+	}
+	return paginateMockByID(backups, params, func(b model.DatabaseBackup) uint { return b.ID }), nil
+}
+
+// MockPhotoRepository は PhotoRepository インターフェースのモック実装です。
+// 作物・収穫記録が削除された写真（GetOrphanedByUserID, GetOrphanedBefore）は作物・収穫記録のモックから判定します。
+type MockPhotoRepository struct {
+	mockClock
+
+	Photos map[uint]*model.Photo
+	// DeletedPhotos は論理削除した写真（保持期間の後に物理削除）
+	DeletedPhotos map[uint]*model.Photo
+	NextID        uint
+
+	crops    *MockCropRepository
+	harvests *MockHarvestRepository
+}
+
+// NewMockPhotoRepository は新しいMockPhotoRepositoryを作成します。
+func NewMockPhotoRepository() *MockPhotoRepository {
+	return &MockPhotoRepository{
+		Photos:        make(map[uint]*model.Photo),
+		DeletedPhotos: make(map[uint]*model.Photo),
+		NextID:        1,
+	}
+}
+
+func (r *MockPhotoRepository) Create(ctx context.Context, photo *model.Photo) error {
+	photo.ID = r.NextID
+	r.NextID++
+	photo.CreatedAt = r.now()
+	photo.UpdatedAt = r.now()
+	r.Photos[photo.ID] = photo
+	return nil
+}
+
+func (r *MockPhotoRepository) GetByID(ctx context.Context, id uint) (*model.Photo, error) {
+	if photo, ok := r.Photos[id]; ok {
+		return photo, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockPhotoRepository) Update(ctx context.Context, photo *model.Photo) error {
+	if _, ok := r.Photos[photo.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	photo.UpdatedAt = r.now()
+	r.Photos[photo.ID] = photo
+	return nil
+}
+
+func (r *MockPhotoRepository) Delete(ctx context.Context, id uint) error {
+	if photo, ok := r.Photos[id]; ok {
+		delete(r.Photos, id)
+		photo.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedPhotos[id] = photo
+	}
+	return nil
+}
+
+// purgeDeleted は before より前に論理削除した写真を数え、dryRun でない場合は物理削除します。
+func (r *MockPhotoRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedPhotos, func(p *model.Photo) gorm.DeletedAt { return p.DeletedAt }, before, dryRun)
+}
+
+func (r *MockPhotoRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	for _, photo := range r.Photos {
+		if photo.UserID == userID {
+			total.Count++
+			total.Bytes += photo.SizeBytes
+		}
+	}
+	return total, nil
+}
+
+func (r *MockPhotoRepository) GetOrphanedByUserID(ctx context.Context, userID uint) ([]model.Photo, error) {
+	var result []model.Photo
+	for _, photo := range r.Photos {
+		if _, orphaned := r.orphanedAt(photo); orphaned && photo.UserID == userID {
+			result = append(result, *photo)
+		}
+	}
+	// 古い順（IDの昇順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockPhotoRepository) GetCompletedByUserID(ctx context.Context, userID uint) ([]model.Photo, error) {
+	var result []model.Photo
+	for _, photo := range r.Photos {
+		if photo.UserID == userID && photo.Status == "completed" {
+			result = append(result, *photo)
+		}
+	}
+	// 古い順（IDの昇順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockPhotoRepository) GetOrphanedBefore(ctx context.Context, before time.Time, limit int) ([]model.Photo, error) {
+	var result []model.Photo
+	for _, photo := range r.Photos {
+		if at, orphaned := r.orphanedAt(photo); orphaned && at.Before(before) {
+			result = append(result, *photo)
+		}
+	}
+	// 古い順（IDの昇順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockPhotoRepository) CountOrphanedBefore(ctx context.Context, before time.Time) (int64, error) {
+	photos, err := r.GetOrphanedBefore(ctx, before, 0)
+	return int64(len(photos)), err
+}
+
+// orphanedAt は写真の作物・収穫記録が削除された日時を返します（論理削除は削除日時、物理削除済みは写真の作成日時）。
+// 作物・収穫記録が削除されていない場合は false です。
+func (r *MockPhotoRepository) orphanedAt(photo *model.Photo) (time.Time, bool) {
+	if photo.CropID != nil && r.crops != nil {
+		if _, active := r.crops.Crops[*photo.CropID]; !active {
+			if crop, deleted := r.crops.DeletedCrops[*photo.CropID]; deleted {
+				return crop.DeletedAt.Time, true
+			}
+			return photo.CreatedAt, true
+		}
+	}
+	if photo.HarvestID != nil && r.harvests != nil {
+		if _, active := r.harvests.Harvests[*photo.HarvestID]; !active {
+			if harvest, deleted := r.harvests.DeletedHarvests[*photo.HarvestID]; deleted {
+				return harvest.DeletedAt.Time, true
+			}
+			return photo.CreatedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// MockAttachmentRepository は AttachmentRepository インターフェースのモック実装です。
+type MockAttachmentRepository struct {
+	mockClock
+
+	Attachments map[uint]*model.Attachment
+	// DeletedAttachments は論理削除した添付ファイル（保持期間の後に物理削除）
+	DeletedAttachments map[uint]*model.Attachment
+	NextID             uint
+}
+
+// NewMockAttachmentRepository は新しいMockAttachmentRepositoryを作成します。
+func NewMockAttachmentRepository() *MockAttachmentRepository {
+	return &MockAttachmentRepository{
+		Attachments:        make(map[uint]*model.Attachment),
+		DeletedAttachments: make(map[uint]*model.Attachment),
+		NextID:             1,
+	}
+}
+
+func (r *MockAttachmentRepository) Create(ctx context.Context, attachment *model.Attachment) error {
+	if exists, _ := r.ExistsByObjectKey(ctx, attachment.ObjectKey); exists {
+		return gorm.ErrDuplicatedKey
+	}
+	attachment.ID = r.NextID
+	r.NextID++
+	attachment.CreatedAt = r.now()
+	attachment.UpdatedAt = r.now()
+	r.Attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (r *MockAttachmentRepository) GetByID(ctx context.Context, id uint) (*model.Attachment, error) {
+	if attachment, ok := r.Attachments[id]; ok {
+		return attachment, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MockAttachmentRepository) ExistsByObjectKey(ctx context.Context, objectKey string) (bool, error) {
+	for _, records := range []map[uint]*model.Attachment{r.Attachments, r.DeletedAttachments} {
+		for _, attachment := range records {
+			if attachment.ObjectKey == objectKey {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *MockAttachmentRepository) Delete(ctx context.Context, id uint) error {
+	if attachment, ok := r.Attachments[id]; ok {
+		delete(r.Attachments, id)
+		attachment.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		r.DeletedAttachments[id] = attachment
+	}
+	return nil
+}
+
+// purgeDeleted は before より前に論理削除した添付ファイルを数え、dryRun でない場合は物理削除します。
+func (r *MockAttachmentRepository) purgeDeleted(before time.Time, dryRun bool) int64 {
+	return purgeDeletedRecords(r.DeletedAttachments, func(a *model.Attachment) gorm.DeletedAt { return a.DeletedAt }, before, dryRun)
+}
+
+func (r *MockAttachmentRepository) ListByParent(ctx context.Context, parentType string, parentID uint) ([]model.Attachment, error) {
+	var result []model.Attachment
+	for _, attachment := range r.Attachments {
+		if attachment.ParentType == parentType && attachment.ParentID == parentID {
+			result = append(result, *attachment)
+		}
+	}
+	// 古い順（IDの昇順）
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *MockAttachmentRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	for _, attachment := range r.Attachments {
+		if attachment.UserID == userID {
+			total.Count++
+			total.Bytes += attachment.SizeBytes
+		}
+	}
+	return total, nil
+}
+
+// MockRepositories は Repositories インターフェースのモック実装です。
+// 各リポジトリのモックを保持し、テストで依存性注入するために使用します。
+//
+// 設計パターン: ファサードパターン
+// - 複数のモックリポジトリを1つのインターフェースでまとめる
+// - Service層は本番/テストを意識せずRepositoriesインターフェースを使用
+type MockRepositories struct {
+	userRepo            *MockUserRepository
+	gardenRepo          *MockGardenRepository
+	plantRepo           *MockPlantRepository
+	careLogRepo         *MockCareLogRepository
+	tokenBlacklistRepo  *MockTokenBlacklistRepository
+	taskRepo            *MockTaskRepository
+	cropRepo            *MockCropRepository
+	growthRecordRepo    *MockGrowthRecordRepository
+	harvestRepo         *MockHarvestRepository
+	seasonSummaryRepo   *MockSeasonSummaryRepository
+	plotRepo            *MockPlotRepository
+	plotAssignmentRepo  *MockPlotAssignmentRepository
+	deviceTokenRepo     *MockDeviceTokenRepository
+	notificationLogRepo *MockNotificationLogRepository
+	notificationPreferenceRepo *MockNotificationPreferenceRepository
+	phoneVerificationRepo *MockPhoneVerificationRepository
+	announcementRepo    *MockAnnouncementRepository
+	notificationOutboxRepo *MockNotificationOutboxRepository
+	notificationDeadLetterRepo *MockNotificationDeadLetterRepository
+	schedulerInvocationRepo *MockSchedulerInvocationRepository
+	jobRunRepo          *MockJobRunRepository
+	schedulerCheckpointRepo *MockSchedulerCheckpointRepository
+	scheduleConfigRepo  *MockScheduleConfigRepository
+	shareTokenRepo      *MockShareTokenRepository
+	analyticsViewRepo   *MockAnalyticsViewRepository
+	exportRecordRepo    *MockExportRecordRepository
+	usageStatsRepo      *MockUsageStatsRepository
+	retentionRepo       *MockRetentionRepository
+	syncRepo            *MockSyncRepository
+	searchRepo          *MockSearchRepository
+	gardenMemberRepo    *MockGardenMemberRepository
+	organizationRepo    *MockOrganizationRepository
+	organizationMemberRepo *MockOrganizationMemberRepository
+	auditLogRepo        *MockAuditLogRepository
+	databaseBackupRepo  *MockDatabaseBackupRepository
+	photoRepo           *MockPhotoRepository
+	attachmentRepo      *MockAttachmentRepository
+
+	// transactional は WithTransaction でロールバックをシミュレートするか（EnableTransactionalMode）
+	transactional bool
+}
+
+// NewMockRepositories は新しいMockRepositoriesを作成します。
+// 各モックリポジトリを初期化して返します。
+func NewMockRepositories() *MockRepositories {
+	m := &MockRepositories{
+		userRepo:            NewMockUserRepository(),
+		gardenRepo:          NewMockGardenRepository(),
+		plantRepo:           &MockPlantRepository{},
+		careLogRepo:         &MockCareLogRepository{},
+		tokenBlacklistRepo:  NewMockTokenBlacklistRepository(),
+		taskRepo:            NewMockTaskRepository(),
+		cropRepo:            NewMockCropRepository(),
+		growthRecordRepo:    NewMockGrowthRecordRepository(),
+		harvestRepo:         NewMockHarvestRepository(),
+		seasonSummaryRepo:   NewMockSeasonSummaryRepository(),
+		plotRepo:            NewMockPlotRepository(),
+		plotAssignmentRepo:  NewMockPlotAssignmentRepository(),
+		deviceTokenRepo:     NewMockDeviceTokenRepository(),
+		notificationLogRepo: NewMockNotificationLogRepository(),
+		notificationPreferenceRepo: NewMockNotificationPreferenceRepository(),
+		phoneVerificationRepo: NewMockPhoneVerificationRepository(),
+		notificationOutboxRepo: NewMockNotificationOutboxRepository(),
+		notificationDeadLetterRepo: NewMockNotificationDeadLetterRepository(),
+		schedulerInvocationRepo: NewMockSchedulerInvocationRepository(),
+		jobRunRepo:          NewMockJobRunRepository(),
+		schedulerCheckpointRepo: NewMockSchedulerCheckpointRepository(),
+		scheduleConfigRepo:  NewMockScheduleConfigRepository(),
+		shareTokenRepo:      NewMockShareTokenRepository(),
+		analyticsViewRepo:   NewMockAnalyticsViewRepository(),
+		exportRecordRepo:    NewMockExportRecordRepository(),
+		usageStatsRepo:      NewMockUsageStatsRepository(),
+		retentionRepo:       NewMockRetentionRepository(),
+		auditLogRepo:        NewMockAuditLogRepository(),
+		databaseBackupRepo:  NewMockDatabaseBackupRepository(),
+		photoRepo:           NewMockPhotoRepository(),
+		attachmentRepo:      NewMockAttachmentRepository(),
+	}
+	m.announcementRepo = NewMockAnnouncementRepository(m.userRepo, m.deviceTokenRepo, m.usageStatsRepo)
+	m.syncRepo = NewMockSyncRepository(m.taskRepo, m.cropRepo, m.plotRepo, m.harvestRepo)
+	m.harvestRepo.crops = m.cropRepo
+	m.plotRepo.assignments = m.plotAssignmentRepo
+	m.plotRepo.crops = m.cropRepo
+	m.photoRepo.crops = m.cropRepo
+	m.photoRepo.harvests = m.harvestRepo
+	m.searchRepo = NewMockSearchRepository(m.taskRepo, m.cropRepo, m.plotRepo, m.growthRecordRepo)
+	m.gardenMemberRepo = NewMockGardenMemberRepository(m.userRepo, m.gardenRepo)
+	m.organizationMemberRepo = NewMockOrganizationMemberRepository(m.userRepo)
+	m.organizationRepo = NewMockOrganizationRepository(m.organizationMemberRepo)
+	m.retentionRepo.tables = map[string]mockSoftDeleteTable{
+		"tasks":            m.taskRepo,
+		"gardens":          m.gardenRepo,
+		"growth_records":   m.growthRecordRepo,
+		"harvests":         m.harvestRepo,
+		"plot_assignments": m.plotAssignmentRepo,
+		"crops":            m.cropRepo,
+		"plots":            m.plotRepo,
+		"photos":           m.photoRepo,
+		"attachments":      m.attachmentRepo,
+	}
+	return m
+}
+
+// User は UserRepository インターフェースを返します。
+// Service層から呼び出されます。
+func (m *MockRepositories) User() UserRepository {
+	return m.userRepo
+}
+
+// Garden は GardenRepository インターフェースを返します。
+func (m *MockRepositories) Garden() GardenRepository {
+	return m.gardenRepo
+}
+
+// Plant は PlantRepository インターフェースを返します。
+func (m *MockRepositories) Plant() PlantRepository {
+	return m.plantRepo
+}
+
+// CareLog は CareLogRepository インターフェースを返します。
+func (m *MockRepositories) CareLog() CareLogRepository {
+	return m.careLogRepo
+}
+
+// TokenBlacklist は TokenBlacklistRepository インターフェースを返します。
+func (m *MockRepositories) TokenBlacklist() TokenBlacklistRepository {
+	return m.tokenBlacklistRepo
+}
+
+// Task は TaskRepository インターフェースを返します。
+func (m *MockRepositories) Task() TaskRepository {
+	return m.taskRepo
+}
+
+// Crop は CropRepository インターフェースを返します。
+func (m *MockRepositories) Crop() CropRepository {
+	return m.cropRepo
+}
+
+// GrowthRecord は GrowthRecordRepository インターフェースを返します。
+func (m *MockRepositories) GrowthRecord() GrowthRecordRepository {
+	return m.growthRecordRepo
+}
+
+// Harvest は HarvestRepository インターフェースを返します。
+func (m *MockRepositories) Harvest() HarvestRepository {
+	return m.harvestRepo
+}
+
+// SeasonSummary は SeasonSummaryRepository インターフェースを返します。
+func (m *MockRepositories) SeasonSummary() SeasonSummaryRepository {
+	return m.seasonSummaryRepo
+}
+
+// Plot は PlotRepository インターフェースを返します。
+func (m *MockRepositories) Plot() PlotRepository {
+	return m.plotRepo
+}
+
+// PlotAssignment は PlotAssignmentRepository インターフェースを返します。
+func (m *MockRepositories) PlotAssignment() PlotAssignmentRepository {
+	return m.plotAssignmentRepo
+}
+
+// DeviceToken は DeviceTokenRepository インターフェースを返します。
+func (m *MockRepositories) DeviceToken() DeviceTokenRepository {
+	return m.deviceTokenRepo
+}
+
+// NotificationLog は NotificationLogRepository インターフェースを返します。
+func (m *MockRepositories) NotificationLog() NotificationLogRepository {
+	return m.notificationLogRepo
+}
+
+// NotificationPreference は NotificationPreferenceRepository インターフェースを返します。
+func (m *MockRepositories) NotificationPreference() NotificationPreferenceRepository {
+	return m.notificationPreferenceRepo
+}
+
+// PhoneVerification は PhoneVerificationRepository インターフェースを返します。
+func (m *MockRepositories) PhoneVerification() PhoneVerificationRepository {
+	return m.phoneVerificationRepo
+}
+
+// Announcement は AnnouncementRepository インターフェースを返します。
+func (m *MockRepositories) Announcement() AnnouncementRepository {
+	return m.announcementRepo
+}
+
+// NotificationOutbox は NotificationOutboxRepository インターフェースを返します。
+func (m *MockRepositories) NotificationOutbox() NotificationOutboxRepository {
+	return m.notificationOutboxRepo
+}
+
+// NotificationDeadLetter は NotificationDeadLetterRepository インターフェースを返します。
+func (m *MockRepositories) NotificationDeadLetter() NotificationDeadLetterRepository {
+	return m.notificationDeadLetterRepo
+}
+
+// SchedulerInvocation は SchedulerInvocationRepository インターフェースを返します。
+func (m *MockRepositories) SchedulerInvocation() SchedulerInvocationRepository {
+	return m.schedulerInvocationRepo
+}
+
+// JobRun は JobRunRepository インターフェースを返します。
+func (m *MockRepositories) JobRun() JobRunRepository {
+	return m.jobRunRepo
+}
+
+// SchedulerCheckpoint は SchedulerCheckpointRepository インターフェースを返します。
+func (m *MockRepositories) SchedulerCheckpoint() SchedulerCheckpointRepository {
+	return m.schedulerCheckpointRepo
+}
+
+// ScheduleConfig は ScheduleConfigRepository インターフェースを返します。
+func (m *MockRepositories) ScheduleConfig() ScheduleConfigRepository {
+	return m.scheduleConfigRepo
+}
+
+// ShareToken は ShareTokenRepository インターフェースを返します。
+func (m *MockRepositories) ShareToken() ShareTokenRepository {
+	return m.shareTokenRepo
+}
+
+// AnalyticsView は AnalyticsViewRepository インターフェースを返します。
+func (m *MockRepositories) AnalyticsView() AnalyticsViewRepository {
+	return m.analyticsViewRepo
+}
+
+// ExportRecord は ExportRecordRepository インターフェースを返します。
+func (m *MockRepositories) ExportRecord() ExportRecordRepository {
+	return m.exportRecordRepo
+}
+
+// UsageStats は UsageStatsRepository インターフェースを返します。
+func (m *MockRepositories) UsageStats() UsageStatsRepository {
+	return m.usageStatsRepo
+}
+
+// Retention は RetentionRepository インターフェースを返します。
+func (m *MockRepositories) Retention() RetentionRepository {
+	return m.retentionRepo
+}
+
+// Sync は SyncRepository インターフェースを返します。
+func (m *MockRepositories) Sync() SyncRepository {
+	return m.syncRepo
+}
+
+// Search は SearchRepository インターフェースを返します。
+func (m *MockRepositories) Search() SearchRepository {
+	return m.searchRepo
+}
+
+// GardenMember は GardenMemberRepository インターフェースを返します。
+func (m *MockRepositories) GardenMember() GardenMemberRepository {
+	return m.gardenMemberRepo
+}
+
+// Organization は OrganizationRepository インターフェースを返します。
+func (m *MockRepositories) Organization() OrganizationRepository {
+	return m.organizationRepo
+}
+
+// OrganizationMember は OrganizationMemberRepository インターフェースを返します。
+func (m *MockRepositories) OrganizationMember() OrganizationMemberRepository {
+	return m.organizationMemberRepo
+}
+
+// AuditLog は AuditLogRepository インターフェースを返します。
+func (m *MockRepositories) AuditLog() AuditLogRepository {
+	return m.auditLogRepo
+}
+
+// DatabaseBackup は DatabaseBackupRepository インターフェースを返します。
+func (m *MockRepositories) DatabaseBackup() DatabaseBackupRepository {
+	return m.databaseBackupRepo
+}
+
+// Photo は PhotoRepository インターフェースを返します。
+func (m *MockRepositories) Photo() PhotoRepository {
+	return m.photoRepo
+}
+
+// Attachment は AttachmentRepository インターフェースを返します。
+func (m *MockRepositories) Attachment() AttachmentRepository {
+	return m.attachmentRepo
 }
 
 // WithTransaction はトランザクション処理をシミュレートします。
@@ -1328,10 +4197,172 @@ func (m *MockRepositories) NotificationLog() NotificationLogRepository {
 // テストでこれで問題ない理由:
 // - 各テストは独立したMockRepositoriesを作成
 // - テスト間でデータが共有されない
-// - ロールバックをテストしたい場合はCreateFunc等でエラーを投げる
+// - ロールバックをテストしたい場合は EnableTransactionalMode を呼び、CreateFunc等でエラーを投げる
+//
+// AfterCommit で登録した処理は本番と同じく、最も外側の関数が成功した場合のみ実行します。
 func (m *MockRepositories) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	// 単純に関数を実行するだけ（BEGIN/COMMIT/ROLLBACKなし）
-	return fn(ctx)
+	if _, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		return fn(ctx)
+	}
+
+	// トランザクションモードの場合は BEGIN の時点の状態を保存する
+	var snapshot *mockSnapshot
+	if m.transactional {
+		snapshot = m.snapshot()
+	}
+
+	txCtx, hooks := contextWithAfterCommit(ctx)
+	if err := fn(txCtx); err != nil {
+		if snapshot != nil {
+			snapshot.restore()
+		}
+		return err
+	}
+	hooks.run()
+	return nil
+}
+
+// SetClock は全てのモックリポジトリの現在時刻（作成日時・期限の判定など）を設定します。
+//
+// 使用例:
+//
+//	fake := clock.NewFake(time.Date(2026, 5, 31, 23, 59, 0, 0, time.UTC))
+//	mockRepos.SetClock(fake)
+//	svc.SetClock(fake)
+func (m *MockRepositories) SetClock(c clock.Clock) {
+	for _, repo := range m.mockRepositories() {
+		if r, ok := repo.(interface{ setClock(clock.Clock) }); ok {
+			r.setClock(c)
+		}
+	}
+}
+
+// mockClock はモックリポジトリの現在時刻です（未設定の場合はシステムの時刻）。
+// 時刻を使用するモックリポジトリに埋め込み、time.Now の代わりに now を使用します。
+type mockClock struct {
+	clock clock.Clock
+}
+
+// now は現在時刻を返します。
+func (c *mockClock) now() time.Time {
+	return clock.Now(c.clock)
+}
+
+// setClock は現在時刻を設定します（MockRepositories.SetClock）。
+func (c *mockClock) setClock(cl clock.Clock) {
+	c.clock = cl
+}
+
+// EnableTransactionalMode は WithTransaction でロールバックをシミュレートするモードにします。
+// BEGIN（最も外側の WithTransaction）の時点で各モックリポジトリの Map・スライス・NextID と格納した記録の値を保存し、
+// 関数がエラーを返した場合に保存した状態に戻します。
+// 入れ子の WithTransaction（本番のセーブポイント）は最も外側のトランザクションに含まれます。
+//
+// 使用例:
+//
+//	mockRepos := repository.NewMockRepositories()
+//	mockRepos.EnableTransactionalMode()
+//	mockRepos.GetMockCropRepository().DeleteFunc = func(...) error { return errors.New("database error") }
+//	// DeleteCrop が失敗しても、先に削除した成長記録・収穫記録は残る
+func (m *MockRepositories) EnableTransactionalMode() {
+	m.transactional = true
+}
+
+// mockRepositories は状態を保存・復元するモックリポジトリの一覧です。
+func (m *MockRepositories) mockRepositories() []interface{} {
+	return []interface{}{
+		m.userRepo, m.gardenRepo, m.plantRepo, m.careLogRepo, m.tokenBlacklistRepo, m.taskRepo, m.cropRepo,
+		m.growthRecordRepo, m.harvestRepo, m.seasonSummaryRepo, m.plotRepo, m.plotAssignmentRepo, m.deviceTokenRepo,
+		m.notificationLogRepo, m.notificationPreferenceRepo, m.phoneVerificationRepo, m.announcementRepo,
+		m.notificationOutboxRepo, m.notificationDeadLetterRepo, m.schedulerInvocationRepo, m.jobRunRepo,
+		m.schedulerCheckpointRepo, m.scheduleConfigRepo, m.shareTokenRepo, m.analyticsViewRepo, m.exportRecordRepo,
+		m.usageStatsRepo, m.retentionRepo, m.syncRepo, m.searchRepo, m.gardenMemberRepo, m.organizationRepo,
+		m.organizationMemberRepo, m.auditLogRepo, m.databaseBackupRepo, m.photoRepo,
+		m.attachmentRepo,
+	}
+}
+
+// mockSnapshot は WithTransaction の BEGIN の時点のモックリポジトリの状態です。
+type mockSnapshot struct {
+	// fields はモックリポジトリの公開フィールドと保存した値（Map・スライスはコピー）
+	fields []mockSnapshotField
+	// records は格納した記録のポインタと保存した値（記録を直接変更する Update・Delete を元に戻す）
+	records map[uintptr]mockSnapshotField
+}
+
+// mockSnapshotField は保存した値と復元先です。
+type mockSnapshotField struct {
+	target reflect.Value
+	saved  reflect.Value
+}
+
+// snapshot はモックリポジトリの状態を保存します。
+// CreateFunc などの関数・エラーのフィールドはテストの設定のため保存しません。
+func (m *MockRepositories) snapshot() *mockSnapshot {
+	snapshot := &mockSnapshot{records: make(map[uintptr]mockSnapshotField)}
+	for _, repo := range m.mockRepositories() {
+		v := reflect.ValueOf(repo).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			switch field.Kind() {
+			case reflect.Func, reflect.Interface, reflect.Ptr, reflect.Chan:
+				continue
+			}
+			snapshot.fields = append(snapshot.fields, mockSnapshotField{target: field, saved: snapshot.copyValue(field)})
+		}
+	}
+	return snapshot
+}
+
+// copyValue は Map・スライスをコピーし、格納した記録（構造体のポインタ）の値を保存します。
+// 記録のポインタはそのまま使うため、テストが保持している記録も復元されます。
+func (s *mockSnapshot) copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), s.copyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(s.copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Ptr:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			if _, ok := s.records[v.Pointer()]; !ok {
+				saved := reflect.New(v.Elem().Type()).Elem()
+				saved.Set(v.Elem())
+				s.records[v.Pointer()] = mockSnapshotField{target: v.Elem(), saved: saved}
+			}
+		}
+		return v
+	}
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
+}
+
+// restore は保存した状態に戻します（ROLLBACK）。
+func (s *mockSnapshot) restore() {
+	for _, field := range s.fields {
+		field.target.Set(field.saved)
+	}
+	for _, record := range s.records {
+		record.target.Set(record.saved)
+	}
 }
 
 // GetMockUserRepository はテストセットアップ用に内部のモックリポジトリを返します。
@@ -1378,6 +4409,11 @@ func (m *MockRepositories) GetMockHarvestRepository() *MockHarvestRepository {
 	return m.harvestRepo
 }
 
+// GetMockSeasonSummaryRepository はテスト用に内部のシーズンの記録モックを返します。
+func (m *MockRepositories) GetMockSeasonSummaryRepository() *MockSeasonSummaryRepository {
+	return m.seasonSummaryRepo
+}
+
 // GetMockPlotRepository はテスト用に内部の区画モックを返します。
 // 区画のテストデータセットアップやカスタム動作注入に使用します。
 func (m *MockRepositories) GetMockPlotRepository() *MockPlotRepository {
@@ -1388,3 +4424,118 @@ func (m *MockRepositories) GetMockPlotRepository() *MockPlotRepository {
 func (m *MockRepositories) GetMockPlotAssignmentRepository() *MockPlotAssignmentRepository {
 	return m.plotAssignmentRepo
 }
+
+// GetMockShareTokenRepository はテスト用に内部の共有トークンモックを返します。
+func (m *MockRepositories) GetMockShareTokenRepository() *MockShareTokenRepository {
+	return m.shareTokenRepo
+}
+
+// GetMockAnalyticsViewRepository はテスト用に内部のマテリアライズドビューモックを返します。
+func (m *MockRepositories) GetMockAnalyticsViewRepository() *MockAnalyticsViewRepository {
+	return m.analyticsViewRepo
+}
+
+// GetMockExportRecordRepository はテスト用に内部のエクスポート履歴モックを返します。
+func (m *MockRepositories) GetMockExportRecordRepository() *MockExportRecordRepository {
+	return m.exportRecordRepo
+}
+
+// GetMockUsageStatsRepository はテスト用に内部の利用統計モックを返します。
+func (m *MockRepositories) GetMockUsageStatsRepository() *MockUsageStatsRepository {
+	return m.usageStatsRepo
+}
+
+// GetMockSyncRepository はテスト用に内部のオフライン同期モックを返します。
+func (m *MockRepositories) GetMockSyncRepository() *MockSyncRepository {
+	return m.syncRepo
+}
+
+// GetMockGardenRepository はテスト用に内部の庭モックを返します。
+func (m *MockRepositories) GetMockGardenRepository() *MockGardenRepository {
+	return m.gardenRepo
+}
+
+// GetMockGardenMemberRepository はテスト用に内部の共有の庭のメンバーモックを返します。
+func (m *MockRepositories) GetMockGardenMemberRepository() *MockGardenMemberRepository {
+	return m.gardenMemberRepo
+}
+
+// GetMockOrganizationRepository はテスト用に内部の組織モックを返します。
+func (m *MockRepositories) GetMockOrganizationRepository() *MockOrganizationRepository {
+	return m.organizationRepo
+}
+
+// GetMockOrganizationMemberRepository はテスト用に内部の組織のメンバーモックを返します。
+func (m *MockRepositories) GetMockOrganizationMemberRepository() *MockOrganizationMemberRepository {
+	return m.organizationMemberRepo
+}
+
+// GetMockAuditLogRepository はテスト用に内部の変更履歴モックを返します。
+func (m *MockRepositories) GetMockAuditLogRepository() *MockAuditLogRepository {
+	return m.auditLogRepo
+}
+
+// GetMockDatabaseBackupRepository はテスト用に内部のバックアップの履歴モックを返します。
+func (m *MockRepositories) GetMockDatabaseBackupRepository() *MockDatabaseBackupRepository {
+	return m.databaseBackupRepo
+}
+
+// GetMockPhotoRepository はテスト用に内部の写真モックを返します。
+func (m *MockRepositories) GetMockPhotoRepository() *MockPhotoRepository {
+	return m.photoRepo
+}
+
+// GetMockAttachmentRepository はテスト用に内部の添付ファイルモックを返します。
+func (m *MockRepositories) GetMockAttachmentRepository() *MockAttachmentRepository {
+	return m.attachmentRepo
+}
+
+// GetMockNotificationPreferenceRepository はテスト用に内部の通知設定マトリクスモックを返します。
+func (m *MockRepositories) GetMockNotificationPreferenceRepository() *MockNotificationPreferenceRepository {
+	return m.notificationPreferenceRepo
+}
+
+// GetMockPhoneVerificationRepository はテスト用に内部の電話番号認証モックを返します。
+func (m *MockRepositories) GetMockPhoneVerificationRepository() *MockPhoneVerificationRepository {
+	return m.phoneVerificationRepo
+}
+
+// GetMockAnnouncementRepository はテスト用に内部のお知らせモックを返します。
+func (m *MockRepositories) GetMockAnnouncementRepository() *MockAnnouncementRepository {
+	return m.announcementRepo
+}
+
+// GetMockNotificationOutboxRepository はテスト用に内部の通知アウトボックスモックを返します。
+func (m *MockRepositories) GetMockNotificationOutboxRepository() *MockNotificationOutboxRepository {
+	return m.notificationOutboxRepo
+}
+
+// GetMockNotificationDeadLetterRepository はテスト用に内部の通知デッドレターモックを返します。
+func (m *MockRepositories) GetMockNotificationDeadLetterRepository() *MockNotificationDeadLetterRepository {
+	return m.notificationDeadLetterRepo
+}
+
+// GetMockSchedulerInvocationRepository はテスト用に内部のスケジューラー冪等キーモックを返します。
+func (m *MockRepositories) GetMockSchedulerInvocationRepository() *MockSchedulerInvocationRepository {
+	return m.schedulerInvocationRepo
+}
+
+// GetMockJobRunRepository はテスト用に内部のジョブ実行履歴モックを返します。
+func (m *MockRepositories) GetMockJobRunRepository() *MockJobRunRepository {
+	return m.jobRunRepo
+}
+
+// GetMockSchedulerCheckpointRepository はテスト用に内部のスケジューラーの進捗モックを返します。
+func (m *MockRepositories) GetMockSchedulerCheckpointRepository() *MockSchedulerCheckpointRepository {
+	return m.schedulerCheckpointRepo
+}
+
+// GetMockScheduleConfigRepository はテスト用に内部のスケジュール設定モックを返します。
+func (m *MockRepositories) GetMockScheduleConfigRepository() *MockScheduleConfigRepository {
+	return m.scheduleConfigRepo
+}
+
+// GetMockRetentionRepository はテスト用に内部のデータ保持期間モックを返します。
+func (m *MockRepositories) GetMockRetentionRepository() *MockRetentionRepository {
+	return m.retentionRepo
+}
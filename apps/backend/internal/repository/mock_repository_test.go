@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Mock Repository Tests - モックリポジトリのロールバック・論理削除のテスト
+// =============================================================================
+// テスト対象:
+//   - EnableTransactionalMode: WithTransaction のエラーでの状態の復元、コミットした変更の保持
+//   - mockRepositories: 全てのモックリポジトリを保存の対象にしていること
+//   - 論理削除: Delete の DeletedAt、GetUnscoped、MockRetentionRepository の物理削除
+
+// TestMockRepositories_TransactionalMode はトランザクションモードのテストです。
+// 期待動作:
+//   - 関数がエラーを返した場合、作成した記録・NextID・記録の変更を BEGIN の時点に戻す
+//   - 関数が成功した場合は変更を残す
+//   - トランザクションモードでない場合はエラーでも変更を残す
+func TestMockRepositories_TransactionalMode(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	abortErr := errors.New("abort")
+	newRepos := func(transactional bool) (*MockRepositories, *model.Task) {
+		repos := NewMockRepositories()
+		if transactional {
+			repos.EnableTransactionalMode()
+		}
+		task := &model.Task{UserID: 1, Title: "水やり", Status: "pending"}
+		if err := repos.Task().Create(ctx, task); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		return repos, task
+	}
+	change := func(repos *MockRepositories, task *model.Task, err error) error {
+		return repos.WithTransaction(ctx, func(ctx context.Context) error {
+			task.Status = "completed"
+			if err := repos.Task().Update(ctx, task); err != nil {
+				return err
+			}
+			if err := repos.Task().Create(ctx, &model.Task{UserID: 1, Title: "追肥"}); err != nil {
+				return err
+			}
+			return err
+		})
+	}
+
+	// Act
+	rolledBack, rolledBackTask := newRepos(true)
+	rollbackErr := change(rolledBack, rolledBackTask, abortErr)
+	committed, committedTask := newRepos(true)
+	commitErr := change(committed, committedTask, nil)
+	plain, plainTask := newRepos(false)
+	_ = change(plain, plainTask, abortErr)
+
+	// Assert
+	if !errors.Is(rollbackErr, abortErr) || commitErr != nil {
+		t.Fatalf("Expected abort error and commit, got %v / %v", rollbackErr, commitErr)
+	}
+	if tasks := rolledBack.GetMockTaskRepository(); len(tasks.Tasks) != 1 || len(tasks.TasksByUserID[1]) != 1 {
+		t.Errorf("Expected created task to be rolled back, got %d tasks", len(tasks.Tasks))
+	}
+	if rolledBackTask.Status != "pending" || rolledBack.GetMockTaskRepository().Tasks[rolledBackTask.ID] != rolledBackTask {
+		t.Errorf("Expected the same task with status pending, got %q", rolledBackTask.Status)
+	}
+	next := &model.Task{UserID: 1, Title: "草取り"}
+	_ = rolledBack.Task().Create(ctx, next)
+	if next.ID != rolledBackTask.ID+1 {
+		t.Errorf("Expected NextID to be rolled back, got ID %d", next.ID)
+	}
+	if len(committed.GetMockTaskRepository().Tasks) != 2 || committedTask.Status != "completed" {
+		t.Errorf("Expected committed changes to be kept, got %d tasks", len(committed.GetMockTaskRepository().Tasks))
+	}
+	if len(plain.GetMockTaskRepository().Tasks) != 2 || plainTask.Status != "completed" {
+		t.Errorf("Expected changes to be kept without transactional mode, got %d tasks", len(plain.GetMockTaskRepository().Tasks))
+	}
+}
+
+// TestMockRepositories_SnapshotCoversAllRepositories は保存の対象のテストです。
+// 期待動作:
+//   - MockRepositories のモックリポジトリのフィールドが全て mockRepositories に含まれる
+func TestMockRepositories_SnapshotCoversAllRepositories(t *testing.T) {
+	// Arrange
+	repos := NewMockRepositories()
+	v := reflect.ValueOf(repos).Elem()
+
+	// Act
+	listed := make(map[uintptr]bool)
+	for _, repo := range repos.mockRepositories() {
+		listed[reflect.ValueOf(repo).Pointer()] = true
+	}
+
+	// Assert
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr && !listed[field.Pointer()] {
+			t.Errorf("Expected %s to be included in mockRepositories", v.Type().Field(i).Name)
+		}
+	}
+}
+
+// TestMockRepositories_SoftDelete は論理削除のテストです（本番の GORM のソフトデリートと同じ動作）。
+// 期待動作:
+//   - Delete した記録は GetByID・一覧で見つからず、DeletedAt を設定して GetUnscoped で取得できる
+//   - 論理削除したユーザーのメールアドレスは再登録できる
+//   - 保持期間を過ぎた論理削除の記録は MockRetentionRepository が数え・物理削除する
+func TestMockRepositories_SoftDelete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	deletedAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	repos := NewMockRepositories()
+	repos.SetClock(clock.NewFake(deletedAt))
+	user := &model.User{Email: "soft@example.com"}
+	_ = repos.User().Create(ctx, user)
+	garden := &model.Garden{UserID: user.ID, Name: "家庭菜園"}
+	_ = repos.Garden().Create(ctx, garden)
+	record := &model.GrowthRecord{CropID: 1, RecordDate: deletedAt, GrowthStage: "seedling"}
+	_ = repos.GrowthRecord().Create(ctx, record)
+	task := &model.Task{UserID: user.ID, Title: "水やり", Status: "pending"}
+	_ = repos.Task().Create(ctx, task)
+
+	// Act
+	_ = repos.User().Delete(ctx, user.ID)
+	_ = repos.Garden().Delete(ctx, garden.ID)
+	_ = repos.GrowthRecord().Delete(ctx, record.ID)
+	_ = repos.Task().Delete(ctx, task.ID)
+	_, getErr := repos.User().GetByID(ctx, user.ID)
+	reRegisterErr := repos.User().Create(ctx, &model.User{Email: "soft@example.com"})
+	unscopedUser, unscopedErr := repos.GetMockUserRepository().GetUnscoped(user.ID)
+	gardens, _ := repos.Garden().GetByUserID(ctx, user.ID)
+	records, _ := repos.GrowthRecord().GetByCropID(ctx, 1)
+	_, missingErr := repos.GetMockTaskRepository().GetUnscoped(999)
+	retention := repos.Retention()
+	notExpired, _ := retention.CountSoftDeleted(ctx, "tasks", deletedAt)
+	expired, _ := retention.CountSoftDeleted(ctx, "tasks", deletedAt.Add(time.Hour))
+	purged, _ := retention.PurgeSoftDeleted(ctx, "growth_records", deletedAt.Add(time.Hour))
+
+	// Assert
+	if !errors.Is(getErr, gorm.ErrRecordNotFound) || reRegisterErr != nil {
+		t.Errorf("Expected deleted user to be hidden and its email reusable, got %v / %v", getErr, reRegisterErr)
+	}
+	if unscopedErr != nil || !unscopedUser.DeletedAt.Valid || !unscopedUser.DeletedAt.Time.Equal(deletedAt) {
+		t.Errorf("Expected GetUnscoped to return the deleted user, got %+v (%v)", unscopedUser, unscopedErr)
+	}
+	if len(gardens) != 0 || !garden.DeletedAt.Valid || len(records) != 0 || !record.DeletedAt.Valid {
+		t.Errorf("Expected garden and growth record to be soft-deleted, got %d gardens, %d records", len(gardens), len(records))
+	}
+	if !errors.Is(missingErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound for unknown task, got %v", missingErr)
+	}
+	if notExpired != 0 || expired != 1 {
+		t.Errorf("Expected the deleted task to be counted after the cutoff only, got %d / %d", notExpired, expired)
+	}
+	if _, err := repos.GetMockGrowthRecordRepository().GetUnscoped(record.ID); purged != 1 || !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the growth record to be purged, got %d (%v)", purged, err)
+	}
+}
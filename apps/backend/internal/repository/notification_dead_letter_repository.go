@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// NotificationDeadLetterRepository Implementation - 通知デッドレターリポジトリ
+// =============================================================================
+
+// notificationDeadLetterRepository implements NotificationDeadLetterRepository
+type notificationDeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// Create はデッドレターを保存します。
+func (r *notificationDeadLetterRepository) Create(ctx context.Context, letter *model.NotificationDeadLetter) error {
+	return GetDB(ctx, r.db).Create(letter).Error
+}
+
+// GetByID はIDでデッドレターを取得します。
+func (r *notificationDeadLetterRepository) GetByID(ctx context.Context, id uint) (*model.NotificationDeadLetter, error) {
+	var letter model.NotificationDeadLetter
+	if err := GetDB(ctx, r.db).First(&letter, id).Error; err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+// List はデッドレターを新しい順に取得します（status が空の場合は全ステータス）。
+func (r *notificationDeadLetterRepository) List(ctx context.Context, status string, limit int) ([]model.NotificationDeadLetter, error) {
+	var letters []model.NotificationDeadLetter
+	query := GetDB(ctx, r.db).Order("created_at DESC, id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&letters).Error; err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// Update はデッドレターを更新します。
+func (r *notificationDeadLetterRepository) Update(ctx context.Context, letter *model.NotificationDeadLetter) error {
+	return GetDB(ctx, r.db).Save(letter).Error
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// NotificationOutboxRepository Implementation - 通知アウトボックスリポジトリ
+// =============================================================================
+
+// notificationOutboxRepository implements NotificationOutboxRepository
+type notificationOutboxRepository struct {
+	db *gorm.DB
+}
+
+// Enqueue は通知イベントを送信待ちとして保存します。
+// 同じ重複防止キーのイベントが既にある場合（同じ日のスケジューラーの再実行など）は保存しません。
+func (r *notificationOutboxRepository) Enqueue(ctx context.Context, entries []model.NotificationOutbox) (int64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	result := GetDB(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "deduplication_key"}}, DoNothing: true}).
+		Create(&entries)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// GetPending は送信を試行できる送信待ちのイベントを古い順に取得します。
+func (r *notificationOutboxRepository) GetPending(ctx context.Context, now time.Time, limit int) ([]model.NotificationOutbox, error) {
+	var entries []model.NotificationOutbox
+	if err := GetDB(ctx, r.db).
+		Where("status = ? AND available_at <= ?", "pending", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// MarkDispatched はイベントを処理済みにします。
+func (r *notificationOutboxRepository) MarkDispatched(ctx context.Context, id uint, dispatchedAt time.Time) error {
+	return GetDB(ctx, r.db).Model(&model.NotificationOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        "dispatched",
+			"dispatched_at": dispatchedAt,
+		}).Error
+}
+
+// MarkAttemptFailed は送信の試行の失敗を記録します。
+func (r *notificationOutboxRepository) MarkAttemptFailed(ctx context.Context, id uint, status string, attempts int, lastError string, availableAt time.Time) error {
+	return GetDB(ctx, r.db).Model(&model.NotificationOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"attempts":     attempts,
+			"last_error":   lastError,
+			"available_at": availableAt,
+		}).Error
+}
+
+// DeleteDispatchedBefore は指定日時より前に処理済みになったイベントを削除します。
+func (r *notificationOutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).
+		Where("status = ? AND dispatched_at < ?", "dispatched", before).
+		Delete(&model.NotificationOutbox{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// NotificationPreferenceRepository Implementation - 通知設定マトリクスリポジトリ
+// =============================================================================
+
+// notificationPreferenceRepository implements NotificationPreferenceRepository
+type notificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// GetByUserID はユーザーの通知設定を取得します。
+func (r *notificationPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]model.NotificationPreference, error) {
+	var prefs []model.NotificationPreference
+	if err := GetDB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("event_type, channel").
+		Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// Upsert は通知設定を作成または更新します。
+// 主キー（user_id, event_type, channel）が重複する行は enabled を上書きします。
+func (r *notificationPreferenceRepository) Upsert(ctx context.Context, prefs []model.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&prefs).Error
+}
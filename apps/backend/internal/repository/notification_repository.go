@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 	"gorm.io/gorm"
 )
 
@@ -87,10 +88,30 @@ func (r *deviceTokenRepository) DeleteByUserID(ctx context.Context, userID uint)
 	return GetDB(ctx, r.db).Where("user_id = ?", userID).Delete(&model.DeviceToken{}).Error
 }
 
-// DeactivateToken はトークンを無効化します。
+// DeactivateToken はトークンを無効化し、理由を記録します。
 // 無効なトークンが検出された場合（SNSからのエラー等）に使用します。
-func (r *deviceTokenRepository) DeactivateToken(ctx context.Context, id uint) error {
-	return GetDB(ctx, r.db).Model(&model.DeviceToken{}).Where("id = ?", id).Update("is_active", false).Error
+func (r *deviceTokenRepository) DeactivateToken(ctx context.Context, id uint, reason string) error {
+	return GetDB(ctx, r.db).Model(&model.DeviceToken{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_active":           false,
+		"deactivated_at":      time.Now(),
+		"deactivation_reason": reason,
+	}).Error
+}
+
+// UpdateEndpointARN はトークンのSNSエンドポイントARNのキャッシュを更新します。
+// 送信処理から呼ばれるため、updated_at は変更しません。
+func (r *deviceTokenRepository) UpdateEndpointARN(ctx context.Context, id uint, endpointARN string) error {
+	return GetDB(ctx, r.db).Model(&model.DeviceToken{}).Where("id = ?", id).
+		UpdateColumn("endpoint_arn", endpointARN).Error
+}
+
+// DeleteInactiveBefore は指定日時より前に無効化されたトークンを削除します。
+// 無効化日時が未記録のトークンは更新日時で判定します。
+func (r *deviceTokenRepository) DeleteInactiveBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).
+		Where("is_active = ? AND COALESCE(deactivated_at, updated_at) < ?", false, before).
+		Delete(&model.DeviceToken{})
+	return result.RowsAffected, result.Error
 }
 
 // =============================================================================
@@ -143,12 +164,79 @@ func (r *notificationLogRepository) GetByUserID(ctx context.Context, userID uint
 	return logs, nil
 }
 
+// transactionalNotificationTypePattern はトランザクションメール（transactional_*）の通知ログに一致するLIKEパターンです。
+// トランザクションメールは受信箱に表示しないため、受信箱・未読件数から除外します。
+const transactionalNotificationTypePattern = `transactional\_%`
+
+// GetInboxByUserID はアプリ内受信箱用にユーザーの通知ログを取得します。
+// 最新順にソートし、limit/offsetでページングします（トランザクションメールは除外）。
+// 総件数（ページング前、unreadOnly適用後）も合わせて返します。
+func (r *notificationLogRepository) GetInboxByUserID(ctx context.Context, userID uint, limit, offset int, unreadOnly bool) ([]model.NotificationLog, int64, error) {
+	query := GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("user_id = ? AND notification_type NOT LIKE ?", userID, transactionalNotificationTypePattern)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []model.NotificationLog
+	query = query.Order("created_at DESC").Offset(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// ListInboxByUserIDPaginated はアプリ内受信箱用にユーザーの通知ログを1ページ分取得します（新しい順）。
+// オフセットによるページングと異なり、ページを読む間に通知が届いても重複・抜けが起きません。
+func (r *notificationLogRepository) ListInboxByUserIDPaginated(ctx context.Context, userID uint, unreadOnly bool, params pagination.Params) ([]model.NotificationLog, error) {
+	query := GetDB(ctx, r.db).
+		Where("user_id = ? AND notification_type NOT LIKE ?", userID, transactionalNotificationTypePattern)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	var logs []model.NotificationLog
+	if err := paginateByID(query, params).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// CountUnreadByUserID はユーザーの未読通知数を取得します。
+// アプリのバッジ表示に使用します。
+func (r *notificationLogRepository) CountUnreadByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("user_id = ? AND read_at IS NULL AND notification_type NOT LIKE ?", userID, transactionalNotificationTypePattern).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkAsRead は通知ログを既読にします。
+// リトライ処理と競合しないよう read_at のみを更新し、既読済みの場合は何もしません。
+func (r *notificationLogRepository) MarkAsRead(ctx context.Context, id uint, readAt time.Time) error {
+	return GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("id = ? AND read_at IS NULL", id).
+		UpdateColumn("read_at", readAt).Error
+}
+
 // GetPendingNotifications は送信待ちの通知を取得します。
-// リトライ処理で使用します。
+// リトライ処理で使用します。次回リトライ予定日時を過ぎたもののみ対象です。
+// おやすみモードで保留した通知（deferred）も終了日時を過ぎたら対象になります。
 func (r *notificationLogRepository) GetPendingNotifications(ctx context.Context, limit int) ([]model.NotificationLog, error) {
 	var logs []model.NotificationLog
 	query := GetDB(ctx, r.db).
-		Where("status = ? AND retry_count < ?", "pending", 3).
+		Where("status IN ? AND retry_count < ?", []string{"pending", "deferred"}, 3).
+		Where("next_retry_at IS NULL OR next_retry_at <= ?", time.Now()).
 		Order("created_at ASC")
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -164,8 +252,84 @@ func (r *notificationLogRepository) Update(ctx context.Context, log *model.Notif
 	return GetDB(ctx, r.db).Save(log).Error
 }
 
+// CountPushesSince はユーザーへ指定日時以降に送信したプッシュ通知の件数を取得します。
+// 送信済み（sent）で送信チャネルに push を含む通知ログを数えます。
+func (r *notificationLogRepository) CountPushesSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("user_id = ? AND status = ? AND sent_at >= ?", userID, "sent", since).
+		Where("channel LIKE ?", "%push%").
+		Count(&count).Error
+	return count, err
+}
+
+// GetPushRateLimited は送信数の上限によりプッシュ通知を送らなかった通知ログを取得します。
+// まとめ通知の送信処理で使用します。
+func (r *notificationLogRepository) GetPushRateLimited(ctx context.Context, limit int) ([]model.NotificationLog, error) {
+	var logs []model.NotificationLog
+	query := GetDB(ctx, r.db).
+		Where("push_rate_limited = ?", true).
+		Order("created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// ClearPushRateLimited は通知ログのまとめ通知の送信待ちを解除します。
+func (r *notificationLogRepository) ClearPushRateLimited(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("id IN ?", ids).
+		UpdateColumn("push_rate_limited", false).Error
+}
+
 // DeleteExpired は期限切れの通知ログを削除します。
 // 定期的なクリーンアップジョブで使用します。
 func (r *notificationLogRepository) DeleteExpired(ctx context.Context) error {
 	return GetDB(ctx, r.db).Where("expires_at < ?", time.Now()).Delete(&model.NotificationLog{}).Error
 }
+
+// CountExpiredOrCreatedBefore は期限切れ、または createdBefore より前に作成された通知ログの件数を取得します。
+func (r *notificationLogRepository) CountExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Model(&model.NotificationLog{}).
+		Where("expires_at < ? OR created_at < ?", now, createdBefore).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteExpiredOrCreatedBefore は期限切れ、または createdBefore より前に作成された通知ログを削除します。
+// データの保持期間の定期削除で使用します。
+func (r *notificationLogRepository) DeleteExpiredOrCreatedBefore(ctx context.Context, now, createdBefore time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).Where("expires_at < ? OR created_at < ?", now, createdBefore).Delete(&model.NotificationLog{})
+	return result.RowsAffected, result.Error
+}
+
+// CountChannelOutcomesSince は指定日時以降に作成された通知ログを、チャネル×送信結果ごとに数えます。
+// 送信結果は channel_status（JSONB）から取り出し、重複防止キーで送らなかった件数は
+// duplicate_count を元の通知ログのチャネルごとに deduped として合計します。
+func (r *notificationLogRepository) CountChannelOutcomesSince(ctx context.Context, since time.Time) ([]NotificationChannelOutcomeCount, error) {
+	query := `
+		SELECT cs.key AS channel, cs.value AS outcome, COUNT(*) AS count
+		FROM notification_logs, jsonb_each_text(notification_logs.channel_status) AS cs
+		WHERE notification_logs.created_at >= ? AND jsonb_typeof(notification_logs.channel_status) = 'object'
+		GROUP BY cs.key, cs.value
+		UNION ALL
+		SELECT cs.key AS channel, 'deduped' AS outcome, SUM(notification_logs.duplicate_count) AS count
+		FROM notification_logs, jsonb_object_keys(notification_logs.channel_status) AS cs(key)
+		WHERE notification_logs.created_at >= ? AND jsonb_typeof(notification_logs.channel_status) = 'object'
+			AND notification_logs.duplicate_count > 0
+		GROUP BY cs.key`
+
+	var counts []NotificationChannelOutcomeCount
+	if err := GetDB(ctx, r.db).Raw(query, since, since).Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
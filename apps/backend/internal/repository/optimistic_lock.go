@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Optimistic Lock - 楽観的ロック
+// =============================================================================
+// タスク・作物・区画は version の列を持ち、Update は読み込んだ時点のバージョンの場合のみ更新します。
+// 読み込んでから更新するまでに他のリクエスト（同じ菜園の別のメンバーなど）が更新した場合は
+// ErrVersionConflict を返し、後の更新が先の更新を黙って上書きしないようにします。
+
+// ErrVersionConflict は記録のバージョンが読み込んだ時点から変わっていたため更新しなかったエラーです。
+var ErrVersionConflict = errors.New("record version conflict")
+
+// updateVersioned は記録の version 列が *version の場合のみ全列を更新し、*version を1つ進めます。
+// 更新しなかった場合（バージョンの不一致・エラー）は *version を元に戻します。
+//
+// 引数:
+//   - db: GetDB で取得した接続
+//   - record: 更新する記録（主キーを設定したモデルのポインタ）
+//   - version: record の Version フィールドのポインタ
+//
+// 戻り値:
+//   - error: バージョンが一致しない場合は ErrVersionConflict、更新に失敗した場合はそのエラー
+func updateVersioned(db *gorm.DB, record any, version *uint) error {
+	expected := *version
+	*version = expected + 1
+	// Save と同じく全列を更新する（Save は更新した行がない場合に INSERT するため使用しない）
+	result := db.Model(record).Where("version = ?", expected).Select("*").Updates(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	return nil
+}
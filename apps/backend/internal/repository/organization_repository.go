@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// OrganizationRepository Implementation - 組織リポジトリ
+// =============================================================================
+
+// organizationRepository implements OrganizationRepository
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// Create は組織を作成します。
+func (r *organizationRepository) Create(ctx context.Context, organization *model.Organization) error {
+	return GetDB(ctx, r.db).Create(organization).Error
+}
+
+// GetByID はIDで組織を取得します。
+func (r *organizationRepository) GetByID(ctx context.Context, id uint) (*model.Organization, error) {
+	var organization model.Organization
+	if err := GetDB(ctx, r.db).First(&organization, id).Error; err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetByUserID はユーザーがメンバーの組織をID順に取得します。
+func (r *organizationRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Organization, error) {
+	var organizations []model.Organization
+	if err := GetDB(ctx, r.db).
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id AND organization_members.deleted_at IS NULL").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.id ASC").
+		Find(&organizations).Error; err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// Update は組織を更新します。
+func (r *organizationRepository) Update(ctx context.Context, organization *model.Organization) error {
+	return GetDB(ctx, r.db).Save(organization).Error
+}
+
+// =============================================================================
+// OrganizationMemberRepository Implementation - 組織のメンバーリポジトリ
+// =============================================================================
+
+// organizationMemberRepository implements OrganizationMemberRepository
+type organizationMemberRepository struct {
+	db *gorm.DB
+}
+
+// Create は組織のメンバーを追加します。
+func (r *organizationMemberRepository) Create(ctx context.Context, member *model.OrganizationMember) error {
+	return GetDB(ctx, r.db).Create(member).Error
+}
+
+// GetByOrganizationID は組織のメンバーをユーザー情報付きで追加順に取得します。
+func (r *organizationMemberRepository) GetByOrganizationID(ctx context.Context, organizationID uint) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	if err := GetDB(ctx, r.db).
+		Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC, id ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Get は組織のユーザーのメンバーを取得します。
+func (r *organizationMemberRepository) Get(ctx context.Context, organizationID, userID uint) (*model.OrganizationMember, error) {
+	var member model.OrganizationMember
+	if err := GetDB(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// CountByOrganizationID は組織のメンバー数を取得します。
+func (r *organizationMemberRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Model(&model.OrganizationMember{}).Where("organization_id = ?", organizationID).Count(&count).Error
+	return count, err
+}
+
+// Delete はメンバーを物理削除します。
+func (r *organizationMemberRepository) Delete(ctx context.Context, organizationID, userID uint) error {
+	return GetDB(ctx, r.db).
+		Unscoped().
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&model.OrganizationMember{}).Error
+}
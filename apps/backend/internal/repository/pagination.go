@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"gorm.io/gorm"
+)
+
+// paginateByID はIDの降順のカーソルページングの条件をクエリに追加します。
+// 次のページの有無を判定できるよう Limit+1 件を取得します（pagination.NewPage で Limit 件に切り詰めます）。
+func paginateByID(query *gorm.DB, params pagination.Params) *gorm.DB {
+	params = params.Normalize()
+	if params.BeforeID > 0 {
+		query = query.Where("id < ?", params.BeforeID)
+	}
+	return query.Order("id DESC").Limit(params.Limit + 1)
+}
+
+// paginateByKey は (column, id) の昇順のキーセットページングの条件をクエリに追加します。
+// OFFSET ではなく前のページの最後の行の (column, id) より後の行を取得するため、大きなテーブルでもページの位置に関係なく
+// (…, column, id) のインデックスの範囲スキャンで取得できます。column は呼び出し側の固定の列名です（ユーザーの入力を渡さない）。
+func paginateByKey(query *gorm.DB, column string, params pagination.KeyParams) *gorm.DB {
+	params = params.Normalize()
+	if params.AfterID > 0 {
+		query = query.Where(fmt.Sprintf("(%s, id) > (?, ?)", column), params.AfterKey, params.AfterID)
+	}
+	return query.Order(fmt.Sprintf("%s ASC, id ASC", column)).Limit(params.Limit + 1)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// PhoneVerificationRepository Implementation - 電話番号認証リポジトリ
+// =============================================================================
+
+// phoneVerificationRepository implements PhoneVerificationRepository
+type phoneVerificationRepository struct {
+	db *gorm.DB
+}
+
+// GetByUserID はユーザーの認証中の電話番号を取得します。
+func (r *phoneVerificationRepository) GetByUserID(ctx context.Context, userID uint) (*model.PhoneVerification, error) {
+	var verification model.PhoneVerification
+	if err := GetDB(ctx, r.db).Where("user_id = ?", userID).First(&verification).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// Save は認証コードを作成または更新します。
+// 主キーが user_id のため、同じユーザーの認証コードは上書きされます。
+func (r *phoneVerificationRepository) Save(ctx context.Context, verification *model.PhoneVerification) error {
+	return GetDB(ctx, r.db).Save(verification).Error
+}
+
+// DeleteByUserID はユーザーの認証コードを削除します。
+func (r *phoneVerificationRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return GetDB(ctx, r.db).Where("user_id = ?", userID).Delete(&model.PhoneVerification{}).Error
+}
+
+// RecordSend は認証コードのSMSの送信を記録します。
+// 送信数の上限の判定に使わなくなった before より前のユーザーの送信記録は同時に削除します。
+func (r *phoneVerificationRepository) RecordSend(ctx context.Context, send *model.PhoneVerificationSend, before time.Time) error {
+	return GetDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND created_at < ?", send.UserID, before).Delete(&model.PhoneVerificationSend{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(send).Error
+	})
+}
+
+// GetSendsSince は since 以降のユーザーの送信記録を新しい順に取得します。
+func (r *phoneVerificationRepository) GetSendsSince(ctx context.Context, userID uint, since time.Time) ([]model.PhoneVerificationSend, error) {
+	var sends []model.PhoneVerificationSend
+	err := GetDB(ctx, r.db).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at DESC, id DESC").
+		Find(&sends).Error
+	return sends, err
+}
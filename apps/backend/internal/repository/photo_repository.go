@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// PhotoRepository Implementation - 写真リポジトリ
+// =============================================================================
+
+// photoRepository implements PhotoRepository
+type photoRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しい写真を保存します。
+func (r *photoRepository) Create(ctx context.Context, photo *model.Photo) error {
+	return GetDB(ctx, r.db).Create(photo).Error
+}
+
+// GetByID はIDで写真を取得します。
+func (r *photoRepository) GetByID(ctx context.Context, id uint) (*model.Photo, error) {
+	var photo model.Photo
+	if err := GetDB(ctx, r.db).First(&photo, id).Error; err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// Update は写真を更新します（加工の結果の記録）。
+func (r *photoRepository) Update(ctx context.Context, photo *model.Photo) error {
+	return GetDB(ctx, r.db).Save(photo).Error
+}
+
+// Delete は写真を論理削除します。
+func (r *photoRepository) Delete(ctx context.Context, id uint) error {
+	return GetDB(ctx, r.db).Delete(&model.Photo{}, id).Error
+}
+
+// GetStorageTotalByUserID はユーザーの写真の件数と保存容量の合計を返します。
+func (r *photoRepository) GetStorageTotalByUserID(ctx context.Context, userID uint) (StorageTotal, error) {
+	var total StorageTotal
+	if err := GetDB(ctx, r.db).Model(&model.Photo{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(size_bytes), 0) AS bytes").
+		Where("user_id = ?", userID).
+		Scan(&total).Error; err != nil {
+		return StorageTotal{}, err
+	}
+	return total, nil
+}
+
+// GetOrphanedByUserID は作物・収穫記録が削除されたユーザーの写真を古い順に取得します。
+func (r *photoRepository) GetOrphanedByUserID(ctx context.Context, userID uint) ([]model.Photo, error) {
+	var photos []model.Photo
+	if err := r.joinParents(ctx).
+		Where("photos.user_id = ?", userID).
+		Where("((photos.crop_id IS NOT NULL AND (crops.id IS NULL OR crops.deleted_at IS NOT NULL)) OR " +
+			"(photos.harvest_id IS NOT NULL AND (harvests.id IS NULL OR harvests.deleted_at IS NOT NULL)))").
+		Order("photos.created_at ASC").
+		Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// GetCompletedByUserID はユーザーの加工済みの写真を古い順に取得します。
+func (r *photoRepository) GetCompletedByUserID(ctx context.Context, userID uint) ([]model.Photo, error) {
+	var photos []model.Photo
+	if err := GetDB(ctx, r.db).
+		Where("user_id = ? AND status = ?", userID, "completed").
+		Order("created_at ASC, id ASC").
+		Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// GetOrphanedBefore は before より前に作物・収穫記録が削除された写真を古い順に取得します。
+// 物理削除済みの作物・収穫記録は削除日時が分からないため、写真の作成日時で判定します。
+func (r *photoRepository) GetOrphanedBefore(ctx context.Context, before time.Time, limit int) ([]model.Photo, error) {
+	var photos []model.Photo
+	query := r.orphanedBefore(ctx, before).Order("photos.created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// CountOrphanedBefore は before より前に作物・収穫記録が削除された写真の件数を返します。
+func (r *photoRepository) CountOrphanedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	if err := r.orphanedBefore(ctx, before).Model(&model.Photo{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// orphanedBefore は before より前に作物・収穫記録が削除された写真の条件です（GetOrphanedBefore, CountOrphanedBefore）。
+func (r *photoRepository) orphanedBefore(ctx context.Context, before time.Time) *gorm.DB {
+	return r.joinParents(ctx).
+		Where("((photos.crop_id IS NOT NULL AND ((crops.id IS NULL AND photos.created_at < ?) OR crops.deleted_at < ?)) OR "+
+			"(photos.harvest_id IS NOT NULL AND ((harvests.id IS NULL AND photos.created_at < ?) OR harvests.deleted_at < ?)))",
+			before, before, before, before)
+}
+
+// joinParents は写真に作物・収穫記録を結合します。
+// 論理削除した作物・収穫記録も対象にするため、deleted_at の条件を付けずに結合します。
+func (r *photoRepository) joinParents(ctx context.Context) *gorm.DB {
+	return GetDB(ctx, r.db).
+		Joins("LEFT JOIN crops ON crops.id = photos.crop_id").
+		Joins("LEFT JOIN harvests ON harvests.id = photos.harvest_id")
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/secure-scorecard/backend/internal/filter"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 	"gorm.io/gorm"
 )
 
@@ -17,15 +20,16 @@ type plotRepository struct {
 	db *gorm.DB
 }
 
-// Create は新しい区画を作成します
+// Create は新しい区画を作成します（組織のスコープでは組織の区画）
 func (r *plotRepository) Create(ctx context.Context, plot *model.Plot) error {
+	assignOrganization(ctx, &plot.OrganizationID)
 	db := GetDB(ctx, r.db)
 	return db.Create(plot).Error
 }
 
-// GetByID は指定されたIDの区画を取得します
+// GetByID は指定されたIDの区画を取得します（組織のスコープ外の区画は見つからない）
 func (r *plotRepository) GetByID(ctx context.Context, id uint) (*model.Plot, error) {
-	db := GetDB(ctx, r.db)
+	db := scopeRecordByOrganization(ctx, GetDB(ctx, r.db))
 	var plot model.Plot
 	if err := db.First(&plot, id).Error; err != nil {
 		return nil, err
@@ -33,39 +37,118 @@ func (r *plotRepository) GetByID(ctx context.Context, id uint) (*model.Plot, err
 	return &plot, nil
 }
 
-// GetByUserID は指定されたユーザーの全区画を取得します
+// GetByIDs は指定されたIDの区画を1回のクエリで取得します（見つからないIDと組織のスコープ外の区画は含まない）
+func (r *plotRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Plot, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	db := scopeRecordByOrganization(ctx, GetDB(ctx, r.db))
+	var plots []model.Plot
+	if err := db.Where("id IN ?", ids).Find(&plots).Error; err != nil {
+		return nil, err
+	}
+	return plots, nil
+}
+
+// GetByUserID は指定されたユーザーの全区画を取得します（組織のスコープでは組織の全区画）
 func (r *plotRepository) GetByUserID(ctx context.Context, userID uint) ([]model.Plot, error) {
-	db := GetDB(ctx, r.db)
+	db := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID)
 	var plots []model.Plot
-	if err := db.Where("user_id = ?", userID).Find(&plots).Error; err != nil {
+	if err := db.Find(&plots).Error; err != nil {
 		return nil, err
 	}
 	return plots, nil
 }
 
+// GetLayoutByUserID は指定されたユーザーの区画を現在アクティブな配置と作物とともに取得します
+// 区画・配置・作物をそれぞれ1回のクエリで取得するため、区画の数によらずクエリ数は一定です。
+// PlotAssignments には配置解除されていない配置のうち最も古い1件（GetActiveByPlotID と同じ配置）だけを含みます。
+func (r *plotRepository) GetLayoutByUserID(ctx context.Context, userID uint) ([]model.Plot, error) {
+	db := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID)
+	var plots []model.Plot
+	err := db.
+		Preload("PlotAssignments", func(db *gorm.DB) *gorm.DB {
+			return db.Where("unassigned_date IS NULL").Order("id")
+		}).
+		Preload("PlotAssignments.Crop").
+		Find(&plots).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range plots {
+		if len(plots[i].PlotAssignments) > 1 {
+			plots[i].PlotAssignments = plots[i].PlotAssignments[:1]
+		}
+	}
+	return plots, nil
+}
+
 // GetByUserIDAndStatus は指定されたユーザーの特定ステータスの区画を取得します
 // ステータス: available（空き）, occupied（使用中）
 func (r *plotRepository) GetByUserIDAndStatus(ctx context.Context, userID uint, status string) ([]model.Plot, error) {
-	db := GetDB(ctx, r.db)
+	db := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID)
 	var plots []model.Plot
-	if err := db.Where("user_id = ? AND status = ?", userID, status).Find(&plots).Error; err != nil {
+	if err := db.Where("status = ?", status).Find(&plots).Error; err != nil {
 		return nil, err
 	}
 	return plots, nil
 }
 
-// Update は区画情報を更新します
+// ListByUserIDPaginated は指定されたユーザーの区画を1ページ分取得します（新しい順、spec の条件で絞り込み）
+func (r *plotRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Plot, error) {
+	query := scopeListByOrganization(ctx, GetDB(ctx, r.db), userID)
+	query = applyFilter(query, spec)
+	var plots []model.Plot
+	if err := paginateByID(query, params).Find(&plots).Error; err != nil {
+		return nil, err
+	}
+	return plots, nil
+}
+
+// Update は区画情報を更新します（バージョンが読み込んだ時点から変わっている場合は ErrVersionConflict）
 func (r *plotRepository) Update(ctx context.Context, plot *model.Plot) error {
-	db := GetDB(ctx, r.db)
-	return db.Save(plot).Error
+	return updateVersioned(GetDB(ctx, r.db), plot, &plot.Version)
+}
+
+// CountByOrganizationID は組織の区画数を取得します（組織の上限の確認用）
+func (r *plotRepository) CountByOrganizationID(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Model(&model.Plot{}).Where("organization_id = ?", organizationID).Count(&count).Error
+	return count, err
 }
 
-// Delete は区画を削除します（ソフトデリート）
+// Delete は区画を削除します（ソフトデリート、組織のスコープ外の区画は削除しない）
 func (r *plotRepository) Delete(ctx context.Context, id uint) error {
-	db := GetDB(ctx, r.db)
+	db := scopeRecordByOrganization(ctx, GetDB(ctx, r.db))
 	return db.Delete(&model.Plot{}, id).Error
 }
 
+// GetDeletedByUserID はユーザーの deletedAfter より後に論理削除された区画を取得します（組織のスコープでは組織の区画）
+func (r *plotRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Plot, error) {
+	db := scopeListByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at"), userID)
+	var plots []model.Plot
+	if err := db.Where("deleted_at > ?", deletedAfter).Order("deleted_at DESC").Find(&plots).Error; err != nil {
+		return nil, err
+	}
+	return plots, nil
+}
+
+// GetDeletedByID は論理削除された区画を取得します（組織のスコープ外の区画は見つからない）
+func (r *plotRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Plot, error) {
+	db := scopeRecordByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at"))
+	var plot model.Plot
+	if err := db.First(&plot, id).Error; err != nil {
+		return nil, err
+	}
+	return &plot, nil
+}
+
+// Restore は論理削除された区画を復元します（組織のスコープ外の区画は復元しない）
+func (r *plotRepository) Restore(ctx context.Context, id uint) error {
+	db := scopeRecordByOrganization(ctx, unscopedDeleted(GetDB(ctx, r.db), "deleted_at"))
+	return db.Model(&model.Plot{}).Where("id = ?", id).Updates(restoreColumns()).Error
+}
+
 // =============================================================================
 // PlotAssignmentRepository - 区画配置リポジトリ実装
 // =============================================================================
@@ -92,6 +175,19 @@ func (r *plotAssignmentRepository) GetByID(ctx context.Context, id uint) (*model
 	return &assignment, nil
 }
 
+// GetByIDs は指定されたIDの区画配置を1回のクエリで取得します（見つからないIDは含まない）
+func (r *plotAssignmentRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.PlotAssignment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	db := GetDB(ctx, r.db)
+	var assignments []model.PlotAssignment
+	if err := db.Where("id IN ?", ids).Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
 // GetByPlotID は指定された区画の全配置履歴を取得します
 func (r *plotAssignmentRepository) GetByPlotID(ctx context.Context, plotID uint) ([]model.PlotAssignment, error) {
 	db := GetDB(ctx, r.db)
@@ -102,6 +198,19 @@ func (r *plotAssignmentRepository) GetByPlotID(ctx context.Context, plotID uint)
 	return assignments, nil
 }
 
+// GetByPlotIDs は複数の区画の全配置履歴を配置日の昇順で取得します
+func (r *plotAssignmentRepository) GetByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error) {
+	if len(plotIDs) == 0 {
+		return nil, nil
+	}
+	db := GetDB(ctx, r.db)
+	var assignments []model.PlotAssignment
+	if err := db.Where("plot_id IN ?", plotIDs).Order("assigned_date, id").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
 // GetActiveByPlotID は指定された区画の現在アクティブな配置を取得します
 // アクティブ = UnassignedDate が NULL
 func (r *plotAssignmentRepository) GetActiveByPlotID(ctx context.Context, plotID uint) (*model.PlotAssignment, error) {
@@ -113,6 +222,27 @@ func (r *plotAssignmentRepository) GetActiveByPlotID(ctx context.Context, plotID
 	return &assignment, nil
 }
 
+// GetActiveByPlotIDs は指定された区画の現在アクティブな配置を1回のクエリで取得します
+// 区画ごとに GetActiveByPlotID と同じ配置（IDの最も小さいアクティブな配置）を返します
+func (r *plotAssignmentRepository) GetActiveByPlotIDs(ctx context.Context, plotIDs []uint) ([]model.PlotAssignment, error) {
+	if len(plotIDs) == 0 {
+		return nil, nil
+	}
+	db := GetDB(ctx, r.db)
+	var assignments []model.PlotAssignment
+	if err := db.Where("plot_id IN ? AND unassigned_date IS NULL", plotIDs).Order("plot_id, id").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	// 区画ごとに最初の配置のみ残す（通常アクティブな配置は区画に1件）
+	var active []model.PlotAssignment
+	for _, assignment := range assignments {
+		if len(active) == 0 || active[len(active)-1].PlotID != assignment.PlotID {
+			active = append(active, assignment)
+		}
+	}
+	return active, nil
+}
+
 // GetByCropID は指定された作物の全配置履歴を取得します
 func (r *plotAssignmentRepository) GetByCropID(ctx context.Context, cropID uint) ([]model.PlotAssignment, error) {
 	db := GetDB(ctx, r.db)
@@ -141,3 +271,12 @@ func (r *plotAssignmentRepository) DeleteByPlotID(ctx context.Context, plotID ui
 	db := GetDB(ctx, r.db)
 	return db.Where("plot_id = ?", plotID).Delete(&model.PlotAssignment{}).Error
 }
+
+// RestoreByPlotID は指定された区画の deletedFrom 以降に論理削除された配置を復元します
+// 区画と一緒に削除した配置のみを復元するため、区画の削除日時の直前を指定します
+func (r *plotAssignmentRepository) RestoreByPlotID(ctx context.Context, plotID uint, deletedFrom time.Time) error {
+	db := unscopedDeleted(GetDB(ctx, r.db), "deleted_at")
+	return db.Model(&model.PlotAssignment{}).
+		Where("plot_id = ? AND deleted_at >= ?", plotID, deletedFrom).
+		Updates(restoreColumns()).Error
+}
@@ -0,0 +1,1345 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/filter"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// PostgreSQL Integration Tests - PostgreSQL でのリポジトリの統合テスト
+// =============================================================================
+// モックではなく実際のリポジトリを testcontainers で起動した PostgreSQL（docker-compose.yml と同じイメージ）に対して実行します。
+// integration のビルドタグを指定した場合のみ実行します（Docker を使用できない場合はスキップ）:
+//
+//	go test -tags integration ./internal/repository/...
+//
+// TEST_DATABASE_URL を指定した場合はコンテナを起動せず、そのデータベースに接続します
+// （テストごとにすべてのテーブルを空にするため、テスト専用のデータベースを指定してください）。
+//
+// テスト対象:
+//   - database.Setup / MigrateDown: AutoMigrate とバージョン管理のマイグレーションの適用・ロールバック
+//   - UserRepository, TokenBlacklistRepository: 取得・更新・論理削除、期限切れのトークン
+//   - GardenRepository, PlantRepository, CareLogRepository: 菜園・植物・手入れの記録（レガシー）
+//   - TaskRepository: 絞り込み・ページング・期限（今日・期限切れ・期限前）・楽観的ロック・論理削除と復元
+//   - CropRepository: 絞り込み・ページング・収穫予定日の範囲・収穫可能の通知・アーカイブ・論理削除と復元
+//   - GrowthRecordRepository, HarvestRepository: 作物ごとの取得・日付の範囲・作物の削除との一括の論理削除と復元・ベンチマーク
+//   - PlotRepository, PlotAssignmentRepository: レイアウト・アクティブな配置・論理削除と復元
+//   - CreateBatch: タスク・作物・成長記録・収穫記録の一括作成
+//   - CHECK 制約、AnalyticsViewRepository（マテリアライズドビューのリフレッシュ）、SearchRepository（全文検索）、
+//     NotificationLogRepository.CountChannelOutcomesSince（JSONB の集計）、WithTransaction
+
+// postgresImage は PostgreSQL のイメージです（docker-compose.yml と同じ）。
+const postgresImage = "postgres:16-alpine"
+
+var (
+	postgresOnce      sync.Once
+	postgresContainer *tcpostgres.PostgresContainer
+	postgresDSN       string
+	postgresErr       error
+)
+
+// TestMain はすべてのテストの終了後に PostgreSQL のコンテナを停止します。
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if postgresContainer != nil {
+		if err := testcontainers.TerminateContainer(postgresContainer); err != nil {
+			log.Printf("Failed to terminate postgres container: %v", err)
+		}
+	}
+	os.Exit(code)
+}
+
+// postgresTestDSN は TEST_DATABASE_URL、または最初の呼び出しで起動したコンテナの接続文字列を返します。
+// Docker を使用できない場合はテストをスキップします。
+func postgresTestDSN(t *testing.T) string {
+	t.Helper()
+	if dsn := os.Getenv("TEST_DATABASE_URL"); dsn != "" {
+		return dsn
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	postgresOnce.Do(func() {
+		ctx := context.Background()
+		postgresContainer, postgresErr = tcpostgres.Run(ctx, postgresImage,
+			tcpostgres.WithDatabase("home_garden_test"),
+			tcpostgres.WithUsername("postgres"),
+			tcpostgres.WithPassword("postgres"),
+			tcpostgres.BasicWaitStrategies(),
+		)
+		if postgresErr != nil {
+			return
+		}
+		postgresDSN, postgresErr = postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	})
+	if postgresErr != nil {
+		t.Fatalf("Failed to start postgres container: %v", postgresErr)
+	}
+	return postgresDSN
+}
+
+// connectPostgres はテスト用の PostgreSQL に接続し、マイグレーションを適用します。
+func connectPostgres(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Connect(&config.Config{
+		Database: config.DatabaseConfig{Driver: config.DatabaseDriverPostgres, URL: postgresTestDSN(t)},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Setup(); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	return db
+}
+
+// newPostgresRepositories はすべてのテーブル・マテリアライズドビューを空にした PostgreSQL のリポジトリを作成します（ID は 1 から）。
+func newPostgresRepositories(t *testing.T) repository.Repositories {
+	t.Helper()
+	db := connectPostgres(t)
+	var tables []string
+	if err := db.DB.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'").
+		Scan(&tables).Error; err != nil {
+		t.Fatalf("Failed to list tables: %v", err)
+	}
+	if err := db.DB.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))).Error; err != nil {
+		t.Fatalf("Failed to truncate tables: %v", err)
+	}
+	// マテリアライズドビューは TRUNCATE で空にならないため、空のテーブルでリフレッシュする
+	var views []string
+	if err := db.DB.Raw("SELECT matviewname FROM pg_matviews WHERE schemaname = current_schema()").Scan(&views).Error; err != nil {
+		t.Fatalf("Failed to list materialized views: %v", err)
+	}
+	for _, view := range views {
+		if err := db.DB.Exec("REFRESH MATERIALIZED VIEW " + view).Error; err != nil {
+			t.Fatalf("Failed to refresh %s: %v", view, err)
+		}
+	}
+	return repository.NewRepositoryManager(db.DB)
+}
+
+// createPostgresUser はテスト用のユーザーを作成します（Firebase UID は一意のインデックスのためメールアドレスから作成）。
+func createPostgresUser(t *testing.T, repos repository.Repositories, email string) *model.User {
+	t.Helper()
+	user := &model.User{Email: email, DisplayName: email, FirebaseUID: "uid-" + email}
+	if err := repos.User().Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user
+}
+
+// createPostgresCrop はテスト用の作物を作成します（収穫予定日は植え付け日の3か月後）。
+func createPostgresCrop(t *testing.T, repos repository.Repositories, crop *model.Crop) *model.Crop {
+	t.Helper()
+	if crop.ExpectedHarvestDate.IsZero() {
+		crop.ExpectedHarvestDate = crop.PlantedDate.AddDate(0, 3, 0)
+	}
+	if err := repos.Crop().Create(context.Background(), crop); err != nil {
+		t.Fatalf("Failed to create crop: %v", err)
+	}
+	return crop
+}
+
+// ids は記録のIDの一覧を返します（順序の確認用）。
+func ids[T any](records []T, id func(T) uint) []uint {
+	result := make([]uint, 0, len(records))
+	for _, record := range records {
+		result = append(result, id(record))
+	}
+	return result
+}
+
+// sameIDs は2つのIDの一覧が同じ順序で一致するかを返します。
+func sameIDs(got, want []uint) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func taskID(task model.Task) uint                       { return task.ID }
+func cropID(crop model.Crop) uint                       { return crop.ID }
+func plotID(plot model.Plot) uint                       { return plot.ID }
+func harvestID(harvest model.Harvest) uint              { return harvest.ID }
+func growthRecordID(record model.GrowthRecord) uint     { return record.ID }
+func assignmentID(assignment model.PlotAssignment) uint { return assignment.ID }
+
+// TestPostgres_Migrations は PostgreSQL のマイグレーションのテストです。
+// 期待動作:
+//   - すべてのバージョンを適用し、dirty でない
+//   - すべてのバージョンをロールバックして再び適用できる（down のファイルの確認）
+func TestPostgres_Migrations(t *testing.T) {
+	// Arrange
+	db := connectPostgres(t)
+
+	// Act
+	status, statusErr := db.MigrationVersion()
+	downErr := db.MigrateDown(int(status.Version))
+	down, downStatusErr := db.MigrationVersion()
+	upErr := db.Migrate()
+	up, upStatusErr := db.MigrationVersion()
+
+	// Assert
+	if statusErr != nil || status.Version != 6 || status.Dirty {
+		t.Fatalf("Expected version 6 (clean), got %+v (%v)", status, statusErr)
+	}
+	if downErr != nil || downStatusErr != nil || down.Version != 0 {
+		t.Errorf("Expected all versions to roll back, got %+v (%v / %v)", down, downErr, downStatusErr)
+	}
+	if upErr != nil || upStatusErr != nil || up.Version != 6 || up.Dirty {
+		t.Errorf("Expected version 6 after re-applying, got %+v (%v / %v)", up, upErr, upStatusErr)
+	}
+}
+
+// TestPostgres_UserRepository はユーザーのリポジトリのテストです。
+// 期待動作:
+//   - ID・メールアドレス・Firebase UID で取得でき、通知設定は JSONB のデフォルト値
+//   - GetIDsAfter は指定したIDより後のIDを昇順に limit 件まで返す
+//   - 更新した値を取得でき、論理削除したユーザーは取得できない
+//   - 論理削除したユーザーと同じメールアドレスでは作成でき、論理削除していないユーザーと同じメールアドレスでは作成できない
+func TestPostgres_UserRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	first := createPostgresUser(t, repos, "first@example.com")
+	second := createPostgresUser(t, repos, "second@example.com")
+	third := createPostgresUser(t, repos, "third@example.com")
+
+	// Act
+	byID, byIDErr := repos.User().GetByID(ctx, first.ID)
+	byEmail, byEmailErr := repos.User().GetByEmail(ctx, "second@example.com")
+	byUID, byUIDErr := repos.User().GetByFirebaseUID(ctx, "uid-third@example.com")
+	after, afterErr := repos.User().GetIDsAfter(ctx, first.ID, 1)
+	byID.DisplayName = "更新後"
+	byID.BenchmarkOptIn = true
+	updateErr := repos.User().Update(ctx, byID)
+	updated, _ := repos.User().GetByID(ctx, first.ID)
+	deleteErr := repos.User().Delete(ctx, third.ID)
+	_, deletedErr := repos.User().GetByID(ctx, third.ID)
+	reuseErr := repos.User().Create(ctx, &model.User{Email: "third@example.com", FirebaseUID: "uid-reused"})
+	duplicateErr := repos.User().Create(ctx, &model.User{Email: "first@example.com", FirebaseUID: "uid-duplicate"})
+
+	// Assert
+	if byIDErr != nil || byID.Email != "first@example.com" {
+		t.Fatalf("Expected the user by ID, got %v", byIDErr)
+	}
+	if byID.NotificationSettings == nil || !byID.NotificationSettings.PushEnabled {
+		t.Errorf("Expected the default notification settings, got %+v", byID.NotificationSettings)
+	}
+	if byEmailErr != nil || byEmail.ID != second.ID {
+		t.Errorf("Expected the user by email, got %v", byEmailErr)
+	}
+	if byUIDErr != nil || byUID.ID != third.ID {
+		t.Errorf("Expected the user by Firebase UID, got %v", byUIDErr)
+	}
+	if afterErr != nil || !sameIDs(after, []uint{second.ID}) {
+		t.Errorf("Expected [%d] after %d, got %v (%v)", second.ID, first.ID, after, afterErr)
+	}
+	if updateErr != nil || updated.DisplayName != "更新後" || !updated.BenchmarkOptIn {
+		t.Errorf("Expected the updated user, got %+v (%v)", updated, updateErr)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted user not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+	if reuseErr != nil || duplicateErr == nil || !strings.Contains(duplicateErr.Error(), "idx_users_email_active") {
+		t.Errorf("Expected the email of the deleted user to be reusable and an active email to be unique, got %v / %v", reuseErr, duplicateErr)
+	}
+}
+
+// TestPostgres_TokenBlacklistRepository はトークンのブラックリストのテストです。
+// 期待動作:
+//   - 有効期限内のトークンのみブラックリストに含まれる
+//   - DeleteExpired は期限切れのトークンのみ削除する
+func TestPostgres_TokenBlacklistRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	now := time.Now()
+	if err := repos.TokenBlacklist().Add(ctx, "active-hash", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := repos.TokenBlacklist().Add(ctx, "expired-hash", now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// Act
+	active, activeErr := repos.TokenBlacklist().IsBlacklisted(ctx, "active-hash")
+	expired, expiredErr := repos.TokenBlacklist().IsBlacklisted(ctx, "expired-hash")
+	unknown, unknownErr := repos.TokenBlacklist().IsBlacklisted(ctx, "unknown-hash")
+	deleteErr := repos.TokenBlacklist().DeleteExpired(ctx)
+	stillActive, _ := repos.TokenBlacklist().IsBlacklisted(ctx, "active-hash")
+	// 期限切れのトークンを削除したため、同じハッシュを再び追加できる（一意のインデックス）
+	readdErr := repos.TokenBlacklist().Add(ctx, "expired-hash", now.Add(time.Hour))
+
+	// Assert
+	if activeErr != nil || !active {
+		t.Errorf("Expected the active token to be blacklisted, got %v (%v)", active, activeErr)
+	}
+	if expiredErr != nil || expired {
+		t.Errorf("Expected the expired token not to be blacklisted, got %v (%v)", expired, expiredErr)
+	}
+	if unknownErr != nil || unknown {
+		t.Errorf("Expected an unknown token not to be blacklisted, got %v (%v)", unknown, unknownErr)
+	}
+	if deleteErr != nil || !stillActive {
+		t.Errorf("Expected DeleteExpired to keep the active token, got %v (%v)", stillActive, deleteErr)
+	}
+	if readdErr != nil {
+		t.Errorf("Expected the expired token to be deleted, got %v", readdErr)
+	}
+}
+
+// TestPostgres_GardenRepositories は菜園・植物・手入れの記録のリポジトリのテストです。
+// 期待動作:
+//   - 菜園ごとの植物、植物ごとの手入れの記録を取得できる（植物は菜園を含む）
+//   - 更新した値を取得でき、DeleteByGardenID は菜園の植物をすべて論理削除する
+func TestPostgres_GardenRepositories(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "garden@example.com")
+	garden := &model.Garden{UserID: user.ID, Name: "裏庭", SizeM2: 12}
+	if err := repos.Garden().Create(ctx, garden); err != nil {
+		t.Fatalf("Failed to create garden: %v", err)
+	}
+	basil := &model.Plant{GardenID: garden.ID, Name: "バジル", PlantedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	mint := &model.Plant{GardenID: garden.ID, Name: "ミント", PlantedAt: time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)}
+	for _, plant := range []*model.Plant{basil, mint} {
+		if err := repos.Plant().Create(ctx, plant); err != nil {
+			t.Fatalf("Failed to create plant: %v", err)
+		}
+	}
+	careLog := &model.CareLog{PlantID: basil.ID, Type: "watering", CaredAt: time.Date(2026, 5, 3, 7, 0, 0, 0, time.UTC)}
+	if err := repos.CareLog().Create(ctx, careLog); err != nil {
+		t.Fatalf("Failed to create care log: %v", err)
+	}
+
+	// Act
+	gardens, gardensErr := repos.Garden().GetByUserID(ctx, user.ID)
+	garden.Name = "表庭"
+	gardenUpdateErr := repos.Garden().Update(ctx, garden)
+	updatedGarden, _ := repos.Garden().GetByID(ctx, garden.ID)
+	plant, plantErr := repos.Plant().GetByID(ctx, basil.ID)
+	plants, plantsErr := repos.Plant().GetByGardenID(ctx, garden.ID)
+	logs, logsErr := repos.CareLog().GetByPlantID(ctx, basil.ID)
+	gotLog, gotLogErr := repos.CareLog().GetByID(ctx, careLog.ID)
+	logDeleteErr := repos.CareLog().Delete(ctx, careLog.ID)
+	_, deletedLogErr := repos.CareLog().GetByID(ctx, careLog.ID)
+	mint.Status = "harvested"
+	plantUpdateErr := repos.Plant().Update(ctx, mint)
+	plantDeleteErr := repos.Plant().Delete(ctx, mint.ID)
+	_, deletedPlantErr := repos.Plant().GetByID(ctx, mint.ID)
+	deleteByGardenErr := repos.Plant().DeleteByGardenID(ctx, garden.ID)
+	remaining, _ := repos.Plant().GetByGardenID(ctx, garden.ID)
+	gardenDeleteErr := repos.Garden().Delete(ctx, garden.ID)
+	_, deletedGardenErr := repos.Garden().GetByID(ctx, garden.ID)
+
+	// Assert
+	if gardensErr != nil || len(gardens) != 1 {
+		t.Errorf("Expected 1 garden, got %d (%v)", len(gardens), gardensErr)
+	}
+	if gardenUpdateErr != nil || updatedGarden == nil || updatedGarden.Name != "表庭" {
+		t.Errorf("Expected the updated garden, got %v", gardenUpdateErr)
+	}
+	if plantErr != nil || plant.Garden.ID != garden.ID {
+		t.Errorf("Expected the plant with its garden, got %v", plantErr)
+	}
+	if plantsErr != nil || len(plants) != 2 {
+		t.Errorf("Expected 2 plants, got %d (%v)", len(plants), plantsErr)
+	}
+	if logsErr != nil || len(logs) != 1 || gotLogErr != nil || gotLog.Type != "watering" {
+		t.Errorf("Expected the care log, got %d (%v / %v)", len(logs), logsErr, gotLogErr)
+	}
+	if logDeleteErr != nil || !errors.Is(deletedLogErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted care log not to be found, got %v / %v", logDeleteErr, deletedLogErr)
+	}
+	if plantUpdateErr != nil || plantDeleteErr != nil || !errors.Is(deletedPlantErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the plant to be updated and deleted, got %v / %v / %v", plantUpdateErr, plantDeleteErr, deletedPlantErr)
+	}
+	if deleteByGardenErr != nil || len(remaining) != 0 {
+		t.Errorf("Expected no plants after DeleteByGardenID, got %d (%v)", len(remaining), deleteByGardenErr)
+	}
+	if gardenDeleteErr != nil || !errors.Is(deletedGardenErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted garden not to be found, got %v / %v", gardenDeleteErr, deletedGardenErr)
+	}
+}
+
+// TestPostgres_TaskRepository はタスクの取得のテストです。
+// 期待動作:
+//   - ユーザー・ステータスで絞り込み、期限の昇順で返す（他のユーザーのタスクを含まない）
+//   - ページングは ID の降順で limit + 1 件まで、カーソルより前のIDを返す
+//   - 期限日順のページングは (期限日, ID) の昇順で、カーソルより後のタスクを返す
+//   - 今日のタスクは [dayStart, dayEnd) の未完了のタスクを優先度の降順、期限切れは dayStart より前の未完了のタスク
+//   - GetPendingTasksDueBefore は指定したユーザーの期限前の未完了のタスクをユーザーを含めて返す
+func TestPostgres_TaskRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "tasks@example.com")
+	other := createPostgresUser(t, repos, "other-tasks@example.com")
+	dayStart := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	low := &model.Task{UserID: user.ID, Title: "草取り", DueDate: dayStart.Add(8 * time.Hour), Priority: "low"}
+	high := &model.Task{UserID: user.ID, Title: "水やり", DueDate: dayStart.Add(9 * time.Hour), Priority: "high"}
+	overdue := &model.Task{UserID: user.ID, Title: "追肥", DueDate: dayStart.Add(-24 * time.Hour)}
+	done := &model.Task{UserID: user.ID, Title: "種まき", DueDate: dayStart.Add(-48 * time.Hour), Status: "completed"}
+	tomorrow := &model.Task{UserID: user.ID, Title: "支柱立て", DueDate: dayStart.Add(30 * time.Hour)}
+	others := &model.Task{UserID: other.ID, Title: "他のユーザー", DueDate: dayStart.Add(10 * time.Hour)}
+	for _, task := range []*model.Task{low, high, overdue, done, tomorrow, others} {
+		if err := repos.Task().Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	// Act
+	all, allErr := repos.Task().GetByUserID(ctx, user.ID)
+	completed, completedErr := repos.Task().GetByUserIDAndStatus(ctx, user.ID, "completed")
+	page, pageErr := repos.Task().ListByUserIDPaginated(ctx, user.ID, filter.Spec{}.EqualIfSet("status", "pending"), pagination.Params{Limit: 2})
+	next, nextErr := repos.Task().ListByUserIDPaginated(ctx, user.ID, filter.Spec{}.EqualIfSet("status", "pending"), pagination.Params{Limit: 2, BeforeID: high.ID})
+	byDueDate, byDueDateErr := repos.Task().ListByUserIDByDueDate(ctx, user.ID, filter.Spec{}.EqualIfSet("status", "pending"), pagination.KeyParams{Limit: 1})
+	afterLow, afterLowErr := repos.Task().ListByUserIDByDueDate(ctx, user.ID, nil, pagination.KeyParams{Limit: 5, AfterKey: low.DueDate, AfterID: low.ID})
+	todayTasks, todayErr := repos.Task().GetTodayTasks(ctx, user.ID, dayStart, dayStart.Add(24*time.Hour))
+	overdueTasks, overdueErr := repos.Task().GetOverdueTasks(ctx, user.ID, dayStart)
+	dueBefore, dueBeforeErr := repos.Task().GetPendingTasksDueBefore(ctx, []uint{user.ID, other.ID}, dayStart.Add(9*time.Hour+time.Minute))
+	none, noneErr := repos.Task().GetPendingTasksDueBefore(ctx, nil, dayStart)
+	got, getErr := repos.Task().GetByID(ctx, high.ID)
+
+	// Assert
+	if allErr != nil || !sameIDs(ids(all, taskID), []uint{done.ID, overdue.ID, low.ID, high.ID, tomorrow.ID}) {
+		t.Errorf("Expected the user's tasks by due date, got %v (%v)", ids(all, taskID), allErr)
+	}
+	if completedErr != nil || !sameIDs(ids(completed, taskID), []uint{done.ID}) {
+		t.Errorf("Expected only the completed task, got %v (%v)", ids(completed, taskID), completedErr)
+	}
+	// pending は low(1), high(2), overdue(3), tomorrow(5) の4件
+	if pageErr != nil || !sameIDs(ids(page, taskID), []uint{tomorrow.ID, overdue.ID, high.ID}) {
+		t.Errorf("Expected the first page (limit + 1) by ID desc, got %v (%v)", ids(page, taskID), pageErr)
+	}
+	if nextErr != nil || !sameIDs(ids(next, taskID), []uint{low.ID}) {
+		t.Errorf("Expected the tasks before the cursor, got %v (%v)", ids(next, taskID), nextErr)
+	}
+	if byDueDateErr != nil || !sameIDs(ids(byDueDate, taskID), []uint{overdue.ID, low.ID}) {
+		t.Errorf("Expected the first page (limit + 1) by due date, got %v (%v)", ids(byDueDate, taskID), byDueDateErr)
+	}
+	if afterLowErr != nil || !sameIDs(ids(afterLow, taskID), []uint{high.ID, tomorrow.ID}) {
+		t.Errorf("Expected the tasks due after the cursor, got %v (%v)", ids(afterLow, taskID), afterLowErr)
+	}
+	if todayErr != nil || !sameIDs(ids(todayTasks, taskID), []uint{low.ID, high.ID}) && !sameIDs(ids(todayTasks, taskID), []uint{high.ID, low.ID}) {
+		t.Errorf("Expected today's pending tasks, got %v (%v)", ids(todayTasks, taskID), todayErr)
+	}
+	if overdueErr != nil || !sameIDs(ids(overdueTasks, taskID), []uint{overdue.ID}) {
+		t.Errorf("Expected only the pending overdue task, got %v (%v)", ids(overdueTasks, taskID), overdueErr)
+	}
+	if dueBeforeErr != nil || len(dueBefore) != 3 {
+		t.Fatalf("Expected 3 pending tasks due before 09:01, got %v (%v)", ids(dueBefore, taskID), dueBeforeErr)
+	}
+	for _, task := range dueBefore {
+		if task.UserID != user.ID || task.User.Email != "tasks@example.com" {
+			t.Errorf("Expected the task with its user, got user %d %q", task.UserID, task.User.Email)
+		}
+	}
+	if noneErr != nil || len(none) != 0 {
+		t.Errorf("Expected no tasks without user IDs, got %d (%v)", len(none), noneErr)
+	}
+	if getErr != nil || got.Version != 1 || got.Status != "pending" || got.Priority != "high" {
+		t.Errorf("Expected the stored task with defaults, got %+v (%v)", got, getErr)
+	}
+}
+
+// TestPostgres_TaskRepository_UpdateAndSoftDelete はタスクの更新・論理削除のテストです。
+// 期待動作:
+//   - 古いバージョンの更新は ErrVersionConflict で、記録は変わらない
+//   - 論理削除したタスクは通常の取得に含まれず、削除済みの取得（削除日時の範囲）に含まれる
+//   - 復元すると再び取得でき、削除済みの取得に含まれない
+func TestPostgres_TaskRepository_UpdateAndSoftDelete(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "trash-tasks@example.com")
+	task := &model.Task{UserID: user.ID, Title: "水やり", DueDate: time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)}
+	if err := repos.Task().Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	beforeDelete := time.Now().Add(-time.Minute)
+
+	// Act
+	first, _ := repos.Task().GetByID(ctx, task.ID)
+	stale, _ := repos.Task().GetByID(ctx, task.ID)
+	first.Title = "水やり（朝）"
+	updateErr := repos.Task().Update(ctx, first)
+	stale.Title = "水やり（夕）"
+	conflictErr := repos.Task().Update(ctx, stale)
+	current, _ := repos.Task().GetByID(ctx, task.ID)
+	deleteErr := repos.Task().Delete(ctx, task.ID)
+	_, deletedErr := repos.Task().GetByID(ctx, task.ID)
+	listed, _ := repos.Task().GetByUserID(ctx, user.ID)
+	trash, trashErr := repos.Task().GetDeletedByUserID(ctx, user.ID, beforeDelete)
+	outOfRange, _ := repos.Task().GetDeletedByUserID(ctx, user.ID, time.Now().Add(time.Minute))
+	deleted, deletedByIDErr := repos.Task().GetDeletedByID(ctx, task.ID)
+	restoreErr := repos.Task().Restore(ctx, task.ID)
+	restored, restoredErr := repos.Task().GetByID(ctx, task.ID)
+	_, notDeletedErr := repos.Task().GetDeletedByID(ctx, task.ID)
+
+	// Assert
+	if updateErr != nil || !errors.Is(conflictErr, repository.ErrVersionConflict) {
+		t.Fatalf("Expected the first update and a conflict, got %v / %v", updateErr, conflictErr)
+	}
+	if current.Title != "水やり（朝）" || current.Version != 2 || stale.Version != 1 {
+		t.Errorf("Expected the first update (version 2) to be kept, got %q (version %d, stale %d)", current.Title, current.Version, stale.Version)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) || len(listed) != 0 {
+		t.Errorf("Expected the deleted task to be hidden, got %v / %v / %d", deleteErr, deletedErr, len(listed))
+	}
+	if trashErr != nil || !sameIDs(ids(trash, taskID), []uint{task.ID}) || len(outOfRange) != 0 {
+		t.Errorf("Expected the task in the trash only within the range, got %v / %d (%v)", ids(trash, taskID), len(outOfRange), trashErr)
+	}
+	if deletedByIDErr != nil || !deleted.DeletedAt.Valid {
+		t.Errorf("Expected the deleted task by ID, got %v", deletedByIDErr)
+	}
+	if restoreErr != nil || restoredErr != nil || restored.Title != "水やり（朝）" {
+		t.Errorf("Expected the restored task, got %v / %v", restoreErr, restoredErr)
+	}
+	if !errors.Is(notDeletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the restored task not to be in the trash, got %v", notDeletedErr)
+	}
+}
+
+// TestPostgres_CropRepository は作物の取得・更新のテストです。
+// 期待動作:
+//   - ユーザー・ステータスで絞り込み、植え付け日の降順で返す。ページングは ID の降順
+//   - GetByIDs は存在するIDのみ返し、空の場合は nil
+//   - 収穫予定日が [from, to) の成長中の作物、通知していない収穫可能の候補を返し、通知後は候補に含まない
+//   - 植え付け日の範囲の収穫済み・失敗の作物を返し、アーカイブは未アーカイブの作物のみ日時を設定する
+//   - 組織の作物を数え、古いバージョンの更新は ErrVersionConflict
+func TestPostgres_CropRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "crops@example.com")
+	other := createPostgresUser(t, repos, "other-crops@example.com")
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	growing := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", Status: "growing",
+		PlantedDate: now.AddDate(0, -2, 0), ExpectedHarvestDate: now.AddDate(0, 0, 3)})
+	overdue := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "ナス", Status: "planted",
+		PlantedDate: now.AddDate(0, -3, 0), ExpectedHarvestDate: now.AddDate(0, 0, -1)})
+	ready := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "キュウリ", Status: "ready_to_harvest",
+		PlantedDate: now.AddDate(0, -1, 0), ExpectedHarvestDate: now.AddDate(0, 1, 0)})
+	harvested := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "レタス", Status: "harvested",
+		PlantedDate: now.AddDate(0, -4, 0)})
+	organization := &model.Organization{Name: "市民農園", Type: "community_garden", OwnerID: other.ID}
+	if err := repos.Organization().Create(ctx, organization); err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+	orgCrop := createPostgresCrop(t, repos, &model.Crop{UserID: other.ID, Name: "ダイコン", Status: "growing",
+		PlantedDate: now.AddDate(0, -1, 0), OrganizationID: &organization.ID})
+
+	// Act
+	all, allErr := repos.Crop().GetByUserID(ctx, user.ID)
+	growingCrops, growingErr := repos.Crop().GetByUserIDAndStatus(ctx, user.ID, "growing")
+	page, pageErr := repos.Crop().ListByUserIDPaginated(ctx, user.ID, nil, pagination.Params{Limit: 2})
+	byIDs, byIDsErr := repos.Crop().GetByIDs(ctx, []uint{ready.ID, growing.ID, 999})
+	empty, emptyErr := repos.Crop().GetByIDs(ctx, nil)
+	upcoming, upcomingErr := repos.Crop().GetUpcomingHarvests(ctx, []uint{user.ID, other.ID}, now, now.AddDate(0, 0, 7))
+	candidates, candidatesErr := repos.Crop().GetHarvestReadyCandidates(ctx, []uint{user.ID}, now)
+	markErr := repos.Crop().MarkHarvestReadyNotified(ctx, []uint{ready.ID}, now)
+	afterMark, _ := repos.Crop().GetHarvestReadyCandidates(ctx, []uint{user.ID}, now)
+	finished, finishedErr := repos.Crop().GetFinishedPlantedBetween(ctx, now.AddDate(0, -5, 0), now.AddDate(0, -3, 0))
+	archivedAt := now.Add(time.Hour)
+	archiveErr := repos.Crop().Archive(ctx, []uint{harvested.ID}, archivedAt)
+	rearchiveErr := repos.Crop().Archive(ctx, []uint{harvested.ID}, archivedAt.Add(time.Hour))
+	archived, _ := repos.Crop().GetByID(ctx, harvested.ID)
+	orgCount, orgCountErr := repos.Crop().CountByOrganizationID(ctx, organization.ID)
+	first, _ := repos.Crop().GetByID(ctx, growing.ID)
+	stale, _ := repos.Crop().GetByID(ctx, growing.ID)
+	first.Notes = "わき芽かき"
+	updateErr := repos.Crop().Update(ctx, first)
+	conflictErr := repos.Crop().Update(ctx, stale)
+
+	// Assert
+	if allErr != nil || !sameIDs(ids(all, cropID), []uint{ready.ID, growing.ID, overdue.ID, harvested.ID}) {
+		t.Errorf("Expected the user's crops by planted date desc, got %v (%v)", ids(all, cropID), allErr)
+	}
+	if growingErr != nil || !sameIDs(ids(growingCrops, cropID), []uint{growing.ID}) {
+		t.Errorf("Expected only the growing crop, got %v (%v)", ids(growingCrops, cropID), growingErr)
+	}
+	if pageErr != nil || !sameIDs(ids(page, cropID), []uint{harvested.ID, ready.ID, overdue.ID}) {
+		t.Errorf("Expected the first page (limit + 1) by ID desc, got %v (%v)", ids(page, cropID), pageErr)
+	}
+	if byIDsErr != nil || len(byIDs) != 2 || emptyErr != nil || empty != nil {
+		t.Errorf("Expected 2 crops by IDs and nil for no IDs, got %d / %v (%v / %v)", len(byIDs), empty, byIDsErr, emptyErr)
+	}
+	if upcomingErr != nil || !sameIDs(ids(upcoming, cropID), []uint{growing.ID}) || upcoming[0].User.ID != user.ID {
+		t.Errorf("Expected the growing crop due within a week with its user, got %v (%v)", ids(upcoming, cropID), upcomingErr)
+	}
+	if candidatesErr != nil || !sameIDs(ids(candidates, cropID), []uint{overdue.ID, ready.ID}) {
+		t.Errorf("Expected the overdue and ready crops as candidates, got %v (%v)", ids(candidates, cropID), candidatesErr)
+	}
+	if markErr != nil || !sameIDs(ids(afterMark, cropID), []uint{overdue.ID}) {
+		t.Errorf("Expected the notified crop to be excluded, got %v (%v)", ids(afterMark, cropID), markErr)
+	}
+	if finishedErr != nil || !sameIDs(ids(finished, cropID), []uint{harvested.ID}) {
+		t.Errorf("Expected the harvested crop planted in range, got %v (%v)", ids(finished, cropID), finishedErr)
+	}
+	if archiveErr != nil || rearchiveErr != nil || archived.ArchivedAt == nil || !archived.ArchivedAt.Equal(archivedAt) {
+		t.Errorf("Expected the first archive time to be kept, got %v (%v / %v)", archived.ArchivedAt, archiveErr, rearchiveErr)
+	}
+	if orgCountErr != nil || orgCount != 1 || orgCrop.OrganizationID == nil {
+		t.Errorf("Expected 1 organization crop, got %d (%v)", orgCount, orgCountErr)
+	}
+	if updateErr != nil || !errors.Is(conflictErr, repository.ErrVersionConflict) {
+		t.Errorf("Expected the first update and a conflict, got %v / %v", updateErr, conflictErr)
+	}
+}
+
+// TestPostgres_CropRepository_SoftDelete は作物と成長記録・収穫記録の論理削除と復元のテストです。
+// 期待動作:
+//   - 作物・成長記録・収穫記録を論理削除すると取得できず、作物は削除済みの取得に含まれる
+//   - 作物の削除日時以降に削除した成長記録・収穫記録のみ復元し、それより前に削除した記録は復元しない
+//   - 復元した収穫記録のIDを返す
+func TestPostgres_CropRepository_SoftDelete(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "trash-crops@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+	earlier := &model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 2, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"}
+	later := &model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 2, 7), Quantity: 2, QuantityUnit: "kg", Quality: "good"}
+	for _, harvest := range []*model.Harvest{earlier, later} {
+		if err := repos.Harvest().Create(ctx, harvest); err != nil {
+			t.Fatalf("Failed to create harvest: %v", err)
+		}
+	}
+	record := &model.GrowthRecord{CropID: crop.ID, RecordDate: planted.AddDate(0, 1, 0), GrowthStage: "vegetative"}
+	if err := repos.GrowthRecord().Create(ctx, record); err != nil {
+		t.Fatalf("Failed to create growth record: %v", err)
+	}
+	// 作物の削除より前に個別に削除した収穫記録（作物の復元では戻さない）
+	if err := repos.Harvest().Delete(ctx, earlier.ID); err != nil {
+		t.Fatalf("Failed to delete harvest: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	deletedFrom := time.Now()
+
+	// Act
+	deleteErr := repos.Crop().Delete(ctx, crop.ID)
+	harvestsErr := repos.Harvest().DeleteByCropID(ctx, crop.ID)
+	recordsErr := repos.GrowthRecord().DeleteByCropID(ctx, crop.ID)
+	_, deletedErr := repos.Crop().GetByID(ctx, crop.ID)
+	remainingHarvests, _ := repos.Harvest().GetByCropID(ctx, crop.ID)
+	remainingRecords, _ := repos.GrowthRecord().GetByCropID(ctx, crop.ID)
+	trash, trashErr := repos.Crop().GetDeletedByUserID(ctx, user.ID, deletedFrom.Add(-time.Second))
+	deleted, deletedByIDErr := repos.Crop().GetDeletedByID(ctx, crop.ID)
+	restoreErr := repos.Crop().Restore(ctx, crop.ID)
+	restoredHarvestIDs, restoreHarvestsErr := repos.Harvest().RestoreByCropID(ctx, crop.ID, deletedFrom)
+	restoreRecordsErr := repos.GrowthRecord().RestoreByCropID(ctx, crop.ID, deletedFrom)
+	noneRestored, noneErr := repos.Harvest().RestoreByCropID(ctx, crop.ID, deletedFrom)
+	restored, restoredErr := repos.Crop().GetByID(ctx, crop.ID)
+	harvests, _ := repos.Harvest().GetByCropID(ctx, crop.ID)
+	records, _ := repos.GrowthRecord().GetByCropID(ctx, crop.ID)
+
+	// Assert
+	if deleteErr != nil || harvestsErr != nil || recordsErr != nil {
+		t.Fatalf("Delete failed: %v / %v / %v", deleteErr, harvestsErr, recordsErr)
+	}
+	if !errors.Is(deletedErr, gorm.ErrRecordNotFound) || len(remainingHarvests) != 0 || len(remainingRecords) != 0 {
+		t.Errorf("Expected the crop and its records to be hidden, got %v / %d / %d", deletedErr, len(remainingHarvests), len(remainingRecords))
+	}
+	if trashErr != nil || !sameIDs(ids(trash, cropID), []uint{crop.ID}) || deletedByIDErr != nil || !deleted.DeletedAt.Valid {
+		t.Errorf("Expected the crop in the trash, got %v (%v / %v)", ids(trash, cropID), trashErr, deletedByIDErr)
+	}
+	if restoreErr != nil || restoredErr != nil || restored.Name != "トマト" {
+		t.Errorf("Expected the restored crop, got %v / %v", restoreErr, restoredErr)
+	}
+	if restoreHarvestsErr != nil || !sameIDs(restoredHarvestIDs, []uint{later.ID}) {
+		t.Errorf("Expected only the harvest deleted with the crop to be restored, got %v (%v)", restoredHarvestIDs, restoreHarvestsErr)
+	}
+	if noneErr != nil || noneRestored != nil {
+		t.Errorf("Expected nil when nothing is restored, got %v (%v)", noneRestored, noneErr)
+	}
+	if !sameIDs(ids(harvests, harvestID), []uint{later.ID}) {
+		t.Errorf("Expected only the later harvest after restoring, got %v", ids(harvests, harvestID))
+	}
+	if restoreRecordsErr != nil || !sameIDs(ids(records, growthRecordID), []uint{record.ID}) {
+		t.Errorf("Expected the growth record to be restored, got %v (%v)", ids(records, growthRecordID), restoreRecordsErr)
+	}
+}
+
+// TestPostgres_GrowthRecordRepository は成長記録のリポジトリのテストです。
+// 期待動作:
+//   - 作物ごと・複数の作物の成長記録を記録日の降順で返し、作物IDが空の場合は空
+//   - 論理削除した成長記録は取得できない
+func TestPostgres_GrowthRecordRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "growth@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tomato := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+	basil := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "バジル", PlantedDate: planted})
+	seedling := &model.GrowthRecord{CropID: tomato.ID, RecordDate: planted.AddDate(0, 0, 7), GrowthStage: "seedling"}
+	flowering := &model.GrowthRecord{CropID: tomato.ID, RecordDate: planted.AddDate(0, 1, 0), GrowthStage: "flowering"}
+	basilRecord := &model.GrowthRecord{CropID: basil.ID, RecordDate: planted.AddDate(0, 0, 14), GrowthStage: "vegetative"}
+	for _, record := range []*model.GrowthRecord{seedling, flowering, basilRecord} {
+		if err := repos.GrowthRecord().Create(ctx, record); err != nil {
+			t.Fatalf("Failed to create growth record: %v", err)
+		}
+	}
+
+	// Act
+	got, getErr := repos.GrowthRecord().GetByID(ctx, seedling.ID)
+	byCrop, byCropErr := repos.GrowthRecord().GetByCropID(ctx, tomato.ID)
+	byCrops, byCropsErr := repos.GrowthRecord().GetByCropIDs(ctx, []uint{tomato.ID, basil.ID})
+	empty, emptyErr := repos.GrowthRecord().GetByCropIDs(ctx, nil)
+	deleteErr := repos.GrowthRecord().Delete(ctx, seedling.ID)
+	_, deletedErr := repos.GrowthRecord().GetByID(ctx, seedling.ID)
+
+	// Assert
+	if getErr != nil || got.GrowthStage != "seedling" || !got.RecordDate.Equal(seedling.RecordDate) {
+		t.Errorf("Expected the stored growth record, got %+v (%v)", got, getErr)
+	}
+	if byCropErr != nil || !sameIDs(ids(byCrop, growthRecordID), []uint{flowering.ID, seedling.ID}) {
+		t.Errorf("Expected the crop's records by date desc, got %v (%v)", ids(byCrop, growthRecordID), byCropErr)
+	}
+	if byCropsErr != nil || !sameIDs(ids(byCrops, growthRecordID), []uint{flowering.ID, basilRecord.ID, seedling.ID}) {
+		t.Errorf("Expected the records of both crops by date desc, got %v (%v)", ids(byCrops, growthRecordID), byCropsErr)
+	}
+	if emptyErr != nil || len(empty) != 0 {
+		t.Errorf("Expected no records without crop IDs, got %d (%v)", len(empty), emptyErr)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted record not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+}
+
+// TestPostgres_HarvestRepository は収穫記録のリポジトリのテストです。
+// 期待動作:
+//   - 作物ごと・複数の作物の収穫記録を収穫日の降順で返し、ページングは ID の降順
+//   - 日付の範囲（開始・終了を含む、片方のみの指定も可）でユーザーの収穫記録を絞り込み、削除した作物の記録は含まない
+//   - 論理削除した収穫記録は削除済みの取得に含まれ、復元すると再び取得できる
+func TestPostgres_HarvestRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "harvests@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tomato := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+	removed := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "ナス", PlantedDate: planted})
+	june := &model.Harvest{CropID: tomato.ID, HarvestDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Quantity: 1, QuantityUnit: "kg", Quality: "good"}
+	july := &model.Harvest{CropID: tomato.ID, HarvestDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Quantity: 2, QuantityUnit: "kg", Quality: "good"}
+	august := &model.Harvest{CropID: tomato.ID, HarvestDate: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), Quantity: 3, QuantityUnit: "kg", Quality: "good"}
+	removedHarvest := &model.Harvest{CropID: removed.ID, HarvestDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Quantity: 1, QuantityUnit: "pieces", Quality: "good"}
+	for _, harvest := range []*model.Harvest{june, july, august, removedHarvest} {
+		if err := repos.Harvest().Create(ctx, harvest); err != nil {
+			t.Fatalf("Failed to create harvest: %v", err)
+		}
+	}
+	if err := repos.Crop().Delete(ctx, removed.ID); err != nil {
+		t.Fatalf("Failed to delete crop: %v", err)
+	}
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	got, getErr := repos.Harvest().GetByID(ctx, june.ID)
+	byCrop, byCropErr := repos.Harvest().GetByCropID(ctx, tomato.ID)
+	page, pageErr := repos.Harvest().ListByCropIDPaginated(ctx, tomato.ID, pagination.Params{Limit: 1, BeforeID: august.ID})
+	byCrops, byCropsErr := repos.Harvest().GetByCropIDs(ctx, []uint{tomato.ID, removed.ID})
+	inRange, inRangeErr := repos.Harvest().GetByUserIDWithDateRange(ctx, user.ID, &start, &end)
+	fromStart, fromStartErr := repos.Harvest().GetByUserIDWithDateRange(ctx, user.ID, &start, nil)
+	untilStart, untilStartErr := repos.Harvest().GetByUserIDWithDateRange(ctx, user.ID, nil, &start)
+	beforeDelete := time.Now().Add(-time.Minute)
+	deleteErr := repos.Harvest().Delete(ctx, july.ID)
+	_, deletedErr := repos.Harvest().GetByID(ctx, july.ID)
+	trash, trashErr := repos.Harvest().GetDeletedByUserID(ctx, user.ID, beforeDelete)
+	deleted, deletedByIDErr := repos.Harvest().GetDeletedByID(ctx, july.ID)
+	restoreErr := repos.Harvest().Restore(ctx, july.ID)
+	restored, restoredErr := repos.Harvest().GetByID(ctx, july.ID)
+
+	// Assert
+	if getErr != nil || got.Quality != "good" || got.Quantity != 1 {
+		t.Errorf("Expected the stored harvest, got %+v (%v)", got, getErr)
+	}
+	if byCropErr != nil || !sameIDs(ids(byCrop, harvestID), []uint{august.ID, july.ID, june.ID}) {
+		t.Errorf("Expected the crop's harvests by date desc, got %v (%v)", ids(byCrop, harvestID), byCropErr)
+	}
+	if pageErr != nil || !sameIDs(ids(page, harvestID), []uint{july.ID, june.ID}) {
+		t.Errorf("Expected the page before the cursor (limit + 1), got %v (%v)", ids(page, harvestID), pageErr)
+	}
+	// GetByCropIDs は作物の削除を確認しない（作物の論理削除後も収穫記録は論理削除していない）
+	if byCropsErr != nil || len(byCrops) != 4 {
+		t.Errorf("Expected 4 harvests of both crops, got %d (%v)", len(byCrops), byCropsErr)
+	}
+	if inRangeErr != nil || !sameIDs(ids(inRange, harvestID), []uint{august.ID, july.ID}) {
+		t.Errorf("Expected the harvests within the inclusive range, got %v (%v)", ids(inRange, harvestID), inRangeErr)
+	}
+	if fromStartErr != nil || !sameIDs(ids(fromStart, harvestID), []uint{august.ID, july.ID}) {
+		t.Errorf("Expected the harvests from the start date, got %v (%v)", ids(fromStart, harvestID), fromStartErr)
+	}
+	if untilStartErr != nil || !sameIDs(ids(untilStart, harvestID), []uint{july.ID, june.ID}) {
+		t.Errorf("Expected the harvests until the end date, got %v (%v)", ids(untilStart, harvestID), untilStartErr)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted harvest not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+	// 削除した作物の収穫記録は削除済みの取得に含まない（作物の復元で戻す）
+	if trashErr != nil || !sameIDs(ids(trash, harvestID), []uint{july.ID}) || deletedByIDErr != nil || !deleted.DeletedAt.Valid {
+		t.Errorf("Expected only the deleted harvest in the trash, got %v (%v / %v)", ids(trash, harvestID), trashErr, deletedByIDErr)
+	}
+	if restoreErr != nil || restoredErr != nil || restored.Quantity != 2 {
+		t.Errorf("Expected the restored harvest, got %v / %v", restoreErr, restoredErr)
+	}
+}
+
+// TestPostgres_HarvestRepository_BenchmarkYields は匿名ベンチマークの収穫量のテストです。
+// 期待動作:
+//   - オプトインしたユーザーの区画に配置した作物（名前の大文字小文字を区別しない）のみ集計する
+//   - 収穫量は kg に換算（g は 1/1000、pieces は 0.1kg）し、面積は作物を配置した区画の合計
+func TestPostgres_HarvestRepository_BenchmarkYields(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	optedIn := createPostgresUser(t, repos, "benchmark@example.com")
+	optedIn.BenchmarkOptIn = true
+	if err := repos.User().Update(ctx, optedIn); err != nil {
+		t.Fatalf("Failed to opt in: %v", err)
+	}
+	optedOut := createPostgresUser(t, repos, "private@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	for _, user := range []*model.User{optedIn, optedOut} {
+		crop := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "Tomato", PlantedDate: planted})
+		plot := &model.Plot{UserID: user.ID, Name: "A区画", Width: 2, Height: 3, SoilType: "loamy", Sunlight: "full_sun"}
+		if err := repos.Plot().Create(ctx, plot); err != nil {
+			t.Fatalf("Failed to create plot: %v", err)
+		}
+		if err := repos.PlotAssignment().Create(ctx, &model.PlotAssignment{PlotID: plot.ID, CropID: crop.ID, AssignedDate: planted}); err != nil {
+			t.Fatalf("Failed to create assignment: %v", err)
+		}
+		for _, harvest := range []*model.Harvest{
+			{CropID: crop.ID, HarvestDate: planted.AddDate(0, 3, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"},
+			{CropID: crop.ID, HarvestDate: planted.AddDate(0, 3, 7), Quantity: 500, QuantityUnit: "g", Quality: "good"},
+			{CropID: crop.ID, HarvestDate: planted.AddDate(0, 3, 14), Quantity: 5, QuantityUnit: "pieces", Quality: "good"},
+		} {
+			if err := repos.Harvest().Create(ctx, harvest); err != nil {
+				t.Fatalf("Failed to create harvest: %v", err)
+			}
+		}
+	}
+
+	// Act
+	yields, err := repos.Harvest().GetBenchmarkYields(ctx, "tomato")
+
+	// Assert
+	if err != nil || len(yields) != 1 {
+		t.Fatalf("Expected 1 opted-in yield, got %+v (%v)", yields, err)
+	}
+	if yields[0].UserID != optedIn.ID || yields[0].TotalKg != 2 || yields[0].AreaM2 != 6 {
+		t.Errorf("Expected 2kg over 6m2 for the opted-in user, got %+v", yields[0])
+	}
+}
+
+// TestPostgres_PlotRepository は区画のリポジトリのテストです。
+// 期待動作:
+//   - ユーザー・ステータスで絞り込み、ページングは ID の降順。GetByIDs は存在するIDのみ
+//   - レイアウトは区画ごとに配置解除していない最初の配置と作物を含める
+//   - 古いバージョンの更新は ErrVersionConflict
+//   - 論理削除した区画は削除済みの取得に含まれ、復元すると再び取得できる
+func TestPostgres_PlotRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "plots@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "キュウリ", PlantedDate: planted})
+	occupied := &model.Plot{UserID: user.ID, Name: "A区画", Width: 2, Height: 3, SoilType: "loamy", Sunlight: "full_sun", Status: "occupied"}
+	available := &model.Plot{UserID: user.ID, Name: "B区画", Width: 1, Height: 1, SoilType: "loamy", Sunlight: "full_sun"}
+	spare := &model.Plot{UserID: user.ID, Name: "C区画", Width: 1, Height: 2, SoilType: "loamy", Sunlight: "full_sun"}
+	for _, plot := range []*model.Plot{occupied, available, spare} {
+		if err := repos.Plot().Create(ctx, plot); err != nil {
+			t.Fatalf("Failed to create plot: %v", err)
+		}
+	}
+	unassigned := planted.AddDate(0, 1, 0)
+	for _, assignment := range []*model.PlotAssignment{
+		{PlotID: occupied.ID, CropID: crop.ID, AssignedDate: planted},
+		{PlotID: available.ID, CropID: crop.ID, AssignedDate: planted, UnassignedDate: &unassigned},
+	} {
+		if err := repos.PlotAssignment().Create(ctx, assignment); err != nil {
+			t.Fatalf("Failed to create assignment: %v", err)
+		}
+	}
+
+	// Act
+	got, getErr := repos.Plot().GetByID(ctx, occupied.ID)
+	all, allErr := repos.Plot().GetByUserID(ctx, user.ID)
+	availablePlots, availableErr := repos.Plot().GetByUserIDAndStatus(ctx, user.ID, "available")
+	page, pageErr := repos.Plot().ListByUserIDPaginated(ctx, user.ID, filter.Spec{}.EqualIfSet("status", "available"), pagination.Params{Limit: 1})
+	byIDs, byIDsErr := repos.Plot().GetByIDs(ctx, []uint{occupied.ID, 999})
+	layout, layoutErr := repos.Plot().GetLayoutByUserID(ctx, user.ID)
+	orgCount, orgCountErr := repos.Plot().CountByOrganizationID(ctx, 1)
+	first, _ := repos.Plot().GetByID(ctx, spare.ID)
+	stale, _ := repos.Plot().GetByID(ctx, spare.ID)
+	first.Notes = "日陰"
+	updateErr := repos.Plot().Update(ctx, first)
+	conflictErr := repos.Plot().Update(ctx, stale)
+	beforeDelete := time.Now().Add(-time.Minute)
+	deleteErr := repos.Plot().Delete(ctx, spare.ID)
+	_, deletedErr := repos.Plot().GetByID(ctx, spare.ID)
+	trash, trashErr := repos.Plot().GetDeletedByUserID(ctx, user.ID, beforeDelete)
+	deleted, deletedByIDErr := repos.Plot().GetDeletedByID(ctx, spare.ID)
+	restoreErr := repos.Plot().Restore(ctx, spare.ID)
+	restored, restoredErr := repos.Plot().GetByID(ctx, spare.ID)
+
+	// Assert
+	if getErr != nil || got.Status != "occupied" || got.Version != 1 {
+		t.Errorf("Expected the stored plot, got %+v (%v)", got, getErr)
+	}
+	if allErr != nil || len(all) != 3 {
+		t.Errorf("Expected 3 plots, got %d (%v)", len(all), allErr)
+	}
+	if availableErr != nil || len(availablePlots) != 2 {
+		t.Errorf("Expected 2 available plots (default status), got %d (%v)", len(availablePlots), availableErr)
+	}
+	if pageErr != nil || !sameIDs(ids(page, plotID), []uint{spare.ID, available.ID}) {
+		t.Errorf("Expected the first page (limit + 1) by ID desc, got %v (%v)", ids(page, plotID), pageErr)
+	}
+	if byIDsErr != nil || !sameIDs(ids(byIDs, plotID), []uint{occupied.ID}) {
+		t.Errorf("Expected only the existing plot by IDs, got %v (%v)", ids(byIDs, plotID), byIDsErr)
+	}
+	if layoutErr != nil || len(layout) != 3 {
+		t.Fatalf("Expected 3 plots in the layout, got %d (%v)", len(layout), layoutErr)
+	}
+	for _, plot := range layout {
+		want := 0
+		if plot.ID == occupied.ID {
+			want = 1
+		}
+		if len(plot.PlotAssignments) != want {
+			t.Errorf("Expected %d active assignments for plot %d, got %d", want, plot.ID, len(plot.PlotAssignments))
+		}
+		if want == 1 && plot.PlotAssignments[0].Crop.Name != "キュウリ" {
+			t.Errorf("Expected the active assignment with its crop, got %+v", plot.PlotAssignments[0])
+		}
+	}
+	if orgCountErr != nil || orgCount != 0 {
+		t.Errorf("Expected no organization plots, got %d (%v)", orgCount, orgCountErr)
+	}
+	if updateErr != nil || !errors.Is(conflictErr, repository.ErrVersionConflict) {
+		t.Errorf("Expected the first update and a conflict, got %v / %v", updateErr, conflictErr)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted plot not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+	if trashErr != nil || !sameIDs(ids(trash, plotID), []uint{spare.ID}) || deletedByIDErr != nil || !deleted.DeletedAt.Valid {
+		t.Errorf("Expected the plot in the trash, got %v (%v / %v)", ids(trash, plotID), trashErr, deletedByIDErr)
+	}
+	if restoreErr != nil || restoredErr != nil || restored.Notes != "日陰" {
+		t.Errorf("Expected the restored plot, got %v / %v", restoreErr, restoredErr)
+	}
+}
+
+// TestPostgres_PlotAssignmentRepository は区画の配置のリポジトリのテストです。
+// 期待動作:
+//   - 区画・作物ごとの配置を配置日の降順で返し、アクティブな配置は配置解除していない配置
+//   - 複数の区画のアクティブな配置は区画ごとに最初の1件、複数の区画の全配置は配置日の昇順
+//   - 配置解除を更新でき、区画の配置をまとめて論理削除・復元できる
+func TestPostgres_PlotAssignmentRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "assignments@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tomato := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+	peas := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "エンドウ", PlantedDate: planted})
+	plotA := &model.Plot{UserID: user.ID, Name: "A区画", Width: 2, Height: 2, SoilType: "loamy", Sunlight: "full_sun"}
+	plotB := &model.Plot{UserID: user.ID, Name: "B区画", Width: 2, Height: 2, SoilType: "loamy", Sunlight: "full_sun"}
+	for _, plot := range []*model.Plot{plotA, plotB} {
+		if err := repos.Plot().Create(ctx, plot); err != nil {
+			t.Fatalf("Failed to create plot: %v", err)
+		}
+	}
+	unassigned := planted.AddDate(0, 1, 0)
+	past := &model.PlotAssignment{PlotID: plotA.ID, CropID: peas.ID, AssignedDate: planted, UnassignedDate: &unassigned}
+	active := &model.PlotAssignment{PlotID: plotA.ID, CropID: tomato.ID, AssignedDate: unassigned}
+	activeB := &model.PlotAssignment{PlotID: plotB.ID, CropID: peas.ID, AssignedDate: unassigned}
+	for _, assignment := range []*model.PlotAssignment{past, active, activeB} {
+		if err := repos.PlotAssignment().Create(ctx, assignment); err != nil {
+			t.Fatalf("Failed to create assignment: %v", err)
+		}
+	}
+
+	// Act
+	got, getErr := repos.PlotAssignment().GetByID(ctx, past.ID)
+	byIDs, byIDsErr := repos.PlotAssignment().GetByIDs(ctx, []uint{past.ID, active.ID})
+	byPlot, byPlotErr := repos.PlotAssignment().GetByPlotID(ctx, plotA.ID)
+	activeA, activeErr := repos.PlotAssignment().GetActiveByPlotID(ctx, plotA.ID)
+	activeAll, activeAllErr := repos.PlotAssignment().GetActiveByPlotIDs(ctx, []uint{plotA.ID, plotB.ID})
+	byPlots, byPlotsErr := repos.PlotAssignment().GetByPlotIDs(ctx, []uint{plotA.ID, plotB.ID})
+	byCrop, byCropErr := repos.PlotAssignment().GetByCropID(ctx, peas.ID)
+	ended := unassigned.AddDate(0, 1, 0)
+	activeB.UnassignedDate = &ended
+	updateErr := repos.PlotAssignment().Update(ctx, activeB)
+	_, noActiveErr := repos.PlotAssignment().GetActiveByPlotID(ctx, plotB.ID)
+	deleteErr := repos.PlotAssignment().Delete(ctx, activeB.ID)
+	_, deletedErr := repos.PlotAssignment().GetByID(ctx, activeB.ID)
+	deletedFrom := time.Now()
+	deleteByPlotErr := repos.PlotAssignment().DeleteByPlotID(ctx, plotA.ID)
+	afterDelete, _ := repos.PlotAssignment().GetByPlotID(ctx, plotA.ID)
+	restoreErr := repos.PlotAssignment().RestoreByPlotID(ctx, plotA.ID, deletedFrom)
+	afterRestore, _ := repos.PlotAssignment().GetByPlotID(ctx, plotA.ID)
+
+	// Assert
+	if getErr != nil || got.UnassignedDate == nil || !got.UnassignedDate.Equal(unassigned) {
+		t.Errorf("Expected the stored assignment, got %+v (%v)", got, getErr)
+	}
+	if byIDsErr != nil || len(byIDs) != 2 {
+		t.Errorf("Expected 2 assignments by IDs, got %d (%v)", len(byIDs), byIDsErr)
+	}
+	if byPlotErr != nil || !sameIDs(ids(byPlot, assignmentID), []uint{active.ID, past.ID}) {
+		t.Errorf("Expected the plot's assignments by date desc, got %v (%v)", ids(byPlot, assignmentID), byPlotErr)
+	}
+	if activeErr != nil || activeA.ID != active.ID {
+		t.Errorf("Expected the active assignment, got %v", activeErr)
+	}
+	if activeAllErr != nil || !sameIDs(ids(activeAll, assignmentID), []uint{active.ID, activeB.ID}) {
+		t.Errorf("Expected one active assignment per plot, got %v (%v)", ids(activeAll, assignmentID), activeAllErr)
+	}
+	if byPlotsErr != nil || !sameIDs(ids(byPlots, assignmentID), []uint{past.ID, active.ID, activeB.ID}) {
+		t.Errorf("Expected the plots' assignments by date asc, got %v (%v)", ids(byPlots, assignmentID), byPlotsErr)
+	}
+	if byCropErr != nil || !sameIDs(ids(byCrop, assignmentID), []uint{activeB.ID, past.ID}) {
+		t.Errorf("Expected the crop's assignments by date desc, got %v (%v)", ids(byCrop, assignmentID), byCropErr)
+	}
+	if updateErr != nil || !errors.Is(noActiveErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected no active assignment after unassigning, got %v / %v", updateErr, noActiveErr)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted assignment not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+	if deleteByPlotErr != nil || len(afterDelete) != 0 {
+		t.Errorf("Expected no assignments after DeleteByPlotID, got %d (%v)", len(afterDelete), deleteByPlotErr)
+	}
+	if restoreErr != nil || len(afterRestore) != 2 {
+		t.Errorf("Expected the plot's assignments to be restored, got %d (%v)", len(afterRestore), restoreErr)
+	}
+}
+
+// TestPostgres_CreateBatch は記録の一括作成のテストです。
+// 期待動作:
+//   - 作成した記録のIDを各要素に設定し、デフォルト値（ステータス・バージョン）を設定する
+//   - 複数のバッチになる場合も1件でも失敗した場合はすべて作成しない
+func TestPostgres_CreateBatch(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "batch@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crops := []model.Crop{
+		{UserID: user.ID, Name: "トマト", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)},
+		{UserID: user.ID, Name: "ナス", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)},
+	}
+	newTasks := func(n int) []model.Task {
+		tasks := make([]model.Task, n)
+		for i := range tasks {
+			tasks[i] = model.Task{UserID: user.ID, Title: fmt.Sprintf("水やり %d", i+1), DueDate: planted.AddDate(0, 0, i)}
+		}
+		return tasks
+	}
+	// 2つ目のバッチの最後のタスクが制約違反
+	invalid := newTasks(501)
+	invalid[500].Priority = "urgent"
+	valid := newTasks(501)
+
+	// Act
+	cropsErr := repos.Crop().CreateBatch(ctx, crops)
+	recordsErr := repos.GrowthRecord().CreateBatch(ctx, []model.GrowthRecord{
+		{CropID: crops[0].ID, RecordDate: planted.AddDate(0, 0, 7), GrowthStage: "seedling"},
+	})
+	harvestsErr := repos.Harvest().CreateBatch(ctx, []model.Harvest{
+		{CropID: crops[1].ID, HarvestDate: planted.AddDate(0, 3, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"},
+	})
+	invalidErr := repos.Task().CreateBatch(ctx, invalid)
+	afterInvalid, _ := repos.Task().GetByUserID(ctx, user.ID)
+	validErr := repos.Task().CreateBatch(ctx, valid)
+	afterValid, _ := repos.Task().GetByUserID(ctx, user.ID)
+	stored, storedErr := repos.Task().GetByID(ctx, valid[500].ID)
+	records, _ := repos.GrowthRecord().GetByCropID(ctx, crops[0].ID)
+	harvests, _ := repos.Harvest().GetByCropID(ctx, crops[1].ID)
+
+	// Assert
+	if cropsErr != nil || recordsErr != nil || harvestsErr != nil {
+		t.Fatalf("CreateBatch failed: %v / %v / %v", cropsErr, recordsErr, harvestsErr)
+	}
+	if crops[0].ID == 0 || crops[1].ID == 0 || crops[0].Version != 1 {
+		t.Errorf("Expected the crops with IDs, got %+v", crops)
+	}
+	if len(records) != 1 || len(harvests) != 1 {
+		t.Errorf("Expected 1 growth record and 1 harvest, got %d / %d", len(records), len(harvests))
+	}
+	if !strings.Contains(fmt.Sprint(invalidErr), "chk_tasks_priority") || len(afterInvalid) != 0 {
+		t.Errorf("Expected no tasks after the failed batch, got %d (%v)", len(afterInvalid), invalidErr)
+	}
+	if validErr != nil || len(afterValid) != 501 {
+		t.Errorf("Expected 501 tasks, got %d (%v)", len(afterValid), validErr)
+	}
+	if storedErr != nil || stored.Title != "水やり 501" || stored.Status != "pending" || stored.Version != 1 {
+		t.Errorf("Expected the last task with its ID and defaults, got %+v (%v)", stored, storedErr)
+	}
+}
+
+// TestPostgres_Constraints は CHECK 制約のテストです。
+// 期待動作:
+//   - マイグレーションの CHECK 制約を満たさない作成・更新は制約名を含むエラーになる
+func TestPostgres_Constraints(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "constraints@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+
+	// Act
+	datesErr := repos.Crop().Create(ctx, &model.Crop{UserID: user.ID, Name: "ナス", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, -1, 0)})
+	quantityErr := repos.Harvest().Create(ctx, &model.Harvest{CropID: crop.ID, HarvestDate: planted, Quantity: 0, QuantityUnit: "kg", Quality: "good"})
+	stageErr := repos.GrowthRecord().Create(ctx, &model.GrowthRecord{CropID: crop.ID, RecordDate: planted, GrowthStage: "dormant"})
+	dimensionsErr := repos.Plot().Create(ctx, &model.Plot{UserID: user.ID, Name: "A区画", Width: 0, Height: 1, SoilType: "loamy", Sunlight: "full_sun"})
+	priorityErr := repos.Task().Create(ctx, &model.Task{UserID: user.ID, Title: "水やり", DueDate: planted, Priority: "urgent"})
+	crop.Status = "unknown"
+	statusErr := repos.Crop().Update(ctx, crop)
+
+	// Assert
+	for name, err := range map[string]error{
+		"chk_crops_valid_dates":    datesErr,
+		"chk_harvests_quantity":    quantityErr,
+		"chk_growth_records_stage": stageErr,
+		"chk_plots_dimensions":     dimensionsErr,
+		"chk_tasks_priority":       priorityErr,
+		"chk_crops_status":         statusErr,
+	} {
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected %s, got %v", name, err)
+		}
+	}
+}
+
+// TestPostgres_AnalyticsViewRepository は分析のマテリアライズドビューのリポジトリのテストです。
+// 期待動作:
+//   - マテリアライズドビューはリフレッシュするまで新しい記録を含まず、リフレッシュ後は収穫量・回数・初収穫までの日数を集計する
+//   - リフレッシュの結果をビュー名で upsert し、失敗の記録は前回の成功日時を上書きしない
+//   - データのバージョンはスコープごとに増え、記録がないスコープは 0
+func TestPostgres_AnalyticsViewRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "analytics@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted})
+	for _, days := range []int{60, 70} {
+		harvest := &model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 0, days), Quantity: 1.5, QuantityUnit: "kg", Quality: "excellent"}
+		if err := repos.Harvest().Create(ctx, harvest); err != nil {
+			t.Fatalf("Failed to create harvest: %v", err)
+		}
+	}
+	refreshedAt := time.Date(2026, 7, 1, 3, 0, 0, 0, time.UTC)
+
+	// Act
+	stale, staleErr := repos.AnalyticsView().GetCropHarvestAnalytics(ctx, user.ID, "トマト")
+	refreshErr := repos.AnalyticsView().Refresh(ctx, "mv_harvest_analytics")
+	monthlyErr := repos.AnalyticsView().Refresh(ctx, "mv_monthly_harvest")
+	rows, rowsErr := repos.AnalyticsView().GetCropHarvestAnalytics(ctx, user.ID, "とまと")
+	matched, matchedErr := repos.AnalyticsView().GetCropHarvestAnalytics(ctx, user.ID, "トマト")
+	recordErr := repos.AnalyticsView().RecordRefresh(ctx, &model.MaterializedViewRefresh{
+		ViewName: "mv_harvest_analytics", LastRefreshedAt: &refreshedAt, LastAttemptAt: refreshedAt, DurationMs: 120,
+	})
+	failedErr := repos.AnalyticsView().RecordRefresh(ctx, &model.MaterializedViewRefresh{
+		ViewName: "mv_harvest_analytics", LastAttemptAt: refreshedAt.Add(time.Hour), LastError: "timeout",
+	})
+	statuses, statusesErr := repos.AnalyticsView().GetRefreshStatuses(ctx)
+	emptyVersion, emptyVersionErr := repos.AnalyticsView().GetDataVersion(ctx, []string{"user:1"})
+	bumpErr := repos.AnalyticsView().BumpDataVersion(ctx, "user:1", "org:2")
+	rebumpErr := repos.AnalyticsView().BumpDataVersion(ctx, "user:1")
+	version, versionErr := repos.AnalyticsView().GetDataVersion(ctx, []string{"user:1", "org:2"})
+
+	// Assert
+	if staleErr != nil || len(stale) != 0 {
+		t.Errorf("Expected no rows before refreshing, got %d (%v)", len(stale), staleErr)
+	}
+	if refreshErr != nil || monthlyErr != nil {
+		t.Fatalf("Refresh failed: %v / %v", refreshErr, monthlyErr)
+	}
+	if rowsErr != nil || len(rows) != 0 {
+		t.Errorf("Expected no rows for another crop name, got %d (%v)", len(rows), rowsErr)
+	}
+	if matchedErr != nil || len(matched) != 1 {
+		t.Fatalf("Expected 1 row after refreshing, got %d (%v)", len(matched), matchedErr)
+	}
+	row := matched[0]
+	if row.TotalQuantity != 3 || row.HarvestCount != 2 || row.QuantityUnit != "kg" {
+		t.Errorf("Expected 3kg over 2 harvests, got %+v", row)
+	}
+	if row.DaysToFirstHarvest == nil || *row.DaysToFirstHarvest != 60 {
+		t.Errorf("Expected 60 days to the first harvest, got %v", row.DaysToFirstHarvest)
+	}
+	if row.AvgQualityScore == nil || *row.AvgQualityScore != 4 {
+		t.Errorf("Expected an average quality score of 4, got %v", row.AvgQualityScore)
+	}
+	if recordErr != nil || failedErr != nil || statusesErr != nil || len(statuses) != 1 {
+		t.Fatalf("Expected 1 refresh status, got %d (%v / %v / %v)", len(statuses), recordErr, failedErr, statusesErr)
+	}
+	status := statuses[0]
+	if status.LastRefreshedAt == nil || !status.LastRefreshedAt.Equal(refreshedAt) || status.LastError != "timeout" {
+		t.Errorf("Expected the last success to be kept with the failure, got %+v", status)
+	}
+	if emptyVersionErr != nil || emptyVersion != 0 {
+		t.Errorf("Expected version 0 without records, got %d (%v)", emptyVersion, emptyVersionErr)
+	}
+	if bumpErr != nil || rebumpErr != nil || versionErr != nil || version != 3 {
+		t.Errorf("Expected the summed version 3, got %d (%v / %v / %v)", version, bumpErr, rebumpErr, versionErr)
+	}
+}
+
+// TestPostgres_SearchRepository は横断検索のテストです。
+// 期待動作:
+//   - 英数字の単語は全文検索で一致し、関連度は 1 以上
+//   - 日本語はトライグラム・部分一致で一致し、他のユーザー・論理削除した記録は含まない
+//   - 成長記録は作物の名前と作物IDを返す
+func TestPostgres_SearchRepository(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "search@example.com")
+	other := createPostgresUser(t, repos, "other-search@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	tomato := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "ミニトマト", Variety: "Aiko", PlantedDate: planted})
+	createPostgresCrop(t, repos, &model.Crop{UserID: other.ID, Name: "ミニトマト", PlantedDate: planted})
+	deleted := createPostgresCrop(t, repos, &model.Crop{UserID: user.ID, Name: "トマト（削除）", PlantedDate: planted})
+	if err := repos.Crop().Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Failed to delete crop: %v", err)
+	}
+	record := &model.GrowthRecord{CropID: tomato.ID, RecordDate: planted.AddDate(0, 1, 0), GrowthStage: "flowering", Notes: "黄色い花が咲いた"}
+	if err := repos.GrowthRecord().Create(ctx, record); err != nil {
+		t.Fatalf("Failed to create growth record: %v", err)
+	}
+
+	// Act
+	fullText, fullTextErr := repos.Search().Search(ctx, user.ID, repository.SearchEntityCrops, "aiko", 10)
+	japanese, japaneseErr := repos.Search().Search(ctx, user.ID, repository.SearchEntityCrops, "トマト", 10)
+	records, recordsErr := repos.Search().Search(ctx, user.ID, repository.SearchEntityGrowthRecords, "花", 10)
+	_, unknownErr := repos.Search().Search(ctx, user.ID, "gardens", "トマト", 10)
+
+	// Assert
+	if fullTextErr != nil || len(fullText) != 1 || fullText[0].ID != tomato.ID || fullText[0].Rank < 1 {
+		t.Errorf("Expected a full-text match with rank >= 1, got %+v (%v)", fullText, fullTextErr)
+	}
+	if japaneseErr != nil || len(japanese) != 1 || japanese[0].ID != tomato.ID || japanese[0].Type != repository.SearchEntityCrops {
+		t.Errorf("Expected only the user's crop, got %+v (%v)", japanese, japaneseErr)
+	}
+	if recordsErr != nil || len(records) != 1 || records[0].CropID != tomato.ID || records[0].Title != "ミニトマト" {
+		t.Errorf("Expected the growth record with its crop, got %+v (%v)", records, recordsErr)
+	}
+	if unknownErr == nil {
+		t.Error("Expected an error for an unknown entity")
+	}
+}
+
+// TestPostgres_NotificationChannelOutcomes は通知の送信結果の集計（JSONB）のテストです。
+// 期待動作:
+//   - 指定日時以降の通知ログの channel_status をチャネル×送信結果ごとに数える
+//   - 重複防止キーで送らなかった件数はチャネルごとに deduped として合計する
+func TestPostgres_NotificationChannelOutcomes(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "outcomes@example.com")
+	since := time.Now().Add(-time.Hour)
+	for _, log := range []*model.NotificationLog{
+		{UserID: user.ID, NotificationType: "task_due_reminder", Channel: "push,email", ChannelStatus: map[string]string{"push": "sent", "email": "failed"}, DuplicateCount: 2},
+		{UserID: user.ID, NotificationType: "task_due_reminder", Channel: "push", ChannelStatus: map[string]string{"push": "sent"}},
+	} {
+		log.ExpiresAt = time.Now().Add(24 * time.Hour)
+		if err := repos.NotificationLog().Create(ctx, log); err != nil {
+			t.Fatalf("Failed to create notification log: %v", err)
+		}
+	}
+
+	// Act
+	counts, err := repos.NotificationLog().CountChannelOutcomesSince(ctx, since)
+	future, futureErr := repos.NotificationLog().CountChannelOutcomesSince(ctx, time.Now().Add(time.Hour))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CountChannelOutcomesSince failed: %v", err)
+	}
+	got := map[string]int64{}
+	for _, count := range counts {
+		got[count.Channel+"/"+count.Outcome] = count.Count
+	}
+	want := map[string]int64{"push/sent": 2, "email/failed": 1, "push/deduped": 2, "email/deduped": 2}
+	if len(got) != len(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Errorf("Expected %s = %d, got %d", key, count, got[key])
+		}
+	}
+	if futureErr != nil || len(future) != 0 {
+		t.Errorf("Expected no outcomes after the logs, got %v (%v)", future, futureErr)
+	}
+}
+
+// TestPostgres_WithTransaction はトランザクションのテストです。
+// 期待動作:
+//   - fn がエラーを返した場合はトランザクション内の作成をロールバックし、成功した場合はコミットする
+func TestPostgres_WithTransaction(t *testing.T) {
+	// Arrange
+	repos := newPostgresRepositories(t)
+	ctx := context.Background()
+	user := createPostgresUser(t, repos, "tx@example.com")
+	errAbort := errors.New("abort")
+	create := func(title string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			return repos.Task().Create(ctx, &model.Task{UserID: user.ID, Title: title, DueDate: time.Now()})
+		}
+	}
+
+	// Act
+	abortErr := repos.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := create("ロールバック")(ctx); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	commitErr := repos.WithTransaction(ctx, create("コミット"))
+	tasks, listErr := repos.Task().GetByUserID(ctx, user.ID)
+
+	// Assert
+	if !errors.Is(abortErr, errAbort) || commitErr != nil {
+		t.Errorf("Expected the fn error and a commit, got %v / %v", abortErr, commitErr)
+	}
+	if listErr != nil || len(tasks) != 1 || tasks[0].Title != "コミット" {
+		t.Errorf("Expected only the committed task, got %d (%v)", len(tasks), listErr)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Query Timeout - クエリごとのタイムアウト
+// =============================================================================
+// リポジトリのクエリは GetDB でリクエストのコンテキストを使用するため、クライアントが切断するとキャンセルされます。
+// さらに GORM のコールバックで各クエリにタイムアウトを設定し、遅い集計クエリが接続を保持し続けないようにします。
+// コンテキストの期限がタイムアウトより早い場合は、コンテキストの期限を使用します。
+
+const (
+	// queryTimeoutParentKey はタイムアウトを設定する前のコンテキストの Statement の設定のキーです。
+	queryTimeoutParentKey = "repository:query_timeout_parent"
+	// queryTimeoutCancelKey はタイムアウトのコンテキストのキャンセル関数の Statement の設定のキーです。
+	queryTimeoutCancelKey = "repository:query_timeout_cancel"
+)
+
+// QueryTimeouts はクエリのタイムアウトです（0 の場合はタイムアウトなし）。
+type QueryTimeouts struct {
+	Default     time.Duration // 通常のクエリ
+	Maintenance time.Duration // マテリアライズドビューのリフレッシュなど時間のかかる処理（ContextWithMaintenanceQuery）
+}
+
+// maintenanceQueryKey is the context key for marking maintenance queries
+type maintenanceQueryKey struct{}
+
+// ContextWithMaintenanceQuery returns a new context whose queries use the maintenance timeout
+// マテリアライズドビューのリフレッシュ・マイグレーションなど、通常のタイムアウトを超える処理に使用します。
+func ContextWithMaintenanceQuery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, maintenanceQueryKey{}, true)
+}
+
+// RegisterQueryTimeout は各クエリにタイムアウトを設定する GORM のコールバックを登録します。
+//
+// 引数:
+//   - db: 登録先の接続（database.Connect で作成した接続）
+//   - timeouts: 通常・メンテナンスのクエリのタイムアウト
+//
+// 戻り値:
+//   - error: コールバックの登録に失敗した場合のエラー
+func RegisterQueryTimeout(db *gorm.DB, timeouts QueryTimeouts) error {
+	before := func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		timeout := timeouts.Default
+		if maintenance, _ := ctx.Value(maintenanceQueryKey{}).(bool); maintenance {
+			timeout = timeouts.Maintenance
+		}
+		if timeout <= 0 {
+			return
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+			return
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+		db.InstanceSet(queryTimeoutParentKey, ctx)
+		db.InstanceSet(queryTimeoutCancelKey, cancel)
+		db.Statement.Context = timeoutCtx
+	}
+	// after は Statement のコンテキストを戻します（同じ Statement で続けて実行するクエリのため）。
+	// Rows() の結果はコールバックの後に読み取るため、Row のクエリではキャンセルせず、
+	// タイムアウトまたはリクエストのコンテキストの終了で解放します。
+	after := func(release bool) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			parent, ok := db.InstanceGet(queryTimeoutParentKey)
+			if !ok {
+				return
+			}
+			db.Statement.Context = parent.(context.Context)
+			if cancel, ok := db.InstanceGet(queryTimeoutCancelKey); ok && release {
+				cancel.(context.CancelFunc)()
+			}
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("*").Register("repository:query_timeout", before),
+		callbacks.Create().After("*").Register("repository:query_timeout_release", after(true)),
+		callbacks.Query().Before("*").Register("repository:query_timeout", before),
+		callbacks.Query().After("*").Register("repository:query_timeout_release", after(true)),
+		callbacks.Update().Before("*").Register("repository:query_timeout", before),
+		callbacks.Update().After("*").Register("repository:query_timeout_release", after(true)),
+		callbacks.Delete().Before("*").Register("repository:query_timeout", before),
+		callbacks.Delete().After("*").Register("repository:query_timeout_release", after(true)),
+		callbacks.Raw().Before("*").Register("repository:query_timeout", before),
+		callbacks.Raw().After("*").Register("repository:query_timeout_release", after(true)),
+		callbacks.Row().Before("*").Register("repository:query_timeout", before),
+		callbacks.Row().After("*").Register("repository:query_timeout_release", after(false)),
+	)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Query Timeout Tests - クエリごとのタイムアウトのテスト
+// =============================================================================
+// テスト対象:
+//   - RegisterQueryTimeout: クエリのコンテキストの期限、メンテナンスのクエリ、リクエストのコンテキストのキャンセル
+
+// errRecorded は recordingConnPool がクエリを実行せずに返すエラーです。
+var errRecorded = errors.New("recorded")
+
+// recordingConnPool はクエリのコンテキストを記録する gorm.ConnPool です（データベースに接続しない）。
+type recordingConnPool struct {
+	contexts []context.Context
+}
+
+func (p *recordingConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	p.contexts = append(p.contexts, ctx)
+	return nil, errRecorded
+}
+
+func (p *recordingConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.contexts = append(p.contexts, ctx)
+	return nil, errRecorded
+}
+
+func (p *recordingConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	p.contexts = append(p.contexts, ctx)
+	return nil, errRecorded
+}
+
+func (p *recordingConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	p.contexts = append(p.contexts, ctx)
+	return &sql.Row{}
+}
+
+// newTimeoutTestDB は recordingConnPool を使用する接続にタイムアウトのコールバックを登録します。
+func newTimeoutTestDB(t *testing.T, timeouts QueryTimeouts) (*gorm.DB, *recordingConnPool) {
+	t.Helper()
+	pool := &recordingConnPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open failed: %v", err)
+	}
+	if err := RegisterQueryTimeout(db, timeouts); err != nil {
+		t.Fatalf("RegisterQueryTimeout failed: %v", err)
+	}
+	return db, pool
+}
+
+// deadlineIn はコンテキストの期限までの時間を返します（期限がない場合は 0）。
+func deadlineIn(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return time.Until(deadline)
+}
+
+// TestRegisterQueryTimeout はクエリのタイムアウトのテストです。
+// 期待動作:
+//   - 各クエリ（検索・作成・Raw）のコンテキストに通常のタイムアウトの期限を設定する
+//   - ContextWithMaintenanceQuery のクエリはメンテナンスのタイムアウト
+//   - リクエストのコンテキストの期限がタイムアウトより早い場合はその期限のまま
+//   - 実行後は同じ Statement のコンテキストを元に戻し、タイムアウトのコンテキストをキャンセルする
+func TestRegisterQueryTimeout(t *testing.T) {
+	// Arrange
+	db, pool := newTimeoutTestDB(t, QueryTimeouts{Default: time.Minute, Maintenance: time.Hour})
+	ctx := context.Background()
+	shortCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	query := GetDB(ctx, db).Model(&model.Crop{})
+
+	// Act
+	_ = GetDB(ctx, db).First(&model.Crop{}, 1).Error
+	_ = GetDB(ctx, db).Create(&model.Crop{Name: "トマト"}).Error
+	_ = GetDB(ContextWithMaintenanceQuery(ctx), db).Exec("REFRESH MATERIALIZED VIEW mv_harvest_analytics").Error
+	_ = GetDB(shortCtx, db).Find(&[]model.Crop{}).Error
+	_ = query.Find(&[]model.Crop{}).Error
+
+	// Assert
+	if len(pool.contexts) != 5 {
+		t.Fatalf("Expected 5 queries, got %d", len(pool.contexts))
+	}
+	for i, want := range []time.Duration{time.Minute, time.Minute, time.Hour, time.Second} {
+		if got := deadlineIn(pool.contexts[i]); got > want || got < want/2 {
+			t.Errorf("Query %d: expected deadline within %v, got %v", i, want, got)
+		}
+	}
+	if !errors.Is(pool.contexts[0].Err(), context.Canceled) {
+		t.Errorf("Expected the timeout context to be released after the query, got %v", pool.contexts[0].Err())
+	}
+	if query.Statement.Context != ctx {
+		t.Error("Expected the statement context to be restored after the query")
+	}
+}
+
+// TestRegisterQueryTimeout_Disabled はタイムアウトなしの設定のテストです。
+// 期待動作:
+//   - タイムアウトが 0 の場合はリクエストのコンテキストをそのまま使用する（キャンセルは伝わる）
+func TestRegisterQueryTimeout_Disabled(t *testing.T) {
+	// Arrange
+	db, pool := newTimeoutTestDB(t, QueryTimeouts{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	_ = GetDB(ctx, db).First(&model.Crop{}, 1).Error
+	cancel()
+
+	// Assert
+	if len(pool.contexts) != 1 {
+		t.Fatalf("Expected 1 query, got %d", len(pool.contexts))
+	}
+	if _, ok := pool.contexts[0].Deadline(); ok {
+		t.Error("Expected no deadline when the timeout is disabled")
+	}
+	if !errors.Is(pool.contexts[0].Err(), context.Canceled) {
+		t.Errorf("Expected the request cancellation to reach the query, got %v", pool.contexts[0].Err())
+	}
+}
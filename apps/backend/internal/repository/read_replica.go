@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// =============================================================================
+// Read Replica - 分析・エクスポートの読み取りレプリカ
+// =============================================================================
+// 読み取りレプリカを設定した場合、ContextWithReadReplica のコンテキストの読み取りクエリだけをレプリカで実行します。
+// 書き込み・トランザクション内のクエリ・ヒントのないクエリは常にプライマリで実行するため、
+// 通常の API はレプリケーションの遅延の影響を受けません。
+
+// readReplicaResolver は読み取りレプリカの dbresolver の名前です。
+const readReplicaResolver = "read_replica"
+
+// readReplicaKey is the context key for the read replica routing hint
+type readReplicaKey struct{}
+
+// ContextWithReadReplica returns a new context whose read queries go to the read replica
+// 集計・グラフ・エクスポートなど、数秒のレプリケーションの遅延を許容できる重い読み取りに使用します。
+// レプリカを設定していない場合はプライマリで実行します。
+func ContextWithReadReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readReplicaKey{}, true)
+}
+
+// ContextWithPrimary returns a new context whose queries go to the primary even under ContextWithReadReplica
+// 書き込み直後の読み取りなど、レプリカの分析の処理の中で最新のデータが必要なクエリに使用します。
+func ContextWithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readReplicaKey{}, false)
+}
+
+// usesReadReplica はコンテキストのクエリをレプリカで実行するかを返します。
+func usesReadReplica(ctx context.Context) bool {
+	replica, _ := ctx.Value(readReplicaKey{}).(bool)
+	return replica
+}
+
+// RegisterReadReplica は読み取りレプリカの dbresolver を登録します。
+// ContextWithReadReplica の読み取りクエリはレプリカ、書き込みはプライマリ（db の接続）で実行します。
+//
+// 引数:
+//   - db: プライマリの接続（database.Connect で作成した接続）
+//   - replica: レプリカの接続の Dialector
+//
+// 戻り値:
+//   - error: レプリカの接続・登録に失敗した場合のエラー
+func RegisterReadReplica(db *gorm.DB, replica gorm.Dialector) error {
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{replica},
+	}, readReplicaResolver))
+}
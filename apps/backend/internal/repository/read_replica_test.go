@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Read Replica Tests - 読み取りレプリカの振り分けのテスト
+// =============================================================================
+// テスト対象:
+//   - RegisterReadReplica, GetDB: ContextWithReadReplica・ContextWithPrimary のヒントによるクエリの振り分け
+
+// newRecordingDB は recordingConnPool を使用する接続を作成します（データベースに接続しない）。
+func newRecordingDB(t *testing.T) (*gorm.DB, *recordingConnPool) {
+	t.Helper()
+	pool := &recordingConnPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open failed: %v", err)
+	}
+	return db, pool
+}
+
+// TestRegisterReadReplica は読み取りレプリカの振り分けのテストです。
+// 期待動作:
+//   - ContextWithReadReplica の読み取り（検索・SELECT の Raw）はレプリカで実行する
+//   - 書き込み（作成・SELECT 以外の Raw）とヒントのないクエリはプライマリで実行する
+//   - ContextWithPrimary はレプリカのヒントより優先する
+func TestRegisterReadReplica(t *testing.T) {
+	// Arrange
+	db, primary := newRecordingDB(t)
+	replica := &recordingConnPool{}
+	if err := RegisterReadReplica(db, postgres.New(postgres.Config{Conn: replica})); err != nil {
+		t.Fatalf("RegisterReadReplica failed: %v", err)
+	}
+	ctx := context.Background()
+	replicaCtx := ContextWithReadReplica(ctx)
+
+	// Act
+	_ = GetDB(replicaCtx, db).Find(&[]model.Harvest{}).Error
+	_ = GetDB(replicaCtx, db).Raw("SELECT crop_name FROM mv_harvest_analytics").Scan(&[]CropHarvestAnalytics{}).Error
+	_ = GetDB(replicaCtx, db).Create(&model.Crop{Name: "トマト"}).Error
+	_ = GetDB(replicaCtx, db).Exec("REFRESH MATERIALIZED VIEW mv_harvest_analytics").Error
+	_ = GetDB(ctx, db).Find(&[]model.Harvest{}).Error
+	_ = GetDB(ContextWithPrimary(replicaCtx), db).Find(&[]model.Harvest{}).Error
+
+	// Assert
+	if len(replica.contexts) != 2 {
+		t.Errorf("Expected 2 queries on the replica, got %d", len(replica.contexts))
+	}
+	if len(primary.contexts) != 4 {
+		t.Errorf("Expected 4 queries on the primary, got %d", len(primary.contexts))
+	}
+}
+
+// TestGetDB_ReadReplicaNotConfigured はレプリカを設定していない場合のテストです。
+// 期待動作:
+//   - ContextWithReadReplica のクエリもプライマリで実行する
+func TestGetDB_ReadReplicaNotConfigured(t *testing.T) {
+	// Arrange
+	db, primary := newRecordingDB(t)
+
+	// Act
+	_ = GetDB(ContextWithReadReplica(context.Background()), db).Find(&[]model.Harvest{}).Error
+
+	// Assert
+	if len(primary.contexts) != 1 {
+		t.Errorf("Expected the query on the primary, got %d queries", len(primary.contexts))
+	}
+}
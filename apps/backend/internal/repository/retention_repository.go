@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// RetentionRepository Implementation - データ保持期間リポジトリ
+// =============================================================================
+
+// retentionRepository implements RetentionRepository
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// CountSoftDeleted は before より前に論理削除された行の件数を取得します。
+func (r *retentionRepository) CountSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error) {
+	var count int64
+	err := GetDB(ctx, r.db).Table(table).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Count(&count).Error
+	return count, err
+}
+
+// PurgeSoftDeleted は before より前に論理削除された行を物理削除します。
+// 論理削除されていない行から参照されている場合は外部キー制約によりエラーになります。
+func (r *retentionRepository) PurgeSoftDeleted(ctx context.Context, table string, before time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).Exec("DELETE FROM "+table+" WHERE deleted_at IS NOT NULL AND deleted_at < ?", before)
+	return result.RowsAffected, result.Error
+}
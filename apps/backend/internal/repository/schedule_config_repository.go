@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// ScheduleConfigRepository Implementation - スケジュール設定リポジトリ
+// =============================================================================
+
+// scheduleConfigRepository implements ScheduleConfigRepository
+type scheduleConfigRepository struct {
+	db *gorm.DB
+}
+
+// GetAll は全ジョブの設定をジョブ名順に取得します。
+func (r *scheduleConfigRepository) GetAll(ctx context.Context) ([]model.ScheduleConfig, error) {
+	var configs []model.ScheduleConfig
+	if err := GetDB(ctx, r.db).Order("name ASC").Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// GetByName はジョブ名で設定を取得します。
+func (r *scheduleConfigRepository) GetByName(ctx context.Context, name string) (*model.ScheduleConfig, error) {
+	var config model.ScheduleConfig
+	if err := GetDB(ctx, r.db).Where("name = ?", name).First(&config).Error; err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Upsert はジョブ名の設定を作成または更新します。
+// 同時に同じジョブの設定を作成した場合も1行になるよう、ジョブ名の一意制約の競合時は更新します。
+func (r *scheduleConfigRepository) Upsert(ctx context.Context, config *model.ScheduleConfig) error {
+	if config.ID != 0 {
+		return GetDB(ctx, r.db).Save(config).Error
+	}
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"schedule", "enabled", "lookahead_days", "updated_by", "updated_at"}),
+	}).Create(config).Error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// SchedulerCheckpointRepository Implementation - スケジューラーの進捗リポジトリ
+// =============================================================================
+
+// schedulerCheckpointRepository implements SchedulerCheckpointRepository
+type schedulerCheckpointRepository struct {
+	db *gorm.DB
+}
+
+// Get は処理名の進捗を取得します
+func (r *schedulerCheckpointRepository) Get(ctx context.Context, name string) (*model.SchedulerCheckpoint, error) {
+	var checkpoint model.SchedulerCheckpoint
+	if err := GetDB(ctx, r.db).Where("name = ?", name).First(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save は処理名の進捗を作成し、既にある場合は上書きします
+func (r *schedulerCheckpointRepository) Save(ctx context.Context, checkpoint *model.SchedulerCheckpoint) error {
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		UpdateAll: true,
+	}).Create(checkpoint).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// SchedulerInvocationRepository Implementation - スケジューラー冪等キーリポジトリ
+// =============================================================================
+
+// schedulerInvocationRepository implements SchedulerInvocationRepository
+type schedulerInvocationRepository struct {
+	db *gorm.DB
+}
+
+// Reserve は冪等キーを処理中として登録します。
+// 同時に同じ冪等キーのリクエストを受けた場合も1件のみ登録されるよう、一意制約の競合時は何もしません。
+func (r *schedulerInvocationRepository) Reserve(ctx context.Context, invocation *model.SchedulerInvocation) (bool, error) {
+	result := GetDB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(invocation)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByKey はエンドポイントと冪等キーで処理結果を取得します。
+func (r *schedulerInvocationRepository) GetByKey(ctx context.Context, endpoint, key string) (*model.SchedulerInvocation, error) {
+	var invocation model.SchedulerInvocation
+	if err := GetDB(ctx, r.db).
+		Where("endpoint = ? AND idempotency_key = ?", endpoint, key).
+		First(&invocation).Error; err != nil {
+		return nil, err
+	}
+	return &invocation, nil
+}
+
+// Complete は処理結果（ステータスコードとレスポンス）を記録します。
+func (r *schedulerInvocationRepository) Complete(ctx context.Context, id uint, statusCode int, responseBody string, completedAt time.Time) error {
+	return GetDB(ctx, r.db).Model(&model.SchedulerInvocation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"response_body": responseBody,
+		"completed_at":  completedAt,
+	}).Error
+}
+
+// Delete は冪等キーの登録を削除します。
+func (r *schedulerInvocationRepository) Delete(ctx context.Context, id uint) error {
+	return GetDB(ctx, r.db).Delete(&model.SchedulerInvocation{}, id).Error
+}
+
+// DeleteExpired は保持期限を過ぎた冪等キーを削除します。
+func (r *schedulerInvocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := GetDB(ctx, r.db).Where("expires_at < ?", now).Delete(&model.SchedulerInvocation{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SearchRepository Implementation - 横断検索リポジトリ
+// =============================================================================
+// 作物・タスク・区画・成長記録のメモを PostgreSQL の全文検索（to_tsvector / plainto_tsquery）で検索し、
+// 一致しない場合はトライグラム（pg_trgm の word_similarity）と部分一致で検索します。
+// 日本語は単語に分割されないため、全文検索は英数字の単語、トライグラム・部分一致は日本語の検索に使用します。
+// 文書の式は migrations/000001_create_indexes.up.sql の GIN インデックスの式と同じにしてください（インデックスを使用するため）。
+
+// 検索の対象
+const (
+	SearchEntityCrops         = "crops"
+	SearchEntityTasks         = "tasks"
+	SearchEntityPlots         = "plots"
+	SearchEntityGrowthRecords = "growth_records"
+)
+
+// searchSource は検索の対象のテーブルと列です。
+type searchSource struct {
+	table    string // テーブル（成長記録は作物と結合）
+	title    string // タイトルの式
+	document string // 検索する文書の式（GIN インデックスの式と同じ）
+	cropID   string // 成長記録の作物IDの式（成長記録以外は 0）
+	scoped   bool   // 組織のスコープで絞り込むか（タスクはユーザーのみ）
+}
+
+// searchSources は検索の対象ごとのテーブルと列です。
+var searchSources = map[string]searchSource{
+	SearchEntityCrops: {
+		table:    "crops",
+		title:    "crops.name",
+		document: "(coalesce(crops.name, '') || ' ' || coalesce(crops.variety, '') || ' ' || coalesce(crops.notes, ''))",
+		cropID:   "0",
+		scoped:   true,
+	},
+	SearchEntityTasks: {
+		table:    "tasks",
+		title:    "tasks.title",
+		document: "(coalesce(tasks.title, '') || ' ' || coalesce(tasks.description, ''))",
+		cropID:   "0",
+	},
+	SearchEntityPlots: {
+		table:    "plots",
+		title:    "plots.name",
+		document: "(coalesce(plots.name, '') || ' ' || coalesce(plots.notes, ''))",
+		cropID:   "0",
+		scoped:   true,
+	},
+	SearchEntityGrowthRecords: {
+		table:    "growth_records JOIN crops ON crops.id = growth_records.crop_id AND crops.deleted_at IS NULL",
+		title:    "crops.name",
+		document: "coalesce(growth_records.notes, '')",
+		cropID:   "growth_records.crop_id",
+		scoped:   true,
+	},
+}
+
+// searchRepository implements SearchRepository
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// Search は対象の記録を関連度の高い順に limit 件まで検索します。
+// 全文検索で一致した記録の関連度は 1 + ts_rank、トライグラム・部分一致のみの記録は word_similarity（1 未満）です。
+func (r *searchRepository) Search(ctx context.Context, userID uint, entity, query string, limit int) ([]SearchHit, error) {
+	source, ok := searchSources[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown search entity: %s", entity)
+	}
+	table := strings.Fields(source.table)[0]
+	tsQuery := "plainto_tsquery('simple', ?)"
+	tsVector := fmt.Sprintf("to_tsvector('simple', %s)", source.document)
+
+	db := GetDB(ctx, r.db).Table(source.table).
+		Select(fmt.Sprintf(
+			"%s.id AS id, %s AS crop_id, %s AS title, %s AS body, %s.updated_at AS updated_at, "+
+				"CASE WHEN %s @@ %s THEN 1 + ts_rank(%s, %s) ELSE word_similarity(?, %s) END AS rank",
+			table, source.cropID, source.title, source.document, table,
+			tsVector, tsQuery, tsVector, tsQuery, source.document,
+		), query, query, query).
+		Where(table+".deleted_at IS NULL").
+		Where(fmt.Sprintf("(%s @@ %s OR %s ILIKE ? ESCAPE '\\' OR ? <%% %s)", tsVector, tsQuery, source.document, source.document),
+			query, "%"+escapeLike(query)+"%", query)
+	if source.scoped {
+		db = scopeListByOrganization(ctx, db, userID)
+	} else {
+		db = db.Where(table+".user_id = ?", userID)
+	}
+
+	var hits []SearchHit
+	if err := db.Order("rank DESC, updated_at DESC").Limit(limit).Scan(&hits).Error; err != nil {
+		return nil, err
+	}
+	for i := range hits {
+		hits[i].Type = entity
+	}
+	return hits, nil
+}
+
+// escapeLike は LIKE のパターンの特殊文字（%, _, \）をエスケープします。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// SeasonSummaryRepository Implementation - シーズンの記録リポジトリ
+// =============================================================================
+
+// seasonSummaryRepository implements SeasonSummaryRepository
+type seasonSummaryRepository struct {
+	db *gorm.DB
+}
+
+// Upsert はユーザー・シーズン・区画ごとの記録を作成し、既にある場合は集計値を更新します。
+// シーズンの締めを再実行した場合も1行のままになります。
+func (r *seasonSummaryRepository) Upsert(ctx context.Context, summaries []model.SeasonSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "season"}, {Name: "plot_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"plot_name", "area_m2", "crops_grown", "crops_harvested", "crops_failed",
+			"harvest_count", "total_kg", "kg_per_m2", "closed_at", "updated_at",
+		}),
+	}).Create(&summaries).Error
+}
+
+// GetByUserAndSeason はユーザーのシーズンの記録を総収穫量の多い順に取得します。
+func (r *seasonSummaryRepository) GetByUserAndSeason(ctx context.Context, userID uint, season int) ([]model.SeasonSummary, error) {
+	var summaries []model.SeasonSummary
+	if err := GetDB(ctx, r.db).
+		Where("user_id = ? AND season = ?", userID, season).
+		Order("total_kg DESC, plot_id ASC").
+		Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
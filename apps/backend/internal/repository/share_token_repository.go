@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// ShareTokenRepository Implementation - 共有トークンリポジトリ
+// =============================================================================
+
+// shareTokenRepository implements ShareTokenRepository
+type shareTokenRepository struct {
+	db *gorm.DB
+}
+
+// Create は新しい共有トークンを作成します。
+func (r *shareTokenRepository) Create(ctx context.Context, token *model.ShareToken) error {
+	return GetDB(ctx, r.db).Create(token).Error
+}
+
+// GetByID はIDで共有トークンを取得します。
+func (r *shareTokenRepository) GetByID(ctx context.Context, id uint) (*model.ShareToken, error) {
+	var token model.ShareToken
+	if err := GetDB(ctx, r.db).First(&token, id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByTokenHash はトークンのハッシュ（SHA-256）で共有トークンを取得します。
+func (r *shareTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.ShareToken, error) {
+	var shareToken model.ShareToken
+	if err := GetDB(ctx, r.db).Where("token = ?", tokenHash).First(&shareToken).Error; err != nil {
+		return nil, err
+	}
+	return &shareToken, nil
+}
+
+// GetByUserID はユーザーの全共有トークンを取得します。
+func (r *shareTokenRepository) GetByUserID(ctx context.Context, userID uint) ([]model.ShareToken, error) {
+	var tokens []model.ShareToken
+	if err := GetDB(ctx, r.db).Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Update は共有トークンを更新します。
+func (r *shareTokenRepository) Update(ctx context.Context, token *model.ShareToken) error {
+	return GetDB(ctx, r.db).Save(token).Error
+}
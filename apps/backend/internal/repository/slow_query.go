@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Slow Query Log - 遅いクエリの記録
+// =============================================================================
+// GORM のプラグイン（SlowQueryPlugin）で各クエリの実行時間を計測し、閾値を超えたクエリを
+// リクエストのロガー（logging.FromContext）で出力して SlowQueryLog に記録します（直近の記録は GET /admin/database、件数は /metrics の db_slow_queries_total）。
+// SQL はプレースホルダーのまま記録し、パラメータの値（メールアドレスなど）は含めません。
+// リクエストのクエリには呼び出し元のルート（ContextWithRoute、"GET /api/v1/crops/:id" など）を記録します。
+
+const (
+	// DefaultSlowQueryLogSize は SlowQueryLog が保持する直近の遅いクエリの件数です。
+	DefaultSlowQueryLogSize = 50
+
+	// slowQueryStartKey はクエリの開始時刻の Statement の設定のキーです。
+	slowQueryStartKey = "repository:slow_query_start"
+	// slowQueriesMetric は遅いクエリの件数のPrometheusカウンター名
+	slowQueriesMetric = "db_slow_queries_total"
+)
+
+// SlowQuery は閾値を超えたクエリの記録です。
+type SlowQuery struct {
+	SQL        string    `json:"sql"`             // プレースホルダーのままの SQL
+	Route      string    `json:"route,omitempty"` // 呼び出し元のルート（リクエスト以外のクエリは空）
+	DurationMs float64   `json:"duration_ms"`
+	Rows       int64     `json:"rows"`
+	Error      string    `json:"error,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// SlowQueryLog は直近の遅いクエリと件数です（プロセス内で保持）。
+type SlowQueryLog struct {
+	mu     sync.Mutex
+	recent []SlowQuery // リングバッファ（next が最も古い記録の位置）
+	next   int
+	total  uint64
+}
+
+// NewSlowQueryLog は新しいSlowQueryLogを作成します。
+//
+// 引数:
+//   - size: 保持する直近の遅いクエリの件数（0 以下の場合は DefaultSlowQueryLogSize）
+func NewSlowQueryLog(size int) *SlowQueryLog {
+	if size <= 0 {
+		size = DefaultSlowQueryLogSize
+	}
+	return &SlowQueryLog{recent: make([]SlowQuery, 0, size)}
+}
+
+// Record は遅いクエリを記録します（保持する件数を超えた場合は最も古い記録を捨てます）。
+func (l *SlowQueryLog) Record(query SlowQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.total++
+	if len(l.recent) < cap(l.recent) {
+		l.recent = append(l.recent, query)
+		return
+	}
+	l.recent[l.next] = query
+	l.next = (l.next + 1) % len(l.recent)
+}
+
+// Recent は直近の遅いクエリを新しい順に返します。
+func (l *SlowQueryLog) Recent() []SlowQuery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]SlowQuery, 0, len(l.recent))
+	for i := len(l.recent) - 1; i >= 0; i-- {
+		result = append(result, l.recent[(l.next+i)%len(l.recent)])
+	}
+	return result
+}
+
+// Total はプロセス起動時からの遅いクエリの件数を返します。
+func (l *SlowQueryLog) Total() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.total
+}
+
+// WritePrometheus は遅いクエリの件数をPrometheusのテキスト形式で書き出します。
+//
+// 出力例:
+//
+//	# HELP db_slow_queries_total Queries exceeding the slow query threshold.
+//	# TYPE db_slow_queries_total counter
+//	db_slow_queries_total 3
+func (l *SlowQueryLog) WritePrometheus(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s Queries exceeding the slow query threshold.\n# TYPE %s counter\n%s %d\n",
+		slowQueriesMetric, slowQueriesMetric, slowQueriesMetric, l.Total())
+	return err
+}
+
+// routeKey is the context key for the route that issued the query
+type routeKey struct{}
+
+// ContextWithRoute returns a new context whose slow queries are recorded with the route
+// ルートはパスのテンプレート（"GET /api/v1/crops/:id"）を指定し、IDなどの値を含めないでください。
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// RouteFromContext はコンテキストのルートを返します（設定していない場合は空）。
+func RouteFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	route, _ := ctx.Value(routeKey{}).(string)
+	return route
+}
+
+// SlowQueryPlugin は閾値を超えたクエリを記録する GORM のプラグインです。
+type SlowQueryPlugin struct {
+	threshold time.Duration
+	log       *SlowQueryLog
+}
+
+// NewSlowQueryPlugin は新しいSlowQueryPluginを作成します。
+//
+// 引数:
+//   - threshold: 遅いクエリの閾値（実行時間がこの値以上のクエリを記録）
+//   - log: 記録先
+//
+// 戻り値:
+//   - *SlowQueryPlugin: db.Use で登録するプラグイン
+func NewSlowQueryPlugin(threshold time.Duration, log *SlowQueryLog) *SlowQueryPlugin {
+	return &SlowQueryPlugin{threshold: threshold, log: log}
+}
+
+// Name はプラグインの名前を返します（gorm.Plugin）。
+func (p *SlowQueryPlugin) Name() string {
+	return "repository:slow_query"
+}
+
+// Initialize はクエリの実行時間を計測するコールバックを登録します（gorm.Plugin）。
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	before := func(db *gorm.DB) {
+		db.InstanceSet(slowQueryStartKey, time.Now())
+	}
+	// after は Row のクエリでは Rows() を返した時点の実行時間です（結果の読み取りの時間を含まない）。
+	after := func(db *gorm.DB) {
+		start, ok := db.InstanceGet(slowQueryStartKey)
+		if !ok {
+			return
+		}
+		elapsed := time.Since(start.(time.Time))
+		if elapsed < p.threshold {
+			return
+		}
+		query := SlowQuery{
+			SQL:        db.Statement.SQL.String(),
+			Route:      RouteFromContext(db.Statement.Context),
+			DurationMs: float64(elapsed.Microseconds()) / 1000,
+			Rows:       db.Statement.RowsAffected,
+			OccurredAt: time.Now(),
+		}
+		if db.Error != nil {
+			query.Error = db.Error.Error()
+		}
+		p.log.Record(query)
+
+		// リクエストのロガー（request_id・user_id 付き）、リクエスト以外のクエリは slog.Default()
+		logger := logging.FromContext(db.Statement.Context)
+		attrs := []any{"duration_ms", query.DurationMs, "route", query.Route, "rows", query.Rows, "sql", query.SQL}
+		if query.Error != "" {
+			attrs = append(attrs, "error", query.Error)
+		}
+		logger.Warn("Slow query", attrs...)
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Create().After("*").Register("repository:slow_query_log", after),
+		callbacks.Query().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Query().After("*").Register("repository:slow_query_log", after),
+		callbacks.Update().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Update().After("*").Register("repository:slow_query_log", after),
+		callbacks.Delete().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Delete().After("*").Register("repository:slow_query_log", after),
+		callbacks.Raw().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Raw().After("*").Register("repository:slow_query_log", after),
+		callbacks.Row().Before("*").Register("repository:slow_query_start", before),
+		callbacks.Row().After("*").Register("repository:slow_query_log", after),
+	)
+}
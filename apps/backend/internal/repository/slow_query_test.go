@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Slow Query Log Tests - 遅いクエリの記録のテスト
+// =============================================================================
+// テスト対象:
+//   - SlowQueryPlugin: 閾値を超えたクエリの SQL・ルート・エラーの記録
+//   - SlowQueryLog: 直近の記録の保持件数と順序、Prometheusのカウンター
+
+// newSlowQueryTestDB は recordingConnPool を使用する接続に遅いクエリのプラグインを登録します。
+func newSlowQueryTestDB(t *testing.T, threshold time.Duration) (*gorm.DB, *SlowQueryLog) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &recordingConnPool{}}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open failed: %v", err)
+	}
+	slowQueries := NewSlowQueryLog(DefaultSlowQueryLogSize)
+	if err := db.Use(NewSlowQueryPlugin(threshold, slowQueries)); err != nil {
+		t.Fatalf("Use failed: %v", err)
+	}
+	return db, slowQueries
+}
+
+// TestSlowQueryPlugin は遅いクエリの記録のテストです。
+// 期待動作:
+//   - 閾値以上のクエリ（検索・作成・Raw）を記録し、SQL はプレースホルダーのまま（値を含めない）
+//   - ContextWithRoute のルートとクエリのエラーを記録する
+//   - 閾値未満のクエリは記録しない
+func TestSlowQueryPlugin(t *testing.T) {
+	// Arrange
+	db, slowQueries := newSlowQueryTestDB(t, time.Nanosecond)
+	fastDB, fastQueries := newSlowQueryTestDB(t, time.Hour)
+	ctx := ContextWithRoute(context.Background(), "GET /api/v1/users/:id")
+
+	// Act
+	_ = GetDB(ctx, db).Where("email = ?", "secret@example.com").First(&model.User{}).Error
+	_ = GetDB(context.Background(), db).Create(&model.Crop{Name: "トマト"}).Error
+	_ = GetDB(context.Background(), db).Exec("REFRESH MATERIALIZED VIEW mv_harvest_analytics").Error
+	_ = GetDB(ctx, fastDB).First(&model.User{}, 1).Error
+
+	// Assert
+	recent := slowQueries.Recent()
+	if len(recent) != 3 || slowQueries.Total() != 3 {
+		t.Fatalf("Expected 3 slow queries, got %d (total %d)", len(recent), slowQueries.Total())
+	}
+	first := recent[2]
+	if !strings.Contains(first.SQL, "email = $1") || strings.Contains(first.SQL, "secret@example.com") {
+		t.Errorf("Expected the SQL with placeholders, got %q", first.SQL)
+	}
+	if first.Route != "GET /api/v1/users/:id" || first.Error != errRecorded.Error() {
+		t.Errorf("Expected the route and error, got %+v", first)
+	}
+	if recent[0].Route != "" || !strings.Contains(recent[0].SQL, "REFRESH MATERIALIZED VIEW") {
+		t.Errorf("Expected the newest query first without a route, got %+v", recent[0])
+	}
+	if fastQueries.Total() != 0 {
+		t.Errorf("Expected no slow queries below the threshold, got %d", fastQueries.Total())
+	}
+}
+
+// TestSlowQueryLog は直近の遅いクエリの保持のテストです。
+// 期待動作:
+//   - 保持件数を超えた場合は古い記録を捨て、新しい順に返す（件数は累計）
+//   - db_slow_queries_total に累計の件数を書き出す
+func TestSlowQueryLog(t *testing.T) {
+	// Arrange
+	slowQueries := NewSlowQueryLog(3)
+
+	// Act
+	for i := range 5 {
+		slowQueries.Record(SlowQuery{SQL: fmt.Sprintf("SELECT %d", i)})
+	}
+	var buf bytes.Buffer
+	err := slowQueries.WritePrometheus(&buf)
+
+	// Assert
+	var got []string
+	for _, query := range slowQueries.Recent() {
+		got = append(got, query.SQL)
+	}
+	if strings.Join(got, ",") != "SELECT 4,SELECT 3,SELECT 2" {
+		t.Errorf("Expected the 3 newest queries, got %v", got)
+	}
+	if err != nil || !strings.Contains(buf.String(), "db_slow_queries_total 5\n") {
+		t.Errorf("Expected db_slow_queries_total 5, got %q (%v)", buf.String(), err)
+	}
+}
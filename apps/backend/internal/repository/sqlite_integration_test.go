@@ -0,0 +1,819 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/filter"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/seed"
+	"github.com/secure-scorecard/backend/internal/service"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SQLite Integration Tests - SQLite でのリポジトリの統合テスト
+// =============================================================================
+// モックではなく実際のリポジトリを SQLite（DB_DRIVER=sqlite のメモリのデータベース）に対して実行します。
+// テスト対象:
+//   - database.Connect / Setup: SQLite での AutoMigrate とバージョン管理のマイグレーション（インデックス・制約・ビュー）
+//   - TaskRepository: 作成・取得・期限での絞り込み・楽観的ロック・論理削除と復元・期限日順のキーセットページング・filter.Spec の絞り込み
+//   - CropRepository, HarvestRepository: 一括取得と制約のトリガー
+//   - UserRepository: 論理削除したユーザーを除いたメールアドレスの一意制約
+//   - PlotRepository: 配置と作物を含むレイアウトの取得
+//   - PhotoRepository: 作物・収穫記録が削除された写真の取得
+//   - AnalyticsViewRepository: 収穫分析のビューの読み取り
+//   - CreateBatch: タスク・作物・成長記録・収穫記録の一括作成
+//   - WithTransaction: エラーでのロールバック
+//   - AuditPlugin, AuditLogRepository: 作成・更新・削除・復元の変更履歴の記録と取得
+//   - DB.Backup / Restore: 論理バックアップからの別のデータベースへのリストア
+//   - seed: フィクスチャセットの投入（検証済みのフィクスチャがデータベースの制約を満たすこと）
+
+// newSQLiteRepositories はマイグレーションを適用したメモリの SQLite のリポジトリを作成します（テストごとに別のデータベース）。
+func newSQLiteRepositories(t *testing.T) repository.Repositories {
+	t.Helper()
+	db, err := database.Connect(&config.Config{
+		Database: config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, SQLitePath: ":memory:"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Setup(); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	return repository.NewRepositoryManager(db.DB)
+}
+
+// createSQLiteUser はテスト用のユーザーを作成します（記録の外部キーの参照先）。
+func createSQLiteUser(t *testing.T, repos repository.Repositories, email string) *model.User {
+	t.Helper()
+	user := &model.User{Email: email, DisplayName: email}
+	if err := repos.User().Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user
+}
+
+// TestSQLite_Migrations は SQLite のマイグレーションのテストです。
+// 期待動作:
+//   - すべてのバージョンを適用し、dirty でない
+func TestSQLite_Migrations(t *testing.T) {
+	// Arrange
+	db, err := database.Connect(&config.Config{
+		Database: config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, SQLitePath: ":memory:"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer db.Close()
+
+	// Act
+	setupErr := db.Setup()
+	status, statusErr := db.MigrationVersion()
+
+	// Assert
+	if setupErr != nil || statusErr != nil {
+		t.Fatalf("Expected migrations to apply, got %v / %v", setupErr, statusErr)
+	}
+	if status.Version != 6 || status.Dirty {
+		t.Errorf("Expected version 6 (clean), got %+v", status)
+	}
+}
+
+// TestSQLite_TaskRepository はタスクのリポジトリのテストです。
+// 期待動作:
+//   - 作成したタスクを取得でき、version は 1
+//   - 期限による今日・期限切れのタスクの絞り込みが時刻の比較で動作する
+//   - 古いバージョンの更新は ErrVersionConflict で、記録は変わらない
+//   - 論理削除したタスクは取得できず、復元すると再び取得できる
+func TestSQLite_TaskRepository(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "tasks@example.com")
+	dayStart := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	today := &model.Task{UserID: user.ID, Title: "水やり", DueDate: dayStart.Add(9 * time.Hour), Priority: "high"}
+	overdue := &model.Task{UserID: user.ID, Title: "追肥", DueDate: dayStart.Add(-24 * time.Hour)}
+	for _, task := range []*model.Task{today, overdue} {
+		if err := repos.Task().Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	// Act
+	todayTasks, todayErr := repos.Task().GetTodayTasks(ctx, user.ID, dayStart, dayStart.Add(24*time.Hour))
+	overdueTasks, overdueErr := repos.Task().GetOverdueTasks(ctx, user.ID, dayStart)
+	first, _ := repos.Task().GetByID(ctx, today.ID)
+	stale, _ := repos.Task().GetByID(ctx, today.ID)
+	first.Title = "水やり（朝）"
+	updateErr := repos.Task().Update(ctx, first)
+	stale.Title = "水やり（夕）"
+	conflictErr := repos.Task().Update(ctx, stale)
+	current, _ := repos.Task().GetByID(ctx, today.ID)
+	deleteErr := repos.Task().Delete(ctx, overdue.ID)
+	_, deletedErr := repos.Task().GetByID(ctx, overdue.ID)
+	restoreErr := repos.Task().Restore(ctx, overdue.ID)
+	restored, restoredErr := repos.Task().GetByID(ctx, overdue.ID)
+
+	// Assert
+	if todayErr != nil || len(todayTasks) != 1 || todayTasks[0].ID != today.ID {
+		t.Errorf("Expected only today's task, got %d (%v)", len(todayTasks), todayErr)
+	}
+	if overdueErr != nil || len(overdueTasks) != 1 || overdueTasks[0].ID != overdue.ID {
+		t.Errorf("Expected only the overdue task, got %d (%v)", len(overdueTasks), overdueErr)
+	}
+	if updateErr != nil {
+		t.Fatalf("Update failed: %v", updateErr)
+	}
+	if !errors.Is(conflictErr, repository.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict for the stale update, got %v", conflictErr)
+	}
+	if current.Title != "水やり（朝）" || current.Version != 2 {
+		t.Errorf("Expected the first update (version 2) to be kept, got %q (version %d)", current.Title, current.Version)
+	}
+	if deleteErr != nil || !errors.Is(deletedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the deleted task not to be found, got %v / %v", deleteErr, deletedErr)
+	}
+	if restoreErr != nil || restoredErr != nil || restored.Title != "追肥" {
+		t.Errorf("Expected the restored task, got %v / %v", restoreErr, restoredErr)
+	}
+}
+
+// TestSQLite_TaskDueDatePagination はタスクの期限日順のキーセットページングのテストです。
+// 期待動作:
+//   - next_cursor をたどると全てのタスクを (期限日, ID) の昇順に重複・抜けなく返す（期限日が同じタスクはIDの順）
+//   - UTC 以外のタイムゾーンで作成したタスクもカーソルの期限日と正しく比較する
+//   - ステータスで絞り込み、論理削除したタスクを含まない
+func TestSQLite_TaskDueDatePagination(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "due-date@example.com")
+	jst := time.FixedZone("JST", 9*60*60)
+	day := time.Date(2026, 5, 1, 9, 0, 0, 0, jst)
+	dues := []time.Time{day.AddDate(0, 0, 2), day, day.AddDate(0, 0, 1), day, day.AddDate(0, 0, -1)}
+	tasks := make([]model.Task, len(dues))
+	for i, due := range dues {
+		tasks[i] = model.Task{UserID: user.ID, Title: fmt.Sprintf("水やり %d", i+1), DueDate: due}
+	}
+	if err := repos.Task().CreateBatch(ctx, tasks); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	completed := &model.Task{UserID: user.ID, Title: "種まき", DueDate: day, Status: "completed"}
+	deleted := &model.Task{UserID: user.ID, Title: "追肥", DueDate: day}
+	for _, task := range []*model.Task{completed, deleted} {
+		if err := repos.Task().Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	if err := repos.Task().Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	keyOf := func(task model.Task) (time.Time, uint) { return task.DueDate, task.ID }
+
+	// Act
+	var walked []uint
+	var walkErr error
+	for token, pages := "", 0; pages < 10; pages++ {
+		params, err := pagination.NewKeyParams(2, token)
+		if err != nil {
+			walkErr = err
+			break
+		}
+		rows, err := repos.Task().ListByUserIDByDueDate(ctx, user.ID, filter.Spec{}.EqualIfSet("status", "pending"), params)
+		if err != nil {
+			walkErr = err
+			break
+		}
+		page := pagination.NewKeyPage(rows, params, keyOf)
+		for _, task := range page.Items {
+			walked = append(walked, task.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		token = page.NextCursor
+	}
+	all, allErr := repos.Task().ListByUserIDByDueDate(ctx, user.ID, nil, pagination.KeyParams{Limit: 10})
+
+	// Assert
+	want := []uint{tasks[4].ID, tasks[1].ID, tasks[3].ID, tasks[2].ID, tasks[0].ID}
+	if walkErr != nil || fmt.Sprint(walked) != fmt.Sprint(want) {
+		t.Errorf("Expected the pending tasks by (due date, id) %v, got %v (%v)", want, walked, walkErr)
+	}
+	if allErr != nil || len(all) != 6 {
+		t.Errorf("Expected 6 tasks without the deleted one, got %d (%v)", len(all), allErr)
+	}
+}
+
+// TestSQLite_TaskFilter は filter.Spec の絞り込みのテストです。
+// 期待動作:
+//   - 演算子ごとの条件を AND で WHERE に追加する（in、日時の範囲、ne）
+//   - contains は大文字と小文字を区別せず、値の % と _ はワイルドカードではなく文字として扱う
+func TestSQLite_TaskFilter(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repos := newSQLiteRepositories(t)
+	user := createSQLiteUser(t, repos, "filter@example.com")
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []model.Task{
+		{UserID: user.ID, Title: "Water tomatoes", DueDate: day, Priority: "high"},
+		{UserID: user.ID, Title: "液肥 100%", DueDate: day.AddDate(0, 0, 1), Priority: "medium"},
+		{UserID: user.ID, Title: "液肥 1000倍", DueDate: day.AddDate(0, 0, 2), Priority: "low"},
+		{UserID: user.ID, Title: "草取り", DueDate: day.AddDate(0, 0, 3), Priority: "high", Status: "completed"},
+	}
+	if err := repos.Task().CreateBatch(ctx, tasks); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	titles := func(spec filter.Spec) string {
+		rows, err := repos.Task().ListByUserIDPaginated(ctx, user.ID, spec, pagination.Params{Limit: 10})
+		if err != nil {
+			return err.Error()
+		}
+		var got []string
+		for _, task := range rows {
+			got = append(got, task.Title)
+		}
+		return strings.Join(got, ",")
+	}
+	schema := filter.Schema{
+		"title":    {Column: "title", Type: filter.String},
+		"priority": {Column: "priority", Type: filter.String},
+		"due_date": {Column: "due_date", Type: filter.Time},
+	}
+	parse := func(raw ...string) filter.Spec {
+		spec, err := filter.Parse(raw, schema)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		return spec
+	}
+
+	// Act
+	inRange := titles(parse("priority:in:high,low", "due_date:gte:2026-05-02").EqualIfSet("status", "pending"))
+	contains := titles(parse("title:contains:TOMATO"))
+	literal := titles(parse("title:contains:0%"))
+	notHigh := titles(parse("priority:ne:high"))
+
+	// Assert
+	if inRange != "液肥 1000倍" {
+		t.Errorf("Expected the pending low/high task from 2026-05-02, got %q", inRange)
+	}
+	if contains != "Water tomatoes" {
+		t.Errorf("Expected a case-insensitive match, got %q", contains)
+	}
+	if literal != "液肥 100%" {
+		t.Errorf("Expected %% to match literally, got %q", literal)
+	}
+	if notHigh != "液肥 1000倍,液肥 100%" {
+		t.Errorf("Expected the medium and low tasks (newest first), got %q", notHigh)
+	}
+}
+
+// TestSQLite_Constraints は制約のトリガーのテストです。
+// 期待動作:
+//   - PostgreSQL の CHECK 制約と同じ条件を満たさない作成・更新は制約名を含むエラーになる
+//   - 条件を満たす作成・一括取得は成功する
+func TestSQLite_Constraints(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "crops@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	valid := &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+	invalid := &model.Crop{UserID: user.ID, Name: "ナス", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, -1, 0)}
+
+	// Act
+	validErr := repos.Crop().Create(ctx, valid)
+	invalidErr := repos.Crop().Create(ctx, invalid)
+	quantityErr := repos.Harvest().Create(ctx, &model.Harvest{CropID: valid.ID, HarvestDate: planted.AddDate(0, 3, 0), Quantity: 0, QuantityUnit: "kg", Quality: "good"})
+	valid.Status = "unknown"
+	statusErr := repos.Crop().Update(ctx, valid)
+	crops, getErr := repos.Crop().GetByIDs(ctx, []uint{valid.ID, 999})
+
+	// Assert
+	if validErr != nil {
+		t.Fatalf("Expected the valid crop to be created, got %v", validErr)
+	}
+	if invalidErr == nil || !strings.Contains(invalidErr.Error(), "chk_crops_valid_dates") {
+		t.Errorf("Expected chk_crops_valid_dates, got %v", invalidErr)
+	}
+	if quantityErr == nil || !strings.Contains(quantityErr.Error(), "chk_harvests_quantity") {
+		t.Errorf("Expected chk_harvests_quantity, got %v", quantityErr)
+	}
+	if statusErr == nil || !strings.Contains(statusErr.Error(), "chk_crops_status") {
+		t.Errorf("Expected chk_crops_status on update, got %v", statusErr)
+	}
+	if getErr != nil || len(crops) != 1 || crops[0].Status != "planted" {
+		t.Errorf("Expected the stored crop (planted), got %d (%v)", len(crops), getErr)
+	}
+}
+
+// TestSQLite_UserEmailUnique はメールアドレスの一意制約のテストです。
+// 期待動作:
+//   - 論理削除していないユーザーと同じメールアドレスでは作成できない
+//   - 論理削除したユーザーと同じメールアドレスでは作成でき、メールアドレスで新しいユーザーを取得する
+func TestSQLite_UserEmailUnique(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	deleted := &model.User{Email: "reuse@example.com", FirebaseUID: "uid-deleted"}
+	if err := repos.User().Create(ctx, deleted); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	// Act
+	duplicateErr := repos.User().Create(ctx, &model.User{Email: "reuse@example.com", FirebaseUID: "uid-duplicate"})
+	deleteErr := repos.User().Delete(ctx, deleted.ID)
+	reused := &model.User{Email: "reuse@example.com", FirebaseUID: "uid-reused"}
+	reuseErr := repos.User().Create(ctx, reused)
+	byEmail, byEmailErr := repos.User().GetByEmail(ctx, "reuse@example.com")
+
+	// Assert
+	if duplicateErr == nil || !strings.Contains(duplicateErr.Error(), "UNIQUE") {
+		t.Errorf("Expected a unique constraint error for an active user, got %v", duplicateErr)
+	}
+	if deleteErr != nil || reuseErr != nil {
+		t.Fatalf("Expected the email of a deleted user to be reusable, got %v / %v", deleteErr, reuseErr)
+	}
+	if byEmailErr != nil || byEmail.ID != reused.ID {
+		t.Errorf("Expected the new user by email, got %+v (%v)", byEmail, byEmailErr)
+	}
+}
+
+// TestSQLite_PlotLayout は区画のレイアウトの取得のテストです。
+// 期待動作:
+//   - 区画ごとに配置解除されていない配置と作物を含め、配置解除した配置は含まない
+func TestSQLite_PlotLayout(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "plots@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := &model.Crop{UserID: user.ID, Name: "キュウリ", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 2, 0)}
+	if err := repos.Crop().Create(ctx, crop); err != nil {
+		t.Fatalf("Failed to create crop: %v", err)
+	}
+	occupied := &model.Plot{UserID: user.ID, Name: "A区画", Width: 2, Height: 3, SoilType: "loamy", Sunlight: "full_sun", Status: "occupied"}
+	empty := &model.Plot{UserID: user.ID, Name: "B区画", Width: 1, Height: 1, SoilType: "sandy", Sunlight: "shade"}
+	for _, plot := range []*model.Plot{occupied, empty} {
+		if err := repos.Plot().Create(ctx, plot); err != nil {
+			t.Fatalf("Failed to create plot: %v", err)
+		}
+	}
+	unassigned := planted.AddDate(0, 1, 0)
+	for _, assignment := range []*model.PlotAssignment{
+		{PlotID: occupied.ID, CropID: crop.ID, AssignedDate: planted},
+		{PlotID: empty.ID, CropID: crop.ID, AssignedDate: planted, UnassignedDate: &unassigned},
+	} {
+		if err := repos.PlotAssignment().Create(ctx, assignment); err != nil {
+			t.Fatalf("Failed to create assignment: %v", err)
+		}
+	}
+
+	// Act
+	plots, err := repos.Plot().GetLayoutByUserID(ctx, user.ID)
+
+	// Assert
+	if err != nil || len(plots) != 2 {
+		t.Fatalf("Expected 2 plots, got %d (%v)", len(plots), err)
+	}
+	for _, plot := range plots {
+		switch plot.ID {
+		case occupied.ID:
+			if len(plot.PlotAssignments) != 1 || plot.PlotAssignments[0].Crop.Name != "キュウリ" {
+				t.Errorf("Expected the active assignment with its crop, got %+v", plot.PlotAssignments)
+			}
+		case empty.ID:
+			if len(plot.PlotAssignments) != 0 {
+				t.Errorf("Expected no active assignment for the empty plot, got %d", len(plot.PlotAssignments))
+			}
+		}
+	}
+}
+
+// TestSQLite_PhotoOrphans は作物・収穫記録が削除された写真の取得のテストです。
+// 期待動作:
+//   - 論理削除・物理削除した作物、論理削除した収穫記録の写真を返し、作物が残っている写真・作物のない写真は返さない
+//   - GetOrphanedBefore は作物・収穫記録の削除日時（物理削除の場合は写真の作成日時）が before より前の写真のみ返す
+//   - CountOrphanedBefore は GetOrphanedBefore の対象の件数を上限なしで返す
+func TestSQLite_PhotoOrphans(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "photos@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	active := &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+	deleted := &model.Crop{UserID: user.ID, Name: "ナス", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+	for _, crop := range []*model.Crop{active, deleted} {
+		if err := repos.Crop().Create(ctx, crop); err != nil {
+			t.Fatalf("Failed to create crop: %v", err)
+		}
+	}
+	harvest := &model.Harvest{CropID: active.ID, HarvestDate: planted.AddDate(0, 2, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"}
+	if err := repos.Harvest().Create(ctx, harvest); err != nil {
+		t.Fatalf("Failed to create harvest: %v", err)
+	}
+	purgedCropID := uint(9999)
+	newPhoto := func(cropID, harvestID *uint) *model.Photo {
+		photo := &model.Photo{UserID: user.ID, CropID: cropID, HarvestID: harvestID, SourceKey: "uploads/source.jpg", Status: "completed"}
+		if err := repos.Photo().Create(ctx, photo); err != nil {
+			t.Fatalf("Failed to create photo: %v", err)
+		}
+		return photo
+	}
+	_ = newPhoto(&active.ID, nil)
+	deletedCropPhoto := newPhoto(&deleted.ID, nil)
+	harvestPhoto := newPhoto(nil, &harvest.ID)
+	purgedPhoto := newPhoto(&purgedCropID, nil)
+	_ = newPhoto(nil, nil)
+	if err := repos.Crop().Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Failed to delete crop: %v", err)
+	}
+	if err := repos.Harvest().Delete(ctx, harvest.ID); err != nil {
+		t.Fatalf("Failed to delete harvest: %v", err)
+	}
+
+	// Act
+	orphaned, err := repos.Photo().GetOrphanedByUserID(ctx, user.ID)
+	expired, expiredErr := repos.Photo().GetOrphanedBefore(ctx, time.Now().Add(time.Hour), 0)
+	limited, limitedErr := repos.Photo().GetOrphanedBefore(ctx, time.Now().Add(time.Hour), 2)
+	inGrace, inGraceErr := repos.Photo().GetOrphanedBefore(ctx, time.Now().Add(-time.Hour), 0)
+	count, countErr := repos.Photo().CountOrphanedBefore(ctx, time.Now().Add(time.Hour))
+
+	// Assert
+	if err != nil || expiredErr != nil || limitedErr != nil || inGraceErr != nil || countErr != nil {
+		t.Fatalf("Unexpected errors: %v / %v / %v / %v / %v", err, expiredErr, limitedErr, inGraceErr, countErr)
+	}
+	want := []uint{deletedCropPhoto.ID, harvestPhoto.ID, purgedPhoto.ID}
+	for name, photos := range map[string][]model.Photo{"GetOrphanedByUserID": orphaned, "GetOrphanedBefore": expired} {
+		ids := make([]uint, 0, len(photos))
+		for _, photo := range photos {
+			ids = append(ids, photo.ID)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("%s: expected photos %v, got %v", name, want, ids)
+		}
+	}
+	if len(limited) != 2 {
+		t.Errorf("Expected 2 photos with the limit, got %d", len(limited))
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 orphaned photos counted, got %d", count)
+	}
+	if len(inGrace) != 0 {
+		t.Errorf("Expected no photos within the grace period, got %d", len(inGrace))
+	}
+}
+
+// TestSQLite_CropHarvestAnalytics は収穫分析のビューのテストです。
+// 期待動作:
+//   - ビューは常に最新の集計を返し（リフレッシュは何もしない）、収穫量・回数・初収穫までの日数を集計する
+func TestSQLite_CropHarvestAnalytics(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "analytics@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crop := &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+	if err := repos.Crop().Create(ctx, crop); err != nil {
+		t.Fatalf("Failed to create crop: %v", err)
+	}
+	for _, days := range []int{60, 70} {
+		harvest := &model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 0, days), Quantity: 1.5, QuantityUnit: "kg", Quality: "excellent"}
+		if err := repos.Harvest().Create(ctx, harvest); err != nil {
+			t.Fatalf("Failed to create harvest: %v", err)
+		}
+	}
+
+	// Act
+	refreshErr := repos.AnalyticsView().Refresh(ctx, "mv_harvest_analytics")
+	rows, err := repos.AnalyticsView().GetCropHarvestAnalytics(ctx, user.ID, "とまと")
+	matched, matchedErr := repos.AnalyticsView().GetCropHarvestAnalytics(ctx, user.ID, "トマト")
+
+	// Assert
+	if refreshErr != nil {
+		t.Errorf("Expected refresh to be a no-op, got %v", refreshErr)
+	}
+	if err != nil || len(rows) != 0 {
+		t.Errorf("Expected no rows for another crop name, got %d (%v)", len(rows), err)
+	}
+	if matchedErr != nil || len(matched) != 1 {
+		t.Fatalf("Expected 1 row, got %d (%v)", len(matched), matchedErr)
+	}
+	row := matched[0]
+	if row.TotalQuantity != 3 || row.HarvestCount != 2 || row.QuantityUnit != "kg" {
+		t.Errorf("Expected 3kg over 2 harvests, got %+v", row)
+	}
+	if row.DaysToFirstHarvest == nil || *row.DaysToFirstHarvest != 60 {
+		t.Errorf("Expected 60 days to the first harvest, got %v", row.DaysToFirstHarvest)
+	}
+	if row.AvgQualityScore == nil || *row.AvgQualityScore != 4 {
+		t.Errorf("Expected an average quality score of 4, got %v", row.AvgQualityScore)
+	}
+}
+
+// TestSQLite_WithTransaction はトランザクションのテストです。
+// 期待動作:
+//   - fn がエラーを返した場合はトランザクション内の作成をロールバックする
+func TestSQLite_WithTransaction(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "tx@example.com")
+	errAbort := errors.New("abort")
+
+	// Act
+	err := repos.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.Task().Create(ctx, &model.Task{UserID: user.ID, Title: "ロールバック", DueDate: time.Now()}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	tasks, listErr := repos.Task().GetByUserID(ctx, user.ID)
+
+	// Assert
+	if !errors.Is(err, errAbort) {
+		t.Errorf("Expected the fn error, got %v", err)
+	}
+	if listErr != nil || len(tasks) != 0 {
+		t.Errorf("Expected the task to be rolled back, got %d (%v)", len(tasks), listErr)
+	}
+}
+
+// TestSQLite_AuditLog は変更履歴のテストです。
+// 期待動作:
+//   - 作成は全列の変更後、更新は変更した列の変更前・変更後（updated_at を除く）、削除は変更前を記録する
+//   - 論理削除した記録の復元は restore として記録する
+//   - context の変更したユーザー・ルートを記録し、設定していない変更の変更したユーザーは NULL
+//   - 収穫記録の所有者は作物の所有者
+//   - 競合した更新とロールバックした変更は記録しない
+//   - ownerID を指定した場合は所有者の変更履歴のみ返す
+func TestSQLite_AuditLog(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	user := createSQLiteUser(t, repos, "audit@example.com")
+	ctx := repository.ContextWithRoute(repository.ContextWithActor(context.Background(), user.ID), "PUT /api/v1/tasks/:id")
+	task := &model.Task{UserID: user.ID, Title: "水やり", DueDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	if err := repos.Task().Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	crop := &model.Crop{UserID: user.ID, Name: "トマト", PlantedDate: task.DueDate, ExpectedHarvestDate: task.DueDate.AddDate(0, 3, 0)}
+	if err := repos.Crop().Create(ctx, crop); err != nil {
+		t.Fatalf("Failed to create crop: %v", err)
+	}
+	logs := func(entity string, id, ownerID uint) []model.AuditLog {
+		t.Helper()
+		found, err := repos.AuditLog().ListByEntity(context.Background(), entity, id, ownerID, pagination.Params{}.Normalize())
+		if err != nil {
+			t.Fatalf("ListByEntity failed: %v", err)
+		}
+		return found
+	}
+
+	// Act
+	stale, _ := repos.Task().GetByID(ctx, task.ID)
+	current, _ := repos.Task().GetByID(ctx, task.ID)
+	current.Title = "水やり（朝）"
+	updateErr := repos.Task().Update(ctx, current)
+	stale.Title = "水やり（夕）"
+	conflictErr := repos.Task().Update(ctx, stale)
+	deleteErr := repos.Task().Delete(context.Background(), task.ID)
+	restoreErr := repos.Task().Restore(ctx, task.ID)
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: task.DueDate.AddDate(0, 3, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"}
+	harvestErr := repos.Harvest().Create(context.Background(), harvest)
+	rollbackErr := repos.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.Crop().Delete(ctx, crop.ID); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	taskLogs := logs("tasks", task.ID, user.ID)
+	harvestLogs := logs("harvests", harvest.ID, 0)
+	cropLogs := logs("crops", crop.ID, 0)
+	otherOwner := logs("tasks", task.ID, user.ID+1)
+
+	// Assert
+	if updateErr != nil || deleteErr != nil || restoreErr != nil || harvestErr != nil {
+		t.Fatalf("Changes failed: %v / %v / %v / %v", updateErr, deleteErr, restoreErr, harvestErr)
+	}
+	if !errors.Is(conflictErr, repository.ErrVersionConflict) || rollbackErr == nil {
+		t.Fatalf("Expected the conflict and the rollback, got %v / %v", conflictErr, rollbackErr)
+	}
+	var actions []string
+	for _, entry := range taskLogs {
+		actions = append(actions, entry.Action)
+	}
+	if got := strings.Join(actions, ","); got != "restore,delete,update,create" {
+		t.Fatalf("Expected restore, delete, update and create (newest first), got %s", got)
+	}
+	restored, deleted, updated, created := taskLogs[0], taskLogs[1], taskLogs[2], taskLogs[3]
+	if created.Changes["title"].After != "水やり" || created.Changes["title"].Before != nil {
+		t.Errorf("Expected the created title, got %+v", created.Changes["title"])
+	}
+	if created.ActorID == nil || *created.ActorID != user.ID || created.OwnerID != user.ID || created.Route != "PUT /api/v1/tasks/:id" {
+		t.Errorf("Expected the actor, owner and route, got %v / %d / %q", created.ActorID, created.OwnerID, created.Route)
+	}
+	if _, ok := updated.Changes["updated_at"]; ok || updated.Changes["title"].Before != "水やり" || updated.Changes["title"].After != "水やり（朝）" {
+		t.Errorf("Expected only the changed columns, got %+v", updated.Changes)
+	}
+	if deleted.ActorID != nil || deleted.Changes["title"].Before != "水やり（朝）" {
+		t.Errorf("Expected the deleted row without an actor, got %v / %+v", deleted.ActorID, deleted.Changes["title"])
+	}
+	if _, ok := restored.Changes["deleted_at"]; !ok {
+		t.Errorf("Expected the restore to change deleted_at, got %+v", restored.Changes)
+	}
+	if len(harvestLogs) != 1 || harvestLogs[0].OwnerID != user.ID {
+		t.Errorf("Expected the harvest owned by the crop owner, got %+v", harvestLogs)
+	}
+	if len(cropLogs) != 1 || cropLogs[0].Action != model.AuditActionCreate {
+		t.Errorf("Expected the rolled back delete not to be logged, got %+v", cropLogs)
+	}
+	if len(otherOwner) != 0 {
+		t.Errorf("Expected no logs for another owner, got %d", len(otherOwner))
+	}
+}
+
+// TestSQLite_BackupRestore は論理バックアップとリストアのテストです。
+// 期待動作:
+//   - バックアップを別のデータベースにリストアすると、論理削除した行を含めて同じ記録を取得できる
+//   - リストアはリストア先の既存の行を置き換え、リストア後に作成した記録のIDはリストアした行のIDより後
+//   - 読み取れないファイルは ErrInvalidBackup で、リストア先の記録は変わらない
+func TestSQLite_BackupRestore(t *testing.T) {
+	// Arrange
+	connect := func() *database.DB {
+		t.Helper()
+		db, err := database.Connect(&config.Config{
+			Database: config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, SQLitePath: ":memory:"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		if err := db.Setup(); err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+		return db
+	}
+	ctx := context.Background()
+	source, target := connect(), connect()
+	sourceRepos, targetRepos := repository.NewRepositoryManager(source.DB), repository.NewRepositoryManager(target.DB)
+	user := createSQLiteUser(t, sourceRepos, "backup@example.com")
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	kept := &model.Task{UserID: user.ID, Title: "水やり", DueDate: due, Priority: "high"}
+	deleted := &model.Task{UserID: user.ID, Title: "追肥", DueDate: due.AddDate(0, 0, 1)}
+	for _, task := range []*model.Task{kept, deleted} {
+		if err := sourceRepos.Task().Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+	if err := sourceRepos.Task().Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	createSQLiteUser(t, targetRepos, "replaced@example.com")
+
+	// Act
+	var backup bytes.Buffer
+	backupStats, backupErr := source.Backup(ctx, &backup)
+	_, invalidErr := target.Restore(ctx, strings.NewReader("not a backup"))
+	restoreStats, restoreErr := target.Restore(ctx, bytes.NewReader(backup.Bytes()))
+	restoredUser, userErr := targetRepos.User().GetByEmail(ctx, "backup@example.com")
+	_, replacedErr := targetRepos.User().GetByEmail(ctx, "replaced@example.com")
+	restored, restoredErr := targetRepos.Task().GetByID(ctx, kept.ID)
+	restoreDeletedErr := targetRepos.Task().Restore(ctx, deleted.ID)
+	created := &model.Task{UserID: user.ID, Title: "収穫", DueDate: due}
+	createErr := targetRepos.Task().Create(ctx, created)
+
+	// Assert
+	if backupErr != nil || restoreErr != nil {
+		t.Fatalf("Backup / Restore failed: %v / %v", backupErr, restoreErr)
+	}
+	if backupStats.TableRows["tasks"] != 2 || restoreStats.Rows != backupStats.Rows || restoreStats.MigrationVersion != 6 {
+		t.Errorf("Expected the same rows to be restored, got %+v / %+v", backupStats, restoreStats)
+	}
+	if !errors.Is(invalidErr, database.ErrInvalidBackup) {
+		t.Errorf("Expected ErrInvalidBackup, got %v", invalidErr)
+	}
+	if userErr != nil || restoredUser.ID != user.ID || !errors.Is(replacedErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected the users to be replaced, got %v / %v", userErr, replacedErr)
+	}
+	if restoredErr != nil || restored.Title != "水やり" || restored.Priority != "high" || !restored.DueDate.Equal(due) || restored.Version != 1 {
+		t.Errorf("Expected the restored task, got %+v (%v)", restored, restoredErr)
+	}
+	if restoreDeletedErr != nil {
+		t.Errorf("Expected the soft-deleted task to be restored, got %v", restoreDeletedErr)
+	}
+	if createErr != nil || created.ID <= deleted.ID {
+		t.Errorf("Expected a new ID after the restored rows, got %d (%v)", created.ID, createErr)
+	}
+}
+
+// TestSQLite_CreateBatch は記録の一括作成のテストです。
+// 期待動作:
+//   - 作成した記録のIDを各要素に設定し、デフォルト値（ステータス・バージョン）を設定する
+//   - 複数のバッチになる場合も1件でも失敗した場合はすべて作成しない
+//   - 空の場合は何もしない
+func TestSQLite_CreateBatch(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "batch@example.com")
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	crops := []model.Crop{
+		{UserID: user.ID, Name: "トマト", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)},
+		{UserID: user.ID, Name: "ナス", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)},
+	}
+	newTasks := func(n int) []model.Task {
+		tasks := make([]model.Task, n)
+		for i := range tasks {
+			tasks[i] = model.Task{UserID: user.ID, Title: fmt.Sprintf("水やり %d", i+1), DueDate: planted.AddDate(0, 0, i)}
+		}
+		return tasks
+	}
+	// 2つ目のバッチの最後のタスクが制約違反
+	invalid := newTasks(501)
+	invalid[500].Priority = "urgent"
+	valid := newTasks(501)
+
+	// Act
+	cropsErr := repos.Crop().CreateBatch(ctx, crops)
+	recordsErr := repos.GrowthRecord().CreateBatch(ctx, []model.GrowthRecord{
+		{CropID: crops[0].ID, RecordDate: planted.AddDate(0, 0, 7), GrowthStage: "seedling"},
+		{CropID: crops[0].ID, RecordDate: planted.AddDate(0, 1, 0), GrowthStage: "flowering"},
+	})
+	harvestsErr := repos.Harvest().CreateBatch(ctx, []model.Harvest{
+		{CropID: crops[1].ID, HarvestDate: planted.AddDate(0, 3, 0), Quantity: 1, QuantityUnit: "kg", Quality: "good"},
+	})
+	invalidErr := repos.Task().CreateBatch(ctx, invalid)
+	afterInvalid, _ := repos.Task().GetByUserID(ctx, user.ID)
+	validErr := repos.Task().CreateBatch(ctx, valid)
+	afterValid, _ := repos.Task().GetByUserID(ctx, user.ID)
+	stored, storedErr := repos.Task().GetByID(ctx, valid[500].ID)
+	emptyErr := repos.Task().CreateBatch(ctx, nil)
+	records, _ := repos.GrowthRecord().GetByCropID(ctx, crops[0].ID)
+	harvests, _ := repos.Harvest().GetByCropID(ctx, crops[1].ID)
+	gotCrop, cropErr := repos.Crop().GetByID(ctx, crops[1].ID)
+
+	// Assert
+	if cropsErr != nil || recordsErr != nil || harvestsErr != nil {
+		t.Fatalf("CreateBatch failed: %v / %v / %v", cropsErr, recordsErr, harvestsErr)
+	}
+	if crops[0].ID == 0 || crops[1].ID == 0 || cropErr != nil || gotCrop.Name != "ナス" || gotCrop.Status != "planted" {
+		t.Errorf("Expected the crops with IDs and the default status, got %d / %d (%v)", crops[0].ID, crops[1].ID, cropErr)
+	}
+	if len(records) != 2 || len(harvests) != 1 {
+		t.Errorf("Expected 2 growth records and 1 harvest, got %d / %d", len(records), len(harvests))
+	}
+	if invalidErr == nil || len(afterInvalid) != 0 {
+		t.Errorf("Expected no tasks after the failed batch, got %d (%v)", len(afterInvalid), invalidErr)
+	}
+	if validErr != nil || len(afterValid) != 501 {
+		t.Errorf("Expected 501 tasks, got %d (%v)", len(afterValid), validErr)
+	}
+	if storedErr != nil || stored.Title != "水やり 501" || stored.Status != "pending" || stored.Version != 1 {
+		t.Errorf("Expected the last task with its ID and defaults, got %+v (%v)", stored, storedErr)
+	}
+	if emptyErr != nil {
+		t.Errorf("Expected no error for an empty batch, got %v", emptyErr)
+	}
+}
+
+// TestSQLite_SeedSets はフィクスチャセットの投入のテストです。
+// 期待動作:
+//   - demo・loadtest のフィクスチャがデータベースの制約（CHECK・トリガー）に違反せずに作成できる
+//   - loadtest は指定した件数の収穫記録を作成する
+func TestSQLite_SeedSets(t *testing.T) {
+	// Arrange
+	repos := newSQLiteRepositories(t)
+	svc := service.NewService(repos)
+	ctx := context.Background()
+	user := createSQLiteUser(t, repos, "seed@example.com")
+
+	for _, set := range seed.Sets() {
+		// Act
+		summary, err := set.Load(ctx, svc, seed.Options{UserID: user.ID, Harvests: 1200})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Load %s failed after %s: %v", set.Name, summary, err)
+		}
+	}
+	crops, err := repos.Crop().GetByUserID(ctx, user.ID)
+	if err != nil || len(crops) != 3+3 {
+		t.Errorf("Expected 3 demo and 3 load test crops, got %d (%v)", len(crops), err)
+	}
+	harvests, err := repos.Harvest().GetByUserIDWithDateRange(ctx, user.ID, nil, nil)
+	if err != nil || len(harvests) != 4+1200 {
+		t.Errorf("Expected 1204 harvests, got %d (%v)", len(harvests), err)
+	}
+}
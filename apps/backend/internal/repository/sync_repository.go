@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SyncRepository Implementation - オフライン同期リポジトリ
+// =============================================================================
+
+// syncRepository implements SyncRepository
+type syncRepository struct {
+	db *gorm.DB
+}
+
+// changedSince はユーザーの記録のうち、期間内に作成・更新されたものを更新日時の順に取得します。
+func changedSince[T any](db *gorm.DB, userID uint, since, until time.Time) ([]T, error) {
+	var rows []T
+	err := db.Where("user_id = ? AND updated_at > ? AND updated_at <= ?", userID, since, until).
+		Order("updated_at ASC, id ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+// ChangedTasks は期間内に作成・更新されたユーザーのタスクを取得します。
+func (r *syncRepository) ChangedTasks(ctx context.Context, userID uint, since, until time.Time) ([]model.Task, error) {
+	return changedSince[model.Task](GetDB(ctx, r.db), userID, since, until)
+}
+
+// ChangedCrops は期間内に作成・更新されたユーザーの作物を取得します。
+func (r *syncRepository) ChangedCrops(ctx context.Context, userID uint, since, until time.Time) ([]model.Crop, error) {
+	return changedSince[model.Crop](GetDB(ctx, r.db), userID, since, until)
+}
+
+// ChangedPlots は期間内に作成・更新されたユーザーの区画を取得します。
+func (r *syncRepository) ChangedPlots(ctx context.Context, userID uint, since, until time.Time) ([]model.Plot, error) {
+	return changedSince[model.Plot](GetDB(ctx, r.db), userID, since, until)
+}
+
+// ChangedHarvests は期間内に作成・更新されたユーザーの作物の収穫記録を取得します。
+func (r *syncRepository) ChangedHarvests(ctx context.Context, userID uint, since, until time.Time) ([]model.Harvest, error) {
+	var harvests []model.Harvest
+	err := GetDB(ctx, r.db).
+		Joins("JOIN crops ON crops.id = harvests.crop_id AND crops.deleted_at IS NULL").
+		Where("crops.user_id = ? AND harvests.updated_at > ? AND harvests.updated_at <= ?", userID, since, until).
+		Order("harvests.updated_at ASC, harvests.id ASC").
+		Find(&harvests).Error
+	return harvests, err
+}
+
+// CreateTombstones は削除の記録を作成します。
+func (r *syncRepository) CreateTombstones(ctx context.Context, tombstones []model.SyncTombstone) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).Create(&tombstones).Error
+}
+
+// GetTombstones は期間内のユーザーの削除の記録を取得します。
+func (r *syncRepository) GetTombstones(ctx context.Context, userID uint, since, until time.Time) ([]model.SyncTombstone, error) {
+	var tombstones []model.SyncTombstone
+	err := GetDB(ctx, r.db).
+		Where("user_id = ? AND deleted_at > ? AND deleted_at <= ?", userID, since, until).
+		Order("deleted_at ASC, id ASC").
+		Find(&tombstones).Error
+	return tombstones, err
+}
+
+// GetTombstone は記録の最新の削除の記録を取得します。
+func (r *syncRepository) GetTombstone(ctx context.Context, userID uint, entity string, entityID uint) (*model.SyncTombstone, error) {
+	var tombstone model.SyncTombstone
+	err := GetDB(ctx, r.db).
+		Where("user_id = ? AND entity = ? AND entity_id = ?", userID, entity, entityID).
+		Order("deleted_at DESC").
+		First(&tombstone).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tombstone, nil
+}
+
+// DeleteTombstones は記録の削除の記録を削除します（復元した記録を同期で削除として返さないため）。
+func (r *syncRepository) DeleteTombstones(ctx context.Context, userID uint, entity string, entityIDs []uint) error {
+	if len(entityIDs) == 0 {
+		return nil
+	}
+	return GetDB(ctx, r.db).
+		Where("user_id = ? AND entity = ? AND entity_id IN ?", userID, entity, entityIDs).
+		Delete(&model.SyncTombstone{}).Error
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/filter"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +20,11 @@ func (r *taskRepository) Create(ctx context.Context, task *model.Task) error {
 	return GetDB(ctx, r.db).Create(task).Error
 }
 
+// CreateBatch creates multiple tasks with batched inserts
+func (r *taskRepository) CreateBatch(ctx context.Context, tasks []model.Task) error {
+	return createInBatches(ctx, r.db, tasks)
+}
+
 // GetByID retrieves a task by ID
 func (r *taskRepository) GetByID(ctx context.Context, id uint) (*model.Task, error) {
 	var task model.Task
@@ -45,63 +52,70 @@ func (r *taskRepository) GetByUserIDAndStatus(ctx context.Context, userID uint,
 	return tasks, nil
 }
 
-// GetTodayTasks retrieves tasks due today for a user
-func (r *taskRepository) GetTodayTasks(ctx context.Context, userID uint) ([]model.Task, error) {
+// ListByUserIDPaginated retrieves one page of tasks for a user (newest first, filtered by spec)
+func (r *taskRepository) ListByUserIDPaginated(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) ([]model.Task, error) {
+	query := GetDB(ctx, r.db).Where("user_id = ?", userID)
+	query = applyFilter(query, spec)
 	var tasks []model.Task
-	today := time.Now().Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
+	if err := paginateByID(query, params).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
 
-	if err := GetDB(ctx, r.db).
-		Where("user_id = ? AND status = ? AND due_date >= ? AND due_date < ?",
-			userID, "pending", today, tomorrow).
-		Order("priority DESC, due_date ASC").
-		Find(&tasks).Error; err != nil {
+// ListByUserIDByDueDate retrieves one page of tasks for a user ordered by due date (filtered by spec)
+func (r *taskRepository) ListByUserIDByDueDate(ctx context.Context, userID uint, spec filter.Spec, params pagination.KeyParams) ([]model.Task, error) {
+	query := GetDB(ctx, r.db).Where("user_id = ?", userID)
+	query = applyFilter(query, spec)
+	var tasks []model.Task
+	if err := paginateByKey(query, "due_date", params).Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// GetOverdueTasks retrieves overdue tasks for a user
-func (r *taskRepository) GetOverdueTasks(ctx context.Context, userID uint) ([]model.Task, error) {
+// GetTodayTasks retrieves pending tasks due within [dayStart, dayEnd)
+// 日の境界はサービス層でユーザーのタイムゾーンから計算して渡します
+func (r *taskRepository) GetTodayTasks(ctx context.Context, userID uint, dayStart, dayEnd time.Time) ([]model.Task, error) {
 	var tasks []model.Task
-	today := time.Now().Truncate(24 * time.Hour)
 
 	if err := GetDB(ctx, r.db).
-		Where("user_id = ? AND status = ? AND due_date < ?",
-			userID, "pending", today).
-		Order("due_date ASC").
+		Where("user_id = ? AND status = ? AND due_date >= ? AND due_date < ?",
+			userID, "pending", dayStart, dayEnd).
+		Order("priority DESC, due_date ASC").
 		Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// GetAllOverdueTasks はシステム全体の期限切れタスクを取得します（通知処理用）
-// ユーザー情報を含めて取得し、通知対象の判定に使用します
-func (r *taskRepository) GetAllOverdueTasks(ctx context.Context) ([]model.Task, error) {
+// GetOverdueTasks retrieves pending tasks due before dayStart for a user
+func (r *taskRepository) GetOverdueTasks(ctx context.Context, userID uint, dayStart time.Time) ([]model.Task, error) {
 	var tasks []model.Task
-	today := time.Now().Truncate(24 * time.Hour)
 
 	if err := GetDB(ctx, r.db).
-		Preload("User").
-		Where("status = ? AND due_date < ?", "pending", today).
-		Order("user_id ASC, due_date ASC").
+		Where("user_id = ? AND status = ? AND due_date < ?",
+			userID, "pending", dayStart).
+		Order("due_date ASC").
 		Find(&tasks).Error; err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// GetAllTodayTasks はシステム全体の今日が期限のタスクを取得します（通知処理用）
-// ユーザー情報を含めて取得し、リマインダー通知に使用します
-func (r *taskRepository) GetAllTodayTasks(ctx context.Context) ([]model.Task, error) {
+// GetPendingTasksDueBefore は指定したユーザーの期限が before より前の未完了タスクを取得します（通知処理用）
+// ユーザー情報（通知設定マトリクスを含む）を含めて取得し、ユーザーごとのタイムゾーンで「期限切れ」「今日」を判定するために使用します
+// 定期通知はユーザーをページごとに処理するため、1回に取得する量はページのユーザー数で制限されます
+func (r *taskRepository) GetPendingTasksDueBefore(ctx context.Context, userIDs []uint, before time.Time) ([]model.Task, error) {
 	var tasks []model.Task
-	today := time.Now().Truncate(24 * time.Hour)
-	tomorrow := today.Add(24 * time.Hour)
+	if len(userIDs) == 0 {
+		return tasks, nil
+	}
 
 	if err := GetDB(ctx, r.db).
 		Preload("User").
-		Where("status = ? AND due_date >= ? AND due_date < ?", "pending", today, tomorrow).
+		Preload("User.NotificationPreferences").
+		Where("user_id IN ? AND status = ? AND due_date < ?", userIDs, "pending", before).
 		Order("user_id ASC, priority DESC, due_date ASC").
 		Find(&tasks).Error; err != nil {
 		return nil, err
@@ -109,12 +123,40 @@ func (r *taskRepository) GetAllTodayTasks(ctx context.Context) ([]model.Task, er
 	return tasks, nil
 }
 
-// Update updates a task
+// Update updates a task if its version is unchanged (optimistic lock, ErrVersionConflict otherwise)
 func (r *taskRepository) Update(ctx context.Context, task *model.Task) error {
-	return GetDB(ctx, r.db).Save(task).Error
+	return updateVersioned(GetDB(ctx, r.db), task, &task.Version)
 }
 
 // Delete soft deletes a task
 func (r *taskRepository) Delete(ctx context.Context, id uint) error {
 	return GetDB(ctx, r.db).Delete(&model.Task{}, id).Error
 }
+
+// GetDeletedByUserID retrieves the user's tasks soft deleted after deletedAfter (most recently deleted first)
+func (r *taskRepository) GetDeletedByUserID(ctx context.Context, userID uint, deletedAfter time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	if err := unscopedDeleted(GetDB(ctx, r.db), "deleted_at").
+		Where("user_id = ? AND deleted_at > ?", userID, deletedAfter).
+		Order("deleted_at DESC").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetDeletedByID retrieves a soft deleted task by ID
+func (r *taskRepository) GetDeletedByID(ctx context.Context, id uint) (*model.Task, error) {
+	var task model.Task
+	if err := unscopedDeleted(GetDB(ctx, r.db), "deleted_at").First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Restore restores a soft deleted task
+func (r *taskRepository) Restore(ctx context.Context, id uint) error {
+	return unscopedDeleted(GetDB(ctx, r.db), "deleted_at").Model(&model.Task{}).
+		Where("id = ?", id).
+		Updates(restoreColumns()).Error
+}
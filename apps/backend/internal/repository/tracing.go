@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Query Tracing - クエリのスパン
+// =============================================================================
+// GORM のプラグイン（TracingPlugin）で各クエリを OpenTelemetry のスパンとして記録します。
+// スパンはクエリのコンテキスト（GetDB(ctx, db) で設定したリクエスト・ジョブのコンテキスト）を親にし、
+// 遅いリクエストのトレースでどのクエリに時間がかかったかを確認できます。
+// SQL は遅いクエリの記録と同じくプレースホルダーのまま記録し、パラメータの値は含めません。
+// レコードが見つからないエラー（gorm.ErrRecordNotFound）はスパンのエラーとしません。
+
+const (
+	// tracingTracerName はクエリのスパンの instrumentation scope です。
+	tracingTracerName = "github.com/secure-scorecard/backend/internal/repository"
+	// tracingSpanKey はクエリのスパンの Statement の設定のキーです。
+	tracingSpanKey = "repository:tracing_span"
+)
+
+// tracedQuery は実行中のクエリのスパンです。
+type tracedQuery struct {
+	span      trace.Span
+	operation string
+}
+
+// TracingPlugin はクエリのスパンを記録する GORM のプラグインです。
+type TracingPlugin struct {
+	system attribute.KeyValue
+}
+
+// NewTracingPlugin は新しいTracingPluginを作成します。
+//
+// 引数:
+//   - driver: 接続するデータベース（DatabaseConfig.Driver: postgres / mysql / sqlite）
+//
+// 戻り値:
+//   - *TracingPlugin: db.Use で登録するプラグイン
+func NewTracingPlugin(driver string) *TracingPlugin {
+	system := semconv.DBSystemNamePostgreSQL
+	switch driver {
+	case "mysql":
+		system = semconv.DBSystemNameMySQL
+	case "sqlite":
+		system = semconv.DBSystemNameSQLite
+	}
+	return &TracingPlugin{system: system}
+}
+
+// Name はプラグインの名前を返します（gorm.Plugin）。
+func (p *TracingPlugin) Name() string {
+	return "repository:tracing"
+}
+
+// Initialize はクエリのスパンを開始・終了するコールバックを登録します（gorm.Plugin）。
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	start := func(operation string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			_, span := otel.Tracer(tracingTracerName).Start(db.Statement.Context, operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(p.system, semconv.DBOperationName(operation)),
+			)
+			db.InstanceSet(tracingSpanKey, tracedQuery{span: span, operation: operation})
+		}
+	}
+	// end は Row のクエリでは Rows() を返した時点で終了します（結果の読み取りの時間を含まない）。
+	end := func(db *gorm.DB) {
+		value, ok := db.InstanceGet(tracingSpanKey)
+		if !ok {
+			return
+		}
+		query := value.(tracedQuery)
+		span := query.span
+		defer span.End()
+
+		// スパン名は "{操作} {テーブル}"（例: SELECT crops）
+		if table := db.Statement.Table; table != "" {
+			span.SetName(query.operation + " " + table)
+			span.SetAttributes(semconv.DBCollectionName(table))
+		}
+		span.SetAttributes(
+			semconv.DBQueryText(db.Statement.SQL.String()),
+			attribute.Int64("db.response.affected_rows", db.Statement.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("*").Register("repository:tracing_start", start("INSERT")),
+		callbacks.Create().After("*").Register("repository:tracing_end", end),
+		callbacks.Query().Before("*").Register("repository:tracing_start", start("SELECT")),
+		callbacks.Query().After("*").Register("repository:tracing_end", end),
+		callbacks.Update().Before("*").Register("repository:tracing_start", start("UPDATE")),
+		callbacks.Update().After("*").Register("repository:tracing_end", end),
+		callbacks.Delete().Before("*").Register("repository:tracing_start", start("DELETE")),
+		callbacks.Delete().After("*").Register("repository:tracing_end", end),
+		callbacks.Raw().Before("*").Register("repository:tracing_start", start("RAW")),
+		callbacks.Raw().After("*").Register("repository:tracing_end", end),
+		callbacks.Row().Before("*").Register("repository:tracing_start", start("ROW")),
+		callbacks.Row().After("*").Register("repository:tracing_end", end),
+	)
+}
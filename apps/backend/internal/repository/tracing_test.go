@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Query Tracing Tests - クエリのスパンのテスト
+// =============================================================================
+// テスト対象:
+//   - TracingPlugin: クエリのスパン名・親のスパン・SQL・データベースの種類・エラー
+
+// TestTracingPlugin はクエリのスパンのテストです。
+// 期待動作:
+//   - クエリごとに "{操作} {テーブル}" のスパンを記録し、クエリのコンテキストのスパンを親にする
+//   - SQL はプレースホルダーのまま（値を含めない）、データベースの種類は接続のドライバー
+//   - クエリのエラーはスパンのエラー
+func TestTracingPlugin(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &recordingConnPool{}}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open failed: %v", err)
+	}
+	if err := db.Use(NewTracingPlugin("postgres")); err != nil {
+		t.Fatalf("Use failed: %v", err)
+	}
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /api/v1/users/:id")
+
+	// Act
+	_ = GetDB(ctx, db).Where("email = ?", "secret@example.com").First(&model.User{}).Error
+	_ = GetDB(ctx, db).Create(&model.Crop{Name: "トマト"}).Error
+	_ = GetDB(context.Background(), db).Exec("REFRESH MATERIALIZED VIEW mv_harvest_analytics").Error
+	parent.End()
+
+	// Assert
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("Expected 3 query spans and the parent, got %d", len(spans))
+	}
+	query, create, raw := spans[0], spans[1], spans[2]
+	if query.Name() != "SELECT users" || create.Name() != "INSERT crops" || raw.Name() != "RAW" {
+		t.Errorf("Expected the spans SELECT users, INSERT crops and RAW, got %s, %s, %s", query.Name(), create.Name(), raw.Name())
+	}
+	if query.Parent().SpanID() != parent.SpanContext().SpanID() || query.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected a client span under the request, got parent %v", query.Parent())
+	}
+	if raw.Parent().IsValid() {
+		t.Errorf("Expected no parent without a span on the context, got %v", raw.Parent())
+	}
+	var sql, system string
+	for _, kv := range query.Attributes() {
+		switch kv.Key {
+		case "db.query.text":
+			sql = kv.Value.AsString()
+		case "db.system.name":
+			system = kv.Value.AsString()
+		}
+	}
+	if !strings.Contains(sql, "email = $1") || strings.Contains(sql, "secret@example.com") {
+		t.Errorf("Expected the SQL with placeholders, got %q", sql)
+	}
+	if system != "postgresql" {
+		t.Errorf("Expected db.system.name postgresql, got %q", system)
+	}
+	if query.Status().Code != codes.Error || query.Status().Description != errRecorded.Error() {
+		t.Errorf("Expected the query error on the span, got %v", query.Status())
+	}
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // txKey is the context key for storing transaction
@@ -23,41 +25,123 @@ func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, txKey{}, tx)
 }
 
+// afterCommitKey is the context key for storing the after-commit hooks of a transaction
+type afterCommitKey struct{}
+
+// afterCommitHooks はトランザクションのコミット後に実行する処理です。
+type afterCommitHooks struct {
+	fns []func()
+}
+
+// contextWithAfterCommit returns a new context that collects after-commit hooks
+func contextWithAfterCommit(ctx context.Context) (context.Context, *afterCommitHooks) {
+	hooks := &afterCommitHooks{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), hooks
+}
+
+// run は登録した順に処理を実行します。
+func (h *afterCommitHooks) run() {
+	for _, fn := range h.fns {
+		fn()
+	}
+}
+
+// AfterCommit registers fn to run after the transaction in ctx commits
+// トランザクション外の場合はすぐに実行し、ロールバックした場合は実行しません。
+// イベントの配信など、コミットされた変更のみ外部に知らせる処理に使用します。
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
 // repositoryManager implements Repositories interface with transaction support
 type repositoryManager struct {
-	db              *gorm.DB
-	user            *userRepository
-	garden          *gardenRepository
-	plant           *plantRepository
-	careLog         *careLogRepository
-	tokenBlacklist  *tokenBlacklistRepository
-	task            *taskRepository
-	crop            *cropRepository
-	growthRecord    *growthRecordRepository
-	harvest         *harvestRepository
-	plot            *plotRepository
-	plotAssignment  *plotAssignmentRepository
-	deviceToken     *deviceTokenRepository
-	notificationLog *notificationLogRepository
+	db                     *gorm.DB
+	txRetry                txRetryPolicy
+	user                   *userRepository
+	garden                 *gardenRepository
+	plant                  *plantRepository
+	careLog                *careLogRepository
+	tokenBlacklist         *tokenBlacklistRepository
+	task                   *taskRepository
+	crop                   *cropRepository
+	growthRecord           *growthRecordRepository
+	harvest                *harvestRepository
+	seasonSummary          *seasonSummaryRepository
+	plot                   *plotRepository
+	plotAssignment         *plotAssignmentRepository
+	deviceToken            *deviceTokenRepository
+	notificationLog        *notificationLogRepository
+	notificationPreference *notificationPreferenceRepository
+	phoneVerification      *phoneVerificationRepository
+	announcement           *announcementRepository
+	notificationOutbox     *notificationOutboxRepository
+	notificationDeadLetter *notificationDeadLetterRepository
+	schedulerInvocation    *schedulerInvocationRepository
+	jobRun                 *jobRunRepository
+	schedulerCheckpoint    *schedulerCheckpointRepository
+	scheduleConfig         *scheduleConfigRepository
+	shareToken             *shareTokenRepository
+	analyticsView          *analyticsViewRepository
+	exportRecord           *exportRecordRepository
+	usageStats             *usageStatsRepository
+	retention              *retentionRepository
+	sync                   *syncRepository
+	search                 *searchRepository
+	gardenMember           *gardenMemberRepository
+	organization           *organizationRepository
+	organizationMember     *organizationMemberRepository
+	auditLog               *auditLogRepository
+	databaseBackup         *databaseBackupRepository
+	photo                  *photoRepository
+	attachment             *attachmentRepository
 }
 
 // NewRepositoryManager creates a new repository manager
 func NewRepositoryManager(db *gorm.DB) Repositories {
 	return &repositoryManager{
-		db:              db,
-		user:            &userRepository{db: db},
-		garden:          &gardenRepository{db: db},
-		plant:           &plantRepository{db: db},
-		careLog:         &careLogRepository{db: db},
-		tokenBlacklist:  &tokenBlacklistRepository{db: db},
-		task:            &taskRepository{db: db},
-		crop:            &cropRepository{db: db},
-		growthRecord:    &growthRecordRepository{db: db},
-		harvest:         &harvestRepository{db: db},
-		plot:            &plotRepository{db: db},
-		plotAssignment:  &plotAssignmentRepository{db: db},
-		deviceToken:     &deviceTokenRepository{db: db},
-		notificationLog: &notificationLogRepository{db: db},
+		db:                     db,
+		txRetry:                defaultTxRetryPolicy,
+		user:                   &userRepository{db: db},
+		garden:                 &gardenRepository{db: db},
+		plant:                  &plantRepository{db: db},
+		careLog:                &careLogRepository{db: db},
+		tokenBlacklist:         &tokenBlacklistRepository{db: db},
+		task:                   &taskRepository{db: db},
+		crop:                   &cropRepository{db: db},
+		growthRecord:           &growthRecordRepository{db: db},
+		harvest:                &harvestRepository{db: db},
+		seasonSummary:          &seasonSummaryRepository{db: db},
+		plot:                   &plotRepository{db: db},
+		plotAssignment:         &plotAssignmentRepository{db: db},
+		deviceToken:            &deviceTokenRepository{db: db},
+		notificationLog:        &notificationLogRepository{db: db},
+		notificationPreference: &notificationPreferenceRepository{db: db},
+		phoneVerification:      &phoneVerificationRepository{db: db},
+		announcement:           &announcementRepository{db: db},
+		notificationOutbox:     &notificationOutboxRepository{db: db},
+		notificationDeadLetter: &notificationDeadLetterRepository{db: db},
+		schedulerInvocation:    &schedulerInvocationRepository{db: db},
+		jobRun:                 &jobRunRepository{db: db},
+		schedulerCheckpoint:    &schedulerCheckpointRepository{db: db},
+		scheduleConfig:         &scheduleConfigRepository{db: db},
+		shareToken:             &shareTokenRepository{db: db},
+		analyticsView:          &analyticsViewRepository{db: db},
+		exportRecord:           &exportRecordRepository{db: db},
+		usageStats:             &usageStatsRepository{db: db},
+		retention:              &retentionRepository{db: db},
+		sync:                   &syncRepository{db: db},
+		search:                 &searchRepository{db: db},
+		gardenMember:           &gardenMemberRepository{db: db},
+		organization:           &organizationRepository{db: db},
+		organizationMember:     &organizationMemberRepository{db: db},
+		auditLog:               &auditLogRepository{db: db},
+		databaseBackup:         &databaseBackupRepository{db: db},
+		photo:                  &photoRepository{db: db},
+		attachment:             &attachmentRepository{db: db},
 	}
 }
 
@@ -106,6 +190,11 @@ func (m *repositoryManager) Harvest() HarvestRepository {
 	return m.harvest
 }
 
+// SeasonSummary returns the season summary repository
+func (m *repositoryManager) SeasonSummary() SeasonSummaryRepository {
+	return m.seasonSummary
+}
+
 // Plot returns the plot repository
 func (m *repositoryManager) Plot() PlotRepository {
 	return m.plot
@@ -126,21 +215,158 @@ func (m *repositoryManager) NotificationLog() NotificationLogRepository {
 	return m.notificationLog
 }
 
+// NotificationPreference returns the notification preference repository
+func (m *repositoryManager) NotificationPreference() NotificationPreferenceRepository {
+	return m.notificationPreference
+}
+
+// PhoneVerification returns the phone verification repository
+func (m *repositoryManager) PhoneVerification() PhoneVerificationRepository {
+	return m.phoneVerification
+}
+
+// Announcement returns the announcement repository
+func (m *repositoryManager) Announcement() AnnouncementRepository {
+	return m.announcement
+}
+
+// NotificationOutbox returns the notification outbox repository
+func (m *repositoryManager) NotificationOutbox() NotificationOutboxRepository {
+	return m.notificationOutbox
+}
+
+// NotificationDeadLetter returns the notification dead letter repository
+func (m *repositoryManager) NotificationDeadLetter() NotificationDeadLetterRepository {
+	return m.notificationDeadLetter
+}
+
+// SchedulerInvocation returns the scheduler invocation repository
+func (m *repositoryManager) SchedulerInvocation() SchedulerInvocationRepository {
+	return m.schedulerInvocation
+}
+
+// JobRun returns the job run repository
+func (m *repositoryManager) JobRun() JobRunRepository {
+	return m.jobRun
+}
+
+// SchedulerCheckpoint returns the scheduler checkpoint repository
+func (m *repositoryManager) SchedulerCheckpoint() SchedulerCheckpointRepository {
+	return m.schedulerCheckpoint
+}
+
+// ScheduleConfig returns the schedule config repository
+func (m *repositoryManager) ScheduleConfig() ScheduleConfigRepository {
+	return m.scheduleConfig
+}
+
+// ShareToken returns the share token repository
+func (m *repositoryManager) ShareToken() ShareTokenRepository {
+	return m.shareToken
+}
+
+// AnalyticsView returns the analytics view repository
+func (m *repositoryManager) AnalyticsView() AnalyticsViewRepository {
+	return m.analyticsView
+}
+
+// ExportRecord returns the export record repository
+func (m *repositoryManager) ExportRecord() ExportRecordRepository {
+	return m.exportRecord
+}
+
+// UsageStats returns the usage statistics repository
+func (m *repositoryManager) UsageStats() UsageStatsRepository {
+	return m.usageStats
+}
+
+// Retention returns the data retention repository
+func (m *repositoryManager) Retention() RetentionRepository {
+	return m.retention
+}
+
+// Sync returns the offline sync repository
+func (m *repositoryManager) Sync() SyncRepository {
+	return m.sync
+}
+
+// Search returns the global search repository
+func (m *repositoryManager) Search() SearchRepository {
+	return m.search
+}
+
+// GardenMember returns the garden member repository
+func (m *repositoryManager) GardenMember() GardenMemberRepository {
+	return m.gardenMember
+}
+
+// Organization returns the organization repository
+func (m *repositoryManager) Organization() OrganizationRepository {
+	return m.organization
+}
+
+// OrganizationMember returns the organization member repository
+func (m *repositoryManager) OrganizationMember() OrganizationMemberRepository {
+	return m.organizationMember
+}
+
+// AuditLog returns the audit log repository
+func (m *repositoryManager) AuditLog() AuditLogRepository {
+	return m.auditLog
+}
+
+// DatabaseBackup returns the database backup history repository
+func (m *repositoryManager) DatabaseBackup() DatabaseBackupRepository {
+	return m.databaseBackup
+}
+
+// Photo returns the photo repository
+func (m *repositoryManager) Photo() PhotoRepository {
+	return m.photo
+}
+
+// Attachment returns the attachment repository
+func (m *repositoryManager) Attachment() AttachmentRepository {
+	return m.attachment
+}
+
 // WithTransaction executes a function within a database transaction
+// シリアライゼーションの失敗・デッドロックの場合は待機してから fn を最初から実行し直します（transaction_retry.go）。
+// ContextWithIsolationLevel のコンテキストではその分離レベルのトランザクションを開始します。
 func (m *repositoryManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	// Check if already in a transaction
 	if TxFromContext(ctx) != nil {
 		return fn(ctx)
 	}
 
+	for attempt := 1; ; attempt++ {
+		err := m.runTransaction(ctx, fn)
+		if err == nil || attempt >= m.txRetry.maxAttempts || !IsRetryableTxError(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(m.txRetry.delay(attempt)):
+		}
+	}
+}
+
+// runTransaction はトランザクションを開始して fn を実行し、コミットまたはロールバックします。
+func (m *repositoryManager) runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	// Start new transaction
-	tx := m.db.WithContext(ctx).Begin()
+	tx := m.db.WithContext(ctx)
+	if opts := txOptionsFrom(ctx); opts != nil {
+		tx = tx.Begin(opts)
+	} else {
+		tx = tx.Begin()
+	}
 	if tx.Error != nil {
 		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
 
 	// Create context with transaction
-	txCtx := ContextWithTx(ctx, tx)
+	txCtx, hooks := contextWithAfterCommit(ContextWithTx(ctx, tx))
 
 	// Execute function
 	if err := fn(txCtx); err != nil {
@@ -156,13 +382,18 @@ func (m *repositoryManager) WithTransaction(ctx context.Context, fn func(ctx con
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	hooks.run()
 	return nil
 }
 
 // GetDB returns the appropriate database connection (transaction or main)
+// ContextWithReadReplica のコンテキストでは、読み取りクエリを読み取りレプリカで実行します（トランザクション内を除く）。
 func GetDB(ctx context.Context, db *gorm.DB) *gorm.DB {
 	if tx := TxFromContext(ctx); tx != nil {
 		return tx
 	}
+	if usesReadReplica(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Use(readReplicaResolver))
+	}
 	return db.WithContext(ctx)
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// =============================================================================
+// Transaction Retry - シリアライゼーションの失敗・デッドロックのリトライ
+// =============================================================================
+// WithTransaction はシリアライゼーションの失敗・デッドロックでロールバックしたトランザクションを、
+// 待機時間（指数関数的に増やし、ランダムにずらす）をあけて最初から実行し直します（最大 txRetryMaxAttempts 回）。
+// 入れ子の WithTransaction は外側のトランザクションの一部のため、最も外側の WithTransaction で実行し直します。
+// fn は複数回実行される場合があるため、イベントの配信などの外部への処理は AfterCommit で登録してください
+// （失敗した回に登録した処理は実行しません）。
+
+const (
+	// txRetryMaxAttempts はトランザクションを実行する最大の回数です（初回を含む）。
+	txRetryMaxAttempts = 3
+	// txRetryBaseDelay は1回目のリトライの前の待機時間の基準です（リトライごとに2倍）。
+	txRetryBaseDelay = 20 * time.Millisecond
+	// txRetryMaxDelay はリトライの前の待機時間の上限です。
+	txRetryMaxDelay = 500 * time.Millisecond
+)
+
+const (
+	// pgSerializationFailure は PostgreSQL のシリアライゼーションの失敗の SQLSTATE です。
+	pgSerializationFailure = "40001"
+	// pgDeadlockDetected は PostgreSQL のデッドロックの SQLSTATE です。
+	pgDeadlockDetected = "40P01"
+	// mysqlLockDeadlock は MySQL のデッドロックのエラー番号（ER_LOCK_DEADLOCK）です。
+	mysqlLockDeadlock = 1213
+)
+
+// txRetryPolicy はトランザクションのリトライの回数・待機時間です。
+type txRetryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// defaultTxRetryPolicy は WithTransaction のリトライの設定です。
+var defaultTxRetryPolicy = txRetryPolicy{
+	maxAttempts: txRetryMaxAttempts,
+	baseDelay:   txRetryBaseDelay,
+	maxDelay:    txRetryMaxDelay,
+}
+
+// delay は attempt 回目の実行が失敗した後の待機時間を返します。
+// 基準の待機時間の半分からその値までのランダムな時間にし、同時に失敗したトランザクションが再び衝突しないようにします。
+func (p txRetryPolicy) delay(attempt int) time.Duration {
+	d := p.baseDelay << (attempt - 1)
+	if d > p.maxDelay || d <= 0 {
+		d = p.maxDelay
+	}
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// IsRetryableTxError reports whether err is a serialization failure or deadlock that succeeds on retry
+// PostgreSQL のシリアライゼーションの失敗（40001）・デッドロック（40P01）と MySQL のデッドロック（1213）です。
+// 一意制約の違反・楽観的ロックの競合（ErrVersionConflict）など、実行し直しても同じ結果になるエラーは含みません。
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlLockDeadlock
+	}
+	return false
+}
+
+// isolationLevelKey is the context key for the isolation level of transactions
+type isolationLevelKey struct{}
+
+// ContextWithIsolationLevel returns a new context whose transactions use the isolation level
+// 区画への作物の配置など、同時の更新で不整合になる処理で sql.LevelSerializable などの厳しい分離レベルを指定します。
+// 指定しない場合はデータベースのデフォルト（PostgreSQL は READ COMMITTED）です。
+// 既にトランザクション内の場合は外側のトランザクションの分離レベルのままです。
+func ContextWithIsolationLevel(ctx context.Context, level sql.IsolationLevel) context.Context {
+	return context.WithValue(ctx, isolationLevelKey{}, level)
+}
+
+// txOptionsFrom はコンテキストの分離レベルのトランザクションのオプションを返します（指定がない場合は nil）。
+func txOptionsFrom(ctx context.Context) *sql.TxOptions {
+	level, _ := ctx.Value(isolationLevelKey{}).(sql.IsolationLevel)
+	if level == sql.LevelDefault {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: level}
+}
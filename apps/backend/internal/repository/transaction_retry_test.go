@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// Transaction Retry Tests - トランザクションのリトライ・分離レベルのテスト
+// =============================================================================
+// テスト対象:
+//   - WithTransaction: シリアライゼーションの失敗・デッドロックのリトライ、ContextWithIsolationLevel の分離レベル
+//   - IsRetryableTxError: リトライするエラー
+//   - txRetryPolicy.delay: リトライの前の待機時間
+
+// txConnPool はトランザクションの開始・コミット・ロールバックを記録する gorm.ConnPool です（データベースに接続しない）。
+type txConnPool struct {
+	recordingConnPool
+	options    []*sql.TxOptions
+	commitErrs []error // 順に Commit が返すエラー（足りない場合は nil）
+	commits    int
+	rollbacks  int
+}
+
+func (p *txConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.options = append(p.options, opts)
+	return &txConn{pool: p}, nil
+}
+
+// txConn は txConnPool で開始したトランザクションです。
+type txConn struct {
+	recordingConnPool
+	pool *txConnPool
+}
+
+func (c *txConn) Commit() error {
+	c.pool.commits++
+	if len(c.pool.commitErrs) == 0 {
+		return nil
+	}
+	err := c.pool.commitErrs[0]
+	c.pool.commitErrs = c.pool.commitErrs[1:]
+	return err
+}
+
+func (c *txConn) Rollback() error {
+	c.pool.rollbacks++
+	return nil
+}
+
+// newTxTestManager は txConnPool を使用する repositoryManager を作成します（リトライの待機時間は 0）。
+func newTxTestManager(t *testing.T) (*repositoryManager, *txConnPool) {
+	t.Helper()
+	pool := &txConnPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open failed: %v", err)
+	}
+	return &repositoryManager{db: db, txRetry: txRetryPolicy{maxAttempts: txRetryMaxAttempts}}, pool
+}
+
+// TestWithTransaction_RetriesSerializationFailure はシリアライゼーションの失敗のリトライのテストです。
+// 期待動作:
+//   - 失敗した回はロールバックし、成功するまで fn を最初から実行し直す
+//   - 失敗した回に AfterCommit で登録した処理は実行せず、成功した回の処理のみ実行する
+func TestWithTransaction_RetriesSerializationFailure(t *testing.T) {
+	// Arrange
+	m, pool := newTxTestManager(t)
+	attempts := 0
+	hooks := 0
+
+	// Act
+	err := m.WithTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		AfterCommit(ctx, func() { hooks++ })
+		if attempts < 3 {
+			return fmt.Errorf("update plot: %w", &pgconn.PgError{Code: pgSerializationFailure})
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if attempts != 3 || pool.rollbacks != 2 || pool.commits != 1 {
+		t.Errorf("Expected 3 attempts (2 rollbacks, 1 commit), got %d (%d, %d)", attempts, pool.rollbacks, pool.commits)
+	}
+	if hooks != 1 {
+		t.Errorf("Expected only the committed attempt's hook to run, got %d", hooks)
+	}
+}
+
+// TestWithTransaction_RetriesCommitFailure はコミットのシリアライゼーションの失敗のリトライのテストです。
+// 期待動作:
+//   - SERIALIZABLE のコミットの失敗（40001）でもトランザクションを実行し直す
+func TestWithTransaction_RetriesCommitFailure(t *testing.T) {
+	// Arrange
+	m, pool := newTxTestManager(t)
+	pool.commitErrs = []error{&pgconn.PgError{Code: pgSerializationFailure}}
+	attempts := 0
+
+	// Act
+	err := m.WithTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return nil
+	})
+
+	// Assert
+	if err != nil || attempts != 2 || pool.commits != 2 {
+		t.Errorf("Expected the transaction to be retried after the commit failure, got %v (%d attempts, %d commits)", err, attempts, pool.commits)
+	}
+}
+
+// TestWithTransaction_RetryLimit はリトライの上限とリトライしないエラーのテストです。
+// 期待動作:
+//   - デッドロックが続く場合は最大の回数まで実行し、最後のエラーを返す
+//   - リトライしないエラーは1回のみ実行する
+//   - 入れ子の WithTransaction はリトライせず、外側のトランザクションで実行し直す
+func TestWithTransaction_RetryLimit(t *testing.T) {
+	// Arrange
+	m, pool := newTxTestManager(t)
+	deadlock := &pgconn.PgError{Code: pgDeadlockDetected}
+	errFailed := errors.New("failed")
+	deadlockAttempts, failedAttempts, nestedAttempts := 0, 0, 0
+
+	// Act
+	deadlockErr := m.WithTransaction(context.Background(), func(ctx context.Context) error {
+		deadlockAttempts++
+		return deadlock
+	})
+	failedErr := m.WithTransaction(context.Background(), func(ctx context.Context) error {
+		failedAttempts++
+		return errFailed
+	})
+	beginsBeforeNested := len(pool.options)
+	nestedErr := m.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return m.WithTransaction(ctx, func(ctx context.Context) error {
+			nestedAttempts++
+			return deadlock
+		})
+	})
+
+	// Assert
+	if !errors.Is(deadlockErr, deadlock) || deadlockAttempts != txRetryMaxAttempts {
+		t.Errorf("Expected %d attempts and the deadlock, got %d (%v)", txRetryMaxAttempts, deadlockAttempts, deadlockErr)
+	}
+	if !errors.Is(failedErr, errFailed) || failedAttempts != 1 {
+		t.Errorf("Expected a single attempt for a non-retryable error, got %d (%v)", failedAttempts, failedErr)
+	}
+	if !errors.Is(nestedErr, deadlock) || nestedAttempts != txRetryMaxAttempts || len(pool.options)-beginsBeforeNested != txRetryMaxAttempts {
+		t.Errorf("Expected the outer transaction to retry %d times, got %d attempts (%d begins)",
+			txRetryMaxAttempts, nestedAttempts, len(pool.options)-beginsBeforeNested)
+	}
+}
+
+// TestWithTransaction_ContextCanceled はリトライの待機中のキャンセルのテストです。
+// 期待動作:
+//   - コンテキストがキャンセルされた場合は実行し直さずに最後のエラーを返す
+func TestWithTransaction_ContextCanceled(t *testing.T) {
+	// Arrange
+	m, _ := newTxTestManager(t)
+	m.txRetry = txRetryPolicy{maxAttempts: txRetryMaxAttempts, baseDelay: time.Hour, maxDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	// Act
+	err := m.WithTransaction(ctx, func(ctx context.Context) error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: pgSerializationFailure}
+	})
+
+	// Assert
+	if !IsRetryableTxError(err) || attempts != 1 {
+		t.Errorf("Expected a single attempt after the cancellation, got %d (%v)", attempts, err)
+	}
+}
+
+// TestWithTransaction_IsolationLevel はトランザクションの分離レベルのテストです。
+// 期待動作:
+//   - ContextWithIsolationLevel の分離レベルでトランザクションを開始する
+//   - 指定しない場合はオプションなし（データベースのデフォルト）
+func TestWithTransaction_IsolationLevel(t *testing.T) {
+	// Arrange
+	m, pool := newTxTestManager(t)
+	noop := func(ctx context.Context) error { return nil }
+
+	// Act
+	serializableErr := m.WithTransaction(ContextWithIsolationLevel(context.Background(), sql.LevelSerializable), noop)
+	defaultErr := m.WithTransaction(context.Background(), noop)
+
+	// Assert
+	if serializableErr != nil || defaultErr != nil || len(pool.options) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d (%v / %v)", len(pool.options), serializableErr, defaultErr)
+	}
+	if pool.options[0] == nil || pool.options[0].Isolation != sql.LevelSerializable {
+		t.Errorf("Expected a serializable transaction, got %+v", pool.options[0])
+	}
+	if pool.options[1] != nil {
+		t.Errorf("Expected the default isolation level, got %+v", pool.options[1])
+	}
+}
+
+// TestIsRetryableTxError はリトライするエラーのテストです。
+// 期待動作:
+//   - PostgreSQL の 40001・40P01、MySQL の 1213 はラップされていてもリトライする
+//   - 一意制約の違反・楽観的ロックの競合・nil はリトライしない
+func TestIsRetryableTxError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: pgSerializationFailure}, true},
+		{"deadlock", fmt.Errorf("failed to commit transaction: %w", &pgconn.PgError{Code: pgDeadlockDetected}), true},
+		{"mysql deadlock", &mysql.MySQLError{Number: mysqlLockDeadlock}, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"mysql duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"version conflict", ErrVersionConflict, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		// Act
+		got := IsRetryableTxError(tt.err)
+
+		// Assert
+		if got != tt.want {
+			t.Errorf("IsRetryableTxError(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestTxRetryPolicy_Delay はリトライの前の待機時間のテストです。
+// 期待動作:
+//   - 基準の待機時間はリトライごとに2倍になり、上限を超えない
+//   - 待機時間は基準の半分から基準までのランダムな時間
+func TestTxRetryPolicy_Delay(t *testing.T) {
+	// Arrange
+	policy := defaultTxRetryPolicy
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		base := txRetryBaseDelay << (attempt - 1)
+		if base > txRetryMaxDelay {
+			base = txRetryMaxDelay
+		}
+
+		// Act
+		got := policy.delay(attempt)
+
+		// Assert
+		if got < base/2 || got > base {
+			t.Errorf("delay(%d) = %v, want between %v and %v", attempt, got, base/2, base)
+		}
+	}
+}
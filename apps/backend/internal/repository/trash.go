@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Trash - 論理削除した記録の一覧・復元
+// =============================================================================
+// 論理削除した行は GORM の既定のクエリから除外されるため、ゴミ箱の一覧・復元は Unscoped で
+// deleted_at IS NOT NULL の行を対象にします。
+// 復元は updated_at も更新し、オフライン同期（GET /sync）で変更として返します。
+
+// unscopedDeleted は論理削除した行のみを対象にするクエリです（column は deleted_at の列名）。
+func unscopedDeleted(db *gorm.DB, column string) *gorm.DB {
+	return db.Unscoped().Where(column + " IS NOT NULL")
+}
+
+// restoreColumns は復元で更新する列の値です。
+func restoreColumns() map[string]any {
+	return map[string]any{"deleted_at": nil, "updated_at": time.Now()}
+}
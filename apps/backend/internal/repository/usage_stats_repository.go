@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// UsageStatsRepository Implementation - 利用統計リポジトリ
+// =============================================================================
+
+// usageStatsRepository implements UsageStatsRepository
+type usageStatsRepository struct {
+	db *gorm.DB
+}
+
+// IncrementCounter は (date, metric) のカウンターをupsertで加算します。
+// 同時リクエストでも値が失われないよう、加算はDB側で行います。
+func (r *usageStatsRepository) IncrementCounter(ctx context.Context, date time.Time, metric string, delta int64) error {
+	counter := &model.UsageCounter{
+		Date:      date,
+		Metric:    metric,
+		Value:     delta,
+		UpdatedAt: time.Now(),
+	}
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "date"}, {Name: "metric"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"value":      gorm.Expr("usage_counters.value + ?", delta),
+			"updated_at": counter.UpdatedAt,
+		}),
+	}).Create(counter).Error
+}
+
+// RecordActiveUser はその日のアクティブユーザーを記録します。
+// 既に記録済みの場合は何もしません。
+func (r *usageStatsRepository) RecordActiveUser(ctx context.Context, date time.Time, userID uint) error {
+	return GetDB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.DailyActiveUser{Date: date, UserID: userID}).Error
+}
+
+// GetCounters は期間内（from〜to、両端を含む）の全カウンターを日付順に取得します。
+func (r *usageStatsRepository) GetCounters(ctx context.Context, from, to time.Time) ([]model.UsageCounter, error) {
+	var counters []model.UsageCounter
+	if err := GetDB(ctx, r.db).
+		Where("date BETWEEN ? AND ?", from, to).
+		Order("date, metric").
+		Find(&counters).Error; err != nil {
+		return nil, err
+	}
+	return counters, nil
+}
+
+// GetDailyActiveUsers は期間内の日別アクティブユーザー数を取得します。
+func (r *usageStatsRepository) GetDailyActiveUsers(ctx context.Context, from, to time.Time) ([]DailyCount, error) {
+	var counts []DailyCount
+	if err := GetDB(ctx, r.db).Model(&model.DailyActiveUser{}).
+		Select("date, COUNT(*) AS count").
+		Where("date BETWEEN ? AND ?", from, to).
+		Group("date").
+		Order("date").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// CountDistinctActiveUsers は期間内のユニークアクティブユーザー数を取得します。
+func (r *usageStatsRepository) CountDistinctActiveUsers(ctx context.Context, from, to time.Time) (int64, error) {
+	var count int64
+	if err := GetDB(ctx, r.db).Model(&model.DailyActiveUser{}).
+		Where("date BETWEEN ? AND ?", from, to).
+		Distinct("user_id").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -44,6 +44,20 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 	return &user, nil
 }
 
+// GetIDsAfter はIDが afterID より大きいユーザーのIDを昇順に最大 limit 件取得します
+// 定期通知でユーザーをページごとに処理するためのキーセットページネーションに使用します
+func (r *userRepository) GetIDsAfter(ctx context.Context, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	if err := GetDB(ctx, r.db).Model(&model.User{}).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // Update updates a user
 func (r *userRepository) Update(ctx context.Context, user *model.User) error {
 	return GetDB(ctx, r.db).Save(user).Error
@@ -0,0 +1,208 @@
+// Package scheduler - 内蔵スケジューラー
+//
+// EventBridge Scheduler を使えないセルフホスト環境向けに、定期タスクをプロセス内で実行します。
+// 機能:
+//   - cron形式（分 時 日 月 曜日）と @hourly / @daily / @every 5m 等の記述子によるスケジュール
+//   - ジョブごとの有効・無効とスケジュールの設定
+//   - 前回の実行が終わっていないジョブの重複実行の防止
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Cron Schedule - cron形式のスケジュール
+// =============================================================================
+
+// ErrInvalidSchedule はスケジュールの書式が不正な場合のエラー
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule は次回の実行日時を求めるスケジュールです。
+type Schedule interface {
+	// Next は t より後の最初の実行日時を返します（t のタイムゾーンで判定）。
+	Next(t time.Time) time.Time
+}
+
+// cronField はcronの各フィールドの値の範囲です。
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = cronField{"minute", 0, 59}
+	hourField   = cronField{"hour", 0, 23}
+	domField    = cronField{"day of month", 1, 31}
+	monthField  = cronField{"month", 1, 12}
+	dowField    = cronField{"day of week", 0, 7} // 0と7はどちらも日曜日
+)
+
+// cronSchedule は5フィールドのcron式です。各フィールドは許可する値のビットセットです。
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar/dowStar は日・曜日が * の場合 true（両方とも指定された場合はどちらかに一致すれば実行）
+	domStar, dowStar bool
+}
+
+// everySchedule は一定間隔（@every）のスケジュールです。
+type everySchedule struct {
+	interval time.Duration
+}
+
+// descriptors は記述子とcron式の対応です。
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule はスケジュールを解析します。
+//
+// 受け付ける書式:
+//   - cron式（分 時 日 月 曜日）: *, 数値, 範囲（1-5）, 間隔（*/15, 0-30/5）, カンマ区切りの組み合わせ
+//   - 記述子: @yearly, @monthly, @weekly, @daily, @midnight, @hourly
+//   - 一定間隔: @every 5m（time.ParseDuration の書式、1分以上）
+//
+// 引数:
+//   - spec: スケジュール
+//
+// 戻り値:
+//   - Schedule: スケジュール
+//   - error: 書式が不正な場合は ErrInvalidSchedule
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval < time.Minute {
+			return nil, fmt.Errorf("%w: %q: interval must be at least 1m", ErrInvalidSchedule, spec)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+	if expr, ok := descriptors[spec]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields", ErrInvalidSchedule, spec)
+	}
+
+	s := &cronSchedule{domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&s.minute, minuteField},
+		{&s.hour, hourField},
+		{&s.dom, domField},
+		{&s.month, monthField},
+		{&s.dow, dowField},
+	} {
+		if *target.bits, err = parseCronField(fields[i], target.field); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSchedule, spec, err)
+		}
+	}
+	// 7（日曜日）は0として扱う
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseCronField はcronのフィールドを許可する値のビットセットに変換します。
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepExpr, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" {
+			lo, hi, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = strconv.Atoi(lo); err != nil {
+				return 0, fmt.Errorf("invalid value %q in %s", part, field.name)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(hi); err != nil {
+					return 0, fmt.Errorf("invalid value %q in %s", part, field.name)
+				}
+			} else if hasStep {
+				// "5/15" は 5 から最大値まで15ごと
+				end = field.max
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("value %q out of range in %s (%d-%d)", part, field.name, field.min, field.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// maxScheduleSearch は次回の実行日時を探す期間の上限です（2月30日のような実行されない式の無限ループを防ぐ）。
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next は t より後の最初の実行日時を返します。実行日時がない式（例: 2月30日）の場合はゼロ値を返します。
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches は日付が日・曜日のフィールドに一致するかを判定します。
+// 日と曜日の両方が指定されている場合は、標準のcronと同じくどちらかに一致すれば実行します。
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next は t から一定間隔後の日時を返します（分未満は切り捨て）。
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval).Truncate(time.Minute)
+}
@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Scheduler Jobs - 定期タスクの登録
+// =============================================================================
+// EventBridge Scheduler から呼び出す /api/v1/scheduler/* と同じ処理を、内蔵スケジューラーのジョブとして登録します。
+
+// NewFromConfig は設定で有効なジョブを登録したSchedulerを作成します。
+// 通知の送信が必要なジョブは、eventHandler がnilの場合は登録しません（データベースのバックアップは保存先が未設定の場合）。
+// 登録したジョブのスケジュールは、管理者が変更した設定（svc.GetScheduleConfigs）で上書きします。
+// ジョブの実行は実行履歴（job_runs）に記録し、cfg.CatchUpEnabled の場合は停止中に過ぎた実行日時のジョブを起動時に1回実行します。
+//
+// 引数:
+//   - cfg: スケジューラー設定（タイムゾーン・ジョブごとの設定）
+//   - svc: サービス
+//   - eventHandler: 通知イベントハンドラー（nilの場合は通知送信なし）
+//
+// 戻り値:
+//   - *Scheduler: ジョブを登録したスケジューラー（Start で開始）
+//   - error: タイムゾーン・スケジュールが不正な場合のエラー
+func NewFromConfig(cfg config.SchedulerConfig, svc *service.Service, eventHandler service.NotificationEventHandler) (*Scheduler, error) {
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler timezone %q: %w", cfg.Timezone, err)
+	}
+	s := New(loc)
+
+	jobs := map[string]JobFunc{
+		config.SchedulerJobAnalyticsRefresh: func(ctx context.Context) error {
+			result, err := svc.RefreshMaterializedViews(ctx)
+			if err != nil {
+				return err
+			}
+			if result.Failed > 0 {
+				return fmt.Errorf("%d materialized view(s) failed to refresh", result.Failed)
+			}
+			return nil
+		},
+		config.SchedulerJobDeviceTokenPrune: func(ctx context.Context) error {
+			deleted, err := svc.PruneInactiveDeviceTokens(ctx)
+			if err == nil && deleted > 0 {
+				log.Printf("Scheduler: pruned %d inactive device tokens", deleted)
+			}
+			return err
+		},
+		config.SchedulerJobNotificationLogCleanup: func(ctx context.Context) error {
+			if err := svc.CleanupExpiredNotificationLogs(ctx); err != nil {
+				return err
+			}
+			_, err := svc.CleanupDispatchedNotificationOutbox(ctx)
+			return err
+		},
+		config.SchedulerJobTokenBlacklistCleanup: svc.CleanupExpiredTokens,
+		config.SchedulerJobDataRetention: func(ctx context.Context) error {
+			report, err := svc.RunScheduledDataRetention(ctx)
+			if err != nil {
+				return err
+			}
+			log.Printf("Scheduler: data retention matched %d, purged %d (dry run: %v)", report.TotalMatched, report.TotalPurged, report.DryRun)
+			if len(report.Errors) > 0 {
+				return fmt.Errorf("data retention failed for %d target(s): %s", len(report.Errors), strings.Join(report.Errors, "; "))
+			}
+			return nil
+		},
+		config.SchedulerJobIdempotencyKeyCleanup: func(ctx context.Context) error {
+			if _, err := svc.CleanupExpiredSchedulerInvocations(ctx); err != nil {
+				return err
+			}
+			_, err := svc.CleanupJobRuns(ctx)
+			return err
+		},
+	}
+	if svc.DatabaseBackupAvailable() {
+		jobs[config.SchedulerJobDatabaseBackup] = func(ctx context.Context) error {
+			backup, err := svc.RunDatabaseBackup(ctx, service.BackupTriggerScheduled)
+			if err != nil {
+				return err
+			}
+			log.Printf("Scheduler: database backup %d saved (%d rows, %d bytes)", backup.ID, backup.RowCount, backup.FileSizeBytes)
+			return nil
+		}
+	}
+	if eventHandler != nil {
+		jobs[config.SchedulerJobNotifications] = func(ctx context.Context) error {
+			_, err := eventHandler.ProcessScheduledNotificationsAndSend(ctx)
+			return err
+		}
+		jobs[config.SchedulerJobNotificationRetry] = func(ctx context.Context) error {
+			_, err := eventHandler.RetryPendingNotifications(ctx)
+			return err
+		}
+		jobs[config.SchedulerJobNotificationOutbox] = func(ctx context.Context) error {
+			_, err := eventHandler.DispatchNotificationOutbox(ctx)
+			return err
+		}
+		jobs[config.SchedulerJobAnnouncements] = func(ctx context.Context) error {
+			_, err := eventHandler.DeliverAnnouncements(ctx)
+			return err
+		}
+		jobs[config.SchedulerJobSeasonRollover] = func(ctx context.Context) error {
+			result, err := eventHandler.RunSeasonRollover(ctx, 0)
+			if err != nil {
+				return err
+			}
+			log.Printf("Scheduler: season %d closed for %d users (%d crops archived, %d notifications)",
+				result.Season, result.Users, result.ArchivedCrops, result.Notifications)
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("season rollover failed for %d item(s): %s", len(result.Errors), strings.Join(result.Errors, "; "))
+			}
+			return nil
+		}
+	}
+
+	for _, def := range config.SchedulerJobNames {
+		jobCfg, ok := cfg.Jobs[def.Name]
+		if !ok {
+			jobCfg = config.SchedulerJobConfig{Enabled: config.SchedulerJobEnabledByDefault(def.Name), Schedule: def.Schedule}
+		}
+		if !jobCfg.Enabled {
+			continue
+		}
+		run, ok := jobs[def.Name]
+		if !ok {
+			log.Printf("Scheduler: job %s is disabled (notification sender or backup storage not configured)", def.Name)
+			continue
+		}
+		if err := s.Add(def.Name, jobCfg.Schedule, run); err != nil {
+			return nil, err
+		}
+	}
+
+	// 管理者が変更したスケジュール（schedule_configs）を実行のたびに反映する
+	s.SetScheduleSource(func(ctx context.Context) (map[string]JobSchedule, error) {
+		configs, err := svc.GetScheduleConfigs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		overrides := make(map[string]JobSchedule, len(configs))
+		for _, cfg := range configs {
+			overrides[cfg.Name] = JobSchedule{Schedule: cfg.Schedule, Enabled: cfg.Enabled}
+		}
+		return overrides, nil
+	})
+	s.SetRunHistory(jobRunHistory{svc: svc}, cfg.CatchUpEnabled)
+	return s, nil
+}
+
+// jobRunHistory はサービスの実行履歴（job_runs）を RunHistory として提供します。
+type jobRunHistory struct {
+	svc *service.Service
+}
+
+func (h jobRunHistory) LastScheduledAt(ctx context.Context, name string) (time.Time, error) {
+	run, err := h.svc.GetLatestJobRun(ctx, name)
+	if err != nil || run == nil {
+		return time.Time{}, err
+	}
+	return run.ScheduledAt, nil
+}
+
+func (h jobRunHistory) RecordStart(ctx context.Context, name string, scheduledAt time.Time, catchUp bool) (uint, error) {
+	run, err := h.svc.StartJobRun(ctx, name, scheduledAt, catchUp)
+	if err != nil {
+		return 0, err
+	}
+	return run.ID, nil
+}
+
+func (h jobRunHistory) RecordFinish(ctx context.Context, id uint, runErr error) error {
+	return h.svc.FinishJobRun(ctx, id, runErr)
+}
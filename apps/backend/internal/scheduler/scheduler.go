@@ -0,0 +1,507 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Scheduler - ジョブの定期実行
+// =============================================================================
+// 登録したジョブをスケジュールに従って実行します。
+// 各ジョブは別のゴルーチンで実行し、前回の実行が終わっていない場合はその回をスキップします。
+//
+// ScheduleSource を設定した場合は、実行のたびに（最長 scheduleRefreshInterval ごとに）ジョブのスケジュールの上書きを読み込み、
+// 管理者が変更したスケジュール・一時停止を再起動せずに反映します。
+//
+// RunHistory を設定した場合は、ジョブの実行を記録します。キャッチアップを有効にすると、
+// 起動後の最初の実行時に最後の実行日時から停止中に過ぎた実行日時を検出し、そのジョブを1回だけ実行します
+// （複数回の実行日時を過ぎていても1回。実行したことがない・一時停止中のジョブは実行しません）。
+//
+// ジョブの実行はそれぞれ OpenTelemetry のスパン（"scheduler.run {ジョブ名}"）として記録し、
+// ジョブのクエリ・AWS の呼び出しはそのスパンの子になります。
+//
+// 注意: 複数のインスタンスで有効にすると同じジョブがインスタンスごとに実行されます。
+// 通知は重複防止キーで二重送信されませんが、内蔵スケジューラーは1インスタンスのみで有効にしてください。
+
+const (
+	// scheduleRefreshInterval はスケジュールの上書きを読み込む最長の間隔です。
+	scheduleRefreshInterval = time.Minute
+	// scheduleSourceTimeout はスケジュールの上書きの読み込みのタイムアウトです。
+	scheduleSourceTimeout = 10 * time.Second
+	// runHistoryTimeout は実行履歴の読み込み・記録のタイムアウトです。
+	runHistoryTimeout = 10 * time.Second
+	// maxCatchUpWindows は停止中に過ぎた実行日時を数える上限です（@every 1m で長期間停止した場合など）。
+	maxCatchUpWindows = 10000
+	// tracerName はジョブの実行のスパンの instrumentation scope です。
+	tracerName = "github.com/secure-scorecard/backend/internal/scheduler"
+)
+
+var (
+	// ErrJobNotFound は RunNow で指定したジョブが登録されていない場合のエラーです。
+	ErrJobNotFound = errors.New("job not registered")
+	// ErrJobRunning は RunNow で指定したジョブが実行中の場合のエラーです。
+	ErrJobRunning = errors.New("job already running")
+)
+
+// JobFunc はジョブの処理です。
+type JobFunc func(ctx context.Context) error
+
+// JobSchedule はジョブのスケジュールの上書きです。
+type JobSchedule struct {
+	Schedule string // 空の場合は登録時のスケジュール
+	Enabled  bool   // false の場合はジョブを一時停止
+}
+
+// ScheduleSource はジョブ名ごとのスケジュールの上書きを返します。
+// 含まれないジョブは登録時のスケジュールで実行します。
+type ScheduleSource func(ctx context.Context) (map[string]JobSchedule, error)
+
+// RunHistory はジョブの実行履歴です。
+type RunHistory interface {
+	// LastScheduledAt はジョブの最後の実行の実行日時を返します（実行したことがない場合はゼロ値）。
+	LastScheduledAt(ctx context.Context, name string) (time.Time, error)
+	// RecordStart はジョブの実行開始を記録し、実行履歴のIDを返します。
+	RecordStart(ctx context.Context, name string, scheduledAt time.Time, catchUp bool) (uint, error)
+	// RecordFinish はジョブの実行結果を記録します。
+	RecordFinish(ctx context.Context, id uint, runErr error) error
+}
+
+// job は登録されたジョブです。
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	run      JobFunc
+	next     time.Time
+	running  bool
+
+	defaultSpec     string   // 登録時のスケジュール
+	defaultSchedule Schedule // 登録時のスケジュール（解析済み）
+	paused          bool     // 上書きで一時停止中
+	rejected        string   // 不正なため無視した上書きのスケジュール（ログを1回だけ出す）
+	caughtUp        bool     // 起動後にキャッチアップの判定を終えたか
+}
+
+// JobStatus はジョブの登録内容と次回の実行日時です。
+type JobStatus struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	Enabled  bool      `json:"enabled"`
+	NextRun  time.Time `json:"next_run"`
+	Running  bool      `json:"running"`
+}
+
+// Scheduler はジョブを定期実行するスケジューラーです。
+type Scheduler struct {
+	loc    *time.Location
+	now    func() time.Time
+	source ScheduleSource
+
+	history RunHistory
+	catchUp bool
+
+	mu     sync.Mutex
+	jobs   []*job
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New は新しいSchedulerを作成します。
+//
+// 引数:
+//   - loc: スケジュールを判定するタイムゾーン（nilの場合はUTC）
+//
+// 戻り値:
+//   - *Scheduler: スケジューラー
+func New(loc *time.Location) *Scheduler {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Scheduler{loc: loc, now: time.Now}
+}
+
+// Add はジョブを登録します。Start の前に呼び出してください。
+//
+// 引数:
+//   - name: ジョブ名（ログ用）
+//   - spec: スケジュール（ParseSchedule の書式）
+//   - run: ジョブの処理
+//
+// 戻り値:
+//   - error: スケジュールの書式が不正な場合は ErrInvalidSchedule
+func (s *Scheduler) Add(name, spec string, run JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &job{
+		name:            name,
+		spec:            spec,
+		schedule:        schedule,
+		run:             run,
+		defaultSpec:     spec,
+		defaultSchedule: schedule,
+	})
+	return nil
+}
+
+// SetScheduleSource はジョブのスケジュールの上書きを読み込む関数を設定します。Start の前に呼び出してください。
+func (s *Scheduler) SetScheduleSource(source ScheduleSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.source = source
+}
+
+// SetRunHistory はジョブの実行履歴を設定します。Start の前に呼び出してください。
+//
+// 引数:
+//   - history: ジョブの実行履歴
+//   - catchUp: 起動時に停止中に過ぎた実行日時のジョブを1回だけ実行するか
+func (s *Scheduler) SetRunHistory(history RunHistory, catchUp bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = history
+	s.catchUp = catchUp
+}
+
+// Jobs は登録されたジョブの状態を名前順で返します。
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		statuses = append(statuses, JobStatus{Name: j.name, Schedule: j.spec, Enabled: !j.paused, NextRun: j.next, Running: j.running})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// RunNow は登録したジョブをスケジュールに関係なくすぐに実行し、終了を待ちます（管理CLIの手動実行用）。
+// 一時停止中のジョブも実行し、実行は通常の実行と同じく実行履歴に記録します。
+//
+// 引数:
+//   - ctx: ジョブを実行するコンテキスト
+//   - name: ジョブ名
+//
+// 戻り値:
+//   - error: ジョブの処理のエラー、登録されていない場合は ErrJobNotFound、実行中の場合は ErrJobRunning
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	s.mu.Lock()
+	var target *job
+	for _, j := range s.jobs {
+		if j.name == name {
+			target = j
+			break
+		}
+	}
+	if target == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if target.running {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobRunning, name)
+	}
+	target.running = true
+	s.wg.Add(1)
+	scheduledAt := s.now().In(s.loc)
+	s.mu.Unlock()
+
+	return s.execute(ctx, target, scheduledAt, false)
+}
+
+// Start はスケジューラーを開始します。ジョブは ctx から派生したコンテキストで実行します。
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	s.mu.Lock()
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	now := s.now().In(s.loc)
+	for _, j := range s.jobs {
+		j.next = j.schedule.Next(now)
+	}
+	s.mu.Unlock()
+
+	go s.loop(ctx)
+}
+
+// Stop はスケジューラーを停止し、実行中のジョブの終了を待ちます。
+// ctx の期限までに終わらない場合は待たずに戻ります。
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	finished := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop は次回の実行日時まで待機し、実行日時を過ぎたジョブを起動します。
+func (s *Scheduler) loop(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		if s.source != nil {
+			s.refreshSchedules(ctx, s.now().In(s.loc))
+		}
+		if s.history != nil && s.catchUp {
+			s.catchUpMissed(ctx, s.now().In(s.loc))
+		}
+
+		wait := time.Hour
+		if next := s.nextRun(); !next.IsZero() {
+			wait = time.Until(next)
+		}
+		if s.source != nil && wait > scheduleRefreshInterval {
+			wait = scheduleRefreshInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runDue(ctx, s.now().In(s.loc))
+		}
+	}
+}
+
+// refreshSchedules はスケジュールの上書きを読み込み、変更されたジョブの次回の実行日時を更新します。
+// 読み込みに失敗した場合は現在のスケジュールのまま実行します。
+func (s *Scheduler) refreshSchedules(ctx context.Context, now time.Time) {
+	loadCtx, cancel := context.WithTimeout(ctx, scheduleSourceTimeout)
+	defer cancel()
+	overrides, err := s.source(loadCtx)
+	if err != nil {
+		log.Printf("Scheduler: failed to load schedule overrides, keeping current schedules: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		spec, schedule, enabled := j.defaultSpec, j.defaultSchedule, true
+		if override, ok := overrides[j.name]; ok {
+			enabled = override.Enabled
+			if override.Schedule != "" {
+				if parsed, err := ParseSchedule(override.Schedule); err != nil {
+					if j.rejected != override.Schedule {
+						log.Printf("Scheduler: ignoring invalid schedule %q for job %s: %v", override.Schedule, j.name, err)
+						j.rejected = override.Schedule
+					}
+				} else {
+					spec, schedule = override.Schedule, parsed
+				}
+			}
+		}
+
+		if spec != j.spec {
+			j.spec, j.schedule = spec, schedule
+			j.next = schedule.Next(now)
+			log.Printf("Scheduler: job %s rescheduled to %s, next run at %s", j.name, spec, j.next.Format(time.RFC3339))
+		}
+		if enabled == j.paused {
+			j.paused = !enabled
+			if enabled {
+				j.next = j.schedule.Next(now)
+				log.Printf("Scheduler: job %s resumed, next run at %s", j.name, j.next.Format(time.RFC3339))
+			} else {
+				log.Printf("Scheduler: job %s paused", j.name)
+			}
+		}
+	}
+}
+
+// catchUpMissed は停止中に実行日時を過ぎたジョブを1回だけ実行します。
+// 実行履歴を読み込めなかったジョブは次回の判定で再試行します。
+func (s *Scheduler) catchUpMissed(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	pending := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		if j.caughtUp {
+			continue
+		}
+		if j.paused {
+			// 一時停止中に過ぎた実行日時は再開してもキャッチアップしない
+			j.caughtUp = true
+			continue
+		}
+		pending = append(pending, j)
+	}
+	s.mu.Unlock()
+
+	for _, j := range pending {
+		loadCtx, cancel := context.WithTimeout(ctx, runHistoryTimeout)
+		last, err := s.history.LastScheduledAt(loadCtx, j.name)
+		cancel()
+		if err != nil {
+			log.Printf("Scheduler: failed to load run history for job %s, will retry catch-up: %v", j.name, err)
+			continue
+		}
+
+		s.mu.Lock()
+		j.caughtUp = true
+		missed, count := missedWindow(j.schedule, last.In(s.loc), now)
+		if count > 0 && !j.running {
+			log.Printf("Scheduler: catching up job %s (%d missed run(s) since %s)", j.name, count, last.Format(time.RFC3339))
+			j.running = true
+			s.wg.Add(1)
+			go s.execute(ctx, j, missed, true)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// missedWindow は last の後、now までに過ぎた実行日時のうち最後のものと、その数を返します。
+// last がゼロ値（実行したことがない）の場合は 0 件です。
+func missedWindow(schedule Schedule, last, now time.Time) (time.Time, int) {
+	if last.IsZero() {
+		return time.Time{}, 0
+	}
+	var missed time.Time
+	count := 0
+	for next := schedule.Next(last); !next.IsZero() && !next.After(now) && count < maxCatchUpWindows; next = schedule.Next(next) {
+		missed = next
+		count++
+	}
+	return missed, count
+}
+
+// nextRun は全ジョブのうち最も早い次回の実行日時を返します（ジョブがない場合はゼロ値）。
+func (s *Scheduler) nextRun() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, j := range s.jobs {
+		if !j.paused && !j.next.IsZero() && (earliest.IsZero() || j.next.Before(earliest)) {
+			earliest = j.next
+		}
+	}
+	return earliest
+}
+
+// runDue は実行日時を過ぎたジョブを起動し、次回の実行日時を更新します。
+// 前回の実行が終わっていないジョブはその回をスキップします。
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.paused || j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+		scheduledAt := j.next
+		j.next = j.schedule.Next(now)
+
+		if j.running {
+			log.Printf("Scheduler: skipping job %s (previous run still in progress)", j.name)
+			continue
+		}
+		j.running = true
+		s.wg.Add(1)
+		go s.execute(ctx, j, scheduledAt, false)
+	}
+}
+
+// execute はジョブを実行し、結果をログ・実行履歴・スパンに記録します。
+func (s *Scheduler) execute(ctx context.Context, j *job, scheduledAt time.Time, catchUp bool) (err error) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		j.running = false
+		s.mu.Unlock()
+	}()
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "scheduler.run "+j.name, trace.WithAttributes(
+		attribute.String("scheduler.job.name", j.name),
+		attribute.String("scheduler.job.scheduled_at", scheduledAt.Format(time.RFC3339)),
+		attribute.Bool("scheduler.job.catch_up", catchUp),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduler: job %s panicked: %v", j.name, r)
+			err = fmt.Errorf("job %s panicked: %v", j.name, r)
+		}
+	}()
+
+	runID := s.recordStart(ctx, j.name, scheduledAt, catchUp)
+	started := time.Now()
+	err = j.run(ctx)
+	s.recordFinish(ctx, j.name, runID, err)
+	if err != nil {
+		log.Printf("Scheduler: job %s failed after %s: %v", j.name, time.Since(started).Round(time.Millisecond), err)
+		return err
+	}
+	log.Printf("Scheduler: job %s completed in %s", j.name, time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// recordStart はジョブの実行開始を実行履歴に記録します（RunHistory がない・記録に失敗した場合は 0）。
+// 記録に失敗してもジョブは実行します。
+func (s *Scheduler) recordStart(ctx context.Context, name string, scheduledAt time.Time, catchUp bool) uint {
+	if s.history == nil {
+		return 0
+	}
+	recordCtx, cancel := context.WithTimeout(ctx, runHistoryTimeout)
+	defer cancel()
+	id, err := s.history.RecordStart(recordCtx, name, scheduledAt, catchUp)
+	if err != nil {
+		log.Printf("Scheduler: failed to record start of job %s: %v", name, err)
+		return 0
+	}
+	return id
+}
+
+// recordFinish はジョブの実行結果を実行履歴に記録します。
+// 停止中（ctx のキャンセル後）に終わったジョブも記録できるよう、キャンセルを引き継がないコンテキストで記録します。
+func (s *Scheduler) recordFinish(ctx context.Context, name string, id uint, runErr error) {
+	if s.history == nil || id == 0 {
+		return
+	}
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), runHistoryTimeout)
+	defer cancel()
+	if err := s.history.RecordFinish(recordCtx, id, runErr); err != nil {
+		log.Printf("Scheduler: failed to record result of job %s: %v", name, err)
+	}
+}
@@ -0,0 +1,472 @@
+// Package scheduler - Scheduler Tests
+//
+// 内蔵スケジューラーのテストを提供します。
+// テスト対象:
+//   - cron式・記述子の解析と次回の実行日時
+//   - 実行日時を過ぎたジョブの実行と重複実行の防止
+//   - 設定によるジョブの有効・無効
+//   - 管理者が変更したスケジュール・一時停止の反映
+//   - 実行履歴の記録と停止中に過ぎた実行日時のキャッチアップ
+//   - ジョブの手動実行（RunNow）
+//   - ジョブの実行のスパン
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestParseSchedule_Next はスケジュールの次回の実行日時のテストです。
+// 期待動作:
+//   - 分・時・日・月・曜日の条件に一致する最初の日時を返す
+//   - 日と曜日の両方を指定した場合はどちらかに一致すれば実行する
+//   - 記述子・@every に対応する
+func TestParseSchedule_Next(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	from := time.Date(2024, 1, 15, 9, 7, 30, 0, time.UTC) // 月曜日
+
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"0 * * * *", from, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{"*/5 * * * *", from, time.Date(2024, 1, 15, 9, 10, 0, 0, time.UTC)},
+		{"30 3 * * *", from, time.Date(2024, 1, 16, 3, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * 1-5", from, time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", from, time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 3", from, time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", from, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", from, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"@every 15m", from, time.Date(2024, 1, 15, 9, 22, 0, 0, time.UTC)},
+		{"0 3 * * *", from.In(jst), time.Date(2024, 1, 16, 3, 0, 0, 0, jst)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule failed: %v", err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParseSchedule_Invalid は不正なスケジュールのテストです。
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "@every 10s", "@fortnightly"} {
+		if _, err := ParseSchedule(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Expected ErrInvalidSchedule for %q, got %v", spec, err)
+		}
+	}
+
+	// 存在しない日付は実行しない（ゼロ値）
+	schedule, _ := ParseSchedule("0 0 30 2 *")
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Expected no next run for Feb 30, got %s", next)
+	}
+}
+
+// TestScheduler_RunDue は実行日時を過ぎたジョブの実行のテストです。
+// 期待動作:
+//   - 実行日時を過ぎたジョブのみ実行し、次回の実行日時を更新する
+//   - 前回の実行が終わっていないジョブはスキップする
+func TestScheduler_RunDue(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 15, 9, 0, 30, 0, time.UTC)
+	s := New(time.UTC)
+	s.now = func() time.Time { return start }
+
+	var mu sync.Mutex
+	runs := map[string]int{}
+	release := make(chan struct{})
+	_ = s.Add("fast", "* * * * *", func(ctx context.Context) error {
+		mu.Lock()
+		runs["fast"]++
+		mu.Unlock()
+		return nil
+	})
+	_ = s.Add("slow", "* * * * *", func(ctx context.Context) error {
+		mu.Lock()
+		runs["slow"]++
+		mu.Unlock()
+		<-release
+		return nil
+	})
+	_ = s.Add("hourly", "0 * * * *", func(ctx context.Context) error {
+		mu.Lock()
+		runs["hourly"]++
+		mu.Unlock()
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		j.next = j.schedule.Next(start)
+	}
+	s.mu.Unlock()
+
+	// Act
+	s.runDue(ctx, start.Add(time.Minute))
+	waitForRunning(t, s, "slow", true)
+	waitForRunning(t, s, "fast", false)
+	s.runDue(ctx, start.Add(2*time.Minute))
+	close(release)
+	s.wg.Wait()
+
+	// Assert
+	mu.Lock()
+	defer mu.Unlock()
+	if runs["fast"] != 2 || runs["slow"] != 1 || runs["hourly"] != 0 {
+		t.Errorf("Unexpected runs: %v", runs)
+	}
+	for _, job := range s.Jobs() {
+		if job.Name == "hourly" && !job.NextRun.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unexpected next run for hourly job: %s", job.NextRun)
+		}
+	}
+}
+
+// TestScheduler_RefreshSchedules はスケジュールの上書きの反映のテストです。
+// 期待動作:
+//   - 上書きしたスケジュールで次回の実行日時を再計算する
+//   - 一時停止したジョブは実行しない、再開すると次回の実行日時を再計算する
+//   - 不正なスケジュール・読み込みの失敗は現在のスケジュールのまま
+//   - 上書きがなくなると登録時のスケジュールに戻す
+func TestScheduler_RefreshSchedules(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 15, 9, 0, 30, 0, time.UTC)
+	s := New(time.UTC)
+	runs := 0
+	_ = s.Add("cleanup", "30 4 * * *", func(ctx context.Context) error {
+		runs++
+		return nil
+	})
+	_ = s.Add("notifications", "0 * * * *", func(ctx context.Context) error { return nil })
+	overrides := map[string]JobSchedule{}
+	var loadErr error
+	s.SetScheduleSource(func(ctx context.Context) (map[string]JobSchedule, error) {
+		return overrides, loadErr
+	})
+	ctx := context.Background()
+	status := func(name string) JobStatus {
+		for _, job := range s.Jobs() {
+			if job.Name == name {
+				return job
+			}
+		}
+		t.Fatalf("job %s not found", name)
+		return JobStatus{}
+	}
+
+	// Act & Assert: スケジュールの上書き
+	overrides["cleanup"] = JobSchedule{Schedule: "@every 1h", Enabled: true}
+	overrides["notifications"] = JobSchedule{Enabled: false}
+	s.refreshSchedules(ctx, start)
+	if got := status("cleanup"); got.Schedule != "@every 1h" || !got.NextRun.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected cleanup to be rescheduled, got %+v", got)
+	}
+	if got := status("notifications"); got.Enabled {
+		t.Errorf("Expected notifications to be paused, got %+v", got)
+	}
+
+	// 一時停止中のジョブは実行日時を過ぎても実行しない
+	overrides["cleanup"] = JobSchedule{Schedule: "@every 1h", Enabled: false}
+	s.refreshSchedules(ctx, start)
+	s.runDue(ctx, start.Add(2*time.Hour))
+	s.wg.Wait()
+	if runs != 0 {
+		t.Errorf("Expected paused job not to run, got %d runs", runs)
+	}
+
+	// 不正なスケジュール・読み込みの失敗
+	overrides["cleanup"] = JobSchedule{Schedule: "every hour", Enabled: true}
+	s.refreshSchedules(ctx, start)
+	if got := status("cleanup"); got.Schedule != "30 4 * * *" || !got.Enabled {
+		t.Errorf("Expected invalid override to fall back to the registered schedule, got %+v", got)
+	}
+	loadErr = errors.New("database unavailable")
+	overrides["cleanup"] = JobSchedule{Schedule: "@every 1h", Enabled: true}
+	s.refreshSchedules(ctx, start)
+	if got := status("cleanup"); got.Schedule != "30 4 * * *" {
+		t.Errorf("Expected schedule to be kept when loading fails, got %+v", got)
+	}
+
+	// 上書きの削除
+	loadErr = nil
+	delete(overrides, "notifications")
+	s.refreshSchedules(ctx, start)
+	if got := status("notifications"); !got.Enabled || got.Schedule != "0 * * * *" || !got.NextRun.Equal(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected notifications to resume with the registered schedule, got %+v", got)
+	}
+}
+
+// fakeRunHistory はテスト用の実行履歴です。
+type fakeRunHistory struct {
+	mu      sync.Mutex
+	last    map[string]time.Time
+	loadErr error
+	started []string // 実行開始を記録したジョブ名
+	catchUp map[string]time.Time
+	results map[uint]error
+}
+
+func (h *fakeRunHistory) LastScheduledAt(ctx context.Context, name string) (time.Time, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last[name], h.loadErr
+}
+
+func (h *fakeRunHistory) RecordStart(ctx context.Context, name string, scheduledAt time.Time, catchUp bool) (uint, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.started = append(h.started, name)
+	if catchUp {
+		h.catchUp[name] = scheduledAt
+	}
+	return uint(len(h.started)), nil
+}
+
+func (h *fakeRunHistory) RecordFinish(ctx context.Context, id uint, runErr error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[id] = runErr
+	return nil
+}
+
+// TestScheduler_CatchUp は停止中に過ぎた実行日時のキャッチアップのテストです。
+// 期待動作:
+//   - 最後の実行の後に実行日時を過ぎたジョブを1回だけ実行し、最後に過ぎた実行日時で記録する
+//   - 実行日時を過ぎていない・実行したことがない・一時停止中のジョブは実行しない
+//   - 実行履歴を読み込めない場合は次回の判定で再試行し、判定は1回だけ行う
+//   - 通常の実行も実行履歴に記録する
+func TestScheduler_CatchUp(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	s := New(time.UTC)
+	var mu sync.Mutex
+	runs := map[string]int{}
+	for _, name := range []string{"hourly", "daily", "fresh", "paused"} {
+		spec := "0 * * * *"
+		if name == "daily" {
+			spec = "30 4 * * *"
+		}
+		jobName := name
+		_ = s.Add(jobName, spec, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			runs[jobName]++
+			return nil
+		})
+	}
+	history := &fakeRunHistory{
+		last: map[string]time.Time{
+			"hourly": time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC),  // 7:00, 8:00, 9:00 を過ぎている
+			"daily":  time.Date(2024, 1, 15, 4, 30, 0, 0, time.UTC), // 次は翌日
+			"paused": time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC),
+		},
+		loadErr: errors.New("database unavailable"),
+		catchUp: map[string]time.Time{},
+		results: map[uint]error{},
+	}
+	s.SetRunHistory(history, true)
+	s.SetScheduleSource(func(ctx context.Context) (map[string]JobSchedule, error) {
+		return map[string]JobSchedule{"paused": {Enabled: false}}, nil
+	})
+	ctx := context.Background()
+	s.refreshSchedules(ctx, now)
+
+	// Act
+	s.catchUpMissed(ctx, now)
+	s.wg.Wait()
+	startedWhileUnavailable := len(history.started)
+	history.loadErr = nil
+	s.catchUpMissed(ctx, now)
+	s.wg.Wait()
+	s.catchUpMissed(ctx, now.Add(2*time.Hour))
+	s.wg.Wait()
+
+	// Assert
+	if startedWhileUnavailable != 0 {
+		t.Errorf("Expected no catch-up while history is unavailable, got %d", startedWhileUnavailable)
+	}
+	if runs["hourly"] != 1 || runs["daily"] != 0 || runs["fresh"] != 0 || runs["paused"] != 0 {
+		t.Errorf("Expected only hourly to be caught up once, got %v", runs)
+	}
+	if got := history.catchUp["hourly"]; !got.Equal(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected catch-up to be recorded for the last missed run (09:00), got %s", got)
+	}
+	if len(history.results) != 1 || history.results[1] != nil {
+		t.Errorf("Expected catch-up result to be recorded, got %v", history.results)
+	}
+
+	// 通常の実行
+	for _, job := range s.jobs {
+		job.next = now
+	}
+	s.runDue(ctx, now)
+	s.wg.Wait()
+	if len(history.started) != 4 || len(history.catchUp) != 1 {
+		t.Errorf("Expected 3 scheduled runs to be recorded, got started=%v", history.started)
+	}
+}
+
+// TestScheduler_RunNow はジョブの手動実行のテストです。
+// 期待動作:
+//   - 一時停止中のジョブも実行し、ジョブのエラーを返して実行履歴に記録する
+//   - 登録されていないジョブは ErrJobNotFound、実行中のジョブは ErrJobRunning
+func TestScheduler_RunNow(t *testing.T) {
+	// Arrange
+	s := New(time.UTC)
+	jobErr := errors.New("refresh failed")
+	release := make(chan struct{})
+	_ = s.Add("failing", "0 * * * *", func(ctx context.Context) error { return jobErr })
+	_ = s.Add("slow", "0 * * * *", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	history := &fakeRunHistory{catchUp: map[string]time.Time{}, results: map[uint]error{}}
+	s.SetRunHistory(history, false)
+	s.SetScheduleSource(func(ctx context.Context) (map[string]JobSchedule, error) {
+		return map[string]JobSchedule{"failing": {Enabled: false}}, nil
+	})
+	ctx := context.Background()
+	s.refreshSchedules(ctx, time.Now())
+
+	// Act
+	failingErr := s.RunNow(ctx, "failing")
+	missingErr := s.RunNow(ctx, "missing")
+	slowDone := make(chan error, 1)
+	go func() { slowDone <- s.RunNow(ctx, "slow") }()
+	waitForRunning(t, s, "slow", true)
+	runningErr := s.RunNow(ctx, "slow")
+	close(release)
+
+	// Assert
+	if !errors.Is(failingErr, jobErr) {
+		t.Errorf("Expected job error, got %v", failingErr)
+	}
+	if !errors.Is(missingErr, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", missingErr)
+	}
+	if !errors.Is(runningErr, ErrJobRunning) {
+		t.Errorf("Expected ErrJobRunning, got %v", runningErr)
+	}
+	if err := <-slowDone; err != nil {
+		t.Errorf("Expected slow job to succeed, got %v", err)
+	}
+	if len(history.started) != 2 || !errors.Is(history.results[1], jobErr) {
+		t.Errorf("Expected manual runs to be recorded, got started=%v results=%v", history.started, history.results)
+	}
+}
+
+// TestScheduler_ExecuteSpan はジョブの実行のスパンのテストです。
+// 期待動作:
+//   - 実行ごとに "scheduler.run {ジョブ名}" のスパンを記録し、ジョブのコンテキストに設定する
+//   - ジョブのエラー・パニックはスパンのエラー
+func TestScheduler_ExecuteSpan(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	s := New(time.UTC)
+	var jobSpan trace.SpanContext
+	_ = s.Add("analytics_refresh", "0 3 * * *", func(ctx context.Context) error {
+		jobSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	_ = s.Add("season_rollover", "0 6 1 1 *", func(ctx context.Context) error { panic("unexpected season") })
+	ctx := context.Background()
+
+	// Act
+	refreshErr := s.RunNow(ctx, "analytics_refresh")
+	rolloverErr := s.RunNow(ctx, "season_rollover")
+
+	// Assert
+	spans := recorder.Ended()
+	if refreshErr != nil || rolloverErr == nil || len(spans) != 2 {
+		t.Fatalf("Expected 2 runs with a panic, got %v / %v (%d spans)", refreshErr, rolloverErr, len(spans))
+	}
+	if spans[0].Name() != "scheduler.run analytics_refresh" || spans[0].SpanContext().SpanID() != jobSpan.SpanID() {
+		t.Errorf("Expected the job span on the job context, got %s", spans[0].Name())
+	}
+	if spans[0].Status().Code == codes.Error || spans[1].Status().Code != codes.Error {
+		t.Errorf("Expected an error span only for the panic, got %v / %v", spans[0].Status(), spans[1].Status())
+	}
+}
+
+// waitForRunning はジョブの実行中の状態が running になるまで待ちます。
+func waitForRunning(t *testing.T, s *Scheduler, name string, running bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, job := range s.Jobs() {
+			if job.Name == name && job.Running == running {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach running=%v", name, running)
+}
+
+// TestNewFromConfig は設定によるジョブの登録のテストです。
+// 期待動作:
+//   - 無効にしたジョブは登録しない
+//   - 通知送信が設定されていない場合は通知のジョブを登録しない
+//   - 不正なスケジュール・タイムゾーンはエラー
+func TestNewFromConfig(t *testing.T) {
+	// Arrange
+	svc := service.NewService(repository.NewMockRepositories())
+	jobs := map[string]config.SchedulerJobConfig{}
+	for _, def := range config.SchedulerJobNames {
+		jobs[def.Name] = config.SchedulerJobConfig{Enabled: true, Schedule: def.Schedule}
+	}
+	jobs[config.SchedulerJobAnalyticsRefresh] = config.SchedulerJobConfig{Enabled: false, Schedule: "0 3 * * *"}
+	cfg := config.SchedulerConfig{EmbeddedEnabled: true, Timezone: "Asia/Tokyo", Jobs: jobs}
+
+	// Act
+	s, err := NewFromConfig(cfg, svc, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NewFromConfig failed: %v", err)
+	}
+	names := map[string]bool{}
+	for _, job := range s.Jobs() {
+		names[job.Name] = true
+	}
+	if names[config.SchedulerJobAnalyticsRefresh] || names[config.SchedulerJobNotifications] {
+		t.Errorf("Expected disabled and notification jobs to be skipped, got %v", names)
+	}
+	if !names[config.SchedulerJobDeviceTokenPrune] || !names[config.SchedulerJobNotificationLogCleanup] {
+		t.Errorf("Expected cleanup jobs to be registered, got %v", names)
+	}
+
+	jobs[config.SchedulerJobDeviceTokenPrune] = config.SchedulerJobConfig{Enabled: true, Schedule: "every day"}
+	if _, err := NewFromConfig(cfg, svc, nil); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("Expected ErrInvalidSchedule, got %v", err)
+	}
+	cfg.Timezone = "Mars/Olympus"
+	if _, err := NewFromConfig(cfg, svc, nil); err == nil {
+		t.Error("Expected invalid timezone error")
+	}
+}
@@ -0,0 +1,125 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Demo - デモの菜園
+// =============================================================================
+
+// demoSet はデモの菜園のフィクスチャセットです。
+// 日付は Now を基準にするため、投入直後からダッシュボード・カレンダー・分析に表示されます。
+var demoSet = Set{
+	Name:        "demo",
+	Description: "デモの菜園（区画・作物・成長記録・収穫記録・タスク）",
+	load:        loadDemo,
+}
+
+// loadDemo はユーザーにデモの菜園のデータを投入します。
+// 記録は1件ずつサービスで作成するため、区画への配置・リアルタイムの通知などは API と同じように動作します。
+func loadDemo(ctx context.Context, svc *service.Service, opts Options) (*Summary, error) {
+	summary := &Summary{}
+	userID := opts.UserID
+	day := func(offset int) time.Time {
+		return opts.Now.AddDate(0, 0, offset).Truncate(time.Hour)
+	}
+
+	// 区画
+	plots := []*model.Plot{
+		{UserID: userID, Name: "南側の畝", Width: 1.2, Height: 4, SoilType: "loamy", Sunlight: "full_sun", Status: "available"},
+		{UserID: userID, Name: "プランター", Width: 0.6, Height: 0.3, SoilType: "sandy", Sunlight: "partial_shade", Status: "available"},
+	}
+	for _, plot := range plots {
+		if err := Validate(plot); err != nil {
+			return summary, err
+		}
+		if err := svc.CreatePlot(ctx, plot); err != nil {
+			return summary, fmt.Errorf("failed to create plot %s: %w", plot.Name, err)
+		}
+		summary.Plots++
+	}
+
+	// 作物（収穫済み・生育中・植え付け直後）
+	crops := []*model.Crop{
+		{UserID: userID, Name: "トマト", Variety: "桃太郎", PlantedDate: day(-100), ExpectedHarvestDate: day(-20), Status: "harvested"},
+		{UserID: userID, Name: "きゅうり", Variety: "夏すずみ", PlantedDate: day(-40), ExpectedHarvestDate: day(10), Status: "growing"},
+		{UserID: userID, Name: "バジル", PlantedDate: day(-7), ExpectedHarvestDate: day(50), Status: "planted"},
+	}
+	for _, crop := range crops {
+		if err := Validate(crop); err != nil {
+			return summary, err
+		}
+		if err := svc.CreateCrop(ctx, crop); err != nil {
+			return summary, fmt.Errorf("failed to create crop %s: %w", crop.Name, err)
+		}
+		summary.Crops++
+	}
+	tomato, cucumber, basil := crops[0], crops[1], crops[2]
+	if _, err := svc.AssignCropToPlot(ctx, plots[0].ID, cucumber.ID, cucumber.PlantedDate); err != nil {
+		return summary, fmt.Errorf("failed to assign crop %s: %w", cucumber.Name, err)
+	}
+	if _, err := svc.AssignCropToPlot(ctx, plots[1].ID, basil.ID, basil.PlantedDate); err != nil {
+		return summary, fmt.Errorf("failed to assign crop %s: %w", basil.Name, err)
+	}
+
+	// 成長記録
+	records := []*model.GrowthRecord{
+		{CropID: tomato.ID, RecordDate: day(-80), GrowthStage: "vegetative", Notes: "脇芽を摘んだ"},
+		{CropID: tomato.ID, RecordDate: day(-55), GrowthStage: "flowering"},
+		{CropID: tomato.ID, RecordDate: day(-35), GrowthStage: "fruiting", Notes: "実が色づき始めた"},
+		{CropID: cucumber.ID, RecordDate: day(-30), GrowthStage: "seedling"},
+		{CropID: cucumber.ID, RecordDate: day(-10), GrowthStage: "flowering"},
+	}
+	for _, record := range records {
+		if err := Validate(record); err != nil {
+			return summary, err
+		}
+		if err := svc.CreateGrowthRecord(ctx, record); err != nil {
+			return summary, fmt.Errorf("failed to create growth record: %w", err)
+		}
+		summary.GrowthRecords++
+	}
+
+	// 収穫記録
+	harvests := []*model.Harvest{
+		{CropID: tomato.ID, HarvestDate: day(-25), Quantity: 1.2, QuantityUnit: "kg", Quality: "excellent"},
+		{CropID: tomato.ID, HarvestDate: day(-18), Quantity: 850, QuantityUnit: "g", Quality: "good"},
+		{CropID: tomato.ID, HarvestDate: day(-10), Quantity: 12, QuantityUnit: "pieces", Quality: "fair", Notes: "最後の収穫"},
+		{CropID: cucumber.ID, HarvestDate: day(-2), Quantity: 3, QuantityUnit: "pieces", Quality: "good"},
+	}
+	for _, harvest := range harvests {
+		if err := Validate(harvest); err != nil {
+			return summary, err
+		}
+		if err := svc.CreateHarvest(ctx, harvest); err != nil {
+			return summary, fmt.Errorf("failed to create harvest: %w", err)
+		}
+		summary.Harvests++
+	}
+
+	// タスク（完了済み・期限切れ・今後・繰り返し）
+	completedAt := day(-3)
+	tasks := []*model.Task{
+		{UserID: userID, Title: "トマトの片付け", DueDate: day(-3), Priority: "low", Status: "completed", CompletedAt: &completedAt},
+		{UserID: userID, Title: "追肥", Description: "きゅうりに化成肥料", DueDate: day(-1), Priority: "high", Status: "pending"},
+		{UserID: userID, Title: "支柱の補強", DueDate: day(2), Priority: "medium", Status: "pending"},
+		{UserID: userID, Title: "水やり", DueDate: day(1), Priority: "medium", Status: "pending", Recurrence: "daily", RecurrenceInterval: 1},
+		{UserID: userID, Title: "病害虫のチェック", DueDate: day(5), Priority: "medium", Status: "pending", Recurrence: "weekly", RecurrenceInterval: 1},
+	}
+	for _, task := range tasks {
+		if err := Validate(task); err != nil {
+			return summary, err
+		}
+		if err := svc.CreateTask(ctx, task); err != nil {
+			return summary, fmt.Errorf("failed to create task %s: %w", task.Title, err)
+		}
+		summary.Tasks++
+	}
+	return summary, nil
+}
@@ -0,0 +1,105 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Load Test - 負荷試験用の大量の収穫記録
+// =============================================================================
+
+const (
+	// DefaultLoadTestHarvests は loadtest の収穫記録のデフォルトの件数です。
+	DefaultLoadTestHarvests = 100000
+	// loadTestHarvestsPerCrop は loadtest の作物1件あたりの収穫記録の件数です。
+	loadTestHarvestsPerCrop = 500
+	// loadTestChunkSize は1回の一括作成（1つのトランザクション）で作成する収穫記録の件数です。
+	loadTestChunkSize = 5000
+	// loadTestHistoryDays は loadtest の作物を植え付ける期間（基準日時より前の日数）です。
+	loadTestHistoryDays = 3 * 365
+)
+
+// loadTestCropNames は loadtest の作物の名前です（順に繰り返す）。
+var loadTestCropNames = []string{"トマト", "きゅうり", "ナス", "ピーマン", "オクラ", "ゴーヤ", "ズッキーニ", "いちご"}
+
+// loadTestSet は負荷試験用のフィクスチャセットです。
+var loadTestSet = Set{
+	Name:        "loadtest",
+	Description: fmt.Sprintf("負荷試験用の作物と大量の収穫記録（デフォルト %d 件、--harvests で変更）", DefaultLoadTestHarvests),
+	load:        loadLoadTest,
+}
+
+// loadLoadTest はユーザーに負荷試験用の作物と収穫記録を投入します。
+// 同じ指定では同じデータになるよう、日付・数量は固定のシードの乱数で決めます。
+// 記録は一括作成（CreateBatch）で loadTestChunkSize 件ずつ作成するため、途中で失敗した場合はそれまでのチャンクが残ります。
+func loadLoadTest(ctx context.Context, svc *service.Service, opts Options) (*Summary, error) {
+	summary := &Summary{}
+	total := opts.Harvests
+	if total <= 0 {
+		total = DefaultLoadTestHarvests
+	}
+	rng := rand.New(rand.NewPCG(1, uint64(total)))
+	base := opts.Now.Truncate(24 * time.Hour)
+
+	// 作物（収穫済み、loadTestHarvestsPerCrop 件ずつ収穫記録を作成する）
+	crops := make([]model.Crop, (total+loadTestHarvestsPerCrop-1)/loadTestHarvestsPerCrop)
+	for i := range crops {
+		planted := base.AddDate(0, 0, -120-rng.IntN(loadTestHistoryDays))
+		crops[i] = model.Crop{
+			UserID:              opts.UserID,
+			Name:                loadTestCropNames[i%len(loadTestCropNames)],
+			Variety:             fmt.Sprintf("負荷試験 %d", i+1),
+			PlantedDate:         planted,
+			ExpectedHarvestDate: planted.AddDate(0, 0, 60),
+			Status:              "harvested",
+		}
+		if err := Validate(&crops[i]); err != nil {
+			return summary, err
+		}
+	}
+	if err := svc.CreateCrops(ctx, crops); err != nil {
+		return summary, fmt.Errorf("failed to create crops: %w", err)
+	}
+	summary.Crops = len(crops)
+
+	// 収穫記録（植え付けの60〜120日後）
+	units := []string{"kg", "g", "pieces"}
+	qualities := []string{"excellent", "good", "fair", "poor"}
+	chunk := make([]model.Harvest, 0, min(total, loadTestChunkSize))
+	for i := 0; i < total; i++ {
+		crop := crops[i/loadTestHarvestsPerCrop]
+		unit := units[rng.IntN(len(units))]
+		quantity := 0.1 + rng.Float64()*5
+		switch unit {
+		case "g":
+			quantity *= 1000
+		case "pieces":
+			quantity = float64(1 + rng.IntN(30))
+		}
+		harvest := model.Harvest{
+			CropID:       crop.ID,
+			HarvestDate:  crop.PlantedDate.AddDate(0, 0, 60+rng.IntN(61)),
+			Quantity:     quantity,
+			QuantityUnit: unit,
+			Quality:      qualities[rng.IntN(len(qualities))],
+		}
+		if err := Validate(&harvest); err != nil {
+			return summary, err
+		}
+		chunk = append(chunk, harvest)
+		if len(chunk) == cap(chunk) || i == total-1 {
+			if err := svc.CreateHarvests(ctx, chunk); err != nil {
+				return summary, fmt.Errorf("failed to create harvests: %w", err)
+			}
+			summary.Harvests += len(chunk)
+			chunk = chunk[:0]
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,136 @@
+// Package seed - フィクスチャセットの投入
+//
+// 開発・ステージング・負荷試験の環境に、名前で選んだフィクスチャセットのデータを投入します（cmd/seed・cmd/admin seed）。
+//   - demo: デモの菜園（区画・作物・成長記録・収穫記録・タスク）
+//   - loadtest: 負荷試験用の大量の収穫記録（デフォルト 100,000 件）
+//
+// 投入する記録は API のリクエストと同じ検証（Validate）を通してから作成するため、
+// 検証・データベースの制約が変わった場合はフィクスチャの誤りとして投入の前にエラーになります。
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Fixture Sets - フィクスチャセット
+// =============================================================================
+
+// MinPasswordLength はシードのユーザーのパスワードの最小文字数です（POST /api/v1/auth/register と同じ）。
+const MinPasswordLength = 8
+
+// ErrUnknownSet は登録されていないフィクスチャセットを指定した場合のエラー
+var ErrUnknownSet = errors.New("unknown fixture set")
+
+// Options はフィクスチャセットの投入の指定です。
+type Options struct {
+	UserID   uint      // データを投入するユーザーのID
+	Now      time.Time // 日付の基準日時（ゼロ値の場合は現在時刻）
+	Harvests int       // loadtest の収穫記録の件数（0以下の場合は DefaultLoadTestHarvests）
+}
+
+// Summary は投入した記録の件数です。
+type Summary struct {
+	Plots         int
+	Crops         int
+	GrowthRecords int
+	Harvests      int
+	Tasks         int
+}
+
+// String は投入した件数を1行で返します。
+func (s *Summary) String() string {
+	return fmt.Sprintf("%d plots, %d crops, %d growth records, %d harvests, %d tasks",
+		s.Plots, s.Crops, s.GrowthRecords, s.Harvests, s.Tasks)
+}
+
+// Set は名前で選ぶフィクスチャセットです。
+type Set struct {
+	Name        string
+	Description string
+	load        func(ctx context.Context, svc *service.Service, opts Options) (*Summary, error)
+}
+
+// Load はフィクスチャセットのデータをユーザーに投入します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - svc: サービス（記録はサービス経由で作成する）
+//   - opts: 投入の指定
+//
+// 戻り値:
+//   - *Summary: 投入した件数（エラーの場合もそれまでに投入した件数）
+//   - error: 検証・投入に失敗した場合のエラー
+func (s Set) Load(ctx context.Context, svc *service.Service, opts Options) (*Summary, error) {
+	if opts.UserID == 0 {
+		return &Summary{}, errors.New("seed: user ID is required")
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	return s.load(ctx, svc, opts)
+}
+
+// sets は登録済みのフィクスチャセットです（名前をキーとする）。
+var sets = map[string]Set{
+	demoSet.Name:     demoSet,
+	loadTestSet.Name: loadTestSet,
+}
+
+// Sets は登録済みのフィクスチャセットを名前の順に返します。
+func Sets() []Set {
+	result := make([]Set, 0, len(sets))
+	for _, set := range sets {
+		result = append(result, set)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Lookup は名前でフィクスチャセットを取得します。
+//
+// 戻り値:
+//   - Set: フィクスチャセット
+//   - error: 登録されていない名前の場合は ErrUnknownSet
+func Lookup(name string) (Set, error) {
+	set, ok := sets[name]
+	if !ok {
+		return Set{}, fmt.Errorf("%w: %q", ErrUnknownSet, name)
+	}
+	return set, nil
+}
+
+// CreateUser はシードのデータを投入するユーザーを作成します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - svc: サービス
+//   - email: メールアドレス（既に存在する場合は service.ErrEmailAlreadyExists）
+//   - password: パスワード（MinPasswordLength 文字以上）
+//   - displayName: 表示名
+//
+// 戻り値:
+//   - *model.User: 作成したユーザー
+//   - error: 作成に失敗した場合のエラー
+func CreateUser(ctx context.Context, svc *service.Service, email, password, displayName string) (*model.User, error) {
+	if len(password) < MinPasswordLength {
+		return nil, fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	hashed, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user, err := svc.RegisterUser(ctx, email, hashed, displayName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seed user %s: %w", email, err)
+	}
+	return user, nil
+}
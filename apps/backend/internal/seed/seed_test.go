@@ -0,0 +1,168 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/service"
+)
+
+// =============================================================================
+// Seed Tests - フィクスチャセットのテスト
+// =============================================================================
+// テスト対象:
+//   - Sets / Lookup: 登録済みのフィクスチャセット
+//   - Validate: API のリクエストと同じ規則の検証
+//   - demo / loadtest: モックリポジトリへの投入
+
+// newTestUser はモックリポジトリのサービスとシードのユーザーを作成します。
+func newTestUser(t *testing.T) (*service.Service, *model.User) {
+	t.Helper()
+	svc := service.NewService(repository.NewMockRepositories())
+	user, err := CreateUser(context.Background(), svc, "seed@example.com", "password123", "シード")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return svc, user
+}
+
+// TestLookup はフィクスチャセットの取得のテストです。
+// 期待動作:
+//   - demo・loadtest を名前の順に返す
+//   - 登録されていない名前は ErrUnknownSet
+func TestLookup(t *testing.T) {
+	// Act
+	var names []string
+	for _, set := range Sets() {
+		names = append(names, set.Name)
+	}
+	demo, demoErr := Lookup("demo")
+	_, unknownErr := Lookup("missing")
+
+	// Assert
+	if strings.Join(names, ",") != "demo,loadtest" {
+		t.Errorf("Expected demo and loadtest, got %v", names)
+	}
+	if demoErr != nil || demo.Name != "demo" || demo.Description == "" {
+		t.Errorf("Expected the demo set, got %+v (%v)", demo, demoErr)
+	}
+	if !errors.Is(unknownErr, ErrUnknownSet) {
+		t.Errorf("Expected ErrUnknownSet, got %v", unknownErr)
+	}
+}
+
+// TestValidate はフィクスチャの検証のテストです。
+// 期待動作:
+//   - API のリクエストの validate タグ（品質・単位・土壌・ステータスなど）に違反する記録はエラーで、項目名を含む
+//   - 植え付け日が予想収穫日より後の作物はエラー
+//   - 有効な記録・対応しない型
+func TestValidate(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		record  any
+		wantErr string // 空の場合はエラーなし
+	}{
+		{"valid harvest", &model.Harvest{HarvestDate: day, Quantity: 1, QuantityUnit: "kg", Quality: "good"}, ""},
+		{"invalid quality", &model.Harvest{HarvestDate: day, Quantity: 1, QuantityUnit: "kg", Quality: "great"}, "quality"},
+		{"zero quantity", &model.Harvest{HarvestDate: day, QuantityUnit: "kg"}, "quantity"},
+		{"invalid soil type", &model.Plot{Name: "畝", Width: 1, Height: 1, SoilType: "rocky"}, "soil_type"},
+		{"invalid crop status", &model.Crop{Name: "トマト", PlantedDate: day, ExpectedHarvestDate: day, Status: "eaten"}, "status"},
+		{"crop dates", &model.Crop{Name: "トマト", PlantedDate: day.AddDate(0, 0, 1), ExpectedHarvestDate: day}, "planted_date"},
+		{"invalid task priority", &model.Task{Title: "水やり", DueDate: day, Priority: "urgent", Status: "pending"}, "priority"},
+		{"invalid growth stage", &model.GrowthRecord{RecordDate: day, GrowthStage: "ripe"}, "growth_stage"},
+		{"unsupported", &model.User{}, "unsupported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := Validate(tt.record)
+
+			// Assert
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error mentioning %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestLoad_Demo はデモの菜園の投入のテストです。
+// 期待動作:
+//   - 区画・作物・成長記録・収穫記録・タスクを作成し、生育中の作物を区画に配置する
+//   - ユーザーIDがない場合はエラー
+func TestLoad_Demo(t *testing.T) {
+	// Arrange
+	svc, user := newTestUser(t)
+	ctx := context.Background()
+	demo, _ := Lookup("demo")
+
+	// Act
+	summary, err := demo.Load(ctx, svc, Options{UserID: user.ID})
+	_, noUserErr := demo.Load(ctx, svc, Options{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := summary.String(); got != "2 plots, 3 crops, 5 growth records, 4 harvests, 5 tasks" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+	crops, _ := svc.GetUserCrops(ctx, user.ID)
+	plots, _ := svc.GetUserPlots(ctx, user.ID)
+	if len(crops) != 3 || len(plots) != 2 {
+		t.Errorf("Expected 3 crops and 2 plots, got %d / %d", len(crops), len(plots))
+	}
+	if noUserErr == nil {
+		t.Error("Expected an error without a user ID")
+	}
+}
+
+// TestLoad_LoadTest は負荷試験用のデータの投入のテストです。
+// 期待動作:
+//   - 指定した件数の収穫記録を作物ごとに loadTestHarvestsPerCrop 件ずつ作成する（チャンクの境界をまたぐ）
+//   - 収穫日は植え付け日より後で、基準日時より前
+func TestLoad_LoadTest(t *testing.T) {
+	// Arrange
+	svc, user := newTestUser(t)
+	ctx := context.Background()
+	loadTest, _ := Lookup("loadtest")
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	total := loadTestChunkSize + 250
+
+	// Act
+	summary, err := loadTest.Load(ctx, svc, Options{UserID: user.ID, Now: now, Harvests: total})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	wantCrops := (total + loadTestHarvestsPerCrop - 1) / loadTestHarvestsPerCrop
+	if summary.Harvests != total || summary.Crops != wantCrops {
+		t.Fatalf("Expected %d harvests for %d crops, got %s", total, wantCrops, summary)
+	}
+	crops, _ := svc.GetUserCrops(ctx, user.ID)
+	if len(crops) != wantCrops {
+		t.Fatalf("Expected %d crops, got %d", wantCrops, len(crops))
+	}
+	harvests, _ := svc.GetCropHarvests(ctx, crops[0].ID)
+	if len(harvests) == 0 || len(harvests) > loadTestHarvestsPerCrop {
+		t.Fatalf("Expected up to %d harvests for the crop, got %d", loadTestHarvestsPerCrop, len(harvests))
+	}
+	for _, harvest := range harvests {
+		if !harvest.HarvestDate.After(crops[0].PlantedDate) || !harvest.HarvestDate.Before(now) {
+			t.Errorf("Harvest date %v outside (%v, %v)", harvest.HarvestDate, crops[0].PlantedDate, now)
+			break
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+	"github.com/secure-scorecard/backend/internal/handler"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/validator"
+)
+
+// =============================================================================
+// Validation - フィクスチャの検証
+// =============================================================================
+// 記録を API のリクエストの構造体（handler.Create*Request・Update*Request）に詰め替え、
+// API と同じ validate タグで検証します。リクエストにない項目（ステータスなど）は更新のリクエストで検証します。
+// 検証の規則をフィクスチャ用に複製しないため、API の検証を変更するとシードも同じ規則になります。
+
+// fixtureValidator は API と同じ検証です。
+var fixtureValidator = validator.NewValidator()
+
+// Validate はフィクスチャの記録を API のリクエストと同じ規則で検証します。
+//
+// 引数:
+//   - record: *model.Plot, *model.Crop, *model.GrowthRecord, *model.Harvest, *model.Task のいずれか
+//
+// 戻り値:
+//   - error: 検証に失敗した場合は失敗した項目を含むエラー（API と同じ VALIDATION_ERROR の AppError をラップ）、対応しない型の場合もエラー
+func Validate(record any) error {
+	var requests []any
+	switch r := record.(type) {
+	case *model.Plot:
+		requests = []any{handler.CreatePlotRequest{
+			Name: r.Name, Width: r.Width, Height: r.Height, SoilType: r.SoilType, Sunlight: r.Sunlight,
+			PositionX: r.PositionX, PositionY: r.PositionY, Notes: r.Notes,
+		}}
+	case *model.Crop:
+		if r.PlantedDate.After(r.ExpectedHarvestDate) {
+			return fmt.Errorf("crop %q: planted_date must be before or equal to expected_harvest_date", r.Name)
+		}
+		requests = []any{
+			handler.CreateCropRequest{
+				Name: r.Name, Variety: r.Variety, PlantedDate: r.PlantedDate, ExpectedHarvestDate: r.ExpectedHarvestDate, Notes: r.Notes,
+			},
+			handler.UpdateCropRequest{Status: r.Status},
+		}
+	case *model.GrowthRecord:
+		requests = []any{handler.CreateGrowthRecordRequest{
+			RecordDate: r.RecordDate, GrowthStage: r.GrowthStage, Notes: r.Notes, ImageURL: r.ImageURL,
+		}}
+	case *model.Harvest:
+		requests = []any{handler.CreateHarvestRequest{
+			HarvestDate: r.HarvestDate, Quantity: r.Quantity, QuantityUnit: r.QuantityUnit, Quality: r.Quality, Notes: r.Notes,
+		}}
+	case *model.Task:
+		requests = []any{
+			handler.CreateTaskRequest{
+				Title: r.Title, Description: r.Description, DueDate: r.DueDate, Priority: r.Priority, PlantID: r.PlantID,
+				Recurrence: r.Recurrence, RecurrenceInterval: r.RecurrenceInterval,
+				MaxOccurrences: r.MaxOccurrences, RecurrenceEndDate: r.RecurrenceEndDate,
+			},
+			handler.UpdateTaskRequest{Status: r.Status},
+		}
+	default:
+		return fmt.Errorf("seed: unsupported fixture record %T", record)
+	}
+
+	for _, req := range requests {
+		if err := fixtureValidator.Validate(req); err != nil {
+			var appErr *apperrors.AppError
+			if errors.As(err, &appErr) {
+				if fields, ok := appErr.Details.(validator.FieldErrors); ok {
+					return fmt.Errorf("invalid %T fixture %v: %w", record, fieldMessages(fields), err)
+				}
+			}
+			return fmt.Errorf("invalid %T fixture: %w", record, err)
+		}
+	}
+	return nil
+}
+
+// fieldMessages は検証に失敗した項目のメッセージの一覧です。
+func fieldMessages(fields validator.FieldErrors) []string {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return messages
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Analytics Cache - グラフ・集計のキャッシュのバージョン
+// =============================================================================
+// グラフ・集計のレスポンスの ETag は、レスポンスのボディではなくデータのバージョンから作成します。
+// クライアント・CDN の持つ ETag がバージョンと一致する場合は、集計を実行せずに 304 を返せます。
+//
+// データのバージョンはスコープ（ユーザー・組織）ごとに保持し、作物・収穫記録・区画の変更と
+// マテリアライズドビューのリフレッシュで増やします（invalidateAnalytics / AnalyticsViewsScope）。
+// 日付の変わり目で内容が変わるグラフ（今年のヒートマップなど）のため、ETag には UTC の日付も含めます。
+
+// AnalyticsViewsScope はマテリアライズドビューのデータのバージョンのスコープです（全ユーザー共通）。
+const AnalyticsViewsScope = "views"
+
+// analyticsUserScope はユーザーのデータのバージョンのスコープです。
+func analyticsUserScope(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// analyticsOrganizationScope は組織のデータのバージョンのスコープです。
+func analyticsOrganizationScope(organizationID uint) string {
+	return fmt.Sprintf("org:%d", organizationID)
+}
+
+// AnalyticsETag はリクエストのスコープのデータのバージョンから、グラフ・集計のレスポンスの ETag を作成します。
+// ETag はユーザー・組織のスコープ・リソース（パスとクエリ）で異なるため、
+// 共有キャッシュが他のユーザーのレスポンスの ETag で再検証しても一致しません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト（組織のスコープを含む）
+//   - userID: ユーザーID
+//   - resource: レスポンスを識別する文字列（パスと正規化したクエリ）
+//
+// 戻り値:
+//   - string: ETag（引用符を含む）
+//   - error: バージョンの取得に失敗した場合のエラー
+func (s *Service) AnalyticsETag(ctx context.Context, userID uint, resource string) (string, error) {
+	scope := analyticsUserScope(userID)
+	organizationID, scoped := repository.OrganizationFromContext(ctx)
+	if scoped && organizationID != 0 {
+		scope = analyticsOrganizationScope(organizationID)
+	}
+
+	version, err := s.repos.AnalyticsView().GetDataVersion(ctx, []string{scope, AnalyticsViewsScope})
+	if err != nil {
+		return "", err
+	}
+
+	// 個人のスコープ（X-Org-ID: 0）とスコープなしは対象の作物が異なるため、ETag を分ける
+	key := fmt.Sprintf("%d|%s|%t|%d|%s|%s", userID, scope, scoped, version, s.now().UTC().Format("2006-01-02"), resource)
+	sum := sha256.Sum256([]byte(key))
+	return `"a` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// invalidateAnalytics は作物・収穫記録・区画の変更で、所有者と組織のグラフ・集計のキャッシュを無効にします。
+// トランザクション内で呼び出した場合は、記録の変更がロールバックされるとバージョンも戻ります。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト（トランザクションを含む）
+//   - userID: 記録の所有者のユーザーID
+//   - organizationID: 記録の組織ID（個人の記録の場合は nil）
+func (s *Service) invalidateAnalytics(ctx context.Context, userID uint, organizationID *uint) error {
+	scopes := []string{analyticsUserScope(userID)}
+	if organizationID != nil {
+		scopes = append(scopes, analyticsOrganizationScope(*organizationID))
+	}
+	return s.repos.AnalyticsView().BumpDataVersion(ctx, scopes...)
+}
+
+// invalidateAnalyticsAfterWrite はトランザクション外の記録の変更の後にキャッシュを無効にします。
+// 記録は変更済みのため失敗はエラーにせず、ETag の日付が変わるまで古いレスポンスが返る場合があります。
+func (s *Service) invalidateAnalyticsAfterWrite(ctx context.Context, userID uint, organizationID *uint) {
+	if err := s.invalidateAnalytics(ctx, userID, organizationID); err != nil {
+		logging.FromContext(ctx).Warn("Failed to invalidate analytics cache", "owner_id", userID, "error", err)
+	}
+}
@@ -7,11 +7,18 @@
 //   - 収穫量集計（GetHarvestSummary）
 //   - グラフデータ生成（GetChartData）
 //   - CSVエクスポート（ExportCSV）
+//   - エクスポート履歴（RecordExport, GetUserExports, GetExportRecord）
+//   - エクスポートの匿名化（ExportCSVWithOptions）
+//   - 収穫ヒートマップ（harvest_heatmap）
+//   - 作物のシーズン比較（crop_seasons）
+//   - 匿名ベンチマーク（crop_benchmark）
 package service
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -882,3 +889,707 @@ func TestExportCSV_BOMPresent(t *testing.T) {
 		t.Error("CSV should start with UTF-8 BOM for Excel compatibility")
 	}
 }
+
+// =============================================================================
+// Crop Benchmark テスト
+// =============================================================================
+
+// setupBenchmarkUsers はベンチマークテスト用にユーザーと集計データをセットアップします。
+func setupBenchmarkUsers(mockRepos *repository.MockRepositories, optIn bool, peerCount int) {
+	mockRepos.GetMockUserRepository().Users[1] = &model.User{BaseModel: model.BaseModel{ID: 1}, BenchmarkOptIn: optIn}
+
+	// 自分: 10kg / 5m² = 2.0 kg/m²
+	yields := []repository.UserCropYield{{UserID: 1, TotalKg: 10, AreaM2: 5}}
+	// 比較対象: 1.0, 2.0, 3.0, ... kg/m²
+	for i := 0; i < peerCount; i++ {
+		yields = append(yields, repository.UserCropYield{UserID: uint(100 + i), TotalKg: float64(i + 1), AreaM2: 1})
+	}
+	mockRepos.GetMockHarvestRepository().BenchmarkYields["トマト"] = yields
+}
+
+// TestGetChartData_CropBenchmark_Success はベンチマーク集計値の計算をテストします。
+// 比較対象が BenchmarkQuantileMinUsers 未満の場合は平均のみを返すことを確認します。
+func TestGetChartData_CropBenchmark_Success(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	setupBenchmarkUsers(mockRepos, true, 5)
+
+	// Act
+	result, err := svc.GetChartData(context.Background(), 1, ChartTypeCropBenchmark, ChartFilter{CropName: "トマト"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data := result.Data.(CropBenchmarkData)
+	if !data.Available {
+		t.Fatal("Expected benchmark to be available")
+	}
+	if data.PeerCount != 5 {
+		t.Errorf("Expected 5 peers (self excluded), got %d", data.PeerCount)
+	}
+	if *data.PeerAverage != 3.0 {
+		t.Errorf("Expected average 3.0, got %f", *data.PeerAverage)
+	}
+	if *data.UserKgPerM2 != 2.0 {
+		t.Errorf("Expected own value 2.0, got %f", *data.UserKgPerM2)
+	}
+	// 5人の四分位数は2・3・4番目のユーザーの値そのものになるため返さない
+	if data.QuantilesAvailable || data.PeerMedian != nil || data.PeerP25 != nil || data.PeerP75 != nil || data.Percentile != nil {
+		t.Errorf("Expected only the average below the quantile threshold, got %+v", data)
+	}
+}
+
+// TestGetChartData_CropBenchmark_Quantiles は比較対象が十分な場合の四分位数・順位をテストします。
+// 集計値は 0.1kg/m²、順位は 10 単位に丸めることを確認します。
+func TestGetChartData_CropBenchmark_Quantiles(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	setupBenchmarkUsers(mockRepos, true, BenchmarkQuantileMinUsers)
+
+	// Act
+	result, err := svc.GetChartData(context.Background(), 1, ChartTypeCropBenchmark, ChartFilter{CropName: "トマト"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data := result.Data.(CropBenchmarkData)
+	if !data.QuantilesAvailable {
+		t.Fatal("Expected quantiles to be available")
+	}
+	// 比較対象: 1.0〜20.0 kg/m²（p25 = 5.75、中央値 = 10.5、p75 = 15.25）
+	if *data.PeerP25 != 5.8 || *data.PeerMedian != 10.5 || *data.PeerP75 != 15.3 {
+		t.Errorf("Expected rounded quartiles 5.8 / 10.5 / 15.3, got %v / %v / %v", *data.PeerP25, *data.PeerMedian, *data.PeerP75)
+	}
+	if *data.PeerAverage != 10.5 {
+		t.Errorf("Expected average 10.5, got %f", *data.PeerAverage)
+	}
+	// 自分（2.0）より小さい値は1件（5%）で、10 単位に丸める
+	if *data.Percentile != 10 {
+		t.Errorf("Expected percentile 10, got %f", *data.Percentile)
+	}
+}
+
+// TestGetChartData_CropBenchmark_BelowThreshold は比較対象が少ない場合に集計値を返さないことをテストします。
+func TestGetChartData_CropBenchmark_BelowThreshold(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	setupBenchmarkUsers(mockRepos, true, BenchmarkMinUsers-1)
+
+	// Act
+	result, err := svc.GetChartData(context.Background(), 1, ChartTypeCropBenchmark, ChartFilter{CropName: "トマト"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data := result.Data.(CropBenchmarkData)
+	if data.Available {
+		t.Error("Expected benchmark to be unavailable below threshold")
+	}
+	if data.PeerAverage != nil || data.PeerMedian != nil {
+		t.Error("Expected no aggregates below threshold")
+	}
+}
+
+// TestGetChartData_CropBenchmark_NotOptedIn はオプトインしていないユーザーが拒否されることをテストします。
+func TestGetChartData_CropBenchmark_NotOptedIn(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	setupBenchmarkUsers(mockRepos, false, 10)
+
+	// Act
+	_, err := svc.GetChartData(context.Background(), 1, ChartTypeCropBenchmark, ChartFilter{CropName: "トマト"})
+
+	// Assert
+	if !errors.Is(err, ErrBenchmarkNotOptedIn) {
+		t.Errorf("Expected ErrBenchmarkNotOptedIn, got %v", err)
+	}
+}
+
+// =============================================================================
+// Materialized View Refresh テスト
+// =============================================================================
+
+// TestRefreshMaterializedViews_RecordsMetadata はリフレッシュ結果がメタデータに記録されることをテストします。
+func TestRefreshMaterializedViews_RecordsMetadata(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	// Act
+	result, err := svc.RefreshMaterializedViews(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Succeeded != len(AnalyticsMaterializedViews) {
+		t.Errorf("Expected %d succeeded, got %d", len(AnalyticsMaterializedViews), result.Succeeded)
+	}
+	freshness, _ := svc.GetAnalyticsFreshness(ctx)
+	if freshness.IsStale {
+		t.Error("Expected analytics to be fresh after refresh")
+	}
+}
+
+// TestRefreshMaterializedViews_PartialFailure は失敗時に前回成功日時が保持されることをテストします。
+func TestRefreshMaterializedViews_PartialFailure(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	svc.RefreshMaterializedViews(ctx)
+
+	viewRepo := mockRepos.GetMockAnalyticsViewRepository()
+	previous := *viewRepo.Records["mv_harvest_analytics"].LastRefreshedAt
+	viewRepo.RefreshFunc = func(ctx context.Context, viewName string) error {
+		if viewName == "mv_harvest_analytics" {
+			return errors.New("refresh failed")
+		}
+		return nil
+	}
+
+	// Act
+	result, err := svc.RefreshMaterializedViews(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Failed != 1 {
+		t.Errorf("Expected 1 failed view, got %d", result.Failed)
+	}
+	record := viewRepo.Records["mv_harvest_analytics"]
+	if record.LastError == "" {
+		t.Error("Expected last error to be recorded")
+	}
+	if !record.LastRefreshedAt.Equal(previous) {
+		t.Error("Expected previous successful refresh time to be preserved")
+	}
+}
+
+// TestGetChartData_Freshness はマテリアライズドビューを使用するグラフのみ鮮度情報を含むことをテストします。
+// 期待動作:
+//   - シーズン比較（mv_harvest_analytics）は、リフレッシュ前は古い鮮度情報を含む
+//   - シーズン比較の鮮度は mv_harvest_analytics のみで判定する（他のビューの失敗は影響しない）
+//   - 収穫記録から集計するグラフ（月別収穫量）は鮮度情報を含まない
+func TestGetChartData_Freshness(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	seasonsFilter := ChartFilter{CropName: "トマト"}
+
+	// Act
+	before, beforeErr := svc.GetChartData(ctx, 1, ChartTypeCropSeasons, seasonsFilter)
+	mockRepos.GetMockAnalyticsViewRepository().RefreshFunc = func(ctx context.Context, viewName string) error {
+		if viewName == "mv_monthly_harvest" {
+			return errors.New("refresh failed")
+		}
+		return nil
+	}
+	_, _ = svc.RefreshMaterializedViews(ctx)
+	after, afterErr := svc.GetChartData(ctx, 1, ChartTypeCropSeasons, seasonsFilter)
+	monthly, monthlyErr := svc.GetChartData(ctx, 1, ChartTypeMonthlyHarvest, ChartFilter{})
+
+	// Assert
+	if beforeErr != nil || afterErr != nil || monthlyErr != nil {
+		t.Fatalf("Expected no error, got %v / %v / %v", beforeErr, afterErr, monthlyErr)
+	}
+	if before.Freshness == nil || !before.Freshness.IsStale {
+		t.Errorf("Expected stale freshness before any refresh, got %+v", before.Freshness)
+	}
+	if after.Freshness == nil || after.Freshness.IsStale || after.Freshness.LastRefreshedAt == nil {
+		t.Errorf("Expected fresh mv_harvest_analytics after the refresh, got %+v", after.Freshness)
+	}
+	if monthly.Freshness != nil {
+		t.Errorf("Expected no freshness for the chart built from harvest records, got %+v", monthly.Freshness)
+	}
+}
+
+// =============================================================================
+// GetTimeSeries テスト
+// =============================================================================
+
+// TestGetTimeSeries_DailyZeroFilled は日単位で空のバケットが0埋めされることをテストします。
+func TestGetTimeSeries_DailyZeroFilled(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	day1 := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	day3 := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: day1, Quantity: 1, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: day3, Quantity: 500, QuantityUnit: "g"})
+
+	// Act
+	result, err := svc.GetTimeSeries(context.Background(), 1, TimeSeriesQuery{
+		Metric:      MetricHarvestKg,
+		Granularity: GranularityDay,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Series) != 1 || result.Series[0].Key != "total" {
+		t.Fatalf("Expected single total series, got %+v", result.Series)
+	}
+	points := result.Series[0].Points
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 daily points, got %d", len(points))
+	}
+	if points[1].Value != 0 || points[1].Label != "2024-06-02" {
+		t.Errorf("Expected zero-filled 2024-06-02, got %+v", points[1])
+	}
+	if points[2].Value != 0.5 {
+		t.Errorf("Expected 0.5kg on day 3, got %f", points[2].Value)
+	}
+}
+
+// TestGetTimeSeries_MixedLocations は期間の指定と収穫日のロケーションが異なる場合のテストです。
+// 期待動作:
+//   - UTC の期間の指定と他のロケーション（DBの time.Local）の収穫日が同じ日のバケットに集計される
+func TestGetTimeSeries_MixedLocations(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: time.Date(2024, 6, 2, 0, 0, 0, 0, tokyo), Quantity: 2, QuantityUnit: "kg"})
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	result, err := svc.GetTimeSeries(context.Background(), 1, TimeSeriesQuery{
+		Metric:      MetricHarvestKg,
+		Granularity: GranularityDay,
+		StartDate:   &start,
+		EndDate:     &end,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Series) != 1 || len(result.Series[0].Points) != 3 {
+		t.Fatalf("Expected one series with 3 daily points, got %+v", result.Series)
+	}
+	if point := result.Series[0].Points[1]; point.Label != "2024-06-02" || point.Value != 2 {
+		t.Errorf("Expected 2kg on 2024-06-02, got %+v", point)
+	}
+}
+
+// TestGetTimeSeries_GroupByCrop は作物ごとに系列が分割されることをテストします。
+func TestGetTimeSeries_GroupByCrop(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	now := time.Now()
+	tomato := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: now, ExpectedHarvestDate: now}
+	cucumber := &model.Crop{UserID: 1, Name: "きゅうり", PlantedDate: now, ExpectedHarvestDate: now}
+	svc.CreateCrop(ctx, tomato)
+	svc.CreateCrop(ctx, cucumber)
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: tomato.ID, HarvestDate: now, Quantity: 1, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: cucumber.ID, HarvestDate: now, Quantity: 2, QuantityUnit: "kg"})
+
+	// Act
+	result, err := svc.GetTimeSeries(ctx, 1, TimeSeriesQuery{
+		Metric:      MetricHarvestCount,
+		Granularity: GranularityMonth,
+		GroupBy:     GroupByCrop,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Series) != 2 {
+		t.Fatalf("Expected 2 series, got %d", len(result.Series))
+	}
+	for _, series := range result.Series {
+		if series.Label == "" {
+			t.Errorf("Expected series label for %s", series.Key)
+		}
+		if len(series.Points) != 1 || series.Points[0].Value != 1 {
+			t.Errorf("Expected one harvest in series %s, got %+v", series.Key, series.Points)
+		}
+	}
+}
+
+// TestGetTimeSeries_InvalidGranularity は不正な粒度が拒否されることをテストします。
+func TestGetTimeSeries_InvalidGranularity(t *testing.T) {
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+
+	_, err := svc.GetTimeSeries(context.Background(), 1, TimeSeriesQuery{
+		Metric:      MetricHarvestKg,
+		Granularity: "hour",
+	})
+
+	if !errors.Is(err, ErrInvalidTimeSeriesQuery) {
+		t.Errorf("Expected ErrInvalidTimeSeriesQuery, got %v", err)
+	}
+}
+
+// =============================================================================
+// ExportCSV 成長記録・区画配置テスト
+// =============================================================================
+
+// TestExportCSV_GrowthRecords は成長記録のCSVエクスポートをテストします。
+func TestExportCSV_GrowthRecords(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	userID := uint(1)
+
+	crop := &model.Crop{UserID: userID, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now(), Status: "growing"}
+	_ = svc.CreateCrop(ctx, crop)
+	otherCrop := &model.Crop{UserID: 2, Name: "なす", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now(), Status: "growing"}
+	_ = svc.CreateCrop(ctx, otherCrop)
+	_ = svc.CreateGrowthRecord(ctx, &model.GrowthRecord{CropID: crop.ID, RecordDate: time.Now(), GrowthStage: "flowering", Notes: "花が咲いた"})
+	_ = svc.CreateGrowthRecord(ctx, &model.GrowthRecord{CropID: otherCrop.ID, RecordDate: time.Now(), GrowthStage: "seedling"})
+
+	// Act
+	result, err := svc.ExportCSV(ctx, userID, ExportDataTypeGrowthRecords)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if result.RecordCount != 1 {
+		t.Errorf("Expected 1 record, got %d", result.RecordCount)
+	}
+	csvContent := string(result.Data)
+	for _, want := range []string{"成長段階", "トマト", "flowering", "花が咲いた"} {
+		if !strings.Contains(csvContent, want) {
+			t.Errorf("CSV should contain %q", want)
+		}
+	}
+	if strings.Contains(csvContent, "なす") {
+		t.Error("CSV should not contain other user's crops")
+	}
+}
+
+// TestExportCSV_PlotAssignments は区画配置履歴のCSVエクスポートをテストします。
+func TestExportCSV_PlotAssignments(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	userID := uint(1)
+
+	plot := &model.Plot{UserID: userID, Name: "A区画", Width: 1, Height: 2, Status: "available"}
+	_ = svc.CreatePlot(ctx, plot)
+	crop := &model.Crop{UserID: userID, Name: "きゅうり", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now(), Status: "planted"}
+	_ = svc.CreateCrop(ctx, crop)
+	if _, err := svc.AssignCropToPlot(ctx, plot.ID, crop.ID, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("AssignCropToPlot failed: %v", err)
+	}
+
+	// Act
+	result, err := svc.ExportCSV(ctx, userID, ExportDataTypePlotAssignments)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if result.RecordCount != 1 {
+		t.Errorf("Expected 1 record, got %d", result.RecordCount)
+	}
+	csvContent := string(result.Data)
+	for _, want := range []string{"区画名", "A区画", "きゅうり", "2024-05-01"} {
+		if !strings.Contains(csvContent, want) {
+			t.Errorf("CSV should contain %q", want)
+		}
+	}
+}
+
+// TestExportCSV_AllIncludesNewDataTypes は全データZIPに成長記録・区画配置が含まれることをテストします。
+func TestExportCSV_AllIncludesNewDataTypes(t *testing.T) {
+	svc := NewService(repository.NewMockRepositories())
+
+	result, err := svc.ExportCSV(context.Background(), 1, ExportDataTypeAll)
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(result.Data), int64(len(result.Data)))
+	if err != nil {
+		t.Fatalf("Failed to open ZIP: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, f := range zr.File {
+		names[f.Name] = true
+	}
+	for _, want := range []string{"crops.csv", "harvests.csv", "tasks.csv", "growth_records.csv", "plot_assignments.csv"} {
+		if !names[want] {
+			t.Errorf("ZIP should contain %s", want)
+		}
+	}
+}
+
+// =============================================================================
+// エクスポート履歴テスト
+// =============================================================================
+
+// TestRecordExport_ListAndOwnership はエクスポート履歴の記録・一覧・所有者チェックをテストします。
+func TestRecordExport_ListAndOwnership(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	userID := uint(1)
+	otherUserID := uint(2)
+
+	_ = svc.CreateCrop(ctx, &model.Crop{
+		UserID:              userID,
+		Name:                "トマト",
+		PlantedDate:         time.Now(),
+		ExpectedHarvestDate: time.Now().AddDate(0, 3, 0),
+		Status:              "planted",
+	})
+	result, err := svc.ExportCSV(ctx, userID, ExportDataTypeCrops)
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+
+	// Act
+	first, err := svc.RecordExport(ctx, userID, result, "")
+	if err != nil {
+		t.Fatalf("RecordExport failed: %v", err)
+	}
+	second, err := svc.RecordExport(ctx, userID, result, "exports/1/2024/01/abc-crops.csv")
+	if err != nil {
+		t.Fatalf("RecordExport failed: %v", err)
+	}
+	_, _ = svc.RecordExport(ctx, otherUserID, result, "exports/2/2024/01/def-crops.csv")
+
+	records, err := svc.GetUserExports(ctx, userID, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetUserExports failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].ID != second.ID {
+		t.Errorf("Expected newest export first, got ID %d", records[0].ID)
+	}
+	if second.RecordCount != 1 || second.FileSizeBytes != int64(len(result.Data)) {
+		t.Errorf("Unexpected metadata: count=%d size=%d", second.RecordCount, second.FileSizeBytes)
+	}
+	if first.IsDownloadable() {
+		t.Error("Export without S3 key should not be downloadable")
+	}
+	if !second.IsDownloadable() {
+		t.Error("Export with S3 key should be downloadable")
+	}
+
+	if _, err := svc.GetExportRecord(ctx, otherUserID, second.ID); !errors.Is(err, ErrExportNotOwned) {
+		t.Errorf("Expected ErrExportNotOwned, got %v", err)
+	}
+	if got, err := svc.GetExportRecord(ctx, userID, second.ID); err != nil || got.S3Key != second.S3Key {
+		t.Errorf("Expected own export to be returned, got %v", err)
+	}
+}
+
+// TestExportCSVWithOptions_Anonymize は匿名化エクスポートで個人情報が除去されることをテストします。
+func TestExportCSVWithOptions_Anonymize(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "hanako@example.com", DisplayName: "山田花子"}
+	_ = mockRepos.User().Create(ctx, user)
+	userID := user.ID
+	_ = mockRepos.Garden().Create(ctx, &model.Garden{UserID: userID, Name: "家庭菜園", Location: "世田谷区"})
+
+	crop := &model.Crop{
+		UserID:              userID,
+		Name:                "山田花子のトマト",
+		Variety:             "桃太郎",
+		PlantedDate:         time.Now(),
+		ExpectedHarvestDate: time.Now().AddDate(0, 3, 0),
+		Status:              "planted",
+		Notes:               "世田谷区の畑で栽培 連絡先 hanako@example.com",
+	}
+	_ = svc.CreateCrop(ctx, crop)
+	_ = svc.CreateTask(ctx, &model.Task{
+		UserID:      userID,
+		Title:       "other@example.org に連絡",
+		Description: "個人的なメモ",
+		DueDate:     time.Now(),
+		Priority:    "medium",
+		Status:      "pending",
+	})
+
+	// Act
+	cropsResult, err := svc.ExportCSVWithOptions(ctx, userID, ExportDataTypeCrops, ExportOptions{Anonymize: true})
+	if err != nil {
+		t.Fatalf("ExportCSVWithOptions failed: %v", err)
+	}
+	tasksResult, err := svc.ExportCSVWithOptions(ctx, userID, ExportDataTypeTasks, ExportOptions{Anonymize: true})
+	if err != nil {
+		t.Fatalf("ExportCSVWithOptions failed: %v", err)
+	}
+
+	// Assert
+	if !cropsResult.Anonymized || !strings.HasSuffix(cropsResult.FileName, "_anonymized.csv") {
+		t.Errorf("Expected anonymized result, got anonymized=%v file=%s", cropsResult.Anonymized, cropsResult.FileName)
+	}
+	content := string(cropsResult.Data) + string(tasksResult.Data)
+	for _, pii := range []string{"hanako@example.com", "other@example.org", "山田花子", "世田谷区", "個人的なメモ"} {
+		if strings.Contains(content, pii) {
+			t.Errorf("Anonymized export should not contain %q", pii)
+		}
+	}
+	if !strings.Contains(string(cropsResult.Data), "[REDACTED]のトマト") {
+		t.Error("Crop name should keep non-identifying text")
+	}
+	if !strings.Contains(string(cropsResult.Data), "桃太郎") {
+		t.Error("Variety should be preserved")
+	}
+
+	// 匿名化なしでは元のデータがそのまま出力される
+	plain, err := svc.ExportCSV(ctx, userID, ExportDataTypeCrops)
+	if err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if plain.Anonymized || !strings.Contains(string(plain.Data), "山田花子のトマト") {
+		t.Error("Plain export should not be anonymized")
+	}
+}
+
+// =============================================================================
+// 収穫ヒートマップテスト
+// =============================================================================
+
+// TestGetChartData_HarvestHeatmap は1年分の日別ヒートマップが0埋めで生成されることをテストします。
+func TestGetChartData_HarvestHeatmap(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), Quantity: 2, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC), Quantity: 2, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC), Quantity: 500, QuantityUnit: "g"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: time.Date(2023, 12, 31, 9, 0, 0, 0, time.UTC), Quantity: 10, QuantityUnit: "kg"})
+	year := 2024
+
+	// Act
+	chart, err := svc.GetChartData(context.Background(), 1, ChartTypeHarvestHeatmap, ChartFilter{Year: &year})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, ok := chart.Data.(HarvestHeatmapData)
+	if !ok {
+		t.Fatalf("Expected HarvestHeatmapData, got %T", chart.Data)
+	}
+	if len(data.Days) != 366 {
+		t.Fatalf("Expected 366 days for leap year, got %d", len(data.Days))
+	}
+	if data.TotalKg != 4.5 || data.MaxKg != 4 || data.ActiveDays != 2 {
+		t.Errorf("Unexpected totals: total=%f max=%f active=%d", data.TotalKg, data.MaxKg, data.ActiveDays)
+	}
+
+	first := data.Days[0]
+	if first.Date != "2024-01-01" || first.Kg != 4 || first.Count != 2 || first.Level != HeatmapLevels {
+		t.Errorf("Unexpected first day: %+v", first)
+	}
+	// 2024-01-01は月曜日 → 週0の行1
+	if first.Weekday != 1 || first.Week != 0 {
+		t.Errorf("Expected weekday 1 week 0, got weekday %d week %d", first.Weekday, first.Week)
+	}
+	// 2024-01-07（日曜日）は週1の先頭
+	if data.Days[6].Weekday != 0 || data.Days[6].Week != 1 {
+		t.Errorf("Expected 2024-01-07 to start week 1, got %+v", data.Days[6])
+	}
+	if data.Days[1].Kg != 0 || data.Days[1].Level != 0 {
+		t.Errorf("Expected zero-filled day, got %+v", data.Days[1])
+	}
+
+	july15 := data.Days[time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC).YearDay()-1]
+	if july15.Date != "2024-07-15" || july15.Kg != 0.5 || july15.Level != 1 {
+		t.Errorf("Unexpected 2024-07-15 cell: %+v", july15)
+	}
+}
+
+// =============================================================================
+// 作物のシーズン比較テスト
+// =============================================================================
+
+// TestGetChartData_CropSeasons は植え付け年ごとに集計されることをテストします。
+func TestGetChartData_CropSeasons(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	viewRepo := mockRepos.GetMockAnalyticsViewRepository()
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	viewRepo.CropAnalytics[1] = []repository.CropHarvestAnalytics{
+		{CropID: 1, CropName: "トマト", PlantedDate: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), TotalQuantity: 5, QuantityUnit: "kg", HarvestCount: 4, DaysToFirstHarvest: intPtr(80), AvgQualityScore: floatPtr(3)},
+		{CropID: 2, CropName: "トマト", PlantedDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), TotalQuantity: 3000, QuantityUnit: "g", HarvestCount: 1, DaysToFirstHarvest: intPtr(70), AvgQualityScore: floatPtr(4)},
+		{CropID: 3, CropName: "トマト", PlantedDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), TotalQuantity: 2, QuantityUnit: "kg", HarvestCount: 3, DaysToFirstHarvest: intPtr(60), AvgQualityScore: floatPtr(2)},
+		{CropID: 4, CropName: "トマト", PlantedDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)}, // 未収穫
+		{CropID: 5, CropName: "きゅうり", PlantedDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), TotalQuantity: 9, QuantityUnit: "kg", HarvestCount: 9},
+	}
+
+	// Act
+	chart, err := svc.GetChartData(context.Background(), 1, ChartTypeCropSeasons, ChartFilter{CropName: "トマト"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, ok := chart.Data.(CropSeasonComparison)
+	if !ok {
+		t.Fatalf("Expected CropSeasonComparison, got %T", chart.Data)
+	}
+	if len(data.Seasons) != 3 {
+		t.Fatalf("Expected 3 seasons, got %d", len(data.Seasons))
+	}
+
+	s2024 := data.Seasons[1]
+	if s2024.Season != 2024 || s2024.Plantings != 2 || s2024.TotalKg != 5 || s2024.HarvestCount != 4 {
+		t.Errorf("Unexpected 2024 season: %+v", s2024)
+	}
+	if s2024.DaysToFirstHarvest == nil || *s2024.DaysToFirstHarvest != 65 {
+		t.Errorf("Expected average 65 days to first harvest, got %v", s2024.DaysToFirstHarvest)
+	}
+	// 品質は収穫回数で加重: (4*1 + 2*3) / 4 = 2.5
+	if s2024.AvgQualityScore == nil || *s2024.AvgQualityScore != 2.5 {
+		t.Errorf("Expected weighted quality 2.5, got %v", s2024.AvgQualityScore)
+	}
+
+	s2025 := data.Seasons[2]
+	if s2025.DaysToFirstHarvest != nil || s2025.AvgQualityScore != nil || s2025.TotalKg != 0 {
+		t.Errorf("Expected empty metrics for unharvested season, got %+v", s2025)
+	}
+}
+
+// TestGetChartData_CropSeasons_RequiresCrop は作物名なしでエラーになることをテストします。
+func TestGetChartData_CropSeasons_RequiresCrop(t *testing.T) {
+	svc := NewService(repository.NewMockRepositories())
+
+	_, err := svc.GetChartData(context.Background(), 1, ChartTypeCropSeasons, ChartFilter{})
+
+	if !errors.Is(err, ErrSeasonCropRequired) {
+		t.Errorf("Expected ErrSeasonCropRequired, got %v", err)
+	}
+}
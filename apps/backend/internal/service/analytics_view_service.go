@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Materialized View Refresh - マテリアライズドビューのリフレッシュ
+// =============================================================================
+
+// AnalyticsMaterializedViews はリフレッシュ対象のマテリアライズドビュー一覧です。
+// migrations/000003_create_materialized_views.up.sql で作成されるビューと一致させてください。
+var AnalyticsMaterializedViews = []string{
+	"mv_harvest_analytics",
+	"mv_monthly_harvest",
+}
+
+// AnalyticsStaleThreshold は分析データを「古い」とみなすまでの経過時間です。
+// 日次リフレッシュを想定し、多少の遅延を許容して26時間としています。
+const AnalyticsStaleThreshold = 26 * time.Hour
+
+// ViewRefreshResult はビュー単位のリフレッシュ結果です。
+type ViewRefreshResult struct {
+	ViewName   string `json:"view_name"`
+	Success    bool   `json:"success"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// MaterializedViewRefreshResult はリフレッシュ処理全体の結果です。
+type MaterializedViewRefreshResult struct {
+	RefreshedAt time.Time           `json:"refreshed_at"`
+	Views       []ViewRefreshResult `json:"views"`
+	Succeeded   int                 `json:"succeeded"`
+	Failed      int                 `json:"failed"`
+}
+
+// AnalyticsFreshness は分析データ（マテリアライズドビュー）の鮮度を表します。
+type AnalyticsFreshness struct {
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"` // 最も古いビューの最終リフレッシュ日時
+	StaleSeconds    int64      `json:"stale_seconds"`               // 最終リフレッシュからの経過秒数
+	IsStale         bool       `json:"is_stale"`                    // AnalyticsStaleThresholdを超えているか
+}
+
+// RefreshMaterializedViews は分析用マテリアライズドビューをすべてリフレッシュし、
+// 結果をメタデータテーブルに記録します。
+// 1つのビューが失敗しても残りのビューの処理は継続します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//
+// 戻り値:
+//   - *MaterializedViewRefreshResult: ビューごとのリフレッシュ結果
+//   - error: メタデータの記録に失敗した場合のエラー
+func (s *Service) RefreshMaterializedViews(ctx context.Context) (*MaterializedViewRefreshResult, error) {
+	result := &MaterializedViewRefreshResult{
+		RefreshedAt: s.now(),
+		Views:       make([]ViewRefreshResult, 0, len(AnalyticsMaterializedViews)),
+	}
+
+	for _, view := range AnalyticsMaterializedViews {
+		// 所要時間はシステムの時刻（単調時計）で計測する
+		attemptedAt := s.now()
+		start := time.Now()
+		refreshErr := s.repos.AnalyticsView().Refresh(ctx, view)
+		duration := time.Since(start).Milliseconds()
+
+		record := &model.MaterializedViewRefresh{
+			ViewName:      view,
+			LastAttemptAt: attemptedAt,
+			DurationMs:    duration,
+		}
+		viewResult := ViewRefreshResult{ViewName: view, DurationMs: duration}
+
+		if refreshErr != nil {
+			record.LastError = truncateString(refreshErr.Error(), 500)
+			viewResult.Error = refreshErr.Error()
+			result.Failed++
+		} else {
+			finishedAt := s.now()
+			record.LastRefreshedAt = &finishedAt
+			viewResult.Success = true
+			result.Succeeded++
+		}
+
+		if err := s.repos.AnalyticsView().RecordRefresh(ctx, record); err != nil {
+			return nil, err
+		}
+		result.Views = append(result.Views, viewResult)
+	}
+
+	// ビューを使用するグラフ（crop_seasons など）のキャッシュを無効にする
+	if result.Succeeded > 0 {
+		if err := s.repos.AnalyticsView().BumpDataVersion(ctx, AnalyticsViewsScope); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// GetMaterializedViewStatuses は全ビューの最新リフレッシュ状況を取得します。
+func (s *Service) GetMaterializedViewStatuses(ctx context.Context) ([]model.MaterializedViewRefresh, error) {
+	return s.repos.AnalyticsView().GetRefreshStatuses(ctx)
+}
+
+// GetAnalyticsFreshness は分析データ（全てのマテリアライズドビュー）の鮮度を取得します。
+// 最も古いビューの最終リフレッシュ日時を基準とし、
+// 一度もリフレッシュされていないビューがある場合は常に古いと判定します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//
+// 戻り値:
+//   - *AnalyticsFreshness: 鮮度情報
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetAnalyticsFreshness(ctx context.Context) (*AnalyticsFreshness, error) {
+	return s.viewFreshness(ctx, AnalyticsMaterializedViews...)
+}
+
+// viewFreshness は指定したマテリアライズドビューの鮮度を取得します（ビューを使用するレスポンスの鮮度）。
+func (s *Service) viewFreshness(ctx context.Context, views ...string) (*AnalyticsFreshness, error) {
+	statuses, err := s.repos.AnalyticsView().GetRefreshStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := make(map[string]*time.Time, len(statuses))
+	for i := range statuses {
+		refreshed[statuses[i].ViewName] = statuses[i].LastRefreshedAt
+	}
+
+	freshness := &AnalyticsFreshness{}
+	for _, view := range views {
+		last := refreshed[view]
+		if last == nil {
+			// 未リフレッシュのビューがある
+			freshness.LastRefreshedAt = nil
+			freshness.IsStale = true
+			return freshness, nil
+		}
+		if freshness.LastRefreshedAt == nil || last.Before(*freshness.LastRefreshedAt) {
+			freshness.LastRefreshedAt = last
+		}
+	}
+
+	if freshness.LastRefreshedAt != nil {
+		age := s.now().Sub(*freshness.LastRefreshedAt)
+		freshness.StaleSeconds = int64(age.Seconds())
+		freshness.IsStale = age > AnalyticsStaleThreshold
+	}
+	return freshness, nil
+}
+
+// truncateString は文字列を最大長（バイト数）で切り詰めます。
+func truncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Announcements - 管理者からのお知らせ配信
+// =============================================================================
+// 管理者が作成したお知らせ（メンテナンス・新機能など）を、全ユーザーまたは条件
+// （言語・プラットフォーム・直近のアクティブ日）に合うユーザーへ通知パイプラインで配信します。
+//
+// 配信はスケジューラー（DeliverAnnouncements）が配信予定日時を過ぎたお知らせについて行い、
+// 1回の処理で AnnouncementBatchSize 人ずつ配信して、残りは次回の処理で続きから配信します。
+// 通知設定・おやすみモード・送信数の上限は通常の通知と同じく適用され、
+// 重複防止キーにお知らせIDを含めるため、処理が中断しても同じユーザーへ二重に送信しません。
+
+// お知らせのステータス
+const (
+	AnnouncementStatusScheduled = "scheduled"
+	AnnouncementStatusSending   = "sending"
+	AnnouncementStatusSent      = "sent"
+	AnnouncementStatusCancelled = "cancelled"
+)
+
+const (
+	// AnnouncementBatchSize は1件のお知らせを1回の処理で配信する最大ユーザー数です。
+	AnnouncementBatchSize = 500
+	// MaxAnnouncementActiveDays は配信対象の「直近N日以内にアクティブ」に指定できる最大日数です。
+	MaxAnnouncementActiveDays = 365
+
+	// announcementDueLimit は1回の処理で扱う最大お知らせ数です。
+	announcementDueLimit = 10
+	// announcementListLimit は一覧で返す最大件数です。
+	announcementListLimit = 100
+)
+
+var (
+	// ErrAnnouncementNotFound はお知らせが存在しない場合のエラー
+	ErrAnnouncementNotFound = errors.New("announcement not found")
+	// ErrAnnouncementNotCancellable は配信済み・取り消し済みのお知らせを取り消そうとした場合のエラー
+	ErrAnnouncementNotCancellable = errors.New("announcement cannot be cancelled")
+	// ErrInvalidAnnouncement は配信対象の条件・配信予定日時が不正な場合のエラー
+	ErrInvalidAnnouncement = errors.New("invalid announcement")
+)
+
+// CreateAnnouncementInput はお知らせの作成内容です。
+type CreateAnnouncementInput struct {
+	Title       string
+	Body        string
+	Category    string     // maintenance, feature, general（空の場合は general）
+	ScheduledAt *time.Time // nilの場合は次回のスケジューラー実行時に配信
+
+	// 配信対象の条件（空・0 の場合は条件なし）
+	Locale     string
+	Platform   string
+	ActiveDays int
+}
+
+// AnnouncementDeliveryResult はお知らせの配信処理の結果です。
+type AnnouncementDeliveryResult struct {
+	ProcessedAt   time.Time `json:"processed_at"`
+	Announcements int       `json:"announcements"` // 配信したお知らせの数
+	Completed     int       `json:"completed"`     // 全対象ユーザーへの配信が完了したお知らせの数
+	Recipients    int       `json:"recipients"`    // 今回の処理で配信したユーザー数
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+// CreateAnnouncement はお知らせを作成し、配信を予約します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - adminID: 作成した管理者のユーザーID
+//   - input: お知らせの内容と配信対象の条件
+//
+// 戻り値:
+//   - *model.Announcement: 作成したお知らせ（TargetCount は現時点の対象ユーザー数）
+//   - error: 言語・日数・配信予定日時が不正な場合は ErrInvalidAnnouncement
+func (s *Service) CreateAnnouncement(ctx context.Context, adminID uint, input CreateAnnouncementInput) (*model.Announcement, error) {
+	now := s.now()
+	announcement := &model.Announcement{
+		Title:              input.Title,
+		Body:               input.Body,
+		Category:           input.Category,
+		ScheduledAt:        now,
+		Status:             AnnouncementStatusScheduled,
+		CreatedBy:          adminID,
+		AudiencePlatform:   input.Platform,
+		AudienceActiveDays: input.ActiveDays,
+	}
+	if announcement.Category == "" {
+		announcement.Category = "general"
+	}
+	if input.ScheduledAt != nil {
+		if input.ScheduledAt.Before(now.Add(-time.Minute)) {
+			return nil, fmt.Errorf("%w: scheduled_at must not be in the past", ErrInvalidAnnouncement)
+		}
+		announcement.ScheduledAt = *input.ScheduledAt
+	}
+	if input.ActiveDays < 0 || input.ActiveDays > MaxAnnouncementActiveDays {
+		return nil, fmt.Errorf("%w: active_days must be between 0 and %d", ErrInvalidAnnouncement, MaxAnnouncementActiveDays)
+	}
+	if input.Locale != "" {
+		lang, err := NormalizeLocale(input.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unsupported locale", ErrInvalidAnnouncement)
+		}
+		announcement.AudienceLocale = lang
+	}
+
+	count, err := s.repos.Announcement().CountAudience(ctx, announcementAudience(announcement))
+	if err != nil {
+		return nil, err
+	}
+	announcement.TargetCount = int(count)
+
+	if err := s.repos.Announcement().Create(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// ListAnnouncements はお知らせを新しい順に取得します。
+func (s *Service) ListAnnouncements(ctx context.Context) ([]model.Announcement, error) {
+	return s.repos.Announcement().List(ctx, announcementListLimit)
+}
+
+// GetAnnouncement はお知らせと配信結果を取得します。
+//
+// 戻り値:
+//   - *model.Announcement: お知らせ
+//   - error: 存在しない場合は ErrAnnouncementNotFound
+func (s *Service) GetAnnouncement(ctx context.Context, id uint) (*model.Announcement, error) {
+	announcement, err := s.repos.Announcement().GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrAnnouncementNotFound
+	}
+	return announcement, nil
+}
+
+// CancelAnnouncement はお知らせの配信を取り消します。
+// 配信中のお知らせは、配信済みのユーザーを除いて以降の配信を止めます。
+//
+// 戻り値:
+//   - *model.Announcement: 取り消したお知らせ
+//   - error: 存在しない場合は ErrAnnouncementNotFound、配信済み・取り消し済みの場合は ErrAnnouncementNotCancellable
+func (s *Service) CancelAnnouncement(ctx context.Context, id uint) (*model.Announcement, error) {
+	announcement, err := s.GetAnnouncement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement.Status != AnnouncementStatusScheduled && announcement.Status != AnnouncementStatusSending {
+		return nil, ErrAnnouncementNotCancellable
+	}
+	announcement.Status = AnnouncementStatusCancelled
+	if err := s.repos.Announcement().Update(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// announcementAudience はお知らせの配信対象の条件をリポジトリの条件に変換します。
+// 直近のアクティブ日は配信予定日を基準にし、複数回に分けて配信しても対象が変わらないようにします。
+func announcementAudience(announcement *model.Announcement) repository.AnnouncementAudience {
+	audience := repository.AnnouncementAudience{
+		Locale:   announcement.AudienceLocale,
+		Platform: announcement.AudiencePlatform,
+	}
+	if announcement.AudienceActiveDays > 0 {
+		since := usageDate(announcement.ScheduledAt).AddDate(0, 0, -(announcement.AudienceActiveDays - 1))
+		audience.ActiveSince = &since
+	}
+	return audience
+}
+
+// announcementEvent はお知らせをユーザーへの通知イベントに変換します。
+func announcementEvent(announcement *model.Announcement, userID uint) NotificationEvent {
+	return NotificationEvent{
+		Type:   NotificationEventAnnouncement,
+		UserID: userID,
+		Title:  announcement.Title,
+		Body:   announcement.Body,
+		Data: map[string]interface{}{
+			"announcement_id":       announcement.ID,
+			"announcement_category": announcement.Category,
+		},
+		// 配信が日をまたいでも同じ重複防止キーになるよう配信予定日を使用する
+		LocalDate:          announcement.ScheduledAt.UTC().Format("2006-01-02"),
+		DeduplicationScope: "a" + strconv.FormatUint(uint64(announcement.ID), 10),
+	}
+}
+
+// DeliverAnnouncements は配信予定日時を過ぎたお知らせを配信します。
+// AWS EventBridge Scheduler から定期的（例: 5分ごと）に呼び出されることを想定しています。
+//
+// 処理内容:
+//   - 配信開始時に対象ユーザー数を確定し、ステータスを sending にする
+//   - 前回の続きから AnnouncementBatchSize 人ずつ HandleEvents で配信し、結果を集計する
+//   - 全対象ユーザーへの配信が終わったら sent にする
+//
+// 戻り値:
+//   - *AnnouncementDeliveryResult: 処理結果
+//   - error: お知らせを取得できない場合のエラー
+func (h *notificationEventHandler) DeliverAnnouncements(ctx context.Context) (*AnnouncementDeliveryResult, error) {
+	now := h.service.now()
+	result := &AnnouncementDeliveryResult{ProcessedAt: now}
+
+	due, err := h.repos.Announcement().GetDue(ctx, now, announcementDueLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due announcements: %w", err)
+	}
+
+	for i := range due {
+		announcement := &due[i]
+		if err := h.deliverAnnouncementBatch(ctx, announcement, now, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("announcement %d: %v", announcement.ID, err))
+			continue
+		}
+		result.Announcements++
+		if announcement.Status == AnnouncementStatusSent {
+			result.Completed++
+		}
+	}
+	return result, nil
+}
+
+// deliverAnnouncementBatch はお知らせを次の AnnouncementBatchSize 人へ配信し、配信結果を保存します。
+func (h *notificationEventHandler) deliverAnnouncementBatch(ctx context.Context, announcement *model.Announcement, now time.Time, result *AnnouncementDeliveryResult) error {
+	audience := announcementAudience(announcement)
+	if announcement.Status == AnnouncementStatusScheduled {
+		count, err := h.repos.Announcement().CountAudience(ctx, audience)
+		if err != nil {
+			return err
+		}
+		announcement.TargetCount = int(count)
+		announcement.Status = AnnouncementStatusSending
+	}
+
+	userIDs, err := h.repos.Announcement().GetAudienceUserIDs(ctx, audience, announcement.LastUserID, AnnouncementBatchSize)
+	if err != nil {
+		return err
+	}
+
+	events := make([]NotificationEvent, len(userIDs))
+	for i, userID := range userIDs {
+		events[i] = announcementEvent(announcement, userID)
+	}
+	sent, err := h.HandleEvents(ctx, events)
+	if err != nil {
+		return err
+	}
+	result.Errors = append(result.Errors, sent.Errors...)
+
+	announcement.SentCount += sent.SuccessfulSends
+	announcement.FailedCount += sent.FailedSends
+	announcement.DeferredCount += sent.DeferredSends
+	announcement.DuplicateCount += sent.DuplicateSends
+	result.Recipients += len(userIDs)
+	if len(userIDs) > 0 {
+		announcement.LastUserID = userIDs[len(userIDs)-1]
+	}
+	if len(userIDs) < AnnouncementBatchSize {
+		announcement.Status = AnnouncementStatusSent
+		completedAt := now
+		announcement.CompletedAt = &completedAt
+	}
+	return h.repos.Announcement().Update(ctx, announcement)
+}
@@ -0,0 +1,200 @@
+// Package service - Announcement Tests
+//
+// 管理者からのお知らせ配信のテストを提供します。
+// テスト対象:
+//   - お知らせの作成（配信対象の条件の検証と対象ユーザー数）
+//   - 配信対象の条件（言語・プラットフォーム・直近のアクティブ日）による絞り込み
+//   - 配信予定日時前は配信しないこと、配信結果の集計と二重送信の防止
+//   - 配信の取り消し
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// createAnnouncementUsers はお知らせのテスト用ユーザーを作成します。
+//   - ja ユーザー（iOS、今日アクティブ）
+//   - en ユーザー（Android）
+//   - 無効化されたユーザー
+func createAnnouncementUsers(t *testing.T, mockRepos *repository.MockRepositories) (jaUser, enUser *model.User) {
+	t.Helper()
+	ctx := context.Background()
+
+	jaUser = &model.User{Email: "ja@example.com", Timezone: "UTC", Locale: "ja", IsActive: true}
+	enUser = &model.User{Email: "en@example.com", Timezone: "UTC", Locale: "en", IsActive: true}
+	inactive := &model.User{Email: "gone@example.com", Timezone: "UTC", Locale: "ja", IsActive: false}
+	for _, user := range []*model.User{jaUser, enUser, inactive} {
+		_ = mockRepos.User().Create(ctx, user)
+	}
+	_ = mockRepos.DeviceToken().Create(ctx, &model.DeviceToken{UserID: jaUser.ID, Token: "ja-token", Platform: "ios", IsActive: true})
+	_ = mockRepos.DeviceToken().Create(ctx, &model.DeviceToken{UserID: enUser.ID, Token: "en-token", Platform: "android", IsActive: true})
+	_ = mockRepos.UsageStats().RecordActiveUser(ctx, usageDate(time.Now()), jaUser.ID)
+	return jaUser, enUser
+}
+
+// TestCreateAnnouncement はお知らせの作成のテストです。
+// 期待動作:
+//   - 条件に合う有効なユーザー数を target_count に設定する
+//   - 言語タグは正規化する（en-US → en）
+//   - 過去の配信予定日時・未対応の言語は ErrInvalidAnnouncement
+func TestCreateAnnouncement(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	createAnnouncementUsers(t, mockRepos)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		input      CreateAnnouncementInput
+		wantTarget int
+		wantErr    error
+	}{
+		{"all users", CreateAnnouncementInput{Title: "新機能", Body: "本文"}, 2, nil},
+		{"locale", CreateAnnouncementInput{Title: "New", Body: "Body", Locale: "en-US"}, 1, nil},
+		{"platform", CreateAnnouncementInput{Title: "iOS", Body: "本文", Platform: "ios"}, 1, nil},
+		{"active days", CreateAnnouncementInput{Title: "Active", Body: "本文", ActiveDays: 7}, 1, nil},
+		{"no match", CreateAnnouncementInput{Title: "Web", Body: "本文", Platform: "web"}, 0, nil},
+		{"past schedule", CreateAnnouncementInput{Title: "Past", Body: "本文", ScheduledAt: &past}, 0, ErrInvalidAnnouncement},
+		{"unsupported locale", CreateAnnouncementInput{Title: "Fr", Body: "本文", Locale: "fr"}, 0, ErrInvalidAnnouncement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			announcement, err := svc.CreateAnnouncement(ctx, 1, tt.input)
+
+			// Assert
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateAnnouncement failed: %v", err)
+			}
+			if announcement.TargetCount != tt.wantTarget {
+				t.Errorf("Expected target count %d, got %d", tt.wantTarget, announcement.TargetCount)
+			}
+			if announcement.Status != AnnouncementStatusScheduled || announcement.Category != "general" {
+				t.Errorf("Unexpected announcement: %+v", announcement)
+			}
+		})
+	}
+}
+
+// TestDeliverAnnouncements はお知らせの配信のテストです。
+// 期待動作:
+//   - 配信予定日時前のお知らせは配信しない
+//   - 条件に合うユーザーにのみ配信し、配信結果を集計して sent にする
+//   - 配信済みのお知らせは再度配信しない
+//   - 取り消したお知らせは配信しない
+func TestDeliverAnnouncements(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	_, enUser := createAnnouncementUsers(t, mockRepos)
+	inAnHour := time.Now().Add(time.Hour)
+
+	now, _ := svc.CreateAnnouncement(ctx, 1, CreateAnnouncementInput{Title: "Maintenance", Body: "Tonight 2:00-4:00", Category: "maintenance", Locale: "en"})
+	later, _ := svc.CreateAnnouncement(ctx, 1, CreateAnnouncementInput{Title: "あとで", Body: "本文", ScheduledAt: &inAnHour})
+	cancelled, _ := svc.CreateAnnouncement(ctx, 1, CreateAnnouncementInput{Title: "取り消し", Body: "本文"})
+	if _, err := svc.CancelAnnouncement(ctx, cancelled.ID); err != nil {
+		t.Fatalf("CancelAnnouncement failed: %v", err)
+	}
+
+	// Act
+	result, err := handler.DeliverAnnouncements(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DeliverAnnouncements failed: %v", err)
+	}
+	if result.Announcements != 1 || result.Completed != 1 || result.Recipients != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(mockSender.SentPushNotifications) != 1 || mockSender.SentPushNotifications[0].Token != "en-token" {
+		t.Fatalf("Expected push to en user only, got %+v", mockSender.SentPushNotifications)
+	}
+	if data := mockSender.SentPushNotifications[0].Data; data["announcement_id"] != now.ID || data["announcement_category"] != "maintenance" {
+		t.Errorf("Unexpected push data: %+v", data)
+	}
+
+	delivered, _ := svc.GetAnnouncement(ctx, now.ID)
+	if delivered.Status != AnnouncementStatusSent || delivered.SentCount != 1 || delivered.TargetCount != 1 || delivered.CompletedAt == nil {
+		t.Errorf("Unexpected delivery stats: %+v", delivered)
+	}
+	if pending, _ := svc.GetAnnouncement(ctx, later.ID); pending.Status != AnnouncementStatusScheduled {
+		t.Errorf("Expected later announcement to stay scheduled, got %s", pending.Status)
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, enUser.ID, 0)
+	if len(logs) != 1 || logs[0].NotificationType != string(NotificationEventAnnouncement) {
+		t.Errorf("Expected announcement log, got %+v", logs)
+	}
+
+	// 配信済みのお知らせは再度配信しない
+	result, _ = handler.DeliverAnnouncements(ctx)
+	if result.Announcements != 0 || len(mockSender.SentPushNotifications) != 1 {
+		t.Errorf("Expected no redelivery, got %+v", result)
+	}
+
+	// 配信済みのお知らせは取り消せない
+	if _, err := svc.CancelAnnouncement(ctx, now.ID); !errors.Is(err, ErrAnnouncementNotCancellable) {
+		t.Errorf("Expected ErrAnnouncementNotCancellable, got %v", err)
+	}
+}
+
+// TestDeliverAnnouncements_Batches は対象ユーザーが多い場合の分割配信のテストです。
+// 期待動作:
+//   - 1回の処理で AnnouncementBatchSize 人まで配信し、sending のまま続きを次回に配信する
+//   - 中断後に再開しても同じユーザーへ二重に送信しない
+func TestDeliverAnnouncements_Batches(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	for i := 0; i < AnnouncementBatchSize+1; i++ {
+		_ = mockRepos.User().Create(ctx, &model.User{Email: fmt.Sprintf("user%d@example.com", i), Timezone: "UTC", IsActive: true})
+	}
+	announcement, _ := svc.CreateAnnouncement(ctx, 1, CreateAnnouncementInput{Title: "新機能", Body: "本文"})
+
+	// Act
+	first, _ := handler.DeliverAnnouncements(ctx)
+	inProgress, _ := svc.GetAnnouncement(ctx, announcement.ID)
+
+	// 最後のユーザーへの配信前に中断された状態を再現する
+	resumed := *inProgress
+	resumed.LastUserID--
+	_ = mockRepos.Announcement().Update(ctx, &resumed)
+	second, _ := handler.DeliverAnnouncements(ctx)
+
+	// Assert
+	if first.Recipients != AnnouncementBatchSize || inProgress.Status != AnnouncementStatusSending {
+		t.Errorf("Expected first batch of %d, got %+v (%s)", AnnouncementBatchSize, first, inProgress.Status)
+	}
+	if second.Completed != 1 {
+		t.Errorf("Expected delivery to complete, got %+v", second)
+	}
+	done, _ := svc.GetAnnouncement(ctx, announcement.ID)
+	if done.SentCount != AnnouncementBatchSize+1 || done.DuplicateCount != 1 || done.TargetCount != AnnouncementBatchSize+1 {
+		t.Errorf("Unexpected delivery stats: %+v", done)
+	}
+	if len(mockSender.SentEmailNotifications) != AnnouncementBatchSize+1 {
+		t.Errorf("Expected %d emails, got %d", AnnouncementBatchSize+1, len(mockSender.SentEmailNotifications))
+	}
+}
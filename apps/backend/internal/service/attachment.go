@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Attachments - 作物・区画の添付ファイル
+// =============================================================================
+// 土壌検査の報告書・種苗の請求書などのファイル（PDF・画像）を作物・区画に添付します。
+// ファイルは写真と同様に保存先に直接アップロードし、登録（CreateAttachment）の時点で保存先が形式とサイズを確認します。
+// 添付ファイルの一覧・ダウンロードは添付先の作物・区画を閲覧できるユーザー（所有者・組織のメンバー）、
+// 削除はアップロードしたユーザーのみです。
+// 添付ファイルの SizeBytes はユーザーの保存容量の上限（storage_quota.go）に含めます。
+
+// 添付先の種類
+const (
+	AttachmentParentCrop = "crop"
+	AttachmentParentPlot = "plot"
+)
+
+// maxAttachmentFileNameLength は添付ファイルのファイル名の最大長（文字数）です
+const maxAttachmentFileNameLength = 255
+
+var (
+	// ErrAttachmentStorageNotConfigured は添付ファイルの保存先が未設定の場合のエラー
+	ErrAttachmentStorageNotConfigured = errors.New("attachment storage is not configured")
+	// ErrAttachmentNotOwned は他ユーザーの添付ファイルにアクセスしようとした場合のエラー
+	ErrAttachmentNotOwned = errors.New("attachment does not belong to user")
+	// ErrAttachmentParentNotFound は添付先の作物・区画が存在しない、またはユーザーの記録・メンバーの組織の記録でない場合のエラー
+	ErrAttachmentParentNotFound = errors.New("attachment parent not found")
+	// ErrAttachmentAlreadyRegistered はアップロードしたファイル（オブジェクトキー）が添付ファイルとして登録済みの場合のエラー
+	ErrAttachmentAlreadyRegistered = errors.New("attachment already registered")
+)
+
+// AttachmentUpload は添付ファイルとして登録する直接アップロードしたファイルです。
+type AttachmentUpload struct {
+	ParentType  string // 添付先の種類（crop, plot）
+	ParentID    uint   // 添付先の作物・区画のID
+	ObjectKey   string // 登録したファイルのオブジェクトキー（storage.Service.RegisterAttachmentUpload）
+	FileName    string // 元のファイル名（パスは除く）
+	ContentType string // 内容から判定したMIMEタイプ
+	SizeBytes   int64  // ファイルのサイズ（バイト）
+}
+
+// AttachmentStorage は添付ファイルの保存先です（storage.Service）。
+type AttachmentStorage interface {
+	DeleteAttachment(ctx context.Context, objectKey string) error
+}
+
+// SetAttachmentStorage は添付ファイルの保存先を設定します。
+// 未設定の場合、添付ファイルの登録（CreateAttachment）・削除は ErrAttachmentStorageNotConfigured を返します。
+func (s *Service) SetAttachmentStorage(storage AttachmentStorage) {
+	s.attachmentStorage = storage
+}
+
+// CreateAttachment は直接アップロードしたファイルを作物・区画の添付ファイルとして登録します。
+// 保存容量の上限を超える場合・添付先が見つからない場合は、アップロードしたファイルを削除します。
+// 登録済みのファイルは登録済みの添付ファイルが使用しているため、削除せずにエラーを返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - upload: 登録するファイルと添付先
+//
+// 戻り値:
+//   - *model.Attachment: 作成した添付ファイル
+//   - error: 保存先が未設定の場合は ErrAttachmentStorageNotConfigured、添付先が見つからない場合は ErrAttachmentParentNotFound、
+//     上限を超える場合は ErrStorageQuotaExceeded、登録済みのファイルの場合は ErrAttachmentAlreadyRegistered
+func (s *Service) CreateAttachment(ctx context.Context, userID uint, upload AttachmentUpload) (*model.Attachment, error) {
+	if s.attachmentStorage == nil {
+		return nil, ErrAttachmentStorageNotConfigured
+	}
+	registered, err := s.repos.Attachment().ExistsByObjectKey(ctx, upload.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		return nil, ErrAttachmentAlreadyRegistered
+	}
+	if err := s.checkAttachmentUpload(ctx, userID, upload); err != nil {
+		if deleteErr := s.attachmentStorage.DeleteAttachment(ctx, upload.ObjectKey); deleteErr != nil {
+			logging.FromContext(ctx).Warn("Failed to delete rejected attachment", "object_key", upload.ObjectKey, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	attachment := &model.Attachment{
+		UserID:      userID,
+		ParentType:  upload.ParentType,
+		ParentID:    upload.ParentID,
+		FileName:    sanitizeAttachmentFileName(upload.FileName, path.Ext(upload.ObjectKey)),
+		ContentType: upload.ContentType,
+		ObjectKey:   upload.ObjectKey,
+		SizeBytes:   upload.SizeBytes,
+	}
+	if err := s.repos.Attachment().Create(ctx, attachment); err != nil {
+		// 同時に登録した場合（オブジェクトキーのユニークインデックス）
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAttachmentAlreadyRegistered
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// checkAttachmentUpload は添付先（ユーザーの記録、またはユーザーがメンバーの組織の記録）と保存容量の上限を確認します。
+func (s *Service) checkAttachmentUpload(ctx context.Context, userID uint, upload AttachmentUpload) error {
+	if err := s.CheckAttachmentParent(ctx, userID, upload.ParentType, upload.ParentID); err != nil {
+		return err
+	}
+	return s.CheckStorageQuota(ctx, userID, upload.SizeBytes)
+}
+
+// CheckAttachmentParent は添付先の作物・区画をユーザーが閲覧できるか（所有者・組織のメンバー）確認します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - parentType: 添付先の種類（crop, plot）
+//   - parentID: 添付先の作物・区画のID
+//
+// 戻り値:
+//   - error: 存在しない・閲覧できない場合は ErrAttachmentParentNotFound、取得に失敗した場合のエラー
+func (s *Service) CheckAttachmentParent(ctx context.Context, userID uint, parentType string, parentID uint) error {
+	shared, err := s.sharesAttachmentParent(ctx, userID, parentType, parentID)
+	if err != nil {
+		return err
+	}
+	if !shared {
+		return ErrAttachmentParentNotFound
+	}
+	return nil
+}
+
+// sharesAttachmentParent は添付先がユーザーの記録、またはユーザーがメンバーの組織の記録か判定します（削除された場合は false）。
+func (s *Service) sharesAttachmentParent(ctx context.Context, userID uint, parentType string, parentID uint) (bool, error) {
+	var (
+		ownerID        uint
+		organizationID *uint
+	)
+	switch parentType {
+	case AttachmentParentCrop:
+		crop, err := s.repos.Crop().GetByID(ctx, parentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		ownerID, organizationID = crop.UserID, crop.OrganizationID
+	case AttachmentParentPlot:
+		plot, err := s.repos.Plot().GetByID(ctx, parentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		ownerID, organizationID = plot.UserID, plot.OrganizationID
+	default:
+		return false, nil
+	}
+	return s.sharesOrganizationRecord(ctx, userID, ownerID, organizationID)
+}
+
+// ListAttachments は作物・区画の添付ファイルを古い順に取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - parentType: 添付先の種類（crop, plot）
+//   - parentID: 添付先の作物・区画のID
+//
+// 戻り値:
+//   - []model.Attachment: 添付ファイルの一覧（ない場合は空）
+//   - error: 添付先が見つからない場合は ErrAttachmentParentNotFound
+func (s *Service) ListAttachments(ctx context.Context, userID uint, parentType string, parentID uint) ([]model.Attachment, error) {
+	if err := s.CheckAttachmentParent(ctx, userID, parentType, parentID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.repos.Attachment().ListByParent(ctx, parentType, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	if attachments == nil {
+		attachments = []model.Attachment{}
+	}
+	return attachments, nil
+}
+
+// GetAttachment はユーザーが閲覧できる添付ファイルを取得します（ダウンロード）。
+// アップロードしたユーザーのほか、添付先の作物・区画の所有者・組織のメンバーが取得できます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - id: 添付ファイルのID
+//
+// 戻り値:
+//   - *model.Attachment: 添付ファイル
+//   - error: 存在しない場合はリポジトリのエラー、閲覧できない場合は ErrAttachmentNotOwned
+func (s *Service) GetAttachment(ctx context.Context, userID, id uint) (*model.Attachment, error) {
+	attachment, err := s.repos.Attachment().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.UserID == userID {
+		return attachment, nil
+	}
+
+	shared, err := s.sharesAttachmentParent(ctx, userID, attachment.ParentType, attachment.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, ErrAttachmentNotOwned
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment はユーザーがアップロードした添付ファイルを削除します。
+// 保存先のファイルを削除してから添付ファイルを論理削除するため、保存先の削除に失敗した場合は再度削除できます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - id: 添付ファイルのID
+//
+// 戻り値:
+//   - error: 存在しない場合はリポジトリのエラー、他ユーザーの場合は ErrAttachmentNotOwned、
+//     保存先が未設定の場合は ErrAttachmentStorageNotConfigured、保存先の削除に失敗した場合のエラー
+func (s *Service) DeleteAttachment(ctx context.Context, userID, id uint) error {
+	attachment, err := s.repos.Attachment().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.UserID != userID {
+		return ErrAttachmentNotOwned
+	}
+	if s.attachmentStorage == nil {
+		return ErrAttachmentStorageNotConfigured
+	}
+
+	if err := s.attachmentStorage.DeleteAttachment(ctx, attachment.ObjectKey); err != nil {
+		return err
+	}
+	return s.repos.Attachment().Delete(ctx, attachment.ID)
+}
+
+// sanitizeAttachmentFileName は添付ファイルのファイル名からパスと制御文字を除き、最大長に切り詰めます。
+// 空の場合は attachment{ext} とします。
+func sanitizeAttachmentFileName(fileName, ext string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return "attachment" + ext
+	}
+	if runes := []rune(name); len(runes) > maxAttachmentFileNameLength {
+		name = string(runes[:maxAttachmentFileNameLength])
+	}
+	return name
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Attachment Tests - 作物・区画の添付ファイルのテスト
+// =============================================================================
+// テスト対象:
+//   - CreateAttachment: 添付先の確認、保存容量の上限、拒否したファイルの削除、ファイル名の整理、登録済みのファイルの拒否
+//   - ListAttachments / GetAttachment: 添付先の所有者・組織のメンバーの閲覧
+//   - DeleteAttachment: アップロードしたユーザーのみの削除と保存先のファイルの削除
+
+// mockAttachmentStorage はテスト用の添付ファイルの保存先です。
+type mockAttachmentStorage struct {
+	objects map[string][]byte
+}
+
+func (s *mockAttachmentStorage) DeleteAttachment(ctx context.Context, objectKey string) error {
+	delete(s.objects, objectKey)
+	return nil
+}
+
+// TestCreateAttachment は添付ファイルの登録のテストです。
+// 期待動作:
+//   - ユーザーの作物・メンバーの組織の区画に登録し、ファイル名からパスと制御文字を除く
+//   - 他ユーザーの作物・存在しない区画は ErrAttachmentParentNotFound で、アップロードしたファイルを削除する
+//   - 保存容量の上限を超える場合は ErrStorageQuotaExceeded で、アップロードしたファイルを削除する
+//   - 保存先が未設定の場合は ErrAttachmentStorageNotConfigured
+func TestCreateAttachment(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetStorageQuota(1000)
+	ctx := context.Background()
+	storage := &mockAttachmentStorage{objects: map[string][]byte{
+		"attachments/1/2026/05/report.pdf":  []byte("report"),
+		"attachments/1/2026/05/invoice.pdf": []byte("invoice"),
+		"attachments/1/2026/05/other.pdf":   []byte("other"),
+		"attachments/1/2026/05/large.pdf":   []byte("large"),
+	}}
+	svc.SetAttachmentStorage(storage)
+
+	crop := &model.Crop{UserID: 1, Name: "トマト"}
+	otherCrop := &model.Crop{UserID: 2, Name: "キュウリ"}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	_ = mockRepos.Crop().Create(ctx, otherCrop)
+	orgID := uint(7)
+	orgPlot := &model.Plot{UserID: 2, OrganizationID: &orgID, Name: "共同区画A"}
+	_ = mockRepos.Plot().Create(ctx, orgPlot)
+	_ = mockRepos.OrganizationMember().Create(ctx, &model.OrganizationMember{OrganizationID: orgID, UserID: 1, Role: model.OrganizationRoleMember})
+
+	upload := func(parentType string, parentID uint, key, name string, size int64) AttachmentUpload {
+		return AttachmentUpload{ParentType: parentType, ParentID: parentID, ObjectKey: key, FileName: name, ContentType: "application/pdf", SizeBytes: size}
+	}
+
+	// Act
+	report, reportErr := svc.CreateAttachment(ctx, 1, upload(AttachmentParentCrop, crop.ID, "attachments/1/2026/05/report.pdf", "C:\\docs\\土壌検査\x00.pdf", 400))
+	invoice, invoiceErr := svc.CreateAttachment(ctx, 1, upload(AttachmentParentPlot, orgPlot.ID, "attachments/1/2026/05/invoice.pdf", "", 300))
+	_, otherErr := svc.CreateAttachment(ctx, 1, upload(AttachmentParentCrop, otherCrop.ID, "attachments/1/2026/05/other.pdf", "other.pdf", 10))
+	_, missingErr := svc.CreateAttachment(ctx, 1, upload(AttachmentParentPlot, 999, "attachments/1/2026/05/missing.pdf", "missing.pdf", 10))
+	_, quotaErr := svc.CreateAttachment(ctx, 1, upload(AttachmentParentCrop, crop.ID, "attachments/1/2026/05/large.pdf", "large.pdf", 301))
+	_, unconfiguredErr := NewService(mockRepos).CreateAttachment(ctx, 1, upload(AttachmentParentCrop, crop.ID, "attachments/1/2026/05/x.pdf", "x.pdf", 1))
+
+	// Assert
+	if reportErr != nil || invoiceErr != nil {
+		t.Fatalf("CreateAttachment failed: %v / %v", reportErr, invoiceErr)
+	}
+	if report.FileName != "土壌検査.pdf" || report.UserID != 1 || report.ParentID != crop.ID {
+		t.Errorf("Expected the attachment of the crop named 土壌検査.pdf, got %+v", report)
+	}
+	if invoice.FileName != "attachment.pdf" || invoice.ParentType != AttachmentParentPlot {
+		t.Errorf("Expected the organization plot attachment named attachment.pdf, got %+v", invoice)
+	}
+	if !errors.Is(otherErr, ErrAttachmentParentNotFound) || !errors.Is(missingErr, ErrAttachmentParentNotFound) {
+		t.Errorf("Expected ErrAttachmentParentNotFound, got %v / %v", otherErr, missingErr)
+	}
+	if !errors.Is(quotaErr, ErrStorageQuotaExceeded) {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", quotaErr)
+	}
+	if !errors.Is(unconfiguredErr, ErrAttachmentStorageNotConfigured) {
+		t.Errorf("Expected ErrAttachmentStorageNotConfigured, got %v", unconfiguredErr)
+	}
+	if len(storage.objects) != 2 {
+		t.Errorf("Expected the rejected uploads to be deleted, got %d objects", len(storage.objects))
+	}
+	if usage, _ := svc.GetStorageUsage(ctx, 1); usage.UsedBytes != 700 {
+		t.Errorf("Expected 700 bytes of attachments in the storage usage, got %d", usage.UsedBytes)
+	}
+}
+
+// TestCreateAttachment_AlreadyRegistered は登録済みのファイルの登録のテストです。
+// 期待動作:
+//   - 同じオブジェクトキーを同じ作物・別の区画に登録すると ErrAttachmentAlreadyRegistered
+//   - 登録済みの添付ファイルが使用しているため、アップロードしたファイルを削除しない
+//   - 保存容量の使用量は1件分のみ
+func TestCreateAttachment_AlreadyRegistered(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetStorageQuota(1000)
+	ctx := context.Background()
+	storage := &mockAttachmentStorage{objects: map[string][]byte{
+		"attachments/1/2026/05/report.pdf": []byte("report"),
+	}}
+	svc.SetAttachmentStorage(storage)
+
+	crop := &model.Crop{UserID: 1, Name: "トマト"}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	plot := &model.Plot{UserID: 1, Name: "区画A"}
+	_ = mockRepos.Plot().Create(ctx, plot)
+	upload := AttachmentUpload{ParentType: AttachmentParentCrop, ParentID: crop.ID, ObjectKey: "attachments/1/2026/05/report.pdf", FileName: "report.pdf", ContentType: "application/pdf", SizeBytes: 400}
+	if _, err := svc.CreateAttachment(ctx, 1, upload); err != nil {
+		t.Fatalf("CreateAttachment failed: %v", err)
+	}
+
+	// Act
+	_, sameParentErr := svc.CreateAttachment(ctx, 1, upload)
+	otherParent := upload
+	otherParent.ParentType = AttachmentParentPlot
+	otherParent.ParentID = plot.ID
+	_, otherParentErr := svc.CreateAttachment(ctx, 1, otherParent)
+
+	// Assert
+	if !errors.Is(sameParentErr, ErrAttachmentAlreadyRegistered) || !errors.Is(otherParentErr, ErrAttachmentAlreadyRegistered) {
+		t.Errorf("Expected ErrAttachmentAlreadyRegistered, got %v / %v", sameParentErr, otherParentErr)
+	}
+	if _, ok := storage.objects[upload.ObjectKey]; !ok {
+		t.Error("Expected the registered upload not to be deleted")
+	}
+	if usage, _ := svc.GetStorageUsage(ctx, 1); usage.UsedBytes != 400 {
+		t.Errorf("Expected 400 bytes of attachments in the storage usage, got %d", usage.UsedBytes)
+	}
+}
+
+// TestAttachmentAccess は添付ファイルの閲覧・削除のテストです。
+// 期待動作:
+//   - 添付先の区画の組織のメンバーは一覧・取得できるが、削除はアップロードしたユーザーのみ（ErrAttachmentNotOwned）
+//   - 組織のメンバーでないユーザーの一覧は ErrAttachmentParentNotFound、取得は ErrAttachmentNotOwned
+//   - 削除した添付ファイルは保存先のファイルも削除し、一覧に含めない
+func TestAttachmentAccess(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	key := "attachments/1/2026/05/soil.pdf"
+	storage := &mockAttachmentStorage{objects: map[string][]byte{key: []byte("soil")}}
+	svc.SetAttachmentStorage(storage)
+
+	orgID := uint(3)
+	plot := &model.Plot{UserID: 1, OrganizationID: &orgID, Name: "共同区画B"}
+	_ = mockRepos.Plot().Create(ctx, plot)
+	_ = mockRepos.OrganizationMember().Create(ctx, &model.OrganizationMember{OrganizationID: orgID, UserID: 2, Role: model.OrganizationRoleMember})
+	attachment, err := svc.CreateAttachment(ctx, 1, AttachmentUpload{ParentType: AttachmentParentPlot, ParentID: plot.ID, ObjectKey: key, FileName: "soil.pdf", ContentType: "application/pdf", SizeBytes: 4})
+	if err != nil {
+		t.Fatalf("CreateAttachment failed: %v", err)
+	}
+
+	// Act
+	memberList, memberListErr := svc.ListAttachments(ctx, 2, AttachmentParentPlot, plot.ID)
+	_, memberGetErr := svc.GetAttachment(ctx, 2, attachment.ID)
+	_, outsiderListErr := svc.ListAttachments(ctx, 4, AttachmentParentPlot, plot.ID)
+	_, outsiderGetErr := svc.GetAttachment(ctx, 4, attachment.ID)
+	memberDeleteErr := svc.DeleteAttachment(ctx, 2, attachment.ID)
+	deleteErr := svc.DeleteAttachment(ctx, 1, attachment.ID)
+	afterDelete, _ := svc.ListAttachments(ctx, 1, AttachmentParentPlot, plot.ID)
+
+	// Assert
+	if memberListErr != nil || len(memberList) != 1 || memberList[0].ID != attachment.ID {
+		t.Errorf("Expected the member to list the attachment, got %+v %v", memberList, memberListErr)
+	}
+	if memberGetErr != nil {
+		t.Errorf("Expected the member to get the attachment, got %v", memberGetErr)
+	}
+	if !errors.Is(outsiderListErr, ErrAttachmentParentNotFound) || !errors.Is(outsiderGetErr, ErrAttachmentNotOwned) {
+		t.Errorf("Expected the outsider to be rejected, got %v / %v", outsiderListErr, outsiderGetErr)
+	}
+	if !errors.Is(memberDeleteErr, ErrAttachmentNotOwned) {
+		t.Errorf("Expected ErrAttachmentNotOwned for the member, got %v", memberDeleteErr)
+	}
+	if deleteErr != nil {
+		t.Fatalf("DeleteAttachment failed: %v", deleteErr)
+	}
+	if _, ok := storage.objects[key]; ok {
+		t.Error("Expected the file to be deleted from the storage")
+	}
+	if afterDelete == nil || len(afterDelete) != 0 {
+		t.Errorf("Expected an empty list after the delete, got %+v", afterDelete)
+	}
+}
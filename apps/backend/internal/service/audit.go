@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Audit Log - 作物・区画・タスク・収穫記録の変更履歴の参照
+// =============================================================================
+// 変更履歴は repository.AuditPlugin が変更と同じトランザクションで記録します。
+// 記録の所有者（収穫記録は作物の所有者）は自分の記録の履歴を、管理者は全ての記録の履歴を参照できます。
+
+// ErrInvalidAuditEntity は変更履歴のエンティティが不正な場合のエラー
+var ErrInvalidAuditEntity = errors.New("invalid audit entity")
+
+// GetAuditLogsPage は記録の変更履歴を新しい順に1ページ分取得します。
+// 管理者以外のユーザーには、所有者が自分の変更履歴のみを返します（他のユーザーの記録の場合は空のページ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 参照するユーザーID
+//   - entity: エンティティ（repository.AuditEntities）
+//   - entityID: 記録のID
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.AuditLog]: 変更履歴の1ページ分（新しい順）
+//   - error: 不正なエンティティの場合は ErrInvalidAuditEntity、取得に失敗した場合のエラー
+func (s *Service) GetAuditLogsPage(ctx context.Context, userID uint, entity string, entityID uint, params pagination.Params) (*pagination.Page[model.AuditLog], error) {
+	if !slices.Contains(repository.AuditEntities, entity) {
+		return nil, ErrInvalidAuditEntity
+	}
+
+	admin, err := s.IsAdminUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ownerID := userID
+	if admin {
+		ownerID = 0
+	}
+
+	logs, err := s.repos.AuditLog().ListByEntity(ctx, entity, entityID, ownerID, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(logs, params, func(l model.AuditLog) uint { return l.ID }), nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+)
+
+// =============================================================================
+// Background Jobs - バックグラウンドジョブ
+// =============================================================================
+// 登録完了メールなど、レスポンスを待たせる必要のない処理はワーカープールで実行します。
+// ジョブにはリクエストのコンテキストではなくワーカープールのコンテキストを渡すため、
+// レスポンスを返した後やシャットダウン中も処理を続けられます。
+
+// BackgroundRunner はバックグラウンドジョブを実行するワーカープールです（worker.Pool）。
+type BackgroundRunner interface {
+	Submit(name string, run func(ctx context.Context) error) error
+}
+
+// SetBackgroundRunner はバックグラウンドジョブを実行するワーカープールを設定します。
+// 未設定の場合、バックグラウンドジョブは呼び出し元で実行します。
+func (s *Service) SetBackgroundRunner(runner BackgroundRunner) {
+	s.background = runner
+}
+
+// runInBackground はジョブをワーカープールに登録します。
+// ワーカープールが未設定・登録できない場合（シャットダウン中、キューが満杯）は呼び出し元で実行します。
+func (s *Service) runInBackground(ctx context.Context, name string, run func(ctx context.Context) error) {
+	if s.background != nil {
+		err := s.background.Submit(name, run)
+		if err == nil {
+			return
+		}
+		logging.FromContext(ctx).Warn("Failed to submit background job, running inline", "job", name, "error", err)
+	}
+	if err := run(ctx); err != nil {
+		logging.FromContext(ctx).Warn("Background job failed", "job", name, "error", err)
+	}
+}
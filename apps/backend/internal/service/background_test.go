@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Background Job Tests - バックグラウンドジョブのテスト
+// =============================================================================
+// テスト対象:
+//   - runInBackground: 呼び出し元で実行したジョブの失敗の警告ログ
+
+// TestService_RunInBackground_LogsWithRequestContext はジョブの失敗の警告ログのテストです。
+// 期待動作:
+//   - 失敗したジョブはリクエストのロガー（logging.FromContext）で WARN として出力する
+//   - 警告ログにリクエストの request_id・user_id とジョブ名・エラーが付く
+func TestService_RunInBackground_LogsWithRequestContext(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	svc := NewService(repository.NewMockRepositories())
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)))
+	ctx = logging.WithRequestID(ctx, "req-background-1")
+	ctx = logging.WithUserID(ctx, 42)
+
+	// Act
+	svc.runInBackground(ctx, "welcome_email", func(ctx context.Context) error {
+		return errors.New("smtp unavailable")
+	})
+
+	// Assert
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log line, got %q", buf.String())
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "Background job failed" {
+		t.Errorf("Expected a background job warning, got %v", entry)
+	}
+	if entry["request_id"] != "req-background-1" || entry["user_id"] != float64(42) {
+		t.Errorf("Expected request_id and user_id from the request, got %v", entry)
+	}
+	if entry["job"] != "welcome_email" || entry["error"] != "smtp unavailable" {
+		t.Errorf("Expected the job name and error, got %v", entry)
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Batch Lookup Tests - 作物のまとめての取得のテスト
+// =============================================================================
+// テスト対象:
+//   - GetPlotHistory, GetHarvestSummary, HarvestsCSV: 作物を GetByIDs の1回の呼び出しで取得する
+//   - GetPlotLayout: 区画・配置・作物を GetLayoutByUserID の1回の呼び出しで取得する
+//   - CreateHarvests: 分析のキャッシュの無効化の作物を GetByIDs の1回の呼び出しで取得する
+//   - GetUserActiveCropsByPlotIDs, GetUserHarvestsByCropIDs, GetUserGrowthRecordsByCropIDs: 指定したIDのユーザーの記録のみを取得する
+
+// TestBatchLookup_Crops は一覧・集計の作物の取得のテストです。
+// 期待動作:
+//   - 作物を記録ごとの GetByID ではなく GetByIDs の1回の呼び出しで取得する（重複したIDは1回）
+//   - レイアウトは作物を個別に取得しない
+//   - レイアウト・履歴・集計・CSV の内容は記録ごとに取得した場合と同じ
+//   - 見つからない作物（削除済み）は作物なし・空の作物名として扱う
+func TestBatchLookup_Crops(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	var crops []*model.Crop
+	for _, name := range []string{"トマト", "キュウリ", "ナス"} {
+		crop := &model.Crop{UserID: 1, Name: name, Status: "growing", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+		if err := svc.CreateCrop(ctx, crop); err != nil {
+			t.Fatalf("CreateCrop failed: %v", err)
+		}
+		crops = append(crops, crop)
+	}
+	var plots []*model.Plot
+	for _, name := range []string{"A区画", "B区画", "C区画"} {
+		plot := &model.Plot{UserID: 1, Name: name, Width: 1, Height: 1, Status: "available"}
+		if err := svc.CreatePlot(ctx, plot); err != nil {
+			t.Fatalf("CreatePlot failed: %v", err)
+		}
+		plots = append(plots, plot)
+	}
+	for i, crop := range crops[:2] {
+		if _, err := svc.AssignCropToPlot(ctx, plots[i].ID, crop.ID, planted); err != nil {
+			t.Fatalf("AssignCropToPlot failed: %v", err)
+		}
+	}
+	if err := svc.UnassignCropFromPlot(ctx, plots[0].ID); err != nil {
+		t.Fatalf("UnassignCropFromPlot failed: %v", err)
+	}
+	if _, err := svc.AssignCropToPlot(ctx, plots[0].ID, crops[2].ID, planted.AddDate(0, 1, 0)); err != nil {
+		t.Fatalf("AssignCropToPlot failed: %v", err)
+	}
+	for _, cropID := range []uint{crops[0].ID, crops[0].ID, crops[1].ID} {
+		mockRepos.GetMockHarvestRepository().AddHarvestForUser(1, &model.Harvest{CropID: cropID, HarvestDate: planted.AddDate(0, 2, 0), Quantity: 1, QuantityUnit: "kg"})
+	}
+	// 収穫記録の作物が削除されている場合
+	delete(mockRepos.GetMockCropRepository().Crops, crops[1].ID)
+
+	cropRepo := mockRepos.GetMockCropRepository()
+	var batchCalls [][]uint
+	cropRepo.GetByIDsFunc = func(ctx context.Context, ids []uint) ([]model.Crop, error) {
+		batchCalls = append(batchCalls, ids)
+		var result []model.Crop
+		for _, id := range ids {
+			if crop, ok := cropRepo.Crops[id]; ok {
+				result = append(result, *crop)
+			}
+		}
+		return result, nil
+	}
+	cropRepo.GetByIDFunc = func(ctx context.Context, id uint) (*model.Crop, error) {
+		t.Errorf("Unexpected GetByID(%d) call", id)
+		return nil, nil
+	}
+
+	// Act
+	layout, layoutErr := svc.GetPlotLayout(ctx, 1)
+	history, historyErr := svc.GetPlotHistory(ctx, plots[0].ID)
+	summary, summaryErr := svc.GetHarvestSummary(ctx, 1, HarvestFilter{})
+	harvests, _ := mockRepos.GetMockHarvestRepository().GetByUserIDWithDateRange(ctx, 1, nil, nil)
+	csvResult, csvErr := svc.HarvestsCSV(ctx, harvests)
+
+	// Assert
+	for _, err := range []error{layoutErr, historyErr, summaryErr, csvErr} {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(batchCalls) != 3 {
+		t.Fatalf("Expected 3 GetByIDs calls (one per operation except the layout), got %d: %v", len(batchCalls), batchCalls)
+	}
+	if ids := batchCalls[1]; len(ids) != 2 {
+		t.Errorf("Expected duplicate crop IDs to be requested once, got %v", ids)
+	}
+
+	activeCrops := map[uint]string{}
+	for _, item := range layout {
+		if item.ActiveCrop != nil {
+			activeCrops[item.Plot.ID] = item.ActiveCrop.Name
+		}
+	}
+	if activeCrops[plots[0].ID] != "ナス" || activeCrops[plots[1].ID] != "" || len(layout) != 3 {
+		t.Errorf("Unexpected layout crops: %v", activeCrops)
+	}
+	if len(history) != 2 || history[0].Crop == nil || history[1].Crop == nil {
+		t.Errorf("Expected 2 history items with crops, got %+v", history)
+	}
+	if summary.TotalHarvests != 3 || len(summary.CropSummaries) != 1 || summary.CropSummaries[0].CropName != "トマト" {
+		t.Errorf("Expected only the existing crop in the summary, got %+v", summary)
+	}
+	if csvResult.RecordCount != 3 {
+		t.Errorf("Expected 3 harvest rows, got %d", csvResult.RecordCount)
+	}
+}
+
+// TestBatchLookup_UserScopedLoaders は GraphQL の DataLoader 用のまとめての取得のテストです。
+// 期待動作:
+//   - 配置中の作物は指定した区画のみを区画IDをキーとして返し、レイアウト全体を取得しない
+//   - 他のユーザーの区画・作物のIDは無視し、その作物の収穫記録・成長記録を返さない
+func TestBatchLookup_UserScopedLoaders(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	newCrop := func(userID uint, name string) *model.Crop {
+		crop := &model.Crop{UserID: userID, Name: name, Status: "growing", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+		if err := svc.CreateCrop(ctx, crop); err != nil {
+			t.Fatalf("CreateCrop failed: %v", err)
+		}
+		return crop
+	}
+	newPlot := func(userID uint, name string, crop *model.Crop) *model.Plot {
+		plot := &model.Plot{UserID: userID, Name: name, Width: 1, Height: 1, Status: "available"}
+		if err := svc.CreatePlot(ctx, plot); err != nil {
+			t.Fatalf("CreatePlot failed: %v", err)
+		}
+		if crop != nil {
+			if _, err := svc.AssignCropToPlot(ctx, plot.ID, crop.ID, planted); err != nil {
+				t.Fatalf("AssignCropToPlot failed: %v", err)
+			}
+		}
+		return plot
+	}
+	tomato, cucumber, others := newCrop(1, "トマト"), newCrop(1, "キュウリ"), newCrop(2, "他のユーザーのナス")
+	plotA, plotB, plotC := newPlot(1, "A区画", tomato), newPlot(1, "B区画", cucumber), newPlot(1, "C区画", nil)
+	otherPlot := newPlot(2, "他のユーザーの区画", others)
+	for _, crop := range []*model.Crop{tomato, others} {
+		mockRepos.GetMockHarvestRepository().AddHarvestForUser(crop.UserID, &model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 2, 0), Quantity: 1, QuantityUnit: "kg"})
+		if err := svc.CreateGrowthRecord(ctx, &model.GrowthRecord{CropID: crop.ID, RecordDate: planted, GrowthStage: "seedling"}); err != nil {
+			t.Fatalf("CreateGrowthRecord failed: %v", err)
+		}
+	}
+
+	// Act
+	activeCrops, activeErr := svc.GetUserActiveCropsByPlotIDs(ctx, 1, []uint{plotA.ID, plotC.ID, otherPlot.ID})
+	harvests, harvestsErr := svc.GetUserHarvestsByCropIDs(ctx, 1, []uint{tomato.ID, others.ID})
+	records, recordsErr := svc.GetUserGrowthRecordsByCropIDs(ctx, 1, []uint{tomato.ID, others.ID})
+
+	// Assert
+	for _, err := range []error{activeErr, harvestsErr, recordsErr} {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(activeCrops) != 1 || activeCrops[plotA.ID] == nil || activeCrops[plotA.ID].Name != "トマト" {
+		t.Errorf("Expected only the active crop of the requested own plot, got %v", activeCrops)
+	}
+	if _, ok := activeCrops[plotB.ID]; ok {
+		t.Errorf("Expected the unrequested plot not to be loaded, got %v", activeCrops)
+	}
+	if len(harvests) != 1 || harvests[0].CropID != tomato.ID {
+		t.Errorf("Expected only the harvests of the user's crop, got %+v", harvests)
+	}
+	if len(records) != 1 || records[0].CropID != tomato.ID {
+		t.Errorf("Expected only the growth records of the user's crop, got %+v", records)
+	}
+}
+
+// TestBatchLookup_CreateHarvests は収穫記録の一括作成の作物の取得のテストです。
+// 期待動作:
+//   - 作物を作物ごとの GetByID ではなく GetByIDs の1回の呼び出しで取得する（重複したIDは1回）
+//   - 収穫記録はすべて作成する
+func TestBatchLookup_CreateHarvests(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	planted := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	var harvests []model.Harvest
+	for _, name := range []string{"トマト", "キュウリ"} {
+		crop := &model.Crop{UserID: 1, Name: name, Status: "growing", PlantedDate: planted, ExpectedHarvestDate: planted.AddDate(0, 3, 0)}
+		if err := svc.CreateCrop(ctx, crop); err != nil {
+			t.Fatalf("CreateCrop failed: %v", err)
+		}
+		for range 3 {
+			harvests = append(harvests, model.Harvest{CropID: crop.ID, HarvestDate: planted.AddDate(0, 2, 0), Quantity: 1, QuantityUnit: "kg"})
+		}
+	}
+	cropRepo := mockRepos.GetMockCropRepository()
+	var batchCalls [][]uint
+	cropRepo.GetByIDsFunc = func(ctx context.Context, ids []uint) ([]model.Crop, error) {
+		batchCalls = append(batchCalls, ids)
+		var result []model.Crop
+		for _, id := range ids {
+			if crop, ok := cropRepo.Crops[id]; ok {
+				result = append(result, *crop)
+			}
+		}
+		return result, nil
+	}
+	cropRepo.GetByIDFunc = func(ctx context.Context, id uint) (*model.Crop, error) {
+		t.Errorf("Unexpected GetByID(%d) call", id)
+		return nil, nil
+	}
+
+	// Act
+	err := svc.CreateHarvests(ctx, harvests)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreateHarvests failed: %v", err)
+	}
+	if len(batchCalls) != 1 || len(batchCalls[0]) != 2 {
+		t.Errorf("Expected one GetByIDs call with 2 crop IDs, got %v", batchCalls)
+	}
+	if got := len(mockRepos.GetMockHarvestRepository().Harvests); got != 6 {
+		t.Errorf("Expected 6 harvests to be created, got %d", got)
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// =============================================================================
+// Benchmark - 匿名ベンチマーク
+// =============================================================================
+
+// ChartTypeCropBenchmark は作物別の匿名ベンチマークグラフ
+const ChartTypeCropBenchmark ChartType = "crop_benchmark"
+
+const (
+	// BenchmarkMinUsers は平均を公開するために必要な比較対象ユーザー数の下限です。
+	// これ未満の場合は個人の値が推測されるのを防ぐため集計値を返しません。
+	BenchmarkMinUsers = 5
+	// BenchmarkQuantileMinUsers は四分位数・順位を公開するために必要な比較対象ユーザー数の下限です。
+	// 少人数の四分位数は個人の値そのもの（5人の場合は2・3・4番目の値）になるため、平均より大きくします。
+	BenchmarkQuantileMinUsers = 20
+	// benchmarkValueStep は公開する集計値（kg/m²）の丸めの単位です
+	benchmarkValueStep = 0.1
+	// benchmarkPercentileStep は公開する順位の丸めの単位です
+	benchmarkPercentileStep = 10
+)
+
+var (
+	// ErrBenchmarkNotOptedIn はベンチマークに参加していないユーザーが参照した場合のエラー
+	ErrBenchmarkNotOptedIn = errors.New("user has not opted in to benchmarks")
+	// ErrBenchmarkCropRequired は作物名が指定されていない場合のエラー
+	ErrBenchmarkCropRequired = errors.New("crop name is required for benchmark")
+)
+
+// CropBenchmarkData は作物別ベンチマークのデータを表します。
+// 比較対象ユーザー（自分を除く）の集計値のみを含み、個々の値は含みません。
+type CropBenchmarkData struct {
+	CropName    string   `json:"crop_name"`
+	UserKgPerM2 *float64 `json:"user_kg_per_m2,omitempty"` // 自分の面積あたり収穫量（データがない場合はnil）
+	PeerCount   int      `json:"peer_count"`               // 比較対象ユーザー数
+	MinPeers    int      `json:"min_peers"`                // 集計値公開に必要な最小ユーザー数
+	Available   bool     `json:"available"`                // 集計値が公開可能か
+	PeerAverage *float64 `json:"peer_average,omitempty"`   // 平均（kg/m²、0.1単位）
+
+	MinPeersForQuantiles int      `json:"min_peers_for_quantiles"` // 四分位数・順位の公開に必要な最小ユーザー数
+	QuantilesAvailable   bool     `json:"quantiles_available"`     // 四分位数・順位が公開可能か
+	PeerMedian           *float64 `json:"peer_median,omitempty"`   // 中央値（kg/m²、0.1単位）
+	PeerP25              *float64 `json:"peer_p25,omitempty"`      // 25パーセンタイル（kg/m²、0.1単位）
+	PeerP75              *float64 `json:"peer_p75,omitempty"`      // 75パーセンタイル（kg/m²、0.1単位）
+	Percentile           *float64 `json:"percentile,omitempty"`    // 比較対象の中での自分の順位（0-100、10単位）
+}
+
+// SetBenchmarkOptIn はユーザーのベンチマーク参加設定を更新します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - optIn: 参加する場合はtrue
+//
+// 戻り値:
+//   - bool: 更新後の設定
+//   - error: 更新に失敗した場合のエラー
+func (s *Service) SetBenchmarkOptIn(ctx context.Context, userID uint, optIn bool) (bool, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	user.BenchmarkOptIn = optIn
+	if err := s.repos.User().Update(ctx, user); err != nil {
+		return false, err
+	}
+	return user.BenchmarkOptIn, nil
+}
+
+// getCropBenchmarkChart は作物別の匿名ベンチマークグラフデータを生成します。
+// ベンチマークに参加しているユーザーのみが閲覧でき、
+// 比較対象がBenchmarkMinUsers未満の場合は集計値を返しません。
+// BenchmarkQuantileMinUsers未満の場合は平均のみを返し、集計値は丸めて個人の値が分からないようにします。
+func (s *Service) getCropBenchmarkChart(ctx context.Context, userID uint, filter ChartFilter) (*ChartData, error) {
+	if filter.CropName == "" {
+		return nil, ErrBenchmarkCropRequired
+	}
+
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.BenchmarkOptIn {
+		return nil, ErrBenchmarkNotOptedIn
+	}
+
+	yields, err := s.repos.Harvest().GetBenchmarkYields(ctx, filter.CropName)
+	if err != nil {
+		return nil, err
+	}
+
+	data := CropBenchmarkData{
+		CropName:             filter.CropName,
+		MinPeers:             BenchmarkMinUsers,
+		MinPeersForQuantiles: BenchmarkQuantileMinUsers,
+	}
+
+	// 自分の値と比較対象の値を分離
+	var peers []float64
+	for _, y := range yields {
+		if y.AreaM2 <= 0 {
+			continue
+		}
+		kgPerM2 := y.TotalKg / y.AreaM2
+		if y.UserID == userID {
+			data.UserKgPerM2 = &kgPerM2
+			continue
+		}
+		peers = append(peers, kgPerM2)
+	}
+	data.PeerCount = len(peers)
+
+	if len(peers) >= BenchmarkMinUsers {
+		sort.Float64s(peers)
+		data.Available = true
+
+		var sum float64
+		for _, v := range peers {
+			sum += v
+		}
+		avg := roundTo(sum/float64(len(peers)), benchmarkValueStep)
+		data.PeerAverage = &avg
+	}
+
+	if len(peers) >= BenchmarkQuantileMinUsers {
+		data.QuantilesAvailable = true
+		median := roundTo(percentileOf(peers, 50), benchmarkValueStep)
+		p25 := roundTo(percentileOf(peers, 25), benchmarkValueStep)
+		p75 := roundTo(percentileOf(peers, 75), benchmarkValueStep)
+		data.PeerMedian = &median
+		data.PeerP25 = &p25
+		data.PeerP75 = &p75
+
+		if data.UserKgPerM2 != nil {
+			below := sort.SearchFloat64s(peers, *data.UserKgPerM2)
+			rank := roundTo(float64(below)/float64(len(peers))*100, benchmarkPercentileStep)
+			data.Percentile = &rank
+		}
+	}
+
+	return &ChartData{
+		ChartType:   ChartTypeCropBenchmark,
+		Title:       "作物別ベンチマーク",
+		Data:        data,
+		GeneratedAt: s.now(),
+	}, nil
+}
+
+// percentileOf はソート済みスライスのパーセンタイル値を線形補間で計算します。
+func percentileOf(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// roundTo は値を step 単位に丸めます（0.1 単位の場合は 3.0000000000000004 にならないよう 10 倍して丸める）。
+func roundTo(v, step float64) float64 {
+	if step < 1 {
+		scale := math.Round(1 / step)
+		return math.Round(v*scale) / scale
+	}
+	return math.Round(v/step) * step
+}
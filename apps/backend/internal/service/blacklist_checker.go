@@ -1,11 +1,18 @@
 package service
 
 import (
+	"context"
+
 	"github.com/labstack/echo/v4"
 )
 
 // IsTokenBlacklisted implements auth.TokenBlacklistChecker interface
 func (s *Service) IsTokenBlacklisted(c echo.Context, tokenHash string) (bool, error) {
-	ctx := c.Request().Context()
+	return s.IsTokenRevoked(c.Request().Context(), tokenHash)
+}
+
+// IsTokenRevoked reports whether the token (by hash) has been revoked by logout.
+// It is used by transports without an echo.Context, such as the gRPC API.
+func (s *Service) IsTokenRevoked(ctx context.Context, tokenHash string) (bool, error) {
 	return s.repos.TokenBlacklist().IsBlacklisted(ctx, tokenHash)
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Bulk Create - 記録の一括作成
+// =============================================================================
+// シードなどで多数の記録を作成する場合に、1件ずつの CreateCrop・CreateHarvest の代わりに使用します。
+// リポジトリの CreateBatch で複数行の INSERT にし、分析のキャッシュの無効化はまとめて1回にします。
+// 1件ずつの作成と異なり、組織の上限の確認・収穫のイベントの配信は行いません。
+
+// CreateCrops は複数の作物を一括で作成します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - crops: 作成する作物（作成した作物のIDを各要素に設定）
+//
+// 戻り値:
+//   - error: 作成に失敗した場合のエラー（1件でも失敗した場合はすべて作成しない）
+func (s *Service) CreateCrops(ctx context.Context, crops []model.Crop) error {
+	if err := s.repos.Crop().CreateBatch(ctx, crops); err != nil {
+		return err
+	}
+	invalidated := make(map[analyticsWriteScope]bool)
+	for _, crop := range crops {
+		s.invalidateAnalyticsOnce(ctx, invalidated, &crop)
+	}
+	return nil
+}
+
+// CreateHarvests は複数の収穫記録を一括で作成します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - harvests: 作成する収穫記録（作成した記録のIDを各要素に設定）
+//
+// 戻り値:
+//   - error: 作成に失敗した場合のエラー（1件でも失敗した場合はすべて作成しない）
+func (s *Service) CreateHarvests(ctx context.Context, harvests []model.Harvest) error {
+	if err := s.repos.Harvest().CreateBatch(ctx, harvests); err != nil {
+		return err
+	}
+	// 作物は1回のクエリでまとめて取得する（収穫記録は作成済みのため、取得の失敗はエラーにしない）
+	crops, err := s.cropsByID(ctx, harvestCropIDs(harvests))
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load crops to invalidate analytics cache", "error", err)
+		return nil
+	}
+	invalidated := make(map[analyticsWriteScope]bool)
+	for _, crop := range crops {
+		s.invalidateAnalyticsOnce(ctx, invalidated, crop)
+	}
+	return nil
+}
+
+// analyticsWriteScope は一括作成で無効にした分析のキャッシュのユーザー・組織です。
+type analyticsWriteScope struct {
+	userID         uint
+	organizationID uint
+}
+
+// invalidateAnalyticsOnce は作物のユーザー・組織の分析のキャッシュを、まだ無効にしていない場合のみ無効にします。
+func (s *Service) invalidateAnalyticsOnce(ctx context.Context, invalidated map[analyticsWriteScope]bool, crop *model.Crop) {
+	scope := analyticsWriteScope{userID: crop.UserID}
+	if crop.OrganizationID != nil {
+		scope.organizationID = *crop.OrganizationID
+	}
+	if invalidated[scope] {
+		return
+	}
+	invalidated[scope] = true
+	s.invalidateAnalyticsAfterWrite(ctx, crop.UserID, crop.OrganizationID)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Clock Tests - 現在時刻の差し替えのテスト
+// =============================================================================
+// テスト対象:
+//   - SetClock: 日付の境界（ユーザーのタイムゾーンの0時）の今日のタスク・期限切れのタスク、アカウントのロックの期限
+//   - MockRepositories.SetClock: モックリポジトリの作成日時
+
+// TestService_Clock_DayBoundary は日付の境界のテストです。
+// 期待動作:
+//   - ユーザーのタイムゾーンの 23:59 では今日が期限のタスクは「今日のタスク」
+//   - 0時を過ぎると同じタスクは「期限切れ」になる
+//   - モックリポジトリの作成日時は設定した時刻
+func TestService_Clock_DayBoundary(t *testing.T) {
+	// Arrange
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo is not available: %v", err)
+	}
+	fake := clock.NewFake(time.Date(2026, 5, 31, 23, 59, 0, 0, tokyo))
+	mockRepos := repository.NewMockRepositories()
+	mockRepos.SetClock(fake)
+	svc := NewService(mockRepos)
+	svc.SetClock(fake)
+	ctx := context.Background()
+	user := &model.User{Email: "clock@example.com", Timezone: "Asia/Tokyo"}
+	_ = mockRepos.User().Create(ctx, user)
+	task := &model.Task{UserID: user.ID, Title: "水やり", DueDate: time.Date(2026, 5, 31, 9, 0, 0, 0, tokyo), Status: "pending"}
+	_ = mockRepos.Task().Create(ctx, task)
+
+	// Act
+	today, _ := svc.GetTodayTasks(ctx, user.ID)
+	overdue, _ := svc.GetOverdueTasks(ctx, user.ID)
+	fake.Advance(2 * time.Minute)
+	nextDayToday, _ := svc.GetTodayTasks(ctx, user.ID)
+	nextDayOverdue, _ := svc.GetOverdueTasks(ctx, user.ID)
+
+	// Assert
+	if len(today) != 1 || len(overdue) != 0 {
+		t.Errorf("Expected the task to be due today at 23:59, got %d today, %d overdue", len(today), len(overdue))
+	}
+	if len(nextDayToday) != 0 || len(nextDayOverdue) != 1 {
+		t.Errorf("Expected the task to be overdue after midnight, got %d today, %d overdue", len(nextDayToday), len(nextDayOverdue))
+	}
+	if !task.CreatedAt.Equal(time.Date(2026, 5, 31, 23, 59, 0, 0, tokyo)) {
+		t.Errorf("Expected CreatedAt from the fake clock, got %v", task.CreatedAt)
+	}
+}
+
+// TestService_Clock_AccountLock はアカウントのロックの期限のテストです。
+// 期待動作:
+//   - ログインの失敗が上限に達するとロックする
+//   - AccountLockDuration を過ぎるとロックが解除される
+func TestService_Clock_AccountLock(t *testing.T) {
+	// Arrange
+	fake := clock.NewFake(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetClock(fake)
+	ctx := context.Background()
+	user := &model.User{Email: "lock@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	for i := 0; i < MaxFailedLoginAttempts; i++ {
+		_ = svc.IncrementFailedLogin(ctx, user)
+	}
+	locked := svc.IsAccountLocked(user)
+	fake.Advance(AccountLockDuration - time.Second)
+	stillLocked := svc.IsAccountLocked(user)
+	fake.Advance(time.Second)
+	unlocked := !svc.IsAccountLocked(user)
+
+	// Assert
+	if !locked || !stillLocked || !unlocked {
+		t.Errorf("Expected lock for %v, got locked=%t stillLocked=%t unlocked=%t", AccountLockDuration, locked, stillLocked, unlocked)
+	}
+}
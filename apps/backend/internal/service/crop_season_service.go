@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Crop Season Comparison - 作物のシーズン比較
+// =============================================================================
+
+// ChartTypeCropSeasons は同じ作物の栽培シーズン（植え付け年）ごとの比較グラフ
+const ChartTypeCropSeasons ChartType = "crop_seasons"
+
+// ErrSeasonCropRequired はシーズン比較で作物名が指定されていない場合のエラー
+var ErrSeasonCropRequired = errors.New("crop name is required for season comparison")
+
+// CropSeasonData は1シーズン（植え付け年）分の集計を表します。
+// 同じ年に複数回植え付けた場合はまとめて集計します。
+type CropSeasonData struct {
+	Season             int      `json:"season"`                          // 植え付け年
+	Plantings          int      `json:"plantings"`                       // 植え付け回数
+	TotalKg            float64  `json:"total_kg"`                        // 総収穫量（kg換算）
+	HarvestCount       int      `json:"harvest_count"`                   // 収穫回数
+	DaysToFirstHarvest *float64 `json:"days_to_first_harvest,omitempty"` // 植え付けから初収穫までの平均日数
+	AvgQualityScore    *float64 `json:"avg_quality_score,omitempty"`     // 平均品質スコア（1: poor〜4: excellent、収穫回数で加重）
+}
+
+// CropSeasonComparison は作物のシーズン比較データを表します。
+type CropSeasonComparison struct {
+	CropName string           `json:"crop_name"`
+	Seasons  []CropSeasonData `json:"seasons"` // 古いシーズン順
+}
+
+// getCropSeasonsChart は同じ作物のシーズン比較グラフデータを生成します。
+// mv_harvest_analytics の days_to_first_harvest・avg_quality_score を利用します。
+func (s *Service) getCropSeasonsChart(ctx context.Context, userID uint, filter ChartFilter) (*ChartData, error) {
+	cropName := strings.TrimSpace(filter.CropName)
+	if cropName == "" {
+		return nil, ErrSeasonCropRequired
+	}
+
+	rows, err := s.repos.AnalyticsView().GetCropHarvestAnalytics(ctx, userID, cropName)
+	if err != nil {
+		return nil, err
+	}
+
+	type seasonAgg struct {
+		data         CropSeasonData
+		daysSum      float64
+		daysCount    int
+		qualitySum   float64
+		qualityCount int
+	}
+	seasons := make(map[int]*seasonAgg)
+	for _, row := range rows {
+		year := row.PlantedDate.Year()
+		agg, ok := seasons[year]
+		if !ok {
+			agg = &seasonAgg{data: CropSeasonData{Season: year}}
+			seasons[year] = agg
+		}
+
+		agg.data.Plantings++
+		agg.data.TotalKg += convertToKg(row.TotalQuantity, row.QuantityUnit)
+		agg.data.HarvestCount += row.HarvestCount
+		if row.DaysToFirstHarvest != nil {
+			agg.daysSum += float64(*row.DaysToFirstHarvest)
+			agg.daysCount++
+		}
+		if row.AvgQualityScore != nil && row.HarvestCount > 0 {
+			agg.qualitySum += *row.AvgQualityScore * float64(row.HarvestCount)
+			agg.qualityCount += row.HarvestCount
+		}
+	}
+
+	result := CropSeasonComparison{CropName: cropName, Seasons: make([]CropSeasonData, 0, len(seasons))}
+	for _, agg := range seasons {
+		data := agg.data
+		data.TotalKg = math.Round(data.TotalKg*100) / 100
+		if agg.daysCount > 0 {
+			days := math.Round(agg.daysSum/float64(agg.daysCount)*10) / 10
+			data.DaysToFirstHarvest = &days
+		}
+		if agg.qualityCount > 0 {
+			quality := math.Round(agg.qualitySum/float64(agg.qualityCount)*100) / 100
+			data.AvgQualityScore = &quality
+		}
+		result.Seasons = append(result.Seasons, data)
+	}
+	sort.Slice(result.Seasons, func(i, j int) bool { return result.Seasons[i].Season < result.Seasons[j].Season })
+
+	chart := &ChartData{
+		ChartType:   ChartTypeCropSeasons,
+		Title:       cropName + "のシーズン比較",
+		Data:        result,
+		GeneratedAt: s.now(),
+	}
+	// 鮮度情報の取得失敗はグラフデータの返却を妨げない
+	if freshness, err := s.viewFreshness(ctx, "mv_harvest_analytics"); err == nil {
+		chart.Freshness = freshness
+	}
+	return chart, nil
+}
@@ -366,6 +366,49 @@ func TestDeleteCrop_WithRelatedRecords(t *testing.T) {
 	}
 }
 
+// TestDeleteCrop_RollbackOnError は作物の削除に失敗した場合にロールバックされることをテストします。
+// 期待動作:
+//   - 作物の削除が失敗した場合、先に削除した成長記録・収穫記録も元に戻る
+func TestDeleteCrop_RollbackOnError(t *testing.T) {
+	// Arrange: トランザクションモードのモックリポジトリで作物の削除を失敗させる
+	mockRepos := repository.NewMockRepositories()
+	mockRepos.EnableTransactionalMode()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	crop := &model.Crop{
+		UserID:              1,
+		Name:                "トマト",
+		PlantedDate:         time.Now(),
+		ExpectedHarvestDate: time.Now().AddDate(0, 3, 0),
+		Status:              "growing",
+	}
+	_ = svc.CreateCrop(ctx, crop)
+	_ = svc.CreateGrowthRecord(ctx, &model.GrowthRecord{CropID: crop.ID, RecordDate: time.Now(), GrowthStage: "vegetative"})
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 5.0, QuantityUnit: "kg"}
+	_ = svc.CreateHarvest(ctx, harvest)
+	deleteErr := errors.New("database error")
+	mockRepos.GetMockCropRepository().DeleteFunc = func(ctx context.Context, id uint) error {
+		return deleteErr
+	}
+
+	// Act
+	err := svc.DeleteCrop(ctx, crop.ID)
+
+	// Assert
+	if !errors.Is(err, deleteErr) {
+		t.Fatalf("Expected delete error, got %v", err)
+	}
+	records, _ := svc.GetCropGrowthRecords(ctx, crop.ID)
+	harvests, _ := svc.GetCropHarvests(ctx, crop.ID)
+	if len(records) != 1 || len(harvests) != 1 {
+		t.Errorf("Expected growth record and harvest to be rolled back, got %d records, %d harvests", len(records), len(harvests))
+	}
+	if harvest.DeletedAt.Valid {
+		t.Error("Expected harvest deleted_at to be rolled back")
+	}
+}
+
 // =============================================================================
 // GrowthRecord テスト
 // =============================================================================
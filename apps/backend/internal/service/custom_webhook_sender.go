@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Custom Webhook Notification - 汎用Webhook通知
+// =============================================================================
+// ユーザーが登録した任意のHTTPS URLへ、通知イベント（NotificationEvent）のJSONをそのままPOSTします。
+// ホームオートメーション（例: 霜注意報で散水を止める）との連携を想定しています。
+//
+// 受信側が送信元を検証できるよう、ユーザーごとのシークレットでHMAC-SHA256署名を付けます。
+//   - X-Webhook-Timestamp: 送信時刻（Unix秒）
+//   - X-Webhook-Signature: "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+//   - X-Webhook-Event: イベントタイプ
+//
+// 任意のURLへサーバーからリクエストするため、ループバック・プライベート・リンクローカル等の
+// アドレスへは接続しません（URLの検証に加え、名前解決後の接続先アドレスも検証します）。
+
+const (
+	// NotificationChannelCustomWebhook は汎用Webhook通知チャネル
+	NotificationChannelCustomWebhook = "custom_webhook"
+
+	// CustomWebhookTimestampHeader は署名に使用した送信時刻のヘッダー
+	CustomWebhookTimestampHeader = "X-Webhook-Timestamp"
+	// CustomWebhookSignatureHeader は署名のヘッダー
+	CustomWebhookSignatureHeader = "X-Webhook-Signature"
+	// CustomWebhookEventHeader はイベントタイプのヘッダー
+	CustomWebhookEventHeader = "X-Webhook-Event"
+
+	// customWebhookSecretBytes は署名用シークレットのバイト数
+	customWebhookSecretBytes = 32
+	// customWebhookSignaturePrefix は署名ヘッダーの値の接頭辞
+	customWebhookSignaturePrefix = "sha256="
+)
+
+var (
+	// ErrInvalidCustomWebhookURL は汎用Webhook URLとして受け付けられない場合のエラー
+	ErrInvalidCustomWebhookURL = errors.New("invalid custom webhook URL")
+	// errCustomWebhookAddressBlocked は接続先が内部ネットワークのアドレスだった場合のエラー
+	errCustomWebhookAddressBlocked = errors.New("custom webhook address is not allowed")
+)
+
+// ValidateCustomWebhookURL は汎用Webhook URLを検証します。
+// HTTPSのみ受け付け、認証情報付きのURL、localhost や内部向けのホスト名、
+// 公開されていないIPアドレスを直接指定したURLは拒否します。
+//
+// 引数:
+//   - webhookURL: 検証するURL
+//
+// 戻り値:
+//   - error: 受け付けられないURLの場合は ErrInvalidCustomWebhookURL
+func ValidateCustomWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Hostname() == "" {
+		return ErrInvalidCustomWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicWebhookIP(ip) {
+			return ErrInvalidCustomWebhookURL
+		}
+		return nil
+	}
+	if !strings.Contains(host, ".") || host == "localhost" {
+		return ErrInvalidCustomWebhookURL
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".localdomain"} {
+		if strings.HasSuffix(host, suffix) {
+			return ErrInvalidCustomWebhookURL
+		}
+	}
+	return nil
+}
+
+// isPublicWebhookIP はIPアドレスがWebhookの接続先として許可される公開アドレスかを判定します。
+func isPublicWebhookIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	// キャリアグレードNAT（100.64.0.0/10）も内部ネットワークとして扱う
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}
+
+// newCustomWebhookClient は汎用Webhook投稿用のHTTPクライアントを作成します。
+// 名前解決後の接続先が公開アドレスでない場合は接続せず、リダイレクトも追従しません。
+// 環境変数のプロキシを経由すると接続先の検証ができないため、プロキシは使用しません。
+func newCustomWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookRequestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicWebhookIP(ip) {
+				return errCustomWebhookAddressBlocked
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: webhookRequestTimeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookRequestTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// GenerateCustomWebhookSecret は汎用Webhookの署名用シークレットを生成します。
+//
+// 戻り値:
+//   - string: 64文字の16進数文字列
+//   - error: 乱数の生成に失敗した場合のエラー
+func GenerateCustomWebhookSecret() (string, error) {
+	b := make([]byte, customWebhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SignCustomWebhookPayload は汎用Webhookのリクエストボディに署名します。
+// 受信側は同じ計算で署名を求め、X-Webhook-Signature と定数時間で比較してください。
+//
+// 引数:
+//   - secret: ユーザーの署名用シークレット
+//   - timestamp: 送信時刻（Unix秒、X-Webhook-Timestamp の値）
+//   - payload: リクエストボディ
+//
+// 戻り値:
+//   - string: "sha256=" + HMAC-SHA256の16進数
+func SignCustomWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return customWebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCustomWebhookSignature は汎用Webhookの署名を検証します（受信側の実装例・テスト用）。
+func VerifyCustomWebhookSignature(secret string, timestamp int64, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(SignCustomWebhookPayload(secret, timestamp, payload)), []byte(signature))
+}
+
+// customWebhookTarget はユーザー設定で汎用Webhookが有効な場合に送信先URLを返します。
+// 署名用シークレットが未発行の場合は署名なしで送らないよう送信しません。
+func customWebhookTarget(user *model.User) (string, bool) {
+	settings := effectiveNotificationSettings(user)
+	if !settings.CustomWebhookEnabled || settings.CustomWebhookURL == "" || user.WebhookSecret == "" {
+		return "", false
+	}
+	return settings.CustomWebhookURL, true
+}
+
+// SendCustomWebhook は汎用Webhookへ通知イベントのJSONを署名付きでPOSTします。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - webhookURL: ユーザーが設定したWebhook URL
+//   - secret: ユーザーの署名用シークレット
+//   - event: 通知イベント（そのままJSONで送信）
+//
+// 戻り値:
+//   - error: URLが不正、または投稿に失敗した場合のエラー
+func (n *notificationSender) SendCustomWebhook(ctx context.Context, webhookURL, secret string, event NotificationEvent) error {
+	if err := ValidateCustomWebhookURL(webhookURL); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return n.sendWithRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		// リトライごとに送信時刻を更新して署名する
+		timestamp := n.now().Unix()
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(CustomWebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(CustomWebhookSignatureHeader, SignCustomWebhookPayload(secret, timestamp, payload))
+		req.Header.Set(CustomWebhookEventHeader, string(event.Type))
+
+		resp, err := n.customWebhookClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("custom webhook failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// Data Retention - 保持期間を過ぎたデータの定期削除
+// =============================================================================
+// 対象（config.RetentionTargets）ごとの保持期間を過ぎたデータを削除します。
+//   - 論理削除した作物・タスク等: 論理削除から保持期間を過ぎた行を物理削除
+//   - 通知ログ: 期限切れ、または作成から保持期間を過ぎたものを削除
+//   - エクスポートファイル: 作成から保持期間を過ぎたS3のファイルを削除（エクスポート履歴は再ダウンロード不可として残す）
+//   - 作物・収穫記録が削除された写真: 削除から保持期間（猶予）を過ぎた写真の画像を保存先から削除し、写真を論理削除
+//     （猶予の間に作物をゴミ箱から復元した場合は写真も残る）
+//
+// dry run では削除せずに対象の件数のみ報告します。保持期間を変更する前の確認に使用します。
+
+// RetentionExportFileBatchSize は1回の実行で削除するエクスポートファイルの最大数
+const RetentionExportFileBatchSize = 500
+
+// RetentionOrphanedPhotoBatchSize は1回の実行で削除する作物・収穫記録が削除された写真の最大数
+const RetentionOrphanedPhotoBatchSize = 500
+
+// RetentionPolicy はデータの保持期間の設定です。
+type RetentionPolicy struct {
+	Days   map[string]int // 対象ごとの保持期間（日数、0 の場合は削除しない。含まれない対象はデフォルト）
+	DryRun bool           // 定期実行（RunScheduledDataRetention）で削除せずに件数のみ報告するか
+}
+
+// RetentionTargetReport は対象ごとの削除結果です。
+type RetentionTargetReport struct {
+	Target        string    `json:"target"`
+	RetentionDays int       `json:"retention_days"`
+	Cutoff        time.Time `json:"cutoff"`          // この日時より前のデータが対象
+	Matched       int64     `json:"matched"`         // 保持期間を過ぎた件数
+	Purged        int64     `json:"purged"`          // 削除した件数（dry run では 0）
+	Error         string    `json:"error,omitempty"` // 削除に失敗した理由
+}
+
+// RetentionReport はデータの保持期間の処理結果です。
+type RetentionReport struct {
+	ProcessedAt  time.Time               `json:"processed_at"`
+	DryRun       bool                    `json:"dry_run"`
+	Targets      []RetentionTargetReport `json:"targets"`
+	TotalMatched int64                   `json:"total_matched"`
+	TotalPurged  int64                   `json:"total_purged"`
+	Errors       []string                `json:"errors,omitempty"`
+}
+
+// SetRetentionPolicy はデータの保持期間を設定します。
+// 保持期間はデータ保持設定（config.RetentionConfig）から main で設定します。
+func (s *Service) SetRetentionPolicy(policy RetentionPolicy) {
+	s.retention = policy
+}
+
+// RunScheduledDataRetention は定期実行のデータ削除です（RetentionPolicy.DryRun の場合は件数のみ報告）。
+func (s *Service) RunScheduledDataRetention(ctx context.Context) (*RetentionReport, error) {
+	return s.PurgeExpiredData(ctx, s.retention.DryRun)
+}
+
+// PurgeExpiredData は保持期間を過ぎたデータを削除します。
+// 対象ごとに処理し、1つの対象の失敗（外部キー制約など）は他の対象の削除を止めません。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - dryRun: true の場合は削除せずに対象の件数のみ報告
+//
+// 戻り値:
+//   - *RetentionReport: 対象ごとの件数と削除結果
+//   - error: 常に nil（対象ごとのエラーは RetentionReport.Errors に記録）
+func (s *Service) PurgeExpiredData(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	now := s.now()
+	report := &RetentionReport{
+		ProcessedAt: now,
+		DryRun:      dryRun,
+		Targets:     make([]RetentionTargetReport, 0, len(config.RetentionTargets)),
+	}
+
+	for _, target := range config.RetentionTargets {
+		days := s.retentionDays(target.Name, target.Days)
+		if days <= 0 {
+			continue
+		}
+		result := RetentionTargetReport{
+			Target:        target.Name,
+			RetentionDays: days,
+			Cutoff:        now.AddDate(0, 0, -days),
+		}
+
+		var err error
+		switch target.Name {
+		case config.RetentionTargetNotificationLogs:
+			err = s.purgeNotificationLogs(ctx, now, &result, dryRun)
+		case config.RetentionTargetExportFiles:
+			err = s.purgeExportFiles(ctx, &result, dryRun)
+		case config.RetentionTargetOrphanedPhotos:
+			err = s.purgeOrphanedPhotos(ctx, &result, dryRun)
+		default:
+			err = s.purgeSoftDeleted(ctx, target.Name, &result, dryRun)
+		}
+		if err != nil {
+			result.Error = truncateString(err.Error(), 500)
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", target.Name, err))
+		}
+
+		report.TotalMatched += result.Matched
+		report.TotalPurged += result.Purged
+		report.Targets = append(report.Targets, result)
+	}
+
+	return report, nil
+}
+
+// retentionDays は対象の保持期間を返します（設定していない場合はデフォルト）。
+func (s *Service) retentionDays(target string, defaultDays int) int {
+	if days, ok := s.retention.Days[target]; ok {
+		return days
+	}
+	return defaultDays
+}
+
+// purgeSoftDeleted は論理削除から保持期間を過ぎた行を物理削除します（対象名はテーブル名）。
+func (s *Service) purgeSoftDeleted(ctx context.Context, table string, result *RetentionTargetReport, dryRun bool) error {
+	repo := s.repos.Retention()
+	matched, err := repo.CountSoftDeleted(ctx, table, result.Cutoff)
+	if err != nil {
+		return err
+	}
+	result.Matched = matched
+	if dryRun || matched == 0 {
+		return nil
+	}
+	result.Purged, err = repo.PurgeSoftDeleted(ctx, table, result.Cutoff)
+	return err
+}
+
+// purgeNotificationLogs は期限切れ、または保持期間を過ぎた通知ログを削除します。
+func (s *Service) purgeNotificationLogs(ctx context.Context, now time.Time, result *RetentionTargetReport, dryRun bool) error {
+	repo := s.repos.NotificationLog()
+	matched, err := repo.CountExpiredOrCreatedBefore(ctx, now, result.Cutoff)
+	if err != nil {
+		return err
+	}
+	result.Matched = matched
+	if dryRun || matched == 0 {
+		return nil
+	}
+	result.Purged, err = repo.DeleteExpiredOrCreatedBefore(ctx, now, result.Cutoff)
+	return err
+}
+
+// purgeExportFiles は保持期間を過ぎたエクスポートファイルをS3から削除し、エクスポート履歴を再ダウンロード不可にします。
+// 1回の実行で RetentionExportFileBatchSize 件まで処理し、残りは次回の実行で削除します（Matched は残りを含む件数）。
+func (s *Service) purgeExportFiles(ctx context.Context, result *RetentionTargetReport, dryRun bool) error {
+	repo := s.repos.ExportRecord()
+	matched, err := repo.CountWithFilesCreatedBefore(ctx, result.Cutoff)
+	if err != nil {
+		return err
+	}
+	result.Matched = matched
+	if dryRun || matched == 0 {
+		return nil
+	}
+	if s.exportStorage == nil || !s.exportStorage.IsConfigured() {
+		return ErrExportStorageNotConfigured
+	}
+	records, err := repo.GetWithFilesCreatedBefore(ctx, result.Cutoff, RetentionExportFileBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	var lastErr error
+	for i := range records {
+		record := &records[i]
+		if err := s.exportStorage.DeleteExport(ctx, record.S3Key); err != nil {
+			failed++
+			lastErr = err
+			continue
+		}
+		record.S3Key = ""
+		if err := repo.Update(ctx, record); err != nil {
+			// ファイルは削除済みのため、次回の実行で再度削除を試みても問題ない
+			failed++
+			lastErr = err
+			continue
+		}
+		result.Purged++
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d export file(s): %w", failed, lastErr)
+	}
+	return nil
+}
+
+// purgeOrphanedPhotos は作物・収穫記録が削除されてから保持期間を過ぎた写真の画像を保存先から削除し、写真を論理削除します。
+// 1回の実行で RetentionOrphanedPhotoBatchSize 件まで処理し、残りは次回の実行で削除します（Matched は残りを含む件数）。
+// 論理削除した写真は photos の保持期間の後に物理削除します。
+func (s *Service) purgeOrphanedPhotos(ctx context.Context, result *RetentionTargetReport, dryRun bool) error {
+	repo := s.repos.Photo()
+	matched, err := repo.CountOrphanedBefore(ctx, result.Cutoff)
+	if err != nil {
+		return err
+	}
+	result.Matched = matched
+	if dryRun || matched == 0 {
+		return nil
+	}
+	if s.photoStorage == nil {
+		return ErrPhotoStorageNotConfigured
+	}
+	photos, err := repo.GetOrphanedBefore(ctx, result.Cutoff, RetentionOrphanedPhotoBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	var lastErr error
+	for i := range photos {
+		photo := &photos[i]
+		if err := s.deletePhotoObjects(ctx, photo); err != nil {
+			failed++
+			lastErr = err
+			continue
+		}
+		if err := repo.Delete(ctx, photo.ID); err != nil {
+			// 画像は削除済みのため、次回の実行で再度削除を試みても問題ない
+			failed++
+			lastErr = err
+			continue
+		}
+		result.Purged++
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d orphaned photo(s): %w", failed, lastErr)
+	}
+	return nil
+}
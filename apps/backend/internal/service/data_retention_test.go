@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Data Retention Tests - 保持期間を過ぎたデータの削除のテスト
+// =============================================================================
+// テスト対象:
+//   - PurgeExpiredData: 対象ごとの保持期間による削除、dry run、対象ごとの失敗
+//   - RunScheduledDataRetention: 設定した dry run の反映
+//   - PurgeExpiredData（orphaned_photos）: 作物・収穫記録が削除された写真と保存先の画像の猶予の後の削除
+
+// findRetentionTarget は処理結果から対象の結果を取得します。
+func findRetentionTarget(t *testing.T, report *RetentionReport, target string) RetentionTargetReport {
+	t.Helper()
+	for _, result := range report.Targets {
+		if result.Target == target {
+			return result
+		}
+	}
+	t.Fatalf("target %s not found in report", target)
+	return RetentionTargetReport{}
+}
+
+// TestPurgeExpiredData は保持期間を過ぎたデータの削除のテストです。
+// 期待動作:
+//   - dry run では削除せずに保持期間を過ぎた件数のみ報告する
+//   - 論理削除から保持期間を過ぎた行・期限切れの通知ログ・古いエクスポートファイルを削除する
+//   - 保持期間を 0 にした対象は処理しない
+//   - 1つの対象の失敗は他の対象の削除を止めない
+func TestPurgeExpiredData(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	storage := &mockExportStorage{uploads: map[string][]byte{"exports/old.csv": []byte("a"), "exports/new.csv": []byte("b")}}
+	svc.SetExportStorage(storage)
+	svc.SetRetentionPolicy(RetentionPolicy{Days: map[string]int{config.RetentionTargetTasks: 0}})
+	ctx := context.Background()
+	now := time.Now()
+
+	retention := mockRepos.GetMockRetentionRepository()
+	retention.DeletedAt[config.RetentionTargetCrops] = []time.Time{now.AddDate(0, 0, -40), now.AddDate(0, 0, -31), now.AddDate(0, 0, -5)}
+	retention.DeletedAt[config.RetentionTargetTasks] = []time.Time{now.AddDate(0, 0, -90)}
+	retention.DeletedAt[config.RetentionTargetPlots] = []time.Time{now.AddDate(0, 0, -60)}
+	retention.Err[config.RetentionTargetPlots] = errors.New("violates foreign key constraint")
+
+	_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{UserID: 1, NotificationType: "task_due", ExpiresAt: now.Add(-time.Hour)})
+	_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{UserID: 1, NotificationType: "task_due", ExpiresAt: now.Add(time.Hour)})
+
+	exports := mockRepos.GetMockExportRecordRepository()
+	oldExport := &model.ExportRecord{UserID: 1, DataType: "crops", FileName: "old.csv", S3Key: "exports/old.csv"}
+	newExport := &model.ExportRecord{UserID: 1, DataType: "crops", FileName: "new.csv", S3Key: "exports/new.csv"}
+	_ = exports.Create(ctx, oldExport)
+	_ = exports.Create(ctx, newExport)
+	oldExport.CreatedAt = now.AddDate(0, 0, -45)
+
+	// Act
+	dryRun, dryErr := svc.PurgeExpiredData(ctx, true)
+	filesAfterDryRun := len(storage.uploads)
+	report, err := svc.PurgeExpiredData(ctx, false)
+
+	// Assert
+	if dryErr != nil || err != nil {
+		t.Fatalf("PurgeExpiredData failed: %v / %v", dryErr, err)
+	}
+	if got := findRetentionTarget(t, dryRun, config.RetentionTargetCrops); got.Matched != 2 || got.Purged != 0 {
+		t.Errorf("Expected dry run to match 2 crops without purging, got %+v", got)
+	}
+	if dryRun.TotalPurged != 0 || filesAfterDryRun != 2 {
+		t.Errorf("Expected dry run not to delete anything, got %d purged / %d files", dryRun.TotalPurged, filesAfterDryRun)
+	}
+
+	if got := findRetentionTarget(t, report, config.RetentionTargetCrops); got.Purged != 2 || len(retention.DeletedAt[config.RetentionTargetCrops]) != 1 {
+		t.Errorf("Expected 2 crops to be purged, got %+v", got)
+	}
+	remainingLogs, _ := mockRepos.NotificationLog().GetByUserID(ctx, 1, 0)
+	if got := findRetentionTarget(t, report, config.RetentionTargetNotificationLogs); got.Purged != 1 || len(remainingLogs) != 1 {
+		t.Errorf("Expected expired notification log to be purged, got %+v", got)
+	}
+	if got := findRetentionTarget(t, report, config.RetentionTargetExportFiles); got.Matched != 1 || got.Purged != 1 {
+		t.Errorf("Expected 1 export file to be purged, got %+v", got)
+	}
+	if _, ok := storage.uploads["exports/old.csv"]; ok || exports.Records[oldExport.ID].IsDownloadable() || !exports.Records[newExport.ID].IsDownloadable() {
+		t.Errorf("Expected only the old export file to be deleted, got files=%v", storage.uploads)
+	}
+	for _, result := range report.Targets {
+		if result.Target == config.RetentionTargetTasks {
+			t.Errorf("Expected disabled target not to be processed, got %+v", result)
+		}
+	}
+	if got := findRetentionTarget(t, report, config.RetentionTargetPlots); got.Error == "" || len(report.Errors) != 1 {
+		t.Errorf("Expected plots failure to be reported, got %+v (errors=%v)", got, report.Errors)
+	}
+}
+
+// TestRunScheduledDataRetention_DryRun は定期実行の dry run のテストです。
+// 期待動作:
+//   - RetentionPolicy.DryRun の場合は定期実行でも削除しない
+func TestRunScheduledDataRetention_DryRun(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetRetentionPolicy(RetentionPolicy{DryRun: true})
+	retention := mockRepos.GetMockRetentionRepository()
+	retention.DeletedAt[config.RetentionTargetHarvests] = []time.Time{time.Now().AddDate(0, 0, -100)}
+
+	// Act
+	report, err := svc.RunScheduledDataRetention(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunScheduledDataRetention failed: %v", err)
+	}
+	if !report.DryRun || report.TotalMatched != 1 || report.TotalPurged != 0 || len(retention.DeletedAt[config.RetentionTargetHarvests]) != 1 {
+		t.Errorf("Expected dry run report without purging, got %+v", report)
+	}
+}
+
+// TestPurgeExpiredData_OrphanedPhotos は作物・収穫記録が削除された写真の削除のテストです。
+// 期待動作:
+//   - 作物・収穫記録の論理削除から保持期間を過ぎた写真は、保存先の画像を削除して写真を論理削除する
+//   - 保持期間（猶予）の間の写真・作物が残っている写真・作物のない写真は削除しない
+//   - 物理削除済みの作物の写真は写真の作成から保持期間を過ぎていれば削除する
+//   - dry run では削除せずに件数のみ報告する
+func TestPurgeExpiredData_OrphanedPhotos(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	fake := clock.NewFake(time.Date(2026, 5, 1, 5, 0, 0, 0, time.UTC))
+	mockRepos.SetClock(fake)
+	svc.SetClock(fake)
+	ctx := context.Background()
+	storage := &mockPhotoStorage{objects: map[string][]byte{}}
+	svc.SetPhotoStorage(storage)
+
+	crops := mockRepos.GetMockCropRepository()
+	activeCrop := &model.Crop{UserID: 1, Name: "トマト"}
+	oldCrop := &model.Crop{UserID: 1, Name: "ナス"}
+	recentCrop := &model.Crop{UserID: 1, Name: "キュウリ"}
+	for _, crop := range []*model.Crop{activeCrop, oldCrop, recentCrop} {
+		_ = crops.Create(ctx, crop)
+	}
+	harvest := &model.Harvest{CropID: activeCrop.ID, Quantity: 1, QuantityUnit: "kg"}
+	_ = mockRepos.Harvest().Create(ctx, harvest)
+	purgedCropID := uint(999)
+
+	newPhoto := func(cropID, harvestID *uint) *model.Photo {
+		photo := &model.Photo{UserID: 1, CropID: cropID, HarvestID: harvestID, Status: PhotoStatusCompleted}
+		_ = mockRepos.Photo().Create(ctx, photo)
+		photo.ThumbnailKey = fmt.Sprintf("photos/1/%d/thumbnail.webp", photo.ID)
+		storage.objects[photo.ThumbnailKey] = []byte("thumbnail")
+		return photo
+	}
+	activePhoto := newPhoto(&activeCrop.ID, nil)
+	oldPhoto := newPhoto(&oldCrop.ID, nil)
+	harvestPhoto := newPhoto(nil, &harvest.ID)
+	purgedPhoto := newPhoto(&purgedCropID, nil)
+	_ = newPhoto(nil, nil)
+
+	_ = crops.Delete(ctx, oldCrop.ID)
+	_ = mockRepos.Harvest().Delete(ctx, harvest.ID)
+	fake.Advance(40 * 24 * time.Hour)
+	_ = crops.Delete(ctx, recentCrop.ID)
+	recentPhoto := newPhoto(&recentCrop.ID, nil)
+
+	// Act
+	dryRun, dryRunErr := svc.PurgeExpiredData(ctx, true)
+	report, err := svc.PurgeExpiredData(ctx, false)
+
+	// Assert
+	if dryRunErr != nil || err != nil {
+		t.Fatalf("PurgeExpiredData failed: %v / %v", dryRunErr, err)
+	}
+	if got := findRetentionTarget(t, dryRun, config.RetentionTargetOrphanedPhotos); got.Matched != 3 || got.Purged != 0 {
+		t.Errorf("Expected 3 matched photos in the dry run, got %+v", got)
+	}
+	if got := findRetentionTarget(t, report, config.RetentionTargetOrphanedPhotos); got.Matched != 3 || got.Purged != 3 || got.Error != "" {
+		t.Errorf("Expected 3 purged photos, got %+v", got)
+	}
+	for _, photo := range []*model.Photo{oldPhoto, harvestPhoto, purgedPhoto} {
+		if _, err := mockRepos.Photo().GetByID(ctx, photo.ID); err == nil {
+			t.Errorf("Expected photo %d to be deleted", photo.ID)
+		}
+		if _, ok := storage.objects[photo.ThumbnailKey]; ok {
+			t.Errorf("Expected %s to be deleted from the storage", photo.ThumbnailKey)
+		}
+	}
+	for _, photo := range []*model.Photo{activePhoto, recentPhoto} {
+		if _, err := mockRepos.Photo().GetByID(ctx, photo.ID); err != nil {
+			t.Errorf("Expected photo %d to be kept, got %v", photo.ID, err)
+		}
+	}
+	if len(storage.objects) != 3 {
+		t.Errorf("Expected 3 objects to be kept, got %d", len(storage.objects))
+	}
+}
+
+// TestPurgeExpiredData_OrphanedPhotosBeyondBatch は1回の実行の上限を超える写真の削除のテストです。
+// 期待動作:
+//   - dry run・削除とも Matched は上限を超えた件数を含むすべての対象の件数
+//   - 1回の実行で削除するのは RetentionOrphanedPhotoBatchSize 件までで、残りは次回の実行で削除する
+func TestPurgeExpiredData_OrphanedPhotosBeyondBatch(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	fake := clock.NewFake(time.Date(2026, 5, 1, 5, 0, 0, 0, time.UTC))
+	mockRepos.SetClock(fake)
+	svc.SetClock(fake)
+	ctx := context.Background()
+	svc.SetPhotoStorage(&mockPhotoStorage{objects: map[string][]byte{}})
+
+	crop := &model.Crop{UserID: 1, Name: "ナス"}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	total := RetentionOrphanedPhotoBatchSize + 1
+	for range total {
+		_ = mockRepos.Photo().Create(ctx, &model.Photo{UserID: 1, CropID: &crop.ID, Status: PhotoStatusCompleted})
+	}
+	_ = mockRepos.Crop().Delete(ctx, crop.ID)
+	fake.Advance(40 * 24 * time.Hour)
+
+	// Act
+	dryRun, dryRunErr := svc.PurgeExpiredData(ctx, true)
+	first, firstErr := svc.PurgeExpiredData(ctx, false)
+	second, secondErr := svc.PurgeExpiredData(ctx, false)
+
+	// Assert
+	if dryRunErr != nil || firstErr != nil || secondErr != nil {
+		t.Fatalf("PurgeExpiredData failed: %v / %v / %v", dryRunErr, firstErr, secondErr)
+	}
+	if got := findRetentionTarget(t, dryRun, config.RetentionTargetOrphanedPhotos); got.Matched != int64(total) || got.Purged != 0 {
+		t.Errorf("Expected %d matched photos in the dry run, got %+v", total, got)
+	}
+	if got := findRetentionTarget(t, first, config.RetentionTargetOrphanedPhotos); got.Matched != int64(total) || got.Purged != RetentionOrphanedPhotoBatchSize {
+		t.Errorf("Expected %d matched and %d purged photos, got %+v", total, RetentionOrphanedPhotoBatchSize, got)
+	}
+	if got := findRetentionTarget(t, second, config.RetentionTargetOrphanedPhotos); got.Matched != 1 || got.Purged != 1 {
+		t.Errorf("Expected the remaining photo to be purged in the next run, got %+v", got)
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// =============================================================================
+// Database Backup - データベースの論理バックアップ
+// =============================================================================
+// 管理者の指示（POST /admin/database/backups、ジョブキューのワーカーで作成）、定期実行（database_backup のジョブ）、
+// 管理CLI（admin backup create）でデータベースの論理バックアップ（database.DB.Backup）を作成し、S3 に保存します。
+// バックアップのファイルは一時ファイルに書き出してからアップロードするため、メモリに全体を読み込みません。
+// リストアは管理CLI（admin backup restore）のみで実行できます（稼働中のサーバーからは実行しない）。
+
+// JobTypeDatabaseBackup はデータベースのバックアップのジョブの種類です。
+const JobTypeDatabaseBackup = "database.backup"
+
+// バックアップを作成した契機
+const (
+	BackupTriggerManual    = "manual"    // 管理者（POST /admin/database/backups）
+	BackupTriggerScheduled = "scheduled" // 定期実行
+	BackupTriggerCLI       = "cli"       // 管理CLI
+)
+
+// バックアップの履歴の状態
+const (
+	BackupStatusPending   = "pending"
+	BackupStatusCompleted = "completed"
+	BackupStatusFailed    = "failed"
+)
+
+var (
+	// ErrBackupNotConfigured はバックアップするデータベース・保存先が未設定の場合のエラー
+	ErrBackupNotConfigured = errors.New("database backup is not configured")
+	// ErrBackupNotCompleted は作成が完了していないバックアップを取得した場合のエラー
+	ErrBackupNotCompleted = errors.New("database backup is not completed")
+)
+
+// DatabaseBackupSource はデータベースの論理バックアップを書き出します（*database.DB）。
+type DatabaseBackupSource interface {
+	Backup(ctx context.Context, w io.Writer) (*database.BackupStats, error)
+}
+
+// BackupStorage はバックアップのファイルの保存先です（storage.Service）。
+type BackupStorage interface {
+	IsConfigured() bool
+	UploadBackup(ctx context.Context, fileName string, body io.ReadSeeker, size int64) (string, error)
+	DownloadBackup(ctx context.Context, objectKey string) (io.ReadCloser, error)
+}
+
+// DatabaseBackupJobPayload はデータベースのバックアップのジョブのパラメータです。
+type DatabaseBackupJobPayload struct {
+	BackupID uint `json:"backup_id"`
+}
+
+// SetDatabaseBackup はバックアップするデータベースと保存先を設定します。
+// 未設定の場合、バックアップの作成は ErrBackupNotConfigured を返します。
+func (s *Service) SetDatabaseBackup(source DatabaseBackupSource, storage BackupStorage) {
+	s.backupSource = source
+	s.backupStorage = storage
+}
+
+// DatabaseBackupAvailable はバックアップを作成できるか（データベースと保存先を設定済みか）を返します。
+func (s *Service) DatabaseBackupAvailable() bool {
+	return s.backupSource != nil && s.backupStorage != nil && s.backupStorage.IsConfigured()
+}
+
+// RequestDatabaseBackup はバックアップの作成をジョブキューに登録します。
+// バックアップの履歴を pending で作成し、作成はワーカーが行います（ProcessDatabaseBackupJob）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - adminID: 作成を指示した管理者のユーザーID
+//
+// 戻り値:
+//   - *model.DatabaseBackup: 作成したバックアップの履歴（Status: pending）
+//   - error: 未設定の場合は ErrBackupNotConfigured、キューに登録できない場合は ErrJobQueueUnavailable
+func (s *Service) RequestDatabaseBackup(ctx context.Context, adminID uint) (*model.DatabaseBackup, error) {
+	if s.jobQueue == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+	if !s.DatabaseBackupAvailable() {
+		return nil, ErrBackupNotConfigured
+	}
+
+	backup := &model.DatabaseBackup{Trigger: BackupTriggerManual, RequestedBy: &adminID, Status: BackupStatusPending}
+	if err := s.repos.DatabaseBackup().Create(ctx, backup); err != nil {
+		return nil, err
+	}
+	if err := s.jobQueue.Enqueue(ctx, JobTypeDatabaseBackup, DatabaseBackupJobPayload{BackupID: backup.ID}); err != nil {
+		s.failDatabaseBackup(ctx, backup, err)
+		return nil, fmt.Errorf("%w: %v", ErrJobQueueUnavailable, err)
+	}
+	return backup, nil
+}
+
+// ProcessDatabaseBackupJob はデータベースのバックアップのジョブを処理します。
+// 処理済み（pending 以外）のバックアップは再配信されたジョブとみなして何もしません。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - payload: ジョブのパラメータ
+//   - lastAttempt: 最後の試行か（失敗した場合にバックアップの履歴を failed にする）
+//
+// 戻り値:
+//   - error: 作成・保存に失敗した場合のエラー（ジョブキューが再試行する）
+func (s *Service) ProcessDatabaseBackupJob(ctx context.Context, payload DatabaseBackupJobPayload, lastAttempt bool) error {
+	backup, err := s.repos.DatabaseBackup().GetByID(ctx, payload.BackupID)
+	if err != nil {
+		return fmt.Errorf("failed to get database backup %d: %w", payload.BackupID, err)
+	}
+	if backup.Status != BackupStatusPending {
+		return nil
+	}
+
+	err = s.createDatabaseBackup(ctx, backup)
+	if err != nil && lastAttempt {
+		s.failDatabaseBackup(ctx, backup, err)
+	}
+	return err
+}
+
+// RunDatabaseBackup はバックアップをすぐに作成します（定期実行・管理CLI）。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - trigger: 作成した契機（BackupTriggerScheduled, BackupTriggerCLI）
+//
+// 戻り値:
+//   - *model.DatabaseBackup: バックアップの履歴（失敗した場合は Status: failed）
+//   - error: 未設定の場合は ErrBackupNotConfigured、作成・保存に失敗した場合のエラー
+func (s *Service) RunDatabaseBackup(ctx context.Context, trigger string) (*model.DatabaseBackup, error) {
+	if !s.DatabaseBackupAvailable() {
+		return nil, ErrBackupNotConfigured
+	}
+
+	backup := &model.DatabaseBackup{Trigger: trigger, Status: BackupStatusPending}
+	if err := s.repos.DatabaseBackup().Create(ctx, backup); err != nil {
+		return nil, err
+	}
+	if err := s.createDatabaseBackup(ctx, backup); err != nil {
+		s.failDatabaseBackup(ctx, backup, err)
+		return backup, err
+	}
+	return backup, nil
+}
+
+// createDatabaseBackup はバックアップを一時ファイルに書き出して保存し、バックアップの履歴を completed にします。
+func (s *Service) createDatabaseBackup(ctx context.Context, backup *model.DatabaseBackup) error {
+	if !s.DatabaseBackupAvailable() {
+		return ErrBackupNotConfigured
+	}
+
+	file, err := os.CreateTemp("", "database-backup-*.jsonl.gz")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	stats, err := s.backupSource.Backup(ctx, file)
+	if err != nil {
+		return fmt.Errorf("failed to back up the database: %w", err)
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("database-%s-%d.jsonl.gz", s.now().UTC().Format("20060102T150405Z"), backup.ID)
+	s3Key, err := s.backupStorage.UploadBackup(ctx, fileName, file, size)
+	if err != nil {
+		return fmt.Errorf("failed to upload the database backup: %w", err)
+	}
+
+	completedAt := s.now()
+	backup.Status = BackupStatusCompleted
+	backup.S3Key = s3Key
+	backup.FileSizeBytes = size
+	backup.TableCount = stats.Tables
+	backup.RowCount = stats.Rows
+	backup.MigrationVersion = stats.MigrationVersion
+	backup.ErrorMessage = ""
+	backup.CompletedAt = &completedAt
+	if err := s.repos.DatabaseBackup().Update(ctx, backup); err != nil {
+		return fmt.Errorf("failed to update database backup %d: %w", backup.ID, err)
+	}
+	return nil
+}
+
+// failDatabaseBackup はバックアップの履歴を failed にします（記録はベストエフォート）。
+func (s *Service) failDatabaseBackup(ctx context.Context, backup *model.DatabaseBackup, cause error) {
+	backup.Status = BackupStatusFailed
+	backup.ErrorMessage = truncateString(cause.Error(), 500)
+	if err := s.repos.DatabaseBackup().Update(ctx, backup); err != nil {
+		logging.FromContext(ctx).Warn("Failed to mark database backup as failed", "backup_id", backup.ID, "error", err)
+	}
+}
+
+// GetDatabaseBackupsPage はバックアップの履歴を新しい順に1ページ分取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.DatabaseBackup]: バックアップの履歴の1ページ分
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetDatabaseBackupsPage(ctx context.Context, params pagination.Params) (*pagination.Page[model.DatabaseBackup], error) {
+	backups, err := s.repos.DatabaseBackup().ListPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(backups, params, func(b model.DatabaseBackup) uint { return b.ID }), nil
+}
+
+// OpenDatabaseBackup は保存したバックアップのファイルを取得します（管理CLIのリストア）。
+// 呼び出し元で戻り値の io.ReadCloser を閉じてください。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - id: バックアップの履歴のID
+//
+// 戻り値:
+//   - io.ReadCloser: バックアップのファイル
+//   - *model.DatabaseBackup: バックアップの履歴
+//   - error: 完了していない場合は ErrBackupNotCompleted、未設定の場合は ErrBackupNotConfigured
+func (s *Service) OpenDatabaseBackup(ctx context.Context, id uint) (io.ReadCloser, *model.DatabaseBackup, error) {
+	if s.backupStorage == nil || !s.backupStorage.IsConfigured() {
+		return nil, nil, ErrBackupNotConfigured
+	}
+	backup, err := s.repos.DatabaseBackup().GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if backup.Status != BackupStatusCompleted || backup.S3Key == "" {
+		return nil, backup, ErrBackupNotCompleted
+	}
+	body, err := s.backupStorage.DownloadBackup(ctx, backup.S3Key)
+	if err != nil {
+		return nil, backup, err
+	}
+	return body, backup, nil
+}
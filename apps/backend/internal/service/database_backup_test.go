@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/database"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Database Backup Tests - データベースのバックアップのテスト
+// =============================================================================
+// テスト対象:
+//   - RequestDatabaseBackup / ProcessDatabaseBackupJob: pending のバックアップの履歴の作成とジョブでの作成
+//   - RunDatabaseBackup: 定期実行・管理CLIのバックアップ、失敗時の状態
+//   - OpenDatabaseBackup: 保存したバックアップの取得
+
+// mockBackupJobQueue はテスト用のジョブキューです（登録したバックアップのジョブを保持する）。
+type mockBackupJobQueue struct {
+	jobs []DatabaseBackupJobPayload
+}
+
+func (q *mockBackupJobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	q.jobs = append(q.jobs, payload.(DatabaseBackupJobPayload))
+	return nil
+}
+
+// mockBackupSource はテスト用のバックアップするデータベースです。
+type mockBackupSource struct {
+	data []byte
+	err  error
+}
+
+func (s *mockBackupSource) Backup(ctx context.Context, w io.Writer) (*database.BackupStats, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if _, err := w.Write(s.data); err != nil {
+		return nil, err
+	}
+	return &database.BackupStats{MigrationVersion: 5, Tables: 2, Rows: 3}, nil
+}
+
+// mockBackupStorage はテスト用のバックアップの保存先です。
+type mockBackupStorage struct {
+	uploads map[string][]byte
+}
+
+func (s *mockBackupStorage) IsConfigured() bool { return true }
+
+func (s *mockBackupStorage) UploadBackup(ctx context.Context, fileName string, body io.ReadSeeker, size int64) (string, error) {
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	key := "backups/database/" + fileName
+	s.uploads[key] = data
+	return key, nil
+}
+
+func (s *mockBackupStorage) DownloadBackup(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.uploads[objectKey])), nil
+}
+
+// TestRequestDatabaseBackup_ProcessJob は管理者の指示によるバックアップのテストです。
+// 期待動作:
+//   - RequestDatabaseBackup は pending のバックアップの履歴を作成してジョブを登録する
+//   - ProcessDatabaseBackupJob はファイルを保存してバックアップの履歴を completed にする
+//   - 再配信された同じジョブは再作成しない
+//   - OpenDatabaseBackup は保存したファイルを返す
+func TestRequestDatabaseBackup_ProcessJob(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	jobQueue := &mockBackupJobQueue{}
+	storage := &mockBackupStorage{uploads: make(map[string][]byte)}
+	svc.SetJobQueue(jobQueue)
+	svc.SetDatabaseBackup(&mockBackupSource{data: []byte("backup")}, storage)
+	ctx := context.Background()
+
+	// Act
+	backup, err := svc.RequestDatabaseBackup(ctx, 1)
+	if err != nil {
+		t.Fatalf("RequestDatabaseBackup failed: %v", err)
+	}
+	if backup.Status != BackupStatusPending || backup.Trigger != BackupTriggerManual || len(jobQueue.jobs) != 1 {
+		t.Fatalf("Expected pending manual backup with 1 job, got %+v jobs=%d", backup, len(jobQueue.jobs))
+	}
+	processErr := svc.ProcessDatabaseBackupJob(ctx, jobQueue.jobs[0], false)
+	redeliveredErr := svc.ProcessDatabaseBackupJob(ctx, jobQueue.jobs[0], false)
+
+	// Assert
+	if processErr != nil || redeliveredErr != nil {
+		t.Fatalf("ProcessDatabaseBackupJob failed: %v / %v", processErr, redeliveredErr)
+	}
+	if len(storage.uploads) != 1 {
+		t.Errorf("Expected 1 upload, got %d", len(storage.uploads))
+	}
+	body, stored, err := svc.OpenDatabaseBackup(ctx, backup.ID)
+	if err != nil {
+		t.Fatalf("OpenDatabaseBackup failed: %v", err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if stored.Status != BackupStatusCompleted || stored.RowCount != 3 || stored.FileSizeBytes != 6 || string(data) != "backup" {
+		t.Errorf("Expected completed backup with 3 rows and the uploaded file, got %+v %q", stored, data)
+	}
+}
+
+// TestRunDatabaseBackup_Failures はバックアップの失敗のテストです。
+// 期待動作:
+//   - データベース・保存先が未設定の場合は ErrBackupNotConfigured を返し、バックアップの履歴を作成しない
+//   - 作成に失敗した場合はバックアップの履歴を failed にしてエラーを返す
+//   - 完了していないバックアップは OpenDatabaseBackup で ErrBackupNotCompleted
+func TestRunDatabaseBackup_Failures(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetJobQueue(&mockBackupJobQueue{})
+	ctx := context.Background()
+	backupRepo := mockRepos.GetMockDatabaseBackupRepository()
+
+	// Act & Assert: 未設定
+	if _, err := svc.RequestDatabaseBackup(ctx, 1); !errors.Is(err, ErrBackupNotConfigured) {
+		t.Errorf("Expected ErrBackupNotConfigured, got %v", err)
+	}
+	if _, err := svc.RunDatabaseBackup(ctx, BackupTriggerScheduled); !errors.Is(err, ErrBackupNotConfigured) {
+		t.Errorf("Expected ErrBackupNotConfigured, got %v", err)
+	}
+	if len(backupRepo.Backups) != 0 {
+		t.Errorf("Expected no backups, got %d", len(backupRepo.Backups))
+	}
+
+	// Act & Assert: 作成に失敗
+	sourceErr := errors.New("connection lost")
+	svc.SetDatabaseBackup(&mockBackupSource{err: sourceErr}, &mockBackupStorage{uploads: make(map[string][]byte)})
+	backup, err := svc.RunDatabaseBackup(ctx, BackupTriggerScheduled)
+	if !errors.Is(err, sourceErr) {
+		t.Fatalf("Expected the source error, got %v", err)
+	}
+	if backup.Status != BackupStatusFailed || backup.ErrorMessage == "" {
+		t.Errorf("Expected failed backup with error message, got %+v", backup)
+	}
+	if _, _, err := svc.OpenDatabaseBackup(ctx, backup.ID); !errors.Is(err, ErrBackupNotCompleted) {
+		t.Errorf("Expected ErrBackupNotCompleted, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+)
+
+// =============================================================================
+// Device Token Cleanup - 無効なデバイストークンの整理
+// =============================================================================
+// SNS/FCMがエンドポイントの無効化や未登録のトークンを通知した場合、
+// 送信時にトークンを無効化して理由を記録します（以降の送信対象から外れます）。
+// 無効化から DeviceTokenInactiveRetention を過ぎたトークンはスケジューラーで削除します。
+
+// DeviceTokenInactiveRetention は無効化されたトークンを削除せずに保持する期間
+const DeviceTokenInactiveRetention = 90 * 24 * time.Hour
+
+// デバイストークンの無効化理由
+const (
+	// DeviceTokenReasonSNSEndpointDisabled はSNSエンドポイントが無効化されていた場合
+	DeviceTokenReasonSNSEndpointDisabled = "sns_endpoint_disabled"
+	// DeviceTokenReasonSNSInvalidToken はSNSがトークンを不正と判定した場合
+	DeviceTokenReasonSNSInvalidToken = "sns_invalid_token"
+	// DeviceTokenReasonFCMUnregistered はFCMでトークンが登録解除されていた場合
+	DeviceTokenReasonFCMUnregistered = "fcm_unregistered"
+	// DeviceTokenReasonFCMInvalidToken はFCMがトークンを不正と判定した場合
+	DeviceTokenReasonFCMInvalidToken = "fcm_invalid_token"
+)
+
+// InvalidDeviceTokenError はプッシュ通知の送信先トークンが無効であることを示すエラーです。
+// リトライしても成功しないため、送信処理はリトライせずにトークンを無効化します。
+type InvalidDeviceTokenError struct {
+	TokenID uint
+	Reason  string
+	Err     error
+}
+
+// Error はエラーメッセージを返します。
+func (e *InvalidDeviceTokenError) Error() string {
+	return fmt.Sprintf("invalid device token %d (%s): %v", e.TokenID, e.Reason, e.Err)
+}
+
+// Unwrap は元のエラーを返します。
+func (e *InvalidDeviceTokenError) Unwrap() error {
+	return e.Err
+}
+
+// invalidDeviceTokenErrors はエラーに含まれる InvalidDeviceTokenError をすべて取り出します。
+// sendNotificationEvent は複数トークンのエラーを errors.Join でまとめるため、ツリー全体を探索します。
+//
+// 戻り値:
+//   - []*InvalidDeviceTokenError: 無効なトークンのエラー
+//   - error: 無効なトークン以外のエラー（ない場合はnil）
+func invalidDeviceTokenErrors(err error) ([]*InvalidDeviceTokenError, error) {
+	if err == nil {
+		return nil, nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		var invalid *InvalidDeviceTokenError
+		if errors.As(err, &invalid) {
+			return []*InvalidDeviceTokenError{invalid}, nil
+		}
+		return nil, err
+	}
+
+	var tokens []*InvalidDeviceTokenError
+	var others []error
+	for _, e := range joined.Unwrap() {
+		t, other := invalidDeviceTokenErrors(e)
+		tokens = append(tokens, t...)
+		if other != nil {
+			others = append(others, other)
+		}
+	}
+	return tokens, errors.Join(others...)
+}
+
+// isSNSInvalidTokenMessage はSNSの InvalidParameter エラーがトークン自体の不正を示すかを判定します。
+// 同じトークンで属性が異なるエンドポイントが既にある場合も InvalidParameter になるため除外します。
+func isSNSInvalidTokenMessage(message string) bool {
+	return strings.Contains(message, "Invalid parameter: Token") && !strings.Contains(message, "already exists")
+}
+
+// deactivateInvalidTokens は送信エラーに含まれる無効なトークンを無効化します。
+// 無効なトークンはリトライ対象にしないため、それ以外のエラーのみを返します。
+// 無効化に失敗した場合は次回の送信で再検出されるため、警告を出力して処理を継続します。
+func (h *notificationEventHandler) deactivateInvalidTokens(ctx context.Context, sendErr error) error {
+	invalid, remaining := invalidDeviceTokenErrors(sendErr)
+	for _, e := range invalid {
+		if err := h.repos.DeviceToken().DeactivateToken(ctx, e.TokenID, e.Reason); err != nil {
+			logging.FromContext(ctx).Warn("Failed to deactivate device token", "device_token_id", e.TokenID, "error", err)
+		}
+	}
+	return remaining
+}
+
+// PruneInactiveDeviceTokens は無効化から DeviceTokenInactiveRetention を過ぎたデバイストークンを削除します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//
+// 戻り値:
+//   - int64: 削除したトークン数
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) PruneInactiveDeviceTokens(ctx context.Context) (int64, error) {
+	return s.repos.DeviceToken().DeleteInactiveBefore(ctx, s.now().Add(-DeviceTokenInactiveRetention))
+}
@@ -0,0 +1,148 @@
+// Package service - Device Token Cleanup Tests
+//
+// 無効なデバイストークンの整理のテストを提供します。
+// テスト対象:
+//   - FCMエラーレスポンスからの無効化理由の判定
+//   - 送信時の無効なトークンの無効化（リトライ対象にしない）
+//   - 無効化から90日以上経過したトークンの削除
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestFCMInvalidTokenReason はFCMエラーレスポンスの判定のテストです。
+func TestFCMInvalidTokenReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       string
+	}{
+		{"unregistered", 404, `{"error":{"code":404,"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`, DeviceTokenReasonFCMUnregistered},
+		{"not found without details", 404, `not found`, DeviceTokenReasonFCMUnregistered},
+		{"invalid token", 400, `{"error":{"code":400,"message":"The registration token is not a valid FCM registration token","status":"INVALID_ARGUMENT"}}`, DeviceTokenReasonFCMInvalidToken},
+		{"invalid payload", 400, `{"error":{"code":400,"message":"Invalid JSON payload received.","status":"INVALID_ARGUMENT"}}`, ""},
+		{"server error", 503, `{"error":{"code":503,"status":"UNAVAILABLE"}}`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := fcmInvalidTokenReason(tt.statusCode, []byte(tt.body)); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestInvalidDeviceTokenErrors は送信エラーの分類のテストです。
+func TestInvalidDeviceTokenErrors(t *testing.T) {
+	other := errors.New("email failed")
+	err := errors.Join(
+		&InvalidDeviceTokenError{TokenID: 1, Reason: DeviceTokenReasonSNSEndpointDisabled, Err: errors.New("disabled")},
+		&InvalidDeviceTokenError{TokenID: 2, Reason: DeviceTokenReasonFCMUnregistered, Err: errors.New("unregistered")},
+		other,
+	)
+
+	invalid, remaining := invalidDeviceTokenErrors(err)
+
+	if len(invalid) != 2 || invalid[0].TokenID != 1 || invalid[1].TokenID != 2 {
+		t.Errorf("Expected 2 invalid tokens, got %+v", invalid)
+	}
+	if !errors.Is(remaining, other) {
+		t.Errorf("Expected remaining error to contain %v, got %v", other, remaining)
+	}
+	if _, remaining := invalidDeviceTokenErrors(errors.Join(invalid[0])); remaining != nil {
+		t.Errorf("Expected no remaining error, got %v", remaining)
+	}
+}
+
+// TestNotificationEventHandler_DeactivatesInvalidTokens は送信時の無効トークン処理のテストです。
+// 期待動作:
+//   - 無効と判定されたトークンは理由付きで無効化される
+//   - 有効なトークンへの送信は成功し、通知はリトライ対象にならない
+func TestNotificationEventHandler_DeactivatesInvalidTokens(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	mockSender.InvalidTokens = map[string]string{"stale-token": DeviceTokenReasonFCMUnregistered}
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "tokens@example.com", Timezone: "UTC"}
+	_ = mockRepos.User().Create(ctx, user)
+	stale := &model.DeviceToken{UserID: user.ID, Token: "stale-token", Platform: "android", IsActive: true}
+	valid := &model.DeviceToken{UserID: user.ID, Token: "valid-token", Platform: "ios", IsActive: true}
+	_ = mockRepos.DeviceToken().Create(ctx, stale)
+	_ = mockRepos.DeviceToken().Create(ctx, valid)
+
+	event := NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: user.ID, UserEmail: user.Email, Title: "リマインダー", Body: "水やり"}
+
+	// Act
+	err := handler.HandleEvent(ctx, event)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	deactivated, _ := mockRepos.DeviceToken().GetByID(ctx, stale.ID)
+	if deactivated.IsActive || deactivated.DeactivationReason != DeviceTokenReasonFCMUnregistered || deactivated.DeactivatedAt == nil {
+		t.Errorf("Expected stale token to be deactivated, got %+v", deactivated)
+	}
+	active, _ := mockRepos.DeviceToken().GetActiveByUserID(ctx, user.ID)
+	if len(active) != 1 || active[0].Token != "valid-token" {
+		t.Errorf("Expected only valid token to remain active, got %+v", active)
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].Status != "sent" {
+		t.Errorf("Expected sent log, got %+v", logs)
+	}
+}
+
+// TestPruneInactiveDeviceTokens は無効なトークンの削除のテストです。
+// 期待動作:
+//   - 無効化から90日以上経過したトークンのみ削除される
+//   - 有効なトークンは更新日時が古くても削除されない
+func TestPruneInactiveDeviceTokens(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	old := time.Now().Add(-100 * 24 * time.Hour)
+	recent := time.Now().Add(-10 * 24 * time.Hour)
+	tokens := []*model.DeviceToken{
+		{UserID: 1, Token: "old-inactive", Platform: "ios"},
+		{UserID: 1, Token: "recent-inactive", Platform: "android"},
+		{UserID: 1, Token: "old-active", Platform: "web", IsActive: true},
+	}
+	for _, token := range tokens {
+		_ = mockRepos.DeviceToken().Create(ctx, token)
+	}
+	tokens[0].DeactivatedAt = &old
+	tokens[1].DeactivatedAt = &recent
+	tokens[2].UpdatedAt = old
+
+	// Act
+	deleted, err := svc.PruneInactiveDeviceTokens(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PruneInactiveDeviceTokens failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted token, got %d", deleted)
+	}
+	if _, err := mockRepos.DeviceToken().GetByToken(ctx, "old-inactive"); err == nil {
+		t.Error("Expected old inactive token to be deleted")
+	}
+	remaining, _ := mockRepos.DeviceToken().GetByUserID(ctx, 1)
+	if len(remaining) != 2 {
+		t.Errorf("Expected 2 remaining tokens, got %d", len(remaining))
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+// =============================================================================
+// Email Template - 通知メールテンプレート
+// =============================================================================
+// 通知メールの件名・HTML本文・テキスト本文を templates/email 以下のテンプレートから生成します。
+//
+// テンプレートの構成:
+//   - layout.html.tmpl: 全言語共通のHTMLレイアウト
+//   - {locale}/common.html.tmpl: 言語ごとの共通部品（lang属性、フッター）
+//   - {locale}/{event_type}.html.tmpl: イベント別のHTML本文（"title", "content" を定義）
+//   - {locale}/{event_type}.txt.tmpl: イベント別のテキスト本文（"subject", "text" を定義）
+//
+// イベント別のテンプレートがない場合は default を、未対応の言語の場合は DefaultEmailLocale を使用します。
+
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+// DefaultEmailLocale はロケール未設定・未対応の場合に使用する言語
+const DefaultEmailLocale = "ja"
+
+// emailTemplateDefault はイベント別テンプレートがない場合に使用するテンプレート名
+const emailTemplateDefault = "default"
+
+var (
+	// ErrUnsupportedLocale は対応していない言語が指定された場合のエラー
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	// ErrUnknownEmailTemplate はプレビュー対象のイベントタイプが存在しない場合のエラー
+	ErrUnknownEmailTemplate = errors.New("unknown email template")
+)
+
+// SupportedEmailLocales は通知メールが対応している言語です。
+var SupportedEmailLocales = []string{"ja", "en"}
+
+// emailTemplateNames はイベント別テンプレートの名前です（default を含む）。
+var emailTemplateNames = []string{
+	string(NotificationEventTaskDueReminder),
+	string(NotificationEventTaskOverdueAlert),
+	string(NotificationEventHarvestReminder),
+	string(NotificationEventCropReadyToHarvest),
+	string(NotificationEventDailyDigest),
+	string(TransactionalEmailWelcome),
+	string(TransactionalEmailVerification),
+	string(TransactionalEmailPasswordReset),
+	emailTemplateDefault,
+}
+
+// RenderedEmail はテンプレートから生成した通知メールです。
+type RenderedEmail struct {
+	Locale   string `json:"locale"`
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body"`
+}
+
+// emailTemplateData はテンプレートに渡すデータです。
+type emailTemplateData struct {
+	Title     string
+	Body      string
+	Lines     []string // Body を行ごとに分割したもの（HTMLで段落にするため）
+	Data      map[string]interface{}
+	LocalDate string
+}
+
+// emailTemplateSet は言語・イベントタイプごとのテンプレートです。
+type emailTemplateSet struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var (
+	emailTemplatesOnce sync.Once
+	emailTemplates     map[string]*emailTemplateSet // キー: {locale}/{name}
+	emailTemplatesErr  error
+)
+
+// emailTemplateFuncs はテンプレートで使用する関数です。
+var emailTemplateFuncs = map[string]interface{}{
+	// plural は件数が1の場合に単数形、それ以外は複数形を返します（英語用）
+	"plural": func(n interface{}, singular, pluralForm string) string {
+		if fmt.Sprint(n) == "1" {
+			return singular
+		}
+		return pluralForm
+	},
+}
+
+// loadEmailTemplates は埋め込みのテンプレートをすべて解析します。
+func loadEmailTemplates() (map[string]*emailTemplateSet, error) {
+	sets := make(map[string]*emailTemplateSet)
+	for _, locale := range SupportedEmailLocales {
+		for _, name := range emailTemplateNames {
+			dir := "templates/email/" + locale + "/"
+
+			html, err := htmltemplate.New("layout.html.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS,
+				"templates/email/layout.html.tmpl", dir+"common.html.tmpl", dir+name+".html.tmpl")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s%s.html.tmpl: %w", dir, name, err)
+			}
+
+			text, err := texttemplate.New(name+".txt.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS, dir+name+".txt.tmpl")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s%s.txt.tmpl: %w", dir, name, err)
+			}
+
+			sets[locale+"/"+name] = &emailTemplateSet{html: html, text: text}
+		}
+	}
+	return sets, nil
+}
+
+// NormalizeLocale は言語タグ（例: en-US, ja_JP）を対応言語に正規化します。
+//
+// 戻り値:
+//   - string: 対応言語（ja, en）
+//   - error: 対応していない言語の場合は ErrUnsupportedLocale
+func NormalizeLocale(locale string) (string, error) {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	for _, supported := range SupportedEmailLocales {
+		if lang == supported {
+			return supported, nil
+		}
+	}
+	return "", ErrUnsupportedLocale
+}
+
+// RenderNotificationEmail は通知イベントからメールの件名・本文を生成します。
+// 未設定・未対応の言語は DefaultEmailLocale で生成します。
+//
+// 引数:
+//   - event: 通知イベント
+//   - locale: ユーザーの言語（User.Locale）
+//
+// 戻り値:
+//   - *RenderedEmail: 生成したメール
+//   - error: テンプレートの解析・実行に失敗した場合のエラー
+func RenderNotificationEmail(event NotificationEvent, locale string) (*RenderedEmail, error) {
+	return renderEmailTemplate(string(event.Type), locale, emailTemplateData{
+		Title:     event.Title,
+		Body:      event.Body,
+		Lines:     strings.Split(event.Body, "\n"),
+		Data:      event.Data,
+		LocalDate: event.LocalDate,
+	})
+}
+
+// renderEmailTemplate はテンプレート名（イベントタイプ・トランザクションメールの種類）のテンプレートでメールを生成します。
+// テンプレートがない場合は default を、未設定・未対応の言語は DefaultEmailLocale を使用します。
+func renderEmailTemplate(name, locale string, data emailTemplateData) (*RenderedEmail, error) {
+	emailTemplatesOnce.Do(func() {
+		emailTemplates, emailTemplatesErr = loadEmailTemplates()
+	})
+	if emailTemplatesErr != nil {
+		return nil, emailTemplatesErr
+	}
+
+	lang, err := NormalizeLocale(locale)
+	if err != nil {
+		lang = DefaultEmailLocale
+	}
+	set, ok := emailTemplates[lang+"/"+name]
+	if !ok {
+		set = emailTemplates[lang+"/"+emailTemplateDefault]
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := set.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, fmt.Errorf("failed to render email text: %w", err)
+	}
+	if err := set.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render email html: %w", err)
+	}
+
+	return &RenderedEmail{
+		Locale:   lang,
+		Subject:  strings.TrimSpace(subject.String()),
+		HTMLBody: html.String(),
+		TextBody: strings.TrimSpace(text.String()),
+	}, nil
+}
+
+// emailPreviewEvents はプレビュー用のサンプル通知イベントです。
+var emailPreviewEvents = map[NotificationEventType]NotificationEvent{
+	NotificationEventTaskDueReminder: {
+		Type:  NotificationEventTaskDueReminder,
+		Title: "今日のタスクリマインダー",
+		Body:  "今日のタスクが2件あります。",
+		Data:  map[string]interface{}{"task_count": 2, "task_ids": []uint{1, 2}},
+	},
+	NotificationEventTaskOverdueAlert: {
+		Type:  NotificationEventTaskOverdueAlert,
+		Title: "期限切れタスクの警告",
+		Body:  "3件のタスクが期限切れです。確認してください。",
+		Data:  map[string]interface{}{"overdue_count": 3, "task_ids": []uint{3, 4, 5}},
+	},
+	NotificationEventHarvestReminder: {
+		Type:  NotificationEventHarvestReminder,
+		Title: "収穫リマインダー",
+		Body:  "トマト があと3日で収穫予定です。",
+		Data:  map[string]interface{}{"crop_count": 1, "crop_ids": []uint{1}},
+	},
+	NotificationEventCropReadyToHarvest: {
+		Type:  NotificationEventCropReadyToHarvest,
+		Title: "収穫の時期です",
+		Body:  "トマト が収穫できます。",
+		Data:  map[string]interface{}{"crop_count": 1, "crop_ids": []uint{1}},
+	},
+	NotificationEventYearInReview: {
+		Type:  NotificationEventYearInReview,
+		Title: "2025年の菜園のふりかえり",
+		Body:  "12件の作物を育て、合計38.5kgを収穫しました。最も収穫が多かった区画はA-1（14.2kg）でした。",
+		Data:  map[string]interface{}{"season": 2025, "crops_grown": 12, "crops_harvested": 10, "total_kg": 38.5},
+	},
+}
+
+// PreviewNotificationEmail は管理者向けにサンプルデータで通知メールを生成します。
+// daily_digest は他のサンプルイベントをまとめたダイジェストで生成します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - eventType: 通知イベントタイプ
+//   - locale: 言語（ja, en）
+//
+// 戻り値:
+//   - *RenderedEmail: 生成したメール
+//   - error: 未対応の言語は ErrUnsupportedLocale、未知のイベントタイプは ErrUnknownEmailTemplate
+func (s *Service) PreviewNotificationEmail(ctx context.Context, eventType NotificationEventType, locale string) (*RenderedEmail, error) {
+	lang, err := NormalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	event, ok := emailPreviewEvents[eventType]
+	if eventType == NotificationEventDailyDigest {
+		event, ok = buildDigestEvent([]NotificationEvent{
+			emailPreviewEvents[NotificationEventTaskOverdueAlert],
+			emailPreviewEvents[NotificationEventTaskDueReminder],
+			emailPreviewEvents[NotificationEventHarvestReminder],
+		}, lang), true
+	}
+	if !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+	event.LocalDate = "2026-04-01"
+
+	return RenderNotificationEmail(event, lang)
+}
+
+// GetUserLocale はユーザーの通知メールの言語を取得します（未設定の場合はデフォルト）。
+func (s *Service) GetUserLocale(ctx context.Context, userID uint) (string, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	lang, err := NormalizeLocale(user.Locale)
+	if err != nil {
+		return DefaultEmailLocale, nil
+	}
+	return lang, nil
+}
+
+// SetUserLocale はユーザーの通知メールの言語を更新します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - locale: 言語タグ（例: ja, en, en-US）
+//
+// 戻り値:
+//   - string: 更新後の言語（正規化済み）
+//   - error: 未対応の言語の場合は ErrUnsupportedLocale、更新に失敗した場合のエラー
+func (s *Service) SetUserLocale(ctx context.Context, userID uint, locale string) (string, error) {
+	lang, err := NormalizeLocale(locale)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	user.Locale = lang
+	if err := s.repos.User().Update(ctx, user); err != nil {
+		return "", err
+	}
+	return user.Locale, nil
+}
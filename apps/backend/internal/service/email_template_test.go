@@ -0,0 +1,102 @@
+// Package service - Email Template Tests
+//
+// 通知メールテンプレートのテストを提供します。
+// テスト対象:
+//   - 全言語・全イベントタイプのテンプレートの解析と生成
+//   - 言語ごとの件名・本文、HTMLエスケープ
+//   - 未対応言語のフォールバックとプレビュー
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestRenderNotificationEmail_AllTemplates は全テンプレートが生成できることのテストです。
+func TestRenderNotificationEmail_AllTemplates(t *testing.T) {
+	for _, locale := range SupportedEmailLocales {
+		for _, name := range emailTemplateNames {
+			event := NotificationEvent{Type: NotificationEventType(name), Title: "タイトル", Body: "本文"}
+			if preview, ok := emailPreviewEvents[event.Type]; ok {
+				event = preview
+			}
+
+			email, err := RenderNotificationEmail(event, locale)
+			if err != nil {
+				t.Fatalf("%s/%s: RenderNotificationEmail failed: %v", locale, name, err)
+			}
+			if email.Subject == "" || email.TextBody == "" || !strings.Contains(email.HTMLBody, `<html lang="`+locale+`">`) {
+				t.Errorf("%s/%s: unexpected email %+v", locale, name, email)
+			}
+		}
+	}
+}
+
+// TestRenderNotificationEmail_Locales は言語ごとの生成内容のテストです。
+// 期待動作:
+//   - ja はイベントのタイトル・本文を使用し、HTMLはエスケープされる
+//   - en はイベントデータの件数から英語の本文を生成する
+//   - 未対応の言語は ja で生成する
+func TestRenderNotificationEmail_Locales(t *testing.T) {
+	event := NotificationEvent{
+		Type:  NotificationEventTaskOverdueAlert,
+		Title: "期限切れタスクの警告",
+		Body:  "3件のタスクが期限切れです。<b>確認</b>",
+		Data:  map[string]interface{}{"overdue_count": 3},
+	}
+
+	ja, err := RenderNotificationEmail(event, "ja-JP")
+	if err != nil {
+		t.Fatalf("RenderNotificationEmail(ja) failed: %v", err)
+	}
+	en, err := RenderNotificationEmail(event, "en-US")
+	if err != nil {
+		t.Fatalf("RenderNotificationEmail(en) failed: %v", err)
+	}
+	fallback, err := RenderNotificationEmail(event, "fr")
+	if err != nil {
+		t.Fatalf("RenderNotificationEmail(fr) failed: %v", err)
+	}
+
+	if ja.Subject != "期限切れタスクの警告" || !strings.Contains(ja.TextBody, "<b>確認</b>") {
+		t.Errorf("Unexpected ja email: %+v", ja)
+	}
+	if strings.Contains(ja.HTMLBody, "<b>確認</b>") || !strings.Contains(ja.HTMLBody, "&lt;b&gt;") {
+		t.Error("Expected event body to be escaped in HTML")
+	}
+	if en.Subject != "Overdue tasks" || !strings.Contains(en.TextBody, "3 tasks are overdue.") {
+		t.Errorf("Unexpected en email: %+v", en)
+	}
+	if fallback.Locale != "ja" || fallback.Subject != ja.Subject {
+		t.Errorf("Expected fallback to ja, got %+v", fallback)
+	}
+}
+
+// TestPreviewNotificationEmail はプレビュー生成のテストです。
+// 期待動作:
+//   - daily_digest はサンプルイベントをまとめて生成する
+//   - 未対応の言語は ErrUnsupportedLocale、未知のタイプは ErrUnknownEmailTemplate
+func TestPreviewNotificationEmail(t *testing.T) {
+	svc := NewService(repository.NewMockRepositories())
+	ctx := context.Background()
+
+	digest, err := svc.PreviewNotificationEmail(ctx, NotificationEventDailyDigest, "en")
+	if err != nil {
+		t.Fatalf("PreviewNotificationEmail failed: %v", err)
+	}
+	_, localeErr := svc.PreviewNotificationEmail(ctx, NotificationEventTaskDueReminder, "xx")
+	_, typeErr := svc.PreviewNotificationEmail(ctx, "unknown", "ja")
+
+	if !strings.Contains(digest.TextBody, "- 3 tasks are overdue.") || !strings.Contains(digest.TextBody, "- 1 crop is expected") {
+		t.Errorf("Unexpected digest preview: %s", digest.TextBody)
+	}
+	if localeErr != ErrUnsupportedLocale {
+		t.Errorf("Expected ErrUnsupportedLocale, got %v", localeErr)
+	}
+	if typeErr != ErrUnknownEmailTemplate {
+		t.Errorf("Expected ErrUnknownEmailTemplate, got %v", typeErr)
+	}
+}
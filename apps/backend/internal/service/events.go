@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secure-scorecard/backend/internal/dto"
+	"github.com/secure-scorecard/backend/internal/events"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Live Events - 記録の変更のイベント配信
+// =============================================================================
+// タスクの完了・収穫記録の追加・通知の受信をイベントバスに配信し、
+// Server-Sent Events（GET /api/v1/events）で接続中のクライアントに知らせます。
+// トランザクション内の変更はコミット後に配信し、ロールバックした変更は配信しません。
+
+// ErrEventsUnavailable はイベントバスが未設定の場合のエラー
+var ErrEventsUnavailable = errors.New("live events are unavailable")
+
+// EventBus は記録の変更のイベントの配信先です（events.Bus）。
+type EventBus interface {
+	Publish(event events.Event)
+	Subscribe(userID uint, lastEventID uint64) *events.Subscription
+}
+
+// SetEventBus は記録の変更のイベントの配信先を設定します。
+// 未設定の場合はイベントを配信せず、SubscribeEvents は ErrEventsUnavailable を返します。
+func (s *Service) SetEventBus(bus EventBus) {
+	s.events = bus
+}
+
+// SubscribeEvents はユーザーの記録の変更のイベントを購読します。
+//
+// 引数:
+//   - userID: ユーザーID
+//   - lastEventID: 再接続時に前回最後に受信したイベントのID（以降の直近のイベントを再送、0 の場合は再送しない）
+//
+// 戻り値:
+//   - *events.Subscription: 購読（呼び出し元で Close する）
+//   - error: イベントバスが未設定の場合は ErrEventsUnavailable
+func (s *Service) SubscribeEvents(userID uint, lastEventID uint64) (*events.Subscription, error) {
+	if s.events == nil {
+		return nil, ErrEventsUnavailable
+	}
+	return s.events.Subscribe(userID, lastEventID), nil
+}
+
+// publishEvent はイベントを配信します（トランザクション内の場合はコミット後、未設定の場合は何もしない）。
+func (s *Service) publishEvent(ctx context.Context, event events.Event) {
+	if s.events == nil {
+		return
+	}
+	repository.AfterCommit(ctx, func() {
+		s.events.Publish(event)
+	})
+}
+
+// publishTaskCompleted はタスクの完了のイベントを配信します。
+func (s *Service) publishTaskCompleted(ctx context.Context, task *model.Task) {
+	s.publishEvent(ctx, events.Event{
+		Type:     events.TypeTaskCompleted,
+		UserID:   task.UserID,
+		Entity:   SyncEntityTasks,
+		EntityID: task.ID,
+		Data:     dto.NewTaskResponse(task),
+	})
+}
+
+// publishHarvestAdded は収穫記録の追加のイベントを配信します（配信先は作物のユーザー）。
+func (s *Service) publishHarvestAdded(ctx context.Context, harvest *model.Harvest) error {
+	if s.events == nil {
+		return nil
+	}
+	crop, err := s.repos.Crop().GetByID(ctx, harvest.CropID)
+	if err != nil {
+		return err
+	}
+	s.publishEvent(ctx, events.Event{
+		Type:     events.TypeHarvestAdded,
+		UserID:   crop.UserID,
+		Entity:   SyncEntityHarvests,
+		EntityID: harvest.ID,
+		Data:     dto.NewHarvestResponse(harvest),
+	})
+	return nil
+}
+
+// publishNotificationReceived は受信箱への通知の追加のイベントを配信します。
+func (s *Service) publishNotificationReceived(ctx context.Context, log *model.NotificationLog) {
+	s.publishEvent(ctx, events.Event{
+		Type:     events.TypeNotificationReceived,
+		UserID:   log.UserID,
+		Entity:   "notifications",
+		EntityID: log.ID,
+		Data:     newNotificationInboxItem(log),
+	})
+}
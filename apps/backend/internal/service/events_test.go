@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/dto"
+	"github.com/secure-scorecard/backend/internal/events"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Live Events Tests - 記録の変更のイベント配信のテスト
+// =============================================================================
+// テスト対象:
+//   - CompleteTask / CreateHarvest / CreateNotificationLog: イベントの配信（配信先は記録のユーザー）
+//   - RunInTransaction: ロールバックした変更のイベントを配信しないこと
+//   - events.Bus: Last-Event-ID 以降の再送、受信が遅い購読者の切断
+
+// receiveEvent は購読からイベントを1件受信します（届かない場合はテストを失敗にする）。
+func receiveEvent(t *testing.T, sub *events.Subscription) events.Event {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		if !ok {
+			t.Fatal("Subscription closed unexpectedly")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+	}
+	return events.Event{}
+}
+
+// assertNoEvent は購読にイベントが届いていないことを確認します。
+func assertNoEvent(t *testing.T, sub *events.Subscription) {
+	t.Helper()
+	select {
+	case event := <-sub.Events():
+		t.Errorf("Expected no event, got %+v", event)
+	default:
+	}
+}
+
+// TestEvents_PublishedFromService はサービスのメソッドからのイベントの配信のテストです。
+// 期待動作:
+//   - タスクの完了は task.completed、収穫記録の追加は harvest.added、通知ログの作成は notification.received
+//   - イベントは記録のユーザーの購読者にのみ届き、IDは連番
+func TestEvents_PublishedFromService(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	svc.SetEventBus(events.NewBus(events.DefaultBufferSize, events.DefaultHistorySize))
+	ctx := context.Background()
+	sub, err := svc.SubscribeEvents(1, 0)
+	if err != nil {
+		t.Fatalf("SubscribeEvents failed: %v", err)
+	}
+	defer sub.Close()
+	other, _ := svc.SubscribeEvents(2, 0)
+	defer other.Close()
+
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	_ = svc.CreateCrop(ctx, crop)
+
+	// Act
+	if err := svc.CompleteTask(ctx, task.ID); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 1, QuantityUnit: "kg"}
+	_ = svc.CreateHarvest(ctx, harvest)
+	_ = svc.CreateNotificationLog(ctx, &model.NotificationLog{UserID: 1, NotificationType: "task_reminder", Title: "リマインダー"})
+
+	// Assert
+	completed := receiveEvent(t, sub)
+	if completed.Type != events.TypeTaskCompleted || completed.EntityID != task.ID || completed.ID != 1 {
+		t.Errorf("Expected task.completed for task %d, got %+v", task.ID, completed)
+	}
+	if data, ok := completed.Data.(dto.TaskResponse); !ok || data.Status != "completed" {
+		t.Errorf("Expected completed task data, got %+v", completed.Data)
+	}
+	if added := receiveEvent(t, sub); added.Type != events.TypeHarvestAdded || added.EntityID != harvest.ID || added.ID != 2 {
+		t.Errorf("Expected harvest.added for harvest %d, got %+v", harvest.ID, added)
+	}
+	if received := receiveEvent(t, sub); received.Type != events.TypeNotificationReceived {
+		t.Errorf("Expected notification.received, got %+v", received)
+	}
+	assertNoEvent(t, other)
+}
+
+// TestEvents_NotPublishedOnRollback はロールバックしたトランザクションのイベントのテストです。
+// 期待動作:
+//   - トランザクション内の変更のイベントはコミットまで配信しない
+//   - ロールバックした場合は配信しない
+func TestEvents_NotPublishedOnRollback(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	svc.SetEventBus(events.NewBus(events.DefaultBufferSize, events.DefaultHistorySize))
+	ctx := context.Background()
+	sub, _ := svc.SubscribeEvents(1, 0)
+	defer sub.Close()
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+	errAbort := errors.New("abort")
+
+	// Act
+	err := svc.RunInTransaction(ctx, func(txCtx context.Context) error {
+		if err := svc.CompleteTask(txCtx, task.ID); err != nil {
+			return err
+		}
+		assertNoEvent(t, sub)
+		return errAbort
+	})
+
+	// Assert
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Expected abort error, got %v", err)
+	}
+	assertNoEvent(t, sub)
+}
+
+// TestEvents_Unavailable はイベントバスが未設定の場合のテストです。
+// 期待動作:
+//   - 購読は ErrEventsUnavailable、サービスのメソッドはイベントなしで成功する
+func TestEvents_Unavailable(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	ctx := context.Background()
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+
+	// Act & Assert
+	if _, err := svc.SubscribeEvents(1, 0); !errors.Is(err, ErrEventsUnavailable) {
+		t.Errorf("Expected ErrEventsUnavailable, got %v", err)
+	}
+	if err := svc.CompleteTask(ctx, task.ID); err != nil {
+		t.Errorf("Expected CompleteTask to succeed without event bus, got %v", err)
+	}
+}
+
+// TestBus_ReplayAndOverflow はイベントバスの再送・切断のテストです。
+// 期待動作:
+//   - lastEventID を指定した購読は、それより後の同じユーザーのイベントを先に受信する
+//   - バッファが満杯の購読者は購読を閉じる（他の購読者には配信を続ける）
+//   - Close 後の購読は閉じた購読
+func TestBus_ReplayAndOverflow(t *testing.T) {
+	// Arrange
+	bus := events.NewBus(2, 10)
+	for i := 0; i < 3; i++ {
+		bus.Publish(events.Event{Type: events.TypeHarvestAdded, UserID: 1, EntityID: uint(i + 1)})
+	}
+	bus.Publish(events.Event{Type: events.TypeHarvestAdded, UserID: 2, EntityID: 99})
+
+	t.Run("replay", func(t *testing.T) {
+		// Act
+		sub := bus.Subscribe(1, 1)
+		defer sub.Close()
+
+		// Assert
+		if first, second := receiveEvent(t, sub), receiveEvent(t, sub); first.ID != 2 || second.ID != 3 {
+			t.Errorf("Expected replay of events 2 and 3, got %d and %d", first.ID, second.ID)
+		}
+		assertNoEvent(t, sub)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		// Arrange
+		slow := bus.Subscribe(1, 0)
+		fast := bus.Subscribe(1, 0)
+		defer fast.Close()
+
+		// Act
+		for i := 0; i < 3; i++ {
+			bus.Publish(events.Event{Type: events.TypeHarvestAdded, UserID: 1})
+			<-fast.Events()
+		}
+
+		// Assert
+		var received int
+		for range slow.Events() {
+			received++
+		}
+		if received != 2 {
+			t.Errorf("Expected slow subscriber to be closed after 2 buffered events, got %d", received)
+		}
+		if bus.SubscriberCount() != 1 {
+			t.Errorf("Expected only the fast subscriber to remain, got %d", bus.SubscriberCount())
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		// Act
+		bus.Close()
+		sub := bus.Subscribe(1, 0)
+
+		// Assert
+		if _, ok := <-sub.Events(); ok {
+			t.Error("Expected subscription after Close to be closed")
+		}
+	})
+}
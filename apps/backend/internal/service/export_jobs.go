@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Async Export - ジョブキューによるエクスポート生成
+// =============================================================================
+// POST /exports はエクスポート履歴を pending で作成してジョブを登録するだけで応答し、
+// CSV/ZIPの生成とS3への保存はワーカーが行います。生成結果はエクスポート履歴の状態で確認し、
+// 完了後は GET /exports/:id/download からダウンロードします。
+// 写真を含む全データのエクスポートは複数の ZIP に分かれる場合があります（export_photos.go）。
+
+// JobTypeExportGenerate はエクスポート生成のジョブの種類です。
+const JobTypeExportGenerate = "export.generate"
+
+// エクスポート履歴の状態
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+var (
+	// ErrJobQueueUnavailable はジョブキューが未設定・登録できない場合のエラー
+	ErrJobQueueUnavailable = errors.New("job queue is unavailable")
+	// ErrExportStorageNotConfigured は非同期エクスポートの保存先が未設定の場合のエラー
+	ErrExportStorageNotConfigured = errors.New("export storage is not configured")
+)
+
+// JobQueue は重い処理をワーカーで実行するジョブキューです（queue.Queue）。
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+}
+
+// ExportStorage は生成したエクスポートファイルの保存先です（storage.Service）。
+type ExportStorage interface {
+	IsConfigured() bool
+	UploadExport(ctx context.Context, userID uint, fileName, contentType string, data []byte) (string, error)
+	DeleteExport(ctx context.Context, objectKey string) error
+}
+
+// ExportJobPayload はエクスポート生成のジョブのパラメータです。
+type ExportJobPayload struct {
+	ExportID      uint   `json:"export_id"`
+	UserID        uint   `json:"user_id"`
+	DataType      string `json:"data_type"`
+	Anonymize     bool   `json:"anonymize"`
+	IncludePhotos bool   `json:"include_photos"`
+}
+
+// SetJobQueue は重い処理を登録するジョブキューを設定します。
+// 未設定の場合、非同期エクスポート（RequestExport）は ErrJobQueueUnavailable を返します。
+func (s *Service) SetJobQueue(queue JobQueue) {
+	s.jobQueue = queue
+}
+
+// SetExportStorage は非同期エクスポートの保存先を設定します。
+func (s *Service) SetExportStorage(storage ExportStorage) {
+	s.exportStorage = storage
+}
+
+// RequestExport はエクスポートの生成をジョブキューに登録します。
+// エクスポート履歴を pending で作成し、生成はワーカーが行います（ProcessExportJob）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - dataType: エクスポートするデータ種類
+//   - opts: エクスポートのオプション
+//
+// 戻り値:
+//   - *model.ExportRecord: 作成したエクスポート履歴（Status: pending）
+//   - error: 保存先が未設定の場合は ErrExportStorageNotConfigured、キューに登録できない場合は ErrJobQueueUnavailable、
+//     all 以外で写真を含める場合は ErrExportPhotosRequireAll、写真を含めると保存容量の上限を超える場合は ErrStorageQuotaExceeded
+func (s *Service) RequestExport(ctx context.Context, userID uint, dataType ExportDataType, opts ExportOptions) (*model.ExportRecord, error) {
+	if opts.IncludePhotos && dataType != ExportDataTypeAll {
+		return nil, ErrExportPhotosRequireAll
+	}
+	if s.jobQueue == nil {
+		return nil, ErrJobQueueUnavailable
+	}
+	// 生成したファイルは保存先からしかダウンロードできない
+	if s.exportStorage == nil || !s.exportStorage.IsConfigured() {
+		return nil, ErrExportStorageNotConfigured
+	}
+	if opts.IncludePhotos {
+		if s.photoStorage == nil {
+			return nil, ErrPhotoStorageNotConfigured
+		}
+		// 写真の ZIP は写真と同じ大きさの保存容量を使用する
+		estimate, err := s.EstimateExport(ctx, userID, dataType, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.CheckStorageQuota(ctx, userID, estimate.PhotoBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &model.ExportRecord{
+		UserID:        userID,
+		DataType:      string(dataType),
+		Anonymized:    opts.Anonymize,
+		IncludePhotos: opts.IncludePhotos,
+		Status:        ExportStatusPending,
+	}
+	if err := s.repos.ExportRecord().Create(ctx, record); err != nil {
+		return nil, err
+	}
+
+	err := s.jobQueue.Enqueue(ctx, JobTypeExportGenerate, ExportJobPayload{
+		ExportID:      record.ID,
+		UserID:        userID,
+		DataType:      string(dataType),
+		Anonymize:     opts.Anonymize,
+		IncludePhotos: opts.IncludePhotos,
+	})
+	if err != nil {
+		s.failExport(ctx, record, err)
+		return nil, fmt.Errorf("%w: %v", ErrJobQueueUnavailable, err)
+	}
+	return record, nil
+}
+
+// ProcessExportJob はエクスポート生成のジョブを処理します。
+// 生成したファイルを保存先にアップロードし、エクスポート履歴を completed にします。
+// 処理済み（pending 以外）のエクスポートは再配信されたジョブとみなして何もしません。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - payload: ジョブのパラメータ
+//   - lastAttempt: 最後の試行か（失敗した場合にエクスポート履歴を failed にする）
+//
+// 戻り値:
+//   - error: 生成・保存に失敗した場合のエラー（ジョブキューが再試行する）
+func (s *Service) ProcessExportJob(ctx context.Context, payload ExportJobPayload, lastAttempt bool) error {
+	record, err := s.repos.ExportRecord().GetByID(ctx, payload.ExportID)
+	if err != nil {
+		return fmt.Errorf("failed to get export %d: %w", payload.ExportID, err)
+	}
+	if record.UserID != payload.UserID {
+		return ErrExportNotOwned
+	}
+	if record.Status != ExportStatusPending {
+		return nil
+	}
+
+	err = s.generateExport(ctx, record, payload)
+	if err != nil && lastAttempt {
+		s.failExport(ctx, record, err)
+	}
+	return err
+}
+
+// generateExport はエクスポートを生成・保存し、エクスポート履歴を completed にします。
+func (s *Service) generateExport(ctx context.Context, record *model.ExportRecord, payload ExportJobPayload) error {
+	if s.exportStorage == nil || !s.exportStorage.IsConfigured() {
+		return ErrExportStorageNotConfigured
+	}
+	if payload.IncludePhotos && ExportDataType(payload.DataType) == ExportDataTypeAll {
+		return s.generatePhotoExport(ctx, record, payload)
+	}
+
+	// エクスポートの読み取りは読み取りレプリカ（エクスポート履歴の更新はプライマリ）
+	result, err := s.ExportCSVWithOptions(repository.ContextWithReadReplica(ctx), payload.UserID, ExportDataType(payload.DataType), ExportOptions{Anonymize: payload.Anonymize})
+	if err != nil {
+		return fmt.Errorf("failed to generate export: %w", err)
+	}
+	s3Key, err := s.exportStorage.UploadExport(ctx, payload.UserID, result.FileName, result.ContentType, result.Data)
+	if err != nil {
+		return fmt.Errorf("failed to upload export: %w", err)
+	}
+
+	record.FileName = result.FileName
+	record.ContentType = result.ContentType
+	record.RecordCount = result.RecordCount
+	record.FileSizeBytes = int64(len(result.Data))
+	record.Anonymized = result.Anonymized
+	record.S3Key = s3Key
+	record.Status = ExportStatusCompleted
+	record.ErrorMessage = ""
+	if err := s.repos.ExportRecord().Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to update export %d: %w", record.ID, err)
+	}
+	_ = s.IncrementUsage(ctx, UsageMetricExports, 1)
+	return nil
+}
+
+// failExport はエクスポート履歴を failed にします（記録はベストエフォート）。
+func (s *Service) failExport(ctx context.Context, record *model.ExportRecord, cause error) {
+	record.Status = ExportStatusFailed
+	record.ErrorMessage = truncateString(cause.Error(), 500)
+	if err := s.repos.ExportRecord().Update(ctx, record); err != nil {
+		logging.FromContext(ctx).Warn("Failed to mark export as failed", "export_id", record.ID, "error", err)
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Async Export Tests - ジョブキューによるエクスポート生成のテスト
+// =============================================================================
+// テスト対象:
+//   - RequestExport: pending のエクスポート履歴の作成とジョブの登録
+//   - ProcessExportJob: エクスポートの生成・保存、失敗時の状態
+
+// mockJobQueue はテスト用のジョブキューです（登録したジョブを保持する）。
+type mockJobQueue struct {
+	jobs []ExportJobPayload
+	err  error
+}
+
+func (q *mockJobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.jobs = append(q.jobs, payload.(ExportJobPayload))
+	return nil
+}
+
+// mockExportStorage はテスト用のエクスポートの保存先です。
+type mockExportStorage struct {
+	uploads map[string][]byte
+	err     error
+}
+
+func (s *mockExportStorage) IsConfigured() bool { return true }
+
+func (s *mockExportStorage) UploadExport(ctx context.Context, userID uint, fileName, contentType string, data []byte) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	key := "exports/" + fileName
+	s.uploads[key] = data
+	return key, nil
+}
+
+func (s *mockExportStorage) DeleteExport(ctx context.Context, objectKey string) error {
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.uploads, objectKey)
+	return nil
+}
+
+// TestRequestExport_ProcessJob は非同期エクスポートのテストです。
+// 期待動作:
+//   - RequestExport は pending のエクスポート履歴を作成してジョブを登録する
+//   - ProcessExportJob はファイルを保存してエクスポート履歴を completed にする
+//   - 再配信された同じジョブは再生成しない
+func TestRequestExport_ProcessJob(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	jobQueue := &mockJobQueue{}
+	storage := &mockExportStorage{uploads: make(map[string][]byte)}
+	svc.SetJobQueue(jobQueue)
+	svc.SetExportStorage(storage)
+	ctx := context.Background()
+	userID := uint(1)
+	_ = svc.CreateCrop(ctx, &model.Crop{
+		UserID:              userID,
+		Name:                "トマト",
+		PlantedDate:         time.Now(),
+		ExpectedHarvestDate: time.Now().AddDate(0, 3, 0),
+		Status:              "planted",
+	})
+
+	// Act
+	record, err := svc.RequestExport(ctx, userID, ExportDataTypeCrops, ExportOptions{})
+	if err != nil {
+		t.Fatalf("RequestExport failed: %v", err)
+	}
+	if record.Status != ExportStatusPending || len(jobQueue.jobs) != 1 {
+		t.Fatalf("Expected pending export with 1 job, got status=%s jobs=%d", record.Status, len(jobQueue.jobs))
+	}
+	processErr := svc.ProcessExportJob(ctx, jobQueue.jobs[0], false)
+	redeliveredErr := svc.ProcessExportJob(ctx, jobQueue.jobs[0], false)
+
+	// Assert
+	if processErr != nil || redeliveredErr != nil {
+		t.Fatalf("ProcessExportJob failed: %v / %v", processErr, redeliveredErr)
+	}
+	stored, _ := svc.GetExportRecord(ctx, userID, record.ID)
+	if stored.Status != ExportStatusCompleted || !stored.IsDownloadable() || stored.RecordCount != 1 {
+		t.Errorf("Expected completed downloadable export with 1 record, got %+v", stored)
+	}
+	if len(storage.uploads) != 1 {
+		t.Errorf("Expected 1 upload, got %d", len(storage.uploads))
+	}
+}
+
+// TestRequestExport_Failures は非同期エクスポートの失敗のテストです。
+// 期待動作:
+//   - ジョブキュー・保存先が未設定の場合はエラーを返し、エクスポート履歴を作成しない
+//   - ジョブを登録できない場合はエクスポート履歴を failed にする
+//   - 最後の試行で生成に失敗した場合のみエクスポート履歴を failed にする
+func TestRequestExport_Failures(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	exportRepo := mockRepos.GetMockExportRecordRepository()
+
+	// Act & Assert: 未設定
+	if _, err := svc.RequestExport(ctx, 1, ExportDataTypeCrops, ExportOptions{}); !errors.Is(err, ErrJobQueueUnavailable) {
+		t.Errorf("Expected ErrJobQueueUnavailable, got %v", err)
+	}
+	jobQueue := &mockJobQueue{}
+	svc.SetJobQueue(jobQueue)
+	if _, err := svc.RequestExport(ctx, 1, ExportDataTypeCrops, ExportOptions{}); !errors.Is(err, ErrExportStorageNotConfigured) {
+		t.Errorf("Expected ErrExportStorageNotConfigured, got %v", err)
+	}
+	if len(exportRepo.Records) != 0 {
+		t.Errorf("Expected no export records, got %d", len(exportRepo.Records))
+	}
+
+	// Act & Assert: 登録できない
+	storage := &mockExportStorage{uploads: make(map[string][]byte)}
+	svc.SetExportStorage(storage)
+	jobQueue.err = errors.New("job queue is full")
+	if _, err := svc.RequestExport(ctx, 1, ExportDataTypeCrops, ExportOptions{}); !errors.Is(err, ErrJobQueueUnavailable) {
+		t.Errorf("Expected ErrJobQueueUnavailable, got %v", err)
+	}
+	if exportRepo.Records[1].Status != ExportStatusFailed {
+		t.Errorf("Expected export to be failed, got %s", exportRepo.Records[1].Status)
+	}
+
+	// Act & Assert: 生成の失敗
+	jobQueue.err = nil
+	record, err := svc.RequestExport(ctx, 1, ExportDataTypeCrops, ExportOptions{})
+	if err != nil {
+		t.Fatalf("RequestExport failed: %v", err)
+	}
+	storage.err = errors.New("upload failed")
+	if err := svc.ProcessExportJob(ctx, jobQueue.jobs[0], false); err == nil {
+		t.Error("Expected upload error")
+	}
+	if record.Status != ExportStatusPending {
+		t.Errorf("Expected export to stay pending before the last attempt, got %s", record.Status)
+	}
+	_ = svc.ProcessExportJob(ctx, jobQueue.jobs[0], true)
+	if record.Status != ExportStatusFailed || record.ErrorMessage == "" {
+		t.Errorf("Expected export to be failed after the last attempt, got %+v", record)
+	}
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Photo Export - 全データのエクスポートの写真の画像
+// =============================================================================
+// 全データ（all）の非同期エクスポートで写真を含める場合（POST /exports の include_photos）、CSV に加えて
+// 加工済みの写真の画像（large のバリアント、ない場合は medium・thumbnail）を保存先から取得して ZIP の photos/ に含め、
+// 写真の一覧を photos.csv に書き出します。
+// 写真は ZIP の1ファイルの上限（SetExportPartSize）ごとに複数の ZIP に分け、2番目以降の ZIP は
+// 最初のエクスポート履歴を ParentID とするエクスポート履歴として保存します（再ダウンロード・保持期間・保存容量は通常のエクスポートと同じ）。
+// 分け方は写真の SizeBytes（全バリアントの合計で、ZIP に含めるバリアントより大きい）で決めるため、
+// 見積もり（EstimateExport）と生成した ZIP の数は一致し、各 ZIP の写真の合計は上限を超えません（上限を超える1枚の写真は1つの ZIP）。
+// ZIP は1つずつメモリで作成して保存するため、ワーカーのメモリの使用量は上限程度です。
+
+// DefaultExportPartSizeBytes は写真を含むエクスポートの ZIP の1ファイルの上限のデフォルトです（100MiB）
+const DefaultExportPartSizeBytes int64 = 100 << 20
+
+var (
+	// ErrExportPhotosRequireAll は全データ（all）以外のエクスポートで写真を含めようとした場合のエラー
+	ErrExportPhotosRequireAll = errors.New("photos can only be included in the all data export")
+)
+
+// ExportEstimate はエクスポートの大きさの見積もりです。
+type ExportEstimate struct {
+	DataType      ExportDataType `json:"data_type"`
+	IncludePhotos bool           `json:"include_photos"`
+	PhotoCount    int            `json:"photo_count"`     // 含める写真の枚数
+	PhotoBytes    int64          `json:"photo_bytes"`     // 含める写真の保存容量の合計（ZIP の写真の大きさの上限の目安）
+	PartCount     int            `json:"part_count"`      // 生成する ZIP の数
+	PartSizeBytes int64          `json:"part_size_bytes"` // ZIP の1ファイルの写真の合計の上限
+}
+
+// SetExportPartSize は写真を含むエクスポートの ZIP の1ファイルの写真の合計の上限（バイト）を設定します。
+// 未設定・0 以下の場合は DefaultExportPartSizeBytes です。
+func (s *Service) SetExportPartSize(partSizeBytes int64) {
+	s.exportPartSize = partSizeBytes
+}
+
+// exportPartSizeBytes は ZIP の1ファイルの写真の合計の上限を返します。
+func (s *Service) exportPartSizeBytes() int64 {
+	if s.exportPartSize <= 0 {
+		return DefaultExportPartSizeBytes
+	}
+	return s.exportPartSize
+}
+
+// EstimateExport はエクスポートに含める写真の枚数・容量と ZIP の数を見積もります。
+// CSV の大きさは含めません（写真と比べて小さいため）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - dataType: エクスポートするデータ種類
+//   - opts: エクスポートのオプション
+//
+// 戻り値:
+//   - *ExportEstimate: 見積もり（写真を含めない場合は ZIP の数は 1）
+//   - error: all 以外で写真を含める場合は ErrExportPhotosRequireAll、写真の取得に失敗した場合のエラー
+func (s *Service) EstimateExport(ctx context.Context, userID uint, dataType ExportDataType, opts ExportOptions) (*ExportEstimate, error) {
+	if opts.IncludePhotos && dataType != ExportDataTypeAll {
+		return nil, ErrExportPhotosRequireAll
+	}
+
+	estimate := &ExportEstimate{
+		DataType:      dataType,
+		IncludePhotos: opts.IncludePhotos,
+		PartCount:     1,
+		PartSizeBytes: s.exportPartSizeBytes(),
+	}
+	if !opts.IncludePhotos {
+		return estimate, nil
+	}
+
+	photos, err := s.exportPhotos(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, photo := range photos {
+		estimate.PhotoCount++
+		estimate.PhotoBytes += photo.SizeBytes
+	}
+	estimate.PartCount = len(planExportPhotoParts(photos, estimate.PartSizeBytes))
+	return estimate, nil
+}
+
+// exportPhotos はエクスポートに含めるユーザーの写真（加工済みでバリアントがある写真）を古い順に返します。
+func (s *Service) exportPhotos(ctx context.Context, userID uint) ([]model.Photo, error) {
+	photos, err := s.repos.Photo().GetCompletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	result := photos[:0]
+	for _, photo := range photos {
+		if exportPhotoKey(&photo) != "" {
+			result = append(result, photo)
+		}
+	}
+	return result, nil
+}
+
+// planExportPhotoParts は写真の SizeBytes の合計が partSize を超えないように写真を ZIP ごとに分けます（写真がない場合も ZIP は1つ）。
+func planExportPhotoParts(photos []model.Photo, partSize int64) [][]model.Photo {
+	parts := [][]model.Photo{nil}
+	var size int64
+	for _, photo := range photos {
+		last := len(parts) - 1
+		if len(parts[last]) > 0 && size+photo.SizeBytes > partSize {
+			parts = append(parts, nil)
+			last++
+			size = 0
+		}
+		parts[last] = append(parts[last], photo)
+		size += photo.SizeBytes
+	}
+	return parts
+}
+
+// exportPhotoKey は ZIP に含める写真のバリアントのオブジェクトキーを返します（大きいバリアントから）。
+func exportPhotoKey(photo *model.Photo) string {
+	for _, key := range []string{photo.LargeKey, photo.MediumKey, photo.ThumbnailKey} {
+		if key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// exportPhotoFileName は ZIP の写真のファイル名を返します（photos/{ID}.webp、匿名化した場合は仮ID）。
+func exportPhotoFileName(photo *model.Photo, anon *exportAnonymizer) string {
+	return "photos/" + anon.id("photo", photo.ID) + path.Ext(exportPhotoKey(photo))
+}
+
+// generatePhotoExport は写真を含む全データのエクスポートを ZIP ごとに生成・保存し、エクスポート履歴を completed にします。
+// 最初の ZIP はエクスポート履歴に、2番目以降の ZIP は ParentID を付けたエクスポート履歴に記録します。
+// 途中で失敗した場合は保存済みの ZIP を削除します（ジョブキューが再試行する）。
+func (s *Service) generatePhotoExport(ctx context.Context, record *model.ExportRecord, payload ExportJobPayload) error {
+	if s.photoStorage == nil {
+		return ErrPhotoStorageNotConfigured
+	}
+
+	// エクスポートの読み取りは読み取りレプリカ（エクスポート履歴の更新はプライマリ）
+	readCtx := repository.ContextWithReadReplica(ctx)
+	var anon *exportAnonymizer
+	if payload.Anonymize {
+		var err error
+		if anon, err = s.newExportAnonymizer(readCtx, payload.UserID); err != nil {
+			return err
+		}
+	}
+	files, recordCount, err := s.exportAllFiles(readCtx, payload.UserID, anon)
+	if err != nil {
+		return fmt.Errorf("failed to generate export: %w", err)
+	}
+	photos, err := s.exportPhotos(readCtx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	parts := planExportPhotoParts(photos, s.exportPartSizeBytes())
+	manifest, err := exportPhotosManifest(parts, anon)
+	if err != nil {
+		return err
+	}
+	files = append(files, exportZipFile{"photos.csv", manifest})
+
+	baseName := fmt.Sprintf("export_all_%s", s.now().Format("20060102_150405"))
+	stored := make([]model.ExportRecord, 0, len(parts))
+	for i, partPhotos := range parts {
+		var partFiles []exportZipFile
+		count := len(partPhotos)
+		if i == 0 {
+			partFiles = files
+			count += recordCount
+		}
+		data, err := s.exportPhotoPart(ctx, partFiles, partPhotos, anon)
+		if err != nil {
+			s.deleteExportParts(ctx, stored)
+			return err
+		}
+
+		fileName := baseName + ".zip"
+		if len(parts) > 1 {
+			fileName = fmt.Sprintf("%s_part%dof%d.zip", baseName, i+1, len(parts))
+		}
+		if anon != nil {
+			fileName = anonymizedFileName(fileName)
+		}
+		s3Key, err := s.exportStorage.UploadExport(ctx, payload.UserID, fileName, "application/zip", data)
+		if err != nil {
+			s.deleteExportParts(ctx, stored)
+			return fmt.Errorf("failed to upload export part %d: %w", i+1, err)
+		}
+		stored = append(stored, model.ExportRecord{
+			UserID:        payload.UserID,
+			DataType:      string(ExportDataTypeAll),
+			FileName:      fileName,
+			ContentType:   "application/zip",
+			RecordCount:   count,
+			FileSizeBytes: int64(len(data)),
+			Anonymized:    anon != nil,
+			S3Key:         s3Key,
+			Status:        ExportStatusCompleted,
+			IncludePhotos: true,
+			PartNumber:    i + 1,
+			PartCount:     len(parts),
+		})
+	}
+
+	for i := 1; i < len(stored); i++ {
+		stored[i].ParentID = &record.ID
+		if err := s.repos.ExportRecord().Create(ctx, &stored[i]); err != nil {
+			s.deleteExportParts(ctx, stored[i:])
+			return fmt.Errorf("failed to record export part %d: %w", i+1, err)
+		}
+	}
+
+	first := stored[0]
+	record.FileName = first.FileName
+	record.ContentType = first.ContentType
+	record.RecordCount = first.RecordCount
+	record.FileSizeBytes = first.FileSizeBytes
+	record.Anonymized = first.Anonymized
+	record.S3Key = first.S3Key
+	record.IncludePhotos = true
+	record.PartNumber = 1
+	record.PartCount = len(stored)
+	record.Status = ExportStatusCompleted
+	record.ErrorMessage = ""
+	if err := s.repos.ExportRecord().Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to update export %d: %w", record.ID, err)
+	}
+	_ = s.IncrementUsage(ctx, UsageMetricExports, 1)
+	return nil
+}
+
+// exportPhotoPart は CSV 等のファイルと写真の画像の ZIP を作成します（画像は圧縮済みのため無圧縮で格納する）。
+func (s *Service) exportPhotoPart(ctx context.Context, files []exportZipFile, photos []model.Photo, anon *exportAnonymizer) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	if err := writeExportZipFiles(zipWriter, files); err != nil {
+		return nil, err
+	}
+
+	for i := range photos {
+		photo := &photos[i]
+		w, err := zipWriter.CreateHeader(&zip.FileHeader{Name: exportPhotoFileName(photo, anon), Method: zip.Store})
+		if err != nil {
+			return nil, err
+		}
+		body, err := s.photoStorage.DownloadPhoto(ctx, exportPhotoKey(photo))
+		if err != nil {
+			return nil, fmt.Errorf("failed to download photo %d: %w", photo.ID, err)
+		}
+		_, err = io.Copy(w, body)
+		body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to download photo %d: %w", photo.ID, err)
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportPhotosManifest は写真の一覧（photos.csv、写真を含む ZIP の番号付き）を作成します。
+func exportPhotosManifest(parts [][]model.Photo, anon *exportAnonymizer) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	// BOM for Excel compatibility
+	buf.WriteString("\xEF\xBB\xBF")
+
+	// ヘッダー行
+	if err := writer.Write([]string{"ID", "作物ID", "収穫記録ID", "幅", "高さ", "ファイル", "ZIP", "作成日"}); err != nil {
+		return nil, err
+	}
+
+	// データ行
+	for i, photos := range parts {
+		for j := range photos {
+			photo := &photos[j]
+			var cropID, harvestID string
+			if photo.CropID != nil {
+				cropID = anon.id("crop", *photo.CropID)
+			}
+			if photo.HarvestID != nil {
+				harvestID = anon.id("harvest", *photo.HarvestID)
+			}
+			row := []string{
+				anon.id("photo", photo.ID),
+				cropID,
+				harvestID,
+				fmt.Sprintf("%d", photo.Width),
+				fmt.Sprintf("%d", photo.Height),
+				exportPhotoFileName(photo, anon),
+				fmt.Sprintf("%d", i+1),
+				anon.timestamp(photo.CreatedAt),
+			}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// deleteExportParts は保存済みの ZIP を削除します（削除はベストエフォート）。
+func (s *Service) deleteExportParts(ctx context.Context, parts []model.ExportRecord) {
+	for _, part := range parts {
+		if err := s.exportStorage.DeleteExport(ctx, part.S3Key); err != nil {
+			logging.FromContext(ctx).Warn("Failed to delete export part", "key", part.S3Key, "error", err)
+		}
+	}
+}
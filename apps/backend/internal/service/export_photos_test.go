@@ -0,0 +1,152 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Photo Export Tests - 全データのエクスポートの写真の画像のテスト
+// =============================================================================
+// テスト対象:
+//   - EstimateExport: 写真の枚数・容量と ZIP の数の見積もり
+//   - RequestExport / ProcessExportJob: 写真を含む全データのエクスポートの ZIP の分割と履歴の記録
+//   - planExportPhotoParts: ZIP ごとの写真の合計の上限
+
+// zipEntries はテスト用に ZIP のファイル名と内容を返します。
+func zipEntries(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader failed: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, file := range reader.File {
+		body, _ := file.Open()
+		content, _ := io.ReadAll(body)
+		body.Close()
+		entries[file.Name] = string(content)
+	}
+	return entries
+}
+
+// TestPhotoExport は写真を含む全データのエクスポートのテストです。
+// 期待動作:
+//   - 見積もりは加工済みの写真の枚数・容量と、写真の合計が上限を超えないように分けた ZIP の数
+//   - 最初の ZIP は CSV と photos.csv と写真、2番目以降の ZIP は写真のみで、ParentID を付けたエクスポート履歴に記録する
+//   - ZIP の写真は large のバリアント（ない場合は medium）で、加工中・他ユーザーの写真は含めない
+//   - all 以外で写真を含める場合は ErrExportPhotosRequireAll、保存容量の上限を超える場合は ErrStorageQuotaExceeded
+func TestPhotoExport(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	jobQueue := &mockJobQueue{}
+	exports := &mockExportStorage{uploads: make(map[string][]byte)}
+	photoStorage := &mockPhotoStorage{objects: map[string][]byte{
+		"photos/1/1/large.webp":  []byte("large-1"),
+		"photos/1/2/large.webp":  []byte("large-2"),
+		"photos/1/3/medium.webp": []byte("medium-3"),
+	}}
+	svc.SetJobQueue(jobQueue)
+	svc.SetExportStorage(exports)
+	svc.SetPhotoStorage(photoStorage)
+	svc.SetExportPartSize(100)
+	ctx := context.Background()
+
+	photos := mockRepos.Photo()
+	_ = photos.Create(ctx, &model.Photo{UserID: 1, Status: PhotoStatusCompleted, LargeKey: "photos/1/1/large.webp", SizeBytes: 60})
+	_ = photos.Create(ctx, &model.Photo{UserID: 1, Status: PhotoStatusCompleted, LargeKey: "photos/1/2/large.webp", SizeBytes: 30})
+	_ = photos.Create(ctx, &model.Photo{UserID: 1, Status: PhotoStatusCompleted, MediumKey: "photos/1/3/medium.webp", SizeBytes: 50})
+	_ = photos.Create(ctx, &model.Photo{UserID: 1, Status: PhotoStatusPending, SourceKey: "uploads/1/2026/05/pending.jpg", SizeBytes: 500})
+	_ = photos.Create(ctx, &model.Photo{UserID: 2, Status: PhotoStatusCompleted, LargeKey: "photos/2/5/large.webp", SizeBytes: 10})
+
+	// Act
+	estimate, estimateErr := svc.EstimateExport(ctx, 1, ExportDataTypeAll, ExportOptions{IncludePhotos: true})
+	_, cropsErr := svc.RequestExport(ctx, 1, ExportDataTypeCrops, ExportOptions{IncludePhotos: true})
+	svc.SetStorageQuota(200)
+	_, quotaErr := svc.RequestExport(ctx, 1, ExportDataTypeAll, ExportOptions{IncludePhotos: true})
+	svc.SetStorageQuota(0)
+	record, requestErr := svc.RequestExport(ctx, 1, ExportDataTypeAll, ExportOptions{IncludePhotos: true})
+	if requestErr != nil {
+		t.Fatalf("RequestExport failed: %v", requestErr)
+	}
+	processErr := svc.ProcessExportJob(ctx, jobQueue.jobs[0], false)
+
+	// Assert
+	if estimateErr != nil || estimate.PhotoCount != 3 || estimate.PhotoBytes != 140 || estimate.PartCount != 2 {
+		t.Errorf("Expected 3 photos of 140 bytes in 2 parts, got %+v (%v)", estimate, estimateErr)
+	}
+	if !errors.Is(cropsErr, ErrExportPhotosRequireAll) {
+		t.Errorf("Expected ErrExportPhotosRequireAll, got %v", cropsErr)
+	}
+	if !errors.Is(quotaErr, ErrStorageQuotaExceeded) {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", quotaErr)
+	}
+	if processErr != nil {
+		t.Fatalf("ProcessExportJob failed: %v", processErr)
+	}
+
+	records, _ := svc.GetUserExports(ctx, 1, 0)
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	if len(records) != 2 {
+		t.Fatalf("Expected the export and 1 additional part, got %d records", len(records))
+	}
+	first, second := records[0], records[1]
+	if first.ID != record.ID || first.Status != ExportStatusCompleted || first.PartNumber != 1 || first.PartCount != 2 || !first.IncludePhotos {
+		t.Errorf("Expected the first part on the requested export, got %+v", first)
+	}
+	if second.ParentID == nil || *second.ParentID != record.ID || second.PartNumber != 2 || second.PartCount != 2 || !second.IsDownloadable() {
+		t.Errorf("Expected the second part linked to the export, got %+v", second)
+	}
+	if !strings.HasSuffix(first.FileName, "_part1of2.zip") || !strings.HasSuffix(second.FileName, "_part2of2.zip") {
+		t.Errorf("Expected part file names, got %s / %s", first.FileName, second.FileName)
+	}
+
+	firstEntries := zipEntries(t, exports.uploads[first.S3Key])
+	secondEntries := zipEntries(t, exports.uploads[second.S3Key])
+	if firstEntries["photos/1.webp"] != "large-1" || firstEntries["photos/2.webp"] != "large-2" || firstEntries["crops.csv"] == "" {
+		t.Errorf("Expected the CSVs and photos 1-2 in the first part, got %v", firstEntries)
+	}
+	if len(secondEntries) != 1 || secondEntries["photos/3.webp"] != "medium-3" {
+		t.Errorf("Expected only photo 3 in the second part, got %v", secondEntries)
+	}
+	manifest := firstEntries["photos.csv"]
+	if !strings.Contains(manifest, "photos/3.webp,2,") || strings.Contains(manifest, "photos/4") || strings.Contains(manifest, "photos/5") {
+		t.Errorf("Expected the manifest of the exported photos with part numbers, got %q", manifest)
+	}
+}
+
+// TestPlanExportPhotoParts は ZIP ごとの写真の分け方のテストです。
+// 期待動作:
+//   - 写真の合計が上限を超えない範囲で順に詰める
+//   - 上限を超える1枚の写真は1つの ZIP にする
+//   - 写真がない場合も ZIP は1つ
+func TestPlanExportPhotoParts(t *testing.T) {
+	// Arrange
+	photos := []model.Photo{{SizeBytes: 40}, {SizeBytes: 60}, {SizeBytes: 150}, {SizeBytes: 10}}
+
+	// Act
+	parts := planExportPhotoParts(photos, 100)
+	empty := planExportPhotoParts(nil, 100)
+
+	// Assert
+	sizes := make([]int, 0, len(parts))
+	for _, part := range parts {
+		sizes = append(sizes, len(part))
+	}
+	if len(parts) != 3 || sizes[0] != 2 || sizes[1] != 1 || sizes[2] != 1 {
+		t.Errorf("Expected parts of 2, 1 and 1 photos, got %v", sizes)
+	}
+	if len(empty) != 1 || len(empty[0]) != 0 {
+		t.Errorf("Expected 1 empty part, got %v", empty)
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Export History - エクスポート履歴
+// =============================================================================
+
+var (
+	// ErrExportNotOwned は他ユーザーのエクスポート履歴にアクセスしようとした場合のエラー
+	ErrExportNotOwned = errors.New("export does not belong to user")
+)
+
+const (
+	// DefaultExportHistoryLimit はエクスポート履歴一覧のデフォルト取得件数
+	DefaultExportHistoryLimit = 50
+)
+
+// RecordExport は生成したエクスポートのメタデータを保存します。
+// s3Keyが空の場合はS3未保存として記録され、再ダウンロードはできません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - result: ExportCSVの結果
+//   - s3Key: 保存先S3オブジェクトキー（未保存の場合は空文字）
+//
+// 戻り値:
+//   - *model.ExportRecord: 保存されたエクスポート履歴
+//   - error: 保存に失敗した場合のエラー
+func (s *Service) RecordExport(ctx context.Context, userID uint, result *CSVExportResult, s3Key string) (*model.ExportRecord, error) {
+	record := &model.ExportRecord{
+		UserID:        userID,
+		DataType:      string(result.DataType),
+		FileName:      result.FileName,
+		ContentType:   result.ContentType,
+		RecordCount:   result.RecordCount,
+		FileSizeBytes: int64(len(result.Data)),
+		Anonymized:    result.Anonymized,
+		S3Key:         s3Key,
+		Status:        ExportStatusCompleted,
+	}
+	if err := s.repos.ExportRecord().Create(ctx, record); err != nil {
+		return nil, err
+	}
+	_ = s.IncrementUsage(ctx, UsageMetricExports, 1)
+	return record, nil
+}
+
+// GetUserExports はユーザーのエクスポート履歴を新しい順に取得します。
+func (s *Service) GetUserExports(ctx context.Context, userID uint, limit int) ([]model.ExportRecord, error) {
+	if limit <= 0 {
+		limit = DefaultExportHistoryLimit
+	}
+	return s.repos.ExportRecord().GetByUserID(ctx, userID, limit)
+}
+
+// GetExportRecord はユーザーが所有するエクスポート履歴を取得します。
+//
+// 戻り値:
+//   - *model.ExportRecord: エクスポート履歴
+//   - error: 存在しない場合はリポジトリのエラー、他ユーザーの場合は ErrExportNotOwned
+func (s *Service) GetExportRecord(ctx context.Context, userID, id uint) (*model.ExportRecord, error) {
+	record, err := s.repos.ExportRecord().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.UserID != userID {
+		return nil, ErrExportNotOwned
+	}
+	return record, nil
+}
+
+// =============================================================================
+// List CSV - 一覧のCSV
+// =============================================================================
+// 一覧のエンドポイント（GET /tasks, /crops, /crops/:id/harvests）は Accept: text/csv の場合に、
+// 一覧の絞り込み・ページングの結果をエクスポートと同じ列のCSVで返します（エクスポート履歴には記録しない）。
+
+// TasksCSV はタスクの一覧をエクスポートと同じ形式のCSVにします。
+func (s *Service) TasksCSV(tasks []model.Task) (*CSVExportResult, error) {
+	return s.tasksCSV(tasks, nil)
+}
+
+// CropsCSV は作物の一覧をエクスポートと同じ形式のCSVにします。
+func (s *Service) CropsCSV(crops []model.Crop) (*CSVExportResult, error) {
+	return s.cropsCSV(crops, nil)
+}
+
+// HarvestsCSV は収穫記録の一覧をエクスポートと同じ形式のCSVにします（作物名は作物から取得）。
+func (s *Service) HarvestsCSV(ctx context.Context, harvests []model.Harvest) (*CSVExportResult, error) {
+	return s.harvestsCSV(ctx, harvests, nil)
+}
+
+// =============================================================================
+// Export Anonymization - エクスポートの匿名化
+// =============================================================================
+
+// ExportOptions はエクスポート生成のオプションです。
+//
+// Anonymize が true の場合:
+//   - メモ・説明などの自由記述欄を空にする
+//   - 作物名・タスク名などに含まれるメールアドレス、表示名、菜園の所在地を伏せ字にする
+//   - レコードIDを連番の仮IDに置き換える（作物IDは収穫データと対応を維持）
+//   - 作成日時・完了日時を日付のみに丸める
+//
+// IncludePhotos は全データ（all）の非同期エクスポート（RequestExport）で写真の画像を含めます（export_photos.go）。
+type ExportOptions struct {
+	Anonymize     bool
+	IncludePhotos bool
+}
+
+// anonymizedPlaceholder は伏せ字に置き換える文字列
+const anonymizedPlaceholder = "[REDACTED]"
+
+// emailPattern は自由記述に含まれるメールアドレスを検出します
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// exportAnonymizer はエクスポート行の匿名化を行います。
+// nilレシーバーの場合は値をそのまま返すため、匿名化なしのエクスポートでも同じ呼び出しで済みます。
+type exportAnonymizer struct {
+	terms []string                // 伏せ字対象の文字列（長い順）
+	ids   map[string]map[uint]int // 種類ごとの実ID→仮ID
+}
+
+// newExportAnonymizer はユーザーの個人情報から匿名化器を作成します。
+func (s *Service) newExportAnonymizer(ctx context.Context, userID uint) (*exportAnonymizer, error) {
+	var terms []string
+	if user, err := s.repos.User().GetByID(ctx, userID); err == nil {
+		terms = append(terms, user.Email, user.DisplayName)
+		if local, _, ok := strings.Cut(user.Email, "@"); ok {
+			terms = append(terms, local)
+		}
+	}
+
+	gardens, err := s.repos.Garden().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, garden := range gardens {
+		terms = append(terms, garden.Location)
+	}
+
+	// 短すぎる語は誤検出が多いため除外し、長い語から置換する
+	filtered := terms[:0]
+	for _, term := range terms {
+		if len([]rune(strings.TrimSpace(term))) >= 2 {
+			filtered = append(filtered, strings.TrimSpace(term))
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return len(filtered[i]) > len(filtered[j]) })
+
+	return &exportAnonymizer{
+		terms: filtered,
+		ids:   make(map[string]map[uint]int),
+	}, nil
+}
+
+// id はレコードIDを文字列化します。匿名化時は種類ごとの連番に置き換えます。
+func (a *exportAnonymizer) id(kind string, id uint) string {
+	if a == nil {
+		return fmt.Sprintf("%d", id)
+	}
+	m, ok := a.ids[kind]
+	if !ok {
+		m = make(map[uint]int)
+		a.ids[kind] = m
+	}
+	pseudo, ok := m[id]
+	if !ok {
+		pseudo = len(m) + 1
+		m[id] = pseudo
+	}
+	return fmt.Sprintf("%d", pseudo)
+}
+
+// text は短いテキスト（作物名、タスク名等）から個人情報を伏せ字にします。
+func (a *exportAnonymizer) text(value string) string {
+	if a == nil || value == "" {
+		return value
+	}
+	value = emailPattern.ReplaceAllString(value, anonymizedPlaceholder)
+	for _, term := range a.terms {
+		value = replaceFold(value, term, anonymizedPlaceholder)
+	}
+	return value
+}
+
+// freeText は自由記述欄を返します。匿名化時は個人情報を含みやすいため空にします。
+func (a *exportAnonymizer) freeText(value string) string {
+	if a == nil {
+		return value
+	}
+	return ""
+}
+
+// timestamp は日時を文字列化します。匿名化時は日付のみに丸めます。
+func (a *exportAnonymizer) timestamp(t time.Time) string {
+	if a == nil {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return t.Format("2006-01-02")
+}
+
+// nullableTimestamp はnil許容の日時を文字列化します。
+func (a *exportAnonymizer) nullableTimestamp(t *time.Time) string {
+	if a == nil {
+		return formatNullableTime(t)
+	}
+	return formatNullableDate(t)
+}
+
+// replaceFold は大文字小文字を区別せずに文字列を置換します。
+func replaceFold(value, old, replacement string) string {
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(old))
+	return pattern.ReplaceAllLiteralString(value, replacement)
+}
+
+// anonymizedFileName はファイル名に匿名化済みの接尾辞を付与します（crops_xxx.csv → crops_xxx_anonymized.csv）。
+func anonymizedFileName(fileName string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + "_anonymized" + ext
+}
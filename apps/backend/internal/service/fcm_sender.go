@@ -0,0 +1,419 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// FCM Sender - FCM HTTP v1 API による通知送信
+// =============================================================================
+// AWS SNS Platform Application を使わずに、FCM HTTP v1 API へ直接プッシュ通知を送信します。
+// 認証はサービスアカウントの秘密鍵で署名したJWTをOAuth2アクセストークンに交換して行います。
+// メール通知は従来どおりSESで送信します。
+
+const (
+	// fcmEndpoint はFCM HTTP v1 APIのベースURL
+	fcmEndpoint = "https://fcm.googleapis.com"
+	// fcmScope はFCM送信に必要なOAuth2スコープ
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+	// fcmDefaultTokenURI はサービスアカウントにtoken_uriがない場合のトークンエンドポイント
+	fcmDefaultTokenURI = "https://oauth2.googleapis.com/token"
+	// fcmTokenLifetime はJWTアサーションの有効期間（Googleの上限は1時間）
+	fcmTokenLifetime = time.Hour
+	// fcmTokenRefreshMargin はアクセストークンを期限前に更新する余裕
+	fcmTokenRefreshMargin = time.Minute
+	// fcmRequestTimeout はFCM/トークンエンドポイントへのHTTPタイムアウト
+	fcmRequestTimeout = 10 * time.Second
+)
+
+// fcmServiceAccount はGoogleサービスアカウントJSONのうちFCM送信に必要な項目です。
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	PrivateKey  string `json:"private_key"`
+	ClientEmail string `json:"client_email"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMSender はFCM HTTP v1 APIでプッシュ通知を送信するNotificationSenderの実装です。
+type FCMSender struct {
+	mailer     *notificationSender // メール通知（SES）、Webhook・SMS通知とリトライ処理
+	httpClient *http.Client
+	endpoint   string
+	projectID  string
+
+	clientEmail string
+	tokenURI    string
+	privateKey  *rsa.PrivateKey
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+
+	clock clock.Clock // アクセストークンの期限の判定（nilの場合はシステムの時刻）
+}
+
+// NewFCMSender は新しいFCMSenderを作成します。
+// サービスアカウントは FCMServiceAccountJSON、なければ FCMServiceAccountFile から読み込みます。
+//
+// 引数:
+//   - cfg: 通知設定（FCM・SES設定を含む）
+//
+// 戻り値:
+//   - *FCMSender: FCM通知送信者
+//   - error: サービスアカウントの読み込みやAWS設定のロードに失敗した場合のエラー
+func NewFCMSender(cfg *config.NotificationConfig) (*FCMSender, error) {
+	raw := []byte(cfg.FCMServiceAccountJSON)
+	if len(raw) == 0 {
+		if cfg.FCMServiceAccountFile == "" {
+			return nil, fmt.Errorf("FCM service account not configured")
+		}
+		data, err := os.ReadFile(cfg.FCMServiceAccountFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read FCM service account: %w", err)
+		}
+		raw = data
+	}
+
+	var account fcmServiceAccount
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM service account: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, fmt.Errorf("FCM service account is missing client_email or private_key")
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM private key: %w", err)
+	}
+
+	projectID := cfg.FCMProjectID
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("FCM project ID not configured")
+	}
+
+	tokenURI := account.TokenURI
+	if tokenURI == "" {
+		tokenURI = fcmDefaultTokenURI
+	}
+
+	// FCMではSNSを使わないため、エンドポイントARNはキャッシュしない
+	mailer, err := newSNSNotificationSender(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FCMSender{
+		mailer:      mailer,
+		httpClient:  &http.Client{Timeout: fcmRequestTimeout},
+		endpoint:    fcmEndpoint,
+		projectID:   projectID,
+		clientEmail: account.ClientEmail,
+		tokenURI:    tokenURI,
+		privateKey:  privateKey,
+	}, nil
+}
+
+// fcmSendRequest はFCM HTTP v1 messages:send のリクエストボディです。
+type fcmSendRequest struct {
+	Message fcmV1Message `json:"message"`
+}
+
+// fcmV1Message はFCM HTTP v1のメッセージです。
+type fcmV1Message struct {
+	Token        string            `json:"token"`
+	Notification *FCMNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroidConfig `json:"android,omitempty"`
+	APNS         *fcmAPNSConfig    `json:"apns,omitempty"`
+}
+
+// fcmAndroidConfig はAndroid向けの配信設定です。
+type fcmAndroidConfig struct {
+	Priority     string                  `json:"priority,omitempty"`
+	Notification *fcmAndroidNotification `json:"notification,omitempty"`
+}
+
+// fcmAndroidNotification はAndroid向けの通知設定です。
+type fcmAndroidNotification struct {
+	ClickAction string `json:"click_action,omitempty"` // 通知タップ時のアクション（通知カテゴリ）
+}
+
+// fcmAPNSConfig はiOS（APNS経由）向けの配信設定です。
+type fcmAPNSConfig struct {
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// SendPushNotification はFCM HTTP v1 APIでプッシュ通知を送信します。
+// ios/android/web のいずれもFCM登録トークンを前提とします。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - token: デバイストークン（FCM登録トークン）
+//   - title: 通知タイトル
+//   - body: 通知本文
+//   - data: カスタムデータ（任意、文字列に変換して送信）
+//
+// 戻り値:
+//   - error: 送信に失敗した場合のエラー
+func (f *FCMSender) SendPushNotification(ctx context.Context, token *model.DeviceToken, title, body string, data map[string]interface{}) error {
+	switch token.Platform {
+	case "ios", "android", "web":
+	default:
+		return fmt.Errorf("unsupported platform: %s", token.Platform)
+	}
+
+	payload, err := f.buildSendRequest(token, title, body, data)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %w", err)
+	}
+
+	return f.mailer.sendWithRetry(ctx, func() error {
+		return f.send(ctx, token, payload)
+	})
+}
+
+// buildSendRequest はFCM HTTP v1のリクエストボディを構築します。
+// カスタムデータの通知カテゴリは、Androidでは click_action、iOSでは aps.category として送信します。
+func (f *FCMSender) buildSendRequest(token *model.DeviceToken, title, body string, data map[string]interface{}) ([]byte, error) {
+	var stringData map[string]string
+	if len(data) > 0 {
+		stringData = make(map[string]string, len(data))
+		for k, v := range data {
+			stringData[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	message := fcmV1Message{
+		Token: token.Token,
+		Notification: &FCMNotification{
+			Title: title,
+			Body:  body,
+		},
+		Data:    stringData,
+		Android: &fcmAndroidConfig{Priority: "high"},
+	}
+	category, _ := pushRichFields(data)
+	if category != "" {
+		message.Android.Notification = &fcmAndroidNotification{ClickAction: category}
+	}
+	if token.Platform == "ios" {
+		aps := map[string]interface{}{
+			"content-available": 1,
+			"mutable-content":   1,
+		}
+		if category != "" {
+			aps["category"] = category
+		}
+		message.APNS = &fcmAPNSConfig{
+			Payload: map[string]interface{}{"aps": aps},
+		}
+	}
+
+	return json.Marshal(fcmSendRequest{Message: message})
+}
+
+// send はmessages:sendを1回呼び出します。
+// トークンが登録解除・不正と判定された場合は InvalidDeviceTokenError を返します。
+func (f *FCMSender) send(ctx context.Context, token *model.DeviceToken, payload []byte) error {
+	accessToken, err := f.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", f.endpoint, url.PathEscape(f.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// トークンが失効している可能性があるため、次回は再取得する
+		f.invalidateAccessToken()
+	}
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := fmt.Errorf("FCM send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		if reason := fcmInvalidTokenReason(resp.StatusCode, respBody); reason != "" {
+			return &InvalidDeviceTokenError{TokenID: token.ID, Reason: reason, Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// fcmErrorResponse はFCM HTTP v1のエラーレスポンスです。
+type fcmErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// fcmInvalidTokenReason はエラーレスポンスがトークンの無効を示す場合に無効化理由を返します。
+// UNREGISTERED（404 NOT_FOUND）は登録解除、トークンに関する INVALID_ARGUMENT は不正なトークンとします。
+// それ以外（ペイロード不正やサーバーエラー等）は空文字を返します。
+func fcmInvalidTokenReason(statusCode int, body []byte) string {
+	var resp fcmErrorResponse
+	_ = json.Unmarshal(body, &resp)
+
+	for _, detail := range resp.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return DeviceTokenReasonFCMUnregistered
+		}
+	}
+	if statusCode == http.StatusNotFound {
+		return DeviceTokenReasonFCMUnregistered
+	}
+	if statusCode == http.StatusBadRequest && resp.Error.Status == "INVALID_ARGUMENT" &&
+		strings.Contains(strings.ToLower(resp.Error.Message), "registration token") {
+		return DeviceTokenReasonFCMInvalidToken
+	}
+	return ""
+}
+
+// =============================================================================
+// OAuth2 Access Token - サービスアカウント認証
+// =============================================================================
+
+// fcmTokenResponse はトークンエンドポイントのレスポンスです。
+type fcmTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// getAccessToken はキャッシュ済みのアクセストークンを返します。
+// 期限切れ間近の場合はサービスアカウントのJWTアサーションで再取得します。
+func (f *FCMSender) getAccessToken(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if f.accessToken != "" && now.Add(fcmTokenRefreshMargin).Before(f.expiresAt) {
+		return f.accessToken, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   f.clientEmail,
+		"scope": fcmScope,
+		"aud":   f.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(fcmTokenLifetime).Unix(),
+	}).SignedString(f.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch FCM access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to fetch FCM access token: status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var token fcmTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode FCM access token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("FCM token endpoint returned no access token")
+	}
+
+	f.accessToken = token.AccessToken
+	f.expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
+
+// SetClock はアクセストークンの期限と汎用Webhookの送信時刻に使用する現在時刻を設定します（テスト用）。
+func (f *FCMSender) SetClock(c clock.Clock) {
+	f.clock = c
+	if f.mailer != nil {
+		f.mailer.SetClock(c)
+	}
+}
+
+// now は現在時刻を返します。
+func (f *FCMSender) now() time.Time {
+	return clock.Now(f.clock)
+}
+
+// invalidateAccessToken はキャッシュ済みのアクセストークンを破棄します。
+func (f *FCMSender) invalidateAccessToken() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accessToken = ""
+}
+
+// =============================================================================
+// Email / Webhook / Event - 共通実装への委譲
+// =============================================================================
+
+// SendEmailNotification はSESでメール通知を送信します。
+func (f *FCMSender) SendEmailNotification(ctx context.Context, toEmail, subject, htmlBody, textBody string) error {
+	return f.mailer.SendEmailNotification(ctx, toEmail, subject, htmlBody, textBody)
+}
+
+// SendWebhookNotification はSlack/DiscordのWebhookへ通知を投稿します。
+func (f *FCMSender) SendWebhookNotification(ctx context.Context, channel, webhookURL, title, body string) error {
+	return f.mailer.SendWebhookNotification(ctx, channel, webhookURL, title, body)
+}
+
+// SendCustomWebhook は汎用Webhookへ通知イベントのJSONを署名付きで送信します。
+func (f *FCMSender) SendCustomWebhook(ctx context.Context, webhookURL, secret string, event NotificationEvent) error {
+	return f.mailer.SendCustomWebhook(ctx, webhookURL, secret, event)
+}
+
+// SendSMSNotification はSNSでSMSを送信します。
+func (f *FCMSender) SendSMSNotification(ctx context.Context, phoneNumber, message string) error {
+	return f.mailer.SendSMSNotification(ctx, phoneNumber, message)
+}
+
+// SendNotificationEvent は通知イベントを処理して送信します。
+// プッシュ通知はFCM、メール通知はSES、Webhook通知はSlack/Discordへ送信します。
+func (f *FCMSender) SendNotificationEvent(ctx context.Context, event NotificationEvent, user *model.User, tokens []model.DeviceToken) error {
+	return sendNotificationEvent(ctx, f, event, user, tokens)
+}
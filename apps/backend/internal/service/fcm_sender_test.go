@@ -0,0 +1,171 @@
+// Package service - FCMSender Tests
+//
+// FCM HTTP v1 API による通知送信のテストを提供します。
+// テスト対象:
+//   - サービスアカウントJWTによるアクセストークン取得とキャッシュ
+//   - messages:send のリクエスト形式
+//   - 送信失敗時のエラー
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// newTestFCMSender はテスト用サーバーに向けたFCMSenderを作成します。
+func newTestFCMSender(t *testing.T, server *httptest.Server) *FCMSender {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	account, _ := json.Marshal(fcmServiceAccount{
+		ProjectID:   "garden-test",
+		PrivateKey:  string(keyPEM),
+		ClientEmail: "fcm@garden-test.iam.gserviceaccount.com",
+		TokenURI:    server.URL + "/token",
+	})
+
+	sender, err := NewFCMSender(&config.NotificationConfig{
+		AWSRegion:             "ap-northeast-1",
+		PushProvider:          PushProviderFCM,
+		FCMServiceAccountJSON: string(account),
+		MaxRetries:            1,
+		InitialBackoffMs:      1,
+	})
+	if err != nil {
+		t.Fatalf("NewFCMSender failed: %v", err)
+	}
+	sender.endpoint = server.URL
+	return sender
+}
+
+// TestFCMSender_SendPushNotification はFCM HTTP v1への送信テストです。
+// 期待動作:
+//   - トークンエンドポイントにJWTアサーションが送られる
+//   - アクセストークンは2回目以降キャッシュされ、期限切れ間近になると再取得する
+//   - messages:send にトークン・通知・文字列化したデータが送られる
+func TestFCMSender_SendPushNotification(t *testing.T) {
+	// Arrange
+	var tokenRequests int32
+	var received fcmSendRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			atomic.AddInt32(&tokenRequests, 1)
+			if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || r.FormValue("assertion") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(fcmTokenResponse{AccessToken: "access-123", ExpiresIn: 3600})
+		case "/v1/projects/garden-test/messages:send":
+			if r.Header.Get("Authorization") != "Bearer access-123" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			_, _ = w.Write([]byte(`{"name":"projects/garden-test/messages/1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sender := newTestFCMSender(t, server)
+	fake := clock.NewFake(time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC))
+	sender.SetClock(fake)
+	ctx := context.Background()
+	token := &model.DeviceToken{Token: "fcm-registration-token", Platform: "ios"}
+
+	// Act
+	err := sender.SendPushNotification(ctx, token, "収穫リマインダー", "トマトの収穫時期です", map[string]interface{}{"crop_id": 42})
+	if err != nil {
+		t.Fatalf("SendPushNotification failed: %v", err)
+	}
+	err = sender.SendPushNotification(ctx, token, "second", "body", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Second SendPushNotification failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Errorf("Expected access token to be cached (1 request), got %d", n)
+	}
+	fake.Advance(time.Hour)
+	if err := sender.SendPushNotification(ctx, token, "third", "body", nil); err != nil {
+		t.Fatalf("Third SendPushNotification failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&tokenRequests); n != 2 {
+		t.Errorf("Expected access token to be refreshed after expiry (2 requests), got %d", n)
+	}
+	if received.Message.Token != "fcm-registration-token" || received.Message.Notification == nil {
+		t.Fatalf("Unexpected message: %+v", received.Message)
+	}
+	if received.Message.APNS == nil {
+		t.Error("Expected APNS config for ios token")
+	}
+
+	// 1回目のデータが文字列化されていることを確認
+	payload, _ := sender.buildSendRequest(token, "t", "b", map[string]interface{}{"crop_id": 42})
+	var first fcmSendRequest
+	_ = json.Unmarshal(payload, &first)
+	if first.Message.Data["crop_id"] != "42" {
+		t.Errorf("Expected stringified data, got %+v", first.Message.Data)
+	}
+}
+
+// TestFCMSender_SendPushNotification_Error は送信失敗時のテストです。
+// 期待動作:
+//   - 200以外のレスポンスでエラーを返す
+//   - 未対応プラットフォームはリクエスト前にエラー
+func TestFCMSender_SendPushNotification_Error(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_ = json.NewEncoder(w).Encode(fcmTokenResponse{AccessToken: "access-123", ExpiresIn: 3600})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"status":"NOT_FOUND","message":"Requested entity was not found."}}`))
+	}))
+	defer server.Close()
+
+	sender := newTestFCMSender(t, server)
+	ctx := context.Background()
+
+	// Act
+	sendErr := sender.SendPushNotification(ctx, &model.DeviceToken{Token: "stale", Platform: "android"}, "t", "b", nil)
+	platformErr := sender.SendPushNotification(ctx, &model.DeviceToken{Token: "x", Platform: "blackberry"}, "t", "b", nil)
+
+	// Assert
+	if sendErr == nil {
+		t.Error("Expected error for non-200 response")
+	}
+	if platformErr == nil {
+		t.Error("Expected error for unsupported platform")
+	}
+}
+
+// TestNewNotificationSender_UnsupportedProvider は未対応プロバイダーのテストです。
+func TestNewNotificationSender_UnsupportedProvider(t *testing.T) {
+	_, err := NewNotificationSender(&config.NotificationConfig{PushProvider: "pigeon"}, nil)
+	if err == nil {
+		t.Error("Expected error for unsupported push provider")
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Harvest Ready - 収穫可能になった作物の通知
+// =============================================================================
+// 作物のステータスが ready_to_harvest になった、または収穫予定日（ユーザーのタイムゾーンでの今日）を
+// 迎えた作物について crop_ready_to_harvest の通知イベントを生成します。
+// 7日前の収穫リマインダー（harvest_reminder）とは別のイベントで、送信時刻の設定は使わず
+// スケジューラーの実行ごとに検出します（おやすみモードは通常どおり適用されます）。
+//
+// 1つの作物につき1回だけ通知し、送信日時を Crop.HarvestReadyNotifiedAt に記録します。
+// 収穫予定日の変更などで収穫可能でなくなった場合は UpdateCrop で記録をリセットします。
+
+// harvestReadyAlertsEnabled は収穫可能通知が有効かを判定します（未設定の場合は有効）。
+func harvestReadyAlertsEnabled(settings *model.NotificationSettings) bool {
+	return settings.HarvestReadyAlerts == nil || *settings.HarvestReadyAlerts
+}
+
+// isHarvestReady は作物がユーザーのタイムゾーンで収穫可能になっているかを判定します。
+func isHarvestReady(crop *model.Crop, user *model.User, now time.Time) bool {
+	switch crop.Status {
+	case "ready_to_harvest":
+		return true
+	case "planted", "growing":
+		_, dayEnd := userDayBounds(user, now)
+		return crop.ExpectedHarvestDate.Before(dayEnd)
+	default:
+		return false
+	}
+}
+
+// resetHarvestReadyNotification は収穫可能でなくなった作物の収穫可能通知の記録をリセットします。
+// 再び収穫可能になったときに改めて通知するためです。
+func resetHarvestReadyNotification(crop *model.Crop, now time.Time) {
+	growing := crop.Status == "planted" || crop.Status == "growing"
+	if crop.HarvestReadyNotifiedAt != nil && growing && crop.ExpectedHarvestDate.After(now) {
+		crop.HarvestReadyNotifiedAt = nil
+	}
+}
+
+// processHarvestReadyAlerts は収穫可能になった作物の通知を処理します。
+// ユーザーごとに1件のイベントにまとめ、対象の作物に送信日時を記録します。
+func (s *Service) processHarvestReadyAlerts(ctx context.Context, userIDs []uint, now time.Time) ([]NotificationEvent, error) {
+	// タイムゾーン差を吸収できる範囲で取得し、ユーザーごとに絞り込む
+	candidates, err := s.repos.Crop().GetHarvestReadyCandidates(ctx, userIDs, now.Add(schedulerDayMargin))
+	if err != nil {
+		return nil, err
+	}
+
+	var order []uint
+	userCrops := make(map[uint][]model.Crop)
+	userInfo := make(map[uint]*model.User)
+	for i := range candidates {
+		crop := candidates[i]
+		if crop.User.ID == 0 {
+			continue
+		}
+		user := &crop.User
+		if !isHarvestReady(&crop, user, now) {
+			continue
+		}
+		if _, ok := userCrops[crop.UserID]; !ok {
+			order = append(order, crop.UserID)
+		}
+		userCrops[crop.UserID] = append(userCrops[crop.UserID], crop)
+		userInfo[crop.UserID] = user
+	}
+
+	var events []NotificationEvent
+	var notifiedIDs []uint
+	for _, userID := range order {
+		crops := userCrops[userID]
+		user := userInfo[userID]
+		cropIDs := getCropIDs(crops)
+		// 通知が無効な作物も送信済みとして記録し、有効にしたときに過去の作物をまとめて通知しない
+		notifiedIDs = append(notifiedIDs, cropIDs...)
+		if !notificationEventEnabled(user, NotificationEventCropReadyToHarvest) {
+			continue
+		}
+
+		msg := localizedMessage(userLocale(user), NotificationEventCropReadyToHarvest)
+		body := fmt.Sprintf(msg.Body, len(crops))
+		if len(crops) == 1 {
+			body = fmt.Sprintf(msg.BodyOne, crops[0].Name)
+		}
+		scope := make([]string, len(cropIDs))
+		for i, id := range cropIDs {
+			scope[i] = strconv.FormatUint(uint64(id), 10)
+		}
+
+		dayStart, _ := userDayBounds(user, now)
+		events = append(events, NotificationEvent{
+			Type:      NotificationEventCropReadyToHarvest,
+			UserID:    userID,
+			UserEmail: user.Email,
+			Title:     msg.Title,
+			Body:      body,
+			Data: map[string]interface{}{
+				"crop_count": len(crops),
+				"crop_ids":   cropIDs,
+			},
+			LocalDate:          dayStart.Format("2006-01-02"),
+			DeduplicationScope: strings.Join(scope, "-"),
+		})
+	}
+
+	if err := s.repos.Crop().MarkHarvestReadyNotified(ctx, notifiedIDs, now); err != nil {
+		return nil, fmt.Errorf("failed to mark harvest ready crops: %w", err)
+	}
+	return events, nil
+}
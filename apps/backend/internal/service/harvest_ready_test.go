@@ -0,0 +1,133 @@
+// Package service - Harvest Ready Notification Tests
+//
+// 収穫可能になった作物の通知のテストを提供します。
+// テスト対象:
+//   - ready_to_harvest になった作物・収穫予定日を迎えた作物の検出
+//   - 1つの作物につき1回だけ通知し、収穫可能でなくなったらリセットすること
+//   - 収穫可能通知の設定（harvest_ready_alerts）による無効化
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestProcessHarvestReadyAlerts は収穫可能通知の検出のテストです。
+// 期待動作:
+//   - ready_to_harvest の作物と収穫予定日が今日の作物を1件のイベントにまとめる
+//   - 収穫予定日が先の作物・収穫済みの作物は対象外
+//   - 通知済みの作物は次回の実行で通知しない
+//   - 収穫可能でなくなった作物は、再び収穫可能になったときに通知する
+func TestProcessHarvestReadyAlerts(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	user := &model.User{Email: "ready@example.com", Timezone: "UTC"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	flipped := &model.Crop{UserID: user.ID, Name: "トマト", Status: "ready_to_harvest", ExpectedHarvestDate: now.AddDate(0, 0, 5), User: *user}
+	dueToday := &model.Crop{UserID: user.ID, Name: "ナス", Status: "growing", ExpectedHarvestDate: now.Add(3 * time.Hour), User: *user}
+	upcoming := &model.Crop{UserID: user.ID, Name: "キュウリ", Status: "growing", ExpectedHarvestDate: now.AddDate(0, 0, 5), User: *user}
+	harvested := &model.Crop{UserID: user.ID, Name: "レタス", Status: "harvested", ExpectedHarvestDate: now.AddDate(0, 0, -1), User: *user}
+	for _, crop := range []*model.Crop{flipped, dueToday, upcoming, harvested} {
+		_ = mockRepos.Crop().Create(ctx, crop)
+	}
+
+	// Act
+	result, err := svc.processScheduledNotificationsAt(ctx, now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("processScheduledNotificationsAt failed: %v", err)
+	}
+	if result.HarvestReadyAlerts != 1 {
+		t.Fatalf("Expected 1 harvest ready alert, got %d", result.HarvestReadyAlerts)
+	}
+	var ready NotificationEvent
+	for _, event := range result.Events {
+		if event.Type == NotificationEventCropReadyToHarvest {
+			ready = event
+		}
+	}
+	ids, _ := ready.Data["crop_ids"].([]uint)
+	if len(ids) != 2 || ids[0] != flipped.ID || ids[1] != dueToday.ID {
+		t.Errorf("Expected flipped and due today crops, got %v", ids)
+	}
+	if want := fmt.Sprintf("%d-%d", flipped.ID, dueToday.ID); ready.DeduplicationScope != want {
+		t.Errorf("Expected deduplication scope %q, got %q", want, ready.DeduplicationScope)
+	}
+
+	// 通知済みの作物は再度通知しない
+	result, _ = svc.processScheduledNotificationsAt(ctx, now.Add(time.Hour))
+	if result.HarvestReadyAlerts != 0 {
+		t.Errorf("Expected no alert for notified crops, got %d", result.HarvestReadyAlerts)
+	}
+
+	// 収穫予定日を延期すると記録がリセットされ、再び収穫可能になったら通知する
+	stored, _ := mockRepos.Crop().GetByID(ctx, flipped.ID)
+	stored.Status = "growing"
+	stored.ExpectedHarvestDate = time.Now().AddDate(0, 0, 10)
+	_ = svc.UpdateCrop(ctx, stored)
+	if stored.HarvestReadyNotifiedAt != nil {
+		t.Fatal("Expected harvest ready notification to be reset")
+	}
+	stored.Status = "ready_to_harvest"
+	_ = svc.UpdateCrop(ctx, stored)
+
+	result, _ = svc.processScheduledNotificationsAt(ctx, now.Add(2*time.Hour))
+	if result.HarvestReadyAlerts != 1 {
+		t.Errorf("Expected alert after crop became ready again, got %d", result.HarvestReadyAlerts)
+	}
+}
+
+// TestProcessHarvestReadyAlerts_Disabled は収穫可能通知を無効にした場合のテストです。
+// 期待動作:
+//   - harvest_ready_alerts が false の場合は通知しない（収穫リマインダーの設定とは独立）
+//   - 対象の作物は通知済みとして記録し、有効にしたときにまとめて通知しない
+func TestProcessHarvestReadyAlerts_Disabled(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	disabled := false
+	user := &model.User{
+		Email:    "optout@example.com",
+		Timezone: "UTC",
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:        true,
+			EmailEnabled:       true,
+			HarvestReminders:   true,
+			HarvestReadyAlerts: &disabled,
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+	crop := &model.Crop{UserID: user.ID, Name: "トマト", Status: "ready_to_harvest", ExpectedHarvestDate: now, User: *user}
+	_ = mockRepos.Crop().Create(ctx, crop)
+
+	// Act
+	result, err := svc.processScheduledNotificationsAt(ctx, now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("processScheduledNotificationsAt failed: %v", err)
+	}
+	if result.HarvestReadyAlerts != 0 {
+		t.Errorf("Expected no harvest ready alert, got %d", result.HarvestReadyAlerts)
+	}
+	if stored, _ := mockRepos.Crop().GetByID(ctx, crop.ID); stored.HarvestReadyNotifiedAt == nil {
+		t.Error("Expected crop to be marked as notified")
+	}
+	if notificationEventEnabled(user, NotificationEventHarvestReminder) != true {
+		t.Error("Expected harvest reminders to stay enabled")
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// =============================================================================
+// Harvest Heatmap - 収穫ヒートマップ
+// =============================================================================
+
+// ChartTypeHarvestHeatmap はカレンダー形式の収穫ヒートマップ（GitHubのコントリビューショングラフ風）
+const ChartTypeHarvestHeatmap ChartType = "harvest_heatmap"
+
+// HeatmapLevels はヒートマップの濃淡段階数（0: 収穫なし、1〜4: 最大値に対する割合）
+const HeatmapLevels = 4
+
+// HarvestHeatmapDay はヒートマップの1日分のセルを表します。
+type HarvestHeatmapDay struct {
+	Date    string  `json:"date"`    // 日付（YYYY-MM-DD）
+	Weekday int     `json:"weekday"` // 曜日（0: 日曜〜6: 土曜、行の位置）
+	Week    int     `json:"week"`    // 年初からの週番号（0始まり、日曜始まり、列の位置）
+	Kg      float64 `json:"kg"`      // 収穫量（kg換算）
+	Count   int     `json:"count"`   // 収穫回数
+	Level   int     `json:"level"`   // 濃淡レベル（0〜4）
+}
+
+// HarvestHeatmapData は1年分の収穫ヒートマップデータを表します。
+// 収穫がない日も含め、対象年の全日を日付順に返します。
+type HarvestHeatmapData struct {
+	Year       int                 `json:"year"`
+	TotalKg    float64             `json:"total_kg"`
+	MaxKg      float64             `json:"max_kg"`      // 1日あたりの最大収穫量
+	ActiveDays int                 `json:"active_days"` // 収穫があった日数
+	Days       []HarvestHeatmapDay `json:"days"`
+}
+
+// getHarvestHeatmapChart は収穫ヒートマップのグラフデータを生成します。
+// filter.Year で対象年を指定します（省略時は今年）。
+func (s *Service) getHarvestHeatmapChart(ctx context.Context, userID uint, filter ChartFilter) (*ChartData, error) {
+	year := s.now().Year()
+	if filter.Year != nil {
+		year = *filter.Year
+	}
+
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0).Add(-time.Nanosecond)
+
+	harvests, err := s.repos.Harvest().GetByUserIDWithDateRange(ctx, userID, &start, &end)
+	if err != nil {
+		return nil, err
+	}
+
+	// 日付ごとに集計
+	kgByDate := make(map[string]float64)
+	countByDate := make(map[string]int)
+	for _, h := range harvests {
+		key := h.HarvestDate.Format("2006-01-02")
+		kgByDate[key] += convertToKg(h.Quantity, h.QuantityUnit)
+		countByDate[key]++
+	}
+
+	data := HarvestHeatmapData{Year: year}
+	for _, kg := range kgByDate {
+		data.TotalKg += kg
+		if kg > data.MaxKg {
+			data.MaxKg = kg
+		}
+	}
+
+	// 全日を0埋めして生成
+	firstWeekday := int(start.Weekday())
+	for day := start; day.Year() == year; day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		kg := kgByDate[key]
+		if countByDate[key] > 0 {
+			data.ActiveDays++
+		}
+		data.Days = append(data.Days, HarvestHeatmapDay{
+			Date:    key,
+			Weekday: int(day.Weekday()),
+			Week:    (day.YearDay() - 1 + firstWeekday) / 7,
+			Kg:      math.Round(kg*100) / 100,
+			Count:   countByDate[key],
+			Level:   heatmapLevel(kg, data.MaxKg),
+		})
+	}
+	data.TotalKg = math.Round(data.TotalKg*100) / 100
+	data.MaxKg = math.Round(data.MaxKg*100) / 100
+
+	return &ChartData{
+		ChartType:   ChartTypeHarvestHeatmap,
+		Title:       "収穫カレンダー",
+		Data:        data,
+		GeneratedAt: s.now(),
+	}, nil
+}
+
+// heatmapLevel は収穫量を最大値に対する割合で0〜HeatmapLevelsの段階に変換します。
+// 収穫がわずかでもあれば1以上になります。
+func heatmapLevel(kg, maxKg float64) int {
+	if kg <= 0 || maxKg <= 0 {
+		return 0
+	}
+	level := int(math.Ceil(kg / maxKg * HeatmapLevels))
+	if level < 1 {
+		return 1
+	}
+	if level > HeatmapLevels {
+		return HeatmapLevels
+	}
+	return level
+}
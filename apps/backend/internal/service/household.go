@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secure-scorecard/backend/internal/dto"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/realtime"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Household - 共有の庭（世帯）のメンバーとリアルタイム配信
+// =============================================================================
+// 庭の所有者は同じ世帯のユーザーをメンバーに追加でき、所有者とメンバーは WebSocket（GET /api/v1/ws）で
+// 庭のルームに参加して、区画のレイアウトとタスクの変更をリアルタイムに受信します。
+//
+// 区画・タスクは庭ではなくユーザーに属するため、所有者の区画・タスクの変更は所有者の全ての庭のルームに配信します。
+// トランザクション内の変更はコミット後に配信し、ロールバックした変更は配信しません。
+
+// GardenMemberRoleMember は庭のメンバーの役割です（所有者は Garden.UserID）。
+const GardenMemberRoleMember = "member"
+
+var (
+	// ErrRealtimeUnavailable はリアルタイム配信のハブが未設定の場合のエラー
+	ErrRealtimeUnavailable = errors.New("realtime updates are unavailable")
+	// ErrGardenNotFound は庭が見つからない場合のエラー
+	ErrGardenNotFound = errors.New("garden not found")
+	// ErrGardenAccessDenied は庭の所有者・メンバーでない（所有者のみの操作では所有者でない）場合のエラー
+	ErrGardenAccessDenied = errors.New("garden access denied")
+	// ErrGardenMemberUserNotFound はメンバーに追加するユーザーが見つからない場合のエラー
+	ErrGardenMemberUserNotFound = errors.New("user to add as garden member not found")
+	// ErrGardenMemberExists はユーザーがすでに庭のメンバー・所有者の場合のエラー
+	ErrGardenMemberExists = errors.New("user is already a garden member")
+	// ErrGardenMemberNotFound はユーザーが庭のメンバーでない場合のエラー
+	ErrGardenMemberNotFound = errors.New("garden member not found")
+)
+
+// RoomHub は庭ごとのルームへのリアルタイム配信のハブです（realtime.Hub）。
+type RoomHub interface {
+	Connect(userID uint) *realtime.Client
+	Join(client *realtime.Client, gardenID uint)
+	Leave(client *realtime.Client, gardenID uint)
+	Broadcast(gardenID uint, msg realtime.Message)
+	RemoveUser(gardenID, userID uint)
+}
+
+// SetRoomHub はリアルタイム配信のハブを設定します。
+// 未設定の場合は変更を配信せず、ConnectRealtime は ErrRealtimeUnavailable を返します。
+func (s *Service) SetRoomHub(hub RoomHub) {
+	s.rooms = hub
+}
+
+// =============================================================================
+// Garden Members - 庭のメンバー
+// =============================================================================
+
+// GetGardenMembers は庭のメンバーを取得します（所有者・メンバーのみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 取得するユーザーのID
+//   - gardenID: 庭ID
+//
+// 戻り値:
+//   - []model.GardenMember: メンバーの一覧（追加順、所有者は含まない）
+//   - error: 庭がない場合は ErrGardenNotFound、所有者・メンバーでない場合は ErrGardenAccessDenied
+func (s *Service) GetGardenMembers(ctx context.Context, userID, gardenID uint) ([]model.GardenMember, error) {
+	if _, err := s.authorizeGardenAccess(ctx, gardenID, userID); err != nil {
+		return nil, err
+	}
+	return s.repos.GardenMember().GetByGardenID(ctx, gardenID)
+}
+
+// AddGardenMember はメールアドレスのユーザーを庭のメンバーに追加します（所有者のみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - ownerID: 庭の所有者のユーザーID
+//   - gardenID: 庭ID
+//   - email: 追加するユーザーのメールアドレス
+//
+// 戻り値:
+//   - *model.GardenMember: 追加したメンバー（ユーザー情報付き）
+//   - error: ErrGardenNotFound / ErrGardenAccessDenied / ErrGardenMemberUserNotFound / ErrGardenMemberExists
+func (s *Service) AddGardenMember(ctx context.Context, ownerID, gardenID uint, email string) (*model.GardenMember, error) {
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, err
+	}
+	if garden.UserID != ownerID {
+		return nil, ErrGardenAccessDenied
+	}
+
+	user, err := s.repos.User().GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGardenMemberUserNotFound
+		}
+		return nil, err
+	}
+	if user.ID == garden.UserID {
+		return nil, ErrGardenMemberExists
+	}
+	if _, err := s.repos.GardenMember().Get(ctx, gardenID, user.ID); err == nil {
+		return nil, ErrGardenMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	member := &model.GardenMember{
+		GardenID: gardenID,
+		UserID:   user.ID,
+		Role:     GardenMemberRoleMember,
+	}
+	if err := s.repos.GardenMember().Create(ctx, member); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrGardenMemberExists
+		}
+		return nil, err
+	}
+	member.User = *user
+	return member, nil
+}
+
+// RemoveGardenMember は庭のメンバーを削除します。
+// 所有者は全てのメンバーを削除でき、メンバーは自分自身を削除（世帯から退出）できます。
+// 削除したユーザーの WebSocket の接続はコミット後に庭のルームから退出させます（room.revoked）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 削除するユーザーのID（所有者または削除されるメンバー本人）
+//   - gardenID: 庭ID
+//   - memberUserID: 削除するメンバーのユーザーID
+//
+// 戻り値:
+//   - error: ErrGardenNotFound / ErrGardenAccessDenied / ErrGardenMemberNotFound
+func (s *Service) RemoveGardenMember(ctx context.Context, userID, gardenID, memberUserID uint) error {
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return err
+	}
+	if userID != garden.UserID && userID != memberUserID {
+		return ErrGardenAccessDenied
+	}
+
+	if _, err := s.repos.GardenMember().Get(ctx, gardenID, memberUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrGardenMemberNotFound
+		}
+		return err
+	}
+	if err := s.repos.GardenMember().Delete(ctx, gardenID, memberUserID); err != nil {
+		return err
+	}
+	if s.rooms != nil {
+		repository.AfterCommit(ctx, func() {
+			s.rooms.RemoveUser(gardenID, memberUserID)
+		})
+	}
+	return nil
+}
+
+// GetSharedGardens はユーザーがメンバーとして参加している庭を取得します（所有する庭は GetUserGardens）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - []model.Garden: 参加している庭の一覧
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetSharedGardens(ctx context.Context, userID uint) ([]model.Garden, error) {
+	return s.repos.GardenMember().GetSharedGardens(ctx, userID)
+}
+
+// getGarden は庭を取得します（見つからない場合は ErrGardenNotFound）。
+func (s *Service) getGarden(ctx context.Context, gardenID uint) (*model.Garden, error) {
+	garden, err := s.repos.Garden().GetByID(ctx, gardenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGardenNotFound
+		}
+		return nil, err
+	}
+	return garden, nil
+}
+
+// authorizeGardenAccess はユーザーが庭の所有者・メンバーであることを確認します。
+func (s *Service) authorizeGardenAccess(ctx context.Context, gardenID, userID uint) (*model.Garden, error) {
+	garden, err := s.getGarden(ctx, gardenID)
+	if err != nil {
+		return nil, err
+	}
+	if garden.UserID == userID {
+		return garden, nil
+	}
+	if _, err := s.repos.GardenMember().Get(ctx, gardenID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGardenAccessDenied
+		}
+		return nil, err
+	}
+	return garden, nil
+}
+
+// sharesGardenRecord はユーザーの記録がユーザーがメンバーの庭で共有されているか判定します。
+// 区画・作物は庭ではなくユーザーに属するため、所有者の庭のメンバーであれば共有されているとみなします。
+func (s *Service) sharesGardenRecord(ctx context.Context, userID, ownerID uint) (bool, error) {
+	gardens, err := s.repos.GardenMember().GetSharedGardens(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, garden := range gardens {
+		if garden.UserID == ownerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// =============================================================================
+// Garden Rooms - 庭のルーム
+// =============================================================================
+
+// ConnectRealtime はユーザーの WebSocket の接続をハブに登録します。
+//
+// 戻り値:
+//   - *realtime.Client: 接続（呼び出し元で Close する）
+//   - error: ハブが未設定の場合は ErrRealtimeUnavailable
+func (s *Service) ConnectRealtime(userID uint) (*realtime.Client, error) {
+	if s.rooms == nil {
+		return nil, ErrRealtimeUnavailable
+	}
+	return s.rooms.Connect(userID), nil
+}
+
+// JoinGardenRoom は接続を庭のルームに参加させます（所有者・メンバーのみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - client: ConnectRealtime で登録した接続
+//   - gardenID: 庭ID
+//
+// 戻り値:
+//   - error: 庭がない場合は ErrGardenNotFound、所有者・メンバーでない場合は ErrGardenAccessDenied
+func (s *Service) JoinGardenRoom(ctx context.Context, client *realtime.Client, gardenID uint) error {
+	if s.rooms == nil {
+		return ErrRealtimeUnavailable
+	}
+	if _, err := s.authorizeGardenAccess(ctx, gardenID, client.UserID()); err != nil {
+		return err
+	}
+	s.rooms.Join(client, gardenID)
+	return nil
+}
+
+// LeaveGardenRoom は接続を庭のルームから退出させます。
+func (s *Service) LeaveGardenRoom(client *realtime.Client, gardenID uint) {
+	if s.rooms == nil {
+		return
+	}
+	s.rooms.Leave(client, gardenID)
+}
+
+// broadcastHousehold は所有者の全ての庭のルームにメッセージを配信します
+// （トランザクション内の場合はコミット後、ハブが未設定の場合は何もしない）。
+// 記録は作成・更新済みのため、庭の取得の失敗はエラーにせずログに記録します。
+func (s *Service) broadcastHousehold(ctx context.Context, ownerID uint, msg realtime.Message) {
+	if s.rooms == nil {
+		return
+	}
+	gardens, err := s.repos.Garden().GetByUserID(ctx, ownerID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load gardens for household broadcast", "owner_id", ownerID, "message_type", msg.Type, "error", err)
+		return
+	}
+	if len(gardens) == 0 {
+		return
+	}
+	repository.AfterCommit(ctx, func() {
+		for _, garden := range gardens {
+			s.rooms.Broadcast(garden.ID, msg)
+		}
+	})
+}
+
+// broadcastPlotLayout は区画のレイアウト（区画と現在の配置）の変更を配信します。
+func (s *Service) broadcastPlotLayout(ctx context.Context, msgType string, plot *model.Plot) {
+	if s.rooms == nil {
+		return
+	}
+	s.broadcastHousehold(ctx, plot.UserID, realtime.Message{
+		Type:     msgType,
+		Entity:   SyncEntityPlots,
+		EntityID: plot.ID,
+		Data:     s.plotLayoutItem(ctx, *plot),
+	})
+}
+
+// broadcastTask はタスクの変更を配信します。
+func (s *Service) broadcastTask(ctx context.Context, msgType string, task *model.Task) {
+	s.broadcastHousehold(ctx, task.UserID, realtime.Message{
+		Type:     msgType,
+		Entity:   SyncEntityTasks,
+		EntityID: task.ID,
+		Data:     dto.NewTaskResponse(task),
+	})
+}
+
+// broadcastDeleted は区画・タスクの削除を配信します。
+func (s *Service) broadcastDeleted(ctx context.Context, ownerID uint, msgType, entity string, id uint) {
+	s.broadcastHousehold(ctx, ownerID, realtime.Message{
+		Type:     msgType,
+		Entity:   entity,
+		EntityID: id,
+	})
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Job Runs - 内蔵スケジューラーのジョブの実行履歴
+// =============================================================================
+// 内蔵スケジューラーはジョブを実行するたびに実行履歴（job_runs）を記録します。
+// 起動時に最後の実行日時から停止中に過ぎた実行日時を検出し、ジョブを1回だけ実行します（キャッチアップ）。
+
+// JobRunRetention は実行履歴の保持期間
+const JobRunRetention = 30 * 24 * time.Hour
+
+// ジョブの実行状態
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+)
+
+// StartJobRun はジョブの実行開始を記録します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - jobName: ジョブ名（config.SchedulerJobNames）
+//   - scheduledAt: 実行する回の実行日時
+//   - catchUp: 停止中に過ぎた実行日時のキャッチアップか
+//
+// 戻り値:
+//   - *model.JobRun: 作成した実行履歴（Status: running）
+//   - error: 記録に失敗した場合のエラー
+func (s *Service) StartJobRun(ctx context.Context, jobName string, scheduledAt time.Time, catchUp bool) (*model.JobRun, error) {
+	run := &model.JobRun{
+		JobName:     jobName,
+		ScheduledAt: scheduledAt,
+		CatchUp:     catchUp,
+		Status:      JobRunStatusRunning,
+		StartedAt:   s.now(),
+	}
+	if err := s.repos.JobRun().Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// FinishJobRun はジョブの実行結果を記録します。
+// runErr が nil の場合は succeeded、それ以外は failed としてエラー内容を記録します。
+func (s *Service) FinishJobRun(ctx context.Context, id uint, runErr error) error {
+	status, errorMessage := JobRunStatusSucceeded, ""
+	if runErr != nil {
+		status, errorMessage = JobRunStatusFailed, truncateString(runErr.Error(), 500)
+	}
+	return s.repos.JobRun().Finish(ctx, id, status, errorMessage, s.now())
+}
+
+// GetLatestJobRun はジョブの最後の実行履歴を取得します。
+//
+// 戻り値:
+//   - *model.JobRun: 最後の実行履歴（実行したことがない場合は nil）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetLatestJobRun(ctx context.Context, jobName string) (*model.JobRun, error) {
+	run, err := s.repos.JobRun().GetLatest(ctx, jobName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return run, err
+}
+
+// CleanupJobRuns は保持期間を過ぎた実行履歴を削除します。
+// 実行間隔が保持期間より長いジョブは、実行履歴が削除されると実行したことがないジョブと同じくキャッチアップしません。
+//
+// 戻り値:
+//   - int64: 削除した件数
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) CleanupJobRuns(ctx context.Context) (int64, error) {
+	return s.repos.JobRun().DeleteStartedBefore(ctx, s.now().Add(-JobRunRetention))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/filter"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// =============================================================================
+// List Pagination - 一覧のカーソルページング
+// =============================================================================
+// タスク・作物・収穫記録・区画の一覧を pagination.Page の共通の形式で1ページずつ返します。
+// ページングした一覧はIDの降順（新しい順）で、ページングしない一覧（GetUserTasks など）の並び順とは異なります。
+// タスクは GetUserTasksPageByDueDate で期限日の順にもページングできます。
+// 絞り込みの条件は filter.Spec で指定します（ステータスのみの場合は filter.Spec{}.EqualIfSet("status", status)）。
+
+// GetUserTasksPage はユーザーのタスクを1ページ分取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - spec: 絞り込みの条件（空の場合は絞り込まない）
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.Task]: タスクの1ページ分（新しい順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserTasksPage(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) (*pagination.Page[model.Task], error) {
+	tasks, err := s.repos.Task().ListByUserIDPaginated(ctx, userID, spec, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(tasks, params, func(t model.Task) uint { return t.ID }), nil
+}
+
+// GetUserTasksPageByDueDate はユーザーのタスクを期限日の順に1ページ分取得します。
+// (期限日, ID) のキーセットページングのため、期限日が同じタスクはIDの順に並びます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - spec: 絞り込みの条件（空の場合は絞り込まない）
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.Task]: タスクの1ページ分（期限日順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserTasksPageByDueDate(ctx context.Context, userID uint, spec filter.Spec, params pagination.KeyParams) (*pagination.Page[model.Task], error) {
+	tasks, err := s.repos.Task().ListByUserIDByDueDate(ctx, userID, spec, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewKeyPage(tasks, params, func(t model.Task) (time.Time, uint) { return t.DueDate, t.ID }), nil
+}
+
+// GetUserCropsPage はユーザーの作物を1ページ分取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - spec: 絞り込みの条件（空の場合は絞り込まない）
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.Crop]: 作物の1ページ分（新しい順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserCropsPage(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) (*pagination.Page[model.Crop], error) {
+	crops, err := s.repos.Crop().ListByUserIDPaginated(ctx, userID, spec, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(crops, params, func(c model.Crop) uint { return c.ID }), nil
+}
+
+// GetCropHarvestsPage は作物の収穫記録を1ページ分取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - cropID: 作物ID
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.Harvest]: 収穫記録の1ページ分（新しい順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetCropHarvestsPage(ctx context.Context, cropID uint, params pagination.Params) (*pagination.Page[model.Harvest], error) {
+	harvests, err := s.repos.Harvest().ListByCropIDPaginated(ctx, cropID, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(harvests, params, func(h model.Harvest) uint { return h.ID }), nil
+}
+
+// GetUserPlotsPage はユーザーの区画を1ページ分取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - spec: 絞り込みの条件（空の場合は絞り込まない）
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[model.Plot]: 区画の1ページ分（新しい順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserPlotsPage(ctx context.Context, userID uint, spec filter.Spec, params pagination.Params) (*pagination.Page[model.Plot], error) {
+	plots, err := s.repos.Plot().ListByUserIDPaginated(ctx, userID, spec, params)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewPage(plots, params, func(p model.Plot) uint { return p.ID }), nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/filter"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// List Pagination Tests - 一覧のカーソルページングのテスト
+// =============================================================================
+// テスト対象:
+//   - GetUserTasksPage: カーソルで全件を重複なく取得すること、ステータスでのフィルタ
+//   - GetUserTasksPageByDueDate: 期限日順のキーセットページング
+//   - GetNotificationInboxPage: 受信箱のカーソルページング、オフセットのページからの続き
+
+// TestGetUserTasksPage はタスクの一覧のページングのテストです。
+// 期待動作:
+//   - next_cursor をたどると全件を新しい順に重複なく取得する
+//   - ページを読む間に追加したタスクは以降のページに混ざらない
+//   - status を指定した場合はそのステータスのみ
+func TestGetUserTasksPage(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		status := "pending"
+		if i%2 == 1 {
+			status = "completed"
+		}
+		_ = mockRepos.Task().Create(ctx, &model.Task{UserID: 1, Title: "task", DueDate: time.Now(), Status: status})
+	}
+	_ = mockRepos.Task().Create(ctx, &model.Task{UserID: 2, Title: "other", DueDate: time.Now(), Status: "pending"})
+
+	// Act
+	var ids []uint
+	params := pagination.Params{Limit: 2}
+	pages := 0
+	for {
+		page, err := svc.GetUserTasksPage(ctx, 1, nil, params)
+		if err != nil {
+			t.Fatalf("GetUserTasksPage failed: %v", err)
+		}
+		pages++
+		for _, task := range page.Items {
+			ids = append(ids, task.ID)
+		}
+		if pages == 1 {
+			_ = mockRepos.Task().Create(ctx, &model.Task{UserID: 1, Title: "new", DueDate: time.Now(), Status: "pending"})
+		}
+		if !page.HasMore {
+			break
+		}
+		params, err = pagination.NewParams(2, page.NextCursor)
+		if err != nil {
+			t.Fatalf("NewParams failed: %v", err)
+		}
+	}
+	pending, err := svc.GetUserTasksPage(ctx, 1, filter.Spec{}.EqualIfSet("status", "pending"), pagination.Params{})
+
+	// Assert
+	if pages != 3 || len(ids) != 5 {
+		t.Fatalf("Expected 5 tasks in 3 pages, got %v in %d pages", ids, pages)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] >= ids[i-1] {
+			t.Errorf("Expected newest first without duplicates, got %v", ids)
+		}
+	}
+	if err != nil || len(pending.Items) != 4 || pending.HasMore {
+		t.Errorf("Expected 4 pending tasks in one page, got %+v (err=%v)", pending, err)
+	}
+}
+
+// TestGetUserTasksPageByDueDate はタスクの期限日順のページングのテストです。
+// 期待動作:
+//   - next_cursor をたどると全件を (期限日, ID) の昇順に重複なく取得する（期限日が同じタスクはIDの順）
+//   - 期限日順のページのカーソルは新しい順のページングには使えない
+func TestGetUserTasksPageByDueDate(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	for _, offset := range []int{3, 1, 2, 1, 0} {
+		_ = mockRepos.Task().Create(ctx, &model.Task{UserID: 1, Title: "task", DueDate: day.AddDate(0, 0, offset), Status: "pending"})
+	}
+
+	// Act
+	var ids []uint
+	var cursors []string
+	params := pagination.KeyParams{Limit: 2}
+	for pages := 0; pages < 10; pages++ {
+		page, err := svc.GetUserTasksPageByDueDate(ctx, 1, nil, params)
+		if err != nil {
+			t.Fatalf("GetUserTasksPageByDueDate failed: %v", err)
+		}
+		for _, task := range page.Items {
+			ids = append(ids, task.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		cursors = append(cursors, page.NextCursor)
+		params, err = pagination.NewKeyParams(2, page.NextCursor)
+		if err != nil {
+			t.Fatalf("NewKeyParams failed: %v", err)
+		}
+	}
+
+	// Assert
+	want := []uint{5, 2, 4, 3, 1}
+	if len(ids) != len(want) || len(cursors) != 2 {
+		t.Fatalf("Expected %v in 3 pages, got %v (%d cursors)", want, ids, len(cursors))
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("Expected tasks by (due date, id) %v, got %v", want, ids)
+			break
+		}
+	}
+	if _, err := pagination.NewParams(2, cursors[0]); err == nil {
+		t.Error("Expected the due date cursor to be rejected for the newest-first list")
+	}
+}
+
+// TestGetNotificationInboxPage は受信箱のカーソルページングのテストです。
+// 期待動作:
+//   - オフセットで取得したページの next_cursor から続きを取得できる
+//   - トランザクションメールは受信箱に含めない
+func TestGetNotificationInboxPage(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{UserID: 1, NotificationType: "task_due", Title: "t"})
+	}
+	_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{UserID: 1, NotificationType: "transactional_welcome", Title: "w"})
+
+	// Act
+	first, err := svc.GetNotificationInbox(ctx, 1, 2, 0, false)
+	if err != nil {
+		t.Fatalf("GetNotificationInbox failed: %v", err)
+	}
+	params, err := pagination.NewParams(2, first.NextCursor)
+	if err != nil {
+		t.Fatalf("Expected a next cursor on the first page, got %q (err=%v)", first.NextCursor, err)
+	}
+	rest, err := svc.GetNotificationInboxPage(ctx, 1, false, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetNotificationInboxPage failed: %v", err)
+	}
+	if len(rest.Items) != 1 || rest.HasMore || rest.NextCursor != "" {
+		t.Fatalf("Expected the last notification only, got %+v", rest)
+	}
+	if rest.Items[0].ID >= first.Items[1].ID || rest.Items[0].NotificationType != "task_due" {
+		t.Errorf("Expected the oldest inbox notification, got %+v", rest.Items[0])
+	}
+}
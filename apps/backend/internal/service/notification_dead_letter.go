@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Notification Dead Letters - 送信できなかった通知イベント
+// =============================================================================
+// 定期通知のうち、再試行しても送信できなかったイベントをエラー内容とともにデッドレター
+// （notification_dead_letters）に保存します。
+//   - アウトボックスのイベントが NotificationOutboxMaxAttempts 回失敗した（ユーザーを取得できないなど）
+//   - アウトボックスのイベントを読み取れない
+//   - 送信に失敗した通知が NotificationMaxRetries 回のリトライでも送信できなかった
+//
+// 管理者は一覧でエラー内容を確認し、原因を解消した後に再ドライブします。
+// 再ドライブしたイベントはアウトボックスに戻し、次回のディスパッチで送信します。
+
+// デッドレターの発生元
+const (
+	DeadLetterSourceOutbox          = "outbox"
+	DeadLetterSourceNotificationLog = "notification_log"
+)
+
+// デッドレターのステータス
+const (
+	DeadLetterStatusOpen     = "open"
+	DeadLetterStatusRedriven = "redriven"
+)
+
+// DefaultDeadLetterListLimit はデッドレター一覧のデフォルト取得件数
+const DefaultDeadLetterListLimit = 100
+
+var (
+	// ErrDeadLetterNotFound はデッドレターが存在しない場合のエラー
+	ErrDeadLetterNotFound = errors.New("notification dead letter not found")
+	// ErrDeadLetterAlreadyRedriven は再ドライブ済みのデッドレターを再ドライブしようとした場合のエラー
+	ErrDeadLetterAlreadyRedriven = errors.New("notification dead letter has already been redriven")
+)
+
+// recordDeadLetter は送信できなかった通知イベントをデッドレターに保存します。
+// 保存はベストエフォートで、失敗した場合は警告を出力します（発生元の記録は残ります）。
+func (h *notificationEventHandler) recordDeadLetter(ctx context.Context, letter *model.NotificationDeadLetter) {
+	letter.Status = DeadLetterStatusOpen
+	letter.ErrorMessage = truncateString(letter.ErrorMessage, 500)
+	if err := h.repos.NotificationDeadLetter().Create(ctx, letter); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record notification dead letter", "source", letter.Source, "source_id", letter.SourceID, "error", err)
+	}
+}
+
+// deadLetterFromOutbox は失敗したアウトボックスのイベントからデッドレターを作成します。
+func deadLetterFromOutbox(entry model.NotificationOutbox, attempts int, cause error) *model.NotificationDeadLetter {
+	return &model.NotificationDeadLetter{
+		UserID:       entry.UserID,
+		EventType:    entry.EventType,
+		Source:       DeadLetterSourceOutbox,
+		SourceID:     entry.ID,
+		Payload:      entry.Payload,
+		ErrorMessage: cause.Error(),
+		Attempts:     attempts,
+	}
+}
+
+// deadLetterFromLog は最大リトライ回数に達した通知ログからデッドレターを作成します。
+// 通知ログには通知イベントの一部（種類・タイトル・本文）のみ残るため、それらから通知イベントを再構築します。
+func deadLetterFromLog(log *model.NotificationLog) *model.NotificationDeadLetter {
+	payload, _ := json.Marshal(NotificationEvent{
+		Type:      NotificationEventType(log.NotificationType),
+		UserID:    log.UserID,
+		Title:     log.Title,
+		Body:      log.Body,
+		LocalDate: log.CreatedAt.Format("2006-01-02"),
+	})
+	return &model.NotificationDeadLetter{
+		UserID:       log.UserID,
+		EventType:    log.NotificationType,
+		Source:       DeadLetterSourceNotificationLog,
+		SourceID:     log.ID,
+		Payload:      string(payload),
+		ErrorMessage: log.ErrorMessage,
+		Attempts:     log.RetryCount + 1,
+	}
+}
+
+// ListNotificationDeadLetters はデッドレターを新しい順に取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - status: ステータスで絞り込む（open, redriven。空の場合は全件）
+//   - limit: 取得件数（0以下の場合は DefaultDeadLetterListLimit）
+func (s *Service) ListNotificationDeadLetters(ctx context.Context, status string, limit int) ([]model.NotificationDeadLetter, error) {
+	if limit <= 0 {
+		limit = DefaultDeadLetterListLimit
+	}
+	return s.repos.NotificationDeadLetter().List(ctx, status, limit)
+}
+
+// GetNotificationDeadLetter はデッドレターを取得します。
+//
+// 戻り値:
+//   - error: 存在しない場合は ErrDeadLetterNotFound
+func (s *Service) GetNotificationDeadLetter(ctx context.Context, id uint) (*model.NotificationDeadLetter, error) {
+	letter, err := s.repos.NotificationDeadLetter().GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDeadLetterNotFound
+	}
+	return letter, err
+}
+
+// RedriveNotificationDeadLetter はデッドレターの通知イベントをアウトボックスに戻し、再送信します。
+// 元のイベントの通知ログ（failed）と重複しないよう、再ドライブごとの重複防止キーで保存します。
+// イベントは次回のディスパッチ（notification_outbox ジョブ、POST /scheduler/notifications/outbox）で送信されます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - adminID: 再ドライブした管理者のユーザーID
+//   - id: デッドレターID
+//
+// 戻り値:
+//   - *model.NotificationDeadLetter: 再ドライブしたデッドレター（Status: redriven）
+//   - error: 存在しない場合は ErrDeadLetterNotFound、再ドライブ済みの場合は ErrDeadLetterAlreadyRedriven
+func (s *Service) RedriveNotificationDeadLetter(ctx context.Context, adminID, id uint) (*model.NotificationDeadLetter, error) {
+	letter, err := s.GetNotificationDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if letter.Status != DeadLetterStatusOpen {
+		return nil, ErrDeadLetterAlreadyRedriven
+	}
+
+	var event NotificationEvent
+	if err := json.Unmarshal([]byte(letter.Payload), &event); err != nil {
+		return nil, fmt.Errorf("failed to decode dead letter %d: %w", letter.ID, err)
+	}
+	if event.DeduplicationScope != "" {
+		event.DeduplicationScope += ","
+	}
+	event.DeduplicationScope += fmt.Sprintf("redrive-%d", letter.ID)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification event: %w", err)
+	}
+
+	now := s.now()
+	err = s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := s.repos.NotificationOutbox().Enqueue(txCtx, []model.NotificationOutbox{{
+			UserID:           event.UserID,
+			EventType:        string(event.Type),
+			DeduplicationKey: generateDeduplicationKey(event, nil, now),
+			Payload:          string(payload),
+			Status:           "pending",
+			AvailableAt:      now,
+		}}); err != nil {
+			return err
+		}
+		letter.Status = DeadLetterStatusRedriven
+		letter.RedrivenAt = &now
+		letter.RedrivenBy = adminID
+		return s.repos.NotificationDeadLetter().Update(txCtx, letter)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
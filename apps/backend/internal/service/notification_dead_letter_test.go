@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Notification Dead Letter Tests - 送信できなかった通知イベントのテスト
+// =============================================================================
+// テスト対象:
+//   - DispatchNotificationOutbox / RetryPendingNotifications: 再試行の上限に達したイベントのデッドレターへの保存
+//   - RedriveNotificationDeadLetter: デッドレターの通知イベントの再送信
+
+// TestDispatchNotificationOutbox_DeadLetter はアウトボックスのイベントのデッドレターのテストです。
+// 期待動作:
+//   - 最大試行回数に達したイベントをエラー内容とともにデッドレターに保存する
+//   - 読み取れないイベントをデッドレターに保存する
+func TestDispatchNotificationOutbox_DeadLetter(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	handler := NewNotificationEventHandler(svc, NewMockNotificationSender(), mockRepos).(*notificationEventHandler)
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	_, _ = mockRepos.NotificationOutbox().Enqueue(ctx, []model.NotificationOutbox{
+		{
+			UserID:           9999, // 存在しないユーザー
+			EventType:        string(NotificationEventTaskDueReminder),
+			DeduplicationKey: "dead-letter-test",
+			Payload:          `{"type":"task_due_reminder","user_id":9999,"title":"t","body":"b"}`,
+			Status:           "pending",
+			Attempts:         NotificationOutboxMaxAttempts - 1,
+			AvailableAt:      past,
+		},
+		{
+			UserID:           9999,
+			EventType:        string(NotificationEventTaskDueReminder),
+			DeduplicationKey: "undecodable-test",
+			Payload:          `{"type":`,
+			Status:           "pending",
+			AvailableAt:      past,
+		},
+	})
+
+	// Act
+	result, err := handler.DispatchNotificationOutbox(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DispatchNotificationOutbox failed: %v", err)
+	}
+	if result.DeadLettered != 2 {
+		t.Errorf("Expected 2 dead-lettered events, got %d", result.DeadLettered)
+	}
+	letters := mockRepos.GetMockNotificationDeadLetterRepository().Letters
+	if len(letters) != 2 {
+		t.Fatalf("Expected 2 dead letters, got %d", len(letters))
+	}
+	for _, letter := range letters {
+		if letter.Source != DeadLetterSourceOutbox || letter.Status != DeadLetterStatusOpen || letter.ErrorMessage == "" {
+			t.Errorf("Unexpected dead letter: %+v", letter)
+		}
+	}
+}
+
+// TestRedriveNotificationDeadLetter はリトライの上限に達した通知の再送信のテストです。
+// 期待動作:
+//   - 最大リトライ回数に達した通知ログをデッドレターに保存する
+//   - 再ドライブしたイベントは元の通知ログと重複せずにアウトボックスから送信される
+//   - 再ドライブ済みのデッドレターは再ドライブできない
+func TestRedriveNotificationDeadLetter(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	user := &model.User{Email: "deadletter@example.com", Timezone: "UTC"}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	mockSender.ShouldFail = true
+	event := NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: user.ID, Title: "リマインダー", Body: "水やり"}
+	if err := handler.HandleEvent(ctx, event); err == nil {
+		t.Fatal("Expected send error")
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	past := time.Now().Add(-time.Minute)
+	logs[0].RetryCount = NotificationMaxRetries - 1
+	logs[0].NextRetryAt = &past
+	_ = mockRepos.NotificationLog().Update(ctx, &logs[0])
+
+	// Act
+	retryResult, err := handler.RetryPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	letters, _ := svc.ListNotificationDeadLetters(ctx, DeadLetterStatusOpen, 0)
+	if retryResult.DeadLettered != 1 || len(letters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d (result=%d)", len(letters), retryResult.DeadLettered)
+	}
+	mockSender.ShouldFail = false
+	redriven, redriveErr := svc.RedriveNotificationDeadLetter(ctx, 1, letters[0].ID)
+	dispatchResult, dispatchErr := handler.(*notificationEventHandler).DispatchNotificationOutbox(ctx)
+
+	// Assert
+	if redriveErr != nil || dispatchErr != nil {
+		t.Fatalf("Redrive failed: %v / %v", redriveErr, dispatchErr)
+	}
+	if redriven.Status != DeadLetterStatusRedriven || redriven.RedrivenAt == nil || redriven.RedrivenBy != 1 {
+		t.Errorf("Unexpected redriven dead letter: %+v", redriven)
+	}
+	if letters[0].Source != DeadLetterSourceNotificationLog || letters[0].UserID != user.ID {
+		t.Errorf("Unexpected dead letter: %+v", letters[0])
+	}
+	if dispatchResult.SuccessfulSends != 1 || len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected redriven event to be sent, got %d sent (errors=%v)", dispatchResult.SuccessfulSends, dispatchResult.Errors)
+	}
+	if _, err := svc.RedriveNotificationDeadLetter(ctx, 1, letters[0].ID); !errors.Is(err, ErrDeadLetterAlreadyRedriven) {
+		t.Errorf("Expected ErrDeadLetterAlreadyRedriven, got %v", err)
+	}
+	if _, err := svc.RedriveNotificationDeadLetter(ctx, 1, 9999); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("Expected ErrDeadLetterNotFound, got %v", err)
+	}
+}
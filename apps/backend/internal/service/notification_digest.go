@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Notification Digest - 通知ダイジェスト
+// =============================================================================
+// スケジューラーが同じユーザーに複数の通知イベント（期限切れ警告・当日タスク・収穫リマインダー）を
+// 生成した場合に、ダイジェストを希望するユーザー（NotificationSettings.DigestNotifications）には
+// 1件のプッシュ通知・メールにまとめて送信します。
+//
+// ダイジェストの重複防止キーは daily_digest:{user_id}:{date} のため、
+// ダイジェストを希望するユーザーへの定期通知はローカル日付ごとに1回になります。
+
+// wantsDigest はユーザーがダイジェスト通知を希望しているかを返します。
+func wantsDigest(user *model.User) bool {
+	return user != nil && user.NotificationSettings != nil && user.NotificationSettings.DigestNotifications
+}
+
+// groupEventsByUser は通知イベントをユーザーごとにまとめます（ユーザーの出現順を保持）。
+func groupEventsByUser(events []NotificationEvent) ([]uint, map[uint][]NotificationEvent) {
+	var order []uint
+	byUser := make(map[uint][]NotificationEvent)
+	for _, event := range events {
+		if _, ok := byUser[event.UserID]; !ok {
+			order = append(order, event.UserID)
+		}
+		byUser[event.UserID] = append(byUser[event.UserID], event)
+	}
+	return order, byUser
+}
+
+// buildDigestEvent は同じユーザーの通知イベントを1件のダイジェストイベントにまとめます。
+// イベントが1件の場合はタイトルと本文をそのまま使用します。
+//
+// 引数:
+//   - events: 同じユーザーの通知イベント（1件以上）
+//   - locale: ダイジェストのタイトル・本文の言語
+//
+// 戻り値:
+//   - NotificationEvent: ダイジェストイベント
+func buildDigestEvent(events []NotificationEvent, locale string) NotificationEvent {
+	first := events[0]
+	msg := localizedMessage(locale, NotificationEventDailyDigest)
+	digest := NotificationEvent{
+		Type:      NotificationEventDailyDigest,
+		UserID:    first.UserID,
+		UserEmail: first.UserEmail,
+		Title:     first.Title,
+		Body:      first.Body,
+		LocalDate: first.LocalDate,
+	}
+
+	eventTypes := make([]string, 0, len(events))
+	data := map[string]interface{}{"digest": true}
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		eventTypes = append(eventTypes, string(event.Type))
+		if event.Data != nil {
+			data[string(event.Type)] = event.Data
+		}
+		lines = append(lines, fmt.Sprintf(msg.Body, event.Title, event.Body))
+	}
+	data["event_types"] = eventTypes
+	digest.Data = data
+
+	if len(events) > 1 {
+		digest.Title = fmt.Sprintf(msg.Title, len(events))
+		digest.Body = strings.Join(lines, "\n")
+	}
+	return digest
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/logging"
 	"github.com/secure-scorecard/backend/internal/model"
 	"github.com/secure-scorecard/backend/internal/repository"
 )
@@ -25,6 +27,49 @@ type NotificationEventHandler interface {
 
 	// ProcessScheduledNotificationsAndSend はスケジューラー処理と通知送信を実行します。
 	ProcessScheduledNotificationsAndSend(ctx context.Context) (*NotificationProcessResult, error)
+
+	// DispatchNotificationOutbox はアウトボックスの送信待ちの通知イベントを送信します。
+	DispatchNotificationOutbox(ctx context.Context) (*NotificationProcessResult, error)
+
+	// RetryPendingNotifications は送信に失敗した通知を再送信します。
+	RetryPendingNotifications(ctx context.Context) (*NotificationRetryResult, error)
+
+	// DeliverAnnouncements は配信予定日時を過ぎた管理者からのお知らせを配信します。
+	DeliverAnnouncements(ctx context.Context) (*AnnouncementDeliveryResult, error)
+
+	// RunSeasonRollover はシーズンを締め、菜園のふりかえりを通知します。
+	RunSeasonRollover(ctx context.Context, season int) (*SeasonRolloverResult, error)
+}
+
+const (
+	// NotificationMaxRetries は通知の最大リトライ回数です。
+	// 到達した通知は failed（デッドレター）になります。
+	// NotificationLogRepository.GetPendingNotifications の条件と一致させています。
+	NotificationMaxRetries = 3
+
+	// NotificationRetryBaseDelay は初回リトライまでの待機時間です。
+	// 以降はリトライごとに2倍になります（5分、10分、20分）。
+	NotificationRetryBaseDelay = 5 * time.Minute
+
+	// NotificationRetryBatchSize は1回のリトライ処理で扱う最大件数です。
+	NotificationRetryBatchSize = 100
+)
+
+// NotificationRetryResult は通知リトライ処理の結果を表します。
+type NotificationRetryResult struct {
+	ProcessedAt       time.Time `json:"processed_at"`
+	Attempted         int       `json:"attempted"`          // 再送信を試みた件数
+	Succeeded         int       `json:"succeeded"`          // 再送信に成功した件数
+	Rescheduled       int       `json:"rescheduled"`        // 失敗し次回リトライを予約した件数
+	Deferred          int       `json:"deferred"`           // おやすみモード中のため再度保留した件数
+	DeadLettered      int       `json:"dead_lettered"`      // 最大リトライ回数に達しfailedにした件数
+	OverflowSummaries int       `json:"overflow_summaries"` // 送信数の上限で送らなかったプッシュ通知のまとめ通知を送った件数
+	Errors            []string  `json:"errors,omitempty"`
+}
+
+// notificationRetryDelay はリトライ回数に応じた待機時間を返します（Exponential backoff）。
+func notificationRetryDelay(retryCount int) time.Duration {
+	return NotificationRetryBaseDelay * time.Duration(1<<retryCount)
 }
 
 // NotificationProcessResult は通知処理の結果を表します。
@@ -33,15 +78,32 @@ type NotificationProcessResult struct {
 	TotalEvents     int       `json:"total_events"`
 	SuccessfulSends int       `json:"successful_sends"`
 	FailedSends     int       `json:"failed_sends"`
-	SkippedSends    int       `json:"skipped_sends"` // 設定で無効化されたもの
+	SkippedSends    int       `json:"skipped_sends"`             // 設定で無効化されたもの
+	DeferredSends   int       `json:"deferred_sends"`            // おやすみモードのため保留したもの
+	DuplicateSends  int       `json:"duplicate_sends"`           // 重複防止キーの通知ログがあるため送信しなかったもの
+	UserJobs        int       `json:"user_jobs,omitempty"`       // 処理したユーザーごとのリマインダージョブの数（定期通知のみ）
+	UserPages       int       `json:"user_pages,omitempty"`      // 処理したユーザーのページ数（定期通知のみ）
+	EnqueuedEvents  int       `json:"enqueued_events,omitempty"` // アウトボックスに保存した通知イベントの数（定期通知のみ）
+	DeadLettered    int       `json:"dead_lettered,omitempty"`   // 再試行の上限に達したためデッドレターに保存した数
 	Errors          []string  `json:"errors,omitempty"`
 }
 
+// eventOutcome は通知イベント1件の処理結果です。
+type eventOutcome int
+
+const (
+	eventSent       eventOutcome = iota // 送信した（失敗した場合はエラーを返す）
+	eventDeferred                       // おやすみモードのため保留した
+	eventDuplicate                      // 同じ重複防止キーの通知ログがあるため送信しなかった
+	eventUnrecorded                     // 通知ログを記録する前に失敗した（リトライ処理の対象にならない）
+)
+
 // notificationEventHandler はNotificationEventHandlerの実装です。
 type notificationEventHandler struct {
 	service *Service
 	sender  NotificationSender
 	repos   repository.Repositories
+	metrics *NotificationMetrics // チャネルごとの送信結果のカウンター
 }
 
 // NewNotificationEventHandler は新しいNotificationEventHandlerを作成します。
@@ -58,11 +120,13 @@ func NewNotificationEventHandler(service *Service, sender NotificationSender, re
 		service: service,
 		sender:  sender,
 		repos:   repos,
+		metrics: DefaultNotificationMetrics,
 	}
 }
 
 // HandleEvent は単一の通知イベントを処理します。
 // ユーザー情報とデバイストークンを取得し、通知を送信します。
+// ユーザーのおやすみモード中は送信せず、deferred として通知ログに記録します。
 //
 // 引数:
 //   - ctx: コンテキスト
@@ -71,17 +135,25 @@ func NewNotificationEventHandler(service *Service, sender NotificationSender, re
 // 戻り値:
 //   - error: 処理に失敗した場合のエラー
 func (h *notificationEventHandler) HandleEvent(ctx context.Context, event NotificationEvent) error {
+	_, err := h.handleEvent(ctx, event)
+	return err
+}
+
+// handleEvent は通知イベントを処理し、処理結果（送信・保留・重複スキップ）を返します。
+func (h *notificationEventHandler) handleEvent(ctx context.Context, event NotificationEvent) (eventOutcome, error) {
 	// ユーザー情報を取得
-	user, err := h.repos.User().GetByID(ctx, event.UserID)
+	user, err := h.getUserWithPreferences(ctx, event.UserID)
 	if err != nil {
-		return fmt.Errorf("failed to get user %d: %w", event.UserID, err)
+		return eventUnrecorded, fmt.Errorf("failed to get user %d: %w", event.UserID, err)
 	}
 
-	// 重複チェック
-	deduplicationKey := generateDeduplicationKey(event)
-	isDuplicate, err := h.service.CheckDeduplication(ctx, deduplicationKey)
-	if err == nil && isDuplicate {
-		return nil // 重複のためスキップ
+	// 重複チェック（期限切れでない同じキーの通知ログがあれば送信しない）
+	// 送信失敗（pending）や保留（deferred）のログも対象とし、再送信はリトライ処理に任せる
+	// 送信しなかった件数は既存の通知ログの DuplicateCount に記録する
+	deduplicationKey := generateDeduplicationKey(event, user, h.service.now())
+	if existing, err := h.repos.NotificationLog().GetByDeduplicationKey(ctx, deduplicationKey); err == nil && existing != nil {
+		h.recordDuplicate(ctx, existing)
+		return eventDuplicate, nil
 	}
 
 	// デバイストークンを取得
@@ -91,39 +163,81 @@ func (h *notificationEventHandler) HandleEvent(ctx context.Context, event Notifi
 		tokens = []model.DeviceToken{}
 	}
 
-	// 通知を送信
-	sendErr := h.sender.SendNotificationEvent(ctx, event, user, tokens)
+	// おやすみモード中は送信せず、終了日時にリトライ処理で配信する
+	if deferUntil, quiet := quietHoursEnd(user, h.service.now()); quiet {
+		channels := NotificationChannels(event, user, tokens)
+		log := &model.NotificationLog{
+			UserID:           event.UserID,
+			NotificationType: string(event.Type),
+			Channel:          strings.Join(channels, ","),
+			ChannelStatus:    uniformChannelStatus(channels, NotificationOutcomeDeferred),
+			Title:            event.Title,
+			Body:             event.Body,
+			Status:           "deferred",
+			NextRetryAt:      &deferUntil,
+			DeduplicationKey: deduplicationKey,
+			ExpiresAt:        h.service.now().Add(24 * time.Hour),
+		}
+		if logErr := h.service.CreateNotificationLog(ctx, log); logErr != nil {
+			// ログに残せない場合は配信できなくなるためエラーとする
+			return eventUnrecorded, fmt.Errorf("failed to defer notification: %w", logErr)
+		}
+		h.recordDeliveryMetrics(log.ChannelStatus)
+		return eventDeferred, nil
+	}
+
+	// プッシュ通知の送信数の上限に達している場合はプッシュ通知を送らず、まとめ通知の対象にする
+	pushRateLimited := h.applyPushRateLimit(ctx, &event, user, tokens)
+
+	// 通知を送信（無効と判定されたデバイストークンは無効化し、リトライ対象にしない）
+	sendErr := h.deactivateInvalidTokens(ctx, h.sender.SendNotificationEvent(ctx, event, user, tokens))
 
 	// 通知ログを記録
+	// 送信失敗時は pending としてリトライ待ちにする（RetryPendingNotifications で再送信）
 	status := "sent"
 	var errorMessage string
+	var nextRetryAt *time.Time
 	if sendErr != nil {
-		status = "failed"
-		errorMessage = sendErr.Error()
+		status = "pending"
+		errorMessage = truncateString(sendErr.Error(), 500)
+		retryAt := h.service.now().Add(notificationRetryDelay(0))
+		nextRetryAt = &retryAt
 	}
 
+	channels := NotificationChannels(event, user, tokens)
 	log := &model.NotificationLog{
 		UserID:           event.UserID,
 		NotificationType: string(event.Type),
-		Channel:          "push,email",
+		Channel:          strings.Join(channels, ","),
+		ChannelStatus:    channelDeliveryStatus(channels, sendErr),
 		Title:            event.Title,
 		Body:             event.Body,
 		Status:           status,
 		ErrorMessage:     errorMessage,
+		NextRetryAt:      nextRetryAt,
 		DeduplicationKey: deduplicationKey,
-		ExpiresAt:        time.Now().Add(24 * time.Hour),
+		PushRateLimited:  pushRateLimited,
+		ExpiresAt:        h.service.now().Add(24 * time.Hour),
 	}
 	if status == "sent" {
-		now := time.Now()
+		now := h.service.now()
 		log.SentAt = &now
 	}
 
+	// 利用統計を記録（失敗しても通知処理は継続）
+	usageMetric := UsageMetricNotificationsSent
+	if sendErr != nil {
+		usageMetric = UsageMetricNotificationsFailed
+	}
+	_ = h.service.IncrementUsage(ctx, usageMetric, 1)
+	h.recordDeliveryMetrics(log.ChannelStatus)
+
 	if logErr := h.service.CreateNotificationLog(ctx, log); logErr != nil {
 		// ログ記録失敗は警告レベルとして処理を継続
-		fmt.Printf("warning: failed to create notification log: %v\n", logErr)
+		logging.FromContext(ctx).Warn("Failed to create notification log", "error", logErr)
 	}
 
-	return sendErr
+	return eventSent, sendErr
 }
 
 // HandleEvents は複数の通知イベントを処理します。
@@ -138,29 +252,58 @@ func (h *notificationEventHandler) HandleEvent(ctx context.Context, event Notifi
 //   - error: 致命的なエラーが発生した場合
 func (h *notificationEventHandler) HandleEvents(ctx context.Context, events []NotificationEvent) (*NotificationProcessResult, error) {
 	result := &NotificationProcessResult{
-		ProcessedAt: time.Now(),
+		ProcessedAt: h.service.now(),
 		TotalEvents: len(events),
 		Errors:      make([]string, 0),
 	}
 
 	for _, event := range events {
-		if err := h.HandleEvent(ctx, event); err != nil {
-			result.FailedSends++
-			result.Errors = append(result.Errors, fmt.Sprintf("event %s for user %d: %v", event.Type, event.UserID, err))
-		} else {
-			result.SuccessfulSends++
-		}
+		outcome, err := h.handleEvent(ctx, event)
+		result.record(event, outcome, err)
 	}
 
 	return result, nil
 }
 
+// record は通知イベント1件の処理結果を集計します。
+func (r *NotificationProcessResult) record(event NotificationEvent, outcome eventOutcome, err error) {
+	switch {
+	case err != nil:
+		r.FailedSends++
+		r.Errors = append(r.Errors, fmt.Sprintf("event %s for user %d: %v", event.Type, event.UserID, err))
+	case outcome == eventDeferred:
+		r.DeferredSends++
+	case outcome == eventDuplicate:
+		r.DuplicateSends++
+	default:
+		r.SuccessfulSends++
+	}
+}
+
+// merge は別の処理結果の件数とエラーを加算します。
+func (r *NotificationProcessResult) merge(other *NotificationProcessResult) {
+	r.TotalEvents += other.TotalEvents
+	r.SuccessfulSends += other.SuccessfulSends
+	r.FailedSends += other.FailedSends
+	r.SkippedSends += other.SkippedSends
+	r.DeferredSends += other.DeferredSends
+	r.DuplicateSends += other.DuplicateSends
+	r.UserJobs += other.UserJobs
+	r.EnqueuedEvents += other.EnqueuedEvents
+	r.DeadLettered += other.DeadLettered
+	r.Errors = append(r.Errors, other.Errors...)
+}
+
 // ProcessScheduledNotificationsAndSend はスケジューラー処理と通知送信を実行します。
 // このメソッドはEventBridge Schedulerから定期的に呼び出されます。
 //
-// 処理フロー:
-//  1. ProcessScheduledNotifications を呼び出してイベントを生成
-//  2. 生成されたイベントを HandleEvents で処理
+// 処理フロー（ユーザーをID順のページに分けて、ページごとに 1〜4 を繰り返す）:
+//  1. 毎時0分のスロットを基準にページのユーザーごとのリマインダージョブを作成
+//  2. ダイジェストを希望するユーザーのイベントを1件にまとめ、トランザクション内でアウトボックスに保存
+//  3. DispatchNotificationOutbox でアウトボックスの送信待ちのイベントを送信（以前の実行で送信できなかったものを含む）
+//  4. 処理を終えたページの最後のユーザーIDを進捗（scheduler_checkpoints）に保存
+//
+// 同じスロットの前回の実行が途中で止まった場合（タイムアウト・再起動）は、保存した進捗の続きのページから再開します。
 //
 // 引数:
 //   - ctx: コンテキスト
@@ -169,28 +312,241 @@ func (h *notificationEventHandler) HandleEvents(ctx context.Context, events []No
 //   - *NotificationProcessResult: 処理結果
 //   - error: 致命的なエラーが発生した場合
 func (h *notificationEventHandler) ProcessScheduledNotificationsAndSend(ctx context.Context) (*NotificationProcessResult, error) {
-	// 1. スケジューラー処理でイベントを生成
-	schedulerResult, err := h.service.ProcessScheduledNotifications(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to process scheduled notifications: %w", err)
+	slot := reminderSlot(h.service.now())
+	checkpoint := h.service.reminderCheckpoint(ctx, slot)
+
+	result := &NotificationProcessResult{
+		ProcessedAt: h.service.now(),
+		Errors:      make([]string, 0),
 	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-	// 2. 生成されたイベントを処理
-	result, err := h.HandleEvents(ctx, schedulerResult.Events)
+		// 1. スケジューラー処理でページのユーザーごとのジョブを作成
+		jobs, lastUserID, err := h.service.buildReminderJobsPage(ctx, slot, checkpoint.LastUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process scheduled notifications: %w", err)
+		}
+		if lastUserID == 0 {
+			break
+		}
+
+		// 2. アウトボックスに保存
+		enqueued, err := h.enqueueReminderJobs(ctx, jobs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enqueue notification events: %w", err)
+		}
+
+		// 3. 送信待ちのイベントを送信
+		sent, err := h.DispatchNotificationOutbox(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sent.EnqueuedEvents = int(enqueued)
+		result.merge(sent)
+		result.UserPages++
+
+		// 4. 進捗を保存
+		checkpoint.LastUserID = lastUserID
+		checkpoint.Pages++
+		h.service.saveReminderCheckpoint(ctx, checkpoint)
+	}
+
+	// 処理するユーザーがいない場合も以前の実行で送信できなかったイベントを送信する
+	if result.UserPages == 0 {
+		sent, err := h.DispatchNotificationOutbox(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.merge(sent)
+	}
+
+	completedAt := h.service.now()
+	checkpoint.CompletedAt = &completedAt
+	h.service.saveReminderCheckpoint(ctx, checkpoint)
+	return result, nil
+}
+
+// batchDigestEvents はダイジェストを希望するユーザーのイベントを1件のダイジェストにまとめます。
+// ユーザー情報を取得できない場合は個別のイベントのまま返します（HandleEvent でエラーになります）。
+func (h *notificationEventHandler) batchDigestEvents(ctx context.Context, events []NotificationEvent) []NotificationEvent {
+	order, byUser := groupEventsByUser(events)
+
+	batched := make([]NotificationEvent, 0, len(events))
+	for _, userID := range order {
+		userEvents := byUser[userID]
+		user, err := h.repos.User().GetByID(ctx, userID)
+		if err != nil || !wantsDigest(user) {
+			batched = append(batched, userEvents...)
+			continue
+		}
+		batched = append(batched, buildDigestEvent(userEvents, userLocale(user)))
+	}
+	return batched
+}
+
+// RetryPendingNotifications は送信待ちの通知ログを再送信します。
+// AWS EventBridge Scheduler から定期的（例: 5分ごと）に呼び出されることを想定しています。
+//
+// 処理内容:
+//   - 次回リトライ予定日時を過ぎた pending / deferred の通知を取得
+//   - ユーザーがおやすみモード中の場合は送信せず、終了日時まで deferred として保留
+//   - 再送信に成功した場合は sent に更新
+//   - 失敗した場合は RetryCount を加算し、次回リトライ日時を指数的に延長
+//   - RetryCount が NotificationMaxRetries に達した場合は failed（デッドレター）に更新
+//   - 送信数の上限で送らなかったプッシュ通知を、上限を下回ったユーザーへまとめ通知として送信
+//
+// 引数:
+//   - ctx: コンテキスト
+//
+// 戻り値:
+//   - *NotificationRetryResult: 処理結果
+//   - error: 対象の取得に失敗した場合のエラー
+func (h *notificationEventHandler) RetryPendingNotifications(ctx context.Context) (*NotificationRetryResult, error) {
+	logs, err := h.repos.NotificationLog().GetPendingNotifications(ctx, NotificationRetryBatchSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to handle events: %w", err)
+		return nil, fmt.Errorf("failed to get pending notifications: %w", err)
+	}
+
+	result := &NotificationRetryResult{
+		ProcessedAt: h.service.now(),
+		Errors:      make([]string, 0),
+	}
+
+	for i := range logs {
+		log := &logs[i]
+
+		user, err := h.getUserWithPreferences(ctx, log.UserID)
+		var sendErr error
+		if err != nil {
+			sendErr = fmt.Errorf("failed to get user %d: %w", log.UserID, err)
+		} else if deferUntil, quiet := quietHoursEnd(user, h.service.now()); quiet {
+			// 設定変更などで再びおやすみモード中になった場合は保留し直す（リトライ回数は加算しない）
+			log.Status = "deferred"
+			log.NextRetryAt = &deferUntil
+			log.ChannelStatus = uniformChannelStatus(logChannels(log), NotificationOutcomeDeferred)
+			h.recordDeliveryMetrics(log.ChannelStatus)
+			result.Deferred++
+			if err := h.repos.NotificationLog().Update(ctx, log); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("notification %d: failed to update log: %v", log.ID, err))
+			}
+			continue
+		} else {
+			sendErr = h.resendNotification(ctx, log, user)
+		}
+
+		result.Attempted++
+		log.ChannelStatus = channelDeliveryStatus(logChannels(log), sendErr)
+		h.recordDeliveryMetrics(log.ChannelStatus)
+		if sendErr == nil {
+			now := h.service.now()
+			log.Status = "sent"
+			log.SentAt = &now
+			log.NextRetryAt = nil
+			log.ErrorMessage = ""
+			result.Succeeded++
+			_ = h.service.IncrementUsage(ctx, UsageMetricNotificationsSent, 1)
+		} else {
+			log.Status = "pending"
+			log.RetryCount++
+			log.ErrorMessage = truncateString(sendErr.Error(), 500)
+			if log.RetryCount >= NotificationMaxRetries {
+				log.Status = "failed"
+				log.NextRetryAt = nil
+				result.DeadLettered++
+				h.recordDeadLetter(ctx, deadLetterFromLog(log))
+			} else {
+				retryAt := h.service.now().Add(notificationRetryDelay(log.RetryCount))
+				log.NextRetryAt = &retryAt
+				result.Rescheduled++
+			}
+			result.Errors = append(result.Errors, fmt.Sprintf("notification %d for user %d: %v", log.ID, log.UserID, sendErr))
+			_ = h.service.IncrementUsage(ctx, UsageMetricNotificationsFailed, 1)
+		}
+
+		if err := h.repos.NotificationLog().Update(ctx, log); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("notification %d: failed to update log: %v", log.ID, err))
+		}
 	}
 
+	h.sendPushOverflowSummaries(ctx, result)
+
 	return result, nil
 }
 
+// recordDuplicate は重複防止キーにより送信しなかった通知を既存の通知ログの DuplicateCount に記録します。
+// 記録に失敗しても通知処理は継続します。
+func (h *notificationEventHandler) recordDuplicate(ctx context.Context, log *model.NotificationLog) {
+	log.DuplicateCount++
+	if err := h.repos.NotificationLog().Update(ctx, log); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record duplicate notification", "notification_log_id", log.ID, "error", err)
+	}
+	for _, channel := range logChannels(log) {
+		h.metrics.Inc(channel, NotificationOutcomeDeduped)
+	}
+}
+
+// logChannels は通知ログの送信対象チャネルを返します。
+func logChannels(log *model.NotificationLog) []string {
+	if log.Channel == "" {
+		return nil
+	}
+	return strings.Split(log.Channel, ",")
+}
+
+// getUserWithPreferences はユーザーをイベントタイプ×チャネルの通知設定とともに取得します。
+// 通知設定の取得に失敗した場合は NotificationSettings の既定値で送信します。
+func (h *notificationEventHandler) getUserWithPreferences(ctx context.Context, userID uint) (*model.User, error) {
+	user, err := h.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs, err := h.repos.NotificationPreference().GetByUserID(ctx, userID); err == nil {
+		user.NotificationPreferences = prefs
+	}
+	return user, nil
+}
+
+// resendNotification は通知ログの内容から通知イベントを再構築して送信します。
+// プッシュ通知の送信数の上限に達している場合は、通知ログをまとめ通知の対象にします。
+func (h *notificationEventHandler) resendNotification(ctx context.Context, log *model.NotificationLog, user *model.User) error {
+	tokens, err := h.repos.DeviceToken().GetActiveByUserID(ctx, log.UserID)
+	if err != nil {
+		tokens = []model.DeviceToken{}
+	}
+
+	event := NotificationEvent{
+		Type:      NotificationEventType(log.NotificationType),
+		UserID:    log.UserID,
+		UserEmail: user.Email,
+		Title:     log.Title,
+		Body:      log.Body,
+	}
+	if h.applyPushRateLimit(ctx, &event, user, tokens) {
+		log.PushRateLimited = true
+		log.Channel = strings.Join(NotificationChannels(event, user, tokens), ",")
+	}
+	return h.deactivateInvalidTokens(ctx, h.sender.SendNotificationEvent(ctx, event, user, tokens))
+}
+
 // generateDeduplicationKey は通知イベントの重複防止キーを生成します。
 // 24時間以内に同じキーで送信された通知はスキップされます。
 //
-// キーのフォーマット: {event_type}:{user_id}:{date}
-func generateDeduplicationKey(event NotificationEvent) string {
-	today := time.Now().Format("2006-01-02")
-	return fmt.Sprintf("%s:%d:%s", event.Type, event.UserID, today)
+// キーのフォーマット: {event_type}:{user_id}:{date}（DeduplicationScope がある場合は :{scope} を追加）
+// date はイベントの LocalDate、未設定の場合は now のユーザーのタイムゾーンでの日付です。
+// 同じユーザー・同じローカル日付の同じイベントタイプは、生成元によらず同じキーになります。
+func generateDeduplicationKey(event NotificationEvent, user *model.User, now time.Time) string {
+	date := event.LocalDate
+	if date == "" {
+		date = now.In(userLocation(user)).Format("2006-01-02")
+	}
+	key := fmt.Sprintf("%s:%d:%s", event.Type, event.UserID, date)
+	if event.DeduplicationScope != "" {
+		key = truncateString(key+":"+event.DeduplicationScope, 100)
+	}
+	return key
 }
 
 // =============================================================================
@@ -202,7 +558,7 @@ func generateDeduplicationKey(event NotificationEvent) string {
 // このメソッドはhandler層から呼び出されます。
 
 // ProcessDailyNotifications は日次通知処理を実行します。
-// EventBridge Scheduler から毎日呼び出されることを想定しています。
+// EventBridge Scheduler から1時間ごとに呼び出されることを想定しています（ユーザーごとの送信時刻で判定）。
 //
 // 処理内容:
 //   - 期限切れタスクの警告通知（3件以上の場合）
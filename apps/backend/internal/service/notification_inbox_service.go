@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/pagination"
+)
+
+// =============================================================================
+// Notification Inbox - アプリ内通知受信箱
+// =============================================================================
+// NotificationLog をアプリ内の受信箱として参照します。
+// プッシュ通知の配信状況（pending/sent/failed）に関わらず、記録された通知は全て受信箱に表示されます。
+
+var (
+	// ErrNotificationNotOwned は他ユーザーの通知を操作しようとした場合のエラー
+	ErrNotificationNotOwned = errors.New("notification does not belong to user")
+)
+
+const (
+	// DefaultNotificationInboxLimit は受信箱の1ページあたりのデフォルト件数
+	DefaultNotificationInboxLimit = 20
+	// MaxNotificationInboxLimit は受信箱の1ページあたりの最大件数
+	MaxNotificationInboxLimit = 100
+)
+
+// NotificationInboxItem は受信箱の通知1件を表します。
+type NotificationInboxItem struct {
+	ID               uint       `json:"id"`
+	NotificationType string     `json:"notification_type"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	Unread           bool       `json:"unread"`
+	ReadAt           *time.Time `json:"read_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// NotificationInbox は受信箱の1ページ分を表します。
+type NotificationInbox struct {
+	Items       []NotificationInboxItem `json:"items"`
+	Total       int64                   `json:"total"`        // 条件に一致する総件数
+	UnreadCount int64                   `json:"unread_count"` // 未読の総件数（unreadOnlyに関わらず）
+	Limit       int                     `json:"limit"`
+	Offset      int                     `json:"offset"`
+	NextCursor  string                  `json:"next_cursor,omitempty"` // 続きを GetNotificationInboxPage で取得するカーソル（最後のページでは省略）
+}
+
+// newNotificationInboxItem は通知ログを受信箱アイテムに変換します。
+func newNotificationInboxItem(log *model.NotificationLog) NotificationInboxItem {
+	return NotificationInboxItem{
+		ID:               log.ID,
+		NotificationType: log.NotificationType,
+		Title:            log.Title,
+		Body:             log.Body,
+		Unread:           log.ReadAt == nil,
+		ReadAt:           log.ReadAt,
+		CreatedAt:        log.CreatedAt,
+	}
+}
+
+// GetNotificationInbox はユーザーの受信箱を最新順に取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - limit: 取得件数（0以下はデフォルト、最大 MaxNotificationInboxLimit）
+//   - offset: 取得開始位置（負の値は0）
+//   - unreadOnly: trueの場合は未読のみ取得
+//
+// 戻り値:
+//   - *NotificationInbox: 受信箱の1ページ分
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetNotificationInbox(ctx context.Context, userID uint, limit, offset int, unreadOnly bool) (*NotificationInbox, error) {
+	if limit <= 0 {
+		limit = DefaultNotificationInboxLimit
+	}
+	if limit > MaxNotificationInboxLimit {
+		limit = MaxNotificationInboxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	logs, total, err := s.repos.NotificationLog().GetInboxByUserID(ctx, userID, limit, offset, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.repos.NotificationLog().CountUnreadByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NotificationInboxItem, 0, len(logs))
+	for i := range logs {
+		items = append(items, newNotificationInboxItem(&logs[i]))
+	}
+
+	inbox := &NotificationInbox{
+		Items:       items,
+		Total:       total,
+		UnreadCount: unread,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	if len(items) > 0 && int64(offset+len(items)) < total {
+		inbox.NextCursor = pagination.EncodeCursor(items[len(items)-1].ID)
+	}
+	return inbox, nil
+}
+
+// GetNotificationInboxPage はユーザーの受信箱をカーソルページングで1ページ分取得します（新しい順）。
+// オフセットによる GetNotificationInbox と異なり、ページを読む間に通知が届いても重複・抜けが起きません。
+// 総件数・未読数は含まないため、未読数は GetUnreadNotificationCount で取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - unreadOnly: trueの場合は未読のみ取得
+//   - params: ページングの指定
+//
+// 戻り値:
+//   - *pagination.Page[NotificationInboxItem]: 受信箱の1ページ分
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetNotificationInboxPage(ctx context.Context, userID uint, unreadOnly bool, params pagination.Params) (*pagination.Page[NotificationInboxItem], error) {
+	logs, err := s.repos.NotificationLog().ListInboxByUserIDPaginated(ctx, userID, unreadOnly, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NotificationInboxItem, 0, len(logs))
+	for i := range logs {
+		items = append(items, newNotificationInboxItem(&logs[i]))
+	}
+	return pagination.NewPage(items, params, func(item NotificationInboxItem) uint { return item.ID }), nil
+}
+
+// MarkNotificationRead は受信箱の通知を既読にします。
+// 既読済みの場合は既読日時を変更せずにそのまま返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（所有者チェック用）
+//   - id: 通知ログID
+//
+// 戻り値:
+//   - *NotificationInboxItem: 既読にした通知
+//   - error: 存在しない・所有者でない場合のエラー
+func (s *Service) MarkNotificationRead(ctx context.Context, userID, id uint) (*NotificationInboxItem, error) {
+	log, err := s.repos.NotificationLog().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if log.UserID != userID {
+		return nil, ErrNotificationNotOwned
+	}
+
+	if log.ReadAt == nil {
+		now := s.now()
+		if err := s.repos.NotificationLog().MarkAsRead(ctx, log.ID, now); err != nil {
+			return nil, err
+		}
+		log.ReadAt = &now
+	}
+
+	item := newNotificationInboxItem(log)
+	return &item, nil
+}
+
+// GetUnreadNotificationCount はユーザーの未読通知数を取得します。
+// アプリのバッジ表示用です。
+func (s *Service) GetUnreadNotificationCount(ctx context.Context, userID uint) (int64, error) {
+	return s.repos.NotificationLog().CountUnreadByUserID(ctx, userID)
+}
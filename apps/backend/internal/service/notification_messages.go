@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Notification Messages - 通知メッセージカタログ
+// =============================================================================
+// スケジューラーが生成する通知（プッシュ通知・アプリ内通知）のタイトル・本文を
+// イベントタイプと言語ごとに定義します。言語は User.Locale（通知メールと同じ設定）で選択し、
+// 未設定・未対応の場合は DefaultEmailLocale を使用します。
+//
+// 本文は fmt の書式文字列です。BodyOne は対象が1件の場合の本文（対象名を含む）です。
+// メール本文はイベントのデータからテンプレートで生成するため、このカタログは使用しません。
+
+// notificationMessage はイベントタイプごとの通知メッセージです。
+type notificationMessage struct {
+	Title   string
+	Body    string // 件数を含む本文
+	BodyOne string // 対象が1件の場合の本文
+}
+
+// notificationMessageCatalog は言語・イベントタイプごとの通知メッセージです。
+var notificationMessageCatalog = map[string]map[NotificationEventType]notificationMessage{
+	"ja": {
+		NotificationEventTaskDueReminder: {
+			Title:   "今日のタスクリマインダー",
+			Body:    "今日のタスクが%d件あります。",
+			BodyOne: "今日のタスク: %s",
+		},
+		NotificationEventTaskOverdueAlert: {
+			Title: "期限切れタスクの警告",
+			Body:  "%d件のタスクが期限切れです。確認してください。",
+		},
+		NotificationEventHarvestReminder: {
+			Title:   "収穫リマインダー",
+			Body:    "%d件の作物が%d日以内に収穫予定です。",
+			BodyOne: "%s があと%d日で収穫予定です。",
+		},
+		NotificationEventCropReadyToHarvest: {
+			Title:   "収穫の時期です",
+			Body:    "%d件の作物が収穫できます。",
+			BodyOne: "%s が収穫できます。",
+		},
+		NotificationEventDailyDigest: {
+			Title: "今日のお知らせ（%d件）",
+			Body:  "・%s: %s", // 各イベントの行（タイトル: 本文）
+		},
+		NotificationEventPushOverflow: {
+			Title: "Home Garden",
+			Body:  "ほかに%d件の更新があります。アプリで確認してください。",
+		},
+		NotificationEventYearInReview: {
+			Title:   "%d年の菜園のふりかえり",
+			Body:    "%d件の作物を育て、合計%.1fkgを収穫しました。",
+			BodyOne: "最も収穫が多かった区画は%s（%.1fkg）でした。", // Body に続けて表示
+		},
+	},
+	"en": {
+		NotificationEventTaskDueReminder: {
+			Title:   "Today's tasks",
+			Body:    "You have %d tasks due today.",
+			BodyOne: "Today's task: %s",
+		},
+		NotificationEventTaskOverdueAlert: {
+			Title: "Overdue tasks",
+			Body:  "%d tasks are overdue. Please review them.",
+		},
+		NotificationEventHarvestReminder: {
+			Title:   "Harvest reminder",
+			Body:    "%d crops are expected to be ready for harvest within %d days.",
+			BodyOne: "%s is expected to be ready for harvest in %d day(s).",
+		},
+		NotificationEventCropReadyToHarvest: {
+			Title:   "Time to harvest",
+			Body:    "%d crops are ready to harvest.",
+			BodyOne: "%s is ready to harvest.",
+		},
+		NotificationEventDailyDigest: {
+			Title: "Your garden digest (%d)",
+			Body:  "- %s: %s", // 各イベントの行（タイトル: 本文）
+		},
+		NotificationEventPushOverflow: {
+			Title: "Home Garden",
+			Body:  "You have %d more updates. Open the app to see them.",
+		},
+		NotificationEventYearInReview: {
+			Title:   "Your %d year in the garden",
+			Body:    "You grew %d crops and harvested %.1f kg in total.",
+			BodyOne: " Your most productive plot was %s (%.1f kg).", // Body に続けて表示
+		},
+	},
+}
+
+// userLocale はユーザーの通知の言語を返します（未設定・未対応の場合は DefaultEmailLocale）。
+func userLocale(user *model.User) string {
+	if user == nil {
+		return DefaultEmailLocale
+	}
+	lang, err := NormalizeLocale(user.Locale)
+	if err != nil {
+		return DefaultEmailLocale
+	}
+	return lang
+}
+
+// localizedMessage は言語とイベントタイプに対応する通知メッセージを返します。
+// 言語のメッセージがない場合は DefaultEmailLocale のメッセージを使用します。
+func localizedMessage(locale string, eventType NotificationEventType) notificationMessage {
+	if msg, ok := notificationMessageCatalog[locale][eventType]; ok {
+		return msg
+	}
+	return notificationMessageCatalog[DefaultEmailLocale][eventType]
+}
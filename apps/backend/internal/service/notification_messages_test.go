@@ -0,0 +1,132 @@
+// Package service - Notification Messages Tests
+//
+// 通知メッセージカタログのテストを提供します。
+// テスト対象:
+//   - ユーザーの言語（User.Locale）の選択とフォールバック
+//   - スケジューラーが生成する通知のタイトル・本文の言語（ja, en）
+//   - ダイジェスト・まとめ通知の言語
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestNotificationMessageCatalog はカタログの網羅性のテストです。
+// 期待動作:
+//   - 対応言語すべてに ja と同じイベントタイプのメッセージがある
+func TestNotificationMessageCatalog(t *testing.T) {
+	for _, locale := range SupportedEmailLocales {
+		messages, ok := notificationMessageCatalog[locale]
+		if !ok {
+			t.Fatalf("Expected messages for locale %s", locale)
+		}
+		for eventType := range notificationMessageCatalog[DefaultEmailLocale] {
+			if msg, ok := messages[eventType]; !ok || msg.Title == "" || msg.Body == "" {
+				t.Errorf("Expected %s message for %s, got %+v", locale, eventType, msg)
+			}
+		}
+	}
+}
+
+// TestUserLocale はユーザーの言語の選択のテストです。
+// 期待動作:
+//   - 言語タグは対応言語に正規化する（en-US → en）
+//   - 未設定・未対応の場合は DefaultEmailLocale
+func TestUserLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "en"},
+		{"en-US", "en"},
+		{"ja_JP", "ja"},
+		{"", DefaultEmailLocale},
+		{"fr", DefaultEmailLocale},
+	}
+	for _, tt := range tests {
+		if got := userLocale(&model.User{Locale: tt.locale}); got != tt.want {
+			t.Errorf("userLocale(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+	if got := userLocale(nil); got != DefaultEmailLocale {
+		t.Errorf("userLocale(nil) = %q, want %q", got, DefaultEmailLocale)
+	}
+}
+
+// TestScheduledNotifications_Localized はスケジューラーの通知の言語のテストです。
+// 期待動作:
+//   - ja と en のユーザーそれぞれの言語でタイトル・本文を生成する
+func TestScheduledNotifications_Localized(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	jaUser := &model.User{Email: "ja@example.com", Timezone: "UTC", Locale: "ja"}
+	enUser := &model.User{Email: "en@example.com", Timezone: "UTC", Locale: "en-US"}
+	_ = mockRepos.User().Create(ctx, jaUser)
+	_ = mockRepos.User().Create(ctx, enUser)
+	_ = mockRepos.Task().Create(ctx, &model.Task{UserID: jaUser.ID, Title: "水やり", DueDate: now.Add(time.Hour), Status: "pending", User: *jaUser})
+	_ = mockRepos.Task().Create(ctx, &model.Task{UserID: enUser.ID, Title: "Watering", DueDate: now.Add(time.Hour), Status: "pending", User: *enUser})
+	_ = mockRepos.Crop().Create(ctx, &model.Crop{UserID: enUser.ID, Name: "Tomato", Status: "ready_to_harvest", ExpectedHarvestDate: now, User: *enUser})
+
+	// Act
+	result, err := svc.processScheduledNotificationsAt(ctx, now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("processScheduledNotificationsAt failed: %v", err)
+	}
+	got := make(map[string]NotificationEvent)
+	for _, event := range result.Events {
+		got[event.UserEmail+"/"+string(event.Type)] = event
+	}
+
+	want := map[string][2]string{
+		"ja@example.com/task_due_reminder":     {"今日のタスクリマインダー", "今日のタスク: 水やり"},
+		"en@example.com/task_due_reminder":     {"Today's tasks", "Today's task: Watering"},
+		"en@example.com/crop_ready_to_harvest": {"Time to harvest", "Tomato is ready to harvest."},
+	}
+	for key, texts := range want {
+		event, ok := got[key]
+		if !ok {
+			t.Errorf("Expected event %s", key)
+			continue
+		}
+		if event.Title != texts[0] || event.Body != texts[1] {
+			t.Errorf("%s: expected %q / %q, got %q / %q", key, texts[0], texts[1], event.Title, event.Body)
+		}
+	}
+}
+
+// TestBuildDigestEvent_Localized はダイジェスト・まとめ通知の言語のテストです。
+// 期待動作:
+//   - ダイジェストのタイトルと各行の書式が言語に従う
+//   - まとめ通知の本文が言語に従う
+func TestBuildDigestEvent_Localized(t *testing.T) {
+	events := []NotificationEvent{
+		{Type: NotificationEventTaskDueReminder, UserID: 1, Title: "Today's tasks", Body: "You have 2 tasks due today."},
+		{Type: NotificationEventHarvestReminder, UserID: 1, Title: "Harvest reminder", Body: "Tomato is expected to be ready for harvest in 3 day(s)."},
+	}
+
+	digest := buildDigestEvent(events, "en")
+	if digest.Title != "Your garden digest (2)" {
+		t.Errorf("Unexpected digest title: %q", digest.Title)
+	}
+	if want := "- Today's tasks: You have 2 tasks due today.\n- Harvest reminder: Tomato is expected to be ready for harvest in 3 day(s)."; digest.Body != want {
+		t.Errorf("Unexpected digest body: %q", digest.Body)
+	}
+
+	if body := buildPushOverflowBody(3, "en"); body != "You have 3 more updates. Open the app to see them." {
+		t.Errorf("Unexpected overflow body: %q", body)
+	}
+	if body := buildPushOverflowBody(3, "ja"); body != "ほかに3件の更新があります。アプリで確認してください。" {
+		t.Errorf("Unexpected overflow body: %q", body)
+	}
+}
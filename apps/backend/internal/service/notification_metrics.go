@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Notification Delivery Metrics - 通知の送信結果の集計
+// =============================================================================
+// 通知のチャネルごとの送信結果（sent, failed, deferred, deduped）を次の2つで集計します。
+//   - 通知ログ: ChannelStatus（チャネルごとの最新の送信結果）と DuplicateCount（重複防止キーで送らなかった件数）。
+//     GET /admin/notifications/stats で直近の集計を返します（通知ログの保持期間は24時間）。
+//   - Prometheusカウンター: notification_deliveries_total{channel, outcome}。プロセス起動時からの累計で、
+//     おやすみモードで保留した通知をリトライで送信した場合は deferred と sent の両方を加算します。
+//
+// SES/SNSの障害による失敗の急増は、通知ログの failed の件数とカウンターの増加率で確認できます。
+
+// 通知の送信結果
+const (
+	NotificationOutcomeSent     = "sent"
+	NotificationOutcomeFailed   = "failed"
+	NotificationOutcomeDeferred = "deferred"
+	NotificationOutcomeDeduped  = "deduped"
+)
+
+const (
+	// DefaultNotificationStatsHours は通知の送信結果のデフォルト集計時間
+	DefaultNotificationStatsHours = 24
+	// MaxNotificationStatsHours は通知の送信結果の最大集計時間（通知ログの保持期間）
+	MaxNotificationStatsHours = 24
+
+	// notificationDeliveriesMetric は送信結果のPrometheusカウンター名
+	notificationDeliveriesMetric = "notification_deliveries_total"
+)
+
+// ErrInvalidNotificationStatsRange は集計時間が範囲外の場合のエラー
+var ErrInvalidNotificationStatsRange = errors.New("invalid notification stats range")
+
+// notificationMetricKey はカウンターのラベルの組み合わせです。
+type notificationMetricKey struct {
+	channel string
+	outcome string
+}
+
+// NotificationMetrics はチャネルごとの送信結果のカウンターです（プロセス内で保持）。
+type NotificationMetrics struct {
+	mu     sync.Mutex
+	counts map[notificationMetricKey]uint64
+}
+
+// NewNotificationMetrics は新しいNotificationMetricsを作成します。
+func NewNotificationMetrics() *NotificationMetrics {
+	return &NotificationMetrics{counts: make(map[notificationMetricKey]uint64)}
+}
+
+// DefaultNotificationMetrics は通知イベントハンドラーが記録し、/metrics で公開するカウンターです。
+var DefaultNotificationMetrics = NewNotificationMetrics()
+
+// Inc はチャネルの送信結果のカウンターを1加算します。
+func (m *NotificationMetrics) Inc(channel, outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[notificationMetricKey{channel, outcome}]++
+}
+
+// Count はチャネルの送信結果のカウンターの値を返します。
+func (m *NotificationMetrics) Count(channel, outcome string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[notificationMetricKey{channel, outcome}]
+}
+
+// WritePrometheus はカウンターをPrometheusのテキスト形式で書き出します。
+//
+// 出力例:
+//
+//	# HELP notification_deliveries_total Notification delivery outcomes per channel.
+//	# TYPE notification_deliveries_total counter
+//	notification_deliveries_total{channel="email",outcome="failed"} 3
+func (m *NotificationMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	keys := make([]notificationMetricKey, 0, len(m.counts))
+	for key := range m.counts {
+		keys = append(keys, key)
+	}
+	counts := make(map[notificationMetricKey]uint64, len(m.counts))
+	for key, count := range m.counts {
+		counts[key] = count
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].channel != keys[j].channel {
+			return keys[i].channel < keys[j].channel
+		}
+		return keys[i].outcome < keys[j].outcome
+	})
+
+	if _, err := fmt.Fprintf(w, "# HELP %s Notification delivery outcomes per channel.\n# TYPE %s counter\n",
+		notificationDeliveriesMetric, notificationDeliveriesMetric); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s{channel=%q,outcome=%q} %d\n", notificationDeliveriesMetric, key.channel, key.outcome, counts[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// channelDeliveryStatus は送信エラーからチャネルごとの送信結果を作成します。
+// ChannelError を含まないエラー（ユーザーの取得失敗など）の場合はすべてのチャネルを failed にします。
+// 送信対象のチャネルがない場合は nil を返します。
+func channelDeliveryStatus(channels []string, sendErr error) map[string]string {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	failed := make(map[string]bool)
+	if sendErr != nil {
+		collectFailedChannels(sendErr, failed)
+	}
+	allFailed := sendErr != nil && len(failed) == 0
+
+	status := make(map[string]string, len(channels))
+	for _, channel := range channels {
+		if allFailed || failed[channel] {
+			status[channel] = NotificationOutcomeFailed
+		} else {
+			status[channel] = NotificationOutcomeSent
+		}
+	}
+	return status
+}
+
+// collectFailedChannels はエラー（errors.Join でまとめたものを含む）から失敗したチャネルを集めます。
+func collectFailedChannels(err error, failed map[string]bool) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			collectFailedChannels(e, failed)
+		}
+		return
+	}
+	var channelErr *ChannelError
+	if errors.As(err, &channelErr) {
+		failed[channelErr.Channel] = true
+	}
+}
+
+// uniformChannelStatus はすべてのチャネルを同じ送信結果にします（おやすみモードの保留用）。
+func uniformChannelStatus(channels []string, outcome string) map[string]string {
+	if len(channels) == 0 {
+		return nil
+	}
+	status := make(map[string]string, len(channels))
+	for _, channel := range channels {
+		status[channel] = outcome
+	}
+	return status
+}
+
+// recordDeliveryMetrics はチャネルごとの送信結果をカウンターに加算します。
+func (h *notificationEventHandler) recordDeliveryMetrics(status map[string]string) {
+	for channel, outcome := range status {
+		h.metrics.Inc(channel, outcome)
+	}
+}
+
+// NotificationChannelStats はチャネル1つの送信結果の件数です。
+type NotificationChannelStats struct {
+	Channel  string `json:"channel"`
+	Sent     int64  `json:"sent"`
+	Failed   int64  `json:"failed"`
+	Deferred int64  `json:"deferred"`
+	Deduped  int64  `json:"deduped"`
+}
+
+// NotificationDeliveryStats は通知の送信結果の集計です。
+type NotificationDeliveryStats struct {
+	Since    time.Time                  `json:"since"`
+	Until    time.Time                  `json:"until"`
+	Channels []NotificationChannelStats `json:"channels"` // チャネル名の順
+	Total    NotificationChannelStats   `json:"total"`    // 全チャネルの合計（channel は空）
+}
+
+// GetNotificationDeliveryStats は直近hours時間に作成された通知ログから、チャネルごとの送信結果を集計します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - hours: 集計時間（1〜MaxNotificationStatsHours）
+//
+// 戻り値:
+//   - *NotificationDeliveryStats: チャネルごと・全体の送信結果の件数
+//   - error: 範囲外の場合は ErrInvalidNotificationStatsRange
+func (s *Service) GetNotificationDeliveryStats(ctx context.Context, hours int) (*NotificationDeliveryStats, error) {
+	if hours < 1 || hours > MaxNotificationStatsHours {
+		return nil, ErrInvalidNotificationStatsRange
+	}
+
+	until := s.now()
+	since := until.Add(-time.Duration(hours) * time.Hour)
+	counts, err := s.repos.NotificationLog().CountChannelOutcomesSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildNotificationDeliveryStats(since, until, counts), nil
+}
+
+// buildNotificationDeliveryStats はチャネル×送信結果の件数を集計結果にまとめます。
+func buildNotificationDeliveryStats(since, until time.Time, counts []repository.NotificationChannelOutcomeCount) *NotificationDeliveryStats {
+	stats := &NotificationDeliveryStats{Since: since, Until: until, Channels: []NotificationChannelStats{}}
+	byChannel := make(map[string]*NotificationChannelStats)
+	for _, c := range counts {
+		channel, ok := byChannel[c.Channel]
+		if !ok {
+			channel = &NotificationChannelStats{Channel: c.Channel}
+			byChannel[c.Channel] = channel
+		}
+		for _, target := range []*NotificationChannelStats{channel, &stats.Total} {
+			switch c.Outcome {
+			case NotificationOutcomeSent:
+				target.Sent += c.Count
+			case NotificationOutcomeFailed:
+				target.Failed += c.Count
+			case NotificationOutcomeDeferred:
+				target.Deferred += c.Count
+			case NotificationOutcomeDeduped:
+				target.Deduped += c.Count
+			}
+		}
+	}
+
+	for _, channel := range byChannel {
+		stats.Channels = append(stats.Channels, *channel)
+	}
+	sort.Slice(stats.Channels, func(i, j int) bool { return stats.Channels[i].Channel < stats.Channels[j].Channel })
+	return stats
+}
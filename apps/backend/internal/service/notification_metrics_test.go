@@ -0,0 +1,195 @@
+// Package service - Notification Metrics Tests
+//
+// 通知の送信結果の集計のテストを提供します。
+// テスト対象:
+//   - 送信エラーからのチャネルごとの送信結果
+//   - 通知ログへの送信結果・重複件数の記録とチャネルごとの集計
+//   - Prometheusテキスト形式の出力
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestChannelDeliveryStatus は送信エラーからのチャネルごとの送信結果のテストです。
+// 期待動作:
+//   - ChannelError のチャネルのみ failed、それ以外は sent
+//   - ChannelError を含まないエラーはすべてのチャネルを failed
+//   - 送信対象のチャネルがない場合は nil
+func TestChannelDeliveryStatus(t *testing.T) {
+	channels := []string{NotificationChannelPush, NotificationChannelEmail, NotificationChannelSlack}
+	tests := []struct {
+		name string
+		err  error
+		want map[string]string
+	}{
+		{"all sent", nil, map[string]string{"push": "sent", "email": "sent", "slack": "sent"}},
+		{"email failed", errors.Join(&ChannelError{Channel: NotificationChannelEmail, Err: errors.New("ses throttled")}),
+			map[string]string{"push": "sent", "email": "failed", "slack": "sent"}},
+		{"unknown error", errors.New("failed to get user"), map[string]string{"push": "failed", "email": "failed", "slack": "failed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := channelDeliveryStatus(channels, tt.err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for channel, outcome := range tt.want {
+				if got[channel] != outcome {
+					t.Errorf("Expected %s=%s, got %v", channel, outcome, got)
+				}
+			}
+		})
+	}
+
+	if got := channelDeliveryStatus(nil, nil); got != nil {
+		t.Errorf("Expected nil for no channels, got %v", got)
+	}
+}
+
+// TestNotificationDeliveryStats は通知ログへの送信結果の記録と集計のテストです。
+// 期待動作:
+//   - 送信した通知ログに channel_status が記録される
+//   - 重複防止キーで送らなかった通知は元の通知ログの duplicate_count に加算される
+//   - おやすみモードで保留した通知は deferred になる
+//   - チャネルごとに sent / failed / deferred / deduped を集計し、カウンターにも加算する
+func TestNotificationDeliveryStats(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	metrics := NewNotificationMetrics()
+	handler.(*notificationEventHandler).metrics = metrics
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	user := &model.User{
+		Email:                "stats@example.com",
+		Timezone:             "UTC",
+		NotificationSettings: &model.NotificationSettings{EmailEnabled: true, HarvestReminders: true, TaskReminders: true},
+	}
+	sleeper := &model.User{
+		Email:    "sleeper@example.com",
+		Timezone: "UTC",
+		NotificationSettings: &model.NotificationSettings{
+			EmailEnabled: true, HarvestReminders: true,
+			QuietHoursEnabled: true, QuietHoursStart: now.Add(-time.Hour).Format("15:04"), QuietHoursEnd: now.Add(time.Hour).Format("15:04"),
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+	_ = mockRepos.User().Create(ctx, sleeper)
+	harvest := NotificationEvent{Type: NotificationEventHarvestReminder, UserID: user.ID, Title: "収穫", Body: "本文"}
+
+	// Act
+	_, _ = handler.HandleEvents(ctx, []NotificationEvent{
+		harvest,
+		harvest, // 重複
+		{Type: NotificationEventHarvestReminder, UserID: sleeper.ID, Title: "収穫", Body: "本文"},
+	})
+	mockSender.ShouldFail = true
+	_ = handler.HandleEvent(ctx, NotificationEvent{Type: NotificationEventTaskOverdueAlert, UserID: user.ID, Title: "期限切れ", Body: "本文"})
+	stats, err := svc.GetNotificationDeliveryStats(ctx, DefaultNotificationStatsHours)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetNotificationDeliveryStats failed: %v", err)
+	}
+	if len(stats.Channels) != 1 || stats.Channels[0].Channel != NotificationChannelEmail {
+		t.Fatalf("Expected email channel only, got %+v", stats.Channels)
+	}
+	email := stats.Channels[0]
+	if email.Sent != 1 || email.Failed != 1 || email.Deferred != 1 || email.Deduped != 1 {
+		t.Errorf("Unexpected email stats: %+v", email)
+	}
+	if stats.Total.Sent != 1 || stats.Total.Deduped != 1 {
+		t.Errorf("Unexpected total: %+v", stats.Total)
+	}
+	if metrics.Count(NotificationChannelEmail, NotificationOutcomeFailed) != 1 || metrics.Count(NotificationChannelEmail, NotificationOutcomeDeduped) != 1 {
+		t.Errorf("Unexpected metrics: failed=%d deduped=%d",
+			metrics.Count(NotificationChannelEmail, NotificationOutcomeFailed), metrics.Count(NotificationChannelEmail, NotificationOutcomeDeduped))
+	}
+
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	for _, log := range logs {
+		if log.NotificationType == string(NotificationEventHarvestReminder) && (log.DuplicateCount != 1 || log.ChannelStatus[NotificationChannelEmail] != NotificationOutcomeSent) {
+			t.Errorf("Unexpected harvest log: %+v", log)
+		}
+	}
+
+	if _, err := svc.GetNotificationDeliveryStats(ctx, 25); !errors.Is(err, ErrInvalidNotificationStatsRange) {
+		t.Errorf("Expected ErrInvalidNotificationStatsRange, got %v", err)
+	}
+}
+
+// TestRetryPendingNotifications_ChannelStatus はリトライ時の送信結果の更新のテストです。
+// 期待動作:
+//   - 再送信に成功した場合は channel_status が sent に更新される
+func TestRetryPendingNotifications_ChannelStatus(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	handler.(*notificationEventHandler).metrics = NewNotificationMetrics()
+	ctx := context.Background()
+
+	user := &model.User{Email: "retry@example.com", Timezone: "UTC"}
+	_ = mockRepos.User().Create(ctx, user)
+	past := time.Now().Add(-time.Minute)
+	log := &model.NotificationLog{
+		UserID:           user.ID,
+		NotificationType: string(NotificationEventHarvestReminder),
+		Channel:          NotificationChannelEmail,
+		ChannelStatus:    map[string]string{NotificationChannelEmail: NotificationOutcomeFailed},
+		Status:           "pending",
+		NextRetryAt:      &past,
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+	_ = mockRepos.NotificationLog().Create(ctx, log)
+
+	// Act
+	result, err := handler.RetryPendingNotifications(ctx)
+
+	// Assert
+	if err != nil || result.Succeeded != 1 {
+		t.Fatalf("Expected retry to succeed, got %+v, %v", result, err)
+	}
+	updated, _ := mockRepos.NotificationLog().GetByID(ctx, log.ID)
+	if updated.ChannelStatus[NotificationChannelEmail] != NotificationOutcomeSent {
+		t.Errorf("Expected email to be sent, got %v", updated.ChannelStatus)
+	}
+}
+
+// TestNotificationMetrics_WritePrometheus はPrometheusテキスト形式の出力のテストです。
+func TestNotificationMetrics_WritePrometheus(t *testing.T) {
+	// Arrange
+	metrics := NewNotificationMetrics()
+	metrics.Inc(NotificationChannelPush, NotificationOutcomeSent)
+	metrics.Inc(NotificationChannelEmail, NotificationOutcomeFailed)
+	metrics.Inc(NotificationChannelEmail, NotificationOutcomeFailed)
+
+	// Act
+	var b strings.Builder
+	err := metrics.WritePrometheus(&b)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	want := "# HELP notification_deliveries_total Notification delivery outcomes per channel.\n" +
+		"# TYPE notification_deliveries_total counter\n" +
+		"notification_deliveries_total{channel=\"email\",outcome=\"failed\"} 2\n" +
+		"notification_deliveries_total{channel=\"push\",outcome=\"sent\"} 1\n"
+	if b.String() != want {
+		t.Errorf("Unexpected output:\n%s", b.String())
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Notification Outbox - 通知イベントのアウトボックス
+// =============================================================================
+// スケジューラーが生成した通知イベントは、送信する前にトランザクション内でまとめて
+// アウトボックス（notification_outbox）に保存し、ディスパッチャーが取り出して送信します。
+// 送信の途中でプロセスが終了しても、処理済みにしていないイベントは次回のディスパッチで送信します。
+//
+// イベントの状態:
+//   - pending: 送信待ち
+//   - dispatched: 通知イベントハンドラーで処理済み（送信失敗時は通知ログのリトライ処理で再送信）
+//   - failed: 通知ログを記録する前の失敗（ユーザーを取得できないなど）が NotificationOutboxMaxAttempts 回続いたもの
+//
+// 同じ重複防止キーのイベントは1件だけ保存するため、スケジューラーを同じ日に何度実行しても同じイベントは1回だけ送信します。
+
+const (
+	// NotificationOutboxBatchSize は1回のディスパッチで扱う最大件数です。
+	NotificationOutboxBatchSize = 500
+	// NotificationOutboxMaxAttempts は通知ログを記録する前の失敗を再試行する最大回数です。
+	NotificationOutboxMaxAttempts = 5
+	// NotificationOutboxRetention は処理済みのイベントを保持する期間です。
+	NotificationOutboxRetention = 7 * 24 * time.Hour
+)
+
+// enqueueReminderJobs はリマインダージョブの通知イベントを1つのトランザクションでアウトボックスに保存します。
+// ダイジェストを希望するユーザーのイベントは1件にまとめてから保存します。
+//
+// 戻り値:
+//   - int64: 保存したイベントの数（同じ重複防止キーのイベントが既にあるものは除く）
+//   - error: 保存に失敗した場合のエラー（いずれのイベントも保存しない）
+func (h *notificationEventHandler) enqueueReminderJobs(ctx context.Context, jobs []ReminderJob) (int64, error) {
+	entries := make([]model.NotificationOutbox, 0, len(jobs))
+	for _, job := range jobs {
+		for _, event := range h.batchDigestEvents(ctx, job.Events) {
+			payload, err := json.Marshal(event)
+			if err != nil {
+				return 0, fmt.Errorf("failed to encode notification event: %w", err)
+			}
+			entries = append(entries, model.NotificationOutbox{
+				UserID:           event.UserID,
+				EventType:        string(event.Type),
+				DeduplicationKey: generateDeduplicationKey(event, nil, job.SlotTime),
+				Payload:          string(payload),
+				Status:           "pending",
+				AvailableAt:      job.SlotTime,
+			})
+		}
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	var enqueued int64
+	err := h.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		enqueued, err = h.repos.NotificationOutbox().Enqueue(txCtx, entries)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return enqueued, nil
+}
+
+// DispatchNotificationOutbox はアウトボックスの送信待ちの通知イベントを送信します。
+// イベントはユーザーごとのリマインダージョブにまとめ、キューから並行して処理します（SetReminderWorkers）。
+//
+// 処理内容:
+//   - 通知イベントハンドラーで処理したイベント（送信・保留・重複・送信失敗）は処理済みにする
+//   - 通知ログを記録する前に失敗したイベントは、試行回数を加算して後で再試行する
+//   - NotificationOutboxMaxAttempts 回失敗したイベント・読み取れないイベントは failed にしてデッドレターに保存する
+//
+// 引数:
+//   - ctx: コンテキスト
+//
+// 戻り値:
+//   - *NotificationProcessResult: 処理結果
+//   - error: 送信待ちのイベントの取得に失敗した場合のエラー
+func (h *notificationEventHandler) DispatchNotificationOutbox(ctx context.Context) (*NotificationProcessResult, error) {
+	now := h.service.now()
+	entries, err := h.repos.NotificationOutbox().GetPending(ctx, now, NotificationOutboxBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending notification outbox: %w", err)
+	}
+
+	var order []uint
+	byUser := make(map[uint]*ReminderJob)
+	var undecodable []string
+	for _, entry := range entries {
+		var event NotificationEvent
+		if err := json.Unmarshal([]byte(entry.Payload), &event); err != nil {
+			// 読み取れないイベントは再試行しても送信できない
+			_ = h.repos.NotificationOutbox().MarkAttemptFailed(ctx, entry.ID, "failed", entry.Attempts+1,
+				truncateString(err.Error(), 500), entry.AvailableAt)
+			h.recordDeadLetter(ctx, deadLetterFromOutbox(entry, entry.Attempts+1, err))
+			undecodable = append(undecodable, fmt.Sprintf("outbox %d: %v", entry.ID, err))
+			continue
+		}
+		job, ok := byUser[entry.UserID]
+		if !ok {
+			job = &ReminderJob{UserID: entry.UserID, SlotTime: reminderSlot(entry.CreatedAt)}
+			byUser[entry.UserID] = job
+			order = append(order, entry.UserID)
+		}
+		job.Events = append(job.Events, event)
+		job.outbox = append(job.outbox, entry)
+	}
+
+	jobs := make([]ReminderJob, 0, len(order))
+	for _, userID := range order {
+		jobs = append(jobs, *byUser[userID])
+	}
+
+	result := h.runReminderJobs(ctx, jobs)
+	result.FailedSends += len(undecodable)
+	result.DeadLettered += len(undecodable)
+	result.Errors = append(result.Errors, undecodable...)
+	return result, nil
+}
+
+// completeOutboxEntry はアウトボックスのイベントの処理結果を記録します。
+// 通知ログを記録した場合は再送信を通知ログのリトライ処理に任せ、処理済みにします。
+// NotificationOutboxMaxAttempts 回失敗したイベントはデッドレターに保存し、true を返します。
+func (h *notificationEventHandler) completeOutboxEntry(ctx context.Context, entry model.NotificationOutbox, outcome eventOutcome, handleErr error) bool {
+	repo := h.repos.NotificationOutbox()
+	now := h.service.now()
+
+	var err error
+	deadLettered := false
+	if handleErr == nil || outcome != eventUnrecorded {
+		err = repo.MarkDispatched(ctx, entry.ID, now)
+	} else {
+		attempts := entry.Attempts + 1
+		status := "pending"
+		if attempts >= NotificationOutboxMaxAttempts {
+			status = "failed"
+			deadLettered = true
+		}
+		err = repo.MarkAttemptFailed(ctx, entry.ID, status, attempts,
+			truncateString(handleErr.Error(), 500), now.Add(notificationRetryDelay(attempts-1)))
+		if deadLettered {
+			h.recordDeadLetter(ctx, deadLetterFromOutbox(entry, attempts, handleErr))
+		}
+	}
+	if err != nil {
+		// 処理済みにできない場合は次回のディスパッチで再処理される（重複防止キーにより二重送信はしない）
+		logging.FromContext(ctx).Warn("Failed to update notification outbox", "outbox_id", entry.ID, "error", err)
+	}
+	return deadLettered
+}
+
+// CleanupDispatchedNotificationOutbox は保持期間を過ぎた処理済みのアウトボックスのイベントを削除します。
+//
+// 戻り値:
+//   - int64: 削除した件数
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) CleanupDispatchedNotificationOutbox(ctx context.Context) (int64, error) {
+	return s.repos.NotificationOutbox().DeleteDispatchedBefore(ctx, s.now().Add(-NotificationOutboxRetention))
+}
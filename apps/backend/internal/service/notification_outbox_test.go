@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Notification Outbox Tests - 通知イベントのアウトボックスのテスト
+// =============================================================================
+// テスト対象:
+//   - ProcessScheduledNotificationsAndSend: 通知イベントをアウトボックスに保存してから送信すること
+//   - DispatchNotificationOutbox: 送信前に終了した実行で残ったイベントの送信、失敗時の再試行
+
+// TestProcessScheduledNotificationsAndSend_Outbox はアウトボックス経由の定期通知のテストです。
+// 期待動作:
+//   - 生成した通知イベントをアウトボックスに保存し、送信後に処理済みにする
+//   - 同じ日の再実行では同じイベントを保存・送信しない
+func TestProcessScheduledNotificationsAndSend_Outbox(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	createReminderUser(t, mockRepos, "outbox@example.com", "Asia/Tokyo", 0, time.Now())
+
+	// Act
+	first, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+	second, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+
+	// Assert
+	if first.EnqueuedEvents != 1 || first.SuccessfulSends != 1 {
+		t.Errorf("Expected 1 enqueued / 1 sent, got %d / %d (errors=%v)", first.EnqueuedEvents, first.SuccessfulSends, first.Errors)
+	}
+	if second.EnqueuedEvents != 0 || second.TotalEvents != 0 {
+		t.Errorf("Expected re-run not to enqueue the same event, got %d enqueued / %d events", second.EnqueuedEvents, second.TotalEvents)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected 1 email, got %d", len(mockSender.SentEmailNotifications))
+	}
+	for _, entry := range mockRepos.GetMockNotificationOutboxRepository().Entries {
+		if entry.Status != "dispatched" || entry.DispatchedAt == nil {
+			t.Errorf("Expected outbox entry to be dispatched, got %+v", entry)
+		}
+	}
+}
+
+// TestDispatchNotificationOutbox_Recovery は送信前に終了した実行で残ったイベントの送信のテストです。
+// 期待動作:
+//   - アウトボックスに保存したまま送信していないイベントを次回のディスパッチで送信する
+//   - 通知ログを記録する前の失敗は試行回数を加算し、後で再試行する
+//   - 最大試行回数に達したイベントは failed にする
+func TestDispatchNotificationOutbox_Recovery(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos).(*notificationEventHandler)
+	ctx := context.Background()
+	createReminderUser(t, mockRepos, "recover@example.com", "Asia/Tokyo", 0, time.Now())
+
+	// 保存後、送信前にプロセスが終了した状態
+	jobs, _ := svc.BuildReminderJobs(ctx, time.Now())
+	jobs = append(jobs, ReminderJob{
+		UserID:   9999, // 存在しないユーザー
+		SlotTime: jobs[0].SlotTime,
+		Events: []NotificationEvent{{
+			Type: NotificationEventTaskDueReminder, UserID: 9999, LocalDate: "2026-03-10", Title: "t", Body: "b",
+		}},
+	})
+	if enqueued, err := handler.enqueueReminderJobs(ctx, jobs); err != nil || enqueued != 2 {
+		t.Fatalf("Expected 2 enqueued events, got %d (err=%v)", enqueued, err)
+	}
+	outbox := mockRepos.GetMockNotificationOutboxRepository()
+
+	// Act
+	result, err := handler.DispatchNotificationOutbox(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DispatchNotificationOutbox failed: %v", err)
+	}
+	if result.SuccessfulSends != 1 || result.FailedSends != 1 {
+		t.Errorf("Expected 1 sent / 1 failed, got %d / %d", result.SuccessfulSends, result.FailedSends)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected recovered event to be sent, got %d emails", len(mockSender.SentEmailNotifications))
+	}
+
+	var failed *model.NotificationOutbox
+	for _, entry := range outbox.Entries {
+		if entry.UserID == 9999 {
+			failed = entry
+		}
+	}
+	if failed == nil || failed.Status != "pending" || failed.Attempts != 1 || !failed.AvailableAt.After(time.Now()) {
+		t.Fatalf("Expected failed entry to be rescheduled, got %+v", failed)
+	}
+
+	// 再試行の時刻前は送信しない
+	if again, _ := handler.DispatchNotificationOutbox(ctx); again.TotalEvents != 0 {
+		t.Errorf("Expected no events before the retry time, got %d", again.TotalEvents)
+	}
+
+	// 最大試行回数に達すると failed
+	failed.Attempts = NotificationOutboxMaxAttempts - 1
+	failed.AvailableAt = time.Now().Add(-time.Minute)
+	_, _ = handler.DispatchNotificationOutbox(ctx)
+	if failed.Status != "failed" || failed.Attempts != NotificationOutboxMaxAttempts {
+		t.Errorf("Expected entry to be failed after max attempts, got %+v", failed)
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Notification Preferences - イベントタイプ×チャネルの通知設定
+// =============================================================================
+// 通知を送るかどうかを、イベントタイプ（当日タスク・期限切れ・収穫・収穫可能・ダイジェスト・お知らせ）と
+// チャネル（push, email, webhook）の組み合わせごとに設定します。
+// 設定していない組み合わせは NotificationSettings の値（チャネルの有効/無効と
+// タスク・収穫リマインダー・収穫可能通知の有効/無効）から決まる既定値を使用します。
+
+// NotificationChannelWebhook はSlack/Discord・汎用Webhookをまとめた設定上のチャネル
+const NotificationChannelWebhook = "webhook"
+
+// ErrInvalidNotificationPreference は未知のイベントタイプ・チャネルが指定された場合のエラー
+var ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+
+// NotificationPreferenceEventTypes は通知設定の対象となるイベントタイプです。
+var NotificationPreferenceEventTypes = []NotificationEventType{
+	NotificationEventTaskDueReminder,
+	NotificationEventTaskOverdueAlert,
+	NotificationEventHarvestReminder,
+	NotificationEventCropReadyToHarvest,
+	NotificationEventDailyDigest,
+	NotificationEventAnnouncement,
+	NotificationEventYearInReview,
+}
+
+// NotificationPreferenceChannels は通知設定の対象となるチャネルです。
+var NotificationPreferenceChannels = []string{
+	NotificationChannelPush,
+	NotificationChannelEmail,
+	NotificationChannelWebhook,
+}
+
+// NotificationPreferenceItem は通知設定マトリクスの1セルです。
+type NotificationPreferenceItem struct {
+	EventType  string `json:"event_type"`
+	Channel    string `json:"channel"`
+	Enabled    bool   `json:"enabled"`
+	Customized bool   `json:"customized"` // trueの場合はユーザーが設定した値、falseの場合は既定値
+}
+
+// NotificationPreferenceMatrix は全イベントタイプ×チャネルの通知設定です。
+type NotificationPreferenceMatrix struct {
+	Preferences []NotificationPreferenceItem `json:"preferences"`
+}
+
+// defaultNotificationPreference は NotificationSettings から既定の通知可否を返します。
+// webhook は送信先（Slack/Discordの有効化とURL）が NotificationSettings 側で管理されるため、
+// イベントタイプの有効/無効のみで決まります。
+func defaultNotificationPreference(settings *model.NotificationSettings, eventType NotificationEventType, channel string) bool {
+	typeEnabled := true
+	switch eventType {
+	case NotificationEventTaskDueReminder, NotificationEventTaskOverdueAlert:
+		typeEnabled = settings.TaskReminders
+	case NotificationEventHarvestReminder:
+		typeEnabled = settings.HarvestReminders
+	case NotificationEventCropReadyToHarvest:
+		typeEnabled = harvestReadyAlertsEnabled(settings)
+	}
+
+	switch channel {
+	case NotificationChannelPush:
+		return typeEnabled && settings.PushEnabled
+	case NotificationChannelEmail:
+		return typeEnabled && settings.EmailEnabled
+	default:
+		return typeEnabled
+	}
+}
+
+// notificationPreferenceEnabled はイベントタイプ×チャネルで通知を送ってよいかを判定します。
+// user.NotificationPreferences に該当する行があればその値、なければ既定値を使用します。
+func notificationPreferenceEnabled(user *model.User, eventType NotificationEventType, channel string) bool {
+	for _, pref := range user.NotificationPreferences {
+		if pref.EventType == string(eventType) && pref.Channel == channel {
+			return pref.Enabled
+		}
+	}
+	return defaultNotificationPreference(effectiveNotificationSettings(user), eventType, channel)
+}
+
+// notificationEventEnabled はいずれかのチャネルでイベントタイプの通知が有効かを判定します。
+// スケジューラーが通知イベントを生成するかどうかの判定に使用します。
+func notificationEventEnabled(user *model.User, eventType NotificationEventType) bool {
+	for _, channel := range NotificationPreferenceChannels {
+		if notificationPreferenceEnabled(user, eventType, channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildNotificationPreferenceMatrix はユーザーの全イベントタイプ×チャネルの通知設定を構築します。
+func buildNotificationPreferenceMatrix(user *model.User) *NotificationPreferenceMatrix {
+	customized := make(map[string]bool)
+	for _, pref := range user.NotificationPreferences {
+		customized[pref.EventType+":"+pref.Channel] = true
+	}
+
+	matrix := &NotificationPreferenceMatrix{
+		Preferences: make([]NotificationPreferenceItem, 0, len(NotificationPreferenceEventTypes)*len(NotificationPreferenceChannels)),
+	}
+	for _, eventType := range NotificationPreferenceEventTypes {
+		for _, channel := range NotificationPreferenceChannels {
+			matrix.Preferences = append(matrix.Preferences, NotificationPreferenceItem{
+				EventType:  string(eventType),
+				Channel:    channel,
+				Enabled:    notificationPreferenceEnabled(user, eventType, channel),
+				Customized: customized[string(eventType)+":"+channel],
+			})
+		}
+	}
+	return matrix
+}
+
+// isValidNotificationPreference はイベントタイプとチャネルが設定対象かを判定します。
+func isValidNotificationPreference(eventType, channel string) bool {
+	validType := false
+	for _, t := range NotificationPreferenceEventTypes {
+		if string(t) == eventType {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return false
+	}
+	for _, c := range NotificationPreferenceChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNotificationPreferences はユーザーの通知設定マトリクスを取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - *NotificationPreferenceMatrix: 全イベントタイプ×チャネルの通知設定（既定値を含む）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetNotificationPreferences(ctx context.Context, userID uint) (*NotificationPreferenceMatrix, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.NotificationPreferences, err = s.repos.NotificationPreference().GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return buildNotificationPreferenceMatrix(user), nil
+}
+
+// UpdateNotificationPreferences はユーザーの通知設定マトリクスを更新します。
+// 指定した組み合わせのみ更新し、指定しなかった組み合わせは変更しません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - items: 更新するイベントタイプ×チャネルの通知設定
+//
+// 戻り値:
+//   - *NotificationPreferenceMatrix: 更新後の通知設定
+//   - error: 未知のイベントタイプ・チャネルの場合は ErrInvalidNotificationPreference、更新に失敗した場合のエラー
+func (s *Service) UpdateNotificationPreferences(ctx context.Context, userID uint, items []NotificationPreferenceItem) (*NotificationPreferenceMatrix, error) {
+	prefs := make([]model.NotificationPreference, 0, len(items))
+	for _, item := range items {
+		if !isValidNotificationPreference(item.EventType, item.Channel) {
+			return nil, ErrInvalidNotificationPreference
+		}
+		prefs = append(prefs, model.NotificationPreference{
+			UserID:    userID,
+			EventType: item.EventType,
+			Channel:   item.Channel,
+			Enabled:   item.Enabled,
+		})
+	}
+
+	if _, err := s.repos.User().GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repos.NotificationPreference().Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return s.GetNotificationPreferences(ctx, userID)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Push Rate Limit - プッシュ通知の送信数の上限
+// =============================================================================
+// ユーザーごとに1時間・1日あたりのプッシュ通知の送信数を制限します。
+// 送信数は通知ログ（送信済みでチャネルに push を含むもの）から数えます。
+//
+// 上限に達したイベントはプッシュ通知を送らず（メール等の他のチャネルには送信）、
+// 通知ログに PushRateLimited として記録します。上限を下回った時点でリトライ処理が
+// 「ほかにN件の更新があります」というまとめ通知を1件だけ送信します。
+
+// pushOverflowBatchSize は1回のまとめ通知処理で扱う通知ログの最大件数です。
+const pushOverflowBatchSize = 500
+
+// PushRateLimit はユーザーごとのプッシュ通知の送信数の上限です（0の場合は無制限）。
+type PushRateLimit struct {
+	PerHour int // 直近1時間あたりの上限
+	PerDay  int // 直近24時間あたりの上限
+}
+
+// SetPushRateLimit はプッシュ通知の送信数の上限を設定します。
+// 上限は通知設定（config.NotificationConfig）から main で設定します。
+func (s *Service) SetPushRateLimit(limit PushRateLimit) {
+	s.pushRateLimit = limit
+}
+
+// pushRateLimitReached はユーザーへのプッシュ通知が送信数の上限に達しているかを判定します。
+// 送信数を取得できない場合は通知を優先し、上限に達していないものとして扱います。
+func (h *notificationEventHandler) pushRateLimitReached(ctx context.Context, userID uint, now time.Time) bool {
+	limit := h.service.pushRateLimit
+	windows := []struct {
+		max    int
+		period time.Duration
+	}{
+		{limit.PerHour, time.Hour},
+		{limit.PerDay, 24 * time.Hour},
+	}
+	for _, w := range windows {
+		if w.max <= 0 {
+			continue
+		}
+		count, err := h.repos.NotificationLog().CountPushesSince(ctx, userID, now.Add(-w.period))
+		if err == nil && count >= int64(w.max) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPushRateLimit はプッシュ通知を送るイベントが送信数の上限に達している場合に
+// event.PushRateLimited を設定します。
+//
+// 戻り値:
+//   - bool: 上限によりプッシュ通知を送らない場合は true
+func (h *notificationEventHandler) applyPushRateLimit(ctx context.Context, event *NotificationEvent, user *model.User, tokens []model.DeviceToken) bool {
+	channels := NotificationChannels(*event, user, tokens)
+	if len(channels) == 0 || channels[0] != NotificationChannelPush {
+		return false
+	}
+	event.PushRateLimited = h.pushRateLimitReached(ctx, event.UserID, h.service.now())
+	return event.PushRateLimited
+}
+
+// buildPushOverflowBody はまとめ通知の本文を作成します。
+func buildPushOverflowBody(count int, locale string) string {
+	return fmt.Sprintf(localizedMessage(locale, NotificationEventPushOverflow).Body, count)
+}
+
+// sendPushOverflowSummaries は送信数の上限により送らなかったプッシュ通知を、
+// ユーザーごとに1件のまとめ通知として送信します。
+// まだ上限に達している、またはおやすみモード中のユーザーは次回の処理まで待ちます。
+func (h *notificationEventHandler) sendPushOverflowSummaries(ctx context.Context, result *NotificationRetryResult) {
+	logs, err := h.repos.NotificationLog().GetPushRateLimited(ctx, pushOverflowBatchSize)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to get rate limited notifications: %v", err))
+		return
+	}
+
+	var order []uint
+	idsByUser := make(map[uint][]uint)
+	for _, log := range logs {
+		if _, ok := idsByUser[log.UserID]; !ok {
+			order = append(order, log.UserID)
+		}
+		idsByUser[log.UserID] = append(idsByUser[log.UserID], log.ID)
+	}
+
+	now := h.service.now()
+	for _, userID := range order {
+		ids := idsByUser[userID]
+		user, err := h.getUserWithPreferences(ctx, userID)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("push overflow for user %d: %v", userID, err))
+			continue
+		}
+		if _, quiet := quietHoursEnd(user, now); quiet || h.pushRateLimitReached(ctx, userID, now) {
+			continue
+		}
+
+		tokens, err := h.repos.DeviceToken().GetActiveByUserID(ctx, userID)
+		if err != nil {
+			tokens = []model.DeviceToken{}
+		}
+		locale := userLocale(user)
+		title := localizedMessage(locale, NotificationEventPushOverflow).Title
+		body := buildPushOverflowBody(len(ids), locale)
+		sent, sendErr := h.sendPushOverflow(ctx, tokens, title, body, len(ids))
+		if sendErr != nil && sent == 0 {
+			// 有効なトークンへ1件も送れなかった場合は次回の処理で再送信する
+			result.Errors = append(result.Errors, fmt.Sprintf("push overflow for user %d: %v", userID, sendErr))
+			continue
+		}
+
+		if sent > 0 {
+			sentAt := h.service.now()
+			log := &model.NotificationLog{
+				UserID:           userID,
+				NotificationType: string(NotificationEventPushOverflow),
+				Channel:          NotificationChannelPush,
+				Title:            title,
+				Body:             body,
+				Status:           "sent",
+				SentAt:           &sentAt,
+				ExpiresAt:        sentAt.Add(24 * time.Hour),
+			}
+			if err := h.service.CreateNotificationLog(ctx, log); err != nil {
+				logging.FromContext(ctx).Warn("Failed to create notification log", "error", err)
+			}
+			result.OverflowSummaries++
+		}
+		if err := h.repos.NotificationLog().ClearPushRateLimited(ctx, ids); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("push overflow for user %d: failed to update logs: %v", userID, err))
+		}
+	}
+}
+
+// sendPushOverflow はユーザーの有効なデバイストークンへまとめ通知を送信します。
+// 無効と判定されたトークンは無効化します。
+//
+// 戻り値:
+//   - int: 送信に成功したトークン数
+//   - error: 無効なトークン以外の送信エラー
+func (h *notificationEventHandler) sendPushOverflow(ctx context.Context, tokens []model.DeviceToken, title, body string, count int) (int, error) {
+	data := pushPayloadData(NotificationEvent{
+		Type: NotificationEventPushOverflow,
+		Data: map[string]interface{}{"count": count},
+	})
+
+	sent := 0
+	var errs []error
+	for _, token := range tokens {
+		if !token.IsActive {
+			continue
+		}
+		if err := h.sender.SendPushNotification(ctx, &token, title, body, data); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	return sent, h.deactivateInvalidTokens(ctx, errors.Join(errs...))
+}
@@ -0,0 +1,102 @@
+// Package service - Push Rate Limit Tests
+//
+// プッシュ通知の送信数の上限のテストを提供します。
+// テスト対象:
+//   - 上限に達したイベントはプッシュ通知を送らず、他のチャネルには送信すること
+//   - 上限を下回った後に、送らなかった件数のまとめ通知が1件だけ送信されること
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestNotificationEventHandler_PushRateLimit はプッシュ通知の送信数の上限のテストです。
+// 期待動作:
+//   - 1時間あたりの上限を超えたイベントはプッシュ通知を送らず、メールのみ送信する
+//   - 上限を超えたイベントの通知ログは PushRateLimited として記録される
+//   - 上限に達している間はまとめ通知を送らない
+//   - 上限を下回るとまとめ通知を1件送信し、対象の通知ログを解除する
+func TestNotificationEventHandler_PushRateLimit(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetPushRateLimit(PushRateLimit{PerHour: 2, PerDay: 10})
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "busy@example.com", Timezone: "UTC"}
+	_ = mockRepos.User().Create(ctx, user)
+	_ = mockRepos.DeviceToken().Create(ctx, &model.DeviceToken{UserID: user.ID, Token: "busy-token", Platform: "ios", IsActive: true})
+
+	for i := 1; i <= 4; i++ {
+		event := NotificationEvent{
+			Type:      NotificationEventTaskDueReminder,
+			UserID:    user.ID,
+			UserEmail: user.Email,
+			Title:     fmt.Sprintf("リマインダー%d", i),
+			Body:      "水やり",
+			LocalDate: fmt.Sprintf("2026-10-%02d", i),
+		}
+
+		// Act
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	// Assert
+	if len(mockSender.SentPushNotifications) != 2 {
+		t.Errorf("Expected 2 push notifications, got %d", len(mockSender.SentPushNotifications))
+	}
+	if len(mockSender.SentEmailNotifications) != 4 {
+		t.Errorf("Expected 4 email notifications, got %d", len(mockSender.SentEmailNotifications))
+	}
+	limited, _ := mockRepos.NotificationLog().GetPushRateLimited(ctx, 0)
+	if len(limited) != 2 {
+		t.Fatalf("Expected 2 rate limited logs, got %d", len(limited))
+	}
+	if limited[0].Channel != NotificationChannelEmail || limited[0].Status != "sent" {
+		t.Errorf("Expected rate limited log to be sent by email only, got %+v", limited[0])
+	}
+
+	// 上限に達している間はまとめ通知を送らない
+	result, err := handler.RetryPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	if result.OverflowSummaries != 0 || len(mockSender.SentPushNotifications) != 2 {
+		t.Errorf("Expected no overflow summary while limited, got %d", result.OverflowSummaries)
+	}
+
+	// 1時間経過した状態にする
+	past := time.Now().Add(-2 * time.Hour)
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	for _, log := range logs {
+		stored, _ := mockRepos.NotificationLog().GetByID(ctx, log.ID)
+		stored.SentAt = &past
+	}
+
+	result, err = handler.RetryPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	if result.OverflowSummaries != 1 {
+		t.Errorf("Expected 1 overflow summary, got %d", result.OverflowSummaries)
+	}
+	if len(mockSender.SentPushNotifications) != 3 {
+		t.Fatalf("Expected overflow push, got %d pushes", len(mockSender.SentPushNotifications))
+	}
+	if summary := mockSender.SentPushNotifications[2]; summary.Body != buildPushOverflowBody(2, DefaultEmailLocale) {
+		t.Errorf("Unexpected overflow body: %q", summary.Body)
+	}
+	if remaining, _ := mockRepos.NotificationLog().GetPushRateLimited(ctx, 0); len(remaining) != 0 {
+		t.Errorf("Expected rate limited logs to be cleared, got %d", len(remaining))
+	}
+}
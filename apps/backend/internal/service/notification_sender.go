@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,14 +14,20 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 	sestypes "github.com/aws/aws-sdk-go-v2/service/ses/types"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/secure-scorecard/backend/internal/clock"
 	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/logging"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"github.com/secure-scorecard/backend/internal/tracing"
 )
 
 // =============================================================================
 // Notification Sender - 通知送信サービス
 // =============================================================================
 // AWS SNS（プッシュ通知）とAWS SES（メール通知）を使用して通知を送信します。
+// プッシュ通知は設定により FCM HTTP v1 API への直接送信（FCMSender）に切り替えられます。
 // Exponential backoffによるリトライ機構を実装しています。
 
 // NotificationSender は通知送信インターフェースです。
@@ -29,26 +38,84 @@ type NotificationSender interface {
 	// SendEmailNotification はメール通知を送信します。
 	SendEmailNotification(ctx context.Context, toEmail, subject, htmlBody, textBody string) error
 
+	// SendWebhookNotification はSlack/DiscordのWebhookへ通知を投稿します。
+	SendWebhookNotification(ctx context.Context, channel, webhookURL, title, body string) error
+
+	// SendCustomWebhook は汎用Webhookへ通知イベントのJSONを署名付きで送信します。
+	SendCustomWebhook(ctx context.Context, webhookURL, secret string, event NotificationEvent) error
+
+	// SendSMSNotification はSMSを送信します（重要なアラート・電話番号の認証コード用）。
+	SendSMSNotification(ctx context.Context, phoneNumber, message string) error
+
 	// SendNotificationEvent は通知イベントを処理して送信します。
 	SendNotificationEvent(ctx context.Context, event NotificationEvent, user *model.User, tokens []model.DeviceToken) error
 }
 
+const (
+	// PushProviderSNS はAWS SNS経由でプッシュ通知を送信するプロバイダー
+	PushProviderSNS = "sns"
+	// PushProviderFCM はFCM HTTP v1 APIへ直接プッシュ通知を送信するプロバイダー
+	PushProviderFCM = "fcm"
+)
+
+// snsAPI は notificationSender が使用するSNSの操作です（テストで差し替えられるようにインターフェースにしています）。
+type snsAPI interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+	CreatePlatformEndpoint(ctx context.Context, params *sns.CreatePlatformEndpointInput, optFns ...func(*sns.Options)) (*sns.CreatePlatformEndpointOutput, error)
+	DeleteEndpoint(ctx context.Context, params *sns.DeleteEndpointInput, optFns ...func(*sns.Options)) (*sns.DeleteEndpointOutput, error)
+}
+
 // notificationSender はNotificationSenderの実装です。
 type notificationSender struct {
-	snsClient *sns.Client
-	sesClient *ses.Client
-	cfg       *config.NotificationConfig
+	snsClient  snsAPI
+	sesClient  *ses.Client
+	httpClient *http.Client // Webhook投稿用
+	cfg        *config.NotificationConfig
+
+	customWebhookClient *http.Client // 汎用Webhook投稿用（内部ネットワークへの接続を拒否）
+
+	// deviceTokens はSNSエンドポイントARNのキャッシュの保存先（nilの場合は保存しない）
+	deviceTokens repository.DeviceTokenRepository
+
+	// clock は汎用Webhookの署名の送信時刻（nilの場合はシステムの時刻）
+	clock clock.Clock
+}
+
+// SetClock は送信時刻に使用する現在時刻を設定します（テスト用）。
+func (n *notificationSender) SetClock(c clock.Clock) {
+	n.clock = c
+}
+
+// now は現在時刻を返します。
+func (n *notificationSender) now() time.Time {
+	return clock.Now(n.clock)
 }
 
 // NewNotificationSender は新しいNotificationSenderを作成します。
+// cfg.PushProvider に応じてプッシュ通知の送信先を切り替えます。
+//   - sns（デフォルト）: AWS SNS Platform Application 経由
+//   - fcm: FCM HTTP v1 API へ直接送信（メール通知はSESのまま）
 //
 // 引数:
 //   - cfg: 通知設定（AWS設定を含む）
+//   - deviceTokens: SNSエンドポイントARNをキャッシュするデバイストークンリポジトリ（nilの場合はキャッシュしない）
 //
 // 戻り値:
 //   - NotificationSender: 通知送信インターフェース
 //   - error: 初期化に失敗した場合のエラー
-func NewNotificationSender(cfg *config.NotificationConfig) (NotificationSender, error) {
+func NewNotificationSender(cfg *config.NotificationConfig, deviceTokens repository.DeviceTokenRepository) (NotificationSender, error) {
+	switch cfg.PushProvider {
+	case "", PushProviderSNS:
+		return newSNSNotificationSender(cfg, deviceTokens)
+	case PushProviderFCM:
+		return NewFCMSender(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported push provider: %s", cfg.PushProvider)
+	}
+}
+
+// newSNSNotificationSender はSNS/SESを使用するNotificationSenderを作成します。
+func newSNSNotificationSender(cfg *config.NotificationConfig, deviceTokens repository.DeviceTokenRepository) (*notificationSender, error) {
 	// AWS設定をロード
 	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
 		awsconfig.WithRegion(cfg.AWSRegion),
@@ -56,11 +123,21 @@ func NewNotificationSender(cfg *config.NotificationConfig) (NotificationSender,
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	tracing.InstrumentAWS(&awsCfg)
 
 	return &notificationSender{
 		snsClient: sns.NewFromConfig(awsCfg),
 		sesClient: ses.NewFromConfig(awsCfg),
-		cfg:       cfg,
+		httpClient: &http.Client{
+			Timeout: webhookRequestTimeout,
+			// 許可済みWebhookホスト以外へ転送されないようリダイレクトは追従しない
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		customWebhookClient: newCustomWebhookClient(),
+		cfg:                 cfg,
+		deviceTokens:        deviceTokens,
 	}, nil
 }
 
@@ -85,8 +162,9 @@ type FCMMessage struct {
 
 // FCMNotification はFCM通知部分の構造体です。
 type FCMNotification struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	ClickAction string `json:"click_action,omitempty"` // 通知タップ時のアクション（通知カテゴリ、SNS経由のGCM形式のみ）
 }
 
 // APNSMessage はApple Push Notification Service向けのメッセージ構造体です。
@@ -98,6 +176,7 @@ type APNSMessage struct {
 // APNSPayload はAPNS通知ペイロードです。
 type APNSPayload struct {
 	Alert            APNSAlert `json:"alert"`
+	Category         string    `json:"category,omitempty"` // 通知カテゴリ（アクションボタンの種類）
 	ContentAvailable int       `json:"content-available,omitempty"`
 	MutableContent   int       `json:"mutable-content,omitempty"`
 }
@@ -110,6 +189,8 @@ type APNSAlert struct {
 
 // SendPushNotification はプッシュ通知を送信します。
 // プラットフォームに応じてFCMまたはAPNS形式でメッセージを構築します。
+// SNSエンドポイントARNはデバイストークンにキャッシュして再利用し（送信ごとのエンドポイント作成を省略）、
+// キャッシュしたエンドポイントが無効化されていた場合は作り直して送信します。
 //
 // 引数:
 //   - ctx: コンテキスト
@@ -136,45 +217,95 @@ func (n *notificationSender) SendPushNotification(ctx context.Context, token *mo
 		return fmt.Errorf("platform ARN not configured for %s", token.Platform)
 	}
 
-	// エンドポイントを作成または取得
-	endpointARN, err := n.getOrCreateEndpoint(ctx, platformARN, token.Token)
-	if err != nil {
-		return fmt.Errorf("failed to get/create endpoint: %w", err)
-	}
-
 	// メッセージを構築
 	message, err := n.buildPushMessage(token.Platform, title, body, data)
 	if err != nil {
 		return fmt.Errorf("failed to build message: %w", err)
 	}
 
-	// リトライ付きで送信
+	// キャッシュしたエンドポイントARNがあれば再利用し、なければ作成してキャッシュする
+	cached := token.EndpointARN != "" && endpointBelongsTo(token.EndpointARN, platformARN)
+	endpointARN := token.EndpointARN
+	if !cached {
+		if endpointARN, err = n.createEndpoint(ctx, platformARN, token); err != nil {
+			return err
+		}
+	}
+
+	err = n.publishPush(ctx, token, endpointARN, message)
+	var invalid *InvalidDeviceTokenError
+	if cached && errors.As(err, &invalid) && invalid.Reason == DeviceTokenReasonSNSEndpointDisabled {
+		// キャッシュしたエンドポイントが無効化されている場合は作り直して1回だけ送り直す
+		// （作り直したエンドポイントも無効化される場合はトークン自体が無効）
+		if _, delErr := n.snsClient.DeleteEndpoint(ctx, &sns.DeleteEndpointInput{EndpointArn: aws.String(endpointARN)}); delErr != nil {
+			return fmt.Errorf("failed to delete disabled endpoint: %w", delErr)
+		}
+		if endpointARN, err = n.createEndpoint(ctx, platformARN, token); err != nil {
+			return err
+		}
+		err = n.publishPush(ctx, token, endpointARN, message)
+	}
+	return err
+}
+
+// publishPush はSNSエンドポイントへリトライ付きでプッシュ通知を送信します。
+func (n *notificationSender) publishPush(ctx context.Context, token *model.DeviceToken, endpointARN, message string) error {
 	return n.sendWithRetry(ctx, func() error {
 		_, err := n.snsClient.Publish(ctx, &sns.PublishInput{
 			TargetArn:        aws.String(endpointARN),
 			Message:          aws.String(message),
 			MessageStructure: aws.String("json"),
 		})
+		// 無効化されたエンドポイントへの送信はリトライしても成功しない
+		var disabled *snstypes.EndpointDisabledException
+		if errors.As(err, &disabled) {
+			return &InvalidDeviceTokenError{TokenID: token.ID, Reason: DeviceTokenReasonSNSEndpointDisabled, Err: err}
+		}
 		return err
 	})
 }
 
-// getOrCreateEndpoint はSNSエンドポイントを取得または作成します。
-func (n *notificationSender) getOrCreateEndpoint(ctx context.Context, platformARN, token string) (string, error) {
+// createEndpoint はSNSエンドポイントを作成し、ARNをデバイストークンにキャッシュします。
+// 同じトークンのエンドポイントが既にある場合、SNSは既存のARNを返します。
+func (n *notificationSender) createEndpoint(ctx context.Context, platformARN string, token *model.DeviceToken) (string, error) {
 	result, err := n.snsClient.CreatePlatformEndpoint(ctx, &sns.CreatePlatformEndpointInput{
 		PlatformApplicationArn: aws.String(platformARN),
-		Token:                  aws.String(token),
+		Token:                  aws.String(token.Token),
 	})
 	if err != nil {
-		return "", err
+		var invalidParam *snstypes.InvalidParameterException
+		if errors.As(err, &invalidParam) && isSNSInvalidTokenMessage(invalidParam.ErrorMessage()) {
+			return "", &InvalidDeviceTokenError{TokenID: token.ID, Reason: DeviceTokenReasonSNSInvalidToken, Err: err}
+		}
+		return "", fmt.Errorf("failed to get/create endpoint: %w", err)
+	}
+
+	endpointARN := aws.ToString(result.EndpointArn)
+	token.EndpointARN = endpointARN
+	if n.deviceTokens != nil && token.ID != 0 {
+		// キャッシュの保存に失敗しても送信は継続する（次回の送信で作成し直す）
+		if err := n.deviceTokens.UpdateEndpointARN(ctx, token.ID, endpointARN); err != nil {
+			logging.FromContext(ctx).Warn("Failed to cache SNS endpoint", "device_token_id", token.ID, "error", err)
+		}
 	}
-	return *result.EndpointArn, nil
+	return endpointARN, nil
+}
+
+// endpointBelongsTo はエンドポイントARNがプラットフォームアプリケーションのものかを判定します。
+// 設定のプラットフォームARNが変わった場合に、古いアプリケーションのキャッシュを使わないようにします。
+//
+// 例: arn:aws:sns:ap-northeast-1:123:app/APNS/garden → arn:aws:sns:ap-northeast-1:123:endpoint/APNS/garden/{id}
+func endpointBelongsTo(endpointARN, platformARN string) bool {
+	prefix := strings.Replace(platformARN, ":app/", ":endpoint/", 1) + "/"
+	return strings.HasPrefix(endpointARN, prefix)
 }
 
 // buildPushMessage はプラットフォームに応じたメッセージを構築します。
+// カスタムデータの通知カテゴリは、iOSでは aps.category、Androidでは click_action として送信します。
 func (n *notificationSender) buildPushMessage(platform, title, body string, data map[string]interface{}) (string, error) {
 	// SNSはプラットフォームごとに異なるフォーマットを期待する
 	messageMap := make(map[string]string)
+	category, _ := pushRichFields(data)
 
 	switch platform {
 	case "ios":
@@ -184,6 +315,7 @@ func (n *notificationSender) buildPushMessage(platform, title, body string, data
 					Title: title,
 					Body:  body,
 				},
+				Category:         category,
 				ContentAvailable: 1,
 				MutableContent:   1,
 			},
@@ -205,8 +337,9 @@ func (n *notificationSender) buildPushMessage(platform, title, body string, data
 
 		fcmMessage := FCMMessage{
 			Notification: &FCMNotification{
-				Title: title,
-				Body:  body,
+				Title:       title,
+				Body:        body,
+				ClickAction: category,
 			},
 			Data:     stringData,
 			Priority: "high",
@@ -295,42 +428,52 @@ func (n *notificationSender) SendEmailNotification(ctx context.Context, toEmail,
 //   - tokens: ユーザーのデバイストークン
 //
 // 戻り値:
-//   - error: 送信に失敗した場合のエラー
+//   - error: 送信に失敗した場合のエラー（チャネルごとの失敗は ChannelError、無効なトークンは InvalidDeviceTokenError として含まれます）
 func (n *notificationSender) SendNotificationEvent(ctx context.Context, event NotificationEvent, user *model.User, tokens []model.DeviceToken) error {
-	settings := user.NotificationSettings
-	if settings == nil {
-		// デフォルト設定
-		settings = &model.NotificationSettings{
-			PushEnabled:   true,
-			EmailEnabled:  true,
-			TaskReminders: true,
-			HarvestReminders: true,
-		}
-	}
+	return sendNotificationEvent(ctx, n, event, user, tokens)
+}
 
-	// イベントタイプに応じた通知設定チェック
-	var shouldSend bool
-	switch event.Type {
-	case NotificationEventTaskDueReminder, NotificationEventTaskOverdueAlert:
-		shouldSend = settings.TaskReminders
-	case NotificationEventHarvestReminder:
-		shouldSend = settings.HarvestReminders
-	default:
-		shouldSend = true
-	}
+// ChannelError は通知チャネル1つの送信エラーです。
+// 通知ログにチャネルごとの送信結果を記録するために使用します。
+type ChannelError struct {
+	Channel string // push, email, slack, discord, custom_webhook, sms
+	Err     error
+}
 
-	if !shouldSend {
-		return nil // 通知設定で無効化されている
-	}
+// Error はエラーメッセージを返します。
+func (e *ChannelError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Channel, e.Err)
+}
 
-	var lastErr error
+// Unwrap は元のエラーを返します。
+func (e *ChannelError) Unwrap() error {
+	return e.Err
+}
 
-	// プッシュ通知を送信
-	if settings.PushEnabled && len(tokens) > 0 {
+// sendNotificationEvent はユーザーの通知設定に基づいて、senderでプッシュ通知とメール通知を送信します。
+// SNS/FCMの各実装で共通の送信判定ロジックです。
+// チャネルごとの送信可否はイベントタイプ×チャネルの通知設定（notificationPreferenceEnabled）で判定します。
+func sendNotificationEvent(ctx context.Context, sender NotificationSender, event NotificationEvent, user *model.User, tokens []model.DeviceToken) error {
+	settings := effectiveNotificationSettings(user)
+
+	// 送信エラーはチャネルごとの送信結果を記録できるよう ChannelError で返す
+	var channelErrs []error
+	// 無効なトークンのエラーはトークンごとに無効化できるようにすべて返す
+	var invalidTokenErrs []error
+
+	// プッシュ通知を送信（送信数の上限に達している場合は送らず、まとめ通知で知らせる）
+	// カスタムデータにはディープリンクとアクションボタンを追加する
+	if !event.PushRateLimited && notificationPreferenceEnabled(user, event.Type, NotificationChannelPush) && len(tokens) > 0 {
+		data := pushPayloadData(event)
 		for _, token := range tokens {
 			if token.IsActive {
-				if err := n.SendPushNotification(ctx, &token, event.Title, event.Body, event.Data); err != nil {
-					lastErr = err
+				if err := sender.SendPushNotification(ctx, &token, event.Title, event.Body, data); err != nil {
+					var invalid *InvalidDeviceTokenError
+					if errors.As(err, &invalid) {
+						invalidTokenErrs = append(invalidTokenErrs, err)
+					} else {
+						channelErrs = append(channelErrs, &ChannelError{Channel: NotificationChannelPush, Err: err})
+					}
 					// エラーでも他のトークンへの送信を継続
 				}
 			}
@@ -338,48 +481,87 @@ func (n *notificationSender) SendNotificationEvent(ctx context.Context, event No
 	}
 
 	// メール通知を送信
-	if settings.EmailEnabled && user.Email != "" {
-		htmlBody := n.buildEmailHTML(event)
-		textBody := fmt.Sprintf("%s\n\n%s", event.Title, event.Body)
+	if notificationPreferenceEnabled(user, event.Type, NotificationChannelEmail) && user.Email != "" {
+		email, err := RenderNotificationEmail(event, user.Locale)
+		if err == nil {
+			err = sender.SendEmailNotification(ctx, user.Email, email.Subject, email.HTMLBody, email.TextBody)
+		}
+		if err != nil {
+			channelErrs = append(channelErrs, &ChannelError{Channel: NotificationChannelEmail, Err: err})
+		}
+	}
 
-		if err := n.SendEmailNotification(ctx, user.Email, event.Title, htmlBody, textBody); err != nil {
-			lastErr = err
+	// Slack/Discord Webhookへ投稿
+	if notificationPreferenceEnabled(user, event.Type, NotificationChannelWebhook) {
+		for channel, webhookURL := range webhookTargets(settings) {
+			if err := sender.SendWebhookNotification(ctx, channel, webhookURL, event.Title, event.Body); err != nil {
+				channelErrs = append(channelErrs, &ChannelError{Channel: channel, Err: err})
+			}
+		}
+		// 汎用Webhookへは通知イベントのJSONを署名付きで送信
+		if webhookURL, ok := customWebhookTarget(user); ok {
+			if err := sender.SendCustomWebhook(ctx, webhookURL, user.WebhookSecret, event); err != nil {
+				channelErrs = append(channelErrs, &ChannelError{Channel: NotificationChannelCustomWebhook, Err: err})
+			}
+		}
+	}
+
+	// 重要なアラートはSMSでも送信
+	if smsNotificationEnabled(user, event.Type) {
+		if err := sender.SendSMSNotification(ctx, user.PhoneNumber, buildSMSMessage(event.Title, event.Body)); err != nil {
+			channelErrs = append(channelErrs, &ChannelError{Channel: NotificationChannelSMS, Err: err})
 		}
 	}
 
-	return lastErr
-}
-
-// buildEmailHTML はメール通知用のHTML本文を生成します。
-func (n *notificationSender) buildEmailHTML(event NotificationEvent) string {
-	return fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <style>
-        body { font-family: 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #16a34a; color: white; padding: 20px; text-align: center; border-radius: 8px 8px 0 0; }
-        .content { background-color: #f9fafb; padding: 20px; border-radius: 0 0 8px 8px; }
-        .footer { text-align: center; margin-top: 20px; font-size: 12px; color: #6b7280; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>%s</h1>
-        </div>
-        <div class="content">
-            <p>%s</p>
-        </div>
-        <div class="footer">
-            <p>Home Garden アプリからの通知</p>
-        </div>
-    </div>
-</body>
-</html>
-`, event.Title, event.Body)
+	return errors.Join(append(invalidTokenErrs, channelErrs...)...)
+}
+
+// effectiveNotificationSettings はユーザーの通知設定を返します（未設定の場合はデフォルト設定）。
+func effectiveNotificationSettings(user *model.User) *model.NotificationSettings {
+	if user.NotificationSettings != nil {
+		return user.NotificationSettings
+	}
+	return &model.NotificationSettings{
+		PushEnabled:      true,
+		EmailEnabled:     true,
+		TaskReminders:    true,
+		HarvestReminders: true,
+	}
+}
+
+// NotificationChannels は通知イベントの送信対象となるチャネルを返します。
+// 通知ログの Channel に記録するために使用します（送信の成否は含みません）。
+//
+// 戻り値:
+//   - []string: push, email, slack, discord, custom_webhook, sms のうち送信対象のチャネル（この順）
+func NotificationChannels(event NotificationEvent, user *model.User, tokens []model.DeviceToken) []string {
+	var channels []string
+	if !event.PushRateLimited && notificationPreferenceEnabled(user, event.Type, NotificationChannelPush) {
+		for _, token := range tokens {
+			if token.IsActive {
+				channels = append(channels, NotificationChannelPush)
+				break
+			}
+		}
+	}
+	if notificationPreferenceEnabled(user, event.Type, NotificationChannelEmail) && user.Email != "" {
+		channels = append(channels, NotificationChannelEmail)
+	}
+	if notificationPreferenceEnabled(user, event.Type, NotificationChannelWebhook) {
+		targets := webhookTargets(effectiveNotificationSettings(user))
+		for _, channel := range []string{NotificationChannelSlack, NotificationChannelDiscord} {
+			if _, ok := targets[channel]; ok {
+				channels = append(channels, channel)
+			}
+		}
+		if _, ok := customWebhookTarget(user); ok {
+			channels = append(channels, NotificationChannelCustomWebhook)
+		}
+	}
+	if smsNotificationEnabled(user, event.Type) {
+		channels = append(channels, NotificationChannelSMS)
+	}
+	return channels
 }
 
 // =============================================================================
@@ -408,6 +590,12 @@ func (n *notificationSender) sendWithRetry(ctx context.Context, fn func() error)
 		if err := fn(); err != nil {
 			lastErr = err
 
+			// 無効なトークンはリトライしない
+			var invalid *InvalidDeviceTokenError
+			if errors.As(err, &invalid) {
+				return err
+			}
+
 			// 最後のリトライの場合はリトライしない
 			if attempt == maxRetries {
 				break
@@ -436,9 +624,13 @@ func (n *notificationSender) sendWithRetry(ctx context.Context, fn func() error)
 
 // MockNotificationSender はテスト用のモック実装です。
 type MockNotificationSender struct {
-	SentPushNotifications  []PushNotificationRecord
-	SentEmailNotifications []EmailNotificationRecord
-	ShouldFail             bool
+	SentPushNotifications    []PushNotificationRecord
+	SentEmailNotifications   []EmailNotificationRecord
+	SentWebhookNotifications []WebhookNotificationRecord
+	SentCustomWebhooks       []CustomWebhookRecord
+	SentSMSNotifications     []SMSNotificationRecord
+	ShouldFail               bool
+	InvalidTokens            map[string]string // トークン文字列 → 無効化理由（無効なトークンとしてエラーを返す）
 }
 
 // PushNotificationRecord はプッシュ通知の送信記録です。
@@ -457,11 +649,35 @@ type EmailNotificationRecord struct {
 	TextBody string
 }
 
+// WebhookNotificationRecord はWebhook通知の送信記録です。
+type WebhookNotificationRecord struct {
+	Channel    string
+	WebhookURL string
+	Title      string
+	Body       string
+}
+
+// CustomWebhookRecord は汎用Webhook通知の送信記録です。
+type CustomWebhookRecord struct {
+	WebhookURL string
+	Secret     string
+	Event      NotificationEvent
+}
+
+// SMSNotificationRecord はSMS通知の送信記録です。
+type SMSNotificationRecord struct {
+	PhoneNumber string
+	Message     string
+}
+
 // NewMockNotificationSender は新しいモック通知送信者を作成します。
 func NewMockNotificationSender() *MockNotificationSender {
 	return &MockNotificationSender{
-		SentPushNotifications:  make([]PushNotificationRecord, 0),
-		SentEmailNotifications: make([]EmailNotificationRecord, 0),
+		SentPushNotifications:    make([]PushNotificationRecord, 0),
+		SentEmailNotifications:   make([]EmailNotificationRecord, 0),
+		SentWebhookNotifications: make([]WebhookNotificationRecord, 0),
+		SentCustomWebhooks:       make([]CustomWebhookRecord, 0),
+		SentSMSNotifications:     make([]SMSNotificationRecord, 0),
 	}
 }
 
@@ -470,6 +686,9 @@ func (m *MockNotificationSender) SendPushNotification(ctx context.Context, token
 	if m.ShouldFail {
 		return fmt.Errorf("mock error: push notification failed")
 	}
+	if reason, ok := m.InvalidTokens[token.Token]; ok {
+		return &InvalidDeviceTokenError{TokenID: token.ID, Reason: reason, Err: fmt.Errorf("mock error: invalid token")}
+	}
 	m.SentPushNotifications = append(m.SentPushNotifications, PushNotificationRecord{
 		Token: token.Token,
 		Title: title,
@@ -493,6 +712,45 @@ func (m *MockNotificationSender) SendEmailNotification(ctx context.Context, toEm
 	return nil
 }
 
+// SendWebhookNotification はWebhook通知をモックで記録します。
+func (m *MockNotificationSender) SendWebhookNotification(ctx context.Context, channel, webhookURL, title, body string) error {
+	if m.ShouldFail {
+		return fmt.Errorf("mock error: webhook notification failed")
+	}
+	m.SentWebhookNotifications = append(m.SentWebhookNotifications, WebhookNotificationRecord{
+		Channel:    channel,
+		WebhookURL: webhookURL,
+		Title:      title,
+		Body:       body,
+	})
+	return nil
+}
+
+// SendCustomWebhook は汎用Webhook通知をモックで記録します。
+func (m *MockNotificationSender) SendCustomWebhook(ctx context.Context, webhookURL, secret string, event NotificationEvent) error {
+	if m.ShouldFail {
+		return fmt.Errorf("mock error: custom webhook failed")
+	}
+	m.SentCustomWebhooks = append(m.SentCustomWebhooks, CustomWebhookRecord{
+		WebhookURL: webhookURL,
+		Secret:     secret,
+		Event:      event,
+	})
+	return nil
+}
+
+// SendSMSNotification はSMS通知をモックで記録します。
+func (m *MockNotificationSender) SendSMSNotification(ctx context.Context, phoneNumber, message string) error {
+	if m.ShouldFail {
+		return fmt.Errorf("mock error: sms notification failed")
+	}
+	m.SentSMSNotifications = append(m.SentSMSNotifications, SMSNotificationRecord{
+		PhoneNumber: phoneNumber,
+		Message:     message,
+	})
+	return nil
+}
+
 // SendNotificationEvent はイベントをモックで処理します。
 func (m *MockNotificationSender) SendNotificationEvent(ctx context.Context, event NotificationEvent, user *model.User, tokens []model.DeviceToken) error {
 	if m.ShouldFail {
@@ -500,13 +758,21 @@ func (m *MockNotificationSender) SendNotificationEvent(ctx context.Context, even
 	}
 
 	// プッシュ通知を記録
+	var invalidTokenErrs []error
 	for _, token := range tokens {
+		if event.PushRateLimited {
+			break
+		}
+		if reason, ok := m.InvalidTokens[token.Token]; ok && token.IsActive {
+			invalidTokenErrs = append(invalidTokenErrs, &InvalidDeviceTokenError{TokenID: token.ID, Reason: reason, Err: fmt.Errorf("mock error: invalid token")})
+			continue
+		}
 		if token.IsActive {
 			m.SentPushNotifications = append(m.SentPushNotifications, PushNotificationRecord{
 				Token: token.Token,
 				Title: event.Title,
 				Body:  event.Body,
-				Data:  event.Data,
+				Data:  pushPayloadData(event),
 			})
 		}
 	}
@@ -520,5 +786,18 @@ func (m *MockNotificationSender) SendNotificationEvent(ctx context.Context, even
 		})
 	}
 
-	return nil
+	// Webhook通知を記録
+	for channel, webhookURL := range webhookTargets(user.NotificationSettings) {
+		_ = m.SendWebhookNotification(ctx, channel, webhookURL, event.Title, event.Body)
+	}
+	if webhookURL, ok := customWebhookTarget(user); ok {
+		_ = m.SendCustomWebhook(ctx, webhookURL, user.WebhookSecret, event)
+	}
+
+	// SMS通知を記録
+	if smsNotificationEnabled(user, event.Type) {
+		_ = m.SendSMSNotification(ctx, user.PhoneNumber, buildSMSMessage(event.Title, event.Body))
+	}
+
+	return errors.Join(invalidTokenErrs...)
 }
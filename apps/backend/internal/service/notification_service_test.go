@@ -5,10 +5,19 @@
 //   - デバイストークン登録→プッシュ通知配信フロー
 //   - イベント発行→通知配信フロー（スケジューラー含む）
 //   - ユーザー設定による通知スキップ
+//   - 重複防止キーによる同日内の重複送信の抑止
+//   - 送信失敗した通知のリトライとデッドレター
+//   - アプリ内受信箱（一覧・未読数・既読化）
+//   - Slack/Discord Webhook通知
+//   - ユーザーのタイムゾーンによる日付境界と送信時刻
+//   - おやすみモード中の通知保留と終了後の配信
+//   - ダイジェスト通知
+//   - イベントタイプ×チャネルの通知設定マトリクス
 package service
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -502,6 +511,79 @@ func TestNotificationDeduplication(t *testing.T) {
 	}
 }
 
+// TestGenerateDeduplicationKey は重複防止キー生成のテストです。
+// 期待動作:
+//   - イベントの LocalDate がある場合はその日付を使用する
+//   - LocalDate がない場合はユーザーのタイムゾーンでの日付を使用する
+func TestGenerateDeduplicationKey(t *testing.T) {
+	user := &model.User{Timezone: "Asia/Tokyo"}
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC) // 東京では 3/11 05:00
+
+	withDate := generateDeduplicationKey(NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: 7, LocalDate: "2026-03-10"}, user, now)
+	withoutDate := generateDeduplicationKey(NotificationEvent{Type: NotificationEventHarvestReminder, UserID: 7}, user, now)
+
+	if withDate != "task_due_reminder:7:2026-03-10" {
+		t.Errorf("Unexpected key with LocalDate: %s", withDate)
+	}
+	if withoutDate != "harvest_reminder:7:2026-03-11" {
+		t.Errorf("Unexpected key without LocalDate: %s", withoutDate)
+	}
+}
+
+// TestNotificationEventHandler_Deduplication はイベントハンドラーでの重複防止のテストです。
+// 期待動作:
+//   - 同じユーザー・日付・タイプのイベントは1回だけ送信され、以降は DuplicateSends になる
+//   - 送信失敗（pending）のログがある場合も再送信せず、リトライ処理に任せる
+//   - 期限切れのログは重複とみなさない
+func TestNotificationEventHandler_Deduplication(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "dedup@example.com", Timezone: "UTC"}
+	_ = mockRepos.User().Create(ctx, user)
+	event := NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: user.ID, UserEmail: user.Email, Title: "リマインダー", Body: "水やり"}
+	failing := NotificationEvent{Type: NotificationEventHarvestReminder, UserID: user.ID, UserEmail: user.Email, Title: "収穫", Body: "トマト"}
+
+	// Act
+	first, _ := handler.HandleEvents(ctx, []NotificationEvent{event, event})
+	second, _ := handler.HandleEvents(ctx, []NotificationEvent{event})
+
+	mockSender.ShouldFail = true
+	failed, _ := handler.HandleEvents(ctx, []NotificationEvent{failing})
+	mockSender.ShouldFail = false
+	afterFailure, _ := handler.HandleEvents(ctx, []NotificationEvent{failing})
+
+	// Assert
+	if first.SuccessfulSends != 1 || first.DuplicateSends != 1 {
+		t.Errorf("Expected 1 sent and 1 duplicate in first run, got %+v", first)
+	}
+	if second.SuccessfulSends != 0 || second.DuplicateSends != 1 {
+		t.Errorf("Expected duplicate in second run, got %+v", second)
+	}
+	if failed.FailedSends != 1 || afterFailure.DuplicateSends != 1 {
+		t.Errorf("Expected pending log to block resend, got failed=%+v after=%+v", failed, afterFailure)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected 1 email, got %d", len(mockSender.SentEmailNotifications))
+	}
+
+	// 期限切れのログは重複とみなさない
+	key := generateDeduplicationKey(event, user, time.Now())
+	log, err := mockRepos.NotificationLog().GetByDeduplicationKey(ctx, key)
+	if err != nil {
+		t.Fatalf("Expected log for key %s: %v", key, err)
+	}
+	log.ExpiresAt = time.Now().Add(-time.Minute)
+	expired, _ := handler.HandleEvents(ctx, []NotificationEvent{event})
+	if expired.SuccessfulSends != 1 {
+		t.Errorf("Expected send after log expired, got %+v", expired)
+	}
+}
+
 // =============================================================================
 // 通知設定更新テスト
 // =============================================================================
@@ -674,3 +756,831 @@ func TestNotificationEventHandler_HandleEvents(t *testing.T) {
 		t.Errorf("Expected 0 failed sends, got %d", result.FailedSends)
 	}
 }
+
+// =============================================================================
+// 通知リトライテスト
+// =============================================================================
+
+// TestNotificationEventHandler_RetryPendingNotifications は失敗した通知の再送信フローのテストです。
+// 期待動作:
+//   - 送信失敗時は pending としてリトライ待ちになる
+//   - リトライ時刻前は再送信されない
+//   - 再送信に成功すると sent になる
+func TestNotificationEventHandler_RetryPendingNotifications(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "retry@example.com"}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	mockSender.ShouldFail = true
+	event := NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: user.ID, Title: "リマインダー", Body: "水やり"}
+	if err := handler.HandleEvent(ctx, event); err == nil {
+		t.Fatal("Expected send error")
+	}
+
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].Status != "pending" || logs[0].NextRetryAt == nil {
+		t.Fatalf("Expected pending log with next retry time, got %+v", logs)
+	}
+	logID := logs[0].ID
+
+	// リトライ時刻前は対象外
+	result, err := handler.RetryPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	if result.Attempted != 0 {
+		t.Errorf("Expected no attempts before next retry time, got %d", result.Attempted)
+	}
+
+	// リトライ時刻を過去にして再送信
+	log, _ := mockRepos.NotificationLog().GetByID(ctx, logID)
+	past := time.Now().Add(-time.Minute)
+	log.NextRetryAt = &past
+	mockSender.ShouldFail = false
+
+	// Act
+	result, err = handler.RetryPendingNotifications(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	if result.Attempted != 1 || result.Succeeded != 1 {
+		t.Errorf("Expected 1 successful retry, got %+v", result)
+	}
+	log, _ = mockRepos.NotificationLog().GetByID(ctx, logID)
+	if log.Status != "sent" || log.SentAt == nil || log.NextRetryAt != nil {
+		t.Errorf("Expected sent log, got %+v", log)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected 1 email sent, got %d", len(mockSender.SentEmailNotifications))
+	}
+}
+
+// TestNotificationEventHandler_RetryDeadLetter は最大リトライ回数到達で failed になることのテストです。
+func TestNotificationEventHandler_RetryDeadLetter(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	mockSender.ShouldFail = true
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "dead@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+	log := &model.NotificationLog{
+		UserID:           user.ID,
+		NotificationType: string(NotificationEventHarvestReminder),
+		Channel:          "push,email",
+		Title:            "収穫リマインダー",
+		Status:           "pending",
+		RetryCount:       NotificationMaxRetries - 2,
+		ExpiresAt:        time.Now().Add(24 * time.Hour),
+	}
+	_ = mockRepos.NotificationLog().Create(ctx, log)
+
+	// Act: 1回目は再予約、2回目でデッドレター
+	first, _ := handler.RetryPendingNotifications(ctx)
+	stored, _ := mockRepos.NotificationLog().GetByID(ctx, log.ID)
+	if first.Rescheduled != 1 || stored.RetryCount != NotificationMaxRetries-1 || stored.NextRetryAt == nil {
+		t.Fatalf("Expected rescheduled retry, got result=%+v log=%+v", first, stored)
+	}
+	expectedDelay := notificationRetryDelay(stored.RetryCount)
+	if d := time.Until(*stored.NextRetryAt); d < expectedDelay-time.Minute || d > expectedDelay {
+		t.Errorf("Expected next retry in ~%v, got %v", expectedDelay, d)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	stored.NextRetryAt = &past
+	second, _ := handler.RetryPendingNotifications(ctx)
+
+	// Assert
+	stored, _ = mockRepos.NotificationLog().GetByID(ctx, log.ID)
+	if second.DeadLettered != 1 {
+		t.Errorf("Expected 1 dead-lettered notification, got %+v", second)
+	}
+	if stored.Status != "failed" || stored.RetryCount != NotificationMaxRetries || stored.ErrorMessage == "" {
+		t.Errorf("Expected failed log at max retries, got %+v", stored)
+	}
+
+	// デッドレター後は再送信対象外
+	third, _ := handler.RetryPendingNotifications(ctx)
+	if third.Attempted != 0 {
+		t.Errorf("Expected no further attempts, got %d", third.Attempted)
+	}
+}
+
+// =============================================================================
+// アプリ内受信箱テスト
+// =============================================================================
+
+// TestGetNotificationInbox はページングと未読フィルタのテストです。
+// 期待動作:
+//   - 最新順に返される
+//   - total は条件に一致する総件数、unread_count は未読の総件数
+//   - unreadOnly=true では既読の通知が除外される
+func TestGetNotificationInbox(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	for _, title := range []string{"first", "second", "third"} {
+		_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{
+			UserID:           1,
+			NotificationType: string(NotificationEventTaskDueReminder),
+			Title:            title,
+			Status:           "sent",
+		})
+	}
+	_ = mockRepos.NotificationLog().Create(ctx, &model.NotificationLog{UserID: 2, Title: "other user"})
+	_ = mockRepos.NotificationLog().MarkAsRead(ctx, 3, time.Now())
+
+	// Act
+	page, err := svc.GetNotificationInbox(ctx, 1, 2, 0, false)
+	if err != nil {
+		t.Fatalf("GetNotificationInbox failed: %v", err)
+	}
+	unread, err := svc.GetNotificationInbox(ctx, 1, 0, 0, true)
+	if err != nil {
+		t.Fatalf("GetNotificationInbox (unread) failed: %v", err)
+	}
+
+	// Assert
+	if page.Total != 3 || page.UnreadCount != 2 || page.Limit != 2 {
+		t.Errorf("Unexpected page metadata: %+v", page)
+	}
+	if len(page.Items) != 2 || page.Items[0].Title != "third" || page.Items[0].Unread {
+		t.Errorf("Expected newest (read) item first, got %+v", page.Items)
+	}
+	if unread.Total != 2 || len(unread.Items) != 2 || unread.Limit != DefaultNotificationInboxLimit {
+		t.Errorf("Expected 2 unread items with default limit, got %+v", unread)
+	}
+	for _, item := range unread.Items {
+		if !item.Unread {
+			t.Errorf("Expected only unread items, got %+v", item)
+		}
+	}
+}
+
+// TestMarkNotificationRead は既読化と所有者チェックのテストです。
+// 期待動作:
+//   - 既読日時が設定され、未読数が減る
+//   - 再度既読にしても既読日時は変わらない
+//   - 他ユーザーの通知は ErrNotificationNotOwned
+func TestMarkNotificationRead(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	log := &model.NotificationLog{UserID: 1, Title: "収穫リマインダー"}
+	_ = mockRepos.NotificationLog().Create(ctx, log)
+
+	// Act
+	item, err := svc.MarkNotificationRead(ctx, 1, log.ID)
+	if err != nil {
+		t.Fatalf("MarkNotificationRead failed: %v", err)
+	}
+	again, err := svc.MarkNotificationRead(ctx, 1, log.ID)
+	if err != nil {
+		t.Fatalf("MarkNotificationRead (again) failed: %v", err)
+	}
+	_, ownErr := svc.MarkNotificationRead(ctx, 2, log.ID)
+	count, _ := svc.GetUnreadNotificationCount(ctx, 1)
+
+	// Assert
+	if item.Unread || item.ReadAt == nil {
+		t.Errorf("Expected item to be read, got %+v", item)
+	}
+	if again.ReadAt == nil || !again.ReadAt.Equal(*item.ReadAt) {
+		t.Errorf("Expected read_at to stay %v, got %v", item.ReadAt, again.ReadAt)
+	}
+	if ownErr != ErrNotificationNotOwned {
+		t.Errorf("Expected ErrNotificationNotOwned, got %v", ownErr)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 unread, got %d", count)
+	}
+}
+
+// =============================================================================
+// Webhook通知テスト
+// =============================================================================
+
+// TestValidateWebhookURL はWebhook URL検証のテストです。
+// 期待動作:
+//   - 公式のSlack/Discord Webhook URLのみ受け付ける
+func TestValidateWebhookURL(t *testing.T) {
+	cases := []struct {
+		channel string
+		url     string
+		valid   bool
+	}{
+		{NotificationChannelSlack, "https://hooks.slack.com/services/T000/B000/XXXX", true},
+		{NotificationChannelDiscord, "https://discord.com/api/webhooks/123/abc", true},
+		{NotificationChannelDiscord, "https://discordapp.com/api/webhooks/123/abc", true},
+		{NotificationChannelSlack, "http://hooks.slack.com/services/T000/B000/XXXX", false},
+		{NotificationChannelSlack, "https://hooks.slack.com.evil.example/services/x", false},
+		{NotificationChannelSlack, "https://hooks.slack.com/services/", false},
+		{NotificationChannelSlack, "https://discord.com/api/webhooks/123/abc", false},
+		{NotificationChannelDiscord, "https://discord.com:8443/api/webhooks/123/abc", false},
+		{NotificationChannelDiscord, "https://169.254.169.254/latest/meta-data", false},
+	}
+
+	for _, tc := range cases {
+		err := ValidateWebhookURL(tc.channel, tc.url)
+		if tc.valid && err != nil {
+			t.Errorf("Expected %s URL %q to be valid, got %v", tc.channel, tc.url, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("Expected %s URL %q to be rejected", tc.channel, tc.url)
+		}
+	}
+}
+
+// TestBuildWebhookPayload はSlack/Discord向けのメッセージ整形のテストです。
+func TestBuildWebhookPayload(t *testing.T) {
+	// Act
+	slackJSON, err := buildWebhookPayload(NotificationChannelSlack, "収穫リマインダー", "トマトの収穫時期です")
+	if err != nil {
+		t.Fatalf("buildWebhookPayload (slack) failed: %v", err)
+	}
+	discordJSON, err := buildWebhookPayload(NotificationChannelDiscord, "収穫リマインダー", "トマトの収穫時期です")
+	if err != nil {
+		t.Fatalf("buildWebhookPayload (discord) failed: %v", err)
+	}
+
+	// Assert
+	var slack slackMessage
+	_ = json.Unmarshal(slackJSON, &slack)
+	if slack.Text == "" || len(slack.Blocks) != 2 || slack.Blocks[0].Text.Text != "収穫リマインダー" {
+		t.Errorf("Unexpected slack payload: %s", slackJSON)
+	}
+	var discord discordMessage
+	_ = json.Unmarshal(discordJSON, &discord)
+	if len(discord.Embeds) != 1 || discord.Embeds[0].Description != "トマトの収穫時期です" {
+		t.Errorf("Unexpected discord payload: %s", discordJSON)
+	}
+}
+
+// TestNotificationEventHandler_WebhookChannels はWebhookチャネルへの送信とログ記録のテストです。
+// 期待動作:
+//   - 有効かつURL設定済みのチャネルにのみ投稿される
+//   - 通知ログの Channel に送信対象チャネルが記録される
+func TestNotificationEventHandler_WebhookChannels(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{
+		Email: "hook@example.com",
+		NotificationSettings: &model.NotificationSettings{
+			EmailEnabled:      true,
+			HarvestReminders:  true,
+			SlackEnabled:      true,
+			SlackWebhookURL:   "https://hooks.slack.com/services/T000/B000/XXXX",
+			DiscordEnabled:    false,
+			DiscordWebhookURL: "https://discord.com/api/webhooks/123/abc",
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	err := handler.HandleEvent(ctx, NotificationEvent{
+		Type:   NotificationEventHarvestReminder,
+		UserID: user.ID,
+		Title:  "収穫リマインダー",
+		Body:   "トマトの収穫時期です",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	if len(mockSender.SentWebhookNotifications) != 1 || mockSender.SentWebhookNotifications[0].Channel != NotificationChannelSlack {
+		t.Errorf("Expected only slack webhook, got %+v", mockSender.SentWebhookNotifications)
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].Channel != "email,slack" {
+		t.Errorf("Expected channel 'email,slack', got %+v", logs)
+	}
+}
+
+// TestUpdateNotificationSettings_InvalidWebhookURL は不正なWebhook URLの拒否テストです。
+func TestUpdateNotificationSettings_InvalidWebhookURL(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "hook@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	_, err := svc.UpdateNotificationSettings(ctx, user.ID, &model.NotificationSettings{
+		SlackEnabled:    true,
+		SlackWebhookURL: "https://internal.example/services/x",
+	})
+
+	// Assert
+	if err != ErrInvalidWebhookURL {
+		t.Errorf("Expected ErrInvalidWebhookURL, got %v", err)
+	}
+}
+
+// =============================================================================
+// タイムゾーンテスト
+// =============================================================================
+
+// TestUserDayBounds はユーザーのタイムゾーンでの1日の範囲のテストです。
+// 期待動作:
+//   - 同じ時刻でもタイムゾーンによって「今日」が異なる
+//   - 未設定の場合は Asia/Tokyo を使用する
+func TestUserDayBounds(t *testing.T) {
+	// 2026-03-10 15:30 UTC = 2026-03-11 00:30 JST = 2026-03-10 11:30 EDT
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		wantDate string
+	}{
+		{"Tokyo", "Asia/Tokyo", "2026-03-11"},
+		{"NewYork", "America/New_York", "2026-03-10"},
+		{"Default", "", "2026-03-11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := userDayBounds(&model.User{Timezone: tt.timezone}, now)
+
+			if got := start.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("Expected local date %s, got %s", tt.wantDate, got)
+			}
+			if start.Hour() != 0 || start.Minute() != 0 {
+				t.Errorf("Expected start at local midnight, got %v", start)
+			}
+			if !end.Equal(start.AddDate(0, 0, 1)) {
+				t.Errorf("Expected end one day after start, got %v", end)
+			}
+			if now.Before(start) || !now.Before(end) {
+				t.Errorf("Expected now within [%v, %v)", start, end)
+			}
+		})
+	}
+}
+
+// TestProcessScheduledNotifications_ReminderHour はユーザー指定の送信時刻のテストです。
+// 期待動作:
+//   - ユーザーのタイムゾーンで送信時刻前はリマインダーを生成しない
+//   - 送信時刻以降はユーザーのローカル日付でリマインダーを生成する
+func TestProcessScheduledNotifications_ReminderHour(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	reminderHour := 8
+	user := &model.User{
+		Email:    "ny@example.com",
+		Timezone: "America/New_York",
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:       true,
+			TaskReminders:     true,
+			DailyReminderHour: &reminderHour,
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+
+	ny, _ := time.LoadLocation("America/New_York")
+	task := &model.Task{
+		UserID:  user.ID,
+		Title:   "水やり",
+		DueDate: time.Date(2026, 3, 10, 12, 0, 0, 0, ny),
+		Status:  "pending",
+		User:    *user, // モックでPreloadをシミュレート
+	}
+	_ = mockRepos.Task().Create(ctx, task)
+
+	// Act
+	before, err := svc.processScheduledNotificationsAt(ctx, time.Date(2026, 3, 10, 7, 0, 0, 0, ny))
+	if err != nil {
+		t.Fatalf("processScheduledNotificationsAt failed: %v", err)
+	}
+	after, err := svc.processScheduledNotificationsAt(ctx, time.Date(2026, 3, 10, 9, 0, 0, 0, ny))
+	if err != nil {
+		t.Fatalf("processScheduledNotificationsAt failed: %v", err)
+	}
+
+	// Assert
+	if before.TodayTaskReminders != 0 {
+		t.Errorf("Expected no reminders before 08:00 local, got %d", before.TodayTaskReminders)
+	}
+	if after.TodayTaskReminders != 1 {
+		t.Fatalf("Expected 1 reminder after 08:00 local, got %d", after.TodayTaskReminders)
+	}
+	if after.Events[0].LocalDate != "2026-03-10" {
+		t.Errorf("Expected local date 2026-03-10, got %s", after.Events[0].LocalDate)
+	}
+}
+
+// TestSetUserTimezone はタイムゾーン更新のテストです。
+// 期待動作:
+//   - IANAタイムゾーン名は保存される
+//   - 不正な値は ErrInvalidTimezone
+func TestSetUserTimezone(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "tz@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	tz, err := svc.SetUserTimezone(ctx, user.ID, "Europe/Berlin")
+	_, invalidErr := svc.SetUserTimezone(ctx, user.ID, "Mars/Olympus_Mons")
+	_, emptyErr := svc.SetUserTimezone(ctx, user.ID, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SetUserTimezone failed: %v", err)
+	}
+	if tz != "Europe/Berlin" {
+		t.Errorf("Expected Europe/Berlin, got %s", tz)
+	}
+	if invalidErr != ErrInvalidTimezone || emptyErr != ErrInvalidTimezone {
+		t.Errorf("Expected ErrInvalidTimezone, got %v / %v", invalidErr, emptyErr)
+	}
+	if stored, _ := svc.GetUserTimezone(ctx, user.ID); stored != "Europe/Berlin" {
+		t.Errorf("Expected stored timezone Europe/Berlin, got %s", stored)
+	}
+}
+
+// =============================================================================
+// おやすみモードテスト
+// =============================================================================
+
+// TestQuietHoursEnd はおやすみモードの時間帯判定のテストです。
+// 期待動作:
+//   - 日をまたぐ時間帯（21:00〜08:00）は夜・早朝とも対象で、終了日時は次の08:00
+//   - 同日内の時間帯も判定できる
+//   - 無効・不正な設定は対象外
+func TestQuietHoursEnd(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		enabled   bool
+		now       time.Time
+		wantQuiet bool
+		wantEnd   time.Time
+	}{
+		{"夜", "21:00", "08:00", true, time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC), true, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)},
+		{"早朝", "21:00", "08:00", true, time.Date(2026, 3, 11, 7, 59, 0, 0, time.UTC), true, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)},
+		{"日中", "21:00", "08:00", true, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"同日内", "13:00", "15:00", true, time.Date(2026, 3, 11, 14, 0, 0, 0, time.UTC), true, time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC)},
+		{"無効", "21:00", "08:00", false, time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"不正な時刻", "25:00", "08:00", true, time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC), false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{
+				Timezone: "UTC",
+				NotificationSettings: &model.NotificationSettings{
+					QuietHoursEnabled: tt.enabled,
+					QuietHoursStart:   tt.start,
+					QuietHoursEnd:     tt.end,
+				},
+			}
+
+			end, quiet := quietHoursEnd(user, tt.now)
+
+			if quiet != tt.wantQuiet {
+				t.Fatalf("Expected quiet=%v, got %v", tt.wantQuiet, quiet)
+			}
+			if !end.Equal(tt.wantEnd) {
+				t.Errorf("Expected end %v, got %v", tt.wantEnd, end)
+			}
+		})
+	}
+}
+
+// TestNotificationEventHandler_QuietHoursDefer はおやすみモード中の通知保留のテストです。
+// 期待動作:
+//   - おやすみモード中は送信せず deferred として記録する
+//   - 終了日時前のリトライ処理では送信しない
+//   - 終了後のリトライ処理で送信され sent になる
+func TestNotificationEventHandler_QuietHoursDefer(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	// 現在時刻を含むおやすみモードを設定
+	now := time.Now().UTC()
+	user := &model.User{
+		Email:    "quiet@example.com",
+		Timezone: "UTC",
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:       true,
+			EmailEnabled:      true,
+			TaskReminders:     true,
+			QuietHoursEnabled: true,
+			QuietHoursStart:   now.Add(-time.Hour).Format("15:04"),
+			QuietHoursEnd:     now.Add(time.Hour).Format("15:04"),
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+
+	event := NotificationEvent{Type: NotificationEventTaskDueReminder, UserID: user.ID, UserEmail: user.Email, Title: "リマインダー", Body: "水やり"}
+
+	// Act
+	result, err := handler.HandleEvents(ctx, []NotificationEvent{event})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("HandleEvents failed: %v", err)
+	}
+	if result.DeferredSends != 1 || result.SuccessfulSends != 0 {
+		t.Errorf("Expected 1 deferred send, got %+v", result)
+	}
+	if len(mockSender.SentEmailNotifications) != 0 {
+		t.Errorf("Expected no email during quiet hours, got %d", len(mockSender.SentEmailNotifications))
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].Status != "deferred" || logs[0].NextRetryAt == nil || !logs[0].NextRetryAt.After(now) {
+		t.Fatalf("Expected deferred log until quiet hours end, got %+v", logs)
+	}
+	logID := logs[0].ID
+
+	// 終了日時前は送信されない
+	retry, _ := handler.RetryPendingNotifications(ctx)
+	if retry.Attempted != 0 {
+		t.Errorf("Expected no attempts before quiet hours end, got %d", retry.Attempted)
+	}
+
+	// おやすみモードが終了した状態にして再送信
+	log, _ := mockRepos.NotificationLog().GetByID(ctx, logID)
+	past := time.Now().Add(-time.Minute)
+	log.NextRetryAt = &past
+	user.NotificationSettings.QuietHoursEnabled = false
+	_ = mockRepos.User().Update(ctx, user)
+
+	retry, err = handler.RetryPendingNotifications(ctx)
+	if err != nil {
+		t.Fatalf("RetryPendingNotifications failed: %v", err)
+	}
+	if retry.Succeeded != 1 {
+		t.Errorf("Expected 1 delivered notification, got %+v", retry)
+	}
+	log, _ = mockRepos.NotificationLog().GetByID(ctx, logID)
+	if log.Status != "sent" || log.RetryCount != 0 {
+		t.Errorf("Expected sent log without retry count, got %+v", log)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Errorf("Expected 1 email after quiet hours, got %d", len(mockSender.SentEmailNotifications))
+	}
+}
+
+// TestUpdateNotificationSettings_InvalidQuietHours は不正なおやすみモード設定のテストです。
+func TestUpdateNotificationSettings_InvalidQuietHours(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{Email: "quiet@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	_, err := svc.UpdateNotificationSettings(ctx, user.ID, &model.NotificationSettings{
+		QuietHoursEnabled: true,
+		QuietHoursStart:   "21:00",
+		QuietHoursEnd:     "21:00",
+	})
+
+	// Assert
+	if err != ErrInvalidQuietHours {
+		t.Errorf("Expected ErrInvalidQuietHours, got %v", err)
+	}
+}
+
+// =============================================================================
+// ダイジェスト通知テスト
+// =============================================================================
+
+// TestBuildDigestEvent はダイジェストイベント生成のテストです。
+// 期待動作:
+//   - 複数イベントは件数入りのタイトルと箇条書きの本文にまとまる
+//   - 1件の場合は元のタイトルと本文を使用する
+func TestBuildDigestEvent(t *testing.T) {
+	events := []NotificationEvent{
+		{Type: NotificationEventTaskOverdueAlert, UserID: 1, Title: "期限切れタスクの警告", Body: "3件のタスクが期限切れです。", LocalDate: "2026-03-10"},
+		{Type: NotificationEventHarvestReminder, UserID: 1, Title: "収穫リマインダー", Body: "トマト があと2日で収穫予定です。", Data: map[string]interface{}{"crop_count": 1}},
+	}
+
+	digest := buildDigestEvent(events, DefaultEmailLocale)
+	single := buildDigestEvent(events[1:], DefaultEmailLocale)
+
+	if digest.Type != NotificationEventDailyDigest || digest.LocalDate != "2026-03-10" {
+		t.Errorf("Unexpected digest type/date: %+v", digest)
+	}
+	if digest.Title != "今日のお知らせ（2件）" {
+		t.Errorf("Unexpected digest title: %s", digest.Title)
+	}
+	if digest.Body != "・期限切れタスクの警告: 3件のタスクが期限切れです。\n・収穫リマインダー: トマト があと2日で収穫予定です。" {
+		t.Errorf("Unexpected digest body: %s", digest.Body)
+	}
+	if _, ok := digest.Data[string(NotificationEventHarvestReminder)]; !ok {
+		t.Errorf("Expected constituent data in digest, got %+v", digest.Data)
+	}
+	if single.Title != "収穫リマインダー" || single.Type != NotificationEventDailyDigest {
+		t.Errorf("Expected single-event digest to keep title, got %+v", single)
+	}
+}
+
+// TestProcessScheduledNotificationsAndSend_Digest はダイジェスト設定のユーザーへの送信テストです。
+// 期待動作:
+//   - 期限切れ警告と当日リマインダーが1件のメールにまとまる
+//   - 同じ日の再実行では送信されない
+func TestProcessScheduledNotificationsAndSend_Digest(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{
+		Email:    "digest@example.com",
+		Timezone: "UTC",
+		NotificationSettings: &model.NotificationSettings{
+			EmailEnabled:        true,
+			TaskReminders:       true,
+			DigestNotifications: true,
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+
+	now := time.Now().UTC()
+	dueDates := []time.Time{
+		now.AddDate(0, 0, -2), now.AddDate(0, 0, -2), now.AddDate(0, 0, -2), // 期限切れ3件
+		time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.UTC), // 今日
+	}
+	for _, due := range dueDates {
+		_ = mockRepos.Task().Create(ctx, &model.Task{UserID: user.ID, Title: "水やり", DueDate: due, Status: "pending", User: *user})
+	}
+
+	// Act
+	result, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+	again, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+
+	// Assert
+	if result.TotalEvents != 1 || result.SuccessfulSends != 1 {
+		t.Errorf("Expected 1 digest event, got %+v", result)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Fatalf("Expected 1 digest email, got %d (second run: %+v)", len(mockSender.SentEmailNotifications), again)
+	}
+	if subject := mockSender.SentEmailNotifications[0].Subject; subject != "今日のお知らせ（2件）" {
+		t.Errorf("Unexpected digest subject: %s", subject)
+	}
+}
+
+// =============================================================================
+// 通知設定マトリクステスト
+// =============================================================================
+
+// TestNotificationPreferences は通知設定マトリクスの取得・更新のテストです。
+// 期待動作:
+//   - 未設定の組み合わせは NotificationSettings から既定値を返す
+//   - 更新した組み合わせは customized になり、既定値より優先される
+//   - 未知のチャネルは ErrInvalidNotificationPreference
+func TestNotificationPreferences(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	user := &model.User{
+		Email: "prefs@example.com",
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:      false,
+			EmailEnabled:     true,
+			TaskReminders:    true,
+			HarvestReminders: false,
+		},
+	}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act
+	defaults, err := svc.GetNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetNotificationPreferences failed: %v", err)
+	}
+	updated, err := svc.UpdateNotificationPreferences(ctx, user.ID, []NotificationPreferenceItem{
+		{EventType: string(NotificationEventHarvestReminder), Channel: NotificationChannelPush, Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("UpdateNotificationPreferences failed: %v", err)
+	}
+	_, invalidErr := svc.UpdateNotificationPreferences(ctx, user.ID, []NotificationPreferenceItem{
+		{EventType: string(NotificationEventHarvestReminder), Channel: "sms", Enabled: true},
+	})
+
+	// Assert
+	find := func(m *NotificationPreferenceMatrix, eventType NotificationEventType, channel string) NotificationPreferenceItem {
+		for _, item := range m.Preferences {
+			if item.EventType == string(eventType) && item.Channel == channel {
+				return item
+			}
+		}
+		t.Fatalf("Missing %s/%s in matrix", eventType, channel)
+		return NotificationPreferenceItem{}
+	}
+	if len(defaults.Preferences) != len(NotificationPreferenceEventTypes)*len(NotificationPreferenceChannels) {
+		t.Errorf("Expected full matrix, got %d items", len(defaults.Preferences))
+	}
+	if find(defaults, NotificationEventTaskDueReminder, NotificationChannelPush).Enabled {
+		t.Error("Expected push disabled by default when PushEnabled is false")
+	}
+	if !find(defaults, NotificationEventTaskDueReminder, NotificationChannelEmail).Enabled {
+		t.Error("Expected task email enabled by default")
+	}
+	if find(defaults, NotificationEventHarvestReminder, NotificationChannelEmail).Enabled {
+		t.Error("Expected harvest email disabled when HarvestReminders is false")
+	}
+	if item := find(updated, NotificationEventHarvestReminder, NotificationChannelPush); !item.Enabled || !item.Customized {
+		t.Errorf("Expected customized harvest push, got %+v", item)
+	}
+	if invalidErr != ErrInvalidNotificationPreference {
+		t.Errorf("Expected ErrInvalidNotificationPreference, got %v", invalidErr)
+	}
+}
+
+// TestSendNotificationEvent_Preferences は送信時の通知設定マトリクス適用のテストです。
+// 期待動作:
+//   - マトリクスで無効にしたチャネルには送信しない
+//   - 他のイベントタイプは既定値で送信する
+func TestSendNotificationEvent_Preferences(t *testing.T) {
+	// Arrange
+	sender := NewMockNotificationSender()
+	ctx := context.Background()
+	user := &model.User{
+		Email: "prefs@example.com",
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:      true,
+			EmailEnabled:     true,
+			TaskReminders:    true,
+			HarvestReminders: true,
+		},
+		NotificationPreferences: []model.NotificationPreference{
+			{EventType: string(NotificationEventTaskOverdueAlert), Channel: NotificationChannelEmail, Enabled: false},
+		},
+	}
+	tokens := []model.DeviceToken{{Token: "fcm-token", Platform: "android", IsActive: true}}
+	overdue := NotificationEvent{Type: NotificationEventTaskOverdueAlert, Title: "期限切れタスクの警告", Body: "3件"}
+	harvest := NotificationEvent{Type: NotificationEventHarvestReminder, Title: "収穫リマインダー", Body: "トマト"}
+
+	// Act
+	if err := sendNotificationEvent(ctx, sender, overdue, user, tokens); err != nil {
+		t.Fatalf("sendNotificationEvent failed: %v", err)
+	}
+	if err := sendNotificationEvent(ctx, sender, harvest, user, tokens); err != nil {
+		t.Fatalf("sendNotificationEvent failed: %v", err)
+	}
+
+	// Assert
+	if len(sender.SentPushNotifications) != 2 {
+		t.Errorf("Expected 2 push notifications, got %d", len(sender.SentPushNotifications))
+	}
+	if len(sender.SentEmailNotifications) != 1 || sender.SentEmailNotifications[0].Subject != "収穫リマインダー" {
+		t.Errorf("Expected only harvest email, got %+v", sender.SentEmailNotifications)
+	}
+	if channels := NotificationChannels(overdue, user, tokens); len(channels) != 1 || channels[0] != NotificationChannelPush {
+		t.Errorf("Expected only push channel for overdue alert, got %v", channels)
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Organization - 組織（コミュニティガーデン・学校）
+// =============================================================================
+// 世帯（共有の庭）より大きい単位で区画・作物を共有するための組織です。
+// X-Org-ID ヘッダーで組織を指定したリクエストは、ミドルウェアが組織のスコープ（repository.ContextWithOrganization）を設定し、
+// 区画・作物のリポジトリが組織のものに絞り込みます（指定しない場合は個人の区画・作物）。
+//
+// メンバーの役割:
+//   - owner: 組織の作成者（組織から抜けることはできない）
+//   - admin: メンバーの追加・削除
+//   - member: 区画・作物の作成・更新
+//   - viewer: 閲覧のみ（区画・作物を変更するリクエストはミドルウェアで拒否）
+
+var (
+	// ErrOrganizationNotFound は組織が見つからない場合のエラー
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrOrganizationAccessDenied は組織のメンバーでない（管理の操作では owner・admin でない）場合のエラー
+	ErrOrganizationAccessDenied = errors.New("organization access denied")
+	// ErrOrganizationMemberUserNotFound はメンバーに追加するユーザーが見つからない場合のエラー
+	ErrOrganizationMemberUserNotFound = errors.New("user to add as organization member not found")
+	// ErrOrganizationMemberExists はユーザーがすでに組織のメンバーの場合のエラー
+	ErrOrganizationMemberExists = errors.New("user is already an organization member")
+	// ErrOrganizationMemberNotFound はユーザーが組織のメンバーでない場合のエラー
+	ErrOrganizationMemberNotFound = errors.New("organization member not found")
+	// ErrOrganizationOwnerCannotLeave は組織の owner を削除しようとした場合のエラー
+	ErrOrganizationOwnerCannotLeave = errors.New("organization owner cannot be removed")
+	// ErrOrganizationQuotaExceeded は組織の区画・作物・メンバーの数が上限に達している場合のエラー
+	ErrOrganizationQuotaExceeded = errors.New("organization quota exceeded")
+)
+
+// OrganizationQuotas は組織の区画・作物・メンバーの数の上限です（0 の場合は無制限）。
+type OrganizationQuotas struct {
+	MaxPlots   int `json:"max_plots"`
+	MaxCrops   int `json:"max_crops"`
+	MaxMembers int `json:"max_members"`
+}
+
+// OrganizationUsage は組織の区画・作物・メンバーの現在の数です。
+type OrganizationUsage struct {
+	Plots   int64 `json:"plots"`
+	Crops   int64 `json:"crops"`
+	Members int64 `json:"members"`
+}
+
+// organizationQuota は上限を確認する対象（区画・作物）です。
+type organizationQuota int
+
+const (
+	organizationQuotaPlots organizationQuota = iota
+	organizationQuotaCrops
+)
+
+// SetOrganizationQuotaDefaults は作成した組織の上限のデフォルトを設定します。
+// 未設定の場合は無制限で、作成後は管理者が組織ごとに変更できます（UpdateOrganizationQuotas）。
+func (s *Service) SetOrganizationQuotaDefaults(quotas OrganizationQuotas) {
+	s.orgQuotas = quotas
+}
+
+// =============================================================================
+// Organizations - 組織
+// =============================================================================
+
+// CreateOrganization は組織を作成し、作成したユーザーを owner にします。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 作成するユーザーのID（owner になる）
+//   - name: 組織名
+//   - orgType: 組織の種類（community_garden, school）
+//
+// 戻り値:
+//   - *model.Organization: 作成した組織（上限はデフォルト）
+//   - error: 作成に失敗した場合のエラー
+func (s *Service) CreateOrganization(ctx context.Context, userID uint, name, orgType string) (*model.Organization, error) {
+	organization := &model.Organization{
+		Name:       name,
+		Type:       orgType,
+		OwnerID:    userID,
+		MaxPlots:   s.orgQuotas.MaxPlots,
+		MaxCrops:   s.orgQuotas.MaxCrops,
+		MaxMembers: s.orgQuotas.MaxMembers,
+	}
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repos.Organization().Create(txCtx, organization); err != nil {
+			return err
+		}
+		return s.repos.OrganizationMember().Create(txCtx, &model.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+			Role:           model.OrganizationRoleOwner,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// GetUserOrganizations はユーザーがメンバーの組織を取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - []model.Organization: 組織の一覧（ID順）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserOrganizations(ctx context.Context, userID uint) ([]model.Organization, error) {
+	return s.repos.Organization().GetByUserID(ctx, userID)
+}
+
+// GetOrganization は組織と現在の区画・作物・メンバーの数を取得します（メンバーのみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 取得するユーザーのID
+//   - organizationID: 組織ID
+//
+// 戻り値:
+//   - *model.Organization: 組織
+//   - *model.OrganizationMember: ユーザーのメンバー（役割）
+//   - *OrganizationUsage: 現在の区画・作物・メンバーの数
+//   - error: ErrOrganizationNotFound / ErrOrganizationAccessDenied
+func (s *Service) GetOrganization(ctx context.Context, userID, organizationID uint) (*model.Organization, *model.OrganizationMember, *OrganizationUsage, error) {
+	organization, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	member, err := s.GetOrganizationMembership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	usage := &OrganizationUsage{}
+	if usage.Plots, err = s.repos.Plot().CountByOrganizationID(ctx, organizationID); err != nil {
+		return nil, nil, nil, err
+	}
+	if usage.Crops, err = s.repos.Crop().CountByOrganizationID(ctx, organizationID); err != nil {
+		return nil, nil, nil, err
+	}
+	if usage.Members, err = s.repos.OrganizationMember().CountByOrganizationID(ctx, organizationID); err != nil {
+		return nil, nil, nil, err
+	}
+	return organization, member, usage, nil
+}
+
+// GetOrganizationMembership はユーザーの組織のメンバー（役割）を取得します（X-Org-ID のミドルウェア用）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - organizationID: 組織ID
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - *model.OrganizationMember: ユーザーのメンバー
+//   - error: 組織がない場合は ErrOrganizationNotFound、メンバーでない場合は ErrOrganizationAccessDenied
+func (s *Service) GetOrganizationMembership(ctx context.Context, organizationID, userID uint) (*model.OrganizationMember, error) {
+	member, err := s.repos.OrganizationMember().Get(ctx, organizationID, userID)
+	if err == nil {
+		return member, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if _, err := s.getOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	return nil, ErrOrganizationAccessDenied
+}
+
+// UpdateOrganizationQuotas は組織の上限を変更します（管理者用）。
+// 現在の数が新しい上限を超えていても、既存の区画・作物・メンバーは削除しません（新しい作成・追加のみ拒否）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - organizationID: 組織ID
+//   - quotas: 新しい上限（0 の場合は無制限）
+//
+// 戻り値:
+//   - *model.Organization: 変更した組織
+//   - error: 組織がない場合は ErrOrganizationNotFound
+func (s *Service) UpdateOrganizationQuotas(ctx context.Context, organizationID uint, quotas OrganizationQuotas) (*model.Organization, error) {
+	organization, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	organization.MaxPlots = quotas.MaxPlots
+	organization.MaxCrops = quotas.MaxCrops
+	organization.MaxMembers = quotas.MaxMembers
+	if err := s.repos.Organization().Update(ctx, organization); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// =============================================================================
+// Organization Members - 組織のメンバー
+// =============================================================================
+
+// GetOrganizationMembers は組織のメンバーを取得します（メンバーのみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 取得するユーザーのID
+//   - organizationID: 組織ID
+//
+// 戻り値:
+//   - []model.OrganizationMember: メンバーの一覧（追加順、owner を含む）
+//   - error: ErrOrganizationNotFound / ErrOrganizationAccessDenied
+func (s *Service) GetOrganizationMembers(ctx context.Context, userID, organizationID uint) ([]model.OrganizationMember, error) {
+	if _, err := s.GetOrganizationMembership(ctx, organizationID, userID); err != nil {
+		return nil, err
+	}
+	return s.repos.OrganizationMember().GetByOrganizationID(ctx, organizationID)
+}
+
+// AddOrganizationMember はメールアドレスのユーザーを組織のメンバーに追加します（owner・admin のみ）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 追加するユーザー（owner・admin）のID
+//   - organizationID: 組織ID
+//   - email: 追加するユーザーのメールアドレス
+//   - role: 役割（admin, member, viewer）
+//
+// 戻り値:
+//   - *model.OrganizationMember: 追加したメンバー（ユーザー情報付き）
+//   - error: ErrOrganizationNotFound / ErrOrganizationAccessDenied / ErrOrganizationMemberUserNotFound /
+//     ErrOrganizationMemberExists / ErrOrganizationQuotaExceeded
+func (s *Service) AddOrganizationMember(ctx context.Context, userID, organizationID uint, email, role string) (*model.OrganizationMember, error) {
+	organization, err := s.authorizeOrganizationAdmin(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repos.User().GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationMemberUserNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.repos.OrganizationMember().Get(ctx, organizationID, user.ID); err == nil {
+		return nil, ErrOrganizationMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if organization.MaxMembers > 0 {
+		count, err := s.repos.OrganizationMember().CountByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(organization.MaxMembers) {
+			return nil, ErrOrganizationQuotaExceeded
+		}
+	}
+
+	member := &model.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           role,
+	}
+	if err := s.repos.OrganizationMember().Create(ctx, member); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrOrganizationMemberExists
+		}
+		return nil, err
+	}
+	member.User = *user
+	return member, nil
+}
+
+// RemoveOrganizationMember は組織のメンバーを削除します。
+// owner・admin は全てのメンバーを削除でき、メンバーは自分自身を削除（組織から退出）できます。owner は削除できません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 削除するユーザー（owner・admin、または削除されるメンバー本人）のID
+//   - organizationID: 組織ID
+//   - memberUserID: 削除するメンバーのユーザーID
+//
+// 戻り値:
+//   - error: ErrOrganizationNotFound / ErrOrganizationAccessDenied / ErrOrganizationMemberNotFound / ErrOrganizationOwnerCannotLeave
+func (s *Service) RemoveOrganizationMember(ctx context.Context, userID, organizationID, memberUserID uint) error {
+	if userID != memberUserID {
+		if _, err := s.authorizeOrganizationAdmin(ctx, organizationID, userID); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.GetOrganizationMembership(ctx, organizationID, memberUserID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationAccessDenied) {
+			return ErrOrganizationMemberNotFound
+		}
+		return err
+	}
+	if member.Role == model.OrganizationRoleOwner {
+		return ErrOrganizationOwnerCannotLeave
+	}
+	return s.repos.OrganizationMember().Delete(ctx, organizationID, memberUserID)
+}
+
+// getOrganization は組織を取得します（見つからない場合は ErrOrganizationNotFound）。
+func (s *Service) getOrganization(ctx context.Context, organizationID uint) (*model.Organization, error) {
+	organization, err := s.repos.Organization().GetByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+// authorizeOrganizationAdmin はユーザーが組織の owner・admin であることを確認します。
+func (s *Service) authorizeOrganizationAdmin(ctx context.Context, organizationID, userID uint) (*model.Organization, error) {
+	organization, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.GetOrganizationMembership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != model.OrganizationRoleOwner && member.Role != model.OrganizationRoleAdmin {
+		return nil, ErrOrganizationAccessDenied
+	}
+	return organization, nil
+}
+
+// checkOrganizationQuota は組織のスコープで区画・作物を作成できるか（上限に達していないか）を確認します。
+// 組織のスコープでない場合（個人の区画・作物、定期実行の処理）は確認しません。
+func (s *Service) checkOrganizationQuota(ctx context.Context, quota organizationQuota) error {
+	organizationID, ok := repository.OrganizationFromContext(ctx)
+	if !ok || organizationID == 0 {
+		return nil
+	}
+	organization, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	var limit int
+	var count int64
+	switch quota {
+	case organizationQuotaPlots:
+		if limit = organization.MaxPlots; limit > 0 {
+			count, err = s.repos.Plot().CountByOrganizationID(ctx, organizationID)
+		}
+	case organizationQuotaCrops:
+		if limit = organization.MaxCrops; limit > 0 {
+			count, err = s.repos.Crop().CountByOrganizationID(ctx, organizationID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if limit > 0 && count >= int64(limit) {
+		return ErrOrganizationQuotaExceeded
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Organization Tests - 組織のテスト
+// =============================================================================
+// テスト対象:
+//   - CreateOrganization / AddOrganizationMember / RemoveOrganizationMember: 役割ごとの操作の可否
+//   - 組織のスコープ（repository.ContextWithOrganization）での区画・作物の作成・取得
+//   - CreatePlot / CreateCrop / AddOrganizationMember: 組織の上限
+
+// newOrganizationTestUsers は組織のテスト用のユーザーを作成します。
+func newOrganizationTestUsers(t *testing.T, mockRepos *repository.MockRepositories, n int) []*model.User {
+	t.Helper()
+	users := make([]*model.User, n)
+	for i := range users {
+		users[i] = &model.User{Email: fmt.Sprintf("org%d@example.com", i+1), DisplayName: fmt.Sprintf("User %d", i+1), IsActive: true}
+		if err := mockRepos.User().Create(context.Background(), users[i]); err != nil {
+			t.Fatalf("Create user failed: %v", err)
+		}
+	}
+	return users
+}
+
+// TestOrganization_MemberRoles は組織のメンバーの役割のテストです。
+// 期待動作:
+//   - 作成したユーザーは owner になり、owner・admin はメンバーを追加できる
+//   - member・viewer・部外者はメンバーを追加できない（ErrOrganizationAccessDenied）
+//   - メンバーは自分自身を削除（退出）できるが、owner は削除できない
+func TestOrganization_MemberRoles(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	users := newOrganizationTestUsers(t, mockRepos, 5)
+	owner, admin, member, viewer, outsider := users[0], users[1], users[2], users[3], users[4]
+
+	// Act
+	org, err := svc.CreateOrganization(ctx, owner.ID, "市民農園", model.OrganizationTypeCommunityGarden)
+	if err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	_, adminErr := svc.AddOrganizationMember(ctx, owner.ID, org.ID, admin.Email, model.OrganizationRoleAdmin)
+	_, memberErr := svc.AddOrganizationMember(ctx, admin.ID, org.ID, member.Email, model.OrganizationRoleMember)
+	_, viewerErr := svc.AddOrganizationMember(ctx, admin.ID, org.ID, viewer.Email, model.OrganizationRoleViewer)
+	_, byMemberErr := svc.AddOrganizationMember(ctx, member.ID, org.ID, outsider.Email, model.OrganizationRoleMember)
+	_, byOutsiderErr := svc.AddOrganizationMember(ctx, outsider.ID, org.ID, outsider.Email, model.OrganizationRoleMember)
+	_, duplicateErr := svc.AddOrganizationMember(ctx, owner.ID, org.ID, member.Email, model.OrganizationRoleMember)
+	leaveErr := svc.RemoveOrganizationMember(ctx, viewer.ID, org.ID, viewer.ID)
+	removeOwnerErr := svc.RemoveOrganizationMember(ctx, admin.ID, org.ID, owner.ID)
+	removeByMemberErr := svc.RemoveOrganizationMember(ctx, member.ID, org.ID, admin.ID)
+
+	// Assert
+	if adminErr != nil || memberErr != nil || viewerErr != nil {
+		t.Fatalf("Expected owner and admin to add members, got %v %v %v", adminErr, memberErr, viewerErr)
+	}
+	if !errors.Is(byMemberErr, ErrOrganizationAccessDenied) || !errors.Is(byOutsiderErr, ErrOrganizationAccessDenied) {
+		t.Errorf("Expected ErrOrganizationAccessDenied, got %v %v", byMemberErr, byOutsiderErr)
+	}
+	if !errors.Is(duplicateErr, ErrOrganizationMemberExists) {
+		t.Errorf("Expected ErrOrganizationMemberExists, got %v", duplicateErr)
+	}
+	if leaveErr != nil {
+		t.Errorf("Expected viewer to leave, got %v", leaveErr)
+	}
+	if !errors.Is(removeOwnerErr, ErrOrganizationOwnerCannotLeave) {
+		t.Errorf("Expected ErrOrganizationOwnerCannotLeave, got %v", removeOwnerErr)
+	}
+	if !errors.Is(removeByMemberErr, ErrOrganizationAccessDenied) {
+		t.Errorf("Expected ErrOrganizationAccessDenied, got %v", removeByMemberErr)
+	}
+	members, _ := svc.GetOrganizationMembers(ctx, member.ID, org.ID)
+	if len(members) != 3 || members[0].Role != model.OrganizationRoleOwner {
+		t.Errorf("Expected owner, admin and member, got %+v", members)
+	}
+	if _, err := svc.GetOrganizationMembers(ctx, outsider.ID, org.ID); !errors.Is(err, ErrOrganizationAccessDenied) {
+		t.Errorf("Expected outsider to be denied, got %v", err)
+	}
+}
+
+// TestOrganization_Scope は組織のスコープでの区画・作物の作成・取得のテストです。
+// 期待動作:
+//   - 組織のスコープで作成した区画・作物は組織のものになり、組織の全メンバーが一覧・取得できる
+//   - 個人のスコープでは組織の区画・作物は一覧・取得できず、組織のスコープでは個人の区画・作物は取得できない
+//   - 別の組織のスコープでは取得できない
+func TestOrganization_Scope(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	users := newOrganizationTestUsers(t, mockRepos, 2)
+	owner, member := users[0], users[1]
+	org, _ := svc.CreateOrganization(ctx, owner.ID, "学校菜園", model.OrganizationTypeSchool)
+	other, _ := svc.CreateOrganization(ctx, owner.ID, "別の組織", model.OrganizationTypeSchool)
+	_, _ = svc.AddOrganizationMember(ctx, owner.ID, org.ID, member.Email, model.OrganizationRoleMember)
+	orgCtx := repository.ContextWithOrganization(ctx, org.ID)
+	personalCtx := repository.ContextWithOrganization(ctx, 0)
+
+	// Act
+	orgPlot := &model.Plot{UserID: owner.ID, Name: "1年生の区画", Width: 2, Height: 2}
+	orgCrop := &model.Crop{UserID: owner.ID, Name: "ミニトマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 2, 0)}
+	personalCrop := &model.Crop{UserID: owner.ID, Name: "バジル", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 1, 0)}
+	if err := svc.CreatePlot(orgCtx, orgPlot); err != nil {
+		t.Fatalf("CreatePlot failed: %v", err)
+	}
+	if err := svc.CreateCrop(orgCtx, orgCrop); err != nil {
+		t.Fatalf("CreateCrop failed: %v", err)
+	}
+	if err := svc.CreateCrop(personalCtx, personalCrop); err != nil {
+		t.Fatalf("CreateCrop failed: %v", err)
+	}
+	memberOrgCrops, _ := svc.GetUserCrops(orgCtx, member.ID)
+	memberOrgPlots, _ := svc.GetUserPlots(orgCtx, member.ID)
+	ownerPersonalCrops, _ := svc.GetUserCrops(personalCtx, owner.ID)
+	_, personalGetErr := svc.GetCropByID(personalCtx, orgCrop.ID)
+	_, orgGetErr := svc.GetCropByID(orgCtx, personalCrop.ID)
+	_, otherGetErr := svc.GetPlotByID(repository.ContextWithOrganization(ctx, other.ID), orgPlot.ID)
+
+	// Assert
+	if orgCrop.OrganizationID == nil || *orgCrop.OrganizationID != org.ID || orgPlot.OrganizationID == nil {
+		t.Errorf("Expected organization plot and crop, got %v %v", orgCrop.OrganizationID, orgPlot.OrganizationID)
+	}
+	if personalCrop.OrganizationID != nil {
+		t.Errorf("Expected personal crop, got organization %d", *personalCrop.OrganizationID)
+	}
+	if len(memberOrgCrops) != 1 || memberOrgCrops[0].ID != orgCrop.ID || len(memberOrgPlots) != 1 {
+		t.Errorf("Expected member to list organization plot and crop, got %+v %+v", memberOrgCrops, memberOrgPlots)
+	}
+	if len(ownerPersonalCrops) != 1 || ownerPersonalCrops[0].ID != personalCrop.ID {
+		t.Errorf("Expected only personal crop in personal scope, got %+v", ownerPersonalCrops)
+	}
+	if personalGetErr == nil || orgGetErr == nil || otherGetErr == nil {
+		t.Errorf("Expected records outside the scope to be not found, got %v %v %v", personalGetErr, orgGetErr, otherGetErr)
+	}
+}
+
+// TestOrganization_Quotas は組織の上限のテストです。
+// 期待動作:
+//   - 作成した組織の上限はデフォルト（SetOrganizationQuotaDefaults）
+//   - 区画・作物・メンバーの数が上限の場合は ErrOrganizationQuotaExceeded
+//   - 上限は組織のスコープの作成のみに適用し、個人の区画・作物は制限しない
+//   - UpdateOrganizationQuotas で上限を 0（無制限）にすると作成できる
+func TestOrganization_Quotas(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetOrganizationQuotaDefaults(OrganizationQuotas{MaxPlots: 1, MaxCrops: 1, MaxMembers: 2})
+	users := newOrganizationTestUsers(t, mockRepos, 3)
+	org, _ := svc.CreateOrganization(ctx, users[0].ID, "市民農園", model.OrganizationTypeCommunityGarden)
+	defaults := *org
+	orgCtx := repository.ContextWithOrganization(ctx, org.ID)
+	newPlot := func() *model.Plot { return &model.Plot{UserID: users[0].ID, Name: "区画", Width: 1, Height: 1} }
+	newCrop := func() *model.Crop {
+		return &model.Crop{UserID: users[0].ID, Name: "ナス", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 2, 0)}
+	}
+
+	// Act
+	firstPlotErr := svc.CreatePlot(orgCtx, newPlot())
+	secondPlotErr := svc.CreatePlot(orgCtx, newPlot())
+	firstCropErr := svc.CreateCrop(orgCtx, newCrop())
+	secondCropErr := svc.CreateCrop(orgCtx, newCrop())
+	personalErr := svc.CreateCrop(repository.ContextWithOrganization(ctx, 0), newCrop())
+	_, secondMemberErr := svc.AddOrganizationMember(ctx, users[0].ID, org.ID, users[1].Email, model.OrganizationRoleMember)
+	_, thirdMemberErr := svc.AddOrganizationMember(ctx, users[0].ID, org.ID, users[2].Email, model.OrganizationRoleMember)
+	if _, err := svc.UpdateOrganizationQuotas(ctx, org.ID, OrganizationQuotas{}); err != nil {
+		t.Fatalf("UpdateOrganizationQuotas failed: %v", err)
+	}
+	unlimitedErr := svc.CreatePlot(orgCtx, newPlot())
+	_, _, usage, _ := svc.GetOrganization(ctx, users[0].ID, org.ID)
+
+	// Assert
+	if defaults.MaxPlots != 1 || defaults.MaxCrops != 1 || defaults.MaxMembers != 2 {
+		t.Errorf("Expected default quotas, got %+v", defaults)
+	}
+	if firstPlotErr != nil || firstCropErr != nil || secondMemberErr != nil {
+		t.Fatalf("Expected creates within the quota, got %v %v %v", firstPlotErr, firstCropErr, secondMemberErr)
+	}
+	for name, err := range map[string]error{"plot": secondPlotErr, "crop": secondCropErr, "member": thirdMemberErr} {
+		if !errors.Is(err, ErrOrganizationQuotaExceeded) {
+			t.Errorf("Expected ErrOrganizationQuotaExceeded for %s, got %v", name, err)
+		}
+	}
+	if personalErr != nil || unlimitedErr != nil {
+		t.Errorf("Expected personal and unlimited creates to succeed, got %v %v", personalErr, unlimitedErr)
+	}
+	if usage == nil || usage.Plots != 2 || usage.Crops != 1 || usage.Members != 2 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Phone Verification - SMS通知用の電話番号認証
+// =============================================================================
+// SMS通知を受け取る電話番号は、SMSで送った6桁の認証コードを入力して認証します。
+//
+// フロー:
+//  1. StartPhoneVerification: 電話番号を受け付け、認証コードをSMSで送信（コードはハッシュで保存）
+//  2. ConfirmPhoneVerification: 認証コードが一致したら User.PhoneNumber と PhoneVerifiedAt を更新
+//
+// 認証コードは PhoneVerificationCodeTTL で失効し、PhoneVerificationMaxAttempts 回間違えると無効になります。
+// SMSの送信は番号に関わらずユーザーごとに制限します（再送信の間隔、1時間・1日あたりの送信数）。
+// 番号を交互に指定して送信を繰り返す SMS の不正利用（SMS pumping）を防ぐため、送信記録（PhoneVerificationSend）で判定します。
+// 認証済みの番号を変更する場合も同じフローで、認証に成功するまで以前の番号に送信します。
+
+const (
+	// PhoneVerificationCodeTTL は認証コードの有効期間
+	PhoneVerificationCodeTTL = 10 * time.Minute
+	// PhoneVerificationResendInterval は認証コードを再送信できるまでの間隔
+	PhoneVerificationResendInterval = time.Minute
+	// PhoneVerificationMaxAttempts は認証コードの入力を間違えられる回数
+	PhoneVerificationMaxAttempts = 5
+	// PhoneVerificationMaxSendsPerHour はユーザーごとの直近1時間の認証コードの送信数の上限
+	PhoneVerificationMaxSendsPerHour = 5
+	// PhoneVerificationMaxSendsPerDay はユーザーごとの直近24時間の認証コードの送信数の上限
+	PhoneVerificationMaxSendsPerDay = 10
+
+	// phoneVerificationCodeDigits は認証コードの桁数
+	phoneVerificationCodeDigits = 6
+)
+
+var (
+	// ErrInvalidPhoneNumber は電話番号がE.164形式でない場合のエラー
+	ErrInvalidPhoneNumber = errors.New("phone number must be in E.164 format (e.g. +819012345678)")
+	// ErrPhoneVerificationNotFound は認証中の電話番号がない、または認証コードが失効している場合のエラー
+	ErrPhoneVerificationNotFound = errors.New("no pending phone verification or code expired")
+	// ErrPhoneVerificationCodeMismatch は認証コードが一致しない場合のエラー
+	ErrPhoneVerificationCodeMismatch = errors.New("verification code does not match")
+	// ErrPhoneVerificationTooManyAttempts は認証コードの入力失敗が上限に達した場合のエラー
+	ErrPhoneVerificationTooManyAttempts = errors.New("too many verification attempts")
+	// ErrPhoneVerificationTooSoon は認証コードの再送信間隔が短すぎる場合のエラー
+	ErrPhoneVerificationTooSoon = errors.New("verification code was sent recently")
+	// ErrPhoneVerificationSendLimit は認証コードの送信数が1時間・1日あたりの上限に達した場合のエラー
+	ErrPhoneVerificationSendLimit = errors.New("too many verification codes sent")
+	// ErrPhoneNotVerified は電話番号を認証せずにSMS通知を有効にしようとした場合のエラー
+	ErrPhoneNotVerified = errors.New("phone number is not verified")
+	// ErrSMSUnavailable はSMSの送信設定がない場合のエラー
+	ErrSMSUnavailable = errors.New("sms is not available")
+)
+
+// e164Pattern はE.164形式の電話番号（+国番号から最大15桁）です。
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// PhoneVerificationStatus は電話番号の認証状況です。
+type PhoneVerificationStatus struct {
+	PhoneNumber     string     `json:"phone_number,omitempty"`      // 認証済みの電話番号
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"` // 認証日時
+	PendingNumber   string     `json:"pending_number,omitempty"`    // 認証中の電話番号
+	CodeExpiresAt   *time.Time `json:"code_expires_at,omitempty"`   // 認証コードの有効期限
+}
+
+// NormalizePhoneNumber は電話番号の区切り文字（空白・ハイフン・括弧）を取り除き、E.164形式か検証します。
+//
+// 戻り値:
+//   - string: E.164形式の電話番号
+//   - error: E.164形式でない場合は ErrInvalidPhoneNumber
+func NormalizePhoneNumber(phoneNumber string) (string, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phoneNumber))
+	if !e164Pattern.MatchString(normalized) {
+		return "", ErrInvalidPhoneNumber
+	}
+	return normalized, nil
+}
+
+// generateVerificationCode は phoneVerificationCodeDigits 桁の数字の認証コードを生成します。
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < phoneVerificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", phoneVerificationCodeDigits, n), nil
+}
+
+// hashVerificationCode は認証コードをユーザーIDと電話番号に紐づけてハッシュ化します。
+func hashVerificationCode(userID uint, phoneNumber, code string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", userID, phoneNumber, code)))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetPhoneVerificationStatus はユーザーの電話番号の認証状況を取得します。
+func (s *Service) GetPhoneVerificationStatus(ctx context.Context, userID uint) (*PhoneVerificationStatus, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &PhoneVerificationStatus{
+		PhoneNumber:     user.PhoneNumber,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+	}
+	if pending, err := s.repos.PhoneVerification().GetByUserID(ctx, userID); err == nil && pending.ExpiresAt.After(s.now()) {
+		status.PendingNumber = pending.PhoneNumber
+		status.CodeExpiresAt = &pending.ExpiresAt
+	}
+	return status, nil
+}
+
+// StartPhoneVerification は電話番号の認証を開始し、認証コードをSMSで送信します。
+// 認証中の番号がある場合は新しい番号・コードで置き換えます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - phoneNumber: 認証する電話番号（E.164形式、区切り文字は除去）
+//
+// 戻り値:
+//   - *PhoneVerificationStatus: 認証状況（認証中の番号と有効期限）
+//   - error: 電話番号が不正な場合は ErrInvalidPhoneNumber、再送信間隔内の場合は ErrPhoneVerificationTooSoon、
+//     送信数の上限に達した場合は ErrPhoneVerificationSendLimit、SMSを送信できない場合は ErrSMSUnavailable
+func (s *Service) StartPhoneVerification(ctx context.Context, userID uint, phoneNumber string) (*PhoneVerificationStatus, error) {
+	normalized, err := NormalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if s.sender == nil {
+		return nil, ErrSMSUnavailable
+	}
+	if _, err := s.repos.User().GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if err := s.checkPhoneVerificationSendLimit(ctx, userID, now); err != nil {
+		return nil, err
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+	verification := &model.PhoneVerification{
+		UserID:      userID,
+		PhoneNumber: normalized,
+		CodeHash:    hashVerificationCode(userID, normalized, code),
+		ExpiresAt:   now.Add(PhoneVerificationCodeTTL),
+	}
+	if err := s.repos.PhoneVerification().Save(ctx, verification); err != nil {
+		return nil, err
+	}
+	// 送信に失敗した場合も送信数に数える（送信先の番号による失敗を繰り返せないようにする）
+	send := &model.PhoneVerificationSend{UserID: userID, PhoneNumber: normalized}
+	if err := s.repos.PhoneVerification().RecordSend(ctx, send, now.Add(-24*time.Hour)); err != nil {
+		return nil, err
+	}
+
+	message := buildSMSMessage("認証コード: "+code, fmt.Sprintf("%d分以内に入力してください。", int(PhoneVerificationCodeTTL.Minutes())))
+	if err := s.sender.SendSMSNotification(ctx, normalized, message); err != nil {
+		return nil, fmt.Errorf("failed to send verification code: %w", err)
+	}
+
+	return s.GetPhoneVerificationStatus(ctx, userID)
+}
+
+// checkPhoneVerificationSendLimit はユーザーが認証コードを送信できるかを送信記録から判定します。
+// 送信先の番号に関わらず、直前の送信から PhoneVerificationResendInterval 以内の場合は ErrPhoneVerificationTooSoon、
+// 直近1時間・24時間の送信数が上限に達している場合は ErrPhoneVerificationSendLimit を返します。
+func (s *Service) checkPhoneVerificationSendLimit(ctx context.Context, userID uint, now time.Time) error {
+	sends, err := s.repos.PhoneVerification().GetSendsSince(ctx, userID, now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	if len(sends) > 0 && now.Sub(sends[0].CreatedAt) < PhoneVerificationResendInterval {
+		return ErrPhoneVerificationTooSoon
+	}
+	lastHour := 0
+	for _, send := range sends {
+		if now.Sub(send.CreatedAt) < time.Hour {
+			lastHour++
+		}
+	}
+	if lastHour >= PhoneVerificationMaxSendsPerHour || len(sends) >= PhoneVerificationMaxSendsPerDay {
+		return ErrPhoneVerificationSendLimit
+	}
+	return nil
+}
+
+// ConfirmPhoneVerification は認証コードを検証し、一致した場合に電話番号を認証済みにします。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - code: SMSで受け取った認証コード
+//
+// 戻り値:
+//   - *PhoneVerificationStatus: 認証後の状況
+//   - error: 認証中の番号がない・失効している場合は ErrPhoneVerificationNotFound、
+//     コードが一致しない場合は ErrPhoneVerificationCodeMismatch、
+//     入力失敗が上限に達した場合は ErrPhoneVerificationTooManyAttempts
+func (s *Service) ConfirmPhoneVerification(ctx context.Context, userID uint, code string) (*PhoneVerificationStatus, error) {
+	pending, err := s.repos.PhoneVerification().GetByUserID(ctx, userID)
+	if err != nil || !pending.ExpiresAt.After(s.now()) {
+		return nil, ErrPhoneVerificationNotFound
+	}
+	if pending.Attempts >= PhoneVerificationMaxAttempts {
+		return nil, ErrPhoneVerificationTooManyAttempts
+	}
+
+	expected := hashVerificationCode(userID, pending.PhoneNumber, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(pending.CodeHash)) != 1 {
+		pending.Attempts++
+		if err := s.repos.PhoneVerification().Save(ctx, pending); err != nil {
+			return nil, err
+		}
+		if pending.Attempts >= PhoneVerificationMaxAttempts {
+			return nil, ErrPhoneVerificationTooManyAttempts
+		}
+		return nil, ErrPhoneVerificationCodeMismatch
+	}
+
+	err = s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		user, err := s.repos.User().GetByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		now := s.now()
+		user.PhoneNumber = pending.PhoneNumber
+		user.PhoneVerifiedAt = &now
+		if err := s.repos.User().Update(txCtx, user); err != nil {
+			return err
+		}
+		return s.repos.PhoneVerification().DeleteByUserID(txCtx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPhoneVerificationStatus(ctx, userID)
+}
+
+// RemovePhoneNumber はユーザーの電話番号（認証中の番号を含む）を削除し、SMS通知を無効にします。
+func (s *Service) RemovePhoneNumber(ctx context.Context, userID uint) error {
+	return s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		user, err := s.repos.User().GetByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		user.PhoneNumber = ""
+		user.PhoneVerifiedAt = nil
+		if user.NotificationSettings != nil {
+			user.NotificationSettings.SMSEnabled = false
+		}
+		if err := s.repos.User().Update(txCtx, user); err != nil {
+			return err
+		}
+		return s.repos.PhoneVerification().DeleteByUserID(txCtx, userID)
+	})
+}
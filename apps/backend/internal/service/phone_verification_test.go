@@ -0,0 +1,297 @@
+// Package service - Phone Verification / SMS Tests
+//
+// SMS通知と電話番号認証のテストを提供します。
+// テスト対象:
+//   - 電話番号の正規化（E.164）と国番号ごとの送信者の選択
+//   - 認証コードの送信・確認・入力失敗の上限
+//   - 認証コードの送信の間隔・送信数の上限（番号に関わらずユーザーごと）
+//   - 認証済みの電話番号がない場合のSMS通知の有効化の拒否
+//   - 重要アラートのみSMSで送信されること
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// verificationCodePattern はSMS本文から認証コードを取り出します。
+var verificationCodePattern = regexp.MustCompile(`認証コード: ([0-9]{6})`)
+
+// TestNormalizePhoneNumber は電話番号の正規化のテストです。
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{"+81 90-1234-5678", "+819012345678", true},
+		{"+1 (415) 555-0100", "+14155550100", true},
+		{"090-1234-5678", "", false},
+		{"+0123456789", "", false},
+		{"+8190abc5678", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePhoneNumber(tt.input)
+		if tt.valid && (err != nil || got != tt.want) {
+			t.Errorf("%q: expected %q, got %q (%v)", tt.input, tt.want, got, err)
+		}
+		if !tt.valid && err != ErrInvalidPhoneNumber {
+			t.Errorf("%q: expected ErrInvalidPhoneNumber, got %v", tt.input, err)
+		}
+	}
+}
+
+// TestSMSSenderFor は国番号ごとの送信者の選択のテストです。
+// 期待動作:
+//   - 最も長く一致する国番号の送信者を使用する
+//   - 一致しない場合はデフォルトの送信者を使用する
+func TestSMSSenderFor(t *testing.T) {
+	senders := map[string]string{"81": "HomeGarden", "1": "+18005550100", "1868": "+18685550100"}
+
+	if got := smsSenderFor(senders, "Default", "+819012345678"); got != "HomeGarden" {
+		t.Errorf("Expected HomeGarden for JP, got %q", got)
+	}
+	if got := smsSenderFor(senders, "Default", "+18685551234"); got != "+18685550100" {
+		t.Errorf("Expected longest prefix match, got %q", got)
+	}
+	if got := smsSenderFor(senders, "Default", "+447700900123"); got != "Default" {
+		t.Errorf("Expected default sender, got %q", got)
+	}
+
+	attrs := smsMessageAttributes("+18005550100")
+	if _, ok := attrs["AWS.MM.SMS.OriginationNumber"]; !ok {
+		t.Errorf("Expected origination number attribute, got %v", attrs)
+	}
+	if _, ok := smsMessageAttributes("HomeGarden")["AWS.SNS.SMS.SenderID"]; !ok {
+		t.Error("Expected sender ID attribute")
+	}
+}
+
+// TestPhoneVerificationFlow は電話番号認証のテストです。
+// 期待動作:
+//   - 認証前はSMS通知を有効にできない
+//   - 認証コードがSMSで送信され、一致すると電話番号が認証済みになる
+//   - 間違ったコードは ErrPhoneVerificationCodeMismatch
+//   - 送信直後の再送信は ErrPhoneVerificationTooSoon
+func TestPhoneVerificationFlow(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	sender := NewMockNotificationSender()
+	svc.SetNotificationSender(sender)
+	ctx := context.Background()
+
+	user := &model.User{Email: "sms@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act & Assert
+	if _, err := svc.UpdateNotificationSettings(ctx, user.ID, &model.NotificationSettings{SMSEnabled: true}); err != ErrPhoneNotVerified {
+		t.Fatalf("Expected ErrPhoneNotVerified, got %v", err)
+	}
+
+	status, err := svc.StartPhoneVerification(ctx, user.ID, "+81 90-1234-5678")
+	if err != nil {
+		t.Fatalf("StartPhoneVerification failed: %v", err)
+	}
+	if status.PendingNumber != "+819012345678" || status.CodeExpiresAt == nil || status.PhoneNumber != "" {
+		t.Errorf("Unexpected pending status: %+v", status)
+	}
+	if len(sender.SentSMSNotifications) != 1 || sender.SentSMSNotifications[0].PhoneNumber != "+819012345678" {
+		t.Fatalf("Expected verification SMS, got %+v", sender.SentSMSNotifications)
+	}
+	match := verificationCodePattern.FindStringSubmatch(sender.SentSMSNotifications[0].Message)
+	if match == nil {
+		t.Fatalf("Expected code in SMS: %s", sender.SentSMSNotifications[0].Message)
+	}
+
+	if _, err := svc.StartPhoneVerification(ctx, user.ID, "+819012345678"); err != ErrPhoneVerificationTooSoon {
+		t.Errorf("Expected ErrPhoneVerificationTooSoon, got %v", err)
+	}
+	if _, err := svc.ConfirmPhoneVerification(ctx, user.ID, "000000x"); err != ErrPhoneVerificationCodeMismatch {
+		t.Errorf("Expected ErrPhoneVerificationCodeMismatch, got %v", err)
+	}
+
+	verified, err := svc.ConfirmPhoneVerification(ctx, user.ID, match[1])
+	if err != nil {
+		t.Fatalf("ConfirmPhoneVerification failed: %v", err)
+	}
+	if verified.PhoneNumber != "+819012345678" || verified.PhoneVerifiedAt == nil || verified.PendingNumber != "" {
+		t.Errorf("Unexpected verified status: %+v", verified)
+	}
+	if _, err := svc.UpdateNotificationSettings(ctx, user.ID, &model.NotificationSettings{SMSEnabled: true}); err != nil {
+		t.Errorf("Expected SMS to be enabled after verification, got %v", err)
+	}
+
+	if err := svc.RemovePhoneNumber(ctx, user.ID); err != nil {
+		t.Fatalf("RemovePhoneNumber failed: %v", err)
+	}
+	removed, _ := mockRepos.User().GetByID(ctx, user.ID)
+	if removed.PhoneNumber != "" || removed.PhoneVerifiedAt != nil || removed.NotificationSettings.SMSEnabled {
+		t.Errorf("Expected phone number and SMS to be removed, got %+v", removed)
+	}
+}
+
+// TestPhoneVerification_Limits は認証コードの制限のテストです。
+// 期待動作:
+//   - 入力失敗が上限に達すると正しいコードでも認証できない
+//   - 失効したコードは ErrPhoneVerificationNotFound
+//   - 通知送信者がない場合は ErrSMSUnavailable
+func TestPhoneVerification_Limits(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	sender := NewMockNotificationSender()
+	ctx := context.Background()
+
+	user := &model.User{Email: "limits@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act & Assert
+	if _, err := svc.StartPhoneVerification(ctx, user.ID, "+819012345678"); err != ErrSMSUnavailable {
+		t.Errorf("Expected ErrSMSUnavailable, got %v", err)
+	}
+
+	svc.SetNotificationSender(sender)
+	if _, err := svc.StartPhoneVerification(ctx, user.ID, "+819012345678"); err != nil {
+		t.Fatalf("StartPhoneVerification failed: %v", err)
+	}
+	code := verificationCodePattern.FindStringSubmatch(sender.SentSMSNotifications[0].Message)[1]
+
+	var lastErr error
+	for i := 0; i < PhoneVerificationMaxAttempts; i++ {
+		_, lastErr = svc.ConfirmPhoneVerification(ctx, user.ID, "wrong")
+	}
+	if lastErr != ErrPhoneVerificationTooManyAttempts {
+		t.Errorf("Expected ErrPhoneVerificationTooManyAttempts, got %v", lastErr)
+	}
+	if _, err := svc.ConfirmPhoneVerification(ctx, user.ID, code); err != ErrPhoneVerificationTooManyAttempts {
+		t.Errorf("Expected correct code to be rejected after limit, got %v", err)
+	}
+
+	pending, _ := mockRepos.PhoneVerification().GetByUserID(ctx, user.ID)
+	pending.Attempts = 0
+	pending.ExpiresAt = time.Now().Add(-time.Minute)
+	if _, err := svc.ConfirmPhoneVerification(ctx, user.ID, code); err != ErrPhoneVerificationNotFound {
+		t.Errorf("Expected ErrPhoneVerificationNotFound for expired code, got %v", err)
+	}
+}
+
+// TestPhoneVerification_SendLimits は認証コードの送信数の上限のテストです。
+// 期待動作:
+//   - 別の番号を指定しても、直前の送信から1分以内は ErrPhoneVerificationTooSoon
+//   - 番号を交互に指定しても、1時間の送信数が上限に達すると ErrPhoneVerificationSendLimit
+//   - 電話番号を削除しても送信数は戻らない
+//   - 1時間後は送信できるが、24時間の送信数が上限に達すると ErrPhoneVerificationSendLimit
+//   - 24時間後は再び送信できる
+func TestPhoneVerification_SendLimits(t *testing.T) {
+	// Arrange
+	fake := clock.NewFake(time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC))
+	mockRepos := repository.NewMockRepositories()
+	mockRepos.SetClock(fake)
+	svc := NewService(mockRepos)
+	svc.SetClock(fake)
+	sender := NewMockNotificationSender()
+	svc.SetNotificationSender(sender)
+	ctx := context.Background()
+	user := &model.User{Email: "pumping@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+	numbers := []string{"+819012345678", "+819087654321"}
+	start := func(i int) error {
+		_, err := svc.StartPhoneVerification(ctx, user.ID, numbers[i%len(numbers)])
+		return err
+	}
+
+	// Act & Assert: 1時間の上限
+	if err := start(0); err != nil {
+		t.Fatalf("StartPhoneVerification failed: %v", err)
+	}
+	if err := start(1); err != ErrPhoneVerificationTooSoon {
+		t.Errorf("Expected ErrPhoneVerificationTooSoon for another number, got %v", err)
+	}
+	for i := 1; i < PhoneVerificationMaxSendsPerHour; i++ {
+		fake.Advance(PhoneVerificationResendInterval)
+		if err := start(i); err != nil {
+			t.Fatalf("StartPhoneVerification %d failed: %v", i, err)
+		}
+	}
+	fake.Advance(PhoneVerificationResendInterval)
+	if err := svc.RemovePhoneNumber(ctx, user.ID); err != nil {
+		t.Fatalf("RemovePhoneNumber failed: %v", err)
+	}
+	if err := start(0); err != ErrPhoneVerificationSendLimit {
+		t.Errorf("Expected ErrPhoneVerificationSendLimit after %d sends in an hour, got %v", PhoneVerificationMaxSendsPerHour, err)
+	}
+
+	// Act & Assert: 24時間の上限
+	fake.Advance(time.Hour)
+	for i := PhoneVerificationMaxSendsPerHour; i < PhoneVerificationMaxSendsPerDay; i++ {
+		if err := start(i); err != nil {
+			t.Fatalf("StartPhoneVerification %d failed: %v", i, err)
+		}
+		fake.Advance(PhoneVerificationResendInterval)
+	}
+	fake.Advance(time.Hour)
+	if err := start(0); err != ErrPhoneVerificationSendLimit {
+		t.Errorf("Expected ErrPhoneVerificationSendLimit after %d sends in a day, got %v", PhoneVerificationMaxSendsPerDay, err)
+	}
+	if len(sender.SentSMSNotifications) != PhoneVerificationMaxSendsPerDay {
+		t.Errorf("Expected %d verification SMS, got %d", PhoneVerificationMaxSendsPerDay, len(sender.SentSMSNotifications))
+	}
+
+	fake.Advance(24 * time.Hour)
+	if err := start(1); err != nil {
+		t.Errorf("Expected a verification code after 24 hours, got %v", err)
+	}
+}
+
+// TestSendNotificationEvent_SMS はSMS通知の送信対象のテストです。
+// 期待動作:
+//   - 霜注意報は認証済みの電話番号へSMSでも送信される
+//   - 定期リマインダーはSMSで送信されない
+//   - SMSを有効にしていないユーザーには送信されない
+func TestSendNotificationEvent_SMS(t *testing.T) {
+	// Arrange
+	sender := NewMockNotificationSender()
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	user := &model.User{
+		Email:           "frost@example.com",
+		PhoneNumber:     "+819012345678",
+		PhoneVerifiedAt: &verifiedAt,
+		NotificationSettings: &model.NotificationSettings{
+			EmailEnabled:  true,
+			TaskReminders: true,
+			SMSEnabled:    true,
+		},
+	}
+	optedOut := *user
+	optedOut.NotificationSettings = &model.NotificationSettings{EmailEnabled: true}
+	frost := NotificationEvent{Type: NotificationEventFrostWarning, Title: "霜注意報", Body: "今夜は氷点下の予報です。"}
+	reminder := NotificationEvent{Type: NotificationEventTaskDueReminder, Title: "今日のタスク", Body: "水やり"}
+
+	// Act
+	_ = sendNotificationEvent(ctx, sender, frost, user, nil)
+	_ = sendNotificationEvent(ctx, sender, reminder, user, nil)
+	_ = sendNotificationEvent(ctx, sender, frost, &optedOut, nil)
+
+	// Assert
+	if len(sender.SentSMSNotifications) != 1 {
+		t.Fatalf("Expected 1 SMS, got %+v", sender.SentSMSNotifications)
+	}
+	if sms := sender.SentSMSNotifications[0]; sms.PhoneNumber != user.PhoneNumber || sms.Message != "【Home Garden】霜注意報\n今夜は氷点下の予報です。" {
+		t.Errorf("Unexpected SMS: %+v", sms)
+	}
+	if channels := NotificationChannels(frost, user, nil); len(channels) != 2 || channels[1] != NotificationChannelSMS {
+		t.Errorf("Expected email and sms channels, got %v", channels)
+	}
+	if len(sender.SentEmailNotifications) != 3 {
+		t.Errorf("Expected 3 emails, got %d", len(sender.SentEmailNotifications))
+	}
+}
@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/secure-scorecard/backend/internal/imageproc"
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Photo Processing - 直接アップロードした写真の加工
+// =============================================================================
+// 直接アップロードの登録（POST /uploads/complete）で写真を pending で作成し、加工はジョブキューのワーカーが行います
+// （ジョブキューが未設定・登録できない場合はリクエストの中で加工する）。
+// 加工では EXIF の向きを補正して標準サイズ（imageproc.StandardVariants）の WebP を作成し、写真にバリアントのキーを記録します。
+// WebP には EXIF を書き出さないため、位置情報（GPS）はバリアントに残りません。
+// 位置情報を含む可能性がある元の画像は加工の後（失敗した場合も）削除します。
+// 写真の保存容量（SizeBytes）は加工前は元の画像、加工後はバリアントの合計で、ユーザーの保存容量の上限（storage_quota.go）に含めます。
+// 写真の画像の差し替え（ReplacePhoto）は同じキーのバリアントを上書きするため、加工の後と写真の削除の後に
+// バリアントの CDN のキャッシュの削除を依頼します（PhotoStorage.InvalidateCache）。
+// 写真の画像は公開URLで取得できないため、閲覧できるユーザー（GetPhoto）にのみ有効期限付きの署名付きURLを発行します。
+
+// JobTypePhotoProcess は写真の加工のジョブの種類です。
+const JobTypePhotoProcess = "photo.process"
+
+// 写真の状態
+const (
+	PhotoStatusPending   = "pending"
+	PhotoStatusCompleted = "completed"
+	PhotoStatusFailed    = "failed"
+)
+
+var (
+	// ErrPhotoStorageNotConfigured は写真の保存先が未設定の場合のエラー
+	ErrPhotoStorageNotConfigured = errors.New("photo storage is not configured")
+	// ErrPhotoNotOwned は他ユーザーの写真にアクセスしようとした場合のエラー
+	ErrPhotoNotOwned = errors.New("photo does not belong to user")
+	// ErrPhotoCropNotFound は写真の作物が存在しない、またはユーザーの作物・メンバーの組織の作物でない場合のエラー
+	ErrPhotoCropNotFound = errors.New("photo crop not found")
+	// ErrPhotoHarvestNotFound は写真の収穫記録が存在しない、またはユーザーの作物・メンバーの組織の作物の収穫記録でない場合のエラー
+	ErrPhotoHarvestNotFound = errors.New("photo harvest not found")
+)
+
+// PhotoUpload は写真として登録する直接アップロードした画像です。
+type PhotoUpload struct {
+	SourceKey string // 登録した元の画像のオブジェクトキー（storage.Service.RegisterDirectUpload）
+	SizeBytes int64  // 元の画像のサイズ（バイト）
+	CropID    *uint  // 写真の作物（nil の場合は作物なし）
+	HarvestID *uint  // 写真の収穫記録（nil の場合は収穫記録なし）
+}
+
+// PhotoStorage は写真の元の画像とバリアントの保存先です（storage.Service）。
+type PhotoStorage interface {
+	DownloadPhoto(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	UploadPhotoVariant(ctx context.Context, userID, photoID uint, name, contentType string, data []byte) (string, error)
+	DeletePhoto(ctx context.Context, objectKey string) error
+	InvalidateCache(objectKeys ...string)
+}
+
+// PhotoProcessJobPayload は写真の加工のジョブのパラメータです。
+type PhotoProcessJobPayload struct {
+	PhotoID uint `json:"photo_id"`
+}
+
+// SetPhotoStorage は写真の元の画像とバリアントの保存先を設定します。
+// 未設定の場合、写真の登録（CreatePhoto）は ErrPhotoStorageNotConfigured を返します。
+func (s *Service) SetPhotoStorage(storage PhotoStorage) {
+	s.photoStorage = storage
+}
+
+// CreatePhoto は直接アップロードした画像の写真を作成し、加工をジョブキューに登録します。
+// ジョブキューが未設定・登録できない場合はその場で加工します。
+// 保存容量の上限を超える場合・作物や収穫記録が見つからない場合は、アップロードした元の画像を削除します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - upload: 登録する画像と写真の作物・収穫記録
+//
+// 戻り値:
+//   - *model.Photo: 作成した写真（ジョブキューの場合は Status: pending、その場で加工した場合は completed または failed）
+//   - error: 保存先が未設定の場合は ErrPhotoStorageNotConfigured、上限を超える場合は ErrStorageQuotaExceeded、
+//     作物・収穫記録が見つからない場合は ErrPhotoCropNotFound・ErrPhotoHarvestNotFound、
+//     その場での加工に失敗した場合のエラー（imageproc.ErrInvalidImage 等）
+func (s *Service) CreatePhoto(ctx context.Context, userID uint, upload PhotoUpload) (*model.Photo, error) {
+	if s.photoStorage == nil {
+		return nil, ErrPhotoStorageNotConfigured
+	}
+	if err := s.checkPhotoUpload(ctx, userID, upload); err != nil {
+		if deleteErr := s.photoStorage.DeletePhoto(ctx, upload.SourceKey); deleteErr != nil {
+			logging.FromContext(ctx).Warn("Failed to delete rejected upload", "key", upload.SourceKey, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	photo := &model.Photo{
+		UserID:    userID,
+		CropID:    upload.CropID,
+		HarvestID: upload.HarvestID,
+		SourceKey: upload.SourceKey,
+		SizeBytes: upload.SizeBytes,
+		Status:    PhotoStatusPending,
+	}
+	if err := s.repos.Photo().Create(ctx, photo); err != nil {
+		return nil, err
+	}
+	return photo, s.schedulePhoto(ctx, photo)
+}
+
+// ReplacePhoto はユーザーの写真の画像を直接アップロードした画像に差し替え、加工をジョブキューに登録します。
+// 加工が終わるまでは pending で、加工の後に同じキーのバリアントを上書きして CDN のキャッシュの削除を依頼します。
+// 保存容量の上限（以前の写真の容量を除く）を超える場合は、アップロードした元の画像を削除します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - id: 写真のID
+//   - upload: 差し替える画像（CropID・HarvestID は使用しない）
+//
+// 戻り値:
+//   - *model.Photo: 差し替えた写真（ジョブキューの場合は Status: pending、その場で加工した場合は completed または failed）
+//   - error: 存在しない場合はリポジトリのエラー、他ユーザーの場合は ErrPhotoNotOwned、
+//     保存先が未設定の場合は ErrPhotoStorageNotConfigured、上限を超える場合は ErrStorageQuotaExceeded、
+//     その場での加工に失敗した場合のエラー
+func (s *Service) ReplacePhoto(ctx context.Context, userID, id uint, upload PhotoUpload) (*model.Photo, error) {
+	photo, err := s.getOwnedPhoto(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.photoStorage == nil {
+		return nil, ErrPhotoStorageNotConfigured
+	}
+	if err := s.CheckStorageQuota(ctx, userID, upload.SizeBytes-photo.SizeBytes); err != nil {
+		if deleteErr := s.photoStorage.DeletePhoto(ctx, upload.SourceKey); deleteErr != nil {
+			logging.FromContext(ctx).Warn("Failed to delete rejected upload", "key", upload.SourceKey, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	// 加工待ちの以前の元の画像は加工しないため削除する（加工済みの場合は削除済み）
+	if photo.SourceKey != upload.SourceKey {
+		s.deletePhotoSource(ctx, photo)
+	}
+	photo.SourceKey = upload.SourceKey
+	photo.SizeBytes = upload.SizeBytes
+	photo.Status = PhotoStatusPending
+	photo.ErrorMessage = ""
+	if err := s.repos.Photo().Update(ctx, photo); err != nil {
+		return nil, fmt.Errorf("failed to update photo %d: %w", photo.ID, err)
+	}
+	return photo, s.schedulePhoto(ctx, photo)
+}
+
+// schedulePhoto は pending の写真の加工をジョブキューに登録します（未設定・登録できない場合はその場で加工する）。
+func (s *Service) schedulePhoto(ctx context.Context, photo *model.Photo) error {
+	if s.jobQueue != nil {
+		err := s.jobQueue.Enqueue(ctx, JobTypePhotoProcess, PhotoProcessJobPayload{PhotoID: photo.ID})
+		if err == nil {
+			return nil
+		}
+		logging.FromContext(ctx).Warn("Failed to enqueue photo, processing in the request", "photo_id", photo.ID, "error", err)
+	}
+
+	if err := s.processPhoto(ctx, photo); err != nil {
+		s.failPhoto(ctx, photo, err)
+		return err
+	}
+	return nil
+}
+
+// checkPhotoUpload は保存容量の上限と写真の作物・収穫記録（ユーザーの作物、またはユーザーがメンバーの組織の作物）を確認します。
+func (s *Service) checkPhotoUpload(ctx context.Context, userID uint, upload PhotoUpload) error {
+	if err := s.CheckStorageQuota(ctx, userID, upload.SizeBytes); err != nil {
+		return err
+	}
+	if upload.CropID != nil {
+		if err := s.checkPhotoCrop(ctx, userID, *upload.CropID, ErrPhotoCropNotFound); err != nil {
+			return err
+		}
+	}
+	if upload.HarvestID != nil {
+		harvest, err := s.repos.Harvest().GetByID(ctx, *upload.HarvestID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPhotoHarvestNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.checkPhotoCrop(ctx, userID, harvest.CropID, ErrPhotoHarvestNotFound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPhotoCrop は作物がユーザーの作物、またはユーザーがメンバーの組織の作物か確認します（そうでない場合は notFound）。
+func (s *Service) checkPhotoCrop(ctx context.Context, userID, cropID uint, notFound error) error {
+	crop, err := s.repos.Crop().GetByID(ctx, cropID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFound
+	}
+	if err != nil {
+		return err
+	}
+	shared, err := s.sharesPhotoCrop(ctx, userID, crop)
+	if err != nil {
+		return err
+	}
+	if !shared {
+		return notFound
+	}
+	return nil
+}
+
+// sharesPhotoCrop は作物がユーザーの作物、またはユーザーがメンバーの組織の作物か判定します。
+func (s *Service) sharesPhotoCrop(ctx context.Context, userID uint, crop *model.Crop) (bool, error) {
+	return s.sharesOrganizationRecord(ctx, userID, crop.UserID, crop.OrganizationID)
+}
+
+// sharesOrganizationRecord は作物・区画の記録がユーザーの記録、またはユーザーがメンバーの組織の記録か判定します。
+func (s *Service) sharesOrganizationRecord(ctx context.Context, userID, ownerID uint, organizationID *uint) (bool, error) {
+	if ownerID == userID {
+		return true, nil
+	}
+	if organizationID == nil {
+		return false, nil
+	}
+	if _, err := s.repos.OrganizationMember().Get(ctx, *organizationID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// photoCrop は写真の作物（収穫記録の写真は収穫記録の作物）を返します（作物・収穫記録がない・削除された場合は nil）。
+func (s *Service) photoCrop(ctx context.Context, photo *model.Photo) (*model.Crop, error) {
+	cropID := photo.CropID
+	if cropID == nil && photo.HarvestID != nil {
+		harvest, err := s.repos.Harvest().GetByID(ctx, *photo.HarvestID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		cropID = &harvest.CropID
+	}
+	if cropID == nil {
+		return nil, nil
+	}
+	crop, err := s.repos.Crop().GetByID(ctx, *cropID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return crop, err
+}
+
+// ProcessPhotoJob は写真の加工のジョブを処理します。
+// 処理済み（pending 以外）の写真は再配信されたジョブとみなし、削除された写真と同様に何もしません。
+// 画像としてデコードできない場合は再試行しても結果が変わらないため、写真を failed にして再試行しません。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - payload: ジョブのパラメータ
+//   - lastAttempt: 最後の試行か（失敗した場合に写真を failed にする）
+//
+// 戻り値:
+//   - error: 取得・保存に失敗した場合のエラー（ジョブキューが再試行する）
+func (s *Service) ProcessPhotoJob(ctx context.Context, payload PhotoProcessJobPayload, lastAttempt bool) error {
+	photo, err := s.repos.Photo().GetByID(ctx, payload.PhotoID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get photo %d: %w", payload.PhotoID, err)
+	}
+	if photo.Status != PhotoStatusPending {
+		return nil
+	}
+
+	err = s.processPhoto(ctx, photo)
+	if errors.Is(err, imageproc.ErrInvalidImage) || errors.Is(err, imageproc.ErrTooManyPixels) {
+		s.failPhoto(ctx, photo, err)
+		return nil
+	}
+	if err != nil && lastAttempt {
+		s.failPhoto(ctx, photo, err)
+	}
+	return err
+}
+
+// processPhoto は元の画像からバリアントを作成して保存し、写真を completed にして元の画像を削除します。
+// 差し替えで以前のバリアントを上書きした場合は、以前のバリアントの CDN のキャッシュの削除を依頼します。
+func (s *Service) processPhoto(ctx context.Context, photo *model.Photo) error {
+	if s.photoStorage == nil {
+		return ErrPhotoStorageNotConfigured
+	}
+	replaced := []string{photo.ThumbnailKey, photo.MediumKey, photo.LargeKey}
+
+	body, err := s.photoStorage.DownloadPhoto(ctx, photo.SourceKey)
+	if err != nil {
+		return err
+	}
+	result, err := imageproc.Process(body, imageproc.StandardVariants)
+	_ = body.Close()
+	if err != nil {
+		return err
+	}
+
+	var sizeBytes int64
+	for _, variant := range result.Variants {
+		key, err := s.photoStorage.UploadPhotoVariant(ctx, photo.UserID, photo.ID, variant.Name, imageproc.VariantContentType, variant.Data)
+		if err != nil {
+			return err
+		}
+		sizeBytes += int64(len(variant.Data))
+		switch variant.Name {
+		case imageproc.VariantThumbnail:
+			photo.ThumbnailKey = key
+		case imageproc.VariantMedium:
+			photo.MediumKey = key
+		case imageproc.VariantLarge:
+			photo.LargeKey = key
+		}
+	}
+
+	processedAt := s.now()
+	photo.Status = PhotoStatusCompleted
+	photo.Width = result.Width
+	photo.Height = result.Height
+	photo.SizeBytes = sizeBytes
+	photo.ErrorMessage = ""
+	photo.ProcessedAt = &processedAt
+	if err := s.repos.Photo().Update(ctx, photo); err != nil {
+		return fmt.Errorf("failed to update photo %d: %w", photo.ID, err)
+	}
+	s.photoStorage.InvalidateCache(replaced...)
+	s.deletePhotoSource(ctx, photo)
+	return nil
+}
+
+// failPhoto は写真を failed にして元の画像を削除します（記録・削除はベストエフォート）。
+func (s *Service) failPhoto(ctx context.Context, photo *model.Photo, cause error) {
+	photo.Status = PhotoStatusFailed
+	photo.SizeBytes = 0
+	photo.ErrorMessage = truncateString(cause.Error(), 500)
+	if err := s.repos.Photo().Update(ctx, photo); err != nil {
+		logging.FromContext(ctx).Warn("Failed to mark photo as failed", "photo_id", photo.ID, "error", err)
+	}
+	s.deletePhotoSource(ctx, photo)
+}
+
+// deletePhotoSource は位置情報を含む可能性がある元の画像を削除します（ベストエフォート）。
+func (s *Service) deletePhotoSource(ctx context.Context, photo *model.Photo) {
+	if s.photoStorage == nil {
+		return
+	}
+	if err := s.photoStorage.DeletePhoto(ctx, photo.SourceKey); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete photo source", "photo_id", photo.ID, "error", err)
+	}
+}
+
+// GetPhoto はユーザーが閲覧できる写真を取得します（画像の署名付きURLの発行、ジョブキューで加工する場合の状態の確認）。
+// 閲覧できるのは写真をアップロードしたユーザーと、写真の作物（収穫記録の写真は収穫記録の作物）の所有者・
+// 作物の組織のメンバー・作物の所有者の庭のメンバーです（リクエストの組織によらない）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - id: 写真のID
+//
+// 戻り値:
+//   - *model.Photo: 写真
+//   - error: 存在しない場合はリポジトリのエラー、閲覧できない場合は ErrPhotoNotOwned
+func (s *Service) GetPhoto(ctx context.Context, userID, id uint) (*model.Photo, error) {
+	photo, err := s.repos.Photo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if photo.UserID == userID {
+		return photo, nil
+	}
+
+	// 閲覧の権限は作物の所有者・組織・庭で確認するため、リクエストの組織によらず作物を取得する
+	crop, err := s.photoCrop(repository.ContextWithoutOrganization(ctx), photo)
+	if err != nil {
+		return nil, err
+	}
+	if crop == nil {
+		return nil, ErrPhotoNotOwned
+	}
+	shared, err := s.sharesPhotoCrop(ctx, userID, crop)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		shared, err = s.sharesGardenRecord(ctx, userID, crop.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !shared {
+		return nil, ErrPhotoNotOwned
+	}
+	return photo, nil
+}
+
+// getOwnedPhoto はユーザーがアップロードした写真を取得します（差し替え・削除）。
+func (s *Service) getOwnedPhoto(ctx context.Context, userID, id uint) (*model.Photo, error) {
+	photo, err := s.repos.Photo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if photo.UserID != userID {
+		return nil, ErrPhotoNotOwned
+	}
+	return photo, nil
+}
+
+// DeletePhoto はユーザーの写真を削除します（整理の候補の orphaned_photos の削除）。
+// 保存先の元の画像・バリアントを削除してから写真を論理削除するため、保存先の削除に失敗した場合は再度削除できます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - id: 写真のID
+//
+// 戻り値:
+//   - error: 存在しない場合はリポジトリのエラー、他ユーザーの場合は ErrPhotoNotOwned、
+//     保存先が未設定の場合は ErrPhotoStorageNotConfigured、保存先の削除に失敗した場合のエラー
+func (s *Service) DeletePhoto(ctx context.Context, userID, id uint) error {
+	photo, err := s.getOwnedPhoto(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if s.photoStorage == nil {
+		return ErrPhotoStorageNotConfigured
+	}
+
+	if err := s.deletePhotoObjects(ctx, photo); err != nil {
+		return err
+	}
+	return s.repos.Photo().Delete(ctx, photo.ID)
+}
+
+// deletePhotoObjects は写真の元の画像・バリアントを保存先から削除し、バリアントの CDN のキャッシュの削除を依頼します
+// （存在しないオブジェクトの削除は成功）。
+func (s *Service) deletePhotoObjects(ctx context.Context, photo *model.Photo) error {
+	for _, key := range []string{photo.SourceKey, photo.ThumbnailKey, photo.MediumKey, photo.LargeKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.photoStorage.DeletePhoto(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete %s of photo %d: %w", key, photo.ID, err)
+		}
+	}
+	s.photoStorage.InvalidateCache(photo.ThumbnailKey, photo.MediumKey, photo.LargeKey)
+	return nil
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/imageproc"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Photo Processing Tests - 直接アップロードした写真の加工のテスト
+// =============================================================================
+// テスト対象:
+//   - CreatePhoto / ProcessPhotoJob: ジョブキューでの加工、バリアントのキーの記録、元の画像の削除
+//   - CreatePhoto: ジョブキューがない場合のその場での加工、デコードできない画像の failed
+//   - GetPhoto: 他ユーザーの写真、作物の所有者・組織のメンバー・庭のメンバーの閲覧（リクエストの組織によらない）
+//   - ReplacePhoto: 画像の差し替えの加工、上書きしたバリアントの CDN のキャッシュの削除
+
+// mockPhotoJobQueue はテスト用のジョブキューです（登録した写真の加工のジョブを保持する）。
+type mockPhotoJobQueue struct {
+	jobs []PhotoProcessJobPayload
+}
+
+func (q *mockPhotoJobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	q.jobs = append(q.jobs, payload.(PhotoProcessJobPayload))
+	return nil
+}
+
+// mockPhotoStorage はテスト用の写真の保存先です（CDN のキャッシュの削除を依頼したキーを保持する）。
+type mockPhotoStorage struct {
+	objects     map[string][]byte
+	invalidated []string
+}
+
+func (s *mockPhotoStorage) DownloadPhoto(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	data, ok := s.objects[objectKey]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *mockPhotoStorage) UploadPhotoVariant(ctx context.Context, userID, photoID uint, name, contentType string, data []byte) (string, error) {
+	key := fmt.Sprintf("photos/%d/%d/%s.webp", userID, photoID, name)
+	s.objects[key] = data
+	return key, nil
+}
+
+func (s *mockPhotoStorage) DeletePhoto(ctx context.Context, objectKey string) error {
+	delete(s.objects, objectKey)
+	return nil
+}
+
+func (s *mockPhotoStorage) InvalidateCache(objectKeys ...string) {
+	for _, key := range objectKeys {
+		if key != "" {
+			s.invalidated = append(s.invalidated, key)
+		}
+	}
+}
+
+// testJPEG は width x height の JPEG を作成します。
+func testJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{G: uint8(x % 256), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestCreatePhoto_ProcessJob はジョブキューでの写真の加工のテストです。
+// 期待動作:
+//   - CreatePhoto は pending の写真を作成してジョブを登録する
+//   - ProcessPhotoJob はバリアントを保存してキーとサイズ・保存容量を記録し、元の画像を削除する
+//   - 再配信された同じジョブは再加工しない
+func TestCreatePhoto_ProcessJob(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	jobQueue := &mockPhotoJobQueue{}
+	sourceKey := "uploads/1/2026/05/source.jpg"
+	storage := &mockPhotoStorage{objects: map[string][]byte{sourceKey: testJPEG(t, 640, 480)}}
+	svc.SetJobQueue(jobQueue)
+	svc.SetPhotoStorage(storage)
+	ctx := context.Background()
+
+	// Act
+	photo, err := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: sourceKey, SizeBytes: int64(len(storage.objects[sourceKey]))})
+	if err != nil {
+		t.Fatalf("CreatePhoto failed: %v", err)
+	}
+	if photo.Status != PhotoStatusPending || len(jobQueue.jobs) != 1 {
+		t.Fatalf("Expected pending photo with 1 job, got %+v jobs=%d", photo, len(jobQueue.jobs))
+	}
+	processErr := svc.ProcessPhotoJob(ctx, jobQueue.jobs[0], false)
+	variants := len(storage.objects)
+	redeliveredErr := svc.ProcessPhotoJob(ctx, jobQueue.jobs[0], false)
+
+	// Assert
+	if processErr != nil || redeliveredErr != nil {
+		t.Fatalf("ProcessPhotoJob failed: %v / %v", processErr, redeliveredErr)
+	}
+	stored, _ := mockRepos.Photo().GetByID(ctx, photo.ID)
+	if stored.Status != PhotoStatusCompleted || stored.Width != 640 || stored.Height != 480 || stored.ProcessedAt == nil {
+		t.Errorf("Expected completed 640x480 photo, got %+v", stored)
+	}
+	if stored.ThumbnailKey == "" || stored.MediumKey == "" || stored.LargeKey == "" {
+		t.Errorf("Expected variant keys, got %q %q %q", stored.ThumbnailKey, stored.MediumKey, stored.LargeKey)
+	}
+	if _, ok := storage.objects[sourceKey]; ok || variants != len(imageproc.StandardVariants) {
+		t.Errorf("Expected the source to be deleted and %d variants, got %d objects", len(imageproc.StandardVariants), variants)
+	}
+	var variantBytes int64
+	for _, data := range storage.objects {
+		variantBytes += int64(len(data))
+	}
+	if stored.SizeBytes != variantBytes {
+		t.Errorf("Expected SizeBytes to be the variant total %d, got %d", variantBytes, stored.SizeBytes)
+	}
+	if len(storage.invalidated) != 0 {
+		t.Errorf("Expected no CDN invalidation for a new photo, got %v", storage.invalidated)
+	}
+}
+
+// TestCreatePhoto_Failures は写真の加工の失敗のテストです。
+// 期待動作:
+//   - 保存先が未設定の場合は ErrPhotoStorageNotConfigured
+//   - ジョブキューがない場合はその場で加工し、デコードできない画像は failed にして元の画像を削除する
+//   - ジョブでデコードできない画像は failed にして再試行しない（nil を返す）
+//   - 他ユーザーの写真は ErrPhotoNotOwned
+func TestCreatePhoto_Failures(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	invalidKey := "uploads/1/2026/05/broken.jpg"
+	storage := &mockPhotoStorage{objects: map[string][]byte{invalidKey: []byte("\xFF\xD8\xFFbroken")}}
+
+	// Act & Assert: 未設定
+	if _, err := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: invalidKey, SizeBytes: 9}); !errors.Is(err, ErrPhotoStorageNotConfigured) {
+		t.Errorf("Expected ErrPhotoStorageNotConfigured, got %v", err)
+	}
+
+	// Act & Assert: その場での加工の失敗
+	svc.SetPhotoStorage(storage)
+	photo, err := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: invalidKey, SizeBytes: 9})
+	if !errors.Is(err, imageproc.ErrInvalidImage) {
+		t.Fatalf("Expected ErrInvalidImage, got %v", err)
+	}
+	if photo.Status != PhotoStatusFailed || photo.ErrorMessage == "" || len(storage.objects) != 0 {
+		t.Errorf("Expected failed photo without the source, got %+v objects=%d", photo, len(storage.objects))
+	}
+
+	// Act & Assert: ジョブでの加工の失敗
+	storage.objects[invalidKey] = []byte("\xFF\xD8\xFFbroken")
+	queued := &mockPhotoJobQueue{}
+	svc.SetJobQueue(queued)
+	queuedPhoto, _ := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: invalidKey, SizeBytes: 9})
+	if err := svc.ProcessPhotoJob(ctx, queued.jobs[0], false); err != nil {
+		t.Errorf("Expected no retry for an invalid image, got %v", err)
+	}
+	if queuedPhoto.Status != PhotoStatusFailed {
+		t.Errorf("Expected failed photo, got %s", queuedPhoto.Status)
+	}
+
+	// Act & Assert: 他ユーザーの写真
+	if _, err := svc.GetPhoto(ctx, 2, photo.ID); !errors.Is(err, ErrPhotoNotOwned) {
+		t.Errorf("Expected ErrPhotoNotOwned, got %v", err)
+	}
+}
+
+// TestGetPhoto_SharedAccess は写真の作物を共有するユーザーの閲覧のテストです。
+// 期待動作:
+//   - 写真の作物（収穫記録の写真は収穫記録の作物）の所有者・作物の組織のメンバーは閲覧できる
+//   - 組織のメンバーでないユーザー、作物のない写真の他ユーザーは ErrPhotoNotOwned
+//   - 閲覧できても、差し替え・削除はアップロードしたユーザーのみ（ErrPhotoNotOwned）
+//   - 組織の作物への写真の登録はメンバーのみ（メンバーでない場合は ErrPhotoCropNotFound）
+func TestGetPhoto_SharedAccess(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetPhotoStorage(&mockPhotoStorage{objects: map[string][]byte{}})
+	ctx := context.Background()
+	orgID := uint(10)
+	_ = mockRepos.OrganizationMember().Create(ctx, &model.OrganizationMember{OrganizationID: orgID, UserID: 3, Role: model.OrganizationRoleMember})
+	cropOwner := uint(1)
+	crop := &model.Crop{UserID: cropOwner, Name: "トマト", OrganizationID: &orgID}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	harvest := &model.Harvest{CropID: crop.ID}
+	_ = mockRepos.Harvest().Create(ctx, harvest)
+
+	photos := mockRepos.Photo()
+	cropPhoto := &model.Photo{UserID: 2, CropID: &crop.ID, Status: PhotoStatusCompleted}
+	harvestPhoto := &model.Photo{UserID: 2, HarvestID: &harvest.ID, Status: PhotoStatusCompleted}
+	privatePhoto := &model.Photo{UserID: 2, Status: PhotoStatusCompleted}
+	_ = photos.Create(ctx, cropPhoto)
+	_ = photos.Create(ctx, harvestPhoto)
+	_ = photos.Create(ctx, privatePhoto)
+
+	// Act
+	_, ownerErr := svc.GetPhoto(ctx, cropOwner, cropPhoto.ID)
+	_, memberErr := svc.GetPhoto(ctx, 3, harvestPhoto.ID)
+	_, outsiderErr := svc.GetPhoto(ctx, 4, cropPhoto.ID)
+	_, privateErr := svc.GetPhoto(ctx, cropOwner, privatePhoto.ID)
+	deleteErr := svc.DeletePhoto(ctx, 3, harvestPhoto.ID)
+	_, replaceErr := svc.ReplacePhoto(ctx, cropOwner, cropPhoto.ID, PhotoUpload{SourceKey: "uploads/1/2026/05/photo.jpg", SizeBytes: 1})
+	uploadErr := svc.checkPhotoCrop(ctx, 4, crop.ID, ErrPhotoCropNotFound)
+	memberUploadErr := svc.checkPhotoCrop(ctx, 3, crop.ID, ErrPhotoCropNotFound)
+
+	// Assert
+	if ownerErr != nil || memberErr != nil {
+		t.Errorf("Expected the crop owner and the organization member to view the photos, got %v / %v", ownerErr, memberErr)
+	}
+	if !errors.Is(outsiderErr, ErrPhotoNotOwned) || !errors.Is(privateErr, ErrPhotoNotOwned) {
+		t.Errorf("Expected ErrPhotoNotOwned for an outsider and a photo without a crop, got %v / %v", outsiderErr, privateErr)
+	}
+	if !errors.Is(deleteErr, ErrPhotoNotOwned) || !errors.Is(replaceErr, ErrPhotoNotOwned) {
+		t.Errorf("Expected only the uploader to delete and replace, got %v / %v", deleteErr, replaceErr)
+	}
+	if !errors.Is(uploadErr, ErrPhotoCropNotFound) || memberUploadErr != nil {
+		t.Errorf("Expected only members to attach photos to the organization crop, got %v / %v", uploadErr, memberUploadErr)
+	}
+}
+
+// TestGetPhoto_AccessAcrossScopes は写真の作物の取得がリクエストの組織によらないことと、庭のメンバーの閲覧のテストです。
+// 期待動作:
+//   - 作物の所有者は、作物の組織以外のスコープ（個人のスコープ）でも閲覧できる
+//   - 作物の組織のメンバーは、組織のヘッダーがない場合・個人のスコープでも閲覧できる
+//   - 作物の所有者の庭のメンバーは閲覧できる（他ユーザーの庭のメンバーは ErrPhotoNotOwned）
+//   - 所有者・組織のメンバー・庭のメンバーでないユーザーは ErrPhotoNotOwned
+func TestGetPhoto_AccessAcrossScopes(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	orgID := uint(10)
+	cropOwner, uploader, orgMember, gardenMember, otherGardenMember, outsider := uint(1), uint(2), uint(3), uint(4), uint(5), uint(6)
+	_ = mockRepos.OrganizationMember().Create(ctx, &model.OrganizationMember{OrganizationID: orgID, UserID: orgMember, Role: model.OrganizationRoleMember})
+	ownerGarden := &model.Garden{UserID: cropOwner, Name: "家庭菜園"}
+	otherGarden := &model.Garden{UserID: outsider, Name: "貸し農園"}
+	_ = mockRepos.Garden().Create(ctx, ownerGarden)
+	_ = mockRepos.Garden().Create(ctx, otherGarden)
+	_ = mockRepos.GardenMember().Create(ctx, &model.GardenMember{GardenID: ownerGarden.ID, UserID: gardenMember, Role: GardenMemberRoleMember})
+	_ = mockRepos.GardenMember().Create(ctx, &model.GardenMember{GardenID: otherGarden.ID, UserID: otherGardenMember, Role: GardenMemberRoleMember})
+	crop := &model.Crop{UserID: cropOwner, Name: "トマト", OrganizationID: &orgID}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	photo := &model.Photo{UserID: uploader, CropID: &crop.ID, Status: PhotoStatusCompleted}
+	_ = mockRepos.Photo().Create(ctx, photo)
+	personal := repository.ContextWithOrganization(ctx, 0)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		userID  uint
+		wantErr error
+	}{
+		{name: "所有者（個人のスコープ）", ctx: personal, userID: cropOwner},
+		{name: "組織のメンバー（組織のヘッダーなし）", ctx: ctx, userID: orgMember},
+		{name: "組織のメンバー（個人のスコープ）", ctx: personal, userID: orgMember},
+		{name: "所有者の庭のメンバー", ctx: personal, userID: gardenMember},
+		{name: "他ユーザーの庭のメンバー", ctx: personal, userID: otherGardenMember, wantErr: ErrPhotoNotOwned},
+		{name: "無関係のユーザー", ctx: ctx, userID: outsider, wantErr: ErrPhotoNotOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := svc.GetPhoto(tt.ctx, tt.userID, photo.ID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && got.ID != photo.ID {
+				t.Errorf("Expected photo %d, got %+v", photo.ID, got)
+			}
+		})
+	}
+}
+
+// TestReplacePhoto は写真の画像の差し替えのテストです。
+// 期待動作:
+//   - 差し替えた画像を加工して同じキーのバリアントを上書きし、サイズ・保存容量を更新する
+//   - 上書きしたバリアントの CDN のキャッシュの削除を依頼し、差し替えた元の画像を削除する
+//   - 他ユーザーの写真は ErrPhotoNotOwned で、アップロードした画像を削除しない
+//   - 以前の写真の容量を除いて上限を超える場合は ErrStorageQuotaExceeded で、アップロードした画像を削除する
+func TestReplacePhoto(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	firstKey := "uploads/1/2026/05/first.jpg"
+	secondKey := "uploads/1/2026/05/second.jpg"
+	storage := &mockPhotoStorage{objects: map[string][]byte{firstKey: testJPEG(t, 320, 240), secondKey: testJPEG(t, 640, 480)}}
+	svc.SetPhotoStorage(storage)
+	photo, err := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: firstKey, SizeBytes: int64(len(storage.objects[firstKey]))})
+	if err != nil {
+		t.Fatalf("CreatePhoto failed: %v", err)
+	}
+	previous := *photo
+
+	// Act
+	_, notOwnedErr := svc.ReplacePhoto(ctx, 2, photo.ID, PhotoUpload{SourceKey: secondKey, SizeBytes: 1})
+	replaced, replaceErr := svc.ReplacePhoto(ctx, 1, photo.ID, PhotoUpload{SourceKey: secondKey, SizeBytes: int64(len(storage.objects[secondKey]))})
+	storage.objects["uploads/1/2026/05/large.jpg"] = []byte("large")
+	svc.SetStorageQuota(replaced.SizeBytes + 100)
+	_, quotaErr := svc.ReplacePhoto(ctx, 1, photo.ID, PhotoUpload{SourceKey: "uploads/1/2026/05/large.jpg", SizeBytes: replaced.SizeBytes + 101})
+
+	// Assert
+	if !errors.Is(notOwnedErr, ErrPhotoNotOwned) {
+		t.Errorf("Expected ErrPhotoNotOwned, got %v", notOwnedErr)
+	}
+	if replaceErr != nil {
+		t.Fatalf("ReplacePhoto failed: %v", replaceErr)
+	}
+	if replaced.Status != PhotoStatusCompleted || replaced.Width != 640 || replaced.ThumbnailKey != previous.ThumbnailKey {
+		t.Errorf("Expected the 640x480 image under the same keys, got %+v", replaced)
+	}
+	if replaced.SizeBytes == previous.SizeBytes {
+		t.Errorf("Expected SizeBytes to be updated, got %d", replaced.SizeBytes)
+	}
+	want := []string{previous.ThumbnailKey, previous.MediumKey, previous.LargeKey}
+	if fmt.Sprint(storage.invalidated) != fmt.Sprint(want) {
+		t.Errorf("Expected invalidations %v, got %v", want, storage.invalidated)
+	}
+	if _, ok := storage.objects[secondKey]; ok {
+		t.Error("Expected the replaced source to be deleted")
+	}
+	if !errors.Is(quotaErr, ErrStorageQuotaExceeded) {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", quotaErr)
+	}
+	if _, ok := storage.objects["uploads/1/2026/05/large.jpg"]; ok {
+		t.Error("Expected the rejected upload to be deleted")
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Rich Push - ディープリンクとアクションボタン
+// =============================================================================
+// プッシュ通知のカスタムデータに、通知カテゴリ・ディープリンク・アクションボタンを追加します。
+//
+//   - category: iOSは aps.category、Androidは click_action として送信（アプリ側でボタンを登録）
+//   - deep_link: 通知をタップしたときに開く画面（例: app://tasks/123）
+//   - actions: アクションボタンの一覧（JSON文字列、Androidでボタンを表示するために使用）
+//
+// アクションボタンが押されると、アプリは PushActionEndpoint へアクションと対象タスクを送信します。
+
+// プッシュ通知のカスタムデータのキー
+const (
+	PushDataType           = "type"
+	PushDataCategory       = "category"
+	PushDataDeepLink       = "deep_link"
+	PushDataActions        = "actions"
+	PushDataActionEndpoint = "action_endpoint"
+)
+
+// プッシュ通知のカテゴリ（iOSの UNNotificationCategory の識別子と一致させる）
+const (
+	PushCategoryTaskReminder    = "TASK_REMINDER"
+	PushCategoryHarvestReminder = "HARVEST_REMINDER"
+	PushCategoryGeneral         = "GENERAL"
+)
+
+// プッシュ通知のアクション
+const (
+	// PushActionMarkDone は対象のタスクを完了にするアクション
+	PushActionMarkDone = "mark_done"
+	// PushActionSnooze1h は1時間後に再度リマインダーを送るアクション
+	PushActionSnooze1h = "snooze_1h"
+)
+
+const (
+	// PushActionEndpoint はアクションボタンのコールバック先です。
+	PushActionEndpoint = "/api/v1/notifications/actions"
+	// PushActionSnoozeDuration はスヌーズで再通知を遅らせる時間です。
+	PushActionSnoozeDuration = time.Hour
+
+	// deepLinkScheme はアプリのディープリンクのスキームです。
+	deepLinkScheme = "app://"
+)
+
+var (
+	// ErrUnknownPushAction は未対応のアクションの場合のエラー
+	ErrUnknownPushAction = errors.New("unknown push action")
+	// ErrPushActionTaskNotFound はアクションの対象タスクが見つからない（他のユーザーのタスクを含む）場合のエラー
+	ErrPushActionTaskNotFound = errors.New("push action task not found")
+)
+
+// PushAction はプッシュ通知のアクションボタンです。
+type PushAction struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// taskPushActions はタスク通知のアクションボタンです。
+var taskPushActions = []PushAction{
+	{ID: PushActionMarkDone, Title: "完了にする"},
+	{ID: PushActionSnooze1h, Title: "1時間後に通知"},
+}
+
+// PushActionResult はアクションの処理結果です。
+type PushActionResult struct {
+	Action       string     `json:"action"`
+	TaskIDs      []uint     `json:"task_ids"`
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"` // スヌーズの場合の再通知日時
+}
+
+// pushPayloadData は通知イベントのカスタムデータに、カテゴリ・ディープリンク・アクションを追加したものを返します。
+// event.Data は変更しません。
+func pushPayloadData(event NotificationEvent) map[string]interface{} {
+	data := make(map[string]interface{}, len(event.Data)+5)
+	for k, v := range event.Data {
+		data[k] = v
+	}
+	if _, ok := data[PushDataType]; !ok {
+		data[PushDataType] = string(event.Type)
+	}
+
+	switch event.Type {
+	case NotificationEventTaskDueReminder, NotificationEventTaskOverdueAlert:
+		data[PushDataCategory] = PushCategoryTaskReminder
+		ids, _ := event.Data["task_ids"].([]uint)
+		data[PushDataDeepLink] = deepLink("tasks", ids)
+		if len(ids) > 0 {
+			actions, _ := json.Marshal(taskPushActions)
+			data[PushDataActions] = string(actions)
+			data[PushDataActionEndpoint] = PushActionEndpoint
+		}
+	case NotificationEventHarvestReminder, NotificationEventCropReadyToHarvest:
+		data[PushDataCategory] = PushCategoryHarvestReminder
+		ids, _ := event.Data["crop_ids"].([]uint)
+		data[PushDataDeepLink] = deepLink("crops", ids)
+	default:
+		data[PushDataCategory] = PushCategoryGeneral
+		data[PushDataDeepLink] = deepLinkScheme + "notifications"
+	}
+	return data
+}
+
+// deepLink は対象が1件の場合は詳細画面（app://tasks/123）、それ以外は一覧画面（app://tasks）のディープリンクを返します。
+func deepLink(resource string, ids []uint) string {
+	if len(ids) == 1 {
+		return fmt.Sprintf("%s%s/%d", deepLinkScheme, resource, ids[0])
+	}
+	return deepLinkScheme + resource
+}
+
+// pushRichFields はカスタムデータからカテゴリとディープリンクを取り出します（プラットフォーム別のペイロード構築用）。
+func pushRichFields(data map[string]interface{}) (category, link string) {
+	category, _ = data[PushDataCategory].(string)
+	link, _ = data[PushDataDeepLink].(string)
+	return category, link
+}
+
+// HandlePushAction はプッシュ通知のアクションボタンの操作を処理します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: 操作したユーザーID
+//   - action: PushActionMarkDone または PushActionSnooze1h
+//   - taskIDs: 通知の対象タスク
+//
+// 戻り値:
+//   - *PushActionResult: 処理結果
+//   - error: 未対応のアクションの場合は ErrUnknownPushAction、
+//     タスクが見つからない・他のユーザーのタスクの場合は ErrPushActionTaskNotFound
+func (s *Service) HandlePushAction(ctx context.Context, userID uint, action string, taskIDs []uint) (*PushActionResult, error) {
+	if action != PushActionMarkDone && action != PushActionSnooze1h {
+		return nil, ErrUnknownPushAction
+	}
+
+	tasks := make([]*model.Task, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		task, err := s.repos.Task().GetByID(ctx, id)
+		if err != nil || task.UserID != userID {
+			return nil, ErrPushActionTaskNotFound
+		}
+		tasks = append(tasks, task)
+	}
+
+	result := &PushActionResult{Action: action, TaskIDs: taskIDs}
+	switch action {
+	case PushActionMarkDone:
+		for _, task := range tasks {
+			if task.Status == "completed" {
+				continue
+			}
+			if err := s.CompleteTask(ctx, task.ID); err != nil {
+				return nil, err
+			}
+		}
+	case PushActionSnooze1h:
+		snoozedUntil, err := s.snoozeTaskReminder(ctx, userID, tasks)
+		if err != nil {
+			return nil, err
+		}
+		result.SnoozedUntil = &snoozedUntil
+	}
+	return result, nil
+}
+
+// snoozeTaskReminder は PushActionSnoozeDuration 後に送信するタスクのリマインダーを予約します。
+// おやすみモードの保留と同じく deferred の通知ログとして記録し、リトライ処理で配信します。
+func (s *Service) snoozeTaskReminder(ctx context.Context, userID uint, tasks []*model.Task) (time.Time, error) {
+	titles := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status != "completed" {
+			titles = append(titles, task.Title)
+		}
+	}
+
+	snoozedUntil := s.now().Add(PushActionSnoozeDuration)
+	if len(titles) == 0 {
+		// すべて完了済みの場合は再通知しない
+		return snoozedUntil, nil
+	}
+
+	log := &model.NotificationLog{
+		UserID:           userID,
+		NotificationType: string(NotificationEventTaskDueReminder),
+		Channel:          NotificationChannelPush,
+		Title:            "タスクのリマインダー",
+		Body:             truncateString(strings.Join(titles, "、"), 1000),
+		Status:           "deferred",
+		NextRetryAt:      &snoozedUntil,
+		ExpiresAt:        snoozedUntil.Add(24 * time.Hour),
+	}
+	if err := s.CreateNotificationLog(ctx, log); err != nil {
+		return time.Time{}, fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+	return snoozedUntil, nil
+}
@@ -0,0 +1,141 @@
+// Package service - Rich Push Tests
+//
+// ディープリンク・アクションボタン付きのプッシュ通知のテストを提供します。
+// テスト対象:
+//   - イベントタイプごとのカテゴリ・ディープリンク・アクションの付与
+//   - SNS（APNS/GCM）・FCM HTTP v1 のペイロードへのカテゴリの反映
+//   - アクションボタンのコールバック（完了にする・1時間後に通知）
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestPushPayloadData はカスタムデータの構築のテストです。
+// 期待動作:
+//   - タスクが1件の場合は詳細画面、複数の場合は一覧画面のディープリンク
+//   - タスク通知にはアクションボタンとコールバック先を付与する
+//   - 元のイベントのデータは変更しない
+func TestPushPayloadData(t *testing.T) {
+	single := NotificationEvent{Type: NotificationEventTaskDueReminder, Data: map[string]interface{}{"task_ids": []uint{123}}}
+	harvest := NotificationEvent{Type: NotificationEventHarvestReminder, Data: map[string]interface{}{"crop_ids": []uint{1, 2}}}
+	digest := NotificationEvent{Type: NotificationEventDailyDigest}
+
+	data := pushPayloadData(single)
+	if data[PushDataDeepLink] != "app://tasks/123" || data[PushDataCategory] != PushCategoryTaskReminder {
+		t.Errorf("Unexpected task payload: %+v", data)
+	}
+	var actions []PushAction
+	if err := json.Unmarshal([]byte(data[PushDataActions].(string)), &actions); err != nil || len(actions) != 2 || actions[0].ID != PushActionMarkDone {
+		t.Errorf("Expected task actions, got %v (%v)", data[PushDataActions], err)
+	}
+	if data[PushDataActionEndpoint] != PushActionEndpoint || data[PushDataType] != string(NotificationEventTaskDueReminder) {
+		t.Errorf("Expected action endpoint and type, got %+v", data)
+	}
+	if _, ok := single.Data[PushDataDeepLink]; ok {
+		t.Error("Expected event data to be unchanged")
+	}
+
+	if data := pushPayloadData(harvest); data[PushDataDeepLink] != "app://crops" || data[PushDataActions] != nil {
+		t.Errorf("Unexpected harvest payload: %+v", data)
+	}
+	if data := pushPayloadData(digest); data[PushDataDeepLink] != "app://notifications" || data[PushDataCategory] != PushCategoryGeneral {
+		t.Errorf("Unexpected digest payload: %+v", data)
+	}
+}
+
+// TestBuildPushMessage_Category はプラットフォーム別ペイロードのテストです。
+// 期待動作:
+//   - SNSのAPNSは aps.category、GCMは notification.click_action にカテゴリを設定する
+//   - FCM HTTP v1 は android.notification.click_action と apns の aps.category に設定する
+func TestBuildPushMessage_Category(t *testing.T) {
+	data := pushPayloadData(NotificationEvent{Type: NotificationEventTaskOverdueAlert, Data: map[string]interface{}{"task_ids": []uint{7}}})
+	sns := &notificationSender{}
+
+	// SNS（APNS）
+	message, err := sns.buildPushMessage("ios", "期限切れ", "確認してください", data)
+	if err != nil {
+		t.Fatalf("buildPushMessage failed: %v", err)
+	}
+	var messageMap map[string]string
+	_ = json.Unmarshal([]byte(message), &messageMap)
+	var apns APNSMessage
+	_ = json.Unmarshal([]byte(messageMap["APNS"]), &apns)
+	if apns.APS.Category != PushCategoryTaskReminder || apns.Data[PushDataDeepLink] != "app://tasks/7" {
+		t.Errorf("Unexpected APNS message: %+v", apns)
+	}
+
+	// SNS（GCM）
+	message, _ = sns.buildPushMessage("android", "期限切れ", "確認してください", data)
+	_ = json.Unmarshal([]byte(message), &messageMap)
+	var gcm FCMMessage
+	_ = json.Unmarshal([]byte(messageMap["GCM"]), &gcm)
+	if gcm.Notification.ClickAction != PushCategoryTaskReminder || gcm.Data[PushDataDeepLink] != "app://tasks/7" {
+		t.Errorf("Unexpected GCM message: %+v", gcm)
+	}
+
+	// FCM HTTP v1
+	payload, _ := (&FCMSender{}).buildSendRequest(&model.DeviceToken{Token: "t", Platform: "ios"}, "期限切れ", "確認してください", data)
+	var req fcmSendRequest
+	_ = json.Unmarshal(payload, &req)
+	if req.Message.Android.Notification == nil || req.Message.Android.Notification.ClickAction != PushCategoryTaskReminder {
+		t.Errorf("Expected android click_action, got %+v", req.Message.Android)
+	}
+	aps, _ := req.Message.APNS.Payload["aps"].(map[string]interface{})
+	if aps["category"] != PushCategoryTaskReminder {
+		t.Errorf("Expected aps.category, got %+v", aps)
+	}
+}
+
+// TestHandlePushAction はアクションボタンのコールバックのテストです。
+// 期待動作:
+//   - mark_done で対象タスクが完了になる
+//   - snooze_1h で1時間後に配信するリマインダーが deferred として記録される
+//   - 他のユーザーのタスクは ErrPushActionTaskNotFound
+//   - 未対応のアクションは ErrUnknownPushAction
+func TestHandlePushAction(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	done := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now(), Status: "pending"}
+	snoozed := &model.Task{UserID: 1, Title: "追肥", DueDate: time.Now(), Status: "pending"}
+	others := &model.Task{UserID: 2, Title: "剪定", DueDate: time.Now(), Status: "pending"}
+	for _, task := range []*model.Task{done, snoozed, others} {
+		_ = mockRepos.Task().Create(ctx, task)
+	}
+
+	// Act & Assert
+	if _, err := svc.HandlePushAction(ctx, 1, PushActionMarkDone, []uint{done.ID}); err != nil {
+		t.Fatalf("mark_done failed: %v", err)
+	}
+	if completed, _ := mockRepos.Task().GetByID(ctx, done.ID); completed.Status != "completed" {
+		t.Errorf("Expected task to be completed, got %s", completed.Status)
+	}
+
+	result, err := svc.HandlePushAction(ctx, 1, PushActionSnooze1h, []uint{snoozed.ID})
+	if err != nil {
+		t.Fatalf("snooze_1h failed: %v", err)
+	}
+	if result.SnoozedUntil == nil || result.SnoozedUntil.Sub(time.Now()) < 59*time.Minute {
+		t.Errorf("Expected snooze for 1 hour, got %+v", result.SnoozedUntil)
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, 1, 0)
+	if len(logs) != 1 || logs[0].Status != "deferred" || logs[0].Body != "追肥" || logs[0].NextRetryAt == nil {
+		t.Errorf("Expected deferred reminder, got %+v", logs)
+	}
+
+	if _, err := svc.HandlePushAction(ctx, 1, PushActionMarkDone, []uint{others.ID}); err != ErrPushActionTaskNotFound {
+		t.Errorf("Expected ErrPushActionTaskNotFound, got %v", err)
+	}
+	if _, err := svc.HandlePushAction(ctx, 1, "archive", []uint{done.ID}); err != ErrUnknownPushAction {
+		t.Errorf("Expected ErrUnknownPushAction, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Quiet Hours - おやすみモード
+// =============================================================================
+// ユーザーが指定した時間帯（例: 21:00〜08:00）は通知を送信せず、
+// NotificationLog に deferred として記録し、時間帯の終了後にリトライ処理で配信します。
+// 時刻はユーザーのタイムゾーンで解釈します。
+
+// ErrInvalidQuietHours はおやすみモードの時刻が不正な場合のエラー
+var ErrInvalidQuietHours = errors.New("quiet hours must be HH:MM and start must differ from end")
+
+// parseClock は "HH:MM" を0時からの経過分に変換します。
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %w", s, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateQuietHours はおやすみモードの設定を検証します。
+// 無効の場合は時刻を検証しません。
+func ValidateQuietHours(settings *model.NotificationSettings) error {
+	if settings == nil || !settings.QuietHoursEnabled {
+		return nil
+	}
+	start, err := parseClock(settings.QuietHoursStart)
+	if err != nil {
+		return ErrInvalidQuietHours
+	}
+	end, err := parseClock(settings.QuietHoursEnd)
+	if err != nil {
+		return ErrInvalidQuietHours
+	}
+	if start == end {
+		return ErrInvalidQuietHours
+	}
+	return nil
+}
+
+// quietHoursEnd は now がユーザーのおやすみモードの時間帯に含まれるかを判定します。
+//
+// 戻り値:
+//   - time.Time: 時間帯の終了日時（時間帯外の場合はゼロ値）
+//   - bool: 時間帯に含まれる場合はtrue
+func quietHoursEnd(user *model.User, now time.Time) (time.Time, bool) {
+	if user == nil || user.NotificationSettings == nil || !user.NotificationSettings.QuietHoursEnabled ||
+		ValidateQuietHours(user.NotificationSettings) != nil {
+		return time.Time{}, false
+	}
+	start, _ := parseClock(user.NotificationSettings.QuietHoursStart)
+	end, _ := parseClock(user.NotificationSettings.QuietHoursEnd)
+
+	local := now.In(userLocation(user))
+	minute := local.Hour()*60 + local.Minute()
+	endToday := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, local.Location())
+
+	if start < end {
+		// 同日内の時間帯（例: 13:00〜15:00）
+		if minute >= start && minute < end {
+			return endToday, true
+		}
+		return time.Time{}, false
+	}
+
+	// 日をまたぐ時間帯（例: 21:00〜08:00）
+	if minute >= start {
+		return endToday.AddDate(0, 0, 1), true
+	}
+	if minute < end {
+		return endToday, true
+	}
+	return time.Time{}, false
+}
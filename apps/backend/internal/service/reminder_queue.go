@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Reminder Queue - ユーザーごとのリマインダージョブ
+// =============================================================================
+// スケジューラーは毎時0分の時刻（スロット）を基準に、送信時刻
+// （NotificationSettings.DailyReminderHour）を迎えたユーザーごとのリマインダージョブを作成します。
+// ジョブの通知イベントはアウトボックスに保存し、ディスパッチャーがユーザーごとのジョブとしてキューから並行して送信します。
+//
+//   - 同じ時間帯の実行は実行時刻の分・秒によらず同じスロットで計算する（EventBridge の遅延・再送でも結果が変わらない）
+//   - ジョブはユーザー単位のため、1人のユーザーの送信の失敗・遅延が他のユーザーの送信を止めない
+//   - 同じユーザーのイベントは1つのジョブで順に処理する（ダイジェスト・送信数の上限の判定がユーザー内で完結する）
+//   - ユーザーはID順のページ（SchedulerUserPageSize 人ずつ）に分けて処理し、ページごとの進捗を
+//     scheduler_checkpoints に保存する（途中で止まった実行は次の実行で続きのページから再開する）
+
+// ReminderJobTimeout はユーザーごとのリマインダージョブの処理時間の上限
+const ReminderJobTimeout = 30 * time.Second
+
+// SchedulerUserPageSize は定期通知で1ページに処理するユーザー数のデフォルト
+const SchedulerUserPageSize = 500
+
+// reminderCheckpointName は定期通知の進捗（scheduler_checkpoints）の処理名
+const reminderCheckpointName = "notifications"
+
+// ReminderJob はユーザーごとのリマインダージョブです。
+type ReminderJob struct {
+	UserID   uint                `json:"user_id"`
+	SlotTime time.Time           `json:"slot_time"` // ジョブを作成したスロット（毎時0分）
+	Events   []NotificationEvent `json:"events"`    // ユーザーに送る通知イベント
+
+	// outbox は Events に対応するアウトボックスのイベント（アウトボックスから作成したジョブのみ）
+	outbox []model.NotificationOutbox
+}
+
+// SetReminderWorkers はリマインダージョブを並行して処理する数を設定します（未設定・1未満の場合は1件ずつ処理）。
+// 並行数は通知設定（config.NotificationConfig）から main で設定します。
+func (s *Service) SetReminderWorkers(workers int) {
+	s.reminderWorkers = workers
+}
+
+// SetSchedulerPageSize は定期通知で1ページに処理するユーザー数を設定します（未設定・1未満の場合は SchedulerUserPageSize）。
+// ページのサイズは通知設定（config.NotificationConfig）から main で設定します。
+func (s *Service) SetSchedulerPageSize(size int) {
+	s.schedulerPageSize = size
+}
+
+// schedulerUserPageSize は定期通知で1ページに処理するユーザー数を返します。
+func (s *Service) schedulerUserPageSize() int {
+	if s.schedulerPageSize < 1 {
+		return SchedulerUserPageSize
+	}
+	return s.schedulerPageSize
+}
+
+// reminderSlot は実行時刻を含む時間帯のスロット（毎時0分）を返します。
+func reminderSlot(now time.Time) time.Time {
+	return now.Truncate(time.Hour)
+}
+
+// BuildReminderJobs はスロットの時点で送信時刻を迎えたユーザーごとのリマインダージョブを作成します。
+// ジョブはユーザーの出現順に並べます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - now: 実行時刻（毎時0分のスロットに切り捨てて計算）
+//
+// 戻り値:
+//   - []ReminderJob: ユーザーごとのリマインダージョブ
+//   - error: 通知イベントの生成に失敗した場合のエラー
+func (s *Service) BuildReminderJobs(ctx context.Context, now time.Time) ([]ReminderJob, error) {
+	slot := reminderSlot(now)
+	scheduled, err := s.processScheduledNotificationsAt(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildReminderJobs(slot, scheduled.Events), nil
+}
+
+// buildReminderJobsPage は afterUserID より後のユーザー1ページ分のリマインダージョブを作成します。
+// 戻り値の uint はページの最後のユーザーIDで、処理するユーザーがいない場合は 0 です。
+func (s *Service) buildReminderJobsPage(ctx context.Context, slot time.Time, afterUserID uint) ([]ReminderJob, uint, error) {
+	scheduled, lastUserID, err := s.processScheduledNotificationsPage(ctx, slot, afterUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return buildReminderJobs(slot, scheduled.Events), lastUserID, nil
+}
+
+// buildReminderJobs は通知イベントをユーザーの出現順のリマインダージョブにまとめます。
+func buildReminderJobs(slot time.Time, events []NotificationEvent) []ReminderJob {
+	order, byUser := groupEventsByUser(events)
+	jobs := make([]ReminderJob, 0, len(order))
+	for _, userID := range order {
+		jobs = append(jobs, ReminderJob{
+			UserID:   userID,
+			SlotTime: slot,
+			Events:   byUser[userID],
+		})
+	}
+	return jobs
+}
+
+// reminderCheckpoint はスロットの定期通知の進捗を返します。
+// 同じスロットの途中で止まった進捗がない場合（初回・前のスロット・完了済み）は最初のページからの進捗を返します。
+// 進捗を取得できない場合も最初のページから処理します（送信済みのイベントは重複防止キーで送信しません）。
+func (s *Service) reminderCheckpoint(ctx context.Context, slot time.Time) *model.SchedulerCheckpoint {
+	checkpoint, err := s.repos.SchedulerCheckpoint().Get(ctx, reminderCheckpointName)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logging.FromContext(ctx).Warn("Failed to get scheduler checkpoint", "error", err)
+	}
+	if err != nil || !checkpoint.SlotTime.Equal(slot) || checkpoint.CompletedAt != nil {
+		return &model.SchedulerCheckpoint{Name: reminderCheckpointName, SlotTime: slot}
+	}
+	return checkpoint
+}
+
+// saveReminderCheckpoint は定期通知の進捗を保存します。
+// 保存に失敗しても送信は止めず、次の実行で最後に保存したページから再開します。
+func (s *Service) saveReminderCheckpoint(ctx context.Context, checkpoint *model.SchedulerCheckpoint) {
+	if err := s.repos.SchedulerCheckpoint().Save(ctx, checkpoint); err != nil {
+		logging.FromContext(ctx).Warn("Failed to save scheduler checkpoint", "error", err)
+	}
+}
+
+// runReminderJobs はリマインダージョブをキューから並行して処理し、結果を集計します。
+func (h *notificationEventHandler) runReminderJobs(ctx context.Context, jobs []ReminderJob) *NotificationProcessResult {
+	result := &NotificationProcessResult{
+		ProcessedAt: h.service.now(),
+		UserJobs:    len(jobs),
+		Errors:      make([]string, 0),
+	}
+
+	workers := h.service.reminderWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	queue := make(chan ReminderJob, len(jobs))
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				jobResult := h.runReminderJob(ctx, job)
+
+				mu.Lock()
+				result.merge(jobResult)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result
+}
+
+// runReminderJob は1人のユーザーのリマインダージョブを処理します。
+// アウトボックスから作成したジョブは、イベントごとに処理結果をアウトボックスに記録します。
+func (h *notificationEventHandler) runReminderJob(ctx context.Context, job ReminderJob) *NotificationProcessResult {
+	jobCtx, cancel := context.WithTimeout(ctx, ReminderJobTimeout)
+	defer cancel()
+
+	result := &NotificationProcessResult{
+		TotalEvents: len(job.Events),
+		Errors:      make([]string, 0),
+	}
+	for i, event := range job.Events {
+		outcome, err := h.handleEvent(jobCtx, event)
+		result.record(event, outcome, err)
+		if i < len(job.outbox) {
+			// 処理時間の上限を過ぎても処理結果は記録する
+			if h.completeOutboxEntry(ctx, job.outbox[i], outcome, err) {
+				result.DeadLettered++
+			}
+		}
+	}
+	return result
+}
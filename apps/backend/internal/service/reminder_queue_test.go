@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Reminder Queue Tests - ユーザーごとのリマインダージョブのテスト
+// =============================================================================
+// テスト対象:
+//   - BuildReminderJobs: 毎時0分のスロットを基準に、送信時刻を迎えたユーザーごとのジョブを作成すること
+//   - runReminderJobs: ジョブごとの送信と結果の集計
+
+// createReminderUser はリマインダー時刻とタイムゾーンを設定したユーザーと当日のタスクを作成します。
+func createReminderUser(t *testing.T, mockRepos *repository.MockRepositories, email, timezone string, hour int, due time.Time) *model.User {
+	t.Helper()
+	ctx := context.Background()
+	user := &model.User{
+		Email:    email,
+		Timezone: timezone,
+		NotificationSettings: &model.NotificationSettings{
+			PushEnabled:       true,
+			EmailEnabled:      true,
+			TaskReminders:     true,
+			DailyReminderHour: &hour,
+		},
+	}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	task := &model.Task{
+		UserID:  user.ID,
+		Title:   "水やり",
+		DueDate: due,
+		Status:  "pending",
+		User:    *user, // モックでPreloadをシミュレート
+	}
+	if err := mockRepos.Task().Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	return user
+}
+
+// TestBuildReminderJobs はユーザーごとのリマインダージョブの作成のテストです。
+// 期待動作:
+//   - 送信時刻を迎えたユーザーのみジョブを作成する（タイムゾーンごとの送信時刻）
+//   - スロットは実行時刻を毎時0分に切り捨てた時刻
+//   - 同じ時間帯の実行は分・秒によらず同じジョブになる
+func TestBuildReminderJobs(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	ny, _ := time.LoadLocation("America/New_York")
+	// 2026-03-10 08:00 JST = 2026-03-09 19:00 EDT
+	dueUser := createReminderUser(t, mockRepos, "tokyo@example.com", "Asia/Tokyo", 8,
+		time.Date(2026, 3, 10, 12, 0, 0, 0, tokyo))
+	nyUser := createReminderUser(t, mockRepos, "ny@example.com", "America/New_York", 20,
+		time.Date(2026, 3, 9, 21, 0, 0, 0, ny))
+
+	// Act
+	early, err := svc.BuildReminderJobs(ctx, time.Date(2026, 3, 10, 8, 5, 0, 0, tokyo))
+	if err != nil {
+		t.Fatalf("BuildReminderJobs failed: %v", err)
+	}
+	late, err := svc.BuildReminderJobs(ctx, time.Date(2026, 3, 10, 8, 59, 0, 0, tokyo))
+	if err != nil {
+		t.Fatalf("BuildReminderJobs failed: %v", err)
+	}
+
+	// Assert
+	if len(early) != 1 || early[0].UserID != dueUser.ID {
+		t.Fatalf("Expected 1 job for the Tokyo user, got %+v", early)
+	}
+	if !early[0].SlotTime.Equal(time.Date(2026, 3, 10, 8, 0, 0, 0, tokyo)) {
+		t.Errorf("Expected slot at 08:00 JST, got %s", early[0].SlotTime)
+	}
+	if len(early[0].Events) != 1 || early[0].Events[0].Type != NotificationEventTaskDueReminder {
+		t.Errorf("Expected 1 task due reminder, got %+v", early[0].Events)
+	}
+	if len(late) != 1 || !late[0].SlotTime.Equal(early[0].SlotTime) {
+		t.Errorf("Expected the same job within the hour, got %+v", late)
+	}
+
+	// NYのユーザーは20時（EDT）に送信する（東京のユーザーは送信時刻後のため引き続き対象。重複は送信時に防止）
+	evening, _ := svc.BuildReminderJobs(ctx, time.Date(2026, 3, 9, 20, 30, 0, 0, ny))
+	if len(evening) != 2 || evening[0].UserID != nyUser.ID && evening[1].UserID != nyUser.ID {
+		t.Errorf("Expected a job for the New York user at 20:00 EDT, got %+v", evening)
+	}
+}
+
+// TestRunReminderJobs はリマインダージョブの処理のテストです。
+// 期待動作:
+//   - ジョブごとに通知を送信し、結果を集計する
+//   - ユーザーを取得できないジョブは失敗として記録し、他のジョブの送信は続ける
+func TestRunReminderJobs(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos).(*notificationEventHandler)
+	ctx := context.Background()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Now().In(tokyo)
+	user := createReminderUser(t, mockRepos, "jobs@example.com", "Asia/Tokyo", 0, now)
+	jobs, err := svc.BuildReminderJobs(ctx, now)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d (err=%v)", len(jobs), err)
+	}
+	jobs = append(jobs, ReminderJob{
+		UserID:   9999,
+		SlotTime: jobs[0].SlotTime,
+		Events:   []NotificationEvent{{Type: NotificationEventTaskDueReminder, UserID: 9999, Title: "t", Body: "b"}},
+	})
+
+	// Act
+	result := handler.runReminderJobs(ctx, jobs)
+
+	// Assert
+	if result.UserJobs != 2 || result.TotalEvents != 2 {
+		t.Errorf("Expected 2 jobs / 2 events, got %d / %d", result.UserJobs, result.TotalEvents)
+	}
+	if result.SuccessfulSends != 1 || result.FailedSends != 1 {
+		t.Errorf("Expected 1 sent / 1 failed, got %d / %d (errors=%v)", result.SuccessfulSends, result.FailedSends, result.Errors)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 || mockSender.SentEmailNotifications[0].ToEmail != user.Email {
+		t.Errorf("Expected reminder email to %s, got %+v", user.Email, mockSender.SentEmailNotifications)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Schedule Config - 定期タスクのスケジュール設定
+// =============================================================================
+// 定期タスクの実行間隔（定期通知・トークンブラックリストのクリーンアップ等）と収穫リマインダーの先読み日数を、
+// 管理者がAPIから変更できるようにします（schedule_configs テーブル）。
+// 内蔵スケジューラーは実行のたびに設定を読み込むため、変更は再起動せずに反映されます。
+
+const (
+	// MinHarvestReminderDaysAhead は収穫リマインダーの先読み日数の最小値
+	MinHarvestReminderDaysAhead = 1
+	// MaxHarvestReminderDaysAhead は収穫リマインダーの先読み日数の最大値
+	MaxHarvestReminderDaysAhead = 30
+)
+
+var (
+	// ErrUnknownScheduleJob は存在しないジョブ名の場合のエラー
+	ErrUnknownScheduleJob = errors.New("unknown schedule job")
+	// ErrInvalidScheduleConfig はスケジュール設定が不正な場合のエラー
+	ErrInvalidScheduleConfig = errors.New("invalid schedule config")
+)
+
+// ScheduleConfigEntry はジョブのスケジュール設定と、変更されていない場合のデフォルトです。
+type ScheduleConfigEntry struct {
+	Name            string     `json:"name"`
+	Schedule        string     `json:"schedule"`         // 実行するスケジュール（変更されていない場合はデフォルト）
+	DefaultSchedule string     `json:"default_schedule"` // 組み込みのデフォルト（SCHEDULER_JOB_*_SCHEDULE を設定している場合、内蔵スケジューラーはそちらを使用）
+	Enabled         bool       `json:"enabled"`
+	LookaheadDays   *int       `json:"lookahead_days,omitempty"` // notifications のみ（収穫リマインダーを送る日数）
+	Customized      bool       `json:"customized"`               // 管理者が変更したか
+	UpdatedBy       uint       `json:"updated_by,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateScheduleConfigInput はスケジュール設定の変更内容です（nil の項目は変更しません）。
+// Schedule の書式は呼び出し元で検証済みであること（scheduler.ParseSchedule）。
+type UpdateScheduleConfigInput struct {
+	Schedule      *string // 空文字の場合はデフォルトのスケジュールに戻す
+	Enabled       *bool
+	LookaheadDays *int // 0 の場合はデフォルト（HarvestReminderDaysAhead）に戻す
+}
+
+// ListScheduleConfigs は全ジョブのスケジュール設定を返します（config.SchedulerJobNames の順）。
+//
+// 戻り値:
+//   - []ScheduleConfigEntry: ジョブごとのスケジュール設定
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) ListScheduleConfigs(ctx context.Context) ([]ScheduleConfigEntry, error) {
+	stored, err := s.GetScheduleConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]model.ScheduleConfig, len(stored))
+	for _, cfg := range stored {
+		byName[cfg.Name] = cfg
+	}
+
+	entries := make([]ScheduleConfigEntry, 0, len(config.SchedulerJobNames))
+	for _, def := range config.SchedulerJobNames {
+		entries = append(entries, newScheduleConfigEntry(def.Name, def.Schedule, byName[def.Name]))
+	}
+	return entries, nil
+}
+
+// GetScheduleConfigs は管理者が変更したスケジュール設定を取得します（内蔵スケジューラー用）。
+func (s *Service) GetScheduleConfigs(ctx context.Context) ([]model.ScheduleConfig, error) {
+	return s.repos.ScheduleConfig().GetAll(ctx)
+}
+
+// UpdateScheduleConfig はジョブのスケジュール設定を変更します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - adminID: 変更した管理者のユーザーID
+//   - name: ジョブ名（config.SchedulerJobNames）
+//   - input: 変更内容
+//
+// 戻り値:
+//   - *ScheduleConfigEntry: 変更後のスケジュール設定
+//   - error: ジョブ名が存在しない場合は ErrUnknownScheduleJob、先読み日数が不正な場合は ErrInvalidScheduleConfig
+func (s *Service) UpdateScheduleConfig(ctx context.Context, adminID uint, name string, input UpdateScheduleConfigInput) (*ScheduleConfigEntry, error) {
+	defaultSchedule, ok := defaultJobSchedule(name)
+	if !ok {
+		return nil, ErrUnknownScheduleJob
+	}
+	if input.LookaheadDays != nil && *input.LookaheadDays != 0 {
+		if name != config.SchedulerJobNotifications {
+			return nil, fmt.Errorf("%w: lookahead_days is only supported for %s", ErrInvalidScheduleConfig, config.SchedulerJobNotifications)
+		}
+		if *input.LookaheadDays < MinHarvestReminderDaysAhead || *input.LookaheadDays > MaxHarvestReminderDaysAhead {
+			return nil, fmt.Errorf("%w: lookahead_days must be between %d and %d",
+				ErrInvalidScheduleConfig, MinHarvestReminderDaysAhead, MaxHarvestReminderDaysAhead)
+		}
+	}
+
+	cfg, err := s.repos.ScheduleConfig().GetByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		cfg = &model.ScheduleConfig{Name: name, Enabled: true}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if input.Schedule != nil {
+		cfg.Schedule = *input.Schedule
+	}
+	if input.Enabled != nil {
+		cfg.Enabled = *input.Enabled
+	}
+	if input.LookaheadDays != nil {
+		cfg.LookaheadDays = nil
+		if *input.LookaheadDays != 0 {
+			days := *input.LookaheadDays
+			cfg.LookaheadDays = &days
+		}
+	}
+	cfg.UpdatedBy = adminID
+
+	if err := s.repos.ScheduleConfig().Upsert(ctx, cfg); err != nil {
+		return nil, err
+	}
+	entry := newScheduleConfigEntry(name, defaultSchedule, *cfg)
+	return &entry, nil
+}
+
+// harvestReminderDaysAhead は収穫リマインダーを送る日数を返します。
+// 管理者が設定していない・取得に失敗した場合はデフォルト（HarvestReminderDaysAhead）です。
+func (s *Service) harvestReminderDaysAhead(ctx context.Context) int {
+	cfg, err := s.repos.ScheduleConfig().GetByName(ctx, config.SchedulerJobNotifications)
+	if err != nil || cfg.LookaheadDays == nil {
+		return HarvestReminderDaysAhead
+	}
+	days := *cfg.LookaheadDays
+	if days < MinHarvestReminderDaysAhead || days > MaxHarvestReminderDaysAhead {
+		return HarvestReminderDaysAhead
+	}
+	return days
+}
+
+// defaultJobSchedule はジョブのデフォルトのスケジュールを返します。
+func defaultJobSchedule(name string) (string, bool) {
+	for _, def := range config.SchedulerJobNames {
+		if def.Name == name {
+			return def.Schedule, true
+		}
+	}
+	return "", false
+}
+
+// newScheduleConfigEntry は保存された設定とデフォルトからスケジュール設定を作成します。
+// 保存された設定がない（ID が 0）場合はデフォルトのスケジュールで有効です。
+func newScheduleConfigEntry(name, defaultSchedule string, stored model.ScheduleConfig) ScheduleConfigEntry {
+	entry := ScheduleConfigEntry{
+		Name:            name,
+		Schedule:        defaultSchedule,
+		DefaultSchedule: defaultSchedule,
+		Enabled:         true,
+	}
+	if stored.ID == 0 {
+		return entry
+	}
+	if stored.Schedule != "" {
+		entry.Schedule = stored.Schedule
+	}
+	updatedAt := stored.UpdatedAt
+	entry.Enabled = stored.Enabled
+	entry.LookaheadDays = stored.LookaheadDays
+	entry.Customized = true
+	entry.UpdatedBy = stored.UpdatedBy
+	entry.UpdatedAt = &updatedAt
+	return entry
+}
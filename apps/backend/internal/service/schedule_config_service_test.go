@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Schedule Config Tests - 定期タスクのスケジュール設定のテスト
+// =============================================================================
+// テスト対象:
+//   - UpdateScheduleConfig / ListScheduleConfigs: スケジュール設定の変更とデフォルトとの統合
+//   - processHarvestReminders: 管理者が設定した先読み日数の反映
+
+// TestUpdateScheduleConfig はスケジュール設定の変更のテストです。
+// 期待動作:
+//   - 変更したジョブは変更後のスケジュール、変更していないジョブはデフォルトで一覧に含まれる
+//   - 指定しなかった項目は変更しない
+//   - 存在しないジョブ・notifications 以外の先読み日数・範囲外の先読み日数はエラー
+func TestUpdateScheduleConfig(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	schedule := "0 */2 * * *"
+	disabled := false
+	days := 14
+
+	// Act
+	_, err := svc.UpdateScheduleConfig(ctx, 1, config.SchedulerJobNotifications, UpdateScheduleConfigInput{Schedule: &schedule, LookaheadDays: &days})
+	if err != nil {
+		t.Fatalf("UpdateScheduleConfig failed: %v", err)
+	}
+	updated, err := svc.UpdateScheduleConfig(ctx, 2, config.SchedulerJobNotifications, UpdateScheduleConfigInput{Enabled: &disabled})
+	if err != nil {
+		t.Fatalf("UpdateScheduleConfig failed: %v", err)
+	}
+	entries, err := svc.ListScheduleConfigs(ctx)
+	if err != nil {
+		t.Fatalf("ListScheduleConfigs failed: %v", err)
+	}
+
+	// Assert
+	if updated.Schedule != schedule || updated.Enabled || updated.LookaheadDays == nil || *updated.LookaheadDays != 14 || updated.UpdatedBy != 2 {
+		t.Errorf("Unexpected updated config: %+v", updated)
+	}
+	if len(entries) != len(config.SchedulerJobNames) {
+		t.Fatalf("Expected %d entries, got %d", len(config.SchedulerJobNames), len(entries))
+	}
+	for _, entry := range entries {
+		switch entry.Name {
+		case config.SchedulerJobNotifications:
+			if !entry.Customized || entry.Schedule != schedule || entry.DefaultSchedule != "0 * * * *" {
+				t.Errorf("Unexpected notifications entry: %+v", entry)
+			}
+		case config.SchedulerJobTokenBlacklistCleanup:
+			if entry.Customized || !entry.Enabled || entry.Schedule != "30 4 * * *" {
+				t.Errorf("Expected default token cleanup entry, got %+v", entry)
+			}
+		}
+	}
+
+	if _, err := svc.UpdateScheduleConfig(ctx, 1, "unknown", UpdateScheduleConfigInput{}); !errors.Is(err, ErrUnknownScheduleJob) {
+		t.Errorf("Expected ErrUnknownScheduleJob, got %v", err)
+	}
+	if _, err := svc.UpdateScheduleConfig(ctx, 1, config.SchedulerJobTokenBlacklistCleanup, UpdateScheduleConfigInput{LookaheadDays: &days}); !errors.Is(err, ErrInvalidScheduleConfig) {
+		t.Errorf("Expected ErrInvalidScheduleConfig for lookahead on other job, got %v", err)
+	}
+	tooMany := MaxHarvestReminderDaysAhead + 1
+	if _, err := svc.UpdateScheduleConfig(ctx, 1, config.SchedulerJobNotifications, UpdateScheduleConfigInput{LookaheadDays: &tooMany}); !errors.Is(err, ErrInvalidScheduleConfig) {
+		t.Errorf("Expected ErrInvalidScheduleConfig for out-of-range lookahead, got %v", err)
+	}
+}
+
+// TestProcessHarvestReminders_LookaheadDays は収穫リマインダーの先読み日数のテストです。
+// 期待動作:
+//   - デフォルト（7日）では10日後に収穫予定の作物を通知しない
+//   - 先読み日数を14日にすると通知し、本文に日数を含める
+func TestProcessHarvestReminders_LookaheadDays(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	now := time.Now()
+	hour := 0
+	user := &model.User{
+		Email:    "lookahead@example.com",
+		Timezone: "UTC",
+		NotificationSettings: &model.NotificationSettings{
+			EmailEnabled:      true,
+			HarvestReminders:  true,
+			DailyReminderHour: &hour,
+		},
+	}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	for _, name := range []string{"トマト", "なす"} {
+		if err := mockRepos.Crop().Create(ctx, &model.Crop{
+			UserID:              user.ID,
+			Name:                name,
+			PlantedDate:         now.AddDate(0, -1, 0),
+			ExpectedHarvestDate: now.AddDate(0, 0, 10),
+			Status:              "growing",
+			User:                *user, // モックでPreloadをシミュレート
+		}); err != nil {
+			t.Fatalf("Failed to create crop: %v", err)
+		}
+	}
+
+	// Act
+	defaultEvents, err := svc.processHarvestReminders(ctx, []uint{user.ID}, now)
+	if err != nil {
+		t.Fatalf("processHarvestReminders failed: %v", err)
+	}
+	days := 14
+	if _, err := svc.UpdateScheduleConfig(ctx, 1, config.SchedulerJobNotifications, UpdateScheduleConfigInput{LookaheadDays: &days}); err != nil {
+		t.Fatalf("UpdateScheduleConfig failed: %v", err)
+	}
+	events, err := svc.processHarvestReminders(ctx, []uint{user.ID}, now)
+	if err != nil {
+		t.Fatalf("processHarvestReminders failed: %v", err)
+	}
+
+	// Assert
+	if len(defaultEvents) != 0 {
+		t.Errorf("Expected no reminders with the default lookahead, got %d", len(defaultEvents))
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 reminder with a 14-day lookahead, got %d", len(events))
+	}
+	if !strings.Contains(events[0].Body, "14") {
+		t.Errorf("Expected body to mention the lookahead days, got %q", events[0].Body)
+	}
+}
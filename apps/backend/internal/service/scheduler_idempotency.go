@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Scheduler Idempotency - スケジューラー呼び出しの冪等キー
+// =============================================================================
+// EventBridge Scheduler は配信に失敗したと判断すると同じ呼び出しを再送するため、
+// スケジューラーエンドポイントは冪等キーごとに処理結果を記録し、再送時は処理せずに記録したレスポンスを返します。
+//
+// 冪等キーの処理状態:
+//   - 処理中（StatusCode = 0）: 同じキーの別リクエストは ErrSchedulerInvocationInProgress
+//   - 完了: 同じキーのリクエストには記録したレスポンスを返す（SchedulerIdempotencyTTL の間）
+//   - 失敗（5xx）: 登録を削除し、再送で処理し直せるようにする
+
+const (
+	// SchedulerIdempotencyTTL は冪等キーの処理結果の保持期間
+	SchedulerIdempotencyTTL = 24 * time.Hour
+	// SchedulerIdempotencyKeyMaxLength は冪等キーの最大文字数
+	SchedulerIdempotencyKeyMaxLength = 200
+
+	// schedulerInvocationStaleAfter は処理中のまま残った冪等キーを放棄されたとみなす時間（プロセスの異常終了など）
+	schedulerInvocationStaleAfter = 15 * time.Minute
+)
+
+var (
+	// ErrSchedulerInvocationInProgress は同じ冪等キーのリクエストを処理中の場合のエラー
+	ErrSchedulerInvocationInProgress = errors.New("scheduler invocation in progress")
+	// ErrInvalidIdempotencyKey は冪等キーが空、または長すぎる場合のエラー
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+)
+
+// BeginSchedulerInvocation は冪等キーの処理を開始します。
+// 初めてのキーは処理中として登録し、nil を返します（呼び出し元は処理後に CompleteSchedulerInvocation を呼び出します）。
+// 処理済みのキーは記録した処理結果を返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - endpoint: エンドポイント（ルートのパス）
+//   - key: 冪等キー
+//
+// 戻り値:
+//   - *model.SchedulerInvocation: 処理済みの場合は記録した処理結果、初めての場合は nil
+//   - error: 処理中の場合は ErrSchedulerInvocationInProgress、キーが不正な場合は ErrInvalidIdempotencyKey
+func (s *Service) BeginSchedulerInvocation(ctx context.Context, endpoint, key string) (*model.SchedulerInvocation, error) {
+	if key == "" || len(key) > SchedulerIdempotencyKeyMaxLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	now := s.now()
+	repo := s.repos.SchedulerInvocation()
+	// 取得できない場合は未登録として扱う（DBの障害は Reserve でエラーになる）
+	if existing, err := repo.GetByKey(ctx, endpoint, key); err == nil {
+		expired := now.After(existing.ExpiresAt)
+		stale := existing.StatusCode == 0 && now.Sub(existing.CreatedAt) > schedulerInvocationStaleAfter
+		if !expired && !stale {
+			if existing.StatusCode == 0 {
+				return nil, ErrSchedulerInvocationInProgress
+			}
+			return existing, nil
+		}
+		// 期限切れ・放棄されたキーは削除して登録し直す
+		if err := repo.Delete(ctx, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	reserved, err := repo.Reserve(ctx, &model.SchedulerInvocation{
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+		ExpiresAt:      now.Add(SchedulerIdempotencyTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		// 同時に受けた同じキーのリクエストが先に登録した
+		return nil, ErrSchedulerInvocationInProgress
+	}
+	return nil, nil
+}
+
+// CompleteSchedulerInvocation は冪等キーの処理結果を記録します。
+// サーバーエラー（5xx）の場合は記録せずに登録を削除し、再送で処理し直せるようにします。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - endpoint: エンドポイント（ルートのパス）
+//   - key: 冪等キー
+//   - statusCode: レスポンスのステータスコード
+//   - responseBody: レスポンスボディ
+//
+// 戻り値:
+//   - error: 記録に失敗した場合のエラー
+func (s *Service) CompleteSchedulerInvocation(ctx context.Context, endpoint, key string, statusCode int, responseBody []byte) error {
+	repo := s.repos.SchedulerInvocation()
+	invocation, err := repo.GetByKey(ctx, endpoint, key)
+	if err != nil {
+		return err
+	}
+	if statusCode >= 500 {
+		return repo.Delete(ctx, invocation.ID)
+	}
+	return repo.Complete(ctx, invocation.ID, statusCode, string(responseBody), s.now())
+}
+
+// CleanupExpiredSchedulerInvocations は保持期間を過ぎた冪等キーを削除します。
+//
+// 戻り値:
+//   - int64: 削除した件数
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) CleanupExpiredSchedulerInvocations(ctx context.Context) (int64, error) {
+	return s.repos.SchedulerInvocation().DeleteExpired(ctx, s.now())
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Scheduler Pagination Tests - 定期通知のページ処理のテスト
+// =============================================================================
+// テスト対象:
+//   - ProcessScheduledNotificationsAndSend: ユーザーのページごとの送信、進捗の保存、途中からの再開
+//   - BuildReminderJobs: ページに分けても全ユーザーのジョブを作成すること
+
+// TestProcessScheduledNotificationsAndSend_Pages はユーザーのページごとの定期通知のテストです。
+// 期待動作:
+//   - 1ページ1人の場合も全ユーザーに送信し、ページ数を UserPages に記録する
+//   - 全ページの処理後は進捗を完了にする
+func TestProcessScheduledNotificationsAndSend_Pages(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetSchedulerPageSize(1)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		createReminderUser(t, mockRepos, email, "UTC", 0, now)
+	}
+
+	// Act
+	result, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+	if result.UserPages != 3 || result.EnqueuedEvents != 3 || result.SuccessfulSends != 3 {
+		t.Errorf("Expected 3 pages / 3 enqueued / 3 sent, got %+v", result)
+	}
+	if len(mockSender.SentEmailNotifications) != 3 {
+		t.Errorf("Expected 3 reminder emails, got %d", len(mockSender.SentEmailNotifications))
+	}
+	checkpoint := mockRepos.GetMockSchedulerCheckpointRepository().Checkpoints[reminderCheckpointName]
+	if checkpoint == nil || checkpoint.CompletedAt == nil || checkpoint.Pages != 3 {
+		t.Errorf("Expected completed checkpoint with 3 pages, got %+v", checkpoint)
+	}
+}
+
+// TestProcessScheduledNotificationsAndSend_Resume は途中で止まった定期通知の再開のテストです。
+// 期待動作:
+//   - 同じスロットの完了していない進捗がある場合は続きのページから処理する
+//   - 前のスロットの進捗は使わずに最初のページから処理する
+func TestProcessScheduledNotificationsAndSend_Resume(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetSchedulerPageSize(1)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	first := createReminderUser(t, mockRepos, "first@example.com", "UTC", 0, now)
+	second := createReminderUser(t, mockRepos, "second@example.com", "UTC", 0, now)
+
+	checkpoints := mockRepos.GetMockSchedulerCheckpointRepository()
+	checkpoint := svc.reminderCheckpoint(ctx, reminderSlot(now))
+	checkpoint.LastUserID = first.ID
+	checkpoint.Pages = 1
+	svc.saveReminderCheckpoint(ctx, checkpoint)
+
+	// Act
+	resumed, err := handler.ProcessScheduledNotificationsAndSend(ctx)
+	if err != nil {
+		t.Fatalf("ProcessScheduledNotificationsAndSend failed: %v", err)
+	}
+	completed := *checkpoints.Checkpoints[reminderCheckpointName]
+	stale := completed
+	stale.SlotTime = stale.SlotTime.Add(-time.Hour)
+	stale.CompletedAt = nil
+	svc.saveReminderCheckpoint(ctx, &stale)
+	restarted := svc.reminderCheckpoint(ctx, reminderSlot(now))
+
+	// Assert
+	if resumed.UserPages != 1 || len(mockSender.SentEmailNotifications) != 1 {
+		t.Fatalf("Expected only the remaining page to be sent, got %+v", resumed)
+	}
+	if mockSender.SentEmailNotifications[0].ToEmail != second.Email {
+		t.Errorf("Expected reminder to %s, got %s", second.Email, mockSender.SentEmailNotifications[0].ToEmail)
+	}
+	if completed.Pages != 2 || completed.CompletedAt == nil {
+		t.Errorf("Expected completed checkpoint with 2 pages, got %+v", completed)
+	}
+	if restarted.LastUserID != 0 || restarted.Pages != 0 {
+		t.Errorf("Expected a previous slot checkpoint to restart from the first page, got %+v", restarted)
+	}
+}
+
+// TestBuildReminderJobs_Pages はページに分けたリマインダージョブの作成のテストです。
+// 期待動作:
+//   - ページのサイズによらず全ユーザーのジョブを作成する
+func TestBuildReminderJobs_Pages(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetSchedulerPageSize(2)
+	now := time.Now().UTC()
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		createReminderUser(t, mockRepos, email, "UTC", 0, now)
+	}
+
+	// Act
+	jobs, err := svc.BuildReminderJobs(context.Background(), now)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BuildReminderJobs failed: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Errorf("Expected 3 jobs across 2 pages, got %d", len(jobs))
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Search - 作物・タスク・区画・成長記録の横断検索
+// =============================================================================
+// 検索バー（GET /search）の検索です。対象ごとに関連度の高い記録を検索し、関連度の順にまとめて返します。
+// 全文検索で一致した記録は、トライグラム・部分一致のみで一致した記録より上位です（SearchRepository）。
+// スニペットは一致した位置の前後の本文です（一致した位置がない場合は本文の先頭）。
+
+const (
+	// SearchDefaultLimit は limit を指定しない場合の件数
+	SearchDefaultLimit = 20
+	// SearchMaxLimit は limit の上限
+	SearchMaxLimit = 50
+	// SearchMaxQueryLength は検索語の最大文字数
+	SearchMaxQueryLength = 100
+	// searchSnippetRunes はスニペットの最大文字数
+	searchSnippetRunes = 80
+)
+
+var (
+	// ErrInvalidSearchQuery は検索語が空・長すぎる場合のエラー
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+	// ErrInvalidSearchType は検索の対象が不正な場合のエラー
+	ErrInvalidSearchType = errors.New("invalid search type")
+)
+
+// SearchTypes は検索の対象です（type に指定する値）。
+var SearchTypes = []string{
+	repository.SearchEntityCrops,
+	repository.SearchEntityTasks,
+	repository.SearchEntityPlots,
+	repository.SearchEntityGrowthRecords,
+}
+
+// SearchResult は検索結果の1件です。
+type SearchResult struct {
+	Type      string    `json:"type"` // crops, tasks, plots, growth_records
+	ID        uint      `json:"id"`
+	CropID    *uint     `json:"crop_id,omitempty"` // 成長記録の作物ID（成長記録の画面への遷移用）
+	Title     string    `json:"title"`             // 作物・区画の名前、タスクのタイトル、成長記録の作物の名前
+	Snippet   string    `json:"snippet"`           // 一致した位置の前後の本文
+	Rank      float64   `json:"rank"`              // 関連度（全文検索で一致した場合は 1 以上）
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SearchResults は検索の結果です。
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// Search は作物・タスク・区画・成長記録のメモを横断して検索し、関連度の高い順に返します。
+// 組織のスコープでは、組織の作物・区画と作物の成長記録、ユーザーのタスクを検索します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - query: 検索語（前後の空白は除く、1〜SearchMaxQueryLength 文字）
+//   - types: 検索の対象（SearchTypes、空の場合は全て）
+//   - limit: 最大件数（0 の場合は SearchDefaultLimit、SearchMaxLimit まで）
+//
+// 戻り値:
+//   - *SearchResults: 検索結果
+//   - error: 検索語が不正な場合は ErrInvalidSearchQuery、対象が不正な場合は ErrInvalidSearchType
+func (s *Service) Search(ctx context.Context, userID uint, query string, types []string, limit int) (*SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > SearchMaxQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(SearchTypes, t) {
+			return nil, ErrInvalidSearchType
+		}
+	}
+	if limit <= 0 {
+		limit = SearchDefaultLimit
+	}
+	limit = min(limit, SearchMaxLimit)
+
+	var hits []repository.SearchHit
+	for _, t := range slices.Compact(slices.Sorted(slices.Values(types))) {
+		found, err := s.repos.Search().Search(ctx, userID, t, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, found...)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	results := make([]SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = SearchResult{
+			Type:      hit.Type,
+			ID:        hit.ID,
+			Title:     hit.Title,
+			Snippet:   searchSnippet(hit.Body, query),
+			Rank:      hit.Rank,
+			UpdatedAt: hit.UpdatedAt,
+		}
+		if hit.CropID != 0 {
+			cropID := hit.CropID
+			results[i].CropID = &cropID
+		}
+	}
+	return &SearchResults{Query: query, Results: results}, nil
+}
+
+// searchSnippet は本文の検索語の位置の前後 searchSnippetRunes 文字を返します（省略した部分は「…」）。
+// 検索語が本文にない場合（全文検索・トライグラムのみの一致）は本文の先頭を返します。
+func searchSnippet(body, query string) string {
+	text := []rune(strings.Join(strings.Fields(body), " "))
+	if len(text) <= searchSnippetRunes {
+		return string(text)
+	}
+
+	start := 0
+	if i := indexRunesFold(text, []rune(query)); i >= 0 {
+		// 一致した位置が中央付近になるようにする
+		start = max(i-searchSnippetRunes/3, 0)
+	}
+	end := min(start+searchSnippetRunes, len(text))
+	start = max(end-searchSnippetRunes, 0)
+
+	snippet := string(text[start:end])
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// indexRunesFold は text で最初に query と大文字・小文字を区別せずに一致する位置（文字数）を返します（ない場合は -1）。
+// strings.ToLower は文字列のバイト数を変える場合がある（Ⱥ→ⱥ など）ため、文字単位で比較します。
+func indexRunesFold(text, query []rune) int {
+	if len(query) == 0 {
+		return -1
+	}
+	q := string(query)
+	for i := 0; i+len(query) <= len(text); i++ {
+		if strings.EqualFold(string(text[i:i+len(query)]), q) {
+			return i
+		}
+	}
+	return -1
+}
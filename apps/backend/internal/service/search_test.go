@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Search Tests - 横断検索のテスト
+// =============================================================================
+// テスト対象:
+//   - Search: 作物・タスク・区画・成長記録の横断検索、関連度の順、対象の絞り込み、件数の上限
+//   - Search: 検索語・対象が不正な場合のエラー
+//   - searchSnippet: 一致した位置の前後の本文（小文字にするとバイト数が変わる文字を含む本文）
+
+// TestSearch は横断検索のテストです。
+// 期待動作:
+//   - タイトルの一致は本文のみの一致より上位で、成長記録には作物IDが付く
+//   - 他のユーザーの記録・削除した記録は返さない
+//   - type で対象を絞り込み、limit で件数を制限する
+func TestSearch(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	crop := &model.Crop{UserID: 1, Name: "ミニトマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	if err := svc.CreateCrop(ctx, crop); err != nil {
+		t.Fatalf("CreateCrop failed: %v", err)
+	}
+	record := &model.GrowthRecord{CropID: crop.ID, RecordDate: time.Now(), GrowthStage: "flowering", Notes: "トマトの花が咲いた"}
+	if err := svc.CreateGrowthRecord(ctx, record); err != nil {
+		t.Fatalf("CreateGrowthRecord failed: %v", err)
+	}
+	task := &model.Task{UserID: 1, Title: "支柱立て", Description: "トマトの支柱を立てる", DueDate: time.Now()}
+	deleted := &model.Task{UserID: 1, Title: "トマトの収穫", DueDate: time.Now()}
+	other := &model.Task{UserID: 2, Title: "トマトの水やり", DueDate: time.Now()}
+	for _, tk := range []*model.Task{task, deleted, other} {
+		if err := svc.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+	}
+	_ = svc.DeleteTask(ctx, deleted.ID)
+
+	// Act
+	all, err := svc.Search(ctx, 1, "  トマト ", nil, 0)
+	tasksOnly, tasksErr := svc.Search(ctx, 1, "トマト", []string{"tasks"}, 0)
+	limited, limitedErr := svc.Search(ctx, 1, "トマト", nil, 1)
+
+	// Assert
+	if err != nil || tasksErr != nil || limitedErr != nil {
+		t.Fatalf("Search failed: %v, %v, %v", err, tasksErr, limitedErr)
+	}
+	if all.Query != "トマト" {
+		t.Errorf("Expected trimmed query, got %q", all.Query)
+	}
+	if len(all.Results) != 3 {
+		t.Fatalf("Expected crop, growth record and task, got %+v", all.Results)
+	}
+	if all.Results[0].Type != repository.SearchEntityCrops || all.Results[0].ID != crop.ID {
+		t.Errorf("Expected title match of crop first, got %+v", all.Results[0])
+	}
+	for _, result := range all.Results[1:] {
+		switch result.Type {
+		case repository.SearchEntityGrowthRecords:
+			if result.ID != record.ID || result.CropID == nil || *result.CropID != crop.ID || result.Title != crop.Name {
+				t.Errorf("Expected growth record with crop ID, got %+v", result)
+			}
+		case repository.SearchEntityTasks:
+			if result.ID != task.ID || result.Snippet != "支柱立て トマトの支柱を立てる" {
+				t.Errorf("Expected task with snippet, got %+v", result)
+			}
+		default:
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	}
+	if len(tasksOnly.Results) != 1 || tasksOnly.Results[0].ID != task.ID {
+		t.Errorf("Expected only the task, got %+v", tasksOnly.Results)
+	}
+	if len(limited.Results) != 1 {
+		t.Errorf("Expected 1 result with limit, got %d", len(limited.Results))
+	}
+}
+
+// TestSearch_InvalidInput は不正な検索語・対象のテストです。
+// 期待動作:
+//   - 空白のみ・SearchMaxQueryLength 文字を超える検索語は ErrInvalidSearchQuery
+//   - SearchTypes 以外の対象は ErrInvalidSearchType
+func TestSearch_InvalidInput(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	ctx := context.Background()
+
+	// Act
+	_, blankErr := svc.Search(ctx, 1, "   ", nil, 0)
+	_, longErr := svc.Search(ctx, 1, strings.Repeat("あ", SearchMaxQueryLength+1), nil, 0)
+	_, typeErr := svc.Search(ctx, 1, "トマト", []string{"crops", "gardens"}, 0)
+
+	// Assert
+	if !errors.Is(blankErr, ErrInvalidSearchQuery) || !errors.Is(longErr, ErrInvalidSearchQuery) {
+		t.Errorf("Expected ErrInvalidSearchQuery, got %v, %v", blankErr, longErr)
+	}
+	if !errors.Is(typeErr, ErrInvalidSearchType) {
+		t.Errorf("Expected ErrInvalidSearchType, got %v", typeErr)
+	}
+}
+
+// TestSearchSnippet はスニペットのテストです。
+// 期待動作:
+//   - 短い本文はそのまま（連続する空白は1つにまとめる）
+//   - 長い本文は一致した位置の前後を返し、省略した部分に「…」を付ける
+//   - 小文字にするとバイト数が変わる文字を含む本文でも、一致した位置（大文字・小文字を区別しない）の前後を返す
+func TestSearchSnippet(t *testing.T) {
+	// Arrange
+	long := strings.Repeat("あ", 100) + "トマト" + strings.Repeat("い", 100)
+	// Ⱥ（2バイト）は小文字の ⱥ（3バイト）、ẞ（3バイト）は小文字の ß（2バイト）になる
+	growing := strings.Repeat("Ⱥ", 100) + " tomato"
+	shifted := strings.Repeat("ẞ", 200) + " Tomato " + strings.Repeat("x", 100)
+
+	// Act
+	short := searchSnippet("トマトの\n  花", "トマト")
+	snippet := searchSnippet(long, "トマト")
+	growingSnippet := searchSnippet(growing, "tomato")
+	shiftedSnippet := searchSnippet(shifted, "TOMATO")
+
+	// Assert
+	if short != "トマトの 花" {
+		t.Errorf("Expected normalized body, got %q", short)
+	}
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") || !strings.Contains(snippet, "トマト") {
+		t.Errorf("Expected snippet around the match, got %q", snippet)
+	}
+	if n := len([]rune(strings.Trim(snippet, "…"))); n != searchSnippetRunes {
+		t.Errorf("Expected %d runes, got %d", searchSnippetRunes, n)
+	}
+	if !strings.HasSuffix(growingSnippet, "tomato") {
+		t.Errorf("Expected the snippet to end with the match, got %q", growingSnippet)
+	}
+	if !strings.Contains(shiftedSnippet, "Tomato") {
+		t.Errorf("Expected the snippet around the case-insensitive match, got %q", shiftedSnippet)
+	}
+}
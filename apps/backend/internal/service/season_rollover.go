@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Season Rollover - シーズンの締め
+// =============================================================================
+// 年に1回、終わったシーズン（植え付け年）を締めます。
+//   - 収穫済み・失敗の作物をアーカイブする（Crop.ArchivedAt）
+//   - 区画ごとの生産性（作物数・収穫量・面積あたり収穫量）を season_summaries に保存する
+//   - 今回アーカイブした作物があるユーザーに「菜園のふりかえり」（year_in_review）を通知する
+//
+// 区画ごとの集計は区画生産性グラフ（getPlotProductivityChart）と同じく区画への配置履歴を使用し、
+// 配置履歴がない作物は Crop.PlotID、どちらもない作物は区画なし（PlotID: 0）として集計します。
+// 再実行した場合は記録を更新し、ふりかえりの通知は送信済みのユーザーには送りません。
+
+// ErrSeasonNotFinished は終わっていないシーズン（PreviousSeason より後）を締めようとした場合のエラー
+var ErrSeasonNotFinished = errors.New("season has not finished yet")
+
+// SeasonRolloverResult はシーズンの締めの処理結果です。
+type SeasonRolloverResult struct {
+	Season        int       `json:"season"`
+	ProcessedAt   time.Time `json:"processed_at"`
+	Users         int       `json:"users"`          // シーズンを締めたユーザー数
+	ArchivedCrops int       `json:"archived_crops"` // 今回アーカイブした作物の数
+	Summaries     int       `json:"summaries"`      // 作成・更新した区画ごとの記録の数
+	Notifications int       `json:"notifications"`  // 送信したふりかえりの通知の数
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+// seasonEndMargin は年明けが最も早いタイムゾーン（UTC+14）とUTCの差です。
+// いずれかのユーザーのタイムゾーンで年が明けていればシーズンを締められるようにします。
+const seasonEndMargin = 14 * time.Hour
+
+// PreviousSeason は now の時点で最後に終わったシーズン（年明けが最も早いタイムゾーンでの前年）を返します。
+func PreviousSeason(now time.Time) int {
+	return now.Add(seasonEndMargin).UTC().Year() - 1
+}
+
+// RunSeasonRollover はシーズンを締め、ふりかえりの通知を送信します。
+// 内蔵スケジューラー・EventBridge Scheduler から毎年1月1日に呼び出されることを想定しています。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - season: 締めるシーズン（植え付け年、0 の場合は PreviousSeason）
+//
+// 戻り値:
+//   - *SeasonRolloverResult: 処理結果（ユーザーごとのエラーは Errors に記録）
+//   - error: 終わっていないシーズンは ErrSeasonNotFinished、作物の取得に失敗した場合のエラー
+func (h *notificationEventHandler) RunSeasonRollover(ctx context.Context, season int) (*SeasonRolloverResult, error) {
+	result, events, err := h.service.closeSeason(ctx, season, h.service.now())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return result, nil
+	}
+
+	sent, err := h.HandleEvents(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	result.Notifications = sent.SuccessfulSends
+	result.Errors = append(result.Errors, sent.Errors...)
+	return result, nil
+}
+
+// closeSeason はシーズンの作物をユーザーごとにアーカイブし、区画ごとの記録を保存します。
+// 1人のユーザーの失敗は他のユーザーの処理を止めません。
+// 戻り値の通知イベントは、今回アーカイブした作物があり、ふりかえりの通知が有効なユーザーの分です。
+func (s *Service) closeSeason(ctx context.Context, season int, now time.Time) (*SeasonRolloverResult, []NotificationEvent, error) {
+	if season == 0 {
+		season = PreviousSeason(now)
+	}
+	if season > PreviousSeason(now) {
+		return nil, nil, ErrSeasonNotFinished
+	}
+	result := &SeasonRolloverResult{Season: season, ProcessedAt: now}
+
+	from := time.Date(season, 1, 1, 0, 0, 0, 0, time.UTC)
+	crops, err := s.repos.Crop().GetFinishedPlantedBetween(ctx, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get finished crops: %w", err)
+	}
+
+	var order []uint
+	userCrops := make(map[uint][]model.Crop)
+	for _, crop := range crops {
+		if _, ok := userCrops[crop.UserID]; !ok {
+			order = append(order, crop.UserID)
+		}
+		userCrops[crop.UserID] = append(userCrops[crop.UserID], crop)
+	}
+
+	var events []NotificationEvent
+	for _, userID := range order {
+		crops := userCrops[userID]
+		summaries, err := s.buildSeasonSummaries(ctx, userID, season, crops, now)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("user %d: %v", userID, err))
+			continue
+		}
+
+		var archiveIDs []uint
+		for _, crop := range crops {
+			if crop.ArchivedAt == nil {
+				archiveIDs = append(archiveIDs, crop.ID)
+			}
+		}
+		err = s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := s.repos.SeasonSummary().Upsert(txCtx, summaries); err != nil {
+				return err
+			}
+			return s.repos.Crop().Archive(txCtx, archiveIDs, now)
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("user %d: %v", userID, err))
+			continue
+		}
+
+		result.Users++
+		result.ArchivedCrops += len(archiveIDs)
+		result.Summaries += len(summaries)
+
+		// 前回の実行でアーカイブ済みのユーザーには通知しない
+		user := &crops[0].User
+		if len(archiveIDs) == 0 || user.ID == 0 || !notificationEventEnabled(user, NotificationEventYearInReview) {
+			continue
+		}
+		events = append(events, yearInReviewEvent(user, season, summaries))
+	}
+
+	return result, events, nil
+}
+
+// buildSeasonSummaries はユーザーのシーズンの作物を区画ごとに集計します（総収穫量の多い順）。
+func (s *Service) buildSeasonSummaries(ctx context.Context, userID uint, season int, crops []model.Crop, now time.Time) ([]model.SeasonSummary, error) {
+	plots, err := s.repos.Plot().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 作物→区画のマッピングを構築（配置履歴を優先）
+	plotByID := make(map[uint]model.Plot, len(plots))
+	cropToPlot := make(map[uint]uint)
+	for _, plot := range plots {
+		plotByID[plot.ID] = plot
+		assignments, err := s.repos.PlotAssignment().GetByPlotID(ctx, plot.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			cropToPlot[assignment.CropID] = plot.ID
+		}
+	}
+
+	cropIDs := getCropIDs(crops)
+	harvests, err := s.repos.Harvest().GetByCropIDs(ctx, cropIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[uint]*model.SeasonSummary)
+	cropPlot := make(map[uint]uint, len(crops))
+	for _, crop := range crops {
+		plotID, ok := cropToPlot[crop.ID]
+		if !ok && crop.PlotID != nil {
+			plotID = *crop.PlotID
+		}
+		if _, ok := plotByID[plotID]; !ok {
+			plotID = 0 // 削除済みの区画は区画なしとして集計する
+		}
+		cropPlot[crop.ID] = plotID
+
+		summary, ok := summaries[plotID]
+		if !ok {
+			summary = &model.SeasonSummary{UserID: userID, Season: season, PlotID: plotID, ClosedAt: now}
+			if plot, ok := plotByID[plotID]; ok {
+				summary.PlotName = plot.Name
+				summary.AreaM2 = plot.Width * plot.Height
+			}
+			summaries[plotID] = summary
+		}
+		summary.CropsGrown++
+		if crop.Status == "harvested" {
+			summary.CropsHarvested++
+		} else {
+			summary.CropsFailed++
+		}
+	}
+
+	for _, harvest := range harvests {
+		summary, ok := summaries[cropPlot[harvest.CropID]]
+		if !ok {
+			continue
+		}
+		summary.TotalKg += convertToKg(harvest.Quantity, harvest.QuantityUnit)
+		summary.HarvestCount++
+	}
+
+	result := make([]model.SeasonSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		summary.TotalKg = math.Round(summary.TotalKg*100) / 100
+		if summary.AreaM2 > 0 {
+			summary.KgPerM2 = math.Round(summary.TotalKg/summary.AreaM2*100) / 100
+		}
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalKg != result[j].TotalKg {
+			return result[i].TotalKg > result[j].TotalKg
+		}
+		return result[i].PlotID < result[j].PlotID
+	})
+	return result, nil
+}
+
+// yearInReviewEvent はシーズンの記録からふりかえりの通知イベントを生成します。
+// summaries は総収穫量の多い順で、区画なしを除く先頭の区画を最も収穫の多かった区画として通知します。
+func yearInReviewEvent(user *model.User, season int, summaries []model.SeasonSummary) NotificationEvent {
+	var cropsGrown, cropsHarvested int
+	var totalKg float64
+	for _, summary := range summaries {
+		cropsGrown += summary.CropsGrown
+		cropsHarvested += summary.CropsHarvested
+		totalKg += summary.TotalKg
+	}
+	totalKg = math.Round(totalKg*100) / 100
+
+	data := map[string]interface{}{
+		"season":          season,
+		"crops_grown":     cropsGrown,
+		"crops_harvested": cropsHarvested,
+		"total_kg":        totalKg,
+	}
+	msg := localizedMessage(userLocale(user), NotificationEventYearInReview)
+	body := fmt.Sprintf(msg.Body, cropsGrown, totalKg)
+	for _, top := range summaries {
+		if top.PlotID == 0 || top.TotalKg <= 0 {
+			continue
+		}
+		body += fmt.Sprintf(msg.BodyOne, top.PlotName, top.TotalKg)
+		data["top_plot_id"] = top.PlotID
+		data["top_plot_name"] = top.PlotName
+		break
+	}
+
+	return NotificationEvent{
+		Type:      NotificationEventYearInReview,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Title:     fmt.Sprintf(msg.Title, season),
+		Body:      body,
+		Data:      data,
+		// 再実行しても同じ重複防止キーになるようシーズンの最終日を使用する
+		LocalDate: fmt.Sprintf("%d-12-31", season),
+	}
+}
+
+// GetSeasonSummaries はユーザーのシーズンの区画ごとの記録を取得します（総収穫量の多い順）。
+// シーズンを締める前は空の一覧を返します。
+func (s *Service) GetSeasonSummaries(ctx context.Context, userID uint, season int) ([]model.SeasonSummary, error) {
+	return s.repos.SeasonSummary().GetByUserAndSeason(ctx, userID, season)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Season Rollover Tests - シーズンの締めのテスト
+// =============================================================================
+// テスト対象:
+//   - RunSeasonRollover: 作物のアーカイブ、区画ごとの記録、ふりかえりの通知、再実行
+//   - PreviousSeason: 年明けが最も早いタイムゾーンでの前年
+
+// TestRunSeasonRollover はシーズンの締めのテストです。
+// 期待動作:
+//   - シーズンに植え付けた収穫済み・失敗の作物のみアーカイブする
+//   - 配置履歴・Crop.PlotID・区画なしの順で区画を決めて区画ごとに集計する
+//   - 最も収穫の多かった区画を含むふりかえりを通知する
+//   - 再実行しても記録は1行のままで、ふりかえりを再度通知しない
+func TestRunSeasonRollover(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	handler := NewNotificationEventHandler(svc, mockSender, mockRepos)
+	ctx := context.Background()
+	season := PreviousSeason(time.Now())
+	inSeason := func(month time.Month) time.Time { return time.Date(season, month, 1, 0, 0, 0, 0, time.UTC) }
+
+	user := &model.User{Email: "en@example.com", Timezone: "UTC", Locale: "en", IsActive: true}
+	_ = mockRepos.User().Create(ctx, user)
+	_ = mockRepos.DeviceToken().Create(ctx, &model.DeviceToken{UserID: user.ID, Token: "token", Platform: "ios", IsActive: true})
+	plotA := &model.Plot{UserID: user.ID, Name: "A-1", Width: 2, Height: 2}
+	plotB := &model.Plot{UserID: user.ID, Name: "B-1", Width: 1, Height: 3}
+	_ = mockRepos.Plot().Create(ctx, plotA)
+	_ = mockRepos.Plot().Create(ctx, plotB)
+
+	tomato := &model.Crop{UserID: user.ID, Name: "Tomato", Status: "harvested", PlantedDate: inSeason(4), ExpectedHarvestDate: inSeason(7), User: *user}
+	carrot := &model.Crop{UserID: user.ID, Name: "Carrot", Status: "failed", PlotID: &plotB.ID, PlantedDate: inSeason(3), ExpectedHarvestDate: inSeason(6), User: *user}
+	basil := &model.Crop{UserID: user.ID, Name: "Basil", Status: "harvested", PlantedDate: inSeason(5), ExpectedHarvestDate: inSeason(7), User: *user}
+	growing := &model.Crop{UserID: user.ID, Name: "Leek", Status: "growing", PlantedDate: inSeason(9), ExpectedHarvestDate: inSeason(12), User: *user}
+	nextSeason := &model.Crop{UserID: user.ID, Name: "Radish", Status: "harvested", PlantedDate: inSeason(1).AddDate(1, 0, 0), ExpectedHarvestDate: inSeason(3).AddDate(1, 0, 0), User: *user}
+	for _, crop := range []*model.Crop{tomato, carrot, basil, growing, nextSeason} {
+		_ = mockRepos.Crop().Create(ctx, crop)
+	}
+	_ = mockRepos.PlotAssignment().Create(ctx, &model.PlotAssignment{PlotID: plotA.ID, CropID: tomato.ID, AssignedDate: inSeason(4)})
+	_ = mockRepos.Harvest().Create(ctx, &model.Harvest{CropID: tomato.ID, HarvestDate: inSeason(7), Quantity: 2, QuantityUnit: "kg"})
+	_ = mockRepos.Harvest().Create(ctx, &model.Harvest{CropID: tomato.ID, HarvestDate: inSeason(8), Quantity: 500, QuantityUnit: "g"})
+	_ = mockRepos.Harvest().Create(ctx, &model.Harvest{CropID: basil.ID, HarvestDate: inSeason(7), Quantity: 300, QuantityUnit: "g"})
+
+	// Act
+	result, err := handler.RunSeasonRollover(ctx, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunSeasonRollover failed: %v", err)
+	}
+	if result.Season != season || result.Users != 1 || result.ArchivedCrops != 3 || result.Summaries != 3 || result.Notifications != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	for _, crop := range []*model.Crop{tomato, carrot, basil} {
+		if crop.ArchivedAt == nil {
+			t.Errorf("Expected %s to be archived", crop.Name)
+		}
+	}
+	if growing.ArchivedAt != nil || nextSeason.ArchivedAt != nil {
+		t.Error("Expected growing and next season crops not to be archived")
+	}
+
+	summaries, _ := svc.GetSeasonSummaries(ctx, user.ID, season)
+	if len(summaries) != 3 {
+		t.Fatalf("Expected 3 summaries, got %+v", summaries)
+	}
+	if top := summaries[0]; top.PlotID != plotA.ID || top.TotalKg != 2.5 || top.HarvestCount != 2 || top.KgPerM2 != 0.63 || top.CropsHarvested != 1 {
+		t.Errorf("Unexpected plot A summary: %+v", top)
+	}
+	if unassigned := summaries[1]; unassigned.PlotID != 0 || unassigned.TotalKg != 0.3 || unassigned.PlotName != "" {
+		t.Errorf("Unexpected unassigned summary: %+v", unassigned)
+	}
+	if failed := summaries[2]; failed.PlotID != plotB.ID || failed.CropsFailed != 1 || failed.TotalKg != 0 {
+		t.Errorf("Unexpected plot B summary: %+v", failed)
+	}
+
+	if len(mockSender.SentPushNotifications) != 1 {
+		t.Fatalf("Expected 1 push notification, got %+v", mockSender.SentPushNotifications)
+	}
+	push := mockSender.SentPushNotifications[0]
+	if !strings.Contains(push.Title, "year in the garden") || !strings.Contains(push.Body, "3 crops") || !strings.Contains(push.Body, "A-1 (2.5 kg)") {
+		t.Errorf("Unexpected year in review notification: %+v", push)
+	}
+
+	// 再実行しても記録は更新のみで、ふりかえりを再度通知しない
+	again, err := handler.RunSeasonRollover(ctx, season)
+	if err != nil {
+		t.Fatalf("RunSeasonRollover (again) failed: %v", err)
+	}
+	if again.ArchivedCrops != 0 || again.Summaries != 3 || again.Notifications != 0 || len(mockSender.SentPushNotifications) != 1 {
+		t.Errorf("Expected rerun not to notify again, got %+v", again)
+	}
+	if stored := mockRepos.GetMockSeasonSummaryRepository().Summaries; len(stored) != 3 {
+		t.Errorf("Expected summaries to be updated in place, got %d rows", len(stored))
+	}
+}
+
+// TestRunSeasonRollover_Errors はシーズンの締めの失敗のテストです。
+// 期待動作:
+//   - 終わっていないシーズンは ErrSeasonNotFinished
+//   - 記録の保存に失敗したユーザーの作物はアーカイブしない
+func TestRunSeasonRollover_Errors(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	handler := NewNotificationEventHandler(svc, NewMockNotificationSender(), mockRepos)
+	ctx := context.Background()
+	season := PreviousSeason(time.Now())
+
+	user := &model.User{Email: "ja@example.com", Timezone: "UTC", Locale: "ja", IsActive: true}
+	_ = mockRepos.User().Create(ctx, user)
+	crop := &model.Crop{UserID: user.ID, Name: "トマト", Status: "harvested", PlantedDate: time.Date(season, 4, 1, 0, 0, 0, 0, time.UTC), User: *user}
+	_ = mockRepos.Crop().Create(ctx, crop)
+	mockRepos.GetMockSeasonSummaryRepository().UpsertErr = errors.New("database error")
+
+	// Act
+	_, unfinishedErr := handler.RunSeasonRollover(ctx, season+1)
+	result, err := handler.RunSeasonRollover(ctx, season)
+
+	// Assert
+	if !errors.Is(unfinishedErr, ErrSeasonNotFinished) {
+		t.Errorf("Expected ErrSeasonNotFinished, got %v", unfinishedErr)
+	}
+	if err != nil {
+		t.Fatalf("RunSeasonRollover failed: %v", err)
+	}
+	if result.Users != 0 || len(result.Errors) != 1 || crop.ArchivedAt != nil {
+		t.Errorf("Expected failed user not to be archived, got %+v", result)
+	}
+}
+
+// TestPreviousSeason は締めるシーズンの判定のテストです。
+// 期待動作:
+//   - 年明けが最も早いタイムゾーン（UTC+14）で年が明けていれば前年を返す
+func TestPreviousSeason(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 2025},
+		{time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC), 2025}, // 日本時間の1月1日6時
+		{time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC), 2024},
+	}
+	for _, tt := range tests {
+		if got := PreviousSeason(tt.now); got != tt.want {
+			t.Errorf("PreviousSeason(%v) = %d, want %d", tt.now, got, tt.want)
+		}
+	}
+}
@@ -5,14 +5,21 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/csv"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/dto"
+	"github.com/secure-scorecard/backend/internal/logging"
 	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/realtime"
 	"github.com/secure-scorecard/backend/internal/repository"
 )
 
@@ -23,6 +30,8 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	// ErrAccountLocked is returned when account is temporarily locked
 	ErrAccountLocked = errors.New("account is locked")
+	// ErrVersionConflict is returned when a task, crop or plot was updated by another request after it was loaded
+	ErrVersionConflict = repository.ErrVersionConflict
 )
 
 const (
@@ -34,7 +43,25 @@ const (
 
 // Service provides business logic
 type Service struct {
-	repos repository.Repositories
+	repos             repository.Repositories
+	sender            NotificationSender // 電話番号の認証コード送信用（未設定の場合はSMSを送信できない）
+	pushRateLimit     PushRateLimit      // ユーザーごとのプッシュ通知の送信数の上限（未設定の場合は無制限）
+	background        BackgroundRunner   // メール送信などを実行するワーカープール（未設定の場合は呼び出し元で実行）
+	reminderWorkers   int                // リマインダージョブを並行して処理する数（未設定の場合は1件ずつ）
+	schedulerPageSize int                // 定期通知で1ページに処理するユーザー数（未設定の場合は SchedulerUserPageSize）
+	jobQueue          JobQueue           // エクスポート生成などの重い処理のジョブキュー（未設定の場合は非同期処理を受け付けない）
+	exportStorage     ExportStorage      // 非同期エクスポートの保存先（S3）
+	backupSource      DatabaseBackupSource // バックアップするデータベース（未設定の場合はバックアップを作成できない）
+	backupStorage     BackupStorage      // データベースのバックアップの保存先（S3）
+	photoStorage      PhotoStorage       // 写真の元の画像・バリアントの保存先（S3、未設定の場合は写真を登録できない）
+	attachmentStorage AttachmentStorage  // 作物・区画の添付ファイルの保存先（未設定の場合は添付ファイルを登録できない）
+	retention         RetentionPolicy    // データの保持期間（未設定の場合はデフォルト）
+	events            EventBus           // 記録の変更のイベントの配信先（未設定の場合は配信しない）
+	rooms             RoomHub            // 共有の庭のルームへのリアルタイム配信（未設定の場合は配信しない）
+	orgQuotas         OrganizationQuotas // 作成した組織の上限のデフォルト（未設定の場合は無制限）
+	storageQuota      int64              // ユーザーごとの保存容量の上限（バイト、未設定の場合は無制限）
+	exportPartSize    int64              // 写真を含むエクスポートのZIPの1ファイルの上限（バイト、未設定の場合は DefaultExportPartSizeBytes）
+	clock             clock.Clock        // 現在時刻（未設定の場合はシステムの時刻、テストでは clock.Fake）
 }
 
 // NewService creates a new Service instance
@@ -42,6 +69,23 @@ func NewService(repos repository.Repositories) *Service {
 	return &Service{repos: repos}
 }
 
+// SetNotificationSender は認証コードのSMS送信などに使用する通知送信者を設定します。
+// 通知送信者の初期化はサービスより後に行われるため、main から設定します。
+func (s *Service) SetNotificationSender(sender NotificationSender) {
+	s.sender = sender
+}
+
+// SetClock は期限・日付の境界の判定や記録の日時に使用する現在時刻を設定します。
+// 通知イベントハンドラーもサービスの Clock を使用します。
+func (s *Service) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// now は現在時刻を返します（time.Now の代わりに使用する）。
+func (s *Service) now() time.Time {
+	return clock.Now(s.clock)
+}
+
 // --- User Service Methods ---
 
 // CreateUser creates a new user
@@ -86,8 +130,21 @@ func (s *Service) GetOrCreateUser(ctx context.Context, firebaseUID, email, displ
 		result = newUser
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return result, err
+	// 登録完了メール（送信手段が未設定・送信失敗でも登録は成功とする）
+	if s.sender != nil {
+		s.runInBackground(ctx, "welcome_email", func(jobCtx context.Context) error {
+			if mailErr := s.SendTransactionalEmail(jobCtx, TransactionalEmailWelcome, result, nil); mailErr != nil {
+				logging.FromContext(jobCtx).Warn("Failed to send welcome email", "recipient_id", result.ID, "error", mailErr)
+			}
+			return nil
+		})
+	}
+
+	return result, nil
 }
 
 // generateLocalUserID generates a unique ID for local (non-Firebase) users
@@ -134,8 +191,21 @@ func (s *Service) RegisterUser(ctx context.Context, email, hashedPassword, displ
 		result = newUser
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return result, err
+	// 登録完了メール（送信手段が未設定・送信失敗でも登録は成功とする）
+	if s.sender != nil {
+		s.runInBackground(ctx, "welcome_email", func(jobCtx context.Context) error {
+			if mailErr := s.SendTransactionalEmail(jobCtx, TransactionalEmailWelcome, result, nil); mailErr != nil {
+				logging.FromContext(jobCtx).Warn("Failed to send welcome email", "recipient_id", result.ID, "error", mailErr)
+			}
+			return nil
+		})
+	}
+
+	return result, nil
 }
 
 // GetUserByEmail retrieves a user by email
@@ -147,7 +217,7 @@ func (s *Service) GetUserByEmail(ctx context.Context, email string) (*model.User
 func (s *Service) IncrementFailedLogin(ctx context.Context, user *model.User) error {
 	user.FailedLoginCount++
 	if user.FailedLoginCount >= MaxFailedLoginAttempts {
-		lockUntil := time.Now().Add(AccountLockDuration)
+		lockUntil := s.now().Add(AccountLockDuration)
 		user.LockedUntil = &lockUntil
 	}
 	return s.repos.User().Update(ctx, user)
@@ -165,7 +235,7 @@ func (s *Service) IsAccountLocked(user *model.User) bool {
 	if user.LockedUntil == nil {
 		return false
 	}
-	return time.Now().Before(*user.LockedUntil)
+	return s.now().Before(*user.LockedUntil)
 }
 
 // --- Garden Service Methods ---
@@ -267,6 +337,7 @@ func (s *Service) CleanupExpiredTokens(ctx context.Context) error {
 }
 
 // CreateTask は新しいタスクを作成します。
+// 所有者の庭のルームにタスクの作成（task.created）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -275,7 +346,11 @@ func (s *Service) CleanupExpiredTokens(ctx context.Context) error {
 // 戻り値:
 //   - error: 作成に失敗した場合のエラー
 func (s *Service) CreateTask(ctx context.Context, task *model.Task) error {
-	return s.repos.Task().Create(ctx, task)
+	if err := s.repos.Task().Create(ctx, task); err != nil {
+		return err
+	}
+	s.broadcastTask(ctx, realtime.TypeTaskCreated, task)
+	return nil
 }
 
 // GetTaskByID はIDでタスクを取得します。
@@ -326,7 +401,7 @@ func (s *Service) GetUserTasksByStatus(ctx context.Context, userID uint, status
 
 // GetTodayTasks は今日が期限のタスクを取得します。
 // ダッシュボードの「今日のタスク」表示に使用されます。
-// 優先度降順、期限日昇順でソートされます。
+// 「今日」はユーザーのタイムゾーンで判定し、優先度降順、期限日昇順でソートされます。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -336,11 +411,17 @@ func (s *Service) GetUserTasksByStatus(ctx context.Context, userID uint, status
 //   - []model.Task: 今日が期限の未完了タスク
 //   - error: 取得に失敗した場合のエラー
 func (s *Service) GetTodayTasks(ctx context.Context, userID uint) ([]model.Task, error) {
-	return s.repos.Task().GetTodayTasks(ctx, userID)
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dayStart, dayEnd := userDayBounds(user, s.now())
+	return s.repos.Task().GetTodayTasks(ctx, userID, dayStart, dayEnd)
 }
 
 // GetOverdueTasks は期限切れのタスクを取得します。
 // ダッシュボードの「期限切れ」アラート表示に使用されます。
+// ユーザーのタイムゾーンで今日より前が期限のタスクを期限切れとします。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -350,24 +431,35 @@ func (s *Service) GetTodayTasks(ctx context.Context, userID uint) ([]model.Task,
 //   - []model.Task: 期限が過ぎた未完了タスク
 //   - error: 取得に失敗した場合のエラー
 func (s *Service) GetOverdueTasks(ctx context.Context, userID uint) ([]model.Task, error) {
-	return s.repos.Task().GetOverdueTasks(ctx, userID)
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dayStart, _ := userDayBounds(user, s.now())
+	return s.repos.Task().GetOverdueTasks(ctx, userID, dayStart)
 }
 
 // UpdateTask はタスクを更新します。
+// 所有者の庭のルームにタスクの更新（task.updated）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
-//   - task: 更新するタスク（IDは必須）
+//   - task: 更新するタスク（IDは必須、Version は読み込んだ時点の値）
 //
 // 戻り値:
-//   - error: 更新に失敗した場合のエラー
+//   - error: 読み込んだ後に他のリクエストが更新していた場合は ErrVersionConflict、更新に失敗した場合のエラー
 func (s *Service) UpdateTask(ctx context.Context, task *model.Task) error {
-	return s.repos.Task().Update(ctx, task)
+	if err := s.repos.Task().Update(ctx, task); err != nil {
+		return err
+	}
+	s.broadcastTask(ctx, realtime.TypeTaskUpdated, task)
+	return nil
 }
 
 // CompleteTask はタスクを完了としてマークします。
 // Status を "completed" に、CompletedAt を現在時刻に設定します。
 // 繰り返し設定がある場合、次回タスクを自動生成します。
+// コミット後にタスクの完了のイベント（task.completed）を配信し、所有者の庭のルームにも配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -389,7 +481,7 @@ func (s *Service) CompleteTask(ctx context.Context, taskID uint) error {
 		}
 
 		// 完了状態に更新
-		now := time.Now()
+		now := s.now()
 		task.Status = "completed"
 		task.CompletedAt = &now
 		task.OccurrenceCount++
@@ -397,6 +489,8 @@ func (s *Service) CompleteTask(ctx context.Context, taskID uint) error {
 		if err := s.repos.Task().Update(txCtx, task); err != nil {
 			return err
 		}
+		s.publishTaskCompleted(txCtx, task)
+		s.broadcastTask(txCtx, realtime.TypeTaskCompleted, task)
 
 		// 繰り返しタスクの場合、次回タスクを生成
 		if task.Recurrence != "" {
@@ -458,7 +552,8 @@ func (s *Service) generateNextRecurringTask(ctx context.Context, completedTask *
 		ParentTaskID:       &parentID,
 	}
 
-	return s.repos.Task().Create(ctx, newTask)
+	// 次回タスクも作成として配信する（task.created）
+	return s.CreateTask(ctx, newTask)
 }
 
 // calculateNextDueDate は次回の期限日を計算します。
@@ -490,6 +585,7 @@ func (s *Service) calculateNextDueDate(currentDueDate time.Time, recurrence stri
 
 // DeleteTask はタスクを論理削除します。
 // GORMのソフトデリートにより、DeletedAtが設定されます。
+// オフライン同期（GET /sync）で削除を返すため、削除の記録も作成します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -498,7 +594,19 @@ func (s *Service) calculateNextDueDate(currentDueDate time.Time, recurrence stri
 // 戻り値:
 //   - error: 削除に失敗した場合のエラー
 func (s *Service) DeleteTask(ctx context.Context, id uint) error {
-	return s.repos.Task().Delete(ctx, id)
+	return s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		// 削除の記録の所有者を取得（削除済みの場合は何もしない）
+		task, err := s.repos.Task().GetByID(txCtx, id)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+
+		if err := s.repos.Task().Delete(txCtx, id); err != nil {
+			return err
+		}
+		s.broadcastDeleted(txCtx, task.UserID, realtime.TypeTaskDeleted, SyncEntityTasks, id)
+		return s.recordTombstones(txCtx, task.UserID, SyncEntityTasks, id)
+	})
 }
 
 // CreateCrop は新しい作物を登録します。
@@ -508,9 +616,16 @@ func (s *Service) DeleteTask(ctx context.Context, id uint) error {
 //   - crop: 作成する作物（UserID, Name, PlantedDate, ExpectedHarvestDateは必須）
 //
 // 戻り値:
-//   - error: 作成に失敗した場合のエラー
+//   - error: 組織の作物数が上限の場合は ErrOrganizationQuotaExceeded、作成に失敗した場合のエラー
 func (s *Service) CreateCrop(ctx context.Context, crop *model.Crop) error {
-	return s.repos.Crop().Create(ctx, crop)
+	if err := s.checkOrganizationQuota(ctx, organizationQuotaCrops); err != nil {
+		return err
+	}
+	if err := s.repos.Crop().Create(ctx, crop); err != nil {
+		return err
+	}
+	s.invalidateAnalyticsAfterWrite(ctx, crop.UserID, crop.OrganizationID)
+	return nil
 }
 
 // GetCropByID はIDで作物を取得します。
@@ -540,6 +655,38 @@ func (s *Service) GetUserCrops(ctx context.Context, userID uint) ([]model.Crop,
 	return s.repos.Crop().GetByUserID(ctx, userID)
 }
 
+// GetUserCropsByIDs は指定したIDのユーザーの作物を1回のクエリで取得します（GraphQL の DataLoader 用）。
+// 組織のスコープでは組織の作物、それ以外ではユーザーの作物のみを返し、見つからないIDは結果に含めません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - ids: 作物ID（重複を含んでもよい）
+//
+// 戻り値:
+//   - []model.Crop: 作物の一覧（ids の順序）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserCropsByIDs(ctx context.Context, userID uint, ids []uint) ([]model.Crop, error) {
+	byID, err := s.cropsByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return ownedInKeyOrder(ctx, userID, ids, byID, func(c *model.Crop) uint { return c.UserID }), nil
+}
+
+// userCropIDs は ids のうちユーザーが参照できる作物のIDを返します（子の記録をまとめて取得する前の所有者の確認）。
+func (s *Service) userCropIDs(ctx context.Context, userID uint, ids []uint) ([]uint, error) {
+	crops, err := s.GetUserCropsByIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	owned := make([]uint, len(crops))
+	for i, crop := range crops {
+		owned[i] = crop.ID
+	}
+	return owned, nil
+}
+
 // GetUserCropsByStatus はステータスでフィルタリングした作物を取得します。
 //
 // 有効なステータス:
@@ -565,16 +712,23 @@ func (s *Service) GetUserCropsByStatus(ctx context.Context, userID uint, status
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
-//   - crop: 更新する作物（IDは必須）
+//   - crop: 更新する作物（IDは必須、Version は読み込んだ時点の値）
 //
 // 戻り値:
-//   - error: 更新に失敗した場合のエラー
+//   - error: 読み込んだ後に他のリクエストが更新していた場合は ErrVersionConflict、更新に失敗した場合のエラー
 func (s *Service) UpdateCrop(ctx context.Context, crop *model.Crop) error {
-	return s.repos.Crop().Update(ctx, crop)
+	// 収穫可能でなくなった場合は、再び収穫可能になったときに通知できるようにする
+	resetHarvestReadyNotification(crop, s.now())
+	if err := s.repos.Crop().Update(ctx, crop); err != nil {
+		return err
+	}
+	s.invalidateAnalyticsAfterWrite(ctx, crop.UserID, crop.OrganizationID)
+	return nil
 }
 
 // DeleteCrop は作物と関連する成長記録・収穫記録を削除します（トランザクション使用）。
 // N+1問題を避けるため、バッチ削除を使用します。
+// オフライン同期（GET /sync）で削除を返すため、作物と収穫記録の削除の記録も作成します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -584,6 +738,16 @@ func (s *Service) UpdateCrop(ctx context.Context, crop *model.Crop) error {
 //   - error: 削除に失敗した場合のエラー
 func (s *Service) DeleteCrop(ctx context.Context, id uint) error {
 	return s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		// 削除の記録の所有者と収穫記録を取得（削除済みの場合は何もしない）
+		crop, err := s.repos.Crop().GetByID(txCtx, id)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		harvests, err := s.repos.Harvest().GetByCropID(txCtx, id)
+		if err != nil {
+			return err
+		}
+
 		// 関連する成長記録を一括削除
 		if err := s.repos.GrowthRecord().DeleteByCropID(txCtx, id); err != nil {
 			return err
@@ -595,7 +759,21 @@ func (s *Service) DeleteCrop(ctx context.Context, id uint) error {
 		}
 
 		// 作物を削除
-		return s.repos.Crop().Delete(txCtx, id)
+		if err := s.repos.Crop().Delete(txCtx, id); err != nil {
+			return err
+		}
+
+		harvestIDs := make([]uint, len(harvests))
+		for i, harvest := range harvests {
+			harvestIDs[i] = harvest.ID
+		}
+		if err := s.recordTombstones(txCtx, crop.UserID, SyncEntityHarvests, harvestIDs...); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, crop.UserID, crop.OrganizationID); err != nil {
+			return err
+		}
+		return s.recordTombstones(txCtx, crop.UserID, SyncEntityCrops, id)
 	})
 }
 
@@ -630,12 +808,33 @@ func (s *Service) GetCropGrowthRecords(ctx context.Context, cropID uint) ([]mode
 	return s.repos.GrowthRecord().GetByCropID(ctx, cropID)
 }
 
+// GetUserGrowthRecordsByCropIDs はユーザーの複数作物の成長記録をまとめて取得します。
+// GraphQLのDataLoaderからN+1クエリを避けるために使用されます。
+// ユーザーが参照できない作物（他のユーザー・組織のスコープ外）のIDは無視します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - cropIDs: 作物IDの一覧
+//
+// 戻り値:
+//   - []model.GrowthRecord: 成長記録の一覧
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserGrowthRecordsByCropIDs(ctx context.Context, userID uint, cropIDs []uint) ([]model.GrowthRecord, error) {
+	owned, err := s.userCropIDs(ctx, userID, cropIDs)
+	if err != nil || len(owned) == 0 {
+		return nil, err
+	}
+	return s.repos.GrowthRecord().GetByCropIDs(ctx, owned)
+}
+
 // DeleteGrowthRecord は成長記録を削除します。
 func (s *Service) DeleteGrowthRecord(ctx context.Context, id uint) error {
 	return s.repos.GrowthRecord().Delete(ctx, id)
 }
 
 // CreateHarvest は新しい収穫記録を作成します。
+// イベントバスを設定している場合は、収穫記録の追加のイベント（harvest.added）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -644,7 +843,17 @@ func (s *Service) DeleteGrowthRecord(ctx context.Context, id uint) error {
 // 戻り値:
 //   - error: 作成に失敗した場合のエラー
 func (s *Service) CreateHarvest(ctx context.Context, harvest *model.Harvest) error {
-	return s.repos.Harvest().Create(ctx, harvest)
+	if err := s.repos.Harvest().Create(ctx, harvest); err != nil {
+		return err
+	}
+	if crop, err := s.repos.Crop().GetByID(ctx, harvest.CropID); err == nil {
+		s.invalidateAnalyticsAfterWrite(ctx, crop.UserID, crop.OrganizationID)
+	}
+	if err := s.publishHarvestAdded(ctx, harvest); err != nil {
+		// 収穫記録は作成済みのため、イベントの配信の失敗はエラーにしない
+		logging.FromContext(ctx).Warn("Failed to publish harvest event", "harvest_id", harvest.ID, "error", err)
+	}
+	return nil
 }
 
 // GetHarvestByID はIDで収穫記録を取得します。
@@ -666,21 +875,68 @@ func (s *Service) GetCropHarvests(ctx context.Context, cropID uint) ([]model.Har
 	return s.repos.Harvest().GetByCropID(ctx, cropID)
 }
 
-// DeleteHarvest は収穫記録を削除します。
+// GetUserHarvestsByCropIDs はユーザーの複数作物の収穫記録をまとめて取得します。
+// GraphQLのDataLoaderからN+1クエリを避けるために使用されます。
+// ユーザーが参照できない作物（他のユーザー・組織のスコープ外）のIDは無視します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - cropIDs: 作物IDの一覧
+//
+// 戻り値:
+//   - []model.Harvest: 収穫記録の一覧
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserHarvestsByCropIDs(ctx context.Context, userID uint, cropIDs []uint) ([]model.Harvest, error) {
+	owned, err := s.userCropIDs(ctx, userID, cropIDs)
+	if err != nil || len(owned) == 0 {
+		return nil, err
+	}
+	return s.repos.Harvest().GetByCropIDs(ctx, owned)
+}
+
+// DeleteHarvest は収穫記録を削除します（オフライン同期用の削除の記録も作成）。
 func (s *Service) DeleteHarvest(ctx context.Context, id uint) error {
-	return s.repos.Harvest().Delete(ctx, id)
+	return s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		// 削除の記録の所有者（作物のユーザー）を取得（削除済みの場合は何もしない）
+		harvest, err := s.repos.Harvest().GetByID(txCtx, id)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		crop, err := s.repos.Crop().GetByID(txCtx, harvest.CropID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repos.Harvest().Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, crop.UserID, crop.OrganizationID); err != nil {
+			return err
+		}
+		return s.recordTombstones(txCtx, crop.UserID, SyncEntityHarvests, id)
+	})
 }
 
 // CreatePlot は新しい区画を作成します。
+// 所有者の庭のルームに区画の作成（plot.created）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
 //   - plot: 作成する区画（UserID, Name, Width, Heightは必須）
 //
 // 戻り値:
-//   - error: 作成に失敗した場合のエラー
+//   - error: 組織の区画数が上限の場合は ErrOrganizationQuotaExceeded、作成に失敗した場合のエラー
 func (s *Service) CreatePlot(ctx context.Context, plot *model.Plot) error {
-	return s.repos.Plot().Create(ctx, plot)
+	if err := s.checkOrganizationQuota(ctx, organizationQuotaPlots); err != nil {
+		return err
+	}
+	if err := s.repos.Plot().Create(ctx, plot); err != nil {
+		return err
+	}
+	s.invalidateAnalyticsAfterWrite(ctx, plot.UserID, plot.OrganizationID)
+	s.broadcastPlotLayout(ctx, realtime.TypePlotCreated, plot)
+	return nil
 }
 
 // GetPlotByID はIDで区画を取得します。
@@ -709,6 +965,75 @@ func (s *Service) GetUserPlots(ctx context.Context, userID uint) ([]model.Plot,
 	return s.repos.Plot().GetByUserID(ctx, userID)
 }
 
+// GetUserPlotsByIDs は指定したIDのユーザーの区画を1回のクエリで取得します（GraphQL の DataLoader 用）。
+// 組織のスコープでは組織の区画、それ以外ではユーザーの区画のみを返し、見つからないIDは結果に含めません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - ids: 区画ID（重複を含んでもよい）
+//
+// 戻り値:
+//   - []model.Plot: 区画の一覧（ids の順序）
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserPlotsByIDs(ctx context.Context, userID uint, ids []uint) ([]model.Plot, error) {
+	plots, err := s.repos.Plot().GetByIDs(ctx, slices.Compact(slices.Sorted(slices.Values(ids))))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Plot, len(plots))
+	for i := range plots {
+		byID[plots[i].ID] = &plots[i]
+	}
+	return ownedInKeyOrder(ctx, userID, ids, byID, func(p *model.Plot) uint { return p.UserID }), nil
+}
+
+// GetUserActiveCropsByPlotIDs は指定したIDのユーザーの区画に現在配置している作物をまとめて取得します（GraphQL の DataLoader 用）。
+// 区画・配置・作物をそれぞれ指定したIDの1回のクエリで取得し、ユーザーの全区画のレイアウトは取得しません。
+// ユーザーが参照できない区画、配置のない区画、見つからない作物（削除済み）はマップに含めません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - plotIDs: 区画ID（重複を含んでもよい）
+//
+// 戻り値:
+//   - map[uint]*model.Crop: 区画IDをキーとした配置中の作物
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) GetUserActiveCropsByPlotIDs(ctx context.Context, userID uint, plotIDs []uint) (map[uint]*model.Crop, error) {
+	plots, err := s.GetUserPlotsByIDs(ctx, userID, plotIDs)
+	if err != nil || len(plots) == 0 {
+		return nil, err
+	}
+	owned := make([]uint, len(plots))
+	for i, plot := range plots {
+		owned[i] = plot.ID
+	}
+	assignments, err := s.repos.PlotAssignment().GetActiveByPlotIDs(ctx, owned)
+	if err != nil || len(assignments) == 0 {
+		return nil, err
+	}
+	cropIDs := make([]uint, len(assignments))
+	for i, assignment := range assignments {
+		cropIDs[i] = assignment.CropID
+	}
+	crops, err := s.GetUserCropsByIDs(ctx, userID, cropIDs)
+	if err != nil {
+		return nil, err
+	}
+	cropByID := make(map[uint]*model.Crop, len(crops))
+	for i := range crops {
+		cropByID[crops[i].ID] = &crops[i]
+	}
+	result := make(map[uint]*model.Crop, len(assignments))
+	for _, assignment := range assignments {
+		if crop, ok := cropByID[assignment.CropID]; ok {
+			result[assignment.PlotID] = crop
+		}
+	}
+	return result, nil
+}
+
 // GetUserPlotsByStatus はステータスでフィルタリングした区画を取得します。
 //
 // 有効なステータス:
@@ -728,19 +1053,26 @@ func (s *Service) GetUserPlotsByStatus(ctx context.Context, userID uint, status
 }
 
 // UpdatePlot は区画を更新します。
+// 所有者の庭のルームに区画の更新（plot.updated）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
-//   - plot: 更新する区画（IDは必須）
+//   - plot: 更新する区画（IDは必須、Version は読み込んだ時点の値）
 //
 // 戻り値:
-//   - error: 更新に失敗した場合のエラー
+//   - error: 読み込んだ後に他のリクエストが更新していた場合は ErrVersionConflict、更新に失敗した場合のエラー
 func (s *Service) UpdatePlot(ctx context.Context, plot *model.Plot) error {
-	return s.repos.Plot().Update(ctx, plot)
+	if err := s.repos.Plot().Update(ctx, plot); err != nil {
+		return err
+	}
+	s.invalidateAnalyticsAfterWrite(ctx, plot.UserID, plot.OrganizationID)
+	s.broadcastPlotLayout(ctx, realtime.TypePlotUpdated, plot)
+	return nil
 }
 
 // DeletePlot は区画と関連する配置履歴を削除します（トランザクション使用）。
 // N+1問題を避けるため、バッチ削除を使用します。
+// オフライン同期（GET /sync）で削除を返すため、削除の記録も作成します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -750,18 +1082,34 @@ func (s *Service) UpdatePlot(ctx context.Context, plot *model.Plot) error {
 //   - error: 削除に失敗した場合のエラー
 func (s *Service) DeletePlot(ctx context.Context, id uint) error {
 	return s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		// 削除の記録の所有者を取得（削除済みの場合は何もしない）
+		plot, err := s.repos.Plot().GetByID(txCtx, id)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+
 		// 関連する配置履歴を一括削除
 		if err := s.repos.PlotAssignment().DeleteByPlotID(txCtx, id); err != nil {
 			return err
 		}
 
 		// 区画を削除
-		return s.repos.Plot().Delete(txCtx, id)
+		if err := s.repos.Plot().Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, plot.UserID, plot.OrganizationID); err != nil {
+			return err
+		}
+		s.broadcastDeleted(txCtx, plot.UserID, realtime.TypePlotDeleted, SyncEntityPlots, id)
+		return s.recordTombstones(txCtx, plot.UserID, SyncEntityPlots, id)
 	})
 }
 
 // AssignCropToPlot は作物を区画に配置します。
 // 既存のアクティブな配置がある場合は、まずそれを解除します。
+// 同時の配置でアクティブな配置が重複しないよう SERIALIZABLE のトランザクションで実行します
+// （競合した場合は WithTransaction が実行し直します）。
+// 所有者の庭のルームに区画のレイアウトの更新（plot.updated）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -775,11 +1123,12 @@ func (s *Service) DeletePlot(ctx context.Context, id uint) error {
 func (s *Service) AssignCropToPlot(ctx context.Context, plotID, cropID uint, assignedDate time.Time) (*model.PlotAssignment, error) {
 	var result *model.PlotAssignment
 
-	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+	serializableCtx := repository.ContextWithIsolationLevel(ctx, sql.LevelSerializable)
+	err := s.repos.WithTransaction(serializableCtx, func(txCtx context.Context) error {
 		// 既存のアクティブな配置を解除
 		existingAssignment, err := s.repos.PlotAssignment().GetActiveByPlotID(txCtx, plotID)
 		if err == nil && existingAssignment != nil {
-			now := time.Now()
+			now := s.now()
 			existingAssignment.UnassignedDate = &now
 			if err := s.repos.PlotAssignment().Update(txCtx, existingAssignment); err != nil {
 				return err
@@ -806,6 +1155,10 @@ func (s *Service) AssignCropToPlot(ctx context.Context, plotID, cropID uint, ass
 		if err := s.repos.Plot().Update(txCtx, plot); err != nil {
 			return err
 		}
+		if err := s.invalidateAnalytics(txCtx, plot.UserID, plot.OrganizationID); err != nil {
+			return err
+		}
+		s.broadcastPlotLayout(txCtx, realtime.TypePlotUpdated, plot)
 
 		result = assignment
 		return nil
@@ -815,6 +1168,7 @@ func (s *Service) AssignCropToPlot(ctx context.Context, plotID, cropID uint, ass
 }
 
 // UnassignCropFromPlot は区画から作物の配置を解除します。
+// 所有者の庭のルームに区画のレイアウトの更新（plot.updated）を配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -831,7 +1185,7 @@ func (s *Service) UnassignCropFromPlot(ctx context.Context, plotID uint) error {
 		}
 
 		// 配置を解除
-		now := time.Now()
+		now := s.now()
 		assignment.UnassignedDate = &now
 		if err := s.repos.PlotAssignment().Update(txCtx, assignment); err != nil {
 			return err
@@ -843,7 +1197,14 @@ func (s *Service) UnassignCropFromPlot(ctx context.Context, plotID uint) error {
 			return err
 		}
 		plot.Status = "available"
-		return s.repos.Plot().Update(txCtx, plot)
+		if err := s.repos.Plot().Update(txCtx, plot); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, plot.UserID, plot.OrganizationID); err != nil {
+			return err
+		}
+		s.broadcastPlotLayout(txCtx, realtime.TypePlotUpdated, plot)
+		return nil
 	})
 }
 
@@ -895,6 +1256,11 @@ type PlotLayoutItem struct {
 	ActiveCrop       *model.Crop           `json:"active_crop,omitempty"`
 }
 
+// MarshalJSON はレスポンスの形式（dto.PlotLayoutItemResponse）で JSON にします。
+func (i PlotLayoutItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewPlotLayoutItemResponse(&i.Plot, i.ActiveAssignment, i.ActiveCrop))
+}
+
 // GetPlotLayout はユーザーの全区画のレイアウトデータを取得します。
 // グリッド表示用に、区画情報と現在の配置情報を含むデータを返します。
 //
@@ -906,8 +1272,8 @@ type PlotLayoutItem struct {
 //   - []PlotLayoutItem: レイアウトデータの一覧
 //   - error: 取得に失敗した場合のエラー
 func (s *Service) GetPlotLayout(ctx context.Context, userID uint) ([]PlotLayoutItem, error) {
-	// 全区画を取得
-	plots, err := s.repos.Plot().GetByUserID(ctx, userID)
+	// 全区画を現在の配置・作物とともに取得
+	plots, err := s.repos.Plot().GetLayoutByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -915,26 +1281,94 @@ func (s *Service) GetPlotLayout(ctx context.Context, userID uint) ([]PlotLayoutI
 	// レイアウトデータを構築
 	layoutItems := make([]PlotLayoutItem, len(plots))
 	for i, plot := range plots {
-		item := PlotLayoutItem{
-			Plot: plot,
+		item := PlotLayoutItem{Plot: plot}
+		item.Plot.PlotAssignments = nil
+		if len(plot.PlotAssignments) > 0 {
+			assignment := plot.PlotAssignments[0]
+			crop := assignment.Crop
+			assignment.Crop = model.Crop{}
+			item.ActiveAssignment = &assignment
+			if crop.ID != 0 {
+				item.ActiveCrop = &crop
+			}
 		}
+		layoutItems[i] = item
+	}
 
-		// アクティブな配置を取得（エラーは無視 - 配置がない場合も正常）
-		assignment, err := s.repos.PlotAssignment().GetActiveByPlotID(ctx, plot.ID)
-		if err == nil && assignment != nil {
-			item.ActiveAssignment = assignment
+	return layoutItems, nil
+}
 
-			// 配置されている作物を取得
-			crop, err := s.repos.Crop().GetByID(ctx, assignment.CropID)
-			if err == nil {
-				item.ActiveCrop = crop
-			}
+// cropsByID は作物をIDでまとめて取得します（一覧の作物名などの N+1 クエリを避けるため）。
+// 重複したIDは1回だけ取得し、見つからない作物（削除済み・組織のスコープ外）はマップに含めません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - ids: 作物ID（重複を含んでもよい）
+//
+// 戻り値:
+//   - map[uint]*model.Crop: 作物IDをキーとした作物
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) cropsByID(ctx context.Context, ids []uint) (map[uint]*model.Crop, error) {
+	crops, err := s.repos.Crop().GetByIDs(ctx, slices.Compact(slices.Sorted(slices.Values(ids))))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Crop, len(crops))
+	for i := range crops {
+		byID[crops[i].ID] = &crops[i]
+	}
+	return byID, nil
+}
+
+// ownedInKeyOrder は ids の順序で、ユーザーが参照できる記録（組織のスコープでは組織の記録）を返します。
+// GetByIDs は組織のスコープでのみ絞り込むため、個人のスコープ・スコープなしでは所有者で絞り込みます。
+// 重複したIDは1回だけ含めます。
+func ownedInKeyOrder[T any](ctx context.Context, userID uint, ids []uint, byID map[uint]*T, owner func(*T) uint) []T {
+	scoped := false
+	if organizationID, ok := repository.OrganizationFromContext(ctx); ok && organizationID != 0 {
+		scoped = true
+	}
+	result := make([]T, 0, len(byID))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		record, ok := byID[id]
+		if !ok || seen[id] || (!scoped && owner(record) != userID) {
+			continue
 		}
+		seen[id] = true
+		result = append(result, *record)
+	}
+	return result
+}
 
-		layoutItems[i] = item
+// harvestCropIDs は収穫記録の作物IDを返します（cropsByID で作物をまとめて取得するため）。
+func harvestCropIDs(harvests []model.Harvest) []uint {
+	ids := make([]uint, len(harvests))
+	for i, harvest := range harvests {
+		ids[i] = harvest.CropID
 	}
+	return ids
+}
 
-	return layoutItems, nil
+// plotLayoutItem は区画と現在の配置情報からレイアウトデータを構築します。
+func (s *Service) plotLayoutItem(ctx context.Context, plot model.Plot) PlotLayoutItem {
+	item := PlotLayoutItem{
+		Plot: plot,
+	}
+
+	// アクティブな配置を取得（エラーは無視 - 配置がない場合も正常）
+	assignment, err := s.repos.PlotAssignment().GetActiveByPlotID(ctx, plot.ID)
+	if err == nil && assignment != nil {
+		item.ActiveAssignment = assignment
+
+		// 配置されている作物を取得
+		crop, err := s.repos.Crop().GetByID(ctx, assignment.CropID)
+		if err == nil {
+			item.ActiveCrop = crop
+		}
+	}
+
+	return item
 }
 
 // PlotHistoryItem は区画履歴表示用のデータです。
@@ -944,6 +1378,11 @@ type PlotHistoryItem struct {
 	Crop       *model.Crop          `json:"crop,omitempty"`
 }
 
+// MarshalJSON はレスポンスの形式（dto.PlotHistoryItemResponse）で JSON にします。
+func (i PlotHistoryItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewPlotHistoryItemResponse(&i.Assignment, i.Crop))
+}
+
 // GetPlotHistory は区画の栽培履歴を取得します。
 // 過去に配置された作物の履歴を返します。
 //
@@ -961,20 +1400,23 @@ func (s *Service) GetPlotHistory(ctx context.Context, plotID uint) ([]PlotHistor
 		return nil, err
 	}
 
+	// 作物情報をまとめて取得
+	cropIDs := make([]uint, len(assignments))
+	for i, assignment := range assignments {
+		cropIDs[i] = assignment.CropID
+	}
+	crops, err := s.cropsByID(ctx, cropIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// 履歴データを構築
 	historyItems := make([]PlotHistoryItem, len(assignments))
 	for i, assignment := range assignments {
-		item := PlotHistoryItem{
+		historyItems[i] = PlotHistoryItem{
 			Assignment: assignment,
+			Crop:       crops[assignment.CropID],
 		}
-
-		// 作物情報を取得
-		crop, err := s.repos.Crop().GetByID(ctx, assignment.CropID)
-		if err == nil {
-			item.Crop = crop
-		}
-
-		historyItems[i] = item
 	}
 
 	return historyItems, nil
@@ -1025,9 +1467,6 @@ func (s *Service) GetHarvestSummary(ctx context.Context, userID uint, filter Har
 		return nil, err
 	}
 
-	// 作物情報を取得するためのマップ
-	cropCache := make(map[uint]*model.Crop)
-
 	// 作物IDでフィルタ
 	if filter.CropID != nil {
 		var filtered []model.Harvest
@@ -1039,19 +1478,20 @@ func (s *Service) GetHarvestSummary(ctx context.Context, userID uint, filter Har
 		harvests = filtered
 	}
 
+	// 作物情報をまとめて取得
+	crops, err := s.cropsByID(ctx, harvestCropIDs(harvests))
+	if err != nil {
+		return nil, err
+	}
+
 	// 作物ごとに集計
 	cropStats := make(map[uint]*CropHarvestSummary)
 	qualityDist := make(map[string]int)
 
 	for _, harvest := range harvests {
-		// 作物情報をキャッシュから取得または取得
-		crop, ok := cropCache[harvest.CropID]
+		crop, ok := crops[harvest.CropID]
 		if !ok {
-			crop, err = s.repos.Crop().GetByID(ctx, harvest.CropID)
-			if err != nil {
-				continue // 作物が見つからない場合はスキップ
-			}
-			cropCache[harvest.CropID] = crop
+			continue // 作物が見つからない場合はスキップ
 		}
 
 		// 作物ごとの集計を更新
@@ -1094,12 +1534,13 @@ func (s *Service) GetHarvestSummary(ctx context.Context, userID uint, filter Har
 		totalKg += stats.TotalQuantityKg
 	}
 
-	return &HarvestSummary{
+	summary := &HarvestSummary{
 		TotalHarvests:       len(harvests),
 		TotalQuantityKg:     totalKg,
 		CropSummaries:       cropSummaries,
 		QualityDistribution: qualityDist,
-	}, nil
+	}
+	return summary, nil
 }
 
 // convertToKg は指定された単位の数量をkg単位に換算します。
@@ -1165,6 +1606,7 @@ type ChartData struct {
 	Title        string      `json:"title"`
 	Data         interface{} `json:"data"`
 	GeneratedAt  time.Time   `json:"generated_at"`
+	Freshness    *AnalyticsFreshness `json:"freshness,omitempty"` // 集計データ（マテリアライズドビュー）の鮮度、ビューを使用するグラフのみ
 }
 
 // ChartFilter はグラフデータのフィルタ条件を表します。
@@ -1172,6 +1614,7 @@ type ChartFilter struct {
 	StartDate *time.Time `json:"start_date,omitempty"`
 	EndDate   *time.Time `json:"end_date,omitempty"`
 	Year      *int       `json:"year,omitempty"`
+	CropName  string     `json:"crop_name,omitempty"` // ベンチマーク・シーズン比較対象の作物名
 }
 
 // GetChartData は指定された種類のグラフデータを取得します。
@@ -1186,16 +1629,29 @@ type ChartFilter struct {
 //   - *ChartData: グラフデータ
 //   - error: 取得に失敗した場合のエラー
 func (s *Service) GetChartData(ctx context.Context, userID uint, chartType ChartType, filter ChartFilter) (*ChartData, error) {
+	var chart *ChartData
+	var err error
+
 	switch chartType {
 	case ChartTypeMonthlyHarvest:
-		return s.getMonthlyHarvestChart(ctx, userID, filter)
+		chart, err = s.getMonthlyHarvestChart(ctx, userID, filter)
 	case ChartTypeCropComparison:
-		return s.getCropComparisonChart(ctx, userID, filter)
+		chart, err = s.getCropComparisonChart(ctx, userID, filter)
 	case ChartTypePlotProductivity:
-		return s.getPlotProductivityChart(ctx, userID, filter)
+		chart, err = s.getPlotProductivityChart(ctx, userID, filter)
+	case ChartTypeCropBenchmark:
+		chart, err = s.getCropBenchmarkChart(ctx, userID, filter)
+	case ChartTypeHarvestHeatmap:
+		chart, err = s.getHarvestHeatmapChart(ctx, userID, filter)
+	case ChartTypeCropSeasons:
+		chart, err = s.getCropSeasonsChart(ctx, userID, filter)
 	default:
 		return nil, fmt.Errorf("unknown chart type: %s", chartType)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return chart, nil
 }
 
 // getMonthlyHarvestChart は月別収穫量グラフデータを生成します。
@@ -1242,7 +1698,7 @@ func (s *Service) getMonthlyHarvestChart(ctx context.Context, userID uint, filte
 		ChartType:   ChartTypeMonthlyHarvest,
 		Title:       "月別収穫量",
 		Data:        result,
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
@@ -1254,22 +1710,20 @@ func (s *Service) getCropComparisonChart(ctx context.Context, userID uint, filte
 		return nil, err
 	}
 
-	// 作物情報キャッシュ
-	cropCache := make(map[uint]*model.Crop)
+	// 作物情報をまとめて取得
+	crops, err := s.cropsByID(ctx, harvestCropIDs(harvests))
+	if err != nil {
+		return nil, err
+	}
 
 	// 作物別に集計
 	cropData := make(map[uint]*CropComparisonData)
 	var totalKg float64
 
 	for _, harvest := range harvests {
-		// 作物情報を取得
-		crop, ok := cropCache[harvest.CropID]
+		crop, ok := crops[harvest.CropID]
 		if !ok {
-			crop, err = s.repos.Crop().GetByID(ctx, harvest.CropID)
-			if err != nil {
-				continue
-			}
-			cropCache[harvest.CropID] = crop
+			continue
 		}
 
 		if _, ok := cropData[harvest.CropID]; !ok {
@@ -1303,7 +1757,7 @@ func (s *Service) getCropComparisonChart(ctx context.Context, userID uint, filte
 		ChartType:   ChartTypeCropComparison,
 		Title:       "作物別収穫量比較",
 		Data:        result,
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
@@ -1386,7 +1840,7 @@ func (s *Service) getPlotProductivityChart(ctx context.Context, userID uint, fil
 		ChartType:   ChartTypePlotProductivity,
 		Title:       "区画生産性",
 		Data:        result,
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
@@ -1400,6 +1854,10 @@ const (
 	ExportDataTypeHarvests ExportDataType = "harvests"
 	// ExportDataTypeTasks はタスクデータのエクスポート
 	ExportDataTypeTasks ExportDataType = "tasks"
+	// ExportDataTypeGrowthRecords は成長記録のエクスポート
+	ExportDataTypeGrowthRecords ExportDataType = "growth_records"
+	// ExportDataTypePlotAssignments は区画配置履歴のエクスポート
+	ExportDataTypePlotAssignments ExportDataType = "plot_assignments"
 	// ExportDataTypeAll は全データのエクスポート
 	ExportDataTypeAll ExportDataType = "all"
 )
@@ -1411,6 +1869,7 @@ type CSVExportResult struct {
 	ContentType string         `json:"content_type"`
 	Data        []byte         `json:"-"` // JSONには含めない
 	RecordCount int            `json:"record_count"`
+	Anonymized  bool           `json:"anonymized"` // 個人情報を除去したエクスポートか
 	GeneratedAt time.Time      `json:"generated_at"`
 }
 
@@ -1425,27 +1884,70 @@ type CSVExportResult struct {
 //   - *CSVExportResult: エクスポート結果（CSVデータを含む）
 //   - error: 生成に失敗した場合のエラー
 func (s *Service) ExportCSV(ctx context.Context, userID uint, dataType ExportDataType) (*CSVExportResult, error) {
-	switch dataType {
-	case ExportDataTypeCrops:
-		return s.exportCropsCSV(ctx, userID)
-	case ExportDataTypeHarvests:
-		return s.exportHarvestsCSV(ctx, userID)
-	case ExportDataTypeTasks:
-		return s.exportTasksCSV(ctx, userID)
-	case ExportDataTypeAll:
-		return s.exportAllCSV(ctx, userID)
-	default:
+	return s.ExportCSVWithOptions(ctx, userID, dataType, ExportOptions{})
+}
+
+// ExportCSVWithOptions はオプションを指定してCSVを生成します。
+// opts.Anonymize が true の場合、個人を特定できる情報を除去します（詳細は ExportOptions を参照）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - dataType: エクスポートするデータ種類
+//   - opts: エクスポートオプション
+//
+// 戻り値:
+//   - *CSVExportResult: エクスポート結果（CSVデータを含む）
+//   - error: 生成に失敗した場合のエラー
+func (s *Service) ExportCSVWithOptions(ctx context.Context, userID uint, dataType ExportDataType, opts ExportOptions) (*CSVExportResult, error) {
+	var anon *exportAnonymizer
+	if opts.Anonymize {
+		var err error
+		if anon, err = s.newExportAnonymizer(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *CSVExportResult
+	var err error
+	switch dataType {
+	case ExportDataTypeCrops:
+		result, err = s.exportCropsCSV(ctx, userID, anon)
+	case ExportDataTypeHarvests:
+		result, err = s.exportHarvestsCSV(ctx, userID, anon)
+	case ExportDataTypeTasks:
+		result, err = s.exportTasksCSV(ctx, userID, anon)
+	case ExportDataTypeGrowthRecords:
+		result, err = s.exportGrowthRecordsCSV(ctx, userID, anon)
+	case ExportDataTypePlotAssignments:
+		result, err = s.exportPlotAssignmentsCSV(ctx, userID, anon)
+	case ExportDataTypeAll:
+		result, err = s.exportAllCSV(ctx, userID, anon)
+	default:
 		return nil, fmt.Errorf("unknown data type: %s", dataType)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if anon != nil {
+		result.Anonymized = true
+		result.FileName = anonymizedFileName(result.FileName)
+	}
+	return result, nil
 }
 
 // exportCropsCSV は作物データをCSV形式でエクスポートします。
-func (s *Service) exportCropsCSV(ctx context.Context, userID uint) (*CSVExportResult, error) {
+func (s *Service) exportCropsCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
 	crops, err := s.repos.Crop().GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	return s.cropsCSV(crops, anon)
+}
 
+// cropsCSV は作物の一覧のCSVを作成します（エクスポートと一覧の Accept: text/csv で共通）。
+func (s *Service) cropsCSV(crops []model.Crop, anon *exportAnonymizer) (*CSVExportResult, error) {
 	// CSVヘッダー
 	var buf bytes.Buffer
 	writer := csv.NewWriter(&buf)
@@ -1462,14 +1964,14 @@ func (s *Service) exportCropsCSV(ctx context.Context, userID uint) (*CSVExportRe
 	// データ行
 	for _, crop := range crops {
 		row := []string{
-			fmt.Sprintf("%d", crop.ID),
-			crop.Name,
-			crop.Variety,
+			anon.id("crop", crop.ID),
+			anon.text(crop.Name),
+			anon.text(crop.Variety),
 			crop.PlantedDate.Format("2006-01-02"),
 			crop.ExpectedHarvestDate.Format("2006-01-02"),
 			crop.Status,
-			crop.Notes,
-			crop.CreatedAt.Format("2006-01-02 15:04:05"),
+			anon.freeText(crop.Notes),
+			anon.timestamp(crop.CreatedAt),
 		}
 		if err := writer.Write(row); err != nil {
 			return nil, err
@@ -1483,23 +1985,30 @@ func (s *Service) exportCropsCSV(ctx context.Context, userID uint) (*CSVExportRe
 
 	return &CSVExportResult{
 		DataType:    ExportDataTypeCrops,
-		FileName:    fmt.Sprintf("crops_%s.csv", time.Now().Format("20060102_150405")),
+		FileName:    fmt.Sprintf("crops_%s.csv", s.now().Format("20060102_150405")),
 		ContentType: "text/csv; charset=utf-8",
 		Data:        buf.Bytes(),
 		RecordCount: len(crops),
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
 // exportHarvestsCSV は収穫記録をCSV形式でエクスポートします。
-func (s *Service) exportHarvestsCSV(ctx context.Context, userID uint) (*CSVExportResult, error) {
+func (s *Service) exportHarvestsCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
 	harvests, err := s.repos.Harvest().GetByUserIDWithDateRange(ctx, userID, nil, nil)
 	if err != nil {
 		return nil, err
 	}
+	return s.harvestsCSV(ctx, harvests, anon)
+}
 
-	// 作物名のキャッシュ
-	cropCache := make(map[uint]string)
+// harvestsCSV は収穫記録の一覧のCSVを作成します（エクスポートと一覧の Accept: text/csv で共通）。
+func (s *Service) harvestsCSV(ctx context.Context, harvests []model.Harvest, anon *exportAnonymizer) (*CSVExportResult, error) {
+	// 作物名をまとめて取得（見つからない作物は空の作物名）
+	crops, err := s.cropsByID(ctx, harvestCropIDs(harvests))
+	if err != nil {
+		return nil, err
+	}
 
 	var buf bytes.Buffer
 	writer := csv.NewWriter(&buf)
@@ -1515,26 +2024,21 @@ func (s *Service) exportHarvestsCSV(ctx context.Context, userID uint) (*CSVExpor
 
 	// データ行
 	for _, harvest := range harvests {
-		// 作物名を取得
-		cropName, ok := cropCache[harvest.CropID]
-		if !ok {
-			crop, err := s.repos.Crop().GetByID(ctx, harvest.CropID)
-			if err == nil {
-				cropName = crop.Name
-			}
-			cropCache[harvest.CropID] = cropName
+		var cropName string
+		if crop, ok := crops[harvest.CropID]; ok {
+			cropName = crop.Name
 		}
 
 		row := []string{
-			fmt.Sprintf("%d", harvest.ID),
-			fmt.Sprintf("%d", harvest.CropID),
-			cropName,
+			anon.id("harvest", harvest.ID),
+			anon.id("crop", harvest.CropID),
+			anon.text(cropName),
 			harvest.HarvestDate.Format("2006-01-02"),
 			fmt.Sprintf("%.2f", harvest.Quantity),
 			harvest.QuantityUnit,
 			harvest.Quality,
-			harvest.Notes,
-			harvest.CreatedAt.Format("2006-01-02 15:04:05"),
+			anon.freeText(harvest.Notes),
+			anon.timestamp(harvest.CreatedAt),
 		}
 		if err := writer.Write(row); err != nil {
 			return nil, err
@@ -1548,21 +2052,25 @@ func (s *Service) exportHarvestsCSV(ctx context.Context, userID uint) (*CSVExpor
 
 	return &CSVExportResult{
 		DataType:    ExportDataTypeHarvests,
-		FileName:    fmt.Sprintf("harvests_%s.csv", time.Now().Format("20060102_150405")),
+		FileName:    fmt.Sprintf("harvests_%s.csv", s.now().Format("20060102_150405")),
 		ContentType: "text/csv; charset=utf-8",
 		Data:        buf.Bytes(),
 		RecordCount: len(harvests),
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
 // exportTasksCSV はタスクデータをCSV形式でエクスポートします。
-func (s *Service) exportTasksCSV(ctx context.Context, userID uint) (*CSVExportResult, error) {
+func (s *Service) exportTasksCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
 	tasks, err := s.repos.Task().GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	return s.tasksCSV(tasks, anon)
+}
 
+// tasksCSV はタスクの一覧のCSVを作成します（エクスポートと一覧の Accept: text/csv で共通）。
+func (s *Service) tasksCSV(tasks []model.Task, anon *exportAnonymizer) (*CSVExportResult, error) {
 	var buf bytes.Buffer
 	writer := csv.NewWriter(&buf)
 
@@ -1578,15 +2086,15 @@ func (s *Service) exportTasksCSV(ctx context.Context, userID uint) (*CSVExportRe
 	// データ行
 	for _, task := range tasks {
 		row := []string{
-			fmt.Sprintf("%d", task.ID),
-			task.Title,
-			task.Description,
+			anon.id("task", task.ID),
+			anon.text(task.Title),
+			anon.freeText(task.Description),
 			task.DueDate.Format("2006-01-02"),
 			task.Priority,
 			task.Status,
 			formatRecurrence(task.Recurrence, task.RecurrenceInterval),
-			formatNullableTime(task.CompletedAt),
-			task.CreatedAt.Format("2006-01-02 15:04:05"),
+			anon.nullableTimestamp(task.CompletedAt),
+			anon.timestamp(task.CreatedAt),
 		}
 		if err := writer.Write(row); err != nil {
 			return nil, err
@@ -1600,73 +2108,250 @@ func (s *Service) exportTasksCSV(ctx context.Context, userID uint) (*CSVExportRe
 
 	return &CSVExportResult{
 		DataType:    ExportDataTypeTasks,
-		FileName:    fmt.Sprintf("tasks_%s.csv", time.Now().Format("20060102_150405")),
+		FileName:    fmt.Sprintf("tasks_%s.csv", s.now().Format("20060102_150405")),
 		ContentType: "text/csv; charset=utf-8",
 		Data:        buf.Bytes(),
 		RecordCount: len(tasks),
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
-// exportAllCSV は全データを1つのZIPファイルにまとめてエクスポートします。
-// 各データタイプのCSVを個別に生成し、まとめて返します。
-func (s *Service) exportAllCSV(ctx context.Context, userID uint) (*CSVExportResult, error) {
-	// 各データタイプをエクスポート
-	cropsResult, err := s.exportCropsCSV(ctx, userID)
+// exportGrowthRecordsCSV は成長記録をCSV形式でエクスポートします。
+func (s *Service) exportGrowthRecordsCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
+	crops, err := s.repos.Crop().GetByUserID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to export crops: %w", err)
+		return nil, err
 	}
 
-	harvestsResult, err := s.exportHarvestsCSV(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to export harvests: %w", err)
+	cropNames := make(map[uint]string, len(crops))
+	cropIDs := make([]uint, 0, len(crops))
+	for _, crop := range crops {
+		cropNames[crop.ID] = crop.Name
+		cropIDs = append(cropIDs, crop.ID)
 	}
 
-	tasksResult, err := s.exportTasksCSV(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to export tasks: %w", err)
+	var records []model.GrowthRecord
+	if len(cropIDs) > 0 {
+		records, err = s.repos.GrowthRecord().GetByCropIDs(ctx, cropIDs)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// ZIPファイルを作成
 	var buf bytes.Buffer
-	zipWriter := zip.NewWriter(&buf)
+	writer := csv.NewWriter(&buf)
 
-	// 各CSVをZIPに追加
-	files := []struct {
-		name string
-		data []byte
-	}{
-		{"crops.csv", cropsResult.Data},
-		{"harvests.csv", harvestsResult.Data},
-		{"tasks.csv", tasksResult.Data},
+	// BOM for Excel compatibility
+	buf.WriteString("\xEF\xBB\xBF")
+
+	// ヘッダー行
+	header := []string{"ID", "作物ID", "作物名", "記録日", "成長段階", "メモ", "画像URL", "作成日"}
+	if err := writer.Write(header); err != nil {
+		return nil, err
 	}
 
-	for _, file := range files {
-		w, err := zipWriter.Create(file.name)
-		if err != nil {
+	// データ行
+	for _, record := range records {
+		row := []string{
+			anon.id("growth_record", record.ID),
+			anon.id("crop", record.CropID),
+			anon.text(cropNames[record.CropID]),
+			record.RecordDate.Format("2006-01-02"),
+			record.GrowthStage,
+			anon.freeText(record.Notes),
+			anon.freeText(record.ImageURL),
+			anon.timestamp(record.CreatedAt),
+		}
+		if err := writer.Write(row); err != nil {
 			return nil, err
 		}
-		if _, err := w.Write(file.data); err != nil {
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return &CSVExportResult{
+		DataType:    ExportDataTypeGrowthRecords,
+		FileName:    fmt.Sprintf("growth_records_%s.csv", s.now().Format("20060102_150405")),
+		ContentType: "text/csv; charset=utf-8",
+		Data:        buf.Bytes(),
+		RecordCount: len(records),
+		GeneratedAt: s.now(),
+	}, nil
+}
+
+// exportPlotAssignmentsCSV は区画配置履歴をCSV形式でエクスポートします。
+func (s *Service) exportPlotAssignmentsCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
+	plots, err := s.repos.Plot().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 区画ごとの配置履歴と、配置された作物の作物名をまとめて取得
+	assignmentsByPlot := make([][]model.PlotAssignment, len(plots))
+	var cropIDs []uint
+	for i, plot := range plots {
+		assignments, err := s.repos.PlotAssignment().GetByPlotID(ctx, plot.ID)
+		if err != nil {
 			return nil, err
 		}
+		assignmentsByPlot[i] = assignments
+		for _, assignment := range assignments {
+			cropIDs = append(cropIDs, assignment.CropID)
+		}
+	}
+	crops, err := s.cropsByID(ctx, cropIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := zipWriter.Close(); err != nil {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	// BOM for Excel compatibility
+	buf.WriteString("\xEF\xBB\xBF")
+
+	// ヘッダー行
+	header := []string{"ID", "区画ID", "区画名", "作物ID", "作物名", "配置日", "配置解除日", "作成日"}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	// データ行（区画ごとに配置履歴を出力）
+	count := 0
+	for i, plot := range plots {
+		for _, assignment := range assignmentsByPlot[i] {
+			var cropName string
+			if crop, ok := crops[assignment.CropID]; ok {
+				cropName = crop.Name
+			}
+
+			row := []string{
+				anon.id("plot_assignment", assignment.ID),
+				anon.id("plot", plot.ID),
+				anon.text(plot.Name),
+				anon.id("crop", assignment.CropID),
+				anon.text(cropName),
+				assignment.AssignedDate.Format("2006-01-02"),
+				formatNullableDate(assignment.UnassignedDate),
+				anon.timestamp(assignment.CreatedAt),
+			}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+			count++
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return &CSVExportResult{
+		DataType:    ExportDataTypePlotAssignments,
+		FileName:    fmt.Sprintf("plot_assignments_%s.csv", s.now().Format("20060102_150405")),
+		ContentType: "text/csv; charset=utf-8",
+		Data:        buf.Bytes(),
+		RecordCount: count,
+		GeneratedAt: s.now(),
+	}, nil
+}
+
+// exportAllCSV は全データを1つのZIPファイルにまとめてエクスポートします。
+// 各データタイプのCSVを個別に生成し、まとめて返します。
+func (s *Service) exportAllCSV(ctx context.Context, userID uint, anon *exportAnonymizer) (*CSVExportResult, error) {
+	files, totalRecords, err := s.exportAllFiles(ctx, userID, anon)
+	if err != nil {
 		return nil, err
 	}
 
-	totalRecords := cropsResult.RecordCount + harvestsResult.RecordCount + tasksResult.RecordCount
+	// ZIPファイルを作成
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	// 各CSVをZIPに追加
+	if err := writeExportZipFiles(zipWriter, files); err != nil {
+		return nil, err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
 
 	return &CSVExportResult{
 		DataType:    ExportDataTypeAll,
-		FileName:    fmt.Sprintf("export_all_%s.zip", time.Now().Format("20060102_150405")),
+		FileName:    fmt.Sprintf("export_all_%s.zip", s.now().Format("20060102_150405")),
 		ContentType: "application/zip",
 		Data:        buf.Bytes(),
 		RecordCount: totalRecords,
-		GeneratedAt: time.Now(),
+		GeneratedAt: s.now(),
 	}, nil
 }
 
+// exportZipFile は全データのZIPファイルに含めるファイルです。
+type exportZipFile struct {
+	name string
+	data []byte
+}
+
+// exportAllFiles は全データのZIPファイルに含める各データタイプのCSVとレコード数の合計を返します。
+func (s *Service) exportAllFiles(ctx context.Context, userID uint, anon *exportAnonymizer) ([]exportZipFile, int, error) {
+	// 各データタイプをエクスポート
+	cropsResult, err := s.exportCropsCSV(ctx, userID, anon)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export crops: %w", err)
+	}
+
+	harvestsResult, err := s.exportHarvestsCSV(ctx, userID, anon)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export harvests: %w", err)
+	}
+
+	tasksResult, err := s.exportTasksCSV(ctx, userID, anon)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export tasks: %w", err)
+	}
+
+	growthRecordsResult, err := s.exportGrowthRecordsCSV(ctx, userID, anon)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export growth records: %w", err)
+	}
+
+	plotAssignmentsResult, err := s.exportPlotAssignmentsCSV(ctx, userID, anon)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export plot assignments: %w", err)
+	}
+
+	files := []exportZipFile{
+		{"crops.csv", cropsResult.Data},
+		{"harvests.csv", harvestsResult.Data},
+		{"tasks.csv", tasksResult.Data},
+		{"growth_records.csv", growthRecordsResult.Data},
+		{"plot_assignments.csv", plotAssignmentsResult.Data},
+	}
+
+	totalRecords := cropsResult.RecordCount + harvestsResult.RecordCount + tasksResult.RecordCount +
+		growthRecordsResult.RecordCount + plotAssignmentsResult.RecordCount
+	return files, totalRecords, nil
+}
+
+// writeExportZipFiles はファイルをZIPに追加します。
+func writeExportZipFiles(zipWriter *zip.Writer, files []exportZipFile) error {
+	for _, file := range files {
+		w, err := zipWriter.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // formatNullableDate は*time.Timeを文字列にフォーマットします（nilの場合は空文字）
 func formatNullableDate(t *time.Time) string {
 	if t == nil {
@@ -1713,6 +2398,20 @@ const (
 	NotificationEventTaskOverdueAlert NotificationEventType = "task_overdue_alert"
 	// NotificationEventHarvestReminder は収穫予定のリマインダー通知
 	NotificationEventHarvestReminder NotificationEventType = "harvest_reminder"
+	// NotificationEventCropReadyToHarvest は作物が収穫可能になった（収穫予定日を迎えた）通知
+	NotificationEventCropReadyToHarvest NotificationEventType = "crop_ready_to_harvest"
+	// NotificationEventDailyDigest は定期通知を1件にまとめたダイジェスト通知
+	NotificationEventDailyDigest NotificationEventType = "daily_digest"
+	// NotificationEventFrostWarning は霜注意報の重要アラート（SMS送信対象）
+	NotificationEventFrostWarning NotificationEventType = "frost_warning"
+	// NotificationEventAccountSecurity はアカウントセキュリティの重要アラート（SMS送信対象）
+	NotificationEventAccountSecurity NotificationEventType = "account_security"
+	// NotificationEventPushOverflow は送信数の上限により送らなかったプッシュ通知のまとめ通知
+	NotificationEventPushOverflow NotificationEventType = "push_overflow"
+	// NotificationEventAnnouncement は管理者が配信するお知らせ（メンテナンス・新機能など）
+	NotificationEventAnnouncement NotificationEventType = "announcement"
+	// NotificationEventYearInReview はシーズンの締めで送る1年の菜園のふりかえり
+	NotificationEventYearInReview NotificationEventType = "year_in_review"
 )
 
 // NotificationEvent は通知イベントを表します。
@@ -1724,6 +2423,15 @@ type NotificationEvent struct {
 	Title     string                `json:"title"`
 	Body      string                `json:"body"`
 	Data      map[string]interface{} `json:"data,omitempty"`
+	LocalDate string                `json:"local_date,omitempty"` // ユーザーのタイムゾーンでの対象日（重複防止キーに使用）
+
+	// DeduplicationScope は同じ日に同じイベントタイプを複数回送る場合の区別です（例: 作物IDの一覧）。
+	// 設定した場合は重複防止キーに含めます。
+	DeduplicationScope string `json:"deduplication_scope,omitempty"`
+
+	// PushRateLimited はプッシュ通知の送信数の上限に達しているため、プッシュ通知を送らないことを示します。
+	// 通知イベントハンドラーが通知ログの送信数から判定して設定します。
+	PushRateLimited bool `json:"-"`
 }
 
 // SchedulerResult はスケジューラー処理の結果を表します。
@@ -1732,21 +2440,30 @@ type SchedulerResult struct {
 	OverdueTaskAlerts int                 `json:"overdue_task_alerts"` // 期限切れ警告を送った件数
 	TodayTaskReminders int                `json:"today_task_reminders"` // 当日リマインダーを送った件数
 	HarvestReminders  int                 `json:"harvest_reminders"`   // 収穫リマインダーを送った件数
+	HarvestReadyAlerts int                `json:"harvest_ready_alerts"` // 収穫可能通知を送った件数
 	Events            []NotificationEvent `json:"events"`              // 生成された通知イベント
 }
 
 // OverdueWarningThreshold は期限切れタスク警告を発行するしきい値（3件以上で警告）
 const OverdueWarningThreshold = 3
 
-// HarvestReminderDaysAhead は収穫リマインダーを送る日数（7日前）
+// HarvestReminderDaysAhead は収穫リマインダーを送る日数のデフォルト（7日前）
+// 管理者がスケジュール設定（notifications の lookahead_days）で変更できます。
 const HarvestReminderDaysAhead = 7
 
+// schedulerDayMargin はタイムゾーン差を吸収するために取得範囲を広げる幅
+// 各ユーザーの「今日」は now の前後24時間以内に収まるため、余裕を持って48時間とします
+const schedulerDayMargin = 48 * time.Hour
+
 // ProcessScheduledNotifications は定期通知処理を実行します。
-// EventBridge Scheduler から毎日呼び出され、以下の処理を行います：
+// EventBridge Scheduler から1時間ごとに呼び出され、以下の処理を行います：
 //   - 期限切れタスク検出（3件以上で警告通知）
 //   - 当日タスクのリマインダー通知
 //   - 7日以内の収穫予定リマインダー通知
 //
+// 日付の境界はユーザーのタイムゾーンで計算し、ユーザーが指定した送信時刻
+// （NotificationSettings.DailyReminderHour）以降の実行でのみ通知イベントを生成します。
+//
 // 引数:
 //   - ctx: リクエストコンテキスト
 //
@@ -1754,153 +2471,212 @@ const HarvestReminderDaysAhead = 7
 //   - *SchedulerResult: 処理結果（生成された通知イベントを含む）
 //   - error: 処理に失敗した場合のエラー
 func (s *Service) ProcessScheduledNotifications(ctx context.Context) (*SchedulerResult, error) {
+	return s.processScheduledNotificationsAt(ctx, s.now())
+}
+
+// processScheduledNotificationsAt は指定時刻を基準に定期通知処理を実行します。
+// ユーザーをID順のページに分けて処理し、結果をまとめて返します。
+func (s *Service) processScheduledNotificationsAt(ctx context.Context, now time.Time) (*SchedulerResult, error) {
 	result := &SchedulerResult{
-		ProcessedAt: time.Now(),
+		ProcessedAt: now,
 		Events:      make([]NotificationEvent, 0),
 	}
 
-	// 1. 期限切れタスク警告を処理
-	overdueEvents, err := s.processOverdueTaskAlerts(ctx)
+	var afterUserID uint
+	for {
+		page, lastUserID, err := s.processScheduledNotificationsPage(ctx, now, afterUserID)
+		if err != nil {
+			return nil, err
+		}
+		if lastUserID == 0 {
+			break
+		}
+		result.Events = append(result.Events, page.Events...)
+		result.OverdueTaskAlerts += page.OverdueTaskAlerts
+		result.TodayTaskReminders += page.TodayTaskReminders
+		result.HarvestReminders += page.HarvestReminders
+		result.HarvestReadyAlerts += page.HarvestReadyAlerts
+		afterUserID = lastUserID
+	}
+
+	return result, nil
+}
+
+// processScheduledNotificationsPage は afterUserID より後のユーザー1ページ分の定期通知処理を実行します。
+// ユーザー数に比例してタスク・作物を一度に読み込まないよう、ページのユーザーに絞って取得します。
+//
+// 戻り値:
+//   - *SchedulerResult: ページの処理結果
+//   - uint: ページの最後のユーザーID（処理するユーザーがいない場合は 0）
+//   - error: 処理に失敗した場合のエラー
+func (s *Service) processScheduledNotificationsPage(ctx context.Context, now time.Time, afterUserID uint) (*SchedulerResult, uint, error) {
+	userIDs, err := s.repos.User().GetIDsAfter(ctx, afterUserID, s.schedulerUserPageSize())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get users: %w", err)
+	}
+	result := &SchedulerResult{
+		ProcessedAt: now,
+		Events:      make([]NotificationEvent, 0),
+	}
+	if len(userIDs) == 0 {
+		return result, 0, nil
+	}
+
+	// 期限切れ・当日タスクはまとめて取得し、ユーザーごとのタイムゾーンで振り分ける
+	pendingTasks, err := s.repos.Task().GetPendingTasksDueBefore(ctx, userIDs, now.Add(schedulerDayMargin))
 	if err != nil {
-		return nil, fmt.Errorf("failed to process overdue task alerts: %w", err)
+		return nil, 0, fmt.Errorf("failed to get pending tasks: %w", err)
 	}
+
+	// 1. 期限切れタスク警告を処理
+	overdueEvents := s.processOverdueTaskAlerts(pendingTasks, now)
 	result.Events = append(result.Events, overdueEvents...)
 	result.OverdueTaskAlerts = len(overdueEvents)
 
 	// 2. 当日タスクリマインダーを処理
-	todayEvents, err := s.processTodayTaskReminders(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to process today task reminders: %w", err)
-	}
+	todayEvents := s.processTodayTaskReminders(pendingTasks, now)
 	result.Events = append(result.Events, todayEvents...)
 	result.TodayTaskReminders = len(todayEvents)
 
 	// 3. 収穫リマインダーを処理
-	harvestEvents, err := s.processHarvestReminders(ctx)
+	harvestEvents, err := s.processHarvestReminders(ctx, userIDs, now)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process harvest reminders: %w", err)
+		return nil, 0, fmt.Errorf("failed to process harvest reminders: %w", err)
 	}
 	result.Events = append(result.Events, harvestEvents...)
 	result.HarvestReminders = len(harvestEvents)
 
-	return result, nil
-}
-
-// processOverdueTaskAlerts は期限切れタスクの警告通知を処理します。
-// ユーザーごとに期限切れタスクを集計し、3件以上ある場合に警告通知を生成します。
-func (s *Service) processOverdueTaskAlerts(ctx context.Context) ([]NotificationEvent, error) {
-	// システム全体の期限切れタスクを取得
-	overdueTasks, err := s.repos.Task().GetAllOverdueTasks(ctx)
+	// 4. 収穫可能になった作物の通知を処理
+	readyEvents, err := s.processHarvestReadyAlerts(ctx, userIDs, now)
 	if err != nil {
-		return nil, err
+		return nil, 0, fmt.Errorf("failed to process harvest ready alerts: %w", err)
 	}
+	result.Events = append(result.Events, readyEvents...)
+	result.HarvestReadyAlerts = len(readyEvents)
+
+	return result, userIDs[len(userIDs)-1], nil
+}
 
-	// ユーザーごとにタスクをグループ化
+// groupTasksByUser は通知対象のタスクをユーザーごとにグループ化します。
+// ユーザー情報がないタスク、送信時刻前のユーザー、eventType の通知がすべてのチャネルで無効なユーザーは除外します。
+func groupTasksByUser(tasks []model.Task, now time.Time, eventType NotificationEventType) (map[uint][]model.Task, map[uint]*model.User) {
 	userTasks := make(map[uint][]model.Task)
 	userInfo := make(map[uint]*model.User)
-	for _, task := range overdueTasks {
-		userTasks[task.UserID] = append(userTasks[task.UserID], task)
-		if task.User.ID != 0 {
-			userInfo[task.UserID] = &task.User
+	for i := range tasks {
+		task := tasks[i]
+		if task.User.ID == 0 {
+			continue
 		}
+		user := &task.User
+		if !notificationEventEnabled(user, eventType) {
+			continue // 通知設定で無効
+		}
+		if !isDailyReminderDue(user, now) {
+			continue // ユーザーの送信時刻前
+		}
+		userTasks[task.UserID] = append(userTasks[task.UserID], task)
+		userInfo[task.UserID] = user
 	}
+	return userTasks, userInfo
+}
+
+// processOverdueTaskAlerts は期限切れタスクの警告通知を処理します。
+// ユーザーごとに期限切れタスクを集計し、3件以上ある場合に警告通知を生成します。
+func (s *Service) processOverdueTaskAlerts(tasks []model.Task, now time.Time) []NotificationEvent {
+	userTasks, userInfo := groupTasksByUser(tasks, now, NotificationEventTaskOverdueAlert)
 
 	var events []NotificationEvent
 
 	// ユーザーごとに処理
-	for userID, tasks := range userTasks {
+	for userID, candidates := range userTasks {
 		user := userInfo[userID]
-		if user == nil {
-			continue
-		}
+		dayStart, _ := userDayBounds(user, now)
 
-		// 通知設定をチェック
-		if user.NotificationSettings != nil && !user.NotificationSettings.TaskReminders {
-			continue // タスクリマインダーが無効
+		// ユーザーのタイムゾーンで今日より前が期限のタスクを抽出
+		var overdue []model.Task
+		for _, task := range candidates {
+			if task.DueDate.Before(dayStart) {
+				overdue = append(overdue, task)
+			}
 		}
 
 		// 3件以上の場合のみ警告
-		if len(tasks) >= OverdueWarningThreshold {
+		if len(overdue) >= OverdueWarningThreshold {
+			msg := localizedMessage(userLocale(user), NotificationEventTaskOverdueAlert)
 			event := NotificationEvent{
 				Type:      NotificationEventTaskOverdueAlert,
 				UserID:    userID,
 				UserEmail: user.Email,
-				Title:     "期限切れタスクの警告",
-				Body:      fmt.Sprintf("%d件のタスクが期限切れです。確認してください。", len(tasks)),
+				Title:     msg.Title,
+				Body:      fmt.Sprintf(msg.Body, len(overdue)),
 				Data: map[string]interface{}{
-					"overdue_count": len(tasks),
-					"task_ids":      getTaskIDs(tasks),
+					"overdue_count": len(overdue),
+					"task_ids":      getTaskIDs(overdue),
 				},
+				LocalDate: dayStart.Format("2006-01-02"),
 			}
 			events = append(events, event)
 		}
 	}
 
-	return events, nil
+	return events
 }
 
 // processTodayTaskReminders は今日が期限のタスクのリマインダーを処理します。
-func (s *Service) processTodayTaskReminders(ctx context.Context) ([]NotificationEvent, error) {
-	// システム全体の今日のタスクを取得
-	todayTasks, err := s.repos.Task().GetAllTodayTasks(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// ユーザーごとにタスクをグループ化
-	userTasks := make(map[uint][]model.Task)
-	userInfo := make(map[uint]*model.User)
-	for _, task := range todayTasks {
-		userTasks[task.UserID] = append(userTasks[task.UserID], task)
-		if task.User.ID != 0 {
-			userInfo[task.UserID] = &task.User
-		}
-	}
+func (s *Service) processTodayTaskReminders(tasks []model.Task, now time.Time) []NotificationEvent {
+	userTasks, userInfo := groupTasksByUser(tasks, now, NotificationEventTaskDueReminder)
 
 	var events []NotificationEvent
 
 	// ユーザーごとに処理
-	for userID, tasks := range userTasks {
+	for userID, candidates := range userTasks {
 		user := userInfo[userID]
-		if user == nil {
-			continue
-		}
+		dayStart, dayEnd := userDayBounds(user, now)
 
-		// 通知設定をチェック
-		if user.NotificationSettings != nil && !user.NotificationSettings.TaskReminders {
-			continue // タスクリマインダーが無効
+		// ユーザーのタイムゾーンで今日が期限のタスクを抽出
+		var today []model.Task
+		for _, task := range candidates {
+			if !task.DueDate.Before(dayStart) && task.DueDate.Before(dayEnd) {
+				today = append(today, task)
+			}
 		}
 
 		// タスクがあればリマインダーを送信
-		if len(tasks) > 0 {
-			body := fmt.Sprintf("今日のタスクが%d件あります。", len(tasks))
-			if len(tasks) == 1 {
-				body = fmt.Sprintf("今日のタスク: %s", tasks[0].Title)
+		if len(today) > 0 {
+			msg := localizedMessage(userLocale(user), NotificationEventTaskDueReminder)
+			body := fmt.Sprintf(msg.Body, len(today))
+			if len(today) == 1 {
+				body = fmt.Sprintf(msg.BodyOne, today[0].Title)
 			}
 
 			event := NotificationEvent{
 				Type:      NotificationEventTaskDueReminder,
 				UserID:    userID,
 				UserEmail: user.Email,
-				Title:     "今日のタスクリマインダー",
+				Title:     msg.Title,
 				Body:      body,
 				Data: map[string]interface{}{
-					"task_count": len(tasks),
-					"task_ids":   getTaskIDs(tasks),
+					"task_count": len(today),
+					"task_ids":   getTaskIDs(today),
 				},
+				LocalDate: dayStart.Format("2006-01-02"),
 			}
 			events = append(events, event)
 		}
 	}
 
-	return events, nil
+	return events
 }
 
 // processHarvestReminders は収穫予定のリマインダーを処理します。
-// 7日以内に収穫予定の作物があるユーザーに通知を送信します。
-func (s *Service) processHarvestReminders(ctx context.Context) ([]NotificationEvent, error) {
-	// 7日以内に収穫予定の作物を取得
-	upcomingCrops, err := s.repos.Crop().GetUpcomingHarvests(ctx, HarvestReminderDaysAhead)
+// ユーザーのタイムゾーンで今日から先読み日数（harvestReminderDaysAhead）以内に収穫予定の作物があるユーザーに通知を送信します。
+func (s *Service) processHarvestReminders(ctx context.Context, userIDs []uint, now time.Time) ([]NotificationEvent, error) {
+	daysAhead := s.harvestReminderDaysAhead(ctx)
+
+	// タイムゾーン差を吸収できる範囲で取得し、ユーザーごとに絞り込む
+	upcomingCrops, err := s.repos.Crop().GetUpcomingHarvests(ctx, userIDs,
+		now.Add(-schedulerDayMargin),
+		now.Add(schedulerDayMargin).AddDate(0, 0, daysAhead))
 	if err != nil {
 		return nil, err
 	}
@@ -1908,11 +2684,26 @@ func (s *Service) processHarvestReminders(ctx context.Context) ([]NotificationEv
 	// ユーザーごとに作物をグループ化
 	userCrops := make(map[uint][]model.Crop)
 	userInfo := make(map[uint]*model.User)
-	for _, crop := range upcomingCrops {
-		userCrops[crop.UserID] = append(userCrops[crop.UserID], crop)
-		if crop.User.ID != 0 {
-			userInfo[crop.UserID] = &crop.User
+	for i := range upcomingCrops {
+		crop := upcomingCrops[i]
+		if crop.User.ID == 0 {
+			continue
+		}
+		user := &crop.User
+		if !notificationEventEnabled(user, NotificationEventHarvestReminder) {
+			continue // 通知設定で無効
+		}
+		if !isDailyReminderDue(user, now) {
+			continue // ユーザーの送信時刻前
+		}
+
+		dayStart, _ := userDayBounds(user, now)
+		windowEnd := dayStart.AddDate(0, 0, daysAhead+1)
+		if crop.ExpectedHarvestDate.Before(dayStart) || !crop.ExpectedHarvestDate.Before(windowEnd) {
+			continue
 		}
+		userCrops[crop.UserID] = append(userCrops[crop.UserID], crop)
+		userInfo[crop.UserID] = user
 	}
 
 	var events []NotificationEvent
@@ -1920,36 +2711,28 @@ func (s *Service) processHarvestReminders(ctx context.Context) ([]NotificationEv
 	// ユーザーごとに処理
 	for userID, crops := range userCrops {
 		user := userInfo[userID]
-		if user == nil {
-			continue
-		}
+		dayStart, _ := userDayBounds(user, now)
 
-		// 通知設定をチェック
-		if user.NotificationSettings != nil && !user.NotificationSettings.HarvestReminders {
-			continue // 収穫リマインダーが無効
+		msg := localizedMessage(userLocale(user), NotificationEventHarvestReminder)
+		body := fmt.Sprintf(msg.Body, len(crops), daysAhead)
+		if len(crops) == 1 {
+			daysUntil := int(crops[0].ExpectedHarvestDate.Sub(dayStart).Hours() / 24)
+			body = fmt.Sprintf(msg.BodyOne, crops[0].Name, daysUntil)
 		}
 
-		// 作物があればリマインダーを送信
-		if len(crops) > 0 {
-			body := fmt.Sprintf("%d件の作物が7日以内に収穫予定です。", len(crops))
-			if len(crops) == 1 {
-				daysUntil := int(crops[0].ExpectedHarvestDate.Sub(time.Now().Truncate(24*time.Hour)).Hours() / 24)
-				body = fmt.Sprintf("%s があと%d日で収穫予定です。", crops[0].Name, daysUntil)
-			}
-
-			event := NotificationEvent{
-				Type:      NotificationEventHarvestReminder,
-				UserID:    userID,
-				UserEmail: user.Email,
-				Title:     "収穫リマインダー",
-				Body:      body,
-				Data: map[string]interface{}{
-					"crop_count": len(crops),
-					"crop_ids":   getCropIDs(crops),
-				},
-			}
-			events = append(events, event)
+		event := NotificationEvent{
+			Type:      NotificationEventHarvestReminder,
+			UserID:    userID,
+			UserEmail: user.Email,
+			Title:     msg.Title,
+			Body:      body,
+			Data: map[string]interface{}{
+				"crop_count": len(crops),
+				"crop_ids":   getCropIDs(crops),
+			},
+			LocalDate: dayStart.Format("2006-01-02"),
 		}
+		events = append(events, event)
 	}
 
 	return events, nil
@@ -1997,6 +2780,9 @@ func (s *Service) RegisterDeviceToken(ctx context.Context, userID uint, token, p
 			existingToken.Token = token
 			existingToken.DeviceID = deviceID
 			existingToken.IsActive = true
+			existingToken.DeactivatedAt = nil
+			existingToken.DeactivationReason = ""
+			existingToken.EndpointARN = "" // トークンが変わった場合に古いエンドポイントを使わない
 			if err := s.repos.DeviceToken().Update(txCtx, existingToken); err != nil {
 				return err
 			}
@@ -2077,14 +2863,53 @@ func (s *Service) GetActiveDeviceTokens(ctx context.Context, userID uint) ([]mod
 //
 // 戻り値:
 //   - *model.NotificationSettings: 更新後の通知設定
-//   - error: 更新に失敗した場合のエラー
+//   - error: 入力が不正な場合は ErrInvalidReminderHour / ErrInvalidWebhookURL / ErrInvalidCustomWebhookURL、更新に失敗した場合のエラー
 func (s *Service) UpdateNotificationSettings(ctx context.Context, userID uint, settings *model.NotificationSettings) (*model.NotificationSettings, error) {
+	if settings.DailyReminderHour != nil && (*settings.DailyReminderHour < 0 || *settings.DailyReminderHour > 23) {
+		return nil, ErrInvalidReminderHour
+	}
+	if err := ValidateQuietHours(settings); err != nil {
+		return nil, err
+	}
+
+	// Webhook URLは公式のSlack/Discordエンドポイントのみ受け付ける
+	if settings.SlackWebhookURL != "" {
+		if err := ValidateWebhookURL(NotificationChannelSlack, settings.SlackWebhookURL); err != nil {
+			return nil, err
+		}
+	}
+	if settings.DiscordWebhookURL != "" {
+		if err := ValidateWebhookURL(NotificationChannelDiscord, settings.DiscordWebhookURL); err != nil {
+			return nil, err
+		}
+	}
+	// 汎用Webhookは内部ネットワーク以外のHTTPS URLのみ受け付ける
+	if settings.CustomWebhookURL != "" {
+		if err := ValidateCustomWebhookURL(settings.CustomWebhookURL); err != nil {
+			return nil, err
+		}
+	}
+
 	// ユーザーを取得
 	user, err := s.repos.User().GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	// SMS通知は電話番号の認証後のみ有効にできる
+	if settings.SMSEnabled && (user.PhoneNumber == "" || user.PhoneVerifiedAt == nil) {
+		return nil, ErrPhoneNotVerified
+	}
+
+	// 汎用Webhookを初めて有効にした場合は署名用シークレットを発行する
+	if settings.CustomWebhookEnabled && user.WebhookSecret == "" {
+		secret, err := GenerateCustomWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		user.WebhookSecret = secret
+	}
+
 	// 通知設定を更新
 	user.NotificationSettings = settings
 
@@ -2095,8 +2920,52 @@ func (s *Service) UpdateNotificationSettings(ctx context.Context, userID uint, s
 	return settings, nil
 }
 
+// GetCustomWebhookSecret は汎用Webhookの署名用シークレットを返します（未発行の場合は空文字）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - string: 署名用シークレット
+//   - error: ユーザーの取得に失敗した場合のエラー
+func (s *Service) GetCustomWebhookSecret(ctx context.Context, userID uint) (string, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.WebhookSecret, nil
+}
+
+// RotateCustomWebhookSecret は汎用Webhookの署名用シークレットを再発行します。
+// 以降の送信は新しいシークレットで署名され、古いシークレットの署名は送られません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - string: 新しい署名用シークレット
+//   - error: 生成・保存に失敗した場合のエラー
+func (s *Service) RotateCustomWebhookSecret(ctx context.Context, userID uint) (string, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	secret, err := GenerateCustomWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+	user.WebhookSecret = secret
+	if err := s.repos.User().Update(ctx, user); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
 // CreateNotificationLog は通知ログを作成します。
 // 重複防止キーを使用して、同じ通知が期間内に再送されないようにします。
+// 通知ログは受信箱に表示されるため、通知の受信のイベント（notification.received）も配信します。
 //
 // 引数:
 //   - ctx: リクエストコンテキスト
@@ -2105,7 +2974,11 @@ func (s *Service) UpdateNotificationSettings(ctx context.Context, userID uint, s
 // 戻り値:
 //   - error: 作成に失敗した場合のエラー
 func (s *Service) CreateNotificationLog(ctx context.Context, log *model.NotificationLog) error {
-	return s.repos.NotificationLog().Create(ctx, log)
+	if err := s.repos.NotificationLog().Create(ctx, log); err != nil {
+		return err
+	}
+	s.publishNotificationReceived(ctx, log)
+	return nil
 }
 
 // CheckDeduplication は重複防止キーで既存の通知ログをチェックします。
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Sharing - 公開共有
+// =============================================================================
+
+var (
+	// ErrShareTokenInvalid は共有トークンが存在しない・失効・期限切れの場合のエラー
+	ErrShareTokenInvalid = errors.New("share token is invalid or expired")
+	// ErrShareTokenNotOwned は他ユーザーの共有トークンを操作しようとした場合のエラー
+	ErrShareTokenNotOwned = errors.New("share token does not belong to user")
+)
+
+const (
+	// ShareScopeStats は収穫統計の公開スコープ
+	ShareScopeStats = "stats"
+
+	// shareTokenBytes は共有トークンのランダムバイト数（hexで64文字）
+	shareTokenBytes = 32
+)
+
+// PublicStats は公開バッジ用の収穫統計を表します。
+type PublicStats struct {
+	Year           int       `json:"year"`             // 集計対象年
+	TotalHarvestKg float64   `json:"total_harvest_kg"` // 年間総収穫量（kg換算）
+	HarvestCount   int       `json:"harvest_count"`    // 年間収穫回数
+	CropCount      int       `json:"crop_count"`       // 栽培中を含む作物数
+	GeneratedAt    time.Time `json:"generated_at"`
+
+	// ExpiresAt は共有トークンの有効期限です（キャッシュの期限に使用し、レスポンスには含めません）
+	ExpiresAt *time.Time `json:"-"`
+}
+
+// CreateShareToken は新しい共有トークンを発行します。
+// トークンはハッシュ（SHA-256）のみ保存し、トークン文字列は戻り値の Token でのみ返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - expiresAt: 有効期限（nilの場合は無期限）
+//
+// 戻り値:
+//   - *model.ShareToken: 発行された共有トークン
+//   - error: 発行に失敗した場合のエラー
+func (s *Service) CreateShareToken(ctx context.Context, userID uint, expiresAt *time.Time) (*model.ShareToken, error) {
+	bytes := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+
+	plain := hex.EncodeToString(bytes)
+	token := &model.ShareToken{
+		UserID:    userID,
+		TokenHash: auth.HashToken(plain),
+		Token:     plain,
+		Scope:     ShareScopeStats,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repos.ShareToken().Create(ctx, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// GetUserShareTokens はユーザーの共有トークン一覧を取得します。
+func (s *Service) GetUserShareTokens(ctx context.Context, userID uint) ([]model.ShareToken, error) {
+	return s.repos.ShareToken().GetByUserID(ctx, userID)
+}
+
+// RevokeShareToken は共有トークンを失効させます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（所有者チェック用）
+//   - id: 共有トークンID
+//
+// 戻り値:
+//   - *model.ShareToken: 失効させた共有トークン
+//   - error: 存在しない・所有者でない場合のエラー
+func (s *Service) RevokeShareToken(ctx context.Context, userID, id uint) (*model.ShareToken, error) {
+	token, err := s.repos.ShareToken().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if token.UserID != userID {
+		return nil, ErrShareTokenNotOwned
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	now := s.now()
+	token.RevokedAt = &now
+	if err := s.repos.ShareToken().Update(ctx, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ResolveShareToken はトークン文字列を検証し、有効な共有トークンを返します（トークンのハッシュで検索）。
+//
+// 戻り値:
+//   - *model.ShareToken: 有効な共有トークン
+//   - error: 無効な場合は ErrShareTokenInvalid
+func (s *Service) ResolveShareToken(ctx context.Context, token string) (*model.ShareToken, error) {
+	shareToken, err := s.repos.ShareToken().GetByTokenHash(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, ErrShareTokenInvalid
+	}
+	if !shareToken.IsValid(s.now()) {
+		return nil, ErrShareTokenInvalid
+	}
+	return shareToken, nil
+}
+
+// GetPublicStats は共有トークンに紐づくユーザーの今年の収穫統計を取得します。
+// 「今年」はユーザーのタイムゾーンの年です（年末年始にサーバーのタイムゾーンの年にならない）。
+// 個人を特定できる情報は含みません。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - token: 共有トークン文字列
+//
+// 戻り値:
+//   - *PublicStats: 公開用の収穫統計
+//   - error: トークンが無効な場合・ユーザーが削除された場合は ErrShareTokenInvalid
+func (s *Service) GetPublicStats(ctx context.Context, token string) (*PublicStats, error) {
+	shareToken, err := s.ResolveShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.repos.User().GetByID(ctx, shareToken.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareTokenInvalid
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	local := now.In(userLocation(owner))
+	startOfYear := time.Date(local.Year(), 1, 1, 0, 0, 0, 0, local.Location())
+	harvests, err := s.repos.Harvest().GetByUserIDWithDateRange(ctx, shareToken.UserID, &startOfYear, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	crops, err := s.repos.Crop().GetByUserID(ctx, shareToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &PublicStats{
+		Year:         local.Year(),
+		HarvestCount: len(harvests),
+		CropCount:    len(crops),
+		GeneratedAt:  now,
+		ExpiresAt:    shareToken.ExpiresAt,
+	}
+	for _, h := range harvests {
+		stats.TotalHarvestKg += convertToKg(h.Quantity, h.QuantityUnit)
+	}
+	return stats, nil
+}
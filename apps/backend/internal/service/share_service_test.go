@@ -0,0 +1,187 @@
+// Package service - ShareService Unit Tests
+//
+// 公開共有機能のユニットテストを提供します。
+//
+// テスト対象:
+//   - 共有トークンの発行・失効
+//   - 公開統計の集計
+//   - 無効トークンの拒否
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/auth"
+	"github.com/secure-scorecard/backend/internal/clock"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// ShareToken テスト
+// =============================================================================
+
+// TestCreateShareToken_Success は共有トークンの発行をテストします（保存するのはトークンのハッシュのみ）。
+func TestCreateShareToken_Success(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	// Act
+	token, err := svc.CreateShareToken(ctx, 1, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(token.Token) != 64 {
+		t.Errorf("Expected 64 char token, got %d", len(token.Token))
+	}
+	stored := mockRepos.GetMockShareTokenRepository().Tokens[token.ID]
+	if stored.TokenHash != auth.HashToken(token.Token) || stored.Token != "" {
+		t.Errorf("Expected only the SHA-256 hash to be stored, got %+v", stored)
+	}
+	if resolved, err := svc.ResolveShareToken(ctx, token.Token); err != nil || resolved.ID != token.ID {
+		t.Errorf("Expected the token to resolve by its hash, got %+v (%v)", resolved, err)
+	}
+	if token.Scope != ShareScopeStats {
+		t.Errorf("Expected scope %s, got %s", ShareScopeStats, token.Scope)
+	}
+}
+
+// TestRevokeShareToken_InvalidatesToken は失効後にトークンが無効になることをテストします。
+func TestRevokeShareToken_InvalidatesToken(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	token, _ := svc.CreateShareToken(ctx, 1, nil)
+
+	// Act
+	if _, err := svc.RevokeShareToken(ctx, 1, token.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if _, err := svc.ResolveShareToken(ctx, token.Token); !errors.Is(err, ErrShareTokenInvalid) {
+		t.Errorf("Expected ErrShareTokenInvalid, got %v", err)
+	}
+}
+
+// TestRevokeShareToken_OtherUser は他ユーザーのトークンを失効できないことをテストします。
+func TestRevokeShareToken_OtherUser(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	token, _ := svc.CreateShareToken(ctx, 1, nil)
+
+	// Act
+	_, err := svc.RevokeShareToken(ctx, 2, token.ID)
+
+	// Assert
+	if !errors.Is(err, ErrShareTokenNotOwned) {
+		t.Errorf("Expected ErrShareTokenNotOwned, got %v", err)
+	}
+}
+
+// TestResolveShareToken_Expired は期限切れトークンが拒否されることをテストします。
+func TestResolveShareToken_Expired(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	token, _ := svc.CreateShareToken(ctx, 1, &past)
+
+	// Act
+	_, err := svc.ResolveShareToken(ctx, token.Token)
+
+	// Assert
+	if !errors.Is(err, ErrShareTokenInvalid) {
+		t.Errorf("Expected ErrShareTokenInvalid, got %v", err)
+	}
+}
+
+// =============================================================================
+// GetPublicStats テスト
+// =============================================================================
+
+// TestGetPublicStats_SumsThisYear は今年の収穫量のみ集計されることをテストします。
+func TestGetPublicStats_SumsThisYear(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	user := &model.User{Email: "share@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+	token, _ := svc.CreateShareToken(ctx, user.ID, nil)
+
+	now := time.Now()
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: now, Quantity: 2, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: now, Quantity: 500, QuantityUnit: "g"})
+	harvestRepo.AddHarvestForUser(1, &model.Harvest{CropID: 1, HarvestDate: now.AddDate(-1, 0, 0), Quantity: 10, QuantityUnit: "kg"})
+
+	// Act
+	stats, err := svc.GetPublicStats(ctx, token.Token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.TotalHarvestKg != 2.5 {
+		t.Errorf("Expected 2.5kg, got %f", stats.TotalHarvestKg)
+	}
+	if stats.HarvestCount != 2 {
+		t.Errorf("Expected 2 harvests, got %d", stats.HarvestCount)
+	}
+}
+
+// TestGetPublicStats_OwnerTimezone は「今年」がユーザーのタイムゾーンの年であることをテストします。
+// 期待動作:
+//   - サーバー（UTC）では大晦日でもユーザー（Asia/Tokyo）では元日の場合、新しい年の収穫のみ集計する
+func TestGetPublicStats_OwnerTimezone(t *testing.T) {
+	// Arrange
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo is not available: %v", err)
+	}
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetClock(clock.NewFake(time.Date(2026, 12, 31, 20, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	user := &model.User{Email: "share@example.com", Timezone: "Asia/Tokyo"}
+	_ = mockRepos.User().Create(ctx, user)
+	token, _ := svc.CreateShareToken(ctx, user.ID, nil)
+	harvestRepo := mockRepos.GetMockHarvestRepository()
+	harvestRepo.AddHarvestForUser(user.ID, &model.Harvest{CropID: 1, HarvestDate: time.Date(2027, 1, 1, 1, 0, 0, 0, tokyo), Quantity: 1, QuantityUnit: "kg"})
+	harvestRepo.AddHarvestForUser(user.ID, &model.Harvest{CropID: 1, HarvestDate: time.Date(2026, 12, 31, 18, 0, 0, 0, tokyo), Quantity: 10, QuantityUnit: "kg"})
+
+	// Act
+	stats, err := svc.GetPublicStats(ctx, token.Token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Year != 2027 || stats.HarvestCount != 1 || stats.TotalHarvestKg != 1 {
+		t.Errorf("Expected only the 2027 harvest in the owner's timezone, got %+v", stats)
+	}
+}
+
+// TestGetPublicStats_UnknownToken は存在しないトークンが拒否されることをテストします。
+func TestGetPublicStats_UnknownToken(t *testing.T) {
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+
+	_, err := svc.GetPublicStats(context.Background(), "unknown")
+
+	if !errors.Is(err, ErrShareTokenInvalid) {
+		t.Errorf("Expected ErrShareTokenInvalid, got %v", err)
+	}
+}
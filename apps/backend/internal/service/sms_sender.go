@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// SMS Notification - SNS SMS通知
+// =============================================================================
+// 重要なアラート（霜注意報・アカウントセキュリティ）を、SMSを有効にし電話番号を認証した
+// ユーザーへ SNS の SMS Publish で送信します。定期リマインダーはSMSでは送信しません。
+//
+// 送信者は国番号ごとに設定できます（config.NotificationConfig.SMSSenders）。
+// 日本など送信者IDに対応する国は英数字の送信者ID、米国など発信元番号が必要な国は
+// "+" で始まる発信元番号を設定します。
+
+const (
+	// NotificationChannelSMS はSMS通知チャネル
+	NotificationChannelSMS = "sms"
+
+	// smsMaxLength はSMS本文の最大文字数（日本語で3セグメント程度に収める）
+	smsMaxLength = 200
+	// smsPrefix はSMS本文の先頭に付けるサービス名
+	smsPrefix = "【Home Garden】"
+)
+
+// SMSAlertEventTypes はSMSで送信する重要アラートのイベントタイプです。
+var SMSAlertEventTypes = []NotificationEventType{
+	NotificationEventFrostWarning,
+	NotificationEventAccountSecurity,
+}
+
+// isSMSAlertEvent はイベントタイプがSMS送信対象の重要アラートかを判定します。
+func isSMSAlertEvent(eventType NotificationEventType) bool {
+	for _, t := range SMSAlertEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// smsNotificationEnabled はユーザーへイベントをSMSで送信するかを判定します。
+// SMSのオプトイン、認証済みの電話番号、重要アラートであることをすべて満たす必要があります。
+func smsNotificationEnabled(user *model.User, eventType NotificationEventType) bool {
+	if user.PhoneNumber == "" || user.PhoneVerifiedAt == nil || !isSMSAlertEvent(eventType) {
+		return false
+	}
+	return effectiveNotificationSettings(user).SMSEnabled
+}
+
+// buildSMSMessage は通知イベントからSMS本文を作成します（smsMaxLength 文字まで）。
+func buildSMSMessage(title, body string) string {
+	message := smsPrefix + title
+	if body != "" {
+		message += "\n" + body
+	}
+	if utf8.RuneCountInString(message) > smsMaxLength {
+		runes := []rune(message)
+		message = string(runes[:smsMaxLength-1]) + "…"
+	}
+	return message
+}
+
+// smsSenderFor は電話番号の国番号に一致する送信者を返します。
+// 国番号の長さは国によって異なるため、最も長く一致する設定を使用します。
+//
+// 引数:
+//   - senders: 国番号（"+" なし）ごとの送信者
+//   - defaultSender: 一致する設定がない場合の送信者
+//   - phoneNumber: E.164形式の電話番号
+//
+// 戻り値:
+//   - string: 送信者ID、または "+" で始まる発信元番号（空の場合はSNSの既定）
+func smsSenderFor(senders map[string]string, defaultSender, phoneNumber string) string {
+	digits := strings.TrimPrefix(phoneNumber, "+")
+	sender, matched := defaultSender, 0
+	for code, s := range senders {
+		if len(code) > matched && strings.HasPrefix(digits, code) {
+			sender, matched = s, len(code)
+		}
+	}
+	return sender
+}
+
+// smsMessageAttributes はSMS Publishのメッセージ属性を作成します。
+// 重要アラートと認証コードのみを送るため、常に Transactional（配信優先）で送信します。
+func smsMessageAttributes(sender string) map[string]snstypes.MessageAttributeValue {
+	attrs := map[string]snstypes.MessageAttributeValue{
+		"AWS.SNS.SMS.SMSType": {DataType: aws.String("String"), StringValue: aws.String("Transactional")},
+	}
+	switch {
+	case strings.HasPrefix(sender, "+"):
+		attrs["AWS.MM.SMS.OriginationNumber"] = snstypes.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(sender)}
+	case sender != "":
+		attrs["AWS.SNS.SMS.SenderID"] = snstypes.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(sender)}
+	}
+	return attrs
+}
+
+// SendSMSNotification はSNSでSMSを送信します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - phoneNumber: 送信先の電話番号（E.164形式）
+//   - message: SMS本文
+//
+// 戻り値:
+//   - error: 送信に失敗した場合のエラー
+func (n *notificationSender) SendSMSNotification(ctx context.Context, phoneNumber, message string) error {
+	if phoneNumber == "" {
+		return fmt.Errorf("phone number is empty")
+	}
+
+	sender := smsSenderFor(n.cfg.SMSSenders, n.cfg.SMSDefaultSender, phoneNumber)
+	return n.sendWithRetry(ctx, func() error {
+		_, err := n.snsClient.Publish(ctx, &sns.PublishInput{
+			PhoneNumber:       aws.String(phoneNumber),
+			Message:           aws.String(message),
+			MessageAttributes: smsMessageAttributes(sender),
+		})
+		return err
+	})
+}
@@ -0,0 +1,161 @@
+// Package service - SNS Endpoint Cache Tests
+//
+// SNSエンドポイントARNのキャッシュのテストを提供します。
+// テスト対象:
+//   - エンドポイントARNのキャッシュと再利用
+//   - 無効化されたエンドポイントの作り直し
+//   - トークン再登録時のキャッシュのクリア
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+const testSNSPlatformARN = "arn:aws:sns:ap-northeast-1:123456789012:app/APNS/garden"
+
+// fakeSNSClient はSNSの呼び出しを記録するテスト用クライアントです。
+// disabled に含まれるエンドポイントへの送信は EndpointDisabledException を返します。
+type fakeSNSClient struct {
+	created   int
+	deleted   []string
+	published []string
+	disabled  map[string]bool
+}
+
+func (f *fakeSNSClient) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	arn := aws.ToString(params.TargetArn)
+	f.published = append(f.published, arn)
+	if f.disabled[arn] {
+		return nil, &snstypes.EndpointDisabledException{Message: aws.String("Endpoint is disabled")}
+	}
+	return &sns.PublishOutput{}, nil
+}
+
+func (f *fakeSNSClient) CreatePlatformEndpoint(ctx context.Context, params *sns.CreatePlatformEndpointInput, optFns ...func(*sns.Options)) (*sns.CreatePlatformEndpointOutput, error) {
+	f.created++
+	arn := fmt.Sprintf("arn:aws:sns:ap-northeast-1:123456789012:endpoint/APNS/garden/%d", f.created)
+	return &sns.CreatePlatformEndpointOutput{EndpointArn: aws.String(arn)}, nil
+}
+
+func (f *fakeSNSClient) DeleteEndpoint(ctx context.Context, params *sns.DeleteEndpointInput, optFns ...func(*sns.Options)) (*sns.DeleteEndpointOutput, error) {
+	f.deleted = append(f.deleted, aws.ToString(params.EndpointArn))
+	return &sns.DeleteEndpointOutput{}, nil
+}
+
+// newTestSNSSender はフェイクのSNSクライアントを使うnotificationSenderを作成します。
+func newTestSNSSender(client *fakeSNSClient, deviceTokens repository.DeviceTokenRepository) *notificationSender {
+	return &notificationSender{
+		snsClient:    client,
+		cfg:          &config.NotificationConfig{SNSPlatformARNiOS: testSNSPlatformARN, MaxRetries: 1, InitialBackoffMs: 1},
+		deviceTokens: deviceTokens,
+	}
+}
+
+// TestSendPushNotification_CachesEndpoint はエンドポイントARNのキャッシュのテストです。
+// 期待動作:
+//   - 初回の送信でエンドポイントを作成し、ARNをデバイストークンに保存する
+//   - 2回目以降はキャッシュしたARNへ直接送信する（エンドポイントを作成しない）
+//   - 別のプラットフォームアプリケーションのARNはキャッシュとして使わない
+func TestSendPushNotification_CachesEndpoint(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	client := &fakeSNSClient{}
+	sender := newTestSNSSender(client, mockRepos.DeviceToken())
+	ctx := context.Background()
+	token := &model.DeviceToken{UserID: 1, Token: "apns-token", Platform: "ios", IsActive: true}
+	_ = mockRepos.DeviceToken().Create(ctx, token)
+
+	// Act
+	first, _ := mockRepos.DeviceToken().GetActiveByUserID(ctx, 1)
+	err := sender.SendPushNotification(ctx, &first[0], "収穫", "本文", nil)
+	second, _ := mockRepos.DeviceToken().GetActiveByUserID(ctx, 1)
+	secondErr := sender.SendPushNotification(ctx, &second[0], "収穫", "本文", nil)
+
+	// Assert
+	if err != nil || secondErr != nil {
+		t.Fatalf("SendPushNotification failed: %v, %v", err, secondErr)
+	}
+	if client.created != 1 || len(client.published) != 2 {
+		t.Errorf("Expected 1 endpoint creation and 2 publishes, got %d, %v", client.created, client.published)
+	}
+	if second[0].EndpointARN != client.published[0] {
+		t.Errorf("Expected cached endpoint %q, got %q", client.published[0], second[0].EndpointARN)
+	}
+
+	stale := model.DeviceToken{ID: token.ID, Token: "apns-token", Platform: "ios",
+		EndpointARN: "arn:aws:sns:ap-northeast-1:123456789012:endpoint/APNS/old-app/1"}
+	_ = sender.SendPushNotification(ctx, &stale, "収穫", "本文", nil)
+	if client.created != 2 {
+		t.Errorf("Expected endpoint of another application not to be reused, got %d creations", client.created)
+	}
+}
+
+// TestSendPushNotification_RecreatesDisabledEndpoint は無効化されたエンドポイントの作り直しのテストです。
+// 期待動作:
+//   - キャッシュしたエンドポイントが無効化されている場合は削除して作り直し、送信する
+//   - 作り直したエンドポイントも無効化されている場合は InvalidDeviceTokenError
+func TestSendPushNotification_RecreatesDisabledEndpoint(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	disabledARN := "arn:aws:sns:ap-northeast-1:123456789012:endpoint/APNS/garden/0"
+	client := &fakeSNSClient{disabled: map[string]bool{disabledARN: true}}
+	sender := newTestSNSSender(client, mockRepos.DeviceToken())
+	ctx := context.Background()
+	token := &model.DeviceToken{UserID: 1, Token: "apns-token", Platform: "ios", IsActive: true, EndpointARN: disabledARN}
+	_ = mockRepos.DeviceToken().Create(ctx, token)
+
+	// Act
+	err := sender.SendPushNotification(ctx, token, "収穫", "本文", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SendPushNotification failed: %v", err)
+	}
+	if len(client.deleted) != 1 || client.deleted[0] != disabledARN || client.created != 1 {
+		t.Errorf("Expected disabled endpoint to be recreated, got deleted=%v created=%d", client.deleted, client.created)
+	}
+	stored, _ := mockRepos.DeviceToken().GetByID(ctx, token.ID)
+	if stored.EndpointARN == disabledARN || stored.EndpointARN == "" {
+		t.Errorf("Expected new endpoint to be cached, got %q", stored.EndpointARN)
+	}
+
+	// 作り直したエンドポイントも無効化されている場合
+	client.disabled[stored.EndpointARN] = true
+	client.disabled["arn:aws:sns:ap-northeast-1:123456789012:endpoint/APNS/garden/2"] = true
+	err = sender.SendPushNotification(ctx, stored, "収穫", "本文", nil)
+	var invalid *InvalidDeviceTokenError
+	if !errors.As(err, &invalid) || invalid.Reason != DeviceTokenReasonSNSEndpointDisabled {
+		t.Errorf("Expected InvalidDeviceTokenError, got %v", err)
+	}
+}
+
+// TestRegisterDeviceToken_ClearsEndpointCache はトークン再登録時のキャッシュのクリアのテストです。
+func TestRegisterDeviceToken_ClearsEndpointCache(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	token, _ := svc.RegisterDeviceToken(ctx, 1, "old-token", "ios", "")
+	_ = mockRepos.DeviceToken().UpdateEndpointARN(ctx, token.ID, "arn:aws:sns:ap-northeast-1:123456789012:endpoint/APNS/garden/1")
+
+	// Act
+	updated, err := svc.RegisterDeviceToken(ctx, 1, "new-token", "ios", "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RegisterDeviceToken failed: %v", err)
+	}
+	if updated.EndpointARN != "" {
+		t.Errorf("Expected endpoint cache to be cleared, got %q", updated.EndpointARN)
+	}
+}
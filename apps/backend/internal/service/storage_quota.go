@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Storage Quota - ユーザーごとの保存容量
+// =============================================================================
+// 写真（model.Photo.SizeBytes）、作物・区画の添付ファイル（model.Attachment.SizeBytes）と
+// 保存先にファイルが残っているエクスポート（model.ExportRecord.FileSizeBytes）の
+// 合計をユーザーの保存容量とし、上限（STORAGE_USER_QUOTA_BYTES）を超える画像・添付ファイルのアップロードを拒否します。
+// サーバー経由・作物の画像のアップロード（/crops/images/...）は記録を作成しないため集計に含めませんが、
+// 上限を超えている場合は拒否します。
+//
+// 整理の候補:
+//   - orphaned_photos: 作物・収穫記録が削除された（ゴミ箱を含む）写真（DELETE /photos/:id で削除、
+//     削除しない場合も保持期間の後にデータの保持期間の処理で削除）
+
+// 保存容量の種類
+const (
+	StorageUsageTypePhotos      = "photos"
+	StorageUsageTypeAttachments = "attachments"
+	StorageUsageTypeExports     = "exports"
+)
+
+// 整理の候補の種類
+const (
+	StorageSuggestionOrphanedPhotos = "orphaned_photos"
+)
+
+var (
+	// ErrStorageQuotaExceeded はアップロードでユーザーの保存容量の上限を超える場合のエラー
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// StorageUsage はユーザーの保存容量と整理の候補です。
+type StorageUsage struct {
+	UsedBytes   int64                      `json:"used_bytes"`
+	QuotaBytes  int64                      `json:"quota_bytes"` // 上限（0 の場合は無制限）
+	ByType      []StorageUsageByType       `json:"by_type"`     // 種類（photos, attachments, exports）ごとの件数と容量
+	Suggestions []StorageCleanupSuggestion `json:"suggestions"` // 整理の候補（候補がない種類は含めない）
+}
+
+// StorageUsageByType は種類ごとの件数と容量です。
+type StorageUsageByType struct {
+	Type  string `json:"type"` // photos, attachments, exports
+	Count int64  `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+// StorageCleanupSuggestion は整理の候補です。
+type StorageCleanupSuggestion struct {
+	Type     string `json:"type"`      // orphaned_photos
+	Count    int64  `json:"count"`     // 候補の件数
+	Bytes    int64  `json:"bytes"`     // 削除で空く容量
+	PhotoIDs []uint `json:"photo_ids"` // 候補の写真のID（古い順）
+}
+
+// SetStorageQuota はユーザーごとの保存容量の上限（バイト）を設定します。
+// 未設定・0 の場合は無制限です。
+func (s *Service) SetStorageQuota(quotaBytes int64) {
+	s.storageQuota = quotaBytes
+}
+
+// GetStorageUsage はユーザーの保存容量を種類ごとに集計し、整理の候補を返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - *StorageUsage: 保存容量と整理の候補
+//   - error: 集計に失敗した場合のエラー
+func (s *Service) GetStorageUsage(ctx context.Context, userID uint) (*StorageUsage, error) {
+	totals, err := s.storageTotals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &StorageUsage{
+		QuotaBytes:  s.storageQuota,
+		ByType:      totals,
+		Suggestions: []StorageCleanupSuggestion{},
+	}
+	for _, total := range totals {
+		usage.UsedBytes += total.Bytes
+	}
+
+	orphaned, err := s.repos.Photo().GetOrphanedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned photos: %w", err)
+	}
+	if len(orphaned) > 0 {
+		suggestion := StorageCleanupSuggestion{Type: StorageSuggestionOrphanedPhotos, PhotoIDs: make([]uint, 0, len(orphaned))}
+		for _, photo := range orphaned {
+			suggestion.Count++
+			suggestion.Bytes += photo.SizeBytes
+			suggestion.PhotoIDs = append(suggestion.PhotoIDs, photo.ID)
+		}
+		usage.Suggestions = append(usage.Suggestions, suggestion)
+	}
+	return usage, nil
+}
+
+// CheckStorageQuota はアップロードでユーザーの保存容量が上限を超えないか確認します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - additionalBytes: アップロードするサイズ（バイト）
+//
+// 戻り値:
+//   - error: 上限を超える場合は ErrStorageQuotaExceeded、集計に失敗した場合のエラー
+func (s *Service) CheckStorageQuota(ctx context.Context, userID uint, additionalBytes int64) error {
+	if s.storageQuota <= 0 {
+		return nil
+	}
+	totals, err := s.storageTotals(ctx, userID)
+	if err != nil {
+		return err
+	}
+	usedBytes := additionalBytes
+	for _, total := range totals {
+		usedBytes += total.Bytes
+	}
+	if usedBytes > s.storageQuota {
+		return ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// storageTotals はユーザーの写真・添付ファイル・エクスポートの件数・容量の合計を種類ごとに返します。
+func (s *Service) storageTotals(ctx context.Context, userID uint) ([]StorageUsageByType, error) {
+	sources := []struct {
+		usageType string
+		total     func(ctx context.Context, userID uint) (repository.StorageTotal, error)
+	}{
+		{StorageUsageTypePhotos, s.repos.Photo().GetStorageTotalByUserID},
+		{StorageUsageTypeAttachments, s.repos.Attachment().GetStorageTotalByUserID},
+		{StorageUsageTypeExports, s.repos.ExportRecord().GetStorageTotalByUserID},
+	}
+	totals := make([]StorageUsageByType, 0, len(sources))
+	for _, source := range sources {
+		total, err := source.total(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum %s storage: %w", source.usageType, err)
+		}
+		totals = append(totals, StorageUsageByType{Type: source.usageType, Count: total.Count, Bytes: total.Bytes})
+	}
+	return totals, nil
+}
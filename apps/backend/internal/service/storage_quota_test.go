@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Storage Quota Tests - ユーザーごとの保存容量のテスト
+// =============================================================================
+// テスト対象:
+//   - GetStorageUsage: 写真・添付ファイル・エクスポートの種類ごとの集計、作物が削除された写真の整理の候補
+//   - CheckStorageQuota / CreatePhoto: 上限を超えるアップロードの拒否と元の画像の削除
+//   - DeletePhoto: 保存先の画像の削除と写真の論理削除
+
+// TestGetStorageUsage は保存容量の集計と整理の候補のテストです。
+// 期待動作:
+//   - 写真・添付ファイルの SizeBytes と保存先にファイルが残っているエクスポートの容量を種類ごとに合計する
+//   - 他ユーザーの写真・ファイルが削除されたエクスポートは含めない
+//   - 作物が削除された（ゴミ箱を含む）写真は orphaned_photos の候補で、作物が残っている写真は含めない
+func TestGetStorageUsage(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetStorageQuota(10000)
+	ctx := context.Background()
+
+	activeCrop := &model.Crop{UserID: 1, Name: "トマト"}
+	deletedCrop := &model.Crop{UserID: 1, Name: "ナス"}
+	_ = mockRepos.Crop().Create(ctx, activeCrop)
+	_ = mockRepos.Crop().Create(ctx, deletedCrop)
+	_ = mockRepos.Crop().Delete(ctx, deletedCrop.ID)
+
+	photos := mockRepos.Photo()
+	_ = photos.Create(ctx, &model.Photo{UserID: 1, CropID: &activeCrop.ID, SizeBytes: 1000, Status: PhotoStatusCompleted})
+	orphaned := &model.Photo{UserID: 1, CropID: &deletedCrop.ID, SizeBytes: 300, Status: PhotoStatusCompleted}
+	_ = photos.Create(ctx, orphaned)
+	_ = photos.Create(ctx, &model.Photo{UserID: 2, SizeBytes: 5000, Status: PhotoStatusCompleted})
+
+	_ = mockRepos.Attachment().Create(ctx, &model.Attachment{UserID: 1, ParentType: AttachmentParentCrop, ParentID: activeCrop.ID, SizeBytes: 50})
+	_ = mockRepos.Attachment().Create(ctx, &model.Attachment{UserID: 2, ParentType: AttachmentParentCrop, ParentID: activeCrop.ID, SizeBytes: 4000})
+
+	exports := mockRepos.ExportRecord()
+	_ = exports.Create(ctx, &model.ExportRecord{UserID: 1, FileSizeBytes: 200, S3Key: "exports/1/2026/05/crops.csv"})
+	_ = exports.Create(ctx, &model.ExportRecord{UserID: 1, FileSizeBytes: 700})
+
+	// Act
+	usage, err := svc.GetStorageUsage(ctx, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetStorageUsage failed: %v", err)
+	}
+	if usage.UsedBytes != 1550 || usage.QuotaBytes != 10000 {
+		t.Errorf("Expected 1550 of 10000 bytes, got %d of %d", usage.UsedBytes, usage.QuotaBytes)
+	}
+	want := []StorageUsageByType{
+		{Type: StorageUsageTypePhotos, Count: 2, Bytes: 1300},
+		{Type: StorageUsageTypeAttachments, Count: 1, Bytes: 50},
+		{Type: StorageUsageTypeExports, Count: 1, Bytes: 200},
+	}
+	if len(usage.ByType) != 3 || usage.ByType[0] != want[0] || usage.ByType[1] != want[1] || usage.ByType[2] != want[2] {
+		t.Errorf("Expected %+v, got %+v", want, usage.ByType)
+	}
+	if len(usage.Suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %+v", usage.Suggestions)
+	}
+	suggestion := usage.Suggestions[0]
+	if suggestion.Type != StorageSuggestionOrphanedPhotos || suggestion.Bytes != 300 || len(suggestion.PhotoIDs) != 1 || suggestion.PhotoIDs[0] != orphaned.ID {
+		t.Errorf("Expected the photo of the deleted crop as a suggestion, got %+v", suggestion)
+	}
+}
+
+// TestCreatePhoto_StorageQuota は保存容量の上限のテストです。
+// 期待動作:
+//   - 上限を超えない写真は登録する
+//   - 上限を超える写真は ErrStorageQuotaExceeded で、アップロードした元の画像を削除する
+//   - 他ユーザーの作物を指定した写真は ErrPhotoCropNotFound
+//   - 上限が 0 の場合は無制限
+func TestCreatePhoto_StorageQuota(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	svc.SetJobQueue(&mockPhotoJobQueue{})
+	svc.SetStorageQuota(1000)
+	ctx := context.Background()
+	storage := &mockPhotoStorage{objects: map[string][]byte{
+		"uploads/1/2026/05/first.jpg":  []byte("first"),
+		"uploads/1/2026/05/second.jpg": []byte("second"),
+		"uploads/1/2026/05/crop.jpg":   []byte("crop"),
+	}}
+	svc.SetPhotoStorage(storage)
+	otherCrop := &model.Crop{UserID: 2, Name: "キュウリ"}
+	_ = mockRepos.Crop().Create(ctx, otherCrop)
+
+	// Act
+	_, firstErr := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: "uploads/1/2026/05/first.jpg", SizeBytes: 800})
+	_, secondErr := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: "uploads/1/2026/05/second.jpg", SizeBytes: 201})
+	_, cropErr := svc.CreatePhoto(ctx, 1, PhotoUpload{SourceKey: "uploads/1/2026/05/crop.jpg", SizeBytes: 10, CropID: &otherCrop.ID})
+	svc.SetStorageQuota(0)
+	unlimitedErr := svc.CheckStorageQuota(ctx, 1, 1<<40)
+
+	// Assert
+	if firstErr != nil {
+		t.Errorf("Expected the photo within the quota to be created, got %v", firstErr)
+	}
+	if !errors.Is(secondErr, ErrStorageQuotaExceeded) {
+		t.Errorf("Expected ErrStorageQuotaExceeded, got %v", secondErr)
+	}
+	if _, ok := storage.objects["uploads/1/2026/05/second.jpg"]; ok {
+		t.Error("Expected the rejected upload to be deleted")
+	}
+	if !errors.Is(cropErr, ErrPhotoCropNotFound) {
+		t.Errorf("Expected ErrPhotoCropNotFound, got %v", cropErr)
+	}
+	if unlimitedErr != nil {
+		t.Errorf("Expected no quota when the quota is 0, got %v", unlimitedErr)
+	}
+}
+
+// TestDeletePhoto は写真の削除のテストです。
+// 期待動作:
+//   - 他ユーザーの写真は ErrPhotoNotOwned で削除しない
+//   - 保存先のバリアントを削除して CDN のキャッシュの削除を依頼し、写真は取得できなくなり保存容量から外れる
+func TestDeletePhoto(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	thumbnailKey := "photos/1/1/thumbnail.webp"
+	storage := &mockPhotoStorage{objects: map[string][]byte{thumbnailKey: []byte("thumbnail")}}
+	svc.SetPhotoStorage(storage)
+	photo := &model.Photo{UserID: 1, SourceKey: "uploads/1/2026/05/source.jpg", ThumbnailKey: thumbnailKey, SizeBytes: 9, Status: PhotoStatusCompleted}
+	_ = mockRepos.Photo().Create(ctx, photo)
+
+	// Act
+	notOwnedErr := svc.DeletePhoto(ctx, 2, photo.ID)
+	deleteErr := svc.DeletePhoto(ctx, 1, photo.ID)
+
+	// Assert
+	if !errors.Is(notOwnedErr, ErrPhotoNotOwned) {
+		t.Errorf("Expected ErrPhotoNotOwned, got %v", notOwnedErr)
+	}
+	if deleteErr != nil {
+		t.Fatalf("DeletePhoto failed: %v", deleteErr)
+	}
+	if len(storage.objects) != 0 {
+		t.Errorf("Expected the variants to be deleted, got %d objects", len(storage.objects))
+	}
+	if len(storage.invalidated) != 1 || storage.invalidated[0] != thumbnailKey {
+		t.Errorf("Expected a CDN invalidation of %s, got %v", thumbnailKey, storage.invalidated)
+	}
+	if _, err := svc.GetPhoto(ctx, 1, photo.ID); err == nil {
+		t.Error("Expected the deleted photo not to be found")
+	}
+	if usage, _ := svc.GetStorageUsage(ctx, 1); usage.UsedBytes != 0 {
+		t.Errorf("Expected no usage after the delete, got %d", usage.UsedBytes)
+	}
+}
@@ -0,0 +1,696 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/dto"
+	"github.com/secure-scorecard/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Offline Sync - オフライン同期（差分の取得・クライアントの変更の反映）
+// =============================================================================
+// モバイルアプリがオフラインで記録した変更をまとめて反映し、前回の同期以降のサーバーの変更を取得します。
+//   - 取得（GetSyncChanges）: カーソル以降に作成・更新・削除されたタスク・作物・区画・収穫記録
+//   - 反映（PushSyncChanges）: クライアントの作成・更新・削除を1つのトランザクションで反映
+//
+// 削除は削除の記録（model.SyncTombstone）から返します。削除の記録は保持期間（sync_tombstones）を
+// 過ぎると削除するため、それより古いカーソルは ErrSyncCursorExpired になり、全件の再取得が必要です。
+//
+// 競合の判定: クライアントは最後に取得したサーバーの updated_at を base_updated_at に指定し、
+// サーバーの記録がそれより後に更新されている場合は競合とします。
+//   - server_wins（デフォルト）: 競合した変更は反映せず、サーバーの記録を返す
+//   - client_wins: 競合してもクライアントの変更を反映する（サーバーで削除済みの記録の更新は新しい記録として作成）
+
+// 同期の対象
+const (
+	SyncEntityTasks    = "tasks"
+	SyncEntityCrops    = "crops"
+	SyncEntityPlots    = "plots"
+	SyncEntityHarvests = "harvests"
+)
+
+// 変更の操作
+const (
+	SyncOpUpsert = "upsert"
+	SyncOpDelete = "delete"
+)
+
+// 競合の解決方法
+const (
+	SyncStrategyServerWins = "server_wins"
+	SyncStrategyClientWins = "client_wins"
+)
+
+// 変更の反映結果
+const (
+	SyncResultApplied  = "applied"
+	SyncResultConflict = "conflict"
+	SyncResultRejected = "rejected"
+)
+
+const (
+	// MaxSyncPushChanges は1回の反映で指定できる変更の最大数
+	MaxSyncPushChanges = 500
+
+	// SyncCommitLag は次のカーソルを現在時刻より前にする時間です。
+	// 取得中にコミットされたトランザクションの変更（更新日時がカーソルより前）を次回の同期で取得するため、
+	// 次回の同期はこの時間分の変更を重複して返します（クライアントはIDで上書きする）。
+	SyncCommitLag = 5 * time.Second
+)
+
+var (
+	// ErrInvalidSyncCursor は読み取れない同期のカーソルを指定した場合のエラー
+	ErrInvalidSyncCursor = errors.New("invalid sync cursor")
+	// ErrSyncCursorExpired はカーソルが削除の記録の保持期間より古い場合のエラー（全件の再取得が必要）
+	ErrSyncCursorExpired = errors.New("sync cursor has expired")
+	// ErrInvalidSyncStrategy は不正な競合の解決方法を指定した場合のエラー
+	ErrInvalidSyncStrategy = errors.New("invalid sync conflict strategy")
+	// ErrTooManySyncChanges は変更の数が MaxSyncPushChanges を超えた場合のエラー
+	ErrTooManySyncChanges = errors.New("too many sync changes")
+)
+
+// SyncEntityChanges は1種類の記録の変更です。
+type SyncEntityChanges[T any] struct {
+	Created []T    `json:"created"` // カーソル以降に作成された記録
+	Updated []T    `json:"updated"` // カーソルより前に作成され、カーソル以降に更新された記録
+	Deleted []uint `json:"deleted"` // カーソル以降に削除された記録のID
+}
+
+// SyncChanges は同期の取得結果です。
+type SyncChanges struct {
+	Tasks      SyncEntityChanges[model.Task]    `json:"tasks"`
+	Crops      SyncEntityChanges[model.Crop]    `json:"crops"`
+	Plots      SyncEntityChanges[model.Plot]    `json:"plots"`
+	Harvests   SyncEntityChanges[model.Harvest] `json:"harvests"`
+	Full       bool                             `json:"full"`        // カーソルなしの全件の取得か（クライアントは手元の記録を置き換える）
+	NextCursor string                           `json:"next_cursor"` // 次回の同期で since に指定するカーソル
+	ServerTime time.Time                        `json:"server_time"`
+}
+
+// MarshalJSON はレスポンスの形式（dto の記録の形式）で JSON にします。
+func (c SyncChanges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Tasks      SyncEntityChanges[dto.TaskResponse]    `json:"tasks"`
+		Crops      SyncEntityChanges[dto.CropResponse]    `json:"crops"`
+		Plots      SyncEntityChanges[dto.PlotResponse]    `json:"plots"`
+		Harvests   SyncEntityChanges[dto.HarvestResponse] `json:"harvests"`
+		Full       bool                                   `json:"full"`
+		NextCursor string                                 `json:"next_cursor"`
+		ServerTime time.Time                              `json:"server_time"`
+	}{
+		Tasks:      syncEntityResponses(c.Tasks, dto.NewTaskResponse),
+		Crops:      syncEntityResponses(c.Crops, dto.NewCropResponse),
+		Plots:      syncEntityResponses(c.Plots, dto.NewPlotResponse),
+		Harvests:   syncEntityResponses(c.Harvests, dto.NewHarvestResponse),
+		Full:       c.Full,
+		NextCursor: c.NextCursor,
+		ServerTime: c.ServerTime,
+	})
+}
+
+// syncEntityResponses は1種類の記録の変更をレスポンスの形式に変換します。
+func syncEntityResponses[T, R any](changes SyncEntityChanges[T], convert func(*T) R) SyncEntityChanges[R] {
+	return SyncEntityChanges[R]{
+		Created: dto.List(changes.Created, convert),
+		Updated: dto.List(changes.Updated, convert),
+		Deleted: changes.Deleted,
+	}
+}
+
+// SyncChange はクライアントの1件の変更です。
+type SyncChange struct {
+	Entity        string          `json:"entity"`                    // tasks, crops, plots, harvests
+	Op            string          `json:"op"`                        // upsert, delete
+	ID            uint            `json:"id,omitempty"`              // サーバーの記録のID（0 の場合は新規作成）
+	ClientID      string          `json:"client_id,omitempty"`       // クライアントの一時的なID（新規作成した記録のIDの対応付けに使用）
+	BaseUpdatedAt *time.Time      `json:"base_updated_at,omitempty"` // クライアントが最後に取得したサーバーの updated_at
+	Data          json.RawMessage `json:"data,omitempty"`            // upsert の記録の内容（一覧・取得のエンドポイントと同じ形式）
+}
+
+// SyncChangeResult はクライアントの1件の変更の反映結果です。
+type SyncChangeResult struct {
+	Index    int    `json:"index"` // changes の位置
+	Entity   string `json:"entity"`
+	ClientID string `json:"client_id,omitempty"`
+	ID       uint   `json:"id,omitempty"`       // 反映後（競合の場合は現在）のサーバーの記録のID
+	Status   string `json:"status"`             // applied, conflict, rejected
+	Conflict string `json:"conflict,omitempty"` // updated（サーバーで更新済み）, deleted（サーバーで削除済み）
+	Error    string `json:"error,omitempty"`    // rejected の理由
+	Record   any    `json:"record,omitempty"`   // 反映後（競合の場合は現在）のサーバーの記録
+}
+
+// SyncPushResult はクライアントの変更の反映結果です。
+type SyncPushResult struct {
+	Results   []SyncChangeResult `json:"results"`
+	Applied   int                `json:"applied"`
+	Conflicts int                `json:"conflicts"`
+	Rejected  int                `json:"rejected"`
+}
+
+// syncCursor は同期のカーソルの文字列の中身です。
+type syncCursor struct {
+	Time time.Time `json:"t"`
+}
+
+// EncodeSyncCursor は日時を同期のカーソルの文字列にします。
+func EncodeSyncCursor(t time.Time) string {
+	payload, _ := json.Marshal(syncCursor{Time: t.UTC()})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeSyncCursor は同期のカーソルの文字列から日時を取り出します。
+func DecodeSyncCursor(token string) (time.Time, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, ErrInvalidSyncCursor
+	}
+	var c syncCursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.Time.IsZero() {
+		return time.Time{}, ErrInvalidSyncCursor
+	}
+	return c.Time, nil
+}
+
+// GetSyncChanges はカーソル以降に作成・更新・削除された記録を取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - cursor: 前回の同期の next_cursor（空の場合は全件の取得）
+//
+// 戻り値:
+//   - *SyncChanges: 種類ごとの作成・更新・削除と次のカーソル
+//   - error: ErrInvalidSyncCursor / ErrSyncCursorExpired、または取得に失敗した場合のエラー
+func (s *Service) GetSyncChanges(ctx context.Context, userID uint, cursor string) (*SyncChanges, error) {
+	now := s.now()
+	var since time.Time
+	if cursor != "" {
+		var err error
+		if since, err = DecodeSyncCursor(cursor); err != nil {
+			return nil, err
+		}
+		if days := s.syncTombstoneRetentionDays(); days > 0 && since.Before(now.AddDate(0, 0, -days)) {
+			return nil, ErrSyncCursorExpired
+		}
+	}
+
+	// 取得の前に現在時刻を決め、取得中の変更は次回の同期で返す
+	changes := &SyncChanges{Full: since.IsZero(), ServerTime: now}
+	repo := s.repos.Sync()
+
+	tasks, err := repo.ChangedTasks(ctx, userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+	changes.Tasks.Created, changes.Tasks.Updated = splitSyncChanges(tasks, since, func(t model.Task) time.Time { return t.CreatedAt })
+
+	crops, err := repo.ChangedCrops(ctx, userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+	changes.Crops.Created, changes.Crops.Updated = splitSyncChanges(crops, since, func(c model.Crop) time.Time { return c.CreatedAt })
+
+	plots, err := repo.ChangedPlots(ctx, userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+	changes.Plots.Created, changes.Plots.Updated = splitSyncChanges(plots, since, func(p model.Plot) time.Time { return p.CreatedAt })
+
+	harvests, err := repo.ChangedHarvests(ctx, userID, since, now)
+	if err != nil {
+		return nil, err
+	}
+	changes.Harvests.Created, changes.Harvests.Updated = splitSyncChanges(harvests, since, func(h model.Harvest) time.Time { return h.CreatedAt })
+
+	changes.Tasks.Deleted, changes.Crops.Deleted = []uint{}, []uint{}
+	changes.Plots.Deleted, changes.Harvests.Deleted = []uint{}, []uint{}
+	// 全件の取得では手元の記録を置き換えるため、削除は返さない
+	if !changes.Full {
+		tombstones, err := repo.GetTombstones(ctx, userID, since, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, tombstone := range tombstones {
+			switch tombstone.Entity {
+			case SyncEntityTasks:
+				changes.Tasks.Deleted = append(changes.Tasks.Deleted, tombstone.EntityID)
+			case SyncEntityCrops:
+				changes.Crops.Deleted = append(changes.Crops.Deleted, tombstone.EntityID)
+			case SyncEntityPlots:
+				changes.Plots.Deleted = append(changes.Plots.Deleted, tombstone.EntityID)
+			case SyncEntityHarvests:
+				changes.Harvests.Deleted = append(changes.Harvests.Deleted, tombstone.EntityID)
+			}
+		}
+	}
+
+	next := now.Add(-SyncCommitLag)
+	if next.Before(since) {
+		next = since
+	}
+	changes.NextCursor = EncodeSyncCursor(next)
+	return changes, nil
+}
+
+// splitSyncChanges は記録をカーソル以降に作成されたものと更新されたものに分けます。
+func splitSyncChanges[T any](rows []T, since time.Time, createdAt func(T) time.Time) (created, updated []T) {
+	created, updated = []T{}, []T{}
+	for _, row := range rows {
+		if createdAt(row).After(since) {
+			created = append(created, row)
+		} else {
+			updated = append(updated, row)
+		}
+	}
+	return created, updated
+}
+
+// syncTombstoneRetentionDays は削除の記録の保持期間（日数、0 以下は無期限）を返します。
+func (s *Service) syncTombstoneRetentionDays() int {
+	for _, target := range config.RetentionTargets {
+		if target.Name == config.RetentionTargetSyncTombstones {
+			return s.retentionDays(target.Name, target.Days)
+		}
+	}
+	return 0
+}
+
+// recordTombstones は削除した記録の同期用の削除の記録を作成します。
+func (s *Service) recordTombstones(ctx context.Context, userID uint, entity string, ids ...uint) error {
+	now := s.now()
+	tombstones := make([]model.SyncTombstone, 0, len(ids))
+	for _, id := range ids {
+		tombstones = append(tombstones, model.SyncTombstone{UserID: userID, Entity: entity, EntityID: id, DeletedAt: now})
+	}
+	return s.repos.Sync().CreateTombstones(ctx, tombstones)
+}
+
+// ignoreNotFound は記録が見つからないエラーを nil にします（削除済みの記録の削除を成功とする）。
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// PushSyncChanges はクライアントの変更を順番に反映します。
+// 全ての変更を1つのトランザクションで反映し、競合・不正な変更は他の変更の反映を止めません。
+// 作成・更新・削除は通常のエンドポイントと同じサービスのメソッドで行います（削除の記録も作成）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - changes: クライアントの変更（記録した順）
+//   - strategy: 競合の解決方法（空の場合は server_wins）
+//
+// 戻り値:
+//   - *SyncPushResult: 変更ごとの反映結果
+//   - error: ErrInvalidSyncStrategy / ErrTooManySyncChanges、または反映に失敗した場合のエラー（何も反映しない）
+func (s *Service) PushSyncChanges(ctx context.Context, userID uint, changes []SyncChange, strategy string) (*SyncPushResult, error) {
+	if strategy == "" {
+		strategy = SyncStrategyServerWins
+	}
+	if strategy != SyncStrategyServerWins && strategy != SyncStrategyClientWins {
+		return nil, ErrInvalidSyncStrategy
+	}
+	if len(changes) > MaxSyncPushChanges {
+		return nil, ErrTooManySyncChanges
+	}
+
+	result := &SyncPushResult{Results: make([]SyncChangeResult, 0, len(changes))}
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		for i, change := range changes {
+			res := SyncChangeResult{Index: i, Entity: change.Entity, ClientID: change.ClientID, ID: change.ID}
+			applier, ok := s.syncAppliers()[change.Entity]
+			switch {
+			case !ok:
+				res.reject(fmt.Sprintf("unsupported entity %q", change.Entity))
+			case change.Op != SyncOpUpsert && change.Op != SyncOpDelete:
+				res.reject(fmt.Sprintf("unsupported op %q", change.Op))
+			default:
+				if err := s.applySyncChange(txCtx, userID, change, strategy, applier, &res); err != nil {
+					return err
+				}
+			}
+
+			switch res.Status {
+			case SyncResultApplied:
+				result.Applied++
+			case SyncResultConflict:
+				result.Conflicts++
+			default:
+				result.Rejected++
+			}
+			result.Results = append(result.Results, res)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reject は変更を反映しなかった結果にします。
+func (r *SyncChangeResult) reject(reason string) {
+	r.Status = SyncResultRejected
+	r.Error = reason
+}
+
+// errSyncRejected は変更の内容が不正な場合のエラーです（変更を rejected にし、他の変更の反映は続ける）。
+type errSyncRejected struct {
+	reason string
+}
+
+func (e *errSyncRejected) Error() string {
+	return e.reason
+}
+
+// syncRecord は同期の対象の記録の共通の操作です。
+type syncRecord interface {
+	syncID() uint
+	syncUpdatedAt() time.Time
+}
+
+// syncApplier は同期の対象ごとの記録の取得・作成・更新・削除です。
+type syncApplier struct {
+	// get はユーザーの記録を取得します（他のユーザーの記録は gorm.ErrRecordNotFound）
+	get func(ctx context.Context, userID, id uint) (syncRecord, error)
+	// save は data を記録（existing が nil の場合は新規）に反映して保存し、保存した記録を返します
+	save func(ctx context.Context, userID uint, existing syncRecord, data json.RawMessage) (syncRecord, error)
+	// remove は記録を削除します（削除の記録も作成）
+	remove func(ctx context.Context, id uint) error
+}
+
+// applySyncChange は1件の変更を競合を判定して反映し、結果を res に設定します。
+// 変更の内容が不正な場合は rejected にし、リポジトリのエラーのみ返します。
+func (s *Service) applySyncChange(ctx context.Context, userID uint, change SyncChange, strategy string, applier syncApplier, res *SyncChangeResult) error {
+	var existing syncRecord
+	if change.ID != 0 {
+		record, err := applier.get(ctx, userID, change.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err != nil {
+			// サーバーで削除済みの記録か、存在しない（他のユーザーの）記録
+			if _, tombErr := s.repos.Sync().GetTombstone(ctx, userID, change.Entity, change.ID); tombErr != nil {
+				if errors.Is(tombErr, gorm.ErrRecordNotFound) {
+					res.reject("record not found")
+					return nil
+				}
+				return tombErr
+			}
+			if change.Op == SyncOpDelete {
+				// 削除済みの記録の削除は成功とする
+				res.Status = SyncResultApplied
+				return nil
+			}
+			if strategy == SyncStrategyServerWins {
+				res.Status, res.Conflict = SyncResultConflict, "deleted"
+				return nil
+			}
+			// client_wins: 削除済みの記録の更新は新しい記録として作成する
+		} else {
+			existing = record
+			if strategy == SyncStrategyServerWins && syncConflicts(record, change.BaseUpdatedAt) {
+				res.Status, res.Conflict, res.Record = SyncResultConflict, "updated", record
+				return nil
+			}
+		}
+	}
+
+	if change.Op == SyncOpDelete {
+		if change.ID == 0 {
+			res.reject("id is required for delete")
+			return nil
+		}
+		if err := applier.remove(ctx, change.ID); err != nil {
+			return err
+		}
+		res.Status = SyncResultApplied
+		return nil
+	}
+
+	if len(change.Data) == 0 {
+		res.reject("data is required for upsert")
+		return nil
+	}
+	saved, err := applier.save(ctx, userID, existing, change.Data)
+	if err != nil {
+		var rejected *errSyncRejected
+		if errors.As(err, &rejected) {
+			res.reject(rejected.reason)
+			return nil
+		}
+		return err
+	}
+	res.Status, res.ID, res.Record = SyncResultApplied, saved.syncID(), saved
+	return nil
+}
+
+// syncConflicts はサーバーの記録がクライアントの取得した時点より後に更新されているかを判定します。
+// base_updated_at がない場合は、クライアントが記録の状態を知らないため競合とします。
+func syncConflicts(record syncRecord, baseUpdatedAt *time.Time) bool {
+	if baseUpdatedAt == nil {
+		return true
+	}
+	// データベースはマイクロ秒で保存するため、それより細かい差は無視する
+	return record.syncUpdatedAt().Truncate(time.Microsecond).After(baseUpdatedAt.Truncate(time.Microsecond))
+}
+
+// decodeSyncData は変更の内容を記録に反映します。
+func decodeSyncData(data json.RawMessage, dst any) error {
+	if err := json.Unmarshal(data, dst); err != nil {
+		return &errSyncRejected{reason: "invalid data: " + err.Error()}
+	}
+	return nil
+}
+
+// restoreSyncBase はクライアントが変更できないID・作成日時等をサーバーの値に戻します。
+func restoreSyncBase(dst *model.BaseModel, existing *model.BaseModel) {
+	if existing == nil {
+		*dst = model.BaseModel{}
+		return
+	}
+	*dst = *existing
+}
+
+// 同期の対象の記録（レスポンスでは通常のエンドポイントと同じ形式）
+type (
+	syncTask    struct{ model.Task }
+	syncCrop    struct{ model.Crop }
+	syncPlot    struct{ model.Plot }
+	syncHarvest struct{ model.Harvest }
+)
+
+func (t *syncTask) syncID() uint                { return t.ID }
+func (t *syncTask) syncUpdatedAt() time.Time    { return t.UpdatedAt }
+func (c *syncCrop) syncID() uint                { return c.ID }
+func (c *syncCrop) syncUpdatedAt() time.Time    { return c.UpdatedAt }
+func (p *syncPlot) syncID() uint                { return p.ID }
+func (p *syncPlot) syncUpdatedAt() time.Time    { return p.UpdatedAt }
+func (h *syncHarvest) syncID() uint             { return h.ID }
+func (h *syncHarvest) syncUpdatedAt() time.Time { return h.UpdatedAt }
+
+// MarshalJSON は反映結果の記録をレスポンスの形式（dto の記録の形式）で JSON にします。
+func (t *syncTask) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewTaskResponse(&t.Task))
+}
+
+func (c *syncCrop) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewCropResponse(&c.Crop))
+}
+
+func (p *syncPlot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewPlotResponse(&p.Plot))
+}
+
+func (h *syncHarvest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dto.NewHarvestResponse(&h.Harvest))
+}
+
+// syncAppliers は同期の対象ごとの記録の操作を返します。
+func (s *Service) syncAppliers() map[string]syncApplier {
+	return map[string]syncApplier{
+		SyncEntityTasks: {
+			get: func(ctx context.Context, userID, id uint) (syncRecord, error) {
+				task, err := s.repos.Task().GetByID(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if task.UserID != userID {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &syncTask{*task}, nil
+			},
+			save: func(ctx context.Context, userID uint, existing syncRecord, data json.RawMessage) (syncRecord, error) {
+				task := &model.Task{}
+				var base *model.BaseModel
+				if existing != nil {
+					*task = existing.(*syncTask).Task
+					base = &existing.(*syncTask).BaseModel
+				}
+				plantID, parentTaskID, version := task.PlantID, task.ParentTaskID, task.Version
+				if err := decodeSyncData(data, task); err != nil {
+					return nil, err
+				}
+				restoreSyncBase(&task.BaseModel, base)
+				// 植物（レガシー）・繰り返しの元タスクの関連は同期で変更しない
+				// バージョンもサーバーの値のまま（同期の競合は base_updated_at で判定する）
+				task.UserID, task.PlantID, task.ParentTaskID, task.Version = userID, plantID, parentTaskID, version
+				task.User, task.Plant, task.ParentTask = model.User{}, nil, nil
+				if strings.TrimSpace(task.Title) == "" || task.DueDate.IsZero() {
+					return nil, &errSyncRejected{reason: "title and due_date are required"}
+				}
+				var err error
+				if existing == nil {
+					err = s.CreateTask(ctx, task)
+				} else {
+					err = s.UpdateTask(ctx, task)
+				}
+				return &syncTask{*task}, err
+			},
+			remove: s.DeleteTask,
+		},
+		SyncEntityCrops: {
+			get: func(ctx context.Context, userID, id uint) (syncRecord, error) {
+				crop, err := s.repos.Crop().GetByID(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if crop.UserID != userID {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &syncCrop{*crop}, nil
+			},
+			save: func(ctx context.Context, userID uint, existing syncRecord, data json.RawMessage) (syncRecord, error) {
+				crop := &model.Crop{}
+				var base *model.BaseModel
+				if existing != nil {
+					*crop = existing.(*syncCrop).Crop
+					base = &existing.(*syncCrop).BaseModel
+				}
+				version := crop.Version
+				if err := decodeSyncData(data, crop); err != nil {
+					return nil, err
+				}
+				restoreSyncBase(&crop.BaseModel, base)
+				crop.UserID, crop.Version = userID, version
+				crop.User, crop.GrowthRecords, crop.Harvests = model.User{}, nil, nil
+				if strings.TrimSpace(crop.Name) == "" || crop.PlantedDate.IsZero() || crop.ExpectedHarvestDate.IsZero() {
+					return nil, &errSyncRejected{reason: "name, planted_date and expected_harvest_date are required"}
+				}
+				if crop.ExpectedHarvestDate.Before(crop.PlantedDate) {
+					return nil, &errSyncRejected{reason: "expected_harvest_date must be after planted_date"}
+				}
+				if crop.PlotID != nil {
+					plot, err := s.repos.Plot().GetByID(ctx, *crop.PlotID)
+					if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+						return nil, err
+					}
+					if err != nil || plot.UserID != userID {
+						return nil, &errSyncRejected{reason: "plot not found"}
+					}
+				}
+				var err error
+				if existing == nil {
+					err = s.CreateCrop(ctx, crop)
+				} else {
+					err = s.UpdateCrop(ctx, crop)
+				}
+				return &syncCrop{*crop}, err
+			},
+			remove: s.DeleteCrop,
+		},
+		SyncEntityPlots: {
+			get: func(ctx context.Context, userID, id uint) (syncRecord, error) {
+				plot, err := s.repos.Plot().GetByID(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if plot.UserID != userID {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &syncPlot{*plot}, nil
+			},
+			save: func(ctx context.Context, userID uint, existing syncRecord, data json.RawMessage) (syncRecord, error) {
+				plot := &model.Plot{}
+				var base *model.BaseModel
+				if existing != nil {
+					*plot = existing.(*syncPlot).Plot
+					base = &existing.(*syncPlot).BaseModel
+				}
+				version := plot.Version
+				if err := decodeSyncData(data, plot); err != nil {
+					return nil, err
+				}
+				restoreSyncBase(&plot.BaseModel, base)
+				plot.UserID, plot.Version = userID, version
+				plot.User, plot.PlotAssignments = model.User{}, nil
+				if strings.TrimSpace(plot.Name) == "" || plot.Width <= 0 || plot.Height <= 0 {
+					return nil, &errSyncRejected{reason: "name, width and height are required"}
+				}
+				var err error
+				if existing == nil {
+					err = s.CreatePlot(ctx, plot)
+				} else {
+					err = s.UpdatePlot(ctx, plot)
+				}
+				return &syncPlot{*plot}, err
+			},
+			remove: s.DeletePlot,
+		},
+		SyncEntityHarvests: {
+			get: func(ctx context.Context, userID, id uint) (syncRecord, error) {
+				harvest, err := s.repos.Harvest().GetByID(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				crop, err := s.repos.Crop().GetByID(ctx, harvest.CropID)
+				if err != nil {
+					return nil, err
+				}
+				if crop.UserID != userID {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &syncHarvest{*harvest}, nil
+			},
+			save: func(ctx context.Context, userID uint, existing syncRecord, data json.RawMessage) (syncRecord, error) {
+				// 収穫記録は更新のエンドポイントがないため、同期でも作成・削除のみ
+				if existing != nil {
+					return nil, &errSyncRejected{reason: "harvest updates are not supported"}
+				}
+				harvest := &model.Harvest{}
+				if err := decodeSyncData(data, harvest); err != nil {
+					return nil, err
+				}
+				restoreSyncBase(&harvest.BaseModel, nil)
+				harvest.Crop = model.Crop{}
+				crop, err := s.repos.Crop().GetByID(ctx, harvest.CropID)
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, err
+				}
+				if err != nil || crop.UserID != userID {
+					return nil, &errSyncRejected{reason: "crop not found"}
+				}
+				if harvest.Quantity <= 0 || harvest.QuantityUnit == "" || harvest.HarvestDate.IsZero() {
+					return nil, &errSyncRejected{reason: "harvest_date, quantity and quantity_unit are required"}
+				}
+				if err := s.CreateHarvest(ctx, harvest); err != nil {
+					return nil, err
+				}
+				return &syncHarvest{*harvest}, nil
+			},
+			remove: s.DeleteHarvest,
+		},
+	}
+}
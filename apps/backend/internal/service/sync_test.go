@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Offline Sync Tests - オフライン同期のテスト
+// =============================================================================
+// テスト対象:
+//   - GetSyncChanges: カーソル以降の作成・更新・削除の取得、全件の取得、期限切れ・不正なカーソル
+//   - DeleteTask / DeleteCrop: 削除の記録の作成（作物の収穫記録を含む）
+//   - PushSyncChanges: 変更の反映と競合の解決（server_wins / client_wins）
+//   - SyncChanges / SyncChangeResult の JSON: レスポンスの形式（dto）で内部の列を含めないこと
+
+// backdate はモックの記録の作成・更新日時を過去にします。
+func backdate(base *model.BaseModel, t time.Time) {
+	base.CreatedAt = t
+	base.UpdatedAt = t
+}
+
+// TestGetSyncChanges_Full はカーソルなしの同期のテストです。
+// 期待動作:
+//   - ユーザーの全ての記録を created で返し、full が true
+//   - 他のユーザーの記録は返さない
+//   - next_cursor は現在時刻から SyncCommitLag 前
+func TestGetSyncChanges_Full(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	_ = svc.CreateCrop(ctx, crop)
+	_ = svc.CreateHarvest(ctx, &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 1, QuantityUnit: "kg"})
+	_ = svc.CreateTask(ctx, &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()})
+	_ = svc.CreateTask(ctx, &model.Task{UserID: 2, Title: "他のユーザー", DueDate: time.Now()})
+	_ = svc.CreatePlot(ctx, &model.Plot{UserID: 1, Name: "畑A", Width: 1, Height: 1})
+
+	// Act
+	changes, err := svc.GetSyncChanges(ctx, 1, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetSyncChanges failed: %v", err)
+	}
+	if !changes.Full {
+		t.Error("Expected full sync without cursor")
+	}
+	if len(changes.Tasks.Created) != 1 || len(changes.Crops.Created) != 1 || len(changes.Plots.Created) != 1 || len(changes.Harvests.Created) != 1 {
+		t.Errorf("Expected 1 record per entity, got %+v", changes)
+	}
+	next, err := DecodeSyncCursor(changes.NextCursor)
+	if err != nil || !next.Equal(changes.ServerTime.Add(-SyncCommitLag)) {
+		t.Errorf("Expected next cursor %v, got %v (%v)", changes.ServerTime.Add(-SyncCommitLag), next, err)
+	}
+}
+
+// TestGetSyncChanges_Delta はカーソル以降の変更の同期のテストです。
+// 期待動作:
+//   - カーソルより前に作成し、カーソル以降に更新した記録は updated
+//   - カーソル以降に作成した記録は created、カーソル以降に変更のない記録は返さない
+//   - 削除した作物と、一緒に削除した収穫記録を deleted で返す
+func TestGetSyncChanges_Delta(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	past := time.Now().Add(-2 * time.Hour)
+	since := time.Now().Add(-time.Hour)
+
+	unchanged := &model.Task{UserID: 1, Title: "変更なし", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, unchanged)
+	backdate(&unchanged.BaseModel, past)
+	updated := &model.Task{UserID: 1, Title: "更新前", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, updated)
+	backdate(&updated.BaseModel, past)
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	_ = svc.CreateCrop(ctx, crop)
+	backdate(&crop.BaseModel, past)
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 1, QuantityUnit: "kg"}
+	_ = svc.CreateHarvest(ctx, harvest)
+	backdate(&harvest.BaseModel, past)
+
+	updated.Title = "更新後"
+	_ = svc.UpdateTask(ctx, updated)
+	created := &model.Task{UserID: 1, Title: "新規", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, created)
+	if err := svc.DeleteCrop(ctx, crop.ID); err != nil {
+		t.Fatalf("DeleteCrop failed: %v", err)
+	}
+
+	// Act
+	changes, err := svc.GetSyncChanges(ctx, 1, EncodeSyncCursor(since))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetSyncChanges failed: %v", err)
+	}
+	if changes.Full {
+		t.Error("Expected delta sync with cursor")
+	}
+	if len(changes.Tasks.Updated) != 1 || changes.Tasks.Updated[0].ID != updated.ID {
+		t.Errorf("Expected updated task %d, got %+v", updated.ID, changes.Tasks.Updated)
+	}
+	if len(changes.Tasks.Created) != 1 || changes.Tasks.Created[0].ID != created.ID {
+		t.Errorf("Expected created task %d, got %+v", created.ID, changes.Tasks.Created)
+	}
+	if len(changes.Crops.Deleted) != 1 || changes.Crops.Deleted[0] != crop.ID {
+		t.Errorf("Expected deleted crop %d, got %v", crop.ID, changes.Crops.Deleted)
+	}
+	if len(changes.Harvests.Deleted) != 1 || changes.Harvests.Deleted[0] != harvest.ID {
+		t.Errorf("Expected deleted harvest %d, got %v", harvest.ID, changes.Harvests.Deleted)
+	}
+	if len(changes.Plots.Created)+len(changes.Plots.Updated)+len(changes.Plots.Deleted) != 0 {
+		t.Errorf("Expected no plot changes, got %+v", changes.Plots)
+	}
+}
+
+// TestGetSyncChanges_InvalidCursor は不正・期限切れのカーソルのテストです。
+// 期待動作:
+//   - 読み取れないカーソルは ErrInvalidSyncCursor
+//   - 削除の記録の保持期間より古いカーソルは ErrSyncCursorExpired
+//   - 保持期間が 0（削除しない）の場合は古いカーソルも使用できる
+func TestGetSyncChanges_InvalidCursor(t *testing.T) {
+	// Arrange
+	svc := NewService(repository.NewMockRepositories())
+	ctx := context.Background()
+	old := EncodeSyncCursor(time.Now().AddDate(0, 0, -91))
+
+	// Act & Assert
+	if _, err := svc.GetSyncChanges(ctx, 1, "not-a-cursor"); !errors.Is(err, ErrInvalidSyncCursor) {
+		t.Errorf("Expected ErrInvalidSyncCursor, got %v", err)
+	}
+	if _, err := svc.GetSyncChanges(ctx, 1, old); !errors.Is(err, ErrSyncCursorExpired) {
+		t.Errorf("Expected ErrSyncCursorExpired, got %v", err)
+	}
+
+	svc.SetRetentionPolicy(RetentionPolicy{Days: map[string]int{config.RetentionTargetSyncTombstones: 0}})
+	if _, err := svc.GetSyncChanges(ctx, 1, old); err != nil {
+		t.Errorf("Expected old cursor without tombstone retention to be accepted, got %v", err)
+	}
+}
+
+// TestPushSyncChanges_Apply は競合のない変更の反映のテストです。
+// 期待動作:
+//   - id のない upsert は新規作成し、結果に client_id と新しいIDを返す
+//   - 最新の base_updated_at の upsert は更新、delete は削除して削除の記録を作成する
+//   - 削除済みの記録の delete は applied（冪等）
+func TestPushSyncChanges_Apply(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	plot := &model.Plot{UserID: 1, Name: "畑A", Width: 1, Height: 1}
+	_ = svc.CreatePlot(ctx, plot)
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+	base := task.UpdatedAt
+	gone := &model.Task{UserID: 1, Title: "削除済み", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, gone)
+	_ = svc.DeleteTask(ctx, gone.ID)
+
+	changes := []SyncChange{
+		{Entity: SyncEntityCrops, Op: SyncOpUpsert, ClientID: "local-1", Data: json.RawMessage(`{"name": "ナス", "plot_id": ` + jsonUint(plot.ID) + `, "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`)},
+		{Entity: SyncEntityTasks, Op: SyncOpUpsert, ID: task.ID, BaseUpdatedAt: &base, Data: json.RawMessage(`{"title": "水やり（朝）", "due_date": "2026-05-01T09:00:00Z", "user_id": 99}`)},
+		{Entity: SyncEntityPlots, Op: SyncOpDelete, ID: plot.ID, BaseUpdatedAt: &plot.UpdatedAt},
+		{Entity: SyncEntityTasks, Op: SyncOpDelete, ID: gone.ID},
+	}
+
+	// Act
+	result, err := svc.PushSyncChanges(ctx, 1, changes, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PushSyncChanges failed: %v", err)
+	}
+	if result.Applied != 4 || result.Conflicts != 0 || result.Rejected != 0 {
+		t.Fatalf("Expected 4 applied, got %+v", result.Results)
+	}
+	if r := result.Results[0]; r.ClientID != "local-1" || r.ID == 0 {
+		t.Errorf("Expected created crop with server ID, got %+v", r)
+	}
+	stored := mockRepos.GetMockTaskRepository().Tasks[task.ID]
+	if stored.Title != "水やり（朝）" || stored.UserID != 1 {
+		t.Errorf("Expected updated task owned by user 1, got %+v", stored)
+	}
+	if _, ok := mockRepos.GetMockPlotRepository().Plots[plot.ID]; ok {
+		t.Error("Expected plot to be deleted")
+	}
+	if n := len(mockRepos.GetMockSyncRepository().Tombstones); n != 2 {
+		t.Errorf("Expected 2 tombstones (task, plot), got %d", n)
+	}
+}
+
+// TestSyncResponse_Format は同期のレスポンスの形式のテストです。
+// 期待動作:
+//   - 取得・反映結果の記録は dto の形式で、deleted_at・harvest_ready_notified_at を含めない
+//   - 記録の項目（name など）は通常のエンドポイントと同じ名前で返す
+func TestSyncResponse_Format(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	notifiedAt := time.Now()
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0), HarvestReadyNotifiedAt: &notifiedAt}
+	_ = svc.CreateCrop(ctx, crop)
+	changes := []SyncChange{
+		{Entity: SyncEntityCrops, Op: SyncOpUpsert, ID: crop.ID, BaseUpdatedAt: &crop.UpdatedAt, Data: json.RawMessage(`{"name": "ミニトマト", "planted_date": "2026-04-01T00:00:00Z", "expected_harvest_date": "2026-07-01T00:00:00Z"}`)},
+	}
+
+	// Act
+	pulled, pullErr := svc.GetSyncChanges(ctx, 1, "")
+	pushed, pushErr := svc.PushSyncChanges(ctx, 1, changes, "")
+
+	// Assert
+	if pullErr != nil || pushErr != nil {
+		t.Fatalf("Sync failed: %v %v", pullErr, pushErr)
+	}
+	pulledBody, _ := json.Marshal(pulled)
+	pushedBody, _ := json.Marshal(pushed)
+	for _, body := range []string{string(pulledBody), string(pushedBody)} {
+		if strings.Contains(body, `"deleted_at"`) || strings.Contains(body, `"harvest_ready_notified_at"`) {
+			t.Errorf("Expected internal columns to be omitted, got %s", body)
+		}
+	}
+	if !strings.Contains(string(pulledBody), `"name":"トマト"`) || !strings.Contains(string(pushedBody), `"name":"ミニトマト"`) {
+		t.Errorf("Expected records in response format, got %s %s", pulledBody, pushedBody)
+	}
+}
+
+// TestPushSyncChanges_Conflicts は競合の解決のテストです。
+// 期待動作:
+//   - server_wins: base_updated_at より後に更新された記録・削除済みの記録の upsert は conflict で反映しない
+//   - client_wins: 競合してもクライアントの変更を反映し、削除済みの記録は新しい記録として作成する
+//   - 他のユーザーの記録・収穫記録の更新は rejected
+func TestPushSyncChanges_Conflicts(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	task := &model.Task{UserID: 1, Title: "サーバーで更新", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+	stale := task.UpdatedAt.Add(-time.Minute)
+	gone := &model.Task{UserID: 1, Title: "削除済み", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, gone)
+	_ = svc.DeleteTask(ctx, gone.ID)
+	other := &model.Task{UserID: 2, Title: "他のユーザー", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, other)
+	crop := &model.Crop{UserID: 1, Name: "トマト", PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	_ = svc.CreateCrop(ctx, crop)
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 1, QuantityUnit: "kg"}
+	_ = svc.CreateHarvest(ctx, harvest)
+
+	data := json.RawMessage(`{"title": "クライアントで更新", "due_date": "2026-05-01T09:00:00Z"}`)
+	changes := []SyncChange{
+		{Entity: SyncEntityTasks, Op: SyncOpUpsert, ID: task.ID, BaseUpdatedAt: &stale, Data: data},
+		{Entity: SyncEntityTasks, Op: SyncOpUpsert, ID: gone.ID, BaseUpdatedAt: &stale, Data: data},
+		{Entity: SyncEntityTasks, Op: SyncOpDelete, ID: other.ID},
+		{Entity: SyncEntityHarvests, Op: SyncOpUpsert, ID: harvest.ID, BaseUpdatedAt: &harvest.UpdatedAt, Data: json.RawMessage(`{"quantity": 2}`)},
+	}
+
+	t.Run("server_wins", func(t *testing.T) {
+		// Act
+		result, err := svc.PushSyncChanges(ctx, 1, changes, SyncStrategyServerWins)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PushSyncChanges failed: %v", err)
+		}
+		if r := result.Results[0]; r.Status != SyncResultConflict || r.Conflict != "updated" || r.Record == nil {
+			t.Errorf("Expected updated conflict with server record, got %+v", r)
+		}
+		if r := result.Results[1]; r.Status != SyncResultConflict || r.Conflict != "deleted" {
+			t.Errorf("Expected deleted conflict, got %+v", r)
+		}
+		if result.Results[2].Status != SyncResultRejected || result.Results[3].Status != SyncResultRejected {
+			t.Errorf("Expected other user's task and harvest update to be rejected, got %+v", result.Results[2:])
+		}
+		if mockRepos.GetMockTaskRepository().Tasks[task.ID].Title != "サーバーで更新" {
+			t.Error("Expected server version to be kept")
+		}
+		if _, ok := mockRepos.GetMockTaskRepository().Tasks[other.ID]; !ok {
+			t.Error("Expected other user's task not to be deleted")
+		}
+	})
+
+	t.Run("client_wins", func(t *testing.T) {
+		// Act
+		result, err := svc.PushSyncChanges(ctx, 1, changes[:2], SyncStrategyClientWins)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PushSyncChanges failed: %v", err)
+		}
+		if result.Applied != 2 {
+			t.Fatalf("Expected 2 applied, got %+v", result.Results)
+		}
+		if mockRepos.GetMockTaskRepository().Tasks[task.ID].Title != "クライアントで更新" {
+			t.Error("Expected client version to be applied")
+		}
+		if r := result.Results[1]; r.ID == gone.ID || mockRepos.GetMockTaskRepository().Tasks[r.ID] == nil {
+			t.Errorf("Expected deleted task to be recreated with a new ID, got %+v", r)
+		}
+	})
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		if _, err := svc.PushSyncChanges(ctx, 1, changes, "last_write_wins"); !errors.Is(err, ErrInvalidSyncStrategy) {
+			t.Errorf("Expected ErrInvalidSyncStrategy, got %v", err)
+		}
+	})
+}
+
+// jsonUint はIDをJSONの数値の文字列にします。
+func jsonUint(id uint) string {
+	b, _ := json.Marshal(id)
+	return string(b)
+}
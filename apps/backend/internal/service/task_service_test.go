@@ -408,7 +408,12 @@ func TestGetOverdueTasks_Success(t *testing.T) {
 	svc := NewService(mockRepos)
 	ctx := context.Background()
 
-	userID := uint(1)
+	// 日付の境界はユーザーのタイムゾーンで計算されるため、UTCのユーザーを作成
+	user := &model.User{Email: "tz@example.com", Timezone: "UTC"}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	userID := user.ID
 	today := time.Now().Truncate(24 * time.Hour)
 
 	// 期限切れタスク（昨日が期限）
@@ -472,7 +477,12 @@ func TestGetOverdueTasks_Empty(t *testing.T) {
 	svc := NewService(mockRepos)
 	ctx := context.Background()
 
-	userID := uint(1)
+	// 日付の境界はユーザーのタイムゾーンで計算されるため、UTCのユーザーを作成
+	user := &model.User{Email: "tz@example.com", Timezone: "UTC"}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	userID := user.ID
 	today := time.Now().Truncate(24 * time.Hour)
 
 	// 未来のタスクのみ作成
@@ -512,7 +522,12 @@ func TestGetTodayTasks_Success(t *testing.T) {
 	svc := NewService(mockRepos)
 	ctx := context.Background()
 
-	userID := uint(1)
+	// 日付の境界はユーザーのタイムゾーンで計算されるため、UTCのユーザーを作成
+	user := &model.User{Email: "tz@example.com", Timezone: "UTC"}
+	if err := mockRepos.User().Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	userID := user.ID
 	today := time.Now().Truncate(24 * time.Hour)
 
 	// 今日のタスク
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Time Series - 汎用時系列データ
+// =============================================================================
+// フロントエンドの新しいグラフごとにChartTypeを追加しなくて済むよう、
+// 指標・粒度・グループ化軸を指定して汎用の系列データを返します。
+
+// TimeSeriesMetric は時系列の指標を表します。
+type TimeSeriesMetric string
+
+const (
+	// MetricHarvestKg は収穫量（kg換算）
+	MetricHarvestKg TimeSeriesMetric = "harvest_kg"
+	// MetricHarvestCount は収穫回数
+	MetricHarvestCount TimeSeriesMetric = "harvest_count"
+)
+
+// TimeSeriesGranularity は時系列の集計粒度を表します。
+type TimeSeriesGranularity string
+
+const (
+	// GranularityDay は日単位
+	GranularityDay TimeSeriesGranularity = "day"
+	// GranularityWeek は週単位（月曜始まり）
+	GranularityWeek TimeSeriesGranularity = "week"
+	// GranularityMonth は月単位
+	GranularityMonth TimeSeriesGranularity = "month"
+)
+
+// TimeSeriesGroupBy は系列の分割軸を表します。
+type TimeSeriesGroupBy string
+
+const (
+	// GroupByNone は分割なし（合計のみ）
+	GroupByNone TimeSeriesGroupBy = ""
+	// GroupByCrop は作物ごと
+	GroupByCrop TimeSeriesGroupBy = "crop"
+	// GroupByPlot は区画ごと
+	GroupByPlot TimeSeriesGroupBy = "plot"
+)
+
+// MaxTimeSeriesPoints は1系列あたりの最大データポイント数です。
+// 日単位で長期間を指定された場合の過大なレスポンスを防ぎます。
+const MaxTimeSeriesPoints = 1000
+
+var (
+	// ErrInvalidTimeSeriesQuery は時系列クエリのパラメータが不正な場合のエラー
+	ErrInvalidTimeSeriesQuery = errors.New("invalid time series query")
+)
+
+// TimeSeriesQuery は時系列データの取得条件です。
+type TimeSeriesQuery struct {
+	Metric      TimeSeriesMetric
+	Granularity TimeSeriesGranularity
+	GroupBy     TimeSeriesGroupBy
+	StartDate   *time.Time
+	EndDate     *time.Time
+}
+
+// TimeSeriesPoint は時系列の1データポイントです。
+type TimeSeriesPoint struct {
+	Period time.Time `json:"period"` // 期間の開始日時
+	Label  string    `json:"label"`  // 表示用ラベル（例: "2024-01-15", "2024-W03", "2024-01"）
+	Value  float64   `json:"value"`
+}
+
+// TimeSeries は1系列分のデータです。
+type TimeSeries struct {
+	Key    string            `json:"key"`   // 系列キー（"total", "crop:1", "plot:2" 等）
+	Label  string            `json:"label"` // 系列名（作物名・区画名）
+	Points []TimeSeriesPoint `json:"points"`
+}
+
+// TimeSeriesResult は時系列APIのレスポンスです。
+type TimeSeriesResult struct {
+	Metric      TimeSeriesMetric      `json:"metric"`
+	Granularity TimeSeriesGranularity `json:"granularity"`
+	GroupBy     TimeSeriesGroupBy     `json:"group_by,omitempty"`
+	Series      []TimeSeries          `json:"series"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// Validate はクエリのパラメータを検証します。
+func (q TimeSeriesQuery) Validate() error {
+	switch q.Metric {
+	case MetricHarvestKg, MetricHarvestCount:
+	default:
+		return fmt.Errorf("%w: unknown metric %q", ErrInvalidTimeSeriesQuery, q.Metric)
+	}
+	switch q.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+	default:
+		return fmt.Errorf("%w: unknown granularity %q", ErrInvalidTimeSeriesQuery, q.Granularity)
+	}
+	switch q.GroupBy {
+	case GroupByNone, GroupByCrop, GroupByPlot:
+	default:
+		return fmt.Errorf("%w: unknown group_by %q", ErrInvalidTimeSeriesQuery, q.GroupBy)
+	}
+	if q.StartDate != nil && q.EndDate != nil && q.EndDate.Before(*q.StartDate) {
+		return fmt.Errorf("%w: end_date is before start_date", ErrInvalidTimeSeriesQuery)
+	}
+	return nil
+}
+
+// GetTimeSeries は指定された指標・粒度・分割軸で時系列データを生成します。
+// 期間内でデータがないバケットは0で埋められます。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - query: 取得条件
+//
+// 戻り値:
+//   - *TimeSeriesResult: 系列データ
+//   - error: パラメータ不正（ErrInvalidTimeSeriesQuery）または取得失敗
+func (s *Service) GetTimeSeries(ctx context.Context, userID uint, query TimeSeriesQuery) (*TimeSeriesResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	harvests, err := s.repos.Harvest().GetByUserIDWithDateRange(ctx, userID, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 系列キーの解決関数を準備
+	keyOf, labels, err := s.timeSeriesGrouper(ctx, userID, query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期間の範囲を決定（指定がなければデータの最小・最大）
+	var rangeStart, rangeEnd time.Time
+	if query.StartDate != nil {
+		rangeStart = *query.StartDate
+	}
+	if query.EndDate != nil {
+		rangeEnd = *query.EndDate
+	}
+	for _, h := range harvests {
+		if query.StartDate == nil && (rangeStart.IsZero() || h.HarvestDate.Before(rangeStart)) {
+			rangeStart = h.HarvestDate
+		}
+		if query.EndDate == nil && h.HarvestDate.After(rangeEnd) {
+			rangeEnd = h.HarvestDate
+		}
+	}
+
+	// 系列ごと・バケットごとに集計
+	values := make(map[string]map[time.Time]float64)
+	for _, h := range harvests {
+		key, ok := keyOf(h)
+		if !ok {
+			continue
+		}
+		bucket := truncateToGranularity(h.HarvestDate, query.Granularity)
+		if values[key] == nil {
+			values[key] = make(map[time.Time]float64)
+		}
+		switch query.Metric {
+		case MetricHarvestKg:
+			values[key][bucket] += convertToKg(h.Quantity, h.QuantityUnit)
+		case MetricHarvestCount:
+			values[key][bucket]++
+		}
+	}
+
+	// 0埋めしたバケット一覧を生成
+	var buckets []time.Time
+	if !rangeStart.IsZero() && !rangeEnd.IsZero() {
+		buckets = timeBuckets(rangeStart, rangeEnd, query.Granularity)
+		if len(buckets) > MaxTimeSeriesPoints {
+			return nil, fmt.Errorf("%w: too many points (%d > %d), use a coarser granularity",
+				ErrInvalidTimeSeriesQuery, len(buckets), MaxTimeSeriesPoints)
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]TimeSeries, 0, len(keys))
+	for _, key := range keys {
+		points := make([]TimeSeriesPoint, len(buckets))
+		for i, b := range buckets {
+			points[i] = TimeSeriesPoint{
+				Period: b,
+				Label:  granularityLabel(b, query.Granularity),
+				Value:  values[key][b],
+			}
+		}
+		series = append(series, TimeSeries{Key: key, Label: labels[key], Points: points})
+	}
+
+	result := &TimeSeriesResult{
+		Metric:      query.Metric,
+		Granularity: query.Granularity,
+		GroupBy:     query.GroupBy,
+		Series:      series,
+		GeneratedAt: s.now(),
+	}
+	return result, nil
+}
+
+// timeSeriesGrouper は収穫記録から系列キーを求める関数と系列名のマップを返します。
+// 区画で分割する場合は収穫日に作物を配置していた区画の系列とし、配置していない日の収穫は除外されます。
+func (s *Service) timeSeriesGrouper(ctx context.Context, userID uint, groupBy TimeSeriesGroupBy) (func(model.Harvest) (string, bool), map[string]string, error) {
+	labels := make(map[string]string)
+
+	switch groupBy {
+	case GroupByCrop:
+		crops, err := s.repos.Crop().GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, crop := range crops {
+			labels["crop:"+strconv.FormatUint(uint64(crop.ID), 10)] = crop.Name
+		}
+		return func(h model.Harvest) (string, bool) {
+			return "crop:" + strconv.FormatUint(uint64(h.CropID), 10), true
+		}, labels, nil
+
+	case GroupByPlot:
+		plots, err := s.repos.Plot().GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		plotIDs := make([]uint, len(plots))
+		for i, plot := range plots {
+			plotIDs[i] = plot.ID
+			labels["plot:"+strconv.FormatUint(uint64(plot.ID), 10)] = plot.Name
+		}
+		assignments, err := s.repos.PlotAssignment().GetByPlotIDs(ctx, plotIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		// 作物ごとの配置履歴（配置日の昇順）
+		cropAssignments := make(map[uint][]model.PlotAssignment)
+		for _, assignment := range assignments {
+			cropAssignments[assignment.CropID] = append(cropAssignments[assignment.CropID], assignment)
+		}
+		return func(h model.Harvest) (string, bool) {
+			plotID, ok := plotAssignedOn(cropAssignments[h.CropID], h.HarvestDate)
+			if !ok {
+				return "", false
+			}
+			return "plot:" + strconv.FormatUint(uint64(plotID), 10), true
+		}, labels, nil
+
+	default:
+		labels["total"] = "合計"
+		return func(model.Harvest) (string, bool) { return "total", true }, labels, nil
+	}
+}
+
+// plotAssignedOn は配置履歴（配置日の昇順）から、日付 date に作物を配置していた区画を返します。
+// 配置の期間は配置日から配置解除日まで（日単位、両端を含む）で、同じ日に移動した場合は後の配置の区画です。
+func plotAssignedOn(assignments []model.PlotAssignment, date time.Time) (uint, bool) {
+	day := truncateToGranularity(date, GranularityDay)
+	var plotID uint
+	found := false
+	for _, assignment := range assignments {
+		if truncateToGranularity(assignment.AssignedDate, GranularityDay).After(day) {
+			break
+		}
+		if assignment.UnassignedDate != nil && truncateToGranularity(*assignment.UnassignedDate, GranularityDay).Before(day) {
+			continue
+		}
+		plotID, found = assignment.PlotID, true
+	}
+	return plotID, found
+}
+
+// truncateToGranularity は日時を粒度の期間開始日時に切り捨てます。
+// バケットはマップのキーとして比較するため、日時のロケーションの日付を UTC の0時として返します
+// （DBの値は time.Local、期間の指定は UTC など、ロケーションが異なっても同じバケットになる）。
+func truncateToGranularity(t time.Time, granularity TimeSeriesGranularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case GranularityWeek:
+		// 月曜始まり
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// timeBuckets は開始〜終了を含む全バケットの開始日時を返します。
+func timeBuckets(start, end time.Time, granularity TimeSeriesGranularity) []time.Time {
+	var buckets []time.Time
+	current := truncateToGranularity(start, granularity)
+	last := truncateToGranularity(end, granularity)
+	for !current.After(last) {
+		buckets = append(buckets, current)
+		switch granularity {
+		case GranularityWeek:
+			current = current.AddDate(0, 0, 7)
+		case GranularityMonth:
+			current = current.AddDate(0, 1, 0)
+		default:
+			current = current.AddDate(0, 0, 1)
+		}
+		if len(buckets) > MaxTimeSeriesPoints {
+			break
+		}
+	}
+	return buckets
+}
+
+// granularityLabel は粒度に応じた表示ラベルを生成します。
+func granularityLabel(t time.Time, granularity TimeSeriesGranularity) string {
+	switch granularity {
+	case GranularityWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case GranularityMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
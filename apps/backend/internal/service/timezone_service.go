@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Timezone - ユーザーのタイムゾーン
+// =============================================================================
+// 「今日のタスク」や日次リマインダーの日付境界・送信時刻を、サーバーではなく
+// ユーザーのタイムゾーンで計算します。
+
+var (
+	// ErrInvalidTimezone はIANAタイムゾーン名として解釈できない場合のエラー
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	// ErrInvalidReminderHour はリマインダー送信時刻が0-23の範囲外の場合のエラー
+	ErrInvalidReminderHour = errors.New("daily reminder hour must be between 0 and 23")
+)
+
+// DefaultUserTimezone はタイムゾーン未設定のユーザーに使用するタイムゾーン
+const DefaultUserTimezone = "Asia/Tokyo"
+
+// userLocation はユーザーのタイムゾーンを返します。
+// 未設定または不正な値の場合は DefaultUserTimezone を使用します。
+func userLocation(user *model.User) *time.Location {
+	if user != nil && user.Timezone != "" {
+		if loc, err := time.LoadLocation(user.Timezone); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(DefaultUserTimezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// userDayBounds はユーザーのタイムゾーンでの now を含む1日の範囲 [start, end) を返します。
+func userDayBounds(user *model.User, now time.Time) (time.Time, time.Time) {
+	local := now.In(userLocation(user))
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// isDailyReminderDue はユーザーの日次リマインダーを送信してよい時刻かを判定します。
+// ユーザーのタイムゾーンで指定時刻（DailyReminderHour）以降であれば送信対象です。
+// スケジューラーは1時間ごとに実行し、同日内の重複は重複防止キーで抑止されます。
+// 送信時刻が未設定の場合は常に送信対象です（従来の日次実行と同じ動作）。
+func isDailyReminderDue(user *model.User, now time.Time) bool {
+	if user.NotificationSettings == nil || user.NotificationSettings.DailyReminderHour == nil {
+		return true
+	}
+	return now.In(userLocation(user)).Hour() >= *user.NotificationSettings.DailyReminderHour
+}
+
+// ValidateTimezone はIANAタイムゾーン名を検証します。
+func ValidateTimezone(tz string) error {
+	// 空文字はtime.LoadLocationでUTCとして扱われるため明示的に拒否する
+	if tz == "" || tz == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// GetUserTimezone はユーザーのタイムゾーン名を取得します（未設定の場合はデフォルト）。
+func (s *Service) GetUserTimezone(ctx context.Context, userID uint) (string, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return userLocation(user).String(), nil
+}
+
+// SetUserTimezone はユーザーのタイムゾーンを更新します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - tz: IANAタイムゾーン名（例: Asia/Tokyo）
+//
+// 戻り値:
+//   - string: 更新後のタイムゾーン名
+//   - error: 不正なタイムゾーンの場合は ErrInvalidTimezone、更新に失敗した場合のエラー
+func (s *Service) SetUserTimezone(ctx context.Context, userID uint, tz string) (string, error) {
+	if err := ValidateTimezone(tz); err != nil {
+		return "", err
+	}
+
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	user.Timezone = tz
+	if err := s.repos.User().Update(ctx, user); err != nil {
+		return "", err
+	}
+	return user.Timezone, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+)
+
+// RunInTransaction は fn をデータベースのトランザクション内で実行します。
+// fn に渡したコンテキストを使うリポジトリの操作（サービスのメソッドを含む）は全て同じトランザクションで実行し、
+// fn がエラーを返した場合はロールバックします。
+// サービスのメソッドのトランザクションは外側のトランザクションに含まれます（入れ子にしない）。
+// シリアライゼーションの失敗・デッドロックの場合は fn を最初から実行し直すため、fn は複数回実行される場合があります。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - fn: トランザクション内で実行する処理
+//
+// 戻り値:
+//   - error: fn のエラー、またはコミットに失敗した場合のエラー
+func (s *Service) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.repos.WithTransaction(ctx, fn)
+}
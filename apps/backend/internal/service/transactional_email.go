@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Transactional Email - 認証フローのトランザクションメール
+// =============================================================================
+// 登録・メールアドレス確認・パスワード再設定のメールを、通知と同じ NotificationSender（SES）で送信します。
+// 通知メールと同じテンプレート（templates/email/{locale}/{type}.*.tmpl）・リトライ・送信結果のカウンターを使用し、
+// 通知ログに notification_type = "transactional_{type}" で記録します。
+//
+// トランザクションメールは通知設定（メール通知のオン・オフ、おやすみモード）に関係なく送信し、
+// 通知の受信箱には表示しません。確認・再設定のリンクを通知ログに残さないよう、本文は記録しません。
+
+// TransactionalEmailType はトランザクションメールの種類です。
+type TransactionalEmailType string
+
+const (
+	// TransactionalEmailWelcome は登録完了メール
+	TransactionalEmailWelcome TransactionalEmailType = "welcome"
+	// TransactionalEmailVerification はメールアドレス確認メール（data に action_url が必要）
+	TransactionalEmailVerification TransactionalEmailType = "email_verification"
+	// TransactionalEmailPasswordReset はパスワード再設定メール（data に action_url が必要）
+	TransactionalEmailPasswordReset TransactionalEmailType = "password_reset"
+
+	// TransactionalEmailLogPrefix はトランザクションメールの通知ログの notification_type の接頭辞
+	TransactionalEmailLogPrefix = "transactional_"
+
+	// transactionalEmailLogTTL はトランザクションメールの通知ログの保持期間
+	transactionalEmailLogTTL = 24 * time.Hour
+)
+
+var (
+	// ErrTransactionalEmailUnavailable は送信手段（NotificationSender）が未設定の場合のエラー
+	ErrTransactionalEmailUnavailable = errors.New("transactional email is unavailable")
+	// ErrInvalidTransactionalEmail は種類が不明、または必要なデータが不足している場合のエラー
+	ErrInvalidTransactionalEmail = errors.New("invalid transactional email")
+)
+
+// requiresActionURL はリンク（action_url）が必要な種類かを返します。
+func (t TransactionalEmailType) requiresActionURL() bool {
+	return t == TransactionalEmailVerification || t == TransactionalEmailPasswordReset
+}
+
+// valid は既知の種類かを返します。
+func (t TransactionalEmailType) valid() bool {
+	switch t {
+	case TransactionalEmailWelcome, TransactionalEmailVerification, TransactionalEmailPasswordReset:
+		return true
+	}
+	return false
+}
+
+// SendTransactionalEmail はトランザクションメールをユーザーの言語のテンプレートで送信し、通知ログに記録します。
+// SESへの送信は通知メールと同じくリトライし、失敗した場合も通知ログに failed を記録します（通知のリトライ対象にはしません）。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - emailType: メールの種類
+//   - user: 送信先ユーザー
+//   - data: テンプレートに渡すデータ（display_name, action_url, expires_in_minutes など）
+//
+// 戻り値:
+//   - error: 送信手段が未設定の場合は ErrTransactionalEmailUnavailable、
+//     種類が不明・必要なデータ不足の場合は ErrInvalidTransactionalEmail、送信に失敗した場合はそのエラー
+func (s *Service) SendTransactionalEmail(ctx context.Context, emailType TransactionalEmailType, user *model.User, data map[string]interface{}) error {
+	if s.sender == nil {
+		return ErrTransactionalEmailUnavailable
+	}
+	if !emailType.valid() || user == nil || user.Email == "" {
+		return ErrInvalidTransactionalEmail
+	}
+	if emailType.requiresActionURL() {
+		if actionURL, _ := data["action_url"].(string); actionURL == "" {
+			return ErrInvalidTransactionalEmail
+		}
+	}
+
+	templateData := make(map[string]interface{}, len(data)+1)
+	templateData["display_name"] = user.DisplayName
+	for key, value := range data {
+		templateData[key] = value
+	}
+
+	email, err := renderEmailTemplate(string(emailType), user.Locale, emailTemplateData{Data: templateData})
+	if err != nil {
+		return err
+	}
+
+	sendErr := s.sender.SendEmailNotification(ctx, user.Email, email.Subject, email.HTMLBody, email.TextBody)
+
+	outcome := NotificationOutcomeSent
+	log := &model.NotificationLog{
+		UserID:           user.ID,
+		NotificationType: TransactionalEmailLogPrefix + string(emailType),
+		Channel:          NotificationChannelEmail,
+		Title:            email.Subject,
+		Status:           "sent",
+		ExpiresAt:        s.now().Add(transactionalEmailLogTTL),
+	}
+	if sendErr != nil {
+		outcome = NotificationOutcomeFailed
+		log.Status = "failed"
+		log.ErrorMessage = sendErr.Error()
+	} else {
+		now := s.now()
+		log.SentAt = &now
+	}
+	log.ChannelStatus = map[string]string{NotificationChannelEmail: outcome}
+	DefaultNotificationMetrics.Inc(NotificationChannelEmail, outcome)
+
+	if err := s.repos.NotificationLog().Create(ctx, log); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record transactional email log", "recipient_id", user.ID, "error", err)
+	}
+
+	return sendErr
+}
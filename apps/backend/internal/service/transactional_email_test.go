@@ -0,0 +1,139 @@
+// Package service - Transactional Email Tests
+//
+// 認証フローのトランザクションメールのテストを提供します。
+// テスト対象:
+//   - ユーザーの言語のテンプレートでの送信と通知ログへの記録
+//   - 必要なデータ不足・送信手段未設定のエラー
+//   - 受信箱・未読件数からの除外
+//   - 登録時の登録完了メール
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// TestSendTransactionalEmail はトランザクションメールの送信のテストです。
+// 期待動作:
+//   - ユーザーの言語のテンプレートでリンクを含むメールを送信する
+//   - 通知ログに transactional_{type} で記録し、本文（リンク）は記録しない
+//   - 受信箱・未読件数には含めない
+func TestSendTransactionalEmail(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	svc.SetNotificationSender(mockSender)
+	ctx := context.Background()
+
+	user := &model.User{Email: "reset@example.com", DisplayName: "Hana", Locale: "en"}
+	_ = mockRepos.User().Create(ctx, user)
+	resetURL := "https://app.example.com/reset?token=abc123"
+
+	// Act
+	err := svc.SendTransactionalEmail(ctx, TransactionalEmailPasswordReset, user, map[string]interface{}{
+		"action_url":         resetURL,
+		"expires_in_minutes": 30,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("SendTransactionalEmail failed: %v", err)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(mockSender.SentEmailNotifications))
+	}
+	sent := mockSender.SentEmailNotifications[0]
+	if sent.ToEmail != user.Email || sent.Subject != "Reset your password" {
+		t.Errorf("Unexpected email: %+v", sent)
+	}
+	if !strings.Contains(sent.TextBody, resetURL) || !strings.Contains(sent.TextBody, "30 minutes") {
+		t.Errorf("Expected reset link in text body, got %q", sent.TextBody)
+	}
+
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].NotificationType != "transactional_password_reset" || logs[0].Status != "sent" {
+		t.Fatalf("Unexpected logs: %+v", logs)
+	}
+	if strings.Contains(logs[0].Body, resetURL) {
+		t.Error("Expected reset link not to be stored in the log")
+	}
+	inbox, total, _ := mockRepos.NotificationLog().GetInboxByUserID(ctx, user.ID, 20, 0, false)
+	unread, _ := mockRepos.NotificationLog().CountUnreadByUserID(ctx, user.ID)
+	if len(inbox) != 0 || total != 0 || unread != 0 {
+		t.Errorf("Expected transactional email to be excluded from inbox, got %d/%d/%d", len(inbox), total, unread)
+	}
+}
+
+// TestSendTransactionalEmail_Errors はトランザクションメールのエラーのテストです。
+// 期待動作:
+//   - 送信手段が未設定の場合は ErrTransactionalEmailUnavailable
+//   - リンクが必要な種類で action_url がない場合・不明な種類は ErrInvalidTransactionalEmail
+//   - 送信に失敗した場合は通知ログに failed を記録する
+func TestSendTransactionalEmail_Errors(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	user := &model.User{Email: "verify@example.com"}
+	_ = mockRepos.User().Create(ctx, user)
+
+	// Act & Assert
+	if err := svc.SendTransactionalEmail(ctx, TransactionalEmailWelcome, user, nil); !errors.Is(err, ErrTransactionalEmailUnavailable) {
+		t.Errorf("Expected ErrTransactionalEmailUnavailable, got %v", err)
+	}
+
+	mockSender := NewMockNotificationSender()
+	svc.SetNotificationSender(mockSender)
+	if err := svc.SendTransactionalEmail(ctx, TransactionalEmailVerification, user, nil); !errors.Is(err, ErrInvalidTransactionalEmail) {
+		t.Errorf("Expected ErrInvalidTransactionalEmail, got %v", err)
+	}
+	if err := svc.SendTransactionalEmail(ctx, TransactionalEmailType("invite"), user, nil); !errors.Is(err, ErrInvalidTransactionalEmail) {
+		t.Errorf("Expected ErrInvalidTransactionalEmail for unknown type, got %v", err)
+	}
+
+	mockSender.ShouldFail = true
+	err := svc.SendTransactionalEmail(ctx, TransactionalEmailVerification, user, map[string]interface{}{"action_url": "https://app.example.com/verify?token=x"})
+	if err == nil {
+		t.Fatal("Expected send error")
+	}
+	logs, _ := mockRepos.NotificationLog().GetByUserID(ctx, user.ID, 0)
+	if len(logs) != 1 || logs[0].Status != "failed" || logs[0].ChannelStatus[NotificationChannelEmail] != NotificationOutcomeFailed {
+		t.Errorf("Unexpected logs: %+v", logs)
+	}
+}
+
+// TestRegisterUser_WelcomeEmail は登録時の登録完了メールのテストです。
+// 期待動作:
+//   - 送信手段が設定されている場合は登録完了メールを送信する
+//   - 送信に失敗しても登録は成功する
+func TestRegisterUser_WelcomeEmail(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	mockSender := NewMockNotificationSender()
+	svc.SetNotificationSender(mockSender)
+	ctx := context.Background()
+
+	// Act
+	user, err := svc.RegisterUser(ctx, "new@example.com", "hashed", "新規ユーザー")
+	mockSender.ShouldFail = true
+	_, failErr := svc.RegisterUser(ctx, "second@example.com", "hashed", "")
+
+	// Assert
+	if err != nil || failErr != nil {
+		t.Fatalf("RegisterUser failed: %v, %v", err, failErr)
+	}
+	if len(mockSender.SentEmailNotifications) != 1 {
+		t.Fatalf("Expected 1 welcome email, got %d", len(mockSender.SentEmailNotifications))
+	}
+	sent := mockSender.SentEmailNotifications[0]
+	if sent.ToEmail != user.Email || !strings.Contains(sent.TextBody, "新規ユーザー さん") {
+		t.Errorf("Unexpected welcome email: %+v", sent)
+	}
+}
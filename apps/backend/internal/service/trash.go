@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/realtime"
+	"github.com/secure-scorecard/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Trash - 論理削除した記録のゴミ箱と復元
+// =============================================================================
+// 論理削除したタスク・作物・区画・収穫記録を、保持期間（config.RetentionTargets）の間は
+// ゴミ箱に表示し、復元できるようにします。保持期間を過ぎた記録はデータの定期削除で物理削除されます。
+//
+// 作物・区画の復元では、削除時に一緒に削除した子（成長記録・収穫記録、配置履歴）も復元します。
+// 復元した記録の同期用の削除の記録は削除し、オフライン同期（GET /sync）では更新として返します。
+
+// RestoreCascadeWindow は親の削除日時より前に削除された子を、親と一緒に削除したとみなす期間です。
+// 親の削除より前に個別に削除した子（収穫記録の削除など）は、親を復元しても復元しません。
+const RestoreCascadeWindow = time.Minute
+
+var (
+	// ErrTrashItemNotFound は削除された記録が見つからない場合のエラー（削除されていない、他のユーザーの記録、保持期間を過ぎた場合を含む）
+	ErrTrashItemNotFound = errors.New("deleted item not found")
+	// ErrRestoreParentDeleted は収穫記録の作物が削除されているため復元できない場合のエラー
+	ErrRestoreParentDeleted = errors.New("parent of deleted item is also deleted")
+	// ErrInvalidTrashType はゴミ箱の種類が不正な場合のエラー
+	ErrInvalidTrashType = errors.New("invalid trash type")
+)
+
+// TrashTypes はゴミ箱の記録の種類です（同期のエンティティ名と同じ）。
+var TrashTypes = []string{SyncEntityTasks, SyncEntityCrops, SyncEntityPlots, SyncEntityHarvests}
+
+// TrashItem はゴミ箱の記録です。
+type TrashItem struct {
+	Type      string     `json:"type"` // tasks, crops, plots, harvests
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"` // タスクのタイトル、作物・区画の名前、収穫記録の作物の名前
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"` // 物理削除される日時（保持期間が無期限の場合は省略）
+}
+
+// GetTrash はユーザーの保持期間内に削除された記録を削除日時の新しい順に取得します。
+// 組織のスコープでは、組織の作物・区画（と作物の収穫記録）を返します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID
+//   - itemType: 記録の種類（TrashTypes、空の場合は全種類）
+//
+// 戻り値:
+//   - []TrashItem: 削除された記録の一覧
+//   - error: 種類が不正な場合は ErrInvalidTrashType、取得に失敗した場合のエラー
+func (s *Service) GetTrash(ctx context.Context, userID uint, itemType string) ([]TrashItem, error) {
+	types := TrashTypes
+	if itemType != "" {
+		if !slices.Contains(TrashTypes, itemType) {
+			return nil, ErrInvalidTrashType
+		}
+		types = []string{itemType}
+	}
+
+	now := s.now()
+	items := []TrashItem{}
+	for _, t := range types {
+		days := s.trashRetentionDays(t)
+		var deletedAfter time.Time
+		if days > 0 {
+			deletedAfter = now.AddDate(0, 0, -days)
+		}
+
+		found, err := s.trashItems(ctx, userID, t, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range found {
+			if days > 0 {
+				purgeAt := item.DeletedAt.AddDate(0, 0, days)
+				item.PurgeAt = &purgeAt
+			}
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// trashItems は種類ごとの deletedAfter より後に削除された記録を取得します。
+func (s *Service) trashItems(ctx context.Context, userID uint, itemType string, deletedAfter time.Time) ([]TrashItem, error) {
+	var items []TrashItem
+	switch itemType {
+	case SyncEntityTasks:
+		tasks, err := s.repos.Task().GetDeletedByUserID(ctx, userID, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			items = append(items, TrashItem{Type: itemType, ID: task.ID, Name: task.Title, DeletedAt: task.DeletedAt.Time})
+		}
+	case SyncEntityCrops:
+		crops, err := s.repos.Crop().GetDeletedByUserID(ctx, userID, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, crop := range crops {
+			items = append(items, TrashItem{Type: itemType, ID: crop.ID, Name: crop.Name, DeletedAt: crop.DeletedAt.Time})
+		}
+	case SyncEntityPlots:
+		plots, err := s.repos.Plot().GetDeletedByUserID(ctx, userID, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, plot := range plots {
+			items = append(items, TrashItem{Type: itemType, ID: plot.ID, Name: plot.Name, DeletedAt: plot.DeletedAt.Time})
+		}
+	case SyncEntityHarvests:
+		// 作物と一緒に削除した収穫記録は作物の復元で戻すため、削除されていない作物の収穫記録のみ返す
+		harvests, err := s.repos.Harvest().GetDeletedByUserID(ctx, userID, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		crops, err := s.cropsByID(ctx, harvestCropIDs(harvests))
+		if err != nil {
+			return nil, err
+		}
+		for _, harvest := range harvests {
+			var name string
+			if crop, ok := crops[harvest.CropID]; ok {
+				name = crop.Name
+			}
+			items = append(items, TrashItem{Type: itemType, ID: harvest.ID, Name: name, DeletedAt: harvest.DeletedAt.Time})
+		}
+	}
+	return items, nil
+}
+
+// RestoreTask は削除したタスクを復元します。
+// 所有者の庭のルームにタスクの作成（task.created）を配信します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（タスクの所有者）
+//   - id: 復元するタスクのID
+//
+// 戻り値:
+//   - *model.Task: 復元したタスク
+//   - error: 削除されたタスクが見つからない場合は ErrTrashItemNotFound、復元に失敗した場合のエラー
+func (s *Service) RestoreTask(ctx context.Context, userID, id uint) (*model.Task, error) {
+	var task *model.Task
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		deleted, err := s.repos.Task().GetDeletedByID(txCtx, id)
+		if err != nil {
+			return trashNotFound(err)
+		}
+		if deleted.UserID != userID || !s.inTrash(SyncEntityTasks, deleted.DeletedAt) {
+			return ErrTrashItemNotFound
+		}
+
+		if err := s.repos.Task().Restore(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.repos.Sync().DeleteTombstones(txCtx, deleted.UserID, SyncEntityTasks, []uint{id}); err != nil {
+			return err
+		}
+		task, err = s.repos.Task().GetByID(txCtx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.broadcastTask(ctx, realtime.TypeTaskCreated, task)
+	return task, nil
+}
+
+// RestoreCrop は削除した作物と、作物と一緒に削除した成長記録・収穫記録を復元します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（個人の作物の所有者。組織のスコープでは組織の作物を復元）
+//   - id: 復元する作物のID
+//
+// 戻り値:
+//   - *model.Crop: 復元した作物
+//   - error: 削除された作物が見つからない場合は ErrTrashItemNotFound、組織の作物数が上限の場合は ErrOrganizationQuotaExceeded
+func (s *Service) RestoreCrop(ctx context.Context, userID, id uint) (*model.Crop, error) {
+	var crop *model.Crop
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		deleted, err := s.repos.Crop().GetDeletedByID(txCtx, id)
+		if err != nil {
+			return trashNotFound(err)
+		}
+		if !ownsTrashItem(txCtx, deleted.UserID, userID) || !s.inTrash(SyncEntityCrops, deleted.DeletedAt) {
+			return ErrTrashItemNotFound
+		}
+		if err := s.checkOrganizationQuota(txCtx, organizationQuotaCrops); err != nil {
+			return err
+		}
+
+		cascadeFrom := deleted.DeletedAt.Time.Add(-RestoreCascadeWindow)
+		if err := s.repos.Crop().Restore(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.repos.GrowthRecord().RestoreByCropID(txCtx, id, cascadeFrom); err != nil {
+			return err
+		}
+		harvestIDs, err := s.repos.Harvest().RestoreByCropID(txCtx, id, cascadeFrom)
+		if err != nil {
+			return err
+		}
+		if err := s.repos.Sync().DeleteTombstones(txCtx, deleted.UserID, SyncEntityHarvests, harvestIDs); err != nil {
+			return err
+		}
+		if err := s.repos.Sync().DeleteTombstones(txCtx, deleted.UserID, SyncEntityCrops, []uint{id}); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, deleted.UserID, deleted.OrganizationID); err != nil {
+			return err
+		}
+		crop, err = s.repos.Crop().GetByID(txCtx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return crop, nil
+}
+
+// RestorePlot は削除した区画と、区画と一緒に削除した配置履歴を復元します。
+// 所有者の庭のルームに区画の作成（plot.created）を配信します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（個人の区画の所有者。組織のスコープでは組織の区画を復元）
+//   - id: 復元する区画のID
+//
+// 戻り値:
+//   - *model.Plot: 復元した区画
+//   - error: 削除された区画が見つからない場合は ErrTrashItemNotFound、組織の区画数が上限の場合は ErrOrganizationQuotaExceeded
+func (s *Service) RestorePlot(ctx context.Context, userID, id uint) (*model.Plot, error) {
+	var plot *model.Plot
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		deleted, err := s.repos.Plot().GetDeletedByID(txCtx, id)
+		if err != nil {
+			return trashNotFound(err)
+		}
+		if !ownsTrashItem(txCtx, deleted.UserID, userID) || !s.inTrash(SyncEntityPlots, deleted.DeletedAt) {
+			return ErrTrashItemNotFound
+		}
+		if err := s.checkOrganizationQuota(txCtx, organizationQuotaPlots); err != nil {
+			return err
+		}
+
+		if err := s.repos.Plot().Restore(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.repos.PlotAssignment().RestoreByPlotID(txCtx, id, deleted.DeletedAt.Time.Add(-RestoreCascadeWindow)); err != nil {
+			return err
+		}
+		if err := s.repos.Sync().DeleteTombstones(txCtx, deleted.UserID, SyncEntityPlots, []uint{id}); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, deleted.UserID, deleted.OrganizationID); err != nil {
+			return err
+		}
+		plot, err = s.repos.Plot().GetByID(txCtx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.broadcastPlotLayout(ctx, realtime.TypePlotCreated, plot)
+	return plot, nil
+}
+
+// RestoreHarvest は削除した収穫記録を復元します。
+// 作物も削除されている場合は、先に作物を復元する必要があります。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - userID: ユーザーID（作物の所有者。組織のスコープでは組織の作物の収穫記録を復元）
+//   - id: 復元する収穫記録のID
+//
+// 戻り値:
+//   - *model.Harvest: 復元した収穫記録
+//   - error: 削除された収穫記録が見つからない場合は ErrTrashItemNotFound、作物が削除されている場合は ErrRestoreParentDeleted
+func (s *Service) RestoreHarvest(ctx context.Context, userID, id uint) (*model.Harvest, error) {
+	var harvest *model.Harvest
+	err := s.repos.WithTransaction(ctx, func(txCtx context.Context) error {
+		deleted, err := s.repos.Harvest().GetDeletedByID(txCtx, id)
+		if err != nil {
+			return trashNotFound(err)
+		}
+
+		crop, err := s.repos.Crop().GetByID(txCtx, deleted.CropID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 作物も削除されている場合は、作物の復元を案内する
+			deletedCrop, deletedErr := s.repos.Crop().GetDeletedByID(txCtx, deleted.CropID)
+			if deletedErr == nil && ownsTrashItem(txCtx, deletedCrop.UserID, userID) {
+				return ErrRestoreParentDeleted
+			}
+			return ErrTrashItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !ownsTrashItem(txCtx, crop.UserID, userID) || !s.inTrash(SyncEntityHarvests, deleted.DeletedAt) {
+			return ErrTrashItemNotFound
+		}
+
+		if err := s.repos.Harvest().Restore(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.repos.Sync().DeleteTombstones(txCtx, crop.UserID, SyncEntityHarvests, []uint{id}); err != nil {
+			return err
+		}
+		if err := s.invalidateAnalytics(txCtx, crop.UserID, crop.OrganizationID); err != nil {
+			return err
+		}
+		harvest, err = s.repos.Harvest().GetByID(txCtx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return harvest, nil
+}
+
+// trashRetentionDays はゴミ箱の記録の種類の保持期間（日数、0 以下は無期限）を返します。
+func (s *Service) trashRetentionDays(itemType string) int {
+	for _, target := range config.RetentionTargets {
+		if target.Name == itemType {
+			return s.retentionDays(target.Name, target.Days)
+		}
+	}
+	return 0
+}
+
+// inTrash は削除日時が保持期間内（ゴミ箱に表示する期間）かを判定します。
+func (s *Service) inTrash(itemType string, deletedAt gorm.DeletedAt) bool {
+	days := s.trashRetentionDays(itemType)
+	return deletedAt.Valid && (days <= 0 || deletedAt.Time.After(s.now().AddDate(0, 0, -days)))
+}
+
+// ownsTrashItem はユーザーが記録を復元できるかを判定します（組織のスコープでは組織の記録であればよい）。
+func ownsTrashItem(ctx context.Context, ownerID, userID uint) bool {
+	if organizationID, ok := repository.OrganizationFromContext(ctx); ok && organizationID != 0 {
+		return true
+	}
+	return ownerID == userID
+}
+
+// trashNotFound は記録が見つからないエラーを ErrTrashItemNotFound にします。
+func trashNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTrashItemNotFound
+	}
+	return err
+}
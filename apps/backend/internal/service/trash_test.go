@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// Trash Tests - ゴミ箱と復元のテスト
+// =============================================================================
+// テスト対象:
+//   - GetTrash: 保持期間内に削除された記録の一覧（種類の絞り込み、作物と一緒に削除した収穫記録の除外）
+//   - RestoreCrop / RestorePlot: 一緒に削除した子の復元、削除の記録の削除
+//   - RestoreHarvest: 作物が削除されている場合の ErrRestoreParentDeleted
+//   - RestoreTask: 他のユーザー・保持期間を過ぎた記録の ErrTrashItemNotFound
+
+// newTrashTestCrop は収穫記録・成長記録のある作物を作成します。
+func newTrashTestCrop(t *testing.T, svc *Service, userID uint, name string) (*model.Crop, *model.Harvest) {
+	t.Helper()
+	ctx := context.Background()
+	crop := &model.Crop{UserID: userID, Name: name, PlantedDate: time.Now(), ExpectedHarvestDate: time.Now().AddDate(0, 3, 0)}
+	if err := svc.CreateCrop(ctx, crop); err != nil {
+		t.Fatalf("CreateCrop failed: %v", err)
+	}
+	harvest := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 1, QuantityUnit: "kg"}
+	if err := svc.CreateHarvest(ctx, harvest); err != nil {
+		t.Fatalf("CreateHarvest failed: %v", err)
+	}
+	if err := svc.CreateGrowthRecord(ctx, &model.GrowthRecord{CropID: crop.ID, RecordDate: time.Now(), GrowthStage: "seedling"}); err != nil {
+		t.Fatalf("CreateGrowthRecord failed: %v", err)
+	}
+	return crop, harvest
+}
+
+// TestGetTrash はゴミ箱の一覧のテストです。
+// 期待動作:
+//   - ユーザーの削除したタスク・作物・区画・収穫記録を削除日時の新しい順に返し、purge_at は削除日時 + 保持期間
+//   - 作物と一緒に削除した収穫記録、保持期間を過ぎた記録、他のユーザーの記録は返さない
+//   - type で種類を絞り込み、不正な type は ErrInvalidTrashType
+func TestGetTrash(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	expired := &model.Task{UserID: 1, Title: "保持期間切れ", DueDate: time.Now()}
+	other := &model.Task{UserID: 2, Title: "他のユーザー", DueDate: time.Now()}
+	for _, tk := range []*model.Task{task, expired, other} {
+		_ = svc.CreateTask(ctx, tk)
+		_ = svc.DeleteTask(ctx, tk.ID)
+	}
+	mockRepos.GetMockTaskRepository().DeletedTasks[task.ID].DeletedAt.Time = time.Now().Add(-3 * time.Hour)
+	mockRepos.GetMockTaskRepository().DeletedTasks[expired.ID].DeletedAt.Time = time.Now().AddDate(0, 0, -31)
+
+	kept, harvest := newTrashTestCrop(t, svc, 1, "キュウリ")
+	_ = svc.DeleteHarvest(ctx, harvest.ID)
+	mockRepos.GetMockHarvestRepository().DeletedHarvests[harvest.ID].DeletedAt.Time = time.Now().Add(-2 * time.Hour)
+	deletedCrop, _ := newTrashTestCrop(t, svc, 1, "トマト")
+	_ = svc.DeleteCrop(ctx, deletedCrop.ID)
+	mockRepos.GetMockCropRepository().DeletedCrops[deletedCrop.ID].DeletedAt.Time = time.Now().Add(-time.Hour)
+	plot := &model.Plot{UserID: 1, Name: "畑A", Width: 1, Height: 1}
+	_ = svc.CreatePlot(ctx, plot)
+	_ = svc.DeletePlot(ctx, plot.ID)
+
+	// Act
+	items, err := svc.GetTrash(ctx, 1, "")
+	harvests, harvestsErr := svc.GetTrash(ctx, 1, SyncEntityHarvests)
+	_, invalidErr := svc.GetTrash(ctx, 1, "gardens")
+
+	// Assert
+	if err != nil || harvestsErr != nil {
+		t.Fatalf("GetTrash failed: %v, %v", err, harvestsErr)
+	}
+	want := []struct {
+		itemType string
+		id       uint
+		name     string
+	}{
+		{SyncEntityPlots, plot.ID, "畑A"},
+		{SyncEntityCrops, deletedCrop.ID, "トマト"},
+		{SyncEntityHarvests, harvest.ID, kept.Name},
+		{SyncEntityTasks, task.ID, "水やり"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Expected %d items, got %+v", len(want), items)
+	}
+	for i, w := range want {
+		if items[i].Type != w.itemType || items[i].ID != w.id || items[i].Name != w.name {
+			t.Errorf("items[%d] = %+v, want %s %d %s", i, items[i], w.itemType, w.id, w.name)
+		}
+		if items[i].PurgeAt == nil || !items[i].PurgeAt.Equal(items[i].DeletedAt.AddDate(0, 0, 30)) {
+			t.Errorf("items[%d]: expected purge_at 30 days after deletion, got %v", i, items[i].PurgeAt)
+		}
+	}
+	if len(harvests) != 1 || harvests[0].ID != harvest.ID {
+		t.Errorf("Expected only the individually deleted harvest, got %+v", harvests)
+	}
+	if !errors.Is(invalidErr, ErrInvalidTrashType) {
+		t.Errorf("Expected ErrInvalidTrashType, got %v", invalidErr)
+	}
+}
+
+// TestRestoreCrop は作物の復元のテストです。
+// 期待動作:
+//   - 作物と一緒に削除した成長記録・収穫記録を復元し、作物の削除より前に個別に削除した収穫記録は復元しない
+//   - 復元した作物・収穫記録の削除の記録を削除する
+//   - 他のユーザーの作物、削除されていない作物は ErrTrashItemNotFound
+func TestRestoreCrop(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	crop, cascaded := newTrashTestCrop(t, svc, 1, "トマト")
+	earlier := &model.Harvest{CropID: crop.ID, HarvestDate: time.Now(), Quantity: 2, QuantityUnit: "kg"}
+	_ = svc.CreateHarvest(ctx, earlier)
+	_ = svc.DeleteHarvest(ctx, earlier.ID)
+	mockRepos.GetMockHarvestRepository().DeletedHarvests[earlier.ID].DeletedAt.Time = time.Now().Add(-time.Hour)
+	_ = svc.DeleteCrop(ctx, crop.ID)
+
+	// Act
+	_, otherErr := svc.RestoreCrop(ctx, 2, crop.ID)
+	restored, err := svc.RestoreCrop(ctx, 1, crop.ID)
+	_, againErr := svc.RestoreCrop(ctx, 1, crop.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RestoreCrop failed: %v", err)
+	}
+	if restored.ID != crop.ID || restored.DeletedAt.Valid {
+		t.Errorf("Expected restored crop, got %+v", restored)
+	}
+	harvests, _ := mockRepos.Harvest().GetByCropID(ctx, crop.ID)
+	if len(harvests) != 1 || harvests[0].ID != cascaded.ID {
+		t.Errorf("Expected only the cascaded harvest to be restored, got %+v", harvests)
+	}
+	if records, _ := mockRepos.GrowthRecord().GetByCropID(ctx, crop.ID); len(records) != 1 {
+		t.Errorf("Expected growth record to be restored, got %d", len(records))
+	}
+	for _, tombstone := range mockRepos.GetMockSyncRepository().Tombstones {
+		if (tombstone.Entity == SyncEntityCrops && tombstone.EntityID == crop.ID) ||
+			(tombstone.Entity == SyncEntityHarvests && tombstone.EntityID == cascaded.ID) {
+			t.Errorf("Expected tombstone of restored record to be removed: %+v", tombstone)
+		}
+	}
+	if !errors.Is(otherErr, ErrTrashItemNotFound) || !errors.Is(againErr, ErrTrashItemNotFound) {
+		t.Errorf("Expected ErrTrashItemNotFound, got %v, %v", otherErr, againErr)
+	}
+}
+
+// TestRestoreHarvest は収穫記録の復元のテストです。
+// 期待動作:
+//   - 作物も削除されている場合は ErrRestoreParentDeleted
+//   - 作物を削除していない場合は収穫記録のみ復元する
+func TestRestoreHarvest(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	deletedCrop, orphan := newTrashTestCrop(t, svc, 1, "トマト")
+	_ = svc.DeleteCrop(ctx, deletedCrop.ID)
+	_, harvest := newTrashTestCrop(t, svc, 1, "ナス")
+	_ = svc.DeleteHarvest(ctx, harvest.ID)
+
+	// Act
+	_, parentErr := svc.RestoreHarvest(ctx, 1, orphan.ID)
+	restored, err := svc.RestoreHarvest(ctx, 1, harvest.ID)
+
+	// Assert
+	if !errors.Is(parentErr, ErrRestoreParentDeleted) {
+		t.Errorf("Expected ErrRestoreParentDeleted, got %v", parentErr)
+	}
+	if err != nil || restored.ID != harvest.ID {
+		t.Fatalf("RestoreHarvest = %+v, %v", restored, err)
+	}
+	if _, err := svc.GetHarvestByID(ctx, harvest.ID); err != nil {
+		t.Errorf("Expected harvest to be restored, got %v", err)
+	}
+}
+
+// TestRestorePlot は区画の復元のテストです。
+// 期待動作:
+//   - 区画と一緒に削除した配置履歴を復元する
+//   - 組織のスコープでは組織の区画のみ復元する
+func TestRestorePlot(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	plot := &model.Plot{UserID: 1, Name: "畑A", Width: 1, Height: 1}
+	_ = svc.CreatePlot(ctx, plot)
+	crop, _ := newTrashTestCrop(t, svc, 1, "トマト")
+	if _, err := svc.AssignCropToPlot(ctx, plot.ID, crop.ID, time.Now()); err != nil {
+		t.Fatalf("AssignCropToPlot failed: %v", err)
+	}
+	_ = svc.DeletePlot(ctx, plot.ID)
+
+	// Act
+	_, orgErr := svc.RestorePlot(repository.ContextWithOrganization(ctx, 99), 1, plot.ID)
+	restored, err := svc.RestorePlot(ctx, 1, plot.ID)
+
+	// Assert
+	if !errors.Is(orgErr, ErrTrashItemNotFound) {
+		t.Errorf("Expected personal plot not to be restored in organization scope, got %v", orgErr)
+	}
+	if err != nil || restored.ID != plot.ID {
+		t.Fatalf("RestorePlot = %+v, %v", restored, err)
+	}
+	if assignments, _ := mockRepos.PlotAssignment().GetByPlotID(ctx, plot.ID); len(assignments) != 1 {
+		t.Errorf("Expected assignment to be restored, got %d", len(assignments))
+	}
+}
+
+// TestRestoreTask_RetentionWindow は保持期間を過ぎたタスクの復元のテストです。
+// 期待動作:
+//   - 保持期間を過ぎたタスクは ErrTrashItemNotFound
+//   - 保持期間が 0（無期限）の場合は復元でき、GetTrash の purge_at は省略
+func TestRestoreTask_RetentionWindow(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	task := &model.Task{UserID: 1, Title: "水やり", DueDate: time.Now()}
+	_ = svc.CreateTask(ctx, task)
+	_ = svc.DeleteTask(ctx, task.ID)
+	mockRepos.GetMockTaskRepository().DeletedTasks[task.ID].DeletedAt.Time = time.Now().AddDate(0, 0, -31)
+
+	// Act
+	_, expiredErr := svc.RestoreTask(ctx, 1, task.ID)
+	svc.SetRetentionPolicy(RetentionPolicy{Days: map[string]int{config.RetentionTargetTasks: 0}})
+	items, _ := svc.GetTrash(ctx, 1, SyncEntityTasks)
+	restored, err := svc.RestoreTask(ctx, 1, task.ID)
+
+	// Assert
+	if !errors.Is(expiredErr, ErrTrashItemNotFound) {
+		t.Errorf("Expected ErrTrashItemNotFound after retention, got %v", expiredErr)
+	}
+	if len(items) != 1 || items[0].PurgeAt != nil {
+		t.Errorf("Expected task without purge_at, got %+v", items)
+	}
+	if err != nil || restored.Title != "水やり" {
+		t.Fatalf("RestoreTask = %+v, %v", restored, err)
+	}
+}
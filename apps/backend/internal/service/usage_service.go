@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// =============================================================================
+// Usage Statistics - 管理者向け利用統計
+// =============================================================================
+
+// 利用統計のメトリクス名
+const (
+	// UsageMetricRecordsCreated はAPI経由で作成されたレコード数（作物、収穫、タスク等）
+	UsageMetricRecordsCreated = "records_created"
+	// UsageMetricNotificationsSent は送信に成功した通知数
+	UsageMetricNotificationsSent = "notifications_sent"
+	// UsageMetricNotificationsFailed は送信に失敗した通知数
+	UsageMetricNotificationsFailed = "notifications_failed"
+	// UsageMetricExports は生成されたエクスポート数
+	UsageMetricExports = "exports"
+)
+
+const (
+	// DefaultUsageStatsDays は利用統計のデフォルト集計日数
+	DefaultUsageStatsDays = 30
+	// MaxUsageStatsDays は利用統計の最大集計日数
+	MaxUsageStatsDays = 366
+)
+
+var (
+	// ErrNotAdmin は管理者以外が管理者向けエンドポイントにアクセスした場合のエラー
+	ErrNotAdmin = errors.New("user is not an admin")
+	// ErrInvalidUsageStatsRange は集計日数が範囲外の場合のエラー
+	ErrInvalidUsageStatsRange = errors.New("invalid usage stats range")
+)
+
+// UsageDay は1日分の利用統計を表します。
+type UsageDay struct {
+	Date                string `json:"date"` // YYYY-MM-DD
+	ActiveUsers         int64  `json:"active_users"`
+	RecordsCreated      int64  `json:"records_created"`
+	NotificationsSent   int64  `json:"notifications_sent"`
+	NotificationsFailed int64  `json:"notifications_failed"`
+	Exports             int64  `json:"exports"`
+}
+
+// UsageTotals は期間全体の利用統計を表します。
+type UsageTotals struct {
+	ActiveUsers         int64 `json:"active_users"` // 期間内のユニークユーザー数
+	RecordsCreated      int64 `json:"records_created"`
+	NotificationsSent   int64 `json:"notifications_sent"`
+	NotificationsFailed int64 `json:"notifications_failed"`
+	Exports             int64 `json:"exports"`
+}
+
+// UsageStats は管理者向け利用統計のレスポンスです。
+// 指定期間の全日を日付順に含みます（記録がない日は0）。
+type UsageStats struct {
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Totals      UsageTotals `json:"totals"`
+	Daily       []UsageDay  `json:"daily"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
+
+// usageDate は利用統計の集計単位となる日付（UTCの0時）を返します。
+func usageDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IncrementUsage は当日の利用統計カウンターを加算します。
+// 統計の記録は本処理に影響させないため、呼び出し側はエラーを無視して構いません。
+func (s *Service) IncrementUsage(ctx context.Context, metric string, delta int64) error {
+	return s.repos.UsageStats().IncrementCounter(ctx, usageDate(s.now()), metric, delta)
+}
+
+// RecordUserActivity はユーザーを当日のアクティブユーザーとして記録します。
+func (s *Service) RecordUserActivity(ctx context.Context, userID uint) error {
+	return s.repos.UsageStats().RecordActiveUser(ctx, usageDate(s.now()), userID)
+}
+
+// IsAdminUser はユーザーが管理者かどうかを判定します。
+func (s *Service) IsAdminUser(ctx context.Context, userID uint) (bool, error) {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin, nil
+}
+
+// SetUserAdmin はユーザーの管理者権限を設定します（管理CLIから使用）。
+func (s *Service) SetUserAdmin(ctx context.Context, userID uint, isAdmin bool) error {
+	user, err := s.repos.User().GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	user.IsAdmin = isAdmin
+	return s.repos.User().Update(ctx, user)
+}
+
+// GetUsageStats は直近days日間（当日を含む）の利用統計を取得します。
+//
+// 引数:
+//   - ctx: リクエストコンテキスト
+//   - days: 集計日数（1〜MaxUsageStatsDays）
+//
+// 戻り値:
+//   - *UsageStats: 日別・期間合計の利用統計
+//   - error: 範囲外の場合は ErrInvalidUsageStatsRange
+func (s *Service) GetUsageStats(ctx context.Context, days int) (*UsageStats, error) {
+	if days < 1 || days > MaxUsageStatsDays {
+		return nil, ErrInvalidUsageStatsRange
+	}
+
+	to := usageDate(s.now())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	counters, err := s.repos.UsageStats().GetCounters(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	dailyActive, err := s.repos.UsageStats().GetDailyActiveUsers(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	distinctActive, err := s.repos.UsageStats().CountDistinctActiveUsers(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// 全日を0埋めで用意
+	stats := &UsageStats{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Daily:       make([]UsageDay, 0, days),
+		GeneratedAt: s.now(),
+	}
+	index := make(map[string]int, days)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		index[key] = len(stats.Daily)
+		stats.Daily = append(stats.Daily, UsageDay{Date: key})
+	}
+
+	for _, dc := range dailyActive {
+		if i, ok := index[dc.Date.Format("2006-01-02")]; ok {
+			stats.Daily[i].ActiveUsers = dc.Count
+		}
+	}
+
+	for _, counter := range counters {
+		i, ok := index[counter.Date.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		day := &stats.Daily[i]
+		switch counter.Metric {
+		case UsageMetricRecordsCreated:
+			day.RecordsCreated += counter.Value
+			stats.Totals.RecordsCreated += counter.Value
+		case UsageMetricNotificationsSent:
+			day.NotificationsSent += counter.Value
+			stats.Totals.NotificationsSent += counter.Value
+		case UsageMetricNotificationsFailed:
+			day.NotificationsFailed += counter.Value
+			stats.Totals.NotificationsFailed += counter.Value
+		case UsageMetricExports:
+			day.Exports += counter.Value
+			stats.Totals.Exports += counter.Value
+		}
+	}
+	stats.Totals.ActiveUsers = distinctActive
+
+	return stats, nil
+}
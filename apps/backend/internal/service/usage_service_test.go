@@ -0,0 +1,110 @@
+// Package service - UsageService Unit Tests
+//
+// 管理者向け利用統計のユニットテストを提供します。
+//
+// テスト対象:
+//   - カウンターの加算と日別集計
+//   - アクティブユーザーのユニーク集計
+//   - 管理者判定
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+	"github.com/secure-scorecard/backend/internal/repository"
+)
+
+// =============================================================================
+// GetUsageStats テスト
+// =============================================================================
+
+// TestGetUsageStats_AggregatesDaily は日別集計と期間合計をテストします。
+func TestGetUsageStats_AggregatesDaily(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	usageRepo := mockRepos.GetMockUsageStatsRepository()
+
+	today := usageDate(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+	_ = usageRepo.IncrementCounter(ctx, yesterday, UsageMetricRecordsCreated, 3)
+	_ = usageRepo.RecordActiveUser(ctx, yesterday, 1)
+	_ = usageRepo.RecordActiveUser(ctx, yesterday, 2)
+	_ = usageRepo.IncrementCounter(ctx, today.AddDate(0, 0, -30), UsageMetricRecordsCreated, 100) // 期間外
+
+	_ = svc.IncrementUsage(ctx, UsageMetricRecordsCreated, 1)
+	_ = svc.IncrementUsage(ctx, UsageMetricNotificationsSent, 2)
+	_ = svc.IncrementUsage(ctx, UsageMetricNotificationsFailed, 1)
+	_ = svc.RecordUserActivity(ctx, 1)
+	_ = svc.RecordUserActivity(ctx, 1)
+	_, _ = svc.RecordExport(ctx, 1, &CSVExportResult{DataType: ExportDataTypeCrops, FileName: "crops.csv"}, "")
+
+	// Act
+	stats, err := svc.GetUsageStats(ctx, 7)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetUsageStats failed: %v", err)
+	}
+	if len(stats.Daily) != 7 {
+		t.Fatalf("Expected 7 days, got %d", len(stats.Daily))
+	}
+	if stats.To != today.Format("2006-01-02") {
+		t.Errorf("Expected range to end today, got %s", stats.To)
+	}
+
+	y := stats.Daily[5]
+	if y.Date != yesterday.Format("2006-01-02") || y.ActiveUsers != 2 || y.RecordsCreated != 3 {
+		t.Errorf("Unexpected yesterday stats: %+v", y)
+	}
+	d := stats.Daily[6]
+	if d.ActiveUsers != 1 || d.RecordsCreated != 1 || d.NotificationsSent != 2 || d.NotificationsFailed != 1 || d.Exports != 1 {
+		t.Errorf("Unexpected today stats: %+v", d)
+	}
+	if stats.Daily[0].RecordsCreated != 0 {
+		t.Errorf("Expected zero-filled first day, got %+v", stats.Daily[0])
+	}
+
+	if stats.Totals.ActiveUsers != 2 {
+		t.Errorf("Expected 2 distinct active users, got %d", stats.Totals.ActiveUsers)
+	}
+	if stats.Totals.RecordsCreated != 4 {
+		t.Errorf("Expected 4 records created in range, got %d", stats.Totals.RecordsCreated)
+	}
+}
+
+// TestGetUsageStats_InvalidRange は範囲外の日数を拒否することをテストします。
+func TestGetUsageStats_InvalidRange(t *testing.T) {
+	svc := NewService(repository.NewMockRepositories())
+
+	for _, days := range []int{0, -1, MaxUsageStatsDays + 1} {
+		if _, err := svc.GetUsageStats(context.Background(), days); !errors.Is(err, ErrInvalidUsageStatsRange) {
+			t.Errorf("days=%d: expected ErrInvalidUsageStatsRange, got %v", days, err)
+		}
+	}
+}
+
+// TestIsAdminUser は管理者判定をテストします。
+func TestIsAdminUser(t *testing.T) {
+	// Arrange
+	mockRepos := repository.NewMockRepositories()
+	svc := NewService(mockRepos)
+	ctx := context.Background()
+	admin := &model.User{Email: "admin@example.com", IsAdmin: true}
+	member := &model.User{Email: "member@example.com"}
+	_ = mockRepos.User().Create(ctx, admin)
+	_ = mockRepos.User().Create(ctx, member)
+
+	// Act & Assert
+	if ok, err := svc.IsAdminUser(ctx, admin.ID); err != nil || !ok {
+		t.Errorf("Expected admin user, got %v (%v)", ok, err)
+	}
+	if ok, err := svc.IsAdminUser(ctx, member.ID); err != nil || ok {
+		t.Errorf("Expected non-admin user, got %v (%v)", ok, err)
+	}
+}
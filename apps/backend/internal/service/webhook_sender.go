@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/model"
+)
+
+// =============================================================================
+// Webhook Notification - Slack/Discord Webhook通知
+// =============================================================================
+// ユーザーが設定したSlack/DiscordのIncoming Webhook URLへ整形済みメッセージを投稿します。
+// サーバーから任意のURLへリクエストしないよう、URLは各サービスの公式Webhookホストに限定します。
+//
+// LINE Notify はサービスが終了（2025年3月31日）したため、通知チャネルとして対応していません。
+// LINEへの通知が必要になった場合は、公式アカウントとLINEログイン連携が前提となる Messaging API の
+// push message を別チャネルとして実装してください（ユーザー単位のアクセストークン方式は使えません）。
+
+const (
+	// NotificationChannelPush はプッシュ通知チャネル
+	NotificationChannelPush = "push"
+	// NotificationChannelEmail はメール通知チャネル
+	NotificationChannelEmail = "email"
+	// NotificationChannelSlack はSlack Webhook通知チャネル
+	NotificationChannelSlack = "slack"
+	// NotificationChannelDiscord はDiscord Webhook通知チャネル
+	NotificationChannelDiscord = "discord"
+
+	// webhookRequestTimeout はWebhook投稿のHTTPタイムアウト
+	webhookRequestTimeout = 10 * time.Second
+	// webhookUsername はDiscordに表示する投稿者名
+	webhookUsername = "Home Garden"
+	// webhookEmbedColor はDiscord埋め込みの色（メール通知のヘッダーと同じ緑）
+	webhookEmbedColor = 0x16a34a
+)
+
+var (
+	// ErrInvalidWebhookURL はSlack/DiscordのWebhook URLとして受け付けられない場合のエラー
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+)
+
+// webhookHosts はチャネルごとに許可するWebhookのホストとパスプレフィックスです。
+var webhookHosts = map[string][]struct{ host, pathPrefix string }{
+	NotificationChannelSlack: {
+		{"hooks.slack.com", "/services/"},
+	},
+	NotificationChannelDiscord: {
+		{"discord.com", "/api/webhooks/"},
+		{"discordapp.com", "/api/webhooks/"},
+	},
+}
+
+// ValidateWebhookURL はWebhook URLがチャネルの公式Webhookエンドポイントかを検証します。
+//
+// 引数:
+//   - channel: slack または discord
+//   - webhookURL: 検証するURL
+//
+// 戻り値:
+//   - error: 許可されていないURLの場合は ErrInvalidWebhookURL
+func ValidateWebhookURL(channel, webhookURL string) error {
+	allowed, ok := webhookHosts[channel]
+	if !ok {
+		return fmt.Errorf("unsupported webhook channel: %s", channel)
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return ErrInvalidWebhookURL
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(u.Hostname(), a.host) && strings.HasPrefix(u.Path, a.pathPrefix) && len(u.Path) > len(a.pathPrefix) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookURL
+}
+
+// webhookTargets はユーザー設定で有効になっているWebhookの送信先（チャネル→URL）を返します。
+func webhookTargets(settings *model.NotificationSettings) map[string]string {
+	targets := make(map[string]string)
+	if settings == nil {
+		return targets
+	}
+	if settings.SlackEnabled && settings.SlackWebhookURL != "" {
+		targets[NotificationChannelSlack] = settings.SlackWebhookURL
+	}
+	if settings.DiscordEnabled && settings.DiscordWebhookURL != "" {
+		targets[NotificationChannelDiscord] = settings.DiscordWebhookURL
+	}
+	return targets
+}
+
+// slackMessage はSlack Incoming Webhookのメッセージです。
+type slackMessage struct {
+	Text   string       `json:"text"` // 通知プレビュー用のフォールバック
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock はSlack Block Kitのブロックです。
+type slackBlock struct {
+	Type string          `json:"type"`
+	Text *slackBlockText `json:"text,omitempty"`
+}
+
+// slackBlockText はSlackブロックのテキストです。
+type slackBlockText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// discordMessage はDiscord Webhookのメッセージです。
+type discordMessage struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+// discordEmbed はDiscordの埋め込みです。
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+}
+
+// buildWebhookPayload はチャネルに応じたWebhookのリクエストボディを構築します。
+func buildWebhookPayload(channel, title, body string) ([]byte, error) {
+	switch channel {
+	case NotificationChannelSlack:
+		return json.Marshal(slackMessage{
+			Text: fmt.Sprintf("%s: %s", title, body),
+			Blocks: []slackBlock{
+				{Type: "header", Text: &slackBlockText{Type: "plain_text", Text: title}},
+				{Type: "section", Text: &slackBlockText{Type: "mrkdwn", Text: body}},
+			},
+		})
+	case NotificationChannelDiscord:
+		return json.Marshal(discordMessage{
+			Username: webhookUsername,
+			Embeds: []discordEmbed{
+				{Title: title, Description: body, Color: webhookEmbedColor},
+			},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported webhook channel: %s", channel)
+	}
+}
+
+// SendWebhookNotification はSlack/DiscordのWebhookへ通知を投稿します。
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - channel: slack または discord
+//   - webhookURL: ユーザーが設定したWebhook URL
+//   - title: 通知タイトル
+//   - body: 通知本文
+//
+// 戻り値:
+//   - error: URLが不正、または投稿に失敗した場合のエラー
+func (n *notificationSender) SendWebhookNotification(ctx context.Context, channel, webhookURL, title, body string) error {
+	if err := ValidateWebhookURL(channel, webhookURL); err != nil {
+		return err
+	}
+
+	payload, err := buildWebhookPayload(channel, title, body)
+	if err != nil {
+		return err
+	}
+
+	return n.sendWithRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := n.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// Slackは200、Discordは204を返す
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("%s webhook failed: status %d: %s", channel, resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+		return nil
+	})
+}
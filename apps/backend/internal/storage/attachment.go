@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// 添付ファイルの直接アップロード
+// =============================================================================
+// 作物・区画の添付ファイル（土壌検査の報告書・種苗の請求書の PDF、紙の書類を撮影した画像）は写真と同様に
+// PresignAttachmentUpload の Presigned URL で保存先に直接 PUT し、RegisterAttachmentUpload で登録します。
+// 登録では保存先のオブジェクトのサイズと先頭のバイト列の形式（クライアントが申告した Content-Type は信用しない）を確認し、
+// 条件を満たさないオブジェクトは削除します。添付ファイルは加工せず、公開URLでは取得できません
+// （ダウンロードは AttachmentDownloadURL のファイル名付きの署名付きURL）。
+
+const (
+	// AttachmentPrefix は添付ファイルのオブジェクトキーの接頭辞です（attachments/{userID}/{year}/{month}/{uuid}.{ext}）
+	AttachmentPrefix = "attachments"
+
+	// MaxAttachmentSize は添付ファイルの最大サイズ（10MB）
+	MaxAttachmentSize = 10 * 1024 * 1024
+)
+
+// AllowedAttachmentTypes は許可される添付ファイルの形式のMIMEタイプ（http.DetectContentType の判定結果）
+var AllowedAttachmentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+var (
+	// ErrAttachmentTooLarge は添付ファイルのサイズが上限を超えている場合のエラー
+	ErrAttachmentTooLarge = errors.New("attachment size exceeds maximum allowed size (10MB)")
+
+	// ErrInvalidAttachmentType は添付ファイルの形式が許可されていない場合のエラー
+	ErrInvalidAttachmentType = errors.New("invalid attachment type: only PDF, JPEG, PNG, and WEBP are allowed")
+)
+
+// RegisteredAttachment は登録した添付ファイルのオブジェクトです。
+type RegisteredAttachment struct {
+	ObjectKey   string // オブジェクトキー
+	ContentType string // 内容から判定したMIMEタイプ
+	Size        int64  // 保存先のオブジェクトのサイズ（バイト）
+}
+
+// PresignAttachmentUpload は添付ファイルの直接アップロード用のPresigned URLを生成します
+// アップロードの後、RegisterAttachmentUpload で登録するまでファイルは使用できません
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用、登録時の所有者の確認に使用）
+//   - contentType: MIMEタイプ（application/pdf等）
+//   - size: アップロードするファイルのサイズ（バイト、署名に含める）
+//
+// 戻り値:
+//   - *PresignedUploadResult: Presigned URL情報（ContentURL は空、ダウンロードは AttachmentDownloadURL）
+//   - error: 生成に失敗した場合のエラー（サイズ超過は ErrAttachmentTooLarge、形式不正は ErrInvalidAttachmentType）
+func (s *Service) PresignAttachmentUpload(ctx context.Context, userID uint, contentType string, size int64) (*PresignedUploadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+	if size > MaxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	ext, ok := AllowedAttachmentTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidAttachmentType
+	}
+
+	now := time.Now()
+	objectKey := fmt.Sprintf("%s%d/%02d/%s%s", attachmentUserPrefix(userID), now.Year(), now.Month(), uuid.New().String(), ext)
+
+	presigned, err := s.blobs.PresignPut(ctx, objectKey, contentType, size, PresignedURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &PresignedUploadResult{
+		UploadURL: presigned.URL,
+		Headers:   presigned.Headers,
+		ObjectKey: objectKey,
+		ExpiresAt: now.Add(PresignedURLExpiry),
+	}, nil
+}
+
+// RegisterAttachmentUpload は直接アップロードした添付ファイルを確認して登録します
+// サイズの上限を超える、または形式が許可されていないオブジェクトは保存先から削除します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（オブジェクトキーの所有者）
+//   - objectKey: PresignAttachmentUpload の戻り値のオブジェクトキー
+//
+// 戻り値:
+//   - *RegisteredAttachment: 登録したファイル（MIMEタイプは内容から判定、サイズは保存先のオブジェクトのサイズ）
+//   - error: 他のユーザーのオブジェクトキーは ErrUploadKeyForbidden、未アップロードは ErrUploadNotFound、
+//     サイズ超過は ErrAttachmentTooLarge、形式不正は ErrInvalidAttachmentType
+func (s *Service) RegisterAttachmentUpload(ctx context.Context, userID uint, objectKey string) (*RegisteredAttachment, error) {
+	if !strings.HasPrefix(objectKey, attachmentUserPrefix(userID)) || strings.Contains(objectKey, "..") {
+		return nil, ErrUploadKeyForbidden
+	}
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	size, err := s.blobs.Size(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get uploaded object: %w", err)
+	}
+	if size > MaxAttachmentSize {
+		s.deleteDirectUpload(ctx, objectKey)
+		return nil, ErrAttachmentTooLarge
+	}
+
+	data, err := s.readUploadHead(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := AllowedAttachmentTypes[contentType]; !ok {
+		s.deleteDirectUpload(ctx, objectKey)
+		return nil, ErrInvalidAttachmentType
+	}
+
+	return &RegisteredAttachment{ObjectKey: objectKey, ContentType: contentType, Size: size}, nil
+}
+
+// AttachmentDownloadURL は添付ファイルのダウンロード用の署名付きURLを生成します（有効期限15分）
+// Content-Disposition のファイル名は英数字・"."・"-"・"_" 以外を "_" に置き換えます
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー
+//   - fileName: 添付ファイルのファイル名
+//
+// 戻り値:
+//   - *PresignedDownloadResult: 署名付きURL情報
+//   - error: 生成に失敗した場合のエラー
+func (s *Service) AttachmentDownloadURL(ctx context.Context, objectKey, fileName string) (*PresignedDownloadResult, error) {
+	return s.GenerateDownloadURL(ctx, objectKey, attachmentDownloadName(fileName, path.Ext(objectKey)))
+}
+
+// DeleteAttachment は添付ファイルを保存先から削除します
+// 存在しないオブジェクトの削除は成功として扱われます
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー（RegisterAttachmentUpload で登録したファイル）
+//
+// 戻り値:
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) DeleteAttachment(ctx context.Context, objectKey string) error {
+	if !s.IsConfigured() {
+		return ErrStorageNotConfigured
+	}
+
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+// attachmentUserPrefix はユーザーの添付ファイルのオブジェクトキーの接頭辞を返します
+func attachmentUserPrefix(userID uint) string {
+	return fmt.Sprintf("%s/%d/", AttachmentPrefix, userID)
+}
+
+// attachmentDownloadName は Content-Disposition で使用できるファイル名を返します
+// 置き換えた結果が拡張子のみの場合は attachment{ext} とします
+func attachmentDownloadName(fileName, ext string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, path.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if strings.Trim(strings.TrimSuffix(name, path.Ext(name)), "_.") == "" {
+		return "attachment" + ext
+	}
+	return name
+}
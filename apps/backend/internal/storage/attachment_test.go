@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Attachment Tests - 添付ファイルの直接アップロードのテスト
+// =============================================================================
+// テスト対象:
+//   - Service.PresignAttachmentUpload / RegisterAttachmentUpload: サイズ・形式の確認、内容からのMIMEタイプの判定
+//   - Service.AttachmentDownloadURL: Content-Disposition で使用できるファイル名
+
+// pdfHeader は http.DetectContentType が application/pdf と判定する先頭のバイト列です。
+var pdfHeader = []byte("%PDF-1.7\n")
+
+// TestService_AttachmentUpload は添付ファイルの直接アップロードの登録のテストです。
+// 期待動作:
+//   - 署名付きURLで PUT した PDF を登録し、MIMEタイプはファイルの内容から判定する
+//   - 上限を超えるサイズ・許可されていない MIMEタイプの Presigned URL は生成しない
+//   - 内容が許可されていない形式のオブジェクトは ErrInvalidAttachmentType で削除する
+//   - 他のユーザー・写真の直接アップロードのオブジェクトキーは ErrUploadKeyForbidden
+func TestService_AttachmentUpload(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	svc := NewService(blobs)
+	ctx := context.Background()
+	body := append(append([]byte{}, pdfHeader...), "soil test report"...)
+
+	// Act
+	presigned, err := svc.PresignAttachmentUpload(ctx, 1, "application/pdf", int64(len(body)))
+	if err != nil {
+		t.Fatalf("PresignAttachmentUpload failed: %v", err)
+	}
+	serve(blobs, http.MethodPut, presigned.UploadURL, presigned.Headers, body)
+	registered, registerErr := svc.RegisterAttachmentUpload(ctx, 1, presigned.ObjectKey)
+
+	_, tooLargeErr := svc.PresignAttachmentUpload(ctx, 1, "application/pdf", MaxAttachmentSize+1)
+	_, typeErr := svc.PresignAttachmentUpload(ctx, 1, "application/zip", 10)
+
+	textKey := "attachments/1/2026/05/text.pdf"
+	_ = blobs.Put(ctx, textKey, strings.NewReader("not a pdf"), 9, PutOptions{})
+	_, invalidErr := svc.RegisterAttachmentUpload(ctx, 1, textKey)
+	_, otherUserErr := svc.RegisterAttachmentUpload(ctx, 2, presigned.ObjectKey)
+	_, photoKeyErr := svc.RegisterAttachmentUpload(ctx, 1, "uploads/1/2026/05/photo.png")
+
+	// Assert
+	if registerErr != nil {
+		t.Fatalf("RegisterAttachmentUpload failed: %v", registerErr)
+	}
+	if !strings.HasPrefix(presigned.ObjectKey, "attachments/1/") || !strings.HasSuffix(presigned.ObjectKey, ".pdf") {
+		t.Errorf("Unexpected object key %s", presigned.ObjectKey)
+	}
+	if registered.ContentType != "application/pdf" || registered.Size != int64(len(body)) {
+		t.Errorf("Expected a PDF of %d bytes, got %+v", len(body), registered)
+	}
+	if !errors.Is(tooLargeErr, ErrAttachmentTooLarge) || !errors.Is(typeErr, ErrInvalidAttachmentType) {
+		t.Errorf("Expected ErrAttachmentTooLarge and ErrInvalidAttachmentType, got %v / %v", tooLargeErr, typeErr)
+	}
+	if !errors.Is(invalidErr, ErrInvalidAttachmentType) {
+		t.Errorf("Expected ErrInvalidAttachmentType, got %v", invalidErr)
+	}
+	if _, err := blobs.Size(ctx, textKey); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected the rejected upload to be deleted, got %v", err)
+	}
+	if !errors.Is(otherUserErr, ErrUploadKeyForbidden) || !errors.Is(photoKeyErr, ErrUploadKeyForbidden) {
+		t.Errorf("Expected ErrUploadKeyForbidden, got %v / %v", otherUserErr, photoKeyErr)
+	}
+}
+
+// TestService_AttachmentDownloadURL は添付ファイルのダウンロード用の署名付きURLのテストです。
+// 期待動作:
+//   - ファイル名の英数字・"."・"-"・"_" 以外は "_" に置き換える
+//   - 置き換えると拡張子のみになるファイル名は attachment{オブジェクトキーの拡張子}
+//   - 添付ファイルは署名なしでは取得できない
+func TestService_AttachmentDownloadURL(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	svc := NewService(blobs)
+	ctx := context.Background()
+	key := "attachments/1/2026/05/report.pdf"
+	_ = blobs.Put(ctx, key, strings.NewReader("%PDF-1.7"), 8, PutOptions{})
+
+	// Act
+	named, namedErr := svc.AttachmentDownloadURL(ctx, key, "soil test; 2026.pdf")
+	japanese, japaneseErr := svc.AttachmentDownloadURL(ctx, key, "土壌検査.pdf")
+	unsigned := serve(blobs, http.MethodGet, svc.ContentURL(key), nil, nil)
+
+	// Assert
+	if namedErr != nil || japaneseErr != nil {
+		t.Fatalf("AttachmentDownloadURL failed: %v / %v", namedErr, japaneseErr)
+	}
+	for _, tc := range []struct {
+		rawURL string
+		want   string
+	}{
+		{named.DownloadURL, "soil_test__2026.pdf"},
+		{japanese.DownloadURL, "attachment.pdf"},
+	} {
+		parsed, _ := url.Parse(tc.rawURL)
+		if got := parsed.Query().Get("filename"); got != tc.want {
+			t.Errorf("Expected the file name %s, got %s", tc.want, got)
+		}
+		if rec := serve(blobs, http.MethodGet, tc.rawURL, nil, nil); rec.Code != http.StatusOK || rec.Header().Get("Content-Disposition") != "attachment; filename="+tc.want {
+			t.Errorf("Expected the download with %s, got %d %q", tc.want, rec.Code, rec.Header().Get("Content-Disposition"))
+		}
+	}
+	if unsigned.Code == http.StatusOK {
+		t.Error("Expected the attachment not to be public")
+	}
+}
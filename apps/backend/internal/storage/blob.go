@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// BlobStorage - オブジェクトの保存先
+// =============================================================================
+// Service（画像・エクスポートファイル・バックアップのキーの構成と検証）が使用する保存先の操作です。
+// 実装は STORAGE_BACKEND で選択します:
+//   - s3: Amazon S3（S3互換のストレージを含む、デフォルト）
+//   - local: サーバーのローカルファイルシステム（署名付きURLはAPIサーバーの /files で処理する）
+//   - gcs: Google Cloud Storage
+//
+// AWS を使用しない環境でも、local または gcs で画像のアップロード・写真の加工を使用できます。
+
+// ErrObjectNotFound はオブジェクトが存在しない場合のエラー
+var ErrObjectNotFound = errors.New("object not found")
+
+// PutOptions はオブジェクトの保存のオプションです。
+type PutOptions struct {
+	ContentType  string // MIMEタイプ
+	CacheControl string // Cache-Control（空の場合は指定しない）
+}
+
+// PresignedRequest は署名付きURLのリクエストです。
+type PresignedRequest struct {
+	URL     string            // 署名付きURL
+	Headers map[string]string // リクエストに付けるヘッダー（署名に含まれる）
+}
+
+// BlobStorage はオブジェクトの保存先です。
+// キーは "/" 区切りのパス（crops/images/1/2024/01/{uuid}.jpg 等）です。
+type BlobStorage interface {
+	// Put はオブジェクトを保存します（同じキーのオブジェクトは上書きします）。
+	Put(ctx context.Context, key string, body io.Reader, size int64, opts PutOptions) error
+
+	// Get はオブジェクトの内容を返します（存在しない場合は ErrObjectNotFound）。
+	// 呼び出し元で戻り値の io.ReadCloser を閉じてください。
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// GetRange はオブジェクトの offset から最大 length バイトを返します（存在しない場合は ErrObjectNotFound）。
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+
+	// Size はオブジェクトのサイズ（バイト）を返します（存在しない場合は ErrObjectNotFound）。
+	Size(ctx context.Context, key string) (int64, error)
+
+	// Delete はオブジェクトを削除します（存在しないオブジェクトの削除は成功として扱います）。
+	Delete(ctx context.Context, key string) error
+
+	// PresignPut はクライアントが直接 PUT するための署名付きURLを返します。
+	// size が 0 より大きい場合は、そのサイズ以外の PUT を拒否します。
+	PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error)
+
+	// PresignGet はダウンロード用の署名付きURLを返します（fileName の Content-Disposition: attachment）。
+	// fileName が空の場合は Content-Disposition を付けません（画像の表示用）。
+	PresignGet(ctx context.Context, key, fileName string, expiry time.Duration) (string, error)
+
+	// URL はオブジェクトの公開URL（画像URL）を返します。
+	URL(key string) string
+}
+
+// NewBlobStorage は設定の実装（STORAGE_BACKEND）の保存先を作成します。
+// s3 で S3 が未設定（S3_BUCKET_NAME 等がない）の場合は nil を返します（保存先なしで動作する）。
+//
+// 引数:
+//   - ctx: コンテキスト（GCS のクライアントの初期化に使用）
+//   - cfg: 保存先の設定
+//   - s3Cfg: S3/CloudFront設定（s3 の場合）
+//
+// 戻り値:
+//   - BlobStorage: 保存先（未設定の場合は nil）
+//   - error: 初期化に失敗した場合・未対応の実装の場合のエラー
+func NewBlobStorage(ctx context.Context, cfg config.StorageConfig, s3Cfg config.S3Config) (BlobStorage, error) {
+	switch cfg.Backend {
+	case "", config.StorageBackendS3:
+		s3Config := &S3Config{
+			Region:          s3Cfg.Region,
+			BucketName:      s3Cfg.BucketName,
+			AccessKeyID:     s3Cfg.AccessKeyID,
+			SecretAccessKey: s3Cfg.SecretAccessKey,
+			CloudFrontURL:   s3Cfg.CloudFrontURL,
+			Endpoint:        s3Cfg.Endpoint,
+		}
+		if !s3Config.IsConfigured() {
+			return nil, nil
+		}
+		return NewS3BlobStorage(s3Config)
+	case config.StorageBackendLocal:
+		return NewLocalBlobStorage(&LocalConfig{
+			Dir:        cfg.LocalDir,
+			BaseURL:    cfg.LocalBaseURL,
+			SigningKey: cfg.LocalSigningKey,
+		})
+	case config.StorageBackendGCS:
+		return NewGCSBlobStorage(ctx, &GCSConfig{
+			BucketName:      cfg.GCSBucketName,
+			CredentialsFile: cfg.GCSCredentialsFile,
+			PublicURL:       cfg.GCSPublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", cfg.Backend)
+	}
+}
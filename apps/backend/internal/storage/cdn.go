@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/logging"
+)
+
+// =============================================================================
+// CDN Invalidation - 差し替え・削除した画像の CDN のキャッシュの削除
+// =============================================================================
+// 写真のバリアントは長期間キャッシュする（Cache-Control: immutable）ため、差し替え・削除した画像が CDN に残ります。
+// InvalidateCache でキャッシュを削除するオブジェクトキーを登録し、FlushCacheInvalidations を一定間隔で呼び出して
+// まとめて CDN に削除を依頼します。1回の呼び出しで依頼するのは最大 batchSize 件の1回のみのため、
+// 呼び出しの間隔（CLOUDFRONT_INVALIDATION_INTERVAL_MS）が依頼の頻度の上限になり、
+// CloudFront の処理中の invalidation のパス数の上限（ディストリビューションごとに 3000）を超えないようにします。
+// 依頼に失敗した場合（上限を超えた場合の TooManyInvalidationsInProgress を含む）はキーを戻し、次の呼び出しで再度依頼します。
+
+const (
+	// DefaultCDNInvalidationBatchSize は1回の依頼のオブジェクトキーの最大数のデフォルトです
+	DefaultCDNInvalidationBatchSize = 1000
+
+	// DefaultCDNInvalidationInterval は依頼の間隔のデフォルトです
+	DefaultCDNInvalidationInterval = time.Minute
+
+	// MaxPendingCDNInvalidations は依頼待ちのオブジェクトキーの最大数です（超えたキーは登録しない）
+	MaxPendingCDNInvalidations = 10000
+)
+
+// CDNInvalidator は CDN のキャッシュの削除です。
+type CDNInvalidator interface {
+	// Invalidate はオブジェクトキーの画像URLの CDN のキャッシュの削除を1回で依頼します。
+	Invalidate(ctx context.Context, objectKeys []string) error
+}
+
+// cdnInvalidation は依頼待ちのキャッシュの削除です。
+type cdnInvalidation struct {
+	invalidator CDNInvalidator
+	batchSize   int
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	dropped int64 // 依頼待ちが上限に達して登録しなかったキーの累計
+}
+
+// SetCDNInvalidator は差し替え・削除した画像の CDN のキャッシュの削除を設定します
+// 未設定の場合、InvalidateCache・FlushCacheInvalidations は何もしません
+//
+// 引数:
+//   - invalidator: CDN のキャッシュの削除（CloudFront 等）
+//   - batchSize: 1回の依頼のオブジェクトキーの最大数（0以下の場合は DefaultCDNInvalidationBatchSize）
+func (s *Service) SetCDNInvalidator(invalidator CDNInvalidator, batchSize int) {
+	if batchSize <= 0 {
+		batchSize = DefaultCDNInvalidationBatchSize
+	}
+	s.cdn = &cdnInvalidation{invalidator: invalidator, batchSize: batchSize, pending: map[string]struct{}{}}
+}
+
+// InvalidateCache は CDN のキャッシュを削除するオブジェクトキーを登録します（依頼は FlushCacheInvalidations）
+// 登録済みのキーは1回にまとめます
+// 依頼待ちが MaxPendingCDNInvalidations 件に達した場合は登録せず、件数を DroppedCacheInvalidations に加えて警告します
+func (s *Service) InvalidateCache(objectKeys ...string) {
+	if s == nil || s.cdn == nil {
+		return
+	}
+
+	s.cdn.mu.Lock()
+	var dropped int
+	for _, key := range objectKeys {
+		if key == "" {
+			continue
+		}
+		if _, ok := s.cdn.pending[key]; !ok && len(s.cdn.pending) >= MaxPendingCDNInvalidations {
+			dropped++
+			continue
+		}
+		s.cdn.pending[key] = struct{}{}
+	}
+	s.cdn.dropped += int64(dropped)
+	pending := len(s.cdn.pending)
+	s.cdn.mu.Unlock()
+
+	if dropped > 0 {
+		logging.FromContext(context.Background()).Warn("Too many pending CDN invalidations, dropping keys",
+			"dropped", dropped, "pending", pending)
+	}
+}
+
+// FlushCacheInvalidations は依頼待ちのオブジェクトキーのうち最大 batchSize 件の CDN のキャッシュの削除を依頼します
+// 依頼に失敗した場合はキーを依頼待ちに戻し、次の呼び出しで再度依頼します
+//
+// 引数:
+//   - ctx: コンテキスト
+//
+// 戻り値:
+//   - error: 依頼に失敗した場合のエラー
+func (s *Service) FlushCacheInvalidations(ctx context.Context) error {
+	if s == nil || s.cdn == nil {
+		return nil
+	}
+
+	keys := s.cdn.take()
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := s.cdn.invalidator.Invalidate(ctx, keys); err != nil {
+		s.InvalidateCache(keys...)
+		return fmt.Errorf("failed to invalidate %d CDN paths: %w", len(keys), err)
+	}
+	return nil
+}
+
+// PendingCacheInvalidations は依頼待ちのオブジェクトキーの数を返します
+func (s *Service) PendingCacheInvalidations() int {
+	if s == nil || s.cdn == nil {
+		return 0
+	}
+	s.cdn.mu.Lock()
+	defer s.cdn.mu.Unlock()
+	return len(s.cdn.pending)
+}
+
+// DroppedCacheInvalidations は依頼待ちが上限に達して登録しなかったオブジェクトキーの累計を返します
+// 0 でない場合、CDN に古い画像が残っている可能性があります
+func (s *Service) DroppedCacheInvalidations() int64 {
+	if s == nil || s.cdn == nil {
+		return 0
+	}
+	s.cdn.mu.Lock()
+	defer s.cdn.mu.Unlock()
+	return s.cdn.dropped
+}
+
+// take は依頼待ちのオブジェクトキーを最大 batchSize 件（キーの順）取り出します
+func (c *cdnInvalidation) take() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.pending))
+	for key := range c.pending {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) > c.batchSize {
+		keys = keys[:c.batchSize]
+	}
+	for _, key := range keys {
+		delete(c.pending, key)
+	}
+	return keys
+}
@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/google/uuid"
+	"github.com/secure-scorecard/backend/internal/config"
+	"github.com/secure-scorecard/backend/internal/tracing"
+)
+
+// =============================================================================
+// CloudFront - S3 の画像URLの CDN（CLOUDFRONT_URL）のキャッシュの削除
+// =============================================================================
+// 画像URLは {CLOUDFRONT_URL}/{key} のため、CLOUDFRONT_URL のパスの後にオブジェクトキーを付けたパスの削除を依頼します。
+// ディストリビューションは CLOUDFRONT_DISTRIBUTION_ID、未設定の場合は最初の依頼で CLOUDFRONT_URL のホスト名が
+// ドメイン名・代替ドメイン名（CNAME）のディストリビューションを ListDistributions で探します
+// （IAM に cloudfront:CreateInvalidation、未設定の場合は cloudfront:ListDistributions が必要）。
+// S3互換のストレージ（S3_ENDPOINT、Cloudflare R2 等）の CLOUDFRONT_URL は CloudFront とは限らないため、
+// CLOUDFRONT_DISTRIBUTION_ID を設定した場合のみキャッシュを削除します。
+//
+// 写真の署名付きURLは CloudFront の署名付きURL（缶詰ポリシー、CLOUDFRONT_KEY_PAIR_ID と CLOUDFRONT_PRIVATE_KEY_FILE の鍵）で、
+// ディストリビューションの photos/* のビヘイビアに信頼されたキーグループを設定すると署名のないURLを拒否します。
+// CloudFront は署名のクエリをキャッシュキーに含めないため、URLごとに署名が異なっても CDN のキャッシュを使用します。
+
+// cloudFrontAPI は CloudFront のクライアントの使用する操作です（テストで置き換える）
+type cloudFrontAPI interface {
+	CreateInvalidation(ctx context.Context, params *cloudfront.CreateInvalidationInput, optFns ...func(*cloudfront.Options)) (*cloudfront.CreateInvalidationOutput, error)
+	ListDistributions(ctx context.Context, params *cloudfront.ListDistributionsInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsOutput, error)
+}
+
+// cloudFrontInvalidator は CloudFront の CDNInvalidator の実装です
+type cloudFrontInvalidator struct {
+	client     cloudFrontAPI
+	host       string // CLOUDFRONT_URL のホスト名（ディストリビューションの検索用）
+	pathPrefix string // CLOUDFRONT_URL のパス（末尾の "/" なし）
+
+	mu             sync.Mutex
+	distributionID string
+}
+
+// NewCDNInvalidator は画像URLの CDN のキャッシュの削除を作成します
+// s3 で CLOUDFRONT_URL を設定した場合のみ CloudFront のキャッシュを削除し、それ以外は nil を返します
+// （S3_ENDPOINT を設定した場合は CLOUDFRONT_DISTRIBUTION_ID も必要）
+//
+// 引数:
+//   - ctx: コンテキスト（AWS設定の読み込みに使用）
+//   - cfg: 保存先の設定
+//   - s3Cfg: S3/CloudFront設定
+//
+// 戻り値:
+//   - CDNInvalidator: CDN のキャッシュの削除（CDN を使用しない場合は nil）
+//   - error: CLOUDFRONT_URL が不正な場合・AWS設定の読み込みに失敗した場合のエラー
+func NewCDNInvalidator(ctx context.Context, cfg config.StorageConfig, s3Cfg config.S3Config) (CDNInvalidator, error) {
+	if (cfg.Backend != "" && cfg.Backend != config.StorageBackendS3) || s3Cfg.CloudFrontURL == "" {
+		return nil, nil
+	}
+	if s3Cfg.Endpoint != "" && s3Cfg.CloudFrontDistributionID == "" {
+		return nil, nil
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(s3Cfg.Region),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			s3Cfg.AccessKeyID,
+			s3Cfg.SecretAccessKey,
+			"",
+		)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	tracing.InstrumentAWS(&awsCfg)
+	return newCloudFrontInvalidator(cloudfront.NewFromConfig(awsCfg), s3Cfg.CloudFrontURL, s3Cfg.CloudFrontDistributionID)
+}
+
+// newCloudFrontInvalidator は CloudFront のキャッシュの削除を作成します
+func newCloudFrontInvalidator(client cloudFrontAPI, cloudFrontURL, distributionID string) (*cloudFrontInvalidator, error) {
+	parsed, err := url.Parse(cloudFrontURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid CLOUDFRONT_URL: %s", cloudFrontURL)
+	}
+	return &cloudFrontInvalidator{
+		client:         client,
+		host:           parsed.Hostname(),
+		pathPrefix:     strings.TrimSuffix(parsed.EscapedPath(), "/"),
+		distributionID: distributionID,
+	}, nil
+}
+
+// Invalidate はオブジェクトキーの画像URLのパスの CloudFront の invalidation を作成します
+func (i *cloudFrontInvalidator) Invalidate(ctx context.Context, objectKeys []string) error {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+	distributionID, err := i.distribution(ctx)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(objectKeys))
+	for _, key := range objectKeys {
+		paths = append(paths, i.path(key))
+	}
+	_, err = i.client.CreateInvalidation(ctx, &cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		InvalidationBatch: &types.InvalidationBatch{
+			CallerReference: aws.String(uuid.New().String()),
+			Paths: &types.Paths{
+				Quantity: aws.Int32(int32(len(paths))),
+				Items:    paths,
+			},
+		},
+	})
+	var tooMany *types.TooManyInvalidationsInProgress
+	if errors.As(err, &tooMany) {
+		return fmt.Errorf("CloudFront invalidation quota reached, retrying later: %w", err)
+	}
+	return err
+}
+
+// path はオブジェクトキーの画像URLの CloudFront のパスを返します（/{CLOUDFRONT_URL のパス}/{key}）
+func (i *cloudFrontInvalidator) path(objectKey string) string {
+	return i.pathPrefix + "/" + strings.TrimPrefix(objectKey, "/")
+}
+
+// distribution は CLOUDFRONT_URL のディストリビューションのIDを返します（未設定の場合は検索して記録する）
+func (i *cloudFrontInvalidator) distribution(ctx context.Context) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.distributionID != "" {
+		return i.distributionID, nil
+	}
+
+	paginator := cloudfront.NewListDistributionsPaginator(i.client, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list CloudFront distributions: %w", err)
+		}
+		if page.DistributionList == nil {
+			break
+		}
+		for _, distribution := range page.DistributionList.Items {
+			if i.matches(distribution) {
+				i.distributionID = aws.ToString(distribution.Id)
+				return i.distributionID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no CloudFront distribution for %s (set CLOUDFRONT_DISTRIBUTION_ID)", i.host)
+}
+
+// matches はディストリビューションのドメイン名・代替ドメイン名が CLOUDFRONT_URL のホスト名か判定します
+func (i *cloudFrontInvalidator) matches(distribution types.DistributionSummary) bool {
+	if strings.EqualFold(aws.ToString(distribution.DomainName), i.host) {
+		return true
+	}
+	if distribution.Aliases == nil {
+		return false
+	}
+	for _, alias := range distribution.Aliases.Items {
+		if strings.EqualFold(alias, i.host) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloudFrontURLSigner は CloudFront の署名付きURLの URLSigner の実装です
+type cloudFrontURLSigner struct {
+	keyPairID  string
+	privateKey *rsa.PrivateKey
+}
+
+// NewCloudFrontURLSigner は CloudFront の署名付きURLの署名を作成します
+// s3 で CLOUDFRONT_URL と CLOUDFRONT_KEY_PAIR_ID を設定した場合のみ作成し、それ以外は nil を返します
+//
+// 引数:
+//   - cfg: 保存先の設定
+//   - s3Cfg: S3/CloudFront設定
+//
+// 戻り値:
+//   - URLSigner: CloudFront の署名付きURLの署名（CloudFront で署名しない場合は nil）
+//   - error: 秘密鍵（PEM の RSA の鍵）を読み込めない場合のエラー
+func NewCloudFrontURLSigner(cfg config.StorageConfig, s3Cfg config.S3Config) (URLSigner, error) {
+	if (cfg.Backend != "" && cfg.Backend != config.StorageBackendS3) || s3Cfg.CloudFrontURL == "" || s3Cfg.CloudFrontKeyPairID == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(s3Cfg.CloudFrontPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CLOUDFRONT_PRIVATE_KEY_FILE: %w", err)
+	}
+	privateKey, err := parseRSAPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLOUDFRONT_PRIVATE_KEY_FILE: %w", err)
+	}
+	return &cloudFrontURLSigner{keyPairID: s3Cfg.CloudFrontKeyPairID, privateKey: privateKey}, nil
+}
+
+// SignURL は expires まで有効な CloudFront の署名付きURLを返します（缶詰ポリシー）
+func (s *cloudFrontURLSigner) SignURL(rawURL string, expires time.Time) (string, error) {
+	epoch := strconv.FormatInt(expires.Unix(), 10)
+	policy := `{"Statement":[{"Resource":"` + rawURL + `","Condition":{"DateLessThan":{"AWS:EpochTime":` + epoch + `}}}]}`
+	digest := sha1.Sum([]byte(policy))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA1, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign CloudFront URL: %w", err)
+	}
+
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + "Expires=" + epoch +
+		"&Signature=" + cloudFrontBase64(signature) +
+		"&Key-Pair-Id=" + url.QueryEscape(s.keyPairID), nil
+}
+
+// cloudFrontBase64 は CloudFront の署名のURLで使用できる Base64 を返します（+ → -、= → _、/ → ~）
+func cloudFrontBase64(data []byte) string {
+	return strings.NewReplacer("+", "-", "=", "_", "/", "~").Replace(base64.StdEncoding.EncodeToString(data))
+}
+
+// parseRSAPrivateKey は PEM の RSA の秘密鍵（PKCS#1・PKCS#8）を読み込みます
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
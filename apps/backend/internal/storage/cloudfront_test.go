@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// CDN Invalidation Tests - CDN のキャッシュの削除・署名付きURLのテスト
+// =============================================================================
+// テスト対象:
+//   - cloudFrontInvalidator: CLOUDFRONT_URL のパスの invalidation、ディストリビューションの検索
+//   - Service.InvalidateCache / FlushCacheInvalidations: まとめての依頼、1回の依頼の件数の上限、失敗した依頼の再試行
+//   - cloudFrontURLSigner: 缶詰ポリシーの署名付きURL、秘密鍵の読み込み
+
+// mockCloudFront はテスト用の CloudFront のクライアントです（作成した invalidation のパスを保持する）。
+type mockCloudFront struct {
+	distributions []types.DistributionSummary
+	createErr     error
+	listCalls     int
+	invalidations map[string][][]string // ディストリビューションのIDごとのパス
+}
+
+func (m *mockCloudFront) CreateInvalidation(ctx context.Context, params *cloudfront.CreateInvalidationInput, optFns ...func(*cloudfront.Options)) (*cloudfront.CreateInvalidationOutput, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	if aws.ToString(params.InvalidationBatch.CallerReference) == "" || int(aws.ToInt32(params.InvalidationBatch.Paths.Quantity)) != len(params.InvalidationBatch.Paths.Items) {
+		return nil, errors.New("invalid invalidation batch")
+	}
+	id := aws.ToString(params.DistributionId)
+	m.invalidations[id] = append(m.invalidations[id], params.InvalidationBatch.Paths.Items)
+	return &cloudfront.CreateInvalidationOutput{}, nil
+}
+
+func (m *mockCloudFront) ListDistributions(ctx context.Context, params *cloudfront.ListDistributionsInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsOutput, error) {
+	m.listCalls++
+	return &cloudfront.ListDistributionsOutput{
+		DistributionList: &types.DistributionList{Items: m.distributions, IsTruncated: aws.Bool(false)},
+	}, nil
+}
+
+// TestCloudFrontInvalidator は CloudFront の invalidation の作成のテストです。
+// 期待動作:
+//   - パスは CLOUDFRONT_URL のパスの後にオブジェクトキーを付ける
+//   - CLOUDFRONT_DISTRIBUTION_ID がない場合は代替ドメイン名が CLOUDFRONT_URL のホスト名のディストリビューションを1回だけ検索する
+//   - 一致するディストリビューションがない場合・不正な CLOUDFRONT_URL はエラー
+func TestCloudFrontInvalidator(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := &mockCloudFront{
+		distributions: []types.DistributionSummary{
+			{Id: aws.String("EOTHER"), DomainName: aws.String("d2.cloudfront.net")},
+			{Id: aws.String("EPHOTOS"), DomainName: aws.String("d1.cloudfront.net"), Aliases: &types.Aliases{Items: []string{"cdn.example.com"}}},
+		},
+		invalidations: map[string][][]string{},
+	}
+	byAlias, err := newCloudFrontInvalidator(client, "https://CDN.example.com/media/", "")
+	if err != nil {
+		t.Fatalf("newCloudFrontInvalidator failed: %v", err)
+	}
+	explicit, _ := newCloudFrontInvalidator(client, "https://d3.cloudfront.net", "EEXPLICIT")
+	unknown, _ := newCloudFrontInvalidator(client, "https://unknown.example.com", "")
+
+	// Act
+	firstErr := byAlias.Invalidate(ctx, []string{"photos/1/2/thumbnail.webp", "photos/1/2/large.webp"})
+	secondErr := byAlias.Invalidate(ctx, []string{"photos/1/3/thumbnail.webp"})
+	explicitErr := explicit.Invalidate(ctx, []string{"photos/1/2/medium.webp"})
+	unknownErr := unknown.Invalidate(ctx, []string{"photos/1/2/medium.webp"})
+	_, invalidURLErr := newCloudFrontInvalidator(client, "cdn.example.com", "")
+
+	// Assert
+	if firstErr != nil || secondErr != nil || explicitErr != nil {
+		t.Fatalf("Invalidate failed: %v / %v / %v", firstErr, secondErr, explicitErr)
+	}
+	want := "[[/media/photos/1/2/thumbnail.webp /media/photos/1/2/large.webp] [/media/photos/1/3/thumbnail.webp]]"
+	if got := fmt.Sprint(client.invalidations["EPHOTOS"]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if got := fmt.Sprint(client.invalidations["EEXPLICIT"]); got != "[[/photos/1/2/medium.webp]]" {
+		t.Errorf("Expected the path without a prefix, got %s", got)
+	}
+	if client.listCalls != 2 {
+		t.Errorf("Expected 1 lookup per invalidator without a distribution ID, got %d", client.listCalls)
+	}
+	if unknownErr == nil || invalidURLErr == nil {
+		t.Errorf("Expected errors for an unknown distribution and an invalid URL, got %v / %v", unknownErr, invalidURLErr)
+	}
+}
+
+// TestService_FlushCacheInvalidations は依頼待ちのキャッシュの削除のテストです。
+// 期待動作:
+//   - 同じキーは1回にまとめ、1回の呼び出しで最大 batchSize 件を依頼する
+//   - 依頼に失敗した場合（TooManyInvalidationsInProgress）はキーを戻し、次の呼び出しで再度依頼する
+//   - 未設定の場合は何もしない
+func TestService_FlushCacheInvalidations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	client := &mockCloudFront{invalidations: map[string][][]string{}}
+	invalidator, _ := newCloudFrontInvalidator(client, "https://cdn.example.com", "EPHOTOS")
+	svc := NewService(newTestLocalStorage(t))
+	svc.SetCDNInvalidator(invalidator, 2)
+	svc.InvalidateCache("photos/1/2/thumbnail.webp", "photos/1/2/medium.webp", "", "photos/1/2/large.webp")
+	svc.InvalidateCache("photos/1/2/thumbnail.webp")
+
+	// Act
+	client.createErr = &types.TooManyInvalidationsInProgress{Message: aws.String("Your request contains too many invalidations")}
+	quotaErr := svc.FlushCacheInvalidations(ctx)
+	pendingAfterQuota := svc.PendingCacheInvalidations()
+	client.createErr = nil
+	firstErr := svc.FlushCacheInvalidations(ctx)
+	secondErr := svc.FlushCacheInvalidations(ctx)
+	emptyErr := svc.FlushCacheInvalidations(ctx)
+
+	// Assert
+	var tooMany *types.TooManyInvalidationsInProgress
+	if !errors.As(quotaErr, &tooMany) || pendingAfterQuota != 3 {
+		t.Errorf("Expected the quota error with 3 keys kept, got %v (%d pending)", quotaErr, pendingAfterQuota)
+	}
+	if firstErr != nil || secondErr != nil || emptyErr != nil {
+		t.Fatalf("FlushCacheInvalidations failed: %v / %v / %v", firstErr, secondErr, emptyErr)
+	}
+	want := "[[/photos/1/2/large.webp /photos/1/2/medium.webp] [/photos/1/2/thumbnail.webp]]"
+	if got := fmt.Sprint(client.invalidations["EPHOTOS"]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	unconfigured := NewService(nil)
+	unconfigured.InvalidateCache("photos/1/2/thumbnail.webp")
+	if err := unconfigured.FlushCacheInvalidations(ctx); err != nil || unconfigured.PendingCacheInvalidations() != 0 {
+		t.Errorf("Expected no invalidation without an invalidator, got %v", err)
+	}
+}
+
+// TestService_InvalidateCache_Dropped は依頼待ちが上限に達した場合のテストです。
+// 期待動作:
+//   - 上限に達した後の新しいキーは登録せず、DroppedCacheInvalidations に件数を加える
+//   - 登録済みのキーは上限に達していても落とさない
+func TestService_InvalidateCache_Dropped(t *testing.T) {
+	// Arrange
+	client := &mockCloudFront{invalidations: map[string][][]string{}}
+	invalidator, _ := newCloudFrontInvalidator(client, "https://cdn.example.com", "EPHOTOS")
+	svc := NewService(newTestLocalStorage(t))
+	svc.SetCDNInvalidator(invalidator, 0)
+	keys := make([]string, MaxPendingCDNInvalidations)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("photos/1/%d/thumbnail.webp", i)
+	}
+	svc.InvalidateCache(keys...)
+
+	// Act
+	svc.InvalidateCache(keys[0], "photos/2/1/thumbnail.webp", "photos/2/2/thumbnail.webp")
+
+	// Assert
+	if got := svc.PendingCacheInvalidations(); got != MaxPendingCDNInvalidations {
+		t.Errorf("Expected %d pending keys, got %d", MaxPendingCDNInvalidations, got)
+	}
+	if got := svc.DroppedCacheInvalidations(); got != 2 {
+		t.Errorf("Expected 2 dropped keys, got %d", got)
+	}
+}
+
+// TestCloudFrontURLSigner は CloudFront の署名付きURLのテストです。
+// 期待動作:
+//   - Expires・Signature・Key-Pair-Id を付け、署名は缶詰ポリシーの RSA-SHA1（CloudFront の Base64）
+//   - CLOUDFRONT_KEY_PAIR_ID が未設定・s3 以外の保存先の場合は nil
+//   - 秘密鍵のファイルが読み込めない・RSA の鍵でない場合はエラー
+func TestCloudFrontURLSigner(t *testing.T) {
+	// Arrange
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	keyFile := filepath.Join(t.TempDir(), "cloudfront.pem")
+	_ = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	invalidFile := filepath.Join(t.TempDir(), "invalid.pem")
+	_ = os.WriteFile(invalidFile, []byte("not a key"), 0o600)
+	s3Cfg := config.S3Config{CloudFrontURL: "https://cdn.example.com", CloudFrontKeyPairID: "K2JCJMDEHXQW5F", CloudFrontPrivateKeyFile: keyFile}
+	rawURL := "https://cdn.example.com/photos/1/2/thumbnail.webp"
+	expires := time.Unix(1767225600, 0)
+
+	// Act
+	signer, err := NewCloudFrontURLSigner(config.StorageConfig{}, s3Cfg)
+	if err != nil || signer == nil {
+		t.Fatalf("NewCloudFrontURLSigner failed: %v", err)
+	}
+	signed, signErr := signer.SignURL(rawURL, expires)
+	noKeyPair, noKeyPairErr := NewCloudFrontURLSigner(config.StorageConfig{}, config.S3Config{CloudFrontURL: "https://cdn.example.com"})
+	local, localErr := NewCloudFrontURLSigner(config.StorageConfig{Backend: config.StorageBackendLocal}, s3Cfg)
+	s3Cfg.CloudFrontPrivateKeyFile = invalidFile
+	_, invalidErr := NewCloudFrontURLSigner(config.StorageConfig{}, s3Cfg)
+	s3Cfg.CloudFrontPrivateKeyFile = filepath.Join(t.TempDir(), "missing.pem")
+	_, missingErr := NewCloudFrontURLSigner(config.StorageConfig{}, s3Cfg)
+
+	// Assert
+	if signErr != nil {
+		t.Fatalf("SignURL failed: %v", signErr)
+	}
+	parsed, _ := url.Parse(signed)
+	query := parsed.Query()
+	if !strings.HasPrefix(signed, rawURL+"?Expires=1767225600&") || query.Get("Key-Pair-Id") != "K2JCJMDEHXQW5F" {
+		t.Errorf("Expected Expires and Key-Pair-Id on the URL, got %s", signed)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.NewReplacer("-", "+", "_", "=", "~", "/").Replace(query.Get("Signature")))
+	if err != nil || strings.ContainsAny(query.Get("Signature"), "+=/") {
+		t.Fatalf("Expected a CloudFront Base64 signature, got %q", query.Get("Signature"))
+	}
+	policy := `{"Statement":[{"Resource":"` + rawURL + `","Condition":{"DateLessThan":{"AWS:EpochTime":1767225600}}}]}`
+	digest := sha1.Sum([]byte(policy))
+	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA1, digest[:], signature); err != nil {
+		t.Errorf("Expected a signature of the canned policy, got %v", err)
+	}
+	if noKeyPair != nil || noKeyPairErr != nil || local != nil || localErr != nil {
+		t.Errorf("Expected no signer without a key pair or for local storage, got %v / %v", noKeyPair, local)
+	}
+	if invalidErr == nil || missingErr == nil {
+		t.Errorf("Expected errors for an invalid and a missing key file, got %v / %v", invalidErr, missingErr)
+	}
+}
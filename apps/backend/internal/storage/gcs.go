@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// =============================================================================
+// GCS - Google Cloud Storage の保存先（STORAGE_BACKEND=gcs）
+// =============================================================================
+// 認証はサービスアカウントのJSONファイル（GCS_CREDENTIALS_FILE）または Application Default Credentials です。
+// 署名付きURLは V4 署名で、サービスアカウントの鍵がない環境（Cloud Run 等）では IAM の signBlob で署名します
+// （サービスアカウントに roles/iam.serviceAccountTokenCreator が必要）。
+// 画像URLは CDN（GCS_PUBLIC_URL）または https://storage.googleapis.com/{bucket}/{key} です。
+
+// GCSConfig はGCS接続設定を保持します
+type GCSConfig struct {
+	BucketName      string // バケット名
+	CredentialsFile string // サービスアカウントのJSONファイルのパス（空の場合は Application Default Credentials）
+	PublicURL       string // 画像URLのCDN等のURL（オプション）
+}
+
+// gcsBlobStorage はGCSのBlobStorageの実装です
+type gcsBlobStorage struct {
+	bucket *gcs.BucketHandle
+	config *GCSConfig
+}
+
+// NewGCSBlobStorage はGCSの保存先を作成します
+//
+// 引数:
+//   - ctx: コンテキスト（認証情報の読み込みに使用）
+//   - cfg: GCS設定
+//
+// 戻り値:
+//   - BlobStorage: GCSの保存先
+//   - error: バケット名がない場合・クライアントの初期化に失敗した場合のエラー
+func NewGCSBlobStorage(ctx context.Context, cfg *GCSConfig) (BlobStorage, error) {
+	if cfg == nil || cfg.BucketName == "" {
+		return nil, errors.New("GCS_BUCKET_NAME is required for the gcs storage backend")
+	}
+
+	var opts []option.ClientOption
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithAuthCredentialsFile(option.ServiceAccount, cfg.CredentialsFile))
+	}
+	client, err := gcs.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &gcsBlobStorage{bucket: client.Bucket(cfg.BucketName), config: cfg}, nil
+}
+
+// Put はオブジェクトをGCSに保存します
+// 書き込みに失敗した場合はコンテキストをキャンセルし、書き込み途中のオブジェクトを作成しません
+func (b *gcsBlobStorage) Put(ctx context.Context, key string, body io.Reader, size int64, opts PutOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := b.bucket.Object(key).NewWriter(ctx)
+	writer.ContentType = opts.ContentType
+	writer.CacheControl = opts.CacheControl
+	written, err := io.Copy(writer, body)
+	if err == nil && written != size {
+		err = fmt.Errorf("object size mismatch: expected %d bytes, got %d", size, written)
+	}
+	if err != nil {
+		cancel()
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// Get はGCSのオブジェクトの内容を返します
+func (b *gcsBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := b.bucket.Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, gcsError(err)
+	}
+	return reader, nil
+}
+
+// GetRange はGCSのオブジェクトの一部を返します
+func (b *gcsBlobStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	reader, err := b.bucket.Object(key).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		return nil, gcsError(err)
+	}
+	return reader, nil
+}
+
+// Size はGCSのオブジェクトのサイズを返します
+func (b *gcsBlobStorage) Size(ctx context.Context, key string) (int64, error) {
+	attrs, err := b.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		return 0, gcsError(err)
+	}
+	return attrs.Size, nil
+}
+
+// Delete はGCSのオブジェクトを削除します（存在しないオブジェクトの削除は成功として扱う）
+func (b *gcsBlobStorage) Delete(ctx context.Context, key string) error {
+	if err := b.bucket.Object(key).Delete(ctx); err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PresignPut はアップロード用の署名付きURLを生成します
+// size は x-goog-content-length-range として署名に含め、そのサイズ以外の PUT を GCS が拒否します
+func (b *gcsBlobStorage) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error) {
+	headers := map[string]string{"Content-Type": contentType}
+	opts := &gcs.SignedURLOptions{
+		Scheme:      gcs.SigningSchemeV4,
+		Method:      http.MethodPut,
+		Expires:     time.Now().Add(expiry),
+		ContentType: contentType,
+	}
+	if size > 0 {
+		lengthRange := strconv.FormatInt(size, 10) + "," + strconv.FormatInt(size, 10)
+		opts.Headers = []string{"x-goog-content-length-range:" + lengthRange}
+		headers["x-goog-content-length-range"] = lengthRange
+	}
+
+	signedURL, err := b.bucket.SignedURL(key, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &PresignedRequest{URL: signedURL, Headers: headers}, nil
+}
+
+// PresignGet はダウンロード用の署名付きURLを生成します
+func (b *gcsBlobStorage) PresignGet(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	opts := &gcs.SignedURLOptions{
+		Scheme:  gcs.SigningSchemeV4,
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+	}
+	if fileName != "" {
+		opts.QueryParameters = url.Values{
+			"response-content-disposition": {fmt.Sprintf("attachment; filename=%s", fileName)},
+		}
+	}
+	return b.bucket.SignedURL(key, opts)
+}
+
+// URL はオブジェクトの画像URLを返します（CDN経由またはGCSの公開URL）
+func (b *gcsBlobStorage) URL(key string) string {
+	if b.config.PublicURL != "" {
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(b.config.PublicURL, "/"), key)
+	}
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", b.config.BucketName, key)
+}
+
+// gcsError は存在しないオブジェクトのエラーを ErrObjectNotFound に変換します
+func gcsError(err error) error {
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return fmt.Errorf("%w: %v", ErrObjectNotFound, err)
+	}
+	return err
+}
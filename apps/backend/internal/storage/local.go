@@ -0,0 +1,344 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Local - ローカルファイルシステムの保存先（STORAGE_BACKEND=local）
+// =============================================================================
+// AWS・GCS を使用しないセルフホスト向けに、オブジェクトを STORAGE_LOCAL_DIR 配下のファイルとして保存します。
+// 署名付きURLは APIサーバーの /files/{key} を指し、HMAC-SHA256 の署名と有効期限を ServeHTTP で確認します。
+// 画像（crops/・uploads/、キーに UUID を含む）は署名なしの GET で公開し、写真のバリアント（photos/、キーを推測できる）・
+// エクスポートファイル・バックアップは署名付きURLでのみ取得できます。
+// Content-Type は拡張子から判定し、Cache-Control は保存しません。
+
+// LocalFilesPath はローカルの保存先の署名付きURL・画像URLのパスです（/files/{key}）
+const LocalFilesPath = "/files"
+
+// localPublicPrefixes は署名なしで取得できるオブジェクトキーの接頭辞です（画像URL）
+var localPublicPrefixes = []string{"crops/", DirectUploadPrefix + "/"}
+
+// errInvalidObjectKey はファイルのパスにできないオブジェクトキーのエラー
+var errInvalidObjectKey = errors.New("invalid object key")
+
+// LocalConfig はローカルの保存先の設定を保持します
+type LocalConfig struct {
+	Dir        string // 保存先のディレクトリ
+	BaseURL    string // 署名付きURL・画像URLのAPIサーバーのURL（http://localhost:8080 等）
+	SigningKey string // 署名付きURLの署名の鍵
+}
+
+// localBlobStorage はローカルファイルシステムのBlobStorageの実装です
+type localBlobStorage struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalBlobStorage はローカルファイルシステムの保存先を作成します（ディレクトリがない場合は作成する）
+//
+// 引数:
+//   - cfg: ローカルの保存先の設定
+//
+// 戻り値:
+//   - BlobStorage: ローカルの保存先（署名付きURLの http.Handler を実装する）
+//   - error: 設定が不足している場合・ディレクトリを作成できない場合のエラー
+func NewLocalBlobStorage(cfg *LocalConfig) (BlobStorage, error) {
+	if cfg == nil || cfg.Dir == "" || cfg.BaseURL == "" || cfg.SigningKey == "" {
+		return nil, errors.New("directory, base URL and signing key are required for the local storage backend")
+	}
+	dir, err := filepath.Abs(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &localBlobStorage{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		signingKey: []byte(cfg.SigningKey),
+		now:        time.Now,
+	}, nil
+}
+
+// path はオブジェクトキーのファイルのパスを返します（保存先のディレクトリの外を指すキーは拒否する）
+func (b *localBlobStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", errInvalidObjectKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", errInvalidObjectKey
+		}
+	}
+	return filepath.Join(b.dir, filepath.FromSlash(key)), nil
+}
+
+// Put はオブジェクトを一時ファイルに書き込んでから置き換えます（書き込み途中のファイルを読ませない）
+// size が負の場合はサイズを確認しません
+func (b *localBlobStorage) Put(ctx context.Context, key string, body io.Reader, size int64, opts PutOptions) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // 置き換えた後は存在しない
+
+	reader := body
+	if size >= 0 {
+		reader = io.LimitReader(body, size+1)
+	}
+	written, err := io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("object size mismatch: expected %d bytes, got %d", size, written)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get はファイルを開きます
+func (b *localBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	return file, err
+}
+
+// GetRange はファイルの offset から最大 length バイトを返します
+func (b *localBlobStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	body, err := b.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	file := body.(*os.File)
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+// Size はファイルのサイズを返します
+func (b *localBlobStorage) Size(ctx context.Context, key string) (int64, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+		return 0, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Delete はファイルを削除します（存在しないファイルの削除は成功として扱う）
+func (b *localBlobStorage) Delete(ctx context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PresignPut はアップロード用の署名付きURLを生成します（size・Content-Type を署名に含める）
+func (b *localBlobStorage) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error) {
+	if _, err := b.path(key); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(b.now().Add(expiry).Unix(), 10))
+	query.Set("content_type", contentType)
+	headers := map[string]string{"Content-Type": contentType}
+	if size > 0 {
+		query.Set("size", strconv.FormatInt(size, 10))
+		headers["Content-Length"] = strconv.FormatInt(size, 10)
+	}
+	query.Set("signature", b.sign(http.MethodPut, key, query))
+	return &PresignedRequest{URL: b.URL(key) + "?" + query.Encode(), Headers: headers}, nil
+}
+
+// PresignGet はダウンロード用の署名付きURLを生成します
+func (b *localBlobStorage) PresignGet(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	if _, err := b.path(key); err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(b.now().Add(expiry).Unix(), 10))
+	if fileName != "" {
+		query.Set("filename", fileName)
+	}
+	query.Set("signature", b.sign(http.MethodGet, key, query))
+	return b.URL(key) + "?" + query.Encode(), nil
+}
+
+// URL はオブジェクトの画像URLを返します（{BaseURL}/files/{key}）
+func (b *localBlobStorage) URL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return b.baseURL + LocalFilesPath + "/" + strings.Join(segments, "/")
+}
+
+// sign は署名付きURLの署名を返します（メソッド・キー・有効期限・サイズ・Content-Type・ファイル名）
+func (b *localBlobStorage) sign(method, key string, query url.Values) string {
+	mac := hmac.New(sha256.New, b.signingKey)
+	for _, part := range []string{method, key, query.Get("expires"), query.Get("size"), query.Get("content_type"), query.Get("filename")} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{'\n'})
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify は署名付きURLの署名と有効期限を確認します
+func (b *localBlobStorage) verify(method, key string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || b.now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(query.Get("signature")), []byte(b.sign(method, key, query)))
+}
+
+// =============================================================================
+// 署名付きURLのHTTPハンドラ（/files/{key}）
+// =============================================================================
+
+// ServeHTTP は署名付きURLの PUT（アップロード）と GET・HEAD（ダウンロード、画像URL）を処理します
+func (b *localBlobStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, LocalFilesPath+"/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, err := b.path(key); err != nil {
+		http.Error(w, "invalid object key", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		b.servePut(w, r, key)
+	case http.MethodGet, http.MethodHead:
+		b.serveGet(w, r, key)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// servePut は署名付きURLのアップロードを保存します
+// サイズを署名した場合は Content-Length がそのサイズと一致しない PUT を拒否します（S3 の Presigned URL と同じ）
+func (b *localBlobStorage) servePut(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	if !b.verify(http.MethodPut, key, query) || r.Header.Get("Content-Type") != query.Get("content_type") {
+		http.Error(w, "signature does not match or has expired", http.StatusForbidden)
+		return
+	}
+
+	// サイズを署名しない署名付きURLは画像のアップロード（GenerateUploadURL）のみのため、画像の上限まで受け付ける
+	size, limit := int64(-1), int64(MaxImageSize)
+	if signed := query.Get("size"); signed != "" {
+		size, _ = strconv.ParseInt(signed, 10, 64)
+		if r.ContentLength != size {
+			http.Error(w, "content length does not match the signed size", http.StatusForbidden)
+			return
+		}
+		limit = size
+	}
+	body := http.MaxBytesReader(w, r.Body, limit)
+
+	if err := b.Put(r.Context(), key, body, size, PutOptions{ContentType: query.Get("content_type")}); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "object is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to store object", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveGet はファイルを返します（Range・If-Modified-Since に対応）
+// ファイル名を署名した署名付きURLは Content-Disposition: attachment を付け、署名なしは公開の画像の接頭辞のみ許可します
+func (b *localBlobStorage) serveGet(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	if query.Has("signature") {
+		if !b.verify(http.MethodGet, key, query) {
+			http.Error(w, "signature does not match or has expired", http.StatusForbidden)
+			return
+		}
+		if fileName := query.Get("filename"); fileName != "" {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+		}
+	} else if !hasAnyPrefix(key, localPublicPrefixes) {
+		http.Error(w, "access denied", http.StatusForbidden)
+		return
+	}
+
+	path, _ := b.path(key)
+	file, err := os.Open(path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// hasAnyPrefix は key がいずれかの接頭辞で始まるかチェックします
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secure-scorecard/backend/internal/config"
+)
+
+// =============================================================================
+// Local Storage Tests - ローカルファイルシステムの保存先のテスト
+// =============================================================================
+// テスト対象:
+//   - localBlobStorage: 保存・取得・サイズ・削除、保存先のディレクトリの外を指すキー
+//   - ServeHTTP: 署名付きURLの PUT・GET、署名・サイズ・有効期限の確認、署名なしの GET
+//   - Service: ローカルの保存先での直接アップロードの登録、写真の署名付きURL
+//   - NewBlobStorage: STORAGE_BACKEND による実装の選択
+
+// pngHeader は http.DetectContentType が image/png と判定する先頭のバイト列です。
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+// newTestLocalStorage はテスト用の一時ディレクトリのローカルの保存先を作成します。
+func newTestLocalStorage(t *testing.T) *localBlobStorage {
+	t.Helper()
+	blobs, err := NewLocalBlobStorage(&LocalConfig{Dir: t.TempDir(), BaseURL: "http://files.test/", SigningKey: "local-test-signing-key"})
+	if err != nil {
+		t.Fatalf("NewLocalBlobStorage failed: %v", err)
+	}
+	return blobs.(*localBlobStorage)
+}
+
+// serve は署名付きURLのリクエストをローカルの保存先の ServeHTTP で処理します。
+func serve(blobs *localBlobStorage, method, rawURL string, headers map[string]string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, rawURL, bytes.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	blobs.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestLocalBlobStorage はローカルの保存先の操作のテストです。
+// 期待動作:
+//   - 保存したオブジェクトの内容・一部・サイズを取得できる
+//   - サイズが一致しない保存はエラーで、ファイルを作成しない
+//   - 削除したオブジェクトは ErrObjectNotFound で、再度の削除は成功する
+//   - ".." や空のセグメントを含むキーは拒否する
+func TestLocalBlobStorage(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	ctx := context.Background()
+	key := "photos/1/2/thumbnail.webp"
+
+	// Act
+	putErr := blobs.Put(ctx, key, strings.NewReader("variant"), 7, PutOptions{ContentType: "image/webp"})
+	mismatchErr := blobs.Put(ctx, "photos/1/2/medium.webp", strings.NewReader("short"), 10, PutOptions{})
+	size, sizeErr := blobs.Size(ctx, key)
+	part, rangeErr := blobs.GetRange(ctx, key, 2, 3)
+
+	// Assert
+	if putErr != nil || sizeErr != nil || rangeErr != nil {
+		t.Fatalf("Unexpected errors: put=%v size=%v range=%v", putErr, sizeErr, rangeErr)
+	}
+	if data, _ := io.ReadAll(part); string(data) != "ria" || size != 7 {
+		t.Errorf("Expected range %q and size 7, got %q and %d", "ria", data, size)
+	}
+	_ = part.Close()
+	if mismatchErr == nil {
+		t.Error("Expected an error for a size mismatch")
+	}
+	if _, err := blobs.Size(ctx, "photos/1/2/medium.webp"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected no file after a size mismatch, got %v", err)
+	}
+
+	if err := blobs.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := blobs.Get(ctx, key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound after delete, got %v", err)
+	}
+	if err := blobs.Delete(ctx, key); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+	for _, invalid := range []string{"../outside", "photos/../../outside", "/etc/passwd", "photos//1"} {
+		if err := blobs.Put(ctx, invalid, strings.NewReader("x"), 1, PutOptions{}); !errors.Is(err, errInvalidObjectKey) {
+			t.Errorf("Expected errInvalidObjectKey for %q, got %v", invalid, err)
+		}
+	}
+}
+
+// TestLocalBlobStorage_ServeHTTP は署名付きURLのHTTPハンドラのテストです。
+// 期待動作:
+//   - 署名付きURLに headers を付けた PUT は保存する
+//   - 署名したサイズ・Content-Type と異なる PUT、改ざんした・期限切れの署名は 403
+//   - ダウンロードの署名付きURLは Content-Disposition: attachment を付ける
+//   - 署名なしの GET は画像の接頭辞のみ許可する（エクスポートファイルは 403）
+func TestLocalBlobStorage_ServeHTTP(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	ctx := context.Background()
+	key := "uploads/1/2026/05/photo.png"
+	body := append(append([]byte{}, pngHeader...), "image"...)
+	presigned, err := blobs.PresignPut(ctx, key, "image/png", int64(len(body)), time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut failed: %v", err)
+	}
+
+	// Act
+	wrongSize := serve(blobs, http.MethodPut, presigned.URL, presigned.Headers, body[:4])
+	wrongType := serve(blobs, http.MethodPut, presigned.URL, map[string]string{"Content-Type": "image/jpeg"}, body)
+	tampered := serve(blobs, http.MethodPut, strings.Replace(presigned.URL, "uploads/1/", "uploads/2/", 1), presigned.Headers, body)
+	uploaded := serve(blobs, http.MethodPut, presigned.URL, presigned.Headers, body)
+	public := serve(blobs, http.MethodGet, blobs.URL(key), nil, nil)
+
+	_ = blobs.Put(ctx, "exports/1/2026/05/crops.csv", strings.NewReader("a,b"), 3, PutOptions{})
+	private := serve(blobs, http.MethodGet, blobs.URL("exports/1/2026/05/crops.csv"), nil, nil)
+	downloadURL, _ := blobs.PresignGet(ctx, "exports/1/2026/05/crops.csv", "crops.csv", time.Minute)
+	download := serve(blobs, http.MethodGet, downloadURL, nil, nil)
+	blobs.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	expired := serve(blobs, http.MethodGet, downloadURL, nil, nil)
+
+	// Assert
+	if wrongSize.Code != http.StatusForbidden || wrongType.Code != http.StatusForbidden || tampered.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for size, type and key mismatches, got %d / %d / %d", wrongSize.Code, wrongType.Code, tampered.Code)
+	}
+	if uploaded.Code != http.StatusOK || public.Code != http.StatusOK || !bytes.Equal(public.Body.Bytes(), body) {
+		t.Errorf("Expected the upload to be served publicly, got %d / %d", uploaded.Code, public.Code)
+	}
+	if private.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an unsigned export download, got %d", private.Code)
+	}
+	if download.Code != http.StatusOK || download.Body.String() != "a,b" || download.Header().Get("Content-Disposition") != "attachment; filename=crops.csv" {
+		t.Errorf("Expected the signed download as an attachment, got %d %q", download.Code, download.Header().Get("Content-Disposition"))
+	}
+	if expired.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an expired URL, got %d", expired.Code)
+	}
+}
+
+// TestService_PhotoURL は写真の署名付きURLのテストです。
+// 期待動作:
+//   - 写真のバリアントは署名なしの GET は 403 で、署名付きURLは Content-Disposition を付けずに返す
+//   - 有効期限は PhotoURLExpiry 後
+//   - CDN の署名を設定した場合は画像URLに CDN の署名を付ける
+func TestService_PhotoURL(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	ctx := context.Background()
+	key := "photos/1/2/thumbnail.webp"
+	_ = blobs.Put(ctx, key, strings.NewReader("thumbnail"), 9, PutOptions{ContentType: "image/webp"})
+	svc := NewService(blobs)
+
+	// Act
+	before := time.Now()
+	signed, err := svc.PhotoURL(ctx, key)
+	unsigned := serve(blobs, http.MethodGet, blobs.URL(key), nil, nil)
+	var inline *httptest.ResponseRecorder
+	if err == nil {
+		inline = serve(blobs, http.MethodGet, signed.DownloadURL, nil, nil)
+	}
+	svc.SetURLSigner(fakeURLSigner{})
+	cdnSigned, cdnErr := svc.PhotoURL(ctx, key)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PhotoURL failed: %v", err)
+	}
+	if unsigned.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an unsigned photo, got %d", unsigned.Code)
+	}
+	if inline.Code != http.StatusOK || inline.Body.String() != "thumbnail" || inline.Header().Get("Content-Disposition") != "" {
+		t.Errorf("Expected the signed photo inline, got %d %q", inline.Code, inline.Header().Get("Content-Disposition"))
+	}
+	if signed.ExpiresAt.Before(before.Add(PhotoURLExpiry)) || signed.ExpiresAt.After(time.Now().Add(PhotoURLExpiry)) {
+		t.Errorf("Expected the URL to expire after %s, got %s", PhotoURLExpiry, signed.ExpiresAt)
+	}
+	if cdnErr != nil || cdnSigned.DownloadURL != blobs.URL(key)+"?signed" {
+		t.Errorf("Expected the CDN signature on the content URL, got %+v / %v", cdnSigned, cdnErr)
+	}
+}
+
+// fakeURLSigner はテスト用の CDN の署名です（URLの後に ?signed を付ける）。
+type fakeURLSigner struct{}
+
+func (fakeURLSigner) SignURL(rawURL string, expires time.Time) (string, error) {
+	return rawURL + "?signed", nil
+}
+
+// TestService_LocalDirectUpload はローカルの保存先での直接アップロードの登録のテストです。
+// 期待動作:
+//   - 署名付きURLで PUT した画像を登録し、画像URLは /files/{key}
+//   - 画像形式が許可されていないオブジェクトは ErrInvalidImageType で削除する
+//   - アップロードしていないオブジェクトキーは ErrUploadNotFound
+func TestService_LocalDirectUpload(t *testing.T) {
+	// Arrange
+	blobs := newTestLocalStorage(t)
+	svc := NewService(blobs)
+	ctx := context.Background()
+	body := append(append([]byte{}, pngHeader...), "image"...)
+
+	// Act
+	presigned, err := svc.PresignDirectUpload(ctx, 1, "image/png", int64(len(body)))
+	if err != nil {
+		t.Fatalf("PresignDirectUpload failed: %v", err)
+	}
+	serve(blobs, http.MethodPut, presigned.UploadURL, presigned.Headers, body)
+	registered, registerErr := svc.RegisterDirectUpload(ctx, 1, presigned.ObjectKey)
+
+	textKey := "uploads/1/2026/05/text.png"
+	_ = blobs.Put(ctx, textKey, strings.NewReader("not an image"), 12, PutOptions{})
+	_, invalidErr := svc.RegisterDirectUpload(ctx, 1, textKey)
+	_, missingErr := svc.RegisterDirectUpload(ctx, 1, "uploads/1/2026/05/missing.png")
+
+	// Assert
+	if registerErr != nil || registered.Size != int64(len(body)) {
+		t.Fatalf("Expected the upload to be registered, got %+v %v", registered, registerErr)
+	}
+	if registered.ContentURL != "http://files.test/files/"+presigned.ObjectKey {
+		t.Errorf("Unexpected content URL %s", registered.ContentURL)
+	}
+	if !errors.Is(invalidErr, ErrInvalidImageType) {
+		t.Errorf("Expected ErrInvalidImageType, got %v", invalidErr)
+	}
+	if _, err := blobs.Size(ctx, textKey); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected the rejected upload to be deleted, got %v", err)
+	}
+	if !errors.Is(missingErr, ErrUploadNotFound) {
+		t.Errorf("Expected ErrUploadNotFound, got %v", missingErr)
+	}
+}
+
+// TestNewBlobStorage は STORAGE_BACKEND による実装の選択のテストです。
+// 期待動作:
+//   - s3 で S3 が未設定の場合は保存先なし（nil）
+//   - local はローカルの保存先（署名付きURLの HTTPHandler を返す）
+//   - gcs でバケット名がない場合・未対応の実装はエラー
+func TestNewBlobStorage(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	local := config.StorageConfig{Backend: config.StorageBackendLocal, LocalDir: t.TempDir(), LocalBaseURL: "http://localhost:8080", LocalSigningKey: "key"}
+
+	// Act
+	s3Blobs, s3Err := NewBlobStorage(ctx, config.StorageConfig{Backend: config.StorageBackendS3}, config.S3Config{})
+	localBlobs, localErr := NewBlobStorage(ctx, local, config.S3Config{})
+	_, gcsErr := NewBlobStorage(ctx, config.StorageConfig{Backend: config.StorageBackendGCS}, config.S3Config{})
+	_, unknownErr := NewBlobStorage(ctx, config.StorageConfig{Backend: "ftp"}, config.S3Config{})
+
+	// Assert
+	if s3Blobs != nil || s3Err != nil {
+		t.Errorf("Expected no storage without S3 settings, got %v %v", s3Blobs, s3Err)
+	}
+	if localErr != nil || NewService(localBlobs).HTTPHandler() == nil {
+		t.Errorf("Expected the local storage with an HTTP handler, got %v", localErr)
+	}
+	if NewService(nil).HTTPHandler() != nil || NewService(nil).IsConfigured() {
+		t.Error("Expected an unconfigured service without an HTTP handler")
+	}
+	if gcsErr == nil || unknownErr == nil {
+		t.Errorf("Expected errors for gcs without a bucket and an unknown backend, got %v / %v", gcsErr, unknownErr)
+	}
+}
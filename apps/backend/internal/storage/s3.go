@@ -1,11 +1,3 @@
-// Package storage - S3ストレージサービス
-//
-// AWS S3を使用した画像アップロード機能を提供します。
-// 機能:
-//   - Presigned URLの生成（アップロード用、ダウンロード用）
-//   - 画像バリデーション（サイズ、形式）
-//   - Exponential backoffリトライ
-//   - CloudFront CDN統合
 package storage
 
 import (
@@ -13,9 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
-	"net/http"
-	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,55 +13,15 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/google/uuid"
-)
-
-// =============================================================================
-// 定数定義
-// =============================================================================
-
-const (
-	// MaxImageSize は画像の最大サイズ（5MB）
-	MaxImageSize = 5 * 1024 * 1024
-
-	// PresignedURLExpiry はPresigned URLの有効期限（15分）
-	PresignedURLExpiry = 15 * time.Minute
-
-	// MaxRetryAttempts はリトライの最大回数
-	MaxRetryAttempts = 3
-
-	// InitialRetryDelay は最初のリトライ待機時間
-	InitialRetryDelay = 1 * time.Second
-)
-
-// AllowedImageTypes は許可される画像形式のMIMEタイプ
-var AllowedImageTypes = map[string]string{
-	"image/jpeg": ".jpg",
-	"image/png":  ".png",
-	"image/webp": ".webp",
-}
-
-// =============================================================================
-// エラー定義
-// =============================================================================
-
-var (
-	// ErrFileTooLarge はファイルサイズが上限を超えている場合のエラー
-	ErrFileTooLarge = errors.New("file size exceeds maximum allowed size (5MB)")
-
-	// ErrInvalidImageType は画像形式が許可されていない場合のエラー
-	ErrInvalidImageType = errors.New("invalid image type: only JPEG, PNG, and WEBP are allowed")
-
-	// ErrUploadFailed はアップロードが失敗した場合のエラー
-	ErrUploadFailed = errors.New("failed to upload image after retries")
-
-	// ErrS3NotConfigured はS3が設定されていない場合のエラー
-	ErrS3NotConfigured = errors.New("S3 storage is not configured")
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/secure-scorecard/backend/internal/tracing"
 )
 
 // =============================================================================
-// 設定構造体
+// S3 - Amazon S3 の保存先（STORAGE_BACKEND=s3）
 // =============================================================================
+// S3互換のストレージ（LocalStack、MinIO 等）は S3_ENDPOINT で接続します。
+// 画像URLは CloudFront 経由（CLOUDFRONT_URL）または S3 の公開URLです。
 
 // S3Config はS3接続設定を保持します
 type S3Config struct {
@@ -88,28 +38,24 @@ func (c *S3Config) IsConfigured() bool {
 	return c.BucketName != "" && c.Region != ""
 }
 
-// =============================================================================
-// サービス構造体
-// =============================================================================
-
-// S3Service はS3ストレージ操作を提供するサービスです
-type S3Service struct {
-	client          *s3.Client
-	presignClient   *s3.PresignClient
-	config          *S3Config
+// s3BlobStorage はS3のBlobStorageの実装です
+type s3BlobStorage struct {
+	client        *s3.Client
+	presignClient *s3.PresignClient
+	config        *S3Config
 }
 
-// NewS3Service は新しいS3Serviceインスタンスを作成します
+// NewS3BlobStorage はS3の保存先を作成します
 //
 // 引数:
 //   - cfg: S3設定
 //
 // 戻り値:
-//   - *S3Service: S3サービスインスタンス
-//   - error: 初期化に失敗した場合のエラー
-func NewS3Service(cfg *S3Config) (*S3Service, error) {
+//   - BlobStorage: S3の保存先
+//   - error: 未設定の場合・初期化に失敗した場合のエラー
+func NewS3BlobStorage(cfg *S3Config) (BlobStorage, error) {
 	if cfg == nil || !cfg.IsConfigured() {
-		return &S3Service{config: cfg}, nil // 設定なしでも動作（ダミーモード）
+		return nil, errors.New("S3_BUCKET_NAME and AWS_REGION are required for the s3 storage backend")
 	}
 
 	// AWS設定を構築
@@ -124,6 +70,7 @@ func NewS3Service(cfg *S3Config) (*S3Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	tracing.InstrumentAWS(&awsCfg)
 
 	// S3クライアントを作成
 	var client *s3.Client
@@ -137,234 +84,125 @@ func NewS3Service(cfg *S3Config) (*S3Service, error) {
 		client = s3.NewFromConfig(awsCfg)
 	}
 
-	// Presignクライアントを作成
-	presignClient := s3.NewPresignClient(client)
-
-	return &S3Service{
+	return &s3BlobStorage{
 		client:        client,
-		presignClient: presignClient,
+		presignClient: s3.NewPresignClient(client),
 		config:        cfg,
 	}, nil
 }
 
-// =============================================================================
-// Presigned URL生成
-// =============================================================================
-
-// PresignedUploadResult はPresigned URL生成結果を表します
-type PresignedUploadResult struct {
-	UploadURL   string    `json:"upload_url"`    // アップロード用Presigned URL
-	ObjectKey   string    `json:"object_key"`    // S3オブジェクトキー
-	ContentURL  string    `json:"content_url"`   // アップロード後の画像URL（CloudFront経由）
-	ExpiresAt   time.Time `json:"expires_at"`    // URLの有効期限
-}
-
-// GenerateUploadURL はアップロード用のPresigned URLを生成します
-//
-// 引数:
-//   - ctx: コンテキスト
-//   - userID: ユーザーID（パス構成用）
-//   - contentType: MIMEタイプ（image/jpeg等）
-//
-// 戻り値:
-//   - *PresignedUploadResult: Presigned URL情報
-//   - error: 生成に失敗した場合のエラー
-func (s *S3Service) GenerateUploadURL(ctx context.Context, userID uint, contentType string) (*PresignedUploadResult, error) {
-	// S3が設定されているかチェック
-	if s.client == nil || s.config == nil || !s.config.IsConfigured() {
-		return nil, ErrS3NotConfigured
+// Put はオブジェクトをS3に保存します
+func (b *s3BlobStorage) Put(ctx context.Context, key string, body io.Reader, size int64, opts PutOptions) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(b.config.BucketName),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentType:   aws.String(opts.ContentType),
+		ContentLength: aws.Int64(size),
 	}
-
-	// 画像形式をバリデーション
-	ext, ok := AllowedImageTypes[contentType]
-	if !ok {
-		return nil, ErrInvalidImageType
+	if opts.CacheControl != "" {
+		input.CacheControl = aws.String(opts.CacheControl)
 	}
+	_, err := b.client.PutObject(ctx, input)
+	return err
+}
 
-	// ユニークなオブジェクトキーを生成
-	// パス形式: crops/images/{userID}/{year}/{month}/{uuid}.{ext}
-	now := time.Now()
-	objectKey := fmt.Sprintf("crops/images/%d/%d/%02d/%s%s",
-		userID,
-		now.Year(),
-		now.Month(),
-		uuid.New().String(),
-		ext,
-	)
-
-	// Presigned URLを生成
-	expiresAt := now.Add(PresignedURLExpiry)
-	presignedReq, err := s.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.config.BucketName),
-		Key:         aws.String(objectKey),
-		ContentType: aws.String(contentType),
-	}, s3.WithPresignExpires(PresignedURLExpiry))
+// Get はS3のオブジェクトの内容を返します
+func (b *s3BlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.config.BucketName),
+		Key:    aws.String(key),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+		return nil, s3Error(err)
 	}
+	return output.Body, nil
+}
 
-	// コンテンツURLを構築（CloudFront経由またはS3直接）
-	var contentURL string
-	if s.config.CloudFrontURL != "" {
-		contentURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(s.config.CloudFrontURL, "/"), objectKey)
-	} else {
-		contentURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-			s.config.BucketName,
-			s.config.Region,
-			objectKey,
-		)
+// GetRange はS3のオブジェクトの一部を返します（Range リクエスト）
+func (b *s3BlobStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	output, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.config.BucketName),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+	if err != nil {
+		return nil, s3Error(err)
 	}
-
-	return &PresignedUploadResult{
-		UploadURL:  presignedReq.URL,
-		ObjectKey:  objectKey,
-		ContentURL: contentURL,
-		ExpiresAt:  expiresAt,
-	}, nil
+	return output.Body, nil
 }
 
-// =============================================================================
-// 画像アップロード（サーバーサイド）
-// =============================================================================
-
-// UploadResult はアップロード結果を表します
-type UploadResult struct {
-	ObjectKey  string `json:"object_key"`   // S3オブジェクトキー
-	ContentURL string `json:"content_url"`  // 画像URL
-	Size       int64  `json:"size"`         // ファイルサイズ（バイト）
+// Size はS3のオブジェクトのサイズを返します（HeadObject）
+func (b *s3BlobStorage) Size(ctx context.Context, key string) (int64, error) {
+	head, err := b.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.config.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, s3Error(err)
+	}
+	return aws.ToInt64(head.ContentLength), nil
 }
 
-// UploadImage はサーバーサイドで画像をS3にアップロードします
-// Exponential backoffリトライを適用します
-//
-// 引数:
-//   - ctx: コンテキスト
-//   - userID: ユーザーID（パス構成用）
-//   - reader: 画像データのReader
-//   - contentType: MIMEタイプ
-//   - size: ファイルサイズ
-//
-// 戻り値:
-//   - *UploadResult: アップロード結果
-//   - error: アップロードに失敗した場合のエラー
-func (s *S3Service) UploadImage(ctx context.Context, userID uint, reader io.Reader, contentType string, size int64) (*UploadResult, error) {
-	// S3が設定されているかチェック
-	if s.client == nil || s.config == nil || !s.config.IsConfigured() {
-		return nil, ErrS3NotConfigured
-	}
+// Delete はS3のオブジェクトを削除します（存在しないオブジェクトの削除は成功として扱われます、S3の仕様）
+func (b *s3BlobStorage) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.config.BucketName),
+		Key:    aws.String(key),
+	})
+	return err
+}
 
-	// サイズをバリデーション
-	if size > MaxImageSize {
-		return nil, ErrFileTooLarge
+// PresignPut はアップロード用のPresigned URLを生成します（size は Content-Length として署名に含める）
+func (b *s3BlobStorage) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error) {
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(b.config.BucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
 	}
-
-	// 画像形式をバリデーション
-	ext, ok := AllowedImageTypes[contentType]
-	if !ok {
-		return nil, ErrInvalidImageType
+	headers := map[string]string{"Content-Type": contentType}
+	if size > 0 {
+		input.ContentLength = aws.Int64(size)
+		headers["Content-Length"] = strconv.FormatInt(size, 10)
 	}
 
-	// オブジェクトキーを生成
-	now := time.Now()
-	objectKey := fmt.Sprintf("crops/images/%d/%d/%02d/%s%s",
-		userID,
-		now.Year(),
-		now.Month(),
-		uuid.New().String(),
-		ext,
-	)
-
-	// Exponential backoffリトライでアップロード
-	var lastErr error
-	for attempt := 0; attempt < MaxRetryAttempts; attempt++ {
-		if attempt > 0 {
-			// 待機時間を計算（初回1秒、2回目2秒、3回目4秒）
-			delay := time.Duration(math.Pow(2, float64(attempt-1))) * InitialRetryDelay
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
-		}
-
-		_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
-			Bucket:        aws.String(s.config.BucketName),
-			Key:           aws.String(objectKey),
-			Body:          reader,
-			ContentType:   aws.String(contentType),
-			ContentLength: aws.Int64(size),
-		})
-		if err == nil {
-			// 成功
-			var contentURL string
-			if s.config.CloudFrontURL != "" {
-				contentURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(s.config.CloudFrontURL, "/"), objectKey)
-			} else {
-				contentURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-					s.config.BucketName,
-					s.config.Region,
-					objectKey,
-				)
-			}
-
-			return &UploadResult{
-				ObjectKey:  objectKey,
-				ContentURL: contentURL,
-				Size:       size,
-			}, nil
-		}
-
-		lastErr = err
+	presignedReq, err := b.presignClient.PresignPutObject(ctx, input, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, fmt.Errorf("%w: %v", ErrUploadFailed, lastErr)
+	return &PresignedRequest{URL: presignedReq.URL, Headers: headers}, nil
 }
 
-// =============================================================================
-// バリデーションヘルパー
-// =============================================================================
-
-// ValidateImageFile は画像ファイルをバリデーションします
-//
-// 引数:
-//   - fileHeader: アップロードされたファイルのヘッダー
-//   - size: ファイルサイズ
-//
-// 戻り値:
-//   - string: 検出されたMIMEタイプ
-//   - error: バリデーションエラー
-func ValidateImageFile(data []byte, size int64) (string, error) {
-	// サイズチェック
-	if size > MaxImageSize {
-		return "", ErrFileTooLarge
+// PresignGet はダウンロード用のPresigned URLを生成します
+func (b *s3BlobStorage) PresignGet(ctx context.Context, key, fileName string, expiry time.Duration) (string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(b.config.BucketName),
+		Key:    aws.String(key),
 	}
-
-	// MIMEタイプを検出
-	contentType := http.DetectContentType(data)
-
-	// 許可されたタイプかチェック
-	if _, ok := AllowedImageTypes[contentType]; !ok {
-		return "", ErrInvalidImageType
+	if fileName != "" {
+		input.ResponseContentDisposition = aws.String(fmt.Sprintf("attachment; filename=%s", fileName))
 	}
-
-	return contentType, nil
+	presignedReq, err := b.presignClient.PresignGetObject(ctx, input, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+	return presignedReq.URL, nil
 }
 
-// GetExtensionFromContentType はMIMEタイプから拡張子を取得します
-func GetExtensionFromContentType(contentType string) string {
-	if ext, ok := AllowedImageTypes[contentType]; ok {
-		return ext
+// URL はオブジェクトの画像URLを返します（CloudFront経由またはS3直接）
+func (b *s3BlobStorage) URL(key string) string {
+	if b.config.CloudFrontURL != "" {
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(b.config.CloudFrontURL, "/"), key)
 	}
-	return ""
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", b.config.BucketName, b.config.Region, key)
 }
 
-// IsAllowedExtension はファイル拡張子が許可されているかチェックします
-func IsAllowedExtension(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	for _, allowedExt := range AllowedImageTypes {
-		if ext == allowedExt {
-			return true
-		}
+// s3Error は存在しないオブジェクトのエラーを ErrObjectNotFound に変換します
+func s3Error(err error) error {
+	var notFound *types.NotFound
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &notFound) || errors.As(err, &noSuchKey) {
+		return fmt.Errorf("%w: %v", ErrObjectNotFound, err)
 	}
-	return false
+	return err
 }
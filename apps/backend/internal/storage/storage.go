@@ -0,0 +1,725 @@
+// Package storage - ファイルストレージサービス
+//
+// 画像・エクスポートファイル・データベースのバックアップの保存機能を提供します。
+// 保存先は BlobStorage（S3、ローカルファイルシステム、GCS）で、STORAGE_BACKEND で選択します。
+// 機能:
+//   - 署名付きURLの生成（アップロード用、ダウンロード用）
+//   - クライアントの直接アップロードの登録（サイズ・画像形式の確認）
+//   - 作物・区画の添付ファイル（PDF 等）の直接アップロードの登録（サイズ・形式の確認）
+//   - 画像バリデーション（サイズ、形式）
+//   - Exponential backoffリトライ
+//   - CloudFront等のCDN統合（差し替え・削除した画像のキャッシュの削除、写真の署名付きURL）
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secure-scorecard/backend/internal/logging"
+)
+
+// =============================================================================
+// 定数定義
+// =============================================================================
+
+const (
+	// MaxImageSize は画像の最大サイズ（5MB）
+	MaxImageSize = 5 * 1024 * 1024
+
+	// PresignedURLExpiry はPresigned URLの有効期限（15分）
+	PresignedURLExpiry = 15 * time.Minute
+
+	// PhotoURLExpiry は写真の署名付きURLの有効期限（15分）
+	PhotoURLExpiry = 15 * time.Minute
+
+	// MaxRetryAttempts はリトライの最大回数
+	MaxRetryAttempts = 3
+
+	// InitialRetryDelay は最初のリトライ待機時間
+	InitialRetryDelay = 1 * time.Second
+)
+
+// AllowedImageTypes は許可される画像形式のMIMEタイプ
+var AllowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// =============================================================================
+// エラー定義
+// =============================================================================
+
+var (
+	// ErrFileTooLarge はファイルサイズが上限を超えている場合のエラー
+	ErrFileTooLarge = errors.New("file size exceeds maximum allowed size (5MB)")
+
+	// ErrInvalidImageType は画像形式が許可されていない場合のエラー
+	ErrInvalidImageType = errors.New("invalid image type: only JPEG, PNG, and WEBP are allowed")
+
+	// ErrUploadFailed はアップロードが失敗した場合のエラー
+	ErrUploadFailed = errors.New("failed to upload image after retries")
+
+	// ErrStorageNotConfigured は保存先が設定されていない場合のエラー
+	ErrStorageNotConfigured = errors.New("file storage is not configured")
+
+	// ErrUploadNotFound は登録する画像が保存先にアップロードされていない場合のエラー
+	ErrUploadNotFound = errors.New("uploaded object not found")
+
+	// ErrUploadKeyForbidden は他のユーザーの直接アップロードのオブジェクトキーを登録しようとした場合のエラー
+	ErrUploadKeyForbidden = errors.New("object key does not belong to the user")
+)
+
+// =============================================================================
+// サービス構造体
+// =============================================================================
+
+// Service はファイルの保存操作を提供するサービスです
+// オブジェクトキーの構成と検証を行い、保存は BlobStorage に委譲します
+type Service struct {
+	blobs  BlobStorage
+	cdn    *cdnInvalidation // 差し替え・削除した画像の CDN のキャッシュの削除（nil の場合は削除しない）
+	signer URLSigner        // 写真の CDN の署名付きURL（nil の場合は保存先の署名付きURL）
+}
+
+// NewService は新しいServiceインスタンスを作成します
+//
+// 引数:
+//   - blobs: 保存先（nil の場合は保存先なしで動作し、操作は ErrStorageNotConfigured を返す）
+//
+// 戻り値:
+//   - *Service: ストレージサービスインスタンス
+func NewService(blobs BlobStorage) *Service {
+	return &Service{blobs: blobs}
+}
+
+// IsConfigured は保存先が利用可能かチェックします
+func (s *Service) IsConfigured() bool {
+	return s != nil && s.blobs != nil
+}
+
+// HTTPHandler は保存先が処理する署名付きURLのHTTPハンドラを返します（local の /files）
+// 保存先が署名付きURLを直接処理する場合（S3、GCS）は nil を返します
+func (s *Service) HTTPHandler() http.Handler {
+	if !s.IsConfigured() {
+		return nil
+	}
+	handler, _ := s.blobs.(http.Handler)
+	return handler
+}
+
+// =============================================================================
+// Presigned URL生成
+// =============================================================================
+
+// PresignedUploadResult はPresigned URL生成結果を表します
+type PresignedUploadResult struct {
+	UploadURL  string            `json:"upload_url"`  // アップロード用Presigned URL
+	Headers    map[string]string `json:"headers"`     // PUT に付けるヘッダー（署名に含まれる）
+	ObjectKey  string            `json:"object_key"`  // オブジェクトキー
+	ContentURL string            `json:"content_url"` // アップロード後の画像URL（CDN経由）
+	ExpiresAt  time.Time         `json:"expires_at"`  // URLの有効期限
+}
+
+// GenerateUploadURL はアップロード用のPresigned URLを生成します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用）
+//   - contentType: MIMEタイプ（image/jpeg等）
+//
+// 戻り値:
+//   - *PresignedUploadResult: Presigned URL情報
+//   - error: 生成に失敗した場合のエラー
+func (s *Service) GenerateUploadURL(ctx context.Context, userID uint, contentType string) (*PresignedUploadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	// 画像形式をバリデーション
+	ext, ok := AllowedImageTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidImageType
+	}
+
+	// ユニークなオブジェクトキーを生成
+	// パス形式: crops/images/{userID}/{year}/{month}/{uuid}.{ext}
+	now := time.Now()
+	objectKey := cropImageKey(userID, now, ext)
+
+	// Presigned URLを生成
+	presigned, err := s.blobs.PresignPut(ctx, objectKey, contentType, 0, PresignedURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &PresignedUploadResult{
+		UploadURL:  presigned.URL,
+		Headers:    presigned.Headers,
+		ObjectKey:  objectKey,
+		ContentURL: s.ContentURL(objectKey),
+		ExpiresAt:  now.Add(PresignedURLExpiry),
+	}, nil
+}
+
+// cropImageKey は成長記録の画像のオブジェクトキーを生成します
+func cropImageKey(userID uint, now time.Time, ext string) string {
+	return fmt.Sprintf("crops/images/%d/%d/%02d/%s%s",
+		userID,
+		now.Year(),
+		now.Month(),
+		uuid.New().String(),
+		ext,
+	)
+}
+
+// =============================================================================
+// クライアントの直接アップロード（登録のコールバック付き）
+// =============================================================================
+// モバイルクライアントは PresignDirectUpload の Presigned URL で画像を保存先に直接 PUT し（サーバーのメモリを経由しない）、
+// アップロードの完了後に登録のコールバック（POST /api/v1/uploads/complete、RegisterDirectUpload）を呼び出します。
+// Presigned URL にはサイズを署名に含めるため、申告したサイズ以外の PUT は保存先が拒否します。
+// 登録では保存先のオブジェクトのサイズと先頭のバイト列の画像形式を確認し、条件を満たさないオブジェクトは削除します。
+
+// DirectUploadPrefix は直接アップロードの画像のオブジェクトキーの接頭辞です（uploads/{userID}/{year}/{month}/{uuid}.{ext}）
+const DirectUploadPrefix = "uploads"
+
+// PresignDirectUpload はクライアントの直接アップロード用のPresigned URLを生成します
+// アップロードの後、RegisterDirectUpload で登録するまで画像は使用できません
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用、登録時の所有者の確認に使用）
+//   - contentType: MIMEタイプ（image/jpeg等）
+//   - size: アップロードする画像のサイズ（バイト、署名に含める）
+//
+// 戻り値:
+//   - *PresignedUploadResult: Presigned URL情報
+//   - error: 生成に失敗した場合のエラー（サイズ超過は ErrFileTooLarge）
+func (s *Service) PresignDirectUpload(ctx context.Context, userID uint, contentType string, size int64) (*PresignedUploadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+	if size > MaxImageSize {
+		return nil, ErrFileTooLarge
+	}
+	ext, ok := AllowedImageTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidImageType
+	}
+
+	now := time.Now()
+	objectKey := fmt.Sprintf("%s%d/%02d/%s%s", directUploadUserPrefix(userID), now.Year(), now.Month(), uuid.New().String(), ext)
+
+	presigned, err := s.blobs.PresignPut(ctx, objectKey, contentType, size, PresignedURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &PresignedUploadResult{
+		UploadURL:  presigned.URL,
+		Headers:    presigned.Headers,
+		ObjectKey:  objectKey,
+		ContentURL: s.ContentURL(objectKey),
+		ExpiresAt:  now.Add(PresignedURLExpiry),
+	}, nil
+}
+
+// RegisterDirectUpload は直接アップロードした画像を確認して登録します（登録のコールバック）
+// サイズの上限を超える、または画像形式が許可されていないオブジェクトは保存先から削除します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（オブジェクトキーの所有者）
+//   - objectKey: PresignDirectUpload の戻り値のオブジェクトキー
+//
+// 戻り値:
+//   - *UploadResult: 登録した画像（サイズは保存先のオブジェクトのサイズ）
+//   - error: 他のユーザーのオブジェクトキーは ErrUploadKeyForbidden、未アップロードは ErrUploadNotFound、
+//     サイズ超過は ErrFileTooLarge、形式不正は ErrInvalidImageType
+func (s *Service) RegisterDirectUpload(ctx context.Context, userID uint, objectKey string) (*UploadResult, error) {
+	if !strings.HasPrefix(objectKey, directUploadUserPrefix(userID)) || strings.Contains(objectKey, "..") {
+		return nil, ErrUploadKeyForbidden
+	}
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	size, err := s.blobs.Size(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get uploaded object: %w", err)
+	}
+	if size > MaxImageSize {
+		s.deleteDirectUpload(ctx, objectKey)
+		return nil, ErrFileTooLarge
+	}
+
+	// 先頭512バイトでMIMEタイプを判定（クライアントが申告した Content-Type は信用しない）
+	data, err := s.readUploadHead(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ValidateImageFile(data, size); err != nil {
+		s.deleteDirectUpload(ctx, objectKey)
+		return nil, err
+	}
+
+	return &UploadResult{
+		ObjectKey:  objectKey,
+		ContentURL: s.ContentURL(objectKey),
+		Size:       size,
+	}, nil
+}
+
+// readUploadHead は直接アップロードしたオブジェクトの先頭512バイト（MIMEタイプの判定用）を読み込みます
+func (s *Service) readUploadHead(ctx context.Context, objectKey string) ([]byte, error) {
+	body, err := s.blobs.GetRange(ctx, objectKey, 0, 512)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, 512))
+	_ = body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+	return data, nil
+}
+
+// deleteDirectUpload は登録できない直接アップロードのオブジェクトを削除します（ベストエフォート）
+func (s *Service) deleteDirectUpload(ctx context.Context, objectKey string) {
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete rejected upload", "key", objectKey, "error", err)
+	}
+}
+
+// directUploadUserPrefix はユーザーの直接アップロードのオブジェクトキーの接頭辞を返します
+func directUploadUserPrefix(userID uint) string {
+	return fmt.Sprintf("%s/%d/", DirectUploadPrefix, userID)
+}
+
+// ContentURL はオブジェクトの画像URLを返します（CDN経由または保存先の公開URL）
+func (s *Service) ContentURL(objectKey string) string {
+	if !s.IsConfigured() {
+		return ""
+	}
+	return s.blobs.URL(objectKey)
+}
+
+// =============================================================================
+// 写真の加工（バリアントの保存、元の画像の取得・削除）
+// =============================================================================
+
+// DownloadPhoto は直接アップロードした元の画像を取得します（写真の加工）
+// 呼び出し元で戻り値の io.ReadCloser を閉じてください
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー（RegisterDirectUpload で登録した画像）
+//
+// 戻り値:
+//   - io.ReadCloser: 画像の内容
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) DownloadPhoto(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	body, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download photo: %w", err)
+	}
+	return body, nil
+}
+
+// UploadPhotoVariant は写真のバリアント（WebP）を保存します
+// キーは写真ごとに一意のため、長期間キャッシュできるよう Cache-Control を設定します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用）
+//   - photoID: 写真のID（パス構成用）
+//   - name: バリアントの名前（thumbnail, medium, large）
+//   - contentType: MIMEタイプ（image/webp）
+//   - data: バリアントの内容
+//
+// 戻り値:
+//   - string: オブジェクトキー
+//   - error: 保存に失敗した場合のエラー
+func (s *Service) UploadPhotoVariant(ctx context.Context, userID, photoID uint, name, contentType string, data []byte) (string, error) {
+	if !s.IsConfigured() {
+		return "", ErrStorageNotConfigured
+	}
+
+	// パス形式: photos/{userID}/{photoID}/{name}.webp
+	objectKey := fmt.Sprintf("photos/%d/%d/%s%s", userID, photoID, name, GetExtensionFromContentType(contentType))
+	err := s.blobs.Put(ctx, objectKey, bytes.NewReader(data), int64(len(data)), PutOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=31536000, immutable",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload photo variant: %w", err)
+	}
+	return objectKey, nil
+}
+
+// DeletePhoto は写真の画像を削除します（加工した後の元の画像）
+// 存在しないオブジェクトの削除は成功として扱われます
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー
+//
+// 戻り値:
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) DeletePhoto(ctx context.Context, objectKey string) error {
+	if !s.IsConfigured() {
+		return ErrStorageNotConfigured
+	}
+
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	return nil
+}
+
+// URLSigner は CDN の画像URLに有効期限付きの署名を付けます（CloudFront の署名付きURL等）。
+type URLSigner interface {
+	// SignURL は expires まで有効な署名付きURLを返します。
+	SignURL(rawURL string, expires time.Time) (string, error)
+}
+
+// SetURLSigner は写真の署名付きURLに CDN の署名を使用するよう設定します
+// 未設定の場合、写真の署名付きURLは保存先の署名付きURL（S3・GCS の Presigned URL、local の /files）です
+func (s *Service) SetURLSigner(signer URLSigner) {
+	s.signer = signer
+}
+
+// PhotoURL は写真の画像の有効期限付きの署名付きURLを返します
+// 写真は公開URLで取得できないため、表示のたびに PhotoURLExpiry まで有効なURLを生成します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー（写真のバリアント）
+//
+// 戻り値:
+//   - *PresignedDownloadResult: 署名付きURLと有効期限
+//   - error: 生成に失敗した場合のエラー
+func (s *Service) PhotoURL(ctx context.Context, objectKey string) (*PresignedDownloadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	expiresAt := time.Now().Add(PhotoURLExpiry)
+	var (
+		signedURL string
+		err       error
+	)
+	if s.signer != nil {
+		signedURL, err = s.signer.SignURL(s.blobs.URL(objectKey), expiresAt)
+	} else {
+		signedURL, err = s.blobs.PresignGet(ctx, objectKey, "", PhotoURLExpiry)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign photo URL: %w", err)
+	}
+	return &PresignedDownloadResult{DownloadURL: signedURL, ExpiresAt: expiresAt}, nil
+}
+
+// =============================================================================
+// 画像アップロード（サーバーサイド）
+// =============================================================================
+
+// UploadResult はアップロード結果を表します
+type UploadResult struct {
+	ObjectKey  string `json:"object_key"`  // オブジェクトキー
+	ContentURL string `json:"content_url"` // 画像URL
+	Size       int64  `json:"size"`        // ファイルサイズ（バイト）
+}
+
+// UploadImage はサーバーサイドで画像を保存先にアップロードします
+// Exponential backoffリトライを適用します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用）
+//   - reader: 画像データのReader
+//   - contentType: MIMEタイプ
+//   - size: ファイルサイズ
+//
+// 戻り値:
+//   - *UploadResult: アップロード結果
+//   - error: アップロードに失敗した場合のエラー
+func (s *Service) UploadImage(ctx context.Context, userID uint, reader io.Reader, contentType string, size int64) (*UploadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	// サイズをバリデーション
+	if size > MaxImageSize {
+		return nil, ErrFileTooLarge
+	}
+
+	// 画像形式をバリデーション
+	ext, ok := AllowedImageTypes[contentType]
+	if !ok {
+		return nil, ErrInvalidImageType
+	}
+
+	// オブジェクトキーを生成
+	objectKey := cropImageKey(userID, time.Now(), ext)
+
+	// 失敗した場合に先頭から読み直せるよう、シークできない Reader はメモリに読み込む（最大 MaxImageSize）
+	body, ok := reader.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(io.LimitReader(reader, MaxImageSize+1))
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	// Exponential backoffリトライでアップロード
+	if err := s.putWithRetry(ctx, objectKey, body, size, PutOptions{ContentType: contentType}); err != nil {
+		return nil, err
+	}
+
+	return &UploadResult{
+		ObjectKey:  objectKey,
+		ContentURL: s.ContentURL(objectKey),
+		Size:       size,
+	}, nil
+}
+
+// putWithRetry は Exponential backoffリトライでオブジェクトを保存します
+// 待機時間は初回1秒、2回目2秒で、リトライのたびに body を先頭から読み直します
+func (s *Service) putWithRetry(ctx context.Context, objectKey string, body io.ReadSeeker, size int64, opts PutOptions) error {
+	var lastErr error
+	for attempt := 0; attempt < MaxRetryAttempts; attempt++ {
+		if attempt > 0 {
+			delay := time.Duration(math.Pow(2, float64(attempt-1))) * InitialRetryDelay
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		err := s.blobs.Put(ctx, objectKey, body, size, opts)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+	}
+
+	return fmt.Errorf("%w: %v", ErrUploadFailed, lastErr)
+}
+
+// =============================================================================
+// エクスポートファイル保存・再ダウンロード
+// =============================================================================
+
+// PresignedDownloadResult はダウンロード用Presigned URL生成結果を表します
+type PresignedDownloadResult struct {
+	DownloadURL string    `json:"download_url"` // ダウンロード用Presigned URL
+	ExpiresAt   time.Time `json:"expires_at"`   // URLの有効期限
+}
+
+// UploadExport は生成済みのエクスポートファイルを保存します
+// Exponential backoffリトライを適用します
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - userID: ユーザーID（パス構成用）
+//   - fileName: ファイル名（crops_20240101.csv等）
+//   - contentType: MIMEタイプ
+//   - data: ファイル内容
+//
+// 戻り値:
+//   - string: オブジェクトキー
+//   - error: アップロードに失敗した場合のエラー
+func (s *Service) UploadExport(ctx context.Context, userID uint, fileName, contentType string, data []byte) (string, error) {
+	if !s.IsConfigured() {
+		return "", ErrStorageNotConfigured
+	}
+
+	// パス形式: exports/{userID}/{year}/{month}/{uuid}-{fileName}
+	now := time.Now()
+	objectKey := fmt.Sprintf("exports/%d/%d/%02d/%s-%s",
+		userID,
+		now.Year(),
+		now.Month(),
+		uuid.New().String(),
+		filepath.Base(fileName),
+	)
+
+	if err := s.putWithRetry(ctx, objectKey, bytes.NewReader(data), int64(len(data)), PutOptions{ContentType: contentType}); err != nil {
+		return "", err
+	}
+	return objectKey, nil
+}
+
+// DeleteExport は保存済みのエクスポートファイルを削除します
+// 存在しないオブジェクトの削除は成功として扱われます
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー（UploadExport の戻り値）
+//
+// 戻り値:
+//   - error: 削除に失敗した場合のエラー
+func (s *Service) DeleteExport(ctx context.Context, objectKey string) error {
+	if !s.IsConfigured() {
+		return ErrStorageNotConfigured
+	}
+
+	if err := s.blobs.Delete(ctx, objectKey); err != nil {
+		return fmt.Errorf("failed to delete export file: %w", err)
+	}
+	return nil
+}
+
+// GenerateDownloadURL はオブジェクトのダウンロード用Presigned URLを生成します
+// Content-Dispositionを指定し、ブラウザで元のファイル名で保存されるようにします
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー
+//   - fileName: ダウンロード時のファイル名
+//
+// 戻り値:
+//   - *PresignedDownloadResult: Presigned URL情報
+//   - error: 生成に失敗した場合のエラー
+func (s *Service) GenerateDownloadURL(ctx context.Context, objectKey, fileName string) (*PresignedDownloadResult, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	expiresAt := time.Now().Add(PresignedURLExpiry)
+	downloadURL, err := s.blobs.PresignGet(ctx, objectKey, fileName, PresignedURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+
+	return &PresignedDownloadResult{
+		DownloadURL: downloadURL,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// =============================================================================
+// データベースのバックアップの保存・取得
+// =============================================================================
+
+// UploadBackup はデータベースのバックアップのファイルを保存します
+// ファイルが大きいため、メモリに読み込まずにアップロードします（リトライのたびに先頭から読み直す）
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - fileName: ファイル名（database-20240101T020000Z.jsonl.gz等）
+//   - body: ファイル内容
+//   - size: ファイルサイズ（バイト）
+//
+// 戻り値:
+//   - string: オブジェクトキー
+//   - error: アップロードに失敗した場合のエラー
+func (s *Service) UploadBackup(ctx context.Context, fileName string, body io.ReadSeeker, size int64) (string, error) {
+	if !s.IsConfigured() {
+		return "", ErrStorageNotConfigured
+	}
+
+	// パス形式: backups/database/{year}/{month}/{fileName}
+	now := time.Now().UTC()
+	objectKey := fmt.Sprintf("backups/database/%d/%02d/%s", now.Year(), now.Month(), filepath.Base(fileName))
+
+	if err := s.putWithRetry(ctx, objectKey, body, size, PutOptions{ContentType: "application/gzip"}); err != nil {
+		return "", err
+	}
+	return objectKey, nil
+}
+
+// DownloadBackup は保存したデータベースのバックアップのファイルを取得します
+// 呼び出し元で戻り値の io.ReadCloser を閉じてください
+//
+// 引数:
+//   - ctx: コンテキスト
+//   - objectKey: オブジェクトキー（UploadBackup の戻り値）
+//
+// 戻り値:
+//   - io.ReadCloser: ファイル内容
+//   - error: 取得に失敗した場合のエラー
+func (s *Service) DownloadBackup(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if !s.IsConfigured() {
+		return nil, ErrStorageNotConfigured
+	}
+
+	body, err := s.blobs.Get(ctx, objectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download backup: %w", err)
+	}
+	return body, nil
+}
+
+// =============================================================================
+// バリデーションヘルパー
+// =============================================================================
+
+// ValidateImageFile は画像ファイルをバリデーションします
+//
+// 引数:
+//   - data: ファイルの先頭のバイト列（MIMEタイプの判定に使用、512バイトまで）
+//   - size: ファイルサイズ
+//
+// 戻り値:
+//   - string: 検出されたMIMEタイプ
+//   - error: バリデーションエラー
+func ValidateImageFile(data []byte, size int64) (string, error) {
+	// サイズチェック
+	if size > MaxImageSize {
+		return "", ErrFileTooLarge
+	}
+
+	// MIMEタイプを検出
+	contentType := http.DetectContentType(data)
+
+	// 許可されたタイプかチェック
+	if _, ok := AllowedImageTypes[contentType]; !ok {
+		return "", ErrInvalidImageType
+	}
+
+	return contentType, nil
+}
+
+// GetExtensionFromContentType はMIMEタイプから拡張子を取得します
+func GetExtensionFromContentType(contentType string) string {
+	if ext, ok := AllowedImageTypes[contentType]; ok {
+		return ext
+	}
+	return ""
+}
+
+// IsAllowedExtension はファイル拡張子が許可されているかチェックします
+func IsAllowedExtension(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, allowedExt := range AllowedImageTypes {
+		if ext == allowedExt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// AWS - AWS SDK・SQS の呼び出しのスパン
+// =============================================================================
+// スパン名は "{サービス}.{操作}"（例: S3.PutObject、SNS.Publish）です。
+// S3 の署名付きURLの作成（Presign）も SDK の呼び出しのためスパンを記録します（HTTPリクエストは送信しない）。
+
+// awsTracerName は AWS の呼び出しのスパンの instrumentation scope です
+const awsTracerName = "github.com/secure-scorecard/backend/internal/tracing/aws"
+
+// awsSpanMiddlewareID は AWS SDK のミドルウェアの ID です
+const awsSpanMiddlewareID = "tracing:aws_span"
+
+// InstrumentAWS は AWS SDK のクライアントの呼び出しごとにスパンを記録するミドルウェアを追加します。
+// LoadDefaultConfig で読み込んだ設定に追加し、その設定からクライアント（s3.NewFromConfig 等）を作成してください。
+func InstrumentAWS(cfg *aws.Config) {
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		// サービス名・操作名（RegisterServiceMetadata）の設定後に開始する
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(awsSpanMiddlewareID, awsSpanMiddleware), middleware.After)
+	})
+}
+
+// awsSpanMiddleware は AWS SDK の呼び出し（再試行を含む）のスパンを記録します。
+func awsSpanMiddleware(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	ctx, span := StartAWSSpan(ctx, awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), awsmiddleware.GetRegion(ctx))
+	defer span.End()
+
+	out, metadata, err := next.HandleInitialize(ctx, in)
+
+	status := 0
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+		status = resp.StatusCode
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		status = respErr.HTTPStatusCode()
+	}
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(metadata)
+	FinishAWSSpan(span, status, requestID, err)
+	return out, metadata, err
+}
+
+// StartAWSSpan は AWS の API の呼び出しのクライアントのスパンを開始します（AWS SDK を使用しない SQS のクライアント用）。
+// 呼び出しの後に FinishAWSSpan で結果を記録し、span.End() で終了してください。
+//
+// 引数:
+//   - ctx: 呼び出しのコンテキスト（親のスパン）
+//   - service: サービス名（例: SQS）
+//   - operation: 操作名（例: SendMessage）
+//   - region: リージョン
+//
+// 戻り値:
+//   - context.Context: スパンを設定したコンテキスト
+//   - trace.Span: 開始したスパン
+func StartAWSSpan(ctx context.Context, service, operation, region string) (context.Context, trace.Span) {
+	return otel.Tracer(awsTracerName).Start(ctx, service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemNameKey.String("aws-api"),
+			attribute.String("rpc.service", service),
+			semconv.RPCMethod(operation),
+			semconv.CloudRegion(region),
+		),
+	)
+}
+
+// FinishAWSSpan は AWS の API の呼び出しの結果をスパンに記録します。
+//
+// 引数:
+//   - span: StartAWSSpan で開始したスパン
+//   - status: HTTPレスポンスのステータスコード（レスポンスがない場合は 0）
+//   - requestID: AWS のリクエストID（ない場合は空）
+//   - err: 呼び出しのエラー
+func FinishAWSSpan(span trace.Span, status int, requestID string, err error) {
+	if status > 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	}
+	if requestID != "" {
+		span.SetAttributes(semconv.AWSRequestID(requestID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
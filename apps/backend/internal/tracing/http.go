@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// HTTP - Echo のリクエストのスパン
+// =============================================================================
+// スパン名はルート（"GET /api/v1/crops/:id"）で、IDなどの値はパス（url.path）のみに含めます。
+// 記録したトレースのIDはレスポンスヘッダー X-Trace-Id で返し、遅いリクエストのトレースを検索できるようにします。
+
+// httpTracerName は HTTP のスパンの instrumentation scope です
+const httpTracerName = "github.com/secure-scorecard/backend/internal/tracing"
+
+// HeaderTraceID は記録したリクエストのトレースIDのレスポンスヘッダーです
+const HeaderTraceID = "X-Trace-Id"
+
+// untracedPaths はスパンを記録しないパスです（ヘルスチェック・メトリクスのスクレイプ）
+var untracedPaths = map[string]bool{
+	"/health":       true,
+	"/health/ready": true,
+	"/health/db":    true,
+	"/metrics":      true,
+}
+
+// Middleware はリクエストごとにサーバーのスパンを作成する Echo のミドルウェアを返します。
+// リクエストヘッダーの traceparent（W3C Trace Context）を親にし、スパンのコンテキストをリクエストのコンテキストに設定します。
+// エラーのレスポンスを記録するため、エラーを処理するミドルウェア（Logger）より前に登録してください。
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if untracedPaths[req.URL.Path] {
+				return next(c)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := otel.Tracer(httpTracerName).Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(c.Path()),
+					semconv.URLPath(req.URL.Path),
+					semconv.URLScheme(c.Scheme()),
+					semconv.ServerAddress(req.Host),
+					semconv.ClientAddress(c.RealIP()),
+					semconv.UserAgentOriginal(req.UserAgent()),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+			if span.SpanContext().IsSampled() {
+				c.Response().Header().Set(HeaderTraceID, span.SpanContext().TraceID().String())
+			}
+
+			err := next(c)
+
+			status := responseStatus(c, err)
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+				if err != nil {
+					span.RecordError(err)
+				}
+			}
+			return err
+		}
+	}
+}
+
+// responseStatus はレスポンスのステータスコードを返します。
+// エラーのレスポンスを書き込む前（Logger より内側でエラーを返した場合）はエラーから判定します。
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
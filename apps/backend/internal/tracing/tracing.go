@@ -0,0 +1,107 @@
+// Package tracing - OpenTelemetry の分散トレーシング
+//
+// HTTPリクエスト・GORM のクエリ・AWS SDK の呼び出し・スケジューラーとキューのジョブをスパンとして記録し、
+// OTLP（OTEL_EXPORTER_OTLP_ENDPOINT）でコレクター（Jaeger・Grafana Tempo・AWS X-Ray の ADOT 等）に送信します。
+//
+// 構成:
+//   - Setup: TracerProvider と W3C Trace Context・Baggage の伝播の設定（tracing.go）
+//   - Middleware: Echo のリクエストのスパン（受信した traceparent を親にする、http.go）
+//   - InstrumentAWS / StartAWSSpan: AWS SDK・SQS の呼び出しのスパン（aws.go）
+//   - GORM のクエリは repository.NewTracingPlugin、スケジューラー・キューのジョブは各パッケージでスパンを作成します
+//
+// OTEL_EXPORTER_OTLP_ENDPOINT が未設定の場合はグローバルの TracerProvider が no-op のままで、スパンは記録しません
+// （受信した traceparent は AWS SDK の呼び出し・キューのジョブにそのまま引き継ぎます）。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/secure-scorecard/backend/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+)
+
+// OTLP の送信のプロトコル（OTEL_EXPORTER_OTLP_PROTOCOL）
+const (
+	ProtocolHTTP = "http/protobuf" // OTLP/HTTP（デフォルト、ポート 4318）
+	ProtocolGRPC = "grpc"          // OTLP/gRPC（ポート 4317）
+)
+
+// otlpTracesPath は OTLP/HTTP のトレースの送信先のパスです（エンドポイントにパスがない場合に付ける）。
+const otlpTracesPath = "/v1/traces"
+
+// Shutdown は記録したスパンを送信して TracerProvider を停止する関数です。
+type Shutdown func(ctx context.Context) error
+
+// Setup は設定に応じて OTLP でスパンを送信する TracerProvider を作成し、グローバルに設定します。
+// 伝播（W3C Trace Context・Baggage）はトレーシングが無効の場合も設定します。
+//
+// 引数:
+//   - ctx: エクスポーターの作成に使用するコンテキスト
+//   - cfg: トレーシング設定（OTLPEndpoint が空の場合は無効）
+//   - env: 実行環境（APP_ENV、リソースの deployment.environment.name）
+//
+// 戻り値:
+//   - Shutdown: シャットダウン時に呼び出す関数（無効の場合は何もしない）
+//   - error: エンドポイント・プロトコルが不正な場合、エクスポーターの作成に失敗した場合のエラー
+func Setup(ctx context.Context, cfg config.TracingConfig, env string) (Shutdown, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.DeploymentEnvironmentNameKey.String(env),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newExporter は OTLP のエクスポーターを作成します。
+// エンドポイントのスキームが http の場合は TLS を使用しません。
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	endpoint, err := url.Parse(cfg.OTLPEndpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid OTEL_EXPORTER_OTLP_ENDPOINT: %q", cfg.OTLPEndpoint)
+	}
+
+	switch cfg.OTLPProtocol {
+	case "", ProtocolHTTP:
+		if strings.TrimSuffix(endpoint.Path, "/") == "" {
+			endpoint.Path = otlpTracesPath
+		}
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpointURL(endpoint.String()),
+			otlptracehttp.WithHeaders(cfg.OTLPHeaders),
+		)
+	case ProtocolGRPC:
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpointURL(endpoint.String()),
+			otlptracegrpc.WithHeaders(cfg.OTLPHeaders),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_EXPORTER_OTLP_PROTOCOL: %s", cfg.OTLPProtocol)
+	}
+}
@@ -0,0 +1,210 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/labstack/echo/v4"
+	"github.com/secure-scorecard/backend/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Tracing Tests - 分散トレーシングのテスト
+// =============================================================================
+// テスト対象:
+//   - Middleware: リクエストのスパン名・属性、traceparent の親、エラーのステータス、X-Trace-Id
+//   - InstrumentAWS: AWS SDK の呼び出しのスパン（サービス名・操作名・リクエストID・エラー）
+//   - Setup: 無効の場合の伝播の設定、不正なエンドポイント・プロトコル
+
+// useSpanRecorder はテストの間、記録したスパンを保持する TracerProvider をグローバルに設定します。
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// spanAttribute はスパンの属性の値を返します（ない場合は空の値）。
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestMiddleware は Echo のリクエストのスパンのテストです。
+// 期待動作:
+//   - スパン名はメソッドとルート（パスの値を含めない）、リクエストヘッダーの traceparent のトレースを親にする
+//   - ハンドラーのコンテキストにスパンを設定し、レスポンスヘッダー X-Trace-Id でトレースIDを返す
+//   - 5xx のレスポンスはスパンのエラー、4xx はエラーにしない
+//   - ヘルスチェックのスパンは記録しない
+func TestMiddleware(t *testing.T) {
+	// Arrange
+	recorder := useSpanRecorder(t)
+	e := echo.New()
+	e.Use(Middleware())
+	var handlerSpan trace.SpanContext
+	e.GET("/api/v1/crops/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v1/fail", func(c echo.Context) error { return errors.New("database is down") })
+	e.GET("/api/v1/missing", func(c echo.Context) error { return echo.NewHTTPError(http.StatusNotFound) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/crops/42", nil)
+	req.Header.Set("traceparent", parent)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	for _, path := range []string{"/api/v1/fail", "/api/v1/missing", "/health"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Assert
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans without the health check, got %d", len(spans))
+	}
+	crop := spans[0]
+	if crop.Name() != "GET /api/v1/crops/:id" || spanAttribute(crop, "url.path").AsString() != "/api/v1/crops/42" {
+		t.Errorf("Expected the span of the route, got %s (%v)", crop.Name(), crop.Attributes())
+	}
+	if crop.Parent().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || crop.SpanKind() != trace.SpanKindServer {
+		t.Errorf("Expected a server span in the trace of traceparent, got parent %v", crop.Parent())
+	}
+	if handlerSpan.SpanID() != crop.SpanContext().SpanID() {
+		t.Error("Expected the span on the handler context")
+	}
+	if rec.Header().Get(HeaderTraceID) != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace ID header, got %q", rec.Header().Get(HeaderTraceID))
+	}
+	if spans[1].Status().Code != codes.Error || spanAttribute(spans[1], "http.response.status_code").AsInt64() != http.StatusInternalServerError {
+		t.Errorf("Expected an error span for the 500 response, got %v %v", spans[1].Status(), spans[1].Attributes())
+	}
+	if spans[2].Status().Code == codes.Error || spanAttribute(spans[2], "http.response.status_code").AsInt64() != http.StatusNotFound {
+		t.Errorf("Expected a 404 span without an error, got %v %v", spans[2].Status(), spans[2].Attributes())
+	}
+}
+
+// TestInstrumentAWS は AWS SDK の呼び出しのスパンのテストです。
+// 期待動作:
+//   - スパン名は "{サービス}.{操作}" で、リクエストの親のスパンの子にする
+//   - レスポンスのステータスコード・リクエストIDを記録し、エラーのレスポンスはスパンのエラー
+func TestInstrumentAWS(t *testing.T) {
+	// Arrange
+	recorder := useSpanRecorder(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-amz-request-id", "REQ123")
+		if r.URL.Path == "/photos/missing.webp" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	awsCfg := aws.Config{
+		Region:      "ap-northeast-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+	InstrumentAWS(&awsCfg)
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(server.URL)
+		o.UsePathStyle = true
+		o.RetryMaxAttempts = 1
+	})
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+
+	// Act
+	_, headErr := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("photos"), Key: aws.String("1.webp")})
+	_, missingErr := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("photos"), Key: aws.String("missing.webp")})
+	parent.End()
+
+	// Assert
+	if headErr != nil || missingErr == nil {
+		t.Fatalf("Expected the first HeadObject to succeed and the second to fail, got %v / %v", headErr, missingErr)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 2 AWS spans and the parent, got %d", len(spans))
+	}
+	head, missing := spans[0], spans[1]
+	if head.Name() != "S3.HeadObject" || head.Parent().SpanID() != parent.SpanContext().SpanID() || head.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected a client span S3.HeadObject under the request, got %s (parent %v)", head.Name(), head.Parent())
+	}
+	if spanAttribute(head, "aws.request_id").AsString() != "REQ123" || spanAttribute(head, "http.response.status_code").AsInt64() != http.StatusOK {
+		t.Errorf("Expected the request ID and status, got %v", head.Attributes())
+	}
+	if missing.Status().Code != codes.Error || spanAttribute(missing, "http.response.status_code").AsInt64() != http.StatusNotFound {
+		t.Errorf("Expected an error span with 404, got %v %v", missing.Status(), missing.Attributes())
+	}
+}
+
+// TestSetup はトレーシングの設定のテストです。
+// 期待動作:
+//   - エンドポイントが未設定の場合はエクスポーターを作成せず、W3C Trace Context の伝播のみ設定する
+//   - 不正なエンドポイント・未対応のプロトコルはエラー
+//   - OTLP/HTTP・gRPC のエンドポイントでは TracerProvider を作成し、Shutdown で停止する
+func TestSetup(t *testing.T) {
+	// Arrange
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	ctx := context.Background()
+
+	// Act
+	disabled, disabledErr := Setup(ctx, config.TracingConfig{}, "test")
+	fields := otel.GetTextMapPropagator().Fields()
+	_, invalidErr := Setup(ctx, config.TracingConfig{OTLPEndpoint: "collector:4318"}, "test")
+	_, protocolErr := Setup(ctx, config.TracingConfig{OTLPEndpoint: "http://localhost:4318", OTLPProtocol: "http/json"}, "test")
+	httpShutdown, httpErr := Setup(ctx, config.TracingConfig{OTLPEndpoint: "http://localhost:4318", ServiceName: "home-garden-api", SampleRatio: 1}, "test")
+	grpcShutdown, grpcErr := Setup(ctx, config.TracingConfig{OTLPEndpoint: "http://localhost:4317", OTLPProtocol: ProtocolGRPC, SampleRatio: 1}, "test")
+
+	// Assert
+	if disabledErr != nil || disabled(ctx) != nil {
+		t.Fatalf("Expected a no-op shutdown without an endpoint, got %v", disabledErr)
+	}
+	if !slices.Contains(fields, "traceparent") {
+		t.Errorf("Expected the W3C Trace Context propagator, got %v", fields)
+	}
+	if invalidErr == nil || protocolErr == nil {
+		t.Errorf("Expected errors for an invalid endpoint and protocol, got %v / %v", invalidErr, protocolErr)
+	}
+	if httpErr != nil || grpcErr != nil {
+		t.Fatalf("Setup failed: %v / %v", httpErr, grpcErr)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+		t.Errorf("Expected the SDK TracerProvider, got %T", otel.GetTracerProvider())
+	}
+	if err := httpShutdown(ctx); err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+	if err := grpcShutdown(ctx); err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
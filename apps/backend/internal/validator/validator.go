@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -18,6 +19,9 @@ type CustomValidator struct {
 func NewValidator() *CustomValidator {
 	v := validator.New()
 
+	// エラーの field はリクエストの JSON（クエリ・パスパラメータ）の名前にする
+	v.RegisterTagNameFunc(fieldName)
+
 	// Register custom validators here if needed
 	// Example: v.RegisterValidation("custom_rule", customRuleFunc)
 
@@ -25,18 +29,14 @@ func NewValidator() *CustomValidator {
 }
 
 // Validate validates a struct
+// 失敗した場合は 422 VALIDATION_ERROR で、errors に項目ごとの内容（FieldErrors）を返します。
 func (cv *CustomValidator) Validate(i interface{}) error {
 	if err := cv.validator.Struct(i); err != nil {
 		// Convert validator errors to AppError
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			details := make([]map[string]string, 0, len(validationErrors))
+			details := make(FieldErrors, 0, len(validationErrors))
 			for _, e := range validationErrors {
-				details = append(details, map[string]string{
-					"field":   toSnakeCase(e.Field()),
-					"tag":     e.Tag(),
-					"value":   fmt.Sprintf("%v", e.Value()),
-					"message": getErrorMessage(e),
-				})
+				details = append(details, newFieldError(e))
 			}
 			return apperrors.NewValidationError("Validation failed", details)
 		}
@@ -56,29 +56,16 @@ func BindAndValidate(c echo.Context, i interface{}) error {
 	return nil
 }
 
-// getErrorMessage returns a human-readable error message for validation errors
-func getErrorMessage(e validator.FieldError) string {
-	field := toSnakeCase(e.Field())
-	switch e.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", field, e.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters", field, e.Param())
-	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, e.Param())
-	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, e.Param())
-	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", field, e.Param())
-	case "lt":
-		return fmt.Sprintf("%s must be less than %s", field, e.Param())
-	default:
-		return fmt.Sprintf("%s is invalid", field)
+// fieldName returns the request name of a struct field (json, query, param, form tags in that order)
+// タグがない場合はフィールド名の snake_case です（gRPC の入力など）。
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "param", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
 	}
+	return toSnakeCase(field.Name)
 }
 
 // toSnakeCase converts camelCase to snake_case
@@ -92,3 +79,112 @@ func toSnakeCase(s string) string {
 	}
 	return strings.ToLower(result.String())
 }
+
+// =============================================================================
+// Field Errors - 項目ごとのバリデーションエラー
+// =============================================================================
+
+// FieldError はバリデーションに失敗した項目です。
+// 入力値はパスワードなどを含む場合があるため返しません。
+type FieldError struct {
+	Field   string `json:"field"`           // リクエストの項目名（入れ子は items[0].title）
+	Rule    string `json:"rule"`            // 失敗したルール（required, max, oneof など）
+	Param   string `json:"param,omitempty"` // ルールのパラメータ（max=100 の 100）
+	Message string `json:"message"`         // ロケールに応じたメッセージ
+
+	kind reflect.Kind // 値の種類（min・max のメッセージを文字数・件数・値で切り替える）
+}
+
+// FieldErrors はバリデーションに失敗した項目の一覧です（VALIDATION_ERROR の errors）。
+// apperrors.ErrorHandler がリクエストの Accept-Language に応じて Localize でメッセージを翻訳します。
+type FieldErrors []FieldError
+
+// Localize はメッセージをロケールの言語にした一覧を返します（未対応のロケールは英語）。
+func (fe FieldErrors) Localize(locale string) any {
+	localized := make(FieldErrors, len(fe))
+	for i, e := range fe {
+		e.Message = fieldErrorMessage(locale, e)
+		localized[i] = e
+	}
+	return localized
+}
+
+// newFieldError converts a validator error to a FieldError (message in English)
+func newFieldError(e validator.FieldError) FieldError {
+	field := e.Namespace()
+	// 先頭の構造体名（CreateTaskRequest.title の CreateTaskRequest）を除く
+	if _, rest, found := strings.Cut(field, "."); found {
+		field = rest
+	}
+	fe := FieldError{Field: field, Rule: e.Tag(), Param: e.Param(), kind: e.Kind()}
+	fe.Message = fieldErrorMessage(apperrors.DefaultLocale, fe)
+	return fe
+}
+
+// fieldErrorMessage returns a human-readable message for a field error in the locale
+func fieldErrorMessage(locale string, e FieldError) string {
+	messages, ok := ruleMessages[locale]
+	if !ok {
+		messages = ruleMessages[apperrors.DefaultLocale]
+	}
+
+	key := e.Rule
+	if key == "min" || key == "max" {
+		switch e.kind {
+		case reflect.String:
+			key += "_string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			key += "_items"
+		}
+	}
+	param := e.Param
+	if e.Rule == "oneof" {
+		param = strings.Join(strings.Fields(param), ", ")
+	}
+
+	format, ok := messages[key]
+	if !ok {
+		return fmt.Sprintf(messages["default"], e.Field)
+	}
+	if strings.Count(format, "%s") == 1 {
+		return fmt.Sprintf(format, e.Field)
+	}
+	return fmt.Sprintf(format, e.Field, param)
+}
+
+// ruleMessages はルールごとのメッセージです（ロケール → ルール → 書式）。
+// min・max は値の種類ごとに _string（文字数）・_items（件数）を使い分けます。
+var ruleMessages = map[string]map[string]string{
+	"en": {
+		"required":   "%s is required",
+		"email":      "%s must be a valid email address",
+		"min_string": "%s must be at least %s characters",
+		"max_string": "%s must be at most %s characters",
+		"min_items":  "%s must contain at least %s item(s)",
+		"max_items":  "%s must contain at most %s item(s)",
+		"min":        "%s must be at least %s",
+		"max":        "%s must be at most %s",
+		"gte":        "%s must be greater than or equal to %s",
+		"lte":        "%s must be less than or equal to %s",
+		"gt":         "%s must be greater than %s",
+		"lt":         "%s must be less than %s",
+		"oneof":      "%s must be one of: %s",
+		"default":    "%s is invalid",
+	},
+	"ja": {
+		"required":   "%sは必須です",
+		"email":      "%sには有効なメールアドレスを指定してください",
+		"min_string": "%sは%s文字以上で入力してください",
+		"max_string": "%sは%s文字以内で入力してください",
+		"min_items":  "%sは%s件以上指定してください",
+		"max_items":  "%sは%s件以内で指定してください",
+		"min":        "%sは%s以上で指定してください",
+		"max":        "%sは%s以下で指定してください",
+		"gte":        "%sは%s以上で指定してください",
+		"lte":        "%sは%s以下で指定してください",
+		"gt":         "%sは%sより大きい値を指定してください",
+		"lt":         "%sは%s未満の値を指定してください",
+		"oneof":      "%sには次のいずれかを指定してください: %s",
+		"default":    "%sの値が正しくありません",
+	},
+}
@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	apperrors "github.com/secure-scorecard/backend/internal/errors"
+)
+
+// =============================================================================
+// Validator Tests - リクエストのバリデーションのテスト
+// =============================================================================
+// テスト対象:
+//   - CustomValidator.Validate: 項目ごとの内容（field, rule, param, message）
+//   - FieldErrors.Localize: Accept-Language に応じたメッセージ（ErrorHandler 経由）
+
+type testItem struct {
+	Title string `json:"title" validate:"required,max=5"`
+}
+
+type testRequest struct {
+	Name     string     `json:"name" validate:"required"`
+	Password string     `json:"password" validate:"min=8"`
+	Priority string     `json:"priority" validate:"omitempty,oneof=low medium high"`
+	Quantity float64    `json:"quantity" validate:"gt=0"`
+	Items    []testItem `json:"items" validate:"min=1,dive"`
+	Limit    int        `query:"limit" validate:"lte=100"`
+}
+
+// TestValidate_FieldErrors は項目ごとの内容のテストです。
+// 期待動作:
+//   - 422 VALIDATION_ERROR で、field は JSON・クエリの名前（入れ子は items[0].title）
+//   - rule・param はタグの内容、message は英語
+//   - 入力値（パスワードなど）は含めない
+func TestValidate_FieldErrors(t *testing.T) {
+	// Arrange
+	v := NewValidator()
+	req := testRequest{Password: "secret", Priority: "urgent", Items: []testItem{{Title: "長いタイトルです"}}, Limit: 500}
+
+	// Act
+	err := v.Validate(&req)
+
+	// Assert
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.StatusCode != http.StatusUnprocessableEntity || appErr.Code != apperrors.ErrCodeValidation {
+		t.Fatalf("Expected 422 VALIDATION_ERROR, got %v", err)
+	}
+	details, ok := appErr.Details.(FieldErrors)
+	if !ok {
+		t.Fatalf("Expected FieldErrors, got %T", appErr.Details)
+	}
+	want := map[string]FieldError{
+		"name":           {Field: "name", Rule: "required", Message: "name is required"},
+		"password":       {Field: "password", Rule: "min", Param: "8", Message: "password must be at least 8 characters"},
+		"priority":       {Field: "priority", Rule: "oneof", Param: "low medium high", Message: "priority must be one of: low, medium, high"},
+		"quantity":       {Field: "quantity", Rule: "gt", Param: "0", Message: "quantity must be greater than 0"},
+		"items[0].title": {Field: "items[0].title", Rule: "max", Param: "5", Message: "items[0].title must be at most 5 characters"},
+		"limit":          {Field: "limit", Rule: "lte", Param: "100", Message: "limit must be less than or equal to 100"},
+	}
+	if len(details) != len(want) {
+		t.Errorf("Expected %d field errors, got %+v", len(want), details)
+	}
+	for _, got := range details {
+		expected, ok := want[got.Field]
+		got.kind = 0
+		if !ok || got != expected {
+			t.Errorf("Unexpected field error %+v (want %+v)", got, expected)
+		}
+	}
+	body, _ := json.Marshal(details)
+	if strings.Contains(string(body), "secret") {
+		t.Errorf("Expected input values to be omitted, got %s", body)
+	}
+}
+
+// TestValidate_LocalizedMessages はメッセージの言語のテストです。
+// 期待動作:
+//   - Accept-Language が ja の場合は日本語のメッセージ
+//   - Accept-Language がない・未対応の言語の場合は英語のメッセージ
+func TestValidate_LocalizedMessages(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"ja-JP,ja;q=0.9,en;q=0.8", "itemsは1件以上指定してください"},
+		{"fr-FR,en;q=0.5", "items must contain at least 1 item(s)"},
+		{"", "items must contain at least 1 item(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			// Arrange
+			e := echo.New()
+			e.Validator = NewValidator()
+			e.HTTPErrorHandler = apperrors.ErrorHandler
+			e.POST("/items", func(c echo.Context) error {
+				var req struct {
+					Items []testItem `json:"items" validate:"min=1"`
+				}
+				return BindAndValidate(c, &req)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"items": []}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(apperrors.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			e.ServeHTTP(rec, req)
+
+			// Assert
+			var body struct {
+				Status int          `json:"status"`
+				Errors []FieldError `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if rec.Code != http.StatusUnprocessableEntity || body.Status != http.StatusUnprocessableEntity {
+				t.Errorf("Expected 422, got %d", rec.Code)
+			}
+			if len(body.Errors) != 1 || body.Errors[0].Message != tt.want || body.Errors[0].Rule != "min" {
+				t.Errorf("Expected message %q, got %s", tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Package worker - バックグラウンドジョブのワーカープール
+//
+// リクエスト処理と切り離して実行するジョブ（メール送信など）と、一定間隔で繰り返すジョブ（期限切れトークンの削除など）を管理します。
+// 機能:
+//   - 固定数のワーカーとキューによる単発ジョブの実行（Submit）
+//   - 一定間隔で繰り返すジョブ（Every）
+//   - シャットダウン時に新しいジョブの受付を止め、実行中・キュー内のジョブの完了を待つ（Shutdown）
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Worker Pool - ワーカープール
+// =============================================================================
+// ジョブには Shutdown の期限まで取り消されないコンテキストを渡します。
+// SIGTERM を受けても実行中のジョブは途中で止めず、期限を過ぎた場合のみコンテキストを取り消します。
+
+var (
+	// ErrPoolClosed はシャットダウン後にジョブを登録した場合のエラー
+	ErrPoolClosed = errors.New("worker pool is closed")
+	// ErrQueueFull はキューが満杯でジョブを登録できない場合のエラー
+	ErrQueueFull = errors.New("worker pool queue is full")
+)
+
+// task はキューに登録された単発ジョブです。
+type task struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Pool はバックグラウンドジョブのワーカープールです。
+type Pool struct {
+	// workCtx はジョブに渡すコンテキスト（Shutdown の期限を過ぎた場合のみ取り消す）
+	workCtx    context.Context
+	cancelWork context.CancelFunc
+	// stop は繰り返しジョブの停止の合図
+	stop chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+	tasks  chan task
+	wg     sync.WaitGroup
+}
+
+// NewPool は新しいPoolを作成し、ワーカーを起動します。
+//
+// 引数:
+//   - ctx: ジョブに渡すコンテキストの親（リクエストのコンテキストは渡さないでください）
+//   - workers: ワーカー数（1未満の場合は1）
+//   - queueSize: キューの長さ（0未満の場合は0）
+//
+// 戻り値:
+//   - *Pool: ワーカープール
+func NewPool(ctx context.Context, workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	p := &Pool{
+		workCtx:    workCtx,
+		cancelWork: cancel,
+		stop:       make(chan struct{}),
+		tasks:      make(chan task, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// Submit は単発ジョブをキューに登録します（キューが空くのを待ちません）。
+//
+// 引数:
+//   - name: ジョブ名（ログ用）
+//   - run: ジョブの処理
+//
+// 戻り値:
+//   - error: シャットダウン後は ErrPoolClosed、キューが満杯の場合は ErrQueueFull
+func (p *Pool) Submit(name string, run func(ctx context.Context) error) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.tasks <- task{name: name, run: run}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Every は interval ごとに繰り返すジョブを登録します。登録時に1回実行し、以降は interval ごとに実行します。
+// 実行中の回が終わるまで次の回は始めず、Shutdown 後は新しい回を始めません。
+//
+// 引数:
+//   - name: ジョブ名（ログ用）
+//   - interval: 実行間隔
+//   - run: ジョブの処理
+//
+// 戻り値:
+//   - error: シャットダウン後は ErrPoolClosed
+func (p *Pool) Every(name string, interval time.Duration, run func(ctx context.Context) error) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			p.execute(name, run)
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown は新しいジョブの受付を止め、実行中・キュー内のジョブの完了を待ちます。
+// ctx の期限までに終わらない場合はジョブのコンテキストを取り消し、ctx のエラーを返します。
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stop)
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancelWork()
+		return nil
+	case <-ctx.Done():
+		p.cancelWork()
+		return ctx.Err()
+	}
+}
+
+// worker はキューのジョブを順に実行します。キューが閉じられると残りのジョブを実行してから終了します。
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for t := range p.tasks {
+		p.execute(t.name, t.run)
+	}
+}
+
+// execute はジョブを実行し、失敗・パニックをログに記録します。
+func (p *Pool) execute(name string, run func(ctx context.Context) error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Worker: job %s panicked: %v", name, r)
+		}
+	}()
+
+	if err := run(p.workCtx); err != nil {
+		log.Printf("Worker: job %s failed: %v", name, err)
+	}
+}
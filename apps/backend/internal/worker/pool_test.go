@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Worker Pool Tests - ワーカープールのテスト
+// =============================================================================
+// テスト対象:
+//   - Submit: 単発ジョブの実行、キューが満杯・シャットダウン後のエラー
+//   - Every: 登録時の実行と繰り返し
+//   - Shutdown: 実行中・キュー内のジョブの完了を待つこと、期限切れ時のコンテキストの取り消し
+
+// TestPool_ShutdownDrainsJobs はシャットダウン時に実行中・キュー内のジョブを完了させるテストです。
+// 期待動作:
+//   - 実行中のジョブはコンテキストを取り消されずに完了する
+//   - キュー内のジョブもシャットダウン前に実行される
+//   - シャットダウン後の登録は ErrPoolClosed
+func TestPool_ShutdownDrainsJobs(t *testing.T) {
+	// Arrange
+	pool := NewPool(context.Background(), 1, 2)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var completed atomic.Int32
+	var cancelled atomic.Bool
+
+	_ = pool.Submit("slow", func(ctx context.Context) error {
+		close(started)
+		<-release
+		if ctx.Err() != nil {
+			cancelled.Store(true)
+		}
+		completed.Add(1)
+		return nil
+	})
+	<-started
+	_ = pool.Submit("queued-1", func(ctx context.Context) error { completed.Add(1); return nil })
+	_ = pool.Submit("queued-2", func(ctx context.Context) error { completed.Add(1); return nil })
+
+	// Act
+	if err := pool.Submit("overflow", func(ctx context.Context) error { return nil }); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+	done := make(chan error)
+	go func() { done <- pool.Shutdown(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	// Assert
+	if err := <-done; err != nil {
+		t.Fatalf("Expected Shutdown to succeed, got %v", err)
+	}
+	if completed.Load() != 3 {
+		t.Errorf("Expected 3 completed jobs, got %d", completed.Load())
+	}
+	if cancelled.Load() {
+		t.Error("Expected in-flight job context not to be cancelled")
+	}
+	if err := pool.Submit("late", func(ctx context.Context) error { return nil }); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed, got %v", err)
+	}
+	if err := pool.Every("late", time.Minute, func(ctx context.Context) error { return nil }); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed for Every, got %v", err)
+	}
+}
+
+// TestPool_ShutdownDeadline はシャットダウンの期限を過ぎた場合のテストです。
+// 期待動作:
+//   - 期限を過ぎるとジョブのコンテキストを取り消し、ctx のエラーを返す
+//   - パニックしたジョブはワーカーを止めない
+func TestPool_ShutdownDeadline(t *testing.T) {
+	// Arrange
+	pool := NewPool(context.Background(), 1, 2)
+	_ = pool.Submit("panic", func(ctx context.Context) error { panic("boom") })
+	stopped := make(chan struct{})
+	_ = pool.Submit("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+		return ctx.Err()
+	})
+
+	// Act
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := pool.Shutdown(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Expected stuck job context to be cancelled")
+	}
+}
+
+// TestPool_Every は繰り返しジョブのテストです。
+// 期待動作:
+//   - 登録時に1回実行し、以降は interval ごとに実行する
+//   - シャットダウン後は新しい回を始めない
+func TestPool_Every(t *testing.T) {
+	// Arrange
+	pool := NewPool(context.Background(), 1, 0)
+	var runs atomic.Int32
+
+	// Act
+	if err := pool.Every("tick", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("ignored")
+	}); err != nil {
+		t.Fatalf("Expected Every to succeed, got %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected Shutdown to succeed, got %v", err)
+	}
+	afterShutdown := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+
+	// Assert
+	if afterShutdown < 3 {
+		t.Errorf("Expected at least 3 runs, got %d", afterShutdown)
+	}
+	if runs.Load() != afterShutdown {
+		t.Errorf("Expected no runs after shutdown, got %d more", runs.Load()-afterShutdown)
+	}
+}
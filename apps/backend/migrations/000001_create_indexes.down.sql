@@ -0,0 +1,36 @@
+DROP INDEX IF EXISTS idx_growth_records_search_trgm;
+DROP INDEX IF EXISTS idx_growth_records_search_fts;
+DROP INDEX IF EXISTS idx_plots_search_trgm;
+DROP INDEX IF EXISTS idx_plots_search_fts;
+DROP INDEX IF EXISTS idx_tasks_search_trgm;
+DROP INDEX IF EXISTS idx_tasks_search_fts;
+DROP INDEX IF EXISTS idx_crops_search_trgm;
+DROP INDEX IF EXISTS idx_crops_search_fts;
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+DROP INDEX IF EXISTS idx_tasks_overdue;
+DROP INDEX IF EXISTS idx_tasks_user_due_date;
+DROP INDEX IF EXISTS idx_tasks_due_date;
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP INDEX IF EXISTS idx_tasks_user_id;
+DROP INDEX IF EXISTS idx_plot_assignments_active;
+DROP INDEX IF EXISTS idx_plot_assignments_crop_id;
+DROP INDEX IF EXISTS idx_plot_assignments_plot_id;
+DROP INDEX IF EXISTS idx_plots_user_status;
+DROP INDEX IF EXISTS idx_plots_status;
+DROP INDEX IF EXISTS idx_plots_user_id;
+DROP INDEX IF EXISTS idx_harvests_crop_date;
+DROP INDEX IF EXISTS idx_harvests_harvest_date;
+DROP INDEX IF EXISTS idx_harvests_crop_id;
+DROP INDEX IF EXISTS idx_growth_records_crop_date;
+DROP INDEX IF EXISTS idx_growth_records_record_date;
+DROP INDEX IF EXISTS idx_growth_records_crop_id;
+DROP INDEX IF EXISTS idx_crops_planted_date;
+DROP INDEX IF EXISTS idx_crops_expected_harvest_date;
+DROP INDEX IF EXISTS idx_crops_user_status;
+DROP INDEX IF EXISTS idx_crops_status;
+DROP INDEX IF EXISTS idx_crops_user_id;
+DROP INDEX IF EXISTS idx_token_blacklist_expires_at;
+DROP INDEX IF EXISTS idx_token_blacklist_token_hash;
+DROP INDEX IF EXISTS idx_users_is_active;
+DROP INDEX IF EXISTS idx_users_firebase_uid;
+DROP INDEX IF EXISTS idx_users_email;
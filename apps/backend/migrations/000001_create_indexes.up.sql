@@ -0,0 +1,86 @@
+-- パフォーマンス最適化のためのカスタムインデックス
+-- バージョン管理のマイグレーションの導入前に作成済みのデータベースでも実行できるよう IF NOT EXISTS で作成します。
+
+-- users テーブル
+-- メール検索用（ログイン時）
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+-- Firebase UID検索用
+CREATE INDEX IF NOT EXISTS idx_users_firebase_uid ON users(firebase_uid) WHERE firebase_uid IS NOT NULL;
+-- アクティブユーザー検索用
+CREATE INDEX IF NOT EXISTS idx_users_is_active ON users(is_active) WHERE is_active = true;
+
+-- token_blacklist テーブル
+-- トークンハッシュ検索用（認証時）
+CREATE INDEX IF NOT EXISTS idx_token_blacklist_token_hash ON token_blacklist(token_hash);
+-- 期限切れトークン削除用
+CREATE INDEX IF NOT EXISTS idx_token_blacklist_expires_at ON token_blacklist(expires_at);
+
+-- crops テーブル
+-- ユーザー別作物一覧用
+CREATE INDEX IF NOT EXISTS idx_crops_user_id ON crops(user_id);
+-- ステータス別フィルタ用
+CREATE INDEX IF NOT EXISTS idx_crops_status ON crops(status);
+-- ユーザー×ステータス複合インデックス
+CREATE INDEX IF NOT EXISTS idx_crops_user_status ON crops(user_id, status);
+-- 収穫予定日検索用（リマインダー）
+CREATE INDEX IF NOT EXISTS idx_crops_expected_harvest_date ON crops(expected_harvest_date);
+-- 植え付け日でのソート用
+CREATE INDEX IF NOT EXISTS idx_crops_planted_date ON crops(planted_date);
+
+-- growth_records テーブル
+-- 作物別成長記録取得用
+CREATE INDEX IF NOT EXISTS idx_growth_records_crop_id ON growth_records(crop_id);
+-- 記録日でのソート用
+CREATE INDEX IF NOT EXISTS idx_growth_records_record_date ON growth_records(record_date);
+-- 作物×記録日複合インデックス
+CREATE INDEX IF NOT EXISTS idx_growth_records_crop_date ON growth_records(crop_id, record_date DESC);
+
+-- harvests テーブル
+-- 作物別収穫記録取得用
+CREATE INDEX IF NOT EXISTS idx_harvests_crop_id ON harvests(crop_id);
+-- 収穫日でのソート用
+CREATE INDEX IF NOT EXISTS idx_harvests_harvest_date ON harvests(harvest_date);
+-- 分析用: 期間指定での集計
+CREATE INDEX IF NOT EXISTS idx_harvests_crop_date ON harvests(crop_id, harvest_date);
+
+-- plots テーブル
+-- ユーザー別区画一覧用
+CREATE INDEX IF NOT EXISTS idx_plots_user_id ON plots(user_id);
+-- ステータス別フィルタ用
+CREATE INDEX IF NOT EXISTS idx_plots_status ON plots(status);
+-- ユーザー×ステータス複合インデックス
+CREATE INDEX IF NOT EXISTS idx_plots_user_status ON plots(user_id, status);
+
+-- plot_assignments テーブル
+-- 区画別配置履歴取得用
+CREATE INDEX IF NOT EXISTS idx_plot_assignments_plot_id ON plot_assignments(plot_id);
+-- 作物別配置履歴取得用
+CREATE INDEX IF NOT EXISTS idx_plot_assignments_crop_id ON plot_assignments(crop_id);
+-- アクティブな配置検索用
+CREATE INDEX IF NOT EXISTS idx_plot_assignments_active ON plot_assignments(plot_id) WHERE unassigned_date IS NULL;
+
+-- tasks テーブル
+-- ユーザー別タスク一覧用
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks(user_id);
+-- ステータス別フィルタ用
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+-- 期限日でのソート用
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
+-- 今日のタスク検索用
+CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date ON tasks(user_id, due_date);
+-- 期限切れタスク検索用
+CREATE INDEX IF NOT EXISTS idx_tasks_overdue ON tasks(user_id, due_date, status) WHERE status = 'pending';
+-- 繰り返しタスク検索用
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_task_id) WHERE parent_task_id IS NOT NULL;
+
+-- 横断検索（GET /search）
+-- 式は repository.searchSources の文書の式と同じ（全文検索は to_tsvector、トライグラムは gin_trgm_ops）
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_crops_search_fts ON crops USING GIN (to_tsvector('simple', (coalesce(name, '') || ' ' || coalesce(variety, '') || ' ' || coalesce(notes, ''))));
+CREATE INDEX IF NOT EXISTS idx_crops_search_trgm ON crops USING GIN ((coalesce(name, '') || ' ' || coalesce(variety, '') || ' ' || coalesce(notes, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tasks_search_fts ON tasks USING GIN (to_tsvector('simple', (coalesce(title, '') || ' ' || coalesce(description, ''))));
+CREATE INDEX IF NOT EXISTS idx_tasks_search_trgm ON tasks USING GIN ((coalesce(title, '') || ' ' || coalesce(description, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_plots_search_fts ON plots USING GIN (to_tsvector('simple', (coalesce(name, '') || ' ' || coalesce(notes, ''))));
+CREATE INDEX IF NOT EXISTS idx_plots_search_trgm ON plots USING GIN ((coalesce(name, '') || ' ' || coalesce(notes, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_growth_records_search_fts ON growth_records USING GIN (to_tsvector('simple', coalesce(notes, '')));
+CREATE INDEX IF NOT EXISTS idx_growth_records_search_trgm ON growth_records USING GIN ((coalesce(notes, '')) gin_trgm_ops);
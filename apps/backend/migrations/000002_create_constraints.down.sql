@@ -0,0 +1,13 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_recurrence_interval;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_recurrence;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_priority;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_status;
+ALTER TABLE plots DROP CONSTRAINT IF EXISTS chk_plots_status;
+ALTER TABLE plots DROP CONSTRAINT IF EXISTS chk_plots_sunlight;
+ALTER TABLE plots DROP CONSTRAINT IF EXISTS chk_plots_soil_type;
+ALTER TABLE plots DROP CONSTRAINT IF EXISTS chk_plots_dimensions;
+ALTER TABLE harvests DROP CONSTRAINT IF EXISTS chk_harvests_quality;
+ALTER TABLE harvests DROP CONSTRAINT IF EXISTS chk_harvests_quantity;
+ALTER TABLE growth_records DROP CONSTRAINT IF EXISTS chk_growth_records_stage;
+ALTER TABLE crops DROP CONSTRAINT IF EXISTS chk_crops_status;
+ALTER TABLE crops DROP CONSTRAINT IF EXISTS chk_crops_valid_dates;
@@ -0,0 +1,145 @@
+-- CHECK制約
+-- バージョン管理のマイグレーションの導入前に作成済みのデータベースでも実行できるよう、存在しない制約のみ追加します。
+
+-- crops テーブル - 日付バリデーション
+-- 植え付け日 <= 収穫予定日
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_crops_valid_dates'
+	) THEN
+		ALTER TABLE crops ADD CONSTRAINT chk_crops_valid_dates
+			CHECK (planted_date <= expected_harvest_date);
+	END IF;
+END $$;
+
+-- ステータス値の制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_crops_status'
+	) THEN
+		ALTER TABLE crops ADD CONSTRAINT chk_crops_status
+			CHECK (status IN ('planted', 'growing', 'ready_to_harvest', 'harvested', 'failed'));
+	END IF;
+END $$;
+
+-- growth_records テーブル - 成長段階バリデーション
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_growth_records_stage'
+	) THEN
+		ALTER TABLE growth_records ADD CONSTRAINT chk_growth_records_stage
+			CHECK (growth_stage IN ('seedling', 'vegetative', 'flowering', 'fruiting'));
+	END IF;
+END $$;
+
+-- harvests テーブル - 数量バリデーション
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_harvests_quantity'
+	) THEN
+		ALTER TABLE harvests ADD CONSTRAINT chk_harvests_quantity
+			CHECK (quantity > 0);
+	END IF;
+END $$;
+
+-- 品質値の制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_harvests_quality'
+	) THEN
+		ALTER TABLE harvests ADD CONSTRAINT chk_harvests_quality
+			CHECK (quality IS NULL OR quality IN ('excellent', 'good', 'fair', 'poor'));
+	END IF;
+END $$;
+
+-- plots テーブル - サイズバリデーション
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_plots_dimensions'
+	) THEN
+		ALTER TABLE plots ADD CONSTRAINT chk_plots_dimensions
+			CHECK (width > 0 AND height > 0);
+	END IF;
+END $$;
+
+-- 土壌タイプの制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_plots_soil_type'
+	) THEN
+		ALTER TABLE plots ADD CONSTRAINT chk_plots_soil_type
+			CHECK (soil_type IS NULL OR soil_type IN ('clay', 'sandy', 'loamy', 'peaty'));
+	END IF;
+END $$;
+
+-- 日当たりの制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_plots_sunlight'
+	) THEN
+		ALTER TABLE plots ADD CONSTRAINT chk_plots_sunlight
+			CHECK (sunlight IS NULL OR sunlight IN ('full_sun', 'partial_shade', 'shade'));
+	END IF;
+END $$;
+
+-- ステータスの制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_plots_status'
+	) THEN
+		ALTER TABLE plots ADD CONSTRAINT chk_plots_status
+			CHECK (status IN ('available', 'occupied'));
+	END IF;
+END $$;
+
+-- tasks テーブル - ステータス/優先度バリデーション
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_tasks_status'
+	) THEN
+		ALTER TABLE tasks ADD CONSTRAINT chk_tasks_status
+			CHECK (status IN ('pending', 'completed', 'cancelled'));
+	END IF;
+END $$;
+
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_tasks_priority'
+	) THEN
+		ALTER TABLE tasks ADD CONSTRAINT chk_tasks_priority
+			CHECK (priority IN ('low', 'medium', 'high'));
+	END IF;
+END $$;
+
+-- 繰り返し設定の制限
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_tasks_recurrence'
+	) THEN
+		ALTER TABLE tasks ADD CONSTRAINT chk_tasks_recurrence
+			CHECK (recurrence IS NULL OR recurrence = '' OR recurrence IN ('daily', 'weekly', 'monthly'));
+	END IF;
+END $$;
+
+-- 繰り返し間隔は正の値
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_constraint WHERE conname = 'chk_tasks_recurrence_interval'
+	) THEN
+		ALTER TABLE tasks ADD CONSTRAINT chk_tasks_recurrence_interval
+			CHECK (recurrence_interval > 0);
+	END IF;
+END $$;
@@ -0,0 +1,14 @@
+-- 000004_create_pagination_indexes.up.sql のインデックスを削除します。
+
+DROP INDEX IF EXISTS idx_notification_logs_user_id_id;
+DROP INDEX IF EXISTS idx_harvests_crop_id_id;
+DROP INDEX IF EXISTS idx_plots_organization_id_id;
+DROP INDEX IF EXISTS idx_plots_user_status_id;
+DROP INDEX IF EXISTS idx_plots_user_id_id;
+DROP INDEX IF EXISTS idx_crops_organization_id_id;
+DROP INDEX IF EXISTS idx_crops_user_status_id;
+DROP INDEX IF EXISTS idx_crops_user_id_id;
+DROP INDEX IF EXISTS idx_tasks_user_status_due_date_id;
+DROP INDEX IF EXISTS idx_tasks_user_due_date_id;
+DROP INDEX IF EXISTS idx_tasks_user_status_id;
+DROP INDEX IF EXISTS idx_tasks_user_id_id;
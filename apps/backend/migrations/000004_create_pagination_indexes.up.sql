@@ -0,0 +1,39 @@
+-- キーセットページングのインデックス
+-- 一覧のページングは OFFSET ではなくカーソル（前のページの最後の行）より後の行を取得するため、
+-- 絞り込みの列・並び順の列・id の複合インデックスでページの位置に関係なく範囲スキャンで取得できます。
+-- IDの降順（新しい順）の一覧はインデックスを逆順にスキャンします。
+-- 論理削除したレコードは一覧に含まないため、deleted_at IS NULL の部分インデックスにします。
+
+-- tasks テーブル
+-- ページングの一覧用（新しい順、id < カーソル）
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id_id ON tasks(user_id, id) WHERE deleted_at IS NULL;
+-- ステータスで絞り込んだページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_tasks_user_status_id ON tasks(user_id, status, id) WHERE deleted_at IS NULL;
+-- 期限日順のページングの一覧用（(due_date, id) > カーソル）
+CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date_id ON tasks(user_id, due_date, id) WHERE deleted_at IS NULL;
+-- ステータスで絞り込んだ期限日順のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_tasks_user_status_due_date_id ON tasks(user_id, status, due_date, id) WHERE deleted_at IS NULL;
+
+-- crops テーブル
+-- 個人の作物のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_crops_user_id_id ON crops(user_id, id) WHERE deleted_at IS NULL;
+-- ステータスで絞り込んだ個人の作物のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_crops_user_status_id ON crops(user_id, status, id) WHERE deleted_at IS NULL;
+-- 組織の作物のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_crops_organization_id_id ON crops(organization_id, id) WHERE deleted_at IS NULL;
+
+-- plots テーブル
+-- 個人の区画のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_plots_user_id_id ON plots(user_id, id) WHERE deleted_at IS NULL;
+-- ステータスで絞り込んだ個人の区画のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_plots_user_status_id ON plots(user_id, status, id) WHERE deleted_at IS NULL;
+-- 組織の区画のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_plots_organization_id_id ON plots(organization_id, id) WHERE deleted_at IS NULL;
+
+-- harvests テーブル
+-- 作物の収穫記録のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_harvests_crop_id_id ON harvests(crop_id, id) WHERE deleted_at IS NULL;
+
+-- notification_logs テーブル
+-- 受信箱のページングの一覧用
+CREATE INDEX IF NOT EXISTS idx_notification_logs_user_id_id ON notification_logs(user_id, id);
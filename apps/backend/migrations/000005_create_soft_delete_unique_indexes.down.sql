@@ -0,0 +1,5 @@
+-- 000005_create_soft_delete_unique_indexes.up.sql のインデックスを削除し、000001 の idx_users_email に戻します。
+-- 一意インデックスには戻さないため、メールアドレスの重複は登録時の確認（GetByEmail）のみで防ぎます。
+
+DROP INDEX IF EXISTS idx_users_email_active;
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
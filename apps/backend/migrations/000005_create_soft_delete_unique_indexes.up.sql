@@ -0,0 +1,11 @@
+-- 論理削除を考慮した一意制約
+-- users.email の一意インデックス（GORM の uniqueIndex）は論理削除（deleted_at）した行も対象のため、
+-- 退会したユーザーと同じメールアドレスでは登録できませんでした。論理削除していない行のみの部分一意インデックスに置き換えます。
+-- device_tokens（無効化したトークンは is_active = false）と notification_logs（期限切れのログは物理削除）には
+-- deleted_at の列と一意制約がないため、論理削除した行と重複することはありません。
+
+-- users テーブル
+-- 000001 の idx_users_email（既存のデータベースでは AutoMigrate が作成した一意インデックス）を置き換える
+DROP INDEX IF EXISTS idx_users_email;
+-- メールアドレスの重複防止・ログイン時の検索用（論理削除したユーザーは対象外）
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- 000006_hash_share_tokens.up.sql のハッシュからトークン文字列は復元できないため、変更を戻しません。
+-- 戻したバージョンのアプリケーションはトークン文字列で検索するため、ハッシュ化した共有リンクは使用できなくなります（再発行が必要です）。
+
+SELECT 1;
@@ -0,0 +1,6 @@
+-- 共有トークンのハッシュ化
+-- share_tokens.token にはトークン文字列を平文で保存していました。SHA-256 のハッシュ（16進数の64文字、auth.HashToken と同じ）に置き換え、
+-- 公開エンドポイントではトークン文字列のハッシュで検索します（列名は token のまま、モデルの ShareToken.TokenHash）。
+-- 発行済みの共有リンク（トークン文字列）は引き続き使用できます。
+
+UPDATE share_tokens SET token = encode(sha256(convert_to(token, 'UTF8')), 'hex');
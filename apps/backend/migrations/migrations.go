@@ -0,0 +1,32 @@
+// Package migrations - バージョン管理のSQLマイグレーション
+//
+// golang-migrate の形式（{バージョン}_{名前}.up.sql / {バージョン}_{名前}.down.sql）のファイルを埋め込みます。
+// テーブル・列の作成は GORM の AutoMigrate が行い、ここでは AutoMigrate で扱えない変更を管理します:
+//   - インデックス・CHECK制約・マテリアライズドビュー
+//   - 列の名前の変更、データのバックフィル
+//   - ロールバック（down）
+//
+// MySQL（DB_DRIVER=mysql）では mysql ディレクトリ、SQLite（DB_DRIVER=sqlite）では sqlite ディレクトリの
+// 同じバージョン・名前のファイルを適用します。PostgreSQL 固有の構文（DO ブロック・マテリアライズドビュー・
+// 部分インデックス・GIN インデックス）をそれぞれの構文に置き換えたもので、マイグレーションを追加する場合はすべてのファイルを作成してください。
+//
+// 新しいマイグレーションは go run ./cmd/migrate create <名前> で作成します（apps/backend で実行、mysql・sqlite のファイルも作成）。
+// 適用済みのファイルは変更せず、変更は新しいバージョンのファイルで行ってください。
+package migrations
+
+import "embed"
+
+// FS はマイグレーションのファイルです。
+//
+//go:embed *.sql
+var FS embed.FS
+
+// SQLiteFS は SQLite 用のマイグレーションのファイルです（sqlite ディレクトリ）。
+//
+//go:embed sqlite/*.sql
+var SQLiteFS embed.FS
+
+// MySQLFS は MySQL / MariaDB 用のマイグレーションのファイルです（mysql ディレクトリ）。
+//
+//go:embed mysql/*.sql
+var MySQLFS embed.FS
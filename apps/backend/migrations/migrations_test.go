@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Migrations Tests - マイグレーションのファイルのテスト
+// =============================================================================
+// テスト対象:
+//   - FS: 埋め込んだファイルの名前・バージョンの連番・up / down の対応
+//   - SQLiteFS / MySQLFS: PostgreSQL のファイルとの対応、MySQL のファイルの構文
+
+// migrationFilePattern はマイグレーションのファイル名です（{バージョン}_{名前}.up.sql / .down.sql）。
+var migrationFilePattern = regexp.MustCompile(`^(\d{6})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// TestFS_Files は埋め込んだマイグレーションのファイルのテストです。
+// 期待動作:
+//   - すべてのファイルが {6桁のバージョン}_{名前}.up.sql / .down.sql の形式
+//   - バージョンは 1 からの連番で、各バージョンに up と down の両方がある
+func TestFS_Files(t *testing.T) {
+	// Arrange
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+
+	// Act
+	directions := map[int]map[string]string{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			t.Errorf("Unexpected migration file name %q", entry.Name())
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		if directions[version] == nil {
+			directions[version] = map[string]string{}
+		}
+		directions[version][match[3]] = match[2]
+	}
+
+	// Assert
+	if len(directions) == 0 {
+		t.Fatal("Expected embedded migrations")
+	}
+	for version := 1; version <= len(directions); version++ {
+		files, ok := directions[version]
+		if !ok {
+			t.Errorf("Missing migration version %d", version)
+			continue
+		}
+		if files["up"] == "" || files["down"] == "" {
+			t.Errorf("Version %d must have both up and down files, got %v", version, files)
+		}
+		if files["up"] != files["down"] {
+			t.Errorf("Version %d up and down names differ: %q, %q", version, files["up"], files["down"])
+		}
+	}
+}
+
+// assertMatchingFiles は dir のファイルが PostgreSQL のファイルと過不足なく同じ名前かを確認します。
+func assertMatchingFiles(t *testing.T, dialectFS fs.FS, dir string) {
+	t.Helper()
+	postgres, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	entries, err := fs.ReadDir(dialectFS, dir)
+	if err != nil {
+		t.Fatalf("ReadDir %s failed: %v", dir, err)
+	}
+
+	names := map[string]bool{}
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	for _, entry := range postgres {
+		if !names[entry.Name()] {
+			t.Errorf("Missing %s/%s", dir, entry.Name())
+		}
+		delete(names, entry.Name())
+	}
+	for name := range names {
+		t.Errorf("Unexpected %s/%s without a PostgreSQL migration", dir, name)
+	}
+}
+
+// TestSQLiteFS_Files は SQLite 用のマイグレーションのファイルのテストです。
+// 期待動作:
+//   - PostgreSQL のファイルと同じ名前のファイルが過不足なくある（同じバージョンを SQLite でも適用できる）
+func TestSQLiteFS_Files(t *testing.T) {
+	assertMatchingFiles(t, SQLiteFS, "sqlite")
+}
+
+// TestMySQLFS_Files は MySQL / MariaDB 用のマイグレーションのファイルのテストです。
+// 期待動作:
+//   - PostgreSQL のファイルと同じ名前のファイルが過不足なくある
+//   - PostgreSQL 固有の構文（DO ブロック・マテリアライズドビュー・IF NOT EXISTS のインデックス）を含まない
+func TestMySQLFS_Files(t *testing.T) {
+	assertMatchingFiles(t, MySQLFS, "mysql")
+
+	entries, err := fs.ReadDir(MySQLFS, "mysql")
+	if err != nil {
+		t.Fatalf("ReadDir mysql failed: %v", err)
+	}
+	for _, entry := range entries {
+		content, err := fs.ReadFile(MySQLFS, "mysql/"+entry.Name())
+		if err != nil {
+			t.Fatalf("ReadFile %s failed: %v", entry.Name(), err)
+		}
+		for _, syntax := range []string{"DO $$", "MATERIALIZED VIEW", "CREATE INDEX IF NOT EXISTS", "DROP INDEX IF EXISTS"} {
+			if strings.Contains(string(content), syntax) {
+				t.Errorf("mysql/%s contains PostgreSQL syntax %q", entry.Name(), syntax)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+-- ../000001_create_indexes.down.sql と同じインデックスを削除します（MySQL はインデックスの IF EXISTS に対応しないため、存在するインデックスのみ）。
+
+DROP PROCEDURE IF EXISTS migrate_drop_index;
+CREATE PROCEDURE migrate_drop_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('DROP INDEX ', p_name, ' ON ', p_table);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+CALL migrate_drop_index('tasks', 'idx_tasks_parent_id');
+CALL migrate_drop_index('tasks', 'idx_tasks_overdue');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_due_date');
+CALL migrate_drop_index('tasks', 'idx_tasks_due_date');
+CALL migrate_drop_index('tasks', 'idx_tasks_status');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_id');
+CALL migrate_drop_index('plot_assignments', 'idx_plot_assignments_active');
+CALL migrate_drop_index('plot_assignments', 'idx_plot_assignments_crop_id');
+CALL migrate_drop_index('plot_assignments', 'idx_plot_assignments_plot_id');
+CALL migrate_drop_index('plots', 'idx_plots_user_status');
+CALL migrate_drop_index('plots', 'idx_plots_status');
+CALL migrate_drop_index('plots', 'idx_plots_user_id');
+CALL migrate_drop_index('harvests', 'idx_harvests_crop_date');
+CALL migrate_drop_index('harvests', 'idx_harvests_harvest_date');
+CALL migrate_drop_index('harvests', 'idx_harvests_crop_id');
+CALL migrate_drop_index('growth_records', 'idx_growth_records_crop_date');
+CALL migrate_drop_index('growth_records', 'idx_growth_records_record_date');
+CALL migrate_drop_index('growth_records', 'idx_growth_records_crop_id');
+CALL migrate_drop_index('crops', 'idx_crops_planted_date');
+CALL migrate_drop_index('crops', 'idx_crops_expected_harvest_date');
+CALL migrate_drop_index('crops', 'idx_crops_user_status');
+CALL migrate_drop_index('crops', 'idx_crops_status');
+CALL migrate_drop_index('crops', 'idx_crops_user_id');
+CALL migrate_drop_index('token_blacklist', 'idx_token_blacklist_expires_at');
+CALL migrate_drop_index('token_blacklist', 'idx_token_blacklist_token_hash');
+CALL migrate_drop_index('users', 'idx_users_is_active');
+CALL migrate_drop_index('users', 'idx_users_firebase_uid');
+CALL migrate_drop_index('users', 'idx_users_email');
+
+DROP PROCEDURE migrate_drop_index;
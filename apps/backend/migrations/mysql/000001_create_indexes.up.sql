@@ -0,0 +1,93 @@
+-- パフォーマンス最適化のためのカスタムインデックス（MySQL / MariaDB）
+-- ../000001_create_indexes.up.sql と同じインデックスを作成します。
+-- MySQL は インデックスの IF NOT EXISTS・部分インデックスに対応しないため、存在しないインデックスのみ一時的なプロシージャで作成し、
+-- 部分インデックスは条件のないインデックスにします。
+-- 横断検索の GIN インデックス（全文検索・トライグラム）は PostgreSQL のみのため作成しません。
+
+DROP PROCEDURE IF EXISTS migrate_create_index;
+CREATE PROCEDURE migrate_create_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64), IN p_columns VARCHAR(255))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('CREATE INDEX ', p_name, ' ON ', p_table, '(', p_columns, ')');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+-- users テーブル
+-- メール検索用（ログイン時）
+CALL migrate_create_index('users', 'idx_users_email', 'email');
+-- Firebase UID検索用
+CALL migrate_create_index('users', 'idx_users_firebase_uid', 'firebase_uid');
+-- アクティブユーザー検索用
+CALL migrate_create_index('users', 'idx_users_is_active', 'is_active');
+
+-- token_blacklist テーブル
+-- トークンハッシュ検索用（認証時）
+CALL migrate_create_index('token_blacklist', 'idx_token_blacklist_token_hash', 'token_hash');
+-- 期限切れトークン削除用
+CALL migrate_create_index('token_blacklist', 'idx_token_blacklist_expires_at', 'expires_at');
+
+-- crops テーブル
+-- ユーザー別作物一覧用
+CALL migrate_create_index('crops', 'idx_crops_user_id', 'user_id');
+-- ステータス別フィルタ用
+CALL migrate_create_index('crops', 'idx_crops_status', 'status');
+-- ユーザー×ステータス複合インデックス
+CALL migrate_create_index('crops', 'idx_crops_user_status', 'user_id, status');
+-- 収穫予定日検索用（リマインダー）
+CALL migrate_create_index('crops', 'idx_crops_expected_harvest_date', 'expected_harvest_date');
+-- 植え付け日でのソート用
+CALL migrate_create_index('crops', 'idx_crops_planted_date', 'planted_date');
+
+-- growth_records テーブル
+-- 作物別成長記録取得用
+CALL migrate_create_index('growth_records', 'idx_growth_records_crop_id', 'crop_id');
+-- 記録日でのソート用
+CALL migrate_create_index('growth_records', 'idx_growth_records_record_date', 'record_date');
+-- 作物×記録日複合インデックス
+CALL migrate_create_index('growth_records', 'idx_growth_records_crop_date', 'crop_id, record_date DESC');
+
+-- harvests テーブル
+-- 作物別収穫記録取得用
+CALL migrate_create_index('harvests', 'idx_harvests_crop_id', 'crop_id');
+-- 収穫日でのソート用
+CALL migrate_create_index('harvests', 'idx_harvests_harvest_date', 'harvest_date');
+-- 分析用: 期間指定での集計
+CALL migrate_create_index('harvests', 'idx_harvests_crop_date', 'crop_id, harvest_date');
+
+-- plots テーブル
+-- ユーザー別区画一覧用
+CALL migrate_create_index('plots', 'idx_plots_user_id', 'user_id');
+-- ステータス別フィルタ用
+CALL migrate_create_index('plots', 'idx_plots_status', 'status');
+-- ユーザー×ステータス複合インデックス
+CALL migrate_create_index('plots', 'idx_plots_user_status', 'user_id, status');
+
+-- plot_assignments テーブル
+-- 区画別配置履歴取得用
+CALL migrate_create_index('plot_assignments', 'idx_plot_assignments_plot_id', 'plot_id');
+-- 作物別配置履歴取得用
+CALL migrate_create_index('plot_assignments', 'idx_plot_assignments_crop_id', 'crop_id');
+-- アクティブな配置検索用
+CALL migrate_create_index('plot_assignments', 'idx_plot_assignments_active', 'plot_id');
+
+-- tasks テーブル
+-- ユーザー別タスク一覧用
+CALL migrate_create_index('tasks', 'idx_tasks_user_id', 'user_id');
+-- ステータス別フィルタ用
+CALL migrate_create_index('tasks', 'idx_tasks_status', 'status');
+-- 期限日でのソート用
+CALL migrate_create_index('tasks', 'idx_tasks_due_date', 'due_date');
+-- 今日のタスク検索用
+CALL migrate_create_index('tasks', 'idx_tasks_user_due_date', 'user_id, due_date');
+-- 期限切れタスク検索用
+CALL migrate_create_index('tasks', 'idx_tasks_overdue', 'user_id, due_date, status');
+-- 繰り返しタスク検索用
+CALL migrate_create_index('tasks', 'idx_tasks_parent_id', 'parent_task_id');
+
+DROP PROCEDURE migrate_create_index;
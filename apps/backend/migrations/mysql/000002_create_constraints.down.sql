@@ -0,0 +1,31 @@
+-- ../000002_create_constraints.down.sql と同じ制約を削除します（存在する制約のみ）。
+
+DROP PROCEDURE IF EXISTS migrate_drop_check;
+CREATE PROCEDURE migrate_drop_check(IN p_table VARCHAR(64), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.table_constraints
+		WHERE constraint_schema = DATABASE() AND table_name = p_table AND constraint_name = p_name AND constraint_type = 'CHECK';
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('ALTER TABLE ', p_table, ' DROP CONSTRAINT ', p_name);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+CALL migrate_drop_check('tasks', 'chk_tasks_recurrence_interval');
+CALL migrate_drop_check('tasks', 'chk_tasks_recurrence');
+CALL migrate_drop_check('tasks', 'chk_tasks_priority');
+CALL migrate_drop_check('tasks', 'chk_tasks_status');
+CALL migrate_drop_check('plots', 'chk_plots_status');
+CALL migrate_drop_check('plots', 'chk_plots_sunlight');
+CALL migrate_drop_check('plots', 'chk_plots_soil_type');
+CALL migrate_drop_check('plots', 'chk_plots_dimensions');
+CALL migrate_drop_check('harvests', 'chk_harvests_quality');
+CALL migrate_drop_check('harvests', 'chk_harvests_quantity');
+CALL migrate_drop_check('growth_records', 'chk_growth_records_stage');
+CALL migrate_drop_check('crops', 'chk_crops_status');
+CALL migrate_drop_check('crops', 'chk_crops_valid_dates');
+
+DROP PROCEDURE migrate_drop_check;
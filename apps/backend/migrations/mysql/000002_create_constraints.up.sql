@@ -0,0 +1,57 @@
+-- CHECK制約（MySQL 8.0.16 以降 / MariaDB 10.2 以降）
+-- ../000002_create_constraints.up.sql と同じ制約を、存在しない制約のみ一時的なプロシージャで追加します（DO ブロックの置き換え）。
+
+DROP PROCEDURE IF EXISTS migrate_add_check;
+CREATE PROCEDURE migrate_add_check(IN p_table VARCHAR(64), IN p_name VARCHAR(64), IN p_condition VARCHAR(1024))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.table_constraints
+		WHERE constraint_schema = DATABASE() AND table_name = p_table AND constraint_name = p_name AND constraint_type = 'CHECK';
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('ALTER TABLE ', p_table, ' ADD CONSTRAINT ', p_name, ' CHECK (', p_condition, ')');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+-- crops テーブル - 日付バリデーション
+-- 植え付け日 <= 収穫予定日
+CALL migrate_add_check('crops', 'chk_crops_valid_dates', 'planted_date <= expected_harvest_date');
+
+-- ステータス値の制限
+CALL migrate_add_check('crops', 'chk_crops_status', 'status IN (''planted'', ''growing'', ''ready_to_harvest'', ''harvested'', ''failed'')');
+
+-- growth_records テーブル - 成長段階バリデーション
+CALL migrate_add_check('growth_records', 'chk_growth_records_stage', 'growth_stage IN (''seedling'', ''vegetative'', ''flowering'', ''fruiting'')');
+
+-- harvests テーブル - 数量バリデーション
+CALL migrate_add_check('harvests', 'chk_harvests_quantity', 'quantity > 0');
+
+-- 品質値の制限
+CALL migrate_add_check('harvests', 'chk_harvests_quality', 'quality IS NULL OR quality IN (''excellent'', ''good'', ''fair'', ''poor'')');
+
+-- plots テーブル - サイズバリデーション
+CALL migrate_add_check('plots', 'chk_plots_dimensions', 'width > 0 AND height > 0');
+
+-- 土壌タイプの制限
+CALL migrate_add_check('plots', 'chk_plots_soil_type', 'soil_type IS NULL OR soil_type IN (''clay'', ''sandy'', ''loamy'', ''peaty'')');
+
+-- 日当たりの制限
+CALL migrate_add_check('plots', 'chk_plots_sunlight', 'sunlight IS NULL OR sunlight IN (''full_sun'', ''partial_shade'', ''shade'')');
+
+-- ステータスの制限
+CALL migrate_add_check('plots', 'chk_plots_status', 'status IN (''available'', ''occupied'')');
+
+-- tasks テーブル - ステータス/優先度バリデーション
+CALL migrate_add_check('tasks', 'chk_tasks_status', 'status IN (''pending'', ''completed'', ''cancelled'')');
+
+CALL migrate_add_check('tasks', 'chk_tasks_priority', 'priority IN (''low'', ''medium'', ''high'')');
+
+-- 繰り返し設定の制限
+CALL migrate_add_check('tasks', 'chk_tasks_recurrence', 'recurrence IS NULL OR recurrence = '''' OR recurrence IN (''daily'', ''weekly'', ''monthly'')');
+
+-- 繰り返し間隔は正の値
+CALL migrate_add_check('tasks', 'chk_tasks_recurrence_interval', 'recurrence_interval > 0');
+
+DROP PROCEDURE migrate_add_check;
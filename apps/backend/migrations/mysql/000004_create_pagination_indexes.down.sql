@@ -0,0 +1,30 @@
+-- ../000004_create_pagination_indexes.down.sql と同じインデックスを削除します（存在するインデックスのみ）。
+
+DROP PROCEDURE IF EXISTS migrate_drop_index;
+CREATE PROCEDURE migrate_drop_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('DROP INDEX ', p_name, ' ON ', p_table);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+CALL migrate_drop_index('notification_logs', 'idx_notification_logs_user_id_id');
+CALL migrate_drop_index('harvests', 'idx_harvests_crop_id_id');
+CALL migrate_drop_index('plots', 'idx_plots_organization_id_id');
+CALL migrate_drop_index('plots', 'idx_plots_user_status_id');
+CALL migrate_drop_index('plots', 'idx_plots_user_id_id');
+CALL migrate_drop_index('crops', 'idx_crops_organization_id_id');
+CALL migrate_drop_index('crops', 'idx_crops_user_status_id');
+CALL migrate_drop_index('crops', 'idx_crops_user_id_id');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_status_due_date_id');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_due_date_id');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_status_id');
+CALL migrate_drop_index('tasks', 'idx_tasks_user_id_id');
+
+DROP PROCEDURE migrate_drop_index;
@@ -0,0 +1,53 @@
+-- キーセットページングのインデックス（MySQL / MariaDB）
+-- ../000004_create_pagination_indexes.up.sql と同じインデックスを作成します。
+-- 000001 と同じく存在しないインデックスのみ一時的なプロシージャで作成し、部分インデックスは条件のないインデックスにします。
+
+DROP PROCEDURE IF EXISTS migrate_create_index;
+CREATE PROCEDURE migrate_create_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64), IN p_columns VARCHAR(255))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('CREATE INDEX ', p_name, ' ON ', p_table, '(', p_columns, ')');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+-- tasks テーブル
+-- ページングの一覧用（新しい順、id < カーソル）
+CALL migrate_create_index('tasks', 'idx_tasks_user_id_id', 'user_id, id');
+-- ステータスで絞り込んだページングの一覧用
+CALL migrate_create_index('tasks', 'idx_tasks_user_status_id', 'user_id, status, id');
+-- 期限日順のページングの一覧用（(due_date, id) > カーソル）
+CALL migrate_create_index('tasks', 'idx_tasks_user_due_date_id', 'user_id, due_date, id');
+-- ステータスで絞り込んだ期限日順のページングの一覧用
+CALL migrate_create_index('tasks', 'idx_tasks_user_status_due_date_id', 'user_id, status, due_date, id');
+
+-- crops テーブル
+-- 個人の作物のページングの一覧用
+CALL migrate_create_index('crops', 'idx_crops_user_id_id', 'user_id, id');
+-- ステータスで絞り込んだ個人の作物のページングの一覧用
+CALL migrate_create_index('crops', 'idx_crops_user_status_id', 'user_id, status, id');
+-- 組織の作物のページングの一覧用
+CALL migrate_create_index('crops', 'idx_crops_organization_id_id', 'organization_id, id');
+
+-- plots テーブル
+-- 個人の区画のページングの一覧用
+CALL migrate_create_index('plots', 'idx_plots_user_id_id', 'user_id, id');
+-- ステータスで絞り込んだ個人の区画のページングの一覧用
+CALL migrate_create_index('plots', 'idx_plots_user_status_id', 'user_id, status, id');
+-- 組織の区画のページングの一覧用
+CALL migrate_create_index('plots', 'idx_plots_organization_id_id', 'organization_id, id');
+
+-- harvests テーブル
+-- 作物の収穫記録のページングの一覧用
+CALL migrate_create_index('harvests', 'idx_harvests_crop_id_id', 'crop_id, id');
+
+-- notification_logs テーブル
+-- 受信箱のページングの一覧用
+CALL migrate_create_index('notification_logs', 'idx_notification_logs_user_id_id', 'user_id, id');
+
+DROP PROCEDURE migrate_create_index;
@@ -0,0 +1,27 @@
+-- ../000005_create_soft_delete_unique_indexes.down.sql と同じく、一意インデックスと生成列を削除します（idx_users_email は 000001 のまま残す）。
+
+DROP PROCEDURE IF EXISTS migrate_drop_active_unique;
+CREATE PROCEDURE migrate_drop_active_unique(IN p_table VARCHAR(64), IN p_column VARCHAR(64), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('DROP INDEX ', p_name, ' ON ', p_table);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+	SELECT COUNT(*) INTO v_count FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = p_table AND column_name = CONCAT('active_', p_column);
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('ALTER TABLE ', p_table, ' DROP COLUMN active_', p_column);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+CALL migrate_drop_active_unique('users', 'email', 'idx_users_email_active');
+
+DROP PROCEDURE migrate_drop_active_unique;
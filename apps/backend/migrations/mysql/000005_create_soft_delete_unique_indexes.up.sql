@@ -0,0 +1,67 @@
+-- 論理削除を考慮した一意制約（MySQL / MariaDB）
+-- ../000005_create_soft_delete_unique_indexes.up.sql の部分一意インデックスの置き換えです。
+-- MySQL は部分インデックスに対応しないため、論理削除していない行のみメールアドレスを持つ生成列
+-- （論理削除した行は NULL、一意インデックスでは NULL は重複できる）に一意インデックスを作成します。
+
+DROP PROCEDURE IF EXISTS migrate_drop_index;
+CREATE PROCEDURE migrate_drop_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count > 0 THEN
+		SET @ddl = CONCAT('DROP INDEX ', p_name, ' ON ', p_table);
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+DROP PROCEDURE IF EXISTS migrate_create_index;
+CREATE PROCEDURE migrate_create_index(IN p_table VARCHAR(64), IN p_name VARCHAR(64), IN p_columns VARCHAR(255))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('CREATE INDEX ', p_name, ' ON ', p_table, '(', p_columns, ')');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+DROP PROCEDURE IF EXISTS migrate_add_active_unique;
+CREATE PROCEDURE migrate_add_active_unique(IN p_table VARCHAR(64), IN p_column VARCHAR(64), IN p_definition VARCHAR(255), IN p_name VARCHAR(64))
+BEGIN
+	DECLARE v_count INT;
+	SELECT COUNT(*) INTO v_count FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND table_name = p_table AND column_name = CONCAT('active_', p_column);
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('ALTER TABLE ', p_table, ' ADD COLUMN active_', p_column, ' ', p_definition,
+			' GENERATED ALWAYS AS (IF(deleted_at IS NULL, ', p_column, ', NULL)) STORED');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+	SELECT COUNT(*) INTO v_count FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = p_table AND index_name = p_name;
+	IF v_count = 0 THEN
+		SET @ddl = CONCAT('CREATE UNIQUE INDEX ', p_name, ' ON ', p_table, '(active_', p_column, ')');
+		PREPARE stmt FROM @ddl;
+		EXECUTE stmt;
+		DEALLOCATE PREPARE stmt;
+	END IF;
+END;
+
+-- users テーブル
+-- メールアドレスの重複防止用（論理削除したユーザーは対象外）
+CALL migrate_add_active_unique('users', 'email', 'VARCHAR(255)', 'idx_users_email_active');
+-- 既存のデータベースで AutoMigrate が作成した一意インデックスの idx_users_email を、000001 と同じ一意でないインデックスに置き換える
+-- （生成列のインデックスは email の条件の検索に使われないため、ログイン時の検索用に残す）
+CALL migrate_drop_index('users', 'idx_users_email');
+CALL migrate_create_index('users', 'idx_users_email', 'email');
+
+DROP PROCEDURE migrate_add_active_unique;
+DROP PROCEDURE migrate_create_index;
+DROP PROCEDURE migrate_drop_index;
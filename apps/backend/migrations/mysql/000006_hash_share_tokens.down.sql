@@ -0,0 +1,3 @@
+-- ../000006_hash_share_tokens.down.sql と同じく、ハッシュからトークン文字列は復元できないため、変更を戻しません。
+
+SELECT 1;
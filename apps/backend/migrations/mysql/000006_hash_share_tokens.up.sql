@@ -0,0 +1,4 @@
+-- 共有トークンのハッシュ化（MySQL / MariaDB）
+-- ../000006_hash_share_tokens.up.sql と同じく、トークン文字列を SHA-256 のハッシュ（16進数の64文字）に置き換えます。
+
+UPDATE share_tokens SET token = SHA2(token, 256);
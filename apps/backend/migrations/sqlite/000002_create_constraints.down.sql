@@ -0,0 +1,26 @@
+DROP TRIGGER IF EXISTS chk_tasks_recurrence_interval_update;
+DROP TRIGGER IF EXISTS chk_tasks_recurrence_interval_insert;
+DROP TRIGGER IF EXISTS chk_tasks_recurrence_update;
+DROP TRIGGER IF EXISTS chk_tasks_recurrence_insert;
+DROP TRIGGER IF EXISTS chk_tasks_priority_update;
+DROP TRIGGER IF EXISTS chk_tasks_priority_insert;
+DROP TRIGGER IF EXISTS chk_tasks_status_update;
+DROP TRIGGER IF EXISTS chk_tasks_status_insert;
+DROP TRIGGER IF EXISTS chk_plots_status_update;
+DROP TRIGGER IF EXISTS chk_plots_status_insert;
+DROP TRIGGER IF EXISTS chk_plots_sunlight_update;
+DROP TRIGGER IF EXISTS chk_plots_sunlight_insert;
+DROP TRIGGER IF EXISTS chk_plots_soil_type_update;
+DROP TRIGGER IF EXISTS chk_plots_soil_type_insert;
+DROP TRIGGER IF EXISTS chk_plots_dimensions_update;
+DROP TRIGGER IF EXISTS chk_plots_dimensions_insert;
+DROP TRIGGER IF EXISTS chk_harvests_quality_update;
+DROP TRIGGER IF EXISTS chk_harvests_quality_insert;
+DROP TRIGGER IF EXISTS chk_harvests_quantity_update;
+DROP TRIGGER IF EXISTS chk_harvests_quantity_insert;
+DROP TRIGGER IF EXISTS chk_growth_records_stage_update;
+DROP TRIGGER IF EXISTS chk_growth_records_stage_insert;
+DROP TRIGGER IF EXISTS chk_crops_status_update;
+DROP TRIGGER IF EXISTS chk_crops_status_insert;
+DROP TRIGGER IF EXISTS chk_crops_valid_dates_update;
+DROP TRIGGER IF EXISTS chk_crops_valid_dates_insert;
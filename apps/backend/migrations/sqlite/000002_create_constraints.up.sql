@@ -0,0 +1,159 @@
+-- CHECK制約（SQLite）
+-- SQLite は既存のテーブルに制約を追加できない（ALTER TABLE ADD CONSTRAINT がない）ため、
+-- ../000002_create_constraints.up.sql と同じ条件を INSERT・UPDATE の前のトリガーで確認します。
+-- 条件を満たさない場合は制約名を含むエラーで中止します（条件が NULL の場合は CHECK 制約と同じく通します）。
+
+-- crops テーブル - 日付バリデーション
+-- 植え付け日 <= 収穫予定日
+CREATE TRIGGER IF NOT EXISTS chk_crops_valid_dates_insert BEFORE INSERT ON crops
+WHEN NOT (NEW.planted_date <= NEW.expected_harvest_date)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_crops_valid_dates');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_crops_valid_dates_update BEFORE UPDATE ON crops
+WHEN NOT (NEW.planted_date <= NEW.expected_harvest_date)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_crops_valid_dates');
+END;
+
+-- ステータス値の制限
+CREATE TRIGGER IF NOT EXISTS chk_crops_status_insert BEFORE INSERT ON crops
+WHEN NOT (NEW.status IN ('planted', 'growing', 'ready_to_harvest', 'harvested', 'failed'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_crops_status');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_crops_status_update BEFORE UPDATE ON crops
+WHEN NOT (NEW.status IN ('planted', 'growing', 'ready_to_harvest', 'harvested', 'failed'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_crops_status');
+END;
+
+-- growth_records テーブル - 成長段階バリデーション
+CREATE TRIGGER IF NOT EXISTS chk_growth_records_stage_insert BEFORE INSERT ON growth_records
+WHEN NOT (NEW.growth_stage IN ('seedling', 'vegetative', 'flowering', 'fruiting'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_growth_records_stage');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_growth_records_stage_update BEFORE UPDATE ON growth_records
+WHEN NOT (NEW.growth_stage IN ('seedling', 'vegetative', 'flowering', 'fruiting'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_growth_records_stage');
+END;
+
+-- harvests テーブル - 数量バリデーション
+CREATE TRIGGER IF NOT EXISTS chk_harvests_quantity_insert BEFORE INSERT ON harvests
+WHEN NOT (NEW.quantity > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_harvests_quantity');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_harvests_quantity_update BEFORE UPDATE ON harvests
+WHEN NOT (NEW.quantity > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_harvests_quantity');
+END;
+
+-- 品質値の制限
+CREATE TRIGGER IF NOT EXISTS chk_harvests_quality_insert BEFORE INSERT ON harvests
+WHEN NOT (NEW.quality IS NULL OR NEW.quality IN ('excellent', 'good', 'fair', 'poor'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_harvests_quality');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_harvests_quality_update BEFORE UPDATE ON harvests
+WHEN NOT (NEW.quality IS NULL OR NEW.quality IN ('excellent', 'good', 'fair', 'poor'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_harvests_quality');
+END;
+
+-- plots テーブル - サイズバリデーション
+CREATE TRIGGER IF NOT EXISTS chk_plots_dimensions_insert BEFORE INSERT ON plots
+WHEN NOT (NEW.width > 0 AND NEW.height > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_dimensions');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_plots_dimensions_update BEFORE UPDATE ON plots
+WHEN NOT (NEW.width > 0 AND NEW.height > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_dimensions');
+END;
+
+-- 土壌タイプの制限
+CREATE TRIGGER IF NOT EXISTS chk_plots_soil_type_insert BEFORE INSERT ON plots
+WHEN NOT (NEW.soil_type IS NULL OR NEW.soil_type IN ('clay', 'sandy', 'loamy', 'peaty'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_soil_type');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_plots_soil_type_update BEFORE UPDATE ON plots
+WHEN NOT (NEW.soil_type IS NULL OR NEW.soil_type IN ('clay', 'sandy', 'loamy', 'peaty'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_soil_type');
+END;
+
+-- 日当たりの制限
+CREATE TRIGGER IF NOT EXISTS chk_plots_sunlight_insert BEFORE INSERT ON plots
+WHEN NOT (NEW.sunlight IS NULL OR NEW.sunlight IN ('full_sun', 'partial_shade', 'shade'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_sunlight');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_plots_sunlight_update BEFORE UPDATE ON plots
+WHEN NOT (NEW.sunlight IS NULL OR NEW.sunlight IN ('full_sun', 'partial_shade', 'shade'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_sunlight');
+END;
+
+-- ステータスの制限
+CREATE TRIGGER IF NOT EXISTS chk_plots_status_insert BEFORE INSERT ON plots
+WHEN NOT (NEW.status IN ('available', 'occupied'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_status');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_plots_status_update BEFORE UPDATE ON plots
+WHEN NOT (NEW.status IN ('available', 'occupied'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_plots_status');
+END;
+
+-- tasks テーブル - ステータス/優先度バリデーション
+CREATE TRIGGER IF NOT EXISTS chk_tasks_status_insert BEFORE INSERT ON tasks
+WHEN NOT (NEW.status IN ('pending', 'completed', 'cancelled'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_status');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_tasks_status_update BEFORE UPDATE ON tasks
+WHEN NOT (NEW.status IN ('pending', 'completed', 'cancelled'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_status');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_tasks_priority_insert BEFORE INSERT ON tasks
+WHEN NOT (NEW.priority IN ('low', 'medium', 'high'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_priority');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_tasks_priority_update BEFORE UPDATE ON tasks
+WHEN NOT (NEW.priority IN ('low', 'medium', 'high'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_priority');
+END;
+
+-- 繰り返し設定の制限
+CREATE TRIGGER IF NOT EXISTS chk_tasks_recurrence_insert BEFORE INSERT ON tasks
+WHEN NOT (NEW.recurrence IS NULL OR NEW.recurrence = '' OR NEW.recurrence IN ('daily', 'weekly', 'monthly'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_recurrence');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_tasks_recurrence_update BEFORE UPDATE ON tasks
+WHEN NOT (NEW.recurrence IS NULL OR NEW.recurrence = '' OR NEW.recurrence IN ('daily', 'weekly', 'monthly'))
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_recurrence');
+END;
+
+-- 繰り返し間隔は正の値
+CREATE TRIGGER IF NOT EXISTS chk_tasks_recurrence_interval_insert BEFORE INSERT ON tasks
+WHEN NOT (NEW.recurrence_interval > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_recurrence_interval');
+END;
+CREATE TRIGGER IF NOT EXISTS chk_tasks_recurrence_interval_update BEFORE UPDATE ON tasks
+WHEN NOT (NEW.recurrence_interval > 0)
+BEGIN
+	SELECT RAISE(ABORT, 'CHECK constraint failed: chk_tasks_recurrence_interval');
+END;
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS mv_monthly_harvest;
+DROP VIEW IF EXISTS mv_harvest_analytics;
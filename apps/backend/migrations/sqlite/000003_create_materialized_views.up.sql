@@ -0,0 +1,63 @@
+-- 分析用のビュー（SQLite）
+-- SQLite にはマテリアライズドビューがないため、../000003_create_materialized_views.up.sql と同じ名前・列の通常のビューを作成します。
+-- 通常のビューは常に最新の集計を返すため、リフレッシュは行いません。
+
+-- 収穫分析用ビュー
+CREATE VIEW IF NOT EXISTS mv_harvest_analytics AS
+SELECT
+	c.user_id,
+	c.id as crop_id,
+	c.name as crop_name,
+	c.variety,
+	c.planted_date,
+	COALESCE(h.total_quantity, 0) as total_quantity,
+	h.quantity_unit,
+	h.harvest_count,
+	h.first_harvest_date,
+	h.last_harvest_date,
+	CASE
+		WHEN h.first_harvest_date IS NOT NULL
+		THEN CAST(julianday(h.first_harvest_date) - julianday(c.planted_date) AS INTEGER)
+		ELSE NULL
+	END as days_to_first_harvest,
+	h.avg_quality_score,
+	c.status
+FROM crops c
+LEFT JOIN (
+	SELECT
+		crop_id,
+		SUM(quantity) as total_quantity,
+		MAX(quantity_unit) as quantity_unit,
+		COUNT(*) as harvest_count,
+		MIN(harvest_date) as first_harvest_date,
+		MAX(harvest_date) as last_harvest_date,
+		AVG(
+			CASE quality
+				WHEN 'excellent' THEN 4
+				WHEN 'good' THEN 3
+				WHEN 'fair' THEN 2
+				WHEN 'poor' THEN 1
+				ELSE NULL
+			END
+		) as avg_quality_score
+	FROM harvests
+	WHERE deleted_at IS NULL
+	GROUP BY crop_id
+) h ON c.id = h.crop_id
+WHERE c.deleted_at IS NULL
+;
+
+-- 月別収穫集計ビュー
+CREATE VIEW IF NOT EXISTS mv_monthly_harvest AS
+SELECT
+	c.user_id,
+	strftime('%Y-%m-01', h.harvest_date) as harvest_month,
+	c.name as crop_name,
+	SUM(h.quantity) as total_quantity,
+	MAX(h.quantity_unit) as quantity_unit,
+	COUNT(*) as harvest_count
+FROM harvests h
+JOIN crops c ON h.crop_id = c.id
+WHERE h.deleted_at IS NULL AND c.deleted_at IS NULL
+GROUP BY c.user_id, strftime('%Y-%m-01', h.harvest_date), c.name
+;
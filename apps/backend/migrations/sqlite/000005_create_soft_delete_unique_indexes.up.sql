@@ -0,0 +1,8 @@
+-- 論理削除を考慮した一意制約（SQLite）
+-- ../000005_create_soft_delete_unique_indexes.up.sql と同じインデックスを作成します（部分インデックスも同じ条件）。
+
+-- users テーブル
+-- 000001 の idx_users_email（AutoMigrate が作成した一意インデックスの場合もある）を置き換える
+DROP INDEX IF EXISTS idx_users_email;
+-- メールアドレスの重複防止・ログイン時の検索用（論理削除したユーザーは対象外）
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
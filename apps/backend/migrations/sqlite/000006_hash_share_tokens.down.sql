@@ -0,0 +1,3 @@
+-- ../000006_hash_share_tokens.down.sql と同じく、失効させた共有トークンは戻しません。
+
+SELECT 1;
@@ -0,0 +1,5 @@
+-- 共有トークンのハッシュ化（SQLite）
+-- ../000006_hash_share_tokens.up.sql と同じく共有トークンはハッシュのみ保存しますが、SQLite には SHA-256 の関数がないため、
+-- 平文で保存した発行済みの共有トークンを失効させます（共有リンクは再発行が必要です）。
+
+UPDATE share_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE revoked_at IS NULL;
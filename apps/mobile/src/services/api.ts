@@ -80,22 +80,23 @@ async function getHeaders(): Promise<Record<string, string>> {
 async function handleResponse<T>(response: Response): Promise<T> {
   const contentType = response.headers.get('content-type');
   const isJson = contentType?.includes('application/json');
+  // エラーは application/problem+json（RFC 7807）で返る
+  const isProblem = contentType?.includes('application/problem+json');
 
   if (!response.ok) {
     let errorMessage = 'リクエストに失敗しました';
     let errorCode: string | undefined;
 
-    if (isJson) {
+    if (isJson || isProblem) {
       try {
         const errorData = await response.json();
-        // バックエンドのエラー形式: { "error": { "code": "...", "message": "...", "details": [...] } }
-        if (errorData.error && typeof errorData.error === 'object') {
-          const appError = errorData.error;
-          errorMessage = appError.message || errorMessage;
-          errorCode = appError.code;
+        // バックエンドのエラー形式: { "type": "...", "title": "...", "detail": "...", "code": "...", "errors": [...] }
+        if (typeof errorData.code === 'string' && typeof errorData.title === 'string') {
+          errorMessage = errorData.detail || errorData.title;
+          errorCode = errorData.code;
           // バリデーションエラーの詳細がある場合は追加
-          if (appError.details && Array.isArray(appError.details)) {
-            const detailMessages = appError.details
+          if (errorData.errors && Array.isArray(errorData.errors)) {
+            const detailMessages = errorData.errors
               .map((d: { field?: string; message?: string }) => d.message || d.field)
               .filter(Boolean)
               .join(', ');
@@ -104,7 +105,7 @@ async function handleResponse<T>(response: Response): Promise<T> {
             }
           }
         } else if (typeof errorData.message === 'string') {
-          // 別の形式: { "message": "..." }
+          // 別の形式: { "message": "..." }（スケジューラーなど）
           errorMessage = errorData.message;
         } else if (typeof errorData.error === 'string') {
           // 別の形式: { "error": "..." }
@@ -343,5 +344,94 @@ export const analyticsApi = {
     get<ExportResponse>(`/analytics/export/${dataType}`),
 };
 
+// バッチリクエストのサブリクエスト（オフラインで記録した操作）
+interface BatchSubRequest {
+  id?: string;
+  method: 'GET' | 'POST' | 'PUT' | 'PATCH' | 'DELETE';
+  path: string; // /api/v1 からの相対パス（/tasks/1/complete など）
+  body?: unknown;
+  group?: string; // 同じグループのサブリクエストは1つのトランザクションで実行（連続させる）
+}
+
+// サブリクエストごとのレスポンス
+interface BatchSubResponse {
+  id?: string;
+  status: number;
+  body?: unknown;
+  group?: string;
+  rolled_back?: boolean; // 成功したがグループの失敗によりロールバックされた
+}
+
+interface BatchResponse {
+  responses: BatchSubResponse[];
+  succeeded: number;
+  failed: number;
+}
+
+export const batchApi = {
+  // 複数の操作をまとめて実行（最大50件、サブリクエストと同じ順序でレスポンスを返す）
+  send: (requests: BatchSubRequest[]) => post<BatchResponse>('/batch', { requests }),
+};
+
+// 同期の対象
+type SyncEntity = 'tasks' | 'crops' | 'plots' | 'harvests';
+
+// 1種類の記録の変更（deleted は削除された記録のID）
+interface SyncEntityChanges {
+  created: unknown[];
+  updated: unknown[];
+  deleted: number[];
+}
+
+interface SyncChanges {
+  tasks: SyncEntityChanges;
+  crops: SyncEntityChanges;
+  plots: SyncEntityChanges;
+  harvests: SyncEntityChanges;
+  full: boolean; // since なしの全件の取得（手元の記録を置き換える）
+  next_cursor: string; // 次回の同期の since
+  server_time: string;
+}
+
+// オフラインで記録した変更
+interface SyncChange {
+  entity: SyncEntity;
+  op: 'upsert' | 'delete';
+  id?: number; // サーバーの記録のID（省略した場合は新規作成）
+  client_id?: string; // 新規作成した記録のIDの対応付け用
+  base_updated_at?: string; // 最後に取得したサーバーの updated_at
+  data?: unknown;
+}
+
+interface SyncChangeResult {
+  index: number;
+  entity: SyncEntity;
+  client_id?: string;
+  id?: number;
+  status: 'applied' | 'conflict' | 'rejected';
+  conflict?: 'updated' | 'deleted';
+  error?: string;
+  record?: unknown; // 反映後（競合の場合は現在）のサーバーの記録
+}
+
+interface SyncPushResult {
+  results: SyncChangeResult[];
+  applied: number;
+  conflicts: number;
+  rejected: number;
+}
+
+export const syncApi = {
+  // 前回の同期以降の変更を取得（SYNC_CURSOR_EXPIRED の場合は since なしで全件を再取得）
+  pull: (since?: string) =>
+    get<SyncChanges>(since ? `/sync?since=${encodeURIComponent(since)}` : '/sync'),
+
+  // オフラインで記録した変更を反映（最大500件）
+  push: (changes: SyncChange[], strategy: 'server_wins' | 'client_wins' = 'server_wins') =>
+    post<SyncPushResult>('/sync', { changes, strategy }),
+};
+
 // 型エクスポート
 export type { HarvestSummary, ChartData, ChartDataPoint, ExportResponse };
+export type { BatchSubRequest, BatchSubResponse, BatchResponse };
+export type { SyncEntity, SyncChanges, SyncChange, SyncChangeResult, SyncPushResult };
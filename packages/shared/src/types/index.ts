@@ -6,6 +6,10 @@
 export interface User {
   id: string;
   email: string;
+  timezone?: string; // IANAタイムゾーン名（例: Asia/Tokyo）
+  locale?: 'ja' | 'en'; // 通知メールの言語
+  phoneNumber?: string; // SMS通知の送信先（E.164形式、認証済みの番号のみ）
+  phoneVerifiedAt?: Date;
   createdAt: Date;
   updatedAt: Date;
 }
@@ -120,12 +124,119 @@ export interface PaginatedResponse<T> {
 }
 
 // 通知関連
-export type NotificationType = 'TaskReminder' | 'HarvestReminder' | 'GrowthRecord' | 'OverdueAlert';
+export type NotificationType = 'TaskReminder' | 'HarvestReminder' | 'CropReadyToHarvest' | 'GrowthRecord' | 'OverdueAlert' | 'DailyDigest' | 'FrostWarning' | 'AccountSecurity';
 
 export interface NotificationSettings {
   pushEnabled: boolean;
   emailEnabled: boolean;
   taskReminders: boolean;
   harvestReminders: boolean;
+  harvestReadyAlerts?: boolean; // 作物が収穫可能になったときに通知する（未設定の場合は有効）
   growthRecordNotifications: boolean;
+  digestNotifications?: boolean; // 定期通知を1日1件のダイジェストにまとめる
+  slackEnabled?: boolean;
+  slackWebhookUrl?: string;
+  discordEnabled?: boolean;
+  discordWebhookUrl?: string;
+  customWebhookEnabled?: boolean;
+  customWebhookUrl?: string; // 通知イベントのJSONを署名付きでPOSTするHTTPS URL
+  dailyReminderHour?: number; // ユーザーのタイムゾーンでの送信時刻（0-23）
+  quietHoursEnabled?: boolean;
+  quietHoursStart?: string; // HH:MM（例: 21:00）
+  quietHoursEnd?: string; // HH:MM（例: 08:00）
+  smsEnabled?: boolean; // 重要なアラート（霜注意報・アカウントセキュリティ）をSMSでも受け取る（電話番号の認証が必要）
+}
+
+// 汎用Webhookの署名用シークレット
+// 署名は signatureHeader に "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))、
+// timestamp は timestampHeader（Unix秒）で送信されます。
+export interface CustomWebhookSecret {
+  secret?: string; // 未発行の場合は省略
+  signatureHeader: string;
+  timestampHeader: string;
+}
+
+// SMS通知用の電話番号の認証状況
+export interface PhoneVerificationStatus {
+  phoneNumber?: string; // 認証済みの電話番号
+  phoneVerifiedAt?: Date;
+  pendingNumber?: string; // 認証中の電話番号
+  codeExpiresAt?: Date; // 認証コードの有効期限
+}
+
+// プッシュ通知のアクションボタン（カスタムデータの actions、action_endpoint へ送信）
+export type PushActionId = 'mark_done' | 'snooze_1h';
+
+export interface PushActionRequest {
+  action: PushActionId;
+  taskIds: number[]; // 通知のカスタムデータの task_ids
+}
+
+export interface PushActionResult {
+  action: PushActionId;
+  taskIds: number[];
+  snoozedUntil?: Date; // スヌーズの場合の再通知日時
+}
+
+// イベントタイプ×チャネルの通知設定
+export type NotificationPreferenceChannel = 'push' | 'email' | 'webhook';
+
+export interface NotificationPreference {
+  eventType: 'task_due_reminder' | 'task_overdue_alert' | 'harvest_reminder' | 'crop_ready_to_harvest' | 'daily_digest' | 'announcement';
+  channel: NotificationPreferenceChannel;
+  enabled: boolean;
+  customized: boolean; // falseの場合は通知設定から決まる既定値
+}
+
+// 管理者からのお知らせ配信
+export type AnnouncementCategory = 'maintenance' | 'feature' | 'general';
+export type AnnouncementStatus = 'scheduled' | 'sending' | 'sent' | 'cancelled';
+
+export interface CreateAnnouncementRequest {
+  title: string;
+  body: string;
+  category?: AnnouncementCategory;
+  scheduledAt?: Date; // 省略した場合は次回のスケジューラー実行時に配信
+  audience?: {
+    locale?: 'ja' | 'en';
+    platform?: 'ios' | 'android' | 'web';
+    activeDays?: number; // 直近N日以内にアクティブなユーザー（1-365）
+  };
+}
+
+export interface Announcement {
+  id: number;
+  title: string;
+  body: string;
+  category: AnnouncementCategory;
+  scheduledAt: Date;
+  status: AnnouncementStatus;
+  createdBy: number;
+  audienceLocale?: string;
+  audiencePlatform?: string;
+  audienceActiveDays?: number;
+  targetCount: number; // 配信対象のユーザー数
+  sentCount: number;
+  failedCount: number;
+  deferredCount: number; // おやすみモードのため保留した件数
+  duplicateCount: number; // 送信済みのためスキップした件数
+  completedAt?: Date;
+  createdAt: Date;
+  updatedAt: Date;
+}
+
+// 通知のチャネルごとの送信結果（管理者向け、直近24時間まで）
+export interface NotificationChannelStats {
+  channel: string; // push, email, slack, discord, custom_webhook, sms（total の場合は空）
+  sent: number;
+  failed: number;
+  deferred: number; // おやすみモードのため保留中
+  deduped: number; // 重複防止キーにより送信しなかった件数
+}
+
+export interface NotificationDeliveryStats {
+  since: Date;
+  until: Date;
+  channels: NotificationChannelStats[];
+  total: NotificationChannelStats;
 }